@@ -3,30 +3,53 @@ package main
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/kris-nova/logger"
 	"github.com/spf13/cobra"
 
+	"github.com/weaveworks/eksctl/pkg/ctl/apply"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/ctl/completion"
 	"github.com/weaveworks/eksctl/pkg/ctl/create"
 	"github.com/weaveworks/eksctl/pkg/ctl/delete"
+	"github.com/weaveworks/eksctl/pkg/ctl/deregister"
+	"github.com/weaveworks/eksctl/pkg/ctl/docs"
 	"github.com/weaveworks/eksctl/pkg/ctl/drain"
 	"github.com/weaveworks/eksctl/pkg/ctl/get"
+	"github.com/weaveworks/eksctl/pkg/ctl/register"
 	"github.com/weaveworks/eksctl/pkg/ctl/scale"
+	"github.com/weaveworks/eksctl/pkg/ctl/set"
+	"github.com/weaveworks/eksctl/pkg/ctl/top"
+	"github.com/weaveworks/eksctl/pkg/ctl/unset"
 	"github.com/weaveworks/eksctl/pkg/ctl/update"
+	"github.com/weaveworks/eksctl/pkg/ctl/upgrade"
 	"github.com/weaveworks/eksctl/pkg/ctl/utils"
+	"github.com/weaveworks/eksctl/pkg/ctl/validate"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/logging"
+	"github.com/weaveworks/eksctl/pkg/metrics"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 func addCommands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) {
 	rootCmd.AddCommand(create.Command(flagGrouping))
 	rootCmd.AddCommand(get.Command(flagGrouping))
 	rootCmd.AddCommand(update.Command(flagGrouping))
+	rootCmd.AddCommand(upgrade.Command(flagGrouping))
+	rootCmd.AddCommand(apply.Command(flagGrouping))
+	rootCmd.AddCommand(validate.Command(flagGrouping))
 	rootCmd.AddCommand(delete.Command(flagGrouping))
 	rootCmd.AddCommand(scale.Command(flagGrouping))
+	rootCmd.AddCommand(set.Command(flagGrouping))
+	rootCmd.AddCommand(unset.Command(flagGrouping))
 	rootCmd.AddCommand(drain.Command(flagGrouping))
+	rootCmd.AddCommand(top.Command(flagGrouping))
+	rootCmd.AddCommand(register.Command(flagGrouping))
+	rootCmd.AddCommand(deregister.Command(flagGrouping))
 	rootCmd.AddCommand(utils.Command(flagGrouping))
 	rootCmd.AddCommand(completion.Command(rootCmd))
+	rootCmd.AddCommand(docs.Command(rootCmd, flagGrouping))
 	rootCmd.AddCommand(versionCmd(flagGrouping))
 }
 
@@ -48,22 +71,33 @@ func main() {
 	addCommands(rootCmd, flagGrouping)
 
 	rootCmd.PersistentFlags().BoolP("help", "h", false, "help for this command")
-	rootCmd.PersistentFlags().IntVarP(&logger.Level, "verbose", "v", 3, "set log level, use 0 to silence, 4 for debugging and 5 for debugging with AWS debug logging")
+	output := logging.OutputOptions{}
+	rootCmd.PersistentFlags().IntVarP(&output.Level, "verbose", "v", logging.LevelInfo, fmt.Sprintf("set log level, use 0 to silence, %d for warnings and errors only, %d for debugging with every poll of waiters and %d for debugging with AWS debug logging", logging.LevelQuiet, logging.LevelDebug, logging.LevelAWSDebug))
+	rootCmd.PersistentFlags().BoolVarP(&output.Quiet, "quiet", "q", false, "only log warnings and errors, the results of commands are still printed")
 
-	colorValue := rootCmd.PersistentFlags().StringP("color", "C", "true", "toggle colorized logs (valid options: true, false, fabulous)")
+	rootCmd.PersistentFlags().StringVarP(&output.Color, "color", "C", "true", "toggle colorized logs and progress spinners (valid options: true, false, fabulous)")
+	noColor := rootCmd.PersistentFlags().Bool("no-color", false, "disable colorized logs and output, same as setting NO_COLOR environment variable")
+	rootCmd.PersistentFlags().StringVar(&output.Format, "log-format", logging.FormatText, fmt.Sprintf("format of logs (valid options: %s)", strings.Join(logging.Formats(), ", ")))
+	rootCmd.PersistentFlags().StringVar(&metrics.Options.PushgatewayURL, "metrics-pushgateway", "", "URL of a Prometheus Pushgateway to push metrics of the command to once it completes")
+	rootCmd.PersistentFlags().StringVar(&metrics.Options.Textfile, "metrics-textfile", "", "file to write metrics of the command to once it completes, in the Prometheus text format")
 
 	cobra.OnInitialize(func() {
 		// Control colored output
-		logger.Color = *colorValue == "true"
-		logger.Fabulous = *colorValue == "fabulous"
-		// Add timestamps for debugging
-		logger.Timestamps = logger.Level >= 4
+		if *noColor || os.Getenv("NO_COLOR") != "" {
+			output.Color = "false"
+		}
+		printers.Color = output.Color != "false" && output.Format != logging.FormatJSON
+		if err := output.Apply(os.Stdout, rootCmd.PersistentFlags().Changed("verbose")); err != nil {
+			fmt.Println(err)
+			os.Exit(eksctlerrors.ExitCodeValidation)
+		}
 	})
 
 	rootCmd.SetUsageFunc(flagGrouping.Usage)
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
-		os.Exit(-1)
+		// cobra only fails on unknown commands and flags
+		os.Exit(eksctlerrors.ExitCodeValidation)
 	}
 }
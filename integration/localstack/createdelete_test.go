@@ -0,0 +1,51 @@
+// +build localstack
+
+package localstack_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("(LocalStack) Create & Delete", func() {
+
+	var clusterName string
+
+	BeforeEach(func() {
+		if clusterName == "" {
+			clusterName = cmdutils.ClusterName("", "")
+		}
+	})
+
+	AfterSuite(func() {
+		gexec.KillAndWait()
+	})
+
+	It("should submit the cluster stack", func() {
+		session := eksctl("create", "cluster",
+			"--verbose", "4",
+			"--name", clusterName,
+			"--without-nodegroup",
+			"--no-wait",
+		)
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(string(session.Out.Contents())).To(ContainSubstring("creation of cluster %q has been submitted", clusterName))
+	})
+
+	It("should describe the cluster stack", func() {
+		session := eksctl("utils", "describe-stacks", "--name", clusterName)
+		Expect(session.ExitCode()).To(Equal(0))
+		Expect(string(session.Out.Contents())).To(ContainSubstring("eksctl-%s-cluster", clusterName))
+	})
+
+	It("should delete the cluster stack", func() {
+		session := eksctl("delete", "cluster", "--name", clusterName, "--wait")
+		Expect(session.ExitCode()).To(Equal(0))
+
+		session = eksctl("utils", "describe-stacks", "--name", clusterName)
+		Expect(string(session.Out.Contents())).NotTo(ContainSubstring("eksctl-%s-cluster", clusterName))
+	})
+})
@@ -0,0 +1,47 @@
+// +build localstack
+
+package localstack_test
+
+import (
+	"flag"
+	"fmt"
+	"os/exec"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/gomega/gexec"
+
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var (
+	eksctlPath string
+
+	endpoint string
+	region   string
+)
+
+func init() {
+	flag.StringVar(&eksctlPath, "eksctl.path", "../../eksctl", "Path to eksctl")
+
+	flag.StringVar(&endpoint, "eksctl.endpoint", "http://localhost:4566", "Endpoint of LocalStack")
+	flag.StringVar(&region, "eksctl.region", "us-east-1", "Region to use for the tests")
+}
+
+func TestSuite(t *testing.T) {
+	testutils.RegisterAndRun(t)
+}
+
+// eksctl runs eksctl against LocalStack
+func eksctl(args ...string) *gexec.Session {
+	args = append(args, "--local-endpoint", endpoint, "--region", region)
+	command := exec.Command(eksctlPath, args...)
+	fmt.Fprintf(GinkgoWriter, "calling %q with %v\n", eksctlPath, args)
+	session, err := gexec.Start(command, GinkgoWriter, GinkgoWriter)
+	if err != nil {
+		Fail(fmt.Sprintf("error starting process: %v\n", err), 1)
+	}
+	session.Wait(5 * time.Minute)
+	return session
+}
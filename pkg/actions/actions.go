@@ -0,0 +1,68 @@
+// Package actions implements cluster and nodegroup operations as a Go API,
+// it doesn't depend on any of the command-line packages, so it can be used
+// by programs that embed eksctl as a library
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+// Manager performs operations on a single cluster
+type Manager struct {
+	ctl          *eks.ClusterProvider
+	cfg          *api.ClusterConfig
+	stackManager *manager.StackCollection
+}
+
+// New creates a Manager for the cluster described by cfg, it checks
+// that the region is supported and that AWS credentials are valid
+func New(providerConfig *api.ProviderConfig, cfg *api.ClusterConfig) (*Manager, error) {
+	ctl, err := eks.New(providerConfig, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return nil, fmt.Errorf("region %q is not supported - use one of: %s", providerConfig.Region, strings.Join(api.SupportedRegions(), ", "))
+	}
+
+	if err := ctl.CheckAuth(); err != nil {
+		return nil, err
+	}
+
+	return NewFromClusterProvider(ctl, cfg), nil
+}
+
+// NewFromClusterProvider creates a Manager for the cluster described by cfg
+// with an existing cluster provider, e.g. one that uses custom AWS clients
+func NewFromClusterProvider(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) *Manager {
+	return &Manager{
+		ctl:          ctl,
+		cfg:          cfg,
+		stackManager: ctl.NewStackManager(cfg),
+	}
+}
+
+// ClusterProvider returns the underlying cluster provider
+func (m *Manager) ClusterProvider() *eks.ClusterProvider { return m.ctl }
+
+// ClusterConfig returns the config of the cluster
+func (m *Manager) ClusterConfig() *api.ClusterConfig { return m.cfg }
+
+// StackManager returns the underlying CloudFormation stack manager
+func (m *Manager) StackManager() *manager.StackCollection { return m.stackManager }
+
+func errFailedToDelete(errs []error, subject string) error {
+	logger.Info("%d error(s) occurred while deleting %s", len(errs), subject)
+	for _, err := range errs {
+		logger.Critical("%s\n", err.Error())
+	}
+	return fmt.Errorf("failed to delete %s", subject)
+}
@@ -0,0 +1,485 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Actions", func() {
+	var (
+		p *mockprovider.MockProvider
+		m *Manager
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = p.Region()
+
+		m = &Manager{
+			ctl:          &eks.ClusterProvider{Provider: p},
+			cfg:          cfg,
+			stackManager: manager.NewStackCollection(p, cfg),
+		}
+	})
+
+	Describe("ListClusters", func() {
+		It("should follow next token until all clusters are listed", func() {
+			p.MockEKS().On("ListClustersWithContext", mock.Anything, mock.MatchedBy(func(input *awseks.ListClustersInput) bool {
+				return input.NextToken == nil
+			})).Return(&awseks.ListClustersOutput{
+				Clusters:  aws.StringSlice([]string{"a", "b"}),
+				NextToken: aws.String("next"),
+			}, nil).Once()
+
+			p.MockEKS().On("ListClustersWithContext", mock.Anything, mock.MatchedBy(func(input *awseks.ListClustersInput) bool {
+				return input.NextToken != nil && *input.NextToken == "next"
+			})).Return(&awseks.ListClustersOutput{
+				Clusters: aws.StringSlice([]string{"c"}),
+			}, nil).Once()
+
+			clusters, err := m.ListClusters(context.Background(), ListClustersOptions{ChunkSize: 2})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters).To(HaveLen(3))
+			Expect(clusters[2].Name).To(Equal("c"))
+			Expect(clusters[2].Region).To(Equal(p.Region()))
+		})
+	})
+
+	Describe("ScaleNodeGroup", func() {
+		It("should require nodegroup name", func() {
+			err := m.ScaleNodeGroup(context.Background(), ScaleNodeGroupOptions{DesiredCapacity: 1})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("nodegroup name must be set"))
+		})
+
+		It("should reject negative capacity", func() {
+			err := m.ScaleNodeGroup(context.Background(), ScaleNodeGroupOptions{Name: "ng-1", DesiredCapacity: -1})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("number of nodes must be 0 or greater"))
+		})
+
+		It("should not do anything when context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			err := m.ScaleNodeGroup(ctx, ScaleNodeGroupOptions{Name: "ng-1", DesiredCapacity: 1})
+			Expect(err).To(Equal(context.Canceled))
+		})
+
+		It("should validate options before submitting the scaling", func() {
+			stack, err := m.SubmitNodeGroupScale(context.Background(), ScaleNodeGroupOptions{Name: "ng-1", DesiredCapacity: -1})
+			Expect(err).To(HaveOccurred())
+			Expect(stack).To(BeNil())
+		})
+	})
+	Describe("UpdateLabels", func() {
+		It("should plan additions, changes and removals, sorted by key", func() {
+			changes := PlanLabelChanges(
+				map[string]string{"a": "1", "b": "2", "c": "3"},
+				map[string]string{"a": "1", "b": "20", "d": "4"},
+				[]string{"c", "e"},
+			)
+			Expect(changes).To(Equal([]LabelChange{
+				{Key: "b", Old: "2", New: "20"},
+				{Key: "c", Old: "3"},
+				{Key: "d", New: "4"},
+			}))
+		})
+
+		It("should reject changes to labels set by eksctl", func() {
+			_, err := m.UpdateLabels(context.Background(), UpdateLabelsOptions{
+				NodeGroup: "ng-1",
+				Unset:     []string{api.NodeGroupNameLabel},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("is set by eksctl and cannot be removed"))
+		})
+
+		It("should not do anything when context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := m.UpdateLabels(ctx, UpdateLabelsOptions{NodeGroup: "ng-1", Set: map[string]string{"a": "1"}})
+			Expect(err).To(Equal(context.Canceled))
+		})
+	})
+	Describe("RotateNodeAMI", func() {
+		It("should require at least one instance to be replaced at a time", func() {
+			_, err := m.RotateNodeAMI(context.Background(), RotateNodeAMIOptions{MaxUnavailable: 0})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("max unavailable must be 1 or greater"))
+		})
+
+		It("should split instances in batches of max unavailable", func() {
+			Expect(instanceBatches([]string{"i-1", "i-2", "i-3"}, 2)).To(Equal([][]string{{"i-1", "i-2"}, {"i-3"}}))
+			Expect(instanceBatches([]string{"i-1", "i-2"}, 2)).To(Equal([][]string{{"i-1", "i-2"}}))
+			Expect(instanceBatches(nil, 1)).To(BeEmpty())
+		})
+
+		It("should find the instance of a node", func() {
+			Expect(instanceIDFromProviderID("aws:///us-west-2a/i-0123456789abcdef0")).To(Equal("i-0123456789abcdef0"))
+			Expect(instanceIDFromProviderID("")).To(BeEmpty())
+		})
+	})
+
+	Describe("GetNodeGroupInstances", func() {
+		It("should join instances with their nodes", func() {
+			p.MockASG().On("DescribeAutoScalingGroupsWithContext", mock.Anything, mock.Anything).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+				AutoScalingGroups: []*autoscaling.Group{{
+					Instances: []*autoscaling.Instance{{InstanceId: aws.String("i-2")}, {InstanceId: aws.String("i-1")}},
+				}},
+			}, nil)
+			p.MockEC2().On("DescribeInstancesWithContext", mock.Anything, mock.Anything).Return(&ec2.DescribeInstancesOutput{
+				Reservations: []*ec2.Reservation{{
+					Instances: []*ec2.Instance{
+						{InstanceId: aws.String("i-2"), PrivateIpAddress: aws.String("192.168.1.2"), State: &ec2.InstanceState{Name: aws.String("pending")}},
+						{InstanceId: aws.String("i-1"), PrivateIpAddress: aws.String("192.168.1.1"), State: &ec2.InstanceState{Name: aws.String("running")}},
+					},
+				}},
+			}, nil)
+			nodes := map[string]*corev1.Node{
+				"i-1": {
+					ObjectMeta: metav1.ObjectMeta{Name: "ip-192-168-1-1.us-west-2.compute.internal"},
+					Status: corev1.NodeStatus{
+						NodeInfo:   corev1.NodeSystemInfo{KubeletVersion: "v1.13.7-eks-c57ff8"},
+						Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue}},
+					},
+				},
+			}
+
+			instances, err := m.nodeGroupInstances(context.Background(), "asg-1", nodes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instances).To(Equal([]*NodeGroupInstance{
+				{
+					InstanceID:     "i-1",
+					State:          "running",
+					PrivateIP:      "192.168.1.1",
+					NodeName:       "ip-192-168-1-1.us-west-2.compute.internal",
+					KubeletVersion: "v1.13.7-eks-c57ff8",
+					Ready:          "True",
+				},
+				{InstanceID: "i-2", State: "pending", PrivateIP: "192.168.1.2"},
+			}))
+		})
+	})
+
+	Describe("SupportBundle", func() {
+		It("should redact account IDs and ARNs of resources of the account", func() {
+			data := redact([]byte(`{"roleArn":"arn:aws:iam::123456789012:role/eks-service-role","owner":"123456789012",` +
+				`"policy":"arn:aws:iam::aws:policy/AmazonEKSClusterPolicy","version":"1.12"}`))
+			Expect(string(data)).To(Equal(`{"roleArn":"arn:REDACTED:iam","owner":"REDACTED",` +
+				`"policy":"arn:aws:iam::aws:policy/AmazonEKSClusterPolicy","version":"1.12"}`))
+		})
+	})
+
+	Describe("TopNodeGroups", func() {
+		node := func(name, nodeGroup, cpu, memory string) corev1.Node {
+			return corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{api.NodeGroupNameLabel: nodeGroup}},
+				Status: corev1.NodeStatus{Allocatable: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse(cpu),
+					corev1.ResourceMemory: resource.MustParse(memory),
+				}},
+			}
+		}
+		pod := func(nodeName, cpuRequest, cpuLimit string) corev1.Pod {
+			container := corev1.Container{Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuRequest)},
+			}}
+			if cpuLimit != "" {
+				container.Resources.Limits = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpuLimit)}
+			}
+			return corev1.Pod{Spec: corev1.PodSpec{NodeName: nodeName, Containers: []corev1.Container{container, container}}}
+		}
+
+		nodes := []corev1.Node{
+			node("n1", "ng-b", "2", "4Gi"),
+			node("n2", "ng-a", "2", "4Gi"),
+			node("n3", "ng-a", "2", "4Gi"),
+		}
+		pods := []corev1.Pod{
+			pod("n2", "100m", "200m"),
+			pod("n3", "250m", ""),
+			pod("unknown", "1", "1"),
+		}
+
+		It("should sum allocatable resources, requests and limits by nodegroup", func() {
+			summary := summarizeNodeGroupUsage(nodes, pods, nil, nil)
+			Expect(summary).To(HaveLen(2))
+
+			ngA := summary[0]
+			Expect(ngA.NodeGroup).To(Equal("ng-a"))
+			Expect(ngA.Nodes).To(Equal(2))
+			Expect(ngA.CPU.Allocatable.MilliValue()).To(Equal(int64(4000)))
+			Expect(ngA.CPU.Requests.MilliValue()).To(Equal(int64(700)))
+			Expect(ngA.CPU.Limits.MilliValue()).To(Equal(int64(400)))
+			Expect(ngA.Memory.Allocatable.Value()).To(Equal(int64(8 * 1024 * 1024 * 1024)))
+			Expect(ngA.CPU.Usage).To(BeNil())
+
+			Expect(summary[1].NodeGroup).To(Equal("ng-b"))
+			Expect(summary[1].CPU.Requests.IsZero()).To(BeTrue())
+		})
+
+		It("should add usage from node metrics and select nodegroups", func() {
+			usage := map[string]corev1.ResourceList{
+				"n1": {corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			}
+			summary := summarizeNodeGroupUsage(nodes, pods, usage, []string{"ng-b"})
+			Expect(summary).To(HaveLen(1))
+			Expect(summary[0].CPU.Usage.MilliValue()).To(Equal(int64(500)))
+			Expect(summary[0].Memory.Usage.Value()).To(Equal(int64(1024 * 1024 * 1024)))
+		})
+	})
+
+	Describe("GetInventory", func() {
+		It("should find the type of resources from their ARN", func() {
+			Expect(arnResourceType("arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1")).To(Equal("ec2:vpc"))
+			Expect(arnResourceType("arn:aws:logs:us-west-2:123456789012:log-group:/aws/eks/test-cluster/cluster")).To(Equal("logs:log-group"))
+			Expect(arnResourceType("arn:aws:sns:us-west-2:123456789012:topic")).To(Equal("sns:topic"))
+			Expect(arnResourceType("vpc-1")).To(Equal("unknown"))
+		})
+
+		It("should flag resources that outlived their stack or the cluster as orphans", func() {
+			inv := &inventory{
+				resources: map[string]*InventoryResource{},
+				stacks: map[string]*manager.Stack{
+					"eksctl-test-cluster-cluster": {
+						StackName:   aws.String("eksctl-test-cluster-cluster"),
+						StackId:     aws.String("arn:aws:cloudformation:us-west-2:123456789012:stack/eksctl-test-cluster-cluster/1"),
+						StackStatus: aws.String(cfn.StackStatusCreateComplete),
+					},
+					"eksctl-test-cluster-nodegroup-ng-1": {
+						StackName:   aws.String("eksctl-test-cluster-nodegroup-ng-1"),
+						StackId:     aws.String("arn:aws:cloudformation:us-west-2:123456789012:stack/eksctl-test-cluster-nodegroup-ng-1/1"),
+						StackStatus: aws.String(cfn.StackStatusDeleteFailed),
+					},
+				},
+				clusterExists: true,
+			}
+			for _, s := range inv.stacks {
+				inv.addStack(s)
+			}
+			tagged := func(arn, stack string) *resourcegroupstaggingapi.ResourceTagMapping {
+				r := &resourcegroupstaggingapi.ResourceTagMapping{ResourceARN: aws.String(arn)}
+				if stack != "" {
+					r.Tags = []*resourcegroupstaggingapi.Tag{{Key: aws.String(cloudFormationStackNameTag), Value: aws.String(stack)}}
+				}
+				return r
+			}
+			inv.addTaggedResource(tagged("arn:aws:cloudformation:us-west-2:123456789012:stack/eksctl-test-cluster-cluster/1", ""))
+			inv.addTaggedResource(tagged("arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1", "eksctl-test-cluster-cluster"))
+			inv.addTaggedResource(tagged("arn:aws:ec2:us-west-2:123456789012:security-group/sg-1", "eksctl-test-cluster-nodegroup-ng-1"))
+			inv.addTaggedResource(tagged("arn:aws:ec2:us-west-2:123456789012:security-group/sg-2", "eksctl-test-cluster-nodegroup-ng-2"))
+			inv.addStackResource(&cfn.StackResource{
+				ResourceType:       aws.String("AWS::IAM::Role"),
+				PhysicalResourceId: aws.String("eksctl-test-cluster-cluster-ServiceRole-1"),
+				StackName:          aws.String("eksctl-test-cluster-cluster"),
+			})
+			inv.addStackResource(&cfn.StackResource{
+				ResourceType:       aws.String("AWS::EC2::VPC"),
+				PhysicalResourceId: aws.String("vpc-1"),
+				StackName:          aws.String("eksctl-test-cluster-cluster"),
+			})
+
+			resources := inv.list()
+			Expect(resources).To(HaveLen(6))
+			orphans := map[string]string{}
+			for _, r := range resources {
+				if r.Orphan {
+					orphans[r.ID] = r.Reason
+				}
+			}
+			Expect(orphans).To(Equal(map[string]string{
+				"arn:aws:cloudformation:us-west-2:123456789012:stack/eksctl-test-cluster-nodegroup-ng-1/1": `deletion of stack "eksctl-test-cluster-nodegroup-ng-1" failed`,
+				"arn:aws:ec2:us-west-2:123456789012:security-group/sg-1":                                   `deletion of stack "eksctl-test-cluster-nodegroup-ng-1" failed`,
+				"arn:aws:ec2:us-west-2:123456789012:security-group/sg-2":                                   `stack "eksctl-test-cluster-nodegroup-ng-2" no longer exists`,
+			}))
+			Expect(resources[0].Type).To(Equal("cloudformation:stack"))
+			Expect(resources[len(resources)-1].Type).To(Equal("iam:role"))
+		})
+
+		It("should flag all resources as orphans when the cluster no longer exists", func() {
+			inv := &inventory{resources: map[string]*InventoryResource{}, stacks: map[string]*manager.Stack{}}
+			inv.add(&InventoryResource{Type: "logs:log-group", ID: "/aws/eks/test-cluster/cluster"})
+			Expect(inv.list()).To(ConsistOf(&InventoryResource{
+				Type:   "logs:log-group",
+				ID:     "/aws/eks/test-cluster/cluster",
+				Orphan: true,
+				Reason: "cluster no longer exists",
+			}))
+		})
+	})
+
+	Describe("UpdateAutoscalerTags", func() {
+		It("should only consider Cluster Autoscaler node-template tags", func() {
+			tags := nodeTemplateTags([]*autoscaling.TagDescription{
+				{Key: aws.String("k8s.io/cluster-autoscaler/enabled"), Value: aws.String("true")},
+				{Key: aws.String("k8s.io/cluster-autoscaler/node-template/label/role"), Value: aws.String("gpu")},
+				{Key: aws.String("k8s.io/cluster-autoscaler/node-template/taint/dedicated"), Value: aws.String("gpu:NoSchedule")},
+			})
+			Expect(tags).To(Equal(map[string]string{
+				"k8s.io/cluster-autoscaler/node-template/label/role":      "gpu",
+				"k8s.io/cluster-autoscaler/node-template/taint/dedicated": "gpu:NoSchedule",
+			}))
+		})
+	})
+
+	Describe("SuspendNodeGroupProcesses", func() {
+		newGroup := func(desired int64, states ...string) *autoscaling.Group {
+			group := &autoscaling.Group{DesiredCapacity: aws.Int64(desired)}
+			for i, state := range states {
+				group.Instances = append(group.Instances, &autoscaling.Instance{
+					InstanceId:     aws.String(fmt.Sprintf("i-%d", i)),
+					LifecycleState: aws.String(state),
+				})
+			}
+			return group
+		}
+
+		It("should reject unknown processes", func() {
+			_, err := m.SuspendNodeGroupProcesses(context.Background(), NodeGroupProcessesOptions{NodeGroup: "ng-1", Processes: []string{"Launch", "Reboot"}})
+			Expect(err).To(MatchError(ContainSubstring(`unknown process "Reboot"`)))
+		})
+
+		It("should only suspend processes of groups that aren't scaling", func() {
+			Expect(checkNoScalingInProgress(newGroup(2, "InService", "Standby"))).To(Succeed())
+			Expect(checkNoScalingInProgress(newGroup(3, "InService", "InService"))).To(MatchError("it has 2 instance(s) and a desired capacity of 3"))
+			Expect(checkNoScalingInProgress(newGroup(2, "InService", "Terminating:Wait"))).To(MatchError(`instance "i-1" is in state Terminating:Wait`))
+		})
+
+		It("should find the processes suspended for maintenance", func() {
+			group := &autoscaling.Group{
+				SuspendedProcesses: []*autoscaling.SuspendedProcess{
+					{ProcessName: aws.String("Terminate")},
+					{ProcessName: aws.String("AZRebalance")},
+					{ProcessName: aws.String("Launch")},
+				},
+				Tags: []*autoscaling.TagDescription{
+					{Key: aws.String(api.NodeGroupSuspendedProcessesTag), Value: aws.String("Launch,Terminate")},
+				},
+			}
+			Expect(suspendedProcesses(group)).To(Equal([]string{"AZRebalance", "Launch", "Terminate"}))
+			Expect(maintenanceSuspendedProcesses(group)).To(Equal([]string{"Launch", "Terminate"}))
+			Expect(union([]string{"Launch"}, []string{"Terminate", "Launch"})).To(Equal([]string{"Launch", "Terminate"}))
+		})
+	})
+
+	Describe("DeleteCluster", func() {
+		mockStacks := func(protected bool) {
+			p.MockCloudFormation().On("ListStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				consume := args[1].(func(p *cfn.ListStacksOutput, last bool) (shouldContinue bool))
+				consume(&cfn.ListStacksOutput{
+					StackSummaries: []*cfn.StackSummary{{StackName: aws.String("eksctl-test-cluster-cluster")}},
+				}, true)
+			}).Return(nil)
+			p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(&cfn.DescribeStacksOutput{
+				Stacks: []*cfn.Stack{
+					{
+						StackName:                   aws.String("eksctl-test-cluster-cluster"),
+						StackStatus:                 aws.String(cfn.StackStatusCreateComplete),
+						EnableTerminationProtection: aws.Bool(protected),
+					},
+				},
+			}, nil)
+			p.MockCloudFormation().On("UpdateTerminationProtection", mock.MatchedBy(func(input *cfn.UpdateTerminationProtectionInput) bool {
+				return *input.StackName == "eksctl-test-cluster-cluster" && !*input.EnableTerminationProtection
+			})).Return(&cfn.UpdateTerminationProtectionOutput{}, nil)
+
+			// the deletion is stopped when the control plane is described
+			p.MockEC2().On("DescribeKeyPairs", mock.Anything).Return(&ec2.DescribeKeyPairsOutput{}, nil)
+			p.MockEKS().On("DescribeCluster", mock.Anything).Return(nil, fmt.Errorf("control plane unavailable"))
+		}
+
+		It("should refuse to delete a protected cluster", func() {
+			mockStacks(true)
+
+			err := m.DeleteCluster(context.Background(), DeleteClusterOptions{})
+			Expect(err).To(MatchError(`cluster "test-cluster" has deletion protection enabled on 1 stack(s)`))
+			Expect(err).To(BeAssignableToTypeOf(&ErrDeletionProtected{}))
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 0)).To(BeTrue())
+			Expect(p.MockEC2().AssertNumberOfCalls(GinkgoT(), "DescribeKeyPairs", 0)).To(BeTrue())
+		})
+
+		It("should turn off the protection of a protected cluster before deleting it", func() {
+			mockStacks(true)
+
+			err := m.DeleteCluster(context.Background(), DeleteClusterOptions{DisableProtection: true})
+			Expect(err).To(MatchError(ContainSubstring("control plane unavailable")))
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 1)).To(BeTrue())
+		})
+
+		It("should delete an unprotected cluster without changing its stacks", func() {
+			mockStacks(false)
+
+			err := m.DeleteCluster(context.Background(), DeleteClusterOptions{})
+			Expect(err).To(MatchError(ContainSubstring("control plane unavailable")))
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 0)).To(BeTrue())
+		})
+
+		It("should delete the log group and the IAM OIDC provider of the cluster with all dependents", func() {
+			const (
+				issuerURL   = "https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"
+				providerARN = "arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"
+			)
+			p.MockCloudFormation().On("ListStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				consume := args[1].(func(p *cfn.ListStacksOutput, last bool) (shouldContinue bool))
+				consume(&cfn.ListStacksOutput{
+					StackSummaries: []*cfn.StackSummary{{StackName: aws.String("eksctl-test-cluster-cluster")}},
+				}, true)
+			}).Return(nil)
+			p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(&cfn.DescribeStacksOutput{
+				Stacks: []*cfn.Stack{
+					{
+						StackName:   aws.String("eksctl-test-cluster-cluster"),
+						StackStatus: aws.String(cfn.StackStatusCreateComplete),
+						Outputs: []*cfn.Output{
+							{OutputKey: aws.String("OIDCIssuerURL"), OutputValue: aws.String(issuerURL)},
+						},
+					},
+				},
+			}, nil)
+			p.MockCloudWatchLogs().On("DeleteLogGroup", mock.MatchedBy(func(input *cloudwatchlogs.DeleteLogGroupInput) bool {
+				return *input.LogGroupName == "/aws/eks/test-cluster/cluster"
+			})).Return(&cloudwatchlogs.DeleteLogGroupOutput{}, nil)
+			p.MockIAM().On("ListOpenIDConnectProviders", mock.Anything).Return(&awsiam.ListOpenIDConnectProvidersOutput{
+				OpenIDConnectProviderList: []*awsiam.OpenIDConnectProviderListEntry{{Arn: aws.String(providerARN)}},
+			}, nil)
+			p.MockIAM().On("DeleteOpenIDConnectProvider", mock.MatchedBy(func(input *awsiam.DeleteOpenIDConnectProviderInput) bool {
+				return *input.OpenIDConnectProviderArn == providerARN
+			})).Return(&awsiam.DeleteOpenIDConnectProviderOutput{}, nil)
+
+			url, err := m.oidcIssuerURL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(url).To(Equal(issuerURL))
+
+			Expect(m.maybeDeleteDependents(DeleteClusterOptions{}, url)).To(Succeed())
+			Expect(p.MockCloudWatchLogs().AssertNumberOfCalls(GinkgoT(), "DeleteLogGroup", 0)).To(BeTrue())
+			Expect(p.MockIAM().AssertNumberOfCalls(GinkgoT(), "DeleteOpenIDConnectProvider", 0)).To(BeTrue())
+
+			Expect(m.maybeDeleteDependents(DeleteClusterOptions{DeleteAllDependents: true}, url)).To(Succeed())
+			Expect(p.MockCloudWatchLogs().AssertNumberOfCalls(GinkgoT(), "DeleteLogGroup", 1)).To(BeTrue())
+			Expect(p.MockIAM().AssertNumberOfCalls(GinkgoT(), "DeleteOpenIDConnectProvider", 1)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,102 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+// UpdateAddonOptions holds options for default add-on updates
+type UpdateAddonOptions struct {
+	// Plan only reports whether an update is required, without applying it
+	Plan bool
+}
+
+// UpdateKubeProxy updates kube-proxy to match control plane version,
+// it returns true when an update was required
+func (m *Manager) UpdateKubeProxy(ctx context.Context, opts UpdateAddonOptions) (bool, error) {
+	rawClient, kubernetesVersion, err := m.newRawClient(ctx)
+	if err != nil {
+		return false, err
+	}
+	return defaultaddons.UpdateKubeProxyImageTag(rawClient.ClientSet(), kubernetesVersion, opts.Plan)
+}
+
+// UpdateAWSNode updates aws-node to the standard Amazon EKS version, then
+// applies the VPC CNI settings of the config, as the update resets them,
+// it returns true when an update was required
+func (m *Manager) UpdateAWSNode(ctx context.Context, opts UpdateAddonOptions) (bool, error) {
+	rawClient, kubernetesVersion, err := m.newRawClient(ctx)
+	if err != nil {
+		return false, err
+	}
+	updateRequired, err := defaultaddons.UpdateAWSNode(rawClient, m.cfg.Metadata.Region, kubernetesVersion, opts.Plan)
+	if err != nil || m.cfg.VPCCNI == nil {
+		return updateRequired, err
+	}
+	configRequired, err := defaultaddons.ConfigureAWSNode(rawClient.ClientSet(), m.cfg.VPCCNI, opts.Plan)
+	if err != nil {
+		return false, errors.Wrap(err, "configuring VPC CNI")
+	}
+	return updateRequired || configRequired, nil
+}
+
+// UpdateCoreDNS updates coredns to the standard Amazon EKS version,
+// it returns true when an update was required
+func (m *Manager) UpdateCoreDNS(ctx context.Context, opts UpdateAddonOptions) (bool, error) {
+	rawClient, kubernetesVersion, err := m.newRawClient(ctx)
+	if err != nil {
+		return false, err
+	}
+	return defaultaddons.UpdateCoreDNS(rawClient, m.cfg.Metadata.Region, kubernetesVersion, opts.Plan)
+}
+
+// GetAddons returns the versions of the default add-ons deployed to the cluster,
+// or only of the add-on with the given name, when it's not empty
+func (m *Manager) GetAddons(ctx context.Context, name string) ([]*defaultaddons.AddonVersion, error) {
+	rawClient, kubernetesVersion, err := m.newRawClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := defaultaddons.AddonVersions(rawClient.ClientSet(), kubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting versions of default add-ons")
+	}
+	addons := []*defaultaddons.AddonVersion{}
+	for _, v := range versions {
+		if v.Current == "" || (name != "" && v.Name != name) {
+			continue
+		}
+		addons = append(addons, v)
+	}
+	if name != "" && len(addons) == 0 {
+		return nil, eksctlerrors.NewNotFound("add-on %q is not deployed to cluster %q", name, m.cfg.Metadata.Name)
+	}
+	return addons, nil
+}
+
+func (m *Manager) newRawClient(ctx context.Context) (*kubernetes.RawClient, string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, "", err
+	}
+
+	if err := m.ctl.GetCredentials(m.cfg); err != nil {
+		return nil, "", errors.Wrapf(err, "getting credentials for cluster %q", m.cfg.Metadata.Name)
+	}
+
+	rawClient, err := m.ctl.NewRawClient(m.cfg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	kubernetesVersion, err := rawClient.ServerVersion()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return rawClient, kubernetesVersion, nil
+}
@@ -0,0 +1,301 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+// MaintenanceProcesses are the ASG processes suspended during maintenance of a nodegroup
+// by default, so that instances are neither launched, terminated nor rebalanced
+var MaintenanceProcesses = []string{"Launch", "Terminate", "AZRebalance"}
+
+// scalingProcesses are the processes of auto scaling groups that can be suspended
+var scalingProcesses = []string{
+	"Launch", "Terminate", "AddToLoadBalancer", "AlarmNotification",
+	"AZRebalance", "HealthCheck", "ReplaceUnhealthy", "ScheduledActions",
+}
+
+// NodeGroupProcessesOptions holds options for SuspendNodeGroupProcesses and ResumeNodeGroupProcesses
+type NodeGroupProcessesOptions struct {
+	// NodeGroup is the name of the nodegroup
+	NodeGroup string
+	// Processes are the processes to suspend or resume, MaintenanceProcesses are suspended when
+	// it's empty, and the processes suspended by SuspendNodeGroupProcesses are resumed
+	Processes []string
+	// Force suspends processes while the group is launching or terminating instances
+	Force bool
+	// Plan only returns the processes, without suspending or resuming them
+	Plan bool
+}
+
+// NodeGroupProcessesUpdate is the list of processes of a nodegroup that are suspended or resumed
+type NodeGroupProcessesUpdate struct {
+	NodeGroup        string
+	AutoScalingGroup string
+	Processes        []string
+}
+
+// SuspendNodeGroupProcesses suspends processes of the ASG of a nodegroup for maintenance, processes
+// that are already suspended are left out; the suspended processes are recorded in a tag of the ASG,
+// so that processes suspended for other reasons, e.g. AZRebalance of nodegroups with azRebalance
+// disabled, stay suspended when maintenance ends
+func (m *Manager) SuspendNodeGroupProcesses(ctx context.Context, opts NodeGroupProcessesOptions) (*NodeGroupProcessesUpdate, error) {
+	processes := opts.Processes
+	if len(processes) == 0 {
+		processes = MaintenanceProcesses
+	}
+	if err := validateScalingProcesses(processes); err != nil {
+		return nil, err
+	}
+
+	group, err := m.nodeGroupAutoScalingGroup(ctx, opts.NodeGroup)
+	if err != nil {
+		return nil, err
+	}
+	asgName := aws.StringValue(group.AutoScalingGroupName)
+
+	if !opts.Force {
+		if err := checkNoScalingInProgress(group); err != nil {
+			return nil, errors.Wrapf(err, "nodegroup %q is scaling, wait for it to complete or use --force", opts.NodeGroup)
+		}
+	}
+
+	suspended := suspendedProcesses(group)
+	update := &NodeGroupProcessesUpdate{NodeGroup: opts.NodeGroup, AutoScalingGroup: asgName}
+	for _, p := range processes {
+		if !utils.IsOneOf(p, suspended) {
+			update.Processes = append(update.Processes, p)
+		}
+	}
+	if len(update.Processes) == 0 || opts.Plan {
+		return update, nil
+	}
+
+	recorded := union(maintenanceSuspendedProcesses(group), update.Processes)
+	if err := m.setMaintenanceSuspendedProcesses(ctx, asgName, recorded); err != nil {
+		return nil, err
+	}
+	if _, err := m.ctl.Provider.ASG().SuspendProcessesWithContext(ctx, &autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: group.AutoScalingGroupName,
+		ScalingProcesses:     aws.StringSlice(update.Processes),
+	}); err != nil {
+		return nil, errors.Wrapf(err, "suspending processes of auto scaling group %q", asgName)
+	}
+	return update, nil
+}
+
+// ResumeNodeGroupProcesses resumes processes of the ASG of a nodegroup, by default those
+// that were suspended by SuspendNodeGroupProcesses; processes that aren't suspended are left out
+func (m *Manager) ResumeNodeGroupProcesses(ctx context.Context, opts NodeGroupProcessesOptions) (*NodeGroupProcessesUpdate, error) {
+	if err := validateScalingProcesses(opts.Processes); err != nil {
+		return nil, err
+	}
+
+	group, err := m.nodeGroupAutoScalingGroup(ctx, opts.NodeGroup)
+	if err != nil {
+		return nil, err
+	}
+	asgName := aws.StringValue(group.AutoScalingGroupName)
+
+	recorded := maintenanceSuspendedProcesses(group)
+	processes := opts.Processes
+	if len(processes) == 0 {
+		processes = recorded
+	}
+
+	suspended := suspendedProcesses(group)
+	update := &NodeGroupProcessesUpdate{NodeGroup: opts.NodeGroup, AutoScalingGroup: asgName}
+	for _, p := range processes {
+		if utils.IsOneOf(p, suspended) {
+			update.Processes = append(update.Processes, p)
+		}
+	}
+	if opts.Plan {
+		return update, nil
+	}
+
+	if len(update.Processes) > 0 {
+		if _, err := m.ctl.Provider.ASG().ResumeProcessesWithContext(ctx, &autoscaling.ScalingProcessQuery{
+			AutoScalingGroupName: group.AutoScalingGroupName,
+			ScalingProcesses:     aws.StringSlice(update.Processes),
+		}); err != nil {
+			return nil, errors.Wrapf(err, "resuming processes of auto scaling group %q", asgName)
+		}
+	}
+
+	remaining := []string{}
+	for _, p := range recorded {
+		if !utils.IsOneOf(p, processes) && utils.IsOneOf(p, suspended) {
+			remaining = append(remaining, p)
+		}
+	}
+	if len(remaining) != len(recorded) {
+		if err := m.setMaintenanceSuspendedProcesses(ctx, asgName, remaining); err != nil {
+			return nil, err
+		}
+	}
+	return update, nil
+}
+
+// SetNodeGroupSuspendedProcesses sets the processes that are suspended in the ASGs of the nodegroups
+func (m *Manager) SetNodeGroupSuspendedProcesses(ctx context.Context, nodeGroups []*NodeGroupWithInstances) error {
+	if len(nodeGroups) == 0 {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	stacks, err := m.stackManager.DescribeNodeGroupStacksAndResources()
+	if err != nil {
+		return errors.Wrap(err, "getting nodegroup stacks")
+	}
+
+	asgNames := map[string]string{}
+	names := []*string{}
+	for _, ng := range nodeGroups {
+		if asgName := autoScalingGroupName(stacks[ng.Name]); asgName != "" {
+			asgNames[asgName] = ng.Name
+			names = append(names, aws.String(asgName))
+		}
+	}
+
+	suspended := map[string][]string{}
+	// DescribeAutoScalingGroups accepts up to 50 names, which it returns in a single page
+	for len(names) > 0 {
+		n := len(names)
+		if n > 50 {
+			n = 50
+		}
+		output, err := m.ctl.Provider.ASG().DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: names[:n],
+		})
+		if err != nil {
+			return errors.Wrap(err, "describing auto scaling groups of nodegroups")
+		}
+		for _, group := range output.AutoScalingGroups {
+			suspended[asgNames[aws.StringValue(group.AutoScalingGroupName)]] = suspendedProcesses(group)
+		}
+		names = names[n:]
+	}
+
+	for _, ng := range nodeGroups {
+		ng.SuspendedProcesses = suspended[ng.Name]
+	}
+	return nil
+}
+
+func (m *Manager) nodeGroupAutoScalingGroup(ctx context.Context, name string) (*autoscaling.Group, error) {
+	if name == "" {
+		return nil, fmt.Errorf("nodegroup name must be set")
+	}
+	summaries, err := m.GetNodeGroups(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(summaries) == 0 {
+		return nil, eksctlerrors.NewNotFound("nodegroup %q not found in cluster %q", name, m.cfg.Metadata.Name)
+	}
+
+	stacks, err := m.stackManager.DescribeNodeGroupStacksAndResources()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting nodegroup stacks")
+	}
+	asgName := autoScalingGroupName(stacks[name])
+	if asgName == "" {
+		return nil, fmt.Errorf("auto scaling group of nodegroup %q not found", name)
+	}
+
+	groups, err := m.ctl.Provider.ASG().DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(asgName)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing auto scaling group %q", asgName)
+	}
+	if len(groups.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("auto scaling group %q not found", asgName)
+	}
+	return groups.AutoScalingGroups[0], nil
+}
+
+func (m *Manager) setMaintenanceSuspendedProcesses(ctx context.Context, asgName string, processes []string) error {
+	tag := &autoscaling.Tag{
+		Key:               aws.String(api.NodeGroupSuspendedProcessesTag),
+		Value:             aws.String(strings.Join(processes, ",")),
+		ResourceId:        aws.String(asgName),
+		ResourceType:      aws.String("auto-scaling-group"),
+		PropagateAtLaunch: aws.Bool(false),
+	}
+	var err error
+	if len(processes) == 0 {
+		_, err = m.ctl.Provider.ASG().DeleteTagsWithContext(ctx, &autoscaling.DeleteTagsInput{Tags: []*autoscaling.Tag{tag}})
+	} else {
+		_, err = m.ctl.Provider.ASG().CreateOrUpdateTagsWithContext(ctx, &autoscaling.CreateOrUpdateTagsInput{Tags: []*autoscaling.Tag{tag}})
+	}
+	return errors.Wrapf(err, "recording suspended processes of auto scaling group %q", asgName)
+}
+
+// checkNoScalingInProgress returns an error when the group has fewer or more instances than
+// its desired capacity, or instances that are still launching or terminating, as suspending
+// Launch or Terminate would leave it at the wrong size
+func checkNoScalingInProgress(group *autoscaling.Group) error {
+	desired := int(aws.Int64Value(group.DesiredCapacity))
+	if len(group.Instances) != desired {
+		return fmt.Errorf("it has %d instance(s) and a desired capacity of %d", len(group.Instances), desired)
+	}
+	for _, i := range group.Instances {
+		if state := aws.StringValue(i.LifecycleState); state != autoscaling.LifecycleStateInService && state != autoscaling.LifecycleStateStandby {
+			return fmt.Errorf("instance %q is in state %s", aws.StringValue(i.InstanceId), state)
+		}
+	}
+	return nil
+}
+
+func validateScalingProcesses(processes []string) error {
+	for _, p := range processes {
+		if !utils.IsOneOf(p, scalingProcesses) {
+			return eksctlerrors.NewValidationError("unknown process %q - use one of: %s", p, strings.Join(scalingProcesses, ", "))
+		}
+	}
+	return nil
+}
+
+// suspendedProcesses returns the sorted names of the suspended processes of the group
+func suspendedProcesses(group *autoscaling.Group) []string {
+	processes := []string{}
+	for _, p := range group.SuspendedProcesses {
+		processes = append(processes, aws.StringValue(p.ProcessName))
+	}
+	sort.Strings(processes)
+	return processes
+}
+
+// maintenanceSuspendedProcesses returns the processes recorded by SuspendNodeGroupProcesses
+func maintenanceSuspendedProcesses(group *autoscaling.Group) []string {
+	for _, t := range group.Tags {
+		if aws.StringValue(t.Key) == api.NodeGroupSuspendedProcessesTag && aws.StringValue(t.Value) != "" {
+			return strings.Split(aws.StringValue(t.Value), ",")
+		}
+	}
+	return nil
+}
+
+func union(a, b []string) []string {
+	result := append([]string{}, a...)
+	for _, s := range b {
+		if !utils.IsOneOf(s, result) {
+			result = append(result, s)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
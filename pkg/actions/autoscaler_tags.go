@@ -0,0 +1,141 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// UpdateAutoscalerTagsOptions holds options for UpdateAutoscalerTags
+type UpdateAutoscalerTagsOptions struct {
+	// NodeGroup is the name of the nodegroup to update, all nodegroups are updated when it's empty
+	NodeGroup string
+	// Plan only returns the changes, without applying them
+	Plan bool
+}
+
+// AutoscalerTagsUpdate is the change of the Cluster Autoscaler node-template tags of
+// a nodegroup, tag changes have the same form as label changes
+type AutoscalerTagsUpdate struct {
+	NodeGroup string
+	Changes   []LabelChange
+}
+
+// UpdateAutoscalerTags sets the Cluster Autoscaler node-template tags of the ASGs of
+// nodegroups to match the labels and taints their nodes register with, and removes
+// the tags of labels and taints the nodes no longer have
+func (m *Manager) UpdateAutoscalerTags(ctx context.Context, opts UpdateAutoscalerTagsOptions) ([]*AutoscalerTagsUpdate, error) {
+	summaries, err := m.GetNodeGroups(ctx, opts.NodeGroup)
+	if err != nil {
+		return nil, err
+	}
+	if opts.NodeGroup != "" && len(summaries) == 0 {
+		return nil, eksctlerrors.NewNotFound("nodegroup %q not found in cluster %q", opts.NodeGroup, m.cfg.Metadata.Name)
+	}
+
+	stacks, err := m.stackManager.DescribeNodeGroupStacksAndResources()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting nodegroup stacks")
+	}
+
+	updates := []*AutoscalerTagsUpdate{}
+	for _, ng := range summaries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		labels, err := m.stackManager.GetNodeGroupLabels(ng.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting labels of nodegroup %q", ng.Name)
+		}
+		taints, err := m.stackManager.GetNodeGroupTaints(ng.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting taints of nodegroup %q", ng.Name)
+		}
+		asgName := autoScalingGroupName(stacks[ng.Name])
+		if asgName == "" {
+			return nil, fmt.Errorf("auto scaling group of nodegroup %q not found", ng.Name)
+		}
+
+		groups, err := m.ctl.Provider.ASG().DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []*string{aws.String(asgName)},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "describing auto scaling group %q", asgName)
+		}
+		if len(groups.AutoScalingGroups) == 0 {
+			return nil, fmt.Errorf("auto scaling group %q not found", asgName)
+		}
+
+		current := nodeTemplateTags(groups.AutoScalingGroups[0].Tags)
+		desired := api.ClusterAutoscalerNodeTemplateTags(labels, taints)
+		unset := []string{}
+		for k := range current {
+			if _, ok := desired[k]; !ok {
+				unset = append(unset, k)
+			}
+		}
+		changes := PlanLabelChanges(current, desired, unset)
+		if len(changes) == 0 {
+			continue
+		}
+		updates = append(updates, &AutoscalerTagsUpdate{NodeGroup: ng.Name, Changes: changes})
+		if opts.Plan {
+			continue
+		}
+		if err := m.applyAutoscalerTagChanges(ctx, asgName, changes); err != nil {
+			return nil, errors.Wrapf(err, "updating tags of nodegroup %q", ng.Name)
+		}
+	}
+	return updates, nil
+}
+
+// nodeTemplateTags returns the Cluster Autoscaler node-template tags among the tags of an ASG
+func nodeTemplateTags(tags []*autoscaling.TagDescription) map[string]string {
+	nodeTemplateTags := map[string]string{}
+	for _, t := range tags {
+		key := aws.StringValue(t.Key)
+		if strings.HasPrefix(key, api.ClusterAutoscalerLabelTagPrefix) || strings.HasPrefix(key, api.ClusterAutoscalerTaintTagPrefix) {
+			nodeTemplateTags[key] = aws.StringValue(t.Value)
+		}
+	}
+	return nodeTemplateTags
+}
+
+func (m *Manager) applyAutoscalerTagChanges(ctx context.Context, asgName string, changes []LabelChange) error {
+	newTag := func(key, value string) *autoscaling.Tag {
+		return &autoscaling.Tag{
+			Key:               aws.String(key),
+			Value:             aws.String(value),
+			ResourceId:        aws.String(asgName),
+			ResourceType:      aws.String("auto-scaling-group"),
+			PropagateAtLaunch: aws.Bool(false),
+		}
+	}
+
+	set, unset := []*autoscaling.Tag{}, []*autoscaling.Tag{}
+	for _, c := range changes {
+		if c.New == "" {
+			unset = append(unset, newTag(c.Key, c.Old))
+		} else {
+			set = append(set, newTag(c.Key, c.New))
+		}
+	}
+	if len(set) > 0 {
+		if _, err := m.ctl.Provider.ASG().CreateOrUpdateTagsWithContext(ctx, &autoscaling.CreateOrUpdateTagsInput{Tags: set}); err != nil {
+			return err
+		}
+	}
+	if len(unset) > 0 {
+		if _, err := m.ctl.Provider.ASG().DeleteTagsWithContext(ctx, &autoscaling.DeleteTagsInput{Tags: unset}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
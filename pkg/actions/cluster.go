@@ -0,0 +1,244 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/elb"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/ssh"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+// ListClustersOptions holds options for ListClusters
+type ListClustersOptions struct {
+	// ChunkSize is the number of clusters requested per API call
+	ChunkSize int
+}
+
+// DeleteClusterOptions holds options for DeleteCluster
+type DeleteClusterOptions struct {
+	// Wait for deletion of all resources to complete
+	Wait bool
+	// DisableProtection turns off termination protection of cluster stacks
+	// before deleting them
+	DisableProtection bool
+	// DeleteAllDependents also deletes resources created for the cluster outside of
+	// its stacks, which would otherwise be left behind
+	DeleteAllDependents bool
+}
+
+// ErrDeletionProtected is an error type that represents a cluster
+// with termination protection enabled on some of its stacks
+type ErrDeletionProtected struct {
+	clusterName string
+	stacks      int
+}
+
+// NewErrDeletionProtected creates a new instance of ErrDeletionProtected for
+// a given cluster and number of protected stacks
+func NewErrDeletionProtected(clusterName string, stacks int) *ErrDeletionProtected {
+	return &ErrDeletionProtected{
+		clusterName: clusterName,
+		stacks:      stacks,
+	}
+}
+
+// Error return the error message
+func (e *ErrDeletionProtected) Error() string {
+	return fmt.Sprintf("cluster %q has deletion protection enabled on %d stack(s)", e.clusterName, e.stacks)
+}
+
+// ListClusters returns metadata of all clusters in the region
+func (m *Manager) ListClusters(ctx context.Context, opts ListClustersOptions) ([]*api.ClusterMeta, error) {
+	clusters := []*api.ClusterMeta{}
+
+	input := &awseks.ListClustersInput{}
+	if opts.ChunkSize > 0 {
+		input.MaxResults = aws.Int64(int64(opts.ChunkSize))
+	}
+
+	for {
+		output, err := m.ctl.Provider.EKS().ListClustersWithContext(ctx, input)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing control planes")
+		}
+		for _, name := range output.Clusters {
+			clusters = append(clusters, &api.ClusterMeta{
+				Name:   *name,
+				Region: m.ctl.Provider.Region(),
+			})
+		}
+		if !api.IsSetAndNonEmptyString(output.NextToken) {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return clusters, nil
+}
+
+// GetCluster describes the control plane of the cluster
+func (m *Manager) GetCluster(ctx context.Context) (*awseks.Cluster, error) {
+	input := &awseks.DescribeClusterInput{
+		Name: &m.cfg.Metadata.Name,
+	}
+	output, err := m.ctl.Provider.EKS().DescribeClusterWithContext(ctx, input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to describe control plane %q", m.cfg.Metadata.Name)
+	}
+	return output.Cluster, nil
+}
+
+// DeleteCluster deletes all resources that belong to the cluster
+func (m *Manager) DeleteCluster(ctx context.Context, opts DeleteClusterOptions) error {
+	meta := m.cfg.Metadata
+
+	if err := m.checkDeletionProtection(opts.DisableProtection); err != nil {
+		return err
+	}
+
+	// the OIDC issuer is an output of the cluster stack, so it has to be read before deleting it
+	var issuerURL string
+	if opts.DeleteAllDependents {
+		var err error
+		if issuerURL, err = m.oidcIssuerURL(); err != nil {
+			return err
+		}
+	}
+
+	ssh.DeleteKeys(meta.Name, m.ctl.Provider)
+
+	if hasDeprecatedStacks, err := m.deleteDeprecatedStacks(ctx); hasDeprecatedStacks {
+		return err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	logger.Info("cleaning up LoadBalancer services")
+	if err := m.ctl.GetCredentials(m.cfg); err != nil {
+		return err
+	}
+	cs, err := m.ctl.NewStdClientSet(m.cfg)
+	if err != nil {
+		return err
+	}
+	elbCtx, cleanup := context.WithTimeout(ctx, 10*time.Minute)
+	defer cleanup()
+	if err := elb.Cleanup(elbCtx, m.ctl.Provider.EC2(), m.ctl.Provider.ELB(), m.ctl.Provider.ELBV2(), cs, m.cfg); err != nil {
+		return err
+	}
+
+	tasks, err := m.stackManager.WithContext(ctx).NewTasksToDeleteClusterWithNodeGroups(opts.Wait, func(errs chan error, _ string) error {
+		logger.Info("trying to cleanup dangling network interfaces")
+		if err := m.ctl.GetClusterVPC(m.cfg); err != nil {
+			return errors.Wrapf(err, "getting VPC configuration for cluster %q", meta.Name)
+		}
+
+		go func() {
+			errs <- vpc.CleanupNetworkInterfaces(m.ctl.Provider.EC2(), m.cfg)
+			close(errs)
+		}()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if tasks.Len() == 0 {
+		logger.Warning("no cluster resources were found for %q", meta.Name)
+		return m.maybeDeleteDependents(opts, issuerURL)
+	}
+
+	logger.Info("%s", tasks.Describe())
+	if errs := tasks.DoAllSync(); len(errs) > 0 {
+		return errFailedToDelete(errs, "cluster with nodegroup(s)")
+	}
+
+	if err := m.maybeDeleteDependents(opts, issuerURL); err != nil {
+		return err
+	}
+
+	logger.Success("all cluster resources were deleted")
+	return nil
+}
+
+// maybeDeleteDependents deletes the resources eksctl creates for a cluster outside of its stacks,
+// which are the control plane log group and the IAM OIDC provider of the issuer of the cluster
+func (m *Manager) maybeDeleteDependents(opts DeleteClusterOptions, issuerURL string) error {
+	if !opts.DeleteAllDependents {
+		logger.Debug("keeping log group %q and IAM OIDC provider", eks.ClusterLogGroupName(m.cfg.Metadata))
+		return nil
+	}
+	if err := m.ctl.DeleteClusterLogGroup(m.cfg.Metadata); err != nil {
+		return err
+	}
+	if issuerURL == "" {
+		logger.Debug("the OIDC issuer of cluster %q is unknown, not looking for its IAM OIDC provider", m.cfg.Metadata.Name)
+		return nil
+	}
+	_, err := iam.DisassociateOIDCProvider(m.ctl.Provider, issuerURL, false)
+	return err
+}
+
+// oidcIssuerURL returns the OIDC issuer of the cluster from the outputs of its stack, it's
+// empty when there is no stack or the stack predates the output
+func (m *Manager) oidcIssuerURL() (string, error) {
+	stack, err := m.stackManager.DescribeClusterStack()
+	if err != nil {
+		if eksctlerrors.ClassOf(err) == eksctlerrors.ClassNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+	if err := eks.SetClusterStatusFromStack(m.cfg, stack); err != nil {
+		return "", err
+	}
+	return m.cfg.Status.OIDCIssuerURL, nil
+}
+
+func (m *Manager) checkDeletionProtection(disableProtection bool) error {
+	protected, err := m.stackManager.ListProtectedStacks()
+	if err != nil {
+		return err
+	}
+	if len(protected) == 0 {
+		return nil
+	}
+	if !disableProtection {
+		return NewErrDeletionProtected(m.cfg.Metadata.Name, len(protected))
+	}
+	for _, s := range protected {
+		if err := m.stackManager.DisableTerminationProtection(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manager) deleteDeprecatedStacks(ctx context.Context) (bool, error) {
+	tasks, err := m.stackManager.WithContext(ctx).DeleteTasksForDeprecatedStacks()
+	if err != nil {
+		return true, err
+	}
+	if count := tasks.Len(); count > 0 {
+		logger.Info("%s", tasks.Describe())
+		if errs := tasks.DoAllSync(); len(errs) > 0 {
+			return true, errFailedToDelete(errs, "deprecated stacks")
+		}
+		logger.Success("deleted all %d deprecated stacks", count)
+		return true, nil
+	}
+	return false, nil
+}
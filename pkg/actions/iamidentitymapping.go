@@ -0,0 +1,42 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// GetIAMIdentityMappings returns the IAM roles mapped in the aws-auth ConfigMap,
+// or only the mappings of the given role, when it's not empty
+func (m *Manager) GetIAMIdentityMappings(ctx context.Context, role string) (authconfigmap.MapRoles, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := m.ctl.GetCredentials(m.cfg); err != nil {
+		return nil, errors.Wrapf(err, "getting credentials for cluster %q", m.cfg.Metadata.Name)
+	}
+	clientSet, err := m.ctl.NewStdClientSet(m.cfg)
+	if err != nil {
+		return nil, err
+	}
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := acm.Roles()
+	if err != nil {
+		return nil, err
+	}
+	if role != "" {
+		roles = roles.Get(role)
+		// If a filter was given, we error if none was found
+		if len(roles) == 0 {
+			return nil, eksctlerrors.NewNotFound("no iamidentitymapping with role %q found", role)
+		}
+	}
+	return roles, nil
+}
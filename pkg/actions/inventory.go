@@ -0,0 +1,214 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// cloudFormationStackNameTag is set by CloudFormation on the resources of a stack
+const cloudFormationStackNameTag = "aws:cloudformation:stack-name"
+
+// InventoryResource is an AWS resource that eksctl created for a cluster
+type InventoryResource struct {
+	// Type is the service and the type of the resource, e.g. ec2:vpc or iam:role
+	Type string `json:"type"`
+	// ID is the ARN of the resource, or its name for resources that are looked up by name
+	ID string `json:"id"`
+	// Stack is the CloudFormation stack the resource belongs to, if any
+	Stack string `json:"stack,omitempty"`
+	// Orphan is set when the resource outlived its stack or the cluster
+	Orphan bool `json:"orphan"`
+	// Reason explains why the resource is an orphan
+	Reason string `json:"reason,omitempty"`
+}
+
+// inventory collects the resources of a cluster, keyed by ID
+type inventory struct {
+	resources map[string]*InventoryResource
+	// stacks are the stacks of the cluster that haven't been deleted
+	stacks map[string]*manager.Stack
+	// clusterExists is false when EKS no longer knows the cluster
+	clusterExists bool
+}
+
+// GetInventory returns the resources eksctl created for the cluster, sorted by type and ID: the stacks
+// of the cluster, the resources tagged with the name of the cluster, which the Resource Groups Tagging API
+// finds for most services, the IAM resources of the stacks, which it doesn't find, and the log group of
+// the control plane logs. Resources whose stack or cluster no longer exists are flagged as orphans
+func (m *Manager) GetInventory(ctx context.Context) ([]*InventoryResource, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	meta := m.cfg.Metadata
+
+	inv := &inventory{
+		resources:     map[string]*InventoryResource{},
+		stacks:        map[string]*manager.Stack{},
+		clusterExists: true,
+	}
+
+	if _, err := m.ctl.DescribeControlPlane(meta); err != nil {
+		if eksctlerrors.ClassOf(err) != eksctlerrors.ClassNotFound {
+			return nil, err
+		}
+		inv.clusterExists = false
+	}
+
+	stacks, err := m.stackManager.DescribeStacks()
+	if err != nil && eksctlerrors.ClassOf(err) != eksctlerrors.ClassNotFound {
+		return nil, err
+	}
+	for _, s := range stacks {
+		inv.stacks[*s.StackName] = s
+		inv.addStack(s)
+	}
+
+	for _, key := range []string{api.ResourceOwnerTag, api.ClusterNameTag} {
+		input := &resourcegroupstaggingapi.GetResourcesInput{
+			TagFilters: []*resourcegroupstaggingapi.TagFilter{{
+				Key:    aws.String(key),
+				Values: aws.StringSlice([]string{meta.Name}),
+			}},
+		}
+		pager := func(p *resourcegroupstaggingapi.GetResourcesOutput, _ bool) bool {
+			for _, r := range p.ResourceTagMappingList {
+				inv.addTaggedResource(r)
+			}
+			return true
+		}
+		if err := m.ctl.Provider.ResourceGroupsTagging().GetResourcesPagesWithContext(ctx, input, pager); err != nil {
+			return nil, errors.Wrapf(err, "listing resources tagged with %s=%s", key, meta.Name)
+		}
+	}
+
+	for _, s := range stacks {
+		output, err := m.ctl.Provider.CloudFormation().DescribeStackResourcesWithContext(ctx, &cfn.DescribeStackResourcesInput{
+			StackName: s.StackName,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting all resources for %q stack", *s.StackName)
+		}
+		for _, r := range output.StackResources {
+			inv.addStackResource(r)
+		}
+	}
+
+	logGroup := eks.ClusterLogGroupName(meta)
+	output, err := m.ctl.Provider.CloudWatchLogs().DescribeLogGroupsWithContext(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupNamePrefix: aws.String(logGroup),
+	})
+	if err != nil {
+		logger.Warning("unable to look up log group %q: %s", logGroup, err.Error())
+	} else {
+		for _, g := range output.LogGroups {
+			if aws.StringValue(g.LogGroupName) == logGroup {
+				inv.add(&InventoryResource{Type: "logs:log-group", ID: logGroup})
+			}
+		}
+	}
+
+	return inv.list(), nil
+}
+
+// add adds r, unless a resource with the same ID was already added,
+// and flags it as an orphan when it outlived its stack or the cluster
+func (inv *inventory) add(r *InventoryResource) {
+	if _, ok := inv.resources[r.ID]; ok {
+		return
+	}
+	switch {
+	case r.Stack != "" && inv.stacks[r.Stack] == nil:
+		r.Orphan, r.Reason = true, fmt.Sprintf("stack %q no longer exists", r.Stack)
+	case r.Stack != "" && aws.StringValue(inv.stacks[r.Stack].StackStatus) == cfn.StackStatusDeleteFailed:
+		r.Orphan, r.Reason = true, fmt.Sprintf("deletion of stack %q failed", r.Stack)
+	case !inv.clusterExists:
+		r.Orphan, r.Reason = true, "cluster no longer exists"
+	}
+	inv.resources[r.ID] = r
+}
+
+func (inv *inventory) addStack(s *manager.Stack) {
+	inv.add(&InventoryResource{
+		Type:  "cloudformation:stack",
+		ID:    aws.StringValue(s.StackId),
+		Stack: *s.StackName,
+	})
+}
+
+func (inv *inventory) addTaggedResource(r *resourcegroupstaggingapi.ResourceTagMapping) {
+	resource := &InventoryResource{
+		Type: arnResourceType(aws.StringValue(r.ResourceARN)),
+		ID:   aws.StringValue(r.ResourceARN),
+	}
+	for _, tag := range r.Tags {
+		if aws.StringValue(tag.Key) == cloudFormationStackNameTag {
+			resource.Stack = aws.StringValue(tag.Value)
+		}
+	}
+	if parts := strings.Split(resource.ID, "/"); resource.Type == "cloudformation:stack" && len(parts) > 1 {
+		// stacks don't have the tag of their own name, it's part of their ARN
+		resource.Stack = parts[1]
+	}
+	inv.add(resource)
+}
+
+// addStackResource adds the IAM resources of a stack, as the Resource Groups
+// Tagging API doesn't find them, other resources are found by their tags
+func (inv *inventory) addStackResource(r *cfn.StackResource) {
+	types := map[string]string{
+		"AWS::IAM::Role":            "iam:role",
+		"AWS::IAM::InstanceProfile": "iam:instance-profile",
+		"AWS::IAM::ManagedPolicy":   "iam:policy",
+	}
+	t, ok := types[aws.StringValue(r.ResourceType)]
+	if !ok || r.PhysicalResourceId == nil {
+		return
+	}
+	inv.add(&InventoryResource{
+		Type:  t,
+		ID:    *r.PhysicalResourceId,
+		Stack: aws.StringValue(r.StackName),
+	})
+}
+
+func (inv *inventory) list() []*InventoryResource {
+	resources := []*InventoryResource{}
+	for _, r := range inv.resources {
+		resources = append(resources, r)
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].ID < resources[j].ID
+	})
+	return resources
+}
+
+// arnResourceType returns the service and the type of the resource of an ARN, i.e.
+// arn:aws:ec2:us-west-2:123456789012:vpc/vpc-1 is an ec2:vpc resource
+func arnResourceType(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) < 6 {
+		return "unknown"
+	}
+	service, resource := parts[2], parts[5]
+	if i := strings.IndexAny(resource, "/:"); i >= 0 {
+		resource = resource[:i]
+	}
+	return service + ":" + resource
+}
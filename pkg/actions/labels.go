@@ -0,0 +1,165 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// UpdateLabelsOptions holds options for UpdateLabels
+type UpdateLabelsOptions struct {
+	// NodeGroup is the name of the nodegroup to label
+	NodeGroup string
+	// Set are the labels to add or change
+	Set map[string]string
+	// Unset are the keys of the labels to remove
+	Unset []string
+	// Plan only returns the changes, without applying them
+	Plan bool
+}
+
+// LabelChange is a change of a label of a nodegroup
+type LabelChange struct {
+	Key string
+	// Old is empty when the label is added
+	Old string
+	// New is empty when the label is removed
+	New string
+}
+
+func (c LabelChange) String() string {
+	switch {
+	case c.Old == "":
+		return fmt.Sprintf("add %s=%s", c.Key, c.New)
+	case c.New == "":
+		return fmt.Sprintf("remove %s=%s", c.Key, c.Old)
+	default:
+		return fmt.Sprintf("change %s from %s to %s", c.Key, c.Old, c.New)
+	}
+}
+
+// GetLabels returns the labels of the nodes of a nodegroup
+func (m *Manager) GetLabels(ctx context.Context, nodeGroup string) (map[string]string, error) {
+	if nodeGroup == "" {
+		return nil, fmt.Errorf("nodegroup name must be set")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	labels, err := m.stackManager.GetNodeGroupLabels(nodeGroup)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting labels of nodegroup %q", nodeGroup)
+	}
+	return labels, nil
+}
+
+// UpdateLabels sets and removes labels of a nodegroup, in the user data of its stack for new nodes,
+// and on its existing nodes; it returns the changes to the labels of the nodegroup
+func (m *Manager) UpdateLabels(ctx context.Context, opts UpdateLabelsOptions) ([]LabelChange, error) {
+	for key := range opts.Set {
+		if isReservedLabel(key) {
+			return nil, fmt.Errorf("label %q is set by eksctl and cannot be changed", key)
+		}
+	}
+	for _, key := range opts.Unset {
+		if isReservedLabel(key) {
+			return nil, fmt.Errorf("label %q is set by eksctl and cannot be removed", key)
+		}
+	}
+
+	current, err := m.GetLabels(ctx, opts.NodeGroup)
+	if err != nil {
+		return nil, err
+	}
+	changes := PlanLabelChanges(current, opts.Set, opts.Unset)
+	if len(changes) == 0 || opts.Plan {
+		return changes, nil
+	}
+
+	labels := map[string]string{}
+	for k, v := range current {
+		labels[k] = v
+	}
+	for _, c := range changes {
+		if c.New == "" {
+			delete(labels, c.Key)
+		} else {
+			labels[c.Key] = c.New
+		}
+	}
+	ng := &api.NodeGroup{Name: opts.NodeGroup, Labels: labels}
+	if err := api.ValidateNodeGroupLabels(ng); err != nil {
+		return nil, err
+	}
+
+	if err := m.stackManager.WithContext(ctx).UpdateNodeGroupLabels(opts.NodeGroup, labels); err != nil {
+		return nil, errors.Wrapf(err, "updating labels of nodegroup %q", opts.NodeGroup)
+	}
+
+	if err := m.ctl.GetCredentials(m.cfg); err != nil {
+		return nil, errors.Wrapf(err, "getting credentials for cluster %q", m.cfg.Metadata.Name)
+	}
+	clientSet, err := m.ctl.NewStdClientSet(m.cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := labelNodes(clientSet, ng, changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// PlanLabelChanges returns the changes needed to set and remove the labels, sorted by key
+func PlanLabelChanges(current, set map[string]string, unset []string) []LabelChange {
+	changes := []LabelChange{}
+	for k, v := range set {
+		if old, ok := current[k]; !ok || old != v {
+			changes = append(changes, LabelChange{Key: k, Old: old, New: v})
+		}
+	}
+	for _, k := range unset {
+		if old, ok := current[k]; ok {
+			changes = append(changes, LabelChange{Key: k, Old: old})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+// labelNodes applies the changes to the nodes of the nodegroup
+func labelNodes(clientSet kubernetes.Interface, ng *api.NodeGroup, changes []LabelChange) error {
+	nodes, err := clientSet.CoreV1().Nodes().List(ng.ListOptions())
+	if err != nil {
+		return errors.Wrapf(err, "listing nodes of nodegroup %q", ng.Name)
+	}
+	for _, node := range nodes.Items {
+		node := node
+		if node.Labels == nil {
+			node.Labels = map[string]string{}
+		}
+		for _, c := range changes {
+			if c.New == "" {
+				delete(node.Labels, c.Key)
+			} else {
+				node.Labels[c.Key] = c.New
+			}
+		}
+		if _, err := clientSet.CoreV1().Nodes().Update(&node); err != nil {
+			return errors.Wrapf(err, "updating labels of node %q", node.Name)
+		}
+	}
+	logger.Info("updated labels of %d node(s) of nodegroup %q", len(nodes.Items), ng.Name)
+	return nil
+}
+
+func isReservedLabel(key string) bool {
+	return strings.HasPrefix(key, "alpha.eksctl.io/")
+}
@@ -0,0 +1,115 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// UpdateClusterLoggingOptions holds options for control plane logging updates
+type UpdateClusterLoggingOptions struct {
+	// Enable and Disable are the log types to enable and to disable, "*" and "all" stand
+	// for all of them; types that aren't in either are left as they are
+	Enable, Disable []string
+	// Plan only reports the change, without applying it
+	Plan bool
+}
+
+// ClusterLoggingChange is a change to the types of control plane logs of a cluster
+type ClusterLoggingChange struct {
+	// Before and After are the types enabled before and after the change
+	Before, After []string
+	// Enable and Disable are the types the change enables and disables,
+	// both are empty when the cluster already has the requested types
+	Enable, Disable []string
+}
+
+// Required returns true when the change enables or disables any type of logs
+func (c *ClusterLoggingChange) Required() bool {
+	return len(c.Enable) > 0 || len(c.Disable) > 0
+}
+
+// String describes the change, e.g. for logs
+func (c *ClusterLoggingChange) String() string {
+	describe := func(types []string) string {
+		if len(types) == 0 {
+			return "none"
+		}
+		return strings.Join(types, ", ")
+	}
+	return fmt.Sprintf("enable %s, disable %s (enabled: %s)", describe(c.Enable), describe(c.Disable), describe(c.After))
+}
+
+// UpdateClusterLogging enables and disables types of control plane logs, then records
+// the change in the audit trail of the cluster; failing to record the change doesn't fail
+// the update, as logs are already updated by then
+func (m *Manager) UpdateClusterLogging(ctx context.Context, opts UpdateClusterLoggingOptions) (*ClusterLoggingChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	enable, err := expandLogTypes("enable", opts.Enable)
+	if err != nil {
+		return nil, err
+	}
+	disable, err := expandLogTypes("disable", opts.Disable)
+	if err != nil {
+		return nil, err
+	}
+	if both := enable.Intersection(disable); both.Len() > 0 {
+		return nil, eksctlerrors.NewValidationError("log types %s cannot be enabled and disabled at the same time", strings.Join(both.List(), ", "))
+	}
+
+	cluster, err := m.ctl.DescribeControlPlane(m.cfg.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	current := eks.EnabledLogTypes(cluster)
+	desired := sets.NewString(current...).Union(enable).Difference(disable).List()
+
+	change := &ClusterLoggingChange{Before: current, After: desired}
+	change.Enable, change.Disable = eks.ClusterLogTypeChanges(current, desired)
+	if !change.Required() || opts.Plan {
+		return change, nil
+	}
+
+	if err := m.ctl.UpdateClusterLogTypesBlocking(m.cfg.Metadata, change.Enable, change.Disable); err != nil {
+		return nil, err
+	}
+	if err := m.ctl.RecordClusterLoggingChange(m.cfg, change.Before, change.After); err != nil {
+		logger.Warning("unable to record the change in the audit trail of cluster %q: %s", m.cfg.Metadata.Name, err.Error())
+	}
+	return change, nil
+}
+
+// ClusterLoggingHistory returns the changes to control plane logging recorded in the
+// audit trail of the cluster, oldest first
+func (m *Manager) ClusterLoggingHistory(ctx context.Context) ([]*eks.LoggingAuditRecord, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.ctl.ClusterLoggingHistory(m.cfg)
+}
+
+func expandLogTypes(verb string, types []string) (sets.String, error) {
+	supported := api.SupportedCloudWatchClusterLogTypes()
+	expanded := sets.NewString()
+	for _, t := range types {
+		switch {
+		case t == "*" || t == "all":
+			expanded.Insert(supported...)
+		case sets.NewString(supported...).Has(t):
+			expanded.Insert(t)
+		default:
+			return nil, eksctlerrors.NewValidationError("log type %q cannot be %sd, supported values: %s", t, verb, strings.Join(append(supported, "*", "all"), ", "))
+		}
+	}
+	return expanded, nil
+}
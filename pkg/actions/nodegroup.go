@@ -0,0 +1,194 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+)
+
+// ScaleNodeGroupOptions holds options for ScaleNodeGroup
+type ScaleNodeGroupOptions struct {
+	// Name of the nodegroup to scale
+	Name string
+	// DesiredCapacity is the total number of nodes to scale to
+	DesiredCapacity int
+}
+
+// GetNodeGroups returns summaries of all nodegroups in the cluster,
+// or only of the nodegroup with the given name, when it's not empty
+func (m *Manager) GetNodeGroups(ctx context.Context, name string) ([]*manager.NodeGroupSummary, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	summaries, err := m.stackManager.GetNodeGroupSummaries(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting nodegroup stack summaries")
+	}
+	return summaries, nil
+}
+
+// NodeGroupInstance is an instance of a nodegroup, along with the node it registered as
+type NodeGroupInstance struct {
+	InstanceID string
+	State      string
+	PrivateIP  string `json:",omitempty"`
+	// NodeName, KubeletVersion and Ready are only set once the instance has registered
+	NodeName       string `json:",omitempty"`
+	KubeletVersion string `json:",omitempty"`
+	// Ready is the status of the Ready condition of the node: True, False or Unknown
+	Ready string `json:",omitempty"`
+}
+
+// NodeGroupWithInstances is the summary of a nodegroup, along with its instances
+type NodeGroupWithInstances struct {
+	*manager.NodeGroupSummary
+	Instances []*NodeGroupInstance `json:",omitempty"`
+	// SuspendedProcesses are the suspended processes of the ASG of the nodegroup
+	SuspendedProcesses []string `json:",omitempty"`
+}
+
+// GetNodeGroupInstances returns the instances of the given nodegroups, joined with the
+// nodes they registered as
+func (m *Manager) GetNodeGroupInstances(ctx context.Context, summaries []*manager.NodeGroupSummary) ([]*NodeGroupWithInstances, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	stacks, err := m.stackManager.DescribeNodeGroupStacksAndResources()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting nodegroup stacks")
+	}
+
+	if err := m.ctl.GetCredentials(m.cfg); err != nil {
+		return nil, errors.Wrapf(err, "getting credentials for cluster %q", m.cfg.Metadata.Name)
+	}
+	clientSet, err := m.ctl.NewStdClientSet(m.cfg)
+	if err != nil {
+		return nil, err
+	}
+	nodeList, err := clientSet.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing nodes")
+	}
+	nodes := map[string]*corev1.Node{}
+	for i := range nodeList.Items {
+		node := &nodeList.Items[i]
+		nodes[instanceIDFromProviderID(node.Spec.ProviderID)] = node
+	}
+
+	result := []*NodeGroupWithInstances{}
+	for _, s := range summaries {
+		ng := &NodeGroupWithInstances{NodeGroupSummary: s}
+		if asgName := autoScalingGroupName(stacks[s.Name]); asgName != "" {
+			if ng.Instances, err = m.nodeGroupInstances(ctx, asgName, nodes); err != nil {
+				return nil, errors.Wrapf(err, "getting instances of nodegroup %q", s.Name)
+			}
+		}
+		result = append(result, ng)
+	}
+	return result, nil
+}
+
+// nodeGroupInstances returns the instances of the ASG, sorted by ID, joined with their nodes
+func (m *Manager) nodeGroupInstances(ctx context.Context, asgName string, nodes map[string]*corev1.Node) ([]*NodeGroupInstance, error) {
+	groups, err := m.ctl.Provider.ASG().DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(asgName)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing auto scaling group %q", asgName)
+	}
+	if len(groups.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("auto scaling group %q not found", asgName)
+	}
+	ids := []*string{}
+	for _, i := range groups.AutoScalingGroups[0].Instances {
+		ids = append(ids, i.InstanceId)
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	output, err := m.ctl.Provider.EC2().DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing instances")
+	}
+	instances := []*NodeGroupInstance{}
+	for _, reservation := range output.Reservations {
+		for _, i := range reservation.Instances {
+			instance := &NodeGroupInstance{
+				InstanceID: aws.StringValue(i.InstanceId),
+				PrivateIP:  aws.StringValue(i.PrivateIpAddress),
+			}
+			if i.State != nil {
+				instance.State = aws.StringValue(i.State.Name)
+			}
+			if node, ok := nodes[instance.InstanceID]; ok {
+				instance.NodeName = node.Name
+				instance.KubeletVersion = node.Status.NodeInfo.KubeletVersion
+				instance.Ready = string(corev1.ConditionUnknown)
+				for _, c := range node.Status.Conditions {
+					if c.Type == corev1.NodeReady {
+						instance.Ready = string(c.Status)
+					}
+				}
+			}
+			instances = append(instances, instance)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool { return instances[i].InstanceID < instances[j].InstanceID })
+	return instances, nil
+}
+
+// ScaleNodeGroup sets desired capacity of a nodegroup
+func (m *Manager) ScaleNodeGroup(ctx context.Context, opts ScaleNodeGroupOptions) error {
+	ng, err := m.nodeGroupToScale(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	if err := m.stackManager.WithContext(ctx).ScaleNodeGroup(ng); err != nil {
+		return fmt.Errorf("failed to scale nodegroup for cluster %q, error %v", m.cfg.Metadata.Name, err)
+	}
+	return nil
+}
+
+// SubmitNodeGroupScale sets desired capacity of a nodegroup without waiting for the update of
+// its stack, which is returned, or nil when the nodegroup already has the desired capacity
+func (m *Manager) SubmitNodeGroupScale(ctx context.Context, opts ScaleNodeGroupOptions) (*manager.Stack, error) {
+	ng, err := m.nodeGroupToScale(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	stack, err := m.stackManager.WithContext(ctx).SubmitNodeGroupScale(ng)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scale nodegroup for cluster %q, error %v", m.cfg.Metadata.Name, err)
+	}
+	return stack, nil
+}
+
+func (m *Manager) nodeGroupToScale(ctx context.Context, opts ScaleNodeGroupOptions) (*api.NodeGroup, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("nodegroup name must be set")
+	}
+	if opts.DesiredCapacity < 0 {
+		return nil, fmt.Errorf("number of nodes must be 0 or greater")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	ng := m.cfg.NewNodeGroup()
+	ng.Name = opts.Name
+	ng.DesiredCapacity = &opts.DesiredCapacity
+	return ng, nil
+}
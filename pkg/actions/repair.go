@@ -0,0 +1,99 @@
+package actions
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// RepairStackOptions holds options for the repair of stacks whose update rollback failed
+type RepairStackOptions struct {
+	// StackName is the stack to repair, all stacks of the cluster in UPDATE_ROLLBACK_FAILED
+	// are repaired when it's empty
+	StackName string
+	// ResourcesToSkip are the logical IDs of the resources the rollback skips
+	ResourcesToSkip []string
+	// SkipFailedResources skips all resources that couldn't be rolled back
+	SkipFailedResources bool
+	// Plan only reports the repairs, without applying them
+	Plan bool
+}
+
+// StackRepair is the repair of a stack whose update rollback failed
+type StackRepair struct {
+	// StackName is the stack that is repaired
+	StackName string `json:"stackName"`
+	// FailedResources are the resources that couldn't be rolled back
+	FailedResources []string `json:"failedResources"`
+	// SkippedResources are the resources the rollback skips
+	SkippedResources []string `json:"skippedResources"`
+}
+
+// RepairStacks continues the rollback of the stacks of the cluster that are in UPDATE_ROLLBACK_FAILED,
+// so that they can be updated or deleted again; resources that can't be rolled back, e.g. as they
+// were changed or deleted by hand, have to be skipped for the rollback to complete
+func (m *Manager) RepairStacks(ctx context.Context, opts RepairStackOptions) ([]*StackRepair, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if opts.SkipFailedResources && len(opts.ResourcesToSkip) > 0 {
+		return nil, eksctlerrors.NewValidationError("resources to skip cannot be set when all failed resources are skipped")
+	}
+
+	stacks, err := m.stackManager.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+	stuck := []*manager.Stack{}
+	for _, s := range stacks {
+		if opts.StackName != "" && *s.StackName != opts.StackName {
+			continue
+		}
+		if status := aws.StringValue(s.StackStatus); status != cfn.StackStatusUpdateRollbackFailed {
+			if opts.StackName != "" {
+				return nil, eksctlerrors.NewValidationError("stack %q has status %s, only stacks in %s can be repaired", opts.StackName, status, cfn.StackStatusUpdateRollbackFailed)
+			}
+			continue
+		}
+		stuck = append(stuck, s)
+	}
+	if opts.StackName != "" && len(stuck) == 0 {
+		return nil, eksctlerrors.NewNotFound("stack %q is not a stack of cluster %q", opts.StackName, m.cfg.Metadata.Name)
+	}
+
+	repairs := []*StackRepair{}
+	for _, s := range stuck {
+		failed, err := m.stackManager.FailedStackResources(s)
+		if err != nil {
+			return nil, err
+		}
+		repair := &StackRepair{
+			StackName:        *s.StackName,
+			FailedResources:  failed,
+			SkippedResources: opts.ResourcesToSkip,
+		}
+		if opts.SkipFailedResources {
+			repair.SkippedResources = failed
+		}
+		if repair.SkippedResources == nil {
+			repair.SkippedResources = []string{}
+		}
+		repairs = append(repairs, repair)
+
+		if opts.Plan {
+			continue
+		}
+		if len(repair.SkippedResources) > 0 {
+			logger.Warning("resources %v of stack %q won't be rolled back, they may have to be fixed by hand", repair.SkippedResources, repair.StackName)
+		}
+		if err := m.stackManager.WithContext(ctx).ContinueUpdateRollback(s, repair.SkippedResources); err != nil {
+			return nil, err
+		}
+	}
+	return repairs, nil
+}
@@ -0,0 +1,292 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/drain"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/health"
+	"github.com/weaveworks/eksctl/pkg/utils/waiters"
+)
+
+// RotateNodeAMIOptions holds options for RotateNodeAMI
+type RotateNodeAMIOptions struct {
+	// NodeGroup is the name of the nodegroup to rotate, all nodegroups are rotated when it's empty
+	NodeGroup string
+	// MaxUnavailable is the number of instances that are replaced at once
+	MaxUnavailable int
+	// Plan only reports which nodegroups lag the latest AMI, without changing them
+	Plan bool
+}
+
+// NodeGroupRotation describes the AMI refresh of a nodegroup
+type NodeGroupRotation struct {
+	NodeGroup  string
+	CurrentAMI string
+	LatestAMI  string
+	// Replaced is the number of instances that were replaced
+	Replaced int
+}
+
+// RotateNodeAMI moves nodegroups whose AMI lags the latest EKS-optimized AMI to
+// the latest one: the launch template of the nodegroup stack is updated, then the
+// instances running another AMI are drained and terminated, MaxUnavailable at a
+// time, each batch waits for the replacement nodes to become ready; evictions
+// honour PodDisruptionBudgets, nodegroups with custom AMIs are skipped
+func (m *Manager) RotateNodeAMI(ctx context.Context, opts RotateNodeAMIOptions) ([]*NodeGroupRotation, error) {
+	if opts.MaxUnavailable < 1 {
+		return nil, eksctlerrors.NewValidationError("max unavailable must be 1 or greater")
+	}
+
+	cluster, err := m.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusterVersion := aws.StringValue(cluster.Version)
+
+	summaries, err := m.GetNodeGroups(ctx, opts.NodeGroup)
+	if err != nil {
+		return nil, err
+	}
+	if opts.NodeGroup != "" && len(summaries) == 0 {
+		return nil, eksctlerrors.NewNotFound("nodegroup %q not found in cluster %q", opts.NodeGroup, m.cfg.Metadata.Name)
+	}
+
+	rotations := []*NodeGroupRotation{}
+	for _, ng := range summaries {
+		latest, err := m.latestNodeGroupAMI(clusterVersion, ng.ImageID, ng.InstanceType)
+		if err != nil {
+			return nil, errors.Wrapf(err, "checking AMI of nodegroup %q", ng.Name)
+		}
+		switch latest {
+		case "":
+			logger.Info("nodegroup %q uses custom AMI %q, it won't be rotated", ng.Name, ng.ImageID)
+			continue
+		case ng.ImageID:
+			logger.Info("nodegroup %q already uses the latest AMI %q", ng.Name, latest)
+			continue
+		}
+		rotations = append(rotations, &NodeGroupRotation{
+			NodeGroup:  ng.Name,
+			CurrentAMI: ng.ImageID,
+			LatestAMI:  latest,
+		})
+	}
+
+	if opts.Plan {
+		for _, r := range rotations {
+			logger.Info("(plan) would rotate nodegroup %q from AMI %q to %q", r.NodeGroup, r.CurrentAMI, r.LatestAMI)
+		}
+		return rotations, nil
+	}
+	if len(rotations) == 0 {
+		return rotations, nil
+	}
+
+	if err := m.ctl.GetCredentials(m.cfg); err != nil {
+		return nil, errors.Wrapf(err, "getting credentials for cluster %q", m.cfg.Metadata.Name)
+	}
+	clientSet, err := m.ctl.NewStdClientSet(m.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	stacks, err := m.stackManager.DescribeNodeGroupStacksAndResources()
+	if err != nil {
+		return nil, errors.Wrap(err, "getting nodegroup stacks")
+	}
+
+	for _, r := range rotations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := m.stackManager.WithContext(ctx).UpdateNodeGroupImage(r.NodeGroup, r.LatestAMI); err != nil {
+			return nil, errors.Wrapf(err, "updating AMI of nodegroup %q", r.NodeGroup)
+		}
+		asgName := autoScalingGroupName(stacks[r.NodeGroup])
+		if asgName == "" {
+			return nil, fmt.Errorf("auto scaling group of nodegroup %q not found", r.NodeGroup)
+		}
+		if r.Replaced, err = m.replaceInstances(ctx, clientSet, r, asgName, opts.MaxUnavailable); err != nil {
+			return nil, errors.Wrapf(err, "replacing instances of nodegroup %q", r.NodeGroup)
+		}
+		logger.Success("rotated %d instance(s) of nodegroup %q to AMI %q", r.Replaced, r.NodeGroup, r.LatestAMI)
+	}
+	return rotations, nil
+}
+
+func autoScalingGroupName(info manager.StackInfo) string {
+	for _, r := range info.Resources {
+		if aws.StringValue(r.LogicalResourceId) == health.AutoScalingGroupResource {
+			return aws.StringValue(r.PhysicalResourceId)
+		}
+	}
+	return ""
+}
+
+// replaceInstances drains and terminates the instances of the ASG that don't run the
+// latest AMI, in batches of maxUnavailable, the ASG launches their replacements
+func (m *Manager) replaceInstances(ctx context.Context, clientSet kubernetes.Interface, r *NodeGroupRotation, asgName string, maxUnavailable int) (int, error) {
+	outdated, err := m.outdatedInstances(ctx, asgName, r.LatestAMI)
+	if err != nil {
+		return 0, err
+	}
+	ng := &api.NodeGroup{Name: r.NodeGroup}
+	batches := instanceBatches(outdated, maxUnavailable)
+	replaced := sets.NewString()
+	for i, batch := range batches {
+		if err := ctx.Err(); err != nil {
+			return replaced.Len(), err
+		}
+		logger.Info("nodegroup %q: replacing batch %d/%d, instance(s) %s", r.NodeGroup, i+1, len(batches), strings.Join(batch, ", "))
+
+		nodes, err := clientSet.CoreV1().Nodes().List(ng.ListOptions())
+		if err != nil {
+			return replaced.Len(), errors.Wrap(err, "listing nodes")
+		}
+		nodeNames := []string{}
+		for _, node := range nodes.Items {
+			if sets.NewString(batch...).Has(instanceIDFromProviderID(node.Spec.ProviderID)) {
+				nodeNames = append(nodeNames, node.Name)
+			}
+		}
+		if err := drain.Nodes(clientSet, nodeNames, waiters.PollInterval(m.ctl.Provider.PollInterval()), m.ctl.Provider.WaitTimeout()); err != nil {
+			return replaced.Len(), err
+		}
+
+		for _, id := range batch {
+			_, err := m.ctl.Provider.ASG().TerminateInstanceInAutoScalingGroupWithContext(ctx, &autoscaling.TerminateInstanceInAutoScalingGroupInput{
+				InstanceId:                     aws.String(id),
+				ShouldDecrementDesiredCapacity: aws.Bool(false),
+			})
+			if err != nil {
+				return replaced.Len(), errors.Wrapf(err, "terminating instance %q", id)
+			}
+			replaced.Insert(id)
+		}
+
+		if err := m.waitForReplacements(ctx, clientSet, ng, asgName, replaced); err != nil {
+			return replaced.Len(), err
+		}
+		logger.Info("nodegroup %q: batch %d/%d done, %d/%d instance(s) replaced", r.NodeGroup, i+1, len(batches), replaced.Len(), len(outdated))
+	}
+	return replaced.Len(), nil
+}
+
+// outdatedInstances returns the IDs of the in-service instances of the ASG that
+// don't run the given AMI
+func (m *Manager) outdatedInstances(ctx context.Context, asgName, imageID string) ([]string, error) {
+	groups, err := m.ctl.Provider.ASG().DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(asgName)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing auto scaling group %q", asgName)
+	}
+	if len(groups.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("auto scaling group %q not found", asgName)
+	}
+	ids := []*string{}
+	for _, i := range groups.AutoScalingGroups[0].Instances {
+		if aws.StringValue(i.LifecycleState) == autoscaling.LifecycleStateInService {
+			ids = append(ids, i.InstanceId)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	instances, err := m.ctl.Provider.EC2().DescribeInstancesWithContext(ctx, &ec2.DescribeInstancesInput{InstanceIds: ids})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing instances")
+	}
+	outdated := []string{}
+	for _, reservation := range instances.Reservations {
+		for _, i := range reservation.Instances {
+			if aws.StringValue(i.ImageId) != imageID {
+				outdated = append(outdated, aws.StringValue(i.InstanceId))
+			}
+		}
+	}
+	return outdated, nil
+}
+
+// waitForReplacements waits until the ASG is back to its desired capacity with nodes
+// that are ready and weren't replaced
+func (m *Manager) waitForReplacements(ctx context.Context, clientSet kubernetes.Interface, ng *api.NodeGroup, asgName string, replaced sets.String) error {
+	timeout := m.ctl.Provider.WaitTimeout()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		groups, err := m.ctl.Provider.ASG().DescribeAutoScalingGroupsWithContext(ctx, &autoscaling.DescribeAutoScalingGroupsInput{
+			AutoScalingGroupNames: []*string{aws.String(asgName)},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "describing auto scaling group %q", asgName)
+		}
+		if len(groups.AutoScalingGroups) == 0 {
+			return fmt.Errorf("auto scaling group %q not found", asgName)
+		}
+		desired := int(aws.Int64Value(groups.AutoScalingGroups[0].DesiredCapacity))
+
+		nodes, err := clientSet.CoreV1().Nodes().List(ng.ListOptions())
+		if err != nil {
+			return errors.Wrap(err, "listing nodes")
+		}
+		ready := 0
+		for _, node := range nodes.Items {
+			if !replaced.Has(instanceIDFromProviderID(node.Spec.ProviderID)) && isNodeReady(&node) {
+				ready++
+			}
+		}
+		logger.Debug("nodegroup %q has %d/%d ready node(s)", ng.Name, ready, desired)
+		if ready >= desired {
+			return nil
+		}
+		if err := aws.SleepWithContext(ctx, waiters.PollInterval(m.ctl.Provider.PollInterval())); err != nil {
+			return err
+		}
+	}
+	return eksctlerrors.NewTimeout("timed out (after %s) waiting for replacement nodes to become ready in %q", timeout, ng.Name)
+}
+
+// instanceBatches splits the instances in batches of at most size instances
+func instanceBatches(ids []string, size int) [][]string {
+	batches := [][]string{}
+	for len(ids) > size {
+		batches = append(batches, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		batches = append(batches, ids)
+	}
+	return batches
+}
+
+func instanceIDFromProviderID(providerID string) string {
+	if !strings.HasPrefix(providerID, "aws://") {
+		return ""
+	}
+	return providerID[strings.LastIndex(providerID, "/")+1:]
+}
+
+func isNodeReady(node *corev1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
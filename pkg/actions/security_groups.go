@@ -0,0 +1,114 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// UpdateClusterSecurityGroupsOptions holds options for updates of control plane security groups
+type UpdateClusterSecurityGroupsOptions struct {
+	// Plan only reports the change, without applying it
+	Plan bool
+}
+
+// ClusterSecurityGroupsChange is a change to the security groups of the control plane
+type ClusterSecurityGroupsChange struct {
+	// Attach and Detach are the security groups the change attaches to and detaches
+	// from the control plane, both are empty when they are already attached
+	Attach, Detach []string
+	// Unsupported is true when EKS rejected the change, rules that allow HTTPS from the
+	// security groups are added to the control plane security group instead
+	Unsupported bool
+	// RulesUpdated is true when the extra control plane ingress rules of the cluster
+	// stack were updated
+	RulesUpdated bool
+}
+
+// Required returns true when the change attaches or detaches any security group, or updates rules
+func (c *ClusterSecurityGroupsChange) Required() bool {
+	return len(c.Attach) > 0 || len(c.Detach) > 0 || c.RulesUpdated
+}
+
+// String describes the change, e.g. for logs
+func (c *ClusterSecurityGroupsChange) String() string {
+	describe := func(ids []string) string {
+		if len(ids) == 0 {
+			return "none"
+		}
+		return strings.Join(ids, ", ")
+	}
+	s := fmt.Sprintf("attach %s, detach %s", describe(c.Attach), describe(c.Detach))
+	if c.Unsupported {
+		s += " (not supported by the cluster, added rules to the control plane security group instead)"
+	}
+	if c.RulesUpdated {
+		s += ", update control plane ingress rules"
+	}
+	return s
+}
+
+// UpdateClusterSecurityGroups reconciles the security groups attached to the control plane, i.e. the
+// control plane security group and vpc.extraControlPlaneSecurityGroups, and the extra control plane
+// ingress rules with the config; when EKS doesn't support changing the security groups of the cluster,
+// rules that allow HTTPS from the extra security groups are added to the control plane security group
+func (m *Manager) UpdateClusterSecurityGroups(ctx context.Context, opts UpdateClusterSecurityGroupsOptions) (*ClusterSecurityGroupsChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := api.ValidateControlPlaneIngressRules(m.cfg); err != nil {
+		return nil, eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	if err := m.ctl.GetClusterVPC(m.cfg); err != nil {
+		return nil, errors.Wrapf(err, "getting VPC configuration for cluster %q", m.cfg.Metadata.Name)
+	}
+
+	cluster, err := m.ctl.DescribeControlPlane(m.cfg.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := sets.NewString(m.cfg.VPC.SecurityGroup).Insert(m.cfg.VPC.ExtraControlPlaneSecurityGroups...).List()
+	change := &ClusterSecurityGroupsChange{}
+	change.Attach, change.Detach = eks.ControlPlaneSecurityGroupChanges(cluster, desired)
+
+	if (len(change.Attach) > 0 || len(change.Detach) > 0) && !opts.Plan {
+		err := m.ctl.UpdateClusterSecurityGroupsBlocking(m.cfg.Metadata, desired)
+		switch {
+		case eks.IsUnsupportedSecurityGroupsUpdate(err):
+			logger.Warning("security groups of cluster %q cannot be changed (%s), adding rules to the control plane security group instead", m.cfg.Metadata.Name, errors.Cause(err).Error())
+			change.Unsupported = true
+			m.addFallbackIngressRules()
+		case err != nil:
+			return nil, err
+		}
+	}
+
+	if change.RulesUpdated, err = m.stackManager.WithContext(ctx).UpdateControlPlaneIngressRules(opts.Plan); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// addFallbackIngressRules adds a rule that allows HTTPS from each of the extra control plane
+// security groups, which gives their members the access to the API server they'd have if they
+// were attached to the control plane
+func (m *Manager) addFallbackIngressRules() {
+	for _, id := range m.cfg.VPC.ExtraControlPlaneSecurityGroups {
+		m.cfg.VPC.ExtraControlPlaneIngressRules = append(m.cfg.VPC.ExtraControlPlaneIngressRules, &api.ControlPlaneIngressRule{
+			Description:           fmt.Sprintf("Allow HTTPS from %s, which can't be attached to the control plane", id),
+			FromPort:              443,
+			SourceSecurityGroupID: id,
+		})
+	}
+}
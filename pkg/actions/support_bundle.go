@@ -0,0 +1,173 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+)
+
+// SupportBundleOptions holds options for SupportBundle
+type SupportBundleOptions struct {
+	// Redact replaces AWS account IDs and the ARNs of resources of the account
+	Redact bool
+}
+
+var (
+	accountARNPattern = regexp.MustCompile(`arn:aws[a-z-]*:([a-z0-9-]+):[a-z0-9-]*:\d{12}:[^\s"',\\]+`)
+	accountIDPattern  = regexp.MustCompile(`\b\d{12}\b`)
+)
+
+// nodeStatus is the status of a node, as included in support bundles
+type nodeStatus struct {
+	Name           string
+	NodeGroup      string
+	ProviderID     string
+	KubeletVersion string
+	Conditions     []corev1.NodeCondition
+}
+
+// daemonSetStatus is the status of a daemonset, as included in support bundles
+type daemonSetStatus struct {
+	Name      string
+	Images    []string
+	Desired   int32
+	Ready     int32
+	Available int32
+	Updated   int32
+}
+
+// SupportBundle collects the description of the cluster, the templates and events of its
+// stacks, the aws-auth ConfigMap, the versions of the default add-ons and the status of the
+// nodes and kube-system daemonsets, keyed by file name; what can't be collected is listed in
+// errors.txt instead, so that a partial bundle can still be attached to a bug report
+func (m *Manager) SupportBundle(ctx context.Context, opts SupportBundleOptions) (map[string][]byte, error) {
+	cluster, err := m.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := map[string][]byte{}
+	failures := []string{}
+	fail := func(what string, err error) {
+		failures = append(failures, fmt.Sprintf("%s: %v", what, err))
+	}
+	addJSON := func(name string, v interface{}) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			fail("encoding "+name, err)
+			return
+		}
+		files[name] = data
+	}
+
+	addJSON("cluster.json", cluster)
+
+	stacks, err := m.stackManager.DescribeStacks()
+	if err != nil {
+		fail("describing stacks", err)
+	}
+	for _, s := range stacks {
+		name := aws.StringValue(s.StackName)
+		addJSON("stacks/"+name+"/stack.json", s)
+		if template, err := m.stackManager.GetStackTemplate(name); err != nil {
+			fail("getting template of stack "+name, err)
+		} else {
+			files["stacks/"+name+"/template.json"] = []byte(template)
+		}
+		if events, err := m.stackManager.DescribeStackEvents(s); err != nil {
+			fail("describing events of stack "+name, err)
+		} else {
+			addJSON("stacks/"+name+"/events.json", events)
+		}
+	}
+
+	if rawClient, kubernetesVersion, err := m.newRawClient(ctx); err != nil {
+		fail("connecting to the cluster", err)
+	} else {
+		m.addKubernetesStatus(rawClient.ClientSet(), kubernetesVersion, files, addJSON, fail)
+	}
+
+	if len(failures) > 0 {
+		files["errors.txt"] = []byte(strings.Join(failures, "\n") + "\n")
+	}
+
+	if opts.Redact {
+		for name, data := range files {
+			files[name] = redact(data)
+		}
+	}
+	return files, nil
+}
+
+func (m *Manager) addKubernetesStatus(clientSet kubernetes.Interface, kubernetesVersion string, files map[string][]byte,
+	addJSON func(string, interface{}), fail func(string, error)) {
+
+	if cm, err := clientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Get(authconfigmap.ObjectName, metav1.GetOptions{}); err != nil {
+		fail("getting aws-auth ConfigMap", err)
+	} else if data, err := yaml.Marshal(cm); err != nil {
+		fail("encoding aws-auth ConfigMap", err)
+	} else {
+		files["kubernetes/aws-auth.yaml"] = data
+	}
+
+	if addons, err := defaultaddons.AddonVersions(clientSet, kubernetesVersion); err != nil {
+		fail("getting versions of default add-ons", err)
+	} else {
+		addJSON("kubernetes/addons.json", addons)
+	}
+
+	if nodes, err := clientSet.CoreV1().Nodes().List(metav1.ListOptions{}); err != nil {
+		fail("listing nodes", err)
+	} else {
+		statuses := []nodeStatus{}
+		for _, node := range nodes.Items {
+			statuses = append(statuses, nodeStatus{
+				Name:           node.Name,
+				NodeGroup:      node.Labels[api.NodeGroupNameLabel],
+				ProviderID:     node.Spec.ProviderID,
+				KubeletVersion: node.Status.NodeInfo.KubeletVersion,
+				Conditions:     node.Status.Conditions,
+			})
+		}
+		addJSON("kubernetes/nodes.json", statuses)
+	}
+
+	if daemonSets, err := clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).List(metav1.ListOptions{}); err != nil {
+		fail("listing daemonsets", err)
+	} else {
+		statuses := []daemonSetStatus{}
+		for _, ds := range daemonSets.Items {
+			status := daemonSetStatus{
+				Name:      ds.Name,
+				Desired:   ds.Status.DesiredNumberScheduled,
+				Ready:     ds.Status.NumberReady,
+				Available: ds.Status.NumberAvailable,
+				Updated:   ds.Status.UpdatedNumberScheduled,
+			}
+			for _, c := range ds.Spec.Template.Spec.Containers {
+				status.Images = append(status.Images, c.Image)
+			}
+			statuses = append(statuses, status)
+		}
+		addJSON("kubernetes/daemonsets.json", statuses)
+	}
+}
+
+// redact replaces the ARNs of resources of AWS accounts, keeping their service, and
+// any other account IDs; ARNs of AWS-managed resources, e.g. policies, are kept
+func redact(data []byte) []byte {
+	data = accountARNPattern.ReplaceAll(data, []byte("arn:REDACTED:$1"))
+	return accountIDPattern.ReplaceAll(data, []byte("REDACTED"))
+}
@@ -0,0 +1,159 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// nodeMetricsPath is the path of the node metrics served by metrics-server
+const nodeMetricsPath = "/apis/metrics.k8s.io/v1beta1/nodes"
+
+// TopNodeGroupsOptions holds options for TopNodeGroups
+type TopNodeGroupsOptions struct {
+	// NodeGroups restricts the summary to the given nodegroups, all are included when empty
+	NodeGroups []string
+}
+
+// NodeGroupUsage is the resource usage of the nodes of a nodegroup
+type NodeGroupUsage struct {
+	NodeGroup string
+	Nodes     int
+	CPU       ResourceUsage
+	Memory    ResourceUsage
+}
+
+// ResourceUsage is the usage of a resource, summed over the nodes of a nodegroup
+type ResourceUsage struct {
+	// Allocatable is what the nodes can allocate to pods
+	Allocatable resource.Quantity
+	// Requests and Limits are the sums of the requests and limits of the containers of
+	// the running pods, containers that don't set a limit are not counted
+	Requests resource.Quantity
+	Limits   resource.Quantity
+	// Usage is what the nodes use according to metrics-server, it's nil when the
+	// metrics API isn't available
+	Usage *resource.Quantity `json:",omitempty"`
+}
+
+// nodeMetricsList is the part of the NodeMetricsList of the metrics API that is used here
+type nodeMetricsList struct {
+	Items []struct {
+		Metadata metav1.ObjectMeta   `json:"metadata"`
+		Usage    corev1.ResourceList `json:"usage"`
+	} `json:"items"`
+}
+
+// TopNodeGroups summarises the requests, limits and usage of CPU and memory of the nodes of
+// each nodegroup against what they can allocate; usage is read from metrics-server, and is
+// left out with a warning when it isn't installed
+func (m *Manager) TopNodeGroups(ctx context.Context, opts TopNodeGroupsOptions) ([]*NodeGroupUsage, error) {
+	rawClient, _, err := m.newRawClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clientSet := rawClient.ClientSet()
+
+	nodes, err := clientSet.CoreV1().Nodes().List(metav1.ListOptions{LabelSelector: api.NodeGroupNameLabel})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing nodes")
+	}
+	pods, err := clientSet.CoreV1().Pods(metav1.NamespaceAll).List(metav1.ListOptions{
+		FieldSelector: fields.AndSelectors(
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+			fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+		).String(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing pods")
+	}
+
+	usage, err := nodeMetrics(clientSet)
+	if err != nil {
+		logger.Warning("usage is unknown, as node metrics can't be read: %s; install metrics-server to get them", err.Error())
+	}
+
+	return summarizeNodeGroupUsage(nodes.Items, pods.Items, usage, opts.NodeGroups), nil
+}
+
+// nodeMetrics returns the resource usage of each node, keyed by node name
+func nodeMetrics(clientSet kubernetes.Interface) (map[string]corev1.ResourceList, error) {
+	data, err := clientSet.Discovery().RESTClient().Get().AbsPath(nodeMetricsPath).DoRaw()
+	if err != nil {
+		return nil, err
+	}
+	metrics := &nodeMetricsList{}
+	if err := json.Unmarshal(data, metrics); err != nil {
+		return nil, errors.Wrap(err, "decoding node metrics")
+	}
+	usage := make(map[string]corev1.ResourceList, len(metrics.Items))
+	for _, item := range metrics.Items {
+		usage[item.Metadata.Name] = item.Usage
+	}
+	return usage, nil
+}
+
+// summarizeNodeGroupUsage sums the resources of the nodes and pods by nodegroup, sorted by
+// nodegroup name; usage is left out when it's nil
+func summarizeNodeGroupUsage(nodes []corev1.Node, pods []corev1.Pod, usage map[string]corev1.ResourceList, only []string) []*NodeGroupUsage {
+	byNodeGroup := map[string]*NodeGroupUsage{}
+	nodeGroupOfNode := map[string]*NodeGroupUsage{}
+	included := sets.NewString(only...)
+
+	for _, node := range nodes {
+		name := node.Labels[api.NodeGroupNameLabel]
+		if included.Len() > 0 && !included.Has(name) {
+			continue
+		}
+		ng, ok := byNodeGroup[name]
+		if !ok {
+			ng = &NodeGroupUsage{NodeGroup: name}
+			if usage != nil {
+				ng.CPU.Usage, ng.Memory.Usage = &resource.Quantity{}, &resource.Quantity{}
+			}
+			byNodeGroup[name] = ng
+		}
+		nodeGroupOfNode[node.Name] = ng
+
+		ng.Nodes++
+		ng.CPU.Allocatable.Add(node.Status.Allocatable[corev1.ResourceCPU])
+		ng.Memory.Allocatable.Add(node.Status.Allocatable[corev1.ResourceMemory])
+		if nodeUsage, ok := usage[node.Name]; ok {
+			ng.CPU.Usage.Add(nodeUsage[corev1.ResourceCPU])
+			ng.Memory.Usage.Add(nodeUsage[corev1.ResourceMemory])
+		}
+	}
+
+	for _, pod := range pods {
+		ng, ok := nodeGroupOfNode[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		for _, c := range pod.Spec.Containers {
+			ng.CPU.Requests.Add(c.Resources.Requests[corev1.ResourceCPU])
+			ng.CPU.Limits.Add(c.Resources.Limits[corev1.ResourceCPU])
+			ng.Memory.Requests.Add(c.Resources.Requests[corev1.ResourceMemory])
+			ng.Memory.Limits.Add(c.Resources.Limits[corev1.ResourceMemory])
+		}
+	}
+
+	summary := make([]*NodeGroupUsage, 0, len(byNodeGroup))
+	for _, ng := range byNodeGroup {
+		summary = append(summary, ng)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].NodeGroup < summary[j].NodeGroup
+	})
+	return summary
+}
@@ -0,0 +1,138 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
+	"github.com/weaveworks/eksctl/pkg/ami"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+// Kinds of components that are checked for updates
+const (
+	ComponentKubernetes = "kubernetes"
+	ComponentPlatform   = "platform"
+	ComponentAddon      = "addon"
+	ComponentNodeGroup  = "nodegroup"
+)
+
+// ComponentUpdate is the current and latest version of a component of the cluster
+type ComponentUpdate struct {
+	Kind    string
+	Name    string
+	Current string
+	Latest  string
+	// Action describes how to update the component, it's only set when an update is available
+	Action string `json:",omitempty"`
+}
+
+// UpdateAvailable returns true when the component isn't at its latest version
+func (u *ComponentUpdate) UpdateAvailable() bool {
+	return u.Latest != "" && u.Current != u.Latest
+}
+
+// CheckUpdates compares the versions of the control plane, the default add-ons and the
+// AMIs of the nodegroups with the latest ones, nothing is changed
+func (m *Manager) CheckUpdates(ctx context.Context) ([]*ComponentUpdate, error) {
+	meta := m.cfg.Metadata
+
+	cluster, err := m.GetCluster(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusterVersion := aws.StringValue(cluster.Version)
+
+	version, platformVersion := eks.AvailableUpgrades(cluster)
+	updates := []*ComponentUpdate{
+		{
+			Kind:    ComponentKubernetes,
+			Name:    meta.Name,
+			Current: clusterVersion,
+			Latest:  latestOrCurrent(version, clusterVersion),
+			Action:  fmt.Sprintf("eksctl update cluster --name=%s --region=%s --approve", meta.Name, meta.Region),
+		},
+		{
+			Kind:    ComponentPlatform,
+			Name:    meta.Name,
+			Current: aws.StringValue(cluster.PlatformVersion),
+			Latest:  latestOrCurrent(platformVersion, aws.StringValue(cluster.PlatformVersion)),
+			Action:  "rolled out by AWS",
+		},
+	}
+
+	rawClient, kubernetesVersion, err := m.newRawClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	addons, err := defaultaddons.AddonVersions(rawClient.ClientSet(), kubernetesVersion)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting versions of default add-ons")
+	}
+	for _, addon := range addons {
+		if addon.Current == "" {
+			logger.Debug("%q is not deployed", addon.Name)
+			continue
+		}
+		updates = append(updates, &ComponentUpdate{
+			Kind:    ComponentAddon,
+			Name:    addon.Name,
+			Current: addon.Current,
+			Latest:  addon.Latest,
+			Action:  fmt.Sprintf("eksctl utils update-%s --name=%s --region=%s --approve", addon.Name, meta.Name, meta.Region),
+		})
+	}
+
+	nodeGroups, err := m.GetNodeGroups(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, ng := range nodeGroups {
+		update := &ComponentUpdate{
+			Kind:    ComponentNodeGroup,
+			Name:    ng.Name,
+			Current: ng.ImageID,
+			Action:  fmt.Sprintf("eksctl upgrade nodegroup --cluster=%s --region=%s --name=%s", meta.Name, meta.Region, ng.Name),
+		}
+		if update.Latest, err = m.latestNodeGroupAMI(clusterVersion, ng.ImageID, ng.InstanceType); err != nil {
+			return nil, errors.Wrapf(err, "checking AMI of nodegroup %q", ng.Name)
+		}
+		updates = append(updates, update)
+	}
+
+	for _, u := range updates {
+		if !u.UpdateAvailable() {
+			u.Action = ""
+		}
+	}
+	return updates, nil
+}
+
+// latestNodeGroupAMI returns the latest AMI of the image family of the current one, or an
+// empty string when the current AMI is a custom one, which eksctl can't check
+func (m *Manager) latestNodeGroupAMI(clusterVersion, imageID, instanceType string) (string, error) {
+	if !strings.HasPrefix(imageID, "ami-") {
+		return "", nil
+	}
+	imageFamily, err := ami.ImageFamilyOf(m.ctl.Provider.EC2(), imageID)
+	if err != nil {
+		return "", err
+	}
+	if imageFamily == "" {
+		logger.Debug("%q is a custom AMI, it can't be checked for updates", imageID)
+		return "", nil
+	}
+	return ami.NewAutoResolver(m.ctl.Provider.EC2()).Resolve(m.cfg.Metadata.Region, clusterVersion, instanceType, imageFamily)
+}
+
+func latestOrCurrent(latest, current string) string {
+	if latest != "" {
+		return latest
+	}
+	return current
+}
@@ -0,0 +1,75 @@
+package albingress
+
+import (
+	"fmt"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/addons/helm"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// ChartName is the name of the embedded chart of the controller
+	ChartName = "aws-alb-ingress-controller"
+	// ReleaseName is the name the controller is installed with
+	ReleaseName = "alb-ingress-controller"
+	// PolicyName is the name of the inline policy that is put on instance roles
+	PolicyName = "eksctl-alb-ingress-controller"
+)
+
+// NewRepository returns a chart repository with the embedded chart of the controller
+func NewRepository() (*helm.Repository, error) {
+	chart, err := helm.LoadChartFromAssets("chart", AssetNames(), Asset)
+	if err != nil {
+		return nil, err
+	}
+	return helm.NewRepository(chart), nil
+}
+
+// Deploy installs or upgrades the controller, it's configured with the VPC and region of
+// the cluster; in plan mode it only logs what would change, it returns true when changes
+// are required
+func Deploy(rawClient kubernetes.RawClientInterface, cfg *api.ClusterConfig, plan bool) (bool, error) {
+	if cfg.VPC == nil || cfg.VPC.ID == "" {
+		return false, fmt.Errorf("VPC of cluster %q must be known to deploy ALB Ingress Controller", cfg.Metadata.Name)
+	}
+	repository, err := NewRepository()
+	if err != nil {
+		return false, err
+	}
+
+	installer := helm.NewInstaller(rawClient, repository, cfg)
+	_, changed, err := installer.Upgrade(helm.InstallOptions{
+		Chart:       ChartName,
+		ReleaseName: ReleaseName,
+		Plan:        plan,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "deploying ALB Ingress Controller")
+	}
+	return plan && changed, nil
+}
+
+// PolicyDocument returns the IAM policy published for the controller
+func PolicyDocument() (string, error) {
+	data, err := Asset("iam-policy.json")
+	if err != nil {
+		return "", errors.Wrap(err, "decoding embedded IAM policy of ALB Ingress Controller")
+	}
+	return string(data), nil
+}
+
+// AttachNodeRolePolicy puts the IAM policy of the controller on the instance roles of the
+// nodegroups described by the given stacks; it returns true when changes are required in plan mode
+func AttachNodeRolePolicy(provider api.ClusterProvider, stacks []*cfn.Stack, plan bool) (bool, error) {
+	document, err := PolicyDocument()
+	if err != nil {
+		return false, err
+	}
+
+	return iam.PutNodeGroupRolePolicy(provider, stacks, PolicyName, document, plan)
+}
@@ -0,0 +1,70 @@
+package albingress_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/albingress"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("ALB Ingress Controller", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+		cfg.VPC.ID = "vpc-0123"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Public: map[string]api.Network{
+				"eu-west-1a": {ID: "subnet-public-a"},
+			},
+			Private: map[string]api.Network{
+				"eu-west-1a": {ID: "subnet-private-a"},
+			},
+		}
+	})
+
+	It("should deploy the controller with the VPC and region of the cluster", func() {
+		rawClient := testutils.NewFakeRawClient()
+		rawClient.UseUnionTracker = true
+
+		Expect(Deploy(rawClient, cfg, false)).To(BeFalse())
+
+		deployment, err := rawClient.ClientSet().AppsV1().Deployments(metav1.NamespaceSystem).Get(ReleaseName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		container := deployment.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(Equal("docker.io/amazon/aws-alb-ingress-controller:v1.1.3"))
+		Expect(container.Args).To(Equal([]string{
+			"--ingress-class=alb",
+			"--cluster-name=test-cluster",
+			"--aws-vpc-id=vpc-0123",
+			"--aws-region=eu-west-1",
+			"--v=2",
+		}))
+
+		_, err = rawClient.ClientSet().RbacV1().ClusterRoleBindings().Get(ReleaseName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should require the VPC of the cluster", func() {
+		cfg.VPC.ID = ""
+		_, err := Deploy(testutils.NewFakeRawClient(), cfg, false)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should embed a valid IAM policy", func() {
+		document, err := PolicyDocument()
+		Expect(err).ToNot(HaveOccurred())
+
+		policy := map[string]interface{}{}
+		Expect(json.Unmarshal([]byte(document), &policy)).To(Succeed())
+		Expect(policy["Statement"]).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,377 @@
+// Code generated by go-bindata.
+// sources:
+// assets/chart/Chart.yaml
+// assets/chart/templates/_helpers.tpl
+// assets/chart/templates/deployment.yaml
+// assets/chart/templates/rbac.yaml
+// assets/chart/templates/serviceaccount.yaml
+// assets/chart/values.yaml
+// assets/iam-policy.json
+// DO NOT EDIT!
+
+package albingress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _chartChartYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x45\xcc\x31\x0e\xc2\x30\x0c\x85\xe1\x3d\xa7\xf0\x05\x12\x11\xb1\x75\x2b\x1b\x12\x33\xbb\x49\x5d\x88\x14\x62\xcb\x0e\xe1\xfa\xa4\x20\x60\xf1\xf0\xcb\xef\xab\x78\xa7\x09\xf0\x69\x1e\xcb\xc5\xe7\x7a\x55\x32\xf3\x89\x6b\x53\x2e\x85\xd4\x75\x52\xcb\x5c\x27\xd8\x85\x18\x62\x74\x28\x72\xfe\xa6\x3e\x4a\xd8\xbb\x85\x2c\x69\x96\xf6\x6e\xc7\x0f\x01\x7f\x02\xda\x0d\x1b\x88\x72\xcf\xdb\xcc\x60\x16\x29\x39\xe1\xf6\x0f\x27\xc6\x05\x0e\x58\xb0\xa6\xa1\xc2\xca\xfa\x13\xc6\xe1\x87\x26\x32\xf7\x02\xf2\x4a\x8b\xd8\xa5\x00\x00\x00")
+
+func chartChartYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartChartYaml,
+		"chart/Chart.yaml",
+	)
+}
+
+func chartChartYaml() (*asset, error) {
+	bytes, err := chartChartYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/Chart.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesHelpersTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x8f\xb1\x0e\xc2\x30\x0c\x44\xf7\x7e\x45\xd4\x3d\x61\xef\x86\x58\x11\x03\x03\xbb\x9b\x1e\x25\x6a\xea\x44\x71\x40\x42\x51\xff\x9d\xaa\x30\x74\xa8\x60\x63\x3c\xeb\xce\x7a\xaf\x14\xad\x3a\x5c\x1d\x43\xd5\xe4\x5b\xed\xb8\x4f\x10\xd1\x36\x70\x4e\xc1\x7b\x24\xe3\xa9\x85\x97\x5a\xe9\x69\xaa\x28\x46\x33\xdc\x5b\x24\x46\x86\x18\x17\x76\x4c\x23\x1a\x55\x8a\x32\x87\x1b\xa5\x6c\x4e\x73\x56\x9b\x4d\xc7\x92\x89\xed\xa7\x7d\x86\x07\x09\xbe\xf4\x1f\x48\xe2\x02\xaf\x9f\xef\x63\xbc\xbc\xaf\xdb\x93\x91\x98\x7a\x74\xba\x7d\x36\x0a\x83\xd8\xec\xab\x32\x0b\x82\xbb\x85\x7e\x09\x3f\x6c\x65\xe6\xb2\x39\xa4\xe3\x5f\xac\xd7\x74\x2f\x6d\xd5\xaf\x1b\x8b\x01\x00\x00")
+
+func chartTemplatesHelpersTplBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesHelpersTpl,
+		"chart/templates/_helpers.tpl",
+	)
+}
+
+func chartTemplatesHelpersTpl() (*asset, error) {
+	bytes, err := chartTemplatesHelpersTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/_helpers.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesDeploymentYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x54\xc1\x8a\xdb\x30\x10\xbd\xe7\x2b\x06\xc3\x42\x7b\xb0\x37\xd9\xa6\x4b\x31\xf4\x50\x12\x28\x85\xb0\x84\x2e\x04\x7a\x9c\xc8\xb3\xb6\xa8\x2c\x69\x25\xd9\x25\xdd\xee\xbf\x57\xb2\xbd\x8e\x9c\x4d\x43\xa1\x97\xfa\x24\x66\xde\xbc\xf7\x46\x23\x0f\x6a\xbe\x23\x63\xb9\x92\x39\xa0\xd6\xf6\xba\x5d\xcc\xbe\x73\x59\xe4\xb0\x26\x2d\xd4\xa1\x26\xe9\x66\x35\x39\x2c\xd0\x61\x3e\x03\x90\x58\x53\x0e\x4f\x4f\x90\x7d\x25\x41\x68\x29\xbb\xf3\x11\x78\x7e\x1e\x72\x56\x23\x3b\x03\xe8\xc2\x3d\x4a\xe0\x9e\x84\x0d\x5c\xe0\x61\x29\x70\xc9\x44\x53\x10\x24\x28\xf6\x29\x97\xa5\x21\x6b\x53\xa6\xa4\x33\x4a\x08\x32\x59\x8f\x4f\x20\x83\x5f\x20\xbd\x35\xef\x08\x96\x81\xca\x6a\x62\x81\xc6\x78\xa7\x9c\xa1\xed\x55\x77\x28\x1a\xb2\xd9\x10\x5c\xa9\xc6\xc3\x3b\x5d\xeb\xed\x30\xa7\x4c\xaf\x5c\xa3\x63\xd5\x26\xb2\xf2\x77\x66\x5e\x48\x36\x67\x4c\xdd\xf6\x3a\x8e\x6a\x2d\xd0\xd1\xa0\x13\xdd\x5d\xf8\xc4\x44\xf2\xdf\x45\x3f\xf4\xa2\xbe\xbd\xe1\x36\xba\x33\x99\x96\x33\xfa\xc4\x58\x68\xff\xee\xc2\xc8\xc2\x17\x94\x90\x4b\xff\x0c\x5e\xea\xd3\x61\xcc\xe7\xfd\x8c\xde\x79\x8d\x65\xcf\xdc\x9d\xe0\x8d\x36\x5c\xba\x07\x48\xae\x6c\x7e\x15\x7c\x0e\xb3\xe8\xb2\x61\x22\xca\x72\xdf\xc6\xe1\x24\xe1\xb0\x7c\x7b\x74\x03\x80\xa6\x8c\x2e\x28\x85\xf4\xe8\x41\xa0\xb5\x1f\xa3\x29\x0f\x89\x55\x88\xc7\x14\xa1\xc8\xdf\xa9\x75\x64\xd2\xd0\x4a\xa8\x31\xf4\xd8\x70\x43\x05\x24\x43\xa6\x6b\x12\xb8\x1d\x33\x47\xc7\x03\x22\x93\x93\x8b\xea\x79\xf1\x87\x4d\x5b\xcd\x52\x5e\x4c\x59\x77\xdb\x15\x7c\x59\x5f\xe6\xf3\x75\x1e\x72\x86\xd0\x50\xe9\xff\xc1\x29\x61\x1f\xbb\x4c\x38\x60\x4e\x18\xdb\x40\xc4\x1f\x80\x1e\xc7\x02\xa1\xca\x0d\xb5\x24\x20\x29\x68\xdf\x94\x89\x2f\x59\x7a\x90\x7f\x4f\xa1\xc1\x9b\x70\x94\x45\xcc\xa3\x95\x71\x93\x31\xf4\x4f\xa2\x22\x14\xae\x1a\xc3\xd1\xeb\xd9\xfa\x82\x1c\x16\xf3\x9b\xf7\xcb\x31\x6d\x08\x0b\x9f\xb3\x76\x6b\xd4\x9e\xf2\xa8\xac\x72\x4e\x7f\x26\x17\x87\xbc\x26\xba\x2a\x87\xeb\x5e\xe3\xe7\x34\xd5\xb1\xbf\x52\xe7\x92\x3b\x8e\x62\x4d\x02\x0f\xf7\xe4\xbd\x14\x7e\x0d\xbc\x9b\x47\x08\x4d\x86\xab\x62\xcc\xdd\x1e\x73\x82\xb7\xf4\xbf\x7a\xf3\xaf\x5a\x35\x86\x91\x8d\x4d\x84\x5d\xe1\xd4\x37\xac\x45\xb4\xe6\x06\x5c\xb4\x12\x16\xf3\xe3\x1c\xa5\x2a\xe8\x7e\xb2\xf8\xfe\x40\x14\x03\x4f\xd7\xcb\x6f\xda\x0c\xcb\x3a\x25\x06\x00\x00")
+
+func chartTemplatesDeploymentYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesDeploymentYaml,
+		"chart/templates/deployment.yaml",
+	)
+}
+
+func chartTemplatesDeploymentYaml() (*asset, error) {
+	bytes, err := chartTemplatesDeploymentYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/deployment.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesRbacYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x52\xb1\x4e\xc3\x30\x10\xdd\xf3\x15\xa7\xcc\x24\x08\x89\x01\x65\x03\x06\x36\x86\x22\xb1\xa0\x0e\x17\xe7\xda\x9a\xba\xb6\x65\x9f\x53\x44\xe9\xbf\x73\x4e\x52\xa4\x52\xa9\x08\x16\x26\x9f\xdf\x3d\xbf\x7b\xbe\x3b\xf4\xfa\x99\x42\xd4\xce\x36\x10\x5a\x54\x35\x26\x5e\xb9\xa0\xdf\x91\x05\xab\xd7\x37\xb1\xd6\xee\xb2\xbf\x2a\xd6\xda\x76\x0d\xdc\x9b\x14\x99\xc2\xcc\x19\x2a\x36\xc4\xd8\x21\x63\x53\x00\x58\xdc\x50\x03\xbb\x1d\xd4\x33\x32\x84\x91\xea\x47\x41\x60\xbf\x97\x9c\xc1\x96\x4c\xcc\x2c\x10\x46\x05\xda\x2a\x93\x3a\x82\x12\x4d\x5b\x69\xbb\x0c\x14\x63\xa5\x9c\xe5\xe0\x8c\xa1\x50\x8f\xfc\x12\x6a\xf8\x00\x2b\x55\xc9\x32\x5c\x67\xa9\x90\x0c\x89\x4e\x05\xe8\xf5\x43\x70\xc9\xc7\x06\x5e\xca\xf2\x02\x4a\x7a\x63\xb2\xf9\x13\xb1\x9c\x4b\x1d\x51\x74\x29\x28\x1a\xf2\xa2\xbc\xd0\xcb\x0d\xfa\x38\x30\x6d\xe7\x9d\xb6\x3c\x5e\x7a\x9a\xa2\xc9\x06\x1d\x5f\x2e\x23\x23\xa7\x01\x8b\x14\x7a\x2d\x8a\x83\x7e\x4f\xa1\x1d\xb5\x03\x21\x53\xce\x2f\x89\xf3\x61\x74\x1c\xce\xe4\xbb\x29\xb1\x45\x56\xab\x1c\xf8\x21\x98\xff\xd6\xbe\x75\xdd\xe8\xca\xbb\x6e\x72\x22\x55\xf9\xd8\x94\xc4\x79\x02\xd1\xe3\x89\xc5\x6f\xc6\xb6\x07\x17\x55\x55\xe0\x9f\x67\x7f\x27\x80\x74\xe9\x1f\x56\x40\x8a\xcf\x68\x91\x95\x0e\x5d\x3c\xe3\x5d\x58\xa7\x6b\x7b\xce\x69\x4c\xed\x2b\x29\x1e\x96\x6c\x7c\xf9\x34\xb6\xf8\x56\x29\x97\x2c\xff\xf0\xcd\xaf\x19\x9c\x12\x06\x38\xb3\x3e\x01\x42\x12\xe9\xf7\x71\x03\x00\x00")
+
+func chartTemplatesRbacYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesRbacYaml,
+		"chart/templates/rbac.yaml",
+	)
+}
+
+func chartTemplatesRbacYaml() (*asset, error) {
+	bytes, err := chartTemplatesRbacYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/rbac.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesServiceaccountYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x8e\x31\x0e\xc2\x30\x10\x04\xfb\xbc\x62\x95\xde\x96\x90\xa8\xdc\xf1\x01\x0a\x90\xe8\x2f\xf6\x09\x59\x5c\xce\x91\xed\xa4\x09\xf9\x3b\x0e\xa1\xa4\x9d\x1d\x8d\x96\xa6\xf8\xe0\x5c\x62\x52\x87\xe5\xd4\xbd\xa2\x06\x87\x3b\xe7\x25\x7a\xbe\x78\x9f\x66\xad\xdd\xc8\x95\x02\x55\x72\x1d\xa0\x34\xb2\xc3\xba\xc2\xde\x58\x98\x0a\xdb\x6b\x23\xd8\xb6\xdf\x56\x26\xf2\x7f\x84\x2f\x3e\x2c\xa1\x81\xa5\xec\x2d\x34\xcd\x20\xaa\x97\x39\x30\x7a\x92\xc1\x44\x7d\x66\x2e\xc5\xf8\xa4\x35\x27\x11\xce\xf6\xf0\x7b\x58\xbc\xa1\xed\x1e\x6b\xc5\x79\x4f\x7d\x00\x66\x7d\xca\xe5\xbb\x00\x00\x00")
+
+func chartTemplatesServiceaccountYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesServiceaccountYaml,
+		"chart/templates/serviceaccount.yaml",
+	)
+}
+
+func chartTemplatesServiceaccountYaml() (*asset, error) {
+	bytes, err := chartTemplatesServiceaccountYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/serviceaccount.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartValuesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x2d\x8e\x31\x0e\xc2\x30\x0c\x45\x77\x9f\xc2\x17\x68\xab\x88\x2d\x6b\x57\x36\x4e\x60\x52\x13\x45\xb8\x31\xb2\xd3\xa2\x82\xb8\x3b\x11\x62\x7d\x7a\x4f\xff\x97\x95\x32\x47\x40\x34\x7e\xa8\x97\xa6\x76\x44\x5c\x34\xdd\xd9\xc6\xa2\x13\xad\xf4\xd2\x3a\xd1\xd3\x07\x92\xeb\x50\x6a\x36\x76\x1f\x92\xd6\x66\x2a\xc2\xd6\xcb\x46\x39\xe2\x1e\xc6\x30\x9e\x00\xfe\xc6\x2c\xe4\x1e\xb1\x37\x20\x9a\xcf\xbc\xb3\x44\x2c\xf5\xa6\xd0\x77\xa4\x24\x9a\x75\xab\x2d\x62\x80\x0e\x5c\x37\x4b\xdc\xf5\xf7\x07\xaa\x2e\x7c\x61\xe1\xd4\x9f\xfc\xc0\x17\xe9\xc3\x21\x8d\xa1\x00\x00\x00")
+
+func chartValuesYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartValuesYaml,
+		"chart/values.yaml",
+	)
+}
+
+func chartValuesYaml() (*asset, error) {
+	bytes, err := chartValuesYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/values.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _iamPolicyJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x96\x4d\x6f\xa3\x30\x10\x86\xef\xf9\x15\x88\xe3\xaa\x91\x9a\x5e\x2a\xe5\xc6\x26\xbb\xd9\x95\x52\x29\x82\x74\xf7\x50\xf5\xe0\x98\x81\x8e\xd6\xb1\x91\x6d\x52\x45\x55\xff\xfb\xda\x98\x52\xf2\x41\x31\x5c\x38\xcc\x3c\xef\x78\x32\x1f\x8e\xdf\x26\x41\x10\xfe\x01\xa9\x50\xf0\x70\x1e\x84\x77\xb7\xb3\xbb\xe9\xec\x76\x3a\xbb\x0f\x6f\xac\x2b\xd1\x44\xc3\x1e\xb8\x36\xce\x27\x63\x08\x82\xb7\xea\x6b\x5c\x3f\xb2\x0c\xa8\xb5\x87\x11\x63\xe2\xb5\xe2\x2b\x47\x44\xb5\x8b\xf6\x54\x5b\x8c\x8d\xd0\xfd\x7c\x09\x8a\x4a\xdc\xc1\x02\xa4\xc6\x0c\xa9\x89\xdc\x88\x6a\x64\x8d\x4a\xb7\xdc\xea\xdc\xbf\x02\xdd\xa1\x06\x46\x94\x46\xca\x04\x49\x77\x84\x11\x4e\x91\xe7\xf3\x28\x4d\x6d\x44\xe0\x20\xbb\xa2\x76\xe9\xb6\x24\xef\xe7\x16\x12\x4c\xbc\x8f\x23\x7c\x71\x63\xfb\x5e\xd9\xbc\x25\x71\xc9\xc0\x13\xdd\x12\x99\x83\x5e\x49\x51\x16\xbd\x8a\x25\x30\x18\x90\x7e\x8d\x0f\x49\xdf\x49\xbc\xd2\x77\xe8\xb0\xf4\x25\xe4\x36\x79\xe9\x54\xca\x43\xe1\x26\x70\xd4\x50\x9c\x8b\x07\x28\x5a\x25\x1b\xa7\x8a\xb4\x36\xa6\x72\x48\x9a\xb6\xe8\xfe\x74\x92\xac\x37\x82\x21\xc5\x01\x1a\xaf\x15\xf9\x84\x9b\xc6\x8e\x12\x8d\xa8\x80\x53\xff\x02\xc2\xf4\x4b\xaf\xe8\x41\xa4\x98\x1d\xbd\x77\xa1\xc6\xc7\xb5\xc8\x89\xbd\xb6\xc2\xa1\x43\xb6\xe2\x42\x31\x20\xb1\x78\xe0\x3e\xc5\xb0\x17\x87\x71\xdb\xe4\xa4\x5e\x23\x94\x80\xfe\x5d\x98\x2b\x59\x82\x52\xdb\x63\x01\x3e\x82\x04\x68\x29\x51\x1f\x3d\x07\xce\x2a\xca\x1d\xf7\xf9\xcd\x06\xfd\x0b\xbb\x68\xb1\x6e\x93\x48\xf6\xf5\xfd\x9b\x80\x3c\x20\x35\x35\xe1\xff\x20\x8d\xc5\x69\x8b\x2d\xb6\xb2\xc9\xc9\xc3\x49\xb9\xce\x19\x5b\xd1\x0b\xe8\x24\xb5\x57\x92\x4d\xed\xed\x27\x38\x61\x36\xa4\x4b\xe9\xa7\x90\x31\x28\x51\x4a\x0a\xfd\x70\x27\x11\x29\x25\x28\x9a\x13\x7b\xb8\x25\x2a\xd2\x8d\x6a\x92\xdb\xb3\x3e\xf2\x51\xe7\x3e\xd3\xfa\xab\x3e\x73\xc4\xf5\x1c\xa9\xc8\x39\x6a\x31\xc5\xb4\x68\x56\xfc\x51\x81\xdc\x08\xc1\x16\x0c\xed\x1b\xa5\x45\xab\x17\x04\x96\x36\xe0\x46\x0a\x0d\xee\x5d\x72\x09\xad\xaa\xee\x5b\xb0\xb0\x44\xf5\xe6\xb9\x1a\xcb\xfe\x43\x7d\x19\xc9\xcd\xc0\x97\x48\x73\xe3\xb6\x4e\xbc\x82\xd9\x11\xf8\x8c\xa3\xc2\x1a\x78\x6e\x5e\x59\x4d\xa3\xcd\x03\xec\x9b\x73\xbf\x9b\xef\xf3\xe4\x7d\xf2\x1f\x7b\xb9\xb3\xf1\xd5\x09\x00\x00")
+
+func iamPolicyJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_iamPolicyJson,
+		"iam-policy.json",
+	)
+}
+
+func iamPolicyJson() (*asset, error) {
+	bytes, err := iamPolicyJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "iam-policy.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"chart/Chart.yaml": chartChartYaml,
+	"chart/templates/_helpers.tpl": chartTemplatesHelpersTpl,
+	"chart/templates/deployment.yaml": chartTemplatesDeploymentYaml,
+	"chart/templates/rbac.yaml": chartTemplatesRbacYaml,
+	"chart/templates/serviceaccount.yaml": chartTemplatesServiceaccountYaml,
+	"chart/values.yaml": chartValuesYaml,
+	"iam-policy.json": iamPolicyJson,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"chart": &bintree{nil, map[string]*bintree{
+		"Chart.yaml": &bintree{chartChartYaml, map[string]*bintree{}},
+		"templates": &bintree{nil, map[string]*bintree{
+			"_helpers.tpl": &bintree{chartTemplatesHelpersTpl, map[string]*bintree{}},
+			"deployment.yaml": &bintree{chartTemplatesDeploymentYaml, map[string]*bintree{}},
+			"rbac.yaml": &bintree{chartTemplatesRbacYaml, map[string]*bintree{}},
+			"serviceaccount.yaml": &bintree{chartTemplatesServiceaccountYaml, map[string]*bintree{}},
+		}},
+		"values.yaml": &bintree{chartValuesYaml, map[string]*bintree{}},
+	}},
+	"iam-policy.json": &bintree{iamPolicyJson, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
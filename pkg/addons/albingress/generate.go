@@ -0,0 +1,3 @@
+package albingress
+
+//go:generate ${GOBIN}/go-bindata -pkg ${GOPACKAGE} -prefix assets -nometadata -o assets.go assets
@@ -0,0 +1,235 @@
+// Code generated by go-bindata.
+// sources:
+// assets/cluster-autoscaler.yaml
+// DO NOT EDIT!
+
+package clusterautoscaler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _clusterAutoscalerYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x57\x49\x8f\xdb\x36\x14\xbe\xfb\x57\x08\x3a\x87\xf6\x04\xed\xa1\x10\xe0\xc3\x74\x41\x50\xa0\x99\x0c\x26\x40\x7b\x28\x82\x01\x45\x3d\xcb\xcc\x50\x24\xcb\xc5\x13\xd7\xc8\x7f\xef\x23\x25\x8d\xb5\x59\x13\x7b\xd2\x36\x3a\x49\xe4\xf7\xf6\x55\x84\x90\x05\xd5\xfc\x77\x30\x96\x2b\x99\x25\xbb\xd7\x8b\x07\x2e\x8b\x2c\x79\x0f\x66\xc7\x19\x5c\x33\xa6\xbc\x74\x8b\x0a\x1c\x2d\xa8\xa3\xd9\x22\x49\x04\xcd\x41\xd8\xf0\x96\x24\x0f\x3f\x58\x42\x8b\x22\x90\x32\xe1\xad\x03\x43\xa8\x77\xca\x32\x2a\xc0\x2c\xe3\x8d\x5d\x22\x68\xc9\xd5\x11\xaf\xf5\x14\x1a\xef\x25\xad\x60\xe6\xca\x6a\xca\xf0\xfe\xc1\xe7\x40\xec\x1e\x31\xd5\x82\x0c\xf4\x37\x39\x65\x4b\x24\xdc\x2a\xc3\xff\xa6\x0e\xcf\x1a\xe9\xab\x27\xcb\x7e\xaa\xd9\xdf\x29\x01\x3d\xb3\x66\x84\xff\x5b\x16\x1b\x2f\x20\xb2\x25\x09\x1a\xf1\xc6\x28\xaf\x6d\x96\xfc\x99\xa6\x1f\x22\xa9\x01\xab\xbc\x61\x10\xcf\x60\x07\xd2\xd9\xf4\x55\x92\x82\x2c\xb4\xe2\xe1\xa3\x86\xed\xc0\xe4\x11\xc2\x0c\x50\x07\x01\xa2\xa9\x63\xdb\x78\xfd\x05\x9c\xb5\x2a\xec\x0a\x30\xda\xc1\x5d\x27\x78\x9e\xc3\xca\x3a\xea\xfc\x48\x39\xaf\x8b\x33\x18\x0d\x6d\x6c\x2f\x6f\x42\x1a\x44\xbd\x46\xde\x1c\x0a\x2c\xc1\x05\x57\x9c\x27\x57\xaa\x02\x46\xaa\x3f\x46\x6f\x22\x2f\xc1\x6d\xe4\x79\x3e\xeb\xf8\x19\x60\xd1\x45\xe9\xf1\xd3\xd6\x75\xd6\x3d\x32\xa0\x05\x67\x31\x79\x99\x92\xce\x28\x81\xd6\x75\x01\x3a\x64\x3b\x5a\x2f\xdd\x4e\x09\x5f\x01\x13\x94\x57\x73\x80\xe6\x6e\xde\xa0\x09\x33\xe0\x13\xf2\x08\x85\x65\xa7\x7c\xd5\xe8\x69\xa1\x4e\xcb\x82\x42\x85\x48\x70\x5f\xe6\xbf\x09\x71\x5a\x21\xbf\xfd\x89\xbc\x2a\xb8\x35\x5e\x07\xa7\xe4\xbe\x28\x9f\x95\x32\xc1\x1e\xab\x70\xd2\x8e\x90\xaf\xb0\xf1\xa2\x35\xe4\xab\xdb\x65\x9d\x32\xb4\x84\xa6\x2b\x4c\xab\x10\x11\x18\x48\x6b\xe1\x62\x39\x79\x8b\x9b\x8f\xdb\x47\x95\xdb\x13\xc5\xd2\x0a\x78\x92\xd8\x36\x92\x0b\xfa\xec\x39\x0d\xf6\x44\x77\xff\x36\x3a\x2f\x16\xe1\x86\x97\x15\xd5\x33\x0d\xb7\xef\xb8\x0f\x17\x32\xee\x77\xb9\x63\x3d\x8f\x0d\x20\x4d\x93\x9d\xc5\x68\xc3\x31\x3c\x6e\x4f\xe0\x93\xa6\xb2\xc0\x16\xd9\x57\xbf\x00\x01\xb5\xfa\xfd\x86\xd6\xb1\xe3\x65\xf3\xf5\x47\x3c\xe0\xb2\xfc\xdf\xc7\x2c\xaa\x72\x07\x9b\xc0\xb8\x8d\xc9\x8c\x25\x88\x1a\x2f\x0a\x33\x7a\x5b\x9f\x7f\x04\xe6\x9a\x6c\x9a\xdc\x9e\x82\x82\x33\x76\x7f\xcd\xed\xe6\x02\xb7\xff\xf7\xc5\x77\x59\x3c\xbe\xa1\x40\x84\x51\x72\xf4\xf9\xcf\x38\x30\xd4\xbe\x82\xc1\x9e\xfc\x52\x97\x9f\x72\x9f\xd5\xc0\x02\xa2\x9d\x53\x59\xf2\x1a\xbf\x2c\x16\x33\xc3\x31\x52\xd3\x56\xa1\x7e\x7f\xeb\x30\x3b\xcd\x2e\x49\x50\xb4\x16\x58\xf8\x0d\x69\xc7\x84\xf0\x88\x1e\x97\x39\x3e\xf1\x56\x4a\xe5\x62\xf8\x3a\x24\x13\x19\x13\xcc\x36\x12\xdb\x4f\x4c\x5f\x4b\x37\x40\x9c\x22\x71\x0b\xcd\x92\x74\x43\x71\x1a\xd7\xdd\xaa\xb5\x36\xbe\xf7\xa2\x79\x33\x17\x48\x14\x8a\xbb\x13\xe5\x12\x43\x76\x54\x84\x24\xbc\xc2\x39\x9b\x85\xf4\x5c\x96\xcc\x04\xd9\x63\x06\xd9\xe1\x10\x61\xf7\x8e\x96\x9f\x3f\x3f\x11\x3f\x93\x3a\xf5\x33\x5a\xf9\x1a\x27\xf2\x8a\xbb\xc1\x19\xaa\xa8\x3d\x06\xef\xea\xaa\x1a\x9c\x57\xb8\x71\x98\x7d\x96\x7c\x77\x75\xf5\x96\xf7\xee\x0c\xfc\xe5\xc1\xbe\x9c\x13\x53\x55\x85\x23\xa1\xcf\x86\x24\xcb\xd5\xac\x71\x01\x42\xc8\x6e\xfd\xfd\xe8\xcc\x3a\x1c\x2f\xc6\x6d\xd1\xfc\xad\x12\xc5\x9a\xcb\x8d\x1a\x81\x98\x50\xbe\xc0\xa9\xa4\x76\x1c\xd1\x6b\xfa\x68\xc7\x7c\x1e\xb8\x26\x71\x09\x27\x8f\xdc\x6d\x89\x50\xa8\x03\x69\xd6\xa3\x75\x4c\x8b\x11\x4d\x3b\xdd\xd6\x87\x43\xfb\xda\x8b\x5a\x8d\x0a\x4c\x49\x19\x7a\x4d\xb4\x8d\xe0\x3e\xc9\x14\x8e\xc2\xfd\x9a\xda\x32\xc3\x48\xaf\x9b\x56\x3a\xf6\xc0\x0a\x24\xcd\x05\x14\xaf\x4e\x23\x0e\x87\xe6\xf0\x3e\xe4\xc8\x84\xf8\x9c\x0a\x2a\x19\x56\x3a\x26\x82\xa0\xa6\xa3\xce\xf3\x4e\xa8\xbb\x03\x09\x7f\x0e\x23\x17\x80\xdc\x0d\x83\x58\x67\xe9\xf5\x1f\xef\xef\xef\x7e\x79\xf3\xeb\xbb\x9b\x41\x4a\xec\xa8\xf0\x78\x9f\x1e\x0e\x06\x4a\xac\xd3\x46\xe3\xb4\x03\xab\xff\x1a\xde\x86\x22\xb3\xd3\xdc\xad\x15\x84\x81\x71\x76\x98\x6f\x81\xe6\x96\xba\x6d\x96\xac\xc0\xb1\x15\xe2\x56\x11\xb7\x62\x34\x12\xf0\x4d\xf8\xb5\xc1\xaa\x67\xc6\x0d\x68\x71\x99\x2a\xde\x49\x81\xd9\xea\x8c\xef\xda\x18\x8b\xf1\xd6\x0b\x71\x1b\x7f\x10\x50\xf7\x6b\xf1\x48\xf7\x4f\xdb\x4f\xf3\x93\xd3\x2d\xf3\xd3\x5a\x6e\x95\xad\x15\xec\x49\xd7\x51\xe5\x74\xac\x73\xee\x65\x21\x20\x68\x9b\x2e\xfe\x01\x0f\xcc\x16\xde\x2d\x11\x00\x00")
+
+func clusterAutoscalerYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_clusterAutoscalerYaml,
+		"cluster-autoscaler.yaml",
+	)
+}
+
+func clusterAutoscalerYaml() (*asset, error) {
+	bytes, err := clusterAutoscalerYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "cluster-autoscaler.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"cluster-autoscaler.yaml": clusterAutoscalerYaml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"cluster-autoscaler.yaml": &bintree{clusterAutoscalerYaml, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
@@ -0,0 +1,114 @@
+package clusterautoscaler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// ClusterAutoscaler is the name of Cluster Autoscaler deployment
+	ClusterAutoscaler = "cluster-autoscaler"
+
+	clusterNamePlaceholder = "{{cluster_name}}"
+	regionPlaceholder      = "{{region_name}}"
+	expanderPlaceholder    = "{{expander}}"
+	imageTagPlaceholder    = "{{image_tag}}"
+)
+
+// imageTags maps control plane minor versions to Cluster Autoscaler
+// releases, which have to match in order to be compatible
+var imageTags = map[string]string{
+	"1.11": "v1.3.9",
+	"1.12": "v1.12.8",
+	"1.13": "v1.13.8",
+}
+
+// Expander returns the expander that will be used for the given configuration
+func Expander(as *api.ClusterAutoScaler) string {
+	if as == nil || as.Expander == "" {
+		return api.AutoScalerExpanderLeastWaste
+	}
+	return as.Expander
+}
+
+// ImageTag returns the Cluster Autoscaler release that matches the given control plane version
+func ImageTag(controlPlaneVersion string) (string, error) {
+	minorVersion := minorVersionOf(controlPlaneVersion)
+	tag, ok := imageTags[minorVersion]
+	if !ok {
+		return "", fmt.Errorf("no Cluster Autoscaler release is known for Kubernetes version %q", controlPlaneVersion)
+	}
+	return tag, nil
+}
+
+func minorVersionOf(version string) string {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// Deploy creates or replaces Cluster Autoscaler in kube-system namespace, the release
+// is matched to controlPlaneVersion and ASGs are discovered via tags that are applied to
+// nodegroups with autoScaler addon policy enabled
+func Deploy(rawClient kubernetes.RawClientInterface, spec *api.ClusterConfig, controlPlaneVersion string) error {
+	tag, err := ImageTag(controlPlaneVersion)
+	if err != nil {
+		return err
+	}
+
+	expander := Expander(spec.AutoScaler)
+	list, err := loadAsset(spec.Metadata.Name, spec.Metadata.Region, expander, tag)
+	if err != nil {
+		return err
+	}
+
+	for _, rawObj := range list.Items {
+		resource, err := rawClient.NewRawResource(rawObj)
+		if err != nil {
+			return err
+		}
+		if deployment, ok := resource.Info.Object.(*appsv1.Deployment); ok {
+			containers := deployment.Spec.Template.Spec.Containers
+			for i := range containers {
+				containers[i].Image = spec.MirroredImage(containers[i].Image)
+			}
+		}
+		status, err := resource.CreateOrReplace(false)
+		if err != nil {
+			return err
+		}
+		logger.Info("%s", status)
+	}
+
+	logger.Info("Cluster Autoscaler %s has been deployed with %q expander", tag, expander)
+	return nil
+}
+
+func loadAsset(clusterName, region, expander, tag string) (*metav1.List, error) {
+	data, err := Asset(ClusterAutoscaler + ".yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding embedded manifest for %q", ClusterAutoscaler)
+	}
+
+	manifest := strings.Replace(string(data), clusterNamePlaceholder, clusterName, -1)
+	manifest = strings.Replace(manifest, regionPlaceholder, region, -1)
+	manifest = strings.Replace(manifest, expanderPlaceholder, expander, -1)
+	manifest = strings.Replace(manifest, imageTagPlaceholder, tag, -1)
+
+	list, err := kubernetes.NewList([]byte(manifest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading individual resources from manifest for %q", ClusterAutoscaler)
+	}
+	return list, nil
+}
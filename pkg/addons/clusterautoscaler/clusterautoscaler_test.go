@@ -0,0 +1,63 @@
+package clusterautoscaler_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/clusterautoscaler"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("Cluster Autoscaler", func() {
+	var (
+		rawClient *testutils.FakeRawClient
+		cfg       *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		rawClient = testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+		cfg.AutoScaler = &api.ClusterAutoScaler{Install: api.Enabled()}
+	})
+
+	It("can create all objects with image matched to control plane version", func() {
+		Expect(Deploy(rawClient, cfg, "1.13.7")).To(Succeed())
+
+		ct := rawClient.Collection
+		Expect(ct.Updated()).To(BeEmpty())
+		Expect(ct.CreatedItems()).To(HaveLen(6))
+
+		ca, err := rawClient.ClientSet().AppsV1().Deployments(metav1.NamespaceSystem).Get(ClusterAutoscaler, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		container := ca.Spec.Template.Spec.Containers[0]
+		Expect(container.Image).To(Equal("k8s.gcr.io/cluster-autoscaler:v1.13.8"))
+		Expect(container.Command).To(ContainElement("--expander=least-waste"))
+		Expect(container.Command).To(ContainElement(
+			"--node-group-auto-discovery=asg:tag=k8s.io/cluster-autoscaler/enabled,k8s.io/cluster-autoscaler/test-cluster",
+		))
+	})
+
+	It("uses the offline image registry", func() {
+		cfg.ContainerRuntime = &api.ClusterContainerRuntime{
+			Offline: &api.OfflineConfig{ImageRegistry: "registry.example.com:5000"},
+		}
+
+		Expect(Deploy(rawClient, cfg, "1.13.7")).To(Succeed())
+
+		ca, err := rawClient.ClientSet().AppsV1().Deployments(metav1.NamespaceSystem).Get(ClusterAutoscaler, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ca.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.example.com:5000/cluster-autoscaler:v1.13.8"))
+	})
+
+	It("should fail for unknown control plane versions", func() {
+		Expect(Deploy(rawClient, cfg, "1.9.0")).ToNot(Succeed())
+	})
+})
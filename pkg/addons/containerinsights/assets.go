@@ -0,0 +1,258 @@
+// Code generated by go-bindata.
+// sources:
+// assets/cloudwatch-agent.yaml
+// assets/fluent-bit.yaml
+// DO NOT EDIT!
+
+package containerinsights
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _cloudwatchAgentYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x56\x59\x6f\xdb\x38\x10\x7e\xf7\xaf\x10\xf4\x5c\x49\x4e\x16\x2d\x0a\xbd\xa5\x69\xb7\x1b\x6c\x73\x20\x5e\xf4\x65\x51\x18\x14\x35\xb6\x99\x50\xa4\x96\x87\xbb\x69\xea\xff\xde\x21\x65\xd9\x3a\x2c\xd9\x6d\xa3\x07\x41\x9e\xe3\x9b\xe3\x1b\x0e\x1d\x45\xd1\x84\x94\xec\x33\x28\xcd\xa4\x48\x83\xf5\xd9\xe4\x91\x89\x3c\x0d\x6e\x48\x01\xba\x24\x14\x26\x05\x18\x92\x13\x43\xd2\x49\x10\x08\x94\xa6\x01\x29\xc8\x37\x29\x22\xca\xa5\xcd\xbf\x12\x43\x57\xa8\xe1\x24\x03\xae\x9d\xcd\xb0\x55\x34\x14\x6c\x06\x6a\xcd\x28\x5c\x50\x2a\xad\x30\x07\x22\xee\x41\x22\xb2\x04\x34\xa9\x14\x3e\xc1\x53\x22\xa9\x8c\xd0\x98\x58\xb3\x92\x8a\x7d\x23\x06\x65\xf1\xe3\x5b\x1d\x33\x99\xec\x72\xb8\xe4\x56\x1b\x50\xf7\x92\xc3\x09\x09\x44\xca\xd9\x29\xcb\x01\x6b\x8e\x02\x8c\xf5\x51\x49\x5b\xea\x34\xf8\x37\x0c\xbf\xa0\x9b\x02\x2d\xad\xa2\xe0\x25\xa5\xcc\x75\xf8\x2a\x08\x85\xcc\xc1\x7f\x80\xc8\x4b\xc9\x84\xd1\xde\x76\x0d\x2a\xf3\x76\x9c\x69\xe3\xd4\x3e\x10\xaa\x3a\xc0\xa4\x2c\x75\x1f\x5c\x41\xc9\x19\x25\x1a\x7e\x12\x2d\xdb\x8a\x3b\x70\x0f\x32\xfb\x39\x9c\x3e\x84\x2f\x33\x29\x95\xfc\xff\xa9\x8d\xb4\x04\x73\xba\xbf\x36\xc4\xf8\x66\x51\x29\x16\x6c\x59\x90\xb2\x6a\xdd\x1a\x7a\x7d\xa3\x0a\x88\x81\x53\xa0\x1b\x58\x4d\x9d\x9f\x76\xaf\xff\x5a\xd1\x4b\xab\x69\xe0\x40\x72\x50\xfd\x22\x30\x0f\x5b\xe6\xdb\x98\xbf\x35\x6b\xef\x50\xc0\xc4\xf2\xd4\x91\x8b\xb2\xad\xbd\xb6\xd9\x03\x50\xe3\xa7\xef\xe0\x21\xfa\xd5\xa3\xe3\xa2\xdc\xc3\xc2\xe5\xd1\x3f\x18\xe3\xc7\x21\xd8\x75\x7f\xa4\x0b\xc3\x5b\xe0\xd2\x73\x73\x4d\xca\x43\xcd\xa8\x78\xa9\xe8\x3b\x56\x42\xed\xda\x72\x8a\x1f\xb4\x8b\xf6\xdd\x6f\xa8\x67\xff\x0e\x82\x90\xcb\xa5\x0e\xd3\xdd\x6f\x94\x60\x6c\xc5\xa8\x9e\x53\xc9\x39\x36\x18\xf2\x96\x1a\x0d\x1e\x6d\x06\x4a\x80\x01\xdd\xd1\xa0\x6e\x3b\x36\x73\x97\x1e\x6a\xc3\xe7\xe7\xa6\x64\xb3\x09\x5f\xb5\xed\x3b\xc1\xb0\x1f\x73\xdc\x0a\x48\x24\xe1\xe8\xfe\x66\xda\xb0\xde\xec\xbe\x37\x7b\x90\x70\x21\x71\x7a\xe7\x0b\x0c\xb2\x6a\x7a\xbe\x9e\x34\x9d\x36\xbd\x9e\xbb\x4d\xb2\x1f\xc7\xf7\x04\x0a\x29\x66\xf0\x12\x9b\x57\x97\x40\x9d\xb7\x06\x57\x90\x54\xd5\x8d\x50\x38\xdd\xa7\xc6\x15\x31\x02\x6f\xa0\x28\x39\x1e\xad\xad\x67\x23\x23\xf7\xf0\x16\xc8\x08\x0c\xa6\xb0\x4d\xc5\x3d\x38\x03\x86\x30\x81\xf5\xd7\x92\x68\xcc\xd5\x3d\xac\xc0\xdf\x75\x89\x49\xd7\x2c\x3d\x8b\xcf\xff\x98\xbe\x39\x3f\x8b\xf7\x24\xed\x37\x4d\x83\x37\xce\x0a\x66\x5a\x12\xcc\xa6\xb4\x69\x70\x3e\x9d\x16\x2d\x69\x81\x2c\xa8\x27\xaf\xb8\x66\x0d\x8d\x82\xff\x2c\xe8\xdf\xc1\x00\xb1\xde\x3b\xd7\x85\xff\x75\x3b\xfb\x67\x7e\x75\xd7\x70\xc7\xd9\xb1\xf0\xa7\x92\x45\x3b\xd2\x82\x01\xcf\xb7\x2b\xa1\x27\xbf\x23\x66\x95\x06\x6e\x57\x5b\x1d\xaf\xa4\x36\x0d\xc4\x56\xa4\x9b\x8b\xeb\x0f\x2f\x13\x0b\x69\x8d\xdd\x15\xe1\x96\x76\x2f\xd6\xdf\x6f\x67\x3e\xd4\xec\xee\xe2\xf2\x65\xe2\xd5\x13\x18\xef\xe6\xbe\x17\xf4\xf2\x6a\xfe\xf9\xc3\xfd\xec\xea\xf6\xa6\x1b\x11\x77\x00\xae\xbd\x04\xa7\x24\x9e\x86\x3b\xe5\x5a\x72\x5b\xc0\xb5\xdb\xd3\xba\x4f\x4c\x77\xd7\xed\xb8\x75\xf6\x55\x4e\x09\x18\x9a\x1c\xb6\xab\x51\x94\x94\x66\xa1\x07\xdc\x7b\x4a\xbc\x40\xf3\x5b\xc1\x71\x72\x8c\xb2\xfd\x02\x73\x49\x1f\xf1\xec\xe0\x7b\x00\x70\x4d\x54\xa2\xac\x48\x2a\xc3\xb8\x63\x79\x04\x1d\x9d\x39\xcb\x2a\xd7\x11\x7c\xb4\x49\x7a\x46\x47\xa0\xf5\xd3\x50\x0b\xda\x9a\x63\xf5\xc3\x3a\x67\x7a\xa8\x78\xd4\x26\x1d\xf5\x41\xbc\x8a\xf6\xfe\x06\x3a\xc8\x23\xad\x6f\xc3\xe6\x68\x0e\x3b\x0c\xd0\xee\x4e\xa4\xcf\xb2\x01\x52\x56\x59\x4f\x8e\x52\x3c\xe2\x3c\xcc\xf7\x28\xa9\x47\x10\x7b\x0c\x1f\xa2\x71\x04\x63\x6f\x36\x44\xdc\x88\x73\xcd\x62\xdd\x18\xbc\x4c\x0b\x26\xfc\x5f\x97\x8f\x0a\x4f\xfd\x1d\x28\x26\xf3\x19\x60\xdb\x73\xdd\xb8\x9c\x75\xeb\x7f\xd7\xcd\xe1\x4b\xe5\x07\xcd\x16\x51\x9e\x68\x0d\x00\x00")
+
+func cloudwatchAgentYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_cloudwatchAgentYaml,
+		"cloudwatch-agent.yaml",
+	)
+}
+
+func cloudwatchAgentYaml() (*asset, error) {
+	bytes, err := cloudwatchAgentYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "cloudwatch-agent.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _fluentBitYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc5\x58\x6d\x6f\x9b\x48\x10\xfe\xee\x5f\x81\xac\xfa\x4b\x75\x80\x93\xa6\x55\xcb\x37\xa7\x75\x9a\xa8\x7e\x93\x71\x7a\xba\xab\x2a\xb4\x86\xc5\xde\x06\x58\x6e\x77\x71\x92\xe6\xf2\xdf\x6f\x76\xc1\xb0\x18\x62\x1a\x45\xea\x11\x29\x81\xd9\x99\x67\x67\xe7\x7d\x63\x9a\x66\x0f\xa5\xe4\x2b\x66\x9c\xd0\xc4\x31\x76\x27\xbd\x1b\x92\x04\x8e\xf1\x91\x26\x21\xd9\x4c\x51\xda\x8b\xb1\x40\x01\x12\xc8\xe9\x19\x46\x82\x62\xec\x18\x61\x94\xe1\x44\x98\x6b\x22\x4c\x3f\xca\xb8\xc0\xcc\x24\x49\x48\x8b\x75\x9e\x22\x1f\x98\x50\x8c\x7e\xd2\x04\x18\x68\x16\xdc\x22\xe1\x6f\x7b\x7b\x90\x42\xc6\xca\xc1\xfa\x0f\x0f\x05\xc1\x93\x84\xc7\xc7\x3e\xb0\x44\x74\xc3\x2d\x86\x37\x4a\x27\xe0\xc8\x5f\x35\x86\xad\x10\xa9\xc5\x31\xdb\x61\x06\x0c\xf3\x30\x2c\x89\x29\x65\x02\x48\xa7\xc3\xd3\xa1\xa4\x31\x8c\x02\x6b\x0b\xbf\x2a\x36\x45\x12\x88\x44\x92\x94\xf4\x7b\xe6\x53\x36\x70\x01\x9f\xf8\x78\xe4\xfb\x34\x4b\xc4\x51\x43\x74\x9d\xfd\x70\x0f\xb6\x46\xbe\x85\x32\xb1\xa5\x8c\xfc\x44\x02\x68\xd6\xcd\x7b\x6e\x11\x6a\x57\x1e\xc8\xad\xb2\xa4\x11\x3e\xee\x03\x26\x39\x58\x16\x61\xee\xf4\x4c\x23\xa1\xc9\x12\x73\x9a\x31\x1f\x5f\x2f\x27\x5c\x4a\x98\x86\x0d\x00\x8c\xf8\x1c\x3e\xc0\x62\xeb\x82\xba\xc1\x02\x04\x40\xad\xcf\x8c\x66\x29\x77\x8c\x6f\xfd\xfe\x77\x65\xa0\x5c\xbe\x60\x2b\x0f\xc6\xd5\x67\x4a\x83\xea\xc5\x96\x9e\x2a\x51\x01\x00\x30\xfb\x7f\x18\xfd\x88\x70\xf5\x57\x9d\x1e\x40\x5f\x76\xfe\x73\x20\x90\x64\xd3\x6d\x06\x78\xc9\x39\xe5\xc7\x12\x87\x92\x71\x7f\xbe\x23\x9b\x02\x57\xd3\xe6\x4f\x59\x9a\x67\xeb\x1f\xd8\x17\xca\xd8\xad\x71\xf2\xf2\xe8\x78\x76\x16\x2a\xb6\xae\x5d\x20\xad\xd0\x1a\x47\xca\xab\x70\xe0\xf7\xdc\x44\x69\x5a\x53\x73\xbf\x41\x45\xb2\x24\xb4\x63\xfc\xab\x44\xbe\xb9\xe3\xe5\xd7\xab\x8f\xe3\xef\xea\x4b\x3e\x17\x60\xaf\xad\xd1\xf6\xbc\x2d\x79\x26\x74\xe3\x4d\xf0\x0e\x47\x0d\x9e\xa2\x68\xe4\xcf\x27\x84\x63\x9a\xb4\x41\xd1\x30\x2c\xb9\x16\x88\x41\xd6\x73\xef\x82\x44\xb8\xce\x95\xe6\x2b\x4a\xe1\x92\xfd\x72\xb5\x5a\x78\xae\x2a\x13\x07\xa0\xaf\x1e\xf2\x35\x38\xd1\x78\xf9\x58\x17\x98\x40\xf0\xe2\x43\x55\x86\x96\xfa\xa9\x73\x2e\xa0\xd8\x34\xf4\x2d\xa0\x17\xf3\xe5\xea\xb1\x97\x1b\xee\x6a\xb6\xb8\x5e\x55\x66\x9b\x81\x9b\x0e\xa5\x64\x41\x2a\x19\x56\x68\xd3\x80\x05\x67\x45\xc4\xcf\xc3\xf6\xb5\x66\x10\xd1\x70\x80\xbd\x43\x4c\x26\xa6\x0d\xb6\x00\xd8\x04\xcc\x62\xbf\xb6\x80\x50\x19\x9b\xfa\x37\x50\x70\xa7\x34\xa8\xf4\x98\x27\x07\x56\xae\x83\x06\x4a\xa4\x42\x38\x6f\x3a\x4a\xed\x5b\xc5\x8e\xcd\x05\x12\x18\x08\x6b\xaf\x54\xc4\x0a\xd6\x25\xc4\x14\xc7\xde\x79\x16\x82\xb9\x63\xb2\xb7\xe2\xdb\xe1\xf4\xbc\x64\x70\x6f\x48\xea\x4d\x68\x02\xf1\x03\xb2\xfc\x50\x4b\xc8\x6f\xa8\x54\x5b\xef\x2a\x81\x94\xdd\x21\x15\x5f\x27\x43\x6d\x19\x05\x5e\xc8\x68\xec\x5d\xc2\xdb\xde\x35\xcb\xf1\xe8\x93\x77\xb1\x9c\x4f\xbd\x4b\x78\xdb\xfb\xe7\xe2\x6a\xb2\x1a\x2f\x8f\x3b\xe8\x26\x5b\x63\x96\x60\xa1\xca\x60\x71\x02\x99\x57\xbf\xe4\xa6\x2f\x20\xec\x41\x3d\xd6\x39\x65\xbb\xe2\x8e\x6d\x57\xc0\x56\x80\x43\x94\x45\xc2\xe2\x3b\xdf\x39\x3b\x7b\x53\x17\x87\xa0\xf0\x16\x0c\x87\xe4\xae\xb1\x11\x18\x5e\xfa\xd7\xaa\x1c\x6e\x69\x66\x66\x1b\x0c\x66\xd4\x23\x4a\xb3\x62\xb9\xec\x7d\xc1\xf7\x05\x0d\xa0\xbc\x94\x51\xa8\xf8\x1c\x07\x95\x12\xef\x5d\x13\xf8\x36\x50\x5f\xad\x7d\x84\x68\x40\xfa\xf2\xf8\x0e\x7a\x3a\xc4\xd6\x5c\x4b\xdd\x89\x2a\x3e\x75\x63\xe9\xeb\xa3\x24\xa1\x42\x1d\x87\xd7\xd6\x73\x0f\xcd\xaf\x57\x9d\x29\x54\xab\x75\xcf\xf5\x50\x3e\x62\x1c\x66\xf2\xe8\x4f\xd7\x5b\x8e\x3f\x5f\xcd\x67\x55\x8d\x90\xd6\xd9\xc8\x8e\xa2\xe6\x91\x22\xf4\xd1\x2d\xaf\xd2\x8d\x24\x9c\x6c\xb6\x82\xdb\xaf\x1e\x3e\x4e\xae\x5d\x08\x2d\x6f\x36\x9a\x8e\x1f\x6d\x6d\xeb\x1a\x1c\x17\x30\x94\xc4\x60\xf3\xc2\xbb\x50\x42\xe6\xee\x2a\x17\x32\x4b\x4e\x68\x5d\xd4\xf3\x81\x53\xe0\x5c\x01\x59\x36\x58\x26\x5b\x95\x5e\xfd\xca\x72\xbd\x18\x2d\xdd\xae\xa8\x3e\x48\xeb\x0b\xca\x62\x74\x50\xd2\x7e\x70\x4d\xdb\x15\x89\xb1\x16\x29\x79\xe9\x02\x5a\x9d\xe1\x00\x66\xf0\x97\x39\x88\xcd\x41\xb0\x1a\x5c\x3a\x83\xa9\x33\x70\xad\xc1\xe4\xef\x46\xd7\x03\xe3\xf0\xaa\xfd\xe7\xfd\xc0\xc5\x2f\x9b\xbb\xba\x7b\x1e\x4f\xb1\x2f\x57\x39\x8e\xa0\xab\x53\x96\x73\xc6\x52\x7a\xa2\x89\xb6\x0b\xc3\xd9\x71\x9c\x46\xe0\x90\x42\x4c\x53\x55\xf9\xb6\x86\xf0\x14\x06\x6c\x5e\x28\xa1\xa2\xb8\xcc\xe1\x3d\xc5\x6c\x3b\x76\xd1\x42\x63\xb4\x81\x95\x0f\xc3\x77\x6f\x3e\x9c\x9d\x9d\xbc\x3b\x3b\x3d\xb3\x82\x1b\x66\x61\x9f\x59\x19\x37\x6f\x31\x17\xe6\xa9\x95\xdb\x05\x42\x14\xe2\x23\x96\xa1\x6a\x86\x94\x99\x15\x9c\x73\x6a\x9d\xe8\xbd\x4d\xa1\x2e\xb2\x28\x5a\x50\x08\xd7\x7b\xc7\x18\x45\xb7\xe8\xbe\xaa\x7b\x38\xd9\x55\x67\xda\x6b\x57\xa5\x4a\xaf\x0a\x0d\x28\xcb\x19\xbe\x80\x22\xec\xf4\x6a\x99\xba\x9f\x6b\x20\x92\x8a\x31\x4d\x7f\xba\xae\x19\xb5\xb2\x8c\x41\x3f\xed\xbe\xd0\xd0\x4b\xcf\xc0\xdf\xaf\x99\x7e\xd9\x69\xa8\xa6\x8d\x20\xbf\x5f\x33\xed\x0a\xd5\xae\x98\x1c\x60\xfe\x27\xb5\xe4\x25\xae\xa1\x54\xbd\x75\xff\x7e\xcd\xca\xab\x64\xd3\x5c\xfb\x62\xdd\xad\x54\x48\x70\x14\xb4\x68\xa3\xe8\x72\x98\x73\x54\x31\xb0\x12\x18\xcd\x66\x7a\xcc\xd4\x2e\x65\x65\xef\x90\xa3\x13\xaf\x63\xc5\x50\x37\x19\xa8\x7b\x3a\x1c\x4e\x89\xb6\xc2\xf0\x3f\x19\x94\x83\x03\x6e\x3f\xcd\x1c\x98\xba\x86\x71\x2b\xc6\x49\x0d\x63\x47\xa3\x2c\xc6\x53\x79\xc9\xe1\xcd\xfc\xcf\x2d\x09\x86\x54\x63\x9f\x06\x17\x4b\x81\xfc\x68\xad\x13\x62\x03\x09\x98\xf4\x61\xb5\x89\x50\x5f\x95\x7e\x99\x27\x11\xa8\x5b\x34\xc3\x26\x1a\x59\xe7\x7d\xae\xaa\xad\xc7\xd0\xc9\xda\xce\xd9\xed\x56\xfe\x8e\xfd\xda\xee\x65\x6d\x5b\x69\x66\xc0\xc2\xb7\x0b\x2e\x08\xc0\x98\x24\x6a\x42\xf8\xcc\xa0\xa9\x2d\x60\x9a\xa0\x81\x8b\x01\x2a\xe0\x4e\x35\xdf\xe6\xbe\x78\xa2\x45\x34\x9c\xb0\xa5\x3c\xdf\xb6\xa7\x5f\x98\xba\x3c\xf2\x84\x3f\x3a\xc0\x2a\xd6\x5f\xf4\x40\x17\xde\x11\x77\x74\xdb\xbc\x2c\x02\x3a\xfa\x71\x21\x5e\xbb\xce\xcf\xda\x5b\xaf\xa0\x11\x66\xf9\xb4\x5a\xf9\x40\x95\x09\x99\xb9\xea\x3f\x05\x96\x36\xd8\x13\x6a\xc7\x48\x56\x97\x52\x0b\x9a\x4a\x79\x98\x37\x8c\xf1\x1d\xdc\x37\xb5\xe6\x1a\x86\x30\x88\x38\xc6\x8c\xba\xfe\x16\x07\x59\x54\xb9\xa3\x92\xe9\xe7\x42\xfd\x86\x54\x7f\x46\xc7\x77\xd8\xcf\x04\xee\x3f\x53\x6c\xbf\x5b\xbf\xf7\x1f\x31\xb3\xb3\xd5\x15\x14\x00\x00")
+
+func fluentBitYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_fluentBitYaml,
+		"fluent-bit.yaml",
+	)
+}
+
+func fluentBitYaml() (*asset, error) {
+	bytes, err := fluentBitYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "fluent-bit.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"cloudwatch-agent.yaml": cloudwatchAgentYaml,
+	"fluent-bit.yaml": fluentBitYaml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"cloudwatch-agent.yaml": &bintree{cloudwatchAgentYaml, map[string]*bintree{}},
+	"fluent-bit.yaml": &bintree{fluentBitYaml, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
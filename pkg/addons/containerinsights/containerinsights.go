@@ -0,0 +1,226 @@
+package containerinsights
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// Namespace is where all of Container Insights components get deployed
+	Namespace = "amazon-cloudwatch"
+	// CloudWatchAgent is the name of the CloudWatch agent DaemonSet
+	CloudWatchAgent = "cloudwatch-agent"
+	// FluentBit is the name of the Fluent Bit DaemonSet
+	FluentBit = "fluent-bit"
+
+	// CloudWatchAgentServerPolicy is the managed policy that allows nodes to publish metrics and logs
+	CloudWatchAgentServerPolicy = "CloudWatchAgentServerPolicy"
+
+	// RoleARNAnnotation is the annotation of service accounts with the IAM role their pods assume
+	RoleARNAnnotation = "eks.amazonaws.com/role-arn"
+
+	fluentBitImagePrefix = "906394416424.dkr.ecr."
+	fluentBitImageSuffix = ".amazonaws.com/aws-for-fluent-bit"
+
+	clusterNamePlaceholder = "{{cluster_name}}"
+	regionPlaceholder      = "{{region_name}}"
+)
+
+// ServiceAccounts returns the namespaced names of the service accounts of CloudWatch agent and
+// Fluent Bit, which can be given an IAM role instead of using the instance roles of nodes
+func ServiceAccounts() []string {
+	return []string{
+		Namespace + ":" + CloudWatchAgent,
+		Namespace + ":" + FluentBit,
+	}
+}
+
+// Deploy creates or replaces CloudWatch agent and Fluent Bit along with their configuration, when
+// roleARN is set, their service accounts are annotated with it; in plan mode it only logs the objects
+// that differ from the manifests; it returns true when changes are required
+func Deploy(rawClient kubernetes.RawClientInterface, spec *api.ClusterConfig, roleARN string, plan bool) (bool, error) {
+	changesRequired := false
+	region := spec.Metadata.Region
+
+	for _, name := range []string{CloudWatchAgent, FluentBit} {
+		list, err := loadAsset(name, spec.Metadata.Name, region)
+		if err != nil {
+			return false, err
+		}
+
+		for _, rawObj := range list.Items {
+			resource, err := rawClient.NewRawResource(rawObj)
+			if err != nil {
+				return false, err
+			}
+
+			switch obj := resource.Info.Object.(type) {
+			case *appsv1.DaemonSet:
+				if err := useRegionalImage(obj, region); err != nil {
+					return false, err
+				}
+				mirrorImages(obj.Spec.Template.Spec.Containers, spec)
+			case *corev1.ServiceAccount:
+				if roleARN != "" {
+					if obj.Annotations == nil {
+						obj.Annotations = map[string]string{}
+					}
+					obj.Annotations[RoleARNAnnotation] = roleARN
+				}
+			}
+
+			if plan {
+				patch, exists, err := resource.Diff()
+				if err != nil {
+					return false, err
+				}
+				switch {
+				case !exists:
+					logger.Info("%s", resource.LogAction(plan, "created"))
+				case patch != nil:
+					logger.Info("%s", resource.LogAction(plan, "replaced"))
+					logger.Info("(plan) changes to %q: %s", resource, string(patch))
+				default:
+					logger.Debug("%q is up-to-date", resource)
+					continue
+				}
+				changesRequired = true
+				continue
+			}
+
+			status, err := resource.CreateOrReplace(plan)
+			if err != nil {
+				return false, err
+			}
+			logger.Info("%s", status)
+		}
+	}
+
+	if plan {
+		if changesRequired {
+			logger.Critical("(plan) Container Insights is not up-to-date in %q namespace", Namespace)
+		} else {
+			logger.Info("Container Insights is up-to-date in %q namespace", Namespace)
+		}
+		return changesRequired, nil
+	}
+
+	logger.Info("Container Insights has been deployed to %q namespace", Namespace)
+	return false, nil
+}
+
+func loadAsset(name, clusterName, region string) (*metav1.List, error) {
+	data, err := Asset(name + ".yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding embedded manifest for %q", name)
+	}
+
+	manifest := strings.Replace(string(data), clusterNamePlaceholder, clusterName, -1)
+	manifest = strings.Replace(manifest, regionPlaceholder, region, -1)
+
+	list, err := kubernetes.NewList([]byte(manifest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading individual resources from manifest for %q", name)
+	}
+	return list, nil
+}
+
+func useRegionalImage(ds *appsv1.DaemonSet, region string) error {
+	for i := range ds.Spec.Template.Spec.Containers {
+		image := &ds.Spec.Template.Spec.Containers[i].Image
+		imageParts := strings.Split(*image, ":")
+
+		if len(imageParts) != 2 {
+			return fmt.Errorf("unexpected image format %q for %q", *image, ds.Name)
+		}
+
+		if strings.HasPrefix(imageParts[0], fluentBitImagePrefix) &&
+			strings.HasSuffix(imageParts[0], fluentBitImageSuffix) {
+			*image = fluentBitImagePrefix + region + fluentBitImageSuffix + ":" + imageParts[1]
+		}
+	}
+	return nil
+}
+
+func mirrorImages(containers []corev1.Container, spec *api.ClusterConfig) {
+	for i := range containers {
+		containers[i].Image = spec.MirroredImage(containers[i].Image)
+	}
+}
+
+// AttachNodeRolePolicy attaches CloudWatchAgentServerPolicy to instance roles
+// of the nodegroups described by the given stacks
+func AttachNodeRolePolicy(provider api.ClusterProvider, stacks []*cfn.Stack, plan bool) (bool, error) {
+	changesRequired := false
+	policyARN := api.ManagedPolicyARN(provider.Region(), CloudWatchAgentServerPolicy)
+
+	for _, s := range stacks {
+		ng := &api.NodeGroup{}
+		if err := iam.UseFromNodeGroup(provider, s, ng); err != nil {
+			return false, errors.Wrapf(err, "getting instance role of stack %q", *s.StackName)
+		}
+
+		roleARNParts := strings.Split(ng.IAM.InstanceRoleARN, "/")
+		roleName := roleARNParts[len(roleARNParts)-1]
+
+		attached, err := isPolicyAttached(provider, roleName, policyARN)
+		if err != nil {
+			return false, err
+		}
+		if attached {
+			logger.Info("instance role %q already has %q attached", roleName, policyARN)
+			continue
+		}
+
+		changesRequired = true
+		if plan {
+			logger.Info("(plan) would have attached %q to instance role %q", policyARN, roleName)
+			continue
+		}
+
+		input := &awsiam.AttachRolePolicyInput{
+			RoleName:  &roleName,
+			PolicyArn: aws.String(policyARN),
+		}
+		if _, err := provider.IAM().AttachRolePolicy(input); err != nil {
+			return false, errors.Wrapf(err, "attaching %q to instance role %q", policyARN, roleName)
+		}
+		logger.Info("attached %q to instance role %q", policyARN, roleName)
+	}
+
+	return plan && changesRequired, nil
+}
+
+func isPolicyAttached(provider api.ClusterProvider, roleName, policyARN string) (bool, error) {
+	attached := false
+	input := &awsiam.ListAttachedRolePoliciesInput{
+		RoleName: &roleName,
+	}
+	pager := func(p *awsiam.ListAttachedRolePoliciesOutput, _ bool) bool {
+		for _, policy := range p.AttachedPolicies {
+			if policy.PolicyArn != nil && *policy.PolicyArn == policyARN {
+				attached = true
+				return false
+			}
+		}
+		return true
+	}
+	if err := provider.IAM().ListAttachedRolePoliciesPages(input, pager); err != nil {
+		return false, errors.Wrapf(err, "listing policies attached to instance role %q", roleName)
+	}
+	return attached, nil
+}
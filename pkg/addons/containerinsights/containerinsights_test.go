@@ -0,0 +1,99 @@
+package containerinsights_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/containerinsights"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("Container Insights", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+	})
+
+	It("can create all objects with regional images and cluster configuration", func() {
+		rawClient := testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+
+		_, err := Deploy(rawClient, cfg, "", false)
+		Expect(err).ToNot(HaveOccurred())
+
+		ct := rawClient.Collection
+		Expect(ct.Updated()).To(BeEmpty())
+		Expect(ct.CreatedItems()).To(HaveLen(12))
+
+		fluentBit, err := rawClient.ClientSet().AppsV1().DaemonSets(Namespace).Get(FluentBit, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fluentBit.Spec.Template.Spec.Containers[0].Image).To(
+			Equal("906394416424.dkr.ecr.eu-west-1.amazonaws.com/aws-for-fluent-bit:2.10.0"),
+		)
+
+		clusterInfo, err := rawClient.ClientSet().CoreV1().ConfigMaps(Namespace).Get("fluent-bit-cluster-info", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusterInfo.Data["cluster.name"]).To(Equal("test-cluster"))
+		Expect(clusterInfo.Data["logs.region"]).To(Equal("eu-west-1"))
+
+		agentConfig, err := rawClient.ClientSet().CoreV1().ConfigMaps(Namespace).Get("cwagentconfig", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(agentConfig.Data["cwagentconfig.json"]).To(ContainSubstring(`"cluster_name": "test-cluster"`))
+	})
+
+	It("uses the offline image registry", func() {
+		rawClient := testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+		cfg.ContainerRuntime = &api.ClusterContainerRuntime{
+			Offline: &api.OfflineConfig{ImageRegistry: "registry.example.com:5000"},
+		}
+
+		_, err := Deploy(rawClient, cfg, "", false)
+		Expect(err).ToNot(HaveOccurred())
+
+		agent, err := rawClient.ClientSet().AppsV1().DaemonSets(Namespace).Get(CloudWatchAgent, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(agent.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.example.com:5000/amazon/cloudwatch-agent:1.230621.0"))
+
+		fluentBit, err := rawClient.ClientSet().AppsV1().DaemonSets(Namespace).Get(FluentBit, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fluentBit.Spec.Template.Spec.Containers[0].Image).To(Equal("registry.example.com:5000/aws-for-fluent-bit:2.10.0"))
+	})
+
+	It("annotates the service accounts with the IAM role", func() {
+		rawClient := testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+
+		_, err := Deploy(rawClient, cfg, "arn:aws:iam::123456789012:role/container-insights", false)
+		Expect(err).ToNot(HaveOccurred())
+
+		for _, name := range []string{CloudWatchAgent, FluentBit} {
+			sa, err := rawClient.ClientSet().CoreV1().ServiceAccounts(Namespace).Get(name, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sa.Annotations).To(HaveKeyWithValue(RoleARNAnnotation, "arn:aws:iam::123456789012:role/container-insights"))
+		}
+	})
+
+	It("only requires changes in plan mode when objects differ from the manifests", func() {
+		rawClient := testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+
+		changesRequired, err := Deploy(rawClient, cfg, "", true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeTrue())
+		Expect(rawClient.Collection.CreatedItems()).To(BeEmpty())
+
+		// the fake client serves the desired objects as the live ones
+		rawClient.AssumeObjectsMissing = false
+		changesRequired, err = Deploy(rawClient, cfg, "", true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeFalse())
+		Expect(rawClient.Collection.UpdatedItems()).To(BeEmpty())
+	})
+})
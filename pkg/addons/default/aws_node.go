@@ -2,15 +2,20 @@ package defaultaddons
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/blang/semver"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
 
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 )
 
@@ -18,8 +23,9 @@ const (
 	// AWSNode is the name of the aws-node addon
 	AWSNode = "aws-node"
 
-	awsNodeImagePrefix = "602401143452.dkr.ecr."
-	awsNodeImageSuffix = ".amazonaws.com/amazon-k8s-cni"
+	awsNodeImagePrefix     = "602401143452.dkr.ecr."
+	awsNodeImageSuffix     = ".amazonaws.com/amazon-k8s-cni"
+	awsNodeImageRepository = "/amazon-k8s-cni"
 )
 
 // UpdateAWSNode will update the `aws-node` add-on
@@ -54,14 +60,14 @@ func UpdateAWSNode(rawClient kubernetes.RawClientInterface, region, controlPlane
 
 			if strings.HasPrefix(imageParts[0], awsNodeImagePrefix) &&
 				strings.HasSuffix(imageParts[0], awsNodeImageSuffix) {
-				*image = awsNodeImagePrefix + region + awsNodeImageSuffix + ":" + imageParts[1]
+				*image = api.EKSImageRegistry(region) + awsNodeImageRepository + ":" + imageParts[1]
 			}
 		}
 
 		if resource.GVK.Kind == "CustomResourceDefinition" && plan {
 			// eniconfigs.crd.k8s.amazonaws.com CRD is only partially defined in the
 			// manifest, and causes a range of issue in plan mode, we can skip it
-			logger.Info(resource.LogAction(plan, "replaced"))
+			logger.Info("%s", resource.LogAction(plan, "replaced"))
 			continue
 		}
 
@@ -69,7 +75,7 @@ func UpdateAWSNode(rawClient kubernetes.RawClientInterface, region, controlPlane
 		if err != nil {
 			return false, err
 		}
-		logger.Info(status)
+		logger.Info("%s", status)
 	}
 
 	if plan {
@@ -80,3 +86,108 @@ func UpdateAWSNode(rawClient kubernetes.RawClientInterface, region, controlPlane
 	logger.Info("%q is now up-to-date", AWSNode)
 	return false, nil
 }
+
+// AWSNodeVersion returns the version of the `aws-node` add-on running in the
+// cluster, based on the image tag of the DaemonSet
+func AWSNodeVersion(clientSet kubeclient.Interface) (string, error) {
+	d, err := clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(AWSNode, metav1.GetOptions{})
+	if err != nil {
+		return "", errors.Wrapf(err, "getting %q", AWSNode)
+	}
+	if numContainers := len(d.Spec.Template.Spec.Containers); !(numContainers >= 1) {
+		return "", fmt.Errorf("%s has %d containers, expected at least 1", AWSNode, numContainers)
+	}
+
+	image := d.Spec.Template.Spec.Containers[0].Image
+	imageParts := strings.Split(image, ":")
+
+	if len(imageParts) != 2 {
+		return "", fmt.Errorf("unexpected image format %q for %q", image, AWSNode)
+	}
+
+	return strings.TrimPrefix(imageParts[1], "v"), nil
+}
+
+// ConfigureAWSNode sets the environment variables of the `aws-node` add-on from
+// the VPC CNI settings, prefix delegation requires a recent enough version of
+// the add-on; in plan mode it only logs what would change, it returns true
+// when changes are required
+func ConfigureAWSNode(clientSet kubeclient.Interface, cni *api.VPCCNI, plan bool) (bool, error) {
+	if cni == nil {
+		return false, nil
+	}
+
+	if api.IsEnabled(cni.PrefixDelegation) {
+		version, err := AWSNodeVersion(clientSet)
+		if err != nil {
+			return false, err
+		}
+		v, err := semver.ParseTolerant(version)
+		if err != nil {
+			return false, errors.Wrapf(err, "parsing %q version", AWSNode)
+		}
+		if v.LT(semver.MustParse(api.PrefixDelegationMinimumVPCCNIVersion)) {
+			return false, fmt.Errorf("%q version %s doesn't support prefix delegation, version %s or above is required", AWSNode, version, api.PrefixDelegationMinimumVPCCNIVersion)
+		}
+	}
+
+	d, err := clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(AWSNode, metav1.GetOptions{})
+	if err != nil {
+		return false, errors.Wrapf(err, "getting %q", AWSNode)
+	}
+	if len(d.Spec.Template.Spec.Containers) == 0 {
+		return false, fmt.Errorf("%s has no containers", AWSNode)
+	}
+
+	env := map[string]string{}
+	if cni.PrefixDelegation != nil {
+		env["ENABLE_PREFIX_DELEGATION"] = strconv.FormatBool(*cni.PrefixDelegation)
+	}
+	if cni.WarmPrefixTarget != nil {
+		env["WARM_PREFIX_TARGET"] = strconv.Itoa(*cni.WarmPrefixTarget)
+	}
+	if cni.WarmIPTarget != nil {
+		env["WARM_IP_TARGET"] = strconv.Itoa(*cni.WarmIPTarget)
+	}
+
+	container := &d.Spec.Template.Spec.Containers[0]
+	changed := false
+	for _, name := range []string{"ENABLE_PREFIX_DELEGATION", "WARM_PREFIX_TARGET", "WARM_IP_TARGET"} {
+		value, ok := env[name]
+		if ok && setEnvVar(container, name, value) {
+			logger.Info("setting %s=%s in %q", name, value, AWSNode)
+			changed = true
+		}
+	}
+
+	if !changed {
+		logger.Info("VPC CNI settings of %q are already up-to-date", AWSNode)
+		return false, nil
+	}
+	if plan {
+		logger.Critical("(plan) VPC CNI settings of %q are not up-to-date", AWSNode)
+		return true, nil
+	}
+	if _, err := clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).Update(d); err != nil {
+		return false, errors.Wrapf(err, "updating %q", AWSNode)
+	}
+	logger.Info("VPC CNI settings of %q are now up-to-date", AWSNode)
+	return false, nil
+}
+
+// setEnvVar sets an environment variable of the container, it returns
+// true when the variable was added or its value has changed
+func setEnvVar(container *corev1.Container, name, value string) bool {
+	for i := range container.Env {
+		if container.Env[i].Name == name {
+			if container.Env[i].Value == value && container.Env[i].ValueFrom == nil {
+				return false
+			}
+			container.Env[i].Value = value
+			container.Env[i].ValueFrom = nil
+			return true
+		}
+	}
+	container.Env = append(container.Env, corev1.EnvVar{Name: name, Value: value})
+	return true
+}
@@ -11,6 +11,7 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 var _ = Describe("default addons - aws-node", func() {
@@ -89,4 +90,75 @@ var _ = Describe("default addons - aws-node", func() {
 			)
 		})
 	})
+
+	Describe("can get aws-node version", func() {
+		It("can get version from 1.12 sample", func() {
+			clientSet, _ := testutils.NewFakeClientSetWithSamples("testdata/sample-1.12.json")
+
+			version, err := AWSNodeVersion(clientSet)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(version).To(Equal("1.4.1"))
+		})
+	})
+
+	Describe("can configure aws-node", func() {
+		var clientSet *fake.Clientset
+
+		getEnv := func() map[string]string {
+			awsNode, err := clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(AWSNode, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			env := map[string]string{}
+			for _, e := range awsNode.Spec.Template.Spec.Containers[0].Env {
+				env[e.Name] = e.Value
+			}
+			return env
+		}
+
+		setImageTag := func(tag string) {
+			awsNode, err := clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(AWSNode, metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+			awsNode.Spec.Template.Spec.Containers[0].Image = "602401143452.dkr.ecr.eu-west-1.amazonaws.com/amazon-k8s-cni:" + tag
+			_, err = clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).Update(awsNode)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		BeforeEach(func() {
+			clientSet, _ = testutils.NewFakeClientSetWithSamples("testdata/sample-1.12.json")
+		})
+
+		It("rejects prefix delegation with an old version", func() {
+			_, err := ConfigureAWSNode(clientSet, &api.VPCCNI{PrefixDelegation: api.Enabled()}, false)
+			Expect(err).To(MatchError(ContainSubstring("doesn't support prefix delegation")))
+		})
+
+		It("sets environment variables of prefix delegation", func() {
+			setImageTag("v1.9.0")
+			warmPrefixTarget := 1
+
+			cni := &api.VPCCNI{PrefixDelegation: api.Enabled(), WarmPrefixTarget: &warmPrefixTarget}
+
+			changed, err := ConfigureAWSNode(clientSet, cni, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(getEnv()).ToNot(HaveKey("ENABLE_PREFIX_DELEGATION"))
+
+			_, err = ConfigureAWSNode(clientSet, cni, false)
+			Expect(err).ToNot(HaveOccurred())
+			env := getEnv()
+			Expect(env).To(HaveKeyWithValue("ENABLE_PREFIX_DELEGATION", "true"))
+			Expect(env).To(HaveKeyWithValue("WARM_PREFIX_TARGET", "1"))
+			Expect(env).ToNot(HaveKey("WARM_IP_TARGET"))
+
+			changed, err = ConfigureAWSNode(clientSet, cni, true)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+		})
+
+		It("sets the warm IP target with any version", func() {
+			warmIPTarget := 5
+			_, err := ConfigureAWSNode(clientSet, &api.VPCCNI{WarmIPTarget: &warmIPTarget}, false)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getEnv()).To(HaveKeyWithValue("WARM_IP_TARGET", "5"))
+		})
+	})
 })
@@ -12,6 +12,7 @@ import (
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/kubernetes"
 )
 
@@ -21,8 +22,9 @@ const (
 	// KubeDNS is the name of the kube-dns addon
 	KubeDNS = "kube-dns"
 
-	coreDNSImagePrefix = "602401143452.dkr.ecr."
-	coreDNSImageSuffix = ".amazonaws.com/eks/coredns"
+	coreDNSImagePrefix     = "602401143452.dkr.ecr."
+	coreDNSImageSuffix     = ".amazonaws.com/eks/coredns"
+	coreDNSImageRepository = "/eks/coredns"
 )
 
 // UpdateCoreDNS will update the `coredns` add-on
@@ -67,7 +69,7 @@ func UpdateCoreDNS(rawClient kubernetes.RawClientInterface, region, controlPlane
 
 			if strings.HasPrefix(imageParts[0], coreDNSImagePrefix) &&
 				strings.HasSuffix(imageParts[0], coreDNSImageSuffix) {
-				*image = coreDNSImagePrefix + region + coreDNSImageSuffix + ":" + imageParts[1]
+				*image = api.EKSImageRegistry(region) + coreDNSImageRepository + ":" + imageParts[1]
 			}
 		case "Service":
 			resource.Info.Object.(*corev1.Service).SetResourceVersion(kubeDNSSevice.GetResourceVersion())
@@ -78,7 +80,7 @@ func UpdateCoreDNS(rawClient kubernetes.RawClientInterface, region, controlPlane
 		if err != nil {
 			return false, err
 		}
-		logger.Info(status)
+		logger.Info("%s", status)
 	}
 
 	if plan {
@@ -0,0 +1,125 @@
+package defaultaddons
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+)
+
+// AddonVersion is the image tag of a default add-on running in the cluster,
+// along with the image tag it would be updated to
+type AddonVersion struct {
+	Name    string
+	Current string
+	Latest  string
+}
+
+// UpdateAvailable returns true when the add-on is deployed with another version
+func (v *AddonVersion) UpdateAvailable() bool {
+	return v.Current != "" && v.Current != v.Latest
+}
+
+// AddonVersions returns the versions of kube-proxy, aws-node and coredns, the
+// current version of add-ons that aren't deployed is empty
+func AddonVersions(clientSet kubeclient.Interface, controlPlaneVersion string) ([]*AddonVersion, error) {
+	kubeProxy := &AddonVersion{Name: KubeProxy, Latest: "v" + controlPlaneVersion}
+	awsNode := &AddonVersion{Name: AWSNode}
+	coreDNS := &AddonVersion{Name: CoreDNS}
+
+	var err error
+	if kubeProxy.Current, err = deployedImageTag(clientSet, "DaemonSet", KubeProxy); err != nil {
+		return nil, err
+	}
+
+	if awsNode.Current, err = deployedImageTag(clientSet, "DaemonSet", AWSNode); err != nil {
+		return nil, err
+	}
+	list, err := LoadAsset(AWSNode, "yaml")
+	if err != nil {
+		return nil, err
+	}
+	if awsNode.Latest, err = manifestImageTag(list, "DaemonSet", AWSNode); err != nil {
+		return nil, err
+	}
+
+	if coreDNS.Current, err = deployedImageTag(clientSet, "Deployment", CoreDNS); err != nil {
+		return nil, err
+	}
+	if list, err = loadAssetCoreDNS(controlPlaneVersion); err != nil {
+		return nil, err
+	}
+	if coreDNS.Latest, err = manifestImageTag(list, "Deployment", CoreDNS); err != nil {
+		return nil, err
+	}
+
+	return []*AddonVersion{kubeProxy, awsNode, coreDNS}, nil
+}
+
+// deployedImageTag returns the image tag of the first container of a DaemonSet or
+// Deployment in kube-system, or an empty string when it doesn't exist
+func deployedImageTag(clientSet kubeclient.Interface, kind, name string) (string, error) {
+	var (
+		podSpec corev1.PodSpec
+		err     error
+	)
+	switch kind {
+	case "DaemonSet":
+		d, getErr := clientSet.AppsV1().DaemonSets(metav1.NamespaceSystem).Get(name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			podSpec = d.Spec.Template.Spec
+		}
+	default:
+		d, getErr := clientSet.AppsV1().Deployments(metav1.NamespaceSystem).Get(name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			podSpec = d.Spec.Template.Spec
+		}
+	}
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "getting %q", name)
+	}
+	if len(podSpec.Containers) < 1 {
+		return "", fmt.Errorf("%s has no containers", name)
+	}
+	return imageTag(podSpec.Containers[0].Image, name)
+}
+
+// manifestImageTag returns the image tag of the first container of the object of
+// the given kind in an embedded manifest
+func manifestImageTag(list *metav1.List, kind, name string) (string, error) {
+	for _, rawObj := range list.Items {
+		obj := struct {
+			Kind string `json:"kind"`
+			Spec struct {
+				Template struct {
+					Spec corev1.PodSpec `json:"spec"`
+				} `json:"template"`
+			} `json:"spec"`
+		}{}
+		if err := json.Unmarshal(rawObj.Raw, &obj); err != nil {
+			return "", errors.Wrapf(err, "decoding manifest of %q", name)
+		}
+		if obj.Kind != kind || len(obj.Spec.Template.Spec.Containers) < 1 {
+			continue
+		}
+		return imageTag(obj.Spec.Template.Spec.Containers[0].Image, name)
+	}
+	return "", fmt.Errorf("no %s found in manifest of %q", kind, name)
+}
+
+func imageTag(image, name string) (string, error) {
+	imageParts := strings.Split(image, ":")
+	if len(imageParts) != 2 {
+		return "", fmt.Errorf("unexpected image format %q for %q", image, name)
+	}
+	return imageParts[1], nil
+}
@@ -0,0 +1,41 @@
+package defaultaddons_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/default"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("default addons - versions", func() {
+	var clientSet *fake.Clientset
+
+	BeforeEach(func() {
+		clientSet, _ = testutils.NewFakeClientSetWithSamples("testdata/sample-1.12.json")
+	})
+
+	It("reports add-ons that are up-to-date", func() {
+		versions, err := AddonVersions(clientSet, "1.12.6")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions).To(HaveLen(3))
+		for _, v := range versions {
+			Expect(v.UpdateAvailable()).To(BeFalse(), v.Name)
+		}
+	})
+
+	It("reports updates once the control plane is upgraded", func() {
+		versions, err := AddonVersions(clientSet, "1.13.0")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(versions).To(Equal([]*AddonVersion{
+			{Name: KubeProxy, Current: "v1.12.6", Latest: "v1.13.0"},
+			{Name: AWSNode, Current: "v1.4.1", Latest: "v1.4.1"},
+			{Name: CoreDNS, Current: "v1.2.2", Latest: "v1.2.6"},
+		}))
+		Expect(versions[0].UpdateAvailable()).To(BeTrue())
+		Expect(versions[1].UpdateAvailable()).To(BeFalse())
+		Expect(versions[2].UpdateAvailable()).To(BeTrue())
+	})
+})
@@ -0,0 +1,423 @@
+// Code generated by go-bindata.
+// sources:
+// assets/chart/Chart.yaml
+// assets/chart/templates/_helpers.tpl
+// assets/chart/templates/controller.yaml
+// assets/chart/templates/node.yaml
+// assets/chart/templates/rbac.yaml
+// assets/chart/templates/serviceaccount.yaml
+// assets/chart/templates/storageclass.yaml
+// assets/chart/values.yaml
+// assets/iam-policy.json
+// DO NOT EDIT!
+
+package ebscsi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _chartChartYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcb\x4b\xcc\x4d\xb5\x52\x48\x2c\x2f\xd6\x4d\x4d\x2a\xd6\x4d\x2e\xce\xd4\x4d\x29\xca\x2c\x4b\x2d\xe2\x02\xe2\xe2\xcc\xfc\x3c\x2b\x05\x03\x3d\x63\x3d\x03\xae\xc4\x82\x82\x30\x98\x48\x99\x81\x9e\x09\x50\x28\x25\xb5\x38\xb9\x28\xb3\xa0\x04\x2c\xe6\x1c\xec\xa9\x00\xd1\xa9\x90\x96\x5f\xa4\xe0\x98\x9b\x58\x95\x9f\xa7\xe0\xea\x14\xac\x50\x96\x9f\x53\x9a\x9b\x5a\xcc\x05\x00\xa1\xa9\x73\xca\x6a\x00\x00\x00")
+
+func chartChartYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartChartYaml,
+		"chart/Chart.yaml",
+	)
+}
+
+func chartChartYaml() (*asset, error) {
+	bytes, err := chartChartYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/Chart.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesHelpersTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x8f\xb1\x0e\xc2\x30\x0c\x44\xf7\x7e\x85\xd5\x3d\x61\xef\x86\xd8\x19\x18\xd8\x9d\xe6\x80\xa8\xa9\x1b\xc5\x01\x09\x45\xfd\x77\x22\x60\x60\xa8\x18\xef\xf4\xee\xec\xab\xd5\x90\xc7\x25\x08\xa8\x87\x53\x33\x6a\x30\x3e\x87\x07\xb2\x8d\xec\x10\xb5\x27\xb3\xae\x1d\xa7\x64\xa7\xbb\x43\x16\x14\xa8\x0d\xcb\x4e\x78\xc6\x40\xb5\x92\x3d\xdc\x38\x17\x7b\x6c\x9a\x36\xc9\x20\x5a\x58\xc6\x2f\x7d\x42\x04\x2b\xfe\xf0\xed\xb6\x86\x45\x7e\xcb\xf7\x29\x9d\x3f\xee\x76\x64\x66\xe1\x2b\xbc\x71\xcf\x81\x30\xe9\x58\x62\x57\xdb\x30\x88\x7f\x7f\xff\x02\x59\xf3\x61\x95\xe5\x00\x00\x00")
+
+func chartTemplatesHelpersTplBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesHelpersTpl,
+		"chart/templates/_helpers.tpl",
+	)
+}
+
+func chartTemplatesHelpersTpl() (*asset, error) {
+	bytes, err := chartTemplatesHelpersTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/_helpers.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesControllerYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdd\x56\x4d\x6f\xda\x40\x10\xbd\xf3\x2b\x56\x51\x23\x25\x07\x9b\x44\x6d\xa4\xd4\x12\x07\x04\x28\x42\x6a\x09\x0a\x28\x3d\x46\xcb\x7a\x82\x57\x59\x7b\xdd\x9d\xb5\x1b\x37\xcd\x7f\xef\x2c\x36\xce\x12\x20\x0d\x52\xd5\x43\xb9\xd8\xcc\xbc\x79\xfb\xe6\x6b\x81\xe7\xf2\x16\x0c\x4a\x9d\x45\x8c\xe7\x39\x76\xcb\xf3\xce\x83\xcc\xe2\x88\x0d\x21\x57\xba\x4a\x21\xb3\x9d\x14\x2c\x8f\xb9\xe5\x51\x87\xb1\x8c\xa7\x10\x31\x58\x60\x20\x50\x06\x42\x67\xd6\x68\xa5\xc0\x34\x2e\xcc\xb9\x20\xff\xd3\x13\x0b\x6f\x40\x01\x47\x08\x27\x6b\x33\x7b\x7e\x26\x94\xe2\x0b\x50\xe8\xa8\x18\xc1\x02\x26\x33\xa1\x8a\x18\xd8\xd1\x9a\x33\x36\xb2\x04\x13\xd6\xb8\x23\x16\xb2\x5f\x2c\x23\x45\x24\x84\x7d\x72\x14\x98\x83\x70\xe1\x86\x04\x4a\xc1\xb1\x3e\xed\x96\xab\x02\x30\x6c\x8c\x03\x5d\x10\x7c\x75\x1e\x92\x0c\x61\xb5\xa9\x4f\x4c\xb9\x15\xc9\x17\x4f\x02\x73\x69\xef\x49\xc8\x42\x9a\x2b\x6e\xa1\x09\xf5\xaa\xe0\x3e\x6a\x83\xe5\x2d\x1e\x12\xd1\x68\x5e\xbd\x83\x29\xa5\x80\xbe\x10\x4e\xe4\x64\x4f\x3d\x03\xe4\x0d\x3e\x37\x52\x1b\x69\xab\x81\xe2\x88\x35\x1c\x2b\x24\x6d\x01\x15\x8e\x9e\x26\x10\xe4\xa6\xa4\x55\x13\x60\x35\xc5\x73\x4b\x3d\x6d\xd5\x05\xec\x01\xaa\x88\x0d\x1a\x60\x3f\x8e\xc9\x79\x9d\xa9\xaa\x55\xaf\x73\x17\x43\x65\x62\xa3\x47\x89\x16\x1b\x87\x13\xc4\x65\x46\x23\xf2\x42\xf5\x32\x02\xb9\x2a\x96\x32\x6b\x39\x64\xca\x97\x75\xf3\x57\x6f\xec\x84\xa4\x67\xf6\x9e\x1d\x1d\x63\x74\xec\x5a\xd9\x34\x69\xe5\x75\xad\xd2\x28\xe9\xc8\xea\x95\xc3\xf2\xe5\x69\xdd\xbb\xa6\xb0\x66\xe9\x95\x39\x60\x41\x00\x59\x9c\x6b\xa2\xee\x7d\x38\x19\xcc\xc6\x77\xa3\xc9\x70\x7a\x3d\x9e\xcc\x4f\x37\x40\x4a\x2f\xad\x46\x1b\x83\x31\x1b\xf6\xb2\x77\xd1\x7e\x87\xac\xf4\x99\xeb\xcc\x7c\xca\xd6\xc9\x58\xe9\x34\x46\xac\xc8\xe4\x63\xd4\xed\x76\x4b\x6e\xba\x4a\x2e\xba\xd4\xb6\x2e\x6a\xf1\x00\x16\xbb\x75\x3d\x72\xa3\x1f\x2b\x67\x0f\x9d\x7d\x8b\xbe\xff\x6d\x76\x77\x33\xba\x1a\x5f\x4f\xb6\xc9\xa9\x74\x06\xbe\x17\xd2\x40\xcc\x8e\x0c\x2c\xa9\x87\x4c\x62\x6b\x7b\x29\x61\xd3\xfa\xb0\xc1\x78\xd5\x2a\xb5\x2a\x52\xf8\xea\x66\x0b\xb7\x73\xab\x95\x06\xb1\x34\xde\xe1\xa9\x03\x4f\xb9\x4d\x22\xf6\xc7\xb4\xda\xb0\x5c\x9b\x5d\x07\x24\xc0\x95\x4d\x7e\x7a\xec\xed\x08\x4d\x29\x22\x62\x9f\x2f\xcf\x2e\x3d\x2f\xb1\x5a\x2d\xb4\x8a\xd8\x7c\x30\x6d\xed\x8a\xae\x80\x0c\x10\xa7\x46\x2f\x20\xf2\xe0\x89\xb5\xf9\x15\x58\xdf\x44\x1c\xb5\xf4\xed\xa3\x6b\x95\xbb\x44\xc9\x8c\x36\x81\xab\x21\x28\x5e\xcd\x80\x24\xc6\x74\x8f\x9c\x9f\x79\x08\x2b\x53\xd0\x85\x6d\x9d\x1f\x7d\xd1\x40\x3b\x19\xef\x8e\xbb\xe7\x52\x15\x06\xe6\x89\x01\x4c\xb4\xa2\x9b\xf4\xe2\xd5\xe6\xb8\x45\xa7\xb4\x4b\xe9\xae\x5d\x30\x7b\xd7\x67\xdd\x6b\x94\x31\x08\x6e\x30\xf4\x82\xc6\x2b\xc4\x9b\x4b\xe2\xa1\x7b\xb4\xac\xa1\x1b\x48\xfe\x83\x9e\x3a\xdd\xc0\x39\x39\x3c\x8e\x49\x2e\xd2\x3e\xf5\x87\xc3\x9b\xd1\x6c\x76\xba\x77\x65\xdc\xf7\x7b\xe0\x96\x72\x0c\x96\x74\x39\x62\x6f\xae\x73\x4d\xcb\x56\xf5\xac\x29\xe0\xed\xd5\x6a\xd8\xb7\x07\xff\xf0\x75\xfa\x37\x73\xee\xf7\x8c\x5b\xcb\x45\x72\x48\xc3\xd6\x11\xef\xe8\xd6\x81\x5d\xf8\xbf\xaa\xbb\x5e\x77\x37\xb3\x0b\x78\x7f\x7d\x37\xae\x89\x03\x8b\xbc\xd2\xf6\xb7\xf3\x26\xbe\x8e\x4f\xb4\xf5\xab\xb9\x83\x81\xfe\x64\xd8\x6a\x28\xe9\x97\xf7\xe9\xb9\xf3\x1b\xdd\x58\xb7\x70\x90\x09\x00\x00")
+
+func chartTemplatesControllerYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesControllerYaml,
+		"chart/templates/controller.yaml",
+	)
+}
+
+func chartTemplatesControllerYaml() (*asset, error) {
+	bytes, err := chartTemplatesControllerYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/controller.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesNodeYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x56\xdb\x6e\xdb\x38\x10\x7d\xf7\x57\x10\xc6\x16\x68\x80\x95\xd4\x62\x5b\xa0\x15\xd0\x87\xd4\x36\xda\xa0\x5b\xc7\xb0\x83\xbc\x14\x0b\x83\xa6\x26\x16\x11\x8a\x24\x48\x4a\x8d\x37\xdb\x7f\xdf\xa1\x24\xcb\x92\x25\xbb\x49\x0c\xf8\x22\x72\xe6\xcc\xe1\xcc\x9c\xa1\xa9\xe6\xb7\x60\x2c\x57\x32\x26\x54\x6b\x1b\x15\x6f\x47\xf7\x5c\x26\x31\x99\x52\xc8\x94\x5c\x81\x1b\x65\xe0\x68\x42\x1d\x8d\x47\x84\x48\x9a\x41\x4c\x60\x63\x03\x66\x79\x20\x55\x02\xf5\xa2\xd5\x94\xe1\xce\xe3\x23\x09\x97\x20\x80\x5a\x08\xe7\xfb\x65\xf2\xeb\x17\x5a\x09\xba\x01\x61\x3d\x08\x41\xb3\x80\x70\xc9\x44\x9e\x00\x19\xef\xd1\x12\xc3\x0b\x30\x61\x65\x37\x26\x21\xf9\x8f\x48\xa4\x02\xd2\x91\x77\x1e\xc2\x6a\x60\xde\xdd\x22\x3e\x73\xca\x54\x50\x19\x75\x2c\xfd\xbb\x85\x4d\xfc\x41\x7a\x1c\x1d\x64\x5a\x50\x07\xb5\x53\xeb\x48\xfe\x25\x3a\xfe\xc3\x08\x18\xb8\x26\xe0\x5f\xa9\xb2\x6e\x0e\xee\xa7\x32\xf7\x31\x71\x26\x87\x7a\x5d\x1b\xae\x0c\x77\xbb\x89\xa0\xd6\xce\xcb\x6c\xd9\x9d\xc5\xe8\x25\x4c\xc0\x70\x8f\x33\x2a\x6a\x6b\xa7\x04\x18\xea\x30\xfd\x4d\xf0\x80\x28\xed\xd7\xf0\x7c\x64\xf6\xc0\xad\xb3\xf5\x06\x53\xd2\x51\x2e\xb1\x5a\x07\xd3\x43\x39\xb4\xc8\xb7\x5c\x36\x07\xb0\xc0\xf2\x92\x06\x3a\xc1\x83\x3b\x9c\xac\x64\x58\x70\x01\x5b\x48\x3a\xc4\x09\xe1\x19\xdd\x56\x25\x2c\x7f\x91\xd7\x68\x29\xdd\x1d\x19\xbf\xb2\xf1\x2b\x5f\x90\x5b\x2a\x72\xb0\x61\xb9\x1b\x1a\xd0\xca\x72\xa4\xb9\x3b\xda\x70\x74\x7b\x51\x55\xbc\xce\xa5\xd9\xb6\x32\x1b\x90\x20\x00\x99\x68\x85\xd0\x9f\xfe\x78\x3d\x59\x5d\xad\x67\xf3\xe9\xe2\xfa\x6a\x7e\x73\xd1\x31\x12\x6a\xeb\x30\xc7\x09\x18\xd3\x59\x2f\x3e\xbd\x6f\x9e\x41\x16\x6d\xe4\x2a\x1b\x6d\xc8\xd6\xb1\x0b\xcf\x31\x26\xb9\xe4\x0f\x71\x84\x65\xf5\xef\xd0\x2a\x76\xdf\xd8\x14\x4a\xe4\x19\x7c\x57\xb9\x74\xb6\x0f\x7b\x9f\x63\x8b\x80\x0b\x12\x6e\x5a\xa8\x99\xb7\x5e\x50\x97\xc6\x24\x2a\xa8\x89\x04\xdf\x44\xb5\x65\xcf\xca\x28\x4d\xb7\x65\xb1\x63\xf2\x99\x23\x0e\x76\x31\x3e\x34\xcd\x70\x88\x55\x15\xf3\x4c\x28\xe4\xde\x73\x4a\xa0\xe0\x0c\xce\x38\xa1\x41\xb3\xa3\x95\x19\x3a\x64\x0a\x54\xb8\xf4\xdf\x16\x40\xd3\x75\x0b\xf4\x88\xc9\xc7\x0f\x6f\x3e\x74\x7a\x49\x39\xc5\x94\x88\xc9\xcd\x64\xd1\xac\x0b\xd4\xb1\x04\x6b\xf1\xc4\x1b\x68\xb7\x5e\xea\x9c\xfe\x02\x9d\x6e\x44\x8c\x8a\x5d\x3f\x74\xc5\x72\x88\x14\x97\xa8\x22\x2a\xa6\x20\xe8\x6e\x05\x48\x31\xb1\x31\x79\xfb\xa6\x65\xe1\x78\x06\x2a\x77\xcd\xe6\x5f\x6d\xd2\x80\x1a\x4d\x86\xfd\xee\x28\x17\xb9\x81\x9b\xd4\x80\x4d\x95\x40\x89\xbc\x3f\x12\x5b\x29\xe3\x6a\x54\x05\x06\xb6\x28\x50\x43\xcd\x49\x0d\xed\xa5\x61\x79\x02\x8c\x1a\x1b\x7a\xf7\x69\xe9\xbd\xdc\x3b\x5f\x95\x96\x67\x15\xe3\xc7\x10\x4d\x12\x24\x65\x51\x34\x97\xd3\xe9\x72\xb6\x5a\x75\xf5\xb2\xef\xcf\x3d\x27\xdf\x5a\x81\xcf\x2d\x3a\x4c\x97\x57\xb7\xb3\xe5\x7a\x39\xfb\xb2\x5e\x5d\x4f\xbe\xad\x17\x97\x37\x5f\x2f\x4e\xaa\x4a\xf0\x3b\x60\x3b\x26\xa0\x3b\x35\x60\xe5\x94\xee\x96\x0e\x1e\x0e\x03\xf1\xd0\x30\x59\x46\xfd\x05\xf2\x63\x1c\x6d\xb8\x8c\x6c\x3a\xfe\x93\x8c\x03\xe6\x3f\x4d\x46\x02\x73\x47\xa2\x36\xc7\x08\xa7\x57\xe8\xb5\x48\x7f\xe2\xb7\xca\xfc\x01\x4a\x5d\x92\x8e\x4a\xc7\xff\x9c\x57\x7d\x9d\x93\xbe\xe0\x87\xb5\xbe\x77\x1b\xca\xcc\x00\xc6\x91\xb4\xa3\x4a\xa0\xf6\x98\xfb\x73\x67\xca\x8b\x74\xde\x29\xf0\x69\xd7\xb6\xd9\x51\x0b\xef\xf5\x19\x68\x2f\xd0\xa7\xf7\x6e\x47\xd7\xcf\xec\xda\x97\x8c\xdc\x67\xa4\xa7\x02\xea\xdd\x8c\x43\x43\xdb\xdf\xdc\xa5\xff\xa8\x37\x83\x4e\x4f\x70\xb7\xd3\xbe\x5b\xca\x99\x8d\x57\xde\xe8\xb7\x44\x9f\x1e\xe5\x64\x33\x9d\x0e\x7f\x6d\x26\x06\xf0\xcf\xcc\xe8\x89\x9d\xf1\x7c\x32\xeb\x1a\x6b\x17\x3d\x39\x09\x03\xf7\xcf\x99\xb8\xed\xcb\xa8\x8f\xfc\x3f\xab\x82\xa3\xa3\x97\x0a\x00\x00")
+
+func chartTemplatesNodeYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesNodeYaml,
+		"chart/templates/node.yaml",
+	)
+}
+
+func chartTemplatesNodeYaml() (*asset, error) {
+	bytes, err := chartTemplatesNodeYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/node.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesRbacYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xdd\x54\xb1\x6e\xdc\x30\x0c\xdd\xfd\x15\x84\xe7\x5a\x45\x80\x0e\x85\xb7\xb6\x43\xb7\x0c\x57\xa0\x4b\x91\x81\x96\x99\x3b\x35\xb2\x24\x88\x92\x5b\xf4\x9a\x7f\x2f\xe5\x73\x8a\x24\x77\xb9\xf8\x92\x74\x68\x26\x13\x24\xcd\xc7\x47\xea\x11\x83\xf9\x4a\x91\x8d\x77\x2d\xc4\x0e\xb5\xc2\x9c\x36\x3e\x9a\x5f\x98\xc4\xa7\xae\xde\xb3\x32\xfe\xed\x78\x56\x5d\x19\xd7\xb7\xf0\xc9\x66\x4e\x14\x57\xde\x52\x35\x50\xc2\x1e\x13\xb6\x15\x80\xc3\x81\x5a\xa0\x8e\x1b\xfa\x29\x71\x87\xb6\x09\xd1\x8f\xa6\x14\xa6\xd8\xc4\x92\x0f\x60\xb1\x23\xcb\x25\x1f\x60\xbb\x6d\xc0\x38\x6d\x73\x4f\x50\x97\x1f\x35\x9b\xa6\x8f\x66\xa4\xa8\x76\x79\x35\x28\xf8\x0d\x4e\x70\xc9\x25\x78\x07\xd7\xd7\x55\xcc\x96\xe4\xff\x06\x30\x98\xcf\xd1\xe7\xc0\x2d\x7c\xab\xeb\x0b\xa9\x18\x89\x7d\x8e\x9a\x26\x4f\x28\x94\xa4\x51\x97\x46\x6f\xf3\x40\x3c\xa5\x48\xe9\x6e\x0a\xaf\x29\xd5\x6f\xa0\xb6\x92\x52\xbe\x3f\x30\xe9\x4d\x31\x74\x24\x4c\x54\xac\x9e\x2c\x89\x75\x71\x3a\x94\xb6\x68\x86\x85\x78\x39\xf4\x78\x08\x85\x93\x8f\xb8\xa6\x79\xfa\xfb\x98\x73\x5c\xa0\x98\x17\x71\x5b\xc0\x83\x46\xa1\x70\xaf\xd6\x91\x01\xcd\xad\x8b\x15\x0e\x23\xb0\xc3\xc0\x1b\x9f\xd4\x63\x64\xe6\x0d\xcd\xe9\x5c\x4a\xde\x75\x69\xef\xd2\x7e\x6f\xb7\x79\x9e\x3c\x40\x79\x6b\xce\xf7\x2f\x35\xba\x13\x4a\x35\x4d\x85\x4f\x56\xdc\x47\x71\x18\xb7\x7e\x40\x78\x45\x3f\xb7\x35\xd7\xcd\xd9\x2f\x20\x3b\xce\xdd\x77\xd2\x69\x52\xde\xae\xab\x2f\x14\x47\xa3\xe9\x83\xd6\x3e\xbb\xb4\xd7\x47\x59\x99\x68\xde\x4a\x1b\x8c\x73\x94\x03\x6a\x49\xd9\x6e\x41\xad\x44\x5d\xc8\xa4\xce\x6f\xdc\x93\xb6\x85\xe1\x8a\x2e\x4b\x9b\xfb\xb7\x66\xe9\x85\xb9\xd9\xd4\x91\xd1\x3e\x73\x09\x8f\x9d\x3d\x4c\x09\xf5\xe6\xff\xb9\x79\x0f\xdd\xa0\x67\x3d\xf3\xbb\xa5\x84\xa5\x5a\xa8\x48\xe3\x2e\xfd\x93\x30\x96\x5d\x99\xdd\x72\x86\xe3\xd7\xe4\xf0\x74\xfe\xb1\x72\xff\x3e\x9b\xd7\x2f\xdb\xfb\x0a\x59\xa2\xd9\x3f\x2f\xbc\xa3\x2a\xa7\x08\x00\x00")
+
+func chartTemplatesRbacYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesRbacYaml,
+		"chart/templates/rbac.yaml",
+	)
+}
+
+func chartTemplatesRbacYaml() (*asset, error) {
+	bytes, err := chartTemplatesRbacYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/rbac.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesServiceaccountYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x35\xce\x31\x0a\xc3\x30\x0c\x85\xe1\xdd\xa7\x10\xd9\x6d\x28\x74\xf2\xd6\x0b\x74\x68\xa1\xbb\x22\x6b\x10\x75\xe4\x60\x39\x59\xd2\xdc\xbd\x0e\x6d\x56\xbd\x8f\x1f\xe1\x2c\x2f\xae\x26\x45\x23\xac\x17\xf7\x16\x4d\x11\x9e\x5c\x57\x21\xbe\x11\x95\x45\x9b\x9b\xb8\x61\xc2\x86\xd1\x01\x28\x4e\x1c\x81\x47\xf3\x64\xe2\xa9\x68\xab\x25\x67\xae\xde\xf0\xbf\xda\x8c\xd4\xc9\xb6\x41\x78\x70\x66\x34\x0e\xf7\xf3\x0c\xfb\xde\x55\xc6\x91\xb3\x1d\x35\xe8\xcc\x83\x28\xe5\x25\x31\x0c\x67\x36\x55\x59\xb9\x86\x9f\x1b\x20\xc0\x07\xb4\x3f\xc6\xda\xe0\x7a\x24\xbe\xaa\x2e\xcf\x94\xb5\x00\x00\x00")
+
+func chartTemplatesServiceaccountYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesServiceaccountYaml,
+		"chart/templates/serviceaccount.yaml",
+	)
+}
+
+func chartTemplatesServiceaccountYaml() (*asset, error) {
+	bytes, err := chartTemplatesServiceaccountYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/serviceaccount.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesStorageclassYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x91\x31\x6b\xc4\x30\x0c\x85\x77\xff\x0a\x91\x3d\x2e\x85\x0e\x25\x63\x0f\x6e\xeb\xd4\x72\x9d\x95\x58\x77\x98\x73\xec\x20\xcb\x29\x25\xbd\xff\x5e\x39\x49\xa1\x43\xb9\xc9\x83\x9e\xf4\xbe\xf7\xbc\x2c\x2d\xf8\x33\xd8\x13\x86\x42\xd9\x66\x49\x8c\x17\x3a\x04\xcc\xd9\x0e\x4c\x28\x04\xed\xed\x66\x70\xf2\x27\xe2\xec\x53\xec\x60\xd7\xd8\xeb\x73\xb6\x3e\x3d\xcc\x8f\xe6\xea\xa3\xeb\xe0\xed\xcf\xae\x19\x49\xd0\xa1\x60\x67\x00\x22\x8e\xd4\xc1\xb2\xfc\xef\x52\xa7\xa0\x16\x00\x01\x7b\x0a\xb9\x6e\x80\xaa\x95\x2b\x0e\xa1\x38\x82\x86\xfa\xdc\x0e\xd9\xb7\x8e\xfd\x4c\x6c\x37\x5d\x03\x16\xbe\x21\xaa\x35\x45\x81\xa7\xed\xc4\x72\x27\x8e\xa3\x33\x96\x20\x9b\x10\x63\x4c\x82\xa2\x81\x76\xc3\x5d\x3a\xac\xd2\x6b\xe9\x89\x23\x09\xad\x09\x7d\x6e\xf7\xdd\x76\x1d\x77\xd0\x08\x17\x6a\x76\x3f\x8a\xae\xde\x9c\x38\xcd\xbe\x36\x44\xdc\x81\x12\x5b\x25\xb6\xf8\xa9\x6f\x1a\xcd\x9c\x42\x19\xe9\x45\x61\x7d\xbc\xbc\x26\xa7\x7d\x7c\xa0\x97\x63\xe2\xa3\xe7\x2c\x07\xc5\xd0\x39\x9b\x09\x59\xeb\x10\xad\xba\x52\xc9\xd7\x74\xa7\xb8\xed\xe6\xbb\x6a\xaa\xfd\x2f\x49\xfd\xad\x1f\x39\x46\xc5\x14\xd5\x01\x00\x00")
+
+func chartTemplatesStorageclassYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesStorageclassYaml,
+		"chart/templates/storageclass.yaml",
+	)
+}
+
+func chartTemplatesStorageclassYaml() (*asset, error) {
+	bytes, err := chartTemplatesStorageclassYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/storageclass.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartValuesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x90\x31\x6e\xc3\x30\x0c\x45\x77\x9f\x42\x17\xb0\xec\x04\x1d\x0a\xaf\xe9\xd2\xad\x28\x7a\x01\x46\x62\x5c\x21\xb2\xa8\x90\xb2\x0b\xf7\xf4\xa5\x8c\x1a\xe9\x50\x64\x24\xf8\xfe\x03\xf9\xc3\x04\x23\x0e\x8d\x31\x8c\x99\x24\x14\xe2\x75\x30\x9e\xdc\x15\xd9\x06\xea\x60\x82\x6f\x4a\x1d\x7c\x49\x8b\x67\x69\x9d\x84\xd6\x73\x58\x90\x35\x51\x60\x1c\xcc\xd2\xdb\x27\xdb\x37\x8d\x04\x8f\x0e\x58\xaa\x2a\x33\x2d\x41\x02\x25\xe4\xd7\x4d\x6f\x6e\x33\xac\x55\x77\x7d\x16\x55\x74\x55\xf3\x07\x1a\x96\x83\xed\xed\x41\x93\x50\x0a\xb8\xcf\x47\xb1\x9d\xb8\x67\x12\x79\x7c\xd9\x6e\x7a\xc7\x31\x48\x61\x78\x10\xaf\xf0\xef\x07\x2d\xef\x78\x75\xa9\x4d\x5d\x51\x17\x09\x45\xde\x98\xce\xf8\xbf\x65\x47\x72\x45\xf6\x64\xa3\xed\xc5\xe0\xe0\x44\x73\x2a\x83\x39\x6a\x1f\xda\xa4\xc6\x4f\x11\x64\xeb\xc4\x31\x42\x51\xdb\x05\xa2\x60\xbd\x1a\x26\x9d\x6a\xa7\xe2\x74\xf4\x78\x81\x39\x96\xfb\x7e\xa1\x38\x4f\xf8\xb1\x66\xa5\xc6\x7c\x6c\x7e\x00\xda\xfc\x3b\xfb\xa8\x01\x00\x00")
+
+func chartValuesYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartValuesYaml,
+		"chart/values.yaml",
+	)
+}
+
+func chartValuesYaml() (*asset, error) {
+	bytes, err := chartValuesYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/values.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _iamPolicyJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x92\x31\x0b\xc2\x30\x10\x85\xf7\xfe\x8a\x90\x51\x2c\xd8\x2e\x82\x5b\x51\x07\x07\x17\x2b\x0e\x96\x0e\x69\xbc\x6a\x20\x26\xd2\x5c\x15\x91\xfe\x77\xd3\xa6\x76\x4a\x70\xb9\xe1\x7d\x2f\x2f\x0f\xee\x3e\x11\x21\xf4\x04\x8d\x11\x5a\xd1\x15\xa1\xe9\x22\x49\xe3\x64\x11\x27\x4b\x3a\xef\x51\x8e\x0c\xe1\x0e\x0a\x2d\x2c\xac\x40\xc8\x67\x98\x16\x6d\xeb\x1a\x78\xaf\xd3\x4c\x4a\xfd\x1a\xfc\x03\xc8\x38\xba\xb4\x62\x54\xac\x06\x3c\x5d\x65\x88\x8c\xdf\x4e\x5a\xb6\x77\x98\xdc\x23\x5b\x37\x60\x3f\xca\x15\x7b\x98\x9b\x46\x3f\x3d\xb2\xab\xf1\x13\x7f\xe6\x06\x24\x84\x33\x1d\xf5\x65\x3a\x12\xca\x34\xbc\x11\x15\x64\x4f\x26\x24\xab\x84\x14\xf8\x3e\x6b\x05\x26\x64\xdc\x29\x83\x4c\xf1\xb0\xe1\xd7\x2f\x68\xf0\x57\x74\xcc\x95\xfc\x87\xf7\xfa\x22\x6a\xc1\x59\xbf\x16\x8f\x37\xbc\x95\xe1\xe1\x7b\x64\x23\x2a\xa7\x3d\x1f\xc0\xe8\xb6\xe1\xd0\x9f\xc0\xcc\xe1\xce\xce\x32\xea\xa2\x2f\x48\x35\xe8\xf4\x57\x02\x00\x00")
+
+func iamPolicyJsonBytes() ([]byte, error) {
+	return bindataRead(
+		_iamPolicyJson,
+		"iam-policy.json",
+	)
+}
+
+func iamPolicyJson() (*asset, error) {
+	bytes, err := iamPolicyJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "iam-policy.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"chart/Chart.yaml": chartChartYaml,
+	"chart/templates/_helpers.tpl": chartTemplatesHelpersTpl,
+	"chart/templates/controller.yaml": chartTemplatesControllerYaml,
+	"chart/templates/node.yaml": chartTemplatesNodeYaml,
+	"chart/templates/rbac.yaml": chartTemplatesRbacYaml,
+	"chart/templates/serviceaccount.yaml": chartTemplatesServiceaccountYaml,
+	"chart/templates/storageclass.yaml": chartTemplatesStorageclassYaml,
+	"chart/values.yaml": chartValuesYaml,
+	"iam-policy.json": iamPolicyJson,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"chart": &bintree{nil, map[string]*bintree{
+		"Chart.yaml": &bintree{chartChartYaml, map[string]*bintree{}},
+		"templates": &bintree{nil, map[string]*bintree{
+			"_helpers.tpl": &bintree{chartTemplatesHelpersTpl, map[string]*bintree{}},
+			"controller.yaml": &bintree{chartTemplatesControllerYaml, map[string]*bintree{}},
+			"node.yaml": &bintree{chartTemplatesNodeYaml, map[string]*bintree{}},
+			"rbac.yaml": &bintree{chartTemplatesRbacYaml, map[string]*bintree{}},
+			"serviceaccount.yaml": &bintree{chartTemplatesServiceaccountYaml, map[string]*bintree{}},
+			"storageclass.yaml": &bintree{chartTemplatesStorageclassYaml, map[string]*bintree{}},
+		}},
+		"values.yaml": &bintree{chartValuesYaml, map[string]*bintree{}},
+	}},
+	"iam-policy.json": &bintree{iamPolicyJson, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
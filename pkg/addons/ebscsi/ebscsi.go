@@ -0,0 +1,174 @@
+package ebscsi
+
+import (
+	"fmt"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+
+	"github.com/weaveworks/eksctl/pkg/addons/helm"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// ChartName is the name of the embedded chart of the driver
+	ChartName = "aws-ebs-csi-driver"
+	// ReleaseName is the name the driver is installed with
+	ReleaseName = "ebs-csi-driver"
+	// PolicyName is the name of the inline policy that is put on instance roles
+	PolicyName = "eksctl-ebs-csi-driver"
+	// StorageClassName is the name of the StorageClass of the driver
+	StorageClassName = "ebs-sc"
+
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+)
+
+// Options holds options for deploying the driver
+type Options struct {
+	// SetDefaultStorageClass creates a StorageClass of the driver and makes it the default one
+	SetDefaultStorageClass bool
+	// Plan only logs the changes, without applying them
+	Plan bool
+}
+
+// NewRepository returns a chart repository with the embedded chart of the driver
+func NewRepository() (*helm.Repository, error) {
+	chart, err := helm.LoadChartFromAssets("chart", AssetNames(), Asset)
+	if err != nil {
+		return nil, err
+	}
+	return helm.NewRepository(chart), nil
+}
+
+// Deploy installs or upgrades the driver; in plan mode it only logs what would
+// change, it returns true when changes are required
+func Deploy(rawClient kubernetes.RawClientInterface, cfg *api.ClusterConfig, opts Options) (bool, error) {
+	repository, err := NewRepository()
+	if err != nil {
+		return false, err
+	}
+
+	values := map[string]interface{}{}
+	if opts.SetDefaultStorageClass {
+		values["storageClass"] = map[string]interface{}{
+			"create":  true,
+			"name":    StorageClassName,
+			"default": true,
+		}
+	}
+
+	installer := helm.NewInstaller(rawClient, repository, cfg)
+	_, changed, err := installer.Upgrade(helm.InstallOptions{
+		Chart:       ChartName,
+		ReleaseName: ReleaseName,
+		Values:      values,
+		Plan:        opts.Plan,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "deploying EBS CSI driver")
+	}
+
+	defaultChanged := false
+	if opts.SetDefaultStorageClass {
+		defaultChanged, err = unsetOtherDefaultStorageClasses(rawClient.ClientSet(), opts.Plan)
+		if err != nil {
+			return false, err
+		}
+	}
+	return opts.Plan && (changed || defaultChanged), nil
+}
+
+// unsetOtherDefaultStorageClasses removes the default annotation from StorageClasses other
+// than the one of the driver, typically gp2 of the in-tree plugin, as new claims without a
+// class would otherwise be provisioned by an arbitrary default class
+func unsetOtherDefaultStorageClasses(clientSet kubeclient.Interface, plan bool) (bool, error) {
+	classes, err := clientSet.StorageV1().StorageClasses().List(metav1.ListOptions{})
+	if err != nil {
+		return false, errors.Wrap(err, "listing StorageClasses")
+	}
+
+	changesRequired := false
+	for _, sc := range classes.Items {
+		if sc.Name == StorageClassName || sc.Annotations[defaultStorageClassAnnotation] != "true" {
+			continue
+		}
+		changesRequired = true
+		if plan {
+			logger.Info("(plan) would have made StorageClass %q not the default one", sc.Name)
+			continue
+		}
+		sc := sc
+		sc.Annotations[defaultStorageClassAnnotation] = "false"
+		if _, err := clientSet.StorageV1().StorageClasses().Update(&sc); err != nil {
+			return false, errors.Wrapf(err, "updating StorageClass %q", sc.Name)
+		}
+		logger.Info("StorageClass %q is not the default one anymore", sc.Name)
+	}
+	return changesRequired, nil
+}
+
+// PolicyDocument returns the IAM policy published for the driver
+func PolicyDocument() (string, error) {
+	data, err := Asset("iam-policy.json")
+	if err != nil {
+		return "", errors.Wrap(err, "decoding embedded IAM policy of EBS CSI driver")
+	}
+	return string(data), nil
+}
+
+// AttachNodeRolePolicy puts the IAM policy of the driver on the instance roles of the
+// nodegroups described by the given stacks; it returns true when changes are required in plan mode
+func AttachNodeRolePolicy(provider api.ClusterProvider, stacks []*cfn.Stack, plan bool) (bool, error) {
+	document, err := PolicyDocument()
+	if err != nil {
+		return false, err
+	}
+	return iam.PutNodeGroupRolePolicy(provider, stacks, PolicyName, document, plan)
+}
+
+// VerifyInTreeVolumes checks that the persistent volumes of the in-tree plugin are still bound,
+// volumes that were provisioned before the driver was installed keep being managed by the
+// in-tree plugin, or by the driver once CSI migration is enabled on the control plane;
+// it returns the problems that were found
+func VerifyInTreeVolumes(clientSet kubeclient.Interface) ([]string, error) {
+	volumes, err := clientSet.CoreV1().PersistentVolumes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "listing persistent volumes")
+	}
+
+	problems := []string{}
+	inTree := 0
+	for _, pv := range volumes.Items {
+		if pv.Spec.AWSElasticBlockStore == nil {
+			continue
+		}
+		inTree++
+
+		if pv.Status.Phase != corev1.VolumeBound {
+			problems = append(problems, fmt.Sprintf("volume %q of the in-tree plugin is %s", pv.Name, pv.Status.Phase))
+			continue
+		}
+		claimRef := pv.Spec.ClaimRef
+		if claimRef == nil {
+			continue
+		}
+		pvc, err := clientSet.CoreV1().PersistentVolumeClaims(claimRef.Namespace).Get(claimRef.Name, metav1.GetOptions{})
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("claim %s/%s of volume %q cannot be read: %s", claimRef.Namespace, claimRef.Name, pv.Name, err.Error()))
+			continue
+		}
+		if pvc.Status.Phase != corev1.ClaimBound || pvc.Spec.VolumeName != pv.Name {
+			problems = append(problems, fmt.Sprintf("claim %s/%s of volume %q is not bound to it", claimRef.Namespace, claimRef.Name, pv.Name))
+		}
+	}
+
+	logger.Info("checked %d volume(s) of the in-tree EBS plugin, %d problem(s) found", inTree, len(problems))
+	return problems, nil
+}
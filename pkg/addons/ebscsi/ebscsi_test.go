@@ -0,0 +1,92 @@
+package ebscsi_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/ebscsi"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("EBS CSI driver", func() {
+	var (
+		rawClient *testutils.FakeRawClient
+		cfg       *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		rawClient = testutils.NewFakeRawClient()
+		rawClient.UseUnionTracker = true
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+	})
+
+	It("should deploy the driver without a StorageClass by default", func() {
+		Expect(Deploy(rawClient, cfg, Options{})).To(BeFalse())
+
+		controller, err := rawClient.ClientSet().AppsV1().Deployments(metav1.NamespaceSystem).Get("ebs-csi-controller", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(controller.Spec.Template.Spec.Containers[0].Image).To(Equal("docker.io/amazon/aws-ebs-csi-driver:v0.4.0"))
+		Expect(controller.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "AWS_REGION", Value: "eu-west-1"}))
+
+		_, err = rawClient.ClientSet().AppsV1().DaemonSets(metav1.NamespaceSystem).Get("ebs-csi-node", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		classes, err := rawClient.ClientSet().StorageV1().StorageClasses().List(metav1.ListOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(classes.Items).To(BeEmpty())
+	})
+
+	It("should create a default StorageClass of the driver", func() {
+		Expect(Deploy(rawClient, cfg, Options{SetDefaultStorageClass: true})).To(BeFalse())
+
+		sc, err := rawClient.ClientSet().StorageV1().StorageClasses().Get(StorageClassName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sc.Provisioner).To(Equal("ebs.csi.aws.com"))
+		Expect(sc.Annotations).To(HaveKeyWithValue("storageclass.kubernetes.io/is-default-class", "true"))
+	})
+
+	It("should report in-tree volumes that are not bound", func() {
+		ebs := corev1.PersistentVolumeSource{
+			AWSElasticBlockStore: &corev1.AWSElasticBlockStoreVolumeSource{VolumeID: "vol-1"},
+		}
+		clientSet := fake.NewSimpleClientset(
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-bound"},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeSource: ebs,
+					ClaimRef:               &corev1.ObjectReference{Namespace: "default", Name: "data"},
+				},
+				Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeBound},
+			},
+			&corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "data"},
+				Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-bound"},
+				Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-failed"},
+				Spec:       corev1.PersistentVolumeSpec{PersistentVolumeSource: ebs},
+				Status:     corev1.PersistentVolumeStatus{Phase: corev1.VolumeFailed},
+			},
+			&corev1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Name: "pv-nfs"},
+				Spec: corev1.PersistentVolumeSpec{
+					PersistentVolumeSource: corev1.PersistentVolumeSource{NFS: &corev1.NFSVolumeSource{Server: "nfs", Path: "/"}},
+				},
+				Status: corev1.PersistentVolumeStatus{Phase: corev1.VolumeAvailable},
+			},
+		)
+
+		problems, err := VerifyInTreeVolumes(clientSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(problems).To(Equal([]string{`volume "pv-failed" of the in-tree plugin is Failed`}))
+	})
+})
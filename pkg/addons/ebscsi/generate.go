@@ -0,0 +1,3 @@
+package ebscsi
+
+//go:generate ${GOBIN}/go-bindata -pkg ${GOPACKAGE} -prefix assets -nometadata -o assets.go assets
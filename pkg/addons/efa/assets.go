@@ -0,0 +1,235 @@
+// Code generated by go-bindata.
+// sources:
+// assets/efa-device-plugin.yaml
+// DO NOT EDIT!
+
+package efa
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _efaDevicePluginYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x54\x4d\x8f\xda\x30\x10\xbd\xf3\x2b\x2c\xee\x4e\x60\x4b\xab\x2a\x37\xb4\xcb\x61\xa5\x2d\x5d\x95\xb6\x97\x6a\x0f\x83\x33\x80\x15\xc7\x76\xfd\x11\xa0\xbf\xbe\xe3\x10\x50\xc2\xee\xb6\x70\x40\x96\x67\x9e\xdf\xf8\xbd\xe7\x70\xce\x47\x60\xe5\x4f\x74\x5e\x1a\x5d\x30\xb0\xd6\xe7\xcd\x74\x54\x49\x5d\x16\xec\x01\xb0\x36\x7a\x85\x61\x54\x63\x80\x12\x02\x14\x23\xc6\x34\xd4\x48\x9d\x7b\xcf\x71\x03\xbc\xfa\xec\x79\x89\x8d\x14\xc8\xad\x8a\x5b\xa9\x79\xd9\xa2\x3c\xa1\x4e\xbd\xde\x82\x20\x40\x15\xd7\xc8\xfd\xd1\x07\xac\x47\xde\xa2\x48\x47\x79\x54\x28\x82\x71\x69\xcd\x58\x0d\x41\xec\x9e\x60\x8d\xca\x9f\x36\xfe\xcb\x45\x5d\xd1\xd2\x5c\xb8\x0a\x8e\xfe\xb7\xc7\x13\x2e\x1c\x2d\xa1\xbe\x19\xa5\xa4\xde\xfe\x68\x1b\x68\x9f\x88\xad\xa2\x65\x47\xd6\xbb\x51\xfa\x81\xd6\x26\x40\x20\x15\x2e\xe4\x34\x9f\xd8\x61\x19\x15\xba\x0c\x94\xdd\x41\x96\xee\xe0\x34\x06\xf4\x99\x34\xb9\x70\x32\x48\x01\x8a\x5b\x43\x62\x8d\xc7\x1d\x4c\x0d\x6e\x70\xcb\x1d\x88\xa8\x13\xa4\x5d\xa3\x4b\xe5\xb9\x10\x26\xea\x50\xb0\x92\x60\x51\x85\xae\x1a\x0c\x8d\x73\x3d\x27\x67\x15\x1e\x0b\x76\xdf\x0d\x34\x2f\x4b\x2a\x7f\xd5\xea\x78\xe9\x60\xcc\xd8\x84\x23\xad\xd9\xe2\x20\x7d\xf0\xd7\x60\x1a\x30\x83\x1a\xfe\x18\x9d\x09\x53\xe7\x44\x7a\x0b\x98\x31\xdc\x6c\xc8\xc3\x82\x2d\xcd\xaa\x53\xab\x2b\x5a\x27\x0d\x0d\x74\xbc\x57\xe0\xfd\xb2\x15\xe1\x64\x3f\xd7\xa6\x44\x7e\x56\xef\xac\xff\x66\x23\x35\x75\xf7\x74\xa3\xae\xf9\xab\x5d\xc6\x1c\xfe\x8e\xd2\x61\xf9\x10\x1d\xd9\xdb\x91\xd2\xea\x71\xab\xcd\x65\x7b\x71\x40\x11\x93\x4a\x7d\xe4\xe9\xcc\x55\x17\xba\xef\xe8\x6a\x3f\x2c\x27\x31\xda\x14\x2e\x0e\xd6\xa1\xf7\x43\x95\xfb\x5d\xad\x64\x6b\xca\xd0\x55\x26\xa4\xf6\x01\x34\x99\x9b\x32\xf8\x06\xb2\x2f\xe5\xa3\x7e\xb3\xa1\x01\x15\xd1\x17\xec\xd7\x4b\x57\xde\x19\x1f\x96\x18\xf6\xc6\x55\x05\x0b\x2e\x9e\xcf\x15\x46\x07\x90\x9a\xde\x6e\x3f\x09\xb2\x86\x2d\x49\xfd\x69\x72\x37\x9b\x4c\xa7\xb3\x0f\xb3\x8f\x77\x59\x59\xb9\x0c\x85\xcb\xa2\xe7\x7b\xf4\x81\xdf\x75\x4e\x27\xcf\x5b\xb3\x2b\x9f\xbf\x1b\xd0\xa2\x99\x64\xd3\x6c\xd2\x9b\xf5\x96\x44\x9f\xb3\x2c\x62\x9b\x01\x1a\x15\x0f\x61\xa8\x25\x28\x65\xf6\xcf\x4e\x36\x52\xe1\x16\x17\x9e\xc2\xd0\x06\xbb\x60\x1b\x50\x7e\xa8\x9e\x00\x0b\x6b\xa9\x28\x32\xf8\xca\x91\xd2\x19\x4b\x72\x8d\xe7\x4f\x4f\xe3\x97\x5e\xad\x31\x2a\xd6\xf8\x25\xbd\xa2\x2b\x0c\xef\xae\xf0\xde\xd8\xed\x07\x22\xe1\x9e\x21\xec\x0a\x96\x37\xe0\x72\x25\xd7\x79\xf2\x5a\x61\xc8\x07\xb8\xf3\x63\x38\xd1\x0d\xbc\xf8\x37\x4b\xf2\xb5\x25\x18\x30\xdb\x9b\x28\xff\x02\xe9\xe1\xb1\x89\xb9\x05\x00\x00")
+
+func efaDevicePluginYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_efaDevicePluginYaml,
+		"efa-device-plugin.yaml",
+	)
+}
+
+func efaDevicePluginYaml() (*asset, error) {
+	bytes, err := efaDevicePluginYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "efa-device-plugin.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"efa-device-plugin.yaml": efaDevicePluginYaml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"efa-device-plugin.yaml": &bintree{efaDevicePluginYaml, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
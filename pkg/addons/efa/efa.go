@@ -0,0 +1,89 @@
+package efa
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// DevicePlugin is the name of the EFA device plugin DaemonSet
+	DevicePlugin = "aws-efa-k8s-device-plugin-daemonset"
+
+	devicePluginImagePrefix     = "602401143452.dkr.ecr."
+	devicePluginImageSuffix     = ".amazonaws.com/eks/aws-efa-k8s-device-plugin"
+	devicePluginImageRepository = "/eks/aws-efa-k8s-device-plugin"
+)
+
+// DeployDevicePlugin creates or replaces the EFA device plugin, which exposes
+// vpc.amazonaws.com/efa resource on nodes with an Elastic Fabric Adapter
+func DeployDevicePlugin(rawClient kubernetes.RawClientInterface, spec *api.ClusterConfig) error {
+	data, err := Asset("efa-device-plugin.yaml")
+	if err != nil {
+		return errors.Wrap(err, "decoding embedded manifest for EFA device plugin")
+	}
+
+	list, err := kubernetes.NewList(data)
+	if err != nil {
+		return errors.Wrap(err, "loading individual resources from manifest for EFA device plugin")
+	}
+
+	for _, rawObj := range list.Items {
+		resource, err := rawClient.NewRawResource(rawObj)
+		if err != nil {
+			return err
+		}
+
+		if resource.GVK.Kind == "DaemonSet" {
+			if err := customizeDaemonSet(resource.Info.Object.(*appsv1.DaemonSet), spec); err != nil {
+				return err
+			}
+		}
+
+		status, err := resource.CreateOrReplace(false)
+		if err != nil {
+			return err
+		}
+		logger.Info("%s", status)
+	}
+
+	logger.Info("EFA device plugin has been deployed")
+	return nil
+}
+
+func customizeDaemonSet(ds *appsv1.DaemonSet, spec *api.ClusterConfig) error {
+	for i := range ds.Spec.Template.Spec.Containers {
+		image := &ds.Spec.Template.Spec.Containers[i].Image
+		imageParts := strings.Split(*image, ":")
+
+		if len(imageParts) != 2 {
+			return fmt.Errorf("unexpected image format %q for %q", *image, ds.Name)
+		}
+
+		if strings.HasPrefix(imageParts[0], devicePluginImagePrefix) &&
+			strings.HasSuffix(imageParts[0], devicePluginImageSuffix) {
+			*image = api.EKSImageRegistry(spec.Metadata.Region) + devicePluginImageRepository + ":" + imageParts[1]
+		}
+		*image = spec.MirroredImage(*image)
+	}
+
+	// only schedule the plugin on instance types that have an adapter
+	affinity := ds.Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return fmt.Errorf("unexpected affinity of %q", ds.Name)
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for i := range term.MatchExpressions {
+			term.MatchExpressions[i].Values = api.SupportedEFAInstanceTypes()
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package efa_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/efa"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("EFA device plugin", func() {
+	var (
+		rawClient *testutils.FakeRawClient
+		cfg       *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		rawClient = testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Region = "eu-west-1"
+	})
+
+	It("can create the DaemonSet with regional image for EFA instance types", func() {
+		Expect(DeployDevicePlugin(rawClient, cfg)).To(Succeed())
+
+		ct := rawClient.Collection
+		Expect(ct.Updated()).To(BeEmpty())
+		Expect(ct.CreatedItems()).To(HaveLen(1))
+
+		ds, err := rawClient.ClientSet().AppsV1().DaemonSets(metav1.NamespaceSystem).Get(DevicePlugin, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ds.Spec.Template.Spec.Containers[0].Image).To(
+			Equal("602401143452.dkr.ecr.eu-west-1.amazonaws.com/eks/aws-efa-k8s-device-plugin:v0.1.0"),
+		)
+
+		terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		Expect(terms[0].MatchExpressions[0].Values).To(Equal(api.SupportedEFAInstanceTypes()))
+	})
+
+	It("uses the offline image registry", func() {
+		cfg.ContainerRuntime = &api.ClusterContainerRuntime{
+			Offline: &api.OfflineConfig{ImageRegistry: "123456789012.dkr.ecr.eu-west-1.amazonaws.com/mirror"},
+		}
+
+		Expect(DeployDevicePlugin(rawClient, cfg)).To(Succeed())
+
+		ds, err := rawClient.ClientSet().AppsV1().DaemonSets(metav1.NamespaceSystem).Get(DevicePlugin, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ds.Spec.Template.Spec.Containers[0].Image).To(
+			Equal("123456789012.dkr.ecr.eu-west-1.amazonaws.com/mirror/eks/aws-efa-k8s-device-plugin:v0.1.0"),
+		)
+	})
+})
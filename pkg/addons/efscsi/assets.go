@@ -0,0 +1,354 @@
+// Code generated by go-bindata.
+// sources:
+// assets/chart/Chart.yaml
+// assets/chart/templates/_helpers.tpl
+// assets/chart/templates/node.yaml
+// assets/chart/templates/persistentvolume.yaml
+// assets/chart/templates/storageclass.yaml
+// assets/chart/values.yaml
+// DO NOT EDIT!
+
+package efscsi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _chartChartYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcb\x4b\xcc\x4d\xb5\x52\x48\x2c\x2f\xd6\x4d\x4d\x2b\xd6\x4d\x2e\xce\xd4\x4d\x29\xca\x2c\x4b\x2d\xe2\x02\xe2\xe2\xcc\xfc\x3c\x2b\x05\x03\x3d\x43\x3d\x03\xae\xc4\x82\x82\x30\x98\x48\x99\x81\x9e\x11\x50\x28\x25\xb5\x38\xb9\x28\xb3\xa0\x04\x2c\xe6\x1c\xec\xa9\x00\xd1\xa9\x90\x96\x5f\xa4\xe0\x98\x9b\x58\x95\x9f\xa7\xe0\xea\x16\xac\x90\x96\x99\x93\xaa\x50\x5c\x59\x5c\x92\x9a\x5b\xcc\x05\x00\x4d\xc8\x8b\xae\x6f\x00\x00\x00")
+
+func chartChartYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartChartYaml,
+		"chart/Chart.yaml",
+	)
+}
+
+func chartChartYaml() (*asset, error) {
+	bytes, err := chartChartYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/Chart.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesHelpersTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x8f\xb1\x0e\xc2\x30\x0c\x44\xf7\x7e\x85\xd5\x3d\x61\xef\x86\xd8\x19\x18\xd8\xdd\xe6\x0a\x51\x53\x37\x8a\x43\x25\x14\xf5\xdf\x89\x80\x81\xa1\x62\xbc\xd3\xbb\xb3\xaf\x14\x43\x0e\xa3\x17\x50\x8b\x51\xcd\xa0\xde\xb8\xe4\x57\x24\x1b\xb8\x47\xd0\x96\xcc\xb6\x35\x1c\xa3\x9d\x1e\x3d\x92\x20\x43\xad\x5f\x0e\xc2\x33\x3a\x2a\x85\xec\xe9\xce\x29\xdb\x73\xd5\xb4\x4b\x7a\xd1\xcc\x32\x7c\xe9\x0b\x02\x58\xf1\x87\xaf\xb7\xd5\x2f\xf2\x5b\x7e\x8c\xf1\xfa\x71\xf7\x23\x33\x0b\xdf\xe0\x4c\xff\xec\x08\x93\x0e\x39\x34\xa5\x0e\x83\xb8\xf7\xf7\x2f\x2f\x11\x82\xd9\xe5\x00\x00\x00")
+
+func chartTemplatesHelpersTplBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesHelpersTpl,
+		"chart/templates/_helpers.tpl",
+	)
+}
+
+func chartTemplatesHelpersTpl() (*asset, error) {
+	bytes, err := chartTemplatesHelpersTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/_helpers.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesNodeYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x55\x5d\x4f\xdb\x30\x14\x7d\xef\xaf\xb0\xd0\x90\xe0\x21\x09\x6c\x43\x1a\x91\x78\x60\x6d\xc5\xaa\x6d\xa5\x6a\x2b\x5e\x2b\x93\x5c\x5a\xab\x8e\x6d\xd9\x4e\x46\xc7\xf8\xef\xbb\xce\x57\x93\x7e\x40\x3b\xad\x12\xa2\xb5\xef\x3d\xf7\xdc\xeb\x73\x6c\xaa\xd8\x03\x68\xc3\xa4\x08\x09\x55\xca\x04\xd9\x65\x67\xc9\x44\x1c\x92\x1e\x85\x44\x8a\x09\xd8\x4e\x02\x96\xc6\xd4\xd2\xb0\x43\x88\xa0\x09\x84\x04\x9e\x8c\x17\x19\xe6\x09\x19\x43\xb9\x68\x14\x8d\x70\xe7\xe5\x85\xf8\x63\xe0\x40\x0d\xf8\xc3\x6a\x99\xbc\xbe\x62\x14\xa7\x8f\xc0\x8d\x03\x21\x18\xe6\x11\x26\x22\x9e\xc6\x40\x4e\x2a\xb4\x58\xb3\x0c\xb4\x5f\xc4\x9d\x10\x9f\xfc\x21\x02\xa9\x80\xb0\xe4\xb3\x83\x30\x0a\x22\x97\x6e\x10\x3f\xb2\x52\x17\x50\x09\xb5\xd1\xe2\x47\x03\x9b\xb8\x46\xb6\x38\x5a\x48\x14\xa7\x16\xca\xa4\x46\x4b\xee\xc3\x5b\xf9\xbb\x11\xb0\x70\x49\xc0\x7d\x16\xd2\xd8\x21\xd8\x5f\x52\x2f\x43\x62\x75\x0a\xe5\xba\xd2\x4c\x6a\x66\x57\x5d\x4e\x8d\x19\xe6\xd3\x32\x2b\x83\xd5\x73\x18\x2f\xc2\x3d\x16\x51\x5e\x46\x5b\xc9\x41\x53\x8b\xe3\xaf\x8b\x7b\x44\x2a\xb7\x86\xfd\x91\xfe\x33\x33\xd6\x94\x1b\x91\x14\x96\x32\x81\xa7\xb5\x0e\x5d\x1f\x87\xe2\xe9\x9c\x89\xba\x01\x03\x51\x9a\xd3\xc0\x24\x78\xb6\xeb\xce\x72\x86\x19\xe3\x30\x87\xb8\x45\x9c\x10\x96\xd0\x79\x71\x84\xf9\x37\x72\x86\x91\xc2\x3e\x91\x93\x53\x13\x9e\xba\x03\x79\xa0\x3c\x05\xe3\xe7\xbb\xbe\x06\x25\x0d\x43\x9a\xab\x8d\x0d\x4b\xe7\xe7\xc5\x89\x97\xb3\xd4\xf3\xc6\x64\x3d\xe2\x79\x20\x62\x25\x11\xfa\xe6\xc3\x59\x77\x32\x98\xf5\x87\xbd\xd1\xfd\x60\x38\x3d\x6f\x05\x71\x39\xb7\x38\xe3\x18\xb4\x6e\xad\x67\x37\x57\xf5\x6f\x10\x59\x13\xb9\x98\x46\x13\xb2\xd1\x76\xe6\x38\x86\x24\x15\xec\x39\x0c\xf0\x58\xdd\x9f\x6f\x64\xb4\xac\x63\x32\xc9\xd3\x04\x7e\xca\x54\x58\xb3\x0d\xbb\x4c\x51\x22\x60\xbd\x98\xe9\x06\x6a\xe2\xa2\x47\xd4\x2e\x42\x12\x64\x54\x07\x9c\x3d\x06\x65\xe4\x56\x94\x96\x8a\xce\xf3\xc3\x0e\xc9\x57\x86\x38\xa8\x62\xfc\x51\x8b\x61\x5d\xab\x38\xcc\x37\x4a\x21\xf7\xad\x24\xa7\x02\x63\x51\xe2\xef\x50\xd4\xa9\x08\x30\xb6\x8e\x50\x52\xef\xea\x77\x01\x94\xdb\xc5\xef\x06\x50\x2d\xc0\x11\x66\x84\xe4\xfa\xcb\xc5\x75\x4b\x56\xd2\xca\x48\xf2\x90\x4c\xbb\xa3\x7a\x9d\xa3\xa5\x05\x18\x83\xcd\x3f\x42\x53\x85\x0b\x6b\xd5\x1d\xb4\x84\x89\x18\x05\xcb\xed\xd2\x05\xcb\x5d\xa4\x98\x40\x43\x51\xde\x03\x4e\x57\x13\x40\x8a\xb1\x09\xc9\xe5\x45\x23\xc2\xb2\x04\x64\x6a\xeb\xcd\x4f\x4d\xd2\x80\x76\x8d\xeb\xad\x8f\x8d\xad\x27\xca\x78\xaa\x61\xba\xd0\x60\x16\x92\xa3\x59\xae\x36\x6c\x97\x1b\xba\xb8\xb4\x3c\x0d\x73\xb4\xaa\xa6\x7a\xaf\x9b\x2a\x93\x18\x16\x43\x44\xb5\xf1\x5d\x7a\x2f\xcf\x1e\x57\xc9\x83\x3c\xf2\x4d\xef\xb8\x0b\x89\xc6\x31\x92\x32\x68\x9f\xdb\x5e\x6f\xdc\x9f\x4c\xda\xce\xa9\x94\x5a\x71\x72\x22\xf3\xdc\x68\x31\xa1\x37\x1e\x3c\xf4\xc7\xb3\x71\xff\x6e\x36\xb9\xef\x7e\x9f\x8d\x6e\xa7\xdf\xce\x8f\xf3\x57\x59\x73\xdb\x5a\xbb\x5d\x55\xa5\xed\xaa\xbc\x03\x63\xc3\x44\x41\x61\x05\xe3\x14\xeb\x3b\x6c\xfa\x0b\xff\xcb\xe4\x58\xf7\xfe\x93\xa3\x5a\x03\xdc\x9f\xda\x0c\xdb\x90\x48\x25\x7f\x4f\x39\xfd\x1f\xae\x8d\x96\x6d\x8e\x54\xc5\xbe\x63\xf0\xbc\xc2\x3d\x9e\xb3\xd2\x4d\xcb\xbd\xff\x6d\x76\x05\xd0\xd6\x03\xb5\xeb\xee\x74\x0f\x68\x9e\xdf\xd9\xf2\xff\xfe\x8b\xd4\xae\x94\x93\x52\x7e\x75\xe2\xcb\xd3\x79\x97\xe8\xe1\x55\xf6\x2a\x6d\x7f\xf9\x7b\xdd\xd5\x80\x17\x6e\xe7\x40\xd9\x1c\x4f\x66\x56\x62\xad\x82\x83\x87\xb0\xfb\x19\x78\xa7\xf4\xe6\x9b\xb0\xbf\xd5\xbf\x06\x4b\xd9\x4a\x31\x0a\x00\x00")
+
+func chartTemplatesNodeYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesNodeYaml,
+		"chart/templates/node.yaml",
+	)
+}
+
+func chartTemplatesNodeYaml() (*asset, error) {
+	bytes, err := chartTemplatesNodeYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/node.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesPersistentvolumeYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x85\x51\x4d\x4b\xc4\x30\x10\xbd\xf7\x57\x0c\x7b\x6f\x40\xf0\x94\xab\x22\x7a\x58\x59\x56\xa8\xe7\x31\x99\x4a\x30\x1f\xa5\x93\x56\x4a\xdd\xff\xee\xa4\xc1\x65\x77\x2f\x9e\x02\xef\x8b\x97\x37\xeb\xda\x82\xeb\x41\x75\xe8\x27\x62\xd5\x3b\x4f\x6f\x0b\x67\x0a\x2f\x8f\xd0\x9e\x4e\x0d\x0e\xae\xa3\x91\x5d\x8a\x1a\xe6\xbb\xe6\xcb\x45\xab\xe1\x50\x10\x11\xc5\xdc\x25\x3f\x05\x6a\x02\x65\xb4\x98\x51\x37\x00\x11\x03\x69\x58\xd7\x73\xe6\x70\xa3\x56\x45\x01\x92\x0d\xe0\xf1\x83\x3c\x17\x17\x88\x43\x9a\x44\xe3\x27\x4b\xb0\xa3\x9e\x5b\xc3\xae\xb5\xa3\x9b\x69\x54\x55\xb7\x03\x05\x3f\x10\xa5\x82\x64\xc1\x7d\x89\xe0\x81\x4c\xb1\x1b\x1c\xd0\xb8\xbc\xd4\x28\xce\x69\xc4\xcf\x7f\x5a\xfc\x59\x6a\x93\x79\x03\xf7\xc9\x8a\xeb\x49\x46\xe0\x6d\x04\x21\xd0\x18\x62\x2e\xc4\xd6\xb3\x85\x23\xa1\x7d\x1f\x5d\xa6\x3d\xc6\x45\x90\xdb\xe0\x23\x19\x8f\x2e\x1c\x92\x77\x66\xd1\x22\xcf\xe8\x62\x73\x2e\xf5\xe0\x91\xf9\xf5\x76\xa3\x4b\xf2\x62\x1f\x99\xa0\xfe\xa8\xee\xa0\x41\x76\x51\x02\x2a\xfc\x96\x37\x85\x8d\xab\xd5\x9f\x31\x5a\x7f\x1d\x7a\x75\x4c\xc9\x2b\x0b\x53\xb4\xdb\x5d\x7f\x01\xf1\xbd\x16\x5a\xf8\x01\x00\x00")
+
+func chartTemplatesPersistentvolumeYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesPersistentvolumeYaml,
+		"chart/templates/persistentvolume.yaml",
+	)
+}
+
+func chartTemplatesPersistentvolumeYaml() (*asset, error) {
+	bytes, err := chartTemplatesPersistentvolumeYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/persistentvolume.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesStorageclassYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x4d\x8e\x31\x0e\xc2\x30\x0c\x45\xf7\x9c\xe2\x8b\xbd\x46\x48\x0c\x28\x2b\x47\x40\xea\x6e\x1a\x83\xa2\xa6\x49\x15\xa7\x65\x28\xbd\x3b\x89\xba\x30\x59\xb2\xdf\xfb\xdf\x3c\xfb\x5e\xb2\xfa\x14\x2d\xb4\xa4\xcc\x6f\xa1\xf1\xa6\xe4\xd3\x79\xbd\x98\xd1\x47\x67\xf1\x38\xf6\xf7\xc0\xaa\x66\x92\xc2\x8e\x0b\x5b\x03\x44\x9e\xc4\x62\xdb\x40\x3d\x87\x45\x94\xf4\x8f\xa4\x76\xc5\xbe\x57\x2e\xf0\x53\x82\x36\x03\x95\xee\xe0\xe3\x10\x16\x27\x38\xc9\x4b\xbb\x41\x7d\xe7\xb2\x5f\x25\xd3\xc1\x9d\x40\xf8\x22\xd6\x6a\x89\x05\xd7\x16\x31\xe7\xb4\xfa\xf6\xa4\x64\x8b\x2a\x51\x95\x88\x3f\x75\xa6\xc9\xfc\x00\x83\x04\x62\x06\xc3\x00\x00\x00")
+
+func chartTemplatesStorageclassYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesStorageclassYaml,
+		"chart/templates/storageclass.yaml",
+	)
+}
+
+func chartTemplatesStorageclassYaml() (*asset, error) {
+	bytes, err := chartTemplatesStorageclassYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/storageclass.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartValuesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x91\xcd\x4e\xc3\x40\x0c\x84\xef\xfb\x14\x56\x7b\x25\x69\x8b\x84\x84\x72\xa5\x80\x2a\x2e\x88\x4a\xdc\xdd\x8d\x1b\xac\x26\xd9\xd4\xde\x04\xc2\xd3\xe3\x0d\x44\xfc\x88\xe3\xda\xe3\xcf\x33\x5e\x6e\xb0\xa2\xc2\x01\x08\x75\x41\x39\x06\x19\x0b\x28\x83\x3f\x91\xe4\x1c\x56\xd8\xe0\x7b\x68\x57\xf8\xaa\x19\x1d\x35\xf3\xca\x59\x29\x3c\x90\xd8\x44\xc4\xaa\x80\x61\x9d\x5f\xe6\x6b\xe7\x94\x4b\xf2\x28\x9a\x50\x6d\x28\x69\x3b\xa9\x9e\xa8\x62\x8d\x82\xb2\x9b\xd6\xc0\xb9\xc7\x31\x61\x4f\xd7\x6a\xa8\x55\xc2\x25\xf1\x17\x33\x93\x59\x5e\x0c\x9b\x7c\x63\x58\x80\xda\x1a\x2d\xa9\x3e\x4a\x38\xd0\xff\x94\x59\xd2\x25\xc9\x3c\xe9\x96\x70\xe4\x9a\xf6\xa3\x46\x6a\x76\x5b\x60\x85\xf8\x42\x70\x7b\xb7\x9f\xea\xa0\x53\xc3\x6a\x18\x53\x8f\xde\x2c\x3d\x95\x80\x0a\x08\x1d\x89\x9a\x0f\x6a\x23\x0c\xa1\xee\x1b\x72\x3f\x51\x05\x2c\x16\x96\xd7\x2e\x65\x66\x6e\x6a\xd4\xcf\xcc\xd8\x98\xb3\x74\x23\xf5\xce\x7d\x13\x9e\x27\xc0\x6f\x45\x37\xd8\x73\xf9\xd7\x8b\x6d\x16\x02\x32\x60\x64\x7f\x31\xb9\xf5\xd8\xa1\xe7\x38\x26\x87\xa1\xad\x47\xfb\xa4\x73\xcf\x62\x3e\x0f\x23\x3c\xf4\x07\x92\x96\x22\xa9\xd1\x66\x65\x01\x57\xf7\xec\x3e\x00\x85\xe1\x80\x25\xd5\x01\x00\x00")
+
+func chartValuesYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartValuesYaml,
+		"chart/values.yaml",
+	)
+}
+
+func chartValuesYaml() (*asset, error) {
+	bytes, err := chartValuesYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/values.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"chart/Chart.yaml": chartChartYaml,
+	"chart/templates/_helpers.tpl": chartTemplatesHelpersTpl,
+	"chart/templates/node.yaml": chartTemplatesNodeYaml,
+	"chart/templates/persistentvolume.yaml": chartTemplatesPersistentvolumeYaml,
+	"chart/templates/storageclass.yaml": chartTemplatesStorageclassYaml,
+	"chart/values.yaml": chartValuesYaml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"chart": &bintree{nil, map[string]*bintree{
+		"Chart.yaml": &bintree{chartChartYaml, map[string]*bintree{}},
+		"templates": &bintree{nil, map[string]*bintree{
+			"_helpers.tpl": &bintree{chartTemplatesHelpersTpl, map[string]*bintree{}},
+			"node.yaml": &bintree{chartTemplatesNodeYaml, map[string]*bintree{}},
+			"persistentvolume.yaml": &bintree{chartTemplatesPersistentvolumeYaml, map[string]*bintree{}},
+			"storageclass.yaml": &bintree{chartTemplatesStorageclassYaml, map[string]*bintree{}},
+		}},
+		"values.yaml": &bintree{chartValuesYaml, map[string]*bintree{}},
+	}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
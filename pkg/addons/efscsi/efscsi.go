@@ -0,0 +1,60 @@
+package efscsi
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/addons/helm"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// ChartName is the name of the embedded chart of the driver
+	ChartName = "aws-efs-csi-driver"
+	// ReleaseName is the name the driver is installed with
+	ReleaseName = "efs-csi-driver"
+	// StorageClassName is the name of the StorageClass of the driver
+	StorageClassName = "efs-sc"
+	// PersistentVolumeName is the name of the volume of the file system of the cluster
+	PersistentVolumeName = "efs-pv"
+)
+
+// NewRepository returns a chart repository with the embedded chart of the driver
+func NewRepository() (*helm.Repository, error) {
+	chart, err := helm.LoadChartFromAssets("chart", AssetNames(), Asset)
+	if err != nil {
+		return nil, err
+	}
+	return helm.NewRepository(chart), nil
+}
+
+// Deploy installs or upgrades the driver along with a StorageClass and a persistent
+// volume of the EFS file system of the cluster; in plan mode it only logs what would
+// change, it returns true when changes are required
+func Deploy(rawClient kubernetes.RawClientInterface, cfg *api.ClusterConfig, plan bool) (bool, error) {
+	if !cfg.HasEFS() || cfg.Storage.EFS.ID == "" {
+		return false, fmt.Errorf("EFS file system of cluster %q must be known to deploy EFS CSI driver", cfg.Metadata.Name)
+	}
+	repository, err := NewRepository()
+	if err != nil {
+		return false, err
+	}
+
+	installer := helm.NewInstaller(rawClient, repository, cfg)
+	_, changed, err := installer.Upgrade(helm.InstallOptions{
+		Chart:       ChartName,
+		ReleaseName: ReleaseName,
+		Values: map[string]interface{}{
+			"fileSystemID":     cfg.Storage.EFS.ID,
+			"storageClass":     map[string]interface{}{"name": StorageClassName},
+			"persistentVolume": map[string]interface{}{"name": PersistentVolumeName},
+		},
+		Plan: plan,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "deploying EFS CSI driver")
+	}
+	return plan && changed, nil
+}
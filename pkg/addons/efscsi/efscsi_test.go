@@ -0,0 +1,63 @@
+package efscsi_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/efscsi"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("EFS CSI driver", func() {
+	var (
+		rawClient *testutils.FakeRawClient
+		cfg       *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		rawClient = testutils.NewFakeRawClient()
+		rawClient.UseUnionTracker = true
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+	})
+
+	It("should require the file system of the cluster", func() {
+		_, err := Deploy(rawClient, cfg, false)
+		Expect(err).To(HaveOccurred())
+
+		cfg.Storage = &api.ClusterStorage{EFS: &api.EFSFileSystem{}}
+		_, err = Deploy(rawClient, cfg, false)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should deploy the driver with a volume of the file system", func() {
+		cfg.Storage = &api.ClusterStorage{EFS: &api.EFSFileSystem{ID: "fs-1234"}}
+		Expect(Deploy(rawClient, cfg, false)).To(BeFalse())
+
+		node, err := rawClient.ClientSet().AppsV1().DaemonSets(metav1.NamespaceSystem).Get("efs-csi-node", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(node.Spec.Template.Spec.Containers[0].Image).To(Equal("docker.io/amazon/aws-efs-csi-driver:v0.2.0"))
+
+		sc, err := rawClient.ClientSet().StorageV1().StorageClasses().Get(StorageClassName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sc.Provisioner).To(Equal("efs.csi.aws.com"))
+
+		pv, err := rawClient.ClientSet().CoreV1().PersistentVolumes().Get(PersistentVolumeName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pv.Spec.StorageClassName).To(Equal(StorageClassName))
+		Expect(pv.Spec.CSI.VolumeHandle).To(Equal("fs-1234"))
+	})
+
+	It("should not create anything in plan mode", func() {
+		cfg.Storage = &api.ClusterStorage{EFS: &api.EFSFileSystem{ID: "fs-1234"}}
+		Expect(Deploy(rawClient, cfg, true)).To(BeTrue())
+
+		_, err := rawClient.ClientSet().StorageV1().StorageClasses().Get(StorageClassName, metav1.GetOptions{})
+		Expect(err).To(HaveOccurred())
+	})
+})
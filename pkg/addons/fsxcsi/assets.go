@@ -0,0 +1,423 @@
+// Code generated by go-bindata.
+// sources:
+// assets/chart/Chart.yaml
+// assets/chart/templates/_helpers.tpl
+// assets/chart/templates/controller.yaml
+// assets/chart/templates/node.yaml
+// assets/chart/templates/persistentvolume.yaml
+// assets/chart/templates/rbac.yaml
+// assets/chart/templates/serviceaccount.yaml
+// assets/chart/templates/storageclass.yaml
+// assets/chart/values.yaml
+// DO NOT EDIT!
+
+package fsxcsi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _chartChartYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcb\x4b\xcc\x4d\xb5\x52\x48\x2c\x2f\xd6\x4d\x2b\xae\xd0\x4d\x2e\xce\xd4\x4d\x29\xca\x2c\x4b\x2d\xe2\x02\xe2\xe2\xcc\xfc\x3c\x2b\x05\x03\x3d\x43\x3d\x03\xae\xc4\x82\x82\x30\x98\x48\x19\x44\x28\x25\xb5\x38\xb9\x28\xb3\xa0\x04\x2c\xe6\x1c\xec\xa9\x00\xd1\xa9\x90\x96\x5f\xa4\xe0\x98\x9b\x58\x95\x9f\xa7\xe0\x16\x5c\x01\xe6\xfa\x94\x16\x97\x14\xa5\x2a\xa4\x65\xe6\xa4\x2a\x14\x57\x16\x97\xa4\xe6\x16\x73\x01\x00\xfc\x83\x13\x8d\x7a\x00\x00\x00")
+
+func chartChartYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartChartYaml,
+		"chart/Chart.yaml",
+	)
+}
+
+func chartChartYaml() (*asset, error) {
+	bytes, err := chartChartYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/Chart.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesHelpersTpl = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x8f\xb1\x0e\xc2\x30\x0c\x44\xf7\x7e\x85\xd5\x3d\x61\xef\x86\xd8\x19\x18\xd8\xdd\xe6\x0a\x51\x53\x37\x8a\x03\x02\x45\xfd\x77\x22\x60\x60\xa8\x18\xef\xf4\xee\xec\x2b\xc5\x90\xc3\xe8\x05\xd4\x8e\xfa\x30\x83\x7a\xe3\x92\xbf\x23\xd9\xc0\x3d\x82\xb6\x64\xd6\xb5\xe1\x18\xed\x74\xeb\x91\x04\x19\x6a\xfd\xb2\x13\x9e\xd1\x51\x29\x64\x0f\x57\x4e\xd9\x1e\xab\xa6\x4d\xd2\x8b\x66\x96\xe1\x4b\x9f\x10\xc0\x8a\x3f\x7c\xbd\xad\x7e\x91\xdf\xf2\x7d\x8c\xe7\x8f\xbb\x1d\x99\x59\xf8\x02\x67\xfa\x67\x47\x98\x74\xc8\xa1\x29\x75\x18\xc4\xbd\xbf\x7f\x01\x49\xda\x28\xb5\xe5\x00\x00\x00")
+
+func chartTemplatesHelpersTplBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesHelpersTpl,
+		"chart/templates/_helpers.tpl",
+	)
+}
+
+func chartTemplatesHelpersTpl() (*asset, error) {
+	bytes, err := chartTemplatesHelpersTplBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/_helpers.tpl", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesControllerYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x54\xc1\x6e\xdb\x30\x0c\xbd\xe7\x2b\x88\x60\x05\xba\x83\x6d\x0c\x58\x2f\x06\x72\x08\x92\x60\x08\xb0\xa5\x45\x33\x74\xc7\x42\x95\xd8\x54\xa8\x2c\x79\xa2\xec\xc5\xe8\xfa\xef\xa3\x62\x35\x75\x9a\xb5\x43\x2f\xf3\xc5\x0e\xf9\xf8\xf8\xc4\xc7\x48\xd4\xfa\x0a\x3d\x69\x67\x4b\x10\x75\x4d\x45\xfb\x69\x74\xaf\xad\x2a\x61\x8e\xb5\x71\x5d\x85\x36\x8c\x2a\x0c\x42\x89\x20\xca\x11\x80\x15\x15\x96\x70\x4b\xdb\x4c\x92\xce\xa4\xb3\xc1\x3b\x63\xd0\xa7\x14\xd5\x42\x72\xfe\xe1\x01\xf2\x4b\x34\x28\x08\xf3\xd5\x53\x18\x1e\x1f\x19\x65\xc4\x0d\x1a\x8a\x54\xc0\xb0\x0c\xb4\x95\xa6\x51\x08\xe3\x27\x4e\xe5\x75\x8b\x3e\xef\x71\x63\xc8\xe1\x37\x58\x56\xc4\x42\xe0\x73\xa4\xa0\x1a\x65\x2c\xf7\x2c\x50\x4b\x41\x7d\xb7\x2b\x61\x1a\xa4\x3c\x05\x67\xae\x61\xf8\xae\x1f\xb1\x0c\x19\x9c\xef\x3b\x56\x22\xc8\xbb\xaf\x03\x09\x10\x8f\xfd\xca\x81\x02\x56\xb5\x11\x01\x53\xe9\x60\x0a\xf1\x31\x07\x2c\x6f\xf1\xb0\x88\xa4\x79\xf7\x8d\xbe\xd5\x12\xa7\x52\x46\x91\xab\x57\xe6\x99\x91\x48\xf8\xda\x6b\xe7\x75\xe8\x66\x46\x10\xf5\x70\xea\x88\xb5\x65\x3c\x38\x7e\xfb\x4c\x72\x9a\x0f\x6d\x52\x41\x70\x5c\x2f\x02\x7b\xba\x57\x97\xc1\x3d\x76\x25\xcc\x12\x70\xaa\x14\x27\xcf\xad\xe9\xf6\xea\x5d\x1d\x6b\x78\x4c\xb0\xd8\x6a\x0a\x94\x12\x51\x90\xd0\x96\x57\xe4\x99\xea\x79\x05\x6a\xd3\x6c\xb4\xdd\x73\xe8\x4a\x6c\x7a\xf3\x77\x5f\x70\xca\xd2\x6d\xb8\x85\xf1\x09\x95\x27\xd1\xca\x64\xd2\x2e\x1b\xad\x72\xa4\xb9\x65\xf7\x22\x11\xc4\xe6\x63\xef\x5d\x1a\xac\xdf\x0c\xc6\x9c\x41\x96\xa1\x55\xb5\x63\xea\xc9\x87\xd3\xd9\x7a\x79\xbd\x58\xcd\x2f\xce\x97\xab\xef\x1f\x0f\x40\xc6\x6d\x82\xa3\xa0\xd0\xfb\x83\x78\x3b\x39\xdb\xff\x46\xdb\x0e\x99\xfb\x93\x0d\x29\xf7\x49\x80\x36\x6a\x2c\xa1\xb1\x7a\x5b\x16\x45\xd1\x0a\x5f\x18\x7d\x53\xb0\x6d\x05\x39\x79\x8f\x81\x8a\x7e\x1e\xb5\x77\xdb\x2e\xc6\xf3\x18\x3f\xa2\x9f\xfe\x58\x5f\x5f\x2e\xbe\x2c\xcf\x57\xc7\xe4\x3c\x3a\x8f\x3f\x1b\xed\x51\xc1\xd8\xe3\x86\x3d\x04\x4d\xfb\xd8\xf3\x08\x93\xf5\x79\xc2\x0c\xa6\xd5\x3a\xd3\x54\xf8\x2d\xee\x16\x1d\x9f\xad\x57\x9a\x29\xed\x07\xcd\xab\x08\xbe\x10\xe1\xae\x84\x7f\x1e\xeb\xc5\x16\xc4\xa5\xe5\x44\xab\xe3\x15\x82\xfe\xd5\x55\x78\xd2\x4d\x5a\xa1\x14\x9e\xf2\x41\xd1\x72\x87\x78\xd3\xf0\x01\x7a\xc2\x8b\x97\xc7\xe1\x8a\x5f\x3c\x06\x57\x1d\xe0\xa2\x1c\xa1\x94\x47\x22\xde\x8d\xe9\x7c\x7e\xb9\x58\xaf\x0f\xd7\x82\x37\xda\xf2\x85\xc0\x5c\x59\xd0\x15\xba\x26\x4c\xce\xaa\xf7\x2d\x48\xe2\x3d\xb6\xef\xfd\x4b\xf1\x7f\xdc\xea\xbb\x1c\xfd\x85\xff\x42\xcf\x37\x5e\xe8\xe6\x9a\xaf\x81\x87\xc7\xd1\x1f\x4e\xfb\xcd\xcb\x1d\x06\x00\x00")
+
+func chartTemplatesControllerYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesControllerYaml,
+		"chart/templates/controller.yaml",
+	)
+}
+
+func chartTemplatesControllerYaml() (*asset, error) {
+	bytes, err := chartTemplatesControllerYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/controller.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesNodeYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x56\x6d\x8f\xda\x38\x10\xfe\xce\xaf\x18\xa1\x56\xea\x4a\x97\xa4\x55\x5b\xa9\x17\xa9\x1f\x5a\x40\xbd\xd5\xb5\x2c\x82\xd5\x7e\xa9\x4e\xc8\x9b\x0c\xc4\x5a\xc7\xb6\x6c\x87\x42\xf7\xfa\xdf\x6f\x9c\x37\x12\x5e\xf6\x96\x22\x81\xc0\x9e\x79\xfc\x78\xe6\x79\x26\x30\xcd\xef\xd0\x58\xae\x64\x0c\x4c\x6b\x1b\x6d\xde\x0c\x1e\xb8\x4c\x63\x18\x33\xcc\x95\x5c\xa0\x1b\xe4\xe8\x58\xca\x1c\x8b\x07\x00\x92\xe5\x18\xc3\xca\x6e\x83\xc4\xf2\x40\xaa\x14\xeb\x45\xab\x59\x42\x3b\x8f\x8f\x10\xce\x51\x20\xb3\x18\x4e\x9b\x65\xf8\xf5\x8b\xa2\x04\xbb\x47\x61\x3d\x08\x50\x58\x00\x5c\x26\xa2\x48\x11\x86\x0d\x5a\x6a\xf8\x06\x4d\x58\xc5\x0d\x21\x84\x7f\x41\x12\x15\x94\x0e\xde\x79\x08\xab\x31\xf1\xe9\x96\xf0\x13\xa7\x4c\x05\x95\x33\x97\x64\x5f\x3b\xd8\xe0\x2f\x72\xc4\xd1\x61\xae\x05\x73\x58\x27\x75\xae\xe4\x5f\xa2\x97\x7f\x1a\x81\x0e\xae\x09\xf8\x57\xa6\xac\x9b\xa2\xfb\xa1\xcc\x43\x0c\xce\x14\x58\xaf\x6b\xc3\x95\xe1\x6e\x37\x12\xcc\xda\x69\x59\x2d\xbb\xb3\x74\x7a\x09\x13\x24\xb4\xc7\x13\x26\xea\x68\xa7\x04\x1a\xe6\xa8\xfc\xed\xe1\x01\x28\xed\xd7\xe8\x7e\x30\xd9\x72\xeb\x6c\xbd\x91\x28\xe9\x18\x97\xd4\xad\x7d\xe8\xbe\x1d\x5a\x14\x6b\x2e\xdb\x0b\x58\x4c\x8a\x92\x06\x25\xe1\xd6\xed\x6f\x56\x32\xdc\x70\x81\x6b\x4c\x7b\xc4\x01\x78\xce\xd6\x55\x0b\xcb\x6f\xf0\x8a\x22\xa5\x5b\xc1\xf0\xa5\x8d\x5f\xfa\x86\xdc\x31\x51\xa0\x0d\xcb\xdd\xd0\xa0\x56\x96\x13\xcd\xdd\xc1\x86\x63\xeb\xab\xaa\xe3\x75\x2d\xcd\xba\x53\xd9\x00\x82\x00\x65\xaa\x15\x41\x7f\x7c\xf1\x6a\xb4\xb8\x5e\x4e\xa6\xe3\xd9\xcd\xf5\xf4\xf6\xaa\x17\x24\xd4\xda\x51\x8d\x53\x34\xa6\xb7\xbe\xf9\xf8\xbe\xfd\x8d\x72\xd3\x45\xae\xaa\xd1\x85\xec\x5c\x7b\xe3\x39\xc6\x50\x48\xbe\x8d\x23\x6a\xab\x7f\x87\x56\x25\x0f\x6d\xcc\x46\x89\x22\xc7\x6f\xaa\x90\xce\x1e\xc3\x3e\x14\x24\x11\x74\x41\xca\x4d\x07\x35\xf7\xd1\x33\xe6\xb2\x18\xa2\x0d\x33\x91\xe0\xf7\x51\x1d\x79\x14\x65\x94\x66\xeb\xb2\xd9\x31\x7c\xe6\x84\x43\x2a\xa6\x1f\xad\x18\xf6\x67\x55\xcd\x7c\xe2\x28\xe2\xde\xee\x68\x65\x4e\xf1\xcd\x90\x09\x97\xfd\xec\x00\xb4\x02\x9a\x51\x46\x0c\x7f\x7e\x78\xfd\xa1\x27\x0b\xe5\x54\xa2\x44\x0c\xb7\xa3\x59\xbb\x2e\xc8\x92\x12\xad\x25\xf2\xf7\xd8\x55\x51\xe6\x9c\xfe\x82\x3d\x61\x11\x46\xc5\xee\xf8\xe8\x8a\xe5\x29\x52\x5c\x92\x21\x98\x18\xa3\x60\xbb\x05\x12\xc5\xd4\xc6\xf0\xe6\x75\x27\xc2\xf1\x1c\x55\xe1\xda\xcd\xb7\x5d\xd2\x48\x76\x4b\x4f\xe7\xad\x18\x17\x85\xc1\xdb\xcc\xa0\xcd\x94\x20\xb5\xbf\x3f\xf0\x4d\xe9\xc8\x6a\xea\x04\x06\xd7\xe4\x35\xc3\xcc\x59\x3b\x34\x2a\xb7\x3c\xc5\x84\x19\x1b\xfa\xf4\x71\x99\x3d\x6f\x92\xaf\xcb\xc8\x27\xc5\xef\x27\x0a\x4b\x53\x22\x65\x49\xff\x9f\xc6\xe3\xf9\x64\xb1\xe8\x4b\xbf\x91\x5a\xc3\xc9\xab\x24\xf0\xb5\xa5\x84\xf1\xfc\xfa\x6e\x32\x5f\xce\x27\x5f\x96\x8b\x9b\xd1\xdf\xcb\xd9\xa7\xdb\xbf\xae\xce\x1a\x44\xf0\x15\x26\xbb\x44\x60\x7f\x00\xe0\xc2\x29\xdd\x6f\x1d\x6e\xf7\xb3\x6d\x2f\x98\x3c\x67\xfe\x59\xf0\x7d\x18\xdd\x73\x19\xd9\x6c\xf8\x07\x0c\x83\xc4\x7f\x9a\x1c\x02\xb3\x82\xa8\xcb\x31\xa2\x41\x14\x7a\x5b\xb1\x1f\x36\xa4\x64\x7f\x81\xd2\x62\xd0\x33\xdc\xf0\x9f\xa7\x0d\x5c\xd7\xe4\xd8\xbb\xa7\x6d\xdb\xa4\x9d\xaa\xcc\x09\x8c\x03\x97\x46\x95\xd7\xec\x21\xf7\x4b\xc7\xc3\x45\x96\x6d\x92\x7a\x0d\x3e\x9f\xda\x0d\x3b\x90\x70\xe3\xcf\x40\x7b\x83\x3e\x5f\xbb\x3d\x5f\x5f\xa8\xda\xdf\x99\x9e\x17\x94\xa7\x02\x3a\x7a\xc8\x9d\x9a\xbf\xfe\x21\x5c\xe6\x0f\x8e\x66\xd0\xf9\x61\xec\x76\xda\xab\xa5\x1c\xbf\xf4\xf4\x1a\xfc\x2f\xd1\xe7\x9f\x72\x56\x4c\xe7\x8f\xbf\x31\x23\x83\xf4\xbf\x64\xf0\x4c\x65\x5c\x4e\x66\x59\x63\xed\x9e\x60\x31\xf8\x0f\xd6\x0c\xfb\x94\x08\x0a\x00\x00")
+
+func chartTemplatesNodeYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesNodeYaml,
+		"chart/templates/node.yaml",
+	)
+}
+
+func chartTemplatesNodeYaml() (*asset, error) {
+	bytes, err := chartTemplatesNodeYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/node.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesPersistentvolumeYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x52\x4d\x4f\xc3\x30\x0c\xbd\xf7\x57\x58\xbb\x37\x12\x12\xa7\xde\x10\x08\x71\xd9\x34\x6d\xd2\x38\x7b\x89\x8b\x2c\xd2\xb4\x24\xe9\xa0\x2a\xfb\xef\x38\x29\xab\xb6\xf1\x71\x8b\xec\xe7\x97\xf7\x9e\x3d\x8e\x25\x70\x0d\x6a\x87\xb6\xa7\xa0\x6a\xb6\xb4\x1d\x42\xa4\x46\xb1\x81\xf2\x78\x2c\xb0\xe3\x1d\xf9\xc0\xad\xab\xe0\x70\x53\xbc\xb2\x33\x15\xac\x53\x45\x50\x2e\xee\x5a\xdb\x37\x54\x34\x14\xd1\x60\xc4\xaa\x00\x70\xd8\x50\x05\xe3\x38\x93\x76\x57\x68\x95\x10\x20\xdc\x00\x16\xf7\x64\x43\x9a\x02\x99\x10\x29\x4e\xdb\xde\x10\x2c\xea\xf0\x51\xea\xc0\xa5\xf1\x7c\x20\xaf\x26\xdc\x02\x14\x7c\x82\x13\x09\xc2\x05\xb7\x89\x22\x74\xa4\xd3\xb8\xc6\x0e\x35\xc7\x61\xa2\x0a\xb1\xf5\xf8\x72\xa9\xe2\xcc\xda\x09\x3c\x69\x38\x64\x51\xcb\xd6\x08\xfe\x51\x40\x21\x83\xa4\x81\x5a\x53\x08\xa9\x91\x15\x96\xb0\x21\x34\xcf\x9e\x23\x2d\xd1\x0d\x52\xb9\x36\xb6\x21\x6d\x91\x9b\x75\x6b\x59\x0f\x95\xc0\x23\xb2\x2b\x66\x39\xf7\x16\x43\x58\x5d\xa7\x73\xde\x3c\x4b\x46\xcc\x4f\x5e\xa6\x04\x2a\x90\x44\x94\x14\x15\xbe\x07\xa5\xdb\x26\xf7\x26\xe9\x4f\xe8\x8c\xfd\xd3\xac\xec\x31\x13\x9e\xd0\x77\x31\x7a\xde\xf7\x91\xbe\x63\x97\x1f\x5c\x98\x77\xe6\xe9\xad\x67\x4f\x06\x16\x0f\xab\x6d\x5e\x25\xb4\x35\x24\x3a\x98\x72\x01\x0e\x33\x68\xf1\xdb\x7f\xc2\xb6\x9a\x5d\xcc\x7b\xfd\xf5\xc4\x9a\xb6\x77\xf1\x12\x9c\x4b\x3f\x2e\xe8\xff\x99\xf4\x01\xb9\x6c\xf3\xf4\x4c\x97\xfb\x05\x8b\x92\x11\xea\xdb\x02\x00\x00")
+
+func chartTemplatesPersistentvolumeYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesPersistentvolumeYaml,
+		"chart/templates/persistentvolume.yaml",
+	)
+}
+
+func chartTemplatesPersistentvolumeYaml() (*asset, error) {
+	bytes, err := chartTemplatesPersistentvolumeYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/persistentvolume.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesRbacYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x53\x3d\x4f\xc3\x30\x10\xdd\xf3\x2b\x4e\x99\x49\x10\x12\x03\xca\x06\x0c\x6c\x0c\x45\x62\x41\x1d\x2e\xce\xb5\x35\x75\x6c\xcb\x1f\xa1\x22\xf4\xbf\x73\x4e\x53\x09\xd1\x02\x29\xea\xe4\xd3\xdd\xf3\xbd\x77\xf6\x3b\xb4\xf2\x99\x9c\x97\x46\x57\xe0\x6a\x14\x25\xc6\xb0\x32\x4e\xbe\x63\xe0\x5c\xb9\xbe\xf1\xa5\x34\x97\xdd\x55\xb6\x96\xba\xa9\xe0\x5e\x45\x1f\xc8\xcd\x8c\xa2\xac\xa5\x80\x0d\x06\xac\x32\x00\x8d\x2d\x55\xb0\xf0\x9b\x82\x36\x5c\xd7\xa8\x0a\xeb\x4c\x27\x53\x63\x72\x85\x4b\x78\x00\x85\x35\x29\x9f\xf0\x00\x7d\x5f\x80\xd4\x42\xc5\x86\x20\x4f\x17\x85\x97\x45\xe3\x64\x47\xae\xdc\xe1\x72\x28\xe1\x03\x34\xf3\x92\x0e\x70\x0d\xdb\x6d\xe6\xa2\x22\xbe\x5f\x00\x5a\xf9\xe0\x4c\xb4\xbe\x82\x97\x3c\x9f\x73\x47\x47\xde\x44\x27\x68\xc8\xd8\x34\x12\x0b\xd5\xa1\x33\x2a\xb6\xe4\x07\x08\xb7\xae\x87\xf2\x92\x42\x7e\x01\xb9\x62\x48\x3a\xdf\x30\x88\x55\x0a\x84\x23\x0c\x94\xa2\x86\x14\x71\x34\x3f\x9d\x4a\x28\x94\xed\x44\xbe\x68\x1b\x3c\xc6\xe2\x83\x71\xb8\xa4\xf1\xf5\x0f\x39\xc7\x3a\x53\x79\x3f\x69\xb6\x09\x73\x50\xc7\x23\x7c\xeb\xf5\xcb\x03\x8d\xd2\x39\xb2\xc7\x19\xfe\x9a\x81\xbf\x5b\x9b\xe6\x5c\xea\x4f\x68\x55\x14\x19\xfe\xdb\xf4\x77\x9c\x90\x7a\xf9\x83\xf7\x93\x85\xbf\xda\xbe\x1e\xd1\x67\x70\xbe\x8f\xf5\x2b\x89\x30\x98\x7f\xa7\xea\x89\x5c\x27\x05\xdd\x0a\x61\xa2\x0e\x07\x3a\x84\xd1\x81\xd7\x4e\xb1\x0c\x8f\x63\xd5\x5b\x14\x0c\xe9\x7b\x28\x67\x6c\x70\xf4\x54\x3e\xee\xd3\xc3\x7a\xf1\x84\x33\x5a\x24\x99\x87\xeb\x3e\x75\xc9\xf7\x3f\xf5\xcb\xd3\x66\x9f\xe7\x03\xda\xe2\x76\x04\x00\x00")
+
+func chartTemplatesRbacYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesRbacYaml,
+		"chart/templates/rbac.yaml",
+	)
+}
+
+func chartTemplatesRbacYaml() (*asset, error) {
+	bytes, err := chartTemplatesRbacYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/rbac.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesServiceaccountYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x35\xce\xc1\x0a\xc2\x30\x0c\xc6\xf1\xfb\x9e\x22\xec\xde\x82\xe0\xa9\x37\x5f\xc0\x83\x82\xf7\x98\x46\x08\x76\xe9\x68\xba\x31\x98\x7b\x77\x3b\x74\xd7\x7c\x3f\xfe\x04\x47\x79\x70\x31\xc9\x1a\x60\x3e\x75\x6f\xd1\x18\xe0\xce\x65\x16\xe2\x0b\x51\x9e\xb4\x76\x03\x57\x8c\x58\x31\x74\x00\x8a\x03\x07\x78\xd9\xe2\xc8\xc4\x51\xd6\x5a\x72\x4a\x5c\x9c\xe1\x7f\xb5\x11\xa9\x91\x75\x05\x7f\xe3\xc4\x68\xec\xaf\xc7\x19\xb6\xad\xa9\x84\x4f\x4e\xb6\xd7\xa0\x31\x07\xa2\x94\xa6\xc8\xd0\x1f\xd9\x58\x64\xe6\xe2\x7f\xae\x07\x0f\x1f\xd0\xf6\x18\x6b\x85\xf3\x9e\xf8\x02\xaa\x12\x8a\x23\xb5\x00\x00\x00")
+
+func chartTemplatesServiceaccountYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesServiceaccountYaml,
+		"chart/templates/serviceaccount.yaml",
+	)
+}
+
+func chartTemplatesServiceaccountYaml() (*asset, error) {
+	bytes, err := chartTemplatesServiceaccountYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/serviceaccount.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartTemplatesStorageclassYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x4f\x3d\x4f\xc3\x30\x10\xdd\xf3\x2b\x4e\xd9\x73\x08\xa9\x03\xf2\x0a\x12\xea\x8c\xd4\xfd\x1a\x1f\xd5\xa9\x8e\x1d\xee\xec\x00\x2a\xfd\xef\xd8\x4d\x85\x00\x31\xb0\xbe\xef\x47\xb3\xec\x58\x4d\x52\x74\x60\x39\x29\x1d\x18\x8f\x77\x86\x92\x6e\x96\xdb\xee\x28\xd1\x3b\x78\x5a\xf1\xfb\x40\x66\xdd\xc4\x99\x3c\x65\x72\x1d\x40\xa4\x89\x1d\x9c\x4e\x80\x3b\x0a\x85\x0d\xed\x9b\x12\x1b\x0b\xe7\x73\xd5\x05\xda\x73\xb0\xe6\x80\xaa\x1e\x40\xe2\x18\x8a\x67\xe8\x9f\xed\x6d\x18\x4d\x06\xaf\xb2\xb0\xe2\xaa\xeb\x01\xe1\x03\x62\xad\xe6\x98\x61\xd3\x22\x66\x4d\x8b\xb4\x91\xac\x0e\xaa\x09\xab\x09\xe9\xd5\x70\x4c\x53\x37\x93\xd6\xa6\x5c\x5f\xb4\x06\x2b\xfb\xc8\x79\xeb\x2f\xbb\x94\x5f\x8a\x28\x7b\xe8\x57\x18\xc4\xbe\xb0\xfe\xef\xd5\x57\xff\xc3\xba\xdc\x78\x2c\x2a\xf9\xfd\x51\x53\x99\xb7\xde\x7e\xa7\x5e\x69\x38\x34\xfe\x3f\xe9\x3f\xf2\x2e\x25\x9f\xf0\x6e\x5c\x07\x82\x01\x00\x00")
+
+func chartTemplatesStorageclassYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartTemplatesStorageclassYaml,
+		"chart/templates/storageclass.yaml",
+	)
+}
+
+func chartTemplatesStorageclassYaml() (*asset, error) {
+	bytes, err := chartTemplatesStorageclassYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/templates/storageclass.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _chartValuesYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x92\x41\x6f\x83\x30\x0c\x85\xef\xf9\x15\xd6\x76\x1d\x94\xf6\x34\x71\x6d\xb5\xaa\xd2\x34\x4d\xab\xb4\xbb\x1b\x0c\xb5\x0a\x09\x8b\x03\x6d\xf7\xeb\x97\x50\x50\x99\x34\xed\x86\x9d\xe7\xcf\x2f\x2f\x70\x83\x15\xe5\x0a\xc0\x51\x6b\x85\xbd\x75\xd7\x1c\x0a\xab\x4f\xe4\x52\xb6\x0b\x6c\xf0\xdb\x9a\x05\x9e\x25\x29\xe5\x92\x68\xe1\xa4\x70\xdc\x93\x0b\x13\x1e\xab\x1c\xfa\x2c\x5d\xa6\x99\x52\xc2\x05\x69\x74\x12\x51\xad\xb3\x3d\x0b\x5b\x43\x6e\x37\xe0\xe1\xab\xc3\x6b\xc4\x9d\x9e\x25\x20\x16\x11\x33\x13\xe5\x7d\x40\xa4\xcb\x30\x69\x6c\x41\x9b\x81\xff\x41\x15\x8b\x77\xf8\x0f\x21\x8a\x47\x37\x89\x9b\xe4\x91\x15\x0d\x01\xd4\xe1\xc0\x90\xc8\xbb\xb3\x07\xfa\x9b\x32\x49\xda\x28\x99\x26\x55\x48\xa2\x66\x8d\x6b\xdb\x19\x9f\xc3\x4a\xa9\x47\x28\xb9\xa6\xfd\x55\x3c\x35\xc0\x02\xfe\x48\xf0\xb2\xbf\x40\x69\x1d\xbc\x76\x61\x2d\x0d\x02\x90\x9b\xc2\x1f\xd1\x47\x19\x5d\x42\xa2\x54\x00\x0a\x20\xb4\xe4\x24\x38\x24\xe3\xa1\xb7\x75\xd7\x90\xba\x33\x63\x66\x5c\xe4\xf0\xf0\x10\x3e\x0a\x23\x6f\xd8\xd0\x58\x35\xd1\xc4\xac\xd6\xd8\xa2\x66\x1f\xde\x68\xb9\xca\xb2\x2d\x47\x73\x12\x1e\x2d\xdc\x6e\x5d\xa3\xc8\x3d\x7b\x01\x43\xe7\xb9\x2f\x19\xfc\xea\x1a\xb9\x91\x27\x60\x33\x5c\x43\xba\x83\x21\x1f\x20\x68\x0a\x38\xb3\x3f\xde\xba\xa4\x3b\x17\xb6\x40\xe5\x6c\xd7\x82\x2d\x87\xee\x8c\xa5\xe6\x3b\xa3\x7d\x33\x58\x8c\xbf\x88\xe8\x50\xde\xb0\xbb\xcd\xe8\x7a\xe2\x6d\x23\x6e\xec\xaa\x7b\x22\x9f\x43\x20\xbf\x31\x6d\xaf\x7e\x00\x27\x20\xf2\x4a\x9b\x02\x00\x00")
+
+func chartValuesYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_chartValuesYaml,
+		"chart/values.yaml",
+	)
+}
+
+func chartValuesYaml() (*asset, error) {
+	bytes, err := chartValuesYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "chart/values.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"chart/Chart.yaml": chartChartYaml,
+	"chart/templates/_helpers.tpl": chartTemplatesHelpersTpl,
+	"chart/templates/controller.yaml": chartTemplatesControllerYaml,
+	"chart/templates/node.yaml": chartTemplatesNodeYaml,
+	"chart/templates/persistentvolume.yaml": chartTemplatesPersistentvolumeYaml,
+	"chart/templates/rbac.yaml": chartTemplatesRbacYaml,
+	"chart/templates/serviceaccount.yaml": chartTemplatesServiceaccountYaml,
+	"chart/templates/storageclass.yaml": chartTemplatesStorageclassYaml,
+	"chart/values.yaml": chartValuesYaml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"chart": &bintree{nil, map[string]*bintree{
+		"Chart.yaml": &bintree{chartChartYaml, map[string]*bintree{}},
+		"templates": &bintree{nil, map[string]*bintree{
+			"_helpers.tpl": &bintree{chartTemplatesHelpersTpl, map[string]*bintree{}},
+			"controller.yaml": &bintree{chartTemplatesControllerYaml, map[string]*bintree{}},
+			"node.yaml": &bintree{chartTemplatesNodeYaml, map[string]*bintree{}},
+			"persistentvolume.yaml": &bintree{chartTemplatesPersistentvolumeYaml, map[string]*bintree{}},
+			"rbac.yaml": &bintree{chartTemplatesRbacYaml, map[string]*bintree{}},
+			"serviceaccount.yaml": &bintree{chartTemplatesServiceaccountYaml, map[string]*bintree{}},
+			"storageclass.yaml": &bintree{chartTemplatesStorageclassYaml, map[string]*bintree{}},
+		}},
+		"values.yaml": &bintree{chartValuesYaml, map[string]*bintree{}},
+	}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
@@ -0,0 +1,76 @@
+package fsxcsi
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/addons/helm"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// ChartName is the name of the embedded chart of the driver
+	ChartName = "aws-fsx-csi-driver"
+	// ReleaseName is the name the driver is installed with
+	ReleaseName = "fsx-csi-driver"
+	// StorageClassName is the name of the StorageClass of the driver
+	StorageClassName = "fsx-sc"
+	// PersistentVolumeName is the name of the volume of the file system of the cluster
+	PersistentVolumeName = "fsx-pv"
+)
+
+// NewRepository returns a chart repository with the embedded chart of the driver
+func NewRepository() (*helm.Repository, error) {
+	chart, err := helm.LoadChartFromAssets("chart", AssetNames(), Asset)
+	if err != nil {
+		return nil, err
+	}
+	return helm.NewRepository(chart), nil
+}
+
+// Deploy installs or upgrades the driver along with a persistent volume of the FSx file
+// system of the cluster, and a StorageClass that provisions new file systems in the same
+// subnet and security group; in plan mode it only logs what would change, it returns true
+// when changes are required
+func Deploy(rawClient kubernetes.RawClientInterface, cfg *api.ClusterConfig, plan bool) (bool, error) {
+	if !cfg.HasFSx() || cfg.Storage.FSx.ID == "" {
+		return false, fmt.Errorf("FSx file system of cluster %q must be known to deploy FSx CSI driver", cfg.Metadata.Name)
+	}
+	// the storage stack creates the file system in the first subnet
+	subnets := cfg.StorageSubnetIDs()
+	if len(subnets) == 0 || cfg.Storage.SecurityGroup == "" {
+		return false, fmt.Errorf("subnets and security group of file systems of cluster %q must be known to deploy FSx CSI driver", cfg.Metadata.Name)
+	}
+	repository, err := NewRepository()
+	if err != nil {
+		return false, err
+	}
+
+	fsx := cfg.Storage.FSx
+	installer := helm.NewInstaller(rawClient, repository, cfg)
+	_, changed, err := installer.Upgrade(helm.InstallOptions{
+		Chart:       ChartName,
+		ReleaseName: ReleaseName,
+		Values: map[string]interface{}{
+			"fileSystem": map[string]interface{}{
+				"id":        fsx.ID,
+				"dnsName":   fsx.DNSName,
+				"mountName": fsx.MountName,
+				"capacity":  fmt.Sprintf("%dGi", fsx.StorageCapacity),
+			},
+			"storageClass": map[string]interface{}{
+				"name":            StorageClassName,
+				"subnetID":        subnets[0],
+				"securityGroupID": cfg.Storage.SecurityGroup,
+			},
+			"persistentVolume": map[string]interface{}{"name": PersistentVolumeName},
+		},
+		Plan: plan,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "deploying FSx CSI driver")
+	}
+	return plan && changed, nil
+}
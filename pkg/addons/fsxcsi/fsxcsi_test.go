@@ -0,0 +1,71 @@
+package fsxcsi_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/fsxcsi"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("FSx CSI driver", func() {
+	var (
+		rawClient *testutils.FakeRawClient
+		cfg       *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		rawClient = testutils.NewFakeRawClient()
+		rawClient.UseUnionTracker = true
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Private: map[string]api.Network{
+				"eu-west-1b": {ID: "subnet-b"},
+				"eu-west-1a": {ID: "subnet-a"},
+			},
+		}
+		cfg.Storage = &api.ClusterStorage{
+			SecurityGroup: "sg-1234",
+			FSx: &api.FSxFileSystem{
+				StorageCapacity: 3600,
+				ID:              "fs-1234",
+				DNSName:         "fs-1234.fsx.eu-west-1.amazonaws.com",
+				MountName:       "abcdef",
+			},
+		}
+	})
+
+	It("should require the security group of the file system", func() {
+		cfg.Storage.SecurityGroup = ""
+		_, err := Deploy(rawClient, cfg, false)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should deploy the driver with a StorageClass and a volume of the file system", func() {
+		Expect(Deploy(rawClient, cfg, false)).To(BeFalse())
+
+		_, err := rawClient.ClientSet().AppsV1().Deployments(metav1.NamespaceSystem).Get("fsx-csi-controller", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = rawClient.ClientSet().AppsV1().DaemonSets(metav1.NamespaceSystem).Get("fsx-csi-node", metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+
+		sc, err := rawClient.ClientSet().StorageV1().StorageClasses().Get(StorageClassName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sc.Provisioner).To(Equal("fsx.csi.aws.com"))
+		Expect(sc.Parameters).To(HaveKeyWithValue("subnetId", "subnet-a"))
+		Expect(sc.Parameters).To(HaveKeyWithValue("securityGroupIds", "sg-1234"))
+
+		pv, err := rawClient.ClientSet().CoreV1().PersistentVolumes().Get(PersistentVolumeName, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pv.Spec.CSI.VolumeHandle).To(Equal("fs-1234"))
+		Expect(pv.Spec.CSI.VolumeAttributes).To(HaveKeyWithValue("dnsname", "fs-1234.fsx.eu-west-1.amazonaws.com"))
+		Expect(pv.Spec.CSI.VolumeAttributes).To(HaveKeyWithValue("mountname", "abcdef"))
+		Expect(pv.Spec.Capacity.Storage().String()).To(Equal("3600Gi"))
+	})
+})
@@ -0,0 +1,164 @@
+// Package helm installs add-ons packaged as Helm charts that are embedded in eksctl,
+// without Tiller or the Helm client; charts are rendered with values derived from the
+// ClusterConfig and applied with the raw client, and releases are recorded in ConfigMaps
+// so that they can be upgraded and uninstalled later
+package helm
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	chartFile  = "Chart.yaml"
+	valuesFile = "values.yaml"
+	templates  = "templates/"
+)
+
+// Chart is a Helm chart, only the subset of the format that is
+// needed by the add-ons is supported (no dependencies or hooks)
+type Chart struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Values are the default values of the chart
+	Values map[string]interface{} `json:"-"`
+	// Templates are keyed by their path in the chart, files with names
+	// starting with "_" only define partials and are not rendered
+	Templates map[string]string `json:"-"`
+}
+
+// LoadChart reads a chart from its files, keyed by their path in the chart
+func LoadChart(files map[string][]byte) (*Chart, error) {
+	metadata, ok := files[chartFile]
+	if !ok {
+		return nil, fmt.Errorf("chart has no %s", chartFile)
+	}
+	chart := &Chart{
+		Values:    map[string]interface{}{},
+		Templates: map[string]string{},
+	}
+	if err := yaml.Unmarshal(metadata, chart); err != nil {
+		return nil, errors.Wrapf(err, "loading %s", chartFile)
+	}
+	if chart.Name == "" || chart.Version == "" {
+		return nil, fmt.Errorf("%s must set name and version", chartFile)
+	}
+	if _, err := semver.ParseTolerant(chart.Version); err != nil {
+		return nil, errors.Wrapf(err, "invalid version of chart %q", chart.Name)
+	}
+
+	if values, ok := files[valuesFile]; ok {
+		if err := yaml.Unmarshal(values, &chart.Values); err != nil {
+			return nil, errors.Wrapf(err, "loading %s of chart %q", valuesFile, chart.Name)
+		}
+	}
+
+	for name, data := range files {
+		if strings.HasPrefix(name, templates) {
+			chart.Templates[name] = string(data)
+		}
+	}
+	return chart, nil
+}
+
+// LoadChartFromAssets reads a chart that is embedded with go-bindata under dir
+func LoadChartFromAssets(dir string, assetNames []string, asset func(string) ([]byte, error)) (*Chart, error) {
+	prefix := strings.TrimSuffix(dir, "/") + "/"
+	files := map[string][]byte{}
+	for _, name := range assetNames {
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		data, err := asset(name)
+		if err != nil {
+			return nil, err
+		}
+		files[strings.TrimPrefix(name, prefix)] = data
+	}
+	chart, err := LoadChart(files)
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading chart from %q", dir)
+	}
+	return chart, nil
+}
+
+// renderedTemplates returns the paths of the templates that produce manifests, in order
+func (c *Chart) renderedTemplates() []string {
+	names := []string{}
+	for name := range c.Templates {
+		if !strings.HasPrefix(path.Base(name), "_") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Repository holds the charts that are available for installation, a
+// chart can be available in several versions, so that it can be pinned
+type Repository struct {
+	charts map[string][]*Chart
+}
+
+// NewRepository creates a Repository with the given charts
+func NewRepository(charts ...*Chart) *Repository {
+	r := &Repository{charts: map[string][]*Chart{}}
+	for _, chart := range charts {
+		r.Add(chart)
+	}
+	return r
+}
+
+// Add makes a chart available
+func (r *Repository) Add(chart *Chart) {
+	versions := append(r.charts[chart.Name], chart)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return parseVersion(versions[i].Version).LT(parseVersion(versions[j].Version))
+	})
+	r.charts[chart.Name] = versions
+}
+
+// Get returns the given version of a chart, or its latest version when version is empty
+func (r *Repository) Get(name, version string) (*Chart, error) {
+	versions, ok := r.charts[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown chart %q", name)
+	}
+	if version == "" {
+		return versions[len(versions)-1], nil
+	}
+	for _, chart := range versions {
+		if parseVersion(chart.Version).EQ(parseVersion(version)) {
+			return chart, nil
+		}
+	}
+	return nil, fmt.Errorf("version %q of chart %q is not available, use one of: %s", version, name, strings.Join(r.Versions(name), ", "))
+}
+
+// Versions returns the available versions of a chart, oldest first
+func (r *Repository) Versions(name string) []string {
+	versions := []string{}
+	for _, chart := range r.charts[name] {
+		versions = append(versions, chart.Version)
+	}
+	return versions
+}
+
+// parseVersion returns the zero version for invalid versions, charts
+// loaded with LoadChart are known to have a valid version
+func parseVersion(version string) semver.Version {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}
+	}
+	return v
+}
@@ -0,0 +1,224 @@
+package helm_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/helm"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+func newTestChart(version string) *Chart {
+	chart, err := LoadChart(map[string][]byte{
+		"Chart.yaml": []byte("name: test-addon\nversion: " + version + "\n"),
+		"values.yaml": []byte(`
+image: k8s.gcr.io/test-addon:v1
+serviceAccount:
+  create: true
+  name: test-addon
+`),
+		"templates/_helpers.tpl": []byte(`{{- define "test-addon.labels" -}}
+app: {{ .Chart.Name }}
+release: {{ .Release.Name }}
+{{- end -}}`),
+		"templates/serviceaccount.yaml": []byte(`{{- if .Values.serviceAccount.create -}}
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ .Values.serviceAccount.name }}
+  labels:
+    {{- include "test-addon.labels" . | nindent 4 }}
+{{- end -}}`),
+		"templates/deployment.yaml": []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  labels:
+    {{- include "test-addon.labels" . | nindent 4 }}
+spec:
+  selector:
+    matchLabels:
+      app: {{ .Chart.Name }}
+  template:
+    metadata:
+      labels:
+        app: {{ .Chart.Name }}
+    spec:
+      serviceAccountName: {{ .Values.serviceAccount.name }}
+      containers:
+      - name: addon
+        image: {{ image .Values.image }}
+        args:
+        - --cluster={{ required "cluster name is required" .Values.cluster.name }}
+        - --region={{ .Values.cluster.region }}
+        - --log-level={{ default "info" .Values.logLevel }}
+`),
+	})
+	Expect(err).ToNot(HaveOccurred())
+	return chart
+}
+
+var _ = Describe("Helm", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+	})
+
+	Describe("LoadChart", func() {
+		It("should require chart metadata", func() {
+			_, err := LoadChart(map[string][]byte{"values.yaml": []byte("a: b")})
+			Expect(err).To(MatchError("chart has no Chart.yaml"))
+
+			_, err = LoadChart(map[string][]byte{"Chart.yaml": []byte("name: test-addon")})
+			Expect(err).To(MatchError("Chart.yaml must set name and version"))
+		})
+	})
+
+	Describe("Render", func() {
+		It("should render templates with values derived from the cluster", func() {
+			chart := newTestChart("0.1.0")
+			values := MergeValues(chart.Values, map[string]interface{}{
+				"cluster":  ClusterValues(cfg),
+				"logLevel": "debug",
+			})
+
+			manifest, err := Render(chart, ReleaseInfo{Name: "addon", Namespace: "kube-system"}, values, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(manifest)).To(ContainSubstring("# Source: templates/deployment.yaml\n"))
+			Expect(string(manifest)).To(ContainSubstring("  labels:\n    app: test-addon\n    release: addon\n"))
+			Expect(string(manifest)).To(ContainSubstring("- --cluster=test-cluster\n"))
+			Expect(string(manifest)).To(ContainSubstring("- --region=eu-west-1\n"))
+			Expect(string(manifest)).To(ContainSubstring("- --log-level=debug\n"))
+			Expect(string(manifest)).To(ContainSubstring("image: k8s.gcr.io/test-addon:v1\n"))
+		})
+
+		It("should map images to the offline registry", func() {
+			cfg.ContainerRuntime = &api.ClusterContainerRuntime{
+				Offline: &api.OfflineConfig{ImageRegistry: "registry.example.com:5000"},
+			}
+			chart := newTestChart("0.1.0")
+			values := MergeValues(chart.Values, map[string]interface{}{"cluster": ClusterValues(cfg)})
+
+			manifest, err := Render(chart, ReleaseInfo{Name: "addon", Namespace: "kube-system"}, values, cfg)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(manifest)).To(ContainSubstring("image: registry.example.com:5000/test-addon:v1\n"))
+		})
+
+		It("should fail when required values are missing", func() {
+			chart := newTestChart("0.1.0")
+
+			_, err := Render(chart, ReleaseInfo{Name: "addon", Namespace: "kube-system"}, chart.Values, cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cluster name is required"))
+		})
+	})
+
+	Describe("MergeValues", func() {
+		It("should merge nested values", func() {
+			merged := MergeValues(
+				map[string]interface{}{"a": map[string]interface{}{"b": 1, "c": 2}, "d": 3},
+				map[string]interface{}{"a": map[string]interface{}{"c": 20}, "e": 5},
+			)
+			Expect(merged).To(Equal(map[string]interface{}{
+				"a": map[string]interface{}{"b": 1, "c": 20},
+				"d": 3,
+				"e": 5,
+			}))
+		})
+	})
+
+	Describe("Repository", func() {
+		It("should return the latest or the pinned version", func() {
+			repo := NewRepository(newTestChart("0.10.0"), newTestChart("0.2.0"), newTestChart("0.9.1"))
+
+			chart, err := repo.Get("test-addon", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chart.Version).To(Equal("0.10.0"))
+
+			chart, err = repo.Get("test-addon", "0.2.0")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(chart.Version).To(Equal("0.2.0"))
+
+			_, err = repo.Get("test-addon", "0.3.0")
+			Expect(err).To(MatchError(`version "0.3.0" of chart "test-addon" is not available, use one of: 0.2.0, 0.9.1, 0.10.0`))
+
+			_, err = repo.Get("other-addon", "")
+			Expect(err).To(MatchError(`unknown chart "other-addon"`))
+		})
+	})
+
+	Describe("Installer", func() {
+		var (
+			rawClient *testutils.FakeRawClient
+			installer *Installer
+		)
+
+		BeforeEach(func() {
+			rawClient = testutils.NewFakeRawClient()
+			rawClient.UseUnionTracker = true
+			installer = NewInstaller(rawClient, NewRepository(newTestChart("0.1.0"), newTestChart("0.2.0")), cfg)
+		})
+
+		It("should install, upgrade and uninstall a release", func() {
+			release, err := installer.Install(InstallOptions{Chart: "test-addon", Version: "0.1.0"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(release.Name).To(Equal("test-addon"))
+			Expect(release.Resources).To(Equal([]ResourceRef{
+				{APIVersion: "v1", Kind: "ServiceAccount", Namespace: "kube-system", Name: "test-addon"},
+				{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "kube-system", Name: "test-addon"},
+			}))
+
+			_, err = rawClient.ClientSet().CoreV1().ConfigMaps(metav1.NamespaceSystem).Get("eksctl-addon-test-addon", metav1.GetOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			installed, err := installer.GetRelease("test-addon", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(installed.Version).To(Equal("0.1.0"))
+			Expect(installed.Resources).To(Equal(release.Resources))
+
+			_, err = installer.Install(InstallOptions{Chart: "test-addon"})
+			Expect(err).To(MatchError(`release "test-addon" is already installed with version 0.1.0 of chart "test-addon"`))
+
+			_, changed, err := installer.Upgrade(InstallOptions{Chart: "test-addon", Version: "0.1.0"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+
+			release, changed, err = installer.Upgrade(InstallOptions{
+				Chart: "test-addon",
+				Values: map[string]interface{}{
+					"serviceAccount": map[string]interface{}{"create": false},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(release.Version).To(Equal("0.2.0"))
+			Expect(release.Resources).To(HaveLen(1))
+
+			for k := range rawClient.Collection.AllTracked() {
+				Expect(k).ToNot(HaveSuffix("/serviceaccounts/test-addon"))
+			}
+
+			Expect(installer.Uninstall("test-addon", "", false)).To(Succeed())
+			Expect(rawClient.Collection.AllTracked()).To(BeEmpty())
+
+			installed, err = installer.GetRelease("test-addon", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(installed).To(BeNil())
+		})
+
+		It("should not change anything in plan mode", func() {
+			_, err := installer.Install(InstallOptions{Chart: "test-addon", Plan: true})
+			Expect(err).ToNot(HaveOccurred())
+
+			installed, err := installer.GetRelease("test-addon", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(installed).To(BeNil())
+		})
+	})
+})
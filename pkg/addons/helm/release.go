@@ -0,0 +1,408 @@
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	releasePrefix    = "eksctl-addon-"
+	managedByLabel   = "app.kubernetes.io/managed-by"
+	releaseLabel     = "addons.eksctl.io/release"
+	managedBy        = "eksctl"
+	chartKey         = "chart"
+	versionKey       = "version"
+	valuesKey        = "values"
+	resourcesKey     = "resources"
+	defaultNamespace = metav1.NamespaceSystem
+)
+
+// installOrder is the order in which objects are created, dependencies first, as in Helm
+var installOrder = []string{
+	"Namespace",
+	"CustomResourceDefinition",
+	"ServiceAccount",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Deployment",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"Ingress",
+	"APIService",
+	"PodDisruptionBudget",
+}
+
+// Release is a chart that is installed in the cluster
+type Release struct {
+	Name      string
+	Namespace string
+	Chart     string
+	Version   string
+	// Values are the values given at installation, without the defaults
+	Values map[string]interface{}
+	// Resources are the objects of the release, in installation order
+	Resources []ResourceRef
+}
+
+// ResourceRef identifies an object of a release
+type ResourceRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+func (r ResourceRef) String() string {
+	if r.Namespace == "" {
+		return fmt.Sprintf("%s/%s", r.Kind, r.Name)
+	}
+	return fmt.Sprintf("%s:%s/%s", r.Namespace, r.Kind, r.Name)
+}
+
+// InstallOptions holds options for installing and upgrading a chart
+type InstallOptions struct {
+	// Chart is the name of the chart in the repository
+	Chart string
+	// Version pins the version of the chart, the latest version is used when it's empty
+	Version string
+	// ReleaseName defaults to the name of the chart
+	ReleaseName string
+	// Namespace defaults to kube-system
+	Namespace string
+	// Values override the defaults of the chart, and the values derived from the cluster
+	Values map[string]interface{}
+	// Plan only logs the changes, without applying them
+	Plan bool
+}
+
+func (o *InstallOptions) releaseName() string {
+	if o.ReleaseName != "" {
+		return o.ReleaseName
+	}
+	return o.Chart
+}
+
+func (o *InstallOptions) namespace() string {
+	if o.Namespace != "" {
+		return o.Namespace
+	}
+	return defaultNamespace
+}
+
+// Installer installs, upgrades and uninstalls charts
+type Installer struct {
+	rawClient  kubernetes.RawClientInterface
+	repository *Repository
+	cfg        *api.ClusterConfig
+}
+
+// NewInstaller creates an Installer for the charts of the repository
+func NewInstaller(rawClient kubernetes.RawClientInterface, repository *Repository, cfg *api.ClusterConfig) *Installer {
+	return &Installer{
+		rawClient:  rawClient,
+		repository: repository,
+		cfg:        cfg,
+	}
+}
+
+// GetRelease returns the release, or nil when it is not installed
+func (i *Installer) GetRelease(name, namespace string) (*Release, error) {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	cm, err := i.rawClient.ClientSet().CoreV1().ConfigMaps(namespace).Get(releasePrefix+name, metav1.GetOptions{})
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "getting release %q", name)
+	}
+	release := &Release{
+		Name:      name,
+		Namespace: namespace,
+		Chart:     cm.Data[chartKey],
+		Version:   cm.Data[versionKey],
+	}
+	if err := json.Unmarshal([]byte(cm.Data[valuesKey]), &release.Values); err != nil {
+		return nil, errors.Wrapf(err, "loading values of release %q", name)
+	}
+	if err := json.Unmarshal([]byte(cm.Data[resourcesKey]), &release.Resources); err != nil {
+		return nil, errors.Wrapf(err, "loading resources of release %q", name)
+	}
+	return release, nil
+}
+
+// Install installs a chart, it fails when the release is already installed
+func (i *Installer) Install(opts InstallOptions) (*Release, error) {
+	existing, err := i.GetRelease(opts.releaseName(), opts.namespace())
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("release %q is already installed with version %s of chart %q", existing.Name, existing.Version, existing.Chart)
+	}
+	release, _, err := i.apply(nil, opts)
+	return release, err
+}
+
+// Upgrade upgrades a release to the pinned or latest version of its chart and to the
+// given values, the release is installed when it's missing; it returns true when the
+// release was changed
+func (i *Installer) Upgrade(opts InstallOptions) (*Release, bool, error) {
+	existing, err := i.GetRelease(opts.releaseName(), opts.namespace())
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil && existing.Chart != opts.Chart {
+		return nil, false, fmt.Errorf("release %q is an installation of chart %q, not %q", existing.Name, existing.Chart, opts.Chart)
+	}
+	return i.apply(existing, opts)
+}
+
+func (i *Installer) apply(existing *Release, opts InstallOptions) (*Release, bool, error) {
+	chart, err := i.repository.Get(opts.Chart, opts.Version)
+	if err != nil {
+		return nil, false, err
+	}
+
+	release := &Release{
+		Name:      opts.releaseName(),
+		Namespace: opts.namespace(),
+		Chart:     chart.Name,
+		Version:   chart.Version,
+		Values:    opts.Values,
+	}
+	if release.Values == nil {
+		release.Values = map[string]interface{}{}
+	}
+
+	if existing != nil && existing.Version == release.Version && reflect.DeepEqual(existing.Values, normalizeValues(release.Values)) {
+		logger.Info("release %q is already up-to-date with version %s of chart %q", release.Name, release.Version, release.Chart)
+		return existing, false, nil
+	}
+
+	manifest, err := Render(chart, ReleaseInfo{Name: release.Name, Namespace: release.Namespace}, releaseValues(chart, i.cfg, release.Values), i.cfg)
+	if err != nil {
+		return nil, false, err
+	}
+	list, err := kubernetes.NewList(manifest)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "loading manifests of chart %q", chart.Name)
+	}
+	sortForInstall(list.Items)
+
+	for _, rawObj := range list.Items {
+		resource, err := i.rawClient.NewRawResource(rawObj)
+		if err != nil {
+			return nil, false, err
+		}
+		if resource.Info.Namespace == "" && resource.Helper.NamespaceScoped {
+			resource.Info.Namespace = release.Namespace
+		}
+		if opts.Plan {
+			logger.Info("%s", resource.LogAction(true, "applied"))
+		} else {
+			status, err := resource.CreateOrReplace(false)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "applying %q of release %q", resource, release.Name)
+			}
+			logger.Info("%s", status)
+		}
+		release.Resources = append(release.Resources, ResourceRef{
+			APIVersion: resource.GVK.GroupVersion().String(),
+			Kind:       resource.GVK.Kind,
+			Namespace:  resource.Info.Namespace,
+			Name:       resource.Info.Name,
+		})
+	}
+
+	if existing != nil {
+		if err := i.deleteResources(removedResources(existing.Resources, release.Resources), opts.Plan); err != nil {
+			return nil, false, err
+		}
+	}
+
+	if !opts.Plan {
+		if err := i.saveRelease(release); err != nil {
+			return nil, false, err
+		}
+	}
+
+	verb := "installed"
+	if existing != nil {
+		verb = "upgraded"
+	}
+	if opts.Plan {
+		logger.Info("(plan) would have %s release %q with version %s of chart %q", verb, release.Name, release.Version, release.Chart)
+	} else {
+		logger.Info("%s release %q with version %s of chart %q", verb, release.Name, release.Version, release.Chart)
+	}
+	return release, true, nil
+}
+
+// Uninstall deletes all objects of a release, and the record of the release
+func (i *Installer) Uninstall(name, namespace string, plan bool) error {
+	release, err := i.GetRelease(name, namespace)
+	if err != nil {
+		return err
+	}
+	if release == nil {
+		logger.Info("release %q is not installed", name)
+		return nil
+	}
+
+	resources := make([]ResourceRef, len(release.Resources))
+	for j, r := range release.Resources {
+		resources[len(resources)-1-j] = r
+	}
+	if err := i.deleteResources(resources, plan); err != nil {
+		return err
+	}
+	if err := i.deleteResources([]ResourceRef{releaseRef(release)}, plan); err != nil {
+		return err
+	}
+
+	if plan {
+		logger.Info("(plan) would have uninstalled release %q", name)
+	} else {
+		logger.Info("uninstalled release %q", name)
+	}
+	return nil
+}
+
+func (i *Installer) saveRelease(release *Release) error {
+	values, err := json.Marshal(release.Values)
+	if err != nil {
+		return errors.Wrapf(err, "serializing values of release %q", release.Name)
+	}
+	resources, err := json.Marshal(release.Resources)
+	if err != nil {
+		return errors.Wrapf(err, "serializing resources of release %q", release.Name)
+	}
+
+	ref := releaseRef(release)
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      ref.Name,
+			Namespace: ref.Namespace,
+			Labels: map[string]string{
+				managedByLabel: managedBy,
+				releaseLabel:   release.Name,
+			},
+		},
+		Data: map[string]string{
+			chartKey:     release.Chart,
+			versionKey:   release.Version,
+			valuesKey:    string(values),
+			resourcesKey: string(resources),
+		},
+	}
+	resource, err := i.rawClient.NewRawResource(runtime.RawExtension{Object: cm})
+	if err != nil {
+		return err
+	}
+	if _, err := resource.CreateOrReplace(false); err != nil {
+		return errors.Wrapf(err, "saving release %q", release.Name)
+	}
+	return nil
+}
+
+func (i *Installer) deleteResources(resources []ResourceRef, plan bool) error {
+	for _, ref := range resources {
+		obj := &unstructured.Unstructured{}
+		obj.SetAPIVersion(ref.APIVersion)
+		obj.SetKind(ref.Kind)
+		obj.SetNamespace(ref.Namespace)
+		obj.SetName(ref.Name)
+
+		resource, err := i.rawClient.NewRawResource(runtime.RawExtension{Object: obj})
+		if err != nil {
+			return err
+		}
+		if !plan {
+			if _, err := resource.Helper.Delete(ref.Namespace, ref.Name); err != nil && !apierrs.IsNotFound(err) {
+				return errors.Wrapf(err, "deleting %q", ref)
+			}
+		}
+		logger.Info("%s", resource.LogAction(plan, "deleted"))
+	}
+	return nil
+}
+
+func releaseRef(release *Release) ResourceRef {
+	return ResourceRef{
+		APIVersion: "v1",
+		Kind:       "ConfigMap",
+		Namespace:  release.Namespace,
+		Name:       releasePrefix + release.Name,
+	}
+}
+
+// removedResources returns the resources of the old release that
+// are not in the current one, in reverse installation order
+func removedResources(old, current []ResourceRef) []ResourceRef {
+	kept := map[ResourceRef]bool{}
+	for _, r := range current {
+		kept[r] = true
+	}
+	removed := []ResourceRef{}
+	for j := len(old) - 1; j >= 0; j-- {
+		if !kept[old[j]] {
+			removed = append(removed, old[j])
+		}
+	}
+	return removed
+}
+
+func sortForInstall(items []runtime.RawExtension) {
+	rank := func(item runtime.RawExtension) int {
+		kind := item.Object.GetObjectKind().GroupVersionKind().Kind
+		for j, k := range installOrder {
+			if k == kind {
+				return j
+			}
+		}
+		return len(installOrder)
+	}
+	sort.SliceStable(items, func(a, b int) bool { return rank(items[a]) < rank(items[b]) })
+}
+
+// normalizeValues returns values as they are after being recorded
+// in a release, so that they can be compared with recorded values
+func normalizeValues(values map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return values
+	}
+	normalized := map[string]interface{}{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return values
+	}
+	return normalized
+}
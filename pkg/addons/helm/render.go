@@ -0,0 +1,143 @@
+package helm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ReleaseInfo is exposed to templates as .Release
+type ReleaseInfo struct {
+	Name      string
+	Namespace string
+}
+
+// Render renders the templates of the chart and returns them as a single multi-document manifest
+func Render(chart *Chart, release ReleaseInfo, values map[string]interface{}, cfg *api.ClusterConfig) ([]byte, error) {
+	tpl := template.New(chart.Name).Option("missingkey=zero")
+	tpl.Funcs(templateFuncs(tpl, cfg))
+	for name, text := range chart.Templates {
+		if _, err := tpl.New(name).Parse(text); err != nil {
+			return nil, errors.Wrapf(err, "parsing template %q of chart %q", name, chart.Name)
+		}
+	}
+
+	data := map[string]interface{}{
+		"Values":  values,
+		"Release": release,
+		"Chart":   chart,
+	}
+
+	manifest := &bytes.Buffer{}
+	for _, name := range chart.renderedTemplates() {
+		out := &bytes.Buffer{}
+		if err := tpl.ExecuteTemplate(out, name, data); err != nil {
+			return nil, errors.Wrapf(err, "rendering template %q of chart %q", name, chart.Name)
+		}
+		// as in Helm, missing values render as empty strings
+		rendered := strings.TrimSpace(strings.Replace(out.String(), "<no value>", "", -1))
+		if rendered == "" {
+			continue
+		}
+		fmt.Fprintf(manifest, "---\n# Source: %s\n%s\n", name, rendered)
+	}
+	return manifest.Bytes(), nil
+}
+
+// templateFuncs are the most commonly used functions of Helm templates,
+// and image, which maps images to the offline registry of the cluster
+func templateFuncs(tpl *template.Template, cfg *api.ClusterConfig) template.FuncMap {
+	return template.FuncMap{
+		"include": func(name string, data interface{}) (string, error) {
+			out := &bytes.Buffer{}
+			if err := tpl.ExecuteTemplate(out, name, data); err != nil {
+				return "", err
+			}
+			return out.String(), nil
+		},
+		"required": func(msg string, value interface{}) (interface{}, error) {
+			if isEmpty(value) {
+				return nil, errors.New(msg)
+			}
+			return value, nil
+		},
+		"default": func(defaultValue interface{}, given ...interface{}) interface{} {
+			if len(given) == 0 || isEmpty(given[0]) {
+				return defaultValue
+			}
+			return given[0]
+		},
+		"toYaml": func(value interface{}) string {
+			data, err := yaml.Marshal(value)
+			if err != nil {
+				return ""
+			}
+			return strings.TrimSuffix(string(data), "\n")
+		},
+		"toJson": func(value interface{}) string {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return ""
+			}
+			return string(data)
+		},
+		"indent": indent,
+		"nindent": func(spaces int, text string) string {
+			return "\n" + indent(spaces, text)
+		},
+		"quote": func(value interface{}) string {
+			if value == nil {
+				return `""`
+			}
+			return fmt.Sprintf("%q", fmt.Sprint(value))
+		},
+		"b64enc": func(text string) string {
+			return base64.StdEncoding.EncodeToString([]byte(text))
+		},
+		"lower": strings.ToLower,
+		"upper": strings.ToUpper,
+		"trim":  strings.TrimSpace,
+		"image": func(image string) string {
+			if cfg == nil {
+				return image
+			}
+			return cfg.MirroredImage(image)
+		},
+	}
+}
+
+func indent(spaces int, text string) string {
+	pad := strings.Repeat(" ", spaces)
+	return pad + strings.Replace(text, "\n", "\n"+pad, -1)
+}
+
+func isEmpty(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package helm
+
+import (
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// ClusterValues are the values derived from the ClusterConfig, they are
+// set under the "cluster" key, so that charts can refer to .Values.cluster.name
+func ClusterValues(cfg *api.ClusterConfig) map[string]interface{} {
+	values := map[string]interface{}{
+		"name":    cfg.Metadata.Name,
+		"region":  cfg.Metadata.Region,
+		"version": cfg.Metadata.Version,
+	}
+	if cfg.VPC != nil && cfg.VPC.ID != "" {
+		values["vpcID"] = cfg.VPC.ID
+	}
+	if cfg.ContainerRuntime != nil && cfg.ContainerRuntime.Offline != nil && cfg.ContainerRuntime.Offline.ImageRegistry != "" {
+		values["imageRegistry"] = cfg.ContainerRuntime.Offline.ImageRegistry
+	}
+	return values
+}
+
+// MergeValues merges values into a copy of base, nested maps are merged
+// recursively and any other values replace the ones in base
+func MergeValues(base, values map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range values {
+		baseMap, baseIsMap := merged[k].(map[string]interface{})
+		valueMap, valueIsMap := v.(map[string]interface{})
+		if baseIsMap && valueIsMap {
+			merged[k] = MergeValues(baseMap, valueMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// releaseValues returns the values a release is rendered with, the defaults of
+// the chart are overridden by the values derived from the cluster, and those by
+// the values that are given by the user
+func releaseValues(chart *Chart, cfg *api.ClusterConfig, values map[string]interface{}) map[string]interface{} {
+	merged := MergeValues(chart.Values, map[string]interface{}{"cluster": ClusterValues(cfg)})
+	return MergeValues(merged, values)
+}
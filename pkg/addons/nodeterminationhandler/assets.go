@@ -0,0 +1,235 @@
+// Code generated by go-bindata.
+// sources:
+// assets/node-termination-handler.yaml
+// DO NOT EDIT!
+
+package nodeterminationhandler
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _nodeTerminationHandlerYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x56\xdf\x73\xe2\x36\x10\x7e\xcf\x5f\xa1\xe1\xb9\x26\xc9\x4d\xdb\xb9\xf1\x9b\x03\x0a\x65\x4a\x0c\x87\xe1\xfa\xd0\xc9\x78\x84\xbc\x80\x8a\x2c\xfb\x24\x99\x84\xcb\xe4\x7f\xef\xca\x98\x0b\x10\x93\x83\xbb\xa6\x4d\xa7\xe5\x21\x61\xa4\xdd\x6f\x3f\xed\x8f\x6f\xf1\x3c\xef\x8c\xe5\xe2\x23\x68\x23\x32\xe5\x93\xe5\xe5\xd9\x42\xa8\xc4\x27\x11\xe8\xa5\xe0\x10\x70\x9e\x15\xca\x9e\xa5\x60\x59\xc2\x2c\xf3\xcf\x08\x51\x2c\x05\x9f\xb0\x3b\xe3\xa9\x2c\x01\xcf\x82\x4e\x85\x62\x16\xfd\xbd\x39\x53\x89\x04\x5d\x19\x99\x9c\x71\xb4\x5c\x14\x13\xf0\xcc\xca\x58\x48\xf1\x42\xb2\x09\x48\xe3\x70\x08\x59\xbc\x37\x1e\xcb\xf3\xaf\x80\x79\x7b\x1c\xf5\x84\xf1\x26\x2b\xec\x3c\xd3\xe2\x73\x69\xda\x44\xa0\xa6\xc8\xce\xbf\xb0\x6f\xc9\x02\xc3\xe9\x61\x26\xe1\x74\xea\xa7\x33\xd4\x85\x84\xd2\xc1\x23\x48\xb4\xa3\xb3\x22\x37\x3e\xf9\xbd\xd1\xb8\x2d\x41\x34\x98\xac\xd0\x1c\xca\x33\x07\x62\xaa\x8b\x25\xe8\x49\x79\x38\x03\xdb\xf8\x81\x34\xa4\x30\xe5\xff\x9c\x59\x3e\x77\x5f\x8a\x1c\x99\x43\x69\x7d\x04\x74\x9e\x25\x2f\x22\x9f\x00\x73\x0e\x58\x7d\xf7\xc6\x7d\x3c\xae\xe1\x00\x23\xb8\xb7\xa0\x5c\x85\x8c\x0b\x88\x49\x33\x75\xe0\x09\x83\x14\x4d\xc0\xd6\x32\xbd\xfd\xce\x62\x5f\xe1\x81\x50\xb3\xbf\xa5\xe6\x18\x6e\x08\x53\xe7\xb2\x49\xc3\x0b\x6c\xd1\xea\x79\x67\x1e\xc5\xcd\x14\x93\x3f\x80\xdb\xaa\xbd\x6a\x87\xd3\x91\x3e\xea\x95\x07\xc7\x72\x3f\xeb\xae\x7a\x4f\x09\x6e\x97\x35\x8b\xe0\x9f\x95\x01\x93\x03\x77\xf6\x06\x24\xe6\x23\xd3\x6b\xdf\xd4\x8d\x4a\x6f\x0b\xec\x58\x38\x42\xd6\xc3\x15\x59\x8d\x7f\x67\xab\xb5\xb7\x5d\xe5\x48\x15\xab\x23\xb1\x8d\xc6\xa5\x01\x9e\x23\xe5\x5c\xe2\xd7\x2a\xe4\x56\x12\xdc\x47\xee\x44\x3f\x3e\x3e\x3e\xa5\x7a\x52\xf9\x7d\xa7\xa6\xe1\x71\xe5\x24\x24\xd7\x02\x7b\xcd\xae\x5a\x92\x19\xb3\xf6\x5a\xe7\x78\xed\xc8\xf1\x4e\x70\x26\x2b\xeb\x79\x66\x6c\x08\xf6\x2e\xd3\x0b\x9f\x58\x5d\x40\x75\x6e\xb1\x1d\x75\x09\xbf\xf5\x0e\x8f\x64\xb9\x3b\xc5\x54\x13\x7a\x8f\x02\x62\xaa\x2b\x36\x9d\x0a\x85\x31\x9f\x4c\x5d\xac\xe0\xd9\xa9\x1b\xfd\x4f\x85\xd0\x90\xb4\x0b\x8d\xf9\x8c\xf8\x1c\x92\xc2\x65\xb6\x3b\x53\xd9\x97\x63\x7a\x0f\xbc\x70\xb1\xb7\x3d\xd7\x98\x51\x55\xeb\x11\xbe\xdf\xec\x5e\x3b\x7e\x65\xf1\xe9\x7d\x8e\x0a\x63\x76\xb9\x6f\x5b\x2d\x60\x85\xa9\x94\xf9\x9c\x35\x61\x61\xb8\x95\x4e\x3d\x1c\xfa\xcc\x0d\xad\xe7\x5a\xb4\xc6\x8f\x6c\xbd\xbe\xab\x6a\x0d\x96\x4c\x16\xa5\xaa\xdd\x56\xd7\x3c\x53\x96\x09\x85\x53\xb4\x9d\x45\x75\x6c\x2d\xdd\x47\xa4\x6c\x86\xd6\x79\x31\x91\x82\x37\x81\xeb\x26\x3a\x9e\x3b\x67\xe0\xef\xce\x5f\x02\xf1\x1f\x1e\x4a\xe7\xd8\xb2\xd9\xe3\xe3\x16\xa4\xc1\xf4\x96\x2d\x82\xec\x50\xa4\x77\x93\x84\x72\x9e\xf4\x95\x5c\x0d\xb3\xcc\x5e\x0b\x5c\x63\x65\xf3\xec\x34\x47\x65\x58\xa8\xc0\x84\x99\x72\x86\x87\xae\xc7\xd8\xc4\x3e\xb9\xbc\xb8\xb8\xd8\xb9\x63\x52\x66\x77\x03\x2d\x96\x88\x3f\x03\x6a\xb0\x1f\x4b\xe6\x3e\x99\x32\x69\xb6\x71\x40\x2d\x77\xe9\x6d\xb2\x17\xf6\xdb\x34\x0e\x83\x1b\x7a\x56\x53\x82\x6b\x9d\xa5\xcf\x4b\x3f\x15\x20\x93\x4a\xa1\x6b\xef\x06\xcc\xce\xfd\x72\x06\x9b\x2e\xa7\xe1\x7e\x1f\x6c\x62\x0f\xfa\xed\xd7\x09\xbd\x11\x92\xa6\x3a\x14\xda\x85\x8d\x06\x41\xeb\x95\x63\x97\x0a\x5d\x4b\xa0\x4d\x7b\x74\x44\xe3\x5e\xbf\x15\xf4\xe2\x76\x30\x0a\xea\x88\xf8\xa4\xe1\xfa\xa1\x51\x8b\xd0\xed\x84\xfd\x21\x45\x5f\x7a\xd3\x0f\xe3\x88\x8e\xa2\x93\x21\x68\x18\x5c\xf5\x68\x1c\x0d\xfa\xa3\xb8\x1b\x8e\xe8\x70\x38\x1e\x8c\xba\x88\xd6\x1e\x06\xdd\xb0\x1b\x76\xbe\x19\xb1\xf5\x0b\x6d\x8f\x7b\xb4\x1d\xd3\x8f\x34\x1c\x9d\x8a\xf7\xf4\xc3\x66\x6f\xa4\x3e\xa1\x2c\xd8\x67\x6a\xc4\xf3\xc2\x27\x3f\x5d\xa4\x7b\xc7\x29\x2e\x57\x8d\x0a\xf5\xf3\x8f\x37\x62\xe7\x4a\x8a\x54\x1c\x40\xc1\x11\x3b\x04\x73\xf9\xee\x3d\xe2\x7c\x65\xa1\x43\x2e\xb3\x55\x0a\xea\x6d\x6c\x74\x8d\x74\x70\x49\xa1\x92\x5e\xfe\x95\xfb\xfd\x5f\xb9\xb5\xf9\xfa\xf7\xe1\xfe\xe2\xfe\x7f\xb3\xbc\xd6\x66\xf9\x2f\xab\x7b\xf0\x5b\x14\x0f\x69\x07\xa5\xf4\x80\xe2\x3d\x3c\x68\x98\x61\x3e\x63\x67\xff\xf8\x58\x2f\xa6\x1f\xc6\x74\x4c\xe3\xf1\xb0\x77\x10\x04\xf5\xb0\x80\xb8\xd0\xf2\x10\xc4\x46\x8f\x3f\x44\x31\xca\xfb\x4d\x37\x0c\xbe\x4f\xdf\x51\xd7\x5b\xbf\xc6\x41\xd4\x89\x47\x41\x27\xbe\xa2\xd7\xe5\x0a\xfa\x56\xb4\x9b\x20\x0c\x3a\xb8\x24\x2a\xbc\x7a\xff\x97\x06\xe8\x3c\x65\x0a\xe7\x27\x79\x6b\x2b\xf6\x0d\x2f\xb0\x3f\x01\xe7\x60\x71\x1f\x99\x12\x00\x00")
+
+func nodeTerminationHandlerYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_nodeTerminationHandlerYaml,
+		"node-termination-handler.yaml",
+	)
+}
+
+func nodeTerminationHandlerYaml() (*asset, error) {
+	bytes, err := nodeTerminationHandlerYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "node-termination-handler.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"node-termination-handler.yaml": nodeTerminationHandlerYaml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"node-termination-handler.yaml": &bintree{nodeTerminationHandlerYaml, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
@@ -0,0 +1,137 @@
+package nodeterminationhandler
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+)
+
+const (
+	// NodeTerminationHandler is the name of the Node Termination Handler DaemonSet, or Deployment in queue mode
+	NodeTerminationHandler = "aws-node-termination-handler"
+
+	// ImageTag is the release of Node Termination Handler that is installed
+	ImageTag = "v1.13.3"
+
+	imageTagPlaceholder = "{{image_tag}}"
+	regionPlaceholder   = "{{region_name}}"
+	queueURLPlaceholder = "{{queue_url}}"
+)
+
+// Deploy creates or replaces Node Termination Handler in kube-system namespace; in IMDS mode,
+// it runs as a DaemonSet on the nodes of spot nodegroups, and in queue mode as a Deployment
+// that consumes the queue created along with the cluster
+func Deploy(rawClient kubernetes.RawClientInterface, spec *api.ClusterConfig) error {
+	nth := spec.NodeTerminationHandler
+	if nth == nil {
+		return fmt.Errorf("Node Termination Handler is not configured for cluster %q", spec.Metadata.Name)
+	}
+	queueMode := nth.Mode == api.NodeTerminationHandlerModeQueue
+	if queueMode && nth.QueueURL == "" {
+		return fmt.Errorf("queue of Node Termination Handler of cluster %q is unknown, it's created along with the cluster", spec.Metadata.Name)
+	}
+
+	list, err := loadAsset(spec.Metadata.Region, nth.QueueURL)
+	if err != nil {
+		return err
+	}
+
+	for _, rawObj := range list.Items {
+		resource, err := rawClient.NewRawResource(rawObj)
+		if err != nil {
+			return err
+		}
+
+		switch obj := resource.Info.Object.(type) {
+		case *appsv1.DaemonSet:
+			if queueMode {
+				continue
+			}
+			if err := customizeDaemonSet(obj, spec); err != nil {
+				return err
+			}
+		case *appsv1.Deployment:
+			if !queueMode {
+				continue
+			}
+			mirrorImages(obj.Spec.Template.Spec.Containers, spec)
+		}
+
+		status, err := resource.CreateOrReplace(false)
+		if err != nil {
+			return err
+		}
+		logger.Info("%s", status)
+	}
+
+	mode := nth.Mode
+	if mode == "" {
+		mode = api.NodeTerminationHandlerModeIMDS
+	}
+	logger.Info("Node Termination Handler %s has been deployed in %q mode", ImageTag, mode)
+	return nil
+}
+
+// customizeDaemonSet only schedules the DaemonSet on the nodes of spot nodegroups,
+// as on-demand instances aren't interrupted
+func customizeDaemonSet(ds *appsv1.DaemonSet, spec *api.ClusterConfig) error {
+	mirrorImages(ds.Spec.Template.Spec.Containers, spec)
+
+	affinity := ds.Spec.Template.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return fmt.Errorf("unexpected affinity of %q", ds.Name)
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) != 1 || len(terms[0].MatchExpressions) != 1 {
+		return fmt.Errorf("unexpected node selector terms of %q", ds.Name)
+	}
+	terms[0].MatchExpressions[0] = corev1.NodeSelectorRequirement{
+		Key:      api.NodeGroupNameLabel,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   SpotNodeGroupNames(spec),
+	}
+	return nil
+}
+
+// SpotNodeGroupNames returns the names of the nodegroups that launch spot instances
+func SpotNodeGroupNames(spec *api.ClusterConfig) []string {
+	names := []string{}
+	for _, ng := range spec.NodeGroups {
+		if api.IsSpotNodeGroup(ng) {
+			names = append(names, ng.Name)
+		}
+	}
+	return names
+}
+
+func mirrorImages(containers []corev1.Container, spec *api.ClusterConfig) {
+	for i := range containers {
+		containers[i].Image = spec.MirroredImage(containers[i].Image)
+	}
+}
+
+func loadAsset(region, queueURL string) (*metav1.List, error) {
+	data, err := Asset(NodeTerminationHandler + ".yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding embedded manifest for %q", NodeTerminationHandler)
+	}
+
+	manifest := strings.Replace(string(data), imageTagPlaceholder, ImageTag, -1)
+	manifest = strings.Replace(manifest, regionPlaceholder, region, -1)
+	manifest = strings.Replace(manifest, queueURLPlaceholder, queueURL, -1)
+
+	list, err := kubernetes.NewList([]byte(manifest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading individual resources from manifest for %q", NodeTerminationHandler)
+	}
+	return list, nil
+}
@@ -0,0 +1,79 @@
+package nodeterminationhandler_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/weaveworks/eksctl/pkg/addons/nodeterminationhandler"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+)
+
+var _ = Describe("Node Termination Handler", func() {
+	var (
+		rawClient *testutils.FakeRawClient
+		cfg       *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		rawClient = testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "eu-west-1"
+
+		onDemandPercentage := 0
+		spot := cfg.NewNodeGroup()
+		spot.Name = "spot"
+		spot.InstancesDistribution = &api.NodeGroupInstancesDistribution{
+			InstanceTypes:                       []string{"m5.large", "m5a.large"},
+			OnDemandPercentageAboveBaseCapacity: &onDemandPercentage,
+		}
+		onDemand := cfg.NewNodeGroup()
+		onDemand.Name = "on-demand"
+	})
+
+	It("only schedules the DaemonSet on spot nodegroups in IMDS mode", func() {
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{Install: api.Enabled()}
+		Expect(Deploy(rawClient, cfg)).To(Succeed())
+
+		// ServiceAccount, ClusterRole, ClusterRoleBinding and DaemonSet
+		Expect(rawClient.Collection.CreatedItems()).To(HaveLen(4))
+
+		ds, err := rawClient.ClientSet().AppsV1().DaemonSets(metav1.NamespaceSystem).Get(NodeTerminationHandler, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ds.Spec.Template.Spec.Containers[0].Image).To(Equal("public.ecr.aws/aws-ec2/aws-node-termination-handler:" + ImageTag))
+
+		terms := ds.Spec.Template.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+		Expect(terms[0].MatchExpressions[0].Key).To(Equal(api.NodeGroupNameLabel))
+		Expect(terms[0].MatchExpressions[0].Values).To(Equal([]string{"spot"}))
+	})
+
+	It("deploys a single replica that consumes the queue in queue mode", func() {
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{
+			Install:  api.Enabled(),
+			Mode:     api.NodeTerminationHandlerModeQueue,
+			QueueURL: "https://sqs.eu-west-1.amazonaws.com/123456789012/eksctl-test-cluster-node-termination-handler",
+		}
+		Expect(Deploy(rawClient, cfg)).To(Succeed())
+
+		Expect(rawClient.Collection.CreatedItems()).To(HaveLen(4))
+
+		deployment, err := rawClient.ClientSet().AppsV1().Deployments(metav1.NamespaceSystem).Get(NodeTerminationHandler, metav1.GetOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+			Name:  "QUEUE_URL",
+			Value: "https://sqs.eu-west-1.amazonaws.com/123456789012/eksctl-test-cluster-node-termination-handler",
+		}))
+		Expect(deployment.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{Name: "AWS_REGION", Value: "eu-west-1"}))
+	})
+
+	It("fails in queue mode when the queue is unknown", func() {
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{Install: api.Enabled(), Mode: api.NodeTerminationHandlerModeQueue}
+		Expect(Deploy(rawClient, cfg)).ToNot(Succeed())
+	})
+})
@@ -1,6 +1,7 @@
 package ami
 
 import (
+	"regexp"
 	"sort"
 	"time"
 
@@ -27,8 +28,17 @@ const (
 	// ResolverAuto is used to indicate that the latest EKS AMIs should be used for the nodes. This implies
 	// that automatic resolution of AMI will occur.
 	ResolverAuto = api.NodeImageResolverAuto
+	// ResolverAutoSSM is used to indicate that the latest EKS AMIs should be looked up in the public
+	// SSM parameters published by AWS and Canonical
+	ResolverAutoSSM = api.NodeImageResolverAutoSSM
 )
 
+// imageNameVersionPatterns match the Kubernetes version in the names of the AMIs of the image families
+var imageNameVersionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`^amazon-eks-(?:[a-z0-9]+-)?node-(\d+\.\d+)-`),
+	regexp.MustCompile(`^ubuntu-eks/k8s_(\d+\.\d+)/`),
+}
+
 // Variations of iamge classes
 const (
 	ImageClassGeneral int = iota
@@ -81,6 +91,55 @@ func Use(ec2api ec2iface.EC2API, ng *api.NodeGroup) error {
 	return nil
 }
 
+// ImageFamilyOf returns the image family of an AMI based on the account that owns it, or an
+// empty string when it isn't owned by any of the accounts that publish the image families
+func ImageFamilyOf(ec2api ec2iface.EC2API, imageID string) (string, error) {
+	output, err := ec2api.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: []*string{&imageID},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to find image %q", imageID)
+	}
+	if len(output.Images) < 1 {
+		return "", NewErrNotFound(imageID)
+	}
+	owner := aws.StringValue(output.Images[0].OwnerId)
+	for family, accountID := range ImageFamilyToAccountID {
+		if accountID == owner {
+			return family, nil
+		}
+	}
+	for _, accountIDs := range regionalImageFamilyToAccountID {
+		for family, accountID := range accountIDs {
+			if accountID == owner {
+				return family, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// KubernetesVersionOf returns the version of the kubelet of an AMI based on its name,
+// or an empty string when its name doesn't follow the naming of the image families
+func KubernetesVersionOf(ec2api ec2iface.EC2API, imageID string) (string, error) {
+	output, err := ec2api.DescribeImages(&ec2.DescribeImagesInput{
+		ImageIds: []*string{&imageID},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to find image %q", imageID)
+	}
+	if len(output.Images) < 1 {
+		return "", NewErrNotFound(imageID)
+	}
+	name := aws.StringValue(output.Images[0].Name)
+	for _, pattern := range imageNameVersionPatterns {
+		if m := pattern.FindStringSubmatch(name); m != nil {
+			return m[1], nil
+		}
+	}
+	return "", nil
+}
+
 // FindImage will get the AMI to use for the EKS nodes by querying AWS EC2 API.
 // It will only look for images with a status of available and it will pick the
 // image with the newest creation date.
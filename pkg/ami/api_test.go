@@ -0,0 +1,51 @@
+package ami_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	. "github.com/weaveworks/eksctl/pkg/ami"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("AMI Kubernetes version", func() {
+	var p *mockprovider.MockProvider
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+	})
+
+	kubernetesVersionOf := func(name string) string {
+		p.MockEC2().On("DescribeImages", mock.Anything).Return(&ec2.DescribeImagesOutput{
+			Images: []*ec2.Image{{ImageId: aws.String("ami-12345"), Name: aws.String(name)}},
+		}, nil)
+		version, err := KubernetesVersionOf(p.MockEC2(), "ami-12345")
+		Expect(err).NotTo(HaveOccurred())
+		return version
+	}
+
+	It("should read the version in the name of Amazon Linux 2 AMIs", func() {
+		Expect(kubernetesVersionOf("amazon-eks-node-1.13-v20190701")).To(Equal("1.13"))
+	})
+
+	It("should read the version in the name of GPU Amazon Linux 2 AMIs", func() {
+		Expect(kubernetesVersionOf("amazon-eks-gpu-node-1.12-v20190701")).To(Equal("1.12"))
+	})
+
+	It("should read the version in the name of Ubuntu AMIs", func() {
+		Expect(kubernetesVersionOf("ubuntu-eks/k8s_1.11/images/hvm-ssd/ubuntu-bionic-18.04-amd64-server-20190514")).To(Equal("1.11"))
+	})
+
+	It("should return an empty version for custom AMIs", func() {
+		Expect(kubernetesVersionOf("my-custom-node-image")).To(BeEmpty())
+	})
+
+	It("should fail when the AMI doesn't exist", func() {
+		p.MockEC2().On("DescribeImages", mock.Anything).Return(&ec2.DescribeImagesOutput{}, nil)
+		_, err := KubernetesVersionOf(p.MockEC2(), "ami-12345")
+		Expect(err).To(BeAssignableToTypeOf(&ErrNotFound{}))
+	})
+})
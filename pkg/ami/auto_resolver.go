@@ -4,6 +4,8 @@ import (
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/utils"
 )
 
@@ -45,6 +47,37 @@ var ImageFamilyToAccountID = map[string]string{
 	ImageFamilyUbuntu1804:   "099720109477",
 }
 
+// regionalImageFamilyToAccountID are the accounts that own the images of the image
+// families in the regions outside of the standard partition
+var regionalImageFamilyToAccountID = map[string]map[string]string{
+	api.RegionCNNorth1: {
+		ImageFamilyAmazonLinux2: "961992271922",
+		ImageFamilyUbuntu1804:   "837727238323",
+	},
+	api.RegionCNNorthWest1: {
+		ImageFamilyAmazonLinux2: "961992271922",
+		ImageFamilyUbuntu1804:   "837727238323",
+	},
+	api.RegionUSGovWest1: {
+		ImageFamilyAmazonLinux2: "013241004608",
+		ImageFamilyUbuntu1804:   "513442679011",
+	},
+	api.RegionUSGovEast1: {
+		ImageFamilyAmazonLinux2: "151742754352",
+		ImageFamilyUbuntu1804:   "513442679011",
+	},
+}
+
+// OwnerAccountID returns the account that owns the images of the image family in the region
+func OwnerAccountID(region, imageFamily string) (string, bool) {
+	accountIDs, ok := regionalImageFamilyToAccountID[region]
+	if !ok {
+		accountIDs = ImageFamilyToAccountID
+	}
+	accountID, ok := accountIDs[imageFamily]
+	return accountID, ok
+}
+
 // AutoResolver resolves the AMi to the defaults for the region
 // by querying AWS EC2 API for the AMI to use
 type AutoResolver struct {
@@ -66,7 +99,7 @@ func (r *AutoResolver) Resolve(region, version, instanceType, imageFamily string
 		}
 	}
 
-	ownerAccount, knownOwner := ImageFamilyToAccountID[imageFamily]
+	ownerAccount, knownOwner := OwnerAccountID(region, imageFamily)
 	if !knownOwner {
 		logger.Critical("unable to determine the account owner for image family %s", imageFamily)
 		return "", NewErrFailedResolution(region, version, instanceType, imageFamily)
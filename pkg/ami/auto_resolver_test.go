@@ -41,6 +41,18 @@ var _ = Describe("AMI Auto Resolution", func() {
 			It("should return the Ubuntu Account ID for Ubuntu images", func() {
 				Expect(ImageFamilyToAccountID[ImageFamilyUbuntu1804]).To(BeEquivalentTo("099720109477"))
 			})
+
+			It("should return the Account IDs of the partition of the region", func() {
+				for region, accountID := range map[string]string{
+					"eu-west-1":     "602401143452",
+					"cn-north-1":    "961992271922",
+					"us-gov-east-1": "151742754352",
+				} {
+					owner, ok := OwnerAccountID(region, ImageFamilyAmazonLinux2)
+					Expect(ok).To(BeTrue())
+					Expect(owner).To(Equal(accountID))
+				}
+			})
 		})
 
 		Context("with a valid region and N instance type", func() {
@@ -0,0 +1,82 @@
+package ami
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+// SSMResolver resolves the AMI to the latest EKS optimised AMI for
+// the region by reading the public SSM parameters published by
+// AWS (for Amazon Linux 2) and Canonical (for Ubuntu)
+type SSMResolver struct {
+	api ssmiface.SSMAPI
+}
+
+// Resolve will return the AMI stored in the SSM parameter for the
+// given version, image family and instance type
+func (r *SSMResolver) Resolve(region, version, instanceType, imageFamily string) (string, error) {
+	logger.Debug("resolving AMI using SSMResolver for region %s, instanceType %s and imageFamily %s", region, instanceType, imageFamily)
+
+	parameterName, err := MakeSSMParameterName(version, instanceType, imageFamily)
+	if err != nil {
+		logger.Critical("%s", err.Error())
+		return "", NewErrFailedResolution(region, version, instanceType, imageFamily)
+	}
+
+	output, err := r.api.GetParameter(&ssm.GetParameterInput{
+		Name: aws.String(parameterName),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ssm.ErrCodeParameterNotFound {
+			logger.Critical("SSM parameter %q not found", parameterName)
+			return "", NewErrFailedResolution(region, version, instanceType, imageFamily)
+		}
+		return "", errors.Wrapf(err, "error getting AMI from SSM parameter %q", parameterName)
+	}
+
+	if output.Parameter == nil || output.Parameter.Value == nil || *output.Parameter.Value == "" {
+		return "", fmt.Errorf("SSM parameter %q has no value", parameterName)
+	}
+
+	return *output.Parameter.Value, nil
+}
+
+// MakeSSMParameterName returns the name of the SSM parameter that holds the
+// ID of the recommended EKS AMI for the given version, instance type and image family
+func MakeSSMParameterName(version, instanceType, imageFamily string) (string, error) {
+	switch imageFamily {
+	case ImageFamilyAmazonLinux2:
+		variant := "amazon-linux-2"
+		switch {
+		case utils.IsGPUInstanceType(instanceType):
+			variant += "-gpu"
+		case utils.IsARMInstanceType(instanceType):
+			variant += "-arm64"
+		}
+		return fmt.Sprintf("/aws/service/eks/optimized-ami/%s/%s/recommended/image_id", version, variant), nil
+	case ImageFamilyUbuntu1804:
+		if utils.IsGPUInstanceType(instanceType) {
+			return "", fmt.Errorf("image family %s doesn't support GPU image class", imageFamily)
+		}
+		arch := "amd64"
+		if utils.IsARMInstanceType(instanceType) {
+			arch = "arm64"
+		}
+		return fmt.Sprintf("/aws/service/canonical/ubuntu/eks/18.04/%s/stable/current/%s/hvm/ebs-gp2/ami-id", version, arch), nil
+	default:
+		return "", fmt.Errorf("unknown image family %s", imageFamily)
+	}
+}
+
+// NewSSMResolver creates a new SSMResolver
+func NewSSMResolver(api ssmiface.SSMAPI) *SSMResolver {
+	return &SSMResolver{api: api}
+}
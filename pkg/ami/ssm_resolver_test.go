@@ -0,0 +1,90 @@
+package ami_test
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	. "github.com/weaveworks/eksctl/pkg/ami"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("AMI SSM Resolution", func() {
+
+	Describe("When making SSM parameter names", func() {
+		It("should use the general Amazon Linux 2 parameter", func() {
+			name, err := MakeSSMParameterName("1.13", "m5.large", ImageFamilyAmazonLinux2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("/aws/service/eks/optimized-ami/1.13/amazon-linux-2/recommended/image_id"))
+		})
+
+		It("should use the GPU Amazon Linux 2 parameter", func() {
+			name, err := MakeSSMParameterName("1.12", "p2.xlarge", ImageFamilyAmazonLinux2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("/aws/service/eks/optimized-ami/1.12/amazon-linux-2-gpu/recommended/image_id"))
+		})
+
+		It("should use the ARM Amazon Linux 2 parameter", func() {
+			name, err := MakeSSMParameterName("1.13", "a1.large", ImageFamilyAmazonLinux2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("/aws/service/eks/optimized-ami/1.13/amazon-linux-2-arm64/recommended/image_id"))
+		})
+
+		It("should use the Canonical parameter for Ubuntu", func() {
+			name, err := MakeSSMParameterName("1.13", "m5.large", ImageFamilyUbuntu1804)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(name).To(Equal("/aws/service/canonical/ubuntu/eks/18.04/1.13/stable/current/amd64/hvm/ebs-gp2/ami-id"))
+		})
+
+		It("should fail for Ubuntu GPU instances", func() {
+			_, err := MakeSSMParameterName("1.13", "p3.2xlarge", ImageFamilyUbuntu1804)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("When resolving an AMI to use", func() {
+		var (
+			p *mockprovider.MockProvider
+		)
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+		})
+
+		It("should return the value of the parameter", func() {
+			p.MockSSM().On("GetParameter", mock.MatchedBy(func(input *ssm.GetParameterInput) bool {
+				return *input.Name == "/aws/service/eks/optimized-ami/1.13/amazon-linux-2/recommended/image_id"
+			})).Return(&ssm.GetParameterOutput{
+				Parameter: &ssm.Parameter{
+					Value: aws.String("ami-12345"),
+				},
+			}, nil)
+
+			resolvedAmi, err := NewSSMResolver(p.MockSSM()).Resolve("eu-west-1", "1.13", "m5.large", ImageFamilyAmazonLinux2)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolvedAmi).To(Equal("ami-12345"))
+			Expect(p.MockSSM().AssertNumberOfCalls(GinkgoT(), "GetParameter", 1)).To(BeTrue())
+		})
+
+		It("should return a resolution error when the parameter doesn't exist", func() {
+			p.MockSSM().On("GetParameter", mock.Anything).Return(nil, awserr.New(ssm.ErrCodeParameterNotFound, "not found", nil))
+
+			_, err := NewSSMResolver(p.MockSSM()).Resolve("eu-west-1", "1.10", "m5.large", ImageFamilyAmazonLinux2)
+			Expect(err).To(HaveOccurred())
+			Expect(err).To(BeAssignableToTypeOf(&ErrFailedResolution{}))
+		})
+
+		It("should return other API errors", func() {
+			p.MockSSM().On("GetParameter", mock.Anything).Return(nil, fmt.Errorf("access denied"))
+
+			_, err := NewSSMResolver(p.MockSSM()).Resolve("eu-west-1", "1.13", "m5.large", ImageFamilyAmazonLinux2)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("access denied"))
+		})
+	})
+})
@@ -36,9 +36,9 @@ func main() {
 			log.Printf("looking up %s/%s images", family, version)
 			for class := range ami.ImageSearchPatterns[version][family] {
 				classImages := Dict{}
-				for _, region := range api.SupportedRegions() {
+				for _, region := range standardRegions() {
 					namePattern := ami.ImageSearchPatterns[version][family][class]
-					ownerAccount := ami.ImageFamilyToAccountID[family]
+					ownerAccount, _ := ami.OwnerAccountID(region, family)
 					log.Printf("looking up images matching %q in %q", namePattern, region)
 					id, err := ami.FindImage(client[region], ownerAccount, namePattern)
 					if err != nil {
@@ -81,8 +81,20 @@ func newSession(region string) *session.Session {
 
 func newMultiRegionClient() map[string]*ec2.EC2 {
 	clients := make(map[string]*ec2.EC2)
-	for _, region := range api.SupportedRegions() {
+	for _, region := range standardRegions() {
 		clients[region] = ec2.New(newSession(region))
 	}
 	return clients
 }
+
+// standardRegions are the supported regions of the standard partition, the credentials
+// of other partitions can't look up images alongside them, so they are resolved at runtime
+func standardRegions() []string {
+	regions := []string{}
+	for _, region := range api.SupportedRegions() {
+		if api.Partition(region) == api.PartitionAWS {
+			regions = append(regions, region)
+		}
+	}
+	return regions
+}
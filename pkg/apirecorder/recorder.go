@@ -0,0 +1,125 @@
+// Package apirecorder records the AWS API calls made by eksctl into fixture files,
+// which unit tests replay with mockprovider instead of mocking every call by hand
+package apirecorder
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+)
+
+// EnvVar enables recording when it's set to the path of a fixture file, the
+// calls are appended to it
+const EnvVar = "EKSCTL_RECORD_FIXTURES"
+
+// Interaction is an AWS API call, fixture files hold one per line in JSON
+type Interaction struct {
+	// Service is the ID of the service, e.g. "CloudFormation"
+	Service   string          `json:"service"`
+	Operation string          `json:"operation"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	// Output is omitted when the call failed
+	Output json.RawMessage `json:"output,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
+// Error is the error returned by a call
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Recorder appends the calls it's given to a fixture file
+type Recorder struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// New returns a recorder for the fixture file
+func New(path string) *Recorder {
+	return &Recorder{path: path}
+}
+
+// Handler returns a handler that records every completed call, it has to be added
+// to the Complete handlers of a session
+func (r *Recorder) Handler() request.NamedHandler {
+	return request.NamedHandler{
+		Name: "eksctlAPIRecorder",
+		Fn: func(req *request.Request) {
+			if err := r.Record(req); err != nil {
+				logger.Warning("recording AWS API call: %s", err.Error())
+			}
+		},
+	}
+}
+
+// Record appends the sanitized call to the fixture file
+func (r *Recorder) Record(req *request.Request) error {
+	if req.Operation == nil {
+		return nil
+	}
+	i := Interaction{
+		Service:   req.ClientInfo.ServiceID,
+		Operation: req.Operation.Name,
+	}
+	var err error
+	if i.Input, err = marshalSanitized(req.Params); err != nil {
+		return errors.Wrapf(err, "%s %s input", i.Service, i.Operation)
+	}
+	if req.Error != nil {
+		i.Error = &Error{Code: "Unknown", Message: req.Error.Error()}
+		if awsErr, ok := req.Error.(awserr.Error); ok {
+			i.Error = &Error{Code: awsErr.Code(), Message: sanitizeString(awsErr.Message())}
+		}
+	} else if i.Output, err = marshalSanitized(req.Data); err != nil {
+		return errors.Wrapf(err, "%s %s output", i.Service, i.Operation)
+	}
+
+	line, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// Load reads the calls of a fixture file, in the order they were made
+func Load(path string) ([]Interaction, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "opening fixture file %q", path)
+	}
+	defer f.Close()
+
+	interactions := []Interaction{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var i Interaction
+		if err := json.Unmarshal(scanner.Bytes(), &i); err != nil {
+			return nil, errors.Wrapf(err, "line %d of fixture file %q", n, path)
+		}
+		interactions = append(interactions, i)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "reading fixture file %q", path)
+	}
+	return interactions, nil
+}
@@ -0,0 +1,127 @@
+package apirecorder_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/sts"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/apirecorder"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+func newRequest(serviceID, operation string, params, data interface{}, err error) *request.Request {
+	return &request.Request{
+		ClientInfo: metadata.ClientInfo{ServiceID: serviceID},
+		Operation:  &request.Operation{Name: operation},
+		Params:     params,
+		Data:       data,
+		Error:      err,
+	}
+}
+
+var _ = Describe("Recorder", func() {
+	var (
+		dir, fixtureFile string
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "apirecorder")
+		Expect(err).NotTo(HaveOccurred())
+		fixtureFile = filepath.Join(dir, "fixture.jsonl")
+
+		recorder := New(fixtureFile)
+
+		Expect(recorder.Record(newRequest(sts.ServiceID, "GetCallerIdentity",
+			&sts.GetCallerIdentityInput{},
+			&sts.GetCallerIdentityOutput{
+				Account: aws.String("210987654321"),
+				Arn:     aws.String("arn:aws:iam::210987654321:user/alice"),
+				UserId:  aws.String("AIDAJQABLZS4A3QDU576Q"),
+			}, nil))).To(Succeed())
+
+		Expect(recorder.Record(newRequest(cloudformation.ServiceID, "DescribeStacks",
+			&cloudformation.DescribeStacksInput{StackName: aws.String("eksctl-test-cluster")},
+			&cloudformation.DescribeStacksOutput{
+				Stacks: []*cloudformation.Stack{{
+					StackName:   aws.String("eksctl-test-cluster"),
+					StackStatus: aws.String(cloudformation.StackStatusCreateComplete),
+					Outputs: []*cloudformation.Output{{
+						OutputKey:   aws.String("ARN"),
+						OutputValue: aws.String("arn:aws:eks:us-west-2:210987654321:cluster/test"),
+					}},
+				}},
+			}, nil))).To(Succeed())
+
+		Expect(recorder.Record(newRequest(cloudformation.ServiceID, "DescribeStacks",
+			&cloudformation.DescribeStacksInput{StackName: aws.String("eksctl-test-nodegroup-ng1")},
+			&cloudformation.DescribeStacksOutput{},
+			awserr.New("ValidationError", "Stack with id eksctl-test-nodegroup-ng1 does not exist", nil),
+		))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("records sanitized calls", func() {
+		interactions, err := Load(fixtureFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(interactions).To(HaveLen(3))
+
+		Expect(interactions[0].Service).To(Equal(sts.ServiceID))
+		Expect(interactions[0].Operation).To(Equal("GetCallerIdentity"))
+		Expect(interactions[0].Input).To(BeEmpty())
+		Expect(interactions[0].Output).To(MatchJSON(`{
+			"Account": "123456789012",
+			"Arn": "arn:aws:iam::123456789012:user/alice",
+			"UserId": "AROAEXAMPLEID"
+		}`))
+
+		Expect(interactions[1].Input).To(MatchJSON(`{"StackName": "eksctl-test-cluster"}`))
+
+		Expect(interactions[2].Output).To(BeEmpty())
+		Expect(*interactions[2].Error).To(Equal(Error{
+			Code:    "ValidationError",
+			Message: "Stack with id eksctl-test-nodegroup-ng1 does not exist",
+		}))
+	})
+
+	It("replays the calls in mockprovider", func() {
+		p, err := mockprovider.NewReplayProvider(fixtureFile)
+		Expect(err).NotTo(HaveOccurred())
+
+		identity, err := p.STS().GetCallerIdentity(&sts.GetCallerIdentityInput{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(*identity.Account).To(Equal("123456789012"))
+
+		out, err := p.CloudFormation().DescribeStacks(&cloudformation.DescribeStacksInput{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.Stacks).To(HaveLen(1))
+		Expect(*out.Stacks[0].StackStatus).To(Equal(cloudformation.StackStatusCreateComplete))
+		Expect(*out.Stacks[0].Outputs[0].OutputValue).To(Equal("arn:aws:eks:us-west-2:123456789012:cluster/test"))
+
+		_, err = p.CloudFormation().DescribeStacksWithContext(aws.BackgroundContext(), &cloudformation.DescribeStacksInput{})
+		Expect(err).To(HaveOccurred())
+		awsErr, ok := err.(awserr.Error)
+		Expect(ok).To(BeTrue())
+		Expect(awsErr.Code()).To(Equal("ValidationError"))
+
+		_, err = p.CloudFormation().DescribeStacks(&cloudformation.DescribeStacksInput{})
+		Expect(err).To(MatchError(ContainSubstring("no more recorded calls of CloudFormation DescribeStacks")))
+	})
+
+	It("fails to replay calls of unknown operations", func() {
+		err := mockprovider.NewMockProvider().ReplayInteractions([]Interaction{{Service: sts.ServiceID, Operation: "Foo"}})
+		Expect(err).To(MatchError(`STS has no operation "Foo"`))
+	})
+})
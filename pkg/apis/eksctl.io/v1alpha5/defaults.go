@@ -53,6 +53,16 @@ func SetNodeGroupDefaults(_ int, ng *NodeGroup) error {
 		ng.VolumeType = &DefaultNodeVolumeType
 	}
 
+	if IsEnabled(ng.EFAEnabled) && ng.Placement == nil {
+		ng.Placement = &NodeGroupPlacement{Strategy: PlacementStrategyCluster}
+	}
+
+	for _, v := range ng.AdditionalVolumes {
+		if !IsSetAndNonEmptyString(v.VolumeType) {
+			v.VolumeType = &DefaultNodeVolumeType
+		}
+	}
+
 	if ng.IAM == nil {
 		ng.IAM = &NodeGroupIAM{}
 	}
@@ -90,6 +100,45 @@ func SetNodeGroupDefaults(_ int, ng *NodeGroup) error {
 	return nil
 }
 
+// SetClusterConfigDefaults sets the defaults of the cluster that don't depend on its
+// region or account, the defaults of nodegroups are set by SetNodeGroupDefaults
+func SetClusterConfigDefaults(cfg *ClusterConfig) {
+	if cfg.Metadata.Version == "" {
+		cfg.Metadata.Version = DefaultVersion
+	}
+
+	if cfg.VPC == nil {
+		cfg.VPC = NewClusterVPC()
+	}
+	if cfg.VPC.NAT == nil {
+		cfg.VPC.NAT = DefaultClusterNAT()
+	}
+	if !IsSetAndNonEmptyString(cfg.VPC.NAT.Gateway) {
+		cfg.VPC.NAT.Gateway = DefaultClusterNAT().Gateway
+	}
+
+	SetClusterStorageDefaults(cfg)
+}
+
+// SetClusterStorageDefaults sets the defaults of file systems
+func SetClusterStorageDefaults(cfg *ClusterConfig) {
+	if cfg.HasEFS() {
+		efs := cfg.Storage.EFS
+		if efs.PerformanceMode == "" {
+			efs.PerformanceMode = EFSPerformanceModeGeneralPurpose
+		}
+		if efs.ThroughputMode == "" {
+			efs.ThroughputMode = EFSThroughputModeBursting
+		}
+		if efs.Encrypted == nil {
+			efs.Encrypted = Enabled()
+		}
+	}
+	if cfg.HasFSx() && cfg.Storage.FSx.StorageCapacity == 0 {
+		cfg.Storage.FSx.StorageCapacity = FSxMinStorageCapacity
+	}
+}
+
 // DefaultClusterNAT will set the default value for Cluster NAT mode
 func DefaultClusterNAT() *ClusterNAT {
 	single := ClusterSingleNAT
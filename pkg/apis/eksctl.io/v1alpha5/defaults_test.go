@@ -40,6 +40,29 @@ var _ = Describe("Default settings", func() {
 		})
 	})
 
+	Context("Cluster settings", func() {
+
+		It("Sets the version, VPC and NAT gateway of a cluster", func() {
+			cfg := &ClusterConfig{
+				Metadata: &ClusterMeta{Name: "cluster-1"},
+				VPC:      &ClusterVPC{NAT: &ClusterNAT{}},
+			}
+
+			SetClusterConfigDefaults(cfg)
+
+			Expect(cfg.Metadata.Version).To(Equal(DefaultVersion))
+			Expect(*cfg.VPC.NAT.Gateway).To(Equal(ClusterSingleNAT))
+
+			cfg.VPC = nil
+			cfg.Metadata.Version = "1.12"
+
+			SetClusterConfigDefaults(cfg)
+
+			Expect(cfg.Metadata.Version).To(Equal("1.12"))
+			Expect(*cfg.VPC.CIDR).To(Equal(DefaultCIDR()))
+		})
+	})
+
 	Context("Cluster NAT settings", func() {
 
 		It("Cluster NAT defaults to single NAT gateway mode", func() {
@@ -52,4 +75,16 @@ var _ = Describe("Default settings", func() {
 
 	})
 
+	Context("Nodegroup size", func() {
+
+		It("Node count falls back to the minimum size, then to the default", func() {
+			one, three := 1, 3
+
+			Expect((&NodeGroup{DesiredCapacity: &three, MinSize: &one}).NodeCount()).To(Equal(3))
+			Expect((&NodeGroup{MinSize: &one}).NodeCount()).To(Equal(1))
+			Expect((&NodeGroup{}).NodeCount()).To(Equal(DefaultNodeCount))
+		})
+
+	})
+
 })
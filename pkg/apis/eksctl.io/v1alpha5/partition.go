@@ -0,0 +1,75 @@
+package v1alpha5
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PartitionAWS is the standard partition
+	PartitionAWS = "aws"
+
+	// PartitionChina is the partition of the China regions
+	PartitionChina = "aws-cn"
+
+	// PartitionUSGov is the partition of the AWS GovCloud (US) regions
+	PartitionUSGov = "aws-us-gov"
+)
+
+// eksAccountIDs are the accounts that publish the images of EKS components,
+// e.g. the pause container, in the regions outside of the standard partition
+var eksAccountIDs = map[string]string{
+	RegionCNNorth1:     "918309763551",
+	RegionCNNorthWest1: "961992271922",
+	RegionUSGovWest1:   "013241004608",
+	RegionUSGovEast1:   "151742754352",
+}
+
+// Partition returns the partition of the region
+func Partition(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return PartitionChina
+	case strings.HasPrefix(region, "us-gov-"):
+		return PartitionUSGov
+	default:
+		return PartitionAWS
+	}
+}
+
+// Partition returns the partition of the region of the cluster
+func (c *ClusterConfig) Partition() string {
+	return Partition(c.Metadata.Region)
+}
+
+// DNSSuffix returns the suffix of the domains of AWS services in the region
+func DNSSuffix(region string) string {
+	if Partition(region) == PartitionChina {
+		return "amazonaws.com.cn"
+	}
+	return "amazonaws.com"
+}
+
+// ServicePrincipal returns the principal that the AWS service assumes roles as
+// in the region, e.g. ec2.amazonaws.com.cn in China; EKS uses the same principal
+// in every partition
+func ServicePrincipal(region, service string) string {
+	if service == "eks" {
+		return "eks.amazonaws.com"
+	}
+	return service + "." + DNSSuffix(region)
+}
+
+// ManagedPolicyARN returns the ARN of the AWS managed policy in the partition of the region
+func ManagedPolicyARN(region, name string) string {
+	return fmt.Sprintf("arn:%s:iam::aws:policy/%s", Partition(region), name)
+}
+
+// EKSImageRegistry returns the ECR registry that serves the images of EKS components in the region
+func EKSImageRegistry(region string) string {
+	accountID, ok := eksAccountIDs[region]
+	if !ok {
+		accountID = "602401143452"
+	}
+	return fmt.Sprintf("%s.dkr.ecr.%s.%s", accountID, region, DNSSuffix(region))
+}
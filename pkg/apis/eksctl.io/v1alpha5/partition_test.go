@@ -0,0 +1,26 @@
+package v1alpha5
+
+import (
+	. "github.com/onsi/ginkgo"
+	"github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Partitions", func() {
+
+	table.DescribeTable("regions",
+		func(region, partition, ec2Principal, policyARN, registry string) {
+			Expect(Partition(region)).To(Equal(partition))
+			Expect(ServicePrincipal(region, "ec2")).To(Equal(ec2Principal))
+			Expect(ServicePrincipal(region, "eks")).To(Equal("eks.amazonaws.com"))
+			Expect(ManagedPolicyARN(region, "AmazonEKSClusterPolicy")).To(Equal(policyARN))
+			Expect(EKSImageRegistry(region)).To(Equal(registry))
+		},
+		table.Entry("standard", RegionUSWest2, PartitionAWS, "ec2.amazonaws.com",
+			"arn:aws:iam::aws:policy/AmazonEKSClusterPolicy", "602401143452.dkr.ecr.us-west-2.amazonaws.com"),
+		table.Entry("China", RegionCNNorthWest1, PartitionChina, "ec2.amazonaws.com.cn",
+			"arn:aws-cn:iam::aws:policy/AmazonEKSClusterPolicy", "961992271922.dkr.ecr.cn-northwest-1.amazonaws.com.cn"),
+		table.Entry("GovCloud", RegionUSGovWest1, PartitionUSGov, "ec2.amazonaws.com",
+			"arn:aws-us-gov:iam::aws:policy/AmazonEKSClusterPolicy", "013241004608.dkr.ecr.us-gov-west-1.amazonaws.com"),
+	)
+})
@@ -2,24 +2,29 @@ package v1alpha5
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
-	// AWSDebugLevel defines the LogLevel for AWS produced logs
-	AWSDebugLevel = 5
-
 	// RegionUSWest2 represents the US West Region Oregon
 	RegionUSWest2 = "us-west-2"
 
@@ -62,6 +67,18 @@ const (
 	// RegionAPSouth1 represents the Asia-Pacific South Region Mumbai
 	RegionAPSouth1 = "ap-south-1"
 
+	// RegionCNNorth1 represents the China North Region Beijing
+	RegionCNNorth1 = "cn-north-1"
+
+	// RegionCNNorthWest1 represents the China North West Region Ningxia
+	RegionCNNorthWest1 = "cn-northwest-1"
+
+	// RegionUSGovWest1 represents the AWS GovCloud (US-West) Region
+	RegionUSGovWest1 = "us-gov-west-1"
+
+	// RegionUSGovEast1 represents the AWS GovCloud (US-East) Region
+	RegionUSGovEast1 = "us-gov-east-1"
+
 	// DefaultRegion defines the default region, where to deploy the EKS cluster
 	DefaultRegion = RegionUSWest2
 
@@ -91,8 +108,12 @@ const (
 
 	// NodeVolumeTypeGP2 is General Purpose SSD
 	NodeVolumeTypeGP2 = "gp2"
+	// NodeVolumeTypeGP3 is General Purpose SSD with configurable IOPS and throughput
+	NodeVolumeTypeGP3 = "gp3"
 	// NodeVolumeTypeIO1 is Provisioned IOPS SSD
 	NodeVolumeTypeIO1 = "io1"
+	// NodeVolumeTypeIO2 is Provisioned IOPS SSD with higher durability
+	NodeVolumeTypeIO2 = "io2"
 	// NodeVolumeTypeSC1 is Throughput Optimized HDD
 	NodeVolumeTypeSC1 = "sc1"
 	// NodeVolumeTypeST1 is Cold HDD
@@ -108,6 +129,8 @@ const (
 	NodeImageResolverStatic = "static"
 	// NodeImageResolverAuto represents auto AMI resolver (see ami package)
 	NodeImageResolverAuto = "auto"
+	// NodeImageResolverAutoSSM represents auto AMI resolver that uses SSM parameters (see ami package)
+	NodeImageResolverAutoSSM = "auto-ssm"
 
 	// ClusterNameTag defines the tag of the cluster name
 	ClusterNameTag = "alpha.eksctl.io/cluster-name"
@@ -115,6 +138,10 @@ const (
 	// OldClusterNameTag defines the tag of the cluster name
 	OldClusterNameTag = "eksctl.cluster.k8s.io/v1alpha1/cluster-name"
 
+	// ResourceOwnerTag defines the tag of the cluster name that is set on all resources
+	// eksctl creates for the cluster, the inventory of the cluster is built from it
+	ResourceOwnerTag = "eksctl.io/v1alpha5/cluster-name"
+
 	// NodeGroupNameTag defines the tag of the node group name
 	NodeGroupNameTag = "alpha.eksctl.io/nodegroup-name"
 
@@ -124,12 +151,22 @@ const (
 	// OldNodeGroupIDTag defines the old version of tag of the node group name
 	OldNodeGroupIDTag = "eksctl.cluster.k8s.io/v1alpha1/nodegroup-id"
 
+	// NodeGroupSuspendedProcessesTag is the ASG tag of the processes suspended for maintenance
+	// of the nodegroup, they are resumed when maintenance ends
+	NodeGroupSuspendedProcessesTag = "alpha.eksctl.io/suspended-processes"
+
 	// ClusterNameLabel defines the tag of the cluster name
 	ClusterNameLabel = "alpha.eksctl.io/cluster-name"
 
 	// NodeGroupNameLabel defines the label of the node group name
 	NodeGroupNameLabel = "alpha.eksctl.io/nodegroup-name"
 
+	// ClusterAutoscalerLabelTagPrefix is the prefix of the ASG tags Cluster Autoscaler reads node labels from
+	ClusterAutoscalerLabelTagPrefix = "k8s.io/cluster-autoscaler/node-template/label/"
+
+	// ClusterAutoscalerTaintTagPrefix is the prefix of the ASG tags Cluster Autoscaler reads node taints from
+	ClusterAutoscalerTaintTagPrefix = "k8s.io/cluster-autoscaler/node-template/taint/"
+
 	// ClusterHighlyAvailableNAT defines the highly available NAT configuration option
 	ClusterHighlyAvailableNAT = "HighlyAvailable"
 
@@ -138,6 +175,77 @@ const (
 
 	// ClusterDisableNAT defines the disabled NAT configuration option
 	ClusterDisableNAT = "Disable"
+
+	// AZRebalanceEnable keeps the AZRebalance process of nodegroup ASGs running, the default
+	AZRebalanceEnable = "enable"
+	// AZRebalanceDisable suspends the AZRebalance process of nodegroup ASGs
+	AZRebalanceDisable = "disable"
+
+	// LifecycleTransitionLaunching pauses instances of nodegroup ASGs when they're launched
+	LifecycleTransitionLaunching = "autoscaling:EC2_INSTANCE_LAUNCHING"
+	// LifecycleTransitionTerminating pauses instances of nodegroup ASGs before they're terminated
+	LifecycleTransitionTerminating = "autoscaling:EC2_INSTANCE_TERMINATING"
+
+	// LifecycleHookResultContinue lets the transition proceed when the heartbeat times out, the default
+	LifecycleHookResultContinue = "CONTINUE"
+	// LifecycleHookResultAbandon terminates instances when the heartbeat times out
+	LifecycleHookResultAbandon = "ABANDON"
+
+	// PlacementStrategyCluster packs instances close together inside a single AZ
+	PlacementStrategyCluster = "cluster"
+	// PlacementStrategySpread places instances on distinct underlying hardware
+	PlacementStrategySpread = "spread"
+	// PlacementStrategyPartition spreads instances across logical partitions
+	PlacementStrategyPartition = "partition"
+
+	// TenancyDefault runs instances on shared hardware
+	TenancyDefault = "default"
+	// TenancyDedicated runs instances on single-tenant hardware
+	TenancyDedicated = "dedicated"
+
+	// AutoScalerExpanderRandom selects a random nodegroup to scale up
+	AutoScalerExpanderRandom = "random"
+	// AutoScalerExpanderMostPods selects the nodegroup that would schedule the most pods
+	AutoScalerExpanderMostPods = "most-pods"
+	// AutoScalerExpanderLeastWaste selects the nodegroup that would leave the least idle resources
+	AutoScalerExpanderLeastWaste = "least-waste"
+
+	// NodeTerminationHandlerModeIMDS runs Node Termination Handler on every node, watching instance metadata
+	NodeTerminationHandlerModeIMDS = "imds"
+	// NodeTerminationHandlerModeQueue runs Node Termination Handler as a deployment that consumes an SQS queue
+	// of EventBridge events, including ASG lifecycle hooks and rebalance recommendations
+	NodeTerminationHandlerModeQueue = "queue"
+	// NodeTerminationHandlerManagedTag is the tag of ASGs Node Termination Handler drains nodes of in queue mode
+	NodeTerminationHandlerManagedTag = "aws-node-termination-handler/managed"
+	// MaxSQSQueueNameLength is the maximum length of names of SQS queues
+	MaxSQSQueueNameLength = 80
+
+	// NodeGroupUpdateStrategyInstanceRefresh replaces the instances of a nodegroup in batches
+	NodeGroupUpdateStrategyInstanceRefresh = "instanceRefresh"
+	// NodeGroupUpdateStrategyReplaceNodeGroup replaces the ASG of a nodegroup with a new one
+	NodeGroupUpdateStrategyReplaceNodeGroup = "replaceNodegroup"
+
+	// IPV4Family is the default IP family of a cluster
+	IPV4Family = "IPv4"
+	// IPV6Family assigns IPv6 addresses to pods and services
+	IPV6Family = "IPv6"
+
+	// IPv6MinimumKubernetesVersion is the lowest Kubernetes version EKS supports IPv6 clusters with
+	IPv6MinimumKubernetesVersion = "1.21"
+	// IPv6MinimumVPCCNIVersion is the lowest version of the VPC CNI plugin that can assign IPv6 addresses
+	IPv6MinimumVPCCNIVersion = "1.10.0"
+	// PrefixDelegationMinimumVPCCNIVersion is the lowest version of the VPC CNI plugin that can assign prefixes
+	PrefixDelegationMinimumVPCCNIVersion = "1.9.0"
+
+	// NodeGroupContainerRuntimeDockerd is the default container runtime of nodegroups
+	NodeGroupContainerRuntimeDockerd = "dockerd"
+	// NodeGroupContainerRuntimeContainerd runs containers with containerd through its CRI plugin
+	NodeGroupContainerRuntimeContainerd = "containerd"
+	// ContainerdMinimumKubernetesVersion is the lowest Kubernetes version nodes can use containerd with
+	ContainerdMinimumKubernetesVersion = Version1_12
+
+	// DefaultStackNamePrefix is the prefix of the names of the stacks eksctl creates
+	DefaultStackNamePrefix = "eksctl-"
 )
 
 var (
@@ -154,6 +262,26 @@ var (
 	DefaultNodeVolumeSize = 0
 )
 
+const (
+	// MinIO1VolumeIOPS is the minimum IOPS of io1 and io2 volumes
+	MinIO1VolumeIOPS = 100
+	// MaxIO1VolumeIOPS is the maximum IOPS of io1 and io2 volumes
+	MaxIO1VolumeIOPS = 64000
+	// MinGP3VolumeIOPS is the minimum (and baseline) IOPS of gp3 volumes
+	MinGP3VolumeIOPS = 3000
+	// MaxGP3VolumeIOPS is the maximum IOPS of gp3 volumes
+	MaxGP3VolumeIOPS = 16000
+	// MinGP3VolumeThroughput is the minimum (and baseline) throughput of gp3 volumes in MiB/s
+	MinGP3VolumeThroughput = 125
+	// MaxGP3VolumeThroughput is the maximum throughput of gp3 volumes in MiB/s
+	MaxGP3VolumeThroughput = 1000
+
+	// MinLifecycleHookHeartbeatTimeout is the minimum heartbeat timeout of ASG lifecycle hooks in seconds
+	MinLifecycleHookHeartbeatTimeout = 30
+	// MaxLifecycleHookHeartbeatTimeout is the maximum heartbeat timeout of ASG lifecycle hooks in seconds
+	MaxLifecycleHookHeartbeatTimeout = 7200
+)
+
 // Enabled return pointer to true value
 // for use in defaulters of *bool fields
 func Enabled() *bool {
@@ -194,6 +322,10 @@ func SupportedRegions() []string {
 		RegionAPSouthEast1,
 		RegionAPSouthEast2,
 		RegionAPSouth1,
+		RegionCNNorth1,
+		RegionCNNorthWest1,
+		RegionUSGovWest1,
+		RegionUSGovEast1,
 	}
 }
 
@@ -215,16 +347,95 @@ func SupportedVersions() []string {
 	}
 }
 
+// LatestPlatformVersions are the newest EKS platform versions that eksctl knows of for each
+// supported version of Kubernetes, AWS rolls them out to existing clusters automatically. See also:
+// https://docs.aws.amazon.com/eks/latest/userguide/platform-versions.html
+func LatestPlatformVersions() map[string]string {
+	return map[string]string{
+		Version1_11: "eks.3",
+		Version1_12: "eks.2",
+		Version1_13: "eks.1",
+	}
+}
+
 // SupportedNodeVolumeTypes are the volume types that can be used for a node root volume
 func SupportedNodeVolumeTypes() []string {
 	return []string{
 		NodeVolumeTypeGP2,
+		NodeVolumeTypeGP3,
 		NodeVolumeTypeIO1,
+		NodeVolumeTypeIO2,
 		NodeVolumeTypeSC1,
 		NodeVolumeTypeST1,
 	}
 }
 
+// SupportedPlacementStrategies are the strategies that can be used for a new placement group
+func SupportedPlacementStrategies() []string {
+	return []string{
+		PlacementStrategyCluster,
+		PlacementStrategySpread,
+		PlacementStrategyPartition,
+	}
+}
+
+// SupportedTenancies are the tenancy options that can be used for nodegroup instances
+func SupportedTenancies() []string {
+	return []string{
+		TenancyDefault,
+		TenancyDedicated,
+	}
+}
+
+// SupportedEFAInstanceTypes are the instance types that have an Elastic Fabric Adapter
+func SupportedEFAInstanceTypes() []string {
+	return []string{
+		"c5n.18xlarge",
+		"c5n.metal",
+		"i3en.24xlarge",
+		"i3en.metal",
+		"m5dn.24xlarge",
+		"m5n.24xlarge",
+		"p3dn.24xlarge",
+		"r5dn.24xlarge",
+		"r5n.24xlarge",
+	}
+}
+
+const (
+	// EFSPerformanceModeGeneralPurpose is the default EFS performance mode
+	EFSPerformanceModeGeneralPurpose = "generalPurpose"
+	// EFSPerformanceModeMaxIO scales to higher throughput at the cost of latency
+	EFSPerformanceModeMaxIO = "maxIO"
+	// EFSThroughputModeBursting is the default EFS throughput mode
+	EFSThroughputModeBursting = "bursting"
+	// EFSThroughputModeProvisioned requires provisionedThroughputInMibps
+	EFSThroughputModeProvisioned = "provisioned"
+
+	// FSxMinStorageCapacity is the smallest FSx for Lustre file system in GiB,
+	// larger ones must be multiples of FSxStorageCapacityIncrement
+	FSxMinStorageCapacity = 1200
+	// FSxStorageCapacityIncrement is the size increment of FSx for Lustre file systems in GiB
+	FSxStorageCapacityIncrement = 3600
+)
+
+// SupportedAutoScalerExpanders are the expanders that can be used with Cluster Autoscaler
+func SupportedAutoScalerExpanders() []string {
+	return []string{
+		AutoScalerExpanderRandom,
+		AutoScalerExpanderMostPods,
+		AutoScalerExpanderLeastWaste,
+	}
+}
+
+// SupportedIPFamilies are the IP families a cluster can be created with
+func SupportedIPFamilies() []string {
+	return []string{
+		IPV4Family,
+		IPV6Family,
+	}
+}
+
 // ClusterMeta is what identifies a cluster
 type ClusterMeta struct {
 	Name   string `json:"name"`
@@ -233,14 +444,23 @@ type ClusterMeta struct {
 	Version string `json:"version,omitempty"`
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
+	// +optional
+	DeletionProtection *bool `json:"deletionProtection,omitempty"`
 }
 
 // ClusterStatus hold read-only attributes of a cluster
 type ClusterStatus struct {
-	Endpoint                 string `json:"endpoint,omitempty"`
-	CertificateAuthorityData []byte `json:"certificateAuthorityData,omitempty"`
-	ARN                      string `json:"arn,omitempty"`
-	StackName                string `json:"stackName,omitempty"`
+	Endpoint                 string       `json:"endpoint,omitempty"`
+	CertificateAuthorityData []byte       `json:"certificateAuthorityData,omitempty"`
+	ARN                      string       `json:"arn,omitempty"`
+	StackName                string       `json:"stackName,omitempty"`
+	ServiceIPv4CIDR          string       `json:"serviceIPv4CIDR,omitempty"`
+	ServiceIPv6CIDR          string       `json:"serviceIPv6CIDR,omitempty"`
+	OIDCIssuerURL            string       `json:"oidcIssuerURL,omitempty"`
+	PlatformVersion          string       `json:"platformVersion,omitempty"`
+	CreatedAt                *metav1.Time `json:"createdAt,omitempty"`
+	// SecurityGroupIDs are the security groups of the control plane
+	SecurityGroupIDs []string `json:"securityGroupIDs,omitempty"`
 }
 
 // String returns canonical representation of ClusterMeta
@@ -264,18 +484,71 @@ type ClusterProvider interface {
 	STS() stsiface.STSAPI
 	IAM() iamiface.IAMAPI
 	CloudTrail() cloudtrailiface.CloudTrailAPI
+	Pricing() pricingiface.PricingAPI
+	SSM() ssmiface.SSMAPI
+	ASG() autoscalingiface.AutoScalingAPI
+	S3() s3iface.S3API
+	CloudWatchLogs() cloudwatchlogsiface.CloudWatchLogsAPI
+	SNS() snsiface.SNSAPI
+	ResourceGroupsTagging() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 	Region() string
 	Profile() string
 	WaitTimeout() time.Duration
+	PollInterval() time.Duration
 }
 
 // ProviderConfig holds global parameters for all interactions with AWS APIs
 type ProviderConfig struct {
 	CloudFormationRoleARN string
 
-	Region      string
-	Profile     string
-	WaitTimeout time.Duration
+	Region  string
+	Profile string
+
+	// RegionSource is where Region comes from, e.g. a flag or the config file,
+	// it's set along with Region when eksctl detects the region
+	RegionSource string
+
+	WaitTimeout  time.Duration
+	PollInterval time.Duration
+
+	// AWSDebug enables logging of every AWS API call
+	AWSDebug bool
+
+	// RoleARN is a role to assume for all AWS API calls
+	RoleARN string
+
+	// StackNamePrefix is used in place of cloudFormation.stackNamePrefix when
+	// the config file doesn't set it
+	StackNamePrefix string
+
+	// CloudFormationDisableRollback enables cloudFormation.disableRollback
+	CloudFormationDisableRollback bool
+
+	// CloudFormationTemplateOverrides is used in place of cloudFormation.templateOverrides
+	// when the config file doesn't set it
+	CloudFormationTemplateOverrides string
+
+	// NotifySNSTopicARN is an SNS topic the start, success and failure
+	// of long-running operations are published to
+	NotifySNSTopicARN string
+
+	// Endpoints override the endpoints of AWS services, keyed by service name,
+	// they take precedence over the AWS_<SERVICE>_ENDPOINT environment variables
+	Endpoints map[string]string
+
+	// LocalEndpoint is the endpoint of a local emulation of AWS APIs, such as LocalStack
+	// or moto in server mode, which every service without an endpoint of its own is
+	// called at, with path-style S3 addressing and placeholder credentials
+	LocalEndpoint string
+
+	// STSRegionalEndpoint makes STS calls use the endpoint of the region
+	// instead of the global one
+	STSRegionalEndpoint bool
+
+	// CABundle is a file of PEM-encoded certificates, e.g. the CA of a TLS-intercepting
+	// proxy, that AWS API servers are trusted with instead of the system ones, and
+	// Kubernetes API servers in addition to the CA of the cluster
+	CABundle string
 }
 
 // +genclient
@@ -299,6 +572,47 @@ type ClusterConfig struct {
 	// +optional
 	AvailabilityZones []string `json:"availabilityZones,omitempty"`
 
+	// LocalZones are Local Zones or Wavelength Zones where eksctl creates
+	// additional subnets for nodegroups, the control plane doesn't use them
+	// +optional
+	LocalZones []string `json:"localZones,omitempty"`
+
+	// Outpost places the control plane on an AWS Outpost
+	// +optional
+	Outpost *Outpost `json:"outpost,omitempty"`
+
+	// +optional
+	KubernetesNetworkConfig *KubernetesNetworkConfig `json:"kubernetesNetworkConfig,omitempty"`
+
+	// +optional
+	VPCCNI *VPCCNI `json:"vpcCNI,omitempty"`
+
+	// +optional
+	AutoScaler *ClusterAutoScaler `json:"autoScaler,omitempty"`
+
+	// +optional
+	NodeTerminationHandler *ClusterNodeTerminationHandler `json:"nodeTerminationHandler,omitempty"`
+
+	// +optional
+	ContainerRuntime *ClusterContainerRuntime `json:"containerRuntime,omitempty"`
+
+	// Proxy is used by the container runtime and kubelet of all nodegroups, and by eksctl
+	// itself, unless its environment sets a proxy
+	// +optional
+	Proxy *ClusterProxy `json:"proxy,omitempty"`
+
+	// +optional
+	Storage *ClusterStorage `json:"storage,omitempty"`
+
+	// +optional
+	CloudWatch *ClusterCloudWatch `json:"cloudWatch,omitempty"`
+
+	// +optional
+	CloudFormation *ClusterCloudFormation `json:"cloudFormation,omitempty"`
+
+	// +optional
+	Hooks *ClusterHooks `json:"hooks,omitempty"`
+
 	Status *ClusterStatus `json:"status,omitempty"`
 }
 
@@ -306,6 +620,434 @@ type ClusterConfig struct {
 type ClusterIAM struct {
 	// +optional
 	ServiceRoleARN string `json:"serviceRoleARN,omitempty"`
+
+	// PermissionsBoundaryARN is the managed policy set as permissions boundary of
+	// all IAM roles eksctl creates, i.e. the service role and the instance roles
+	// of nodegroups that don't set their own
+	// +optional
+	PermissionsBoundaryARN string `json:"permissionsBoundaryARN,omitempty"`
+
+	// NodeRoles are instance roles shared by the nodegroups that set iam.nodeRole to
+	// their name, they are created once in the node roles stack of the cluster
+	// +optional
+	NodeRoles []*NodeRole `json:"nodeRoles,omitempty"`
+}
+
+// NodeRole is an instance role, along with its instance profile, shared by nodegroups;
+// its fields have the same meaning as in the iam section of nodegroups
+type NodeRole struct {
+	// Name is what nodegroups refer to the role by, it names CloudFormation
+	// resources, so it has to be alphanumeric
+	Name string `json:"name"`
+
+	// +optional
+	InstanceRoleName string `json:"instanceRoleName,omitempty"`
+	// +optional
+	AttachPolicyARNs []string `json:"attachPolicyARNs,omitempty"`
+	// +optional
+	AttachPolicy InlineDocument `json:"attachPolicy,omitempty"`
+	// PermissionsBoundaryARN takes precedence over the one set for the cluster
+	// +optional
+	PermissionsBoundaryARN string `json:"permissionsBoundaryARN,omitempty"`
+	// +optional
+	WithAddonPolicies NodeGroupIAMAddonPolicies `json:"withAddonPolicies,omitempty"`
+}
+
+// NodeGroupIAM returns the iam section of a nodegroup that creates the same role
+func (r *NodeRole) NodeGroupIAM() *NodeGroupIAM {
+	return &NodeGroupIAM{
+		InstanceRoleName:       r.InstanceRoleName,
+		AttachPolicyARNs:       append([]string{}, r.AttachPolicyARNs...),
+		AttachPolicy:           r.AttachPolicy,
+		PermissionsBoundaryARN: r.PermissionsBoundaryARN,
+		WithAddonPolicies:      r.WithAddonPolicies,
+	}
+}
+
+// NodeRole returns the node role with the given name, or nil when there's none
+func (c *ClusterConfig) NodeRole(name string) *NodeRole {
+	if c.IAM == nil {
+		return nil
+	}
+	for _, r := range c.IAM.NodeRoles {
+		if r.Name == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// HasNodeRoles returns true when node roles are defined in the config
+func (c *ClusterConfig) HasNodeRoles() bool {
+	return c.IAM != nil && len(c.IAM.NodeRoles) > 0
+}
+
+// Outpost holds the placement of the control plane of a cluster running
+// on an AWS Outpost, with its subnets in the Outpost
+type Outpost struct {
+	// ControlPlaneOutpostARN is the ARN of the Outpost to run the control plane on
+	ControlPlaneOutpostARN string `json:"controlPlaneOutpostARN"`
+
+	// ControlPlaneInstanceType is the instance type of the control plane
+	// instances, it must be available on the Outpost
+	ControlPlaneInstanceType string `json:"controlPlaneInstanceType"`
+
+	// +optional
+	ControlPlanePlacement *OutpostPlacement `json:"controlPlanePlacement,omitempty"`
+}
+
+// OutpostPlacement is an existing placement group for the control plane instances
+type OutpostPlacement struct {
+	GroupName string `json:"groupName"`
+}
+
+// KubernetesNetworkConfig holds Kubernetes network configuration of a cluster
+type KubernetesNetworkConfig struct {
+	// ServiceIPv4CIDR is the CIDR block to assign Kubernetes service IP addresses from,
+	// when not set EKS picks either 10.100.0.0/16 or 172.20.0.0/16
+	// +optional
+	ServiceIPv4CIDR string `json:"serviceIPv4CIDR,omitempty"`
+
+	// IPFamily is either IPv4 (default) or IPv6, the latter
+	// requires a dual-stack VPC created by eksctl
+	// +optional
+	IPFamily string `json:"ipFamily,omitempty"`
+}
+
+// VPCCNI holds settings of the VPC CNI plugin, they are set as
+// environment variables of the aws-node DaemonSet
+type VPCCNI struct {
+	// PrefixDelegation assigns /28 prefixes instead of single addresses to
+	// network interfaces, which raises the number of pods nodes can run
+	// +optional
+	PrefixDelegation *bool `json:"prefixDelegation,omitempty"`
+
+	// WarmPrefixTarget is the number of free prefixes kept attached to nodes
+	// +optional
+	WarmPrefixTarget *int `json:"warmPrefixTarget,omitempty"`
+
+	// WarmIPTarget is the number of free addresses kept attached to nodes
+	// +optional
+	WarmIPTarget *int `json:"warmIPTarget,omitempty"`
+}
+
+// ClusterContainerRuntime holds container runtime settings of all nodegroups,
+// it's mostly useful for clusters in restricted networks
+type ClusterContainerRuntime struct {
+	// +optional
+	RegistryMirror *RegistryMirror `json:"registryMirror,omitempty"`
+
+	// +optional
+	Offline *OfflineConfig `json:"offline,omitempty"`
+}
+
+// RegistryMirror is a pull-through mirror of Docker Hub used by nodes
+type RegistryMirror struct {
+	// Endpoint is the URL of the mirror, e.g. https://mirror.example.com
+	Endpoint string `json:"endpoint"`
+
+	// CABundle is a PEM-encoded certificate bundle used to verify the mirror
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// ClusterProxy holds the HTTP proxy settings of a cluster
+type ClusterProxy struct {
+	// HTTPProxy and HTTPSProxy are the URLs of the proxies of HTTP and HTTPS requests
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy lists hosts, domains and CIDRs that are reached without the proxy; nodes
+	// also reach instance metadata, the VPC, the service CIDR and the cluster endpoint directly
+	// +optional
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// OfflineConfig holds settings of clusters that can't reach public registries
+type OfflineConfig struct {
+	// ImageRegistry is a private registry (e.g. ECR in the account of the cluster) that
+	// holds copies of pause and add-on images, keeping their repository paths
+	ImageRegistry string `json:"imageRegistry"`
+}
+
+// ClusterAutoScaler holds configuration of Cluster Autoscaler deployment
+type ClusterAutoScaler struct {
+	// +optional
+	Install *bool `json:"install,omitempty"`
+	// +optional
+	Expander string `json:"expander,omitempty"`
+}
+
+// ClusterNodeTerminationHandler holds the configuration of AWS Node Termination Handler,
+// which drains nodes of spot nodegroups before their instances are interrupted
+type ClusterNodeTerminationHandler struct {
+	// +optional
+	Install *bool `json:"install,omitempty"`
+	// Mode is either "imds", the default, or "queue"
+	// +optional
+	Mode string `json:"mode,omitempty"`
+	// QueueURL is the URL of the SQS queue created for queue mode
+	// +optional
+	QueueURL string `json:"queueURL,omitempty"`
+}
+
+// ClusterCloudWatch holds CloudWatch settings of a cluster
+type ClusterCloudWatch struct {
+	// +optional
+	ClusterLogging *ClusterCloudWatchLogging `json:"clusterLogging,omitempty"`
+	// +optional
+	Alarms *ClusterCloudWatchAlarms `json:"alarms,omitempty"`
+}
+
+// ClusterCloudWatchAlarms holds the settings of the CloudWatch alarms created
+// in a separate stack to give the cluster basic monitoring
+type ClusterCloudWatchAlarms struct {
+	// Create enables the alarms stack
+	// +optional
+	Create *bool `json:"create,omitempty"`
+
+	// SNSTopicARN is the topic the alarms notify when they go off and
+	// when they recover
+	SNSTopicARN string `json:"snsTopicARN,omitempty"`
+}
+
+// ClusterCloudWatchLogging holds the types of control plane logs that
+// are sent to CloudWatch Logs
+type ClusterCloudWatchLogging struct {
+	// EnableTypes are the log types to enable, "*" or "all" enable all of them,
+	// types that aren't listed are disabled
+	// +optional
+	EnableTypes []string `json:"enableTypes,omitempty"`
+}
+
+// ClusterHooks holds hooks that eksctl runs around the operations on a cluster,
+// in order; the operation is aborted when a pre hook fails
+type ClusterHooks struct {
+	// PreCreate hooks run before the stacks of the cluster are created
+	// +optional
+	PreCreate []ClusterHook `json:"preCreate,omitempty"`
+
+	// PostCreate hooks run once the cluster and its nodegroups are created
+	// +optional
+	PostCreate []ClusterHook `json:"postCreate,omitempty"`
+
+	// PreDelete hooks run before the cluster is deleted
+	// +optional
+	PreDelete []ClusterHook `json:"preDelete,omitempty"`
+
+	// PostNodeGroupCreate hooks run once for each nodegroup that is created,
+	// along with the cluster or later
+	// +optional
+	PostNodeGroupCreate []ClusterHook `json:"postNodegroupCreate,omitempty"`
+}
+
+// ClusterHook is either a local command or an HTTP webhook; the metadata of
+// the cluster is passed in EKSCTL_* environment variables to commands, and as
+// a JSON payload to webhooks
+type ClusterHook struct {
+	// Command is run with "sh -c"
+	// +optional
+	Command string `json:"command,omitempty"`
+
+	// Webhook is an http or https URL the payload is POSTed to, any
+	// status other than 2xx is a failure
+	// +optional
+	Webhook string `json:"webhook,omitempty"`
+
+	// TimeoutSeconds is how long the hook may run, 300 by default
+	// +optional
+	TimeoutSeconds *int `json:"timeoutSeconds,omitempty"`
+}
+
+// ClusterCloudFormation holds settings of the CloudFormation stacks of a cluster
+type ClusterCloudFormation struct {
+	// StackNamePrefix replaces "eksctl-" at the start of the names of all stacks,
+	// it can't be changed once the cluster is created
+	// +optional
+	StackNamePrefix string `json:"stackNamePrefix,omitempty"`
+
+	// StackTags are added to all stacks, along with metadata.tags, but they
+	// aren't propagated to the resources of the stacks
+	// +optional
+	StackTags map[string]string `json:"stackTags,omitempty"`
+
+	// ServiceRoleARN is the IAM role used by CloudFormation to call AWS APIs,
+	// the --cfn-role-arn flag takes precedence over it
+	// +optional
+	ServiceRoleARN string `json:"serviceRoleARN,omitempty"`
+
+	// DisableRollback leaves stacks that fail to be created as they are, instead of
+	// rolling them back, so that the failed resources can be inspected; such stacks
+	// have to be deleted before the creation is retried
+	// +optional
+	DisableRollback *bool `json:"disableRollback,omitempty"`
+
+	// TemplateOverrides is a directory of patches applied to the generated templates
+	// before stacks are created, e.g. to add security group rules or outputs; the patch
+	// of a stack is named after its name without the prefix and the cluster name, e.g.
+	// cluster.yaml or nodegroup-ng-1.json, and nodegroup.yaml applies to all nodegroups
+	// +optional
+	TemplateOverrides string `json:"templateOverrides,omitempty"`
+}
+
+// StackNamePrefix returns the prefix of the names of the stacks of the cluster
+func (c *ClusterConfig) StackNamePrefix() string {
+	if c.CloudFormation != nil && c.CloudFormation.StackNamePrefix != "" {
+		return c.CloudFormation.StackNamePrefix
+	}
+	return DefaultStackNamePrefix
+}
+
+// SupportedCloudWatchClusterLogTypes returns all supported types of control plane logs
+func SupportedCloudWatchClusterLogTypes() []string {
+	return []string{"api", "audit", "authenticator", "controllerManager", "scheduler"}
+}
+
+// EnabledClusterLogTypes returns the types of control plane logs to enable, with "*"
+// and "all" expanded, it's nil when logging isn't set, so that it's left unchanged
+func (c *ClusterConfig) EnabledClusterLogTypes() []string {
+	if c.CloudWatch == nil || c.CloudWatch.ClusterLogging == nil {
+		return nil
+	}
+	enabled := []string{}
+	for _, t := range c.CloudWatch.ClusterLogging.EnableTypes {
+		if t == "*" || t == "all" {
+			return SupportedCloudWatchClusterLogTypes()
+		}
+		enabled = append(enabled, t)
+	}
+	return enabled
+}
+
+// HasAlarms returns true if the alarms stack is created for the cluster
+func (c *ClusterConfig) HasAlarms() bool {
+	return c.CloudWatch != nil && c.CloudWatch.Alarms != nil && IsEnabled(c.CloudWatch.Alarms.Create)
+}
+
+// ClusterStorage holds file systems that are created in the VPC of the cluster
+// in a separate stack, along with the CSI drivers to use them
+type ClusterStorage struct {
+	// EFS requires nodegroups with efs addon policy
+	// +optional
+	EFS *EFSFileSystem `json:"efs,omitempty"`
+
+	// FSx requires nodegroups with fsx addon policy
+	// +optional
+	FSx *FSxFileSystem `json:"fsx,omitempty"`
+
+	// SecurityGroup is the security group of the mount targets, it's
+	// set once the file systems are created
+	// +optional
+	SecurityGroup string `json:"securityGroup,omitempty"`
+}
+
+// EFSFileSystem holds settings of an EFS file system
+type EFSFileSystem struct {
+	// PerformanceMode is either generalPurpose (default) or maxIO
+	// +optional
+	PerformanceMode string `json:"performanceMode,omitempty"`
+
+	// ThroughputMode is either bursting (default) or provisioned
+	// +optional
+	ThroughputMode string `json:"throughputMode,omitempty"`
+
+	// ProvisionedThroughputInMibps is required with provisioned throughput mode
+	// +optional
+	ProvisionedThroughputInMibps int `json:"provisionedThroughputInMibps,omitempty"`
+
+	// +optional
+	Encrypted *bool `json:"encrypted,omitempty"`
+
+	// ID is set once the file system is created
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+// FSxFileSystem holds settings of an FSx for Lustre file system
+type FSxFileSystem struct {
+	// StorageCapacity in GiB, either 1200 (default) or a multiple of 3600
+	// +optional
+	StorageCapacity int `json:"storageCapacity,omitempty"`
+
+	// ImportPath is an S3 location (s3://bucket/prefix) that the file system is loaded from
+	// +optional
+	ImportPath string `json:"importPath,omitempty"`
+
+	// ID, DNSName and MountName are set once the file system is created
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	DNSName string `json:"dnsName,omitempty"`
+	// +optional
+	MountName string `json:"mountName,omitempty"`
+}
+
+// HasEFS returns true when an EFS file system is part of the config
+func (c *ClusterConfig) HasEFS() bool {
+	return c.Storage != nil && c.Storage.EFS != nil
+}
+
+// HasFSx returns true when an FSx file system is part of the config
+func (c *ClusterConfig) HasFSx() bool {
+	return c.Storage != nil && c.Storage.FSx != nil
+}
+
+// HasAutoScalerNodeGroups returns true when at least one nodegroup
+// has Cluster Autoscaler IAM addon policy enabled
+func (c *ClusterConfig) HasAutoScalerNodeGroups() bool {
+	for _, ng := range c.NodeGroups {
+		if ng.IAM != nil && IsEnabled(ng.IAM.WithAddonPolicies.AutoScaler) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasSpotNodeGroups returns true if any nodegroup launches spot instances
+func (c *ClusterConfig) HasSpotNodeGroups() bool {
+	for _, ng := range c.NodeGroups {
+		if IsSpotNodeGroup(ng) {
+			return true
+		}
+	}
+	return false
+}
+
+// NodeTerminationHandlerQueueMode returns true if Node Termination Handler is
+// installed in queue mode, which needs a queue and rules created along with the cluster
+func (c *ClusterConfig) NodeTerminationHandlerQueueMode() bool {
+	nth := c.NodeTerminationHandler
+	return nth != nil && IsEnabled(nth.Install) && nth.Mode == NodeTerminationHandlerModeQueue
+}
+
+// NodeTerminationHandlerQueueName returns the name of the SQS queue of Node Termination Handler in queue mode
+func (c *ClusterConfig) NodeTerminationHandlerQueueName() string {
+	return c.StackNamePrefix() + c.Metadata.Name + "-node-termination-handler"
+}
+
+// MirroredImage returns the image to use in place of the given one, which is
+// only different when offline image registry is set
+func (c *ClusterConfig) MirroredImage(image string) string {
+	if c.ContainerRuntime == nil || c.ContainerRuntime.Offline == nil || c.ContainerRuntime.Offline.ImageRegistry == "" {
+		return image
+	}
+	repository := image
+	if parts := strings.SplitN(image, "/", 2); len(parts) == 2 && strings.ContainsAny(parts[0], ".:") {
+		repository = parts[1]
+	}
+	return strings.TrimSuffix(c.ContainerRuntime.Offline.ImageRegistry, "/") + "/" + repository
+}
+
+// IPv6Enabled returns true when the cluster uses IPv6 for pods and services
+func (c *ClusterConfig) IPv6Enabled() bool {
+	return c.KubernetesNetworkConfig != nil && c.KubernetesNetworkConfig.IPFamily == IPV6Family
+}
+
+// PrefixDelegationEnabled returns true when the VPC CNI assigns prefixes to network interfaces
+func (c *ClusterConfig) PrefixDelegationEnabled() bool {
+	return c.VPCCNI != nil && IsEnabled(c.VPCCNI.PrefixDelegation)
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -410,6 +1152,10 @@ func (c *ClusterConfig) NewNodeGroup() *NodeGroup {
 // specific to a nodegroup
 type NodeGroup struct {
 	Name string `json:"name"`
+	// DependsOn lists the nodegroups that have to be created before this one,
+	// e.g. a system nodegroup hosting the CNI, they're deleted after it
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
 	// +optional
 	AMI string `json:"ami,omitempty"`
 	// +optional
@@ -420,11 +1166,34 @@ type NodeGroup struct {
 	InstancesDistribution *NodeGroupInstancesDistribution `json:"instancesDistribution,omitempty"`
 	// +optional
 	AvailabilityZones []string `json:"availabilityZones,omitempty"`
+	// Subnets launches the nodegroup in the given subnets of the cluster VPC,
+	// instead of the public or private subnets of all its availability zones
+	// +optional
+	Subnets []string `json:"subnets,omitempty"`
+	// AZRebalance can be set to "disable" to suspend the AZRebalance process of
+	// the ASG, which otherwise terminates instances to balance availability zones
+	// +optional
+	AZRebalance string `json:"azRebalance,omitempty"`
+	// LocalZones launches the nodegroup in the subnets of the given
+	// Local Zones or Wavelength Zones
+	// +optional
+	LocalZones []string `json:"localZones,omitempty"`
+	// OutpostARN launches the nodegroup in the subnets of the given Outpost
+	// +optional
+	OutpostARN string `json:"outpostARN,omitempty"`
 	// +optional
 	Tags map[string]string `json:"tags,omitempty"`
 	// +optional
 	PrivateNetworking bool `json:"privateNetworking"`
 
+	// +optional
+	Placement *NodeGroupPlacement `json:"placement,omitempty"`
+	// +optional
+	Tenancy string `json:"tenancy,omitempty"`
+
+	// +optional
+	EFAEnabled *bool `json:"efaEnabled,omitempty"`
+
 	// +optional
 	SecurityGroups *NodeGroupSGs `json:"securityGroups,omitempty"`
 
@@ -447,6 +1216,11 @@ type NodeGroup struct {
 	VolumeKmsKeyID *string `json:"volumeKmsKeyID,omitempty"`
 	// +optional
 	VolumeIOPS *int `json:"volumeIOPS"`
+	// +optional
+	VolumeThroughput *int `json:"volumeThroughput,omitempty"`
+
+	// +optional
+	AdditionalVolumes []*VolumeMapping `json:"additionalVolumes,omitempty"`
 
 	// +optional
 	MaxPodsPerNode int `json:"maxPodsPerNode,omitempty"`
@@ -457,9 +1231,29 @@ type NodeGroup struct {
 	// +optional
 	Taints map[string]string `json:"taints,omitempty"`
 
+	// PropagateASGTags adds the labels and taints to the ASG as Cluster Autoscaler
+	// node-template tags, so that it can scale the nodegroup up from zero; it's
+	// enabled unless set to false
+	// +optional
+	PropagateASGTags *bool `json:"propagateASGTags,omitempty"`
+
 	// +optional
 	TargetGroupARNs []string `json:"targetGroupARNs,omitempty"`
 
+	// ASGLifecycleHooks are added to the ASG, e.g. for a termination handler
+	// to drain nodes before their instances are terminated
+	// +optional
+	ASGLifecycleHooks []*NodeGroupLifecycleHook `json:"asgLifecycleHooks,omitempty"`
+
+	// WarmPool keeps stopped instances initialised ahead of scale-up
+	// +optional
+	WarmPool *NodeGroupWarmPool `json:"warmPool,omitempty"`
+
+	// UpdateConfig sets how instances are replaced when the launch template
+	// of the nodegroup changes, e.g. with a new AMI or user data
+	// +optional
+	UpdateConfig *NodeGroupUpdateConfig `json:"updateConfig,omitempty"`
+
 	SSH *NodeGroupSSH `json:"ssh"`
 
 	// +optional
@@ -471,11 +1265,59 @@ type NodeGroup struct {
 	// +optional
 	OverrideBootstrapCommand *string `json:"overrideBootstrapCommand,omitempty"`
 
+	// UserDataTemplate is a Go template of the user data of the nodes, which replaces
+	// the one eksctl generates, either inline or read from file://<path>; it can use
+	// the values eksctl computes, e.g. {{ .Endpoint }} or {{ .MaxPods }}
+	// +optional
+	UserDataTemplate string `json:"userDataTemplate,omitempty"`
+
 	// +optional
 	ClusterDNS string `json:"clusterDNS,omitempty"`
 
 	// +optional
 	KubeletExtraConfig *NodeGroupKubeletConfig `json:"kubeletExtraConfig,omitempty"`
+
+	// ContainerRuntime is the container runtime of the nodes, dockerd (default) or containerd
+	// +optional
+	ContainerRuntime string `json:"containerRuntime,omitempty"`
+
+	// ContainerRuntimeConfig holds extra settings of the container runtime
+	// +optional
+	ContainerRuntimeConfig *NodeGroupContainerRuntimeConfig `json:"containerRuntimeConfig,omitempty"`
+
+	// ProviderOverride creates the nodegroup in another AWS account
+	// +optional
+	ProviderOverride *NodeGroupProviderOverride `json:"providerOverride,omitempty"`
+}
+
+// NodeGroupContainerRuntimeConfig holds extra settings of the container runtime of a nodegroup
+type NodeGroupContainerRuntimeConfig struct {
+	// InsecureRegistries are registries, e.g. registry.example.com:5000, that are pulled
+	// from over plain HTTP or without verifying their certificates
+	// +optional
+	InsecureRegistries []string `json:"insecureRegistries,omitempty"`
+
+	// DefaultRuntime is the OCI runtime of containers that don't ask for a runtime class,
+	// e.g. nvidia; it must be installed on the AMI of the nodegroup
+	// +optional
+	DefaultRuntime string `json:"defaultRuntime,omitempty"`
+}
+
+// VolumeMapping defines an additional EBS volume attached to nodes
+type VolumeMapping struct {
+	// VolumeName is the device name, e.g. /dev/xvdb
+	VolumeName *string `json:"volumeName"`
+	VolumeSize *int    `json:"volumeSize"`
+	// +optional
+	VolumeType *string `json:"volumeType,omitempty"`
+	// +optional
+	VolumeEncrypted *bool `json:"volumeEncrypted,omitempty"`
+	// +optional
+	VolumeKmsKeyID *string `json:"volumeKmsKeyID,omitempty"`
+	// +optional
+	VolumeIOPS *int `json:"volumeIOPS,omitempty"`
+	// +optional
+	VolumeThroughput *int `json:"volumeThroughput,omitempty"`
 }
 
 // ListOptions returns metav1.ListOptions with label selector for the nodegroup
@@ -485,6 +1327,33 @@ func (n *NodeGroup) ListOptions() metav1.ListOptions {
 	}
 }
 
+// NodeCount returns the number of nodes the nodegroup is expected to have,
+// i.e. its desired capacity, or its minimum size when that isn't set
+func (n *NodeGroup) NodeCount() int {
+	switch {
+	case n.DesiredCapacity != nil:
+		return *n.DesiredCapacity
+	case n.MinSize != nil:
+		return *n.MinSize
+	default:
+		return DefaultNodeCount
+	}
+}
+
+// ClusterAutoscalerNodeTemplateTags returns the ASG tags Cluster Autoscaler needs to know
+// the labels and taints of nodes before any of them exist, taints are in the same
+// value:effect format as in the config file
+func ClusterAutoscalerNodeTemplateTags(labels, taints map[string]string) map[string]string {
+	tags := map[string]string{}
+	for k, v := range labels {
+		tags[ClusterAutoscalerLabelTagPrefix+k] = v
+	}
+	for k, v := range taints {
+		tags[ClusterAutoscalerTaintTagPrefix+k] = v
+	}
+	return tags
+}
+
 type (
 	// NodeGroupSGs holds all SG attributes of a NodeGroup
 	NodeGroupSGs struct {
@@ -505,6 +1374,19 @@ type (
 		InstanceRoleARN string `json:"instanceRoleARN,omitempty"`
 		// +optional
 		InstanceRoleName string `json:"instanceRoleName,omitempty"`
+		// NodeRole is the name of a role of iam.nodeRoles of the cluster, which the
+		// nodegroup uses instead of creating its own
+		// +optional
+		NodeRole string `json:"nodeRole,omitempty"`
+		// PermissionsBoundaryARN is the permissions boundary of the instance role,
+		// it takes precedence over the one set for the cluster
+		// +optional
+		PermissionsBoundaryARN string `json:"permissionsBoundaryARN,omitempty"`
+		// AttachPolicy is an inline policy document attached to the instance role,
+		// in place of the ECR, CloudWatch and addon policies that are otherwise
+		// attached for withAddonPolicies
+		// +optional
+		AttachPolicy InlineDocument `json:"attachPolicy,omitempty"`
 		// +optional
 		WithAddonPolicies NodeGroupIAMAddonPolicies `json:"withAddonPolicies,omitempty"`
 	}
@@ -546,6 +1428,17 @@ type (
 		PublicKeyName *string `json:"publicKeyName,omitempty"`
 	}
 
+	// NodeGroupProviderOverride holds the AWS credentials used to create a nodegroup
+	// in another account than the cluster's, e.g. a dedicated worker account
+	NodeGroupProviderOverride struct {
+		// Profile is the AWS profile to use instead of the cluster's
+		// +optional
+		Profile string `json:"profile,omitempty"`
+		// RoleARN is a role to assume, with the credentials of the profile
+		// +optional
+		RoleARN string `json:"roleARN,omitempty"`
+	}
+
 	// NodeGroupInstancesDistribution holds the configuration for spot instances
 	NodeGroupInstancesDistribution struct {
 		//+required
@@ -559,6 +1452,63 @@ type (
 		//+optional
 		SpotInstancePools *int `json:"spotInstancePools,omitEmpty"`
 	}
+
+	// NodeGroupPlacement holds the placement group configuration of a NodeGroup,
+	// either an existing group is used or a new one is created with the given strategy
+	NodeGroupPlacement struct {
+		// +optional
+		GroupName string `json:"groupName,omitempty"`
+		// +optional
+		Strategy string `json:"strategy,omitempty"`
+	}
+
+	// NodeGroupLifecycleHook holds the configuration of a lifecycle hook of the ASG of a NodeGroup
+	NodeGroupLifecycleHook struct {
+		Name string `json:"name"`
+		// Transition is either autoscaling:EC2_INSTANCE_LAUNCHING or autoscaling:EC2_INSTANCE_TERMINATING
+		Transition string `json:"transition"`
+		// HeartbeatTimeout is how many seconds instances wait in the transition, 3600 by default
+		// +optional
+		HeartbeatTimeout *int `json:"heartbeatTimeout,omitempty"`
+		// DefaultResult is either CONTINUE, the default, or ABANDON
+		// +optional
+		DefaultResult string `json:"defaultResult,omitempty"`
+		// NotificationTargetARN is an SQS queue or SNS topic notified of the transitions,
+		// ASGs publish them with RoleARN
+		// +optional
+		NotificationTargetARN string `json:"notificationTargetARN,omitempty"`
+		// +optional
+		RoleARN string `json:"roleARN,omitempty"`
+		// +optional
+		NotificationMetadata string `json:"notificationMetadata,omitempty"`
+	}
+
+	// NodeGroupWarmPool holds the configuration of the warm pool of the ASG of a NodeGroup
+	NodeGroupWarmPool struct {
+		// MinSize is the minimum number of instances kept in the warm pool
+		// +optional
+		MinSize *int `json:"minSize,omitempty"`
+		// MaxPrepared is the maximum number of instances in the ASG and its warm pool,
+		// the maximum size of the ASG by default
+		// +optional
+		MaxPrepared *int `json:"maxPrepared,omitempty"`
+	}
+
+	// NodeGroupUpdateConfig holds the update strategy of the instances of a NodeGroup
+	NodeGroupUpdateConfig struct {
+		// Strategy is either instanceRefresh, which replaces instances in batches,
+		// or replaceNodegroup, which replaces the whole ASG once the new one is in service
+		// +optional
+		Strategy string `json:"strategy,omitempty"`
+		// MaxUnavailable is the number of instances replaced at once by instanceRefresh,
+		// 1 by default
+		// +optional
+		MaxUnavailable *int `json:"maxUnavailable,omitempty"`
+		// MinHealthyPercentage is the percentage of the desired capacity that stays in
+		// service during instanceRefresh, 0 by default
+		// +optional
+		MinHealthyPercentage *int `json:"minHealthyPercentage,omitempty"`
+	}
 )
 
 // NodeGroupKubeletConfig contains extra config parameters for the kubelet.yaml
@@ -574,7 +1524,29 @@ func (in *NodeGroupKubeletConfig) DeepCopy() *NodeGroupKubeletConfig {
 	return out
 }
 
+// InlineDocument is an IAM policy document, its values must be
+// JSON-compatible, i.e. slices are []interface{}
+type InlineDocument map[string]interface{}
+
+// DeepCopy is needed to generate kubernetes types for InlineDocument
+func (in InlineDocument) DeepCopy() InlineDocument {
+	if in == nil {
+		return nil
+	}
+	return InlineDocument(runtime.DeepCopyJSON(in))
+}
+
 // HasMixedInstances checks if a nodegroup has mixed instances option declared
 func HasMixedInstances(ng *NodeGroup) bool {
 	return ng.InstancesDistribution != nil && ng.InstancesDistribution.InstanceTypes != nil && len(ng.InstancesDistribution.InstanceTypes) != 0
 }
+
+// IsSpotNodeGroup returns true if the nodegroup launches spot instances, i.e. its
+// instances distribution has less than 100% on-demand capacity above the base
+func IsSpotNodeGroup(ng *NodeGroup) bool {
+	if !HasMixedInstances(ng) {
+		return false
+	}
+	percentage := ng.InstancesDistribution.OnDemandPercentageAboveBaseCapacity
+	return percentage != nil && *percentage < 100
+}
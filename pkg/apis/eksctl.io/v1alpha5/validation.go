@@ -1,10 +1,17 @@
 package v1alpha5
 
 import (
+	"encoding/pem"
 	"fmt"
+	"net"
+	"net/url"
+	"regexp"
 	"strings"
 
+	"github.com/blang/semver"
 	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/weaveworks/eksctl/pkg/utils"
 )
 
 func validateNodeGroupIAM(i int, ng *NodeGroup, value, fieldName, path string) error {
@@ -16,6 +23,12 @@ func validateNodeGroupIAM(i int, ng *NodeGroup, value, fieldName, path string) e
 		if len(ng.IAM.AttachPolicyARNs) != 0 {
 			return fmt.Errorf("%s.attachPolicyARNs cannot be set at the same time", p)
 		}
+		if ng.IAM.AttachPolicy != nil {
+			return fmt.Errorf("%s.attachPolicy cannot be set at the same time", p)
+		}
+		if ng.IAM.PermissionsBoundaryARN != "" {
+			return fmt.Errorf("%s.permissionsBoundaryARN cannot be set at the same time", p)
+		}
 		if IsEnabled(ng.IAM.WithAddonPolicies.AutoScaler) {
 			return fmt.Errorf("%s.withAddonPolicies.autoScaler cannot be set at the same time", p)
 		}
@@ -53,6 +66,651 @@ func validateNodeGroupIAM(i int, ng *NodeGroup, value, fieldName, path string) e
 	return nil
 }
 
+// validateNodeGroupIAMAttachPolicy checks that the inline policy document has statements
+func validateNodeGroupIAMAttachPolicy(path string, document InlineDocument) error {
+	if document == nil {
+		return nil
+	}
+	statements, ok := document["Statement"].([]interface{})
+	if !ok || len(statements) == 0 {
+		return fmt.Errorf("%s.iam.attachPolicy must be a policy document with a Statement list", path)
+	}
+	return nil
+}
+
+// ValidateClusterAutoScaler checks Cluster Autoscaler configuration
+func ValidateClusterAutoScaler(cfg *ClusterConfig) error {
+	as := cfg.AutoScaler
+	if as == nil {
+		return nil
+	}
+
+	if as.Expander != "" {
+		if !utils.IsOneOf(as.Expander, SupportedAutoScalerExpanders()) {
+			return fmt.Errorf("autoScaler.expander %q is not supported, supported values: %s", as.Expander, strings.Join(SupportedAutoScalerExpanders(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// ValidateNodeTerminationHandler checks the mode of Node Termination Handler
+func ValidateNodeTerminationHandler(cfg *ClusterConfig) error {
+	nth := cfg.NodeTerminationHandler
+	if nth == nil {
+		return nil
+	}
+	if !utils.IsOneOf(nth.Mode, []string{"", NodeTerminationHandlerModeIMDS, NodeTerminationHandlerModeQueue}) {
+		return fmt.Errorf("nodeTerminationHandler.mode must be either %q or %q", NodeTerminationHandlerModeIMDS, NodeTerminationHandlerModeQueue)
+	}
+	if nth.Mode == NodeTerminationHandlerModeQueue && len(cfg.NodeTerminationHandlerQueueName()) > MaxSQSQueueNameLength {
+		return fmt.Errorf("nodeTerminationHandler.mode %q is not supported for cluster %q, as the name of its queue %q would be longer than %d characters",
+			nth.Mode, cfg.Metadata.Name, cfg.NodeTerminationHandlerQueueName(), MaxSQSQueueNameLength)
+	}
+	return nil
+}
+
+// ValidateKubernetesNetworkConfig checks the service CIDR is a private
+// IPv4 range of acceptable size that doesn't overlap with the VPC, and
+// that IPv6 is only used where EKS supports it
+func ValidateKubernetesNetworkConfig(cfg *ClusterConfig) error {
+	knc := cfg.KubernetesNetworkConfig
+	if knc == nil {
+		return nil
+	}
+
+	if knc.IPFamily != "" && !utils.IsOneOf(knc.IPFamily, SupportedIPFamilies()) {
+		return fmt.Errorf("kubernetesNetworkConfig.ipFamily %q is not supported, supported values: %s", knc.IPFamily, strings.Join(SupportedIPFamilies(), ", "))
+	}
+	if cfg.IPv6Enabled() {
+		if err := validateIPv6Cluster(cfg); err != nil {
+			return err
+		}
+	}
+
+	if knc.ServiceIPv4CIDR == "" {
+		return nil
+	}
+
+	_, serviceCIDR, err := net.ParseCIDR(knc.ServiceIPv4CIDR)
+	if err != nil || serviceCIDR.IP.To4() == nil {
+		return fmt.Errorf("kubernetesNetworkConfig.serviceIPv4CIDR %q is not a valid IPv4 CIDR", knc.ServiceIPv4CIDR)
+	}
+
+	if prefix, _ := serviceCIDR.Mask.Size(); prefix < 12 || prefix > 24 {
+		return fmt.Errorf("kubernetesNetworkConfig.serviceIPv4CIDR %q must have a prefix between /12 and /24", knc.ServiceIPv4CIDR)
+	}
+
+	private := false
+	for _, cidr := range []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16"} {
+		_, privateCIDR, _ := net.ParseCIDR(cidr)
+		if privateCIDR.Contains(serviceCIDR.IP) && privateCIDR.Contains(lastIP(serviceCIDR)) {
+			private = true
+		}
+	}
+	if !private {
+		return fmt.Errorf("kubernetesNetworkConfig.serviceIPv4CIDR %q must be within 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16", knc.ServiceIPv4CIDR)
+	}
+
+	if cfg.VPC != nil && cfg.VPC.CIDR != nil {
+		vpcCIDR := &cfg.VPC.CIDR.IPNet
+		if vpcCIDR.Contains(serviceCIDR.IP) || serviceCIDR.Contains(vpcCIDR.IP) {
+			return fmt.Errorf("kubernetesNetworkConfig.serviceIPv4CIDR %q overlaps with vpc.cidr %q", knc.ServiceIPv4CIDR, cfg.VPC.CIDR.String())
+		}
+	}
+
+	return nil
+}
+
+func validateIPv6Cluster(cfg *ClusterConfig) error {
+	if cfg.KubernetesNetworkConfig.ServiceIPv4CIDR != "" {
+		return fmt.Errorf("kubernetesNetworkConfig.serviceIPv4CIDR cannot be set when kubernetesNetworkConfig.ipFamily is %q", IPV6Family)
+	}
+
+	if cfg.VPC != nil {
+		if cfg.VPC.ID != "" || cfg.HasAnySubnets() {
+			return fmt.Errorf("kubernetesNetworkConfig.ipFamily %q is only supported with a dedicated VPC created by eksctl", IPV6Family)
+		}
+		if cfg.VPC.AutoAllocateIPv6 != nil && !*cfg.VPC.AutoAllocateIPv6 {
+			return fmt.Errorf("vpc.autoAllocateIPv6 cannot be disabled when kubernetesNetworkConfig.ipFamily is %q", IPV6Family)
+		}
+	}
+
+	// EKS only creates IPv6 clusters from Kubernetes 1.21, which is not among the supported versions
+	return fmt.Errorf("kubernetesNetworkConfig.ipFamily %q is not supported yet, as it requires Kubernetes version %s or above", IPV6Family, IPv6MinimumKubernetesVersion)
+}
+
+func lastIP(n *net.IPNet) net.IP {
+	ip := n.IP.To4()
+	last := make(net.IP, len(ip))
+	for i := range ip {
+		last[i] = ip[i] | ^n.Mask[len(n.Mask)-len(ip)+i]
+	}
+	return last
+}
+
+// ValidateContainerRuntime checks registry mirror endpoint is a valid URL
+// with a PEM-encoded CA bundle, that offline image registry is set
+// as a plain host (and optional path) without a scheme, and that the
+// container runtimes of nodegroups are available for them
+func ValidateContainerRuntime(cfg *ClusterConfig) error {
+	for i, ng := range cfg.NodeGroups {
+		if err := validateNodeGroupContainerRuntime(fmt.Sprintf("nodegroups[%d]", i), cfg.Metadata.Version, ng); err != nil {
+			return err
+		}
+	}
+
+	cr := cfg.ContainerRuntime
+	if cr == nil {
+		return nil
+	}
+
+	if m := cr.RegistryMirror; m != nil {
+		u, err := url.Parse(m.Endpoint)
+		if err != nil || !utils.IsOneOf(u.Scheme, []string{"http", "https"}) || u.Host == "" {
+			return fmt.Errorf("containerRuntime.registryMirror.endpoint %q must be an http or https URL", m.Endpoint)
+		}
+		if m.CABundle != "" {
+			if block, _ := pem.Decode([]byte(m.CABundle)); block == nil {
+				return fmt.Errorf("containerRuntime.registryMirror.caBundle must be a PEM-encoded certificate bundle")
+			}
+		}
+	}
+
+	if o := cr.Offline; o != nil {
+		if o.ImageRegistry == "" {
+			return fmt.Errorf("containerRuntime.offline.imageRegistry must be set")
+		}
+		if strings.Contains(o.ImageRegistry, "://") {
+			return fmt.Errorf("containerRuntime.offline.imageRegistry %q must not include a scheme", o.ImageRegistry)
+		}
+	}
+
+	return nil
+}
+
+// validateNodeGroupContainerRuntime checks that the container runtime of a nodegroup is known,
+// that containerd is only used with Amazon Linux 2 and a Kubernetes version that supports it,
+// and that the runtime is configured by the bootstrap script of eksctl
+func validateNodeGroupContainerRuntime(path, version string, ng *NodeGroup) error {
+	switch ng.ContainerRuntime {
+	case "", NodeGroupContainerRuntimeDockerd:
+	case NodeGroupContainerRuntimeContainerd:
+		if family := ng.AMIFamily; family != "" && family != NodeImageFamilyAmazonLinux2 {
+			return fmt.Errorf("%s.containerRuntime %q is only supported with amiFamily %s, got %s", path, ng.ContainerRuntime, NodeImageFamilyAmazonLinux2, family)
+		}
+		if version == "" {
+			version = DefaultVersion
+		}
+		v, err := semver.ParseTolerant(version)
+		if err != nil {
+			return fmt.Errorf("unable to parse Kubernetes version %q", version)
+		}
+		if v.LT(semver.MustParse(ContainerdMinimumKubernetesVersion + ".0")) {
+			return fmt.Errorf("%s.containerRuntime %q requires Kubernetes version %s or above, got %s", path, ng.ContainerRuntime, ContainerdMinimumKubernetesVersion, version)
+		}
+	default:
+		return fmt.Errorf("%s.containerRuntime must be one of %s or %s, got %q", path, NodeGroupContainerRuntimeDockerd, NodeGroupContainerRuntimeContainerd, ng.ContainerRuntime)
+	}
+
+	if c := ng.ContainerRuntimeConfig; c != nil {
+		for _, registry := range c.InsecureRegistries {
+			if registry == "" || strings.ContainsAny(registry, "/ ") {
+				return fmt.Errorf("%s.containerRuntimeConfig.insecureRegistries: %q must be a host with an optional port", path, registry)
+			}
+		}
+		if c.DefaultRuntime != "" && !runtimeNamePattern.MatchString(c.DefaultRuntime) {
+			return fmt.Errorf("%s.containerRuntimeConfig.defaultRuntime %q is not a valid runtime name", path, c.DefaultRuntime)
+		}
+	}
+
+	if (ng.ContainerRuntime != "" || ng.ContainerRuntimeConfig != nil) && (ng.OverrideBootstrapCommand != nil || ng.UserDataTemplate != "") {
+		return fmt.Errorf("%[1]s.containerRuntime and %[1]s.containerRuntimeConfig cannot be set with %[1]s.overrideBootstrapCommand or %[1]s.userDataTemplate, as they replace the bootstrap script that configures the runtime", path)
+	}
+	return nil
+}
+
+var runtimeNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+var nodeRoleNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]+$`)
+
+// ValidateNodeRoles checks that node roles have unique alphanumeric names, and that
+// nodegroups only refer to node roles that are defined
+func ValidateNodeRoles(cfg *ClusterConfig) error {
+	if cfg.IAM != nil {
+		names := map[string]bool{}
+		for i, r := range cfg.IAM.NodeRoles {
+			path := fmt.Sprintf("iam.nodeRoles[%d]", i)
+			if !nodeRoleNamePattern.MatchString(r.Name) {
+				return fmt.Errorf("%s.name %q must be alphanumeric", path, r.Name)
+			}
+			if names[r.Name] {
+				return fmt.Errorf("%s.name %q is used by another node role", path, r.Name)
+			}
+			names[r.Name] = true
+			if r.AttachPolicy != nil {
+				if statements, ok := r.AttachPolicy["Statement"].([]interface{}); !ok || len(statements) == 0 {
+					return fmt.Errorf("%s.attachPolicy must be a policy document with a Statement list", path)
+				}
+			}
+			if arn := r.PermissionsBoundaryARN; arn != "" && !isIAMPolicyARN(arn) {
+				return fmt.Errorf("%s.permissionsBoundaryARN: %q is not the ARN of an IAM policy", path, arn)
+			}
+		}
+	}
+	for i, ng := range cfg.NodeGroups {
+		if ng.IAM == nil || ng.IAM.NodeRole == "" {
+			continue
+		}
+		if cfg.NodeRole(ng.IAM.NodeRole) == nil {
+			return fmt.Errorf("nodeGroups[%d].iam.nodeRole %q is not defined in iam.nodeRoles", i, ng.IAM.NodeRole)
+		}
+	}
+	return nil
+}
+
+// ValidateNodeGroupDependencies checks that nodegroups only depend on other nodegroups
+// of the config, and that their dependencies have no cycles
+func ValidateNodeGroupDependencies(cfg *ClusterConfig) error {
+	indices := map[string]int{}
+	for i, ng := range cfg.NodeGroups {
+		indices[ng.Name] = i
+	}
+	for i, ng := range cfg.NodeGroups {
+		for _, name := range ng.DependsOn {
+			if name == ng.Name {
+				return fmt.Errorf("nodeGroups[%d].dependsOn: nodegroup %q cannot depend on itself", i, name)
+			}
+			if _, ok := indices[name]; !ok {
+				return fmt.Errorf("nodeGroups[%d].dependsOn: nodegroup %q is not defined", i, name)
+			}
+		}
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := map[string]int{}
+	var visit func(ng *NodeGroup, path []string) error
+	visit = func(ng *NodeGroup, path []string) error {
+		switch state[ng.Name] {
+		case visiting:
+			return fmt.Errorf("nodeGroups[%d].dependsOn: nodegroups depend on each other in a cycle: %s", indices[ng.Name], strings.Join(append(path, ng.Name), " -> "))
+		case visited:
+			return nil
+		}
+		state[ng.Name] = visiting
+		for _, name := range ng.DependsOn {
+			if err := visit(cfg.NodeGroups[indices[name]], append(path, ng.Name)); err != nil {
+				return err
+			}
+		}
+		state[ng.Name] = visited
+		return nil
+	}
+	for _, ng := range cfg.NodeGroups {
+		if err := visit(ng, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateProxy checks that proxy URLs are valid http or https URLs
+func ValidateProxy(cfg *ClusterConfig) error {
+	p := cfg.Proxy
+	if p == nil {
+		return nil
+	}
+	if p.HTTPProxy == "" && p.HTTPSProxy == "" {
+		return fmt.Errorf("at least one of proxy.httpProxy and proxy.httpsProxy must be set")
+	}
+	for field, value := range map[string]string{"httpProxy": p.HTTPProxy, "httpsProxy": p.HTTPSProxy} {
+		if value == "" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || !utils.IsOneOf(u.Scheme, []string{"http", "https"}) || u.Host == "" {
+			return fmt.Errorf("proxy.%s %q must be an http or https URL", field, value)
+		}
+	}
+	return nil
+}
+
+// ValidateLocalZones checks that the Local Zones and Wavelength Zones are in the
+// region of the cluster, and that eksctl creates the VPC they need subnets in
+func ValidateLocalZones(cfg *ClusterConfig) error {
+	if len(cfg.LocalZones) == 0 {
+		return nil
+	}
+
+	for i, zone := range cfg.LocalZones {
+		if !IsLocalZone(cfg.Metadata.Region, zone) {
+			return fmt.Errorf("localZones[%d] %q is not a Local Zone or Wavelength Zone of region %s", i, zone, cfg.Metadata.Region)
+		}
+	}
+	if cfg.VPC != nil && (cfg.VPC.ID != "" || cfg.HasAnySubnets()) {
+		return fmt.Errorf("localZones is only supported with a dedicated VPC created by eksctl, use vpc.localZoneSubnets to set existing subnets")
+	}
+	if cfg.IPv6Enabled() {
+		return fmt.Errorf("localZones is not supported when kubernetesNetworkConfig.ipFamily is %q", IPV6Family)
+	}
+	return nil
+}
+
+// ValidateOutpost checks the placement of a control plane on an Outpost, its nodegroups
+// have to run on the same Outpost, in the subnets given for the cluster
+func ValidateOutpost(cfg *ClusterConfig) error {
+	o := cfg.Outpost
+	if o == nil {
+		return nil
+	}
+
+	if !isOutpostARN(o.ControlPlaneOutpostARN) {
+		return fmt.Errorf("outpost.controlPlaneOutpostARN %q is not a valid Outpost ARN", o.ControlPlaneOutpostARN)
+	}
+	if o.ControlPlaneInstanceType == "" {
+		return fmt.Errorf("outpost.controlPlaneInstanceType must be set")
+	}
+	if p := o.ControlPlanePlacement; p != nil && p.GroupName == "" {
+		return fmt.Errorf("outpost.controlPlanePlacement.groupName must be set")
+	}
+	if len(cfg.LocalZones) > 0 {
+		return fmt.Errorf("localZones cannot be set when the control plane is on an Outpost")
+	}
+	if cfg.IPv6Enabled() {
+		return fmt.Errorf("kubernetesNetworkConfig.ipFamily %q is not supported when the control plane is on an Outpost", IPV6Family)
+	}
+
+	for i, ng := range cfg.NodeGroups {
+		path := fmt.Sprintf("nodegroups[%d]", i)
+		if len(ng.LocalZones) > 0 {
+			return fmt.Errorf("%s.localZones cannot be set when the control plane is on an Outpost", path)
+		}
+		if ng.OutpostARN != "" && ng.OutpostARN != o.ControlPlaneOutpostARN {
+			return fmt.Errorf("%s.outpostARN must be the Outpost of the control plane %q", path, o.ControlPlaneOutpostARN)
+		}
+	}
+	return nil
+}
+
+// ValidateClusterEndpoints checks that the API server endpoint of the cluster
+// is accessible either publicly or privately
+func ValidateClusterEndpoints(cfg *ClusterConfig) error {
+	if cfg.VPC == nil || cfg.VPC.ClusterEndpoints == nil {
+		return nil
+	}
+	e := cfg.VPC.ClusterEndpoints
+	if IsDisabled(e.PublicAccess) && !IsEnabled(e.PrivateAccess) {
+		return fmt.Errorf("vpc.clusterEndpoints.publicAccess and vpc.clusterEndpoints.privateAccess cannot both be disabled")
+	}
+	return nil
+}
+
+// ValidateSecurityGroupOverrides checks the IDs of pre-defined security groups, the rules
+// they need are checked against EC2 once the VPC of the cluster is known
+func ValidateSecurityGroupOverrides(cfg *ClusterConfig) error {
+	if cfg.VPC == nil {
+		return nil
+	}
+	if cfg.VPC.SecurityGroup != "" && !strings.HasPrefix(cfg.VPC.SecurityGroup, "sg-") {
+		return fmt.Errorf("vpc.securityGroup must be a security group ID, got %q", cfg.VPC.SecurityGroup)
+	}
+	if cfg.VPC.SharedNodeSecurityGroup != "" && !strings.HasPrefix(cfg.VPC.SharedNodeSecurityGroup, "sg-") {
+		return fmt.Errorf("vpc.sharedNodeSecurityGroup must be a security group ID, got %q", cfg.VPC.SharedNodeSecurityGroup)
+	}
+	if IsEnabled(cfg.VPC.ManageSharedNodeSecurityGroupRules) && cfg.VPC.SharedNodeSecurityGroup == "" {
+		return fmt.Errorf("vpc.manageSharedNodeSecurityGroupRules can only be set with vpc.sharedNodeSecurityGroup")
+	}
+	return nil
+}
+
+// ValidateControlPlaneIngressRules checks that extra ingress rules of the control
+// plane security group have valid ports and exactly one source, and that extra
+// control plane security groups are security group IDs
+func ValidateControlPlaneIngressRules(cfg *ClusterConfig) error {
+	if cfg.VPC == nil {
+		return nil
+	}
+	for i, id := range cfg.VPC.ExtraControlPlaneSecurityGroups {
+		if !strings.HasPrefix(id, "sg-") {
+			return fmt.Errorf("vpc.extraControlPlaneSecurityGroups[%d] must be a security group ID, got %q", i, id)
+		}
+	}
+	for i, r := range cfg.VPC.ExtraControlPlaneIngressRules {
+		path := fmt.Sprintf("vpc.extraControlPlaneIngressRules[%d]", i)
+		if r == nil {
+			return fmt.Errorf("%s must be set", path)
+		}
+
+		sources := 0
+		if r.CIDR != nil {
+			sources++
+		}
+		if r.SourceSecurityGroupID != "" {
+			sources++
+		}
+		if IsEnabled(r.FromSharedNodeSecurityGroup) {
+			sources++
+		}
+		if sources != 1 {
+			return fmt.Errorf("%s must have exactly one of cidr, sourceSecurityGroupID or fromSharedNodeSecurityGroup", path)
+		}
+
+		switch r.Protocol {
+		case "", ProtocolTCP, ProtocolUDP:
+			toPort := r.ToPort
+			if toPort == 0 {
+				toPort = r.FromPort
+			}
+			if r.FromPort < 1 || toPort > 65535 || r.FromPort > toPort {
+				return fmt.Errorf("%s.fromPort and %s.toPort must be a range of ports between 1 and 65535", path, path)
+			}
+		case ProtocolAll:
+			if r.FromPort != 0 || r.ToPort != 0 {
+				return fmt.Errorf("%s cannot have ports when %s.protocol is %q", path, path, ProtocolAll)
+			}
+		default:
+			return fmt.Errorf("%s.protocol must be one of %q, %q or %q", path, ProtocolTCP, ProtocolUDP, ProtocolAll)
+		}
+	}
+	return nil
+}
+
+// ValidateVPCCNI checks the settings of the VPC CNI plugin
+func ValidateVPCCNI(cfg *ClusterConfig) error {
+	cni := cfg.VPCCNI
+	if cni == nil {
+		return nil
+	}
+	if cni.WarmPrefixTarget != nil {
+		if !cfg.PrefixDelegationEnabled() {
+			return fmt.Errorf("vpcCNI.warmPrefixTarget can only be set when vpcCNI.prefixDelegation is enabled")
+		}
+		if *cni.WarmPrefixTarget < 0 {
+			return fmt.Errorf("vpcCNI.warmPrefixTarget cannot be negative")
+		}
+	}
+	if cni.WarmIPTarget != nil && *cni.WarmIPTarget < 0 {
+		return fmt.Errorf("vpcCNI.warmIPTarget cannot be negative")
+	}
+	return nil
+}
+
+// ValidateCloudWatchLogging checks that the types of control plane logs are supported
+func ValidateCloudWatchLogging(cfg *ClusterConfig) error {
+	if cfg.CloudWatch == nil || cfg.CloudWatch.ClusterLogging == nil {
+		return nil
+	}
+	supported := append(SupportedCloudWatchClusterLogTypes(), "*", "all")
+	for i, t := range cfg.CloudWatch.ClusterLogging.EnableTypes {
+		if !utils.IsOneOf(t, supported) {
+			return fmt.Errorf("cloudWatch.clusterLogging.enableTypes[%d]: log type %q is not supported, supported values: %s",
+				i, t, strings.Join(supported, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateCloudWatchAlarms checks that alarms have a topic to notify
+func ValidateCloudWatchAlarms(cfg *ClusterConfig) error {
+	if !cfg.HasAlarms() {
+		return nil
+	}
+	topic := cfg.CloudWatch.Alarms.SNSTopicARN
+	if topic == "" {
+		return fmt.Errorf("cloudWatch.alarms.snsTopicARN must be set when alarms are created")
+	}
+	if !isSNSTopicARN(topic) {
+		return fmt.Errorf("cloudWatch.alarms.snsTopicARN: %q is not the ARN of an SNS topic", topic)
+	}
+	return nil
+}
+
+// stackNamePrefixRegexp matches the start of a valid CloudFormation stack name
+var stackNamePrefixRegexp = regexp.MustCompile(`^[a-zA-Z][-a-zA-Z0-9]*$`)
+
+// maxStackTags is the number of tags CloudFormation allows on a stack
+const maxStackTags = 50
+
+// ValidateCloudFormation checks the stack name prefix, the stack tags and the
+// service role of the stacks of the cluster
+func ValidateCloudFormation(cfg *ClusterConfig) error {
+	if cfg.CloudFormation == nil {
+		return nil
+	}
+	c := cfg.CloudFormation
+	if c.StackNamePrefix != "" && !stackNamePrefixRegexp.MatchString(c.StackNamePrefix) {
+		return fmt.Errorf("cloudFormation.stackNamePrefix: %q must start with a letter and only contain letters, digits and hyphens", c.StackNamePrefix)
+	}
+	for k := range c.StackTags {
+		if strings.HasPrefix(strings.ToLower(k), "aws:") {
+			return fmt.Errorf("cloudFormation.stackTags: tag %q uses the reserved \"aws:\" prefix", k)
+		}
+		if _, ok := cfg.Metadata.Tags[k]; ok {
+			return fmt.Errorf("cloudFormation.stackTags: tag %q is also set in metadata.tags", k)
+		}
+	}
+	// eksctl adds 2 tags with the name of the cluster
+	if n := len(cfg.Metadata.Tags) + len(c.StackTags) + 2; n > maxStackTags {
+		return fmt.Errorf("cloudFormation.stackTags: stacks would have %d tags, CloudFormation allows at most %d", n, maxStackTags)
+	}
+	if c.ServiceRoleARN != "" && !isIAMRoleARN(c.ServiceRoleARN) {
+		return fmt.Errorf("cloudFormation.serviceRoleARN: %q is not the ARN of an IAM role", c.ServiceRoleARN)
+	}
+	return nil
+}
+
+// ValidateClusterIAM checks the existing service role and the permissions boundary of the cluster
+func ValidateClusterIAM(cfg *ClusterConfig) error {
+	if arn := cfg.IAM.ServiceRoleARN; arn != "" && !isIAMRoleARN(arn) {
+		return fmt.Errorf("iam.serviceRoleARN: %q is not the ARN of an IAM role", arn)
+	}
+	if arn := cfg.IAM.PermissionsBoundaryARN; arn != "" && !isIAMPolicyARN(arn) {
+		return fmt.Errorf("iam.permissionsBoundaryARN: %q is not the ARN of an IAM policy", arn)
+	}
+	return nil
+}
+
+// ValidateHooks checks that each hook is either a command or a webhook
+func ValidateHooks(cfg *ClusterConfig) error {
+	if cfg.Hooks == nil {
+		return nil
+	}
+	for _, event := range []struct {
+		name  string
+		hooks []ClusterHook
+	}{
+		{"preCreate", cfg.Hooks.PreCreate},
+		{"postCreate", cfg.Hooks.PostCreate},
+		{"preDelete", cfg.Hooks.PreDelete},
+		{"postNodegroupCreate", cfg.Hooks.PostNodeGroupCreate},
+	} {
+		for i, hook := range event.hooks {
+			path := fmt.Sprintf("hooks.%s[%d]", event.name, i)
+			if (hook.Command == "") == (hook.Webhook == "") {
+				return fmt.Errorf("%s: exactly one of command and webhook must be set", path)
+			}
+			if hook.Webhook != "" {
+				if u, err := url.Parse(hook.Webhook); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+					return fmt.Errorf("%s.webhook: %q is not an http or https URL", path, hook.Webhook)
+				}
+			}
+			if hook.TimeoutSeconds != nil && *hook.TimeoutSeconds <= 0 {
+				return fmt.Errorf("%s.timeoutSeconds must be greater than 0", path)
+			}
+		}
+	}
+	return nil
+}
+
+func isIAMRoleARN(arn string) bool {
+	return strings.HasPrefix(arn, "arn:") && strings.Contains(arn, ":iam::") && strings.Contains(arn, ":role/")
+}
+
+func isIAMPolicyARN(arn string) bool {
+	return strings.HasPrefix(arn, "arn:") && strings.Contains(arn, ":iam::") && strings.Contains(arn, ":policy/")
+}
+
+func isSNSTopicARN(arn string) bool {
+	parts := strings.Split(arn, ":")
+	return len(parts) == 6 && parts[0] == "arn" && parts[2] == "sns" && parts[5] != ""
+}
+
+func isOutpostARN(arn string) bool {
+	return strings.HasPrefix(arn, "arn:") && strings.Contains(arn, ":outposts:") && strings.Contains(arn, ":outpost/")
+}
+
+// ValidateClusterStorage checks the settings of file systems, nodegroups
+// must have the corresponding addon policies to mount them
+func ValidateClusterStorage(cfg *ClusterConfig) error {
+	if cfg.HasEFS() {
+		efs := cfg.Storage.EFS
+		if efs.PerformanceMode != "" && !utils.IsOneOf(efs.PerformanceMode, []string{EFSPerformanceModeGeneralPurpose, EFSPerformanceModeMaxIO}) {
+			return fmt.Errorf("storage.efs.performanceMode %q is not supported, supported values: %s, %s", efs.PerformanceMode, EFSPerformanceModeGeneralPurpose, EFSPerformanceModeMaxIO)
+		}
+		switch efs.ThroughputMode {
+		case "", EFSThroughputModeBursting:
+			if efs.ProvisionedThroughputInMibps != 0 {
+				return fmt.Errorf("storage.efs.provisionedThroughputInMibps can only be set with %q throughput mode", EFSThroughputModeProvisioned)
+			}
+		case EFSThroughputModeProvisioned:
+			if efs.ProvisionedThroughputInMibps <= 0 {
+				return fmt.Errorf("storage.efs.provisionedThroughputInMibps must be set with %q throughput mode", EFSThroughputModeProvisioned)
+			}
+		default:
+			return fmt.Errorf("storage.efs.throughputMode %q is not supported, supported values: %s, %s", efs.ThroughputMode, EFSThroughputModeBursting, EFSThroughputModeProvisioned)
+		}
+		if !hasNodeGroupWithAddonPolicy(cfg, func(p NodeGroupIAMAddonPolicies) *bool { return p.EFS }) {
+			return fmt.Errorf("storage.efs requires at least one nodegroup with iam.withAddonPolicies.efs enabled")
+		}
+	}
+
+	if cfg.HasFSx() {
+		fsx := cfg.Storage.FSx
+		if c := fsx.StorageCapacity; c != 0 && c != FSxMinStorageCapacity && c%FSxStorageCapacityIncrement != 0 {
+			return fmt.Errorf("storage.fsx.storageCapacity must be either %d or a multiple of %d", FSxMinStorageCapacity, FSxStorageCapacityIncrement)
+		}
+		if fsx.ImportPath != "" && !strings.HasPrefix(fsx.ImportPath, "s3://") {
+			return fmt.Errorf("storage.fsx.importPath %q must be an S3 location, i.e. s3://bucket/prefix", fsx.ImportPath)
+		}
+		if !hasNodeGroupWithAddonPolicy(cfg, func(p NodeGroupIAMAddonPolicies) *bool { return p.FSX }) {
+			return fmt.Errorf("storage.fsx requires at least one nodegroup with iam.withAddonPolicies.fsx enabled")
+		}
+	}
+	return nil
+}
+
+func hasNodeGroupWithAddonPolicy(cfg *ClusterConfig, policy func(NodeGroupIAMAddonPolicies) *bool) bool {
+	for _, ng := range cfg.NodeGroups {
+		if ng.IAM != nil && IsEnabled(policy(ng.IAM.WithAddonPolicies)) {
+			return true
+		}
+	}
+	return false
+}
+
 // ValidateNodeGroupLabels uses proper Kubernetes label validation,
 // it's designed to make sure users don't pass weird labels to the
 // nodes, which would prevent kubelets to startup properly
@@ -147,12 +805,8 @@ func ValidateNodeGroup(i int, ng *NodeGroup) error {
 		}
 	}
 
-	if ng.VolumeType != nil && *ng.VolumeType == NodeVolumeTypeIO1 {
-		if ng.VolumeIOPS == nil {
-			return fmt.Errorf("%s.volumeIOPS is required for %s volume type", path, NodeVolumeTypeIO1)
-		}
-	} else if ng.VolumeIOPS != nil {
-		return fmt.Errorf("%s.volumeIOPS is only supported for %s volume type", path, NodeVolumeTypeIO1)
+	if err := validateVolumeOptions(path, ng.VolumeType, ng.VolumeIOPS, ng.VolumeThroughput); err != nil {
+		return err
 	}
 
 	if ng.VolumeEncrypted == nil || IsDisabled(ng.VolumeEncrypted) {
@@ -161,6 +815,46 @@ func ValidateNodeGroup(i int, ng *NodeGroup) error {
 		}
 	}
 
+	if err := validateAdditionalVolumes(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupPlacement(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupEFA(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupProviderOverride(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupLocalZones(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupSubnets(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupLifecycleHooks(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupWarmPool(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupUserDataTemplate(path, ng); err != nil {
+		return err
+	}
+
+	if err := validateNodeGroupUpdateConfig(path, ng); err != nil {
+		return err
+	}
+
 	if ng.IAM != nil {
 		if err := validateNodeGroupIAM(i, ng, ng.IAM.InstanceProfileARN, "instanceProfileARN", path); err != nil {
 			return err
@@ -168,6 +862,18 @@ func ValidateNodeGroup(i int, ng *NodeGroup) error {
 		if err := validateNodeGroupIAM(i, ng, ng.IAM.InstanceRoleARN, "instanceRoleARN", path); err != nil {
 			return err
 		}
+		if err := validateNodeGroupIAM(i, ng, ng.IAM.NodeRole, "nodeRole", path); err != nil {
+			return err
+		}
+		if ng.IAM.NodeRole != "" && (ng.IAM.InstanceProfileARN != "" || ng.IAM.InstanceRoleARN != "") {
+			return fmt.Errorf("%[1]s.iam.nodeRole cannot be set at the same time as %[1]s.iam.instanceProfileARN or %[1]s.iam.instanceRoleARN", path)
+		}
+		if err := validateNodeGroupIAMAttachPolicy(path, ng.IAM.AttachPolicy); err != nil {
+			return err
+		}
+		if arn := ng.IAM.PermissionsBoundaryARN; arn != "" && !isIAMPolicyARN(arn) {
+			return fmt.Errorf("%s.iam.permissionsBoundaryARN: %q is not the ARN of an IAM policy", path, arn)
+		}
 
 		if err := ValidateNodeGroupLabels(ng); err != nil {
 			return err
@@ -189,6 +895,288 @@ func ValidateNodeGroup(i int, ng *NodeGroup) error {
 	return nil
 }
 
+// validateVolumeOptions checks IOPS and throughput settings against the constraints of the
+// given volume type; io1 and io2 require IOPS, gp3 accepts both IOPS and throughput
+func validateVolumeOptions(path string, volumeType *string, iops, throughput *int) error {
+	t := ""
+	if volumeType != nil {
+		t = *volumeType
+	}
+
+	switch t {
+	case NodeVolumeTypeIO1, NodeVolumeTypeIO2:
+		if iops == nil {
+			return fmt.Errorf("%s.volumeIOPS is required for %s volume type", path, t)
+		}
+		if *iops < MinIO1VolumeIOPS || *iops > MaxIO1VolumeIOPS {
+			return fmt.Errorf("%s.volumeIOPS must be between %d and %d for %s volume type", path, MinIO1VolumeIOPS, MaxIO1VolumeIOPS, t)
+		}
+	case NodeVolumeTypeGP3:
+		if iops != nil && (*iops < MinGP3VolumeIOPS || *iops > MaxGP3VolumeIOPS) {
+			return fmt.Errorf("%s.volumeIOPS must be between %d and %d for %s volume type", path, MinGP3VolumeIOPS, MaxGP3VolumeIOPS, t)
+		}
+	default:
+		if iops != nil {
+			return fmt.Errorf("%s.volumeIOPS is only supported for %s, %s and %s volume types", path, NodeVolumeTypeIO1, NodeVolumeTypeIO2, NodeVolumeTypeGP3)
+		}
+	}
+
+	if throughput != nil {
+		if t != NodeVolumeTypeGP3 {
+			return fmt.Errorf("%s.volumeThroughput is only supported for %s volume type", path, NodeVolumeTypeGP3)
+		}
+		if *throughput < MinGP3VolumeThroughput || *throughput > MaxGP3VolumeThroughput {
+			return fmt.Errorf("%s.volumeThroughput must be between %d and %d", path, MinGP3VolumeThroughput, MaxGP3VolumeThroughput)
+		}
+	}
+
+	return nil
+}
+
+func validateAdditionalVolumes(path string, ng *NodeGroup) error {
+	deviceNames := map[string]bool{}
+	if IsSetAndNonEmptyString(ng.VolumeName) {
+		deviceNames[*ng.VolumeName] = true
+	}
+
+	for i, v := range ng.AdditionalVolumes {
+		volumePath := fmt.Sprintf("%s.additionalVolumes[%d]", path, i)
+
+		if !IsSetAndNonEmptyString(v.VolumeName) {
+			return fmt.Errorf("%s.volumeName must be set", volumePath)
+		}
+		if deviceNames[*v.VolumeName] {
+			return fmt.Errorf("%s.volumeName %q is used by another volume", volumePath, *v.VolumeName)
+		}
+		deviceNames[*v.VolumeName] = true
+
+		if v.VolumeSize == nil || *v.VolumeSize <= 0 {
+			return fmt.Errorf("%s.volumeSize must be set", volumePath)
+		}
+
+		if err := validateVolumeOptions(volumePath, v.VolumeType, v.VolumeIOPS, v.VolumeThroughput); err != nil {
+			return err
+		}
+
+		if !IsEnabled(v.VolumeEncrypted) && IsSetAndNonEmptyString(v.VolumeKmsKeyID) {
+			return fmt.Errorf("%s.volumeKmsKeyID can not be set without %s.volumeEncrypted true", volumePath, volumePath)
+		}
+	}
+
+	return nil
+}
+
+func validateNodeGroupPlacement(path string, ng *NodeGroup) error {
+	if ng.Tenancy != "" && !utils.IsOneOf(ng.Tenancy, SupportedTenancies()) {
+		return fmt.Errorf("%s.tenancy %q is not supported, supported values: %s", path, ng.Tenancy, strings.Join(SupportedTenancies(), ", "))
+	}
+
+	p := ng.Placement
+	if p == nil {
+		return nil
+	}
+
+	switch {
+	case p.GroupName != "" && p.Strategy != "":
+		return fmt.Errorf("%s.placement.groupName and %s.placement.strategy cannot be set at the same time, use groupName for an existing placement group or strategy to create a new one", path, path)
+	case p.GroupName == "" && p.Strategy == "":
+		return fmt.Errorf("either %s.placement.groupName or %s.placement.strategy must be set", path, path)
+	case p.Strategy != "" && !utils.IsOneOf(p.Strategy, SupportedPlacementStrategies()):
+		return fmt.Errorf("%s.placement.strategy %q is not supported, supported values: %s", path, p.Strategy, strings.Join(SupportedPlacementStrategies(), ", "))
+	case p.Strategy == PlacementStrategyCluster && len(ng.AvailabilityZones) != 1:
+		return fmt.Errorf("%s.availabilityZones must contain exactly one zone when using %q placement strategy", path, PlacementStrategyCluster)
+	}
+
+	return nil
+}
+
+func validateNodeGroupEFA(path string, ng *NodeGroup) error {
+	if !IsEnabled(ng.EFAEnabled) {
+		return nil
+	}
+
+	if len(ng.AvailabilityZones) != 1 {
+		return fmt.Errorf("%s.availabilityZones must contain exactly one zone when %s.efaEnabled is set", path, path)
+	}
+
+	if p := ng.Placement; p != nil && p.Strategy != "" && p.Strategy != PlacementStrategyCluster {
+		return fmt.Errorf("%s.placement.strategy must be %q when %s.efaEnabled is set", path, PlacementStrategyCluster, path)
+	}
+
+	instanceTypes := []string{ng.InstanceType}
+	if HasMixedInstances(ng) {
+		instanceTypes = ng.InstancesDistribution.InstanceTypes
+	}
+	for _, instanceType := range instanceTypes {
+		if !utils.IsOneOf(instanceType, SupportedEFAInstanceTypes()) {
+			return fmt.Errorf("instance type %q of %s does not support EFA, supported instance types: %s", instanceType, path, strings.Join(SupportedEFAInstanceTypes(), ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateNodeGroupProviderOverride checks that a nodegroup created in another account
+// doesn't depend on security groups of the cluster's account, as they can neither be
+// attached to its instances nor have rules added by its stack
+func validateNodeGroupProviderOverride(path string, ng *NodeGroup) error {
+	o := ng.ProviderOverride
+	if o == nil {
+		return nil
+	}
+
+	if o.Profile == "" && o.RoleARN == "" {
+		return fmt.Errorf("either %s.providerOverride.profile or %s.providerOverride.roleARN must be set", path, path)
+	}
+	if o.RoleARN != "" && !strings.HasPrefix(o.RoleARN, "arn:") {
+		return fmt.Errorf("%s.providerOverride.roleARN %q is not a valid ARN", path, o.RoleARN)
+	}
+
+	sgs := ng.SecurityGroups
+	if sgs == nil || len(sgs.AttachIDs) == 0 {
+		return fmt.Errorf("%s.securityGroups.attachIDs must be set when %s.providerOverride is set, as the nodegroup can only use security groups of its own account", path, path)
+	}
+	if IsEnabled(sgs.WithShared) || IsEnabled(sgs.WithLocal) {
+		return fmt.Errorf("%s.securityGroups.withShared and %s.securityGroups.withLocal must be false when %s.providerOverride is set", path, path, path)
+	}
+	return nil
+}
+
+// validateNodeGroupLocalZones checks that the nodegroup is launched in only one kind
+// of zone, and that it can reach the internet in Wavelength Zones, which only have
+// public subnets as they have no NAT gateways
+func validateNodeGroupLocalZones(path string, ng *NodeGroup) error {
+	if !UsesLocalZoneSubnets(ng) {
+		return nil
+	}
+
+	if len(ng.LocalZones) > 0 && ng.OutpostARN != "" {
+		return fmt.Errorf("%s.localZones and %s.outpostARN cannot be set at the same time", path, path)
+	}
+	if len(ng.AvailabilityZones) > 0 {
+		return fmt.Errorf("%s.availabilityZones cannot be set with %s.localZones or %s.outpostARN", path, path, path)
+	}
+	if ng.OutpostARN != "" && !isOutpostARN(ng.OutpostARN) {
+		return fmt.Errorf("%s.outpostARN %q is not a valid Outpost ARN", path, ng.OutpostARN)
+	}
+	if IsEnabled(ng.EFAEnabled) {
+		return fmt.Errorf("%s.efaEnabled is not supported in Local Zones, Wavelength Zones and Outposts", path)
+	}
+	for _, zone := range ng.LocalZones {
+		if IsWavelengthZone(zone) && ng.PrivateNetworking {
+			return fmt.Errorf("%s.privateNetworking is not supported in Wavelength Zone %q, nodes use carrier IP addresses instead", path, zone)
+		}
+	}
+	return nil
+}
+
+// validateNodeGroupSubnets checks the subnet overrides and the AZRebalance setting of a nodegroup
+func validateNodeGroupSubnets(path string, ng *NodeGroup) error {
+	if !utils.IsOneOf(ng.AZRebalance, []string{"", AZRebalanceEnable, AZRebalanceDisable}) {
+		return fmt.Errorf("%s.azRebalance must be either %q or %q", path, AZRebalanceEnable, AZRebalanceDisable)
+	}
+	if len(ng.Subnets) == 0 {
+		return nil
+	}
+	if len(ng.AvailabilityZones) > 0 || UsesLocalZoneSubnets(ng) {
+		return fmt.Errorf("%s.subnets cannot be set with %s.availabilityZones, %s.localZones or %s.outpostARN", path, path, path, path)
+	}
+	for _, id := range ng.Subnets {
+		if !strings.HasPrefix(id, "subnet-") {
+			return fmt.Errorf("%s.subnets: %q is not a subnet ID", path, id)
+		}
+	}
+	return nil
+}
+
+// validateNodeGroupLifecycleHooks checks the lifecycle hooks of a nodegroup against the
+// constraints of Auto Scaling, so that they don't fail the creation of its stack
+func validateNodeGroupLifecycleHooks(path string, ng *NodeGroup) error {
+	names := map[string]bool{}
+	for i, hook := range ng.ASGLifecycleHooks {
+		hookPath := fmt.Sprintf("%s.asgLifecycleHooks[%d]", path, i)
+		if hook.Name == "" {
+			return fmt.Errorf("%s.name must be set", hookPath)
+		}
+		if names[hook.Name] {
+			return fmt.Errorf("%s.name %q is used by another lifecycle hook", hookPath, hook.Name)
+		}
+		names[hook.Name] = true
+
+		if !utils.IsOneOf(hook.Transition, []string{LifecycleTransitionLaunching, LifecycleTransitionTerminating}) {
+			return fmt.Errorf("%s.transition must be either %q or %q", hookPath, LifecycleTransitionLaunching, LifecycleTransitionTerminating)
+		}
+		if !utils.IsOneOf(hook.DefaultResult, []string{"", LifecycleHookResultContinue, LifecycleHookResultAbandon}) {
+			return fmt.Errorf("%s.defaultResult must be either %q or %q", hookPath, LifecycleHookResultContinue, LifecycleHookResultAbandon)
+		}
+		if t := hook.HeartbeatTimeout; t != nil && (*t < MinLifecycleHookHeartbeatTimeout || *t > MaxLifecycleHookHeartbeatTimeout) {
+			return fmt.Errorf("%s.heartbeatTimeout must be between %d and %d seconds", hookPath, MinLifecycleHookHeartbeatTimeout, MaxLifecycleHookHeartbeatTimeout)
+		}
+		if (hook.NotificationTargetARN == "") != (hook.RoleARN == "") {
+			return fmt.Errorf("%s.notificationTargetARN and %s.roleARN must be set together", hookPath, hookPath)
+		}
+	}
+	return nil
+}
+
+// validateNodeGroupWarmPool checks the sizes of the warm pool of a nodegroup, which
+// Auto Scaling doesn't support for mixed instances policies
+func validateNodeGroupWarmPool(path string, ng *NodeGroup) error {
+	wp := ng.WarmPool
+	if wp == nil {
+		return nil
+	}
+	if HasMixedInstances(ng) {
+		return fmt.Errorf("%s.warmPool cannot be set with %s.instancesDistribution", path, path)
+	}
+	if wp.MinSize != nil && *wp.MinSize < 0 {
+		return fmt.Errorf("%s.warmPool.minSize cannot be negative", path)
+	}
+	if wp.MaxPrepared != nil && wp.MinSize != nil && *wp.MaxPrepared < *wp.MinSize {
+		return fmt.Errorf("%s.warmPool.maxPrepared cannot be less than %s.warmPool.minSize", path, path)
+	}
+	return nil
+}
+
+// validateNodeGroupUserDataTemplate checks that the user data template isn't combined
+// with commands that are added to the user data eksctl generates
+func validateNodeGroupUserDataTemplate(path string, ng *NodeGroup) error {
+	if ng.UserDataTemplate == "" {
+		return nil
+	}
+	if len(ng.PreBootstrapCommands) > 0 {
+		return fmt.Errorf("%s.userDataTemplate cannot be set with %s.preBootstrapCommands", path, path)
+	}
+	if ng.OverrideBootstrapCommand != nil {
+		return fmt.Errorf("%s.userDataTemplate cannot be set with %s.overrideBootstrapCommand", path, path)
+	}
+	return nil
+}
+
+func validateNodeGroupUpdateConfig(path string, ng *NodeGroup) error {
+	uc := ng.UpdateConfig
+	if uc == nil {
+		return nil
+	}
+	strategies := []string{NodeGroupUpdateStrategyInstanceRefresh, NodeGroupUpdateStrategyReplaceNodeGroup}
+	if !utils.IsOneOf(uc.Strategy, strategies) {
+		return fmt.Errorf("%s.updateConfig.strategy must be one of: %s", path, strings.Join(strategies, ", "))
+	}
+	if uc.Strategy != NodeGroupUpdateStrategyInstanceRefresh {
+		if uc.MaxUnavailable != nil || uc.MinHealthyPercentage != nil {
+			return fmt.Errorf("%s.updateConfig.maxUnavailable and minHealthyPercentage can only be set with the %s strategy", path, NodeGroupUpdateStrategyInstanceRefresh)
+		}
+		return nil
+	}
+	if uc.MaxUnavailable != nil && *uc.MaxUnavailable < 1 {
+		return fmt.Errorf("%s.updateConfig.maxUnavailable must be 1 or greater", path)
+	}
+	if p := uc.MinHealthyPercentage; p != nil && (*p < 0 || *p > 100) {
+		return fmt.Errorf("%s.updateConfig.minHealthyPercentage must be between 0 and 100", path)
+	}
+	return nil
+}
+
 func validateInstancesDistribution(ng *NodeGroup) error {
 	if ng.InstancesDistribution == nil {
 		return nil
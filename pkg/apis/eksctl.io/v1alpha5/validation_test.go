@@ -1,8 +1,13 @@
 package v1alpha5
 
 import (
+	"fmt"
+	"strings"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
 
 var _ = Describe("ConfigFile validation", func() {
@@ -217,6 +222,1083 @@ var _ = Describe("ConfigFile validation", func() {
 		})
 	})
 
+	Describe("ebs volume types", func() {
+		var ng *NodeGroup
+
+		BeforeEach(func() {
+			ng = &NodeGroup{
+				Name:       "ng1",
+				VolumeSize: newInt(50),
+				VolumeType: newString(NodeVolumeTypeGP2),
+			}
+		})
+
+		It("requires volumeIOPS for io1 and io2", func() {
+			*ng.VolumeType = NodeVolumeTypeIO2
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.VolumeIOPS = newInt(1000)
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+			ng.VolumeIOPS = newInt(10)
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("allows volumeIOPS and volumeThroughput within gp3 limits", func() {
+			*ng.VolumeType = NodeVolumeTypeGP3
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+			ng.VolumeIOPS = newInt(4000)
+			ng.VolumeThroughput = newInt(500)
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+			ng.VolumeThroughput = newInt(2000)
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("forbids volumeThroughput and volumeIOPS for gp2", func() {
+			ng.VolumeThroughput = newInt(250)
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.VolumeThroughput = nil
+			ng.VolumeIOPS = newInt(3000)
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("validates additional volumes", func() {
+			ng.VolumeName = newString("/dev/xvda")
+			ng.AdditionalVolumes = []*VolumeMapping{{
+				VolumeName: newString("/dev/xvdb"),
+				VolumeSize: newInt(100),
+				VolumeType: newString(NodeVolumeTypeIO1),
+			}}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+			ng.AdditionalVolumes[0].VolumeIOPS = newInt(1000)
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+			ng.AdditionalVolumes[0].VolumeName = newString("/dev/xvda")
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+	})
+
+	Describe("placement and tenancy", func() {
+		var ng *NodeGroup
+
+		BeforeEach(func() {
+			ng = &NodeGroup{Name: "ng1"}
+		})
+
+		It("accepts an existing placement group", func() {
+			ng.Placement = &NodeGroupPlacement{GroupName: "hpc"}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+
+		It("forbids setting both groupName and strategy", func() {
+			ng.Placement = &NodeGroupPlacement{GroupName: "hpc", Strategy: PlacementStrategySpread}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("requires a single zone for cluster strategy", func() {
+			ng.Placement = &NodeGroupPlacement{Strategy: PlacementStrategyCluster}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.AvailabilityZones = []string{"us-west-2a"}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+
+		It("rejects unknown strategies and tenancies", func() {
+			ng.Placement = &NodeGroupPlacement{Strategy: "packed"}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.Placement = nil
+			ng.Tenancy = "host"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.Tenancy = TenancyDedicated
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+	})
+
+	Describe("efa", func() {
+		var ng *NodeGroup
+
+		BeforeEach(func() {
+			ng = &NodeGroup{
+				Name:              "ng1",
+				InstanceType:      "c5n.18xlarge",
+				AvailabilityZones: []string{"us-west-2a"},
+				EFAEnabled:        Enabled(),
+			}
+		})
+
+		It("accepts supported instance types in a single zone", func() {
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+
+		It("requires a single zone", func() {
+			ng.AvailabilityZones = append(ng.AvailabilityZones, "us-west-2b")
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("rejects instance types without an adapter", func() {
+			ng.InstanceType = "m5.large"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("rejects placement strategies other than cluster", func() {
+			ng.Placement = &NodeGroupPlacement{Strategy: PlacementStrategySpread}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("defaults to a cluster placement group", func() {
+			Expect(SetNodeGroupDefaults(0, ng)).To(Succeed())
+			Expect(ng.Placement).To(Equal(&NodeGroupPlacement{Strategy: PlacementStrategyCluster}))
+		})
+	})
+
+	Describe("provider override", func() {
+		var ng *NodeGroup
+
+		BeforeEach(func() {
+			ng = &NodeGroup{
+				Name:             "ng1",
+				ProviderOverride: &NodeGroupProviderOverride{Profile: "workers"},
+				SecurityGroups: &NodeGroupSGs{
+					AttachIDs:  []string{"sg-1"},
+					WithShared: Disabled(),
+					WithLocal:  Disabled(),
+				},
+			}
+		})
+
+		It("accepts a profile or a role", func() {
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+			ng.ProviderOverride = &NodeGroupProviderOverride{RoleARN: "arn:aws:iam::123456789012:role/eksctl"}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+
+		It("requires a profile or a role", func() {
+			ng.ProviderOverride = &NodeGroupProviderOverride{}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.ProviderOverride.RoleARN = "eksctl"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("requires security groups of the nodegroup's account", func() {
+			ng.SecurityGroups.AttachIDs = nil
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.SecurityGroups.AttachIDs = []string{"sg-1"}
+			ng.SecurityGroups.WithShared = Enabled()
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+	})
+
+	Describe("iam attachPolicy", func() {
+		var ng *NodeGroup
+
+		BeforeEach(func() {
+			ng = &NodeGroup{
+				Name: "ng1",
+				IAM: &NodeGroupIAM{
+					AttachPolicy: InlineDocument{
+						"Version": "2012-10-17",
+						"Statement": []interface{}{
+							map[string]interface{}{
+								"Effect":   "Allow",
+								"Action":   []interface{}{"s3:GetObject"},
+								"Resource": "*",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("accepts a policy document", func() {
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+
+		It("requires statements", func() {
+			ng.IAM.AttachPolicy = InlineDocument{"Version": "2012-10-17"}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("can't be set along with an existing role or profile", func() {
+			ng.IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/nodes"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.IAM.InstanceRoleARN = ""
+			ng.IAM.InstanceProfileARN = "arn:aws:iam::123456789012:instance-profile/nodes"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+	})
+
+	Describe("nodegroup subnets and AZ rebalancing", func() {
+		It("accepts subnet IDs, unless zones are set as well", func() {
+			ng := &NodeGroup{Name: "ng1", Subnets: []string{"subnet-1"}, AZRebalance: AZRebalanceDisable}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+			ng.AvailabilityZones = []string{"us-west-2a"}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.AvailabilityZones = nil
+			ng.LocalZones = []string{"us-west-2-lax-1a"}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("rejects subnets that aren't IDs", func() {
+			ng := &NodeGroup{Name: "ng1", Subnets: []string{"us-west-2a"}}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("rejects unknown azRebalance values", func() {
+			ng := &NodeGroup{Name: "ng1", AZRebalance: "false"}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+	})
+
+	Describe("nodegroup lifecycle hooks and warm pools", func() {
+		newHook := func() *NodeGroupLifecycleHook {
+			return &NodeGroupLifecycleHook{Name: "drain", Transition: LifecycleTransitionTerminating}
+		}
+
+		It("accepts hooks with a transition", func() {
+			ng := &NodeGroup{Name: "ng1", ASGLifecycleHooks: []*NodeGroupLifecycleHook{newHook()}}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+			ng.ASGLifecycleHooks[0].Transition = "terminating"
+			Expect(ValidateNodeGroup(0, ng)).To(MatchError(ContainSubstring("nodegroups[0].asgLifecycleHooks[0].transition must be either")))
+		})
+
+		It("rejects duplicate hook names and out of range heartbeat timeouts", func() {
+			ng := &NodeGroup{Name: "ng1", ASGLifecycleHooks: []*NodeGroupLifecycleHook{newHook(), newHook()}}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+			timeout := 10
+			ng.ASGLifecycleHooks = []*NodeGroupLifecycleHook{newHook()}
+			ng.ASGLifecycleHooks[0].HeartbeatTimeout = &timeout
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("requires a role for notification targets", func() {
+			ng := &NodeGroup{Name: "ng1", ASGLifecycleHooks: []*NodeGroupLifecycleHook{newHook()}}
+			ng.ASGLifecycleHooks[0].NotificationTargetARN = "arn:aws:sqs:us-west-2:123456789012:nth"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.ASGLifecycleHooks[0].RoleARN = "arn:aws:iam::123456789012:role/asg-notifications"
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+
+		It("rejects warm pools of mixed instances nodegroups and inconsistent sizes", func() {
+			minSize, maxPrepared := 2, 1
+			ng := &NodeGroup{Name: "ng1", WarmPool: &NodeGroupWarmPool{MinSize: &minSize}}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+			ng.WarmPool.MaxPrepared = &maxPrepared
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+			ng.WarmPool.MaxPrepared = nil
+			ng.InstancesDistribution = &NodeGroupInstancesDistribution{InstanceTypes: []string{"t3.large", "m5.large"}}
+			Expect(ValidateNodeGroup(0, ng)).To(MatchError("nodegroups[0].warmPool cannot be set with nodegroups[0].instancesDistribution"))
+		})
+
+		It("validates the update strategy", func() {
+			ng := &NodeGroup{Name: "ng1", UpdateConfig: &NodeGroupUpdateConfig{Strategy: NodeGroupUpdateStrategyInstanceRefresh}}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+			ng.UpdateConfig.MinHealthyPercentage = newInt(101)
+			Expect(ValidateNodeGroup(0, ng)).To(MatchError("nodegroups[0].updateConfig.minHealthyPercentage must be between 0 and 100"))
+
+			ng.UpdateConfig.MinHealthyPercentage = newInt(90)
+			ng.UpdateConfig.Strategy = NodeGroupUpdateStrategyReplaceNodeGroup
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+			ng.UpdateConfig = &NodeGroupUpdateConfig{Strategy: "rolling"}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("rejects user data templates combined with bootstrap commands", func() {
+			ng := &NodeGroup{Name: "ng1", UserDataTemplate: "file://userdata.sh.tmpl"}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+			ng.PreBootstrapCommands = []string{"yum update -y"}
+			Expect(ValidateNodeGroup(0, ng)).To(MatchError("nodegroups[0].userDataTemplate cannot be set with nodegroups[0].preBootstrapCommands"))
+
+			ng.PreBootstrapCommands = nil
+			override := "/etc/eks/bootstrap.sh"
+			ng.OverrideBootstrapCommand = &override
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+	})
+
+	Describe("Node Termination Handler", func() {
+		It("accepts IMDS and queue modes", func() {
+			cfg := NewClusterConfig()
+			cfg.Metadata.Name = "cluster-1"
+			Expect(ValidateNodeTerminationHandler(cfg)).To(Succeed())
+
+			cfg.NodeTerminationHandler = &ClusterNodeTerminationHandler{Install: Enabled()}
+			Expect(ValidateNodeTerminationHandler(cfg)).To(Succeed())
+			cfg.NodeTerminationHandler.Mode = NodeTerminationHandlerModeQueue
+			Expect(ValidateNodeTerminationHandler(cfg)).To(Succeed())
+			cfg.NodeTerminationHandler.Mode = "sqs"
+			Expect(ValidateNodeTerminationHandler(cfg)).ToNot(Succeed())
+		})
+
+		It("rejects queue mode when the name of the queue would be too long", func() {
+			cfg := NewClusterConfig()
+			cfg.Metadata.Name = strings.Repeat("a", 60)
+			cfg.NodeTerminationHandler = &ClusterNodeTerminationHandler{Install: Enabled(), Mode: NodeTerminationHandlerModeQueue}
+			Expect(ValidateNodeTerminationHandler(cfg)).ToNot(Succeed())
+		})
+	})
+
+	Describe("local zones and outposts", func() {
+		const outpostARN = "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"
+
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			cfg.Metadata.Region = "us-west-2"
+		})
+
+		It("accepts Local Zones and Wavelength Zones of the region", func() {
+			cfg.LocalZones = []string{"us-west-2-lax-1a", "us-west-2-wl1-las-wlz-1"}
+			Expect(ValidateLocalZones(cfg)).To(Succeed())
+		})
+
+		It("rejects zones that aren't Local Zones of the region", func() {
+			cfg.LocalZones = []string{"us-west-2a"}
+			Expect(ValidateLocalZones(cfg)).ToNot(Succeed())
+			cfg.LocalZones = []string{"us-east-1-bos-1a"}
+			Expect(ValidateLocalZones(cfg)).ToNot(Succeed())
+		})
+
+		It("requires a dedicated VPC for Local Zones", func() {
+			cfg.LocalZones = []string{"us-west-2-lax-1a"}
+			cfg.VPC.ID = "vpc-1"
+			Expect(ValidateLocalZones(cfg)).ToNot(Succeed())
+		})
+
+		It("validates nodegroups in Local Zones and Outposts", func() {
+			ng := &NodeGroup{Name: "ng1", LocalZones: []string{"us-west-2-lax-1a"}}
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+
+			ng.AvailabilityZones = []string{"us-west-2a"}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+			ng.AvailabilityZones = nil
+			ng.OutpostARN = outpostARN
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+
+			ng.LocalZones = nil
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+			ng.OutpostARN = "op-0123456789abcdef0"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+
+		It("rejects private networking in Wavelength Zones", func() {
+			ng := &NodeGroup{Name: "ng1", LocalZones: []string{"us-west-2-wl1-las-wlz-1"}, PrivateNetworking: true}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.PrivateNetworking = false
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+
+		It("validates the control plane placement on an Outpost", func() {
+			cfg.Outpost = &Outpost{
+				ControlPlaneOutpostARN:   outpostARN,
+				ControlPlaneInstanceType: "m5d.large",
+			}
+			Expect(ValidateOutpost(cfg)).To(Succeed())
+
+			cfg.Outpost.ControlPlanePlacement = &OutpostPlacement{}
+			Expect(ValidateOutpost(cfg)).ToNot(Succeed())
+			cfg.Outpost.ControlPlanePlacement.GroupName = "control-plane"
+			Expect(ValidateOutpost(cfg)).To(Succeed())
+
+			cfg.Outpost.ControlPlaneInstanceType = ""
+			Expect(ValidateOutpost(cfg)).ToNot(Succeed())
+
+			cfg.Outpost.ControlPlaneInstanceType = "m5d.large"
+			cfg.Outpost.ControlPlaneOutpostARN = "arn:aws:iam::123456789012:role/outpost"
+			Expect(ValidateOutpost(cfg)).ToNot(Succeed())
+		})
+
+		It("requires nodegroups to run on the Outpost of the control plane", func() {
+			cfg.Outpost = &Outpost{
+				ControlPlaneOutpostARN:   outpostARN,
+				ControlPlaneInstanceType: "m5d.large",
+			}
+			ng := cfg.NewNodeGroup()
+			ng.OutpostARN = "arn:aws:outposts:us-west-2:123456789012:outpost/op-1111111111111111"
+			Expect(ValidateOutpost(cfg)).ToNot(Succeed())
+			ng.OutpostARN = outpostARN
+			Expect(ValidateOutpost(cfg)).To(Succeed())
+		})
+
+		It("keeps subnets in Local Zones and Outposts away from the control plane", func() {
+			cfg.VPC.Subnets = &ClusterSubnets{
+				Private: map[string]Network{
+					"us-west-2a":       {ID: "subnet-a"},
+					"us-west-2b":       {ID: "subnet-b", OutpostARN: outpostARN},
+					"us-west-2-lax-1a": {ID: "subnet-lax"},
+				},
+			}
+			cfg.SeparateLocalZoneSubnets()
+			Expect(cfg.PrivateSubnetIDs()).To(ConsistOf("subnet-a"))
+			Expect(cfg.LocalZoneSubnetIDs(SubnetTopologyPrivate)).To(ConsistOf("subnet-b", "subnet-lax"))
+			Expect(cfg.IsLocalZoneSubnet(SubnetTopologyPrivate, "us-west-2b", "subnet-b")).To(BeTrue())
+		})
+	})
+
+	Describe("cluster autoscaler", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			ng := cfg.NewNodeGroup()
+			ng.Name = "ng-1"
+			cfg.AutoScaler = &ClusterAutoScaler{Install: Enabled()}
+		})
+
+		It("should accept supported expanders", func() {
+			for _, e := range SupportedAutoScalerExpanders() {
+				cfg.AutoScaler.Expander = e
+				Expect(ValidateClusterAutoScaler(cfg)).To(Succeed())
+			}
+		})
+
+		It("should reject unknown expanders", func() {
+			cfg.AutoScaler.Expander = "fastest"
+			Expect(ValidateClusterAutoScaler(cfg)).ToNot(Succeed())
+
+			// Cluster Autoscaler only has it for Kubernetes 1.14 and later
+			cfg.AutoScaler.Expander = "priority"
+			Expect(ValidateClusterAutoScaler(cfg)).ToNot(Succeed())
+		})
+	})
+
+	Describe("cluster endpoints", func() {
+		It("should accept public, private or both", func() {
+			cfg := NewClusterConfig()
+			Expect(ValidateClusterEndpoints(cfg)).To(Succeed())
+
+			for _, e := range []*ClusterEndpoints{
+				{PublicAccess: Enabled()},
+				{PublicAccess: Disabled(), PrivateAccess: Enabled()},
+				{PublicAccess: Enabled(), PrivateAccess: Enabled()},
+				{PrivateAccess: Disabled()},
+			} {
+				cfg.VPC.ClusterEndpoints = e
+				Expect(ValidateClusterEndpoints(cfg)).To(Succeed())
+			}
+		})
+
+		It("should reject an endpoint that isn't accessible", func() {
+			cfg := NewClusterConfig()
+			cfg.VPC.ClusterEndpoints = &ClusterEndpoints{PublicAccess: Disabled()}
+			Expect(ValidateClusterEndpoints(cfg)).ToNot(Succeed())
+
+			cfg.VPC.ClusterEndpoints.PrivateAccess = Disabled()
+			Expect(ValidateClusterEndpoints(cfg)).ToNot(Succeed())
+		})
+	})
+
+	Describe("cloudWatch cluster logging", func() {
+		It("should accept supported log types", func() {
+			cfg := NewClusterConfig()
+			Expect(ValidateCloudWatchLogging(cfg)).To(Succeed())
+
+			cfg.CloudWatch = &ClusterCloudWatch{ClusterLogging: &ClusterCloudWatchLogging{
+				EnableTypes: []string{"api", "audit"},
+			}}
+			Expect(ValidateCloudWatchLogging(cfg)).To(Succeed())
+			Expect(cfg.EnabledClusterLogTypes()).To(Equal([]string{"api", "audit"}))
+		})
+
+		It("should expand all log types", func() {
+			cfg := NewClusterConfig()
+			Expect(cfg.EnabledClusterLogTypes()).To(BeNil())
+
+			cfg.CloudWatch = &ClusterCloudWatch{ClusterLogging: &ClusterCloudWatchLogging{}}
+			Expect(cfg.EnabledClusterLogTypes()).To(BeEmpty())
+
+			cfg.CloudWatch.ClusterLogging.EnableTypes = []string{"*"}
+			Expect(ValidateCloudWatchLogging(cfg)).To(Succeed())
+			Expect(cfg.EnabledClusterLogTypes()).To(Equal(SupportedCloudWatchClusterLogTypes()))
+		})
+
+		It("should reject unknown log types", func() {
+			cfg := NewClusterConfig()
+			cfg.CloudWatch = &ClusterCloudWatch{ClusterLogging: &ClusterCloudWatchLogging{
+				EnableTypes: []string{"api", "kubelet"},
+			}}
+			err := ValidateCloudWatchLogging(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`log type "kubelet" is not supported`))
+		})
+	})
+
+	Describe("cluster IAM", func() {
+		It("should only accept the ARN of a role as service role", func() {
+			cfg := NewClusterConfig()
+			Expect(ValidateClusterIAM(cfg)).To(Succeed())
+
+			cfg.IAM.ServiceRoleARN = "arn:aws:iam::123456789012:role/eks-service-role"
+			Expect(ValidateClusterIAM(cfg)).To(Succeed())
+
+			cfg.IAM.ServiceRoleARN = "arn:aws:iam::123456789012:user/eks"
+			Expect(ValidateClusterIAM(cfg)).ToNot(Succeed())
+		})
+
+		It("should only accept the ARN of a policy as permissions boundary", func() {
+			cfg := NewClusterConfig()
+			cfg.IAM.PermissionsBoundaryARN = "arn:aws:iam::123456789012:policy/boundary"
+			Expect(ValidateClusterIAM(cfg)).To(Succeed())
+
+			cfg.IAM.PermissionsBoundaryARN = "arn:aws:iam::123456789012:role/boundary"
+			Expect(ValidateClusterIAM(cfg)).ToNot(Succeed())
+
+			ng := &NodeGroup{Name: "ng-1", IAM: &NodeGroupIAM{PermissionsBoundaryARN: "boundary"}}
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+			ng.IAM.PermissionsBoundaryARN = "arn:aws:iam::123456789012:policy/boundary"
+			Expect(ValidateNodeGroup(0, ng)).To(Succeed())
+		})
+	})
+
+	Describe("cloudWatch alarms", func() {
+		It("should require an SNS topic when alarms are created", func() {
+			cfg := NewClusterConfig()
+			cfg.CloudWatch = &ClusterCloudWatch{Alarms: &ClusterCloudWatchAlarms{}}
+			Expect(cfg.HasAlarms()).To(BeFalse())
+			Expect(ValidateCloudWatchAlarms(cfg)).To(Succeed())
+
+			cfg.CloudWatch.Alarms.Create = Enabled()
+			Expect(cfg.HasAlarms()).To(BeTrue())
+			Expect(ValidateCloudWatchAlarms(cfg)).ToNot(Succeed())
+
+			cfg.CloudWatch.Alarms.SNSTopicARN = "arn:aws:sqs:us-west-2:123456789012:alarms"
+			Expect(ValidateCloudWatchAlarms(cfg)).ToNot(Succeed())
+
+			cfg.CloudWatch.Alarms.SNSTopicARN = "arn:aws:sns:us-west-2:123456789012:alarms"
+			Expect(ValidateCloudWatchAlarms(cfg)).To(Succeed())
+		})
+	})
+
+	Describe("CloudFormation settings", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+		})
+
+		It("should use the default stack name prefix", func() {
+			Expect(ValidateCloudFormation(cfg)).To(Succeed())
+			Expect(cfg.StackNamePrefix()).To(Equal("eksctl-"))
+
+			cfg.CloudFormation = &ClusterCloudFormation{}
+			Expect(cfg.StackNamePrefix()).To(Equal("eksctl-"))
+		})
+
+		It("should accept valid settings", func() {
+			cfg.CloudFormation = &ClusterCloudFormation{
+				StackNamePrefix: "team-a-",
+				StackTags:       map[string]string{"cost-center": "1234"},
+				ServiceRoleARN:  "arn:aws:iam::123456789012:role/cfn-service-role",
+			}
+			Expect(ValidateCloudFormation(cfg)).To(Succeed())
+			Expect(cfg.StackNamePrefix()).To(Equal("team-a-"))
+		})
+
+		It("should reject invalid stack name prefixes", func() {
+			cfg.CloudFormation = &ClusterCloudFormation{StackNamePrefix: "team_a-"}
+			err := ValidateCloudFormation(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cloudFormation.stackNamePrefix"))
+
+			cfg.CloudFormation.StackNamePrefix = "1team-"
+			Expect(ValidateCloudFormation(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject reserved and duplicate stack tags", func() {
+			cfg.CloudFormation = &ClusterCloudFormation{StackTags: map[string]string{"aws:owner": "me"}}
+			err := ValidateCloudFormation(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`reserved "aws:" prefix`))
+
+			cfg.Metadata.Tags = map[string]string{"team": "a"}
+			cfg.CloudFormation.StackTags = map[string]string{"team": "b"}
+			err = ValidateCloudFormation(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("also set in metadata.tags"))
+		})
+
+		It("should reject too many stack tags", func() {
+			cfg.CloudFormation = &ClusterCloudFormation{StackTags: map[string]string{}}
+			for i := 0; i < 49; i++ {
+				cfg.CloudFormation.StackTags[fmt.Sprintf("tag-%d", i)] = "value"
+			}
+			err := ValidateCloudFormation(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("stacks would have 51 tags"))
+		})
+
+		It("should reject service roles that aren't IAM roles", func() {
+			cfg.CloudFormation = &ClusterCloudFormation{ServiceRoleARN: "arn:aws:iam::123456789012:user/someone"}
+			err := ValidateCloudFormation(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cloudFormation.serviceRoleARN"))
+		})
+	})
+
+	Describe("hooks", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+		})
+
+		It("should accept commands and webhooks", func() {
+			Expect(ValidateHooks(cfg)).To(Succeed())
+
+			cfg.Hooks = &ClusterHooks{
+				PreCreate:           []ClusterHook{{Command: "./register-dns.sh"}},
+				PostNodeGroupCreate: []ClusterHook{{Webhook: "https://cmdb.example.com/hooks/eksctl"}},
+			}
+			Expect(ValidateHooks(cfg)).To(Succeed())
+		})
+
+		It("should reject hooks that aren't either a command or a webhook", func() {
+			cfg.Hooks = &ClusterHooks{PostCreate: []ClusterHook{{}}}
+			err := ValidateHooks(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("hooks.postCreate[0]: exactly one of command and webhook must be set"))
+
+			cfg.Hooks.PostCreate[0] = ClusterHook{Command: "true", Webhook: "https://example.com"}
+			Expect(ValidateHooks(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject webhooks that aren't http URLs", func() {
+			cfg.Hooks = &ClusterHooks{PreDelete: []ClusterHook{{Webhook: "ftp://example.com/hook"}}}
+			err := ValidateHooks(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("hooks.preDelete[0].webhook"))
+		})
+	})
+
+	Describe("extra control plane ingress rules", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+		})
+
+		It("should accept rules with one source", func() {
+			cidr := ipnet.MustParseCIDR("10.0.0.0/8")
+			cfg.VPC.ExtraControlPlaneIngressRules = []*ControlPlaneIngressRule{
+				{FromPort: 443, CIDR: cidr},
+				{FromPort: 8443, ToPort: 9443, FromSharedNodeSecurityGroup: Enabled()},
+				{Protocol: ProtocolUDP, FromPort: 53, SourceSecurityGroupID: "sg-1"},
+				{Protocol: ProtocolAll, SourceSecurityGroupID: "sg-2"},
+			}
+			Expect(ValidateControlPlaneIngressRules(cfg)).To(Succeed())
+		})
+
+		It("should reject rules without exactly one source", func() {
+			cfg.VPC.ExtraControlPlaneIngressRules = []*ControlPlaneIngressRule{{FromPort: 443}}
+			Expect(ValidateControlPlaneIngressRules(cfg)).ToNot(Succeed())
+
+			cfg.VPC.ExtraControlPlaneIngressRules[0].SourceSecurityGroupID = "sg-1"
+			cfg.VPC.ExtraControlPlaneIngressRules[0].FromSharedNodeSecurityGroup = Enabled()
+			Expect(ValidateControlPlaneIngressRules(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject invalid ports and protocols", func() {
+			for _, r := range []*ControlPlaneIngressRule{
+				{SourceSecurityGroupID: "sg-1"},
+				{FromPort: 9443, ToPort: 8443, SourceSecurityGroupID: "sg-1"},
+				{FromPort: 443, ToPort: 70000, SourceSecurityGroupID: "sg-1"},
+				{Protocol: ProtocolAll, FromPort: 443, SourceSecurityGroupID: "sg-1"},
+				{Protocol: "icmp", FromPort: 1, SourceSecurityGroupID: "sg-1"},
+			} {
+				cfg.VPC.ExtraControlPlaneIngressRules = []*ControlPlaneIngressRule{r}
+				Expect(ValidateControlPlaneIngressRules(cfg)).ToNot(Succeed())
+			}
+		})
+
+		It("should only accept security group IDs as extra control plane security groups", func() {
+			cfg.VPC.ExtraControlPlaneSecurityGroups = []string{"sg-0123456789abcdef0"}
+			Expect(ValidateControlPlaneIngressRules(cfg)).To(Succeed())
+
+			cfg.VPC.ExtraControlPlaneSecurityGroups = append(cfg.VPC.ExtraControlPlaneSecurityGroups, "corporate")
+			err := ValidateControlPlaneIngressRules(cfg)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("vpc.extraControlPlaneSecurityGroups[1]"))
+		})
+	})
+
+	Describe("Security group overrides", func() {
+		It("should accept security group IDs", func() {
+			cfg := NewClusterConfig()
+			Expect(ValidateSecurityGroupOverrides(cfg)).To(Succeed())
+
+			cfg.VPC.SecurityGroup = "sg-1"
+			cfg.VPC.SharedNodeSecurityGroup = "sg-2"
+			cfg.VPC.ManageSharedNodeSecurityGroupRules = Enabled()
+			Expect(ValidateSecurityGroupOverrides(cfg)).To(Succeed())
+		})
+
+		It("should reject names instead of IDs", func() {
+			cfg := NewClusterConfig()
+			cfg.VPC.SharedNodeSecurityGroup = "nodes"
+			Expect(ValidateSecurityGroupOverrides(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject managed rules without a shared node security group", func() {
+			cfg := NewClusterConfig()
+			cfg.VPC.ManageSharedNodeSecurityGroupRules = Enabled()
+			Expect(ValidateSecurityGroupOverrides(cfg)).ToNot(Succeed())
+		})
+	})
+
+	Describe("VPC CNI", func() {
+		It("should accept prefix delegation with warm targets", func() {
+			cfg := NewClusterConfig()
+			Expect(ValidateVPCCNI(cfg)).To(Succeed())
+
+			warmPrefixTarget, warmIPTarget := 1, 5
+			cfg.VPCCNI = &VPCCNI{
+				PrefixDelegation: Enabled(),
+				WarmPrefixTarget: &warmPrefixTarget,
+				WarmIPTarget:     &warmIPTarget,
+			}
+			Expect(ValidateVPCCNI(cfg)).To(Succeed())
+		})
+
+		It("should reject a warm prefix target without prefix delegation", func() {
+			cfg := NewClusterConfig()
+			warmPrefixTarget := 1
+			cfg.VPCCNI = &VPCCNI{WarmPrefixTarget: &warmPrefixTarget}
+			Expect(ValidateVPCCNI(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject negative warm targets", func() {
+			cfg := NewClusterConfig()
+			warmIPTarget := -1
+			cfg.VPCCNI = &VPCCNI{WarmIPTarget: &warmIPTarget}
+			Expect(ValidateVPCCNI(cfg)).ToNot(Succeed())
+		})
+	})
+
+	Describe("cluster storage", func() {
+		var (
+			cfg *ClusterConfig
+			ng  *NodeGroup
+		)
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			ng = cfg.NewNodeGroup()
+			ng.Name = "ng-1"
+			cfg.Storage = &ClusterStorage{
+				EFS: &EFSFileSystem{},
+				FSx: &FSxFileSystem{},
+			}
+		})
+
+		It("should require nodegroups with addon policies", func() {
+			Expect(ValidateClusterStorage(cfg)).ToNot(Succeed())
+
+			ng.IAM.WithAddonPolicies.EFS = Enabled()
+			Expect(ValidateClusterStorage(cfg)).ToNot(Succeed())
+
+			ng.IAM.WithAddonPolicies.FSX = Enabled()
+			Expect(ValidateClusterStorage(cfg)).To(Succeed())
+		})
+
+		It("should validate EFS throughput mode", func() {
+			ng.IAM.WithAddonPolicies.EFS = Enabled()
+			cfg.Storage.FSx = nil
+
+			cfg.Storage.EFS.ThroughputMode = EFSThroughputModeProvisioned
+			Expect(ValidateClusterStorage(cfg)).ToNot(Succeed())
+
+			cfg.Storage.EFS.ProvisionedThroughputInMibps = 100
+			Expect(ValidateClusterStorage(cfg)).To(Succeed())
+
+			cfg.Storage.EFS.ThroughputMode = EFSThroughputModeBursting
+			Expect(ValidateClusterStorage(cfg)).ToNot(Succeed())
+		})
+
+		It("should validate FSx storage capacity and import path", func() {
+			ng.IAM.WithAddonPolicies.FSX = Enabled()
+			cfg.Storage.EFS = nil
+
+			for _, c := range []int{1200, 3600, 7200} {
+				cfg.Storage.FSx.StorageCapacity = c
+				Expect(ValidateClusterStorage(cfg)).To(Succeed())
+			}
+			cfg.Storage.FSx.StorageCapacity = 2400
+			Expect(ValidateClusterStorage(cfg)).ToNot(Succeed())
+
+			cfg.Storage.FSx.StorageCapacity = 0
+			cfg.Storage.FSx.ImportPath = "bucket/prefix"
+			Expect(ValidateClusterStorage(cfg)).ToNot(Succeed())
+			cfg.Storage.FSx.ImportPath = "s3://bucket/prefix"
+			Expect(ValidateClusterStorage(cfg)).To(Succeed())
+		})
+	})
+
+	Describe("kubernetes network config", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			cfg.KubernetesNetworkConfig = &KubernetesNetworkConfig{}
+		})
+
+		It("should accept an empty service CIDR", func() {
+			Expect(ValidateKubernetesNetworkConfig(cfg)).To(Succeed())
+		})
+
+		It("should accept private service CIDRs that don't overlap with the VPC", func() {
+			for _, cidr := range []string{"10.96.0.0/16", "172.20.0.0/16", "172.16.0.0/24", "10.16.0.0/12"} {
+				cfg.KubernetesNetworkConfig.ServiceIPv4CIDR = cidr
+				Expect(ValidateKubernetesNetworkConfig(cfg)).To(Succeed())
+			}
+		})
+
+		It("should reject invalid or non-private service CIDRs", func() {
+			for _, cidr := range []string{"10.96.0.0", "fd00::/108", "100.64.0.0/16", "172.31.0.0/15"} {
+				cfg.KubernetesNetworkConfig.ServiceIPv4CIDR = cidr
+				Expect(ValidateKubernetesNetworkConfig(cfg)).ToNot(Succeed())
+			}
+		})
+
+		It("should reject service CIDRs with an unsupported prefix", func() {
+			for _, cidr := range []string{"10.0.0.0/8", "10.96.0.0/25"} {
+				cfg.KubernetesNetworkConfig.ServiceIPv4CIDR = cidr
+				Expect(ValidateKubernetesNetworkConfig(cfg)).ToNot(Succeed())
+			}
+		})
+
+		It("should reject a service CIDR overlapping with the VPC", func() {
+			cfg.KubernetesNetworkConfig.ServiceIPv4CIDR = "192.168.128.0/24"
+			Expect(ValidateKubernetesNetworkConfig(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject unknown IP families", func() {
+			cfg.KubernetesNetworkConfig.IPFamily = "IPv5"
+			Expect(ValidateKubernetesNetworkConfig(cfg)).ToNot(Succeed())
+
+			cfg.KubernetesNetworkConfig.IPFamily = IPV4Family
+			Expect(ValidateKubernetesNetworkConfig(cfg)).To(Succeed())
+		})
+
+		It("should reject IPv6 as unsupported", func() {
+			cfg.KubernetesNetworkConfig.IPFamily = IPV6Family
+
+			for _, version := range []string{Version1_13, IPv6MinimumKubernetesVersion} {
+				cfg.Metadata.Version = version
+				Expect(ValidateKubernetesNetworkConfig(cfg)).To(MatchError(ContainSubstring("is not supported yet")))
+			}
+		})
+
+		It("should reject IPv6 with a service IPv4 CIDR or an existing VPC", func() {
+			cfg.KubernetesNetworkConfig.IPFamily = IPV6Family
+
+			cfg.KubernetesNetworkConfig.ServiceIPv4CIDR = "10.96.0.0/16"
+			Expect(ValidateKubernetesNetworkConfig(cfg)).To(MatchError(ContainSubstring("serviceIPv4CIDR cannot be set")))
+
+			cfg.KubernetesNetworkConfig.ServiceIPv4CIDR = ""
+			cfg.VPC.ID = "vpc-123"
+			Expect(ValidateKubernetesNetworkConfig(cfg)).To(MatchError(ContainSubstring("dedicated VPC")))
+		})
+	})
+
+	Describe("container runtime", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			cfg.ContainerRuntime = &ClusterContainerRuntime{}
+		})
+
+		It("should accept an empty configuration", func() {
+			Expect(ValidateContainerRuntime(cfg)).To(Succeed())
+		})
+
+		It("should only accept http or https registry mirror endpoints", func() {
+			for _, endpoint := range []string{"https://mirror.example.com", "http://10.0.0.10:5000"} {
+				cfg.ContainerRuntime.RegistryMirror = &RegistryMirror{Endpoint: endpoint}
+				Expect(ValidateContainerRuntime(cfg)).To(Succeed())
+			}
+			for _, endpoint := range []string{"", "mirror.example.com", "ftp://mirror.example.com", "https://"} {
+				cfg.ContainerRuntime.RegistryMirror = &RegistryMirror{Endpoint: endpoint}
+				Expect(ValidateContainerRuntime(cfg)).ToNot(Succeed())
+			}
+		})
+
+		It("should reject a CA bundle that isn't PEM-encoded", func() {
+			cfg.ContainerRuntime.RegistryMirror = &RegistryMirror{
+				Endpoint: "https://mirror.example.com",
+				CABundle: "not a certificate",
+			}
+			Expect(ValidateContainerRuntime(cfg)).ToNot(Succeed())
+
+			cfg.ContainerRuntime.RegistryMirror.CABundle = "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"
+			Expect(ValidateContainerRuntime(cfg)).To(Succeed())
+		})
+
+		It("should require offline image registry without a scheme", func() {
+			cfg.ContainerRuntime.Offline = &OfflineConfig{}
+			Expect(ValidateContainerRuntime(cfg)).ToNot(Succeed())
+
+			cfg.ContainerRuntime.Offline.ImageRegistry = "https://registry.example.com"
+			Expect(ValidateContainerRuntime(cfg)).ToNot(Succeed())
+
+			cfg.ContainerRuntime.Offline.ImageRegistry = "registry.example.com/mirror"
+			Expect(ValidateContainerRuntime(cfg)).To(Succeed())
+		})
+
+		It("should only accept containerd on Amazon Linux 2 and supported Kubernetes versions", func() {
+			ng := cfg.NewNodeGroup()
+			ng.ContainerRuntime = NodeGroupContainerRuntimeContainerd
+			Expect(ValidateContainerRuntime(cfg)).To(Succeed())
+
+			ng.AMIFamily = NodeImageFamilyUbuntu1804
+			Expect(ValidateContainerRuntime(cfg)).To(MatchError(ContainSubstring("only supported with amiFamily AmazonLinux2")))
+
+			ng.AMIFamily = NodeImageFamilyAmazonLinux2
+			cfg.Metadata.Version = Version1_11
+			Expect(ValidateContainerRuntime(cfg)).To(MatchError(ContainSubstring("requires Kubernetes version 1.12 or above")))
+
+			ng.ContainerRuntime = "cri-o"
+			Expect(ValidateContainerRuntime(cfg)).ToNot(Succeed())
+		})
+
+		It("should validate extra runtime configuration of nodegroups", func() {
+			ng := cfg.NewNodeGroup()
+			ng.ContainerRuntimeConfig = &NodeGroupContainerRuntimeConfig{
+				InsecureRegistries: []string{"registry.example.com:5000", "10.0.0.10"},
+				DefaultRuntime:     "nvidia",
+			}
+			Expect(ValidateContainerRuntime(cfg)).To(Succeed())
+
+			ng.ContainerRuntimeConfig.InsecureRegistries = []string{"http://registry.example.com"}
+			Expect(ValidateContainerRuntime(cfg)).ToNot(Succeed())
+
+			ng.ContainerRuntimeConfig.InsecureRegistries = nil
+			overrideBootstrapCommand := "/opt/bootstrap.sh"
+			ng.OverrideBootstrapCommand = &overrideBootstrapCommand
+			Expect(ValidateContainerRuntime(cfg)).To(MatchError(ContainSubstring("replace the bootstrap script")))
+		})
+
+		It("should map images to the offline image registry", func() {
+			Expect(cfg.MirroredImage("k8s.gcr.io/cluster-autoscaler:v1.13.8")).To(Equal("k8s.gcr.io/cluster-autoscaler:v1.13.8"))
+
+			cfg.ContainerRuntime.Offline = &OfflineConfig{ImageRegistry: "registry.example.com/mirror/"}
+			Expect(cfg.MirroredImage("k8s.gcr.io/cluster-autoscaler:v1.13.8")).To(Equal("registry.example.com/mirror/cluster-autoscaler:v1.13.8"))
+			Expect(cfg.MirroredImage("602401143452.dkr.ecr.us-west-2.amazonaws.com/eks/pause-amd64:3.1")).To(Equal("registry.example.com/mirror/eks/pause-amd64:3.1"))
+			Expect(cfg.MirroredImage("weaveworks/flux:1.13.0")).To(Equal("registry.example.com/mirror/weaveworks/flux:1.13.0"))
+		})
+	})
+
+	Describe("Node roles", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			cfg.IAM = &ClusterIAM{NodeRoles: []*NodeRole{{Name: "workers"}}}
+		})
+
+		It("should accept nodegroups that share a node role", func() {
+			for _, name := range []string{"ng-1", "ng-2"} {
+				ng := cfg.NewNodeGroup()
+				ng.Name = name
+				ng.IAM.NodeRole = "workers"
+			}
+			Expect(ValidateNodeRoles(cfg)).To(Succeed())
+			for i, ng := range cfg.NodeGroups {
+				Expect(ValidateNodeGroup(i, ng)).To(Succeed())
+			}
+		})
+
+		It("should reject names that aren't unique and alphanumeric", func() {
+			cfg.IAM.NodeRoles = append(cfg.IAM.NodeRoles, &NodeRole{Name: "workers"})
+			Expect(ValidateNodeRoles(cfg)).ToNot(Succeed())
+
+			cfg.IAM.NodeRoles = []*NodeRole{{Name: "eks-workers"}}
+			Expect(ValidateNodeRoles(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject nodegroups with an undefined node role", func() {
+			ng := cfg.NewNodeGroup()
+			ng.IAM.NodeRole = "builders"
+			Expect(ValidateNodeRoles(cfg)).ToNot(Succeed())
+		})
+
+		It("should reject nodegroups with a node role and their own instance role", func() {
+			ng := cfg.NewNodeGroup()
+			ng.Name = "ng-1"
+			ng.IAM.NodeRole = "workers"
+			ng.IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/workers"
+			Expect(ValidateNodeGroup(0, ng)).ToNot(Succeed())
+		})
+	})
+
+	Describe("Nodegroup dependencies", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+			for _, name := range []string{"system", "ng-1", "ng-2"} {
+				ng := cfg.NewNodeGroup()
+				ng.Name = name
+			}
+		})
+
+		It("should accept dependencies on other nodegroups", func() {
+			cfg.NodeGroups[1].DependsOn = []string{"system"}
+			cfg.NodeGroups[2].DependsOn = []string{"system", "ng-1"}
+			Expect(ValidateNodeGroupDependencies(cfg)).To(Succeed())
+		})
+
+		It("should reject dependencies on undefined nodegroups and on themselves", func() {
+			cfg.NodeGroups[1].DependsOn = []string{"sytem"}
+			Expect(ValidateNodeGroupDependencies(cfg)).To(MatchError(`nodeGroups[1].dependsOn: nodegroup "sytem" is not defined`))
+
+			cfg.NodeGroups[1].DependsOn = []string{"ng-1"}
+			Expect(ValidateNodeGroupDependencies(cfg)).To(MatchError(`nodeGroups[1].dependsOn: nodegroup "ng-1" cannot depend on itself`))
+		})
+
+		It("should reject cycles", func() {
+			cfg.NodeGroups[0].DependsOn = []string{"ng-2"}
+			cfg.NodeGroups[1].DependsOn = []string{"system"}
+			cfg.NodeGroups[2].DependsOn = []string{"ng-1"}
+			Expect(ValidateNodeGroupDependencies(cfg)).To(MatchError("nodeGroups[0].dependsOn: nodegroups depend on each other in a cycle: system -> ng-2 -> ng-1 -> system"))
+		})
+	})
+
+	Describe("Proxy", func() {
+		var cfg *ClusterConfig
+
+		BeforeEach(func() {
+			cfg = NewClusterConfig()
+		})
+
+		It("should accept an empty configuration", func() {
+			Expect(ValidateProxy(cfg)).To(Succeed())
+		})
+
+		It("should require a proxy", func() {
+			cfg.Proxy = &ClusterProxy{NoProxy: []string{".example.com"}}
+			Expect(ValidateProxy(cfg)).ToNot(Succeed())
+		})
+
+		It("should only accept http or https proxies", func() {
+			for _, proxy := range []string{"http://proxy.example.com:3128", "https://10.0.0.10"} {
+				cfg.Proxy = &ClusterProxy{HTTPProxy: proxy, HTTPSProxy: proxy}
+				Expect(ValidateProxy(cfg)).To(Succeed())
+			}
+			for _, proxy := range []string{"proxy.example.com:3128", "socks5://proxy.example.com", "http://"} {
+				cfg.Proxy = &ClusterProxy{HTTPSProxy: proxy}
+				Expect(ValidateProxy(cfg)).ToNot(Succeed())
+			}
+		})
+	})
+
 })
 
 func checkItDetectsError(SSHConfig *NodeGroupSSH) {
@@ -228,3 +1310,8 @@ func newInt(value int) *int {
 	v := value
 	return &v
 }
+
+func newString(value string) *string {
+	v := value
+	return &v
+}
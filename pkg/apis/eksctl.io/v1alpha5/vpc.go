@@ -3,6 +3,8 @@ package v1alpha5
 import (
 	"fmt"
 	"net"
+	"sort"
+	"strings"
 
 	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 )
@@ -18,16 +20,36 @@ type (
 		// these are keyed by AZ for convenience
 		// +optional
 		Subnets *ClusterSubnets `json:"subnets,omitempty"`
+		// subnets in Local Zones, Wavelength Zones or Outposts, keyed by zone,
+		// these are only used by nodegroups that select them
+		// +optional
+		LocalZoneSubnets *ClusterSubnets `json:"localZoneSubnets,omitempty"`
 		// for additional CIDR associations, e.g. to use with separate CIDR for
 		// private subnets or any ad-hoc subnets
 		// +optional
 		ExtraCIDRs []*ipnet.IPNet `json:"extraCIDRs,omitempty"`
 		// for pre-defined shared node SG
 		SharedNodeSecurityGroup string `json:"sharedNodeSecurityGroup,omitempty"`
+		// add the rule that allows traffic between nodes to the pre-defined
+		// shared node SG, with the cluster stack, when the SG doesn't have it
+		// +optional
+		ManageSharedNodeSecurityGroupRules *bool `json:"manageSharedNodeSecurityGroupRules,omitempty"`
 		// +optional
 		AutoAllocateIPv6 *bool `json:"autoAllocateIPv6,omitempty"`
 		// +optional
 		NAT *ClusterNAT `json:"nat,omitempty"`
+		// access to the API server endpoint of the cluster,
+		// only public access is enabled when unset
+		// +optional
+		ClusterEndpoints *ClusterEndpoints `json:"clusterEndpoints,omitempty"`
+		// additional ingress rules of the control plane security group,
+		// e.g. to reach the API server from corporate networks
+		// +optional
+		ExtraControlPlaneIngressRules []*ControlPlaneIngressRule `json:"extraControlPlaneIngressRules,omitempty"`
+		// additional security groups attached to the control plane,
+		// alongside the control plane security group
+		// +optional
+		ExtraControlPlaneSecurityGroups []string `json:"extraControlPlaneSecurityGroups,omitempty"`
 	}
 	// ClusterSubnets holds private and public subnets
 	ClusterSubnets struct {
@@ -42,11 +64,46 @@ type (
 		ID string `json:"id,omitempty"`
 		// +optional
 		CIDR *ipnet.IPNet `json:"cidr,omitempty"`
+		// OwnerID is set to the account that owns the subnet,
+		// when it's shared from another account with AWS RAM
+		// +optional
+		OwnerID string `json:"ownerID,omitempty"`
+		// OutpostARN is set for subnets in an Outpost
+		// +optional
+		OutpostARN string `json:"outpostARN,omitempty"`
 	}
 	// ClusterNAT holds NAT gateway configuration options
 	ClusterNAT struct {
 		Gateway *string `json:"gateway,omitempty"`
 	}
+	// ClusterEndpoints holds the access settings of the API server endpoint
+	ClusterEndpoints struct {
+		// +optional
+		PrivateAccess *bool `json:"privateAccess,omitempty"`
+		// +optional
+		PublicAccess *bool `json:"publicAccess,omitempty"`
+	}
+	// ControlPlaneIngressRule holds an ingress rule of the control plane security group,
+	// traffic is allowed from exactly one of cidr, sourceSecurityGroupID or the
+	// security group shared by all nodes
+	ControlPlaneIngressRule struct {
+		// +optional
+		Description string `json:"description,omitempty"`
+		// tcp, udp or -1 for all protocols, defaults to tcp
+		// +optional
+		Protocol string `json:"protocol,omitempty"`
+		// +optional
+		FromPort int `json:"fromPort,omitempty"`
+		// defaults to fromPort
+		// +optional
+		ToPort int `json:"toPort,omitempty"`
+		// +optional
+		CIDR *ipnet.IPNet `json:"cidr,omitempty"`
+		// +optional
+		SourceSecurityGroupID string `json:"sourceSecurityGroupID,omitempty"`
+		// +optional
+		FromSharedNodeSecurityGroup *bool `json:"fromSharedNodeSecurityGroup,omitempty"`
+	}
 )
 
 const (
@@ -58,6 +115,13 @@ const (
 	SubnetTopologyPrivate SubnetTopology = "Private"
 	// SubnetTopologyPublic represents publicly-routed subnets
 	SubnetTopologyPublic SubnetTopology = "Public"
+
+	// ProtocolTCP is the default protocol of control plane ingress rules
+	ProtocolTCP = "tcp"
+	// ProtocolUDP allows UDP traffic in control plane ingress rules
+	ProtocolUDP = "udp"
+	// ProtocolAll allows traffic of all protocols and ports in control plane ingress rules
+	ProtocolAll = "-1"
 )
 
 // SubnetTopologies returns a list of topologies
@@ -100,23 +164,40 @@ func (c *ClusterConfig) PublicSubnetIDs() []string {
 	return subnets
 }
 
-// ImportSubnet loads a given subnet into cluster config
+// ImportSubnet loads a given subnet into cluster config, subnets in
+// Local Zones, Wavelength Zones and Outposts go into vpc.localZoneSubnets
 func (c *ClusterConfig) ImportSubnet(topology SubnetTopology, az, subnetID, cidr string) error {
+	if c.IsLocalZoneSubnet(topology, az, subnetID) {
+		return c.ImportLocalZoneSubnet(topology, az, subnetID, cidr)
+	}
+
 	if c.VPC.Subnets == nil {
 		c.VPC.Subnets = &ClusterSubnets{}
 	}
+	return importSubnetWithTopology(c.VPC.Subnets, topology, az, subnetID, cidr)
+}
+
+// ImportLocalZoneSubnet loads a given subnet in a Local Zone, Wavelength Zone
+// or Outpost into cluster config
+func (c *ClusterConfig) ImportLocalZoneSubnet(topology SubnetTopology, az, subnetID, cidr string) error {
+	if c.VPC.LocalZoneSubnets == nil {
+		c.VPC.LocalZoneSubnets = &ClusterSubnets{}
+	}
+	return importSubnetWithTopology(c.VPC.LocalZoneSubnets, topology, az, subnetID, cidr)
+}
 
+func importSubnetWithTopology(subnets *ClusterSubnets, topology SubnetTopology, az, subnetID, cidr string) error {
 	switch topology {
 	case SubnetTopologyPrivate:
-		if c.VPC.Subnets.Private == nil {
-			c.VPC.Subnets.Private = make(map[string]Network)
+		if subnets.Private == nil {
+			subnets.Private = make(map[string]Network)
 		}
-		return doImportSubnet(c.VPC.Subnets.Private, az, subnetID, cidr)
+		return doImportSubnet(subnets.Private, az, subnetID, cidr)
 	case SubnetTopologyPublic:
-		if c.VPC.Subnets.Public == nil {
-			c.VPC.Subnets.Public = make(map[string]Network)
+		if subnets.Public == nil {
+			subnets.Public = make(map[string]Network)
 		}
-		return doImportSubnet(c.VPC.Subnets.Public, az, subnetID, cidr)
+		return doImportSubnet(subnets.Public, az, subnetID, cidr)
 	default:
 		return fmt.Errorf("unexpected subnet topology: %s", topology)
 	}
@@ -143,6 +224,152 @@ func doImportSubnet(subnets map[string]Network, az, subnetID, cidr string) error
 	return nil
 }
 
+// SubnetsWithTopology returns the subnets of the given topology, keyed by AZ
+func (c *ClusterConfig) SubnetsWithTopology(topology SubnetTopology) map[string]Network {
+	if c.VPC.Subnets == nil {
+		return nil
+	}
+	switch topology {
+	case SubnetTopologyPrivate:
+		return c.VPC.Subnets.Private
+	case SubnetTopologyPublic:
+		return c.VPC.Subnets.Public
+	default:
+		return nil
+	}
+}
+
+// StorageSubnetIDs returns the subnets that file systems are mounted from, one per
+// AZ ordered by AZ, private subnets are preferred over public ones
+func (c *ClusterConfig) StorageSubnetIDs() []string {
+	subnets := c.SubnetsWithTopology(SubnetTopologyPrivate)
+	if len(subnets) == 0 {
+		subnets = c.SubnetsWithTopology(SubnetTopologyPublic)
+	}
+	azs := []string{}
+	for az := range subnets {
+		azs = append(azs, az)
+	}
+	sort.Strings(azs)
+
+	ids := []string{}
+	for _, az := range azs {
+		if id := subnets[az].ID; id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// LocalZoneSubnetsWithTopology returns the subnets in Local Zones, Wavelength
+// Zones and Outposts of the given topology, keyed by zone
+func (c *ClusterConfig) LocalZoneSubnetsWithTopology(topology SubnetTopology) map[string]Network {
+	if c.VPC.LocalZoneSubnets == nil {
+		return nil
+	}
+	switch topology {
+	case SubnetTopologyPrivate:
+		return c.VPC.LocalZoneSubnets.Private
+	case SubnetTopologyPublic:
+		return c.VPC.LocalZoneSubnets.Public
+	default:
+		return nil
+	}
+}
+
+// LocalZoneSubnetIDs returns the IDs of the subnets in Local Zones,
+// Wavelength Zones and Outposts of the given topology
+func (c *ClusterConfig) LocalZoneSubnetIDs(topology SubnetTopology) []string {
+	subnets := []string{}
+	for _, s := range c.LocalZoneSubnetsWithTopology(topology) {
+		if s.ID != "" {
+			subnets = append(subnets, s.ID)
+		}
+	}
+	return subnets
+}
+
+// IsLocalZoneSubnet checks if the subnet is in a Local Zone or a Wavelength Zone,
+// or is defined in vpc.localZoneSubnets, as subnets in Outposts are in regular zones
+func (c *ClusterConfig) IsLocalZoneSubnet(topology SubnetTopology, az, subnetID string) bool {
+	if IsLocalZone(c.Metadata.Region, az) {
+		return true
+	}
+	for _, s := range c.LocalZoneSubnetsWithTopology(topology) {
+		if s.ID != "" && s.ID == subnetID {
+			return true
+		}
+	}
+	return false
+}
+
+// SeparateLocalZoneSubnets moves subnets in Local Zones, Wavelength Zones and
+// Outposts given in vpc.subnets to vpc.localZoneSubnets, so that they aren't used
+// by the control plane; subnets in Outposts stay when the control plane runs there
+func (c *ClusterConfig) SeparateLocalZoneSubnets() {
+	for _, topology := range SubnetTopologies() {
+		for az, s := range c.SubnetsWithTopology(topology) {
+			if !IsLocalZone(c.Metadata.Region, az) && (s.OutpostARN == "" || c.Outpost != nil) {
+				continue
+			}
+			if c.VPC.LocalZoneSubnets == nil {
+				c.VPC.LocalZoneSubnets = &ClusterSubnets{}
+			}
+			localZoneSubnets := c.LocalZoneSubnetsWithTopology(topology)
+			if localZoneSubnets == nil {
+				localZoneSubnets = map[string]Network{}
+				if topology == SubnetTopologyPrivate {
+					c.VPC.LocalZoneSubnets.Private = localZoneSubnets
+				} else {
+					c.VPC.LocalZoneSubnets.Public = localZoneSubnets
+				}
+			}
+			localZoneSubnets[az] = s
+			delete(c.SubnetsWithTopology(topology), az)
+		}
+	}
+}
+
+// IsLocalZone checks if the zone is a Local Zone or a Wavelength Zone, their
+// names start with the region, e.g. us-west-2-lax-1a or us-east-1-wl1-bos-wlz-1
+func IsLocalZone(region, zone string) bool {
+	return region != "" && strings.HasPrefix(zone, region+"-")
+}
+
+// IsWavelengthZone checks if the zone is a Wavelength Zone, where subnets
+// reach the internet through a carrier gateway
+func IsWavelengthZone(zone string) bool {
+	return strings.Contains(zone, "-wlz-")
+}
+
+// UsesLocalZoneSubnets checks if the nodegroup is launched in subnets
+// of Local Zones, Wavelength Zones or an Outpost
+func UsesLocalZoneSubnets(ng *NodeGroup) bool {
+	return len(ng.LocalZones) > 0 || ng.OutpostARN != ""
+}
+
+// NodeGroupUsesLocalZoneSubnets checks if the nodegroup is launched in subnets of
+// vpc.localZoneSubnets, nodegroups on the Outpost of the control plane use vpc.subnets
+func (c *ClusterConfig) NodeGroupUsesLocalZoneSubnets(ng *NodeGroup) bool {
+	if c.Outpost != nil && ng.OutpostARN == c.Outpost.ControlPlaneOutpostARN {
+		return false
+	}
+	return UsesLocalZoneSubnets(ng)
+}
+
+// SharedSubnetIDs returns the IDs of subnets owned by another account
+func (c *ClusterConfig) SharedSubnetIDs() []string {
+	subnets := []string{}
+	for _, topology := range SubnetTopologies() {
+		for _, s := range c.SubnetsWithTopology(topology) {
+			if s.OwnerID != "" {
+				subnets = append(subnets, s.ID)
+			}
+		}
+	}
+	return subnets
+}
+
 // HasAnySubnets checks if any subnets were set
 func (c *ClusterConfig) HasAnySubnets() bool {
 	return c.VPC.Subnets != nil && len(c.VPC.Subnets.Private)+len(c.VPC.Subnets.Public) != 0
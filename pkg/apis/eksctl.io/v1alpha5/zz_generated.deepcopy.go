@@ -25,6 +25,123 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterAutoScaler) DeepCopyInto(out *ClusterAutoScaler) {
+	*out = *in
+	if in.Install != nil {
+		in, out := &in.Install, &out.Install
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterAutoScaler.
+func (in *ClusterAutoScaler) DeepCopy() *ClusterAutoScaler {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterAutoScaler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudFormation) DeepCopyInto(out *ClusterCloudFormation) {
+	*out = *in
+	if in.StackTags != nil {
+		in, out := &in.StackTags, &out.StackTags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DisableRollback != nil {
+		in, out := &in.DisableRollback, &out.DisableRollback
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudFormation.
+func (in *ClusterCloudFormation) DeepCopy() *ClusterCloudFormation {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudFormation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudWatch) DeepCopyInto(out *ClusterCloudWatch) {
+	*out = *in
+	if in.ClusterLogging != nil {
+		in, out := &in.ClusterLogging, &out.ClusterLogging
+		*out = new(ClusterCloudWatchLogging)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Alarms != nil {
+		in, out := &in.Alarms, &out.Alarms
+		*out = new(ClusterCloudWatchAlarms)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudWatch.
+func (in *ClusterCloudWatch) DeepCopy() *ClusterCloudWatch {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudWatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudWatchAlarms) DeepCopyInto(out *ClusterCloudWatchAlarms) {
+	*out = *in
+	if in.Create != nil {
+		in, out := &in.Create, &out.Create
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudWatchAlarms.
+func (in *ClusterCloudWatchAlarms) DeepCopy() *ClusterCloudWatchAlarms {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudWatchAlarms)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterCloudWatchLogging) DeepCopyInto(out *ClusterCloudWatchLogging) {
+	*out = *in
+	if in.EnableTypes != nil {
+		in, out := &in.EnableTypes, &out.EnableTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterCloudWatchLogging.
+func (in *ClusterCloudWatchLogging) DeepCopy() *ClusterCloudWatchLogging {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterCloudWatchLogging)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 	*out = *in
@@ -34,7 +151,11 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = new(ClusterMeta)
 		(*in).DeepCopyInto(*out)
 	}
-	out.IAM = in.IAM
+	if in.IAM != nil {
+		in, out := &in.IAM, &out.IAM
+		*out = new(ClusterIAM)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.VPC != nil {
 		in, out := &in.VPC, &out.VPC
 		*out = new(ClusterVPC)
@@ -56,6 +177,66 @@ func (in *ClusterConfig) DeepCopyInto(out *ClusterConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.LocalZones != nil {
+		in, out := &in.LocalZones, &out.LocalZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Outpost != nil {
+		in, out := &in.Outpost, &out.Outpost
+		*out = new(Outpost)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesNetworkConfig != nil {
+		in, out := &in.KubernetesNetworkConfig, &out.KubernetesNetworkConfig
+		*out = new(KubernetesNetworkConfig)
+		**out = **in
+	}
+	if in.VPCCNI != nil {
+		in, out := &in.VPCCNI, &out.VPCCNI
+		*out = new(VPCCNI)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AutoScaler != nil {
+		in, out := &in.AutoScaler, &out.AutoScaler
+		*out = new(ClusterAutoScaler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeTerminationHandler != nil {
+		in, out := &in.NodeTerminationHandler, &out.NodeTerminationHandler
+		*out = new(ClusterNodeTerminationHandler)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerRuntime != nil {
+		in, out := &in.ContainerRuntime, &out.ContainerRuntime
+		*out = new(ClusterContainerRuntime)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ClusterProxy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(ClusterStorage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudWatch != nil {
+		in, out := &in.CloudWatch, &out.CloudWatch
+		*out = new(ClusterCloudWatch)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudFormation != nil {
+		in, out := &in.CloudFormation, &out.CloudFormation
+		*out = new(ClusterCloudFormation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(ClusterHooks)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Status != nil {
 		in, out := &in.Status, &out.Status
 		*out = new(ClusterStatus)
@@ -115,9 +296,137 @@ func (in *ClusterConfigList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterContainerRuntime) DeepCopyInto(out *ClusterContainerRuntime) {
+	*out = *in
+	if in.RegistryMirror != nil {
+		in, out := &in.RegistryMirror, &out.RegistryMirror
+		*out = new(RegistryMirror)
+		**out = **in
+	}
+	if in.Offline != nil {
+		in, out := &in.Offline, &out.Offline
+		*out = new(OfflineConfig)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterContainerRuntime.
+func (in *ClusterContainerRuntime) DeepCopy() *ClusterContainerRuntime {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterContainerRuntime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterEndpoints) DeepCopyInto(out *ClusterEndpoints) {
+	*out = *in
+	if in.PrivateAccess != nil {
+		in, out := &in.PrivateAccess, &out.PrivateAccess
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PublicAccess != nil {
+		in, out := &in.PublicAccess, &out.PublicAccess
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterEndpoints.
+func (in *ClusterEndpoints) DeepCopy() *ClusterEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHook) DeepCopyInto(out *ClusterHook) {
+	*out = *in
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHook.
+func (in *ClusterHook) DeepCopy() *ClusterHook {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterHooks) DeepCopyInto(out *ClusterHooks) {
+	*out = *in
+	if in.PreCreate != nil {
+		in, out := &in.PreCreate, &out.PreCreate
+		*out = make([]ClusterHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostCreate != nil {
+		in, out := &in.PostCreate, &out.PostCreate
+		*out = make([]ClusterHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PreDelete != nil {
+		in, out := &in.PreDelete, &out.PreDelete
+		*out = make([]ClusterHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PostNodeGroupCreate != nil {
+		in, out := &in.PostNodeGroupCreate, &out.PostNodeGroupCreate
+		*out = make([]ClusterHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterHooks.
+func (in *ClusterHooks) DeepCopy() *ClusterHooks {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterHooks)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterIAM) DeepCopyInto(out *ClusterIAM) {
 	*out = *in
+	if in.NodeRoles != nil {
+		in, out := &in.NodeRoles, &out.NodeRoles
+		*out = make([]*NodeRole, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(NodeRole)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	return
 }
 
@@ -141,6 +450,11 @@ func (in *ClusterMeta) DeepCopyInto(out *ClusterMeta) {
 			(*out)[key] = val
 		}
 	}
+	if in.DeletionProtection != nil {
+		in, out := &in.DeletionProtection, &out.DeletionProtection
+		*out = new(bool)
+		**out = **in
+	}
 	return
 }
 
@@ -175,6 +489,48 @@ func (in *ClusterNAT) DeepCopy() *ClusterNAT {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterNodeTerminationHandler) DeepCopyInto(out *ClusterNodeTerminationHandler) {
+	*out = *in
+	if in.Install != nil {
+		in, out := &in.Install, &out.Install
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterNodeTerminationHandler.
+func (in *ClusterNodeTerminationHandler) DeepCopy() *ClusterNodeTerminationHandler {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterNodeTerminationHandler)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterProxy) DeepCopyInto(out *ClusterProxy) {
+	*out = *in
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterProxy.
+func (in *ClusterProxy) DeepCopy() *ClusterProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 	*out = *in
@@ -183,6 +539,15 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.CreatedAt != nil {
+		in, out := &in.CreatedAt, &out.CreatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.SecurityGroupIDs != nil {
+		in, out := &in.SecurityGroupIDs, &out.SecurityGroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -196,6 +561,32 @@ func (in *ClusterStatus) DeepCopy() *ClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStorage) DeepCopyInto(out *ClusterStorage) {
+	*out = *in
+	if in.EFS != nil {
+		in, out := &in.EFS, &out.EFS
+		*out = new(EFSFileSystem)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FSx != nil {
+		in, out := &in.FSx, &out.FSx
+		*out = new(FSxFileSystem)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStorage.
+func (in *ClusterStorage) DeepCopy() *ClusterStorage {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStorage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterSubnets) DeepCopyInto(out *ClusterSubnets) {
 	*out = *in
@@ -235,6 +626,11 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 		*out = new(ClusterSubnets)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.LocalZoneSubnets != nil {
+		in, out := &in.LocalZoneSubnets, &out.LocalZoneSubnets
+		*out = new(ClusterSubnets)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtraCIDRs != nil {
 		in, out := &in.ExtraCIDRs, &out.ExtraCIDRs
 		*out = make([]*ipnet.IPNet, len(*in))
@@ -245,6 +641,11 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 			}
 		}
 	}
+	if in.ManageSharedNodeSecurityGroupRules != nil {
+		in, out := &in.ManageSharedNodeSecurityGroupRules, &out.ManageSharedNodeSecurityGroupRules
+		*out = new(bool)
+		**out = **in
+	}
 	if in.AutoAllocateIPv6 != nil {
 		in, out := &in.AutoAllocateIPv6, &out.AutoAllocateIPv6
 		*out = new(bool)
@@ -255,6 +656,27 @@ func (in *ClusterVPC) DeepCopyInto(out *ClusterVPC) {
 		*out = new(ClusterNAT)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClusterEndpoints != nil {
+		in, out := &in.ClusterEndpoints, &out.ClusterEndpoints
+		*out = new(ClusterEndpoints)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraControlPlaneIngressRules != nil {
+		in, out := &in.ExtraControlPlaneIngressRules, &out.ExtraControlPlaneIngressRules
+		*out = make([]*ControlPlaneIngressRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ControlPlaneIngressRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ExtraControlPlaneSecurityGroups != nil {
+		in, out := &in.ExtraControlPlaneSecurityGroups, &out.ExtraControlPlaneSecurityGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -268,6 +690,84 @@ func (in *ClusterVPC) DeepCopy() *ClusterVPC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneIngressRule) DeepCopyInto(out *ControlPlaneIngressRule) {
+	*out = *in
+	if in.CIDR != nil {
+		in, out := &in.CIDR, &out.CIDR
+		*out = (*in).DeepCopy()
+	}
+	if in.FromSharedNodeSecurityGroup != nil {
+		in, out := &in.FromSharedNodeSecurityGroup, &out.FromSharedNodeSecurityGroup
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneIngressRule.
+func (in *ControlPlaneIngressRule) DeepCopy() *ControlPlaneIngressRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneIngressRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EFSFileSystem) DeepCopyInto(out *EFSFileSystem) {
+	*out = *in
+	if in.Encrypted != nil {
+		in, out := &in.Encrypted, &out.Encrypted
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EFSFileSystem.
+func (in *EFSFileSystem) DeepCopy() *EFSFileSystem {
+	if in == nil {
+		return nil
+	}
+	out := new(EFSFileSystem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FSxFileSystem) DeepCopyInto(out *FSxFileSystem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FSxFileSystem.
+func (in *FSxFileSystem) DeepCopy() *FSxFileSystem {
+	if in == nil {
+		return nil
+	}
+	out := new(FSxFileSystem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesNetworkConfig) DeepCopyInto(out *KubernetesNetworkConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesNetworkConfig.
+func (in *KubernetesNetworkConfig) DeepCopy() *KubernetesNetworkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesNetworkConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Network) DeepCopyInto(out *Network) {
 	*out = *in
@@ -291,6 +791,11 @@ func (in *Network) DeepCopy() *Network {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.InstancesDistribution != nil {
 		in, out := &in.InstancesDistribution, &out.InstancesDistribution
 		*out = new(NodeGroupInstancesDistribution)
@@ -301,6 +806,16 @@ func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LocalZones != nil {
+		in, out := &in.LocalZones, &out.LocalZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Tags != nil {
 		in, out := &in.Tags, &out.Tags
 		*out = make(map[string]string, len(*in))
@@ -308,6 +823,16 @@ func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 			(*out)[key] = val
 		}
 	}
+	if in.Placement != nil {
+		in, out := &in.Placement, &out.Placement
+		*out = new(NodeGroupPlacement)
+		**out = **in
+	}
+	if in.EFAEnabled != nil {
+		in, out := &in.EFAEnabled, &out.EFAEnabled
+		*out = new(bool)
+		**out = **in
+	}
 	if in.SecurityGroups != nil {
 		in, out := &in.SecurityGroups, &out.SecurityGroups
 		*out = new(NodeGroupSGs)
@@ -358,6 +883,22 @@ func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.VolumeThroughput != nil {
+		in, out := &in.VolumeThroughput, &out.VolumeThroughput
+		*out = new(int)
+		**out = **in
+	}
+	if in.AdditionalVolumes != nil {
+		in, out := &in.AdditionalVolumes, &out.AdditionalVolumes
+		*out = make([]*VolumeMapping, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(VolumeMapping)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.Labels != nil {
 		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
@@ -372,11 +913,37 @@ func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 			(*out)[key] = val
 		}
 	}
+	if in.PropagateASGTags != nil {
+		in, out := &in.PropagateASGTags, &out.PropagateASGTags
+		*out = new(bool)
+		**out = **in
+	}
 	if in.TargetGroupARNs != nil {
 		in, out := &in.TargetGroupARNs, &out.TargetGroupARNs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ASGLifecycleHooks != nil {
+		in, out := &in.ASGLifecycleHooks, &out.ASGLifecycleHooks
+		*out = make([]*NodeGroupLifecycleHook, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(NodeGroupLifecycleHook)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.WarmPool != nil {
+		in, out := &in.WarmPool, &out.WarmPool
+		*out = new(NodeGroupWarmPool)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateConfig != nil {
+		in, out := &in.UpdateConfig, &out.UpdateConfig
+		*out = new(NodeGroupUpdateConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.SSH != nil {
 		in, out := &in.SSH, &out.SSH
 		*out = new(NodeGroupSSH)
@@ -401,6 +968,16 @@ func (in *NodeGroup) DeepCopyInto(out *NodeGroup) {
 		in, out := &in.KubeletExtraConfig, &out.KubeletExtraConfig
 		*out = (*in).DeepCopy()
 	}
+	if in.ContainerRuntimeConfig != nil {
+		in, out := &in.ContainerRuntimeConfig, &out.ContainerRuntimeConfig
+		*out = new(NodeGroupContainerRuntimeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderOverride != nil {
+		in, out := &in.ProviderOverride, &out.ProviderOverride
+		*out = new(NodeGroupProviderOverride)
+		**out = **in
+	}
 	return
 }
 
@@ -414,6 +991,27 @@ func (in *NodeGroup) DeepCopy() *NodeGroup {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupContainerRuntimeConfig) DeepCopyInto(out *NodeGroupContainerRuntimeConfig) {
+	*out = *in
+	if in.InsecureRegistries != nil {
+		in, out := &in.InsecureRegistries, &out.InsecureRegistries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupContainerRuntimeConfig.
+func (in *NodeGroupContainerRuntimeConfig) DeepCopy() *NodeGroupContainerRuntimeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupContainerRuntimeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupIAM) DeepCopyInto(out *NodeGroupIAM) {
 	*out = *in
@@ -422,6 +1020,10 @@ func (in *NodeGroupIAM) DeepCopyInto(out *NodeGroupIAM) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AttachPolicy != nil {
+		in, out := &in.AttachPolicy, &out.AttachPolicy
+		*out = (*in).DeepCopy()
+	}
 	in.WithAddonPolicies.DeepCopyInto(&out.WithAddonPolicies)
 	return
 }
@@ -558,6 +1160,59 @@ func (in NodeGroupKubeletConfig) DeepCopyInto(out *NodeGroupKubeletConfig) {
 	}
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupLifecycleHook) DeepCopyInto(out *NodeGroupLifecycleHook) {
+	*out = *in
+	if in.HeartbeatTimeout != nil {
+		in, out := &in.HeartbeatTimeout, &out.HeartbeatTimeout
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupLifecycleHook.
+func (in *NodeGroupLifecycleHook) DeepCopy() *NodeGroupLifecycleHook {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupLifecycleHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupPlacement) DeepCopyInto(out *NodeGroupPlacement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupPlacement.
+func (in *NodeGroupPlacement) DeepCopy() *NodeGroupPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupProviderOverride) DeepCopyInto(out *NodeGroupProviderOverride) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupProviderOverride.
+func (in *NodeGroupProviderOverride) DeepCopy() *NodeGroupProviderOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupProviderOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeGroupSGs) DeepCopyInto(out *NodeGroupSGs) {
 	*out = *in
@@ -625,9 +1280,147 @@ func (in *NodeGroupSSH) DeepCopy() *NodeGroupSSH {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupUpdateConfig) DeepCopyInto(out *NodeGroupUpdateConfig) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int)
+		**out = **in
+	}
+	if in.MinHealthyPercentage != nil {
+		in, out := &in.MinHealthyPercentage, &out.MinHealthyPercentage
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupUpdateConfig.
+func (in *NodeGroupUpdateConfig) DeepCopy() *NodeGroupUpdateConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupUpdateConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeGroupWarmPool) DeepCopyInto(out *NodeGroupWarmPool) {
+	*out = *in
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxPrepared != nil {
+		in, out := &in.MaxPrepared, &out.MaxPrepared
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeGroupWarmPool.
+func (in *NodeGroupWarmPool) DeepCopy() *NodeGroupWarmPool {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeGroupWarmPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeRole) DeepCopyInto(out *NodeRole) {
+	*out = *in
+	if in.AttachPolicyARNs != nil {
+		in, out := &in.AttachPolicyARNs, &out.AttachPolicyARNs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AttachPolicy != nil {
+		in, out := &in.AttachPolicy, &out.AttachPolicy
+		*out = (*in).DeepCopy()
+	}
+	in.WithAddonPolicies.DeepCopyInto(&out.WithAddonPolicies)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeRole.
+func (in *NodeRole) DeepCopy() *NodeRole {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OfflineConfig) DeepCopyInto(out *OfflineConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OfflineConfig.
+func (in *OfflineConfig) DeepCopy() *OfflineConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(OfflineConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Outpost) DeepCopyInto(out *Outpost) {
+	*out = *in
+	if in.ControlPlanePlacement != nil {
+		in, out := &in.ControlPlanePlacement, &out.ControlPlanePlacement
+		*out = new(OutpostPlacement)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Outpost.
+func (in *Outpost) DeepCopy() *Outpost {
+	if in == nil {
+		return nil
+	}
+	out := new(Outpost)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutpostPlacement) DeepCopyInto(out *OutpostPlacement) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutpostPlacement.
+func (in *OutpostPlacement) DeepCopy() *OutpostPlacement {
+	if in == nil {
+		return nil
+	}
+	out := new(OutpostPlacement)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	return
 }
 
@@ -640,3 +1433,101 @@ func (in *ProviderConfig) DeepCopy() *ProviderConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryMirror) DeepCopyInto(out *RegistryMirror) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryMirror.
+func (in *RegistryMirror) DeepCopy() *RegistryMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VPCCNI) DeepCopyInto(out *VPCCNI) {
+	*out = *in
+	if in.PrefixDelegation != nil {
+		in, out := &in.PrefixDelegation, &out.PrefixDelegation
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WarmPrefixTarget != nil {
+		in, out := &in.WarmPrefixTarget, &out.WarmPrefixTarget
+		*out = new(int)
+		**out = **in
+	}
+	if in.WarmIPTarget != nil {
+		in, out := &in.WarmIPTarget, &out.WarmIPTarget
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VPCCNI.
+func (in *VPCCNI) DeepCopy() *VPCCNI {
+	if in == nil {
+		return nil
+	}
+	out := new(VPCCNI)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMapping) DeepCopyInto(out *VolumeMapping) {
+	*out = *in
+	if in.VolumeName != nil {
+		in, out := &in.VolumeName, &out.VolumeName
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeSize != nil {
+		in, out := &in.VolumeSize, &out.VolumeSize
+		*out = new(int)
+		**out = **in
+	}
+	if in.VolumeType != nil {
+		in, out := &in.VolumeType, &out.VolumeType
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeEncrypted != nil {
+		in, out := &in.VolumeEncrypted, &out.VolumeEncrypted
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolumeKmsKeyID != nil {
+		in, out := &in.VolumeKmsKeyID, &out.VolumeKmsKeyID
+		*out = new(string)
+		**out = **in
+	}
+	if in.VolumeIOPS != nil {
+		in, out := &in.VolumeIOPS, &out.VolumeIOPS
+		*out = new(int)
+		**out = **in
+	}
+	if in.VolumeThroughput != nil {
+		in, out := &in.VolumeThroughput, &out.VolumeThroughput
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMapping.
+func (in *VolumeMapping) DeepCopy() *VolumeMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMapping)
+	in.DeepCopyInto(out)
+	return out
+}
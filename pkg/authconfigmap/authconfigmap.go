@@ -249,6 +249,19 @@ func AddNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 	if err != nil {
 		return err
 	}
+	if ng.IAM.NodeRole != "" {
+		// the role is shared with other nodegroups, so it's only added once
+		roles, err := acm.Roles()
+		if err != nil {
+			return err
+		}
+		for _, role := range roles {
+			if role.RoleARN == ng.IAM.InstanceRoleARN {
+				logger.Debug("node role %q of %q is already in auth ConfigMap", ng.IAM.NodeRole, ng.Name)
+				return nil
+			}
+		}
+	}
 	if err := acm.AddRole(ng.IAM.InstanceRoleARN, RoleNodeGroupUsername, RoleNodeGroupGroups); err != nil {
 		return errors.Wrap(err, "adding nodegroup to auth ConfigMap")
 	}
@@ -266,6 +279,10 @@ func RemoveNodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
 	if arn == "" {
 		return errors.New("nodegroup instance role ARN is not set")
 	}
+	if ng.IAM.NodeRole != "" {
+		logger.Info("keeping node role %q of nodegroup %q in auth ConfigMap, as other nodegroups may share it", ng.IAM.NodeRole, ng.Name)
+		return nil
+	}
 	acm, err := NewFromClientSet(clientSet)
 	if err != nil {
 		return err
@@ -0,0 +1,129 @@
+package az
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
+	"github.com/pkg/errors"
+)
+
+// OfferingsAPI is the part of the EC2 API that lists the instance types offered
+// in each zone, the EC2 client of the AWS SDK in use doesn't provide it yet
+type OfferingsAPI interface {
+	DescribeInstanceTypeOfferings(*DescribeInstanceTypeOfferingsInput) (*DescribeInstanceTypeOfferingsOutput, error)
+}
+
+// DescribeInstanceTypeOfferingsInput is the input of the DescribeInstanceTypeOfferings operation
+type DescribeInstanceTypeOfferingsInput struct {
+	_ struct{} `type:"structure"`
+
+	Filters      []*ec2.Filter `locationName:"Filter" locationNameList:"Filter" type:"list"`
+	LocationType *string       `type:"string"`
+	NextToken    *string       `type:"string"`
+}
+
+// DescribeInstanceTypeOfferingsOutput is the output of the DescribeInstanceTypeOfferings operation
+type DescribeInstanceTypeOfferingsOutput struct {
+	_ struct{} `type:"structure"`
+
+	InstanceTypeOfferings []*InstanceTypeOffering `locationName:"instanceTypeOfferingSet" locationNameList:"item" type:"list"`
+	NextToken             *string                 `locationName:"nextToken" type:"string"`
+}
+
+// InstanceTypeOffering is an instance type offered in a zone
+type InstanceTypeOffering struct {
+	_ struct{} `type:"structure"`
+
+	InstanceType *string `locationName:"instanceType" type:"string"`
+	Location     *string `locationName:"location" type:"string"`
+}
+
+type ec2OfferingsAPI struct {
+	ec2 *ec2.EC2
+}
+
+// NewOfferingsAPI makes the offerings calls with the protocol handlers of the EC2 client
+func NewOfferingsAPI(ec2API ec2iface.EC2API) (OfferingsAPI, error) {
+	client, ok := ec2API.(*ec2.EC2)
+	if !ok {
+		return nil, fmt.Errorf("listing instance type offerings requires the EC2 client of the AWS SDK, got %T", ec2API)
+	}
+	return &ec2OfferingsAPI{ec2: client}, nil
+}
+
+// DescribeInstanceTypeOfferings lists the instance types offered in each location
+func (c *ec2OfferingsAPI) DescribeInstanceTypeOfferings(input *DescribeInstanceTypeOfferingsInput) (*DescribeInstanceTypeOfferingsOutput, error) {
+	op := &request.Operation{
+		Name:       "DescribeInstanceTypeOfferings",
+		HTTPMethod: "POST",
+		HTTPPath:   "/",
+	}
+	output := &DescribeInstanceTypeOfferingsOutput{}
+	return output, c.ec2.NewRequest(op, input, output).Send()
+}
+
+// ValidateInstanceTypeOfferings checks that the instance types are offered in all the
+// given zones, Local Zones and Wavelength Zones only offer a few instance types
+func ValidateInstanceTypeOfferings(offerings OfferingsAPI, zones, instanceTypes []string) error {
+	offered, err := listOfferings(offerings, "availability-zone", zones, instanceTypes)
+	if err != nil {
+		return err
+	}
+
+	missing := []string{}
+	for _, zone := range zones {
+		for _, instanceType := range instanceTypes {
+			if !offered[zone][instanceType] {
+				missing = append(missing, fmt.Sprintf("%s in %s", instanceType, zone))
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("instance types are not offered: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// IsInstanceTypeOfferedInRegion returns true when the instance type is offered in the region
+func IsInstanceTypeOfferedInRegion(offerings OfferingsAPI, region, instanceType string) (bool, error) {
+	offered, err := listOfferings(offerings, "region", []string{region}, []string{instanceType})
+	if err != nil {
+		return false, err
+	}
+	return offered[region][instanceType], nil
+}
+
+// listOfferings returns the instance types offered in each location
+func listOfferings(offerings OfferingsAPI, locationType string, locations, instanceTypes []string) (map[string]map[string]bool, error) {
+	offered := map[string]map[string]bool{}
+	input := &DescribeInstanceTypeOfferingsInput{
+		LocationType: aws.String(locationType),
+		Filters: []*ec2.Filter{
+			{Name: aws.String("location"), Values: aws.StringSlice(locations)},
+			{Name: aws.String("instance-type"), Values: aws.StringSlice(instanceTypes)},
+		},
+	}
+	for {
+		output, err := offerings.DescribeInstanceTypeOfferings(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "describing instance types offered in %s", strings.Join(locations, ", "))
+		}
+		for _, o := range output.InstanceTypeOfferings {
+			location := aws.StringValue(o.Location)
+			if offered[location] == nil {
+				offered[location] = map[string]bool{}
+			}
+			offered[location][aws.StringValue(o.InstanceType)] = true
+		}
+		if output.NextToken == nil {
+			return offered, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
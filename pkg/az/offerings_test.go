@@ -0,0 +1,66 @@
+package az_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/az"
+)
+
+type fakeOfferingsAPI struct {
+	pages []*DescribeInstanceTypeOfferingsOutput
+	calls int
+}
+
+func (f *fakeOfferingsAPI) DescribeInstanceTypeOfferings(input *DescribeInstanceTypeOfferingsInput) (*DescribeInstanceTypeOfferingsOutput, error) {
+	page := f.pages[f.calls]
+	f.calls++
+	return page, nil
+}
+
+func offering(instanceType, zone string) *InstanceTypeOffering {
+	return &InstanceTypeOffering{InstanceType: aws.String(instanceType), Location: aws.String(zone)}
+}
+
+var _ = Describe("Instance type offerings", func() {
+	It("accepts instance types offered in all zones", func() {
+		offerings := &fakeOfferingsAPI{
+			pages: []*DescribeInstanceTypeOfferingsOutput{
+				{
+					InstanceTypeOfferings: []*InstanceTypeOffering{offering("t3.medium", "us-west-2-lax-1a")},
+					NextToken:             aws.String("next"),
+				},
+				{
+					InstanceTypeOfferings: []*InstanceTypeOffering{offering("t3.medium", "us-west-2-lax-1b")},
+				},
+			},
+		}
+		Expect(ValidateInstanceTypeOfferings(offerings, []string{"us-west-2-lax-1a", "us-west-2-lax-1b"}, []string{"t3.medium"})).To(Succeed())
+		Expect(offerings.calls).To(Equal(2))
+	})
+
+	It("rejects instance types missing in a zone", func() {
+		offerings := &fakeOfferingsAPI{
+			pages: []*DescribeInstanceTypeOfferingsOutput{
+				{
+					InstanceTypeOfferings: []*InstanceTypeOffering{offering("t3.medium", "us-west-2-lax-1a")},
+				},
+			},
+		}
+		err := ValidateInstanceTypeOfferings(offerings, []string{"us-west-2-lax-1a"}, []string{"t3.medium", "m5.large"})
+		Expect(err).To(MatchError("instance types are not offered: m5.large in us-west-2-lax-1a"))
+	})
+	It("checks instance types offered in a region", func() {
+		offerings := &fakeOfferingsAPI{
+			pages: []*DescribeInstanceTypeOfferingsOutput{
+				{
+					InstanceTypeOfferings: []*InstanceTypeOffering{offering("m5.large", "us-west-2")},
+				},
+				{},
+			},
+		}
+		Expect(IsInstanceTypeOfferedInRegion(offerings, "us-west-2", "m5.large")).To(BeTrue())
+		Expect(IsInstanceTypeOfferedInRegion(offerings, "us-west-2", "p4d.24xlarge")).To(BeFalse())
+	})
+})
@@ -0,0 +1,136 @@
+// Package backup saves snapshots of the definition of clusters managed by eksctl
+// to S3, and restores clusters from them.
+//
+// A snapshot is stored under a prefix of its own, named after its creation time:
+//
+//	<prefix>/<region>/<cluster>/<20060102T150405Z>/snapshot.json
+//	<prefix>/<region>/<cluster>/<20060102T150405Z>/cluster.yaml
+//	<prefix>/<region>/<cluster>/<20060102T150405Z>/aws-auth.yaml
+//	<prefix>/<region>/<cluster>/<20060102T150405Z>/stacks/<stack name>.json
+package backup
+
+import (
+	"fmt"
+	"time"
+
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/version"
+)
+
+// IDFormat is the layout of the creation time that identifies a snapshot, it sorts chronologically
+const IDFormat = "20060102T150405Z"
+
+// Snapshot is a copy of the definition of a cluster
+type Snapshot struct {
+	Metadata SnapshotMetadata
+	// ClusterConfig is reconstructed from the cluster, it's informative and
+	// not used for restoring, as the stacks hold all resources
+	ClusterConfig *api.ClusterConfig
+	Stacks        []*manager.StackSnapshot
+	// AuthConfigMap is nil when the cluster has no aws-auth ConfigMap
+	AuthConfigMap *corev1.ConfigMap
+}
+
+// SnapshotMetadata describes a snapshot
+type SnapshotMetadata struct {
+	ID            string                   `json:"id"`
+	Cluster       string                   `json:"cluster"`
+	Region        string                   `json:"region"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	EksctlVersion string                   `json:"eksctlVersion"`
+	Stacks        []*manager.StackSnapshot `json:"stacks"`
+}
+
+// NewSnapshot creates a snapshot of the cluster
+func NewSnapshot(cfg *api.ClusterConfig, stacks []*manager.StackSnapshot, authConfigMap *corev1.ConfigMap) *Snapshot {
+	createdAt := time.Now().UTC()
+	return &Snapshot{
+		Metadata: SnapshotMetadata{
+			ID:            createdAt.Format(IDFormat),
+			Cluster:       cfg.Metadata.Name,
+			Region:        cfg.Metadata.Region,
+			CreatedAt:     createdAt,
+			EksctlVersion: version.String(),
+			Stacks:        stacks,
+		},
+		ClusterConfig: cfg,
+		Stacks:        stacks,
+		AuthConfigMap: authConfigMap,
+	}
+}
+
+// ReconstructClusterConfig fills in the version of the cluster and its nodegroups, as far as
+// they can be known from the control plane and the nodegroup stacks; the VPC is expected to
+// be loaded already
+func ReconstructClusterConfig(cfg *api.ClusterConfig, cluster *awseks.Cluster, nodeGroups []*manager.NodeGroupSummary) *api.ClusterConfig {
+	reconstructed := cfg.DeepCopy()
+	reconstructed.Status = nil
+	if cluster != nil && cluster.Version != nil {
+		reconstructed.Metadata.Version = *cluster.Version
+	}
+
+	reconstructed.NodeGroups = nil
+	for _, summary := range nodeGroups {
+		ng := &api.NodeGroup{
+			Name:         summary.Name,
+			InstanceType: summary.InstanceType,
+			AMI:          summary.ImageID,
+			MinSize:      intPtr(summary.MinSize),
+			MaxSize:      intPtr(summary.MaxSize),
+		}
+		if summary.DesiredCapacity > 0 {
+			ng.DesiredCapacity = intPtr(summary.DesiredCapacity)
+		}
+		reconstructed.NodeGroups = append(reconstructed.NodeGroups, ng)
+	}
+	return reconstructed
+}
+
+// GetAuthConfigMap returns the aws-auth ConfigMap of the cluster, or nil when there is none
+func GetAuthConfigMap(clientSet kubernetes.Interface) (*corev1.ConfigMap, error) {
+	cm, err := clientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace).Get(authconfigmap.ObjectName, metav1.GetOptions{})
+	if kerr.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "getting auth ConfigMap")
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: authconfigmap.ObjectMeta(),
+		Data:       cm.Data,
+	}, nil
+}
+
+// RestoreAuthConfigMap replaces the data of the aws-auth ConfigMap of the cluster with the one of the snapshot
+func RestoreAuthConfigMap(clientSet kubernetes.Interface, snapshot *corev1.ConfigMap) error {
+	client := clientSet.CoreV1().ConfigMaps(authconfigmap.ObjectNamespace)
+	cm, err := client.Get(authconfigmap.ObjectName, metav1.GetOptions{})
+	switch {
+	case kerr.IsNotFound(err):
+		cm = &corev1.ConfigMap{
+			ObjectMeta: authconfigmap.ObjectMeta(),
+			Data:       snapshot.Data,
+		}
+		_, err = client.Create(cm)
+	case err == nil:
+		cm.Data = snapshot.Data
+		_, err = client.Update(cm)
+	}
+	if err != nil {
+		return errors.Wrap(err, "restoring auth ConfigMap")
+	}
+	logger.Info("restored auth ConfigMap %q", fmt.Sprintf("%s/%s", authconfigmap.ObjectNamespace, authconfigmap.ObjectName))
+	return nil
+}
+
+func intPtr(i int) *int { return &i }
@@ -0,0 +1,220 @@
+package backup
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// DefaultPrefix is the prefix snapshots are stored under by default
+	DefaultPrefix = "eksctl-backups"
+
+	metadataObject      = "snapshot.json"
+	clusterConfigObject = "cluster.yaml"
+	authConfigMapObject = "aws-auth.yaml"
+	stacksPrefix        = "stacks"
+)
+
+// Store saves snapshots to an S3 bucket, and loads them back
+type Store struct {
+	s3     s3iface.S3API
+	bucket string
+	prefix string
+}
+
+// NewStore creates a new Store
+func NewStore(s3API s3iface.S3API, bucket, prefix string) *Store {
+	return &Store{
+		s3:     s3API,
+		bucket: bucket,
+		prefix: strings.Trim(prefix, "/"),
+	}
+}
+
+func (s *Store) clusterPrefix(region, cluster string) string {
+	return path.Join(s.prefix, region, cluster) + "/"
+}
+
+func (s *Store) snapshotKey(region, cluster, id string, elem ...string) string {
+	return path.Join(append([]string{s.prefix, region, cluster, id}, elem...)...)
+}
+
+// URL returns where the snapshot is stored
+func (s *Store) URL(meta SnapshotMetadata) string {
+	return fmt.Sprintf("s3://%s/%s/", s.bucket, s.snapshotKey(meta.Region, meta.Cluster, meta.ID))
+}
+
+// Save writes all objects of the snapshot, the metadata last, so
+// that snapshots without metadata are known to be incomplete
+func (s *Store) Save(snapshot *Snapshot) error {
+	meta := snapshot.Metadata
+	key := func(elem ...string) string {
+		return s.snapshotKey(meta.Region, meta.Cluster, meta.ID, elem...)
+	}
+
+	for _, stack := range snapshot.Stacks {
+		if err := s.put(key(stacksPrefix, stack.Name+".json"), []byte(stack.Template)); err != nil {
+			return err
+		}
+	}
+
+	clusterConfig, err := yaml.Marshal(snapshot.ClusterConfig)
+	if err != nil {
+		return errors.Wrap(err, "serializing cluster config")
+	}
+	if err := s.put(key(clusterConfigObject), clusterConfig); err != nil {
+		return err
+	}
+
+	if snapshot.AuthConfigMap != nil {
+		authConfigMap, err := yaml.Marshal(snapshot.AuthConfigMap)
+		if err != nil {
+			return errors.Wrap(err, "serializing auth ConfigMap")
+		}
+		if err := s.put(key(authConfigMapObject), authConfigMap); err != nil {
+			return err
+		}
+	}
+
+	metadata, err := json.MarshalIndent(meta, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "serializing snapshot metadata")
+	}
+	return s.put(key(metadataObject), metadata)
+}
+
+// List returns the IDs of the snapshots of the cluster, oldest first
+func (s *Store) List(region, cluster string) ([]string, error) {
+	prefix := s.clusterPrefix(region, cluster)
+	input := &s3.ListObjectsV2Input{
+		Bucket:    aws.String(s.bucket),
+		Prefix:    aws.String(prefix),
+		Delimiter: aws.String("/"),
+	}
+
+	ids := []string{}
+	for {
+		output, err := s.s3.ListObjectsV2(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "listing snapshots in s3://%s/%s", s.bucket, prefix)
+		}
+		for _, p := range output.CommonPrefixes {
+			ids = append(ids, strings.TrimSuffix(strings.TrimPrefix(aws.StringValue(p.Prefix), prefix), "/"))
+		}
+		if !aws.BoolValue(output.IsTruncated) {
+			break
+		}
+		input.ContinuationToken = output.NextContinuationToken
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Load reads a snapshot of the cluster, the latest one when id is empty
+func (s *Store) Load(region, cluster, id string) (*Snapshot, error) {
+	if id == "" {
+		ids, err := s.List(region, cluster)
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("no snapshots of cluster %q found in s3://%s/%s", cluster, s.bucket, s.clusterPrefix(region, cluster))
+		}
+		id = ids[len(ids)-1]
+	}
+	key := func(elem ...string) string {
+		return s.snapshotKey(region, cluster, id, elem...)
+	}
+
+	data, err := s.get(key(metadataObject))
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, fmt.Errorf("snapshot %q of cluster %q is incomplete or doesn't exist", id, cluster)
+		}
+		return nil, err
+	}
+	snapshot := &Snapshot{}
+	if err := json.Unmarshal(data, &snapshot.Metadata); err != nil {
+		return nil, errors.Wrapf(err, "loading metadata of snapshot %q", id)
+	}
+	snapshot.Stacks = snapshot.Metadata.Stacks
+
+	for _, stack := range snapshot.Stacks {
+		template, err := s.get(key(stacksPrefix, stack.Name+".json"))
+		if err != nil {
+			return nil, err
+		}
+		stack.Template = string(template)
+	}
+
+	data, err = s.get(key(clusterConfigObject))
+	if err != nil {
+		return nil, err
+	}
+	snapshot.ClusterConfig = &api.ClusterConfig{}
+	if err := yaml.Unmarshal(data, snapshot.ClusterConfig); err != nil {
+		return nil, errors.Wrapf(err, "loading cluster config of snapshot %q", id)
+	}
+
+	data, err = s.get(key(authConfigMapObject))
+	switch {
+	case isNoSuchKey(err):
+		break
+	case err != nil:
+		return nil, err
+	default:
+		snapshot.AuthConfigMap = &corev1.ConfigMap{}
+		if err := yaml.Unmarshal(data, snapshot.AuthConfigMap); err != nil {
+			return nil, errors.Wrapf(err, "loading auth ConfigMap of snapshot %q", id)
+		}
+	}
+	return snapshot, nil
+}
+
+func (s *Store) put(key string, body []byte) error {
+	_, err := s.s3.PutObject(&s3.PutObjectInput{
+		Bucket:               aws.String(s.bucket),
+		Key:                  aws.String(key),
+		Body:                 bytes.NewReader(body),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAes256),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "writing s3://%s/%s", s.bucket, key)
+	}
+	return nil
+}
+
+func (s *Store) get(key string) ([]byte, error) {
+	output, err := s.s3.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		if isNoSuchKey(err) {
+			return nil, err
+		}
+		return nil, errors.Wrapf(err, "reading s3://%s/%s", s.bucket, key)
+	}
+	defer output.Body.Close()
+	return ioutil.ReadAll(output.Body)
+}
+
+func isNoSuchKey(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == s3.ErrCodeNoSuchKey
+}
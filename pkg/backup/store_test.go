@@ -0,0 +1,173 @@
+package backup_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	. "github.com/weaveworks/eksctl/pkg/backup"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+// mockBucket keeps the objects written through the mocked S3 API in memory
+func mockBucket(p *mockprovider.MockProvider) map[string][]byte {
+	objects := map[string][]byte{}
+
+	p.MockS3().On("PutObject", mock.Anything).Return(func(input *s3.PutObjectInput) *s3.PutObjectOutput {
+		data, _ := ioutil.ReadAll(input.Body)
+		objects[*input.Key] = data
+		return &s3.PutObjectOutput{}
+	}, nil)
+
+	p.MockS3().On("GetObject", mock.Anything).Return(func(input *s3.GetObjectInput) *s3.GetObjectOutput {
+		data, ok := objects[*input.Key]
+		if !ok {
+			return nil
+		}
+		return &s3.GetObjectOutput{Body: ioutil.NopCloser(bytes.NewReader(data))}
+	}, func(input *s3.GetObjectInput) error {
+		if _, ok := objects[*input.Key]; !ok {
+			return awserr.New(s3.ErrCodeNoSuchKey, "not found", nil)
+		}
+		return nil
+	})
+
+	p.MockS3().On("ListObjectsV2", mock.Anything).Return(func(input *s3.ListObjectsV2Input) *s3.ListObjectsV2Output {
+		prefixes := map[string]bool{}
+		for key := range objects {
+			if rest := strings.TrimPrefix(key, *input.Prefix); rest != key && strings.Contains(rest, "/") {
+				prefixes[*input.Prefix+rest[:strings.Index(rest, "/")+1]] = true
+			}
+		}
+		output := &s3.ListObjectsV2Output{IsTruncated: aws.Bool(false)}
+		sorted := []string{}
+		for prefix := range prefixes {
+			sorted = append(sorted, prefix)
+		}
+		sort.Strings(sorted)
+		for _, prefix := range sorted {
+			output.CommonPrefixes = append(output.CommonPrefixes, &s3.CommonPrefix{Prefix: aws.String(prefix)})
+		}
+		return output
+	}, nil)
+
+	return objects
+}
+
+var _ = Describe("Store", func() {
+	var (
+		p       *mockprovider.MockProvider
+		objects map[string][]byte
+		store   *Store
+		cfg     *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		objects = mockBucket(p)
+		store = NewStore(p.S3(), "backups", "/eksctl/")
+
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "us-west-2"
+	})
+
+	newSnapshot := func(id string) *Snapshot {
+		stacks := []*manager.StackSnapshot{
+			{
+				Name:         "eksctl-test-cluster-cluster",
+				Template:     `{"Resources":{}}`,
+				Tags:         map[string]string{api.ClusterNameTag: "test-cluster"},
+				Capabilities: []string{"CAPABILITY_IAM"},
+			},
+			{
+				Name:     "eksctl-test-cluster-nodegroup-ng-1",
+				Template: `{"Resources":{"NodeGroup":{}}}`,
+			},
+		}
+		authConfigMap := &corev1.ConfigMap{
+			ObjectMeta: authconfigmap.ObjectMeta(),
+			Data:       map[string]string{"mapRoles": "- rolearn: arn:aws:iam::123456789012:role/admin\n"},
+		}
+		snapshot := NewSnapshot(cfg, stacks, authConfigMap)
+		snapshot.Metadata.ID = id
+		return snapshot
+	}
+
+	It("saves all objects of a snapshot under its own prefix", func() {
+		Expect(store.Save(newSnapshot("20190601T100000Z"))).To(Succeed())
+
+		keys := []string{}
+		for key := range objects {
+			keys = append(keys, key)
+		}
+		Expect(keys).To(ConsistOf(
+			"eksctl/us-west-2/test-cluster/20190601T100000Z/snapshot.json",
+			"eksctl/us-west-2/test-cluster/20190601T100000Z/cluster.yaml",
+			"eksctl/us-west-2/test-cluster/20190601T100000Z/aws-auth.yaml",
+			"eksctl/us-west-2/test-cluster/20190601T100000Z/stacks/eksctl-test-cluster-cluster.json",
+			"eksctl/us-west-2/test-cluster/20190601T100000Z/stacks/eksctl-test-cluster-nodegroup-ng-1.json",
+		))
+		Expect(store.URL(newSnapshot("20190601T100000Z").Metadata)).To(Equal("s3://backups/eksctl/us-west-2/test-cluster/20190601T100000Z/"))
+	})
+
+	It("loads the latest snapshot", func() {
+		Expect(store.Save(newSnapshot("20190601T100000Z"))).To(Succeed())
+		Expect(store.Save(newSnapshot("20190602T100000Z"))).To(Succeed())
+
+		Expect(store.List("us-west-2", "test-cluster")).To(Equal([]string{"20190601T100000Z", "20190602T100000Z"}))
+
+		snapshot, err := store.Load("us-west-2", "test-cluster", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(snapshot.Metadata.ID).To(Equal("20190602T100000Z"))
+		Expect(snapshot.ClusterConfig.Metadata.Name).To(Equal("test-cluster"))
+		Expect(snapshot.Stacks).To(HaveLen(2))
+		Expect(snapshot.Stacks[0].Template).To(Equal(`{"Resources":{}}`))
+		Expect(snapshot.Stacks[0].Capabilities).To(Equal([]string{"CAPABILITY_IAM"}))
+		Expect(snapshot.Stacks[1].Template).To(Equal(`{"Resources":{"NodeGroup":{}}}`))
+		Expect(snapshot.AuthConfigMap.Data).To(HaveKey("mapRoles"))
+	})
+
+	It("fails to load an incomplete snapshot", func() {
+		Expect(store.Save(newSnapshot("20190601T100000Z"))).To(Succeed())
+		delete(objects, "eksctl/us-west-2/test-cluster/20190601T100000Z/snapshot.json")
+
+		_, err := store.Load("us-west-2", "test-cluster", "20190601T100000Z")
+		Expect(err).To(MatchError(`snapshot "20190601T100000Z" of cluster "test-cluster" is incomplete or doesn't exist`))
+	})
+
+	It("fails when there are no snapshots", func() {
+		_, err := store.Load("us-west-2", "test-cluster", "")
+		Expect(err).To(MatchError(`no snapshots of cluster "test-cluster" found in s3://backups/eksctl/us-west-2/test-cluster/`))
+	})
+})
+
+var _ = Describe("ReconstructClusterConfig", func() {
+	It("adds the nodegroups of the stacks", func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Status = &api.ClusterStatus{Endpoint: "https://example.com"}
+
+		reconstructed := ReconstructClusterConfig(cfg, nil, []*manager.NodeGroupSummary{
+			{Name: "ng-1", InstanceType: "m5.large", ImageID: "ami-123", MinSize: 1, MaxSize: 3, DesiredCapacity: 2},
+		})
+		Expect(reconstructed.Status).To(BeNil())
+		Expect(reconstructed.NodeGroups).To(HaveLen(1))
+		Expect(reconstructed.NodeGroups[0].Name).To(Equal("ng-1"))
+		Expect(reconstructed.NodeGroups[0].InstanceType).To(Equal("m5.large"))
+		Expect(*reconstructed.NodeGroups[0].DesiredCapacity).To(Equal(2))
+		Expect(cfg.Status).ToNot(BeNil())
+	})
+})
@@ -0,0 +1,226 @@
+package builder
+
+import (
+	"fmt"
+	"sort"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// alarmPeriod is the period of the metrics of all alarms, in seconds
+	alarmPeriod = 300
+	// alarmEvaluationPeriods is how many consecutive periods a metric has to
+	// breach the threshold for, so that alarms don't go off on transient issues
+	alarmEvaluationPeriods = 3
+
+	// apiServerErrorRateThreshold is the percentage of requests the API server
+	// can fail with 5xx responses before the alarm goes off
+	apiServerErrorRateThreshold = 5
+)
+
+// asgGroupMetrics are the metrics of nodegroup auto scaling groups the node count
+// alarms are based on, groups only publish them once metrics collection is enabled
+var asgGroupMetrics = []string{"GroupDesiredCapacity", "GroupInServiceInstances"}
+
+// AlarmsResourceSet stores the CloudWatch alarms of a cluster, they are created
+// in a separate stack once the control plane logs are enabled and nodegroups
+// are created, as the alarms refer to both
+type AlarmsResourceSet struct {
+	rs            *resourceSet
+	clusterSpec   *api.ClusterConfig
+	nodeGroupASGs map[string]string
+	natGatewayIDs []string
+}
+
+// NewAlarmsResourceSet returns a resource set for the alarms of the cluster, nodeGroupASGs
+// maps the names of nodegroups to their auto scaling groups
+func NewAlarmsResourceSet(spec *api.ClusterConfig, nodeGroupASGs map[string]string, natGatewayIDs []string) *AlarmsResourceSet {
+	return &AlarmsResourceSet{
+		rs:            newResourceSet(),
+		clusterSpec:   spec,
+		nodeGroupASGs: nodeGroupASGs,
+		natGatewayIDs: natGatewayIDs,
+	}
+}
+
+// AddAllResources adds an alarm for the error rate of the API server when audit logs are
+// enabled, one for the node count of each nodegroup and one for each NAT gateway
+func (a *AlarmsResourceSet) AddAllResources() error {
+	if !a.clusterSpec.HasAlarms() {
+		return fmt.Errorf("alarms are not enabled for cluster %q", a.clusterSpec.Metadata.Name)
+	}
+
+	if a.hasAuditLogs() {
+		a.addResourcesForAPIServerErrors()
+	}
+
+	names := []string{}
+	for name := range a.nodeGroupASGs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		a.addNodeCountAlarm(i, name, a.nodeGroupASGs[name])
+	}
+
+	for i, id := range a.natGatewayIDs {
+		a.addNATGatewayAlarm(i, id)
+	}
+
+	if len(a.rs.template.Resources) == 0 {
+		return fmt.Errorf("cluster %q has nothing to create alarms for, enable audit logs or add nodegroups", a.clusterSpec.Metadata.Name)
+	}
+
+	a.rs.template.Description = fmt.Sprintf("EKS cluster alarms %s", templateDescriptionSuffix)
+
+	return nil
+}
+
+func (a *AlarmsResourceSet) hasAuditLogs() bool {
+	for _, t := range a.clusterSpec.EnabledClusterLogTypes() {
+		if t == "audit" {
+			return true
+		}
+	}
+	return false
+}
+
+// addResourcesForAPIServerErrors counts requests and 5xx responses in audit logs, as the
+// control plane doesn't publish metrics, and alarms on the percentage of errors
+func (a *AlarmsResourceSet) addResourcesForAPIServerErrors() {
+	namespace := "eksctl/" + a.clusterSpec.Metadata.Name
+	logGroup := fmt.Sprintf("/aws/eks/%s/cluster", a.clusterSpec.Metadata.Name)
+
+	metricFilter := func(name, pattern, metric string) {
+		a.rs.newResource(name, &awsCloudFormationResource{
+			Type: "AWS::Logs::MetricFilter",
+			Properties: map[string]interface{}{
+				"LogGroupName":  logGroup,
+				"FilterPattern": pattern,
+				"MetricTransformations": []map[string]interface{}{
+					{"MetricNamespace": namespace, "MetricName": metric, "MetricValue": "1", "DefaultValue": 0},
+				},
+			},
+		})
+	}
+	metricFilter("APIServerRequestsMetricFilter", `{ $.responseStatus.code = * }`, "APIServerRequests")
+	metricFilter("APIServerErrorsMetricFilter", `{ $.responseStatus.code >= 500 }`, "APIServerErrors")
+
+	a.newAlarm("APIServerErrorRateAlarm", "api-server-error-rate",
+		fmt.Sprintf("More than %d%% of requests to the API server of cluster %q fail with 5xx responses", apiServerErrorRateThreshold, a.clusterSpec.Metadata.Name),
+		map[string]interface{}{
+			"ComparisonOperator": "GreaterThanThreshold",
+			"Threshold":          apiServerErrorRateThreshold,
+			"Metrics": []map[string]interface{}{
+				metricStat("requests", namespace, "APIServerRequests", "Sum", nil),
+				metricStat("errors", namespace, "APIServerErrors", "Sum", nil),
+				metricExpression("100 * errors / requests"),
+			},
+		},
+		[]string{"APIServerRequestsMetricFilter", "APIServerErrorsMetricFilter"},
+	)
+}
+
+// addNodeCountAlarm alarms when fewer instances of the nodegroup are in service than desired
+func (a *AlarmsResourceSet) addNodeCountAlarm(i int, nodeGroupName, asgName string) {
+	dimensions := []map[string]string{{"Name": "AutoScalingGroupName", "Value": asgName}}
+	a.newAlarm(fmt.Sprintf("NodeGroup%dNodeCountAlarm", i), "nodegroup-"+nodeGroupName+"-node-count",
+		fmt.Sprintf("Fewer instances of nodegroup %q of cluster %q are in service than desired", nodeGroupName, a.clusterSpec.Metadata.Name),
+		map[string]interface{}{
+			"ComparisonOperator": "GreaterThanThreshold",
+			"Threshold":          0,
+			"Metrics": []map[string]interface{}{
+				metricStat("desired", "AWS/AutoScaling", asgGroupMetrics[0], "Average", dimensions),
+				metricStat("inService", "AWS/AutoScaling", asgGroupMetrics[1], "Average", dimensions),
+				metricExpression("desired - inService"),
+			},
+		},
+		nil,
+	)
+}
+
+// addNATGatewayAlarm alarms when the NAT gateway drops packets
+func (a *AlarmsResourceSet) addNATGatewayAlarm(i int, natGatewayID string) {
+	a.newAlarm(fmt.Sprintf("NATGateway%dPacketsDropAlarm", i), natGatewayID+"-packets-drop",
+		fmt.Sprintf("NAT gateway %q of cluster %q drops packets", natGatewayID, a.clusterSpec.Metadata.Name),
+		map[string]interface{}{
+			"ComparisonOperator": "GreaterThanThreshold",
+			"Threshold":          0,
+			"Namespace":          "AWS/NATGateway",
+			"MetricName":         "PacketsDropCount",
+			"Dimensions":         []map[string]string{{"Name": "NatGatewayId", "Value": natGatewayID}},
+			"Statistic":          "Sum",
+			"Period":             alarmPeriod,
+		},
+		nil,
+	)
+}
+
+// newAlarm adds an alarm that notifies the topic of the config when it goes off and
+// when it recovers, periods without data don't make it go off
+func (a *AlarmsResourceSet) newAlarm(logicalID, name, description string, properties map[string]interface{}, dependsOn []string) {
+	topic := []string{a.clusterSpec.CloudWatch.Alarms.SNSTopicARN}
+	properties["AlarmName"] = fmt.Sprintf("%s-%s", a.clusterSpec.Metadata.Name, name)
+	properties["AlarmDescription"] = description
+	properties["AlarmActions"] = topic
+	properties["OKActions"] = topic
+	properties["EvaluationPeriods"] = alarmEvaluationPeriods
+	properties["TreatMissingData"] = "notBreaching"
+
+	// goformation doesn't support metric math in alarms yet, so a custom resource is used
+	a.rs.newResource(logicalID, &awsCloudFormationResource{
+		Type:       "AWS::CloudWatch::Alarm",
+		Properties: properties,
+		DependsOn:  dependsOn,
+	})
+}
+
+func metricStat(id, namespace, name, stat string, dimensions []map[string]string) map[string]interface{} {
+	metric := map[string]interface{}{
+		"Namespace":  namespace,
+		"MetricName": name,
+	}
+	if len(dimensions) > 0 {
+		metric["Dimensions"] = dimensions
+	}
+	return map[string]interface{}{
+		"Id": id,
+		"MetricStat": map[string]interface{}{
+			"Metric": metric,
+			"Period": alarmPeriod,
+			"Stat":   stat,
+		},
+		"ReturnData": false,
+	}
+}
+
+func metricExpression(expression string) map[string]interface{} {
+	return map[string]interface{}{
+		"Id":         "expression",
+		"Expression": expression,
+		"ReturnData": true,
+	}
+}
+
+// RenderJSON returns the rendered JSON
+func (a *AlarmsResourceSet) RenderJSON() ([]byte, error) {
+	return a.rs.renderJSON()
+}
+
+// WithIAM states, if IAM roles will be created or not, alarms don't need any
+func (a *AlarmsResourceSet) WithIAM() bool {
+	return a.rs.withIAM
+}
+
+// WithNamedIAM states, if specifically named IAM roles will be created or not
+func (a *AlarmsResourceSet) WithNamedIAM() bool {
+	return a.rs.withNamedIAM
+}
+
+// GetAllOutputs collects all outputs of the alarms stack
+func (a *AlarmsResourceSet) GetAllOutputs(stack cfn.Stack) error {
+	return a.rs.GetAllOutputs(stack)
+}
@@ -0,0 +1,71 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+var _ = Describe("Alarms stack builder", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+	})
+
+	renderResources := func(rs *AlarmsResourceSet) map[string]map[string]interface{} {
+		data, err := rs.RenderJSON()
+		Expect(err).ToNot(HaveOccurred())
+		template := struct {
+			Resources map[string]map[string]interface{}
+		}{}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+		return template.Resources
+	}
+
+	It("should fail unless alarms are enabled", func() {
+		Expect(NewAlarmsResourceSet(cfg, nil, nil).AddAllResources()).ToNot(Succeed())
+		cfg.CloudWatch = &api.ClusterCloudWatch{Alarms: &api.ClusterCloudWatchAlarms{Create: api.Enabled(), SNSTopicARN: "arn:aws:sns:us-west-2:123456789012:alarms"}}
+		Expect(NewAlarmsResourceSet(cfg, nil, nil).AddAllResources()).ToNot(Succeed())
+	})
+
+	It("should create alarms for the API server, nodegroups and NAT gateways", func() {
+		cfg.CloudWatch = &api.ClusterCloudWatch{
+			ClusterLogging: &api.ClusterCloudWatchLogging{EnableTypes: []string{"audit"}},
+			Alarms:         &api.ClusterCloudWatchAlarms{Create: api.Enabled(), SNSTopicARN: "arn:aws:sns:us-west-2:123456789012:alarms"},
+		}
+
+		rs := NewAlarmsResourceSet(cfg, map[string]string{"ng-2": "asg-2", "ng-1": "asg-1"}, []string{"nat-1"})
+		Expect(rs.AddAllResources()).To(Succeed())
+		resources := renderResources(rs)
+
+		Expect(resources).To(HaveLen(6))
+		Expect(resources["APIServerErrorsMetricFilter"]["Type"]).To(Equal("AWS::Logs::MetricFilter"))
+		Expect(resources["APIServerErrorsMetricFilter"]["Properties"]).To(HaveKeyWithValue("LogGroupName", "/aws/eks/"+clusterName+"/cluster"))
+		Expect(resources).To(HaveKey("APIServerRequestsMetricFilter"))
+
+		for _, name := range []string{"APIServerErrorRateAlarm", "NodeGroup0NodeCountAlarm", "NodeGroup1NodeCountAlarm", "NATGateway0PacketsDropAlarm"} {
+			Expect(resources[name]["Type"]).To(Equal("AWS::CloudWatch::Alarm"))
+			Expect(resources[name]["Properties"]).To(HaveKeyWithValue("AlarmActions", ConsistOf("arn:aws:sns:us-west-2:123456789012:alarms")))
+		}
+		Expect(resources["NodeGroup0NodeCountAlarm"]["Properties"]).To(HaveKeyWithValue("AlarmName", clusterName+"-nodegroup-ng-1-node-count"))
+	})
+
+	It("should skip the API server alarm without audit logs", func() {
+		cfg.CloudWatch = &api.ClusterCloudWatch{
+			Alarms: &api.ClusterCloudWatchAlarms{Create: api.Enabled(), SNSTopicARN: "arn:aws:sns:us-west-2:123456789012:alarms"},
+		}
+
+		rs := NewAlarmsResourceSet(cfg, map[string]string{"ng-1": "asg-1"}, nil)
+		Expect(rs.AddAllResources()).To(Succeed())
+		resources := renderResources(rs)
+
+		Expect(resources).To(HaveLen(1))
+		Expect(resources).To(HaveKey("NodeGroup0NodeCountAlarm"))
+	})
+})
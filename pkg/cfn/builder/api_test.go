@@ -34,6 +34,8 @@ const (
 	caCert      = "LS0tLS1CRUdJTiBDRVJUSUZJQ0FURS0tLS0tCk1JSUN5RENDQWJDZ0F3SUJBZ0lCQURBTkJna3Foa2lHOXcwQkFRc0ZBREFWTVJNd0VRWURWUVFERXdwcmRXSmwKY201bGRHVnpNQjRYRFRFNE1EWXdOekExTlRBMU5Wb1hEVEk0TURZd05EQTFOVEExTlZvd0ZURVRNQkVHQTFVRQpBeE1LYTNWaVpYSnVaWFJsY3pDQ0FTSXdEUVlKS29aSWh2Y05BUUVCQlFBRGdnRVBBRENDQVFvQ2dnRUJBTWJoCnpvZElYR0drckNSZE1jUmVEN0YvMnB1NFZweTdvd3FEVDgrdk9zeGs2bXFMNWxQd3ZicFhmYkE3R0xzMDVHa0wKaDdqL0ZjcU91cnMwUFZSK3N5REtuQXltdDFORWxGNllGQktSV1dUQ1hNd2lwN1pweW9XMXdoYTlJYUlPUGxCTQpPTEVlckRabFVrVDFVV0dWeVdsMmxPeFgxa2JhV2gvakptWWdkeW5jMXhZZ3kxa2JybmVMSkkwLzVUVTRCajJxClB1emtrYW5Xd3lKbGdXQzhBSXlpWW82WFh2UVZmRzYrM3RISE5XM1F1b3ZoRng2MTFOYnl6RUI3QTdtZGNiNmgKR0ZpWjdOeThHZnFzdjJJSmI2Nk9FVzBSdW9oY1k3UDZPdnZmYnlKREhaU2hqTStRWFkxQXN5b3g4Ri9UelhHSgpQUWpoWUZWWEVhZU1wQmJqNmNFQ0F3RUFBYU1qTUNFd0RnWURWUjBQQVFIL0JBUURBZ0trTUE4R0ExVWRFd0VCCi93UUZNQU1CQWY4d0RRWUpLb1pJaHZjTkFRRUxCUUFEZ2dFQkFCa2hKRVd4MHk1LzlMSklWdXJ1c1hZbjN6Z2EKRkZ6V0JsQU44WTlqUHB3S2t0Vy9JNFYyUGg3bWY2Z3ZwZ3Jhc2t1Slk1aHZPcDdBQmcxSTFhaHUxNUFpMUI0ZApuMllRaDlOaHdXM2pKMmhuRXk0VElpb0gza2JFdHRnUVB2bWhUQzNEYUJreEpkbmZJSEJCV1RFTTU1czRwRmxUClpzQVJ3aDc1Q3hYbjdScVU0akpKcWNPaTRjeU5qeFVpRDBqR1FaTmNiZWEyMkRCeTJXaEEzUWZnbGNScGtDVGUKRDVPS3NOWlF4MW9MZFAwci9TSmtPT1NPeUdnbVJURTIrODQxN21PRW02Z3RPMCszdWJkbXQ0aENsWEtFTTZYdwpuQWNlK0JxVUNYblVIN2ZNS3p2TDE5UExvMm5KbFU1TnlCbU1nL1pNVHVlUy80eFZmKy94WnpsQ0Q1WT0KLS0tLS1FTkQgQ0VSVElGSUNBVEUtLS0tLQo="
 	arn         = "arn:aws:eks:us-west-2:122333:cluster/" + clusterName
 
+	oidcIssuerURL = "https://oidc.eks.us-west-2.amazonaws.com/id/DE37D8AFB23F7275D2361AD6B2599143"
+
 	vpcID          = "vpc-0e265ad953062b94b"
 	subnetsPublic  = "subnet-0f98135715dfcf55f,subnet-0ade11bad78dced9e,subnet-0e2e63ff1712bf6ef"
 	subnetsPrivate = "subnet-0f98135715dfcf55a,subnet-0ade11bad78dced9f,subnet-0e2e63ff1712bf6ea"
@@ -41,6 +43,8 @@ const (
 
 var overrideBootstrapCommand = "echo foo > /etc/test_foo; echo bar > /etc/test_bar; poweroff -fn;"
 
+const exportBootstrapEnv = "set -a; . /etc/eksctl/metadata.env; . /etc/eksctl/kubelet.env; set +a"
+
 type Tag struct {
 	Key   interface{}
 	Value interface{}
@@ -52,6 +56,7 @@ type Properties struct {
 	Tags []Tag
 
 	Path, RoleName           string
+	PermissionsBoundary      string
 	Roles, ManagedPolicyArns []interface{}
 	AssumeRolePolicyDocument interface{}
 
@@ -70,9 +75,16 @@ type Properties struct {
 	TargetGroupARNs                   []string
 	DesiredCapacity, MinSize, MaxSize string
 
+	LifecycleHookSpecificationList []map[string]string
+	AutoScalingGroupName           interface{}
+	MaxGroupPreparedCapacity       string
+
 	CidrIp, CidrIpv6, IpProtocol string
 	FromPort, ToPort             int
 
+	GroupId, SourceSecurityGroupId interface{}
+	Description                    string
+
 	VpcId, SubnetId                            interface{}
 	RouteTableId, AllocationId                 interface{}
 	GatewayId, InternetGatewayId, NatGatewayId interface{}
@@ -81,13 +93,31 @@ type Properties struct {
 	Ipv6CidrBlock map[string][]interface{}
 
 	AmazonProvidedIpv6CidrBlock         bool
+	AssignIpv6AddressOnCreation         bool
 	AvailabilityZone, Domain, CidrBlock string
 
+	DestinationIpv6CidrBlock    interface{}
+	EgressOnlyInternetGatewayId interface{}
+
 	Name, Version      string
+	Strategy           string
 	RoleArn            interface{}
 	ResourcesVpcConfig struct {
-		SecurityGroupIds []interface{}
-		SubnetIds        []interface{}
+		SecurityGroupIds      []interface{}
+		SubnetIds             []interface{}
+		EndpointPublicAccess  *bool
+		EndpointPrivateAccess *bool
+	}
+	KubernetesNetworkConfig *struct {
+		ServiceIpv4Cidr string
+		IpFamily        string
+	}
+	OutpostConfig *struct {
+		OutpostArns              []string
+		ControlPlaneInstanceType string
+		ControlPlanePlacement    *struct {
+			GroupName string
+		}
 	}
 	MixedInstancesPolicy *struct {
 		LaunchTemplate struct {
@@ -112,9 +142,15 @@ type LaunchTemplateData struct {
 	IamInstanceProfile              struct{ Arn interface{} }
 	UserData, InstanceType, ImageId string
 	BlockDeviceMappings             []interface{}
-	NetworkInterfaces               []struct {
+	Placement                       *struct {
+		GroupName, Tenancy interface{}
+	}
+	NetworkInterfaces []struct {
 		DeviceIndex              int
-		AssociatePublicIpAddress bool
+		AssociatePublicIpAddress  bool
+		AssociateCarrierIpAddress bool
+		InterfaceType             string
+		Groups                    []interface{}
 	}
 	InstanceMarketOptions *struct {
 		MarketType  string
@@ -127,7 +163,11 @@ type LaunchTemplateData struct {
 
 type Template struct {
 	Description string
-	Resources   map[string]struct{ Properties Properties }
+	Resources   map[string]struct {
+		Properties   Properties
+		UpdatePolicy map[string]map[string]string
+	}
+	Outputs     map[string]interface{}
 }
 
 func kubeconfigBody(authenticator string) string {
@@ -387,6 +427,7 @@ var _ = Describe("CloudFormation template builder API", func() {
 				Endpoint:                 endpoint,
 				CertificateAuthorityData: caCertData,
 				ARN: arn,
+				OIDCIssuerURL: oidcIssuerURL,
 			},
 			AvailabilityZones: testAZs,
 			VPC:               testVPC(),
@@ -450,6 +491,7 @@ var _ = Describe("CloudFormation template builder API", func() {
 			"SharedNodeSecurityGroup": "sg-shared",
 			"ServiceRoleARN":          arn,
 			"FeatureNATMode":          "Single",
+			"OIDCIssuerURL":           oidcIssuerURL,
 		}
 
 		It("should add all resources and collect outputs without errors", func() {
@@ -569,6 +611,52 @@ var _ = Describe("CloudFormation template builder API", func() {
 		})
 	})
 
+	Context("NodeGroup{Labels Taints}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.Name = "ng-abcd1234"
+		ng.Labels = map[string]string{"role": "gpu"}
+		ng.Taints = map[string]string{"nvidia.com/gpu": "true:NoSchedule"}
+
+		build(cfg, "eksctl-test-123-cluster", ng)
+
+		roundtrip()
+
+		It("should have Cluster Autoscaler node-template tags that don't propagate to instances", func() {
+			ngProps := getNodeGroupProperties(ngTemplate)
+
+			Expect(ngProps.Tags).To(HaveLen(4))
+			Expect(ngProps.Tags[2:]).To(Equal([]Tag{
+				{
+					Key:               "k8s.io/cluster-autoscaler/node-template/label/role",
+					Value:             "gpu",
+					PropagateAtLaunch: "false",
+				},
+				{
+					Key:               "k8s.io/cluster-autoscaler/node-template/taint/nvidia.com/gpu",
+					Value:             "true:NoSchedule",
+					PropagateAtLaunch: "false",
+				},
+			}))
+		})
+	})
+
+	Context("NodeGroup{Labels PropagateASGTags=false}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.Name = "ng-abcd1234"
+		ng.Labels = map[string]string{"role": "gpu"}
+		ng.PropagateASGTags = api.Disabled()
+
+		build(cfg, "eksctl-test-123-cluster", ng)
+
+		roundtrip()
+
+		It("should not have node-template tags", func() {
+			Expect(getNodeGroupProperties(ngTemplate).Tags).To(HaveLen(2))
+		})
+	})
+
 	Context("NodeGroup DesiredCapacity=nil MaxSize=nil MinSize=nil", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
@@ -1284,6 +1372,121 @@ var _ = Describe("CloudFormation template builder API", func() {
 		})
 	})
 
+	Context("NodeGroup with shared role that has a path", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.IAM.InstanceRoleARN = "arn:aws:iam::123456789012:role/shared/nodes"
+
+		build(cfg, "eksctl-test-123-cluster", ng)
+
+		roundtrip()
+
+		It("should create a profile for the role by name", func() {
+			Expect(ngTemplate.Resources).ToNot(HaveKey("NodeInstanceRole"))
+			Expect(ngTemplate.Resources).To(HaveKey("NodeInstanceProfile"))
+
+			profile := ngTemplate.Resources["NodeInstanceProfile"].Properties
+			Expect(profile.Roles).To(Equal([]interface{}{"nodes"}))
+		})
+	})
+
+	Context("NodeGroup with attachPolicy", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.IAM.WithAddonPolicies.AutoScaler = api.Enabled()
+		ng.IAM.WithAddonPolicies.CloudWatch = api.Enabled()
+		ng.IAM.AttachPolicy = NodeGroupAddonPolicyDocument(ng, api.PartitionAWS)
+
+		build(cfg, "eksctl-test-123-cluster", ng)
+
+		roundtrip()
+
+		It("should only attach the default managed policies", func() {
+			role := ngTemplate.Resources["NodeInstanceRole"].Properties
+
+			Expect(role.ManagedPolicyArns).To(Equal([]interface{}{
+				"arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+				"arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy",
+			}))
+		})
+
+		It("should attach the document in place of the addon policies", func() {
+			Expect(ngTemplate.Resources).ToNot(HaveKey("PolicyAutoScaling"))
+			Expect(ngTemplate.Resources).To(HaveKey("PolicyAttached"))
+
+			policy := ngTemplate.Resources["PolicyAttached"].Properties
+
+			Expect(policy.Roles).To(HaveLen(1))
+			isRefTo(policy.Roles[0], "NodeInstanceRole")
+
+			statements := policy.PolicyDocument.Statement
+			Expect(statements).To(HaveLen(4))
+			Expect(statements[0].Action).To(ContainElement("ecr:BatchGetImage"))
+			Expect(statements[0].Action).ToNot(ContainElement("ecr:PutImage"))
+			Expect(statements[1].Action).To(ContainElement("cloudwatch:PutMetricData"))
+			Expect(statements[2].Resource).To(Equal("arn:aws:ssm:*:*:parameter/AmazonCloudWatch-*"))
+			Expect(statements[3].Action).To(ContainElement("autoscaling:SetDesiredCapacity"))
+		})
+	})
+
+	Context("NodeGroup in the China partition", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Metadata.Region = "cn-north-1"
+		ng.IAM.WithAddonPolicies.ExternalDNS = api.Enabled()
+
+		build(cfg, "eksctl-test-china-cluster", ng)
+
+		roundtrip()
+
+		It("should use ARNs and service principals of the partition", func() {
+			serviceRole := clusterTemplate.Resources["ServiceRole"].Properties
+			Expect(serviceRole.ManagedPolicyArns).To(Equal([]interface{}{
+				"arn:aws-cn:iam::aws:policy/AmazonEKSServicePolicy",
+				"arn:aws-cn:iam::aws:policy/AmazonEKSClusterPolicy",
+			}))
+			checkARPD("eks.amazonaws.com", serviceRole.AssumeRolePolicyDocument)
+
+			role := ngTemplate.Resources["NodeInstanceRole"].Properties
+			Expect(role.ManagedPolicyArns).To(Equal([]interface{}{
+				"arn:aws-cn:iam::aws:policy/AmazonEKSWorkerNodePolicy",
+				"arn:aws-cn:iam::aws:policy/AmazonEKS_CNI_Policy",
+				"arn:aws-cn:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly",
+			}))
+			checkARPD("ec2.amazonaws.com.cn", role.AssumeRolePolicyDocument)
+
+			policy := ngTemplate.Resources["PolicyExternalDNSChangeSet"].Properties
+			Expect(policy.PolicyDocument.Statement[0].Resource).To(Equal("arn:aws-cn:route53:::hostedzone/*"))
+		})
+	})
+
+	Context("NodeGroupAddonPolicyDocument", func() {
+		_, ng := newClusterConfigAndNodegroup(true)
+
+		ng.IAM.WithAddonPolicies.ImageBuilder = api.Enabled()
+		ng.IAM.WithAddonPolicies.FSX = api.Enabled()
+
+		It("should narrow down wildcard actions", func() {
+			document := NodeGroupAddonPolicyDocument(ng, api.PartitionAWS)
+			Expect(document).To(HaveKeyWithValue("Version", "2012-10-17"))
+
+			statements := document["Statement"].([]interface{})
+			Expect(statements).To(HaveLen(3))
+
+			ecr := statements[0].(map[string]interface{})
+			Expect(ecr["Sid"]).To(Equal("ECR"))
+			Expect(ecr["Action"]).To(ContainElement("ecr:PutImage"))
+
+			fsx := statements[1].(map[string]interface{})
+			Expect(fsx["Sid"]).To(Equal("FSX"))
+			Expect(fsx["Action"]).ToNot(ContainElement("fsx:*"))
+			Expect(fsx["Action"]).To(ContainElement("fsx:CreateFileSystem"))
+
+			// the document can be set in the config, which is deep copied
+			Expect(document.DeepCopy()).To(Equal(document))
+		})
+	})
+
 	Context("Nodegroup encrypted volume using default key, or encrypted AMI", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
@@ -1350,6 +1553,111 @@ var _ = Describe("CloudFormation template builder API", func() {
 		})
 	})
 
+	Context("Nodegroup{Placement.Strategy=cluster Tenancy=dedicated}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.AvailabilityZones = []string{"us-west-2a"}
+		ng.Placement = &api.NodeGroupPlacement{Strategy: api.PlacementStrategyCluster}
+		ng.Tenancy = api.TenancyDedicated
+
+		build(cfg, "eksctl-test-private-ng", ng)
+
+		roundtrip()
+
+		It("should create a placement group and use it in the launch template", func() {
+			Expect(ngTemplate.Resources).To(HaveKey("NodeGroupPlacementGroup"))
+			Expect(ngTemplate.Resources["NodeGroupPlacementGroup"].Properties.Strategy).To(Equal("cluster"))
+
+			ltd := getLaunchTemplateData(ngTemplate)
+			Expect(ltd.Placement).ToNot(BeNil())
+			Expect(ltd.Placement.GroupName).To(Equal(map[string]interface{}{"Ref": "NodeGroupPlacementGroup"}))
+			Expect(ltd.Placement.Tenancy).To(Equal("dedicated"))
+		})
+	})
+
+	Context("Nodegroup{EFAEnabled=true}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.InstanceType = "c5n.18xlarge"
+		ng.AvailabilityZones = []string{"us-west-2a"}
+		ng.EFAEnabled = api.Enabled()
+
+		build(cfg, "eksctl-test-private-ng", ng)
+
+		roundtrip()
+
+		It("should use an EFA interface with a self-referencing security group", func() {
+			Expect(ngTemplate.Resources).To(HaveKey("EFASG"))
+			Expect(ngTemplate.Resources).To(HaveKey("IngressEFA"))
+			Expect(ngTemplate.Resources).To(HaveKey("EgressEFA"))
+			Expect(ngTemplate.Resources["IngressEFA"].Properties.IpProtocol).To(Equal("-1"))
+
+			ltd := getLaunchTemplateData(ngTemplate)
+			Expect(ltd.NetworkInterfaces).To(HaveLen(1))
+			Expect(ltd.NetworkInterfaces[0].InterfaceType).To(Equal("efa"))
+		})
+	})
+
+	Context("Nodegroup{Placement.GroupName=existing}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.Placement = &api.NodeGroupPlacement{GroupName: "existing"}
+
+		build(cfg, "eksctl-test-private-ng", ng)
+
+		roundtrip()
+
+		It("should use the existing placement group", func() {
+			Expect(ngTemplate.Resources).ToNot(HaveKey("NodeGroupPlacementGroup"))
+
+			ltd := getLaunchTemplateData(ngTemplate)
+			Expect(ltd.Placement).ToNot(BeNil())
+			Expect(ltd.Placement.GroupName).To(Equal("existing"))
+			Expect(ltd.Placement.Tenancy).To(BeNil())
+		})
+	})
+
+	Context("Nodegroup{VolumeType=gp3 VolumeThroughput=250 AdditionalVolumes}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		*ng.VolumeType = api.NodeVolumeTypeGP3
+		ng.VolumeIOPS = aws.Int(4000)
+		ng.VolumeThroughput = aws.Int(250)
+		ng.AdditionalVolumes = []*api.VolumeMapping{
+			{
+				VolumeName: aws.String("/dev/xvdb"),
+				VolumeSize: aws.Int(100),
+				VolumeType: aws.String(api.NodeVolumeTypeIO2),
+				VolumeIOPS: aws.Int(1000),
+			},
+		}
+
+		build(cfg, "eksctl-test-private-ng", ng)
+
+		roundtrip()
+
+		It("should have correct resources and attributes", func() {
+			Expect(ngTemplate.Resources).ToNot(BeEmpty())
+
+			ltd := getLaunchTemplateData(ngTemplate)
+			Expect(ltd.BlockDeviceMappings).To(HaveLen(2))
+
+			rootVolume := ltd.BlockDeviceMappings[0].(map[string]interface{})
+			Expect(rootVolume).To(HaveKeyWithValue("DeviceName", "/dev/xvda"))
+			Expect(rootVolume["Ebs"].(map[string]interface{})).To(HaveKeyWithValue("VolumeType", "gp3"))
+			Expect(rootVolume["Ebs"].(map[string]interface{})).To(HaveKeyWithValue("Iops", 4000.0))
+			Expect(rootVolume["Ebs"].(map[string]interface{})).To(HaveKeyWithValue("Throughput", 250.0))
+
+			additionalVolume := ltd.BlockDeviceMappings[1].(map[string]interface{})
+			Expect(additionalVolume).To(HaveKeyWithValue("DeviceName", "/dev/xvdb"))
+			Expect(additionalVolume["Ebs"].(map[string]interface{})).To(HaveKeyWithValue("VolumeType", "io2"))
+			Expect(additionalVolume["Ebs"].(map[string]interface{})).To(HaveKeyWithValue("VolumeSize", 100.0))
+			Expect(additionalVolume["Ebs"].(map[string]interface{})).To(HaveKeyWithValue("Iops", 1000.0))
+			Expect(additionalVolume["Ebs"].(map[string]interface{})).To(HaveKeyWithValue("Encrypted", false))
+			Expect(additionalVolume["Ebs"].(map[string]interface{})).ToNot(HaveKey("Throughput"))
+		})
+	})
+
 	Context("NodeGroup{PrivateNetworking=true SSH.Allow=true}", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
@@ -1547,6 +1855,41 @@ var _ = Describe("CloudFormation template builder API", func() {
 		})
 	})
 
+	Context("NodeGroup{ProviderOverride}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.VPC = testVPC()
+
+		ng.PrivateNetworking = true
+		ng.InstanceType = "t2.medium"
+		ng.AMIFamily = "AmazonLinux2"
+		ng.ProviderOverride = &api.NodeGroupProviderOverride{Profile: "workers"}
+		ng.SecurityGroups = &api.NodeGroupSGs{
+			AttachIDs:  []string{"sg-workers"},
+			WithShared: api.Disabled(),
+			WithLocal:  api.Disabled(),
+		}
+
+		build(cfg, "eksctl-test-cross-account-ng", ng)
+
+		roundtrip()
+
+		It("should use the subnets of the cluster instead of importing them", func() {
+			x, ok := getNodeGroupProperties(ngTemplate).VPCZoneIdentifier.([]interface{})
+			Expect(ok).To(BeTrue())
+			Expect(x).To(ConsistOf(
+				cfg.VPC.Subnets.Private["us-west-2a"].ID,
+				cfg.VPC.Subnets.Private["us-west-2b"].ID,
+				cfg.VPC.Subnets.Private["us-west-2c"].ID,
+			))
+		})
+
+		It("should only attach the given security groups", func() {
+			Expect(ngTemplate.Resources).ToNot(HaveKey("SG"))
+			Expect(getLaunchTemplateData(ngTemplate).NetworkInterfaces[0].Groups).To(Equal([]interface{}{"sg-workers"}))
+		})
+	})
+
 	checkAsset := func(name, expectedContent string) {
 		assetContent, err := nodebootstrap.Asset(name)
 		Expect(err).ToNot(HaveOccurred())
@@ -1671,6 +2014,10 @@ var _ = Describe("CloudFormation template builder API", func() {
 				"MAX_PODS=55",
 			}))
 
+			metadataEnv := getFile(cc, "/etc/eksctl/metadata.env")
+			Expect(metadataEnv).ToNot(BeNil())
+			Expect(strings.Split(metadataEnv.Content, "\n")).To(ContainElement("AWS_EKS_CLUSTER_CA=" + caCert))
+
 			kubeletDropInUnit := getFile(cc, "/etc/systemd/system/kubelet.service.d/10-eksclt.al2.conf")
 			Expect(kubeletDropInUnit).ToNot(BeNil())
 			Expect(kubeletDropInUnit.Permissions).To(Equal("0644"))
@@ -1688,9 +2035,10 @@ var _ = Describe("CloudFormation template builder API", func() {
 
 			checkScript(cc, "/var/lib/cloud/scripts/per-instance/bootstrap.al2.sh", true)
 
-			Expect(cc.Commands).To(HaveLen(len(ng.PreBootstrapCommands) + 1))
+			Expect(cc.Commands).To(HaveLen(len(ng.PreBootstrapCommands) + 2))
+			Expect(cc.Commands[0]).To(Equal(exportBootstrapEnv))
 			for i, cmd := range ng.PreBootstrapCommands {
-				c := cc.Commands[i].([]interface{})
+				c := cc.Commands[i+1].([]interface{})
 				Expect(c[0]).To(Equal("/bin/bash"))
 				Expect(c[1]).To(Equal("-c"))
 				Expect(c[2]).To(Equal(cmd))
@@ -1748,9 +2096,10 @@ var _ = Describe("CloudFormation template builder API", func() {
 			script := getFile(cc, "/var/lib/cloud/scripts/per-instance/bootstrap.al2.sh")
 			Expect(script).To(BeNil())
 
-			Expect(cc.Commands).To(HaveLen(1))
-			Expect(cc.Commands[0]).To(HaveLen(3))
-			c := cc.Commands[0].([]interface{})
+			Expect(cc.Commands).To(HaveLen(2))
+			Expect(cc.Commands[0]).To(Equal(exportBootstrapEnv))
+			Expect(cc.Commands[1]).To(HaveLen(3))
+			c := cc.Commands[1].([]interface{})
 			Expect(c[0]).To(Equal("/bin/bash"))
 			Expect(c[1]).To(Equal("-c"))
 			Expect(c[2]).To(Equal(overrideBootstrapCommand))
@@ -1805,19 +2154,20 @@ var _ = Describe("CloudFormation template builder API", func() {
 			script := getFile(cc, "/var/lib/cloud/scripts/per-instance/bootstrap.al2.sh")
 			Expect(script).To(BeNil())
 
-			Expect(cc.Commands).To(HaveLen(4))
-			Expect(cc.Commands[0]).To(HaveLen(3))
+			Expect(cc.Commands).To(HaveLen(5))
+			Expect(cc.Commands[0]).To(Equal(exportBootstrapEnv))
+			Expect(cc.Commands[1]).To(HaveLen(3))
 
 			for i, cmd := range ng.PreBootstrapCommands {
-				c := cc.Commands[i].([]interface{})
+				c := cc.Commands[i+1].([]interface{})
 				Expect(c[0]).To(Equal("/bin/bash"))
 				Expect(c[1]).To(Equal("-c"))
 				Expect(c[2]).To(Equal(cmd))
 			}
 
-			Expect(cc.Commands[3].([]interface{})[0]).To(Equal("/bin/bash"))
-			Expect(cc.Commands[3].([]interface{})[1]).To(Equal("-c"))
-			Expect(cc.Commands[3].([]interface{})[2]).To(Equal(overrideBootstrapCommand))
+			Expect(cc.Commands[4].([]interface{})[0]).To(Equal("/bin/bash"))
+			Expect(cc.Commands[4].([]interface{})[1]).To(Equal("-c"))
+			Expect(cc.Commands[4].([]interface{})[2]).To(Equal(overrideBootstrapCommand))
 		})
 	})
 
@@ -1913,8 +2263,9 @@ var _ = Describe("CloudFormation template builder API", func() {
 
 			checkScript(cc, "/var/lib/cloud/scripts/per-instance/bootstrap.ubuntu.sh", true)
 
+			Expect(cc.Commands[0]).To(Equal(exportBootstrapEnv))
 			for i, cmd := range ng.PreBootstrapCommands {
-				c := cc.Commands[i].([]interface{})
+				c := cc.Commands[i+1].([]interface{})
 				Expect(c[0]).To(Equal("/bin/bash"))
 				Expect(c[1]).To(Equal("-c"))
 				Expect(c[2]).To(Equal(cmd))
@@ -1979,11 +2330,12 @@ var _ = Describe("CloudFormation template builder API", func() {
 			script := getFile(cc, "/var/lib/cloud/scripts/per-instance/bootstrap.ubuntu.sh")
 			Expect(script).To(BeNil())
 
-			Expect(cc.Commands).To(HaveLen(1))
-			Expect(cc.Commands[0]).To(HaveLen(3))
-			Expect(cc.Commands[0].([]interface{})[0]).To(Equal("/bin/bash"))
-			Expect(cc.Commands[0].([]interface{})[1]).To(Equal("-c"))
-			Expect(cc.Commands[0].([]interface{})[2]).To(Equal(overrideBootstrapCommand))
+			Expect(cc.Commands).To(HaveLen(2))
+			Expect(cc.Commands[0]).To(Equal(exportBootstrapEnv))
+			Expect(cc.Commands[1]).To(HaveLen(3))
+			Expect(cc.Commands[1].([]interface{})[0]).To(Equal("/bin/bash"))
+			Expect(cc.Commands[1].([]interface{})[1]).To(Equal("-c"))
+			Expect(cc.Commands[1].([]interface{})[2]).To(Equal(overrideBootstrapCommand))
 		})
 	})
 
@@ -2040,20 +2392,44 @@ var _ = Describe("CloudFormation template builder API", func() {
 			script := getFile(cc, "/var/lib/cloud/scripts/per-instance/bootstrap.ubuntu.sh")
 			Expect(script).To(BeNil())
 
-			Expect(cc.Commands).To(HaveLen(4))
-			Expect(cc.Commands[0]).To(HaveLen(3))
+			Expect(cc.Commands).To(HaveLen(5))
+			Expect(cc.Commands[0]).To(Equal(exportBootstrapEnv))
+			Expect(cc.Commands[1]).To(HaveLen(3))
 
 			for i, cmd := range ng.PreBootstrapCommands {
-				c := cc.Commands[i].([]interface{})
+				c := cc.Commands[i+1].([]interface{})
 				Expect(c[0]).To(Equal("/bin/bash"))
 				Expect(c[1]).To(Equal("-c"))
 				Expect(c[2]).To(Equal(cmd))
 			}
 
-			c3 := cc.Commands[3].([]interface{})
-			Expect(c3[0]).To(Equal("/bin/bash"))
-			Expect(c3[1]).To(Equal("-c"))
-			Expect(c3[2]).To(Equal(overrideBootstrapCommand))
+			c4 := cc.Commands[4].([]interface{})
+			Expect(c4[0]).To(Equal("/bin/bash"))
+			Expect(c4[1]).To(Equal("-c"))
+			Expect(c4[2]).To(Equal(overrideBootstrapCommand))
+		})
+	})
+
+	Context("with permissions boundaries", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Metadata.Name = "test-permissions-boundary"
+
+		cfg.IAM.PermissionsBoundaryARN = "arn:aws:iam::123456789012:policy/cluster-boundary"
+		ng.IAM.PermissionsBoundaryARN = "arn:aws:iam::123456789012:policy/nodegroup-boundary"
+
+		build(cfg, "eksctl-test-permissions-boundary-cluster", ng)
+
+		roundtrip()
+
+		It("should set the boundary of the cluster on the service role", func() {
+			Expect(clusterTemplate.Resources).To(HaveKey("ServiceRole"))
+			Expect(clusterTemplate.Resources["ServiceRole"].Properties.PermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/cluster-boundary"))
+		})
+
+		It("should set the boundary of the nodegroup on the instance role", func() {
+			Expect(ngTemplate.Resources).To(HaveKey("NodeInstanceRole"))
+			Expect(ngTemplate.Resources["NodeInstanceRole"].Properties.PermissionsBoundary).To(Equal("arn:aws:iam::123456789012:policy/nodegroup-boundary"))
 		})
 	})
 
@@ -2082,10 +2458,147 @@ var _ = Describe("CloudFormation template builder API", func() {
 			Expect(cp.ResourcesVpcConfig.SecurityGroupIds[0]).To(Equal(cfg.VPC.SecurityGroup))
 
 			Expect(cp.ResourcesVpcConfig.SubnetIds).To(HaveLen(6))
+
+			Expect(cp.KubernetesNetworkConfig).To(BeNil())
 		})
 
 	})
 
+	Context("with custom service CIDR", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Metadata.Name = "test-service-cidr"
+
+		cfg.IAM.ServiceRoleARN = "role-1"
+
+		cfg.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{
+			ServiceIPv4CIDR: "10.96.0.0/16",
+		}
+
+		build(cfg, "eksctl-test-service-cidr-cluster", ng)
+
+		roundtrip()
+
+		It("should set the service CIDR on the control plane", func() {
+			cp := clusterTemplate.Resources["ControlPlane"].Properties
+
+			Expect(cp.KubernetesNetworkConfig).ToNot(BeNil())
+			Expect(cp.KubernetesNetworkConfig.ServiceIpv4Cidr).To(Equal("10.96.0.0/16"))
+
+			Expect(clusterTemplate.Outputs).To(HaveKey("ServiceIPv4CIDR"))
+		})
+	})
+
+	Context("with private cluster endpoint", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Metadata.Name = "test-private-endpoint"
+
+		cfg.IAM.ServiceRoleARN = "role-1"
+
+		cfg.VPC.ClusterEndpoints = &api.ClusterEndpoints{
+			PublicAccess:  api.Disabled(),
+			PrivateAccess: api.Enabled(),
+		}
+
+		build(cfg, "eksctl-test-private-endpoint-cluster", ng)
+
+		roundtrip()
+
+		It("should set endpoint access on the control plane", func() {
+			cp := clusterTemplate.Resources["ControlPlane"].Properties
+
+			Expect(cp.ResourcesVpcConfig.EndpointPublicAccess).To(Equal(api.Disabled()))
+			Expect(cp.ResourcesVpcConfig.EndpointPrivateAccess).To(Equal(api.Enabled()))
+			Expect(cp.ResourcesVpcConfig.SecurityGroupIds).To(HaveLen(1))
+			Expect(cp.ResourcesVpcConfig.SubnetIds).To(HaveLen(6))
+		})
+	})
+
+	Context("with extra control plane ingress rules", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Metadata.Name = "test-extra-ingress"
+
+		cfg.VPC.ExtraControlPlaneIngressRules = []*api.ControlPlaneIngressRule{
+			{FromPort: 443, CIDR: ipnet.MustParseCIDR("10.0.0.0/8"), Description: "corporate network"},
+			{FromPort: 8443, ToPort: 9443, FromSharedNodeSecurityGroup: api.Enabled()},
+			{Protocol: api.ProtocolAll, SourceSecurityGroupID: "sg-bastion"},
+		}
+		cfg.VPC.ExtraControlPlaneSecurityGroups = []string{"sg-corporate"}
+
+		build(cfg, "eksctl-test-extra-ingress-cluster", ng)
+
+		roundtrip()
+
+		extraRules := func() map[string]Properties {
+			rules := map[string]Properties{}
+			for name, r := range clusterTemplate.Resources {
+				if strings.HasPrefix(name, ExtraControlPlaneIngressRuleResourcePrefix) {
+					rules[r.Properties.Description] = r.Properties
+				}
+			}
+			return rules
+		}
+
+		It("should add a rule to the control plane security group for each rule", func() {
+			rules := extraRules()
+			Expect(rules).To(HaveLen(3))
+
+			for _, r := range rules {
+				Expect(r.GroupId).To(Equal(map[string]interface{}{"Ref": "ControlPlaneSecurityGroup"}))
+			}
+
+			cidr := rules["corporate network"]
+			Expect(cidr.CidrIp).To(Equal("10.0.0.0/8"))
+			Expect(cidr.IpProtocol).To(Equal("tcp"))
+			Expect(cidr.FromPort).To(Equal(443))
+			Expect(cidr.ToPort).To(Equal(443))
+
+			nodes := rules["Allow tcp ports 8443-9443 from shared node security group to the control plane"]
+			Expect(nodes.SourceSecurityGroupId).To(Equal(map[string]interface{}{"Ref": "ClusterSharedNodeSecurityGroup"}))
+
+			all := rules["Allow -1 ports 0-65535 from sg-bastion to the control plane"]
+			Expect(all.SourceSecurityGroupId).To(Equal("sg-bastion"))
+			Expect(all.IpProtocol).To(Equal("-1"))
+		})
+
+		It("should attach the extra security groups to the control plane", func() {
+			Expect(clusterTemplate.Resources["ControlPlane"].Properties.ResourcesVpcConfig.SecurityGroupIds).To(Equal([]interface{}{
+				map[string]interface{}{"Ref": "ControlPlaneSecurityGroup"},
+				"sg-corporate",
+			}))
+		})
+	})
+
+	Context("with pre-defined security groups", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Metadata.Name = "test-predefined-sgs"
+
+		cfg.VPC.SecurityGroup = "sg-control-plane"
+		cfg.VPC.SharedNodeSecurityGroup = "sg-shared"
+		cfg.VPC.ManageSharedNodeSecurityGroupRules = api.Enabled()
+
+		build(cfg, "eksctl-test-predefined-sgs-cluster", ng)
+
+		roundtrip()
+
+		It("should use the security groups instead of creating new ones", func() {
+			Expect(clusterTemplate.Resources).ToNot(HaveKey("ControlPlaneSecurityGroup"))
+			Expect(clusterTemplate.Resources).ToNot(HaveKey("ClusterSharedNodeSecurityGroup"))
+			Expect(clusterTemplate.Resources["ControlPlane"].Properties.ResourcesVpcConfig.SecurityGroupIds).To(Equal([]interface{}{"sg-control-plane"}))
+		})
+
+		It("should add the rule between nodes to the shared node security group", func() {
+			Expect(clusterTemplate.Resources).To(HaveKey("IngressInterNodeGroupSG"))
+			ingress := clusterTemplate.Resources["IngressInterNodeGroupSG"].Properties
+			Expect(ingress.GroupId).To(Equal("sg-shared"))
+			Expect(ingress.SourceSecurityGroupId).To(Equal("sg-shared"))
+			Expect(ingress.IpProtocol).To(Equal("-1"))
+		})
+	})
+
 	Context("without VPC", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
@@ -2335,6 +2848,74 @@ var _ = Describe("CloudFormation template builder API", func() {
 
 	})
 
+	Context("VPC for IPv6 cluster", func() {
+		cfg, ng := newClusterConfigAndNodegroup(false)
+
+		cfg.Metadata.Version = api.IPv6MinimumKubernetesVersion
+		cfg.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{
+			IPFamily: api.IPV6Family,
+		}
+		cfg.Status.ServiceIPv6CIDR = "fd12:3456:789a::/108"
+
+		cfg.VPC.AutoAllocateIPv6 = api.Enabled()
+
+		setSubnets(cfg)
+
+		build(cfg, "eksctl-test-IPv6-cluster", ng)
+
+		roundtrip()
+
+		It("should create the control plane with IPv6 family", func() {
+			cp := clusterTemplate.Resources["ControlPlane"].Properties
+
+			Expect(cp.KubernetesNetworkConfig).ToNot(BeNil())
+			Expect(cp.KubernetesNetworkConfig.IpFamily).To(Equal("ipv6"))
+			Expect(cp.KubernetesNetworkConfig.ServiceIpv4Cidr).To(BeEmpty())
+
+			Expect(clusterTemplate.Outputs).To(HaveKey("ServiceIPv6CIDR"))
+		})
+
+		It("should create dual-stack subnets", func() {
+			for _, suffix1 := range []string{"PrivateUSWEST2", "PublicUSWEST2"} {
+				for _, suffix2 := range []string{"A", "B", "C"} {
+					suffix := suffix1 + suffix2
+					Expect(clusterTemplate.Resources).ToNot(HaveKey(suffix + "CIDRv6"))
+
+					subnet := clusterTemplate.Resources["Subnet"+suffix].Properties
+					Expect(subnet.AssignIpv6AddressOnCreation).To(BeTrue())
+					Expect(subnet.Ipv6CidrBlock["Fn::Select"]).To(HaveLen(2))
+					isRefTo(subnet.VpcId, "VPC")
+				}
+			}
+		})
+
+		It("should route IPv6 traffic through internet gateways", func() {
+			Expect(clusterTemplate.Resources).To(HaveKey("EgressOnlyInternetGateway"))
+			isRefTo(clusterTemplate.Resources["EgressOnlyInternetGateway"].Properties.VpcId, "VPC")
+
+			publicRoute := clusterTemplate.Resources["PublicSubnetIPv6DefaultRoute"].Properties
+			Expect(publicRoute.DestinationIpv6CidrBlock).To(Equal("::/0"))
+			isRefTo(publicRoute.GatewayId, "InternetGateway")
+			isRefTo(publicRoute.RouteTableId, "PublicRouteTable")
+
+			for _, zone := range []string{"A", "B", "C"} {
+				privateRoute := clusterTemplate.Resources["PrivateSubnetIPv6DefaultRouteUSWEST2"+zone].Properties
+				Expect(privateRoute.DestinationIpv6CidrBlock).To(Equal("::/0"))
+				isRefTo(privateRoute.EgressOnlyInternetGatewayId, "EgressOnlyInternetGateway")
+				isRefTo(privateRoute.RouteTableId, "PrivateRouteTableUSWEST2"+zone)
+			}
+		})
+
+		It("should allow nodes to manage IPv6 addresses and send IPv6 traffic", func() {
+			Expect(ngTemplate.Resources).To(HaveKey("PolicyCNIIPv6"))
+			Expect(ngTemplate.Resources["PolicyCNIIPv6"].Properties.PolicyDocument.Statement[0].Action).To(ContainElement("ec2:AssignIpv6Addresses"))
+			Expect(ngTemplate.Resources).To(HaveKey("PolicyCNIIPv6Tagging"))
+
+			Expect(ngTemplate.Resources).To(HaveKey("EgressAllIPv6"))
+			Expect(ngTemplate.Resources["EgressAllIPv6"].Properties.CidrIpv6).To(Equal("::/0"))
+		})
+	})
+
 	Context("VPC with highly available NAT gateways", func() {
 
 		zones := []string{"A", "B", "C"}
@@ -2471,6 +3052,268 @@ var _ = Describe("CloudFormation template builder API", func() {
 
 	})
 
+	Context("VPC with Local Zones and Wavelength Zones", func() {
+		cfg, ng := newClusterConfigAndNodegroup(false)
+
+		cfg.Metadata.Name = "test-local-zones-VPC"
+		cfg.LocalZones = []string{"us-west-2-lax-1a", "us-west-2-wl1-las-wlz-1"}
+
+		single := api.ClusterSingleNAT
+		cfg.VPC.NAT = &api.ClusterNAT{
+			Gateway: &single,
+		}
+
+		setSubnets(cfg)
+
+		build(cfg, "eksctl-test-local-zones-VPC-cluster", ng)
+
+		roundtrip()
+
+		It("should create subnets in Local Zones, and only public ones in Wavelength Zones", func() {
+			Expect(cfg.VPC.LocalZoneSubnets.Public).To(HaveLen(2))
+			Expect(cfg.VPC.LocalZoneSubnets.Private).To(HaveLen(1))
+
+			Expect(clusterTemplate.Resources).To(HaveKey("SubnetLocalZonePublicUSWEST2LAX1A"))
+			Expect(clusterTemplate.Resources).To(HaveKey("SubnetLocalZonePrivateUSWEST2LAX1A"))
+			Expect(clusterTemplate.Resources).To(HaveKey("SubnetLocalZonePublicUSWEST2WL1LASWLZ1"))
+			Expect(clusterTemplate.Resources).ToNot(HaveKey("SubnetLocalZonePrivateUSWEST2WL1LASWLZ1"))
+			Expect(clusterTemplate.Outputs).To(HaveKey("LocalZoneSubnetsPrivate"))
+			Expect(clusterTemplate.Outputs).To(HaveKey("LocalZoneSubnetsPublic"))
+		})
+
+		It("should route Internet traffic of Local Zones through the region, and of Wavelength Zones through a carrier gateway", func() {
+			isRefTo(clusterTemplate.Resources["RouteTableAssociationLocalZonePrivateUSWEST2LAX1A"].Properties.RouteTableId, "PrivateRouteTableUSWEST2B")
+			isRefTo(clusterTemplate.Resources["RouteTableAssociationLocalZonePublicUSWEST2LAX1A"].Properties.RouteTableId, "PublicRouteTable")
+			isRefTo(clusterTemplate.Resources["RouteTableAssociationLocalZonePublicUSWEST2WL1LASWLZ1"].Properties.RouteTableId, "CarrierRouteTable")
+			Expect(clusterTemplate.Resources).To(HaveKey("CarrierGateway"))
+			isRefTo(clusterTemplate.Resources["CarrierSubnetRoute"].Properties.RouteTableId, "CarrierRouteTable")
+		})
+
+		It("should not use subnets of Local Zones for the control plane", func() {
+			Expect(clusterTemplate.Resources["ControlPlane"].Properties.ResourcesVpcConfig.SubnetIds).To(HaveLen(6))
+		})
+	})
+
+	Context("NodeGroup{LocalZones}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.VPC.LocalZoneSubnets = &api.ClusterSubnets{
+			Private: map[string]api.Network{
+				"us-west-2-lax-1a": {ID: "subnet-lax-private"},
+				"us-west-2b":       {ID: "subnet-outpost-private", OutpostARN: "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"},
+			},
+			Public: map[string]api.Network{
+				"us-west-2-lax-1a":        {ID: "subnet-lax-public"},
+				"us-west-2-wl1-las-wlz-1": {ID: "subnet-wavelength"},
+			},
+		}
+
+		ng.PrivateNetworking = true
+		ng.LocalZones = []string{"us-west-2-lax-1a"}
+
+		build(cfg, "eksctl-test-local-zones-ng", ng)
+
+		roundtrip()
+
+		It("should only use the subnets of the Local Zones", func() {
+			Expect(getNodeGroupProperties(ngTemplate).VPCZoneIdentifier).To(Equal([]interface{}{"subnet-lax-private"}))
+		})
+	})
+
+	Context("NodeGroup{Subnets}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.PrivateNetworking = true
+		ng.Subnets = []string{"subnet-0ade11bad78dced9f"}
+
+		build(cfg, "eksctl-test-single-az-ng", ng)
+
+		roundtrip()
+
+		It("should only use the given subnets", func() {
+			Expect(getNodeGroupProperties(ngTemplate).VPCZoneIdentifier).To(Equal([]interface{}{"subnet-0ade11bad78dced9f"}))
+		})
+	})
+
+	Context("NodeGroup{ASGLifecycleHooks, WarmPool}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		ng.ASGLifecycleHooks = []*api.NodeGroupLifecycleHook{
+			{
+				Name:                  "drain",
+				Transition:            api.LifecycleTransitionTerminating,
+				HeartbeatTimeout:      aws.Int(300),
+				NotificationTargetARN: "arn:aws:sqs:us-west-2:123456789012:nth",
+				RoleARN:               "arn:aws:iam::123456789012:role/asg-notifications",
+			},
+		}
+		ng.WarmPool = &api.NodeGroupWarmPool{MinSize: aws.Int(1)}
+
+		build(cfg, "eksctl-test-warm-pool-ng", ng)
+
+		roundtrip()
+
+		It("should add the lifecycle hooks to the ASG", func() {
+			Expect(getNodeGroupProperties(ngTemplate).LifecycleHookSpecificationList).To(Equal([]map[string]string{
+				{
+					"LifecycleHookName":     "drain",
+					"LifecycleTransition":   "autoscaling:EC2_INSTANCE_TERMINATING",
+					"HeartbeatTimeout":      "300",
+					"NotificationTargetARN": "arn:aws:sqs:us-west-2:123456789012:nth",
+					"RoleARN":               "arn:aws:iam::123456789012:role/asg-notifications",
+				},
+			}))
+		})
+
+		It("should add a warm pool to the ASG", func() {
+			Expect(ngTemplate.Resources).To(HaveKey("NodeGroupWarmPool"))
+			warmPool := ngTemplate.Resources["NodeGroupWarmPool"]
+			isRefTo(warmPool.Properties.AutoScalingGroupName, "NodeGroup")
+			Expect(warmPool.Properties.MinSize).To(Equal("1"))
+			Expect(warmPool.Properties.MaxGroupPreparedCapacity).To(BeEmpty())
+		})
+
+		It("should replace one instance at a time by default", func() {
+			Expect(ngTemplate.Resources["NodeGroup"].UpdatePolicy).To(Equal(map[string]map[string]string{
+				"AutoScalingRollingUpdate": {"MinInstancesInService": "0", "MaxBatchSize": "1"},
+			}))
+		})
+	})
+
+	Context("NodeGroup{UpdateConfig}", func() {
+		It("should roll instances in batches, keeping the healthy percentage in service", func() {
+			_, ng := newClusterConfigAndNodegroup(true)
+			ng.DesiredCapacity, ng.MaxSize = aws.Int(5), aws.Int(10)
+			ng.UpdateConfig = &api.NodeGroupUpdateConfig{
+				Strategy:             api.NodeGroupUpdateStrategyInstanceRefresh,
+				MaxUnavailable:       aws.Int(2),
+				MinHealthyPercentage: aws.Int(50),
+			}
+			Expect(nodeGroupUpdatePolicy(ng)).To(Equal(map[string]map[string]string{
+				"AutoScalingRollingUpdate": {"MinInstancesInService": "3", "MaxBatchSize": "2"},
+			}))
+
+			ng.MaxSize = aws.Int(3)
+			ng.UpdateConfig.MinHealthyPercentage = aws.Int(100)
+			Expect(nodeGroupUpdatePolicy(ng)["AutoScalingRollingUpdate"]["MinInstancesInService"]).To(Equal("2"))
+		})
+
+		It("should replace the ASG", func() {
+			_, ng := newClusterConfigAndNodegroup(true)
+			ng.UpdateConfig = &api.NodeGroupUpdateConfig{Strategy: api.NodeGroupUpdateStrategyReplaceNodeGroup}
+			Expect(nodeGroupUpdatePolicy(ng)).To(Equal(map[string]map[string]string{
+				"AutoScalingReplacingUpdate": {"WillReplace": "true"},
+			}))
+		})
+	})
+
+	Context("NodeGroup{InstancesDistribution} with Node Termination Handler in queue mode", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{Install: api.Enabled(), Mode: api.NodeTerminationHandlerModeQueue}
+		ng.InstanceType = "mixed"
+		ng.InstancesDistribution = &api.NodeGroupInstancesDistribution{
+			InstanceTypes:                       []string{"m5.large", "m5a.large"},
+			OnDemandPercentageAboveBaseCapacity: aws.Int(0),
+		}
+
+		build(cfg, "eksctl-test-spot-ng", ng)
+
+		roundtrip()
+
+		It("should add a termination lifecycle hook and the managed tag to the ASG", func() {
+			properties := getNodeGroupProperties(ngTemplate)
+			Expect(properties.LifecycleHookSpecificationList).To(Equal([]map[string]string{
+				{
+					"LifecycleHookName":   "node-termination-handler",
+					"LifecycleTransition": "autoscaling:EC2_INSTANCE_TERMINATING",
+					"HeartbeatTimeout":    "300",
+					"DefaultResult":       "CONTINUE",
+				},
+			}))
+			Expect(properties.Tags).To(ContainElement(Tag{Key: "aws-node-termination-handler/managed", Value: "", PropagateAtLaunch: "false"}))
+		})
+
+		It("should allow nodes to consume the queue", func() {
+			Expect(ngTemplate.Resources).To(HaveKey("PolicyNodeTerminationHandlerQueue"))
+			Expect(ngTemplate.Resources).To(HaveKey("PolicyNodeTerminationHandler"))
+		})
+	})
+
+	Context("NodeGroup{OutpostARN}", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.VPC.LocalZoneSubnets = &api.ClusterSubnets{
+			Private: map[string]api.Network{
+				"us-west-2-lax-1a": {ID: "subnet-lax-private"},
+				"us-west-2b":       {ID: "subnet-outpost-private", OutpostARN: "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"},
+			},
+		}
+
+		ng.PrivateNetworking = true
+		ng.OutpostARN = "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0"
+
+		build(cfg, "eksctl-test-outpost-ng", ng)
+
+		roundtrip()
+
+		It("should only use the subnets of the Outpost", func() {
+			Expect(getNodeGroupProperties(ngTemplate).VPCZoneIdentifier).To(Equal([]interface{}{"subnet-outpost-private"}))
+		})
+	})
+
+	Context("NodeGroup{LocalZones} in a Wavelength Zone", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.VPC.LocalZoneSubnets = &api.ClusterSubnets{
+			Public: map[string]api.Network{
+				"us-west-2-wl1-las-wlz-1": {ID: "subnet-wavelength"},
+			},
+		}
+
+		ng.LocalZones = []string{"us-west-2-wl1-las-wlz-1"}
+
+		build(cfg, "eksctl-test-wavelength-ng", ng)
+
+		roundtrip()
+
+		It("should assign carrier IP addresses instead of public ones", func() {
+			Expect(getNodeGroupProperties(ngTemplate).VPCZoneIdentifier).To(Equal([]interface{}{"subnet-wavelength"}))
+			networkInterface := getLaunchTemplateData(ngTemplate).NetworkInterfaces[0]
+			Expect(networkInterface.AssociateCarrierIpAddress).To(BeTrue())
+			Expect(networkInterface.AssociatePublicIpAddress).To(BeFalse())
+		})
+	})
+
+	Context("Control plane on an Outpost", func() {
+		cfg, ng := newClusterConfigAndNodegroup(true)
+
+		cfg.Outpost = &api.Outpost{
+			ControlPlaneOutpostARN:   "arn:aws:outposts:us-west-2:123456789012:outpost/op-0123456789abcdef0",
+			ControlPlaneInstanceType: "m5d.large",
+			ControlPlanePlacement:    &api.OutpostPlacement{GroupName: "control-plane"},
+		}
+		ng.OutpostARN = cfg.Outpost.ControlPlaneOutpostARN
+
+		build(cfg, "eksctl-test-outpost-cluster", ng)
+
+		roundtrip()
+
+		It("should set the Outpost of the control plane", func() {
+			outpostConfig := clusterTemplate.Resources["ControlPlane"].Properties.OutpostConfig
+			Expect(outpostConfig).ToNot(BeNil())
+			Expect(outpostConfig.OutpostArns).To(Equal([]string{cfg.Outpost.ControlPlaneOutpostARN}))
+			Expect(outpostConfig.ControlPlaneInstanceType).To(Equal("m5d.large"))
+			Expect(outpostConfig.ControlPlanePlacement.GroupName).To(Equal("control-plane"))
+		})
+
+		It("should launch nodes in the subnets of the cluster", func() {
+			x, ok := getNodeGroupProperties(ngTemplate).VPCZoneIdentifier.(map[string]interface{})
+			Expect(ok).To(BeTrue())
+			Expect(x).To(HaveKey("Fn::Split"))
+		})
+	})
+
 	Context("Nodegroup with Mixed instances", func() {
 		cfg, ng := newClusterConfigAndNodegroup(true)
 
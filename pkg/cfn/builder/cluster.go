@@ -14,12 +14,13 @@ import (
 
 // ClusterResourceSet stores the resource information of the cluster
 type ClusterResourceSet struct {
-	rs             *resourceSet
-	spec           *api.ClusterConfig
-	provider       api.ClusterProvider
-	vpc            *gfn.Value
-	subnets        map[api.SubnetTopology][]*gfn.Value
-	securityGroups []*gfn.Value
+	rs               *resourceSet
+	spec             *api.ClusterConfig
+	provider         api.ClusterProvider
+	vpc              *gfn.Value
+	subnets          map[api.SubnetTopology][]*gfn.Value
+	localZoneSubnets map[api.SubnetTopology][]*gfn.Value
+	securityGroups   []*gfn.Value
 }
 
 // NewClusterResourceSet returns a resource set for the new cluster
@@ -96,11 +97,59 @@ func (c *ClusterResourceSet) addResourcesForControlPlane() {
 		serviceRoleARN = gfn.NewString(c.spec.IAM.ServiceRoleARN)
 	}
 
-	c.newResource("ControlPlane", &gfn.AWSEKSCluster{
-		Name:               gfn.NewString(c.spec.Metadata.Name),
-		RoleArn:            serviceRoleARN,
-		Version:            gfn.NewString(c.spec.Metadata.Version),
-		ResourcesVpcConfig: clusterVPC,
+	var resourcesVPCConfig interface{} = clusterVPC
+	if e := c.spec.VPC.ClusterEndpoints; e != nil {
+		// goformation's VPC config type doesn't have endpoint access settings yet
+		vpcConfig := map[string]interface{}{
+			"SecurityGroupIds": clusterVPC.SecurityGroupIds,
+			"SubnetIds":        clusterVPC.SubnetIds,
+		}
+		if e.PublicAccess != nil {
+			vpcConfig["EndpointPublicAccess"] = *e.PublicAccess
+		}
+		if e.PrivateAccess != nil {
+			vpcConfig["EndpointPrivateAccess"] = *e.PrivateAccess
+		}
+		resourcesVPCConfig = vpcConfig
+	}
+
+	controlPlaneProps := map[string]interface{}{
+		"Name":               gfn.NewString(c.spec.Metadata.Name),
+		"RoleArn":            serviceRoleARN,
+		"Version":            gfn.NewString(c.spec.Metadata.Version),
+		"ResourcesVpcConfig": resourcesVPCConfig,
+	}
+	if knc := c.spec.KubernetesNetworkConfig; knc != nil {
+		networkConfig := map[string]interface{}{}
+		if knc.ServiceIPv4CIDR != "" {
+			networkConfig["ServiceIpv4Cidr"] = knc.ServiceIPv4CIDR
+		}
+		if c.spec.IPv6Enabled() {
+			networkConfig["IpFamily"] = "ipv6"
+		}
+		if len(networkConfig) > 0 {
+			controlPlaneProps["KubernetesNetworkConfig"] = networkConfig
+		}
+	}
+
+	if o := c.spec.Outpost; o != nil {
+		outpostConfig := map[string]interface{}{
+			"OutpostArns":              []string{o.ControlPlaneOutpostARN},
+			"ControlPlaneInstanceType": o.ControlPlaneInstanceType,
+		}
+		if o.ControlPlanePlacement != nil {
+			outpostConfig["ControlPlanePlacement"] = map[string]interface{}{
+				"GroupName": o.ControlPlanePlacement.GroupName,
+			}
+		}
+		controlPlaneProps["OutpostConfig"] = outpostConfig
+	}
+
+	// goformation's cluster type doesn't have KubernetesNetworkConfig yet,
+	// so a custom resource is used for the control plane
+	c.newResource("ControlPlane", &awsCloudFormationResource{
+		Type:       "AWS::EKS::Cluster",
+		Properties: controlPlaneProps,
 	})
 
 	if c.spec.Status == nil {
@@ -123,6 +172,23 @@ func (c *ClusterResourceSet) addResourcesForControlPlane() {
 		c.spec.Status.ARN = v
 		return nil
 	})
+	c.rs.defineOutputFromAtt(outputs.ClusterOIDCIssuerURL, "ControlPlane.OpenIdConnectIssuerUrl", false, func(v string) error {
+		c.spec.Status.OIDCIssuerURL = v
+		return nil
+	})
+	if knc := c.spec.KubernetesNetworkConfig; knc != nil && knc.ServiceIPv4CIDR != "" {
+		// the control plane has no attribute for the IPv4 service CIDR, so the configured one is exported
+		c.rs.defineOutput(outputs.ClusterServiceIPv4CIDR, knc.ServiceIPv4CIDR, false, func(v string) error {
+			c.spec.Status.ServiceIPv4CIDR = v
+			return nil
+		})
+	}
+	if c.spec.IPv6Enabled() {
+		c.rs.defineOutputFromAtt(outputs.ClusterServiceIPv6CIDR, "ControlPlane.KubernetesNetworkConfig.ServiceIpv6Cidr", false, func(v string) error {
+			c.spec.Status.ServiceIPv6CIDR = v
+			return nil
+		})
+	}
 }
 
 // GetAllOutputs collects all outputs of the cluster
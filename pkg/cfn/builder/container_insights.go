@@ -0,0 +1,101 @@
+package builder
+
+import (
+	"fmt"
+	"strings"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	gfn "github.com/awslabs/goformation/cloudformation"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+// ContainerInsightsResourceSet stores the IAM role that CloudWatch agent and Fluent Bit assume
+// through the IAM OIDC provider of the cluster, it's created in a separate stack, so that it can
+// be deleted along with the cluster
+type ContainerInsightsResourceSet struct {
+	rs              *resourceSet
+	clusterSpec     *api.ClusterConfig
+	oidcProviderARN string
+	serviceAccounts []string
+
+	// RoleARN is collected from the outputs of the stack
+	RoleARN string
+}
+
+// NewContainerInsightsResourceSet returns a resource set for the IAM role of the given service
+// accounts, which are namespaced names, e.g. "amazon-cloudwatch:fluent-bit"
+func NewContainerInsightsResourceSet(spec *api.ClusterConfig, oidcProviderARN string, serviceAccounts []string) *ContainerInsightsResourceSet {
+	return &ContainerInsightsResourceSet{
+		rs:              newResourceSet(),
+		clusterSpec:     spec,
+		oidcProviderARN: oidcProviderARN,
+		serviceAccounts: serviceAccounts,
+	}
+}
+
+// AddAllResources adds the role with CloudWatchAgentServerPolicy attached, only tokens of the
+// service accounts issued for STS can be exchanged for its credentials
+func (c *ContainerInsightsResourceSet) AddAllResources() error {
+	if c.clusterSpec.Status == nil || c.clusterSpec.Status.OIDCIssuerURL == "" {
+		return fmt.Errorf("the OIDC issuer of cluster %q must be known to create the IAM role of Container Insights", c.clusterSpec.Metadata.Name)
+	}
+	if c.oidcProviderARN == "" || len(c.serviceAccounts) == 0 {
+		return fmt.Errorf("an IAM OIDC provider and service accounts are required to create the IAM role of Container Insights")
+	}
+
+	issuer := strings.TrimPrefix(c.clusterSpec.Status.OIDCIssuerURL, "https://")
+	subjects := []string{}
+	for _, sa := range c.serviceAccounts {
+		subjects = append(subjects, "system:serviceaccount:"+sa)
+	}
+
+	c.rs.withIAM = true
+	c.rs.newResource("Role", &gfn.AWSIAMRole{
+		AssumeRolePolicyDocument: makePolicyDocument(map[string]interface{}{
+			"Effect": "Allow",
+			"Principal": map[string]string{
+				"Federated": c.oidcProviderARN,
+			},
+			"Action": []string{"sts:AssumeRoleWithWebIdentity"},
+			"Condition": map[string]interface{}{
+				"StringEquals": map[string]interface{}{
+					issuer + ":sub": subjects,
+					issuer + ":aud": iam.OIDCProviderClientID,
+				},
+			},
+		}),
+		ManagedPolicyArns: makeStringSlice(api.ManagedPolicyARN(c.clusterSpec.Metadata.Region, iamPolicyCloudWatchAgentServerPolicy)),
+	})
+
+	c.rs.defineOutputFromAtt(outputs.ContainerInsightsRoleARN, "Role.Arn", false, func(v string) error {
+		c.RoleARN = v
+		return nil
+	})
+
+	c.rs.template.Description = fmt.Sprintf("EKS Container Insights IAM role %s", templateDescriptionSuffix)
+
+	return nil
+}
+
+// RenderJSON returns the rendered JSON
+func (c *ContainerInsightsResourceSet) RenderJSON() ([]byte, error) {
+	return c.rs.renderJSON()
+}
+
+// WithIAM states, if IAM roles will be created or not
+func (c *ContainerInsightsResourceSet) WithIAM() bool {
+	return c.rs.withIAM
+}
+
+// WithNamedIAM states, if specifically named IAM roles will be created or not
+func (c *ContainerInsightsResourceSet) WithNamedIAM() bool {
+	return c.rs.withNamedIAM
+}
+
+// GetAllOutputs collects all outputs of the Container Insights stack
+func (c *ContainerInsightsResourceSet) GetAllOutputs(stack cfn.Stack) error {
+	return c.rs.GetAllOutputs(stack)
+}
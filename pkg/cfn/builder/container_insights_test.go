@@ -0,0 +1,70 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+var _ = Describe("Container Insights stack builder", func() {
+	const oidcProviderARN = "arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"
+
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		cfg.Metadata.Region = "us-west-2"
+	})
+
+	It("should fail unless the OIDC issuer of the cluster is known", func() {
+		rs := NewContainerInsightsResourceSet(cfg, oidcProviderARN, []string{"amazon-cloudwatch:fluent-bit"})
+		Expect(rs.AddAllResources()).ToNot(Succeed())
+	})
+
+	It("should create a role that only the service accounts can assume", func() {
+		cfg.Status = &api.ClusterStatus{OIDCIssuerURL: "https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"}
+
+		rs := NewContainerInsightsResourceSet(cfg, oidcProviderARN, []string{"amazon-cloudwatch:cloudwatch-agent", "amazon-cloudwatch:fluent-bit"})
+		Expect(rs.AddAllResources()).To(Succeed())
+		Expect(rs.WithIAM()).To(BeTrue())
+		data, err := rs.RenderJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		template := struct {
+			Resources map[string]struct {
+				Type       string
+				Properties struct {
+					AssumeRolePolicyDocument struct {
+						Statement []struct {
+							Principal map[string]string
+							Action    []string
+							Condition map[string]map[string]interface{}
+						}
+					}
+					ManagedPolicyArns []string
+				}
+			}
+			Outputs map[string]interface{}
+		}{}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+
+		role := template.Resources["Role"]
+		Expect(role.Type).To(Equal("AWS::IAM::Role"))
+		Expect(role.Properties.ManagedPolicyArns).To(ConsistOf("arn:aws:iam::aws:policy/CloudWatchAgentServerPolicy"))
+
+		statement := role.Properties.AssumeRolePolicyDocument.Statement[0]
+		Expect(statement.Principal).To(HaveKeyWithValue("Federated", oidcProviderARN))
+		Expect(statement.Action).To(ConsistOf("sts:AssumeRoleWithWebIdentity"))
+		Expect(statement.Condition["StringEquals"]).To(HaveKeyWithValue("oidc.eks.us-west-2.amazonaws.com/id/ABCDEF:aud", "sts.amazonaws.com"))
+		Expect(statement.Condition["StringEquals"]).To(HaveKeyWithValue("oidc.eks.us-west-2.amazonaws.com/id/ABCDEF:sub", ConsistOf(
+			"system:serviceaccount:amazon-cloudwatch:cloudwatch-agent",
+			"system:serviceaccount:amazon-cloudwatch:fluent-bit",
+		)))
+		Expect(template.Outputs).To(HaveKey("RoleARN"))
+	})
+})
@@ -1,6 +1,8 @@
 package builder
 
 import (
+	"strings"
+
 	gfn "github.com/awslabs/goformation/cloudformation"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -8,21 +10,22 @@ import (
 	"github.com/weaveworks/eksctl/pkg/iam"
 )
 
+// names of the AWS managed policies, their ARNs depend on the partition
 const (
-	iamPolicyAmazonEKSServicePolicyARN = "arn:aws:iam::aws:policy/AmazonEKSServicePolicy"
-	iamPolicyAmazonEKSClusterPolicyARN = "arn:aws:iam::aws:policy/AmazonEKSClusterPolicy"
-
-	iamPolicyAmazonEKSWorkerNodePolicyARN           = "arn:aws:iam::aws:policy/AmazonEKSWorkerNodePolicy"
-	iamPolicyAmazonEKSCNIPolicyARN                  = "arn:aws:iam::aws:policy/AmazonEKS_CNI_Policy"
-	iamPolicyAmazonEC2ContainerRegistryPowerUserARN = "arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryPowerUser"
-	iamPolicyAmazonEC2ContainerRegistryReadOnlyARN  = "arn:aws:iam::aws:policy/AmazonEC2ContainerRegistryReadOnly"
-	iamPolicyCloudWatchAgentServerPolicyARN         = "arn:aws:iam::aws:policy/CloudWatchAgentServerPolicy"
+	iamPolicyAmazonEKSServicePolicy = "AmazonEKSServicePolicy"
+	iamPolicyAmazonEKSClusterPolicy = "AmazonEKSClusterPolicy"
+
+	iamPolicyAmazonEKSWorkerNodePolicy           = "AmazonEKSWorkerNodePolicy"
+	iamPolicyAmazonEKSCNIPolicy                  = "AmazonEKS_CNI_Policy"
+	iamPolicyAmazonEC2ContainerRegistryPowerUser = "AmazonEC2ContainerRegistryPowerUser"
+	iamPolicyAmazonEC2ContainerRegistryReadOnly  = "AmazonEC2ContainerRegistryReadOnly"
+	iamPolicyCloudWatchAgentServerPolicy         = "CloudWatchAgentServerPolicy"
 )
 
 var (
-	iamDefaultNodePolicyARNs = []string{
-		iamPolicyAmazonEKSWorkerNodePolicyARN,
-		iamPolicyAmazonEKSCNIPolicyARN,
+	iamDefaultNodePolicies = []string{
+		iamPolicyAmazonEKSWorkerNodePolicy,
+		iamPolicyAmazonEKSCNIPolicy,
 	}
 )
 
@@ -78,13 +81,17 @@ func (c *ClusterResourceSet) addResourcesForIAM() {
 
 	c.rs.withIAM = true
 
-	refSR := c.newResource("ServiceRole", &gfn.AWSIAMRole{
-		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument("eks.amazonaws.com"),
+	role := &gfn.AWSIAMRole{
+		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument(api.ServicePrincipal(c.spec.Metadata.Region, "eks")),
 		ManagedPolicyArns: makeStringSlice(
-			iamPolicyAmazonEKSServicePolicyARN,
-			iamPolicyAmazonEKSClusterPolicyARN,
+			api.ManagedPolicyARN(c.spec.Metadata.Region, iamPolicyAmazonEKSServicePolicy),
+			api.ManagedPolicyARN(c.spec.Metadata.Region, iamPolicyAmazonEKSClusterPolicy),
 		),
-	})
+	}
+	if c.spec.IAM.PermissionsBoundaryARN != "" {
+		role.PermissionsBoundary = gfn.NewString(c.spec.IAM.PermissionsBoundaryARN)
+	}
+	refSR := c.newResource("ServiceRole", role)
 	c.rs.attachAllowPolicy("PolicyNLB", refSR, "*", []string{
 		"elasticloadbalancing:*",
 		"ec2:CreateSecurityGroup",
@@ -124,6 +131,10 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 		if n.spec.IAM.InstanceRoleARN != "" {
 			n.rs.defineOutputWithoutCollector(outputs.NodeGroupInstanceProfileARN, n.spec.IAM.InstanceProfileARN, true)
 			n.rs.defineOutputWithoutCollector(outputs.NodeGroupInstanceRoleARN, n.spec.IAM.InstanceRoleARN, true)
+			if n.spec.IAM.NodeRole != "" {
+				// the role is shared, so it's only removed from the aws-auth ConfigMap along with the cluster
+				n.rs.defineOutputWithoutCollector(outputs.NodeGroupNodeRole, n.spec.IAM.NodeRole, false)
+			}
 			return
 		}
 		// if instance role is not given, export profile and use the getter to call importer function
@@ -138,9 +149,10 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 
 	if n.spec.IAM.InstanceRoleARN != "" {
 		// if role is set, but profile isn't - create profile
+		// the role can be shared with other nodegroups, each of them gets its own profile
 		n.newResource("NodeInstanceProfile", &gfn.AWSIAMInstanceProfile{
 			Path:  gfn.NewString("/"),
-			Roles: makeStringSlice(n.spec.IAM.InstanceRoleARN),
+			Roles: makeStringSlice(iam.NameFromARN(n.spec.IAM.InstanceRoleARN)),
 		})
 		n.instanceProfileARN = gfn.MakeFnGetAttString("NodeInstanceProfile.Arn")
 		n.rs.defineOutputFromAtt(outputs.NodeGroupInstanceProfileARN, "NodeInstanceProfile.Arn", true, func(v string) error {
@@ -152,239 +164,402 @@ func (n *NodeGroupResourceSet) addResourcesForIAM() {
 	}
 
 	// if neither role nor profile are given - create both
+	n.rs.addResourcesForInstanceRole("", n.clusterSpec, n.spec.IAM, n.permissionsBoundaryARN())
+	n.instanceProfileARN = gfn.MakeFnGetAttString("NodeInstanceProfile.Arn")
+
+	n.rs.defineOutputFromAtt(outputs.NodeGroupInstanceProfileARN, "NodeInstanceProfile.Arn", true, func(v string) error {
+		n.spec.IAM.InstanceProfileARN = v
+		return nil
+	})
+	n.rs.defineOutputFromAtt(outputs.NodeGroupInstanceRoleARN, "NodeInstanceRole.Arn", true, func(v string) error {
+		n.spec.IAM.InstanceRoleARN = v
+		return nil
+	})
+}
 
-	if n.spec.IAM.InstanceRoleName != "" {
+// addResourcesForInstanceRole adds an instance role with the policies of iamSpec, along with
+// its instance profile; logical IDs start with prefix, so that a stack can have several roles,
+// e.g. NodeInstanceRole and NodeInstanceProfile without a prefix
+func (c *resourceSet) addResourcesForInstanceRole(prefix string, clusterSpec *api.ClusterConfig, iamSpec *api.NodeGroupIAM, permissionsBoundaryARN string) {
+	if iamSpec.InstanceRoleName != "" {
 		// setting role name requires additional capabilities
-		n.rs.withNamedIAM = true
+		c.withNamedIAM = true
 	}
 
-	if len(n.spec.IAM.AttachPolicyARNs) == 0 {
-		n.spec.IAM.AttachPolicyARNs = iamDefaultNodePolicyARNs
-	}
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.ImageBuilder) {
-		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, iamPolicyAmazonEC2ContainerRegistryPowerUserARN)
-	} else {
-		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, iamPolicyAmazonEC2ContainerRegistryReadOnlyARN)
+	region := clusterSpec.Metadata.Region
+	if len(iamSpec.AttachPolicyARNs) == 0 {
+		for _, policy := range iamDefaultNodePolicies {
+			iamSpec.AttachPolicyARNs = append(iamSpec.AttachPolicyARNs, api.ManagedPolicyARN(region, policy))
+		}
 	}
+	// attachPolicy replaces the ECR and CloudWatch managed policies, along with the addon policies
+	if iamSpec.AttachPolicy == nil {
+		if api.IsEnabled(iamSpec.WithAddonPolicies.ImageBuilder) {
+			iamSpec.AttachPolicyARNs = append(iamSpec.AttachPolicyARNs, api.ManagedPolicyARN(region, iamPolicyAmazonEC2ContainerRegistryPowerUser))
+		} else {
+			iamSpec.AttachPolicyARNs = append(iamSpec.AttachPolicyARNs, api.ManagedPolicyARN(region, iamPolicyAmazonEC2ContainerRegistryReadOnly))
+		}
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.CloudWatch) {
-		n.spec.IAM.AttachPolicyARNs = append(n.spec.IAM.AttachPolicyARNs, iamPolicyCloudWatchAgentServerPolicyARN)
+		if api.IsEnabled(iamSpec.WithAddonPolicies.CloudWatch) {
+			iamSpec.AttachPolicyARNs = append(iamSpec.AttachPolicyARNs, api.ManagedPolicyARN(region, iamPolicyCloudWatchAgentServerPolicy))
+		}
 	}
 
 	role := gfn.AWSIAMRole{
 		Path:                     gfn.NewString("/"),
-		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument("ec2.amazonaws.com"),
-		ManagedPolicyArns:        makeStringSlice(n.spec.IAM.AttachPolicyARNs...),
+		AssumeRolePolicyDocument: makeAssumeRolePolicyDocument(api.ServicePrincipal(region, "ec2")),
+		ManagedPolicyArns:        makeStringSlice(iamSpec.AttachPolicyARNs...),
 	}
 
-	if n.spec.IAM.InstanceRoleName != "" {
-		role.RoleName = gfn.NewString(n.spec.IAM.InstanceRoleName)
+	if iamSpec.InstanceRoleName != "" {
+		role.RoleName = gfn.NewString(iamSpec.InstanceRoleName)
+	}
+	if permissionsBoundaryARN != "" {
+		role.PermissionsBoundary = gfn.NewString(permissionsBoundaryARN)
 	}
 
-	refIR := n.newResource("NodeInstanceRole", &role)
+	refIR := c.newResource(prefix+"NodeInstanceRole", &role)
 
-	n.newResource("NodeInstanceProfile", &gfn.AWSIAMInstanceProfile{
+	c.newResource(prefix+"NodeInstanceProfile", &gfn.AWSIAMInstanceProfile{
 		Path:  gfn.NewString("/"),
 		Roles: makeSlice(refIR),
 	})
-	n.instanceProfileARN = gfn.MakeFnGetAttString("NodeInstanceProfile.Arn")
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.AutoScaler) {
-		n.rs.attachAllowPolicy("PolicyAutoScaling", refIR, "*",
+	if clusterSpec.IPv6Enabled() {
+		// AmazonEKS_CNI_Policy only covers IPv4 address management
+		c.attachAllowPolicy(prefix+"PolicyCNIIPv6", refIR, "*",
 			[]string{
-				"autoscaling:DescribeAutoScalingGroups",
-				"autoscaling:DescribeAutoScalingInstances",
-				"autoscaling:DescribeLaunchConfigurations",
-				"autoscaling:DescribeTags",
-				"autoscaling:SetDesiredCapacity",
-				"autoscaling:TerminateInstanceInAutoScalingGroup",
-				"ec2:DescribeLaunchTemplateVersions",
+				"ec2:AssignIpv6Addresses",
+				"ec2:DescribeInstances",
+				"ec2:DescribeInstanceTypes",
+				"ec2:DescribeNetworkInterfaces",
+				"ec2:DescribeTags",
+			},
+		)
+		c.attachAllowPolicy(prefix+"PolicyCNIIPv6Tagging", refIR, "arn:"+api.Partition(region)+":ec2:*:*:network-interface/*",
+			[]string{
+				"ec2:CreateTags",
 			},
 		)
 	}
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.CertManager) {
-		n.rs.attachAllowPolicy("PolicyCertManagerChangeSet", refIR, "arn:aws:route53:::hostedzone/*",
+	if clusterSpec.NodeTerminationHandlerQueueMode() {
+		// Node Termination Handler can run on any node, so every nodegroup can consume the queue
+		c.attachAllowPolicy(prefix+"PolicyNodeTerminationHandlerQueue", refIR,
+			gfn.MakeFnSubString("arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:"+clusterSpec.NodeTerminationHandlerQueueName()),
 			[]string{
-				"route53:ChangeResourceRecordSets",
+				"sqs:DeleteMessage",
+				"sqs:ReceiveMessage",
 			},
 		)
-		n.rs.attachAllowPolicy("PolicyCertManagerHostedZones", refIR, "*",
+		c.attachAllowPolicy(prefix+"PolicyNodeTerminationHandler", refIR, "*",
 			[]string{
+				"autoscaling:CompleteLifecycleAction",
+				"autoscaling:DescribeAutoScalingInstances",
+				"autoscaling:DescribeTags",
+				"ec2:DescribeInstances",
+			},
+		)
+	}
+
+	if iamSpec.AttachPolicy != nil {
+		c.newResource(prefix+"PolicyAttached", &gfn.AWSIAMPolicy{
+			PolicyName:     makeName(prefix + "PolicyAttached"),
+			Roles:          makeSlice(refIR),
+			PolicyDocument: iamSpec.AttachPolicy,
+		})
+	} else {
+		for _, s := range addonPolicyStatements(iamSpec, api.Partition(region), false) {
+			c.attachAllowPolicy(prefix+s.name, refIR, s.resources, s.actions)
+		}
+	}
+}
+
+// allowStatement is a statement of an inline policy that allows actions on resources
+type allowStatement struct {
+	name      string
+	resources interface{}
+	actions   []string
+}
+
+// addonPolicyStatements returns the statements of the inline policies of the addons enabled
+// in iam.withAddonPolicies, with ARNs in the given partition; when minimal is set, statements
+// that pull and push images and run the CloudWatch agent replace the managed policies, and
+// wildcard actions are narrowed down to the actions the addons use
+func addonPolicyStatements(iamSpec *api.NodeGroupIAM, partition string, minimal bool) []allowStatement {
+	addons := iamSpec.WithAddonPolicies
+	arn := func(resource string) string {
+		return "arn:" + partition + ":" + resource
+	}
+	statements := []allowStatement{}
+
+	if minimal {
+		ecrActions := []string{
+			"ecr:GetAuthorizationToken",
+			"ecr:BatchCheckLayerAvailability",
+			"ecr:GetDownloadUrlForLayer",
+			"ecr:BatchGetImage",
+		}
+		if api.IsEnabled(addons.ImageBuilder) {
+			ecrActions = append(ecrActions,
+				"ecr:DescribeRepositories",
+				"ecr:DescribeImages",
+				"ecr:ListImages",
+				"ecr:InitiateLayerUpload",
+				"ecr:UploadLayerPart",
+				"ecr:CompleteLayerUpload",
+				"ecr:PutImage",
+			)
+		}
+		statements = append(statements, allowStatement{"PolicyECR", "*", ecrActions})
+
+		if api.IsEnabled(addons.CloudWatch) {
+			statements = append(statements,
+				allowStatement{"PolicyCloudWatchAgent", "*", []string{
+					"cloudwatch:PutMetricData",
+					"ec2:DescribeTags",
+					"ec2:DescribeVolumes",
+					"logs:CreateLogGroup",
+					"logs:CreateLogStream",
+					"logs:DescribeLogGroups",
+					"logs:DescribeLogStreams",
+					"logs:PutLogEvents",
+				}},
+				allowStatement{"PolicyCloudWatchAgentConfig", arn("ssm:*:*:parameter/AmazonCloudWatch-*"), []string{
+					"ssm:GetParameter",
+				}},
+			)
+		}
+	}
+
+	if api.IsEnabled(addons.AutoScaler) {
+		statements = append(statements, allowStatement{"PolicyAutoScaling", "*", []string{
+			"autoscaling:DescribeAutoScalingGroups",
+			"autoscaling:DescribeAutoScalingInstances",
+			"autoscaling:DescribeLaunchConfigurations",
+			"autoscaling:DescribeTags",
+			"autoscaling:SetDesiredCapacity",
+			"autoscaling:TerminateInstanceInAutoScalingGroup",
+			"ec2:DescribeLaunchTemplateVersions",
+		}})
+	}
+
+	if api.IsEnabled(addons.CertManager) {
+		statements = append(statements,
+			allowStatement{"PolicyCertManagerChangeSet", arn("route53:::hostedzone/*"), []string{
+				"route53:ChangeResourceRecordSets",
+			}},
+			allowStatement{"PolicyCertManagerHostedZones", "*", []string{
 				"route53:ListHostedZones",
 				"route53:ListResourceRecordSets",
 				"route53:ListHostedZonesByName",
-			},
-		)
-		n.rs.attachAllowPolicy("PolicyCertManagerGetChange", refIR, "arn:aws:route53:::change/*",
-			[]string{
+			}},
+			allowStatement{"PolicyCertManagerGetChange", arn("route53:::change/*"), []string{
 				"route53:GetChange",
-			},
+			}},
 		)
-	} else if api.IsEnabled(n.spec.IAM.WithAddonPolicies.ExternalDNS) {
-		n.rs.attachAllowPolicy("PolicyExternalDNSChangeSet", refIR, "arn:aws:route53:::hostedzone/*",
-			[]string{
+	} else if api.IsEnabled(addons.ExternalDNS) {
+		statements = append(statements,
+			allowStatement{"PolicyExternalDNSChangeSet", arn("route53:::hostedzone/*"), []string{
 				"route53:ChangeResourceRecordSets",
-			},
-		)
-		n.rs.attachAllowPolicy("PolicyExternalDNSHostedZones", refIR, "*",
-			[]string{
+			}},
+			allowStatement{"PolicyExternalDNSHostedZones", "*", []string{
 				"route53:ListHostedZones",
 				"route53:ListResourceRecordSets",
-			},
+			}},
 		)
 	}
 
-
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.AppMesh) {
-		n.rs.attachAllowPolicy("PolicyAppMesh", refIR, "*",
-			[]string{
-				"appmesh:*",
-			},
-		)
+	if api.IsEnabled(addons.AppMesh) {
+		appMeshActions := []string{"appmesh:*"}
+		if minimal {
+			appMeshActions = []string{
+				"appmesh:StreamAggregatedResources",
+				"appmesh:Describe*",
+				"appmesh:List*",
+				"appmesh:Create*",
+				"appmesh:Update*",
+				"appmesh:Delete*",
+			}
+		}
+		statements = append(statements, allowStatement{"PolicyAppMesh", "*", appMeshActions})
 	}
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.EBS) {
-		n.rs.attachAllowPolicy("PolicyEBS", refIR, "*",
-			[]string{
-				"ec2:AttachVolume",
-				"ec2:CreateSnapshot",
-				"ec2:CreateTags",
-				"ec2:CreateVolume",
-				"ec2:DeleteSnapshot",
-				"ec2:DeleteTags",
-				"ec2:DeleteVolume",
-				"ec2:DescribeInstances",
-				"ec2:DescribeSnapshots",
-				"ec2:DescribeTags",
-				"ec2:DescribeVolumes",
-				"ec2:DetachVolume",
-			},
-		)
+	if api.IsEnabled(addons.EBS) {
+		statements = append(statements, allowStatement{"PolicyEBS", "*", []string{
+			"ec2:AttachVolume",
+			"ec2:CreateSnapshot",
+			"ec2:CreateTags",
+			"ec2:CreateVolume",
+			"ec2:DeleteSnapshot",
+			"ec2:DeleteTags",
+			"ec2:DeleteVolume",
+			"ec2:DescribeInstances",
+			"ec2:DescribeSnapshots",
+			"ec2:DescribeTags",
+			"ec2:DescribeVolumes",
+			"ec2:DetachVolume",
+		}})
 	}
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.FSX) {
-		n.rs.attachAllowPolicy("PolicyFSX", refIR, "*",
-			[]string{
-				"fsx:*",
-			},
-		)
-		n.rs.attachAllowPolicy("PolicyServiceLinkRole", refIR, "arn:aws:iam::*:role/aws-service-role/*",
-			[]string{
+	if api.IsEnabled(addons.FSX) {
+		fsxActions := []string{"fsx:*"}
+		if minimal {
+			fsxActions = []string{
+				"fsx:CreateFileSystem",
+				"fsx:DeleteFileSystem",
+				"fsx:DescribeFileSystems",
+				"fsx:TagResource",
+			}
+		}
+		statements = append(statements,
+			allowStatement{"PolicyFSX", "*", fsxActions},
+			allowStatement{"PolicyServiceLinkRole", arn("iam::*:role/aws-service-role/*"), []string{
 				"iam:CreateServiceLinkedRole",
 				"iam:AttachRolePolicy",
 				"iam:PutRolePolicy",
-			},
+			}},
 		)
 	}
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.EFS) {
-		n.rs.attachAllowPolicy("PolicyEFS", refIR, "*",
-			[]string{
-				"elasticfilesystem:*",
-			},
-		)
-		n.rs.attachAllowPolicy("PolicyEFSEC2", refIR, "*",
-			[]string{
+	if api.IsEnabled(addons.EFS) {
+		efsActions := []string{"elasticfilesystem:*"}
+		if minimal {
+			efsActions = []string{
+				"elasticfilesystem:ClientMount",
+				"elasticfilesystem:ClientWrite",
+				"elasticfilesystem:CreateAccessPoint",
+				"elasticfilesystem:DeleteAccessPoint",
+				"elasticfilesystem:DescribeAccessPoints",
+				"elasticfilesystem:DescribeFileSystems",
+				"elasticfilesystem:DescribeMountTargets",
+				"elasticfilesystem:TagResource",
+			}
+		}
+		statements = append(statements,
+			allowStatement{"PolicyEFS", "*", efsActions},
+			allowStatement{"PolicyEFSEC2", "*", []string{
 				"ec2:DescribeSubnets",
 				"ec2:CreateNetworkInterface",
 				"ec2:DescribeNetworkInterfaces",
 				"ec2:DeleteNetworkInterface",
 				"ec2:ModifyNetworkInterfaceAttribute",
 				"ec2:DescribeNetworkInterfaceAttribute",
-			},
+			}},
 		)
 	}
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.ALBIngress) {
-		n.rs.attachAllowPolicy("PolicyALBIngress", refIR, "*",
-			[]string{
-				"acm:DescribeCertificate",
-				"acm:ListCertificates",
-				"acm:GetCertificate",
-				"ec2:AuthorizeSecurityGroupIngress",
-				"ec2:CreateSecurityGroup",
-				"ec2:CreateTags",
-				"ec2:DeleteTags",
-				"ec2:DeleteSecurityGroup",
-				"ec2:DescribeAccountAttributes",
-				"ec2:DescribeAddresses",
-				"ec2:DescribeInstances",
-				"ec2:DescribeInstanceStatus",
-				"ec2:DescribeInternetGateways",
-				"ec2:DescribeNetworkInterfaces",
-				"ec2:DescribeSecurityGroups",
-				"ec2:DescribeSubnets",
-				"ec2:DescribeTags",
-				"ec2:DescribeVpcs",
-				"ec2:ModifyInstanceAttribute",
-				"ec2:ModifyNetworkInterfaceAttribute",
-				"ec2:RevokeSecurityGroupIngress",
-				"elasticloadbalancing:AddListenerCertificates",
-				"elasticloadbalancing:AddTags",
-				"elasticloadbalancing:CreateListener",
-				"elasticloadbalancing:CreateLoadBalancer",
-				"elasticloadbalancing:CreateRule",
-				"elasticloadbalancing:CreateTargetGroup",
-				"elasticloadbalancing:DeleteListener",
-				"elasticloadbalancing:DeleteLoadBalancer",
-				"elasticloadbalancing:DeleteRule",
-				"elasticloadbalancing:DeleteTargetGroup",
-				"elasticloadbalancing:DeregisterTargets",
-				"elasticloadbalancing:DescribeListenerCertificates",
-				"elasticloadbalancing:DescribeListeners",
-				"elasticloadbalancing:DescribeLoadBalancers",
-				"elasticloadbalancing:DescribeLoadBalancerAttributes",
-				"elasticloadbalancing:DescribeRules",
-				"elasticloadbalancing:DescribeSSLPolicies",
-				"elasticloadbalancing:DescribeTags",
-				"elasticloadbalancing:DescribeTargetGroups",
-				"elasticloadbalancing:DescribeTargetGroupAttributes",
-				"elasticloadbalancing:DescribeTargetHealth",
-				"elasticloadbalancing:ModifyListener",
-				"elasticloadbalancing:ModifyLoadBalancerAttributes",
-				"elasticloadbalancing:ModifyRule",
-				"elasticloadbalancing:ModifyTargetGroup",
-				"elasticloadbalancing:ModifyTargetGroupAttributes",
-				"elasticloadbalancing:RegisterTargets",
-				"elasticloadbalancing:RemoveListenerCertificates",
-				"elasticloadbalancing:RemoveTags",
-				"elasticloadbalancing:SetIpAddressType",
-				"elasticloadbalancing:SetSecurityGroups",
-				"elasticloadbalancing:SetSubnets",
-				"elasticloadbalancing:SetWebACL",
-				"iam:CreateServiceLinkedRole",
-				"iam:GetServerCertificate",
-				"iam:ListServerCertificates",
-				"waf-regional:GetWebACLForResource",
-				"waf-regional:GetWebACL",
-				"waf-regional:AssociateWebACL",
-				"waf-regional:DisassociateWebACL",
-				"tag:GetResources",
-				"tag:TagResources",
-				"waf:GetWebACL",
-			},
-		)
+	if api.IsEnabled(addons.ALBIngress) {
+		statements = append(statements, allowStatement{"PolicyALBIngress", "*", []string{
+			"acm:DescribeCertificate",
+			"acm:ListCertificates",
+			"acm:GetCertificate",
+			"ec2:AuthorizeSecurityGroupIngress",
+			"ec2:CreateSecurityGroup",
+			"ec2:CreateTags",
+			"ec2:DeleteTags",
+			"ec2:DeleteSecurityGroup",
+			"ec2:DescribeAccountAttributes",
+			"ec2:DescribeAddresses",
+			"ec2:DescribeInstances",
+			"ec2:DescribeInstanceStatus",
+			"ec2:DescribeInternetGateways",
+			"ec2:DescribeNetworkInterfaces",
+			"ec2:DescribeSecurityGroups",
+			"ec2:DescribeSubnets",
+			"ec2:DescribeTags",
+			"ec2:DescribeVpcs",
+			"ec2:ModifyInstanceAttribute",
+			"ec2:ModifyNetworkInterfaceAttribute",
+			"ec2:RevokeSecurityGroupIngress",
+			"elasticloadbalancing:AddListenerCertificates",
+			"elasticloadbalancing:AddTags",
+			"elasticloadbalancing:CreateListener",
+			"elasticloadbalancing:CreateLoadBalancer",
+			"elasticloadbalancing:CreateRule",
+			"elasticloadbalancing:CreateTargetGroup",
+			"elasticloadbalancing:DeleteListener",
+			"elasticloadbalancing:DeleteLoadBalancer",
+			"elasticloadbalancing:DeleteRule",
+			"elasticloadbalancing:DeleteTargetGroup",
+			"elasticloadbalancing:DeregisterTargets",
+			"elasticloadbalancing:DescribeListenerCertificates",
+			"elasticloadbalancing:DescribeListeners",
+			"elasticloadbalancing:DescribeLoadBalancers",
+			"elasticloadbalancing:DescribeLoadBalancerAttributes",
+			"elasticloadbalancing:DescribeRules",
+			"elasticloadbalancing:DescribeSSLPolicies",
+			"elasticloadbalancing:DescribeTags",
+			"elasticloadbalancing:DescribeTargetGroups",
+			"elasticloadbalancing:DescribeTargetGroupAttributes",
+			"elasticloadbalancing:DescribeTargetHealth",
+			"elasticloadbalancing:ModifyListener",
+			"elasticloadbalancing:ModifyLoadBalancerAttributes",
+			"elasticloadbalancing:ModifyRule",
+			"elasticloadbalancing:ModifyTargetGroup",
+			"elasticloadbalancing:ModifyTargetGroupAttributes",
+			"elasticloadbalancing:RegisterTargets",
+			"elasticloadbalancing:RemoveListenerCertificates",
+			"elasticloadbalancing:RemoveTags",
+			"elasticloadbalancing:SetIpAddressType",
+			"elasticloadbalancing:SetSecurityGroups",
+			"elasticloadbalancing:SetSubnets",
+			"elasticloadbalancing:SetWebACL",
+			"iam:CreateServiceLinkedRole",
+			"iam:GetServerCertificate",
+			"iam:ListServerCertificates",
+			"waf-regional:GetWebACLForResource",
+			"waf-regional:GetWebACL",
+			"waf-regional:AssociateWebACL",
+			"waf-regional:DisassociateWebACL",
+			"tag:GetResources",
+			"tag:TagResources",
+			"waf:GetWebACL",
+		}})
 	}
 
-	if api.IsEnabled(n.spec.IAM.WithAddonPolicies.XRay) {
-		n.rs.attachAllowPolicy("PolicyXRay", refIR, "*",
-			[]string{
-				"xray:PutTraceSegments",
-				"xray:PutTelemetryRecords",
-				"xray:GetSamplingRules",
-				"xray:GetSamplingTargets",
-				"xray:GetSamplingStatisticSummaries",
-			},
-		)
+	if api.IsEnabled(addons.XRay) {
+		statements = append(statements, allowStatement{"PolicyXRay", "*", []string{
+			"xray:PutTraceSegments",
+			"xray:PutTelemetryRecords",
+			"xray:GetSamplingRules",
+			"xray:GetSamplingTargets",
+			"xray:GetSamplingStatisticSummaries",
+		}})
 	}
 
-	n.rs.defineOutputFromAtt(outputs.NodeGroupInstanceProfileARN, "NodeInstanceProfile.Arn", true, func(v string) error {
-		n.spec.IAM.InstanceProfileARN = v
-		return nil
-	})
-	n.rs.defineOutputFromAtt(outputs.NodeGroupInstanceRoleARN, "NodeInstanceRole.Arn", true, func(v string) error {
-		n.spec.IAM.InstanceRoleARN = v
-		return nil
-	})
+	return statements
+}
+
+// NodeGroupAddonPolicyDocument returns a policy document with the actions needed by the
+// addons enabled in iam.withAddonPolicies of the nodegroup, along with pulling images
+// from ECR; it's meant to be set as iam.attachPolicy, in place of the broader policies
+// that are otherwise attached
+func NodeGroupAddonPolicyDocument(ng *api.NodeGroup, partition string) api.InlineDocument {
+	statements := []interface{}{}
+	for _, s := range addonPolicyStatements(ng.IAM, partition, true) {
+		actions := []interface{}{}
+		for _, a := range s.actions {
+			actions = append(actions, a)
+		}
+		statements = append(statements, map[string]interface{}{
+			"Sid":      strings.TrimPrefix(s.name, "Policy"),
+			"Effect":   "Allow",
+			"Resource": s.resources,
+			"Action":   actions,
+		})
+	}
+	return api.InlineDocument{
+		"Version":   "2012-10-17",
+		"Statement": statements,
+	}
+}
+
+// permissionsBoundaryARN returns the permissions boundary of the instance role, the
+// one of the nodegroup takes precedence over the one of the cluster
+func (n *NodeGroupResourceSet) permissionsBoundaryARN() string {
+	if n.spec.IAM.PermissionsBoundaryARN != "" {
+		return n.spec.IAM.PermissionsBoundaryARN
+	}
+	return n.clusterSpec.IAM.PermissionsBoundaryARN
 }
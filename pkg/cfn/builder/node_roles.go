@@ -0,0 +1,105 @@
+package builder
+
+import (
+	"fmt"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	gfn "github.com/awslabs/goformation/cloudformation"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+// NodeRolesResourceSet stores the instance roles of iam.nodeRoles, they are created in a
+// separate stack, and the nodegroups that share them use their instance profile and role
+// as if they were set in iam.instanceProfileARN and iam.instanceRoleARN
+type NodeRolesResourceSet struct {
+	rs          *resourceSet
+	clusterSpec *api.ClusterConfig
+}
+
+// NewNodeRolesResourceSet returns a resource set for the node roles of the cluster
+func NewNodeRolesResourceSet(spec *api.ClusterConfig) *NodeRolesResourceSet {
+	return &NodeRolesResourceSet{
+		rs:          newResourceSet(),
+		clusterSpec: spec,
+	}
+}
+
+// AddAllResources adds an instance role and an instance profile for each node role, the logical
+// IDs of their resources and outputs start with the name of the role
+func (n *NodeRolesResourceSet) AddAllResources() error {
+	if n.clusterSpec.IAM == nil || len(n.clusterSpec.IAM.NodeRoles) == 0 {
+		return fmt.Errorf("no node roles are defined for cluster %q", n.clusterSpec.Metadata.Name)
+	}
+
+	n.rs.withIAM = true
+	for _, r := range n.clusterSpec.IAM.NodeRoles {
+		n.addResourcesForNodeRole(r)
+	}
+
+	n.rs.template.Description = fmt.Sprintf(
+		"EKS node roles (%d) %s",
+		len(n.clusterSpec.IAM.NodeRoles),
+		templateDescriptionSuffix)
+
+	return nil
+}
+
+func (n *NodeRolesResourceSet) addResourcesForNodeRole(r *api.NodeRole) {
+	permissionsBoundaryARN := r.PermissionsBoundaryARN
+	if permissionsBoundaryARN == "" {
+		permissionsBoundaryARN = n.clusterSpec.IAM.PermissionsBoundaryARN
+	}
+	n.rs.addResourcesForInstanceRole(r.Name, n.clusterSpec, r.NodeGroupIAM(), permissionsBoundaryARN)
+
+	name := r.Name
+	n.rs.defineOutputFromAtt(name+outputs.NodeGroupInstanceProfileARN, name+"NodeInstanceProfile.Arn", false, func(v string) error {
+		for _, ng := range n.nodeGroupsOf(name) {
+			ng.IAM.InstanceProfileARN = v
+		}
+		return nil
+	})
+	n.rs.defineOutputFromAtt(name+outputs.NodeGroupInstanceRoleARN, name+"NodeInstanceRole.Arn", false, func(v string) error {
+		for _, ng := range n.nodeGroupsOf(name) {
+			ng.IAM.InstanceRoleARN = v
+		}
+		return nil
+	})
+}
+
+// nodeGroupsOf returns the nodegroups that share the node role
+func (n *NodeRolesResourceSet) nodeGroupsOf(nodeRole string) []*api.NodeGroup {
+	nodeGroups := []*api.NodeGroup{}
+	for _, ng := range n.clusterSpec.NodeGroups {
+		if ng.IAM != nil && ng.IAM.NodeRole == nodeRole {
+			nodeGroups = append(nodeGroups, ng)
+		}
+	}
+	return nodeGroups
+}
+
+// RenderJSON returns the rendered JSON
+func (n *NodeRolesResourceSet) RenderJSON() ([]byte, error) {
+	return n.rs.renderJSON()
+}
+
+// Template returns the CloudFormation template
+func (n *NodeRolesResourceSet) Template() gfn.Template {
+	return *n.rs.template
+}
+
+// WithIAM states, if IAM roles will be created or not
+func (n *NodeRolesResourceSet) WithIAM() bool {
+	return n.rs.withIAM
+}
+
+// WithNamedIAM states, if specifically named IAM roles will be created or not
+func (n *NodeRolesResourceSet) WithNamedIAM() bool {
+	return n.rs.withNamedIAM
+}
+
+// GetAllOutputs collects all outputs of the node roles stack
+func (n *NodeRolesResourceSet) GetAllOutputs(stack cfn.Stack) error {
+	return n.rs.GetAllOutputs(stack)
+}
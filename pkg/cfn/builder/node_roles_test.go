@@ -0,0 +1,82 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+var _ = Describe("Node roles stack builder", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		cfg.Metadata.Region = "us-west-2"
+		cfg.IAM = &api.ClusterIAM{}
+	})
+
+	render := func(rs *NodeRolesResourceSet) (map[string]map[string]interface{}, map[string]interface{}) {
+		Expect(rs.AddAllResources()).To(Succeed())
+		data, err := rs.RenderJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		template := struct {
+			Resources map[string]map[string]interface{}
+			Outputs   map[string]interface{}
+		}{}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+		return template.Resources, template.Outputs
+	}
+
+	It("should fail without node roles", func() {
+		Expect(NewNodeRolesResourceSet(cfg).AddAllResources()).ToNot(Succeed())
+	})
+
+	It("should create an instance role and profile for each node role", func() {
+		cfg.IAM.NodeRoles = []*api.NodeRole{
+			{Name: "workers", WithAddonPolicies: api.NodeGroupIAMAddonPolicies{AutoScaler: api.Enabled()}},
+			{Name: "builders", InstanceRoleName: "eks-builders", PermissionsBoundaryARN: "arn:aws:iam::123456789012:policy/boundary"},
+		}
+
+		rs := NewNodeRolesResourceSet(cfg)
+		resources, outputs := render(rs)
+		Expect(rs.WithIAM()).To(BeTrue())
+		Expect(rs.WithNamedIAM()).To(BeTrue())
+
+		for _, name := range []string{"workersNodeInstanceRole", "workersNodeInstanceProfile", "workersPolicyAutoScaling", "buildersNodeInstanceRole", "buildersNodeInstanceProfile"} {
+			Expect(resources).To(HaveKey(name))
+		}
+		Expect(resources).ToNot(HaveKey("buildersPolicyAutoScaling"))
+
+		role := resources["buildersNodeInstanceRole"]["Properties"].(map[string]interface{})
+		Expect(role["RoleName"]).To(Equal("eks-builders"))
+		Expect(role["PermissionsBoundary"]).To(Equal("arn:aws:iam::123456789012:policy/boundary"))
+
+		for _, name := range []string{"workersInstanceRoleARN", "workersInstanceProfileARN", "buildersInstanceRoleARN", "buildersInstanceProfileARN"} {
+			Expect(outputs).To(HaveKey(name))
+		}
+	})
+
+	It("should set the ARNs of the roles on the nodegroups that share them", func() {
+		cfg.IAM.NodeRoles = []*api.NodeRole{{Name: "workers"}}
+		ng1 := cfg.NewNodeGroup()
+		ng1.IAM.NodeRole = "workers"
+		ng2 := cfg.NewNodeGroup()
+
+		rs := NewNodeRolesResourceSet(cfg)
+		Expect(rs.AddAllResources()).To(Succeed())
+		Expect(rs.GetAllOutputs(newStackWithOutputs(map[string]string{
+			"workersInstanceRoleARN":    "arn:aws:iam::123456789012:role/workers",
+			"workersInstanceProfileARN": "arn:aws:iam::123456789012:instance-profile/workers",
+		}))).To(Succeed())
+
+		Expect(ng1.IAM.InstanceRoleARN).To(Equal("arn:aws:iam::123456789012:role/workers"))
+		Expect(ng1.IAM.InstanceProfileARN).To(Equal("arn:aws:iam::123456789012:instance-profile/workers"))
+		Expect(ng2.IAM.InstanceRoleARN).To(BeEmpty())
+	})
+})
@@ -0,0 +1,124 @@
+package builder
+
+import (
+	"fmt"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	gfn "github.com/awslabs/goformation/cloudformation"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+// nodeTerminationHandlerEvents are the EventBridge events Node Termination Handler
+// handles in queue mode, keyed by the logical ID of their rule
+var nodeTerminationHandlerEvents = []struct {
+	rule, source, detailType string
+}{
+	{"ASGTerminationRule", "aws.autoscaling", "EC2 Instance-terminate Lifecycle Action"},
+	{"SpotInterruptionRule", "aws.ec2", "EC2 Spot Instance Interruption Warning"},
+	{"RebalanceRule", "aws.ec2", "EC2 Instance Rebalance Recommendation"},
+	{"InstanceStateChangeRule", "aws.ec2", "EC2 Instance State-change Notification"},
+}
+
+// nodeTerminationHandlerLifecycleHook is the lifecycle hook added to spot nodegroups in queue mode,
+// the heartbeat timeout bounds how long draining can delay the termination of instances
+const nodeTerminationHandlerLifecycleHook = "node-termination-handler"
+
+var nodeTerminationHandlerHeartbeatTimeout = 300
+
+// nodeTerminationHandlerMessageRetention is how long events are kept in the queue, in seconds,
+// instances are interrupted two minutes after the warning, so older events are irrelevant
+const nodeTerminationHandlerMessageRetention = 300
+
+// NodeTerminationHandlerResourceSet stores the SQS queue and EventBridge rules Node Termination
+// Handler consumes in queue mode, they are created in a separate stack, so that they can be
+// deleted along with the cluster
+type NodeTerminationHandlerResourceSet struct {
+	rs          *resourceSet
+	clusterSpec *api.ClusterConfig
+}
+
+// NewNodeTerminationHandlerResourceSet returns a resource set for the queue of Node Termination Handler
+func NewNodeTerminationHandlerResourceSet(spec *api.ClusterConfig) *NodeTerminationHandlerResourceSet {
+	return &NodeTerminationHandlerResourceSet{
+		rs:          newResourceSet(),
+		clusterSpec: spec,
+	}
+}
+
+// AddAllResources adds the queue, its policy and a rule for each of the events
+func (n *NodeTerminationHandlerResourceSet) AddAllResources() error {
+	if !n.clusterSpec.NodeTerminationHandlerQueueMode() {
+		return fmt.Errorf("Node Termination Handler of cluster %q is not installed in %q mode", n.clusterSpec.Metadata.Name, api.NodeTerminationHandlerModeQueue)
+	}
+
+	// goformation doesn't have SQS and EventBridge types yet, so custom resources are used
+	refQueue := n.rs.newResource("Queue", &awsCloudFormationResource{
+		Type: "AWS::SQS::Queue",
+		Properties: map[string]interface{}{
+			"QueueName":              n.clusterSpec.NodeTerminationHandlerQueueName(),
+			"MessageRetentionPeriod": nodeTerminationHandlerMessageRetention,
+		},
+	})
+	queueARN := gfn.MakeFnGetAttString("Queue.Arn")
+
+	n.rs.newResource("QueuePolicy", &awsCloudFormationResource{
+		Type: "AWS::SQS::QueuePolicy",
+		Properties: map[string]interface{}{
+			"Queues": []*gfn.Value{refQueue},
+			"PolicyDocument": makePolicyDocument(map[string]interface{}{
+				"Effect": "Allow",
+				"Principal": map[string][]string{
+					"Service": {"events.amazonaws.com", "sqs.amazonaws.com"},
+				},
+				"Action":   []string{"sqs:SendMessage"},
+				"Resource": queueARN,
+			}),
+		},
+	})
+
+	for _, event := range nodeTerminationHandlerEvents {
+		n.rs.newResource(event.rule, &awsCloudFormationResource{
+			Type: "AWS::Events::Rule",
+			Properties: map[string]interface{}{
+				"EventPattern": map[string][]string{
+					"source":      {event.source},
+					"detail-type": {event.detailType},
+				},
+				"Targets": []map[string]interface{}{
+					{"Id": "Queue", "Arn": queueARN},
+				},
+			},
+		})
+	}
+
+	n.rs.defineOutput(outputs.NodeTerminationHandlerQueueURL, refQueue, false, func(v string) error {
+		n.clusterSpec.NodeTerminationHandler.QueueURL = v
+		return nil
+	})
+
+	n.rs.template.Description = fmt.Sprintf("EKS Node Termination Handler queue %s", templateDescriptionSuffix)
+
+	return nil
+}
+
+// RenderJSON returns the rendered JSON
+func (n *NodeTerminationHandlerResourceSet) RenderJSON() ([]byte, error) {
+	return n.rs.renderJSON()
+}
+
+// WithIAM states, if IAM roles will be created or not, the queue doesn't need any
+func (n *NodeTerminationHandlerResourceSet) WithIAM() bool {
+	return n.rs.withIAM
+}
+
+// WithNamedIAM states, if specifically named IAM roles will be created or not
+func (n *NodeTerminationHandlerResourceSet) WithNamedIAM() bool {
+	return n.rs.withNamedIAM
+}
+
+// GetAllOutputs collects all outputs of the Node Termination Handler stack
+func (n *NodeTerminationHandlerResourceSet) GetAllOutputs(stack cfn.Stack) error {
+	return n.rs.GetAllOutputs(stack)
+}
@@ -0,0 +1,68 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+var _ = Describe("Node Termination Handler stack builder", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+	})
+
+	It("should fail unless Node Termination Handler is installed in queue mode", func() {
+		Expect(NewNodeTerminationHandlerResourceSet(cfg).AddAllResources()).ToNot(Succeed())
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{Install: api.Enabled(), Mode: api.NodeTerminationHandlerModeIMDS}
+		Expect(NewNodeTerminationHandlerResourceSet(cfg).AddAllResources()).ToNot(Succeed())
+	})
+
+	It("should create a queue with a rule for each event", func() {
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{Install: api.Enabled(), Mode: api.NodeTerminationHandlerModeQueue}
+
+		rs := NewNodeTerminationHandlerResourceSet(cfg)
+		Expect(rs.AddAllResources()).To(Succeed())
+		data, err := rs.RenderJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		template := struct {
+			Resources map[string]map[string]interface{}
+			Outputs   map[string]interface{}
+		}{}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+
+		queue := template.Resources["Queue"]
+		Expect(queue["Type"]).To(Equal("AWS::SQS::Queue"))
+		Expect(queue["Properties"]).To(HaveKeyWithValue("QueueName", "eksctl-"+clusterName+"-node-termination-handler"))
+		Expect(template.Resources).To(HaveKey("QueuePolicy"))
+
+		for _, name := range []string{"ASGTerminationRule", "SpotInterruptionRule", "RebalanceRule", "InstanceStateChangeRule"} {
+			Expect(template.Resources).To(HaveKey(name))
+			Expect(template.Resources[name]["Type"]).To(Equal("AWS::Events::Rule"))
+		}
+		Expect(template.Outputs).To(HaveKey("QueueURL"))
+	})
+
+	It("should name the queue after the stack name prefix", func() {
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{Install: api.Enabled(), Mode: api.NodeTerminationHandlerModeQueue}
+		cfg.CloudFormation = &api.ClusterCloudFormation{StackNamePrefix: "team-a-"}
+
+		rs := NewNodeTerminationHandlerResourceSet(cfg)
+		Expect(rs.AddAllResources()).To(Succeed())
+		data, err := rs.RenderJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		template := struct {
+			Resources map[string]map[string]interface{}
+		}{}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+		Expect(template.Resources["Queue"]["Properties"]).To(HaveKeyWithValue("QueueName", "team-a-"+clusterName+"-node-termination-handler"))
+	})
+})
@@ -2,6 +2,7 @@ package builder
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/kris-nova/logger"
 
@@ -11,6 +12,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
 	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
 // NodeGroupResourceSet stores the resource information of the node group
@@ -51,7 +53,12 @@ func (n *NodeGroupResourceSet) AddAllResources() error {
 	n.rs.defineOutputWithoutCollector(outputs.NodeGroupFeatureSharedSecurityGroup, n.spec.SecurityGroups.WithShared, false)
 	n.rs.defineOutputWithoutCollector(outputs.NodeGroupFeatureLocalSecurityGroup, n.spec.SecurityGroups.WithLocal, false)
 
-	n.vpc = makeImportValue(n.clusterStackName, outputs.ClusterVPC)
+	if n.spec.ProviderOverride != nil {
+		// stack exports can't be imported from another account
+		n.vpc = gfn.NewString(n.clusterSpec.VPC.ID)
+	} else {
+		n.vpc = makeImportValue(n.clusterStackName, outputs.ClusterVPC)
+	}
 
 	userData, err := nodebootstrap.NewUserData(n.clusterSpec, n.spec)
 	if err != nil {
@@ -115,39 +122,71 @@ func (n *NodeGroupResourceSet) addResourcesForNodeGroup() error {
 	}
 
 	if volumeSize := n.spec.VolumeSize; volumeSize != nil && *volumeSize > 0 {
-		var (
-			kmsKeyID   *gfn.Value
-			volumeIOPS *gfn.Value
+		launchTemplateData.BlockDeviceMappings = append(launchTemplateData.BlockDeviceMappings,
+			newBlockDeviceMapping(&api.VolumeMapping{
+				VolumeName:       n.spec.VolumeName,
+				VolumeSize:       volumeSize,
+				VolumeType:       n.spec.VolumeType,
+				VolumeEncrypted:  n.spec.VolumeEncrypted,
+				VolumeKmsKeyID:   n.spec.VolumeKmsKeyID,
+				VolumeIOPS:       n.spec.VolumeIOPS,
+				VolumeThroughput: n.spec.VolumeThroughput,
+			}),
 		)
-		if api.IsSetAndNonEmptyString(n.spec.VolumeKmsKeyID) {
-			kmsKeyID = gfn.NewString(*n.spec.VolumeKmsKeyID)
-		}
+	}
 
-		if *n.spec.VolumeType == api.NodeVolumeTypeIO1 {
-			volumeIOPS = gfn.NewInteger(*n.spec.VolumeIOPS)
-		}
+	for _, v := range n.spec.AdditionalVolumes {
+		launchTemplateData.BlockDeviceMappings = append(launchTemplateData.BlockDeviceMappings, newBlockDeviceMapping(v))
+	}
 
-		launchTemplateData.BlockDeviceMappings = []gfn.AWSEC2LaunchTemplate_BlockDeviceMapping{{
-			DeviceName: gfn.NewString(*n.spec.VolumeName),
-			Ebs: &gfn.AWSEC2LaunchTemplate_Ebs{
-				VolumeSize: gfn.NewInteger(*volumeSize),
-				VolumeType: gfn.NewString(*n.spec.VolumeType),
-				Encrypted:  gfn.NewBoolean(*n.spec.VolumeEncrypted),
-				KmsKeyId:   kmsKeyID,
-				Iops:       volumeIOPS,
-			},
-		}}
+	if p := n.spec.Placement; p != nil || n.spec.Tenancy != "" {
+		launchTemplateData.Placement = &launchTemplatePlacement{}
+		if p != nil {
+			if p.Strategy != "" {
+				launchTemplateData.Placement.GroupName = n.newResource("NodeGroupPlacementGroup", &awsCloudFormationResource{
+					Type: "AWS::EC2::PlacementGroup",
+					Properties: map[string]interface{}{
+						"Strategy": p.Strategy,
+					},
+				})
+			} else {
+				launchTemplateData.Placement.GroupName = gfn.NewString(p.GroupName)
+			}
+		}
+		if n.spec.Tenancy != "" {
+			launchTemplateData.Placement.Tenancy = gfn.NewString(n.spec.Tenancy)
+		}
 	}
 
-	n.newResource("NodeGroupLaunchTemplate", &gfn.AWSEC2LaunchTemplate{
-		LaunchTemplateName: launchTemplateName,
-		LaunchTemplateData: launchTemplateData,
+	// goformation's launch template type doesn't have gp3 throughput or EFA
+	// interfaces yet, so a custom resource is used for the launch template
+	n.newResource("NodeGroupLaunchTemplate", &awsCloudFormationResource{
+		Type: "AWS::EC2::LaunchTemplate",
+		Properties: map[string]interface{}{
+			"LaunchTemplateName": launchTemplateName,
+			"LaunchTemplateData": launchTemplateData,
+		},
 	})
 
 	// currently goformation type system doesn't allow specifying `VPCZoneIdentifier: { "Fn::ImportValue": ... }`,
 	// and tags don't have `PropagateAtLaunch` field, so we have a custom method here until this gets resolved
 	var vpcZoneIdentifier interface{}
-	if numNodeGroupsAZs := len(n.spec.AvailabilityZones); numNodeGroupsAZs > 0 {
+	if len(n.spec.Subnets) > 0 {
+		// subnet IDs aren't known before the VPC eksctl creates exists, so they
+		// can only be checked when the cluster uses or was created with them
+		known := map[string]bool{}
+		for _, id := range append(n.clusterSpec.PrivateSubnetIDs(), n.clusterSpec.PublicSubnetIDs()...) {
+			if id != "" {
+				known[id] = true
+			}
+		}
+		for _, id := range n.spec.Subnets {
+			if len(known) > 0 && !known[id] {
+				return fmt.Errorf("subnet %q of nodegroup %q is not a subnet of the VPC of cluster %q", id, n.nodeGroupName, n.clusterSpec.Metadata.Name)
+			}
+		}
+		vpcZoneIdentifier = n.spec.Subnets
+	} else if numNodeGroupsAZs := len(n.spec.AvailabilityZones); numNodeGroupsAZs > 0 {
 		subnets := n.clusterSpec.VPC.Subnets.Private
 		if !n.spec.PrivateNetworking {
 			subnets = n.clusterSpec.VPC.Subnets.Public
@@ -164,6 +203,25 @@ func (n *NodeGroupResourceSet) addResourcesForNodeGroup() error {
 			}
 			vpcZoneIdentifier.([]interface{})[i] = subnet.ID
 		}
+	} else if n.clusterSpec.NodeGroupUsesLocalZoneSubnets(n.spec) {
+		subnets := vpc.NodeGroupSubnetIDs(n.clusterSpec, n.spec)
+		if len(subnets) == 0 {
+			if n.spec.OutpostARN != "" {
+				return fmt.Errorf("VPC of cluster %q has no subnets in Outpost %q for nodegroup %q, set them in vpc.localZoneSubnets with their outpostARN", n.clusterSpec.Metadata.Name, n.spec.OutpostARN, n.nodeGroupName)
+			}
+			return fmt.Errorf("VPC of cluster %q has no subnets in zones %v for nodegroup %q", n.clusterSpec.Metadata.Name, n.spec.LocalZones, n.nodeGroupName)
+		}
+		vpcZoneIdentifier = subnets
+	} else if n.spec.ProviderOverride != nil {
+		subnets := n.clusterSpec.PrivateSubnetIDs()
+		if !n.spec.PrivateNetworking {
+			subnets = n.clusterSpec.PublicSubnetIDs()
+		}
+		if len(subnets) == 0 {
+			return fmt.Errorf("VPC of cluster %q has no subnets for nodegroup %q", n.clusterSpec.Metadata.Name, n.nodeGroupName)
+		}
+		sort.Strings(subnets)
+		vpcZoneIdentifier = subnets
 	} else {
 		subnets := makeImportValue(n.clusterStackName, outputs.ClusterSubnetsPrivate)
 		if !n.spec.PrivateNetworking {
@@ -199,9 +257,65 @@ func (n *NodeGroupResourceSet) addResourcesForNodeGroup() error {
 			},
 		)
 	}
+	if !api.IsDisabled(n.spec.PropagateASGTags) {
+		// these tags are only read from the ASG, instances don't need them
+		nodeTemplateTags := api.ClusterAutoscalerNodeTemplateTags(n.spec.Labels, n.spec.Taints)
+		keys := []string{}
+		for k := range nodeTemplateTags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			tags = append(tags, map[string]interface{}{
+				"Key":               k,
+				"Value":             nodeTemplateTags[k],
+				"PropagateAtLaunch": "false",
+			})
+		}
+	}
+
+	lifecycleHooks := append([]*api.NodeGroupLifecycleHook{}, n.spec.ASGLifecycleHooks...)
+	if n.clusterSpec.NodeTerminationHandlerQueueMode() && api.IsSpotNodeGroup(n.spec) {
+		// Node Termination Handler is notified of the hook through EventBridge, and
+		// only completes it for ASGs that have its managed tag
+		tags = append(tags, map[string]interface{}{
+			"Key":               api.NodeTerminationHandlerManagedTag,
+			"Value":             "",
+			"PropagateAtLaunch": "false",
+		})
+		lifecycleHooks = append(lifecycleHooks, &api.NodeGroupLifecycleHook{
+			Name:             nodeTerminationHandlerLifecycleHook,
+			Transition:       api.LifecycleTransitionTerminating,
+			HeartbeatTimeout: &nodeTerminationHandlerHeartbeatTimeout,
+			DefaultResult:    api.LifecycleHookResultContinue,
+		})
+	}
 
-	asg := nodeGroupResource(launchTemplateName, &vpcZoneIdentifier, tags, n.spec)
-	n.newResource("NodeGroup", asg)
+	asg := nodeGroupResource(launchTemplateName, &vpcZoneIdentifier, tags, lifecycleHooks, n.spec)
+	if n.clusterSpec.HasAlarms() {
+		// the node count alarm of the nodegroup is based on group metrics
+		asg.Properties["MetricsCollection"] = []map[string]interface{}{
+			{"Granularity": "1Minute", "Metrics": asgGroupMetrics},
+		}
+	}
+	refASG := n.newResource("NodeGroup", asg)
+
+	if wp := n.spec.WarmPool; wp != nil {
+		// goformation doesn't have a type for warm pools yet
+		warmPoolProps := map[string]interface{}{
+			"AutoScalingGroupName": refASG,
+		}
+		if wp.MinSize != nil {
+			warmPoolProps["MinSize"] = fmt.Sprintf("%d", *wp.MinSize)
+		}
+		if wp.MaxPrepared != nil {
+			warmPoolProps["MaxGroupPreparedCapacity"] = fmt.Sprintf("%d", *wp.MaxPrepared)
+		}
+		n.newResource("NodeGroupWarmPool", &awsCloudFormationResource{
+			Type:       "AWS::AutoScaling::WarmPool",
+			Properties: warmPoolProps,
+		})
+	}
 
 	return nil
 }
@@ -211,29 +325,107 @@ func (n *NodeGroupResourceSet) GetAllOutputs(stack cfn.Stack) error {
 	return n.rs.GetAllOutputs(stack)
 }
 
-func newLaunchTemplateData(n *NodeGroupResourceSet) *gfn.AWSEC2LaunchTemplate_LaunchTemplateData {
-	launchTemplateData := &gfn.AWSEC2LaunchTemplate_LaunchTemplateData{
+// launchTemplateData overrides block device mappings, placement and network
+// interfaces of goformation's type with ones that support all of the settings we use
+type launchTemplateData struct {
+	*gfn.AWSEC2LaunchTemplate_LaunchTemplateData
+	BlockDeviceMappings []launchTemplateBlockDeviceMapping `json:"BlockDeviceMappings,omitempty"`
+	Placement           *launchTemplatePlacement           `json:"Placement,omitempty"`
+	NetworkInterfaces   []launchTemplateNetworkInterface   `json:"NetworkInterfaces,omitempty"`
+}
+
+type launchTemplateNetworkInterface struct {
+	AssociatePublicIpAddress *gfn.Value   `json:"AssociatePublicIpAddress,omitempty"`
+	DeviceIndex              *gfn.Value   `json:"DeviceIndex,omitempty"`
+	Groups                   []*gfn.Value `json:"Groups,omitempty"`
+	InterfaceType            *gfn.Value   `json:"InterfaceType,omitempty"`
+
+	AssociateCarrierIpAddress *gfn.Value `json:"AssociateCarrierIpAddress,omitempty"`
+}
+
+type launchTemplatePlacement struct {
+	GroupName *gfn.Value `json:"GroupName,omitempty"`
+	Tenancy   *gfn.Value `json:"Tenancy,omitempty"`
+}
+
+type launchTemplateBlockDeviceMapping struct {
+	DeviceName *gfn.Value         `json:"DeviceName,omitempty"`
+	Ebs        *launchTemplateEbs `json:"Ebs,omitempty"`
+}
+
+type launchTemplateEbs struct {
+	gfn.AWSEC2LaunchTemplate_Ebs
+	Throughput *gfn.Value `json:"Throughput,omitempty"`
+}
+
+func newBlockDeviceMapping(v *api.VolumeMapping) launchTemplateBlockDeviceMapping {
+	ebs := &launchTemplateEbs{
+		AWSEC2LaunchTemplate_Ebs: gfn.AWSEC2LaunchTemplate_Ebs{
+			VolumeSize: gfn.NewInteger(*v.VolumeSize),
+			VolumeType: gfn.NewString(*v.VolumeType),
+			Encrypted:  gfn.NewBoolean(api.IsEnabled(v.VolumeEncrypted)),
+		},
+	}
+	if api.IsSetAndNonEmptyString(v.VolumeKmsKeyID) {
+		ebs.KmsKeyId = gfn.NewString(*v.VolumeKmsKeyID)
+	}
+	if v.VolumeIOPS != nil {
+		ebs.Iops = gfn.NewInteger(*v.VolumeIOPS)
+	}
+	if v.VolumeThroughput != nil {
+		ebs.Throughput = gfn.NewInteger(*v.VolumeThroughput)
+	}
+
+	return launchTemplateBlockDeviceMapping{
+		DeviceName: gfn.NewString(*v.VolumeName),
+		Ebs:        ebs,
+	}
+}
+
+func newLaunchTemplateData(n *NodeGroupResourceSet) *launchTemplateData {
+	data := &gfn.AWSEC2LaunchTemplate_LaunchTemplateData{
 		IamInstanceProfile: &gfn.AWSEC2LaunchTemplate_IamInstanceProfile{
 			Arn: n.instanceProfileARN,
 		},
 		ImageId:  gfn.NewString(n.spec.AMI),
 		UserData: n.userData,
-		NetworkInterfaces: []gfn.AWSEC2LaunchTemplate_NetworkInterface{{
-			AssociatePublicIpAddress: gfn.NewBoolean(!n.spec.PrivateNetworking),
-			DeviceIndex:              gfn.NewInteger(0),
-			Groups:                   n.securityGroups,
-		}},
 	}
 	if !api.HasMixedInstances(n.spec) {
-		launchTemplateData.InstanceType = gfn.NewString(n.spec.InstanceType)
+		data.InstanceType = gfn.NewString(n.spec.InstanceType)
 	} else {
-		launchTemplateData.InstanceType = gfn.NewString(n.spec.InstancesDistribution.InstanceTypes[0])
+		data.InstanceType = gfn.NewString(n.spec.InstancesDistribution.InstanceTypes[0])
+	}
+
+	networkInterface := launchTemplateNetworkInterface{
+		AssociatePublicIpAddress: gfn.NewBoolean(!n.spec.PrivateNetworking),
+		DeviceIndex:              gfn.NewInteger(0),
+		Groups:                   n.securityGroups,
+	}
+	if api.IsEnabled(n.spec.EFAEnabled) {
+		networkInterface.InterfaceType = gfn.NewString("efa")
+	}
+	if usesWavelengthZones(n.spec) {
+		// nodes in Wavelength Zones reach the internet through the carrier gateway
+		networkInterface.AssociatePublicIpAddress = nil
+		networkInterface.AssociateCarrierIpAddress = gfn.True()
 	}
 
-	return launchTemplateData
+	return &launchTemplateData{
+		AWSEC2LaunchTemplate_LaunchTemplateData: data,
+		NetworkInterfaces:                       []launchTemplateNetworkInterface{networkInterface},
+	}
+}
+
+func usesWavelengthZones(ng *api.NodeGroup) bool {
+	for _, zone := range ng.LocalZones {
+		if api.IsWavelengthZone(zone) {
+			return true
+		}
+	}
+	return false
 }
 
-func nodeGroupResource(launchTemplateName *gfn.Value, vpcZoneIdentifier *interface{}, tags []map[string]interface{}, ng *api.NodeGroup) *awsCloudFormationResource {
+func nodeGroupResource(launchTemplateName *gfn.Value, vpcZoneIdentifier *interface{}, tags []map[string]interface{}, lifecycleHooks []*api.NodeGroupLifecycleHook, ng *api.NodeGroup) *awsCloudFormationResource {
 	ngProps := map[string]interface{}{
 		"VPCZoneIdentifier": *vpcZoneIdentifier,
 		"Tags":              tags,
@@ -250,6 +442,9 @@ func nodeGroupResource(launchTemplateName *gfn.Value, vpcZoneIdentifier *interfa
 	if len(ng.TargetGroupARNs) > 0 {
 		ngProps["TargetGroupARNs"] = ng.TargetGroupARNs
 	}
+	if len(lifecycleHooks) > 0 {
+		ngProps["LifecycleHookSpecificationList"] = lifecycleHookSpecifications(lifecycleHooks)
+	}
 	if api.HasMixedInstances(ng) {
 		ngProps["MixedInstancesPolicy"] = *mixedInstancesPolicy(launchTemplateName, ng)
 	} else {
@@ -260,17 +455,72 @@ func nodeGroupResource(launchTemplateName *gfn.Value, vpcZoneIdentifier *interfa
 	}
 
 	return &awsCloudFormationResource{
-		Type:       "AWS::AutoScaling::AutoScalingGroup",
-		Properties: ngProps,
-		UpdatePolicy: map[string]map[string]string{
-			"AutoScalingRollingUpdate": {
-				"MinInstancesInService": "0",
-				"MaxBatchSize":          "1",
+		Type:         "AWS::AutoScaling::AutoScalingGroup",
+		Properties:   ngProps,
+		UpdatePolicy: nodeGroupUpdatePolicy(ng),
+	}
+}
+
+// nodeGroupUpdatePolicy returns the policy CloudFormation follows to replace instances when the
+// launch template changes: a rolling update, one instance at a time unless the nodegroup sets its
+// batch size and the share of instances kept in service, or the replacement of the whole ASG
+func nodeGroupUpdatePolicy(ng *api.NodeGroup) map[string]map[string]string {
+	uc := ng.UpdateConfig
+	if uc != nil && uc.Strategy == api.NodeGroupUpdateStrategyReplaceNodeGroup {
+		return map[string]map[string]string{
+			"AutoScalingReplacingUpdate": {
+				"WillReplace": "true",
 			},
+		}
+	}
+
+	maxBatchSize, minInService := 1, 0
+	if uc != nil {
+		if uc.MaxUnavailable != nil {
+			maxBatchSize = *uc.MaxUnavailable
+		}
+		if uc.MinHealthyPercentage != nil && ng.DesiredCapacity != nil {
+			// rounded up, CloudFormation requires it to be less than the maximum size
+			percentage, desired := *uc.MinHealthyPercentage, *ng.DesiredCapacity
+			minInService = (percentage*desired + 99) / 100
+			if ng.MaxSize != nil && minInService >= *ng.MaxSize {
+				minInService = *ng.MaxSize - 1
+			}
+		}
+	}
+	return map[string]map[string]string{
+		"AutoScalingRollingUpdate": {
+			"MinInstancesInService": fmt.Sprintf("%d", minInService),
+			"MaxBatchSize":          fmt.Sprintf("%d", maxBatchSize),
 		},
 	}
 }
 
+func lifecycleHookSpecifications(hooks []*api.NodeGroupLifecycleHook) []map[string]string {
+	specs := make([]map[string]string, len(hooks))
+	for i, hook := range hooks {
+		spec := map[string]string{
+			"LifecycleHookName":   hook.Name,
+			"LifecycleTransition": hook.Transition,
+		}
+		if hook.HeartbeatTimeout != nil {
+			spec["HeartbeatTimeout"] = fmt.Sprintf("%d", *hook.HeartbeatTimeout)
+		}
+		if hook.DefaultResult != "" {
+			spec["DefaultResult"] = hook.DefaultResult
+		}
+		if hook.NotificationTargetARN != "" {
+			spec["NotificationTargetARN"] = hook.NotificationTargetARN
+			spec["RoleARN"] = hook.RoleARN
+		}
+		if hook.NotificationMetadata != "" {
+			spec["NotificationMetadata"] = hook.NotificationMetadata
+		}
+		specs[i] = spec
+	}
+	return specs
+}
+
 func mixedInstancesPolicy(launchTemplateName *gfn.Value, ng *api.NodeGroup) *map[string]interface{} {
 	instanceTypes := ng.InstancesDistribution.InstanceTypes
 	overrides := make([]map[string]string, len(instanceTypes))
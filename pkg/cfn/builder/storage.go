@@ -0,0 +1,193 @@
+package builder
+
+import (
+	"fmt"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	gfn "github.com/awslabs/goformation/cloudformation"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+var (
+	sgPortNFS       = gfn.NewInteger(2049)
+	sgPortLustre    = gfn.NewInteger(988)
+	sgMinPortLustre = gfn.NewInteger(1021)
+	sgMaxPortLustre = gfn.NewInteger(1023)
+)
+
+// fsxDeploymentType is the deployment type of FSx for Lustre file systems,
+// scratch file systems are meant for temporary storage and shorter-term processing
+const fsxDeploymentType = "SCRATCH_1"
+
+// StorageResourceSet stores the resources of the file systems of a cluster,
+// they are created in a separate stack, which imports the VPC and the shared
+// node security group of the cluster stack
+type StorageResourceSet struct {
+	rs               *resourceSet
+	clusterSpec      *api.ClusterConfig
+	clusterStackName string
+	securityGroup    *gfn.Value
+}
+
+// NewStorageResourceSet returns a resource set for the file systems of the cluster
+func NewStorageResourceSet(spec *api.ClusterConfig, clusterStackName string) *StorageResourceSet {
+	return &StorageResourceSet{
+		rs:               newResourceSet(),
+		clusterSpec:      spec,
+		clusterStackName: clusterStackName,
+	}
+}
+
+// AddAllResources adds the security group of the mount targets and the file systems
+func (s *StorageResourceSet) AddAllResources() error {
+	storage := s.clusterSpec.Storage
+	if storage == nil || (storage.EFS == nil && storage.FSx == nil) {
+		return fmt.Errorf("no file systems are defined for cluster %q", s.clusterSpec.Metadata.Name)
+	}
+
+	subnets := s.clusterSpec.StorageSubnetIDs()
+	if len(subnets) == 0 {
+		return fmt.Errorf("VPC of cluster %q has no subnets for mount targets", s.clusterSpec.Metadata.Name)
+	}
+
+	s.addResourcesForSecurityGroup()
+	if storage.EFS != nil {
+		s.addResourcesForEFS(storage.EFS, subnets)
+	}
+	if storage.FSx != nil {
+		s.addResourcesForFSx(storage.FSx, subnets)
+	}
+
+	s.rs.template.Description = fmt.Sprintf(
+		"EKS file systems (EFS: %v, FSx: %v) %s",
+		storage.EFS != nil, storage.FSx != nil,
+		templateDescriptionSuffix)
+
+	return nil
+}
+
+func (s *StorageResourceSet) addResourcesForSecurityGroup() {
+	s.securityGroup = s.rs.newResource("MountTargetSecurityGroup", &gfn.AWSEC2SecurityGroup{
+		VpcId:            makeImportValue(s.clusterStackName, outputs.ClusterVPC),
+		GroupDescription: gfn.NewString("Communication between all nodes in the cluster and file systems"),
+		Tags: []gfn.Tag{{
+			Key:   gfn.NewString("kubernetes.io/cluster/" + s.clusterSpec.Metadata.Name),
+			Value: gfn.NewString("owned"),
+		}},
+	})
+	s.rs.defineOutput(outputs.StorageSecurityGroup, s.securityGroup, false, func(v string) error {
+		s.clusterSpec.Storage.SecurityGroup = v
+		return nil
+	})
+}
+
+func (s *StorageResourceSet) addIngressFromNodes(name, desc string, fromPort, toPort *gfn.Value) {
+	s.rs.newResource(name, &gfn.AWSEC2SecurityGroupIngress{
+		GroupId:               s.securityGroup,
+		SourceSecurityGroupId: makeImportValue(s.clusterStackName, outputs.ClusterSharedNodeSecurityGroup),
+		Description:           gfn.NewString("Allow nodes to mount " + desc),
+		IpProtocol:            sgProtoTCP,
+		FromPort:              fromPort,
+		ToPort:                toPort,
+	})
+}
+
+func (s *StorageResourceSet) addResourcesForEFS(efs *api.EFSFileSystem, subnets []string) {
+	s.addIngressFromNodes("IngressNFS", "EFS file system (NFS)", sgPortNFS, sgPortNFS)
+
+	// goformation doesn't have EFS types yet, so a custom resource is used
+	properties := map[string]interface{}{
+		"PerformanceMode": efs.PerformanceMode,
+		"ThroughputMode":  efs.ThroughputMode,
+		"Encrypted":       api.IsEnabled(efs.Encrypted),
+		"FileSystemTags":  []gfn.Tag{makeAutoNameTag("EFSFileSystem")},
+	}
+	if efs.ThroughputMode == api.EFSThroughputModeProvisioned {
+		properties["ProvisionedThroughputInMibps"] = efs.ProvisionedThroughputInMibps
+	}
+	refFileSystem := s.rs.newResource("EFSFileSystem", &awsCloudFormationResource{
+		Type:       "AWS::EFS::FileSystem",
+		Properties: properties,
+	})
+
+	for i, subnet := range subnets {
+		s.rs.newResource(fmt.Sprintf("EFSMountTarget%d", i), &awsCloudFormationResource{
+			Type: "AWS::EFS::MountTarget",
+			Properties: map[string]interface{}{
+				"FileSystemId":   refFileSystem,
+				"SubnetId":       subnet,
+				"SecurityGroups": []*gfn.Value{s.securityGroup},
+			},
+		})
+	}
+
+	s.rs.defineOutput(outputs.StorageEFSFileSystemID, refFileSystem, false, func(v string) error {
+		efs.ID = v
+		return nil
+	})
+}
+
+func (s *StorageResourceSet) addResourcesForFSx(fsx *api.FSxFileSystem, subnets []string) {
+	s.addIngressFromNodes("IngressLustre", "FSx for Lustre file system", sgPortLustre, sgPortLustre)
+	s.addIngressFromNodes("IngressLustreServers", "FSx for Lustre file system (servers)", sgMinPortLustre, sgMaxPortLustre)
+
+	lustreConfig := map[string]interface{}{
+		"DeploymentType": fsxDeploymentType,
+	}
+	if fsx.ImportPath != "" {
+		lustreConfig["ImportPath"] = fsx.ImportPath
+	}
+
+	// Lustre file systems live in a single subnet
+	refFileSystem := s.rs.newResource("FSxFileSystem", &awsCloudFormationResource{
+		Type: "AWS::FSx::FileSystem",
+		Properties: map[string]interface{}{
+			"FileSystemType":      "LUSTRE",
+			"StorageCapacity":     fsx.StorageCapacity,
+			"SubnetIds":           []string{subnets[0]},
+			"SecurityGroupIds":    []*gfn.Value{s.securityGroup},
+			"LustreConfiguration": lustreConfig,
+			"Tags":                []gfn.Tag{makeAutoNameTag("FSxFileSystem")},
+		},
+	})
+
+	s.rs.defineOutput(outputs.StorageFSxFileSystemID, refFileSystem, false, func(v string) error {
+		fsx.ID = v
+		return nil
+	})
+	s.rs.defineOutputFromAtt(outputs.StorageFSxDNSName, "FSxFileSystem.DNSName", false, func(v string) error {
+		fsx.DNSName = v
+		return nil
+	})
+	s.rs.defineOutputFromAtt(outputs.StorageFSxMountName, "FSxFileSystem.LustreMountName", false, func(v string) error {
+		fsx.MountName = v
+		return nil
+	})
+}
+
+// RenderJSON returns the rendered JSON
+func (s *StorageResourceSet) RenderJSON() ([]byte, error) {
+	return s.rs.renderJSON()
+}
+
+// Template returns the CloudFormation template
+func (s *StorageResourceSet) Template() gfn.Template {
+	return *s.rs.template
+}
+
+// WithIAM states, if IAM roles will be created or not, file systems don't need any
+func (s *StorageResourceSet) WithIAM() bool {
+	return s.rs.withIAM
+}
+
+// WithNamedIAM states, if specifically named IAM roles will be created or not
+func (s *StorageResourceSet) WithNamedIAM() bool {
+	return s.rs.withNamedIAM
+}
+
+// GetAllOutputs collects all outputs of the storage stack
+func (s *StorageResourceSet) GetAllOutputs(stack cfn.Stack) error {
+	return s.rs.GetAllOutputs(stack)
+}
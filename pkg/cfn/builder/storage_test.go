@@ -0,0 +1,79 @@
+package builder_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+var _ = Describe("Storage stack builder", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = clusterName
+		cfg.VPC = testVPC()
+	})
+
+	render := func() map[string]map[string]interface{} {
+		rs := NewStorageResourceSet(cfg, "eksctl-"+clusterName+"-cluster")
+		Expect(rs.AddAllResources()).To(Succeed())
+		data, err := rs.RenderJSON()
+		Expect(err).ToNot(HaveOccurred())
+
+		template := struct {
+			Resources map[string]map[string]interface{}
+			Outputs   map[string]interface{}
+		}{}
+		Expect(json.Unmarshal(data, &template)).To(Succeed())
+		return template.Resources
+	}
+
+	It("should fail without file systems", func() {
+		Expect(NewStorageResourceSet(cfg, "eksctl-"+clusterName+"-cluster").AddAllResources()).ToNot(Succeed())
+	})
+
+	It("should create an EFS file system with a mount target in each AZ", func() {
+		cfg.Storage = &api.ClusterStorage{EFS: &api.EFSFileSystem{}}
+		api.SetClusterStorageDefaults(cfg)
+
+		resources := render()
+		Expect(resources).To(HaveKey("MountTargetSecurityGroup"))
+		Expect(resources).To(HaveKey("IngressNFS"))
+		Expect(resources).ToNot(HaveKey("FSxFileSystem"))
+
+		fs := resources["EFSFileSystem"]
+		Expect(fs["Type"]).To(Equal("AWS::EFS::FileSystem"))
+		properties := fs["Properties"].(map[string]interface{})
+		Expect(properties["PerformanceMode"]).To(Equal("generalPurpose"))
+		Expect(properties["Encrypted"]).To(BeTrue())
+		Expect(properties).ToNot(HaveKey("ProvisionedThroughputInMibps"))
+
+		subnets := []interface{}{}
+		for _, name := range []string{"EFSMountTarget0", "EFSMountTarget1", "EFSMountTarget2"} {
+			Expect(resources).To(HaveKey(name))
+			subnets = append(subnets, resources[name]["Properties"].(map[string]interface{})["SubnetId"])
+		}
+		Expect(subnets).To(Equal([]interface{}{"subnet-0ade11bad78dced9f", "subnet-0f98135715dfcf55a", "subnet-0e2e63ff1712bf6ea"}))
+	})
+
+	It("should create an FSx for Lustre file system in a single subnet", func() {
+		cfg.Storage = &api.ClusterStorage{FSx: &api.FSxFileSystem{ImportPath: "s3://bucket/data"}}
+		api.SetClusterStorageDefaults(cfg)
+
+		resources := render()
+		Expect(resources).To(HaveKey("IngressLustre"))
+		Expect(resources).ToNot(HaveKey("EFSFileSystem"))
+
+		fs := resources["FSxFileSystem"]
+		Expect(fs["Type"]).To(Equal("AWS::FSx::FileSystem"))
+		properties := fs["Properties"].(map[string]interface{})
+		Expect(properties["StorageCapacity"]).To(BeNumerically("==", 1200))
+		Expect(properties["SubnetIds"]).To(Equal([]interface{}{"subnet-0ade11bad78dced9f"}))
+		Expect(properties["LustreConfiguration"]).To(HaveKeyWithValue("ImportPath", "s3://bucket/data"))
+	})
+})
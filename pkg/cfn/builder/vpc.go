@@ -2,6 +2,7 @@ package builder
 
 import (
 	"fmt"
+	"hash/fnv"
 	"strings"
 
 	gfn "github.com/awslabs/goformation/cloudformation"
@@ -13,6 +14,8 @@ import (
 
 var internetCIDR = gfn.NewString("0.0.0.0/0")
 
+var internetCIDRv6 = gfn.NewString("::/0")
+
 func (c *ClusterResourceSet) addSubnets(refRT *gfn.Value, topology api.SubnetTopology, subnets map[string]api.Network) {
 	var subnetIndexForIPv6 int
 	if api.IsEnabled(c.spec.VPC.AutoAllocateIPv6) {
@@ -38,37 +41,58 @@ func (c *ClusterResourceSet) addSubnets(refRT *gfn.Value, topology api.SubnetTop
 			// Choose the appropriate route table for private subnets
 			refRT = gfn.MakeRef("PrivateRouteTable" + strings.ToUpper(strings.Join(strings.Split(az, "-"), "")))
 			subnet.Tags = []gfn.Tag{{
-				Key:   gfn.NewString("kubernetes.io/role/internal-elb"),
+				Key:   gfn.NewString(vpc.PrivateSubnetRoleTag),
 				Value: gfn.NewString("1"),
 			}}
 		case api.SubnetTopologyPublic:
 			subnet.Tags = []gfn.Tag{{
-				Key:   gfn.NewString("kubernetes.io/role/elb"),
+				Key:   gfn.NewString(vpc.PublicSubnetRoleTag),
 				Value: gfn.NewString("1"),
 			}}
 		}
-		refSubnet := c.newResource("Subnet"+alias, subnet)
+		// get 8 of /64 subnets from the auto-allocated IPv6 block,
+		// and pick one block based on subnetIndexForIPv6 counter;
+		// NOTE: this is done inside of CloudFormation using Fn::Cidr,
+		// we don't slice it here, just construct the JSON expression
+		// that does slicing at runtime.
+		refAutoAllocateCIDRv6 := gfn.MakeFnSelect(
+			0, gfn.MakeFnGetAttString("VPC.Ipv6CidrBlocks"),
+		)
+		refSubnetSlices := gfn.MakeFnCIDR(
+			refAutoAllocateCIDRv6, 8, 64,
+		)
+
+		var refSubnet *gfn.Value
+		if c.spec.IPv6Enabled() {
+			// nodes of IPv6 clusters must get an IPv6 address at launch, which
+			// requires the IPv6 block to be set when the subnet is created
+			refSubnet = c.newResource("Subnet"+alias, &awsCloudFormationResource{
+				Type: "AWS::EC2::Subnet",
+				Properties: map[string]interface{}{
+					"AvailabilityZone":            subnet.AvailabilityZone,
+					"CidrBlock":                   subnet.CidrBlock,
+					"VpcId":                       subnet.VpcId,
+					"Tags":                        subnet.Tags,
+					"Ipv6CidrBlock":               gfn.MakeFnSelect(subnetIndexForIPv6, refSubnetSlices),
+					"AssignIpv6AddressOnCreation": true,
+				},
+				DependsOn: []string{"AutoAllocatedCIDRv6"},
+			})
+		} else {
+			refSubnet = c.newResource("Subnet"+alias, subnet)
+		}
 		c.newResource("RouteTableAssociation"+alias, &gfn.AWSEC2SubnetRouteTableAssociation{
 			SubnetId:     refSubnet,
 			RouteTableId: refRT,
 		})
 
 		if api.IsEnabled(c.spec.VPC.AutoAllocateIPv6) {
-			// get 8 of /64 subnets from the auto-allocated IPv6 block,
-			// and pick one block based on subnetIndexForIPv6 counter;
-			// NOTE: this is done inside of CloudFormation using Fn::Cidr,
-			// we don't slice it here, just construct the JSON expression
-			// that does slicing at runtime.
-			refAutoAllocateCIDRv6 := gfn.MakeFnSelect(
-				0, gfn.MakeFnGetAttString("VPC.Ipv6CidrBlocks"),
-			)
-			refSubnetSlices := gfn.MakeFnCIDR(
-				refAutoAllocateCIDRv6, 8, 64,
-			)
-			c.newResource(alias+"CIDRv6", &gfn.AWSEC2SubnetCidrBlock{
-				SubnetId:      refSubnet,
-				Ipv6CidrBlock: gfn.MakeFnSelect(subnetIndexForIPv6, refSubnetSlices),
-			})
+			if !c.spec.IPv6Enabled() {
+				c.newResource(alias+"CIDRv6", &gfn.AWSEC2SubnetCidrBlock{
+					SubnetId:      refSubnet,
+					Ipv6CidrBlock: gfn.MakeFnSelect(subnetIndexForIPv6, refSubnetSlices),
+				})
+			}
 			subnetIndexForIPv6++
 		}
 
@@ -117,9 +141,101 @@ func (c *ClusterResourceSet) addResourcesForVPC() error {
 	}
 
 	c.addSubnets(nil, api.SubnetTopologyPrivate, c.spec.VPC.Subnets.Private)
+
+	c.localZoneSubnets = make(map[api.SubnetTopology][]*gfn.Value)
+	if c.spec.VPC.LocalZoneSubnets != nil {
+		c.addLocalZoneSubnets(refPublicRT)
+	}
+
+	if c.spec.IPv6Enabled() {
+		c.addIPv6Routes(refIG, refPublicRT)
+	}
 	return nil
 }
 
+// addLocalZoneSubnets creates the subnets in Local Zones and Wavelength Zones; public subnets
+// use the internet gateway, or a carrier gateway in Wavelength Zones, and private subnets
+// use the NAT gateway of the first availability zone, as Local Zones have no NAT gateways
+func (c *ClusterResourceSet) addLocalZoneSubnets(refPublicRT *gfn.Value) {
+	var refCarrierRT *gfn.Value
+	refPrivateRT := gfn.MakeRef("PrivateRouteTable" + strings.ToUpper(strings.Join(strings.Split(c.spec.AvailabilityZones[0], "-"), "")))
+
+	for _, topology := range api.SubnetTopologies() {
+		for zone, network := range c.spec.LocalZoneSubnetsWithTopology(topology) {
+			alias := "LocalZone" + string(topology) + strings.ToUpper(strings.Join(strings.Split(zone, "-"), ""))
+			refSubnet := c.newResource("Subnet"+alias, &gfn.AWSEC2Subnet{
+				AvailabilityZone: gfn.NewString(zone),
+				CidrBlock:        gfn.NewString(network.CIDR.String()),
+				VpcId:            c.vpc,
+			})
+
+			refRT := refPrivateRT
+			if topology == api.SubnetTopologyPublic {
+				refRT = refPublicRT
+				if api.IsWavelengthZone(zone) {
+					if refCarrierRT == nil {
+						refCarrierRT = c.addCarrierGateway()
+					}
+					refRT = refCarrierRT
+				}
+			}
+			c.newResource("RouteTableAssociation"+alias, &gfn.AWSEC2SubnetRouteTableAssociation{
+				SubnetId:     refSubnet,
+				RouteTableId: refRT,
+			})
+
+			c.localZoneSubnets[topology] = append(c.localZoneSubnets[topology], refSubnet)
+		}
+	}
+}
+
+// addCarrierGateway creates a carrier gateway and a route table that sends
+// internet traffic of subnets in Wavelength Zones through it
+func (c *ClusterResourceSet) addCarrierGateway() *gfn.Value {
+	// goformation doesn't have carrier gateways yet, so custom resources are used
+	refCG := c.newResource("CarrierGateway", &awsCloudFormationResource{
+		Type: "AWS::EC2::CarrierGateway",
+		Properties: map[string]interface{}{
+			"VpcId": c.vpc,
+		},
+	})
+	refRT := c.newResource("CarrierRouteTable", &gfn.AWSEC2RouteTable{
+		VpcId: c.vpc,
+	})
+	c.newResource("CarrierSubnetRoute", &awsCloudFormationResource{
+		Type: "AWS::EC2::Route",
+		Properties: map[string]interface{}{
+			"RouteTableId":         refRT,
+			"DestinationCidrBlock": internetCIDR,
+			"CarrierGatewayId":     refCG,
+		},
+	})
+	return refRT
+}
+
+// addIPv6Routes sends IPv6 traffic from public subnets through the internet gateway,
+// and from private subnets through an egress-only internet gateway
+func (c *ClusterResourceSet) addIPv6Routes(refIG, refPublicRT *gfn.Value) {
+	c.newResource("PublicSubnetIPv6DefaultRoute", &gfn.AWSEC2Route{
+		RouteTableId:             refPublicRT,
+		DestinationIpv6CidrBlock: internetCIDRv6,
+		GatewayId:                refIG,
+	})
+
+	refEIGW := c.newResource("EgressOnlyInternetGateway", &gfn.AWSEC2EgressOnlyInternetGateway{
+		VpcId: c.vpc,
+	})
+
+	for _, az := range c.spec.AvailabilityZones {
+		alphanumericUpperAZ := strings.ToUpper(strings.Join(strings.Split(az, "-"), ""))
+		c.newResource("PrivateSubnetIPv6DefaultRoute"+alphanumericUpperAZ, &gfn.AWSEC2Route{
+			RouteTableId:                gfn.MakeRef("PrivateRouteTable" + alphanumericUpperAZ),
+			DestinationIpv6CidrBlock:    internetCIDRv6,
+			EgressOnlyInternetGatewayId: refEIGW,
+		})
+	}
+}
+
 func (c *ClusterResourceSet) addNATGateways() error {
 
 	switch *c.spec.VPC.NAT.Gateway {
@@ -146,6 +262,12 @@ func (c *ClusterResourceSet) importResourcesForVPC() {
 	for _, subnet := range c.spec.PublicSubnetIDs() {
 		c.subnets[api.SubnetTopologyPublic] = append(c.subnets[api.SubnetTopologyPublic], gfn.NewString(subnet))
 	}
+	c.localZoneSubnets = make(map[api.SubnetTopology][]*gfn.Value)
+	for _, topology := range api.SubnetTopologies() {
+		for _, subnet := range c.spec.LocalZoneSubnetIDs(topology) {
+			c.localZoneSubnets[topology] = append(c.localZoneSubnets[topology], gfn.NewString(subnet))
+		}
+	}
 
 }
 
@@ -168,10 +290,21 @@ func (c *ClusterResourceSet) addOutputsForVPC() {
 			return vpc.ImportSubnetsFromList(c.provider, c.spec, api.SubnetTopologyPublic, strings.Split(v, ","))
 		})
 	}
+	if refs, ok := c.localZoneSubnets[api.SubnetTopologyPrivate]; ok {
+		c.rs.defineJoinedOutput(outputs.ClusterLocalZoneSubnetsPrivate, refs, true, func(v string) error {
+			return vpc.ImportLocalZoneSubnetsFromList(c.provider, c.spec, api.SubnetTopologyPrivate, strings.Split(v, ","))
+		})
+	}
+	if refs, ok := c.localZoneSubnets[api.SubnetTopologyPublic]; ok {
+		c.rs.defineJoinedOutput(outputs.ClusterLocalZoneSubnetsPublic, refs, true, func(v string) error {
+			return vpc.ImportLocalZoneSubnetsFromList(c.provider, c.spec, api.SubnetTopologyPublic, strings.Split(v, ","))
+		})
+	}
 }
 
 var (
 	sgProtoTCP           = gfn.NewString("tcp")
+	sgProtoAll           = gfn.NewString("-1")
 	sgSourceAnywhereIPv4 = gfn.NewString("0.0.0.0/0")
 	sgSourceAnywhereIPv6 = gfn.NewString("::/0")
 
@@ -195,6 +328,9 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups() {
 		refControlPlaneSG = gfn.NewString(c.spec.VPC.SecurityGroup)
 	}
 	c.securityGroups = []*gfn.Value{refControlPlaneSG} // only this one SG is passed to EKS API, nodes are isolated
+	for _, id := range c.spec.VPC.ExtraControlPlaneSecurityGroups {
+		c.securityGroups = append(c.securityGroups, gfn.NewString(id))
+	}
 
 	if c.spec.VPC.SharedNodeSecurityGroup == "" {
 		refClusterSharedNodeSG = c.newResource("ClusterSharedNodeSecurityGroup", &gfn.AWSEC2SecurityGroup{
@@ -211,8 +347,20 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups() {
 		})
 	} else {
 		refClusterSharedNodeSG = gfn.NewString(c.spec.VPC.SharedNodeSecurityGroup)
+		if api.IsEnabled(c.spec.VPC.ManageSharedNodeSecurityGroupRules) {
+			c.newResource("IngressInterNodeGroupSG", &gfn.AWSEC2SecurityGroupIngress{
+				GroupId:               refClusterSharedNodeSG,
+				SourceSecurityGroupId: refClusterSharedNodeSG,
+				Description:           gfn.NewString("Allow nodes to communicate with each other (all ports)"),
+				IpProtocol:            gfn.NewString("-1"),
+				FromPort:              sgPortZero,
+				ToPort:                sgMaxNodePort,
+			})
+		}
 	}
 
+	c.addResourcesForExtraControlPlaneIngressRules(refControlPlaneSG, refClusterSharedNodeSG)
+
 	if c.spec.VPC == nil {
 		c.spec.VPC = &api.ClusterVPC{}
 	}
@@ -226,6 +374,56 @@ func (c *ClusterResourceSet) addResourcesForSecurityGroups() {
 	})
 }
 
+// ExtraControlPlaneIngressRuleResourcePrefix is the prefix of the names of the resources
+// of extra control plane ingress rules in the cluster stack
+const ExtraControlPlaneIngressRuleResourcePrefix = "IngressControlPlaneExtra"
+
+// addResourcesForExtraControlPlaneIngressRules adds a resource for each rule, named after a hash
+// of the rule, so that new or changed rules are added by updates that only append new resources
+func (c *ClusterResourceSet) addResourcesForExtraControlPlaneIngressRules(refControlPlaneSG, refClusterSharedNodeSG *gfn.Value) {
+	for _, r := range c.spec.VPC.ExtraControlPlaneIngressRules {
+		protocol, fromPort, toPort := r.Protocol, r.FromPort, r.ToPort
+		if protocol == "" {
+			protocol = api.ProtocolTCP
+		}
+		if toPort == 0 {
+			toPort = fromPort
+		}
+		if protocol == api.ProtocolAll {
+			fromPort, toPort = 0, 65535
+		}
+
+		ingress := &gfn.AWSEC2SecurityGroupIngress{
+			GroupId:    refControlPlaneSG,
+			IpProtocol: gfn.NewString(protocol),
+			FromPort:   gfn.NewInteger(fromPort),
+			ToPort:     gfn.NewInteger(toPort),
+		}
+		source := ""
+		switch {
+		case r.CIDR != nil:
+			source = r.CIDR.String()
+			ingress.CidrIp = gfn.NewString(source)
+		case r.SourceSecurityGroupID != "":
+			source = r.SourceSecurityGroupID
+			ingress.SourceSecurityGroupId = gfn.NewString(source)
+		default:
+			source = "shared node security group"
+			ingress.SourceSecurityGroupId = refClusterSharedNodeSG
+		}
+
+		description := r.Description
+		if description == "" {
+			description = fmt.Sprintf("Allow %s ports %d-%d from %s to the control plane", protocol, fromPort, toPort, source)
+		}
+		ingress.Description = gfn.NewString(description)
+
+		h := fnv.New32a()
+		fmt.Fprintf(h, "%s/%d/%d/%s/%s", protocol, fromPort, toPort, source, description)
+		c.newResource(fmt.Sprintf("%s%08X", ExtraControlPlaneIngressRuleResourcePrefix, h.Sum32()), ingress)
+	}
+}
+
 func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 	for _, id := range n.spec.SecurityGroups.AttachIDs {
 		n.securityGroups = append(n.securityGroups, gfn.NewString(id))
@@ -236,6 +434,10 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 		n.securityGroups = append(n.securityGroups, refClusterSharedNodeSG)
 	}
 
+	if api.IsEnabled(n.spec.EFAEnabled) {
+		n.addResourcesForEFASecurityGroup()
+	}
+
 	if api.IsDisabled(n.spec.SecurityGroups.WithLocal) {
 		return
 	}
@@ -247,7 +449,7 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 	refControlPlaneSG := makeImportValue(n.clusterStackName, outputs.ClusterSecurityGroup)
 
 	refNodeGroupLocalSG := n.newResource("SG", &gfn.AWSEC2SecurityGroup{
-		VpcId:            makeImportValue(n.clusterStackName, outputs.ClusterVPC),
+		VpcId:            n.vpc,
 		GroupDescription: gfn.NewString("Communication between the control plane and " + desc),
 		Tags: []gfn.Tag{{
 			Key:   gfn.NewString("kubernetes.io/cluster/" + n.clusterSpec.Metadata.Name),
@@ -297,6 +499,15 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 		FromPort:              sgPortHTTPS,
 		ToPort:                sgPortHTTPS,
 	})
+	if n.clusterSpec.IPv6Enabled() {
+		// security groups only allow outbound IPv4 traffic by default
+		n.newResource("EgressAllIPv6", &gfn.AWSEC2SecurityGroupEgress{
+			GroupId:     refNodeGroupLocalSG,
+			CidrIpv6:    sgSourceAnywhereIPv6,
+			Description: gfn.NewString("Allow " + desc + " to send IPv6 traffic"),
+			IpProtocol:  sgProtoAll,
+		})
+	}
 	if *n.spec.SSH.Allow {
 		if n.spec.PrivateNetworking {
 			n.newResource("SSHIPv4", &gfn.AWSEC2SecurityGroupIngress{
@@ -328,6 +539,36 @@ func (n *NodeGroupResourceSet) addResourcesForSecurityGroups() {
 	}
 }
 
+// addResourcesForEFASecurityGroup creates a security group that allows all
+// traffic between the nodes, as it's required by Elastic Fabric Adapter
+func (n *NodeGroupResourceSet) addResourcesForEFASecurityGroup() {
+	desc := "EFA-enabled worker nodes in group " + n.nodeGroupName
+
+	refEFASG := n.newResource("EFASG", &gfn.AWSEC2SecurityGroup{
+		VpcId:            n.vpc,
+		GroupDescription: gfn.NewString("Communication between " + desc),
+		Tags: []gfn.Tag{{
+			Key:   gfn.NewString("kubernetes.io/cluster/" + n.clusterSpec.Metadata.Name),
+			Value: gfn.NewString("owned"),
+		}},
+	})
+
+	n.securityGroups = append(n.securityGroups, refEFASG)
+
+	n.newResource("IngressEFA", &gfn.AWSEC2SecurityGroupIngress{
+		GroupId:               refEFASG,
+		SourceSecurityGroupId: refEFASG,
+		Description:           gfn.NewString("Allow all traffic between " + desc),
+		IpProtocol:            sgProtoAll,
+	})
+	n.newResource("EgressEFA", &gfn.AWSEC2SecurityGroupEgress{
+		GroupId:                    refEFASG,
+		DestinationSecurityGroupId: refEFASG,
+		Description:                gfn.NewString("Allow all traffic between " + desc),
+		IpProtocol:                 sgProtoAll,
+	})
+}
+
 func (c *ClusterResourceSet) haNAT() {
 
 	for _, az := range c.spec.AvailabilityZones {
@@ -0,0 +1,102 @@
+package manager
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+func (c *StackCollection) makeAlarmsStackName() string {
+	return c.spec.StackNamePrefix() + c.spec.Metadata.Name + "-alarms"
+}
+
+// createAlarmsTask creates the CloudWatch alarms of the cluster, for the nodegroups
+// that exist at that point and the NAT gateways of the VPC
+func (c *StackCollection) createAlarmsTask(errs chan error) error {
+	name := c.makeAlarmsStackName()
+	logger.Info("building alarms stack %q", name)
+
+	nodeGroupASGs, err := c.nodeGroupAutoScalingGroups()
+	if err != nil {
+		return err
+	}
+	natGatewayIDs, err := c.natGatewayIDs()
+	if err != nil {
+		return err
+	}
+
+	stack := builder.NewAlarmsResourceSet(c.spec, nodeGroupASGs, natGatewayIDs)
+	if err := stack.AddAllResources(); err != nil {
+		return err
+	}
+
+	return c.CreateOrResumeStack(name, stack, nil, nil, errs)
+}
+
+// nodeGroupAutoScalingGroups returns the auto scaling groups of the nodegroup stacks, keyed by
+// the name of their nodegroup; nodegroups of other accounts are skipped as alarms can't watch them
+func (c *StackCollection) nodeGroupAutoScalingGroups() (map[string]string, error) {
+	stacks, err := c.DescribeNodeGroupStacks()
+	if err != nil {
+		return nil, err
+	}
+	asgs := map[string]string{}
+	for _, s := range stacks {
+		ngName := c.GetNodeGroupName(s)
+		if _, ok := c.nodeGroupProviders[ngName]; ok {
+			logger.Warning("nodegroup %q is in another account, no alarm will be created for it", ngName)
+			continue
+		}
+		asgName, err := c.getNodeGroupAutoScalingGroupName(ngName)
+		if err != nil {
+			return nil, err
+		}
+		asgs[ngName] = aws.StringValue(asgName)
+	}
+	return asgs, nil
+}
+
+// natGatewayIDs returns the available NAT gateways of the VPC of the cluster
+func (c *StackCollection) natGatewayIDs() ([]string, error) {
+	if c.spec.VPC == nil || c.spec.VPC.ID == "" {
+		return nil, nil
+	}
+	output, err := c.provider.EC2().DescribeNatGateways(&ec2.DescribeNatGatewaysInput{
+		Filter: []*ec2.Filter{
+			{Name: aws.String("vpc-id"), Values: aws.StringSlice([]string{c.spec.VPC.ID})},
+			{Name: aws.String("state"), Values: aws.StringSlice([]string{ec2.NatGatewayStateAvailable})},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing NAT gateways of VPC %q", c.spec.VPC.ID)
+	}
+	ids := []string{}
+	for _, g := range output.NatGateways {
+		ids = append(ids, aws.StringValue(g.NatGatewayId))
+	}
+	return ids, nil
+}
+
+// DescribeAlarmsStack returns the alarms stack of the cluster, or nil when it
+// wasn't created
+func (c *StackCollection) DescribeAlarmsStack() (*Stack, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	name := c.makeAlarmsStackName()
+	for _, s := range stacks {
+		if *s.StackStatus == cfn.StackStatusDeleteComplete {
+			continue
+		}
+		if *s.StackName == name {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
@@ -1,8 +1,10 @@
 package manager
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"sort"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
@@ -11,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
 )
 
 const (
@@ -41,6 +44,15 @@ type StackCollection struct {
 	provider   api.ClusterProvider
 	spec       *api.ClusterConfig
 	sharedTags []*cloudformation.Tag
+
+	// cache is shared by the stack managers of a command, it's nil when stacks aren't cached
+	cache *StackCache
+
+	// nodeGroupProviders holds the providers of nodegroups created in other accounts
+	nodeGroupProviders map[string]api.ClusterProvider
+
+	// ctx stops the waiters when it's done, it's nil unless set with WithContext
+	ctx context.Context
 }
 
 func newTag(key, value string) *cloudformation.Tag {
@@ -52,10 +64,16 @@ func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) *
 	tags := []*cloudformation.Tag{
 		newTag(api.ClusterNameTag, spec.Metadata.Name),
 		newTag(api.OldClusterNameTag, spec.Metadata.Name),
+		newTag(api.ResourceOwnerTag, spec.Metadata.Name),
 	}
 	for key, value := range spec.Metadata.Tags {
 		tags = append(tags, newTag(key, value))
 	}
+	if spec.CloudFormation != nil {
+		for key, value := range spec.CloudFormation.StackTags {
+			tags = append(tags, newTag(key, value))
+		}
+	}
 	return &StackCollection{
 		provider:   provider,
 		spec:       spec,
@@ -63,6 +81,66 @@ func NewStackCollection(provider api.ClusterProvider, spec *api.ClusterConfig) *
 	}
 }
 
+// roleARN returns the service role CloudFormation uses to call AWS APIs, if
+// any, the --cfn-role-arn flag takes precedence over the config file
+func (c *StackCollection) roleARN() string {
+	if cfnRole := c.provider.CloudFormationRoleARN(); cfnRole != "" {
+		return cfnRole
+	}
+	if c.spec.CloudFormation != nil {
+		return c.spec.CloudFormation.ServiceRoleARN
+	}
+	return ""
+}
+
+// rollbackDisabled returns true when stacks that fail to be created are left as they are
+func (c *StackCollection) rollbackDisabled() bool {
+	return c.spec.CloudFormation != nil && api.IsEnabled(c.spec.CloudFormation.DisableRollback)
+}
+
+// SetStackCache makes descriptions of stacks get cached
+func (c *StackCollection) SetStackCache(cache *StackCache) {
+	c.cache = cache
+}
+
+// SetNodeGroupProvider makes the stack of the nodegroup get created with the given
+// provider, i.e. in another account than the cluster's
+func (c *StackCollection) SetNodeGroupProvider(name string, provider api.ClusterProvider) {
+	if c.nodeGroupProviders == nil {
+		c.nodeGroupProviders = make(map[string]api.ClusterProvider)
+	}
+	c.nodeGroupProviders[name] = provider
+}
+
+// forNodeGroup returns the stack collection to manage the stack of the nodegroup with
+func (c *StackCollection) forNodeGroup(name string) *StackCollection {
+	provider, ok := c.nodeGroupProviders[name]
+	if !ok {
+		return c
+	}
+	return &StackCollection{
+		provider:   provider,
+		spec:       c.spec,
+		sharedTags: c.sharedTags,
+		ctx:        c.ctx,
+	}
+}
+
+// WithContext returns a copy of the stack manager whose waiters stop
+// as soon as ctx is done, e.g. when the caller is cancelled
+func (c *StackCollection) WithContext(ctx context.Context) *StackCollection {
+	sc := *c
+	sc.ctx = ctx
+	return &sc
+}
+
+func (c *StackCollection) waitContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 // DoCreateStackRequest requests the creation of a CloudFormation stack
 func (c *StackCollection) DoCreateStackRequest(i *Stack, templateBody []byte, tags, parameters map[string]string, withIAM bool, withNamedIAM bool) error {
 	input := &cloudformation.CreateStackInput{
@@ -86,10 +164,18 @@ func (c *StackCollection) DoCreateStackRequest(i *Stack, templateBody []byte, ta
 		input.SetCapabilities(stackCapabilitiesNamedIAM)
 	}
 
-	if cfnRole := c.provider.CloudFormationRoleARN(); cfnRole != "" {
+	if cfnRole := c.roleARN(); cfnRole != "" {
 		input = input.SetRoleARN(cfnRole)
 	}
 
+	if c.terminationProtected(*i.StackName) {
+		input = input.SetEnableTerminationProtection(true)
+	}
+
+	if c.rollbackDisabled() {
+		input = input.SetOnFailure(cloudformation.OnFailureDoNothing)
+	}
+
 	for k, v := range parameters {
 		p := &cloudformation.Parameter{
 			ParameterKey:   aws.String(k),
@@ -99,6 +185,7 @@ func (c *StackCollection) DoCreateStackRequest(i *Stack, templateBody []byte, ta
 	}
 
 	logger.Debug("CreateStackInput = %#v", input)
+	c.cache.Invalidate(*i.StackName)
 	s, err := c.provider.CloudFormation().CreateStack(input)
 	if err != nil {
 		return errors.Wrapf(err, "creating CloudFormation stack %q", *i.StackName)
@@ -112,47 +199,209 @@ func (c *StackCollection) DoCreateStackRequest(i *Stack, templateBody []byte, ta
 // assume completion, do not expect more then one error value on the
 // channel, it's closed immediately after it is written to
 func (c *StackCollection) CreateStack(name string, stack builder.ResourceSet, tags, parameters map[string]string, errs chan error) error {
+	i, err := c.SubmitStack(name, stack, tags, parameters)
+	if err != nil {
+		return err
+	}
+
+	go c.waitUntilStackIsCreated(i, stack, errs)
+
+	return nil
+}
+
+// SubmitStack requests the creation of a stack with given name, stack builder
+// instance and parameters, it returns as soon as the request is accepted
+func (c *StackCollection) SubmitStack(name string, stack builder.ResourceSet, tags, parameters map[string]string) (*Stack, error) {
 	i := &Stack{StackName: &name}
 	templateBody, err := stack.RenderJSON()
 	if err != nil {
-		return errors.Wrapf(err, "rendering template for %q stack", *i.StackName)
+		return nil, errors.Wrapf(err, "rendering template for %q stack", *i.StackName)
+	}
+	if templateBody, err = c.overrideTemplate(name, templateBody); err != nil {
+		return nil, err
 	}
 
 	if err := c.DoCreateStackRequest(i, templateBody, tags, parameters, stack.WithIAM(), stack.WithNamedIAM()); err != nil {
-		return err
+		return nil, err
 	}
 
 	logger.Info("deploying stack %q", name)
 
-	go c.waitUntilStackIsCreated(i, stack, errs)
+	return i, nil
+}
 
-	return nil
+// Actions taken by CreateOrResumeStack for a stack that may already exist
+const (
+	stackActionCreate   = "create"
+	stackActionSkip     = "skip"
+	stackActionWait     = "wait"
+	stackActionRecreate = "recreate"
+	stackActionFail     = "fail"
+)
+
+// resumeStackAction decides what to do with a stack of the given status, so
+// that a creation that was interrupted can be run again; an empty status
+// means that the stack doesn't exist
+func resumeStackAction(status string) string {
+	switch status {
+	case "", cloudformation.StackStatusDeleteComplete:
+		return stackActionCreate
+	case cloudformation.StackStatusCreateComplete,
+		cloudformation.StackStatusUpdateComplete,
+		cloudformation.StackStatusUpdateRollbackComplete:
+		return stackActionSkip
+	case cloudformation.StackStatusCreateInProgress:
+		return stackActionWait
+	case cloudformation.StackStatusCreateFailed,
+		cloudformation.StackStatusRollbackFailed,
+		cloudformation.StackStatusRollbackComplete,
+		cloudformation.StackStatusDeleteInProgress,
+		cloudformation.StackStatusDeleteFailed:
+		return stackActionRecreate
+	default:
+		return stackActionFail
+	}
+}
+
+// CreateOrResumeStack is like CreateStack, but it can be run again after a failed creation:
+// healthy stacks are kept and their outputs are loaded, stacks that are still being created
+// are waited for, and stacks that failed are deleted and created again
+func (c *StackCollection) CreateOrResumeStack(name string, stack builder.ResourceSet, tags, parameters map[string]string, errs chan error) error {
+	s, err := c.DescribeStack(&Stack{StackName: &name})
+	if err != nil {
+		if eksctlerrors.ClassOf(err) != eksctlerrors.ClassNotFound {
+			return err
+		}
+		s = &Stack{StackName: &name}
+	}
+	status := aws.StringValue(s.StackStatus)
+
+	switch resumeStackAction(status) {
+	case stackActionCreate:
+		return c.CreateStack(name, stack, tags, parameters, errs)
+	case stackActionSkip:
+		logger.Info("stack %q already exists with status %s, it will be kept", name, status)
+		go func() {
+			defer close(errs)
+			if err := stack.GetAllOutputs(*s); err != nil {
+				errs <- errors.Wrapf(err, "getting stack %q outputs", name)
+				return
+			}
+			errs <- nil
+		}()
+		return nil
+	case stackActionWait:
+		logger.Info("stack %q is still being created, waiting for it", name)
+		go c.waitUntilStackIsCreated(s, stack, errs)
+		return nil
+	case stackActionRecreate:
+		logger.Warning("stack %q has status %s, it will be deleted and created again", name, status)
+		go func() {
+			if status != cloudformation.StackStatusDeleteInProgress {
+				if _, err := c.DeleteStackBySpec(s); err != nil {
+					errs <- err
+					close(errs)
+					return
+				}
+			}
+			if err := c.doWaitUntilStackIsDeleted(s); err != nil {
+				errs <- errors.Wrapf(err, "deleting stack %q before creating it again", name)
+				close(errs)
+				return
+			}
+			if err := c.CreateStack(name, stack, tags, parameters, errs); err != nil {
+				errs <- err
+				close(errs)
+			}
+		}()
+		return nil
+	default:
+		if status == cloudformation.StackStatusUpdateRollbackFailed {
+			return fmt.Errorf("stack %q has status %s, repair it with 'eksctl utils repair-stack' before running again", name, status)
+		}
+		return fmt.Errorf("stack %q has status %s, wait for it to complete or fix it before running again", name, status)
+	}
 }
 
 // UpdateStack will update a CloudFormation stack by creating and executing a ChangeSet
 func (c *StackCollection) UpdateStack(stackName string, changeSetName string, description string, template []byte, parameters map[string]string) error {
-	logger.Info(description)
+	i, err := c.SubmitStackUpdate(stackName, changeSetName, description, template, parameters)
+	if err != nil {
+		return err
+	}
+	return c.doWaitUntilStackIsUpdated(i)
+}
+
+// SubmitStackUpdate creates and executes a ChangeSet like UpdateStack does, but it
+// returns as soon as the ChangeSet is executed, without waiting for the update
+func (c *StackCollection) SubmitStackUpdate(stackName string, changeSetName string, description string, template []byte, parameters map[string]string) (*Stack, error) {
+	logger.Info("%s", description)
 	i := &Stack{StackName: &stackName}
 	if err := c.doCreateChangeSetRequest(i, changeSetName, description, template, parameters, true); err != nil {
-		return err
+		return nil, err
 	}
 	if err := c.doWaitUntilChangeSetIsCreated(i, changeSetName); err != nil {
-		return err
+		return nil, err
 	}
 	changeSet, err := c.DescribeStackChangeSet(i, changeSetName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	logger.Debug("changes = %#v", changeSet.Changes)
 	if err := c.doExecuteChangeSet(stackName, changeSetName); err != nil {
 		logger.Warning("error executing Cloudformation changeSet %s in stack %s. Check the Cloudformation console for further details", changeSetName, stackName)
-		return err
+		return nil, err
 	}
-	return c.doWaitUntilStackIsUpdated(i)
+	return i, nil
+}
+
+// FailedStackResources returns the logical IDs of the resources of the stack whose update failed, in
+// a stack in UPDATE_ROLLBACK_FAILED they are the resources that couldn't be rolled back
+func (c *StackCollection) FailedStackResources(s *Stack) ([]string, error) {
+	output, err := c.provider.CloudFormation().DescribeStackResources(&cloudformation.DescribeStackResourcesInput{
+		StackName: s.StackName,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting all resources for %q stack", *s.StackName)
+	}
+	failed := []string{}
+	for _, r := range output.StackResources {
+		if aws.StringValue(r.ResourceStatus) == cloudformation.ResourceStatusUpdateFailed {
+			failed = append(failed, *r.LogicalResourceId)
+		}
+	}
+	sort.Strings(failed)
+	return failed, nil
+}
+
+// ContinueUpdateRollback continues the rollback of a stack in UPDATE_ROLLBACK_FAILED and waits until
+// it's complete; CloudFormation marks the resources to skip as rolled back without rolling them back,
+// so they have to be fixed by hand if they are to match the template again
+func (c *StackCollection) ContinueUpdateRollback(s *Stack, resourcesToSkip []string) error {
+	input := &cloudformation.ContinueUpdateRollbackInput{
+		StackName: s.StackName,
+	}
+	if len(resourcesToSkip) > 0 {
+		input.ResourcesToSkip = aws.StringSlice(resourcesToSkip)
+	}
+	if cfnRole := c.roleARN(); cfnRole != "" {
+		input.SetRoleARN(cfnRole)
+	}
+
+	logger.Info("continuing rollback of stack %q", *s.StackName)
+	logger.Debug("continuing rollback, input = %#v", input)
+	c.cache.Invalidate(*s.StackName)
+	if _, err := c.provider.CloudFormation().ContinueUpdateRollback(input); err != nil {
+		return errors.Wrapf(err, "continuing rollback of stack %q", *s.StackName)
+	}
+	return c.doWaitUntilStackRollbackIsComplete(s)
 }
 
 // DescribeStack describes a cloudformation stack.
 func (c *StackCollection) DescribeStack(i *Stack) (*Stack, error) {
+	if s, ok := c.cache.get(i); ok {
+		return s, nil
+	}
 	input := &cloudformation.DescribeStacksInput{
 		StackName: i.StackName,
 	}
@@ -163,6 +412,7 @@ func (c *StackCollection) DescribeStack(i *Stack) (*Stack, error) {
 	if err != nil {
 		return nil, errors.Wrapf(err, "describing CloudFormation stack %q", *i.StackName)
 	}
+	c.cache.set(resp.Stacks[0])
 	return resp.Stacks[0], nil
 }
 
@@ -208,6 +458,10 @@ func (c *StackCollection) ListStacks(nameRegex string, statusFilters ...string)
 
 // StackStatusIsNotTransitional will return true when stack statate is non-transitional
 func (*StackCollection) StackStatusIsNotTransitional(s *Stack) bool {
+	return stackStatusIsNotTransitional(s)
+}
+
+func stackStatusIsNotTransitional(s *Stack) bool {
 	for _, state := range nonTransitionalReadyStackStatuses() {
 		if *s.StackStatus == state {
 			return true
@@ -318,14 +572,22 @@ func (c *StackCollection) DeleteStackByNameSync(name string, errs chan error) er
 func (c *StackCollection) DeleteStackBySpec(s *Stack) (*Stack, error) {
 	for _, tag := range s.Tags {
 		if matchesClusterName(*tag.Key, *tag.Value, c.spec.Metadata.Name) {
+			// only the cluster stack is kept protected, older eksctl versions protected all the stacks of a cluster
+			if aws.BoolValue(s.EnableTerminationProtection) && *s.StackName != c.makeClusterStackName() {
+				if err := c.DisableTerminationProtection(s); err != nil {
+					return nil, err
+				}
+			}
+
 			input := &cloudformation.DeleteStackInput{
 				StackName: s.StackId,
 			}
 
-			if cfnRole := c.provider.CloudFormationRoleARN(); cfnRole != "" {
+			if cfnRole := c.roleARN(); cfnRole != "" {
 				input = input.SetRoleARN(cfnRole)
 			}
 
+			c.cache.Invalidate(*s.StackName)
 			if _, err := c.provider.CloudFormation().DeleteStack(input); err != nil {
 				return nil, errors.Wrapf(err, "not able to delete stack %q", *s.StackName)
 			}
@@ -340,14 +602,7 @@ func (c *StackCollection) DeleteStackBySpec(s *Stack) (*Stack, error) {
 }
 
 func matchesClusterName(key, value, name string) bool {
-	if key == api.ClusterNameTag && value == name {
-		return true
-	}
-
-	if key == api.OldClusterNameTag && value == name {
-		return true
-	}
-	return false
+	return isClusterNameTag(key) && value == name
 }
 
 // DeleteStackBySpecSync sends a request to delete the stack, and waits until status is DELETE_COMPLETE;
@@ -366,18 +621,58 @@ func (c *StackCollection) DeleteStackBySpecSync(s *Stack, errs chan error) error
 	return nil
 }
 
-func fmtStacksRegexForCluster(name string) string {
-	const ourStackRegexFmt = "^(eksctl|EKS)-%s-((cluster|nodegroup-.+)|(VPC|ServiceRole|ControlPlane|DefaultNodeGroup))$"
-	return fmt.Sprintf(ourStackRegexFmt, name)
+// ListProtectedStacks lists all stacks that belong to the cluster and have termination protection enabled
+func (c *StackCollection) ListProtectedStacks() ([]*Stack, error) {
+	stacks, err := c.ListStacks(fmtStacksRegexForCluster(c.spec.StackNamePrefix(), c.spec.Metadata.Name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing CloudFormation stacks for %q", c.spec.Metadata.Name)
+	}
+	protected := []*Stack{}
+	for _, s := range stacks {
+		if s.EnableTerminationProtection != nil && *s.EnableTerminationProtection {
+			protected = append(protected, s)
+		}
+	}
+	return protected, nil
+}
+
+// terminationProtected tells whether the stack of the given name gets termination protection,
+// only the cluster stack is protected so that nodegroups can still be deleted and replaced
+func (c *StackCollection) terminationProtected(name string) bool {
+	return api.IsEnabled(c.spec.Metadata.DeletionProtection) && name == c.makeClusterStackName()
+}
+
+// DisableTerminationProtection turns off termination protection for the given stack
+func (c *StackCollection) DisableTerminationProtection(s *Stack) error {
+	input := &cloudformation.UpdateTerminationProtectionInput{
+		StackName:                   s.StackName,
+		EnableTerminationProtection: aws.Bool(false),
+	}
+	if api.IsSetAndNonEmptyString(s.StackId) {
+		input.StackName = s.StackId
+	}
+	c.cache.Invalidate(*s.StackName)
+	if _, err := c.provider.CloudFormation().UpdateTerminationProtection(input); err != nil {
+		return errors.Wrapf(err, "disabling termination protection for stack %q", *s.StackName)
+	}
+	logger.Info("disabled termination protection for stack %q", *s.StackName)
+	return nil
+}
+
+// fmtStacksRegexForCluster matches the stacks of a cluster, including those named
+// with the "EKS-" prefix of legacy clusters
+func fmtStacksRegexForCluster(prefix, name string) string {
+	const ourStackRegexFmt = "^(%s|EKS-)%s-((cluster|storage|alarms|noderoles|node-termination-handler|container-insights|nodegroup-.+)|(VPC|ServiceRole|ControlPlane|DefaultNodeGroup))$"
+	return fmt.Sprintf(ourStackRegexFmt, regexp.QuoteMeta(prefix), regexp.QuoteMeta(name))
 }
 
 func (c *StackCollection) errStackNotFound() error {
-	return fmt.Errorf("no eksctl-managed CloudFormation stacks found for %q", c.spec.Metadata.Name)
+	return eksctlerrors.NewNotFound("no eksctl-managed CloudFormation stacks found for %q", c.spec.Metadata.Name)
 }
 
 // DescribeStacks describes the existing stacks
 func (c *StackCollection) DescribeStacks() ([]*Stack, error) {
-	stacks, err := c.ListStacks(fmtStacksRegexForCluster(c.spec.Metadata.Name))
+	stacks, err := c.ListStacks(fmtStacksRegexForCluster(c.spec.StackNamePrefix(), c.spec.Metadata.Name))
 	if err != nil {
 		return nil, errors.Wrapf(err, "describing CloudFormation stacks for %q", c.spec.Metadata.Name)
 	}
@@ -447,7 +742,7 @@ func (c *StackCollection) doCreateChangeSetRequest(i *Stack, changeSetName strin
 		input.SetCapabilities(stackCapabilitiesIAM)
 	}
 
-	if cfnRole := c.provider.CloudFormationRoleARN(); cfnRole != "" {
+	if cfnRole := c.roleARN(); cfnRole != "" {
 		input.SetRoleARN(cfnRole)
 	}
 
@@ -465,6 +760,7 @@ func (c *StackCollection) doCreateChangeSetRequest(i *Stack, changeSetName strin
 		return errors.Wrap(err, fmt.Sprintf("creating ChangeSet %q for stack %q", changeSetName, *i.StackName))
 	}
 	logger.Debug("changeSet = %#v", s)
+	i.StackId = s.StackId
 	return nil
 }
 
@@ -475,6 +771,7 @@ func (c *StackCollection) doExecuteChangeSet(stackName string, changeSetName str
 	}
 
 	logger.Debug("executing changeSet, input = %#v", input)
+	c.cache.Invalidate(stackName)
 
 	if _, err := c.provider.CloudFormation().ExecuteChangeSet(input); err != nil {
 		return errors.Wrapf(err, "executing CloudFormation ChangeSet %q for stack %q", changeSetName, stackName)
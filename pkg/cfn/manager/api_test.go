@@ -0,0 +1,302 @@
+package manager
+
+import (
+	"regexp"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeResourceSet struct{}
+
+func (*fakeResourceSet) AddAllResources() error          { return nil }
+func (*fakeResourceSet) WithIAM() bool                   { return true }
+func (*fakeResourceSet) WithNamedIAM() bool              { return false }
+func (*fakeResourceSet) RenderJSON() ([]byte, error)     { return []byte("{}"), nil }
+func (*fakeResourceSet) GetAllOutputs(_ cfn.Stack) error { return nil }
+
+var _ = Describe("StackCollection stack creation", func() {
+	var (
+		p  *mockprovider.MockProvider
+		sc *StackCollection
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		sc = NewStackCollection(p, cfg)
+	})
+
+	It("submits a stack without waiting for it", func() {
+		p.MockCloudFormation().On("CreateStack", mock.MatchedBy(func(input *cfn.CreateStackInput) bool {
+			return *input.StackName == "eksctl-test-cluster-test" && *input.TemplateBody == "{}" &&
+				len(input.Capabilities) == 1 && *input.Capabilities[0] == cfn.CapabilityCapabilityIam
+		})).Return(&cfn.CreateStackOutput{StackId: aws.String("arn:aws:cloudformation:us-west-2:123:stack/eksctl-test-cluster-test/1")}, nil)
+
+		stack, err := sc.SubmitStack("eksctl-test-cluster-test", &fakeResourceSet{}, map[string]string{"a": "b"}, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*stack.StackName).To(Equal("eksctl-test-cluster-test"))
+		Expect(*stack.StackId).To(Equal("arn:aws:cloudformation:us-west-2:123:stack/eksctl-test-cluster-test/1"))
+
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacks", 0)).To(BeTrue())
+	})
+
+	It("updates tags of a stack without changing its template and parameters", func() {
+		stack := &Stack{
+			StackName: aws.String("eksctl-test-cluster-cluster"),
+			Parameters: []*cfn.Parameter{
+				{ParameterKey: aws.String("ClusterName"), ParameterValue: aws.String("test-cluster")},
+			},
+			Tags: []*cfn.Tag{
+				newTag(api.ClusterNameTag, "test-cluster"),
+				newTag(api.ResourceOwnerTag, "test-cluster"),
+				newTag("team", "a"),
+			},
+		}
+
+		input := sc.updateStackTagsInput(stack, map[string]string{"owner": "me", "env": "prod"})
+		Expect(*input.UsePreviousTemplate).To(BeTrue())
+		Expect(input.Parameters).To(HaveLen(1))
+		Expect(*input.Parameters[0].ParameterKey).To(Equal("ClusterName"))
+		Expect(*input.Parameters[0].UsePreviousValue).To(BeTrue())
+		Expect(input.Parameters[0].ParameterValue).To(BeNil())
+		Expect(input.Tags).To(Equal([]*cfn.Tag{
+			newTag(api.ClusterNameTag, "test-cluster"),
+			newTag(api.ResourceOwnerTag, "test-cluster"),
+			newTag("env", "prod"),
+			newTag("owner", "me"),
+		}))
+	})
+
+	It("decides how to resume the creation of existing stacks", func() {
+		Expect(resumeStackAction("")).To(Equal(stackActionCreate))
+		Expect(resumeStackAction(cfn.StackStatusDeleteComplete)).To(Equal(stackActionCreate))
+		Expect(resumeStackAction(cfn.StackStatusCreateComplete)).To(Equal(stackActionSkip))
+		Expect(resumeStackAction(cfn.StackStatusUpdateComplete)).To(Equal(stackActionSkip))
+		Expect(resumeStackAction(cfn.StackStatusCreateInProgress)).To(Equal(stackActionWait))
+		Expect(resumeStackAction(cfn.StackStatusRollbackComplete)).To(Equal(stackActionRecreate))
+		Expect(resumeStackAction(cfn.StackStatusCreateFailed)).To(Equal(stackActionRecreate))
+		Expect(resumeStackAction(cfn.StackStatusRollbackInProgress)).To(Equal(stackActionFail))
+		Expect(resumeStackAction(cfn.StackStatusUpdateInProgress)).To(Equal(stackActionFail))
+	})
+
+	It("keeps a stack that was already created", func() {
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(&cfn.DescribeStacksOutput{
+			Stacks: []*cfn.Stack{
+				{
+					StackName:   aws.String("eksctl-test-cluster-test"),
+					StackStatus: aws.String(cfn.StackStatusCreateComplete),
+				},
+			},
+		}, nil)
+
+		errs := make(chan error)
+		Expect(sc.CreateOrResumeStack("eksctl-test-cluster-test", &fakeResourceSet{}, nil, nil, errs)).To(Succeed())
+		Expect(<-errs).ToNot(HaveOccurred())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateStack", 0)).To(BeTrue())
+	})
+
+	It("names and tags stacks with the CloudFormation settings of the cluster", func() {
+		Expect(sc.makeClusterStackName()).To(Equal("eksctl-test-cluster-cluster"))
+		Expect(sc.roleARN()).To(BeEmpty())
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.CloudFormation = &api.ClusterCloudFormation{
+			StackNamePrefix: "team-a-",
+			StackTags:       map[string]string{"cost-center": "1234"},
+			ServiceRoleARN:  "arn:aws:iam::123456789012:role/cfn-service-role",
+		}
+		sc = NewStackCollection(p, cfg)
+
+		Expect(sc.makeClusterStackName()).To(Equal("team-a-test-cluster-cluster"))
+		Expect(sc.makeNodeGroupStackName("ng-1")).To(Equal("team-a-test-cluster-nodegroup-ng-1"))
+		Expect(sc.makeStorageStackName()).To(Equal("team-a-test-cluster-storage"))
+		Expect(sc.makeNodeRolesStackName()).To(Equal("team-a-test-cluster-noderoles"))
+		Expect(sc.makeContainerInsightsStackName()).To(Equal("team-a-test-cluster-container-insights"))
+		Expect(sc.sharedTags).To(ContainElement(newTag("cost-center", "1234")))
+		Expect(sc.sharedTags).To(ContainElement(newTag(api.ResourceOwnerTag, "test-cluster")))
+		Expect(sc.roleARN()).To(Equal("arn:aws:iam::123456789012:role/cfn-service-role"))
+	})
+
+	It("matches the stacks of a cluster with a custom prefix", func() {
+		re := regexp.MustCompile(fmtStacksRegexForCluster("team-a-", "test-cluster"))
+		Expect(re.MatchString("team-a-test-cluster-cluster")).To(BeTrue())
+		Expect(re.MatchString("team-a-test-cluster-nodegroup-ng-1")).To(BeTrue())
+		Expect(re.MatchString("team-a-test-cluster-noderoles")).To(BeTrue())
+		Expect(re.MatchString("team-a-test-cluster-node-termination-handler")).To(BeTrue())
+		Expect(re.MatchString("team-a-test-cluster-container-insights")).To(BeTrue())
+		Expect(re.MatchString("EKS-test-cluster-VPC")).To(BeTrue())
+		Expect(re.MatchString("eksctl-test-cluster-cluster")).To(BeFalse())
+		Expect(re.MatchString("team-a-test-cluster-2-cluster")).To(BeFalse())
+	})
+	It("describes stacks that aren't changing only once", func() {
+		status := cfn.StackStatusCreateInProgress
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(func(*cfn.DescribeStacksInput) *cfn.DescribeStacksOutput {
+			return &cfn.DescribeStacksOutput{
+				Stacks: []*cfn.Stack{
+					{
+						StackName:   aws.String("eksctl-test-cluster-cluster"),
+						StackStatus: aws.String(status),
+					},
+				},
+			}
+		}, nil)
+		sc.SetStackCache(NewStackCache())
+		describe := func() string {
+			s, err := sc.DescribeStack(&Stack{StackName: aws.String("eksctl-test-cluster-cluster")})
+			Expect(err).ToNot(HaveOccurred())
+			return *s.StackStatus
+		}
+
+		Expect(describe()).To(Equal(cfn.StackStatusCreateInProgress))
+		status = cfn.StackStatusCreateComplete
+		Expect(describe()).To(Equal(cfn.StackStatusCreateComplete))
+		Expect(describe()).To(Equal(cfn.StackStatusCreateComplete))
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacks", 2)).To(BeTrue())
+
+		sc.cache.Invalidate("eksctl-test-cluster-cluster")
+		Expect(describe()).To(Equal(cfn.StackStatusCreateComplete))
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStacks", 3)).To(BeTrue())
+	})
+
+	It("finds the resources of a stack that couldn't be rolled back", func() {
+		p.MockCloudFormation().On("DescribeStackResources", mock.MatchedBy(func(input *cfn.DescribeStackResourcesInput) bool {
+			return *input.StackName == "eksctl-test-cluster-cluster"
+		})).Return(&cfn.DescribeStackResourcesOutput{
+			StackResources: []*cfn.StackResource{
+				{LogicalResourceId: aws.String("VPC"), ResourceStatus: aws.String(cfn.ResourceStatusUpdateComplete)},
+				{LogicalResourceId: aws.String("NATGateway"), ResourceStatus: aws.String(cfn.ResourceStatusUpdateFailed)},
+				{LogicalResourceId: aws.String("ControlPlaneSecurityGroup"), ResourceStatus: aws.String(cfn.ResourceStatusUpdateFailed)},
+			},
+		}, nil)
+
+		failed, err := sc.FailedStackResources(&Stack{StackName: aws.String("eksctl-test-cluster-cluster")})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(failed).To(Equal([]string{"ControlPlaneSecurityGroup", "NATGateway"}))
+	})
+
+	It("suggests repairing stacks whose update rollback failed", func() {
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(&cfn.DescribeStacksOutput{
+			Stacks: []*cfn.Stack{
+				{
+					StackName:   aws.String("eksctl-test-cluster-test"),
+					StackStatus: aws.String(cfn.StackStatusUpdateRollbackFailed),
+				},
+			},
+		}, nil)
+
+		err := sc.CreateOrResumeStack("eksctl-test-cluster-test", &fakeResourceSet{}, nil, nil, make(chan error))
+		Expect(err).To(MatchError(ContainSubstring("eksctl utils repair-stack")))
+	})
+
+	It("enables termination protection of the cluster stack of protected clusters", func() {
+		sc.spec.Metadata.DeletionProtection = api.Enabled()
+		p.MockCloudFormation().On("CreateStack", mock.Anything).Return(&cfn.CreateStackOutput{StackId: aws.String("arn:aws:cloudformation:us-west-2:123:stack/eksctl-test-cluster-cluster/1")}, nil)
+
+		_, err := sc.SubmitStack("eksctl-test-cluster-cluster", &fakeResourceSet{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = sc.SubmitStack("eksctl-test-cluster-nodegroup-ng-1", &fakeResourceSet{}, nil, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		protection := map[string]bool{}
+		for _, call := range p.MockCloudFormation().Calls {
+			if call.Method != "CreateStack" {
+				continue
+			}
+			input := call.Arguments.Get(0).(*cfn.CreateStackInput)
+			protection[*input.StackName] = aws.BoolValue(input.EnableTerminationProtection)
+		}
+		Expect(protection).To(Equal(map[string]bool{
+			"eksctl-test-cluster-cluster":        true,
+			"eksctl-test-cluster-nodegroup-ng-1": false,
+		}))
+	})
+
+	It("deletes a protected nodegroup stack of a protected cluster", func() {
+		sc.spec.Metadata.DeletionProtection = api.Enabled()
+		p.MockCloudFormation().On("UpdateTerminationProtection", mock.MatchedBy(func(input *cfn.UpdateTerminationProtectionInput) bool {
+			return *input.StackName == "arn:aws:cloudformation:us-west-2:123:stack/eksctl-test-cluster-nodegroup-ng-1/1" &&
+				!*input.EnableTerminationProtection
+		})).Return(&cfn.UpdateTerminationProtectionOutput{}, nil)
+		p.MockCloudFormation().On("DeleteStack", mock.Anything).Return(&cfn.DeleteStackOutput{}, nil)
+
+		_, err := sc.DeleteStackBySpec(&Stack{
+			StackName:                   aws.String("eksctl-test-cluster-nodegroup-ng-1"),
+			StackId:                     aws.String("arn:aws:cloudformation:us-west-2:123:stack/eksctl-test-cluster-nodegroup-ng-1/1"),
+			EnableTerminationProtection: aws.Bool(true),
+			Tags:                        []*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 1)).To(BeTrue())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DeleteStack", 1)).To(BeTrue())
+	})
+
+	It("deletes an unprotected nodegroup stack of a protected cluster as it is", func() {
+		sc.spec.Metadata.DeletionProtection = api.Enabled()
+		p.MockCloudFormation().On("DeleteStack", mock.Anything).Return(&cfn.DeleteStackOutput{}, nil)
+
+		_, err := sc.DeleteStackBySpec(&Stack{
+			StackName:                   aws.String("eksctl-test-cluster-nodegroup-ng-1"),
+			StackId:                     aws.String("arn:aws:cloudformation:us-west-2:123:stack/eksctl-test-cluster-nodegroup-ng-1/1"),
+			EnableTerminationProtection: aws.Bool(false),
+			Tags:                        []*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster")},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 0)).To(BeTrue())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DeleteStack", 1)).To(BeTrue())
+	})
+
+	It("lists and turns off termination protection of the stacks of a cluster", func() {
+		protection := map[string]bool{
+			"eksctl-test-cluster-cluster":            true,
+			"eksctl-test-cluster-nodegroup-ng-1":     false,
+			"eksctl-test-cluster-nodegroup-ng-2":     true,
+			"eksctl-other-cluster-nodegroup-ng-1":    true,
+			"eksctl-test-cluster-2-nodegroup-ng-1":   true,
+			"eksctl-test-cluster-container-insights": false,
+		}
+		p.MockCloudFormation().On("ListStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			consume := args[1].(func(p *cfn.ListStacksOutput, last bool) (shouldContinue bool))
+			out := &cfn.ListStacksOutput{}
+			for name := range protection {
+				out.StackSummaries = append(out.StackSummaries, &cfn.StackSummary{StackName: aws.String(name)})
+			}
+			consume(out, true)
+		}).Return(nil)
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(func(input *cfn.DescribeStacksInput) *cfn.DescribeStacksOutput {
+			return &cfn.DescribeStacksOutput{
+				Stacks: []*cfn.Stack{
+					{
+						StackName:                   input.StackName,
+						StackStatus:                 aws.String(cfn.StackStatusCreateComplete),
+						EnableTerminationProtection: aws.Bool(protection[*input.StackName]),
+					},
+				},
+			}
+		}, nil)
+		p.MockCloudFormation().On("UpdateTerminationProtection", mock.MatchedBy(func(input *cfn.UpdateTerminationProtectionInput) bool {
+			return *input.StackName == "eksctl-test-cluster-cluster" && !*input.EnableTerminationProtection
+		})).Return(&cfn.UpdateTerminationProtectionOutput{}, nil)
+
+		protected, err := sc.ListProtectedStacks()
+		Expect(err).ToNot(HaveOccurred())
+		names := []string{}
+		for _, s := range protected {
+			names = append(names, *s.StackName)
+		}
+		Expect(names).To(ConsistOf("eksctl-test-cluster-cluster", "eksctl-test-cluster-nodegroup-ng-2"))
+
+		Expect(sc.DisableTerminationProtection(&Stack{StackName: aws.String("eksctl-test-cluster-cluster")})).To(Succeed())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 1)).To(BeTrue())
+	})
+})
@@ -0,0 +1,53 @@
+package manager
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+)
+
+// StackCache memoizes descriptions of stacks for the duration of a command, only stacks
+// that aren't changing are cached, and stacks are removed from it when eksctl changes them
+type StackCache struct {
+	mu     sync.Mutex
+	stacks map[string]*Stack
+}
+
+// NewStackCache creates an empty cache
+func NewStackCache() *StackCache {
+	return &StackCache{stacks: make(map[string]*Stack)}
+}
+
+// get returns the cached description of the stack, by name or, when it's set, by ID,
+// a nil cache never has one
+func (c *StackCache) get(i *Stack) (*Stack, bool) {
+	if c == nil || i.StackName == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.stacks[*i.StackName]
+	if !ok || (i.StackId != nil && *i.StackId != "" && aws.StringValue(s.StackId) != *i.StackId) {
+		return nil, false
+	}
+	return s, true
+}
+
+func (c *StackCache) set(s *Stack) {
+	if c == nil || s.StackName == nil || !stackStatusIsNotTransitional(s) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stacks[*s.StackName] = s
+}
+
+// Invalidate removes the stack from the cache
+func (c *StackCache) Invalidate(stackName string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.stacks, stackName)
+}
@@ -2,6 +2,7 @@ package manager
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
+	"github.com/aws/aws-sdk-go/aws"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -22,20 +24,38 @@ func (c *StackCollection) MakeChangeSetName(action string) string {
 }
 
 func (c *StackCollection) makeClusterStackName() string {
-	return "eksctl-" + c.spec.Metadata.Name + "-cluster"
+	return c.spec.StackNamePrefix() + c.spec.Metadata.Name + "-cluster"
 }
 
-// createClusterTask creates the cluster
-func (c *StackCollection) createClusterTask(errs chan error) error {
+func (c *StackCollection) buildClusterStack() (string, *builder.ClusterResourceSet, error) {
 	name := c.makeClusterStackName()
 	logger.Info("building cluster stack %q", name)
 	stack := builder.NewClusterResourceSet(c.provider, c.spec)
 	if err := stack.AddAllResources(); err != nil {
+		return "", nil, err
+	}
+	return name, stack, nil
+}
+
+// createClusterTask creates the cluster
+func (c *StackCollection) createClusterTask(errs chan error) error {
+	name, stack, err := c.buildClusterStack()
+	if err != nil {
 		return err
 	}
 
 	// Unlike with `createNodeGroupTask`, all tags are already set for the cluster stack
-	return c.CreateStack(name, stack, nil, nil, errs)
+	return c.CreateOrResumeStack(name, stack, nil, nil, errs)
+}
+
+// SubmitClusterStack requests the creation of the cluster stack without
+// waiting for it, the stack outputs are only available once it's created
+func (c *StackCollection) SubmitClusterStack() (*Stack, error) {
+	name, stack, err := c.buildClusterStack()
+	if err != nil {
+		return nil, err
+	}
+	return c.SubmitStack(name, stack, nil, nil)
 }
 
 // DescribeClusterStack calls DescribeStacks and filters out cluster stack
@@ -56,6 +76,84 @@ func (c *StackCollection) DescribeClusterStack() (*Stack, error) {
 	return nil, c.errStackNotFound()
 }
 
+// DescribeClusterStackByName describes the cluster stack by its name, unlike
+// DescribeClusterStack it doesn't list the stacks of the cluster
+func (c *StackCollection) DescribeClusterStackByName() (*Stack, error) {
+	return c.DescribeStack(&Stack{StackName: aws.String(c.makeClusterStackName())})
+}
+
+// GetClusterStackTags returns tags of the cluster stack, which are the tags
+// set in metadata.tags and cloudFormation.stackTags at creation time, the tags
+// eksctl uses internally are omitted
+func (c *StackCollection) GetClusterStackTags() (map[string]string, error) {
+	s, err := c.DescribeClusterStackByName()
+	if err != nil {
+		return nil, err
+	}
+
+	tags := map[string]string{}
+	for _, tag := range s.Tags {
+		if isClusterNameTag(*tag.Key) {
+			continue
+		}
+		tags[*tag.Key] = *tag.Value
+	}
+	return tags, nil
+}
+
+// UpdateClusterStackTags replaces the tags of the cluster stack with the given ones, CloudFormation
+// propagates them to the resources of the stack; the tags eksctl uses internally are kept
+func (c *StackCollection) UpdateClusterStackTags(tags map[string]string) error {
+	s, err := c.DescribeStack(&Stack{StackName: aws.String(c.makeClusterStackName())})
+	if err != nil {
+		return err
+	}
+
+	input := c.updateStackTagsInput(s, tags)
+	logger.Info("updating tags of stack %q", *s.StackName)
+	logger.Debug("updating stack, input = %#v", input)
+	c.cache.Invalidate(*s.StackName)
+	if _, err := c.provider.CloudFormation().UpdateStack(input); err != nil {
+		return errors.Wrapf(err, "updating tags of stack %q", *s.StackName)
+	}
+	return c.doWaitUntilStackIsUpdated(s)
+}
+
+// updateStackTagsInput builds an update of the stack that only changes its tags,
+// the template and parameters of the stack are left as they are
+func (c *StackCollection) updateStackTagsInput(s *Stack, tags map[string]string) *cfn.UpdateStackInput {
+	input := &cfn.UpdateStackInput{
+		StackName:           s.StackName,
+		UsePreviousTemplate: aws.Bool(true),
+		Capabilities:        stackCapabilitiesIAM,
+	}
+	if cfnRole := c.roleARN(); cfnRole != "" {
+		input.SetRoleARN(cfnRole)
+	}
+	for _, p := range s.Parameters {
+		input.Parameters = append(input.Parameters, &cfn.Parameter{
+			ParameterKey:     p.ParameterKey,
+			UsePreviousValue: aws.Bool(true),
+		})
+	}
+
+	input.Tags = []*cfn.Tag{}
+	for _, tag := range s.Tags {
+		if isClusterNameTag(*tag.Key) {
+			input.Tags = append(input.Tags, tag)
+		}
+	}
+	keys := []string{}
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		input.Tags = append(input.Tags, newTag(k, tags[k]))
+	}
+	return input
+}
+
 // AppendNewClusterStackResource will update cluster
 // stack with new resources in append-only way
 func (c *StackCollection) AppendNewClusterStackResource(plan bool) (bool, error) {
@@ -67,17 +165,28 @@ func (c *StackCollection) AppendNewClusterStackResource(plan bool) (bool, error)
 	//   is mamaged as part of the stack;
 	// - CloudFormation cannot yet upgrade EKS control plane itself;
 
+	logger.Info("re-building cluster stack %q", name)
+	newStack := builder.NewClusterResourceSet(c.provider, c.spec)
+	if err := newStack.AddAllResources(); err != nil {
+		return false, err
+	}
+
+	return c.appendNewStackResources(name, newStack, c.MakeChangeSetName("update-cluster"), plan)
+}
+
+// UpdateControlPlaneIngressRules reconciles the extra control plane ingress rules of the cluster
+// stack with the config, unlike AppendNewClusterStackResource it also removes the rules that are no
+// longer in the config; other resources are left as they are. It returns true when the stack was
+// updated, or would have been in plan mode
+func (c *StackCollection) UpdateControlPlaneIngressRules(plan bool) (bool, error) {
+	name := c.makeClusterStackName()
+
 	currentTemplate, err := c.GetStackTemplate(name)
 	if err != nil {
 		return false, errors.Wrapf(err, "error getting stack template %s", name)
 	}
-
-	addResources := []string{}
-	addOutputs := []string{}
-
 	currentResources := gjson.Get(currentTemplate, resourcesRootPath)
-	currentOutputs := gjson.Get(currentTemplate, outputsRootPath)
-	if !currentResources.IsObject() || !currentOutputs.IsObject() {
+	if !currentResources.IsObject() {
 		return false, fmt.Errorf("unexpected template format of the current stack ")
 	}
 
@@ -86,11 +195,81 @@ func (c *StackCollection) AppendNewClusterStackResource(plan bool) (bool, error)
 	if err := newStack.AddAllResources(); err != nil {
 		return false, err
 	}
+	newTemplate, err := newStack.RenderJSON()
+	if err != nil {
+		return false, errors.Wrapf(err, "rendering template for %q stack", name)
+	}
+	newResources := gjson.Get(string(newTemplate), resourcesRootPath)
+	if !newResources.IsObject() {
+		return false, fmt.Errorf("unexpected template format of the new version of the stack ")
+	}
+
+	isRule := func(k string) bool {
+		return strings.HasPrefix(k, builder.ExtraControlPlaneIngressRuleResourcePrefix)
+	}
+	addRules, removeRules := []string{}, []string{}
+	var iterErr error
+	newResources.ForEach(func(k, v gjson.Result) bool {
+		if !isRule(k.String()) || currentResources.Get(k.String()).Exists() {
+			return true
+		}
+		addRules = append(addRules, k.String())
+		currentTemplate, iterErr = sjson.Set(currentTemplate, resourcesRootPath+"."+k.String(), v.Value())
+		return iterErr == nil
+	})
+	if iterErr != nil {
+		return false, errors.Wrap(iterErr, "adding rules to current stack template")
+	}
+	currentResources.ForEach(func(k, _ gjson.Result) bool {
+		if !isRule(k.String()) || newResources.Get(k.String()).Exists() {
+			return true
+		}
+		removeRules = append(removeRules, k.String())
+		currentTemplate, iterErr = sjson.Delete(currentTemplate, resourcesRootPath+"."+k.String())
+		return iterErr == nil
+	})
+	if iterErr != nil {
+		return false, errors.Wrap(iterErr, "removing rules from current stack template")
+	}
+
+	if len(addRules) == 0 && len(removeRules) == 0 {
+		logger.Success("control plane ingress rules in stack %q are up-to-date", name)
+		return false, nil
+	}
+
+	describeUpdate := fmt.Sprintf("updating stack to add control plane ingress rules %v and remove %v", addRules, removeRules)
+	if plan {
+		logger.Info("(plan) %s", describeUpdate)
+		return true, nil
+	}
+	return true, c.UpdateStack(name, c.MakeChangeSetName("update-control-plane-ingress"), describeUpdate, []byte(currentTemplate), nil)
+}
+
+// appendNewStackResources updates the stack with the resources and outputs of the new
+// stack that it doesn't have yet, existing ones are left as they are; it returns true
+// when the stack was updated, or would have been in plan mode
+func (c *StackCollection) appendNewStackResources(name string, newStack builder.ResourceSet, changeSetName string, plan bool) (bool, error) {
+	currentTemplate, err := c.GetStackTemplate(name)
+	if err != nil {
+		return false, errors.Wrapf(err, "error getting stack template %s", name)
+	}
+
+	addResources := []string{}
+	addOutputs := []string{}
+
+	currentResources := gjson.Get(currentTemplate, resourcesRootPath)
+	currentOutputs := gjson.Get(currentTemplate, outputsRootPath)
+	if !currentResources.IsObject() || !currentOutputs.IsObject() {
+		return false, fmt.Errorf("unexpected template format of the current stack ")
+	}
 
 	newTemplate, err := newStack.RenderJSON()
 	if err != nil {
 		return false, errors.Wrapf(err, "rendering template for %q stack", name)
 	}
+	if newTemplate, err = c.overrideTemplate(name, newTemplate); err != nil {
+		return false, err
+	}
 	logger.Debug("newTemplate = %s", newTemplate)
 
 	newResources := gjson.Get(string(newTemplate), resourcesRootPath)
@@ -126,7 +305,7 @@ func (c *StackCollection) AppendNewClusterStackResource(plan bool) (bool, error)
 	}
 
 	if len(addResources) == 0 && len(addOutputs) == 0 {
-		logger.Success("all resources in cluster stack %q are up-to-date", name)
+		logger.Success("all resources in stack %q are up-to-date", name)
 		return false, nil
 	}
 
@@ -135,9 +314,9 @@ func (c *StackCollection) AppendNewClusterStackResource(plan bool) (bool, error)
 	describeUpdate := fmt.Sprintf("updating stack to add new resources %v and ouputs %v", addResources, addOutputs)
 	if plan {
 		logger.Info("(plan) %s", describeUpdate)
-		return false, nil
+		return true, nil
 	}
-	return true, c.UpdateStack(name, c.MakeChangeSetName("update-cluster"), describeUpdate, []byte(currentTemplate), nil)
+	return true, c.UpdateStack(name, changeSetName, describeUpdate, []byte(currentTemplate), nil)
 }
 
 func getClusterName(s *Stack) string {
@@ -155,9 +334,15 @@ func getClusterName(s *Stack) string {
 
 func getClusterNameTag(s *Stack) string {
 	for _, tag := range s.Tags {
-		if *tag.Key == api.ClusterNameTag || *tag.Key == api.OldClusterNameTag {
+		if isClusterNameTag(*tag.Key) {
 			return *tag.Value
 		}
 	}
 	return ""
 }
+
+// isClusterNameTag returns true for the tags eksctl sets to the name of the cluster,
+// which are used internally to find the resources of the cluster
+func isClusterNameTag(key string) bool {
+	return key == api.ClusterNameTag || key == api.OldClusterNameTag || key == api.ResourceOwnerTag
+}
@@ -0,0 +1,77 @@
+package manager
+
+import (
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+)
+
+func (c *StackCollection) makeContainerInsightsStackName() string {
+	return c.spec.StackNamePrefix() + c.spec.Metadata.Name + "-container-insights"
+}
+
+// CreateContainerInsightsStack creates the IAM role that the given service accounts of Container
+// Insights assume through the IAM OIDC provider of the cluster, or keeps the existing one, and
+// returns its ARN; the OIDC issuer has to be set in the status of the config
+func (c *StackCollection) CreateContainerInsightsStack(oidcProviderARN string, serviceAccounts []string) (string, error) {
+	name := c.makeContainerInsightsStackName()
+	logger.Info("building Container Insights stack %q", name)
+	stack := builder.NewContainerInsightsResourceSet(c.spec, oidcProviderARN, serviceAccounts)
+	if err := stack.AddAllResources(); err != nil {
+		return "", err
+	}
+
+	errs := make(chan error)
+	if err := c.CreateOrResumeStack(name, stack, nil, nil, errs); err != nil {
+		return "", err
+	}
+	if err := <-errs; err != nil {
+		return "", errors.Wrapf(err, "creating stack %q", name)
+	}
+	return stack.RoleARN, nil
+}
+
+// GetContainerInsightsRoleARN returns the ARN of the IAM role of the Container Insights stack,
+// or an empty string when the stack doesn't exist
+func (c *StackCollection) GetContainerInsightsRoleARN() (string, error) {
+	s, err := c.DescribeContainerInsightsStack()
+	if err != nil || s == nil {
+		return "", err
+	}
+
+	roleARN := ""
+	collectors := map[string]outputs.Collector{
+		outputs.ContainerInsightsRoleARN: func(v string) error {
+			roleARN = v
+			return nil
+		},
+	}
+	if err := outputs.Collect(*s, collectors, nil); err != nil {
+		return "", errors.Wrapf(err, "getting stack %q outputs", *s.StackName)
+	}
+	return roleARN, nil
+}
+
+// DescribeContainerInsightsStack returns the Container Insights stack of the cluster,
+// or nil when Container Insights doesn't use IAM roles for service accounts
+func (c *StackCollection) DescribeContainerInsightsStack() (*Stack, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	name := c.makeContainerInsightsStackName()
+	for _, s := range stacks {
+		if *s.StackStatus == cfn.StackStatusDeleteComplete {
+			continue
+		}
+		if *s.StackName == name {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
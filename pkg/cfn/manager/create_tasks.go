@@ -4,6 +4,8 @@ import (
 	"fmt"
 
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 )
 
 // NewTasksToCreateClusterWithNodeGroups defines all tasks required to create a cluster along
@@ -18,7 +20,27 @@ func (c *StackCollection) NewTasksToCreateClusterWithNodeGroups(onlyNodeGroupSub
 		},
 	)
 
-	nodeGroupTasks := c.NewTasksToCreateNodeGroups(onlyNodeGroupSubset)
+	if c.spec.HasNodeRoles() {
+		tasks.Append(&taskWithoutParams{
+			info: fmt.Sprintf("create node roles of cluster %q", c.spec.Metadata.Name),
+			call: c.createNodeRolesTask,
+		})
+	}
+
+	nodeGroupTasks := inParallel(c.newTasksToCreateNodeGroups(onlyNodeGroupSubset))
+	if c.spec.HasEFS() || c.spec.HasFSx() {
+		// file systems don't depend on nodes, so they are created along with nodegroups
+		nodeGroupTasks.Append(&taskWithoutParams{
+			info: fmt.Sprintf("create file systems of cluster %q", c.spec.Metadata.Name),
+			call: c.createStorageTask,
+		})
+	}
+	if c.spec.NodeTerminationHandlerQueueMode() {
+		nodeGroupTasks.Append(&taskWithoutParams{
+			info: fmt.Sprintf("create Node Termination Handler queue of cluster %q", c.spec.Metadata.Name),
+			call: c.createNodeTerminationHandlerTask,
+		})
+	}
 	if nodeGroupTasks.Len() > 0 {
 		nodeGroupTasks.IsSubTask = true
 		tasks.Append(nodeGroupTasks)
@@ -27,23 +49,129 @@ func (c *StackCollection) NewTasksToCreateClusterWithNodeGroups(onlyNodeGroupSub
 	return tasks
 }
 
+// NewTasksToCreateAlarms defines the task that creates the alarms of the cluster, it's
+// separate from the other tasks as the alarms refer to the control plane log group,
+// which only exists once logging is enabled after the stacks are created
+func (c *StackCollection) NewTasksToCreateAlarms() *TaskTree {
+	tasks := &TaskTree{Parallel: false}
+
+	tasks.Append(&taskWithoutParams{
+		info: fmt.Sprintf("create alarms of cluster %q", c.spec.Metadata.Name),
+		call: c.createAlarmsTask,
+	})
+
+	return tasks
+}
+
 // NewTasksToCreateNodeGroups defines tasks required to create all of the nodegroups if
 // onlySubset is nil, otherwise just the tasks for nodegroups that are in onlySubset
-// will be defined
+// will be defined; when some of these nodegroups share node roles, the node roles stack
+// is created or updated before them
 func (c *StackCollection) NewTasksToCreateNodeGroups(onlySubset sets.String) *TaskTree {
-	tasks := &TaskTree{Parallel: true}
+	nodeGroupTasks := c.newTasksToCreateNodeGroups(onlySubset)
 
-	for i := range c.spec.NodeGroups {
-		ng := c.spec.NodeGroups[i]
+	usesNodeRoles := false
+	for _, ng := range c.spec.NodeGroups {
 		if onlySubset != nil && !onlySubset.Has(ng.Name) {
 			continue
 		}
-		tasks.Append(&taskWithNodeGroupSpec{
-			info:      fmt.Sprintf("create nodegroup %q", ng.Name),
-			nodeGroup: ng,
-			call:      c.createNodeGroupTask,
-		})
+		if ng.IAM != nil && ng.IAM.NodeRole != "" {
+			usesNodeRoles = true
+		}
+	}
+	if !usesNodeRoles || nodeGroupTasks.Len() == 0 {
+		return nodeGroupTasks
+	}
+
+	tasks := c.NewTasksToCreateNodeRoles()
+	nodeGroupTasks.IsSubTask = true
+	tasks.Append(nodeGroupTasks)
+	return tasks
+}
+
+// newTasksToCreateNodeGroups creates the nodegroups in parallel, unless some of them
+// depend on others, then they are created in turn by level of dependencies
+func (c *StackCollection) newTasksToCreateNodeGroups(onlySubset sets.String) *TaskTree {
+	nodeGroups := map[string]*api.NodeGroup{}
+	names := []string{}
+	for _, ng := range c.spec.NodeGroups {
+		if onlySubset != nil && !onlySubset.Has(ng.Name) {
+			continue
+		}
+		nodeGroups[ng.Name] = ng
+		names = append(names, ng.Name)
+	}
+
+	levels := c.nodeGroupDependencyLevels(names)
+	tasks := &TaskTree{Parallel: len(levels) <= 1}
+	for _, level := range levels {
+		levelTasks := tasks
+		if !tasks.Parallel {
+			levelTasks = &TaskTree{Parallel: true, IsSubTask: true}
+			tasks.Append(levelTasks)
+		}
+		for _, name := range level {
+			levelTasks.Append(&taskWithNodeGroupSpec{
+				info:      fmt.Sprintf("create nodegroup %q", name),
+				nodeGroup: nodeGroups[name],
+				call:      c.createNodeGroupTask,
+			})
+		}
 	}
 
 	return tasks
 }
+
+// nodeGroupDependencyLevels groups the nodegroups of names so that those of a level
+// only depend on nodegroups of the levels before it, as set in dependsOn; dependencies
+// on nodegroups that aren't in names are ignored, and names keep their order
+func (c *StackCollection) nodeGroupDependencyLevels(names []string) [][]string {
+	included := sets.NewString(names...)
+	dependsOn := map[string][]string{}
+	for _, ng := range c.spec.NodeGroups {
+		dependsOn[ng.Name] = ng.DependsOn
+	}
+
+	levelOf := map[string]int{}
+	var level func(name string, visiting sets.String) int
+	level = func(name string, visiting sets.String) int {
+		if l, ok := levelOf[name]; ok {
+			return l
+		}
+		l := 0
+		// cycles are rejected by validation, but mustn't cause an endless recursion
+		visiting.Insert(name)
+		for _, dep := range dependsOn[name] {
+			if included.Has(dep) && !visiting.Has(dep) {
+				if depLevel := level(dep, visiting) + 1; depLevel > l {
+					l = depLevel
+				}
+			}
+		}
+		visiting.Delete(name)
+		levelOf[name] = l
+		return l
+	}
+
+	levels := [][]string{}
+	for _, name := range names {
+		l := level(name, sets.NewString())
+		for len(levels) <= l {
+			levels = append(levels, []string{})
+		}
+		levels[l] = append(levels[l], name)
+	}
+	return levels
+}
+
+// inParallel returns tasks as a parallel tree, so that other tasks can be appended
+// to run along with them
+func inParallel(tasks *TaskTree) *TaskTree {
+	if tasks.Parallel {
+		return tasks
+	}
+	tasks.IsSubTask = true
+	parallelTasks := &TaskTree{Parallel: true}
+	parallelTasks.Append(tasks)
+	return parallelTasks
+}
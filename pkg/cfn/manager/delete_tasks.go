@@ -17,11 +17,73 @@ func (c *StackCollection) NewTasksToDeleteClusterWithNodeGroups(wait bool, clean
 	if err != nil {
 		return nil, err
 	}
+	nodeGroupTasks = inParallel(nodeGroupTasks)
+
+	// the storage stack imports outputs of the cluster stack, so like nodegroups
+	// it has to be deleted before the cluster stack, regardless of wait
+	storageStack, err := c.DescribeStorageStack()
+	if err != nil {
+		return nil, err
+	}
+	if storageStack != nil {
+		nodeGroupTasks.Append(&taskWithStackSpec{
+			info:  fmt.Sprintf("delete file systems of cluster %q", c.spec.Metadata.Name),
+			stack: storageStack,
+			call:  c.DeleteStackBySpecSync,
+		})
+	}
+	nodeTerminationHandlerStack, err := c.DescribeNodeTerminationHandlerStack()
+	if err != nil {
+		return nil, err
+	}
+	if nodeTerminationHandlerStack != nil {
+		nodeGroupTasks.Append(&taskWithStackSpec{
+			info:  fmt.Sprintf("delete Node Termination Handler queue of cluster %q", c.spec.Metadata.Name),
+			stack: nodeTerminationHandlerStack,
+			call:  c.DeleteStackBySpecSync,
+		})
+	}
+	containerInsightsStack, err := c.DescribeContainerInsightsStack()
+	if err != nil {
+		return nil, err
+	}
+	if containerInsightsStack != nil {
+		nodeGroupTasks.Append(&taskWithStackSpec{
+			info:  fmt.Sprintf("delete Container Insights IAM role of cluster %q", c.spec.Metadata.Name),
+			stack: containerInsightsStack,
+			call:  c.DeleteStackBySpecSync,
+		})
+	}
+	// alarms would go off while nodegroups are deleted, so they are deleted first
+	alarmsStack, err := c.DescribeAlarmsStack()
+	if err != nil {
+		return nil, err
+	}
+	if alarmsStack != nil {
+		tasks.Append(&taskWithStackSpec{
+			info:  fmt.Sprintf("delete alarms of cluster %q", c.spec.Metadata.Name),
+			stack: alarmsStack,
+			call:  c.DeleteStackBySpecSync,
+		})
+	}
 	if nodeGroupTasks.Len() > 0 {
 		nodeGroupTasks.IsSubTask = true
 		tasks.Append(nodeGroupTasks)
 	}
 
+	// node roles are used by the nodegroups, they are deleted once all of them are gone
+	nodeRolesStack, err := c.DescribeNodeRolesStack()
+	if err != nil {
+		return nil, err
+	}
+	if nodeRolesStack != nil {
+		tasks.Append(&taskWithStackSpec{
+			info:  fmt.Sprintf("delete node roles of cluster %q", c.spec.Metadata.Name),
+			stack: nodeRolesStack,
+			call:  c.DeleteStackBySpecSync,
+		})
+	}
+
 	clusterStack, err := c.DescribeClusterStack()
 	if err != nil {
 		return nil, err
@@ -47,41 +109,67 @@ func (c *StackCollection) NewTasksToDeleteClusterWithNodeGroups(wait bool, clean
 
 // NewTasksToDeleteNodeGroups defines tasks required to delete all of the nodegroups if
 // onlySubset is nil, otherwise just the tasks for nodegroups that are in onlySubset
-// will be defined
+// will be defined; when waiting, nodegroups that others depend on are deleted after them
 func (c *StackCollection) NewTasksToDeleteNodeGroups(onlySubset sets.String, wait bool, cleanup func(chan error, string) error) (*TaskTree, error) {
 	nodeGroupStacks, err := c.DescribeNodeGroupStacks()
 	if err != nil {
 		return nil, err
 	}
 
-	tasks := &TaskTree{Parallel: true}
-
+	stacks := map[string]*Stack{}
+	names := []string{}
 	for _, s := range nodeGroupStacks {
 		name := c.GetNodeGroupName(s)
 		if onlySubset != nil && !onlySubset.Has(name) {
 			continue
 		}
-		if *s.StackStatus == cloudformation.StackStatusDeleteFailed && cleanup != nil {
-			tasks.Append(&taskWithNameParam{
-				info: fmt.Sprintf("cleanup for nodegroup %q", name),
-				call: cleanup,
-			})
+		stacks[name] = s
+		names = append(names, name)
+	}
+
+	levels := [][]string{names}
+	if wait {
+		levels = c.nodeGroupDependencyLevels(names)
+		// the nodegroups of the last level are the first to be deleted
+		for i, j := 0, len(levels)-1; i < j; i, j = i+1, j-1 {
+			levels[i], levels[j] = levels[j], levels[i]
 		}
-		info := fmt.Sprintf("delete nodegroup %q", name)
-		if wait {
-			tasks.Append(&taskWithStackSpec{
-				info:  info,
-				stack: s,
-				call:  c.DeleteStackBySpecSync,
-			})
-		} else {
-			tasks.Append(&asyncTaskWithStackSpec{
-				info:  info,
-				stack: s,
-				call:  c.DeleteStackBySpec,
-			})
+	}
+
+	tasks := &TaskTree{Parallel: len(levels) <= 1}
+	for _, level := range levels {
+		levelTasks := tasks
+		if !tasks.Parallel {
+			levelTasks = &TaskTree{Parallel: true, IsSubTask: true}
+			tasks.Append(levelTasks)
+		}
+		for _, name := range level {
+			c.appendDeleteNodeGroupTasks(levelTasks, name, stacks[name], wait, cleanup)
 		}
 	}
 
 	return tasks, nil
 }
+
+func (c *StackCollection) appendDeleteNodeGroupTasks(tasks *TaskTree, name string, s *Stack, wait bool, cleanup func(chan error, string) error) {
+	if *s.StackStatus == cloudformation.StackStatusDeleteFailed && cleanup != nil {
+		tasks.Append(&taskWithNameParam{
+			info: fmt.Sprintf("cleanup for nodegroup %q", name),
+			call: cleanup,
+		})
+	}
+	info := fmt.Sprintf("delete nodegroup %q", name)
+	if wait {
+		tasks.Append(&taskWithStackSpec{
+			info:  info,
+			stack: s,
+			call:  c.DeleteStackBySpecSync,
+		})
+	} else {
+		tasks.Append(&asyncTaskWithStackSpec{
+			info:  info,
+			stack: s,
+			call:  c.DeleteStackBySpec,
+		})
+	}
+}
@@ -68,7 +68,7 @@ func (c *StackCollection) DeleteTasksForDeprecatedStacks() (*TaskTree, error) {
 				},
 			)
 
-			return waiters.Wait(c.spec.Metadata.Name, msg, acceptors, newRequest, c.provider.WaitTimeout(), nil)
+			return waiters.WaitWithContext(c.waitContext(), c.spec.Metadata.Name, msg, acceptors, newRequest, c.provider.WaitTimeout(), c.provider.PollInterval(), nil)
 		},
 	}
 
@@ -0,0 +1,172 @@
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/utils/file"
+)
+
+const (
+	// nodeLogPath is the log of the user data of nodes, i.e. of their bootstrap
+	nodeLogPath = "/var/log/cloud-init-output.log"
+	// nodeLogLimit is how much of the end of the log is collected, as SSM truncates
+	// the output of commands beyond 24000 characters
+	nodeLogLimit = 24000
+
+	ssmCommandPollInterval = 3 * time.Second
+	ssmCommandMaxAttempts  = 20
+)
+
+// collectFailureBundle writes what's needed to troubleshoot the failed creation of
+// a stack to a local archive, to attach to support tickets
+func (c *StackCollection) collectFailureBundle(i *Stack) {
+	files := c.failureBundleFiles(i)
+	if len(files) == 0 {
+		return
+	}
+	path := fmt.Sprintf("%s-failure-%s.tar.gz", *i.StackName, time.Now().UTC().Format("20060102T150405Z"))
+	if err := file.WriteTarball(path, files); err != nil {
+		logger.Warning("failed to write troubleshooting bundle: %v", err)
+		return
+	}
+	logger.Info("stack events, scaling activities and node logs were collected in %q", path)
+}
+
+// failureBundleFiles collects the events of the stack, the scaling activities of its
+// auto scaling groups and, from their instances that are managed by SSM, the end of
+// the log of the node bootstrap; everything is collected on a best-effort basis
+func (c *StackCollection) failureBundleFiles(i *Stack) map[string][]byte {
+	files := map[string][]byte{}
+	addJSON := func(name string, v interface{}) {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			logger.Debug("encoding %s: %v", name, err)
+			return
+		}
+		files[name] = data
+	}
+
+	events, err := c.DescribeStackEvents(i)
+	if err != nil {
+		logger.Debug("collecting stack events: %v", err)
+	} else {
+		addJSON("stack-events.json", events)
+	}
+
+	resources, err := c.provider.CloudFormation().DescribeStackResources(&cfn.DescribeStackResourcesInput{
+		StackName: i.StackName,
+	})
+	if err != nil {
+		logger.Debug("collecting stack resources: %v", err)
+		return files
+	}
+	for _, r := range resources.StackResources {
+		if aws.StringValue(r.ResourceType) != "AWS::AutoScaling::AutoScalingGroup" || r.PhysicalResourceId == nil {
+			continue
+		}
+		asgName := *r.PhysicalResourceId
+
+		activities, err := c.provider.ASG().DescribeScalingActivities(&autoscaling.DescribeScalingActivitiesInput{
+			AutoScalingGroupName: &asgName,
+		})
+		if err != nil {
+			logger.Debug("collecting scaling activities of %q: %v", asgName, err)
+		} else {
+			addJSON(asgName+"/scaling-activities.json", activities.Activities)
+		}
+
+		for _, id := range c.ssmManagedInstances(asgName) {
+			log, err := c.nodeLog(id)
+			if err != nil {
+				logger.Debug("collecting %s of %q: %v", nodeLogPath, id, err)
+				continue
+			}
+			files[asgName+"/"+id+"-cloud-init-output.log"] = log
+		}
+	}
+	return files
+}
+
+// ssmManagedInstances returns the instances of the auto scaling group that SSM can run commands on
+func (c *StackCollection) ssmManagedInstances(asgName string) []string {
+	groups, err := c.provider.ASG().DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{&asgName},
+	})
+	if err != nil || len(groups.AutoScalingGroups) == 0 {
+		logger.Debug("describing auto scaling group %q: %v", asgName, err)
+		return nil
+	}
+	ids := []*string{}
+	for _, instance := range groups.AutoScalingGroups[0].Instances {
+		ids = append(ids, instance.InstanceId)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	info, err := c.provider.SSM().DescribeInstanceInformation(&ssm.DescribeInstanceInformationInput{
+		Filters: []*ssm.InstanceInformationStringFilter{
+			{Key: aws.String("InstanceIds"), Values: ids},
+		},
+	})
+	if err != nil {
+		logger.Debug("describing SSM instance information: %v", err)
+		return nil
+	}
+	managed := []string{}
+	for _, i := range info.InstanceInformationList {
+		if aws.StringValue(i.PingStatus) == ssm.PingStatusOnline {
+			managed = append(managed, aws.StringValue(i.InstanceId))
+		}
+	}
+	sort.Strings(managed)
+	return managed
+}
+
+// nodeLog returns the end of the log of the node bootstrap, read with SSM Run Command
+func (c *StackCollection) nodeLog(instanceID string) ([]byte, error) {
+	command, err := c.provider.SSM().SendCommand(&ssm.SendCommandInput{
+		DocumentName: aws.String("AWS-RunShellScript"),
+		InstanceIds:  []*string{&instanceID},
+		Parameters: map[string][]*string{
+			"commands": {aws.String(fmt.Sprintf("tail -c %d %s", nodeLogLimit, nodeLogPath))},
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "sending command")
+	}
+
+	for attempt := 0; attempt < ssmCommandMaxAttempts; attempt++ {
+		time.Sleep(ssmCommandPollInterval)
+		invocation, err := c.provider.SSM().GetCommandInvocation(&ssm.GetCommandInvocationInput{
+			CommandId:  command.Command.CommandId,
+			InstanceId: &instanceID,
+		})
+		if err != nil {
+			if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == ssm.ErrCodeInvocationDoesNotExist {
+				continue
+			}
+			return nil, errors.Wrap(err, "getting command output")
+		}
+		switch status := aws.StringValue(invocation.Status); status {
+		case ssm.CommandInvocationStatusPending, ssm.CommandInvocationStatusInProgress, ssm.CommandInvocationStatusDelayed:
+			continue
+		case ssm.CommandInvocationStatusSuccess:
+			return []byte(aws.StringValue(invocation.StandardOutputContent)), nil
+		default:
+			return nil, fmt.Errorf("command %s: %s", status, aws.StringValue(invocation.StandardErrorContent))
+		}
+	}
+	return nil, fmt.Errorf("command didn't complete after %s", ssmCommandPollInterval*ssmCommandMaxAttempts)
+}
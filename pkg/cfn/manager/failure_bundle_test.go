@@ -0,0 +1,73 @@
+package manager
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection failure bundle", func() {
+	var (
+		p  *mockprovider.MockProvider
+		sc *StackCollection
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		sc = NewStackCollection(p, cfg)
+	})
+
+	It("doesn't roll back stacks when rollback is disabled", func() {
+		Expect(sc.rollbackDisabled()).To(BeFalse())
+
+		sc.spec.CloudFormation = &api.ClusterCloudFormation{DisableRollback: api.Enabled()}
+		p.MockCloudFormation().On("CreateStack", mock.MatchedBy(func(input *cfn.CreateStackInput) bool {
+			return aws.StringValue(input.OnFailure) == cfn.OnFailureDoNothing
+		})).Return(&cfn.CreateStackOutput{StackId: aws.String("stack-1")}, nil)
+
+		Expect(sc.DoCreateStackRequest(&Stack{StackName: aws.String("eksctl-test-cluster-nodegroup-ng-1")}, []byte("{}"), nil, nil, false, false)).To(Succeed())
+	})
+
+	It("collects stack events and scaling activities", func() {
+		p.MockCloudFormation().On("DescribeStackEventsPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			pager := args.Get(1).(func(*cfn.DescribeStackEventsOutput, bool) bool)
+			pager(&cfn.DescribeStackEventsOutput{
+				StackEvents: []*cfn.StackEvent{
+					{LogicalResourceId: aws.String("NodeGroup"), ResourceStatus: aws.String(cfn.ResourceStatusCreateFailed)},
+				},
+			}, true)
+		}).Return(nil)
+		p.MockCloudFormation().On("DescribeStackResources", mock.Anything).Return(&cfn.DescribeStackResourcesOutput{
+			StackResources: []*cfn.StackResource{
+				{ResourceType: aws.String("AWS::EC2::LaunchTemplate"), PhysicalResourceId: aws.String("lt-1")},
+				{ResourceType: aws.String("AWS::AutoScaling::AutoScalingGroup"), PhysicalResourceId: aws.String("asg-1")},
+			},
+		}, nil)
+		p.MockASG().On("DescribeScalingActivities", mock.MatchedBy(func(input *autoscaling.DescribeScalingActivitiesInput) bool {
+			return *input.AutoScalingGroupName == "asg-1"
+		})).Return(&autoscaling.DescribeScalingActivitiesOutput{
+			Activities: []*autoscaling.Activity{{StatusCode: aws.String("Failed")}},
+		}, nil)
+		p.MockASG().On("DescribeAutoScalingGroups", mock.Anything).Return(&autoscaling.DescribeAutoScalingGroupsOutput{
+			AutoScalingGroups: []*autoscaling.Group{
+				{Instances: []*autoscaling.Instance{{InstanceId: aws.String("i-1")}}},
+			},
+		}, nil)
+		// the instance isn't managed by SSM, so its log can't be collected
+		p.MockSSM().On("DescribeInstanceInformation", mock.Anything).Return(&ssm.DescribeInstanceInformationOutput{}, nil)
+
+		files := sc.failureBundleFiles(&Stack{StackName: aws.String("eksctl-test-cluster-nodegroup-ng-1")})
+		Expect(files).To(HaveLen(2))
+		Expect(string(files["stack-events.json"])).To(ContainSubstring(`"LogicalResourceId": "NodeGroup"`))
+		Expect(string(files["asg-1/scaling-activities.json"])).To(ContainSubstring(`"StatusCode": "Failed"`))
+	})
+})
@@ -0,0 +1,90 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+func (c *StackCollection) makeNodeRolesStackName() string {
+	return c.spec.StackNamePrefix() + c.spec.Metadata.Name + "-noderoles"
+}
+
+// createNodeRolesTask creates the node roles stack, it has to run before the nodegroups
+// that share a node role are created; when the stack already exists, the roles that
+// were added to the config since are appended to it, the existing ones are kept
+func (c *StackCollection) createNodeRolesTask(errs chan error) error {
+	name := c.makeNodeRolesStackName()
+	logger.Info("building node roles stack %q", name)
+	stack := builder.NewNodeRolesResourceSet(c.spec)
+	if err := stack.AddAllResources(); err != nil {
+		return err
+	}
+
+	s, err := c.DescribeNodeRolesStack()
+	if err != nil {
+		return err
+	}
+	if s == nil || resumeStackAction(aws.StringValue(s.StackStatus)) != stackActionSkip {
+		return c.CreateOrResumeStack(name, stack, nil, nil, errs)
+	}
+
+	go func() {
+		defer close(errs)
+		if _, err := c.appendNewStackResources(name, stack, c.MakeChangeSetName("update-noderoles"), false); err != nil {
+			errs <- errors.Wrapf(err, "updating stack %q", name)
+			return
+		}
+		c.cache.Invalidate(name)
+		s, err := c.DescribeStack(&Stack{StackName: &name})
+		if err != nil {
+			errs <- err
+			return
+		}
+		if err := stack.GetAllOutputs(*s); err != nil {
+			errs <- errors.Wrapf(err, "getting stack %q outputs", name)
+			return
+		}
+		errs <- nil
+	}()
+	return nil
+}
+
+// NewTasksToCreateNodeRoles defines the task that creates or updates the node roles
+// stack, it's used when nodegroups are created without the other tasks of the cluster
+func (c *StackCollection) NewTasksToCreateNodeRoles() *TaskTree {
+	tasks := &TaskTree{Parallel: false}
+
+	tasks.Append(&taskWithoutParams{
+		info: fmt.Sprintf("create node roles of cluster %q", c.spec.Metadata.Name),
+		call: c.createNodeRolesTask,
+	})
+
+	return tasks
+}
+
+// DescribeNodeRolesStack returns the node roles stack of the cluster, or nil
+// when the cluster has no node roles created by eksctl
+func (c *StackCollection) DescribeNodeRolesStack() (*Stack, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	name := c.makeNodeRolesStackName()
+	for _, s := range stacks {
+		if *s.StackStatus == cfn.StackStatusDeleteComplete {
+			continue
+		}
+		if *s.StackName == name {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,46 @@
+package manager
+
+import (
+	"github.com/kris-nova/logger"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+func (c *StackCollection) makeNodeTerminationHandlerStackName() string {
+	return c.spec.StackNamePrefix() + c.spec.Metadata.Name + "-node-termination-handler"
+}
+
+// createNodeTerminationHandlerTask creates the queue and rules Node Termination Handler
+// consumes in queue mode, the URL of the queue is set in the config once it's created
+func (c *StackCollection) createNodeTerminationHandlerTask(errs chan error) error {
+	name := c.makeNodeTerminationHandlerStackName()
+	logger.Info("building Node Termination Handler stack %q", name)
+	stack := builder.NewNodeTerminationHandlerResourceSet(c.spec)
+	if err := stack.AddAllResources(); err != nil {
+		return err
+	}
+
+	return c.CreateOrResumeStack(name, stack, nil, nil, errs)
+}
+
+// DescribeNodeTerminationHandlerStack returns the Node Termination Handler stack of the
+// cluster, or nil when Node Termination Handler wasn't installed in queue mode
+func (c *StackCollection) DescribeNodeTerminationHandlerStack() (*Stack, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	name := c.makeNodeTerminationHandlerStackName()
+	for _, s := range stacks {
+		if *s.StackStatus == cfn.StackStatusDeleteComplete {
+			continue
+		}
+		if *s.StackName == name {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
@@ -11,10 +11,13 @@ import (
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
 )
 
 const (
@@ -23,6 +26,7 @@ const (
 	minSizePath         = resourcesRootPath + ".NodeGroup.Properties.MinSize"
 	instanceTypePath    = resourcesRootPath + ".NodeGroupLaunchTemplate.Properties.LaunchTemplateData.InstanceType"
 	imageIDPath         = resourcesRootPath + ".NodeGroupLaunchTemplate.Properties.LaunchTemplateData.ImageId"
+	userDataPath        = resourcesRootPath + ".NodeGroupLaunchTemplate.Properties.LaunchTemplateData.UserData"
 )
 
 // NodeGroupSummary represents a summary of a nodegroup stack
@@ -36,20 +40,42 @@ type NodeGroupSummary struct {
 	InstanceType    string
 	ImageID         string
 	CreationTime    *time.Time
+	// Tags are the tags of the stack, they include the tags of the nodegroup
+	Tags map[string]string `json:",omitempty"`
 }
 
 // makeNodeGroupStackName generates the name of the node group identified by its ID, isolated by the cluster this StackCollection operates on
 func (c *StackCollection) makeNodeGroupStackName(name string) string {
-	return fmt.Sprintf("eksctl-%s-nodegroup-%s", c.spec.Metadata.Name, name)
+	return fmt.Sprintf("%s%s-nodegroup-%s", c.spec.StackNamePrefix(), c.spec.Metadata.Name, name)
 }
 
-// createNodeGroupTask creates the nodegroup
-func (c *StackCollection) createNodeGroupTask(errs chan error, ng *api.NodeGroup) error {
+// WaitForNodeGroupStackCreated blocks until the stack of the nodegroup is created
+func (c *StackCollection) WaitForNodeGroupStackCreated(name string) error {
+	stackName := c.makeNodeGroupStackName(name)
+	return c.DoWaitUntilStackIsCreated(&Stack{StackName: &stackName})
+}
+
+// WaitForNodeGroupStackUpdated blocks until the update of the stack of the nodegroup is complete
+func (c *StackCollection) WaitForNodeGroupStackUpdated(name string) error {
+	stackName := c.makeNodeGroupStackName(name)
+	return c.doWaitUntilStackIsUpdated(&Stack{StackName: &stackName})
+}
+
+// WaitForNodeGroupStackDeleted blocks until the stack of the nodegroup is deleted
+func (c *StackCollection) WaitForNodeGroupStackDeleted(name string) error {
+	stackName := c.makeNodeGroupStackName(name)
+	return c.doWaitUntilStackIsDeleted(&Stack{StackName: &stackName})
+}
+
+// buildNodeGroupStack returns the stack of the nodegroup, along with
+// the stack collection to create it with
+func (c *StackCollection) buildNodeGroupStack(ng *api.NodeGroup) (string, *StackCollection, *builder.NodeGroupResourceSet, error) {
 	name := c.makeNodeGroupStackName(ng.Name)
 	logger.Info("building nodegroup stack %q", name)
-	stack := builder.NewNodeGroupResourceSet(c.provider, c.spec, c.makeClusterStackName(), ng)
+	stackCollection := c.forNodeGroup(ng.Name)
+	stack := builder.NewNodeGroupResourceSet(stackCollection.provider, c.spec, c.makeClusterStackName(), ng)
 	if err := stack.AddAllResources(); err != nil {
-		return err
+		return "", nil, nil, err
 	}
 
 	if ng.Tags == nil {
@@ -58,7 +84,28 @@ func (c *StackCollection) createNodeGroupTask(errs chan error, ng *api.NodeGroup
 	ng.Tags[api.NodeGroupNameTag] = ng.Name
 	ng.Tags[api.OldNodeGroupNameTag] = ng.Name
 
-	return c.CreateStack(name, stack, ng.Tags, nil, errs)
+	return name, stackCollection, stack, nil
+}
+
+// createNodeGroupTask creates the nodegroup
+func (c *StackCollection) createNodeGroupTask(errs chan error, ng *api.NodeGroup) error {
+	name, stackCollection, stack, err := c.buildNodeGroupStack(ng)
+	if err != nil {
+		return err
+	}
+
+	return stackCollection.CreateOrResumeStack(name, stack, ng.Tags, nil, errs)
+}
+
+// SubmitNodeGroupStack requests the creation of the nodegroup stack without
+// waiting for it, the cluster stack must already be created
+func (c *StackCollection) SubmitNodeGroupStack(ng *api.NodeGroup) (*Stack, error) {
+	name, stackCollection, stack, err := c.buildNodeGroupStack(ng)
+	if err != nil {
+		return nil, err
+	}
+
+	return stackCollection.SubmitStack(name, stack, ng.Tags, nil)
 }
 
 // DescribeNodeGroupStacks calls DescribeStacks and filters out nodegroups
@@ -129,6 +176,16 @@ func (c *StackCollection) DescribeNodeGroupStacksAndResources() (map[string]Stac
 
 // ScaleNodeGroup will scale an existing nodegroup
 func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
+	i, err := c.SubmitNodeGroupScale(ng)
+	if err != nil || i == nil {
+		return err
+	}
+	return c.doWaitUntilStackIsUpdated(i)
+}
+
+// SubmitNodeGroupScale requests the scaling of an existing nodegroup without waiting for
+// the update of its stack, no stack is returned when the desired capacity is already set
+func (c *StackCollection) SubmitNodeGroupScale(ng *api.NodeGroup) (*Stack, error) {
 	clusterName := c.makeClusterStackName()
 	c.spec.Status = &api.ClusterStatus{StackName: clusterName}
 	name := c.makeNodeGroupStackName(ng.Name)
@@ -137,7 +194,7 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 	// Get current stack
 	template, err := c.GetStackTemplate(name)
 	if err != nil {
-		return errors.Wrapf(err, "error getting stack template %s", name)
+		return nil, errors.Wrapf(err, "error getting stack template %s", name)
 	}
 	logger.Debug("stack template (pre-scale change): %s", template)
 
@@ -154,14 +211,14 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 
 	if ng.DesiredCapacity != nil && int64(*ng.DesiredCapacity) == currentCapacity.Int() {
 		logger.Info("desired capacity of nodegroup %q in cluster %q is already %d", ng.Name, clusterName, *ng.DesiredCapacity)
-		return nil
+		return nil, nil
 	}
 
 	// Set the new values
 	newCapacity := fmt.Sprintf("%d", *ng.DesiredCapacity)
 	template, err = sjson.Set(template, desiredCapacityPath, newCapacity)
 	if err != nil {
-		return errors.Wrap(err, "setting desired capacity")
+		return nil, errors.Wrap(err, "setting desired capacity")
 	}
 	descriptionBuffer.WriteString(fmt.Sprintf("desired capacity from %s to %d", currentCapacity.Str, *ng.DesiredCapacity))
 
@@ -170,7 +227,7 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 		newMinSize := fmt.Sprintf("%d", *ng.DesiredCapacity)
 		template, err = sjson.Set(template, minSizePath, newMinSize)
 		if err != nil {
-			return errors.Wrap(err, "setting min size")
+			return nil, errors.Wrap(err, "setting min size")
 		}
 		descriptionBuffer.WriteString(fmt.Sprintf(", min size from %s to %d", currentMinSize.Str, *ng.DesiredCapacity))
 	}
@@ -179,13 +236,132 @@ func (c *StackCollection) ScaleNodeGroup(ng *api.NodeGroup) error {
 		newMaxSize := fmt.Sprintf("%d", *ng.DesiredCapacity)
 		template, err = sjson.Set(template, maxSizePath, newMaxSize)
 		if err != nil {
-			return errors.Wrap(err, "setting max size")
+			return nil, errors.Wrap(err, "setting max size")
 		}
 		descriptionBuffer.WriteString(fmt.Sprintf(", max size from %s to %d", currentMaxSize.Str, *ng.DesiredCapacity))
 	}
 	logger.Debug("stack template (post-scale change): %s", template)
 
-	return c.UpdateStack(name, c.MakeChangeSetName("scale-nodegroup"), descriptionBuffer.String(), []byte(template), nil)
+	return c.SubmitStackUpdate(name, c.MakeChangeSetName("scale-nodegroup"), descriptionBuffer.String(), []byte(template), nil)
+}
+
+// SuspendNodeGroupAZRebalance suspends the AZRebalance process of the auto scaling group of the
+// nodegroup when azRebalance is disabled, as CloudFormation can't suspend processes of groups
+func (c *StackCollection) SuspendNodeGroupAZRebalance(ng *api.NodeGroup) error {
+	if ng.AZRebalance != api.AZRebalanceDisable {
+		return nil
+	}
+	stackCollection := c.forNodeGroup(ng.Name)
+	asgName, err := stackCollection.getNodeGroupAutoScalingGroupName(ng.Name)
+	if err != nil {
+		return err
+	}
+
+	_, err = stackCollection.provider.ASG().SuspendProcesses(&autoscaling.ScalingProcessQuery{
+		AutoScalingGroupName: asgName,
+		ScalingProcesses:     aws.StringSlice([]string{"AZRebalance"}),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "suspending AZRebalance process of auto scaling group %q", aws.StringValue(asgName))
+	}
+	logger.Info("suspended AZRebalance process of auto scaling group %q of nodegroup %q", aws.StringValue(asgName), ng.Name)
+	return nil
+}
+
+// getNodeGroupAutoScalingGroupName returns the name of the auto scaling group of the nodegroup stack
+func (c *StackCollection) getNodeGroupAutoScalingGroupName(ngName string) (*string, error) {
+	name := c.makeNodeGroupStackName(ngName)
+	resource, err := c.provider.CloudFormation().DescribeStackResource(&cfn.DescribeStackResourceInput{
+		StackName:         &name,
+		LogicalResourceId: aws.String("NodeGroup"),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting auto scaling group of nodegroup %q", ngName)
+	}
+	return resource.StackResourceDetail.PhysicalResourceId, nil
+}
+
+// GetNodeGroupLabels returns the labels nodes of the nodegroup register with, as set in the user data of its stack
+func (c *StackCollection) GetNodeGroupLabels(ngName string) (map[string]string, error) {
+	userData, err := c.getNodeGroupUserData(ngName)
+	if err != nil {
+		return nil, err
+	}
+	return nodebootstrap.GetNodeLabels(userData)
+}
+
+// GetNodeGroupTaints returns the taints nodes of the nodegroup register with, as set in the user data of its stack
+func (c *StackCollection) GetNodeGroupTaints(ngName string) (map[string]string, error) {
+	userData, err := c.getNodeGroupUserData(ngName)
+	if err != nil {
+		return nil, err
+	}
+	return nodebootstrap.GetNodeTaints(userData)
+}
+
+func (c *StackCollection) getNodeGroupUserData(ngName string) (string, error) {
+	name := c.makeNodeGroupStackName(ngName)
+	template, err := c.GetStackTemplate(name)
+	if err != nil {
+		return "", errors.Wrapf(err, "error getting stack template %s", name)
+	}
+	userData := gjson.Get(template, userDataPath)
+	if !userData.Exists() || userData.Type != gjson.String {
+		return "", fmt.Errorf("user data of nodegroup %q not found in stack %q", ngName, name)
+	}
+	return userData.String(), nil
+}
+
+// UpdateNodeGroupLabels replaces the labels nodes of the nodegroup register with, only
+// nodes launched after the update get them, existing nodes have to be labelled directly
+func (c *StackCollection) UpdateNodeGroupLabels(ngName string, labels map[string]string) error {
+	name := c.makeNodeGroupStackName(ngName)
+	template, err := c.GetStackTemplate(name)
+	if err != nil {
+		return errors.Wrapf(err, "error getting stack template %s", name)
+	}
+	userData := gjson.Get(template, userDataPath)
+	if !userData.Exists() || userData.Type != gjson.String {
+		return fmt.Errorf("user data of nodegroup %q not found in stack %q", ngName, name)
+	}
+
+	newUserData, err := nodebootstrap.SetNodeLabels(userData.String(), labels)
+	if err != nil {
+		return errors.Wrapf(err, "updating labels of nodegroup %q", ngName)
+	}
+	template, err = sjson.Set(template, userDataPath, newUserData)
+	if err != nil {
+		return errors.Wrap(err, "setting user data")
+	}
+
+	description := fmt.Sprintf("updating labels of nodegroup %q", ngName)
+	return c.UpdateStack(name, c.MakeChangeSetName("update-labels"), description, []byte(template), nil)
+}
+
+// UpdateNodeGroupImage sets the AMI of the launch template of the nodegroup, only
+// instances launched after the update use it, existing ones have to be replaced
+func (c *StackCollection) UpdateNodeGroupImage(ngName, imageID string) error {
+	name := c.makeNodeGroupStackName(ngName)
+	template, err := c.GetStackTemplate(name)
+	if err != nil {
+		return errors.Wrapf(err, "error getting stack template %s", name)
+	}
+	currentImageID := gjson.Get(template, imageIDPath)
+	if !currentImageID.Exists() {
+		return fmt.Errorf("AMI of nodegroup %q not found in stack %q", ngName, name)
+	}
+	if currentImageID.String() == imageID {
+		logger.Info("nodegroup %q already uses AMI %q", ngName, imageID)
+		return nil
+	}
+
+	template, err = sjson.Set(template, imageIDPath, imageID)
+	if err != nil {
+		return errors.Wrap(err, "setting AMI")
+	}
+
+	description := fmt.Sprintf("updating AMI of nodegroup %q from %q to %q", ngName, currentImageID.String(), imageID)
+	return c.UpdateStack(name, c.MakeChangeSetName("update-ami"), description, []byte(template), nil)
 }
 
 // GetNodeGroupSummaries returns a list of summaries for the nodegroups of a cluster
@@ -236,6 +412,10 @@ func (c *StackCollection) mapStackToNodeGroupSummary(stack *Stack) (*NodeGroupSu
 		InstanceType:    instanceType.String(),
 		ImageID:         imageID.String(),
 		CreationTime:    stack.CreationTime,
+		Tags:            make(map[string]string),
+	}
+	for _, tag := range stack.Tags {
+		summary.Tags[*tag.Key] = *tag.Value
 	}
 
 	return summary, nil
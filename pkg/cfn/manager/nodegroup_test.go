@@ -3,6 +3,7 @@ package manager
 import (
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -82,6 +83,89 @@ var _ = Describe("StackCollection NodeGroup", func() {
 
 				Expect(err).NotTo(HaveOccurred())
 			})
+
+			It("should not submit anything if attempting to scale to the existing desired capacity", func() {
+				ng.Name = "12345"
+				cap := 2
+				ng.DesiredCapacity = &cap
+
+				stack, err := sc.SubmitNodeGroupScale(ng)
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stack).To(BeNil())
+				Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateChangeSet", 0)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UpdateNodeGroupImage", func() {
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			cc = newClusterConfig("test-cluster")
+			sc = NewStackCollection(p, cc)
+
+			p.MockCloudFormation().On("GetTemplate", mock.MatchedBy(func(input *cfn.GetTemplateInput) bool {
+				return input.StackName != nil && *input.StackName == "eksctl-test-cluster-nodegroup-12345"
+			})).Return(&cfn.GetTemplateOutput{
+				TemplateBody: aws.String(`{
+					"Resources": {
+						"NodeGroupLaunchTemplate": {
+							"Properties": {
+								"LaunchTemplateData": {
+									"ImageId": "ami-123"
+								}
+							}
+						}
+					}
+				}`),
+			}, nil)
+		})
+
+		It("should be a no-op if the nodegroup already uses the AMI", func() {
+			Expect(sc.UpdateNodeGroupImage("12345", "ami-123")).To(Succeed())
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateChangeSet", 0)).To(BeTrue())
+		})
+
+		It("should error if the stack of the nodegroup has no AMI", func() {
+			p.MockCloudFormation().On("GetTemplate", mock.MatchedBy(func(input *cfn.GetTemplateInput) bool {
+				return input.StackName != nil && *input.StackName == "eksctl-test-cluster-nodegroup-67890"
+			})).Return(&cfn.GetTemplateOutput{
+				TemplateBody: aws.String(`{"Resources": {}}`),
+			}, nil)
+			Expect(sc.UpdateNodeGroupImage("67890", "ami-456")).To(MatchError(`AMI of nodegroup "67890" not found in stack "eksctl-test-cluster-nodegroup-67890"`))
+		})
+	})
+
+	Describe("SuspendNodeGroupAZRebalance", func() {
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			cc = newClusterConfig("test-cluster")
+			sc = NewStackCollection(p, cc)
+		})
+
+		It("should be a no-op unless AZ rebalancing is disabled", func() {
+			ng := newNodeGroup(cc)
+			Expect(sc.SuspendNodeGroupAZRebalance(ng)).To(Succeed())
+			ng.AZRebalance = api.AZRebalanceEnable
+			Expect(sc.SuspendNodeGroupAZRebalance(ng)).To(Succeed())
+			Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "DescribeStackResource", 0)).To(BeTrue())
+		})
+
+		It("should suspend the AZRebalance process of the auto scaling group", func() {
+			p.MockCloudFormation().On("DescribeStackResource", mock.MatchedBy(func(input *cfn.DescribeStackResourceInput) bool {
+				return *input.StackName == "eksctl-test-cluster-nodegroup-12345" && *input.LogicalResourceId == "NodeGroup"
+			})).Return(&cfn.DescribeStackResourceOutput{
+				StackResourceDetail: &cfn.StackResourceDetail{PhysicalResourceId: aws.String("asg-12345")},
+			}, nil)
+			p.MockASG().On("SuspendProcesses", mock.MatchedBy(func(input *autoscaling.ScalingProcessQuery) bool {
+				return *input.AutoScalingGroupName == "asg-12345" && len(input.ScalingProcesses) == 1 && *input.ScalingProcesses[0] == "AZRebalance"
+			})).Return(&autoscaling.SuspendProcessesOutput{}, nil)
+
+			ng := newNodeGroup(cc)
+			ng.Name = "12345"
+			ng.AZRebalance = api.AZRebalanceDisable
+			Expect(sc.SuspendNodeGroupAZRebalance(ng)).To(Succeed())
+			Expect(p.MockASG().AssertNumberOfCalls(GinkgoT(), "SuspendProcesses", 1)).To(BeTrue())
 		})
 	})
 
@@ -193,6 +277,7 @@ var _ = Describe("StackCollection NodeGroup", func() {
 				It("the output should equal the expectation", func() {
 					Expect(out).To(HaveLen(1))
 					Expect(out[0].StackName).To(Equal("eksctl-test-cluster-nodegroup-12345"))
+					Expect(out[0].Tags).To(HaveKeyWithValue(api.NodeGroupNameTag, "12345"))
 				})
 			})
 		})
@@ -0,0 +1,146 @@
+package manager
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+)
+
+// StackSnapshot holds all that's needed to re-create a stack as it was
+type StackSnapshot struct {
+	Name         string            `json:"name"`
+	Template     string            `json:"-"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+	Capabilities []string          `json:"capabilities,omitempty"`
+}
+
+// SnapshotStacks returns the templates, parameters and tags of all stacks of the
+// cluster, in the order they have to be restored in
+func (c *StackCollection) SnapshotStacks() ([]*StackSnapshot, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := []*StackSnapshot{}
+	for _, s := range stacks {
+		if !c.StackStatusIsNotTransitional(s) {
+			logger.Warning("stack %q is %s, its template may not match its resources", *s.StackName, *s.StackStatus)
+		}
+		template, err := c.GetStackTemplate(*s.StackName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting template of stack %q", *s.StackName)
+		}
+		snapshot := &StackSnapshot{
+			Name:         *s.StackName,
+			Template:     template,
+			Parameters:   map[string]string{},
+			Tags:         map[string]string{},
+			Capabilities: aws.StringValueSlice(s.Capabilities),
+		}
+		for _, p := range s.Parameters {
+			snapshot.Parameters[*p.ParameterKey] = aws.StringValue(p.ParameterValue)
+		}
+		for _, t := range s.Tags {
+			snapshot.Tags[*t.Key] = aws.StringValue(t.Value)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	c.sortForRestore(snapshots)
+	return snapshots, nil
+}
+
+// restoreRank tells when a stack has to be restored: the cluster stack first, as the
+// other stacks import its outputs, then the stacks that nodegroups share, such as the
+// node roles, then nodegroups and last the alarms, which watch the nodegroups
+func (c *StackCollection) restoreRank(name string) int {
+	switch {
+	case name == c.makeClusterStackName():
+		return 0
+	case strings.HasPrefix(name, c.makeNodeGroupStackName("")):
+		return 2
+	case name == c.makeAlarmsStackName():
+		return 3
+	default:
+		return 1
+	}
+}
+
+// sortForRestore sorts snapshots in the order of restoreRank, then by name
+func (c *StackCollection) sortForRestore(snapshots []*StackSnapshot) {
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if ri, rj := c.restoreRank(snapshots[i].Name), c.restoreRank(snapshots[j].Name); ri != rj {
+			return ri < rj
+		}
+		return snapshots[i].Name < snapshots[j].Name
+	})
+}
+
+// RestoreStacks creates the stacks of a snapshot one by one, in the order of their
+// dependencies; stacks that already exist are skipped so that an interrupted restore
+// can be resumed
+func (c *StackCollection) RestoreStacks(snapshots []*StackSnapshot) error {
+	existing, err := c.ListStacks(fmtStacksRegexForCluster(c.spec.StackNamePrefix(), c.spec.Metadata.Name))
+	if err != nil {
+		return errors.Wrapf(err, "listing stacks of cluster %q", c.spec.Metadata.Name)
+	}
+	exists := map[string]bool{}
+	for _, s := range existing {
+		exists[*s.StackName] = true
+	}
+
+	// snapshots taken by older versions only have the cluster stack first
+	ordered := append([]*StackSnapshot{}, snapshots...)
+	c.sortForRestore(ordered)
+
+	for _, snapshot := range ordered {
+		if exists[snapshot.Name] {
+			logger.Info("stack %q already exists, not restoring it", snapshot.Name)
+			continue
+		}
+		if err := c.restoreStack(snapshot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreStack creates the stack of a snapshot like any other stack, with the shared tags,
+// the service role and the rollback setting of the collection
+func (c *StackCollection) restoreStack(snapshot *StackSnapshot) error {
+	i := &Stack{StackName: aws.String(snapshot.Name)}
+
+	// the shared tags are set anyway, CloudFormation rejects duplicate keys
+	tags := map[string]string{}
+	for k, v := range snapshot.Tags {
+		tags[k] = v
+	}
+	for _, t := range c.sharedTags {
+		delete(tags, *t.Key)
+	}
+	withIAM, withNamedIAM := false, false
+	for _, capability := range snapshot.Capabilities {
+		switch capability {
+		case cloudformation.CapabilityCapabilityIam:
+			withIAM = true
+		case cloudformation.CapabilityCapabilityNamedIam:
+			withNamedIAM = true
+		}
+	}
+
+	logger.Info("restoring stack %q", snapshot.Name)
+	if err := c.DoCreateStackRequest(i, []byte(snapshot.Template), tags, snapshot.Parameters, withIAM, withNamedIAM); err != nil {
+		return errors.Wrapf(err, "restoring CloudFormation stack %q", snapshot.Name)
+	}
+	if err := c.DoWaitUntilStackIsCreated(i); err != nil {
+		return errors.Wrapf(err, "restoring CloudFormation stack %q", snapshot.Name)
+	}
+	logger.Success("restored stack %q", snapshot.Name)
+	return nil
+}
@@ -0,0 +1,132 @@
+package manager
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection snapshots", func() {
+	var (
+		p  *mockprovider.MockProvider
+		sc *StackCollection
+	)
+
+	mockStacks := func(names ...string) {
+		p.MockCloudFormation().On("ListStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			consume := args[1].(func(p *cfn.ListStacksOutput, last bool) (shouldContinue bool))
+			out := &cfn.ListStacksOutput{}
+			for _, name := range names {
+				out.StackSummaries = append(out.StackSummaries, &cfn.StackSummary{StackName: aws.String(name)})
+			}
+			consume(out, true)
+		}).Return(nil)
+
+		for _, name := range names {
+			name := name
+			p.MockCloudFormation().On("DescribeStacks", mock.MatchedBy(func(input *cfn.DescribeStacksInput) bool {
+				return *input.StackName == name
+			})).Return(&cfn.DescribeStacksOutput{
+				Stacks: []*cfn.Stack{
+					{
+						StackName:    aws.String(name),
+						StackStatus:  aws.String(cfn.StackStatusCreateComplete),
+						Capabilities: aws.StringSlice([]string{cfn.CapabilityCapabilityIam}),
+						Tags:         []*cfn.Tag{newTag(api.ClusterNameTag, "test-cluster")},
+					},
+				},
+			}, nil)
+			p.MockCloudFormation().On("GetTemplate", mock.MatchedBy(func(input *cfn.GetTemplateInput) bool {
+				return *input.StackName == name
+			})).Return(&cfn.GetTemplateOutput{TemplateBody: aws.String("template of " + name)}, nil)
+		}
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = "us-west-2"
+		sc = NewStackCollection(p, cfg)
+	})
+
+	It("puts the stacks in the order of their dependencies", func() {
+		mockStacks("eksctl-test-cluster-nodegroup-ng-2", "eksctl-test-cluster-alarms", "eksctl-test-cluster-cluster",
+			"eksctl-test-cluster-nodegroup-ng-1", "eksctl-test-cluster-noderoles")
+
+		snapshots, err := sc.SnapshotStacks()
+		Expect(err).ToNot(HaveOccurred())
+		names := []string{}
+		for _, snapshot := range snapshots {
+			names = append(names, snapshot.Name)
+		}
+		Expect(names).To(Equal([]string{
+			"eksctl-test-cluster-cluster",
+			"eksctl-test-cluster-noderoles",
+			"eksctl-test-cluster-nodegroup-ng-1",
+			"eksctl-test-cluster-nodegroup-ng-2",
+			"eksctl-test-cluster-alarms",
+		}))
+		Expect(snapshots[0].Template).To(Equal("template of eksctl-test-cluster-cluster"))
+		Expect(snapshots[0].Capabilities).To(Equal([]string{cfn.CapabilityCapabilityIam}))
+		Expect(snapshots[0].Tags).To(HaveKeyWithValue(api.ClusterNameTag, "test-cluster"))
+	})
+
+	It("doesn't restore stacks that exist", func() {
+		mockStacks("eksctl-test-cluster-cluster", "eksctl-test-cluster-nodegroup-ng-1")
+
+		Expect(sc.RestoreStacks([]*StackSnapshot{
+			{Name: "eksctl-test-cluster-cluster", Template: "{}"},
+			{Name: "eksctl-test-cluster-nodegroup-ng-1", Template: "{}"},
+		})).To(Succeed())
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateStack", 0)).To(BeTrue())
+	})
+
+	It("restores the cluster stack first, with the settings of other stacks", func() {
+		mockStacks()
+		sc.spec.Metadata.Tags = map[string]string{"team": "a"}
+		sc.spec.CloudFormation = &api.ClusterCloudFormation{
+			ServiceRoleARN:  "arn:aws:iam::123456789012:role/cfn",
+			DisableRollback: api.Enabled(),
+		}
+		sc = NewStackCollection(p, sc.spec)
+		// the restore is stopped at the first stack
+		p.MockCloudFormation().On("CreateStack", mock.Anything).Return(nil, fmt.Errorf("stack creation unavailable"))
+
+		err := sc.RestoreStacks([]*StackSnapshot{
+			{Name: "eksctl-test-cluster-nodegroup-ng-1", Template: "{}"},
+			{Name: "eksctl-test-cluster-noderoles", Template: "{}"},
+			{
+				Name:         "eksctl-test-cluster-cluster",
+				Template:     "{}",
+				Parameters:   map[string]string{"ClusterName": "test-cluster"},
+				Tags:         map[string]string{api.ClusterNameTag: "test-cluster", "team": "b", "env": "dev"},
+				Capabilities: []string{cfn.CapabilityCapabilityIam},
+			},
+		})
+		Expect(err).To(MatchError(ContainSubstring("stack creation unavailable")))
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "CreateStack", 1)).To(BeTrue())
+
+		input := p.MockCloudFormation().Calls[len(p.MockCloudFormation().Calls)-1].Arguments.Get(0).(*cfn.CreateStackInput)
+		Expect(*input.StackName).To(Equal("eksctl-test-cluster-cluster"))
+		Expect(aws.StringValue(input.RoleARN)).To(Equal("arn:aws:iam::123456789012:role/cfn"))
+		Expect(aws.StringValue(input.OnFailure)).To(Equal(cfn.OnFailureDoNothing))
+		Expect(aws.StringValueSlice(input.Capabilities)).To(Equal([]string{cfn.CapabilityCapabilityIam}))
+		Expect(input.Parameters).To(HaveLen(1))
+		tags := map[string]int{}
+		for _, t := range input.Tags {
+			tags[*t.Key+"="+*t.Value]++
+		}
+		Expect(tags).To(HaveKeyWithValue(api.ClusterNameTag+"=test-cluster", 1))
+		Expect(tags).To(HaveKeyWithValue("team=a", 1))
+		Expect(tags).To(HaveKeyWithValue("env=dev", 1))
+		Expect(tags).ToNot(HaveKey("team=b"))
+	})
+})
@@ -0,0 +1,46 @@
+package manager
+
+import (
+	"github.com/kris-nova/logger"
+
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+)
+
+func (c *StackCollection) makeStorageStackName() string {
+	return c.spec.StackNamePrefix() + c.spec.Metadata.Name + "-storage"
+}
+
+// createStorageTask creates the file systems of the cluster, it has to run
+// after the cluster stack is created, as subnets must be known by then
+func (c *StackCollection) createStorageTask(errs chan error) error {
+	name := c.makeStorageStackName()
+	logger.Info("building storage stack %q", name)
+	stack := builder.NewStorageResourceSet(c.spec, c.makeClusterStackName())
+	if err := stack.AddAllResources(); err != nil {
+		return err
+	}
+
+	return c.CreateOrResumeStack(name, stack, nil, nil, errs)
+}
+
+// DescribeStorageStack returns the storage stack of the cluster, or nil
+// when the cluster has no file systems created by eksctl
+func (c *StackCollection) DescribeStorageStack() (*Stack, error) {
+	stacks, err := c.DescribeStacks()
+	if err != nil {
+		return nil, err
+	}
+
+	name := c.makeStorageStackName()
+	for _, s := range stacks {
+		if *s.StackStatus == cfn.StackStatusDeleteComplete {
+			continue
+		}
+		if *s.StackName == name {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
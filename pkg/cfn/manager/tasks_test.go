@@ -665,6 +665,41 @@ var _ = Describe("StackCollection Tasks", func() {
 					tasks := stackManager.NewTasksToCreateClusterWithNodeGroups(sets.NewString())
 					Expect(tasks.Describe()).To(Equal(`1 task: { create cluster control plane "test-cluster" }`))
 				}
+				{
+					cfg.Storage = &api.ClusterStorage{EFS: &api.EFSFileSystem{}}
+					tasks := stackManager.NewTasksToCreateClusterWithNodeGroups(sets.NewString("bar"))
+					Expect(tasks.Describe()).To(Equal(`2 sequential tasks: { create cluster control plane "test-cluster", 2 parallel sub-tasks: { create nodegroup "bar", create file systems of cluster "test-cluster" } }`))
+				}
+				{
+					cfg.Storage = nil
+					cfg.NodeGroups[0].DependsOn = []string{"foo"}
+
+					tasks := stackManager.NewTasksToCreateNodeGroups(nil)
+					Expect(tasks.Describe()).To(Equal(`2 sequential tasks: { create nodegroup "foo", create nodegroup "bar" }`))
+
+					tasks = stackManager.NewTasksToCreateNodeGroups(sets.NewString("bar"))
+					Expect(tasks.Describe()).To(Equal(`1 task: { create nodegroup "bar" }`))
+
+					cfg.Storage = &api.ClusterStorage{EFS: &api.EFSFileSystem{}}
+					tasks = stackManager.NewTasksToCreateClusterWithNodeGroups(nil)
+					Expect(tasks.Describe()).To(Equal(`2 sequential tasks: { create cluster control plane "test-cluster", 2 parallel sub-tasks: { 2 sequential sub-tasks: { create nodegroup "foo", create nodegroup "bar" }, create file systems of cluster "test-cluster" } }`))
+
+					cfg.Storage = nil
+					cfg.NodeGroups[0].DependsOn = nil
+				}
+				{
+					cfg.IAM = &api.ClusterIAM{NodeRoles: []*api.NodeRole{{Name: "workers"}}}
+					cfg.NodeGroups[0].IAM.NodeRole = "workers"
+
+					tasks := stackManager.NewTasksToCreateNodeGroups(nil)
+					Expect(tasks.Describe()).To(Equal(`2 sequential tasks: { create node roles of cluster "test-cluster", 2 parallel sub-tasks: { create nodegroup "bar", create nodegroup "foo" } }`))
+
+					tasks = stackManager.NewTasksToCreateNodeGroups(sets.NewString("foo"))
+					Expect(tasks.Describe()).To(Equal(`1 task: { create nodegroup "foo" }`))
+
+					tasks = stackManager.NewTasksToCreateClusterWithNodeGroups(sets.NewString("bar"))
+					Expect(tasks.Describe()).To(Equal(`3 sequential tasks: { create cluster control plane "test-cluster", create node roles of cluster "test-cluster", create nodegroup "bar" }`))
+				}
 			})
 		})
 
@@ -0,0 +1,92 @@
+package manager
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// templateOverrideExtensions are the extensions of override files, in order of precedence
+var templateOverrideExtensions = []string{".json", ".yaml", ".yml"}
+
+// nodeGroupTemplateOverride is the name of the override applied to the stacks of all nodegroups
+const nodeGroupTemplateOverride = "nodegroup"
+
+// overrideTemplate applies the overrides of cloudFormation.templateOverrides to the
+// template of the stack, the template is returned as it is when there are none
+func (c *StackCollection) overrideTemplate(stackName string, template []byte) ([]byte, error) {
+	if c.spec.CloudFormation == nil || c.spec.CloudFormation.TemplateOverrides == "" {
+		return template, nil
+	}
+	dir := c.spec.CloudFormation.TemplateOverrides
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return nil, errors.Errorf("template overrides %q must be an existing directory", dir)
+	}
+
+	for _, name := range c.templateOverrideNames(stackName) {
+		path, override, err := readTemplateOverride(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if override == nil {
+			continue
+		}
+		if template, err = applyTemplateOverride(template, override); err != nil {
+			return nil, errors.Wrapf(err, "applying template override %q to stack %q", path, stackName)
+		}
+		logger.Info("applied template override %q to stack %q", path, stackName)
+	}
+	return template, nil
+}
+
+// templateOverrideNames returns the names of the overrides of the stack, in the order
+// they are applied, e.g. "cluster" for the cluster stack, and "nodegroup" followed
+// by "nodegroup-ng-1" for the stack of nodegroup ng-1
+func (c *StackCollection) templateOverrideNames(stackName string) []string {
+	name := strings.TrimPrefix(stackName, c.spec.StackNamePrefix()+c.spec.Metadata.Name+"-")
+	if strings.HasPrefix(name, nodeGroupTemplateOverride+"-") {
+		return []string{nodeGroupTemplateOverride, name}
+	}
+	return []string{name}
+}
+
+// readTemplateOverride reads the override with the given name from the directory and
+// converts it to JSON, the override is nil when there is no such file
+func readTemplateOverride(dir, name string) (string, []byte, error) {
+	for _, ext := range templateOverrideExtensions {
+		path := filepath.Join(dir, name+ext)
+		data, err := ioutil.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return path, nil, errors.Wrapf(err, "reading template override %q", path)
+		}
+		override, err := yaml.YAMLToJSON(data)
+		if err != nil {
+			return path, nil, errors.Wrapf(err, "parsing template override %q", path)
+		}
+		return path, override, nil
+	}
+	return "", nil, nil
+}
+
+// applyTemplateOverride patches the template with a JSON patch (RFC 6902) when the
+// override is a list of operations, and with a JSON merge patch (RFC 7386) otherwise
+func applyTemplateOverride(template, override []byte) ([]byte, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(override), []byte("[")) {
+		patch, err := jsonpatch.DecodePatch(override)
+		if err != nil {
+			return nil, err
+		}
+		return patch.Apply(template)
+	}
+	return jsonpatch.MergePatch(template, override)
+}
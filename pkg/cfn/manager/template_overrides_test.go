@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("StackCollection template overrides", func() {
+	const template = `{"Resources":{"SG":{"Type":"AWS::EC2::SecurityGroup","Properties":{"SecurityGroupIngress":[{"FromPort":443}]}}},"Outputs":{"VPC":{"Value":"vpc-1"}}}`
+
+	var (
+		dir string
+		sc  *StackCollection
+	)
+
+	writeOverride := func(name, content string) {
+		Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "template-overrides")
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.CloudFormation = &api.ClusterCloudFormation{TemplateOverrides: dir}
+		sc = NewStackCollection(mockprovider.NewMockProvider(), cfg)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should leave templates without overrides as they are", func() {
+		out, err := sc.overrideTemplate("eksctl-test-cluster-cluster", []byte(template))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(MatchJSON(template))
+	})
+
+	It("should merge YAML overrides into the cluster template", func() {
+		writeOverride("cluster.yaml", "Outputs:\n  Extra:\n    Value: extra\n  VPC: null\n")
+
+		out, err := sc.overrideTemplate("eksctl-test-cluster-cluster", []byte(template))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"Resources":{"SG":{"Type":"AWS::EC2::SecurityGroup","Properties":{"SecurityGroupIngress":[{"FromPort":443}]}}},"Outputs":{"Extra":{"Value":"extra"}}}`))
+	})
+
+	It("should apply the overrides of all nodegroups before the ones of a nodegroup", func() {
+		writeOverride("nodegroup.json", `[{"op":"add","path":"/Resources/SG/Properties/SecurityGroupIngress/-","value":{"FromPort":22}}]`)
+		writeOverride("nodegroup-ng-1.yml", "Outputs:\n  VPC:\n    Value: vpc-2\n")
+
+		out, err := sc.overrideTemplate("eksctl-test-cluster-nodegroup-ng-1", []byte(template))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"Resources":{"SG":{"Type":"AWS::EC2::SecurityGroup","Properties":{"SecurityGroupIngress":[{"FromPort":443},{"FromPort":22}]}}},"Outputs":{"VPC":{"Value":"vpc-2"}}}`))
+
+		out, err = sc.overrideTemplate("eksctl-test-cluster-nodegroup-ng-2", []byte(template))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"Resources":{"SG":{"Type":"AWS::EC2::SecurityGroup","Properties":{"SecurityGroupIngress":[{"FromPort":443},{"FromPort":22}]}}},"Outputs":{"VPC":{"Value":"vpc-1"}}}`))
+	})
+
+	It("should fail when a patch doesn't apply", func() {
+		writeOverride("cluster.json", `[{"op":"remove","path":"/Resources/Missing"}]`)
+
+		_, err := sc.overrideTemplate("eksctl-test-cluster-cluster", []byte(template))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail when the directory doesn't exist", func() {
+		sc.spec.CloudFormation.TemplateOverrides = filepath.Join(dir, "missing")
+
+		_, err := sc.overrideTemplate("eksctl-test-cluster-cluster", []byte(template))
+		Expect(err).To(MatchError(ContainSubstring("must be an existing directory")))
+	})
+})
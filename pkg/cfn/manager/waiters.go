@@ -43,10 +43,19 @@ func (c *StackCollection) waitWithAcceptors(i *Stack, acceptors []request.Waiter
 		} else {
 			logger.Critical("unexpected status %q while %s", *s.StackStatus, msg)
 			c.troubleshootStackFailureCause(i, desiredStatus)
+			if *s.StackStatus == cfn.StackStatusUpdateRollbackFailed {
+				logger.Warning("rollback of stack %q failed, it can be repaired with 'eksctl utils repair-stack'", *i.StackName)
+			}
+			if desiredStatus == cfn.StackStatusCreateComplete {
+				c.collectFailureBundle(i)
+				if c.rollbackDisabled() {
+					logger.Warning("rollback of stack %q is disabled, its resources were kept for debugging, delete the stack before retrying", *i.StackName)
+				}
+			}
 		}
 	}
 
-	return waiters.Wait(*i.StackName, msg, acceptors, newRequest, c.provider.WaitTimeout(), troubleshoot)
+	return waiters.WaitWithContext(c.waitContext(), *i.StackName, msg, acceptors, newRequest, c.provider.WaitTimeout(), c.provider.PollInterval(), troubleshoot)
 }
 
 func (c *StackCollection) waitWithAcceptorsChangeSet(i *Stack, changesetName string, acceptors []request.WaiterAcceptor) error {
@@ -70,7 +79,7 @@ func (c *StackCollection) waitWithAcceptorsChangeSet(i *Stack, changesetName str
 		}
 	}
 
-	return waiters.Wait(*i.StackName, msg, acceptors, newRequest, c.provider.WaitTimeout(), troubleshoot)
+	return waiters.WaitWithContext(c.waitContext(), *i.StackName, msg, acceptors, newRequest, c.provider.WaitTimeout(), c.provider.PollInterval(), troubleshoot)
 }
 
 func (c *StackCollection) troubleshootStackFailureCause(i *Stack, desiredStatus string) {
@@ -89,23 +98,23 @@ func (c *StackCollection) troubleshootStackFailureCause(i *Stack, desiredStatus
 		case cfn.StackStatusCreateComplete:
 			switch *e.ResourceStatus {
 			case cfn.ResourceStatusCreateFailed:
-				logger.Critical(msg)
+				logger.Critical("%s", msg)
 			case cfn.ResourceStatusDeleteInProgress:
-				logger.Warning(msg)
+				logger.Warning("%s", msg)
 			default:
-				logger.Debug(msg) // only output this when verbose logging is enabled
+				logger.Debug("%s", msg) // only output this when verbose logging is enabled
 			}
 		case cfn.StackStatusDeleteComplete:
 			switch *e.ResourceStatus {
 			case cfn.ResourceStatusDeleteFailed:
-				logger.Critical(msg)
+				logger.Critical("%s", msg)
 			case cfn.ResourceStatusDeleteSkipped:
-				logger.Warning(msg)
+				logger.Warning("%s", msg)
 			default:
-				logger.Info(msg)
+				logger.Info("%s", msg)
 			}
 		default:
-			logger.Info(msg)
+			logger.Info("%s", msg)
 		}
 	}
 }
@@ -223,6 +232,26 @@ func (c *StackCollection) doWaitUntilStackIsUpdated(i *Stack) error {
 	)
 }
 
+func (c *StackCollection) doWaitUntilStackRollbackIsComplete(i *Stack) error {
+	return c.waitWithAcceptors(i,
+		waiters.MakeAcceptors(
+			stackStatus,
+			cfn.StackStatusUpdateRollbackComplete,
+			[]string{
+				cfn.StackStatusUpdateRollbackFailed,
+				cfn.StackStatusDeleteInProgress,
+				cfn.StackStatusDeleteFailed,
+				cfn.StackStatusDeleteComplete,
+			},
+			request.WaiterAcceptor{
+				State:    request.FailureWaiterState,
+				Matcher:  request.ErrorWaiterMatch,
+				Expected: "ValidationError",
+			},
+		),
+	)
+}
+
 func (c *StackCollection) doWaitUntilChangeSetIsCreated(i *Stack, changesetName string) error {
 	return c.waitWithAcceptorsChangeSet(i, changesetName,
 		waiters.MakeAcceptors(
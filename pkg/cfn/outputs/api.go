@@ -19,6 +19,9 @@ const (
 
 	ClusterSubnetsPublicLegacy = "Subnets"
 
+	ClusterLocalZoneSubnetsPrivate = string("LocalZoneSubnets" + api.SubnetTopologyPrivate)
+	ClusterLocalZoneSubnetsPublic  = string("LocalZoneSubnets" + api.SubnetTopologyPublic)
+
 	ClusterCertificateAuthorityData = "CertificateAuthorityData"
 	ClusterEndpoint                 = "Endpoint"
 	ClusterARN                      = "ARN"
@@ -26,10 +29,27 @@ const (
 	ClusterSharedNodeSecurityGroup  = "SharedNodeSecurityGroup"
 	ClusterServiceRoleARN           = "ServiceRoleARN"
 	ClusterFeatureNATMode 			= "FeatureNATMode"
+	ClusterServiceIPv4CIDR          = "ServiceIPv4CIDR"
+	ClusterServiceIPv6CIDR          = "ServiceIPv6CIDR"
+	ClusterOIDCIssuerURL            = "OIDCIssuerURL"
 
 	// outputs from nodegroup stack
 	NodeGroupInstanceRoleARN    = "InstanceRoleARN"
 	NodeGroupInstanceProfileARN = "InstanceProfileARN"
+	NodeGroupNodeRole           = "NodeRole"
+
+	// outputs from storage stack
+	StorageSecurityGroup   = "MountTargetSecurityGroup"
+	StorageEFSFileSystemID = "EFSFileSystemID"
+	StorageFSxFileSystemID = "FSxFileSystemID"
+	StorageFSxDNSName      = "FSxDNSName"
+	StorageFSxMountName    = "FSxMountName"
+
+	// outputs from Node Termination Handler stack
+	NodeTerminationHandlerQueueURL = "QueueURL"
+
+	// outputs from Container Insights stack
+	ContainerInsightsRoleARN = "RoleARN"
 
 	// outputs to indicate configuration attributes that may have critical effect
 	// on critical effect on forward-compatibility with respect to overal functionality
@@ -68,6 +68,13 @@ func (c *CloudConfig) AddShellCommand(cmd string) {
 	c.Commands = append(c.Commands, []string{Shell, "-c", cmd})
 }
 
+// AddShellSnippet adds a line that cloud-init writes as-is into the script it runs
+// all commands from, unlike AddShellCommand it can set environment variables for
+// the commands that follow
+func (c *CloudConfig) AddShellSnippet(snippet string) {
+	c.Commands = append(c.Commands, snippet)
+}
+
 // AddFile adds a file, which will be placed on the node
 func (c *CloudConfig) AddFile(f File) {
 	if f.Owner == "" {
@@ -0,0 +1,111 @@
+package connector
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/eks/eksiface"
+)
+
+// API is the part of the EKS API that registers external clusters, the EKS
+// client of the AWS SDK in use doesn't provide these operations yet
+type API interface {
+	RegisterCluster(*RegisterClusterInput) (*RegisterClusterOutput, error)
+	DeregisterCluster(*DeregisterClusterInput) (*DeregisterClusterOutput, error)
+}
+
+// RegisterClusterInput is the input of the RegisterCluster operation
+type RegisterClusterInput struct {
+	_ struct{} `type:"structure"`
+
+	Name            *string                 `locationName:"name" type:"string" required:"true"`
+	ConnectorConfig *ConnectorConfigRequest `locationName:"connectorConfig" type:"structure" required:"true"`
+	Tags            map[string]*string      `locationName:"tags" type:"map"`
+}
+
+// ConnectorConfigRequest is the configuration of the connector of a cluster to register
+type ConnectorConfigRequest struct {
+	_ struct{} `type:"structure"`
+
+	Provider *string `locationName:"provider" type:"string" required:"true"`
+	RoleArn  *string `locationName:"roleArn" type:"string" required:"true"`
+}
+
+// RegisterClusterOutput is the output of the RegisterCluster operation
+type RegisterClusterOutput struct {
+	_ struct{} `type:"structure"`
+
+	Cluster *Cluster `locationName:"cluster" type:"structure"`
+}
+
+// DeregisterClusterInput is the input of the DeregisterCluster operation
+type DeregisterClusterInput struct {
+	_ struct{} `type:"structure"`
+
+	Name *string `location:"uri" locationName:"name" type:"string" required:"true"`
+}
+
+// DeregisterClusterOutput is the output of the DeregisterCluster operation
+type DeregisterClusterOutput struct {
+	_ struct{} `type:"structure"`
+
+	Cluster *Cluster `locationName:"cluster" type:"structure"`
+}
+
+// Cluster holds the fields of a registered cluster used by eksctl
+type Cluster struct {
+	_ struct{} `type:"structure"`
+
+	Name            *string                  `locationName:"name" type:"string"`
+	Arn             *string                  `locationName:"arn" type:"string"`
+	Status          *string                  `locationName:"status" type:"string"`
+	ConnectorConfig *ConnectorConfigResponse `locationName:"connectorConfig" type:"structure"`
+}
+
+// ConnectorConfigResponse holds the activation of the connector of a registered cluster
+type ConnectorConfigResponse struct {
+	_ struct{} `type:"structure"`
+
+	ActivationID     *string    `locationName:"activationId" type:"string"`
+	ActivationCode   *string    `locationName:"activationCode" type:"string"`
+	ActivationExpiry *time.Time `locationName:"activationExpiry" type:"timestamp"`
+	Provider         *string    `locationName:"provider" type:"string"`
+	RoleArn          *string    `locationName:"roleArn" type:"string"`
+}
+
+type eksConnectorAPI struct {
+	eks *awseks.EKS
+}
+
+// NewAPI makes the registration calls with the protocol handlers of the EKS client
+func NewAPI(eksAPI eksiface.EKSAPI) (API, error) {
+	client, ok := eksAPI.(*awseks.EKS)
+	if !ok {
+		return nil, fmt.Errorf("registering clusters requires the EKS client of the AWS SDK, got %T", eksAPI)
+	}
+	return &eksConnectorAPI{eks: client}, nil
+}
+
+// RegisterCluster connects an external cluster to EKS
+func (c *eksConnectorAPI) RegisterCluster(input *RegisterClusterInput) (*RegisterClusterOutput, error) {
+	op := &request.Operation{
+		Name:       "RegisterCluster",
+		HTTPMethod: "POST",
+		HTTPPath:   "/cluster-registrations",
+	}
+	output := &RegisterClusterOutput{}
+	return output, c.eks.NewRequest(op, input, output).Send()
+}
+
+// DeregisterCluster disconnects an external cluster from EKS
+func (c *eksConnectorAPI) DeregisterCluster(input *DeregisterClusterInput) (*DeregisterClusterOutput, error) {
+	op := &request.Operation{
+		Name:       "DeregisterCluster",
+		HTTPMethod: "DELETE",
+		HTTPPath:   "/cluster-registrations/{name}",
+	}
+	output := &DeregisterClusterOutput{}
+	return output, c.eks.NewRequest(op, input, output).Send()
+}
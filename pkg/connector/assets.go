@@ -0,0 +1,235 @@
+// Code generated by go-bindata.
+// sources:
+// assets/eks-connector.yaml
+// DO NOT EDIT!
+
+package connector
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func bindataRead(data []byte, name string) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewBuffer(data))
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, gz)
+	clErr := gz.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("Read %q: %v", name, err)
+	}
+	if clErr != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+type asset struct {
+	bytes []byte
+	info  os.FileInfo
+}
+
+type bindataFileInfo struct {
+	name    string
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (fi bindataFileInfo) Name() string {
+	return fi.name
+}
+func (fi bindataFileInfo) Size() int64 {
+	return fi.size
+}
+func (fi bindataFileInfo) Mode() os.FileMode {
+	return fi.mode
+}
+func (fi bindataFileInfo) ModTime() time.Time {
+	return fi.modTime
+}
+func (fi bindataFileInfo) IsDir() bool {
+	return false
+}
+func (fi bindataFileInfo) Sys() interface{} {
+	return nil
+}
+
+var _eksConnectorYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd5\x56\x4b\x8f\xdb\x36\x10\xbe\xfb\x57\x08\x42\x0f\x2d\xb0\x92\x77\x37\x4d\xd1\x0a\xc8\x41\xb1\xdd\xc2\xc8\xfa\x01\xdb\xd8\x1c\x8a\xc2\xa0\xa5\xb1\x97\x31\x25\xaa\x24\xa5\xc4\xd9\xee\x7f\xef\x90\x92\xd7\x92\x2c\x3f\x5b\x14\x0d\x0f\x86\x3c\x9c\x6f\xe6\xe3\xbc\x48\xc7\x71\x5a\x24\xa1\x8f\x20\x24\xe5\xb1\x67\x65\x77\xad\x35\x8d\x43\xcf\x1a\x92\x08\x64\x42\x02\x68\x45\xa0\x48\x48\x14\xf1\x5a\x96\x15\xa3\xd4\xb3\x60\x2d\x9d\x80\xc7\x31\x04\x8a\x8b\x96\x73\xc8\xc4\x14\x44\x46\x03\xf0\x83\x80\xa7\xb1\x3a\x69\x27\x97\x1a\x9f\x17\xb8\x08\x04\x9c\x34\xed\x90\x40\xd1\x8c\x28\xc4\x6a\xe1\x92\xae\x8e\x39\x53\x9b\x04\x65\xa3\x84\xfc\x99\x42\x6b\x6b\x35\xe0\x21\x0a\xed\xe7\xe7\x9d\xa9\xb9\x96\xbd\xbc\xd8\x7b\xec\xc4\x82\x04\x2e\x49\xd5\x13\x17\xf4\xab\x51\x75\xd7\x3f\x4b\x97\xf2\xf6\x2b\xef\x09\x67\x27\x03\xeb\x48\x73\x38\x24\x1f\x80\x94\xc7\x18\x8b\x94\x81\xd4\x56\x1c\x0b\x79\xfc\x26\x78\x9a\x48\xcf\xfa\xdd\xb6\xff\x40\x99\x65\x09\x90\x3c\x15\x68\x44\xcb\x72\xa3\xb2\xd8\xca\x40\x2c\x8c\x78\x05\xca\xbe\xb1\xec\x34\x41\x42\x50\xc3\x99\x52\xd0\x4a\x35\x7a\x0a\x55\x9d\x5b\x0d\x6b\xda\xb8\x33\x56\xae\x67\x84\xc2\x9c\xca\x95\xe1\x7d\x8f\x5f\x34\x5e\xfd\x8b\x51\x46\xa3\x13\x58\x6a\x3b\xdb\x33\x1d\x21\x83\x5a\xa5\x54\x9f\xe3\x5a\xa6\x8b\x4f\x28\x2f\x12\xd9\xd8\x42\x3a\x40\xcd\xad\x73\x41\xf3\x9c\x11\xbf\x0e\x4b\xa5\x02\x71\x66\x95\x1a\x8a\x17\x15\x61\x8a\xa0\xbd\x84\xd3\x28\x41\x29\x8f\xaf\xce\x7a\x89\xf5\xf9\xc9\x2f\xc8\x5f\x97\xdb\x72\x9c\x8e\x3b\xf8\xaf\x92\xbb\x0b\x86\x99\x73\x03\x92\x9c\x1e\x8e\x2b\x30\xde\x0f\xfa\xd8\x82\x49\x44\xbe\xe2\x04\x95\x32\xca\x31\xee\x27\xa9\x5d\xfe\x65\x18\x3e\x9b\x5f\xcb\xb2\x7d\xbd\x65\x7b\xaf\x02\x14\x4d\x60\x85\xe4\x6c\x33\x3f\x85\xf9\xd6\x63\x33\xdf\x7c\xb9\xd9\x02\x07\x2b\x79\x06\xec\x66\xb7\x3f\x55\x3c\x99\xd1\x08\x78\xaa\x06\x94\x31\xaa\xe1\xf7\xb7\xb8\xca\x3a\xd8\x59\x08\xfc\xc8\xc5\x1a\x43\xf4\x40\x23\xaa\xb9\xdd\xa1\xd2\x9e\xff\x7e\x88\xc4\xa9\xda\x54\x49\x60\x1c\x65\x1a\x25\x3a\xfb\x23\x11\x82\xb0\x75\xa9\x8e\xe2\xb1\x80\xa8\x28\x60\xb4\xd1\xca\x7f\xeb\xc9\x20\x49\x22\x77\xe5\x39\xd5\x63\x71\x99\xb2\x29\xfc\xa3\xbb\xd0\xb2\x18\x59\x00\x33\x95\xa4\xab\x35\xa9\x2b\xc8\x04\x02\xbd\x29\x20\x61\x34\x20\xd8\x5b\xf7\xf8\xaf\x28\xc4\x61\xa3\x33\x09\xcc\x7c\xe6\x36\x23\xa2\x82\xa7\x87\x92\x93\x46\x37\x96\xa5\x20\x4a\x18\x9e\xa9\x40\x95\x8e\xa4\x17\xab\x18\x38\x60\x02\x5d\x17\x6c\xcd\x77\xa5\x21\x86\x07\x7a\x41\xeb\x05\xa9\xc0\x4c\x61\x6e\x14\x7c\x51\x3b\x1f\x4b\x59\xb4\xee\x4f\x6f\xdf\xbe\xf9\xb1\x10\xd3\x98\x2a\xad\x49\x68\x8c\x89\xd9\x29\x3b\x45\xe4\x77\x9d\xa0\x35\x5f\xb7\x11\x18\x61\x99\x7b\x56\x92\x2e\x30\x8e\x2e\xce\x6a\x97\x7c\x96\xed\x0a\xa1\xea\x3f\x2f\xbb\x75\x6f\xdd\xfb\xba\x89\x71\xca\xd8\x98\xa3\x8d\x8d\x67\xf5\x97\x43\xae\xb0\x78\x24\xc4\x65\x57\x44\xac\x4a\xcc\x72\x76\x35\x36\x5a\xe4\x94\x5e\x32\x2e\x0d\xdf\x7d\xf7\x7d\xef\xc3\x74\xee\x77\x66\xfd\x47\x7f\xd6\x1f\x0d\xe7\xfd\xee\x0f\xc7\x20\xfa\xc5\xb2\x0f\xea\x8c\xba\xbd\x06\x98\x69\xf1\xbc\xef\xde\xed\x1a\xb0\xa4\x07\x71\x56\xe7\x9c\x47\x74\x8f\x54\x45\x0b\x47\x3e\x61\xe9\xde\x6b\x8a\x86\xbb\xa1\x70\xd4\x9c\xa6\xdb\x64\xf0\x57\xc1\x23\xaf\xb6\x61\x6a\x05\xef\xd8\x0f\xb0\x29\xc6\x7b\x7d\x9d\xfb\x5c\xac\xaf\x35\x6c\x3c\xf3\x2a\x2c\xed\x65\x9c\xa5\x11\x0c\x74\xf1\xca\xe6\xc8\x68\x3f\x26\xb0\x4e\x46\x52\xa6\x6a\x76\x23\x8d\x1c\x13\xf5\xe4\x59\xed\x8c\x88\x36\xa3\x8b\x76\x3e\x74\xdb\x38\x74\xdb\x8f\x7b\x10\xa7\xf9\xce\x79\x22\x02\xc2\x13\xb6\x11\xd2\xae\x28\x06\x67\xb5\xc8\xf6\xb2\x38\xde\x23\xf5\x9b\x62\x4f\xe0\xbd\x71\xef\xdc\x5f\xb0\x5b\xae\xe8\x95\x83\x65\xe7\x7f\x9c\xce\x7b\x9d\xfb\xf9\xa0\x37\xf3\xbb\xfe\xcc\x9f\x77\xfb\x53\xff\xfd\x43\xef\x50\xf9\x29\x91\x82\x7d\x65\xfa\x1a\xeb\xa2\x1c\x63\x50\x41\x39\x77\x8d\x77\x67\x0d\x8e\x6f\x84\x1c\x7c\x5a\xf9\x1b\xa8\xa7\xa3\x83\xda\xcc\x3c\xc6\xf8\xe7\xb1\xa0\x19\x65\xb0\x82\x9e\x0c\x08\x33\x3d\xe7\x59\x4b\xc2\x24\x54\x74\x03\x92\x90\x05\x65\x54\x51\x90\xf5\x2e\x26\x61\xa8\xef\xe4\xae\xdf\x99\x8f\x1e\x7b\x93\x49\xbf\xdb\x7b\xbd\x99\xb7\x2b\x14\x3c\xd1\x4a\xfe\xc3\x43\x69\x6f\xbf\xbc\x13\xc1\xbf\x6c\xfe\x3f\x57\x80\xbe\x10\x41\x5c\x5c\xa2\xdf\x4c\xea\x9a\xd2\x92\x1f\xb1\x61\x08\x1d\x69\xbe\x60\xfb\xd8\xad\x3a\x38\xf6\xda\x3d\xb7\x8f\xf0\x8d\xa3\x36\x5d\x2a\xf0\x51\xf8\xd2\xba\x30\xd8\x15\xec\xdf\xaf\xf3\x11\xb9\x6a\x11\x00\x00")
+
+func eksConnectorYamlBytes() ([]byte, error) {
+	return bindataRead(
+		_eksConnectorYaml,
+		"eks-connector.yaml",
+	)
+}
+
+func eksConnectorYaml() (*asset, error) {
+	bytes, err := eksConnectorYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "eks-connector.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+// Asset loads and returns the asset for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func Asset(name string) ([]byte, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("Asset %s can't read by error: %v", name, err)
+		}
+		return a.bytes, nil
+	}
+	return nil, fmt.Errorf("Asset %s not found", name)
+}
+
+// MustAsset is like Asset but panics when Asset would return an error.
+// It simplifies safe initialization of global variables.
+func MustAsset(name string) []byte {
+	a, err := Asset(name)
+	if err != nil {
+		panic("asset: Asset(" + name + "): " + err.Error())
+	}
+
+	return a
+}
+
+// AssetInfo loads and returns the asset info for the given name.
+// It returns an error if the asset could not be found or
+// could not be loaded.
+func AssetInfo(name string) (os.FileInfo, error) {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	if f, ok := _bindata[cannonicalName]; ok {
+		a, err := f()
+		if err != nil {
+			return nil, fmt.Errorf("AssetInfo %s can't read by error: %v", name, err)
+		}
+		return a.info, nil
+	}
+	return nil, fmt.Errorf("AssetInfo %s not found", name)
+}
+
+// AssetNames returns the names of the assets.
+func AssetNames() []string {
+	names := make([]string, 0, len(_bindata))
+	for name := range _bindata {
+		names = append(names, name)
+	}
+	return names
+}
+
+// _bindata is a table, holding each asset generator, mapped to its name.
+var _bindata = map[string]func() (*asset, error){
+	"eks-connector.yaml": eksConnectorYaml,
+}
+
+// AssetDir returns the file names below a certain
+// directory embedded in the file by go-bindata.
+// For example if you run go-bindata on data/... and data contains the
+// following hierarchy:
+//     data/
+//       foo.txt
+//       img/
+//         a.png
+//         b.png
+// then AssetDir("data") would return []string{"foo.txt", "img"}
+// AssetDir("data/img") would return []string{"a.png", "b.png"}
+// AssetDir("foo.txt") and AssetDir("notexist") would return an error
+// AssetDir("") will return []string{"data"}.
+func AssetDir(name string) ([]string, error) {
+	node := _bintree
+	if len(name) != 0 {
+		cannonicalName := strings.Replace(name, "\\", "/", -1)
+		pathList := strings.Split(cannonicalName, "/")
+		for _, p := range pathList {
+			node = node.Children[p]
+			if node == nil {
+				return nil, fmt.Errorf("Asset %s not found", name)
+			}
+		}
+	}
+	if node.Func != nil {
+		return nil, fmt.Errorf("Asset %s not found", name)
+	}
+	rv := make([]string, 0, len(node.Children))
+	for childName := range node.Children {
+		rv = append(rv, childName)
+	}
+	return rv, nil
+}
+
+type bintree struct {
+	Func     func() (*asset, error)
+	Children map[string]*bintree
+}
+var _bintree = &bintree{nil, map[string]*bintree{
+	"eks-connector.yaml": &bintree{eksConnectorYaml, map[string]*bintree{}},
+}}
+
+// RestoreAsset restores an asset under the given directory
+func RestoreAsset(dir, name string) error {
+	data, err := Asset(name)
+	if err != nil {
+		return err
+	}
+	info, err := AssetInfo(name)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(_filePath(dir, filepath.Dir(name)), os.FileMode(0755))
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(_filePath(dir, name), data, info.Mode())
+	if err != nil {
+		return err
+	}
+	err = os.Chtimes(_filePath(dir, name), info.ModTime(), info.ModTime())
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// RestoreAssets restores an asset under the given directory recursively
+func RestoreAssets(dir, name string) error {
+	children, err := AssetDir(name)
+	// File
+	if err != nil {
+		return RestoreAsset(dir, name)
+	}
+	// Dir
+	for _, child := range children {
+		err = RestoreAssets(dir, filepath.Join(name, child))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func _filePath(dir, name string) string {
+	cannonicalName := strings.Replace(name, "\\", "/", -1)
+	return filepath.Join(append([]string{dir}, strings.Split(cannonicalName, "/")...)...)
+}
+
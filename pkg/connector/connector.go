@@ -0,0 +1,282 @@
+package connector
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/kubernetes"
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+const (
+	// Namespace is where the connector runs in the external cluster
+	Namespace = "eks-connector"
+	// ServiceClusterRole is the cluster role the connector uses to impersonate IAM identities
+	ServiceClusterRole = "eks-connector-service"
+
+	manifestName = "eks-connector"
+
+	activationIDPlaceholder   = "{{activation_id}}"
+	activationCodePlaceholder = "{{activation_code}}"
+	regionPlaceholder         = "{{region}}"
+
+	// connectorAgentPolicy allows the SSM agent of the connector to open channels for the cluster
+	connectorAgentPolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": ["ssmmessages:CreateControlChannel"],
+      "Resource": "arn:aws:eks:*:*:cluster/%s"
+    },
+    {
+      "Effect": "Allow",
+      "Action": ["ssmmessages:CreateDataChannel", "ssmmessages:OpenDataChannel", "ssmmessages:OpenControlChannel"],
+      "Resource": "*"
+    }
+  ]
+}`
+	connectorAssumeRolePolicy = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": {"Service": "ssm.amazonaws.com"},
+      "Action": "sts:AssumeRole"
+    }
+  ]
+}`
+	connectorAgentPolicyName = "eks-connector-agent"
+
+	registerAttempts      = 6
+	registerRetryInterval = 10 * time.Second
+)
+
+// SupportedProviders are the providers of external clusters known to EKS
+func SupportedProviders() []string {
+	return []string{"EKS_ANYWHERE", "ANTHOS", "GKE", "AKS", "OPENSHIFT", "TANZU", "RANCHER", "EC2", "OTHER"}
+}
+
+// ExternalCluster is a cluster running outside of EKS
+type ExternalCluster struct {
+	Name     string
+	Provider string
+	// ConnectorRoleARN is the role of the connector, eksctl creates one when it's not set
+	ConnectorRoleARN string
+}
+
+// Manager registers external clusters with EKS
+type Manager struct {
+	provider     api.ClusterProvider
+	connectorAPI API
+}
+
+// NewManager creates a new Manager
+func NewManager(provider api.ClusterProvider, connectorAPI API) *Manager {
+	return &Manager{
+		provider:     provider,
+		connectorAPI: connectorAPI,
+	}
+}
+
+// ConnectorRoleName returns the name of the connector role eksctl creates for the cluster
+func ConnectorRoleName(clusterName string) string {
+	return fmt.Sprintf("eksctl-%s-connector-role", clusterName)
+}
+
+// RegisterCluster registers the cluster, creating the connector role if needed,
+// and returns the activation for the connector
+func (m *Manager) RegisterCluster(cluster ExternalCluster) (*Cluster, error) {
+	if !utils.IsOneOf(cluster.Provider, SupportedProviders()) {
+		return nil, fmt.Errorf("provider %q is not supported, supported values: %s", cluster.Provider, strings.Join(SupportedProviders(), ", "))
+	}
+
+	roleARN := cluster.ConnectorRoleARN
+	if roleARN == "" {
+		var err error
+		if roleARN, err = m.createConnectorRole(cluster.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	input := &RegisterClusterInput{
+		Name: aws.String(cluster.Name),
+		ConnectorConfig: &ConnectorConfigRequest{
+			Provider: aws.String(cluster.Provider),
+			RoleArn:  aws.String(roleARN),
+		},
+	}
+
+	// a new role can take a few seconds until it can be assumed
+	var (
+		output *RegisterClusterOutput
+		err    error
+	)
+	for attempt := 1; attempt <= registerAttempts; attempt++ {
+		output, err = m.connectorAPI.RegisterCluster(input)
+		if !isInvalidRequest(err) || cluster.ConnectorRoleARN != "" || attempt == registerAttempts {
+			break
+		}
+		logger.Debug("retrying registration of cluster %q: %s", cluster.Name, err.Error())
+		time.Sleep(registerRetryInterval)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "registering cluster %q", cluster.Name)
+	}
+	if output.Cluster == nil || output.Cluster.ConnectorConfig == nil {
+		return nil, fmt.Errorf("unexpected response when registering cluster %q", cluster.Name)
+	}
+	return output.Cluster, nil
+}
+
+func (m *Manager) createConnectorRole(clusterName string) (string, error) {
+	roleName := ConnectorRoleName(clusterName)
+	logger.Info("creating IAM role %q for the connector of cluster %q", roleName, clusterName)
+
+	output, err := m.provider.IAM().CreateRole(&iam.CreateRoleInput{
+		RoleName:                 aws.String(roleName),
+		AssumeRolePolicyDocument: aws.String(connectorAssumeRolePolicy),
+		Description:              aws.String(fmt.Sprintf("EKS Connector agent of cluster %s, created by eksctl", clusterName)),
+		Tags: []*iam.Tag{
+			{Key: aws.String(api.ResourceOwnerTag), Value: aws.String(clusterName)},
+		},
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "creating IAM role %q", roleName)
+	}
+
+	_, err = m.provider.IAM().PutRolePolicy(&iam.PutRolePolicyInput{
+		RoleName:       aws.String(roleName),
+		PolicyName:     aws.String(connectorAgentPolicyName),
+		PolicyDocument: aws.String(fmt.Sprintf(connectorAgentPolicy, clusterName)),
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, "adding policy to IAM role %q", roleName)
+	}
+	return *output.Role.Arn, nil
+}
+
+// DeregisterCluster deregisters the cluster, and deletes the connector role if eksctl created it
+func (m *Manager) DeregisterCluster(name string) error {
+	output, err := m.connectorAPI.DeregisterCluster(&DeregisterClusterInput{
+		Name: aws.String(name),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "deregistering cluster %q", name)
+	}
+
+	if output.Cluster == nil || output.Cluster.ConnectorConfig == nil {
+		return nil
+	}
+	roleARN := aws.StringValue(output.Cluster.ConnectorConfig.RoleArn)
+	roleName := ConnectorRoleName(name)
+	if !strings.HasSuffix(roleARN, ":role/"+roleName) {
+		logger.Info("not deleting connector role %q, as it wasn't created by eksctl", roleARN)
+		return nil
+	}
+
+	if _, err := m.provider.IAM().DeleteRolePolicy(&iam.DeleteRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(connectorAgentPolicyName),
+	}); err != nil && !isNoSuchEntity(err) {
+		return errors.Wrapf(err, "deleting policy of IAM role %q", roleName)
+	}
+	if _, err := m.provider.IAM().DeleteRole(&iam.DeleteRoleInput{
+		RoleName: aws.String(roleName),
+	}); err != nil && !isNoSuchEntity(err) {
+		return errors.Wrapf(err, "deleting IAM role %q", roleName)
+	}
+	logger.Info("deleted IAM role %q", roleName)
+	return nil
+}
+
+// NewManifests returns the resources that run the connector in the external cluster
+func NewManifests(cluster *Cluster, region string) (*metav1.List, error) {
+	data, err := Asset(manifestName + ".yaml")
+	if err != nil {
+		return nil, errors.Wrapf(err, "decoding embedded manifest for %q", manifestName)
+	}
+
+	config := cluster.ConnectorConfig
+	manifest := strings.Replace(string(data), activationIDPlaceholder, aws.StringValue(config.ActivationID), -1)
+	manifest = strings.Replace(manifest, activationCodePlaceholder, base64.StdEncoding.EncodeToString([]byte(aws.StringValue(config.ActivationCode))), -1)
+	manifest = strings.Replace(manifest, regionPlaceholder, region, -1)
+
+	list, err := kubernetes.NewList([]byte(manifest))
+	if err != nil {
+		return nil, errors.Wrapf(err, "loading individual resources from manifest for %q", manifestName)
+	}
+	return list, nil
+}
+
+// Deploy creates or replaces the resources of the connector in the external cluster
+func Deploy(rawClient kubernetes.RawClientInterface, list *metav1.List) error {
+	for _, rawObj := range list.Items {
+		resource, err := rawClient.NewRawResource(rawObj)
+		if err != nil {
+			return err
+		}
+		status, err := resource.CreateOrReplace(false)
+		if err != nil {
+			return err
+		}
+		logger.Info("%s", status)
+	}
+	return nil
+}
+
+// Delete removes the resources of the connector from the external cluster
+func Delete(clientSet kubeclient.Interface) error {
+	if err := clientSet.RbacV1().ClusterRoleBindings().Delete(ServiceClusterRole, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting cluster role binding %q", ServiceClusterRole)
+	}
+	if err := clientSet.RbacV1().ClusterRoles().Delete(ServiceClusterRole, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting cluster role %q", ServiceClusterRole)
+	}
+	if err := clientSet.CoreV1().Namespaces().Delete(Namespace, &metav1.DeleteOptions{}); err != nil && !apierrs.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting namespace %q", Namespace)
+	}
+	logger.Info("deleted the connector from namespace %q", Namespace)
+	return nil
+}
+
+// NewExternalClusterClient creates a client for the external cluster from the
+// given kubeconfig, or the default one when the path is empty
+func NewExternalClusterClient(kubeconfigPath, context string) (*kubernetes.RawClient, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeconfigPath
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: context}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "loading kubeconfig of the external cluster")
+	}
+	clientSet, err := kubeclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewRawClient(clientSet, config)
+}
+
+func isInvalidRequest(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == "InvalidRequestException"
+}
+
+func isNoSuchEntity(err error) bool {
+	awsErr, ok := err.(awserr.Error)
+	return ok && awsErr.Code() == iam.ErrCodeNoSuchEntityException
+}
@@ -0,0 +1,129 @@
+package connector_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/connector"
+	"github.com/weaveworks/eksctl/pkg/testutils"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+type fakeConnectorAPI struct {
+	registered   *RegisterClusterInput
+	deregistered *DeregisterClusterInput
+	cluster      *Cluster
+}
+
+func (f *fakeConnectorAPI) RegisterCluster(input *RegisterClusterInput) (*RegisterClusterOutput, error) {
+	f.registered = input
+	f.cluster.ConnectorConfig.RoleArn = input.ConnectorConfig.RoleArn
+	return &RegisterClusterOutput{Cluster: f.cluster}, nil
+}
+
+func (f *fakeConnectorAPI) DeregisterCluster(input *DeregisterClusterInput) (*DeregisterClusterOutput, error) {
+	f.deregistered = input
+	return &DeregisterClusterOutput{Cluster: f.cluster}, nil
+}
+
+var _ = Describe("EKS Connector", func() {
+	var (
+		p            *mockprovider.MockProvider
+		connectorAPI *fakeConnectorAPI
+		manager      *Manager
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		connectorAPI = &fakeConnectorAPI{
+			cluster: &Cluster{
+				Name: aws.String("on-prem"),
+				ConnectorConfig: &ConnectorConfigResponse{
+					ActivationID:   aws.String("activation-id"),
+					ActivationCode: aws.String("activation-code"),
+				},
+			},
+		}
+		manager = NewManager(p, connectorAPI)
+	})
+
+	It("should create the connector role when none is given", func() {
+		p.MockIAM().On("CreateRole", mock.MatchedBy(func(input *iam.CreateRoleInput) bool {
+			return *input.RoleName == "eksctl-on-prem-connector-role" &&
+				len(input.Tags) == 1 && *input.Tags[0].Key == api.ResourceOwnerTag && *input.Tags[0].Value == "on-prem"
+		})).Return(&iam.CreateRoleOutput{
+			Role: &iam.Role{Arn: aws.String("arn:aws:iam::123456789012:role/eksctl-on-prem-connector-role")},
+		}, nil)
+		p.MockIAM().On("PutRolePolicy", mock.Anything).Return(&iam.PutRolePolicyOutput{}, nil)
+
+		cluster, err := manager.RegisterCluster(ExternalCluster{Name: "on-prem", Provider: "OTHER"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*cluster.ConnectorConfig.ActivationID).To(Equal("activation-id"))
+		Expect(*connectorAPI.registered.ConnectorConfig.RoleArn).To(Equal("arn:aws:iam::123456789012:role/eksctl-on-prem-connector-role"))
+		Expect(*connectorAPI.registered.ConnectorConfig.Provider).To(Equal("OTHER"))
+	})
+
+	It("should use the given connector role", func() {
+		_, err := manager.RegisterCluster(ExternalCluster{Name: "on-prem", Provider: "GKE", ConnectorRoleARN: "arn:aws:iam::123456789012:role/connector"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*connectorAPI.registered.ConnectorConfig.RoleArn).To(Equal("arn:aws:iam::123456789012:role/connector"))
+		Expect(p.MockIAM().AssertNotCalled(GinkgoT(), "CreateRole", mock.Anything)).To(BeTrue())
+	})
+
+	It("should reject unknown providers", func() {
+		_, err := manager.RegisterCluster(ExternalCluster{Name: "on-prem", Provider: "k3s"})
+		Expect(err).To(HaveOccurred())
+		Expect(connectorAPI.registered).To(BeNil())
+	})
+
+	It("should only delete the connector role created by eksctl", func() {
+		connectorAPI.cluster.ConnectorConfig.RoleArn = aws.String("arn:aws:iam::123456789012:role/connector")
+		Expect(manager.DeregisterCluster("on-prem")).To(Succeed())
+		Expect(*connectorAPI.deregistered.Name).To(Equal("on-prem"))
+		Expect(p.MockIAM().AssertNotCalled(GinkgoT(), "DeleteRole", mock.Anything)).To(BeTrue())
+
+		connectorAPI.cluster.ConnectorConfig.RoleArn = aws.String("arn:aws:iam::123456789012:role/eksctl-on-prem-connector-role")
+		p.MockIAM().On("DeleteRolePolicy", mock.Anything).Return(&iam.DeleteRolePolicyOutput{}, nil)
+		p.MockIAM().On("DeleteRole", mock.Anything).Return(&iam.DeleteRoleOutput{}, nil)
+		Expect(manager.DeregisterCluster("on-prem")).To(Succeed())
+		Expect(p.MockIAM().AssertCalled(GinkgoT(), "DeleteRole", &iam.DeleteRoleInput{RoleName: aws.String("eksctl-on-prem-connector-role")})).To(BeTrue())
+	})
+
+	It("should render the manifests with the activation", func() {
+		list, err := NewManifests(connectorAPI.cluster, "eu-west-1")
+		Expect(err).ToNot(HaveOccurred())
+
+		var secret *corev1.Secret
+		for _, item := range list.Items {
+			if s, ok := item.Object.(*corev1.Secret); ok {
+				secret = s
+			}
+		}
+		Expect(secret).ToNot(BeNil())
+		Expect(string(secret.Data["code"])).To(Equal("activation-code"))
+
+		var kinds []string
+		for _, item := range list.Items {
+			kinds = append(kinds, item.Object.GetObjectKind().GroupVersionKind().Kind)
+		}
+		Expect(kinds).To(ContainElement("StatefulSet"))
+		Expect(kinds).To(ContainElement("ClusterRoleBinding"))
+	})
+
+	It("should deploy the connector to the external cluster", func() {
+		rawClient := testutils.NewFakeRawClient()
+		rawClient.AssumeObjectsMissing = true
+
+		list, err := NewManifests(connectorAPI.cluster, "eu-west-1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(Deploy(rawClient, list)).To(Succeed())
+
+		Expect(rawClient.Collection.CreatedItems()).To(HaveLen(len(list.Items)))
+		Expect(rawClient.Collection.Updated()).To(BeEmpty())
+	})
+})
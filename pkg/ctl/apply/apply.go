@@ -0,0 +1,394 @@
+package apply
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/weaveworks/eksctl/pkg/addons/clusterautoscaler"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/ctl/create"
+	"github.com/weaveworks/eksctl/pkg/ctl/delete"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// Statuses of the clusters in the summary
+const (
+	statusUnchanged = "unchanged"
+	statusPlanned   = "planned"
+	statusApplied   = "applied"
+	statusFailed    = "failed"
+)
+
+// applyResult is a row of the summary that is printed once all clusters are done
+type applyResult struct {
+	ConfigFile string
+	Name       string
+	Region     string
+	Changes    []string
+	Status     string
+	Error      string `json:",omitempty"`
+}
+
+// Command will create the `apply` command
+func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	return cmdutils.NewResourceCmd(flagGrouping, applyCmd).Command
+}
+
+func applyCmd(rc *cmdutils.ResourceCmd) {
+	var (
+		path, output string
+		parallel     int
+		printPlan    bool
+	)
+
+	rc.SetDescription("apply", "Create or update clusters to match config files",
+		"Loads a config file, or every config file of a directory, and creates missing clusters, "+
+			"or converges existing clusters to their config file: endpoint access, control plane logging, tags, "+
+			"nodegroups and Cluster Autoscaler are updated; clusters are processed in parallel and a summary "+
+			"is printed once all of them are done")
+
+	rc.SetRunFunc(func() error {
+		return doApply(rc, path, parallel, output, printPlan)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&path, "config-file", "f", "", "a config file, or a directory of config files (*.yaml, *.yml, *.json)")
+		fs.IntVar(&parallel, "parallel", 4, "number of clusters that are created or updated at the same time")
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format of the summary (valid option: table, json, yaml)")
+		fs.BoolVar(&printPlan, "plan", false, "print the ordered list of tasks for each cluster, without applying them")
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
+}
+
+func doApply(rc *cmdutils.ResourceCmd, path string, parallel int, output string, printPlan bool) error {
+	if path == "" {
+		return cmdutils.ErrMustBeSet("--config-file")
+	}
+	if printPlan && !rc.Plan {
+		return eksctlerrors.NewValidationError("--plan and --approve cannot be used at the same time")
+	}
+	if parallel < 1 {
+		return eksctlerrors.NewValidationError("--parallel must be 1 or greater")
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	if err := api.Register(); err != nil {
+		return err
+	}
+	files, err := findConfigFiles(path)
+	if err != nil {
+		return err
+	}
+	configs, configFiles, err := loadConfigFiles(files)
+	if err != nil {
+		return err
+	}
+	logger.Info("loaded %d cluster config(s) from %q", len(configs), path)
+
+	results := make([]*applyResult, len(configs))
+	plans := make([]*clusterPlan, len(configs))
+
+	forEachParallel(len(configs), parallel, func(i int) {
+		cfg := configs[i]
+		results[i] = &applyResult{
+			ConfigFile: configFiles[i],
+			Name:       cfg.Metadata.Name,
+			Region:     cfg.Metadata.Region,
+		}
+		plan, err := newClusterPlan(*rc.ProviderConfig, configFiles[i], cfg)
+		if err != nil {
+			results[i].Status = statusFailed
+			results[i].Error = err.Error()
+			return
+		}
+		plans[i] = plan
+		results[i].Changes = plan.Changes()
+
+		switch {
+		case !plan.HasChanges():
+			results[i].Status = statusUnchanged
+		case rc.Plan:
+			cmdutils.LogIntendedAction(true, "apply changes to cluster %q: %s", cfg.Metadata.Name, strings.Join(plan.Changes(), ", "))
+			results[i].Status = statusPlanned
+		}
+	})
+
+	if !rc.Plan {
+		forEachParallel(len(configs), parallel, func(i int) {
+			if plans[i] == nil || !plans[i].HasChanges() {
+				return
+			}
+			cmdutils.LogIntendedAction(false, "apply changes to cluster %q: %s", plans[i].Cluster.Metadata.Name, strings.Join(plans[i].Changes(), ", "))
+			if err := applyClusterPlan(*rc.ProviderConfig, plans[i]); err != nil {
+				results[i].Status = statusFailed
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Status = statusApplied
+		})
+	}
+
+	for i := range plans {
+		if plans[i] != nil && results[i].Status == statusPlanned {
+			for _, a := range plans[i].PlannedActions() {
+				rc.AddPlannedAction(a)
+			}
+		}
+	}
+	if printPlan {
+		printTasks(results)
+	}
+
+	// with --dry-run, the planned actions are printed instead of the summary
+	if !rc.DryRun {
+		if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+			addApplyResultTableColumns(columnPrinter)
+		}
+		if err := printer.PrintObjWithKind("results", results, os.Stdout); err != nil {
+			return err
+		}
+	}
+
+	failed, planned := 0, 0
+	for _, r := range results {
+		switch r.Status {
+		case statusFailed:
+			failed++
+		case statusPlanned:
+			planned++
+		}
+	}
+	cmdutils.LogPlanModeWarning(planned > 0)
+	if failed > 0 {
+		return fmt.Errorf("failed to apply %d of %d cluster config(s)", failed, len(results))
+	}
+	return nil
+}
+
+// newClusterPlan compares a config file with the cluster it describes
+func newClusterPlan(providerConfig api.ProviderConfig, configFile string, cfg *api.ClusterConfig) (*clusterPlan, error) {
+	providerConfig.Region = cfg.Metadata.Region
+	ctl, err := eks.New(&providerConfig, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return nil, cmdutils.ErrUnsupportedRegion(&providerConfig)
+	}
+	if err := ctl.CheckAuth(); err != nil {
+		return nil, err
+	}
+
+	cluster, err := ctl.DescribeControlPlane(cfg.Metadata)
+	if err != nil {
+		if eksctlerrors.ClassOf(err) == eksctlerrors.ClassNotFound {
+			return planCluster(configFile, cfg, nil), nil
+		}
+		return nil, err
+	}
+	current := &clusterState{Cluster: cluster}
+
+	stackManager := ctl.NewStackManager(cfg)
+	if current.NodeGroups, err = stackManager.ListNodeGroupStacks(); err != nil {
+		return nil, err
+	}
+
+	if _, err := stackManager.DescribeClusterStack(); err != nil {
+		if eksctlerrors.ClassOf(err) != eksctlerrors.ClassNotFound {
+			return nil, err
+		}
+		logger.Warning("cluster %q wasn't created by eksctl, its tags will be left as they are", cfg.Metadata.Name)
+	} else if current.Tags, err = stackManager.GetClusterStackTags(); err != nil {
+		return nil, err
+	}
+
+	if cfg.AutoScaler != nil && api.IsEnabled(cfg.AutoScaler.Install) {
+		if current.ClusterAutoscalerDeployed, err = isClusterAutoscalerDeployed(ctl, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return planCluster(configFile, cfg, current), nil
+}
+
+func isClusterAutoscalerDeployed(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) (bool, error) {
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return false, errors.Wrapf(err, "getting credentials for cluster %q", cfg.Metadata.Name)
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return false, err
+	}
+	_, err = clientSet.AppsV1().Deployments(metav1.NamespaceSystem).Get(clusterautoscaler.ClusterAutoscaler, metav1.GetOptions{})
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "looking up Cluster Autoscaler")
+	}
+	return true, nil
+}
+
+// applyClusterPlan creates a missing cluster with `create cluster`, or applies the changes
+// to an existing cluster in the order they are listed by the plan
+func applyClusterPlan(providerConfig api.ProviderConfig, plan *clusterPlan) error {
+	cfg := plan.Cluster
+
+	if plan.CreateCluster {
+		providerConfig.Region = ""
+		rc := create.NewClusterCmd()
+		*rc.ProviderConfig = providerConfig
+		return rc.RunWithFlags(map[string]string{
+			"config-file":      plan.ConfigFile,
+			"cluster-name":     cfg.Metadata.Name,
+			"write-kubeconfig": "false",
+		})
+	}
+
+	regionalProviderConfig := providerConfig
+	regionalProviderConfig.Region = cfg.Metadata.Region
+	regionalProviderConfig.RegionSource = eks.RegionSourceConfigFile
+	ctl, err := eks.New(&regionalProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if plan.UpdateEndpointAccess {
+		if err := ctl.UpdateClusterEndpointAccessBlocking(cfg.Metadata, plan.EndpointPublicAccess, plan.EndpointPrivateAccess); err != nil {
+			return err
+		}
+	}
+
+	if len(plan.EnableLogTypes) > 0 || len(plan.DisableLogTypes) > 0 {
+		if err := ctl.UpdateClusterLogTypesBlocking(cfg.Metadata, plan.EnableLogTypes, plan.DisableLogTypes); err != nil {
+			return err
+		}
+		after := cfg.EnabledClusterLogTypes()
+		before := sets.NewString(after...).Union(sets.NewString(plan.DisableLogTypes...)).Difference(sets.NewString(plan.EnableLogTypes...)).List()
+		if err := ctl.RecordClusterLoggingChange(cfg, before, after); err != nil {
+			logger.Warning("unable to record the change in the audit trail of cluster %q: %s", cfg.Metadata.Name, err.Error())
+		}
+	}
+
+	if plan.UpdateTags {
+		if err := ctl.NewStackManager(cfg).UpdateClusterStackTags(stackTags(cfg)); err != nil {
+			return err
+		}
+	}
+
+	// the commands load the region from the config file
+	providerConfig.Region = ""
+
+	if len(plan.CreateNodeGroups) > 0 {
+		rc := create.NewNodeGroupCmd()
+		*rc.ProviderConfig = providerConfig
+		err := rc.RunWithFlags(map[string]string{
+			"config-file":  plan.ConfigFile,
+			"cluster-name": cfg.Metadata.Name,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(plan.DeleteNodeGroups) > 0 {
+		rc := delete.NewNodeGroupCmd()
+		*rc.ProviderConfig = providerConfig
+		err := rc.RunWithFlags(map[string]string{
+			"config-file":  plan.ConfigFile,
+			"cluster-name": cfg.Metadata.Name,
+			"only-missing": "true",
+			"approve":      "true",
+			"wait":         "true",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if plan.InstallClusterAutoscaler {
+		if err := ctl.GetCredentials(cfg); err != nil {
+			return errors.Wrapf(err, "getting credentials for cluster %q", cfg.Metadata.Name)
+		}
+		if err := create.InstallClusterAutoscaler(ctl, cfg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// printTasks prints the numbered tasks of each cluster, in the order they would be applied
+func printTasks(results []*applyResult) {
+	for _, r := range results {
+		if r.Status == statusFailed {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "cluster %q in %q (%s):\n", r.Name, r.Region, r.ConfigFile)
+		if len(r.Changes) == 0 {
+			fmt.Fprintln(os.Stdout, "  no changes")
+		}
+		for n, change := range r.Changes {
+			fmt.Fprintf(os.Stdout, "  %d. %s\n", n+1, change)
+		}
+	}
+	fmt.Fprintln(os.Stdout)
+}
+
+// forEachParallel calls fn for indexes 0 to count-1, with up to parallel calls at the same time
+func forEachParallel(count, parallel int, fn func(i int)) {
+	sem := make(chan struct{}, parallel)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func addApplyResultTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("CLUSTER", func(r *applyResult) string {
+		return r.Name
+	})
+	printer.AddColumn("REGION", func(r *applyResult) string {
+		return r.Region
+	})
+	printer.AddColumn("CONFIG FILE", func(r *applyResult) string {
+		return r.ConfigFile
+	})
+	printer.AddColumn("CHANGES", func(r *applyResult) string {
+		return strings.Join(r.Changes, "; ")
+	})
+	printer.AddColumn("STATUS", func(r *applyResult) string {
+		return r.Status
+	})
+	printer.AddColumn("ERROR", func(r *applyResult) string {
+		return r.Error
+	})
+}
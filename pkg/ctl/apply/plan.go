@@ -0,0 +1,280 @@
+package apply
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// configFileExtensions are the extensions of files that are loaded from a directory
+var configFileExtensions = []string{".yaml", ".yml", ".json"}
+
+// clusterState is the current state of an existing cluster
+type clusterState struct {
+	Cluster    *awseks.Cluster
+	NodeGroups []string
+	// Tags are the tags of the cluster stack, they are nil when the stack wasn't found
+	Tags map[string]string
+	// ClusterAutoscalerDeployed is only looked up when the config file installs it
+	ClusterAutoscalerDeployed bool
+}
+
+// clusterPlan holds the changes that converge a cluster to its config file
+type clusterPlan struct {
+	ConfigFile string
+	Cluster    *api.ClusterConfig
+
+	CreateCluster bool
+
+	UpdateEndpointAccess                        bool
+	EndpointPublicAccess, EndpointPrivateAccess *bool
+
+	EnableLogTypes, DisableLogTypes []string
+
+	UpdateTags bool
+
+	CreateNodeGroups, DeleteNodeGroups []string
+
+	InstallClusterAutoscaler bool
+}
+
+// HasChanges returns true when the cluster doesn't match its config file
+func (p *clusterPlan) HasChanges() bool {
+	return len(p.Changes()) > 0
+}
+
+// Changes describes the changes of the plan, in the order they are applied; nodegroups
+// are deleted once new ones are created, so that workloads have somewhere to move to
+func (p *clusterPlan) Changes() []string {
+	changes := []string{}
+	if p.CreateCluster {
+		changes = append(changes, "create cluster")
+	}
+	if p.UpdateEndpointAccess {
+		changes = append(changes, fmt.Sprintf("update endpoint access to %s", describeEndpointAccess(p.EndpointPublicAccess, p.EndpointPrivateAccess)))
+	}
+	if len(p.EnableLogTypes) > 0 {
+		changes = append(changes, fmt.Sprintf("enable log types %s", strings.Join(p.EnableLogTypes, ", ")))
+	}
+	if len(p.DisableLogTypes) > 0 {
+		changes = append(changes, fmt.Sprintf("disable log types %s", strings.Join(p.DisableLogTypes, ", ")))
+	}
+	if p.UpdateTags {
+		changes = append(changes, fmt.Sprintf("update tags to %s", describeTags(stackTags(p.Cluster))))
+	}
+	if len(p.CreateNodeGroups) > 0 {
+		changes = append(changes, fmt.Sprintf("create nodegroups %s", strings.Join(p.CreateNodeGroups, ", ")))
+	}
+	if len(p.DeleteNodeGroups) > 0 {
+		changes = append(changes, fmt.Sprintf("delete nodegroups %s", strings.Join(p.DeleteNodeGroups, ", ")))
+	}
+	if p.InstallClusterAutoscaler {
+		changes = append(changes, "install Cluster Autoscaler")
+	}
+	return changes
+}
+
+// PlannedActions returns the changes of the plan as the actions printed with --dry-run,
+// in the same order as Changes
+func (p *clusterPlan) PlannedActions() []cmdutils.PlannedAction {
+	target := "cluster/" + p.Cluster.Metadata.Name
+	actions := []cmdutils.PlannedAction{}
+	add := func(actionType, target string, parameters map[string]string) {
+		actions = append(actions, cmdutils.PlannedAction{Type: actionType, Target: target, Parameters: parameters})
+	}
+	if p.CreateCluster {
+		add("create-cluster", target, map[string]string{"configFile": p.ConfigFile})
+	}
+	if p.UpdateEndpointAccess {
+		parameters := map[string]string{}
+		if p.EndpointPublicAccess != nil {
+			parameters["publicAccess"] = fmt.Sprint(*p.EndpointPublicAccess)
+		}
+		if p.EndpointPrivateAccess != nil {
+			parameters["privateAccess"] = fmt.Sprint(*p.EndpointPrivateAccess)
+		}
+		add("update-endpoint-access", target, parameters)
+	}
+	if len(p.EnableLogTypes) > 0 {
+		add("enable-log-types", target, map[string]string{"types": strings.Join(p.EnableLogTypes, ",")})
+	}
+	if len(p.DisableLogTypes) > 0 {
+		add("disable-log-types", target, map[string]string{"types": strings.Join(p.DisableLogTypes, ",")})
+	}
+	if p.UpdateTags {
+		add("update-tags", target, stackTags(p.Cluster))
+	}
+	for _, ng := range p.CreateNodeGroups {
+		add("create-nodegroup", "nodegroup/"+ng, map[string]string{"cluster": p.Cluster.Metadata.Name})
+	}
+	for _, ng := range p.DeleteNodeGroups {
+		add("delete-nodegroup", "nodegroup/"+ng, map[string]string{"cluster": p.Cluster.Metadata.Name})
+	}
+	if p.InstallClusterAutoscaler {
+		add("install-cluster-autoscaler", target, nil)
+	}
+	return actions
+}
+
+// planCluster compares the config file of a cluster with the current state of
+// the cluster, which is nil when the cluster doesn't exist
+func planCluster(configFile string, cfg *api.ClusterConfig, current *clusterState) *clusterPlan {
+	plan := &clusterPlan{
+		ConfigFile: configFile,
+		Cluster:    cfg,
+	}
+
+	desiredNodeGroups := sets.NewString()
+	for _, ng := range cfg.NodeGroups {
+		desiredNodeGroups.Insert(ng.Name)
+	}
+	installClusterAutoscaler := cfg.AutoScaler != nil && api.IsEnabled(cfg.AutoScaler.Install)
+
+	if current == nil {
+		// everything else is set when the cluster is created
+		plan.CreateCluster = true
+		plan.EnableLogTypes, _ = eks.ClusterLogTypeChanges(nil, cfg.EnabledClusterLogTypes())
+		plan.CreateNodeGroups = desiredNodeGroups.List()
+		plan.InstallClusterAutoscaler = installClusterAutoscaler
+		return plan
+	}
+
+	if cfg.VPC != nil {
+		plan.EndpointPublicAccess, plan.EndpointPrivateAccess, plan.UpdateEndpointAccess = eks.ClusterEndpointAccessChanges(current.Cluster, cfg.VPC.ClusterEndpoints)
+	}
+
+	plan.EnableLogTypes, plan.DisableLogTypes = eks.ClusterLogTypeChanges(eks.EnabledLogTypes(current.Cluster), cfg.EnabledClusterLogTypes())
+
+	if current.Tags != nil {
+		plan.UpdateTags = describeTags(current.Tags) != describeTags(stackTags(cfg))
+	}
+
+	currentNodeGroups := sets.NewString(current.NodeGroups...)
+	plan.CreateNodeGroups = desiredNodeGroups.Difference(currentNodeGroups).List()
+	plan.DeleteNodeGroups = currentNodeGroups.Difference(desiredNodeGroups).List()
+
+	plan.InstallClusterAutoscaler = installClusterAutoscaler && !current.ClusterAutoscalerDeployed
+	return plan
+}
+
+func describeEndpointAccess(public, private *bool) string {
+	access := []string{}
+	if public != nil {
+		access = append(access, fmt.Sprintf("public=%t", *public))
+	}
+	if private != nil {
+		access = append(access, fmt.Sprintf("private=%t", *private))
+	}
+	return strings.Join(access, ",")
+}
+
+// stackTags returns the tags the cluster stack should have, which are
+// metadata.tags and cloudFormation.stackTags, their keys never overlap
+func stackTags(cfg *api.ClusterConfig) map[string]string {
+	tags := map[string]string{}
+	for k, v := range cfg.Metadata.Tags {
+		tags[k] = v
+	}
+	if cfg.CloudFormation != nil {
+		for k, v := range cfg.CloudFormation.StackTags {
+			tags[k] = v
+		}
+	}
+	return tags
+}
+
+func describeTags(tags map[string]string) string {
+	if len(tags) == 0 {
+		return "none"
+	}
+	pairs := []string{}
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// findConfigFiles returns the path itself when it's a file, or the sorted
+// config files of a directory, subdirectories are not searched
+func findConfigFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := ioutil.ReadDir(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading directory %q", path)
+	}
+	files := []string{}
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		for _, ext := range configFileExtensions {
+			if strings.EqualFold(filepath.Ext(e.Name()), ext) {
+				files = append(files, filepath.Join(path, e.Name()))
+				break
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, eksctlerrors.NewValidationError("no config files (%s) found in directory %q", strings.Join(configFileExtensions, ", "), path)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// loadConfigFiles loads and checks the config files, which may define several
+// clusters each, and returns the file of each of the clusters along with them;
+// each cluster can only be described once
+func loadConfigFiles(files []string) ([]*api.ClusterConfig, []string, error) {
+	configs := []*api.ClusterConfig{}
+	configFiles := []string{}
+	seen := map[string]string{}
+	for _, file := range files {
+		cfgs, err := eks.LoadConfigsFromFile(file)
+		if err != nil {
+			return nil, nil, eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+		}
+		for _, cfg := range cfgs {
+			meta := cfg.Metadata
+			if meta == nil || meta.Name == "" || meta.Region == "" {
+				return nil, nil, eksctlerrors.NewValidationError("%s: metadata.name and metadata.region must be set", file)
+			}
+			if err := api.ValidateCloudWatchLogging(cfg); err != nil {
+				return nil, nil, eksctlerrors.NewValidationError("%s: %s", file, err.Error())
+			}
+			if err := api.ValidateClusterEndpoints(cfg); err != nil {
+				return nil, nil, eksctlerrors.NewValidationError("%s: %s", file, err.Error())
+			}
+			if err := api.ValidateCloudFormation(cfg); err != nil {
+				return nil, nil, eksctlerrors.NewValidationError("%s: %s", file, err.Error())
+			}
+			key := meta.Region + "/" + meta.Name
+			if other, ok := seen[key]; ok {
+				return nil, nil, eksctlerrors.NewValidationError("cluster %q in region %q is described by both %s and %s", meta.Name, meta.Region, other, file)
+			}
+			seen[key] = file
+			configs = append(configs, cfg)
+			configFiles = append(configFiles, file)
+		}
+	}
+	return configs, configFiles, nil
+}
@@ -0,0 +1,206 @@
+package apply
+
+import (
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("apply", func() {
+	BeforeEach(func() {
+		Expect(api.Register()).To(Succeed())
+	})
+
+	Describe("config files", func() {
+		It("should load config files of a directory", func() {
+			files, err := findConfigFiles("testdata/fleet")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(Equal([]string{
+				filepath.Join("testdata", "fleet", "cluster-1.yaml"),
+				filepath.Join("testdata", "fleet", "cluster-2.json"),
+			}))
+
+			configs, configFiles, err := loadConfigFiles(files)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs).To(HaveLen(2))
+			Expect(configs[0].Metadata.Name).To(Equal("cluster-1"))
+			Expect(configs[1].Metadata.Region).To(Equal("us-west-2"))
+			Expect(configFiles).To(Equal(files))
+		})
+
+		It("should load all the clusters of a multi-document config file", func() {
+			files, err := findConfigFiles("testdata/multi-cluster.yaml")
+			Expect(err).NotTo(HaveOccurred())
+
+			configs, configFiles, err := loadConfigFiles(files)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(configs).To(HaveLen(2))
+			Expect(configs[0].Metadata.Name).To(Equal("cluster-1"))
+			Expect(configs[1].Metadata.Name).To(Equal("cluster-3"))
+			Expect(configFiles).To(Equal([]string{"testdata/multi-cluster.yaml", "testdata/multi-cluster.yaml"}))
+		})
+
+		It("should load a single config file", func() {
+			files, err := findConfigFiles("testdata/fleet/cluster-2.json")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(Equal([]string{"testdata/fleet/cluster-2.json"}))
+		})
+
+		It("should reject clusters described by several files", func() {
+			files, err := findConfigFiles("testdata/duplicate")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, err = loadConfigFiles(files)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`cluster "cluster-1" in region "eu-north-1" is described by both`))
+		})
+
+		It("should reject a missing path", func() {
+			_, err := findConfigFiles("testdata/missing")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("planCluster", func() {
+		var cfg *api.ClusterConfig
+
+		BeforeEach(func() {
+			cfg = api.NewClusterConfig()
+			cfg.Metadata.Name = "cluster-1"
+			cfg.Metadata.Region = "eu-north-1"
+			for _, name := range []string{"ng-2", "ng-1"} {
+				ng := cfg.NewNodeGroup()
+				ng.Name = name
+			}
+			cfg.CloudWatch = &api.ClusterCloudWatch{ClusterLogging: &api.ClusterCloudWatchLogging{
+				EnableTypes: []string{"api", "audit"},
+			}}
+		})
+
+		It("should create a missing cluster", func() {
+			cfg.AutoScaler = &api.ClusterAutoScaler{Install: api.Enabled()}
+			plan := planCluster("cluster-1.yaml", cfg, nil)
+			Expect(plan.HasChanges()).To(BeTrue())
+			Expect(plan.CreateCluster).To(BeTrue())
+			Expect(plan.Changes()).To(Equal([]string{
+				"create cluster",
+				"enable log types api, audit",
+				"create nodegroups ng-1, ng-2",
+				"install Cluster Autoscaler",
+			}))
+		})
+
+		It("should converge an existing cluster in order", func() {
+			cfg.Metadata.Tags = map[string]string{"team": "a"}
+			cfg.VPC.ClusterEndpoints = &api.ClusterEndpoints{PrivateAccess: api.Enabled()}
+			cfg.AutoScaler = &api.ClusterAutoScaler{Install: api.Enabled()}
+			plan := planCluster("cluster-1.yaml", cfg, &clusterState{
+				Cluster:    newCluster([]string{"api", "scheduler"}, true, false),
+				NodeGroups: []string{"ng-1", "ng-old"},
+				Tags:       map[string]string{"team": "b"},
+			})
+			Expect(plan.CreateCluster).To(BeFalse())
+			Expect(plan.EnableLogTypes).To(Equal([]string{"audit"}))
+			Expect(plan.DisableLogTypes).To(Equal([]string{"scheduler"}))
+			Expect(plan.CreateNodeGroups).To(Equal([]string{"ng-2"}))
+			Expect(plan.DeleteNodeGroups).To(Equal([]string{"ng-old"}))
+			Expect(plan.Changes()).To(Equal([]string{
+				"update endpoint access to private=true",
+				"enable log types audit",
+				"disable log types scheduler",
+				"update tags to team=a",
+				"create nodegroups ng-2",
+				"delete nodegroups ng-old",
+				"install Cluster Autoscaler",
+			}))
+
+			actions := plan.PlannedActions()
+			Expect(actions).To(HaveLen(7))
+			Expect(actions[0]).To(Equal(cmdutils.PlannedAction{
+				Type:       "update-endpoint-access",
+				Target:     "cluster/cluster-1",
+				Parameters: map[string]string{"privateAccess": "true"},
+			}))
+			Expect(actions[3].Parameters).To(Equal(map[string]string{"team": "a"}))
+			Expect(actions[5]).To(Equal(cmdutils.PlannedAction{
+				Type:       "delete-nodegroup",
+				Target:     "nodegroup/ng-old",
+				Parameters: map[string]string{"cluster": "cluster-1"},
+			}))
+		})
+
+		It("should leave tags alone when the cluster stack wasn't found", func() {
+			cfg.CloudWatch = nil
+			cfg.Metadata.Tags = map[string]string{"team": "a"}
+			plan := planCluster("cluster-1.yaml", cfg, &clusterState{
+				Cluster:    newCluster(nil, true, false),
+				NodeGroups: []string{"ng-1", "ng-2"},
+			})
+			Expect(plan.UpdateTags).To(BeFalse())
+			Expect(plan.HasChanges()).To(BeFalse())
+		})
+
+		It("should compare the tags of the cluster stack with both kinds of tags", func() {
+			cfg.CloudWatch = nil
+			cfg.Metadata.Tags = map[string]string{"team": "a"}
+			cfg.CloudFormation = &api.ClusterCloudFormation{StackTags: map[string]string{"cost-center": "1"}}
+			current := &clusterState{
+				Cluster:    newCluster(nil, true, false),
+				NodeGroups: []string{"ng-1", "ng-2"},
+				Tags:       map[string]string{"team": "a", "cost-center": "1"},
+			}
+			Expect(planCluster("cluster-1.yaml", cfg, current).HasChanges()).To(BeFalse())
+
+			current.Tags = map[string]string{"team": "a"}
+			plan := planCluster("cluster-1.yaml", cfg, current)
+			Expect(plan.UpdateTags).To(BeTrue())
+			Expect(plan.Changes()).To(Equal([]string{"update tags to cost-center=1,team=a"}))
+			Expect(plan.PlannedActions()[0].Parameters).To(Equal(map[string]string{"team": "a", "cost-center": "1"}))
+		})
+
+		It("should not change a cluster that matches its config file", func() {
+			cfg.CloudWatch = nil
+			cfg.AutoScaler = &api.ClusterAutoScaler{Install: api.Enabled()}
+			plan := planCluster("cluster-1.yaml", cfg, &clusterState{
+				Cluster:                   newCluster([]string{"api"}, true, false),
+				NodeGroups:                []string{"ng-1", "ng-2"},
+				Tags:                      map[string]string{},
+				ClusterAutoscalerDeployed: true,
+			})
+			Expect(plan.HasChanges()).To(BeFalse())
+			Expect(plan.Changes()).To(BeEmpty())
+		})
+	})
+
+	Describe("forEachParallel", func() {
+		It("should call the function for every index", func() {
+			called := make([]bool, 10)
+			forEachParallel(len(called), 3, func(i int) {
+				called[i] = true
+			})
+			for _, c := range called {
+				Expect(c).To(BeTrue())
+			}
+		})
+	})
+})
+
+func newCluster(enabledLogTypes []string, public, private bool) *awseks.Cluster {
+	return &awseks.Cluster{
+		Logging: &awseks.Logging{
+			ClusterLogging: []*awseks.LogSetup{
+				{Enabled: aws.Bool(true), Types: aws.StringSlice(enabledLogTypes)},
+			},
+		},
+		ResourcesVpcConfig: &awseks.VpcConfigResponse{
+			EndpointPublicAccess:  aws.Bool(public),
+			EndpointPrivateAccess: aws.Bool(private),
+		},
+	}
+}
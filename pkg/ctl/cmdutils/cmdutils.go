@@ -9,6 +9,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 )
 
@@ -66,13 +68,17 @@ func LogPlanModeWarning(plan bool) {
 	}
 }
 
-// AddApproveFlag adds common `--approve` flag
+// AddApproveFlag adds common `--approve` flag, along with `--dry-run`
 func AddApproveFlag(fs *pflag.FlagSet, rc *ResourceCmd) {
 	approve := fs.Bool("approve", !rc.Plan, "Apply the changes")
+	addDryRunFlag(fs, rc)
 	AddPreRun(rc.Command, func(cmd *cobra.Command, args []string) {
 		if cmd.Flag("approve").Changed {
 			rc.Plan = !*approve
 		}
+		if rc.DryRun {
+			rc.Plan = true
+		}
 	})
 }
 
@@ -80,7 +86,7 @@ func AddApproveFlag(fs *pflag.FlagSet, rc *ResourceCmd) {
 func GetNameArg(args []string) string {
 	if len(args) > 1 {
 		logger.Critical("only one argument is allowed to be used as a name")
-		os.Exit(1)
+		os.Exit(eksctlerrors.ExitCodeValidation)
 	}
 	if len(args) == 1 {
 		return (strings.TrimSpace(args[0]))
@@ -99,9 +105,18 @@ func AddCommonFlagsForAWS(group *NamedFlagSetGroup, p *api.ProviderConfig, cfnRo
 			logger.Debug("ignoring error %q", err.Error())
 		}
 		fs.DurationVar(&p.WaitTimeout, "timeout", api.DefaultWaitTimeout, "max wait time in any polling operations")
+		fs.DurationVar(&p.PollInterval, "poll-interval", 0, "interval between status checks in any polling operations (default between 15s and 20s)")
+		fs.BoolVar(&p.AWSDebug, "aws-debug", false, "log service, operation, duration, retry count and request ID of every AWS API call")
+		fs.StringToStringVar(&p.Endpoints, "endpoint-url", nil, fmt.Sprintf(`endpoints of AWS services, e.g. "eks=https://eks.example.com,sts=https://sts.example.com" (overrides the AWS_<SERVICE>_ENDPOINT environment variables, services: %s)`, strings.Join(eks.EndpointServices(), ", ")))
+		fs.StringVar(&p.LocalEndpoint, "local-endpoint", "", `endpoint of a local emulation of AWS APIs, e.g. "http://localhost:4566" for LocalStack, used for the services without an endpoint URL`)
+		fs.BoolVar(&p.STSRegionalEndpoint, "sts-regional-endpoint", false, "call the STS endpoint of the region instead of the global one")
+		fs.StringVar(&p.CABundle, "ca-bundle", "", "file of PEM-encoded certificates to trust instead of the system ones when calling AWS APIs, and in addition to the CA of the cluster when calling Kubernetes APIs (overrides the AWS_CA_BUNDLE environment variable)")
 		if cfnRole {
 			fs.StringVar(&p.CloudFormationRoleARN, "cfn-role-arn", "", "IAM role used by CloudFormation to call AWS API on your behalf")
+			fs.BoolVar(&p.CloudFormationDisableRollback, "cfn-disable-rollback", false, "leave stacks that fail to be created as they are for debugging, instead of rolling them back")
+			fs.StringVar(&p.CloudFormationTemplateOverrides, "template-overrides", "", "directory of patches to the generated CloudFormation templates, unless set in the config file")
 		}
+		fs.StringVar(&p.StackNamePrefix, "stack-name-prefix", "", fmt.Sprintf("prefix of the names of CloudFormation stacks, unless set in the config file (default %q)", api.DefaultStackNamePrefix))
 	})
 }
 
@@ -145,12 +160,17 @@ func AddCommonFlagsForKubeconfig(fs *pflag.FlagSet, outputPath, authenticatorRol
 // AddCommonFlagsForGetCmd adds common flafs for get commands
 func AddCommonFlagsForGetCmd(fs *pflag.FlagSet, chunkSize *int, outputMode *string) {
 	fs.IntVar(chunkSize, "chunk-size", 100, "return large lists in chunks rather than all at once, pass 0 to disable")
-	fs.StringVarP(outputMode, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	fs.StringVarP(outputMode, "output", "o", "table", "specifies the output format (valid option: table, json, yaml, csv, jsonpath=<template>)")
+}
+
+// AddSelectorFlag adds common --selector flag of get commands
+func AddSelectorFlag(fs *pflag.FlagSet, selector *string) {
+	fs.StringVarP(selector, "selector", "l", "", "select by tags, e.g. env=prod,team=payments (supports '=', '==', '!=', 'key' and '!key')")
 }
 
 // ErrUnsupportedRegion is a common error message
 func ErrUnsupportedRegion(provider *api.ProviderConfig) error {
-	return fmt.Errorf("--region=%s is not supported - use one of: %s", provider.Region, strings.Join(api.SupportedRegions(), ", "))
+	return eksctlerrors.NewValidationError("--region=%s is not supported - use one of: %s", provider.Region, strings.Join(api.SupportedRegions(), ", "))
 }
 
 // ErrNameFlagAndArg is a common error message
@@ -161,15 +181,15 @@ func ErrNameFlagAndArg(nameFlag, nameArg string) error {
 // ErrFlagAndArg may be used to err for options that can be given
 // as flags /and/ arg but only one is allowed to be used.
 func ErrFlagAndArg(kind, flag, arg string) error {
-	return fmt.Errorf("%s=%s and argument %s %s", kind, flag, arg, IncompatibleFlags)
+	return eksctlerrors.NewValidationError("%s=%s and argument %s %s", kind, flag, arg, IncompatibleFlags)
 }
 
 // ErrMustBeSet is a common error message
 func ErrMustBeSet(pathOrFlag string) error {
-	return fmt.Errorf("%s must be set", pathOrFlag)
+	return eksctlerrors.NewValidationError("%s must be set", pathOrFlag)
 }
 
 // ErrCannotUseWithConfigFile is a common error message
 func ErrCannotUseWithConfigFile(what string) error {
-	return fmt.Errorf("cannot use %s when --config-file/-f is set", what)
+	return eksctlerrors.NewValidationError("cannot use %s when --config-file/-f is set", what)
 }
@@ -3,16 +3,56 @@ package cmdutils
 import (
 	"fmt"
 
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
 )
 
-// AddConfigFileFlag adds common --config-file flag
-func AddConfigFileFlag(fs *pflag.FlagSet, path *string) {
-	fs.StringVarP(path, "config-file", "f", "", "load configuration from a file (or stdin if set to '-')")
+// AddConfigFileFlag adds common --config-file flag, along with the flags selecting
+// clusters of config files that define several of them
+func AddConfigFileFlag(fs *pflag.FlagSet, rc *ResourceCmd) {
+	fs.StringVarP(&rc.ClusterConfigFile, "config-file", "f", "", "load configuration from a file (or stdin if set to '-')")
+	fs.StringVar(&rc.ClusterConfigName, "cluster-name", "", "name of the cluster to use, when the config file defines several of them")
+	fs.BoolVar(&rc.AllClusterConfigs, "all-clusters", false, "run for each of the clusters the config file defines")
+}
+
+// forEachClusterConfig wraps cmd so that, with --all-clusters, it's run for each of the
+// clusters of the config file in turn
+func (rc *ResourceCmd) forEachClusterConfig(cmd func() error) func() error {
+	return func() error {
+		if !rc.AllClusterConfigs {
+			return cmd()
+		}
+		switch {
+		case rc.ClusterConfigFile == "":
+			return eksctlerrors.NewValidationError("cannot use --all-clusters unless a config file is specified via --config-file/-f")
+		case rc.ClusterConfigFile == "-":
+			return eksctlerrors.NewValidationError("cannot use --all-clusters when the config file is read from stdin")
+		case rc.ClusterConfigName != "":
+			return eksctlerrors.NewValidationError("cannot use --all-clusters and --cluster-name together")
+		}
+		if err := api.Register(); err != nil {
+			return err
+		}
+		cfgs, err := eks.LoadConfigsFromFile(rc.ClusterConfigFile)
+		if err != nil {
+			return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+		}
+		defer func() { rc.ClusterConfigName = "" }()
+		for _, name := range eks.ConfigNames(cfgs) {
+			logger.Info("running for cluster %q of %q", name, rc.ClusterConfigFile)
+			rc.ClusterConfigName = name
+			if err := cmd(); err != nil {
+				return errors.Wrapf(err, "cluster %q", name)
+			}
+		}
+		return nil
+	}
 }
 
 // ClusterConfigLoader is an inteface that loaders should implement
@@ -43,14 +83,27 @@ func newCommonClusterConfigLoader(rc *ResourceCmd) *commonClusterConfigLoader {
 
 // Load ClusterConfig or use flags
 func (l *commonClusterConfigLoader) Load() error {
+	if err := l.load(); err != nil {
+		return err
+	}
+	return l.notifyStarted()
+}
+
+func (l *commonClusterConfigLoader) load() error {
 	if err := api.Register(); err != nil {
 		return err
 	}
 
+	if l.ProviderConfig.CABundle != "" {
+		if _, err := eks.ReadCABundle(l.ProviderConfig.CABundle); err != nil {
+			return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+		}
+	}
+
 	if l.ClusterConfigFile == "" {
 		for f := range l.flagsIncompatibleWithoutConfigFile {
 			if flag := l.Command.Flag(f); flag != nil && flag.Changed {
-				return fmt.Errorf("cannot use --%s unless a config file is specified via --config-file/-f", f)
+				return eksctlerrors.NewValidationError("cannot use --%s unless a config file is specified via --config-file/-f", f)
 			}
 		}
 		return l.validateWithoutConfigFile()
@@ -61,8 +114,8 @@ func (l *commonClusterConfigLoader) Load() error {
 	// The reference to ResourceCmd.ClusterConfig should only be reassigned if ClusterConfigFile is specified
 	// because other parts of the code store the pointer locally and access it directly instead of via
 	// the ResourceCmd reference
-	if l.ClusterConfig, err = eks.LoadConfigFromFile(l.ClusterConfigFile); err != nil {
-		return err
+	if l.ClusterConfig, err = eks.LoadNamedConfigFromFile(l.ClusterConfigFile, l.ClusterConfigName); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
 	}
 	meta := l.ClusterConfig.Metadata
 
@@ -88,6 +141,7 @@ func (l *commonClusterConfigLoader) Load() error {
 		return ErrMustBeSet("metadata.region")
 	}
 	l.ProviderConfig.Region = meta.Region
+	l.ProviderConfig.RegionSource = eks.RegionSourceConfigFile
 
 	return l.validateWithConfigFile()
 }
@@ -119,6 +173,24 @@ func NewMetadataLoader(rc *ResourceCmd) ClusterConfigLoader {
 	return l
 }
 
+// NewGetResourceLoader handles loading of clusterConfigFile vs using the --cluster flag for `eksctl get`
+// commands that list resources of a cluster, their name argument is a resource, so it must be
+// consumed before the config is loaded
+func NewGetResourceLoader(rc *ResourceCmd) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(rc)
+
+	l.flagsIncompatibleWithConfigFile = sets.NewString("cluster", "region")
+
+	l.validateWithoutConfigFile = func() error {
+		if l.ClusterConfig.Metadata.Name == "" {
+			return ErrMustBeSet("--cluster")
+		}
+		return nil
+	}
+
+	return l
+}
+
 // NewCreateClusterLoader will laod config or use flags for 'eksctl create cluster'
 func NewCreateClusterLoader(rc *ResourceCmd, ngFilter *NodeGroupFilter) ClusterConfigLoader {
 	l := newCommonClusterConfigLoader(rc)
@@ -149,6 +221,7 @@ func NewCreateClusterLoader(rc *ResourceCmd, ngFilter *NodeGroupFilter) ClusterC
 		"vpc-cidr",
 		"vpc-nat-mode",
 		"vpc-from-kops-cluster",
+		"role-arn",
 	)
 
 	l.validateWithConfigFile = func() error {
@@ -165,7 +238,7 @@ func NewCreateClusterLoader(rc *ResourceCmd, ngFilter *NodeGroupFilter) ClusterC
 		}
 
 		if l.ClusterConfig.HasAnySubnets() && len(l.ClusterConfig.AvailabilityZones) != 0 {
-			return fmt.Errorf("vpc.subnets and availabilityZones cannot be set at the same time")
+			return eksctlerrors.NewValidationError("vpc.subnets and availabilityZones cannot be set at the same time")
 		}
 
 		return nil
@@ -181,7 +254,7 @@ func NewCreateClusterLoader(rc *ResourceCmd, ngFilter *NodeGroupFilter) ClusterC
 		meta.Name = ClusterName(meta.Name, l.NameArg)
 
 		if l.ClusterConfig.Status != nil {
-			return fmt.Errorf("status fields are read-only")
+			return eksctlerrors.NewValidationError("status fields are read-only")
 		}
 
 		return ngFilter.ForEach(l.ClusterConfig.NodeGroups, func(i int, ng *api.NodeGroup) error {
@@ -255,15 +328,15 @@ func NewCreateNodeGroupLoader(rc *ResourceCmd, ngFilter *NodeGroupFilter) Cluste
 func normalizeNodeGroup(ng *api.NodeGroup, l *commonClusterConfigLoader) error {
 	if l.Command.Flag("ssh-public-key").Changed {
 		if *ng.SSH.PublicKeyPath == "" {
-			return fmt.Errorf("--ssh-public-key must be non-empty string")
+			return eksctlerrors.NewValidationError("--ssh-public-key must be non-empty string")
 		}
 		ng.SSH.Allow = api.Enabled()
 	} else {
 		ng.SSH.PublicKeyPath = nil
 	}
 
-	if *ng.VolumeType == api.NodeVolumeTypeIO1 {
-		return fmt.Errorf("%s volume type is not supported via flag --node-volume-type, please use a config file", api.NodeVolumeTypeIO1)
+	if t := *ng.VolumeType; t == api.NodeVolumeTypeIO1 || t == api.NodeVolumeTypeIO2 {
+		return eksctlerrors.NewValidationError("%s volume type is not supported via flag --node-volume-type, please use a config file", t)
 	}
 
 	return nil
@@ -315,3 +388,83 @@ func NewDeleteNodeGroupLoader(rc *ResourceCmd, ng *api.NodeGroup, ngFilter *Node
 
 	return l
 }
+
+// NewUpgradeNodeGroupLoader will load config or use flags for 'eksctl upgrade nodegroup',
+// oldName is the nodegroup being replaced and newNG is the replacement defined via flags,
+// when a config file is used the replacement is looked up in the file by newNG.Name
+func NewUpgradeNodeGroupLoader(rc *ResourceCmd, oldName *string, newNG *api.NodeGroup, ngFilter *NodeGroupFilter) ClusterConfigLoader {
+	l := newCommonClusterConfigLoader(rc)
+
+	// --name refers to the existing nodegroup, so it's always required
+	l.flagsIncompatibleWithConfigFile.Delete("name")
+
+	l.flagsIncompatibleWithConfigFile.Insert(
+		"cluster",
+		"nodes",
+		"nodes-min",
+		"nodes-max",
+		"node-type",
+		"node-volume-size",
+		"node-volume-type",
+		"max-pods-per-node",
+		"node-ami",
+		"node-ami-family",
+		"ssh-access",
+		"ssh-public-key",
+		"node-private-networking",
+		"node-security-groups",
+		"node-labels",
+		"node-zones",
+		"asg-access",
+		"external-dns-access",
+		"full-ecr-access",
+	)
+
+	l.validateWithConfigFile = func() error {
+		if *oldName == "" {
+			return ErrMustBeSet("--name")
+		}
+		if newNG.Name == "" {
+			return ErrMustBeSet("--new-name")
+		}
+		if *oldName == newNG.Name {
+			return eksctlerrors.NewValidationError("--name and --new-name must be different")
+		}
+		for _, ng := range l.ClusterConfig.NodeGroups {
+			if ng.Name == newNG.Name {
+				ngFilter.AppendIncludeNames(ng.Name)
+				return nil
+			}
+		}
+		return eksctlerrors.NewValidationError("nodegroup %q is not defined in the given config file (%q)", newNG.Name, l.ClusterConfigFile)
+	}
+
+	l.validateWithoutConfigFile = func() error {
+		if l.ClusterConfig.Metadata.Name == "" {
+			return ErrMustBeSet("--cluster")
+		}
+
+		if *oldName != "" && l.NameArg != "" {
+			return ErrNameFlagAndArg(*oldName, l.NameArg)
+		}
+
+		if l.NameArg != "" {
+			*oldName = l.NameArg
+		}
+
+		if *oldName == "" {
+			return ErrMustBeSet("--name")
+		}
+
+		// generate replacement nodegroup name or use flag
+		newNG.Name = NodeGroupName(newNG.Name, "")
+		if *oldName == newNG.Name {
+			return eksctlerrors.NewValidationError("--name and --new-name must be different")
+		}
+		ngFilter.AppendIncludeNames(newNG.Name)
+
+		return normalizeNodeGroup(newNG, l)
+	}
+
+	return l
+}
@@ -76,11 +76,40 @@ var _ = Describe("cmdutils configfile", func() {
 			}
 		})
 
+		It("should load the cluster of get commands from --cluster or the config file", func() {
+			cfg := api.NewClusterConfig()
+			rc := &ResourceCmd{
+				ClusterConfig: cfg,
+				Command:       newCmd(),
+			}
+
+			err := NewGetResourceLoader(rc).Load()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(ErrMustBeSet("--cluster").Error()))
+
+			cfg.Metadata.Name = "foo-1"
+			Expect(NewGetResourceLoader(rc).Load()).To(Succeed())
+
+			rc.ClusterConfigFile = examplesDir + "01-simple-cluster.yaml"
+			fs := rc.Command.Flags()
+			fs.StringVar(&cfg.Metadata.Name, "cluster", "", "")
+			rc.Command.Flag("cluster").Changed = true
+
+			err = NewGetResourceLoader(rc).Load()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(ErrCannotUseWithConfigFile("--cluster").Error()))
+
+			rc.Command.Flag("cluster").Changed = false
+			Expect(NewGetResourceLoader(rc).Load()).To(Succeed())
+			Expect(rc.ClusterConfig.Metadata.Name).To(Equal("cluster-1"))
+		})
+
 		It("load all of example file", func() {
 			examples, err := filepath.Glob(examplesDir + "*.yaml")
 			Expect(err).ToNot(HaveOccurred())
 
-			Expect(examples).To(HaveLen(10))
+			// every example must load, however many there are
+			Expect(examples).ToNot(BeEmpty())
 			for _, example := range examples {
 				rc := &ResourceCmd{
 					Command:           newCmd(),
@@ -129,5 +158,49 @@ var _ = Describe("cmdutils configfile", func() {
 				Expect(*cfg.VPC.NAT.Gateway).To(Equal(natTest.expectedGateway))
 			}
 		})
+
+		It("should pick the replacement nodegroup from the config file for upgrade", func() {
+			newLoader := func(oldName, newName string) (*ResourceCmd, *NodeGroupFilter, ClusterConfigLoader) {
+				rc := &ResourceCmd{
+					Command:           newCmd(),
+					ClusterConfigFile: filepath.Join(examplesDir, "05-advanced-nodegroups.yaml"),
+					ClusterConfig:     api.NewClusterConfig(),
+					ProviderConfig:    &api.ProviderConfig{},
+				}
+				ngFilter := NewNodeGroupFilter()
+				newNG := &api.NodeGroup{Name: newName}
+				return rc, ngFilter, NewUpgradeNodeGroupLoader(rc, &oldName, newNG, ngFilter)
+			}
+
+			{
+				_, _, l := newLoader("", "ng2-private-a")
+				Expect(l.Load()).To(MatchError(ErrMustBeSet("--name")))
+			}
+
+			{
+				_, _, l := newLoader("ng1-public", "")
+				Expect(l.Load()).To(MatchError(ErrMustBeSet("--new-name")))
+			}
+
+			{
+				_, _, l := newLoader("ng1-public", "ng1-public")
+				Expect(l.Load()).To(MatchError("--name and --new-name must be different"))
+			}
+
+			{
+				_, _, l := newLoader("ng1-public", "ng4")
+				err := l.Load()
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`nodegroup "ng4" is not defined in the given config file`))
+			}
+
+			{
+				rc, ngFilter, l := newLoader("ng1-public", "ng2-private-a")
+				Expect(l.Load()).To(Succeed())
+
+				included, _ := ngFilter.MatchAll(rc.ClusterConfig.NodeGroups)
+				Expect(included.List()).To(Equal([]string{"ng2-private-a"}))
+			}
+		})
 	})
 })
@@ -55,6 +55,34 @@ func (n *NamedFlagSetGroup) InFlagSet(name string, cb func(*pflag.FlagSet)) {
 	n.list = append(n.list, nfs)
 }
 
+// lookup returns the flag with the given name from any flagset of the group
+func (n *NamedFlagSetGroup) lookup(name string) *pflag.Flag {
+	for _, nfs := range n.list {
+		if f := nfs.fs.Lookup(name); f != nil {
+			return f
+		}
+	}
+	return nil
+}
+
+// NamedFlagSet is a flagset of a command, with the name its flags are listed under in usage
+type NamedFlagSet struct {
+	Name    string
+	FlagSet *pflag.FlagSet
+}
+
+// FlagSets returns the flagsets of the command, in the order they are listed in usage;
+// it's empty for commands that don't group their flags
+func (g *FlagGrouping) FlagSets(cmd *cobra.Command) []NamedFlagSet {
+	flagSets := []NamedFlagSet{}
+	if group, ok := g.groups[cmd]; ok {
+		for _, nfs := range group.list {
+			flagSets = append(flagSets, NamedFlagSet{Name: nfs.name, FlagSet: nfs.fs})
+		}
+	}
+	return flagSets
+}
+
 // AddTo mixes all flagsets in the given group into another flagset
 func (n *NamedFlagSetGroup) AddTo(cmd *cobra.Command) {
 	for _, nfs := range n.list {
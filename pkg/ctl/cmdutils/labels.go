@@ -0,0 +1,50 @@
+package cmdutils
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+)
+
+// UpdateLabels logs the changes to the labels of a nodegroup, and applies them unless in plan mode
+func UpdateLabels(ctx context.Context, m *actions.Manager, rc *ResourceCmd, opts actions.UpdateLabelsOptions) error {
+	plan := rc.Plan
+	opts.Plan = true
+	changes, err := m.UpdateLabels(ctx, opts)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		logger.Info("labels of nodegroup %q are already up-to-date", opts.NodeGroup)
+		return nil
+	}
+	for _, c := range changes {
+		rc.LogIntendedAction(labelPlannedAction(c, opts.NodeGroup), "%s on nodegroup %q", c, opts.NodeGroup)
+	}
+
+	if !plan {
+		opts.Plan = false
+		if _, err := m.UpdateLabels(ctx, opts); err != nil {
+			return err
+		}
+	}
+
+	LogCompletedAction(plan, "updated labels of nodegroup %q", opts.NodeGroup)
+	LogPlanModeWarning(plan)
+	return nil
+}
+
+func labelPlannedAction(c actions.LabelChange, nodeGroup string) PlannedAction {
+	action := PlannedAction{
+		Type:       "set-label",
+		Target:     "nodegroup/" + nodeGroup,
+		Parameters: map[string]string{"key": c.Key, "value": c.New},
+	}
+	if c.New == "" {
+		action.Type = "remove-label"
+		action.Parameters = map[string]string{"key": c.Key}
+	}
+	return action
+}
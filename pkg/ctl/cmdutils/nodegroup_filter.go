@@ -6,12 +6,12 @@ import (
 
 	"github.com/gobwas/glob"
 	"github.com/kris-nova/logger"
-	"github.com/pkg/errors"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
 )
 
 // NodeGroupFilter holds filter configuration
@@ -50,7 +50,7 @@ func (f *NodeGroupFilter) AppendIncludeGlobs(nodeGroups []*api.NodeGroup, globEx
 	for _, expr := range globExprs {
 		compiledExpr, err := glob.Compile(expr)
 		if err != nil {
-			return errors.Wrapf(err, "parsing glob filter %q", expr)
+			return eksctlerrors.Wrapf(eksctlerrors.ClassValidation, err, "parsing glob filter %q", expr)
 		}
 		f.includeGlobs = append(f.includeGlobs, compiledExpr)
 		f.rawIncludeGlobs = append(f.rawIncludeGlobs, expr)
@@ -67,7 +67,7 @@ func (f *NodeGroupFilter) includeGlobsMatchAnything(nodeGroups []*api.NodeGroup)
 			return nil
 		}
 	}
-	return fmt.Errorf("no nodegroups match include glob filter specification: %q", strings.Join(f.rawIncludeGlobs, ","))
+	return eksctlerrors.NewValidationError("no nodegroups match include glob filter specification: %q", strings.Join(f.rawIncludeGlobs, ","))
 }
 
 // AppendIncludeNames appends explicit names to the include filter
@@ -78,7 +78,7 @@ func (f *NodeGroupFilter) AppendExcludeGlobs(globExprs ...string) error {
 	for _, expr := range globExprs {
 		compiledExpr, err := glob.Compile(expr)
 		if err != nil {
-			return errors.Wrapf(err, "parsing glob filter %q", expr)
+			return eksctlerrors.Wrapf(eksctlerrors.ClassValidation, err, "parsing glob filter %q", expr)
 		}
 		f.excludeGlobs = append(f.excludeGlobs, compiledExpr)
 		f.rawExcludeGlobs = append(f.rawExcludeGlobs, expr)
@@ -110,7 +110,7 @@ func (f *NodeGroupFilter) SetExcludeExistingFilter(stackManager *manager.StackCo
 			isAlsoIncluded = true
 		}
 		if isAlsoIncluded {
-			return fmt.Errorf("existing nodegroup %q should be excluded, but matches include filter: %s", name, f.describeIncludeRules())
+			return eksctlerrors.NewValidationError("existing nodegroup %q should be excluded, but matches include filter: %s", name, f.describeIncludeRules())
 		}
 	}
 	return nil
@@ -297,7 +297,7 @@ func (f *NodeGroupFilter) ForEach(nodeGroups []*api.NodeGroup, iterFn func(i int
 func (f *NodeGroupFilter) ValidateNodeGroupsAndSetDefaults(nodeGroups []*api.NodeGroup) error {
 	return f.ForEach(nodeGroups, func(i int, ng *api.NodeGroup) error {
 		if err := api.ValidateNodeGroup(i, ng); err != nil {
-			return err
+			return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
 		}
 		if err := api.SetNodeGroupDefaults(i, ng); err != nil {
 			return err
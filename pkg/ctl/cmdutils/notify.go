@@ -0,0 +1,95 @@
+package cmdutils
+
+import (
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// operationNotification is an operation whose start has been published
+type operationNotification struct {
+	topicARN, region string
+	event            eks.OperationEvent
+}
+
+// AddNotifySNSTopicFlag adds --notify-sns-topic, to publish the start, success and
+// failure of the operation of the command to an SNS topic
+func AddNotifySNSTopicFlag(fs *pflag.FlagSet, rc *ResourceCmd, operation string) {
+	rc.notifiedOperation = operation
+	fs.StringVar(&rc.ProviderConfig.NotifySNSTopicARN, "notify-sns-topic", "", "ARN of an SNS topic to publish the start, success and failure of the operation to")
+}
+
+// notifyStarted publishes the start of the operation, it's called by the loaders
+// as the cluster it's about is only known once the config is loaded
+func (rc *ResourceCmd) notifyStarted() error {
+	topicARN := rc.ProviderConfig.NotifySNSTopicARN
+	if rc.notifiedOperation == "" || topicARN == "" || rc.notification != nil {
+		return nil
+	}
+	region, err := eks.SNSTopicRegion(topicARN)
+	if err != nil {
+		return eksctlerrors.NewValidationError("--notify-sns-topic: %s", err.Error())
+	}
+	meta := rc.ClusterConfig.Metadata
+	n := &operationNotification{
+		topicARN: topicARN,
+		region:   region,
+		event: eks.OperationEvent{
+			Operation: rc.notifiedOperation,
+			Status:    eks.OperationStarted,
+			Cluster:   meta.Name,
+			Region:    meta.Region,
+			Time:      time.Now().UTC(),
+		},
+	}
+	if n.event.Region == "" {
+		n.event.Region = rc.ProviderConfig.Region
+	}
+	rc.notification = n
+	n.publish(*rc.ProviderConfig, n.event)
+	return nil
+}
+
+// notifyCompletion wraps cmd so that the success or failure of the operation is
+// published once it has returned, when its start has been published
+func (rc *ResourceCmd) notifyCompletion(cmd func() error) func() error {
+	return func() error {
+		err := cmd()
+		n := rc.notification
+		if n == nil {
+			return err
+		}
+		rc.notification = nil
+
+		event := n.event
+		event.Time = time.Now().UTC()
+		event.DurationSeconds = int64(event.Time.Sub(n.event.Time).Seconds())
+		event.Status = eks.OperationSucceeded
+		if err != nil {
+			event.Status = eks.OperationFailed
+			event.Error = err.Error()
+		}
+		n.publish(*rc.ProviderConfig, event)
+		return err
+	}
+}
+
+// publish publishes event in the region of the topic, failures are only
+// reported, as they shouldn't fail the operation
+func (n *operationNotification) publish(providerConfig api.ProviderConfig, event eks.OperationEvent) {
+	providerConfig.Region = n.region
+	ctl, err := eks.New(&providerConfig, nil)
+	if err == nil {
+		err = ctl.PublishOperationEvent(n.topicARN, event)
+	}
+	if err != nil {
+		logger.Warning("%s of %s was not notified: %v", event.Status, event.Operation, err)
+		return
+	}
+	logger.Debug("published %s of %s to %q", event.Status, event.Operation, n.topicARN)
+}
@@ -0,0 +1,138 @@
+package cmdutils
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// PlannedAction is an action that a command would take in plan mode, the
+// actions are printed as a plan document with --dry-run
+type PlannedAction struct {
+	// Type is what the action does, e.g. "delete-nodegroup"
+	Type string `json:"type"`
+	// Target is the resource the action is taken on, e.g. "nodegroup/ng-1"
+	Target     string            `json:"target"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// Plan is the document printed with --dry-run, tools can diff it or require
+// an approval for it before running the command again with --approve
+type Plan struct {
+	Command string           `json:"command"`
+	Actions []*PlannedAction `json:"actions"`
+}
+
+// ClusterAction returns an action on the cluster
+func ClusterAction(actionType string, meta *api.ClusterMeta, parameters map[string]string) PlannedAction {
+	return PlannedAction{
+		Type:       actionType,
+		Target:     "cluster/" + meta.Name,
+		Parameters: parameters,
+	}
+}
+
+// NodeGroupAction returns an action on a nodegroup of the cluster
+func NodeGroupAction(actionType string, meta *api.ClusterMeta, nodeGroup string) PlannedAction {
+	return PlannedAction{
+		Type:       actionType,
+		Target:     "nodegroup/" + nodeGroup,
+		Parameters: map[string]string{"cluster": meta.Name},
+	}
+}
+
+// addDryRunFlag adds the --dry-run flag, the plan is printed in the format of the
+// --output flag of the command, which is added along with it when there's none
+func addDryRunFlag(fs *pflag.FlagSet, rc *ResourceCmd) {
+	fs.BoolVar(&rc.DryRun, "dry-run", false, "print the actions that would be taken as a plan document in the format of --output, without applying them")
+}
+
+func (rc *ResourceCmd) addPlanOutputFlag() {
+	if rc.FlagSetGroup.lookup("dry-run") == nil || rc.FlagSetGroup.lookup("output") != nil {
+		return
+	}
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringP("output", "o", "table", "specifies the output format of the plan printed with --dry-run (valid option: table, json, yaml)")
+	})
+}
+
+// LogIntendedAction logs an action with LogIntendedAction, in plan mode it's also
+// added to the plan printed with --dry-run
+func (rc *ResourceCmd) LogIntendedAction(action PlannedAction, msgFmt string, args ...interface{}) {
+	LogIntendedAction(rc.Plan, msgFmt, args...)
+	rc.AddPlannedAction(action)
+}
+
+// AddPlannedAction adds an action to the plan printed with --dry-run, for actions
+// that are logged by the packages that plan them; it does nothing unless in plan mode
+func (rc *ResourceCmd) AddPlannedAction(action PlannedAction) {
+	if !rc.Plan {
+		return
+	}
+	rc.plannedActions = append(rc.plannedActions, &action)
+}
+
+// printPlan runs the command and prints the actions it planned when --dry-run
+// is set, the plan of all clusters of a config file is printed as one document
+func (rc *ResourceCmd) printPlan(cmd func() error) func() error {
+	return func() error {
+		if !rc.DryRun {
+			return cmd()
+		}
+		if f := rc.Command.Flags().Lookup("approve"); f != nil && f.Changed && f.Value.String() == "true" {
+			return eksctlerrors.NewValidationError(fmt.Sprintf("--dry-run and --approve %s", IncompatibleFlags))
+		}
+		output := "table"
+		if f := rc.Command.Flags().Lookup("output"); f != nil {
+			output = f.Value.String()
+		}
+		printer, err := printers.NewPrinter(output)
+		if err != nil {
+			return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+		}
+
+		rc.plannedActions = []*PlannedAction{}
+		if err := cmd(); err != nil {
+			return err
+		}
+
+		if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+			if len(rc.plannedActions) == 0 {
+				logger.Info("no changes are planned")
+				return nil
+			}
+			addPlannedActionTableColumns(columnPrinter)
+			return printer.PrintObjWithKind("actions", rc.plannedActions, os.Stdout)
+		}
+		plan := &Plan{
+			Command: rc.Command.CommandPath(),
+			Actions: rc.plannedActions,
+		}
+		return printer.PrintObjWithKind("plan", plan, os.Stdout)
+	}
+}
+
+func addPlannedActionTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("TYPE", func(a *PlannedAction) string {
+		return a.Type
+	})
+	printer.AddColumn("TARGET", func(a *PlannedAction) string {
+		return a.Target
+	})
+	printer.AddColumn("PARAMETERS", func(a *PlannedAction) string {
+		parameters := []string{}
+		for k, v := range a.Parameters {
+			parameters = append(parameters, k+"="+v)
+		}
+		sort.Strings(parameters)
+		return strings.Join(parameters, ",")
+	})
+}
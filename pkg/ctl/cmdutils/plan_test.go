@@ -0,0 +1,63 @@
+package cmdutils_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+var _ = Describe("cmdutils plan", func() {
+
+	newCmd := func(run func(rc *ResourceCmd) error) *ResourceCmd {
+		return NewResourceCmd(NewGrouping(), func(rc *ResourceCmd) {
+			rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+				AddApproveFlag(fs, rc)
+			})
+			rc.SetRunFunc(func() error {
+				return run(rc)
+			})
+		})
+	}
+
+	It("should add --dry-run along with --output for the plan", func() {
+		rc := newCmd(func(*ResourceCmd) error { return nil })
+		Expect(rc.Command.Flags().Lookup("dry-run")).ToNot(BeNil())
+		Expect(rc.Command.Flags().ShorthandLookup("o")).ToNot(BeNil())
+	})
+
+	It("should run in plan mode with --dry-run", func() {
+		ran := false
+		rc := newCmd(func(rc *ResourceCmd) error {
+			ran = true
+			Expect(rc.Plan).To(BeTrue())
+			rc.AddPlannedAction(NodeGroupAction("delete-nodegroup", rc.ClusterConfig.Metadata, "ng-1"))
+			return nil
+		})
+		rc.ClusterConfig = api.NewClusterConfig()
+		rc.ClusterConfig.Metadata.Name = "cluster-1"
+		rc.Plan = false
+		Expect(rc.RunWithFlags(map[string]string{"dry-run": "true", "output": "yaml"})).To(Succeed())
+		Expect(ran).To(BeTrue())
+	})
+
+	It("should reject --dry-run along with --approve", func() {
+		rc := newCmd(func(*ResourceCmd) error {
+			Fail("the command shouldn't run")
+			return nil
+		})
+		err := rc.RunWithFlags(map[string]string{"dry-run": "true", "approve": "true"})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(IncompatibleFlags))
+	})
+
+	It("should reject an unknown plan format before running the command", func() {
+		rc := newCmd(func(*ResourceCmd) error {
+			Fail("the command shouldn't run")
+			return nil
+		})
+		Expect(rc.RunWithFlags(map[string]string{"dry-run": "true", "output": "xml"})).ToNot(Succeed())
+	})
+})
@@ -1,12 +1,17 @@
 package cmdutils
 
 import (
+	"fmt"
 	"os"
+	"time"
 
 	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/metrics"
 )
 
 // ResourceCmd holds attributes that most of the commands use
@@ -15,19 +20,38 @@ type ResourceCmd struct {
 	FlagSetGroup *NamedFlagSetGroup
 
 	Plan, Wait bool
+	// DryRun prints the actions planned by the command as a plan document
+	DryRun         bool
+	plannedActions []*PlannedAction
 
 	NameArg string
 
 	ClusterConfigFile string
+	// ClusterConfigName selects a cluster of config files that define several of them,
+	// AllClusterConfigs runs the command for each of them
+	ClusterConfigName string
+	AllClusterConfigs bool
 
 	ProviderConfig *api.ProviderConfig
 	ClusterConfig  *api.ClusterConfig
 
 	IncludeNodeGroups, ExcludeNodeGroups []string
+
+	// notifiedOperation is the name of the operation published to --notify-sns-topic
+	notifiedOperation string
+	notification      *operationNotification
+
+	runFunc func() error
 }
 
 // AddResourceCmd create a registers a new command under the given verb command
 func AddResourceCmd(flagGrouping *FlagGrouping, parentVerbCmd *cobra.Command, newResourceCmd func(*ResourceCmd)) {
+	parentVerbCmd.AddCommand(NewResourceCmd(flagGrouping, newResourceCmd).Command)
+}
+
+// NewResourceCmd creates a new command without registering it under a verb command,
+// so that it can be run by other commands with RunWithFlags
+func NewResourceCmd(flagGrouping *FlagGrouping, newResourceCmd func(*ResourceCmd)) *ResourceCmd {
 	resource := &ResourceCmd{
 		Command:        &cobra.Command{},
 		ProviderConfig: &api.ProviderConfig{},
@@ -37,8 +61,9 @@ func AddResourceCmd(flagGrouping *FlagGrouping, parentVerbCmd *cobra.Command, ne
 	}
 	resource.FlagSetGroup = flagGrouping.New(resource.Command)
 	newResourceCmd(resource)
+	resource.addPlanOutputFlag()
 	resource.FlagSetGroup.AddTo(resource.Command)
-	parentVerbCmd.AddCommand(resource.Command)
+	return resource
 }
 
 // SetDescription sets usage along with short and long descriptions as well as aliases
@@ -51,22 +76,52 @@ func (rc *ResourceCmd) SetDescription(use, short, long string, aliases ...string
 
 // SetRunFunc registers a command function
 func (rc *ResourceCmd) SetRunFunc(cmd func() error) {
-	rc.Command.Run = func(_ *cobra.Command, _ []string) {
-		run(cmd)
+	cmd = rc.printPlan(rc.forEachClusterConfig(rc.notifyCompletion(cmd)))
+	rc.runFunc = cmd
+	rc.Command.Run = func(c *cobra.Command, _ []string) {
+		run(c, cmd)
 	}
 }
 
 // SetRunFuncWithNameArg registers a command function with an optional name argument
 func (rc *ResourceCmd) SetRunFuncWithNameArg(cmd func() error) {
-	rc.Command.Run = func(_ *cobra.Command, args []string) {
+	cmd = rc.printPlan(rc.forEachClusterConfig(rc.notifyCompletion(cmd)))
+	rc.runFunc = cmd
+	rc.Command.Run = func(c *cobra.Command, args []string) {
 		rc.NameArg = GetNameArg(args)
-		run(cmd)
+		run(c, cmd)
+	}
+}
+
+// RunWithFlags sets flags as if they were given on the command line and runs
+// the pre-run hooks and the command function, unlike running the command
+// itself it returns errors instead of exiting
+func (rc *ResourceCmd) RunWithFlags(flags map[string]string) error {
+	if rc.runFunc == nil {
+		return fmt.Errorf("command %q has no run function", rc.Command.Name())
+	}
+	for name, value := range flags {
+		if err := rc.Command.Flags().Set(name, value); err != nil {
+			return eksctlerrors.WithClass(eksctlerrors.ClassValidation, errors.Wrapf(err, "setting --%s", name))
+		}
 	}
+	if rc.Command.PreRun != nil {
+		rc.Command.PreRun(rc.Command, nil)
+	}
+	return rc.runFunc()
 }
 
-func run(cmd func() error) {
-	if err := cmd(); err != nil {
+// run runs the command, records its duration and exports metrics,
+// as commands exit on failure
+func run(c *cobra.Command, cmd func() error) {
+	start := time.Now()
+	err := cmd()
+	metrics.ObserveCommand(c.CommandPath(), start, err)
+	if exportErr := metrics.Export(); exportErr != nil {
+		logger.Warning("%s", exportErr.Error())
+	}
+	if err != nil {
 		logger.Critical("%s\n", err.Error())
-		os.Exit(1)
+		os.Exit(eksctlerrors.ExitCode(err))
 	}
 }
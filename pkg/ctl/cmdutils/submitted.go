@@ -0,0 +1,66 @@
+package cmdutils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// SubmittedOperation is a CloudFormation or EKS operation that was submitted
+// with --no-wait, it's printed so that callers can wait for it themselves
+type SubmittedOperation struct {
+	Resource  string
+	Name      string
+	Operation string
+	// ID is the ID of the stack or of the EKS update
+	ID string
+	// WaitCommand is the command that blocks until the operation is complete
+	WaitCommand string
+}
+
+// AddNoWaitFlags adds common --no-wait flag, along with the --output
+// flag for the submitted operations
+func AddNoWaitFlags(fs *pflag.FlagSet, noWait *bool, output *string, description string) {
+	fs.BoolVar(noWait, "no-wait", false, fmt.Sprintf("submit %s and exit without waiting, the IDs of the stacks and updates are printed", description))
+	fs.StringVarP(output, "output", "o", "table", "specifies the output format of the operations submitted with --no-wait (valid option: table, json, yaml)")
+}
+
+// NewSubmittedOperationsPrinter checks the output format of the submitted
+// operations, so that an invalid format is reported before submitting anything
+func NewSubmittedOperationsPrinter(output string) (printers.OutputPrinter, error) {
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return nil, eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addSubmittedOperationTableColumns(columnPrinter)
+	}
+	return printer, nil
+}
+
+// PrintSubmittedOperations prints the operations submitted with --no-wait
+func PrintSubmittedOperations(printer printers.OutputPrinter, operations []*SubmittedOperation) error {
+	return printer.PrintObjWithKind("operations", operations, os.Stdout)
+}
+
+func addSubmittedOperationTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("RESOURCE", func(o *SubmittedOperation) string {
+		return o.Resource
+	})
+	printer.AddColumn("NAME", func(o *SubmittedOperation) string {
+		return o.Name
+	})
+	printer.AddColumn("OPERATION", func(o *SubmittedOperation) string {
+		return o.Operation
+	})
+	printer.AddColumn("ID", func(o *SubmittedOperation) string {
+		return o.ID
+	})
+	printer.AddColumn("WAIT COMMAND", func(o *SubmittedOperation) string {
+		return o.WaitCommand
+	})
+}
@@ -0,0 +1,29 @@
+package cmdutils
+
+import (
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// AddVersionSkewForceFlag adds the --force flag of commands that create nodegroups
+func AddVersionSkewForceFlag(fs *pflag.FlagSet, force *bool) {
+	fs.BoolVar(force, "force", false, "create nodegroups whose AMI has a kubelet version that the control plane doesn't support")
+}
+
+// CheckNodeGroupVersionSkew rejects nodegroups whose AMI has a kubelet version that is newer than
+// the control plane, or too old for it, unless force is set, in which case only a warning is logged
+func CheckNodeGroupVersionSkew(ctl *eks.ClusterProvider, ng *api.NodeGroup, controlPlaneVersion, version string, force bool) error {
+	err := ctl.CheckNodeGroupVersionSkew(ng, controlPlaneVersion, version)
+	if err == nil {
+		return nil
+	}
+	if !force {
+		return eksctlerrors.NewValidationError("%s, nodes may fail to join the cluster or to run pods; use --force to create the nodegroup anyway", err.Error())
+	}
+	logger.Warning("%s, the nodegroup is created anyway as --force is set", err.Error())
+	return nil
+}
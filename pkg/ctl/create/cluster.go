@@ -2,18 +2,26 @@ package create
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 
+	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/hooks"
 	"github.com/weaveworks/eksctl/pkg/kops"
+	"github.com/weaveworks/eksctl/pkg/pricing"
 	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/ssh"
 	"github.com/weaveworks/eksctl/pkg/utils"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
 	"github.com/weaveworks/eksctl/pkg/vpc"
@@ -30,6 +38,16 @@ type createClusterCmdParams struct {
 	kopsClusterNameForVPC string
 	subnets               map[api.SubnetTopology]*[]string
 	withoutNodeGroup      bool
+
+	installClusterAutoscaler      bool
+	installNodeTerminationHandler string
+
+	showCostEstimate bool
+	interactive      bool
+	skipQuotaChecks  bool
+
+	noWait bool
+	output string
 }
 
 func createClusterCmd(rc *cmdutils.ResourceCmd) {
@@ -54,7 +72,13 @@ func createClusterCmd(rc *cmdutils.ResourceCmd) {
 		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
 		fs.StringSliceVar(&params.availabilityZones, "zones", nil, "(auto-select if unspecified)")
 		cmdutils.AddVersionFlag(fs, cfg.Metadata, "")
-		cmdutils.AddConfigFileFlag(fs, &rc.ClusterConfigFile)
+		fs.StringVar(&cfg.IAM.ServiceRoleARN, "role-arn", "", "existing IAM role EKS uses to manage the cluster (created by eksctl if unspecified)")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddNotifySNSTopicFlag(fs, rc, "create cluster")
+		fs.BoolVar(&params.showCostEstimate, "show-cost-estimate", false, "print an estimated monthly cost of the cluster and its nodegroups and exit without creating anything")
+		fs.BoolVar(&params.interactive, "interactive", false, "ask for the settings of the cluster, print the equivalent config file and ask for confirmation before creating it")
+		fs.BoolVar(&params.skipQuotaChecks, "skip-quota-checks", false, "do not check that the cluster fits in the service quotas of the account before creating it")
+		cmdutils.AddNoWaitFlags(fs, &params.noWait, &params.output, "the creation of the cluster stack")
 	})
 
 	rc.FlagSetGroup.InFlagSet("Initial nodegroup", func(fs *pflag.FlagSet) {
@@ -65,6 +89,8 @@ func createClusterCmd(rc *cmdutils.ResourceCmd) {
 
 	rc.FlagSetGroup.InFlagSet("Cluster and nodegroup add-ons", func(fs *pflag.FlagSet) {
 		cmdutils.AddCommonCreateNodeGroupIAMAddonsFlags(fs, ng)
+		fs.BoolVar(&params.installClusterAutoscaler, "install-cluster-autoscaler", false, "install Cluster Autoscaler once nodegroups with ASG access are ready")
+		fs.StringVar(&params.installNodeTerminationHandler, "install-node-termination-handler", "", "install Node Termination Handler to drain spot nodes before interruptions, in \"imds\" or \"queue\" mode")
 	})
 
 	rc.FlagSetGroup.InFlagSet("VPC networking", func(fs *pflag.FlagSet) {
@@ -96,12 +122,117 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 	cfg := rc.ClusterConfig
 	meta := rc.ClusterConfig.Metadata
 
+	if params.interactive {
+		if rc.ClusterConfigFile != "" {
+			return cmdutils.ErrCannotUseWithConfigFile("--interactive")
+		}
+		confirmed, err := runWizard(rc, params.withoutNodeGroup)
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			logger.Info("cluster %q was not created", meta.Name)
+			return nil
+		}
+	}
+
 	if err := ngFilter.ValidateNodeGroupsAndSetDefaults(cfg.NodeGroups); err != nil {
 		return err
 	}
 
+	var submittedPrinter printers.OutputPrinter
+	if params.noWait {
+		if ngSubset, _ := ngFilter.MatchAll(cfg.NodeGroups); ngSubset.Len() > 0 {
+			return eksctlerrors.NewValidationError("--no-wait cannot be used with nodegroups, as they are created once the control plane is; " +
+				"use --without-nodegroup, and create nodegroups with 'eksctl create nodegroup' once 'eksctl utils wait cluster' returns")
+		}
+		var err error
+		if submittedPrinter, err = cmdutils.NewSubmittedOperationsPrinter(params.output); err != nil {
+			return err
+		}
+	}
+
+	if err := ngFilter.ForEach(cfg.NodeGroups, rejectProviderOverride); err != nil {
+		return err
+	}
+
+	if params.installClusterAutoscaler {
+		if cfg.AutoScaler == nil {
+			cfg.AutoScaler = &api.ClusterAutoScaler{}
+		}
+		cfg.AutoScaler.Install = api.Enabled()
+	}
+	if err := api.ValidateClusterAutoScaler(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if params.installNodeTerminationHandler != "" {
+		if cfg.NodeTerminationHandler == nil {
+			cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{}
+		}
+		cfg.NodeTerminationHandler.Install = api.Enabled()
+		cfg.NodeTerminationHandler.Mode = params.installNodeTerminationHandler
+	}
+	if err := api.ValidateNodeTerminationHandler(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	api.SetClusterStorageDefaults(cfg)
+	if err := api.ValidateClusterStorage(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateKubernetesNetworkConfig(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateContainerRuntime(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateProxy(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateNodeRoles(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateNodeGroupDependencies(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateLocalZones(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateOutpost(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateClusterEndpoints(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateControlPlaneIngressRules(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateSecurityGroupOverrides(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateVPCCNI(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateCloudWatchLogging(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateCloudWatchAlarms(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateCloudFormation(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateClusterIAM(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateHooks(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
 	printer := printers.NewJSONPrinter()
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
 
 	if !ctl.IsSupportedRegion() {
 		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
@@ -145,6 +276,10 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 	}
 	subnetsGiven := cfg.HasAnySubnets() // this will be false when neither flags nor config has any subnets
 
+	if cfg.IPv6Enabled() && (subnetsGiven || params.kopsClusterNameForVPC != "") {
+		return fmt.Errorf("kubernetesNetworkConfig.ipFamily %q is only supported with a dedicated VPC created by eksctl", api.IPV6Family)
+	}
+
 	createOrImportVPC := func() error {
 
 		subnetInfo := func() string {
@@ -163,12 +298,23 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 
 		if !subnetsGiven && params.kopsClusterNameForVPC == "" {
 			// default: create dedicated VPC
+			if cfg.Outpost != nil {
+				return fmt.Errorf("a control plane on an Outpost requires existing subnets in the Outpost, set them in vpc.subnets")
+			}
 			if err := ctl.SetAvailabilityZones(cfg, params.availabilityZones); err != nil {
 				return err
 			}
+			// Local Zones may have been given as availability zones
+			if err := api.ValidateLocalZones(cfg); err != nil {
+				return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+			}
 			if err := vpc.SetSubnets(cfg); err != nil {
 				return err
 			}
+			if cfg.IPv6Enabled() {
+				// IPv6 clusters need a dual-stack VPC
+				cfg.VPC.AutoAllocateIPv6 = api.Enabled()
+			}
 			return nil
 		}
 
@@ -199,7 +345,7 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 			}
 
 			logger.Success("using %s from kops cluster %q", subnetInfo(), params.kopsClusterNameForVPC)
-			logger.Warning(customNetworkingNotice)
+			logger.Warning("%s", customNetworkingNotice)
 			return nil
 		}
 
@@ -226,7 +372,7 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 		}
 
 		logger.Success("using existing %s", subnetInfo())
-		logger.Warning(customNetworkingNotice)
+		logger.Warning("%s", customNetworkingNotice)
 		return nil
 	}
 
@@ -234,7 +380,38 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 		return err
 	}
 
-	err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+	if err := vpc.ValidateSecurityGroups(ctl.Provider, cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	if params.showCostEstimate {
+		var nodeGroups []*api.NodeGroup
+		_ = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+			nodeGroups = append(nodeGroups, ng)
+			return nil
+		})
+		return showCostEstimate(ctl.Provider, cfg, nodeGroups)
+	}
+
+	if err := checkSubnetCapacity(ctl.Provider, cfg, ngFilter); err != nil {
+		return err
+	}
+	if err := checkZoneInstanceTypes(ctl.Provider, cfg, ngFilter); err != nil {
+		return err
+	}
+	if !params.skipQuotaChecks {
+		if err := checkServiceQuotas(ctl.Provider, cfg, ngFilter); err != nil {
+			return err
+		}
+	}
+	if cfg.IAM.ServiceRoleARN != "" {
+		if err := ctl.CheckServiceRole(cfg.IAM.ServiceRoleARN); err != nil {
+			return err
+		}
+		logger.Info("using existing service role %q", cfg.IAM.ServiceRoleARN)
+	}
+
+	err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
 		// resolve AMI
 		if err := ctl.EnsureAMI(meta.Version, ng); err != nil {
 			return err
@@ -249,7 +426,7 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 		// fingerprint, so if unique keys provided, each will get
 		// loaded and used as intended and there is no need to have
 		// nodegroup name in the key name
-		if err := loadSSHKey(ng, meta.Name, ctl.Provider); err != nil {
+		if err := ssh.LoadKeyForNodeGroup(ng, meta.Name, ctl.Provider); err != nil {
 			return err
 		}
 		return nil
@@ -279,10 +456,17 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 			logger.Info("will create a CloudFormation stack for cluster itself and %d nodegroup stack(s)", ngCount)
 		}
 		logger.Info("if you encounter any issues, check CloudFormation console or try 'eksctl utils describe-stacks --region=%s --name=%s'", meta.Region, meta.Name)
+		if err := hooks.Run(cfg, hooks.PreCreate, ""); err != nil {
+			return err
+		}
+		if params.noWait {
+			return submitCluster(stackManager, cfg, submittedPrinter)
+		}
 		tasks := stackManager.NewTasksToCreateClusterWithNodeGroups(ngSubset)
-		logger.Info(tasks.Describe())
+		logger.Info("%s", tasks.Describe())
 		if errs := tasks.DoAllSync(); len(errs) > 0 {
 			logger.Info("%d error(s) occurred and cluster hasn't been created properly, you may wish to check CloudFormation console", len(errs))
+			logger.Info("to resume, run the same command again: stacks that were created are kept and failed ones are created again")
 			logger.Info("to cleanup resources, run 'eksctl delete cluster --region=%s --name=%s'", meta.Region, meta.Name)
 			for _, err := range errs {
 				logger.Critical("%s\n", err.Error())
@@ -293,6 +477,33 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 
 	logger.Success("all EKS cluster resource for %q had been created", meta.Name)
 
+	if subnetsGiven || params.kopsClusterNameForVPC != "" {
+		// subnets of a dedicated VPC are tagged by its stack, existing
+		// subnets may lack the tags load balancers are placed with
+		if _, err := vpc.TagSubnets(ctl.Provider, cfg, false); err != nil {
+			logger.Warning("unable to tag subnets for load balancer discovery: %s", err.Error())
+			logger.Warning("Services of type LoadBalancer may not be provisioned, run 'eksctl utils tag-subnets --region=%s --name=%s' once the subnets can be tagged", meta.Region, meta.Name)
+		}
+	}
+
+	if logTypes := cfg.EnabledClusterLogTypes(); len(logTypes) > 0 {
+		logger.Info("enabling CloudWatch logging of control plane log types: %s", strings.Join(logTypes, ", "))
+		if err := ctl.UpdateClusterLogTypesBlocking(meta, logTypes, nil); err != nil {
+			return err
+		}
+	}
+
+	if cfg.HasAlarms() {
+		tasks := ctl.NewStackManager(cfg).NewTasksToCreateAlarms()
+		logger.Info("%s", tasks.Describe())
+		if errs := tasks.DoAllSync(); len(errs) > 0 {
+			for _, err := range errs {
+				logger.Critical("%s\n", err.Error())
+			}
+			return fmt.Errorf("failed to create alarms of cluster %q", meta.Name)
+		}
+	}
+
 	// obtain cluster credentials, write kubeconfig
 
 	{ // post-creation action
@@ -321,7 +532,22 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 			return err
 		}
 
+		if cfg.IPv6Enabled() {
+			if err := checkVPCCNIVersionForIPv6(clientSet); err != nil {
+				return err
+			}
+		}
+
+		if _, err := defaultaddons.ConfigureAWSNode(clientSet, cfg.VPCCNI, false); err != nil {
+			return errors.Wrap(err, "configuring VPC CNI")
+		}
+
+		stackManager := ctl.NewStackManager(cfg)
 		err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+			if err = stackManager.SuspendNodeGroupAZRebalance(ng); err != nil {
+				return err
+			}
+
 			// authorise nodes to join
 			if err = authconfigmap.AddNodeGroup(clientSet, ng); err != nil {
 				return err
@@ -332,6 +558,10 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 				return err
 			}
 
+			if err = hooks.Run(cfg, hooks.PostNodeGroupCreate, ng.Name); err != nil {
+				return err
+			}
+
 			// if GPU instance type, give instructions
 			if utils.IsGPUInstanceType(ng.InstanceType) || (ng.InstancesDistribution != nil && utils.HasGPUInstanceType(ng.InstancesDistribution.InstanceTypes)) {
 				logger.Info("as you are using a GPU optimized instance type you will need to install NVIDIA Kubernetes device plugin.")
@@ -344,6 +574,28 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 			return err
 		}
 
+		if err := installEFADevicePlugin(ctl, cfg, ngFilter); err != nil {
+			return err
+		}
+
+		if cfg.AutoScaler != nil && api.IsEnabled(cfg.AutoScaler.Install) {
+			if err := InstallClusterAutoscaler(ctl, cfg); err != nil {
+				return err
+			}
+		}
+
+		if err := installStorageDrivers(ctl, cfg); err != nil {
+			return err
+		}
+
+		if cfg.NodeTerminationHandler != nil && api.IsEnabled(cfg.NodeTerminationHandler.Install) {
+			if err := InstallNodeTerminationHandler(ctl, cfg); err != nil {
+				return err
+			}
+		} else if cfg.HasSpotNodeGroups() {
+			logger.Info("spot nodes will be interrupted without being drained; to drain them, use --install-node-termination-handler or nodeTerminationHandler.install")
+		}
+
 		// check kubectl version, and offer install instructions if missing or old
 		// also check heptio-authenticator
 		// TODO: https://github.com/weaveworks/eksctl/issues/30
@@ -359,9 +611,52 @@ func doCreateCluster(rc *cmdutils.ResourceCmd, params *createClusterCmdParams) e
 
 	logger.Success("%s is ready", meta.LogString())
 
+	if err := hooks.Run(cfg, hooks.PostCreate, ""); err != nil {
+		return err
+	}
+
 	if err := printer.LogObj(logger.Debug, "cfg.json = \\\n%s\n", cfg); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// submitCluster requests the creation of the cluster stack and prints it, post-creation
+// actions, such as writing kubeconfig, are left to the caller
+func submitCluster(stackManager *manager.StackCollection, cfg *api.ClusterConfig, printer printers.OutputPrinter) error {
+	meta := cfg.Metadata
+
+	stack, err := stackManager.SubmitClusterStack()
+	if err != nil {
+		return err
+	}
+
+	logger.Success("creation of cluster %q has been submitted", meta.Name)
+	logger.Info("once the cluster is active, run 'eksctl utils write-kubeconfig --region=%s --name=%s' to use it", meta.Region, meta.Name)
+
+	return cmdutils.PrintSubmittedOperations(printer, []*cmdutils.SubmittedOperation{{
+		Resource:    "cluster",
+		Name:        meta.Name,
+		Operation:   "create",
+		ID:          aws.StringValue(stack.StackId),
+		WaitCommand: fmt.Sprintf("eksctl utils wait cluster --region=%s --name=%s --for=active", meta.Region, meta.Name),
+	}})
+}
+
+func showCostEstimate(provider api.ClusterProvider, cfg *api.ClusterConfig, nodeGroups []*api.NodeGroup) error {
+	estimator, err := pricing.NewEstimator(provider.Pricing(), cfg.Metadata.Region)
+	if err != nil {
+		return err
+	}
+	estimate, err := estimator.Estimate(cfg, nodeGroups)
+	if err != nil {
+		return errors.Wrap(err, "estimating cost")
+	}
+	logger.Info("estimated monthly cost of cluster %q (on-demand prices, %d hours per month, excluding EBS volumes and data transfer)", cfg.Metadata.Name, pricing.HoursPerMonth)
+	if err := estimate.Write(os.Stdout); err != nil {
+		return err
+	}
+	logger.Info("no resources were created, re-run without --show-cost-estimate to create the cluster")
+	return nil
+}
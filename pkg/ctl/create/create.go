@@ -16,3 +16,15 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 
 	return verbCmd
 }
+
+// NewClusterCmd returns the `create cluster` command without registering it,
+// so that other commands, such as `apply`, can create clusters with it
+func NewClusterCmd() *cmdutils.ResourceCmd {
+	return cmdutils.NewResourceCmd(cmdutils.NewGrouping(), createClusterCmd)
+}
+
+// NewNodeGroupCmd returns the `create nodegroup` command without registering it,
+// so that other commands, such as `apply`, can create nodegroups with it
+func NewNodeGroupCmd() *cmdutils.ResourceCmd {
+	return cmdutils.NewResourceCmd(cmdutils.NewGrouping(), createNodeGroupCmd)
+}
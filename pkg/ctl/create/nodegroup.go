@@ -3,15 +3,21 @@ package create
 import (
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	"k8s.io/client-go/kubernetes"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/hooks"
 	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/ssh"
 	"github.com/weaveworks/eksctl/pkg/utils"
 )
 
@@ -20,14 +26,19 @@ func createNodeGroupCmd(rc *cmdutils.ResourceCmd) {
 	ng := cfg.NewNodeGroup()
 	rc.ClusterConfig = cfg
 
-	var updateAuthConfigMap bool
+	var (
+		updateAuthConfigMap bool
+		noWait              bool
+		output              string
+		force               bool
+	)
 
 	cfg.Metadata.Version = "auto"
 
 	rc.SetDescription("nodegroup", "Create a nodegroup", "", "ng")
 
 	rc.SetRunFuncWithNameArg(func() error {
-		return doCreateNodeGroups(rc, updateAuthConfigMap)
+		return doCreateNodeGroups(rc, updateAuthConfigMap, noWait, output, force)
 	})
 
 	exampleNodeGroupName := cmdutils.NodeGroupName("", "")
@@ -36,9 +47,12 @@ func createNodeGroupCmd(rc *cmdutils.ResourceCmd) {
 		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "name of the EKS cluster to add the nodegroup to")
 		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
 		cmdutils.AddVersionFlag(fs, cfg.Metadata, `for nodegroups "auto" and "latest" can be used to automatically inherit version from the control plane or force latest`)
-		cmdutils.AddConfigFileFlag(fs, &rc.ClusterConfigFile)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddNotifySNSTopicFlag(fs, rc, "create nodegroup")
 		cmdutils.AddNodeGroupFilterFlags(fs, &rc.IncludeNodeGroups, &rc.ExcludeNodeGroups)
 		cmdutils.AddUpdateAuthConfigMap(fs, &updateAuthConfigMap, "Remove nodegroup IAM role from aws-auth configmap")
+		cmdutils.AddNoWaitFlags(fs, &noWait, &output, "the creation of the nodegroup stacks")
+		cmdutils.AddVersionSkewForceFlag(fs, &force)
 	})
 
 	rc.FlagSetGroup.InFlagSet("New nodegroup", func(fs *pflag.FlagSet) {
@@ -53,7 +67,7 @@ func createNodeGroupCmd(rc *cmdutils.ResourceCmd) {
 	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
 }
 
-func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) error {
+func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap, noWait bool, output string, force bool) error {
 	ngFilter := cmdutils.NewNodeGroupFilter()
 
 	if err := cmdutils.NewCreateNodeGroupLoader(rc, ngFilter).Load(); err != nil {
@@ -66,9 +80,35 @@ func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) erro
 	if err := ngFilter.ValidateNodeGroupsAndSetDefaults(cfg.NodeGroups); err != nil {
 		return err
 	}
+	if err := api.ValidateContainerRuntime(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateProxy(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateNodeRoles(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateNodeGroupDependencies(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateHooks(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	var submittedPrinter printers.OutputPrinter
+	if noWait {
+		var err error
+		if submittedPrinter, err = cmdutils.NewSubmittedOperationsPrinter(output); err != nil {
+			return err
+		}
+	}
 
 	printer := printers.NewJSONPrinter()
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
 
 	if !ctl.IsSupportedRegion() {
 		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
@@ -97,12 +137,28 @@ func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) erro
 		return err
 	}
 
-	err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+	ngProviders, err := newNodeGroupProviders(rc, cfg, ngFilter)
+	if err != nil {
+		return err
+	}
+	for name, ngCtl := range ngProviders {
+		stackManager.SetNodeGroupProvider(name, ngCtl.Provider)
+	}
+
+	err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		ngCtl := ctl
+		if p, ok := ngProviders[ng.Name]; ok {
+			ngCtl = p
+		}
+
 		// resolve AMI
-		if err := ctl.EnsureAMI(meta.Version, ng); err != nil {
+		if err := ngCtl.EnsureAMI(meta.Version, ng); err != nil {
 			return err
 		}
 		logger.Info("nodegroup %q will use %q [%s/%s]", ng.Name, ng.AMI, ng.AMIFamily, cfg.Metadata.Version)
+		if err := cmdutils.CheckNodeGroupVersionSkew(ngCtl, ng, ctl.ControlPlaneVersion(), meta.Version, force); err != nil {
+			return err
+		}
 
 		if err := ctl.SetNodeLabels(ng, meta); err != nil {
 			return err
@@ -112,7 +168,7 @@ func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) erro
 		// fingerprint, so if unique keys provided, each will get
 		// loaded and used as intended and there is no need to have
 		// nodegroup name in the key name
-		if err := loadSSHKey(ng, meta.Name, ctl.Provider); err != nil {
+		if err := ssh.LoadKeyForNodeGroup(ng, meta.Name, ngCtl.Provider); err != nil {
 			return err
 		}
 		return nil
@@ -129,6 +185,13 @@ func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) erro
 		return errors.Wrap(err, "cluster compatibility check failed")
 	}
 
+	if err := checkSubnetCapacity(ctl.Provider, cfg, ngFilter); err != nil {
+		return err
+	}
+	if err := checkZoneInstanceTypes(ctl.Provider, cfg, ngFilter); err != nil {
+		return err
+	}
+
 	ngSubset, _ := ngFilter.MatchAll(cfg.NodeGroups)
 	ngCount := ngSubset.Len()
 
@@ -138,8 +201,12 @@ func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) erro
 			logger.Info("will create a CloudFormation stack for each of %d nodegroups in cluster %q", ngCount, cfg.Metadata.Name)
 		}
 
+		if noWait {
+			return submitNodeGroups(ctl, stackManager, cfg, ngFilter, updateAuthConfigMap, submittedPrinter)
+		}
+
 		tasks := stackManager.NewTasksToCreateNodeGroups(ngSubset)
-		logger.Info(tasks.Describe())
+		logger.Info("%s", tasks.Describe())
 		errs := tasks.DoAllSync()
 		if len(errs) > 0 {
 			logger.Info("%d error(s) occurred and nodegroups haven't been created properly, you may wish to check CloudFormation console", len(errs))
@@ -160,6 +227,10 @@ func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) erro
 		}
 
 		err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+			if err = stackManager.SuspendNodeGroupAZRebalance(ng); err != nil {
+				return err
+			}
+
 			if updateAuthConfigMap {
 				// authorise nodes to join
 				if err = authconfigmap.AddNodeGroup(clientSet, ng); err != nil {
@@ -178,17 +249,86 @@ func doCreateNodeGroups(rc *cmdutils.ResourceCmd, updateAuthConfigMap bool) erro
 				logger.Info("\t see the following page for instructions: https://github.com/NVIDIA/k8s-device-plugin")
 			}
 
-			return nil
+			return hooks.Run(cfg, hooks.PostNodeGroupCreate, ng.Name)
 		})
 		if err != nil {
 			return err
 		}
+
+		if err := installEFADevicePlugin(ctl, cfg, ngFilter); err != nil {
+			return err
+		}
 		logger.Success("created %d nodegroup(s) in cluster %q", ngCount, cfg.Metadata.Name)
 	}
 
 	if err := ctl.ValidateExistingNodeGroupsForCompatibility(cfg, stackManager); err != nil {
-		logger.Critical("failed checking nodegroups", err.Error())
+		logger.Critical("failed checking nodegroups: %s", err.Error())
 	}
 
 	return nil
 }
+
+// submitNodeGroups requests the creation of the nodegroup stacks and prints them; the
+// instance roles of the nodegroups can only be authorised to join before the stacks
+// are created when they are given as instanceRoleARN or shared as a node role
+func submitNodeGroups(ctl *eks.ClusterProvider, stackManager *manager.StackCollection, cfg *api.ClusterConfig, ngFilter *cmdutils.NodeGroupFilter,
+	updateAuthConfigMap bool, printer printers.OutputPrinter) error {
+	meta := cfg.Metadata
+
+	var clientSet kubernetes.Interface
+	if updateAuthConfigMap {
+		var err error
+		if clientSet, err = ctl.NewStdClientSet(cfg); err != nil {
+			return err
+		}
+	}
+
+	usesNodeRoles := false
+	_ = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		if ng.IAM.NodeRole != "" {
+			usesNodeRoles = true
+		}
+		return nil
+	})
+	if usesNodeRoles {
+		// shared instance roles are needed by the nodegroup stacks, so they are
+		// created first, which also sets their ARNs on the nodegroups
+		tasks := stackManager.NewTasksToCreateNodeRoles()
+		logger.Info("%s", tasks.Describe())
+		if errs := tasks.DoAllSync(); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+
+	operations := []*cmdutils.SubmittedOperation{}
+	err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		stack, err := stackManager.SubmitNodeGroupStack(ng)
+		if err != nil {
+			return err
+		}
+		operations = append(operations, &cmdutils.SubmittedOperation{
+			Resource:    "nodegroup",
+			Name:        ng.Name,
+			Operation:   "create",
+			ID:          aws.StringValue(stack.StackId),
+			WaitCommand: fmt.Sprintf("eksctl utils wait nodegroup --region=%s --cluster=%s --name=%s --for=created", meta.Region, meta.Name, ng.Name),
+		})
+
+		if !updateAuthConfigMap {
+			return nil
+		}
+		if ng.IAM.InstanceRoleARN == "" {
+			logger.Warning("nodes of nodegroup %q won't join the cluster until its instance role is added to the aws-auth ConfigMap, "+
+				"once its stack is created run 'eksctl create iamidentitymapping' with the role from 'eksctl utils describe-stacks'", ng.Name)
+			return nil
+		}
+		return authconfigmap.AddNodeGroup(clientSet, ng)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Success("creation of %d nodegroup(s) in cluster %q has been submitted", len(operations), meta.Name)
+
+	return cmdutils.PrintSubmittedOperations(printer, operations)
+}
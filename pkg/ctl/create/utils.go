@@ -2,21 +2,130 @@ package create
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/blang/semver"
 	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
 
+	"github.com/weaveworks/eksctl/pkg/addons/clusterautoscaler"
+	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
+	"github.com/weaveworks/eksctl/pkg/addons/efa"
+	"github.com/weaveworks/eksctl/pkg/addons/efscsi"
+	"github.com/weaveworks/eksctl/pkg/addons/fsxcsi"
+	"github.com/weaveworks/eksctl/pkg/addons/nodeterminationhandler"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/az"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
-	"github.com/weaveworks/eksctl/pkg/ssh"
-	"github.com/weaveworks/eksctl/pkg/utils/file"
+	"github.com/weaveworks/eksctl/pkg/pricing"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/quotas"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+	"github.com/weaveworks/eksctl/pkg/wizard"
 )
 
 func checkSubnetsGivenAsFlags(params *createClusterCmdParams) bool {
 	return len(*params.subnets[api.SubnetTopologyPrivate])+len(*params.subnets[api.SubnetTopologyPublic]) != 0
 }
 
+// checkSubnetCapacity makes sure that existing subnets have enough free
+// IP addresses for the nodegroups that are about to be created
+func checkSubnetCapacity(provider api.ClusterProvider, cfg *api.ClusterConfig, ngFilter *cmdutils.NodeGroupFilter) error {
+	var nodeGroups []*api.NodeGroup
+	_ = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		nodeGroups = append(nodeGroups, ng)
+		return nil
+	})
+	usages, err := vpc.GetNodeGroupsSubnetUsage(provider, cfg, nodeGroups)
+	if err != nil {
+		return errors.Wrap(err, "checking free IP addresses in subnets")
+	}
+	return vpc.ValidateSubnetCapacity(usages)
+}
+
+// checkServiceQuotas makes sure that the cluster and its nodegroups fit in the
+// service quotas of the account, so that creation fails before any stack is created
+func checkServiceQuotas(provider api.ClusterProvider, cfg *api.ClusterConfig, ngFilter *cmdutils.NodeGroupFilter) error {
+	var nodeGroups []*api.NodeGroup
+	_ = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		nodeGroups = append(nodeGroups, ng)
+		return nil
+	})
+	if err := quotas.Validate(cfg.Metadata.Region, quotas.Check(provider, cfg, nodeGroups)); err != nil {
+		logger.Info("if the quotas of your account were already increased, use --skip-quota-checks")
+		return err
+	}
+	return nil
+}
+
+// checkZoneInstanceTypes makes sure the instance types of nodegroups in Local Zones
+// are offered there, as they only provide a few instance types; the instance types
+// of an Outpost depend on its capacity and are not checked
+func checkZoneInstanceTypes(provider api.ClusterProvider, cfg *api.ClusterConfig, ngFilter *cmdutils.NodeGroupFilter) error {
+	var offerings az.OfferingsAPI
+	return ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		if len(ng.LocalZones) == 0 {
+			return nil
+		}
+		if offerings == nil {
+			var err error
+			if offerings, err = az.NewOfferingsAPI(provider.EC2()); err != nil {
+				logger.Debug("not checking instance types offered in Local Zones: %s", err.Error())
+				return nil
+			}
+		}
+		instanceTypes := []string{ng.InstanceType}
+		if api.HasMixedInstances(ng) {
+			instanceTypes = ng.InstancesDistribution.InstanceTypes
+		}
+		if err := az.ValidateInstanceTypeOfferings(offerings, ng.LocalZones, instanceTypes); err != nil {
+			return errors.Wrapf(err, "checking instance types of nodegroup %q", ng.Name)
+		}
+		return nil
+	})
+}
+
+// newNodeGroupProviders creates the AWS APIs for the nodegroups created in other accounts,
+// and checks that the VPC of the cluster can be used from their accounts
+func newNodeGroupProviders(rc *cmdutils.ResourceCmd, cfg *api.ClusterConfig, ngFilter *cmdutils.NodeGroupFilter) (map[string]*eks.ClusterProvider, error) {
+	providers := map[string]*eks.ClusterProvider{}
+	err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		if ng.ProviderOverride == nil {
+			return nil
+		}
+		ngCtl, err := eks.NewForNodeGroup(rc.ProviderConfig, cfg, ng)
+		if err != nil {
+			return err
+		}
+		if err := ngCtl.CheckAuth(); err != nil {
+			return errors.Wrapf(err, "checking AWS credentials of nodegroup %q", ng.Name)
+		}
+		if err := vpc.ValidateNodeGroupAccess(ngCtl.Provider, cfg, ng); err != nil {
+			return err
+		}
+		logger.Info("nodegroup %q will be created with the provider override (profile: %q, role: %q)", ng.Name, ng.ProviderOverride.Profile, ng.ProviderOverride.RoleARN)
+		providers[ng.Name] = ngCtl
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return providers, nil
+}
+
+// rejectProviderOverride fails for nodegroups created in other accounts, as they can
+// only use the VPC of the cluster once it's been created and shared with them
+func rejectProviderOverride(_ int, ng *api.NodeGroup) error {
+	if ng.ProviderOverride != nil {
+		return fmt.Errorf("nodegroup %q has a provider override, nodegroups in other accounts can only be added to an existing cluster; "+
+			"exclude it with --exclude=%s and create it with 'eksctl create nodegroup' afterwards", ng.Name, ng.Name)
+	}
+	return nil
+}
+
 func checkVersion(rc *cmdutils.ResourceCmd, ctl *eks.ClusterProvider, meta *api.ClusterMeta) error {
 	switch meta.Version {
 	case "auto":
@@ -72,51 +181,170 @@ func isDeprecatedVersion(version string) bool {
 	return false
 }
 
-// loadSSHKey loads the ssh public key specified in the NodeGroup. The key should be specified
-// in only one way: by name (for a key existing in EC2), by path (for a key in a local file)
-// or by its contents (in the config-file). It also assumes that if ssh is enabled (SSH.Allow
-// == true) then one key was specified
-func loadSSHKey(ng *api.NodeGroup, clusterName string, provider api.ClusterProvider) error {
-	sshConfig := ng.SSH
-	if sshConfig.Allow == nil || *sshConfig.Allow == false {
+// InstallClusterAutoscaler deploys Cluster Autoscaler, it's also used by `apply` to install
+// it on existing clusters
+func InstallClusterAutoscaler(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) error {
+	if !cfg.HasAutoScalerNodeGroups() {
+		logger.Warning("not installing Cluster Autoscaler, as none of the nodegroups have autoScaler addon policy enabled; use --asg-access or iam.withAddonPolicies.autoScaler")
 		return nil
 	}
 
-	switch {
+	rawClient, err := ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
 
-	// Load Key by content
-	case sshConfig.PublicKey != nil:
-		keyName, err := ssh.LoadKeyByContent(sshConfig.PublicKey, clusterName, ng.Name, provider)
-		if err != nil {
-			return err
+	controlPlaneVersion, err := rawClient.ServerVersion()
+	if err != nil {
+		return err
+	}
+
+	if err := clusterautoscaler.Deploy(rawClient, cfg, controlPlaneVersion); err != nil {
+		return errors.Wrap(err, "installing Cluster Autoscaler")
+	}
+	return nil
+}
+
+// InstallNodeTerminationHandler deploys Node Termination Handler, in queue mode the queue
+// must already be created, which happens along with the cluster
+func InstallNodeTerminationHandler(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) error {
+	if !cfg.HasSpotNodeGroups() {
+		logger.Warning("not installing Node Termination Handler, as none of the nodegroups use spot instances; use instancesDistribution.onDemandPercentageAboveBaseCapacity below 100")
+		return nil
+	}
+
+	rawClient, err := ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := nodeterminationhandler.Deploy(rawClient, cfg); err != nil {
+		return errors.Wrap(err, "installing Node Termination Handler")
+	}
+	return nil
+}
+
+// installStorageDrivers installs CSI drivers of the file systems that were created
+// along with the cluster, each with a StorageClass that is ready to use in claims
+func installStorageDrivers(ctl *eks.ClusterProvider, cfg *api.ClusterConfig) error {
+	if !cfg.HasEFS() && !cfg.HasFSx() {
+		return nil
+	}
+
+	rawClient, err := ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	if cfg.HasEFS() {
+		if _, err := efscsi.Deploy(rawClient, cfg, false); err != nil {
+			return errors.Wrap(err, "installing EFS CSI driver")
+		}
+		logger.Success("EFS file system %q is available as persistent volume %q of StorageClass %q", cfg.Storage.EFS.ID, efscsi.PersistentVolumeName, efscsi.StorageClassName)
+	}
+	if cfg.HasFSx() {
+		if _, err := fsxcsi.Deploy(rawClient, cfg, false); err != nil {
+			return errors.Wrap(err, "installing FSx CSI driver")
 		}
-		sshConfig.PublicKeyName = &keyName
+		logger.Success("FSx file system %q is available as persistent volume %q of StorageClass %q", cfg.Storage.FSx.ID, fsxcsi.PersistentVolumeName, fsxcsi.StorageClassName)
+	}
+	return nil
+}
 
-	// Use key by name in EC2
-	case sshConfig.PublicKeyName != nil && *sshConfig.PublicKeyName != "":
-		if err := ssh.CheckKeyExistsInEC2(*sshConfig.PublicKeyName, provider); err != nil {
-			return err
+func installEFADevicePlugin(ctl *eks.ClusterProvider, cfg *api.ClusterConfig, ngFilter *cmdutils.NodeGroupFilter) error {
+	efaEnabled := false
+	err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		if api.IsEnabled(ng.EFAEnabled) {
+			efaEnabled = true
 		}
-		logger.Info("using EC2 key pair %q", *sshConfig.PublicKeyName)
+		return nil
+	})
+	if err != nil || !efaEnabled {
+		return err
+	}
+
+	rawClient, err := ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	if err := efa.DeployDevicePlugin(rawClient, cfg); err != nil {
+		return errors.Wrap(err, "installing EFA device plugin")
+	}
+	return nil
+}
+
+// checkVPCCNIVersionForIPv6 makes sure the VPC CNI plugin that runs in the
+// cluster is able to assign IPv6 addresses to pods
+func checkVPCCNIVersionForIPv6(clientSet kubernetes.Interface) error {
+	version, err := defaultaddons.AWSNodeVersion(clientSet)
+	if err != nil {
+		return err
+	}
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return errors.Wrapf(err, "parsing %q version", defaultaddons.AWSNode)
+	}
+	if v.LT(semver.MustParse(api.IPv6MinimumVPCCNIVersion)) {
+		return fmt.Errorf("%q version %s doesn't support IPv6, version %s or above is required", defaultaddons.AWSNode, version, api.IPv6MinimumVPCCNIVersion)
+	}
+	return nil
+}
+
+// runWizard asks for the settings of the cluster and its initial nodegroup, then
+// prints the equivalent config file and asks for confirmation to create the cluster
+func runWizard(rc *cmdutils.ResourceCmd, withoutNodeGroup bool) (bool, error) {
+	cfg := rc.ClusterConfig
+	var ng *api.NodeGroup
+	if !withoutNodeGroup && len(cfg.NodeGroups) > 0 {
+		ng = cfg.NodeGroups[0]
+	}
+	if cfg.Metadata.Region == "" {
+		cfg.Metadata.Region = rc.ProviderConfig.Region
+	}
+
+	w := wizard.New(wizard.NewPrompter(os.Stdin, os.Stdout), newWizardChecks(rc.ProviderConfig))
+	if err := w.Run(cfg, ng); err != nil {
+		return false, err
+	}
+	rc.ProviderConfig.Region = cfg.Metadata.Region
 
-	// Local ssh key file
-	case file.Exists(*sshConfig.PublicKeyPath):
-		keyName, err := ssh.LoadKeyFromFile(*sshConfig.PublicKeyPath, clusterName, ng.Name, provider)
+	config := cfg.DeepCopy()
+	if ng == nil {
+		config.NodeGroups = nil
+	}
+	w.Printf("\nthe cluster will be created with the following config file, which can be used with --config-file:\n\n")
+	if err := printers.NewYAMLPrinter().PrintObj(config, os.Stdout); err != nil {
+		return false, err
+	}
+	return w.Confirm("\nCreate the cluster?")
+}
+
+// newWizardChecks returns checks of instance types that use the AWS APIs of the selected region
+func newWizardChecks(provider *api.ProviderConfig) func(string) (*wizard.Checks, error) {
+	return func(region string) (*wizard.Checks, error) {
+		regionProvider := *provider
+		regionProvider.Region = region
+		ctl, err := eks.New(&regionProvider, nil)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		sshConfig.PublicKeyName = &keyName
-
-	// A keyPath, when specified as a flag, can mean a local key (checked above) or a key name in EC2
-	default:
-		err := ssh.CheckKeyExistsInEC2(*sshConfig.PublicKeyPath, provider)
+		if err := ctl.CheckAuth(); err != nil {
+			return nil, err
+		}
+		offerings, err := az.NewOfferingsAPI(ctl.Provider.EC2())
 		if err != nil {
-			return err
+			return nil, err
+		}
+		estimator, err := pricing.NewEstimator(ctl.Provider.Pricing(), region)
+		if err != nil {
+			return nil, err
 		}
-		sshConfig.PublicKeyName = sshConfig.PublicKeyPath
-		sshConfig.PublicKeyPath = nil
-		logger.Info("using EC2 key pair %q", *ng.SSH.PublicKeyName)
+		return &wizard.Checks{
+			InstanceTypeOffered: func(instanceType string) (bool, error) {
+				return az.IsInstanceTypeOfferedInRegion(offerings, region, instanceType)
+			},
+			EstimateCost: estimator.Estimate,
+		}, nil
 	}
-
-	return nil
 }
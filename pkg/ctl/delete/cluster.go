@@ -3,21 +3,20 @@ package delete
 import (
 	"context"
 	"fmt"
-	"time"
+	"strconv"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 
+	"github.com/weaveworks/eksctl/pkg/actions"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
-	"github.com/weaveworks/eksctl/pkg/cfn/manager"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
-	"github.com/weaveworks/eksctl/pkg/eks"
-	"github.com/weaveworks/eksctl/pkg/elb"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/hooks"
 	"github.com/weaveworks/eksctl/pkg/printers"
-	"github.com/weaveworks/eksctl/pkg/ssh"
 	"github.com/weaveworks/eksctl/pkg/utils/kubeconfig"
-	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
 func deleteClusterCmd(rc *cmdutils.ResourceCmd) {
@@ -26,8 +25,19 @@ func deleteClusterCmd(rc *cmdutils.ResourceCmd) {
 
 	rc.SetDescription("cluster", "Delete a cluster", "")
 
+	var (
+		disableProtection, deleteAllDependents, onlyMissing, approve, noWait bool
+		output                                                               string
+	)
+
 	rc.SetRunFuncWithNameArg(func() error {
-		return doDeleteCluster(rc)
+		if onlyMissing {
+			return doDeleteMissingNodeGroups(rc, approve, deleteAllDependents, noWait, output)
+		}
+		if approve {
+			return eksctlerrors.NewValidationError("--approve can only be used with --only-missing")
+		}
+		return doDeleteCluster(rc, disableProtection, deleteAllDependents, noWait, output)
 	})
 
 	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -36,8 +46,15 @@ func deleteClusterCmd(rc *cmdutils.ResourceCmd) {
 
 		rc.Wait = false
 		cmdutils.AddWaitFlag(fs, &rc.Wait, "deletion of all resources")
+		cmdutils.AddNoWaitFlags(fs, &noWait, &output, "the deletion of the cluster stack")
+
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddNotifySNSTopicFlag(fs, rc, "delete cluster")
 
-		cmdutils.AddConfigFileFlag(fs, &rc.ClusterConfigFile)
+		fs.BoolVar(&disableProtection, "disable-protection", false, "Turn off termination protection of cluster stacks before deleting them")
+		fs.BoolVar(&deleteAllDependents, "delete-all-dependents", false, "Also delete resources that outlive the cluster stacks, i.e. the CloudWatch log group of control plane logs and the IAM OIDC provider")
+		fs.BoolVar(&onlyMissing, "only-missing", false, "Only delete nodegroups that are not defined in the given config file, the cluster itself is not deleted")
+		fs.BoolVar(&approve, "approve", false, "Apply the deletion of nodegroups with --only-missing, otherwise it's only planned")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
@@ -51,105 +68,128 @@ func handleErrors(errs []error, subject string) error {
 	return fmt.Errorf("failed to delete %s", subject)
 }
 
-func deleteDeprecatedStacks(stackManager *manager.StackCollection) (bool, error) {
-	tasks, err := stackManager.DeleteTasksForDeprecatedStacks()
-	if err != nil {
-		return true, err
-	}
-	if count := tasks.Len(); count > 0 {
-		logger.Info(tasks.Describe())
-		if errs := tasks.DoAllSync(); len(errs) > 0 {
-			return true, handleErrors(errs, "deprecated stacks")
-		}
-		logger.Success("deleted all %s deperecated stacks", count)
-		return true, nil
-	}
-	return false, nil
-}
-
-func doDeleteCluster(rc *cmdutils.ResourceCmd) error {
+func doDeleteCluster(rc *cmdutils.ResourceCmd, disableProtection, deleteAllDependents, noWait bool, output string) error {
 	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
 		return err
 	}
 
+	var submittedPrinter printers.OutputPrinter
+	if noWait {
+		if rc.Wait {
+			return eksctlerrors.NewValidationError("--wait and --no-wait %s", cmdutils.IncompatibleFlags)
+		}
+		var err error
+		if submittedPrinter, err = cmdutils.NewSubmittedOperationsPrinter(output); err != nil {
+			return err
+		}
+	}
+
 	cfg := rc.ClusterConfig
 	meta := rc.ClusterConfig.Metadata
 
-	printer := printers.NewJSONPrinter()
-	ctl := eks.New(rc.ProviderConfig, cfg)
-
-	if !ctl.IsSupportedRegion() {
-		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	if err := api.ValidateHooks(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
 	}
-	logger.Info("using region %s", meta.Region)
 
-	if err := ctl.CheckAuth(); err != nil {
+	printer := printers.NewJSONPrinter()
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
 		return err
 	}
+	logger.Info("using region %s", meta.Region)
 
 	logger.Info("deleting EKS cluster %q", meta.Name)
 	if err := printer.LogObj(logger.Debug, "cfg.json = \\\n%s\n", cfg); err != nil {
 		return err
 	}
 
-	stackManager := ctl.NewStackManager(cfg)
-
-	ssh.DeleteKeys(meta.Name, ctl.Provider)
-
-	kubeconfig.MaybeDeleteConfig(meta)
-
-	if hasDeprectatedStacks, err := deleteDeprecatedStacks(stackManager); hasDeprectatedStacks {
-		if err != nil {
-			return err
-		}
-		return nil
+	if err := hooks.Run(cfg, hooks.PreDelete, ""); err != nil {
+		return err
 	}
 
-	{
-
-		logger.Info("cleaning up LoadBalancer services")
-		if err := ctl.GetCredentials(cfg); err != nil {
+	submitted := []*cmdutils.SubmittedOperation{}
+	if noWait {
+		// the ID is that of the cluster stack as it is before being deleted
+		stack, err := m.StackManager().DescribeClusterStack()
+		if err != nil && eksctlerrors.ClassOf(err) != eksctlerrors.ClassNotFound {
 			return err
 		}
-		cs, err := ctl.NewStdClientSet(cfg)
-		if err != nil {
-			return err
-		}
-		ctx, cleanup := context.WithTimeout(context.Background(), 10*time.Minute)
-		defer cleanup()
-		if err := elb.Cleanup(ctx, ctl.Provider.EC2(), ctl.Provider.ELB(), ctl.Provider.ELBV2(), cs, cfg); err != nil {
-			return err
+		if stack != nil {
+			submitted = append(submitted, &cmdutils.SubmittedOperation{
+				Resource:    "cluster",
+				Name:        meta.Name,
+				Operation:   "delete",
+				ID:          aws.StringValue(stack.StackId),
+				WaitCommand: fmt.Sprintf("eksctl utils wait cluster --region=%s --name=%s --for=deleted", meta.Region, meta.Name),
+			})
 		}
+	}
 
-		tasks, err := stackManager.NewTasksToDeleteClusterWithNodeGroups(rc.Wait, func(errs chan error, _ string) error {
-			logger.Info("trying to cleanup dangling network interfaces")
-			if err := ctl.GetClusterVPC(cfg); err != nil {
-				return errors.Wrapf(err, "getting VPC configuration for cluster %q", cfg.Metadata.Name)
-			}
+	if err := deleteCluster(m, actions.DeleteClusterOptions{
+		Wait:                rc.Wait,
+		DisableProtection:   disableProtection,
+		DeleteAllDependents: deleteAllDependents,
+	}); err != nil {
+		return err
+	}
 
-			go func() {
-				errs <- vpc.CleanupNetworkInterfaces(ctl.Provider.EC2(), cfg)
-				close(errs)
-			}()
-			return nil
-		})
+	kubeconfig.MaybeDeleteConfig(meta)
 
-		if err != nil {
-			return err
-		}
+	if noWait {
+		return cmdutils.PrintSubmittedOperations(submittedPrinter, submitted)
+	}
+	return nil
+}
 
-		if tasks.Len() == 0 {
-			logger.Warning("no cluster resources were found for %q", meta.Name)
-			return nil
-		}
+// deleteCluster deletes the cluster of m, when the cluster is protected
+// it tells how to turn off the protection
+func deleteCluster(m *actions.Manager, opts actions.DeleteClusterOptions) error {
+	err := m.DeleteCluster(context.Background(), opts)
+	if _, ok := errors.Cause(err).(*actions.ErrDeletionProtected); ok {
+		return eksctlerrors.NewValidationError("%s, use --disable-protection to turn it off and delete the cluster", err)
+	}
+	return err
+}
 
-		logger.Info(tasks.Describe())
-		if errs := tasks.DoAllSync(); len(errs) > 0 {
-			return handleErrors(errs, "cluster with nodegroup(s)")
-		}
+// doDeleteMissingNodeGroups deletes the nodegroups that exist in AWS but are no longer
+// defined in the config file, as `delete nodegroup --only-missing` does, so that
+// nodegroups can be removed declaratively without touching the control plane
+func doDeleteMissingNodeGroups(rc *cmdutils.ResourceCmd, approve, deleteAllDependents, noWait bool, output string) error {
+	flags, err := deleteMissingNodeGroupsFlags(rc, approve, deleteAllDependents, noWait, output)
+	if err != nil {
+		return err
+	}
 
-		logger.Success("all cluster resources were deleted")
+	ngCmd := NewNodeGroupCmd()
+	*ngCmd.ProviderConfig = *rc.ProviderConfig
+	return ngCmd.RunWithFlags(flags)
+}
+
+// deleteMissingNodeGroupsFlags maps the flags of `delete cluster --only-missing` to those
+// of `delete nodegroup`; --all-clusters isn't mapped, as delete cluster itself is run for
+// each cluster with --cluster-name set, and --disable-protection is accepted but has no
+// effect, as the stacks of nodegroups aren't protected
+func deleteMissingNodeGroupsFlags(rc *cmdutils.ResourceCmd, approve, deleteAllDependents, noWait bool, output string) (map[string]string, error) {
+	if rc.ClusterConfigFile == "" {
+		return nil, cmdutils.ErrMustBeSet("--config-file")
+	}
+	if deleteAllDependents {
+		return nil, eksctlerrors.NewValidationError("--only-missing and --delete-all-dependents %s", cmdutils.IncompatibleFlags)
 	}
 
-	return nil
+	flags := map[string]string{
+		"config-file":  rc.ClusterConfigFile,
+		"only-missing": "true",
+		"approve":      strconv.FormatBool(approve),
+		"wait":         strconv.FormatBool(rc.Wait),
+	}
+	if rc.ClusterConfigName != "" {
+		flags["cluster-name"] = rc.ClusterConfigName
+	}
+	if noWait {
+		flags["no-wait"] = "true"
+		flags["output"] = output
+	}
+	return flags, nil
 }
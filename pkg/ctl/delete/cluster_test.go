@@ -0,0 +1,161 @@
+package delete
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("delete cluster", func() {
+	var (
+		p *mockprovider.MockProvider
+		m *actions.Manager
+	)
+
+	mockStacks := func(protected bool) {
+		p.MockCloudFormation().On("ListStacksPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			consume := args[1].(func(p *cfn.ListStacksOutput, last bool) (shouldContinue bool))
+			consume(&cfn.ListStacksOutput{
+				StackSummaries: []*cfn.StackSummary{{StackName: aws.String("eksctl-test-cluster-cluster")}},
+			}, true)
+		}).Return(nil)
+		p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(&cfn.DescribeStacksOutput{
+			Stacks: []*cfn.Stack{
+				{
+					StackName:                   aws.String("eksctl-test-cluster-cluster"),
+					StackStatus:                 aws.String(cfn.StackStatusCreateComplete),
+					EnableTerminationProtection: aws.Bool(protected),
+				},
+			},
+		}, nil)
+		p.MockCloudFormation().On("UpdateTerminationProtection", mock.Anything).Return(&cfn.UpdateTerminationProtectionOutput{}, nil)
+
+		// the deletion is stopped when the control plane is described
+		p.MockEC2().On("DescribeKeyPairs", mock.Anything).Return(&ec2.DescribeKeyPairsOutput{}, nil)
+		p.MockEKS().On("DescribeCluster", mock.Anything).Return(nil, fmt.Errorf("control plane unavailable"))
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.Metadata.Region = p.Region()
+
+		m = actions.NewFromClusterProvider(&eks.ClusterProvider{Provider: p}, cfg)
+	})
+
+	It("should suggest --disable-protection for a protected cluster", func() {
+		mockStacks(true)
+
+		err := deleteCluster(m, actions.DeleteClusterOptions{})
+		Expect(err).To(MatchError(`cluster "test-cluster" has deletion protection enabled on 1 stack(s), use --disable-protection to turn it off and delete the cluster`))
+		Expect(eksctlerrors.ClassOf(err)).To(Equal(eksctlerrors.ClassValidation))
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 0)).To(BeTrue())
+	})
+
+	It("should delete a protected cluster with --disable-protection", func() {
+		mockStacks(true)
+
+		err := deleteCluster(m, actions.DeleteClusterOptions{DisableProtection: true})
+		Expect(err).To(MatchError(ContainSubstring("control plane unavailable")))
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 1)).To(BeTrue())
+	})
+
+	It("should delete an unprotected cluster", func() {
+		mockStacks(false)
+
+		err := deleteCluster(m, actions.DeleteClusterOptions{})
+		Expect(err).To(MatchError(ContainSubstring("control plane unavailable")))
+		Expect(eksctlerrors.ClassOf(err)).ToNot(Equal(eksctlerrors.ClassValidation))
+		Expect(p.MockCloudFormation().AssertNumberOfCalls(GinkgoT(), "UpdateTerminationProtection", 0)).To(BeTrue())
+	})
+})
+
+var _ = Describe("delete cluster --only-missing", func() {
+	It("should map its flags to those of delete nodegroup", func() {
+		rc := cmdutils.NewResourceCmd(cmdutils.NewGrouping(), deleteClusterCmd)
+		Expect(rc.Command.Flags().Set("config-file", "fleet.yaml")).To(Succeed())
+		Expect(rc.Command.Flags().Set("cluster-name", "cluster-1")).To(Succeed())
+		Expect(rc.Command.Flags().Set("wait", "true")).To(Succeed())
+
+		flags, err := deleteMissingNodeGroupsFlags(rc, true, false, false, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flags).To(Equal(map[string]string{
+			"config-file":  "fleet.yaml",
+			"cluster-name": "cluster-1",
+			"only-missing": "true",
+			"approve":      "true",
+			"wait":         "true",
+		}))
+
+		ngCmd := NewNodeGroupCmd()
+		for name := range flags {
+			Expect(ngCmd.Command.Flags().Lookup(name)).ToNot(BeNil(), name)
+		}
+
+		flags, err = deleteMissingNodeGroupsFlags(rc, false, false, false, "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flags).To(HaveKeyWithValue("approve", "false"))
+		Expect(flags).ToNot(HaveKey("no-wait"))
+
+		flags, err = deleteMissingNodeGroupsFlags(rc, true, false, true, "json")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(flags).To(HaveKeyWithValue("no-wait", "true"))
+		Expect(flags).To(HaveKeyWithValue("output", "json"))
+		for name := range flags {
+			Expect(ngCmd.Command.Flags().Lookup(name)).ToNot(BeNil(), name)
+		}
+	})
+
+	It("should require a config file", func() {
+		rc := cmdutils.NewResourceCmd(cmdutils.NewGrouping(), deleteClusterCmd)
+		err := rc.RunWithFlags(map[string]string{"only-missing": "true"})
+		Expect(err).To(MatchError(cmdutils.ErrMustBeSet("--config-file").Error()))
+	})
+
+	It("should reject --delete-all-dependents", func() {
+		rc := cmdutils.NewResourceCmd(cmdutils.NewGrouping(), deleteClusterCmd)
+		err := rc.RunWithFlags(map[string]string{
+			"config-file":           "fleet.yaml",
+			"only-missing":          "true",
+			"delete-all-dependents": "true",
+		})
+		Expect(err).To(MatchError("--only-missing and --delete-all-dependents " + cmdutils.IncompatibleFlags))
+		Expect(eksctlerrors.ClassOf(err)).To(Equal(eksctlerrors.ClassValidation))
+	})
+
+	It("should accept --disable-protection", func() {
+		rc := cmdutils.NewResourceCmd(cmdutils.NewGrouping(), deleteClusterCmd)
+		Expect(rc.Command.Flags().Set("disable-protection", "true")).To(Succeed())
+		Expect(rc.Command.Flags().Set("config-file", "fleet.yaml")).To(Succeed())
+
+		_, err := deleteMissingNodeGroupsFlags(rc, true, false, false, "")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should reject --wait with --no-wait", func() {
+		rc := cmdutils.NewResourceCmd(cmdutils.NewGrouping(), deleteClusterCmd)
+		err := rc.RunWithFlags(map[string]string{"name": "cluster-1", "wait": "true", "no-wait": "true"})
+		Expect(err).To(MatchError("--wait and --no-wait " + cmdutils.IncompatibleFlags))
+		Expect(eksctlerrors.ClassOf(err)).To(Equal(eksctlerrors.ClassValidation))
+	})
+
+	It("should reject --approve without --only-missing", func() {
+		rc := cmdutils.NewResourceCmd(cmdutils.NewGrouping(), deleteClusterCmd)
+		err := rc.RunWithFlags(map[string]string{"name": "cluster-1", "approve": "true"})
+		Expect(err).To(MatchError("--approve can only be used with --only-missing"))
+	})
+})
@@ -16,3 +16,9 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 
 	return verbCmd
 }
+
+// NewNodeGroupCmd returns the `delete nodegroup` command without registering it,
+// so that other commands, such as `apply`, can delete nodegroups with it
+func NewNodeGroupCmd() *cmdutils.ResourceCmd {
+	return cmdutils.NewResourceCmd(cmdutils.NewGrouping(), deleteNodeGroupCmd)
+}
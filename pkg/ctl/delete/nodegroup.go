@@ -1,6 +1,9 @@
 package delete
 
 import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
@@ -10,6 +13,8 @@ import (
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/drain"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 func deleteNodeGroupCmd(rc *cmdutils.ResourceCmd) {
@@ -17,19 +22,23 @@ func deleteNodeGroupCmd(rc *cmdutils.ResourceCmd) {
 	ng := cfg.NewNodeGroup()
 	rc.ClusterConfig = cfg
 
-	var updateAuthConfigMap, deleteNodeGroupDrain, onlyMissing bool
+	var (
+		updateAuthConfigMap, deleteNodeGroupDrain, onlyMissing, noWait bool
+		output                                                         string
+	)
 
 	rc.SetDescription("nodegroup", "Delete a nodegroup", "", "ng")
 
 	rc.SetRunFuncWithNameArg(func() error {
-		return doDeleteNodeGroup(rc, ng, updateAuthConfigMap, deleteNodeGroupDrain, onlyMissing)
+		return doDeleteNodeGroup(rc, ng, updateAuthConfigMap, deleteNodeGroupDrain, onlyMissing, noWait, output)
 	})
 
 	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
 		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
 		fs.StringVarP(&ng.Name, "name", "n", "", "Name of the nodegroup to delete")
-		cmdutils.AddConfigFileFlag(fs, &rc.ClusterConfigFile)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddNotifySNSTopicFlag(fs, rc, "delete nodegroup")
 		cmdutils.AddApproveFlag(fs, rc)
 		cmdutils.AddNodeGroupFilterFlags(fs, &rc.IncludeNodeGroups, &rc.ExcludeNodeGroups)
 		fs.BoolVar(&onlyMissing, "only-missing", false, "Only delete nodegroups that are not defined in the given config file")
@@ -38,21 +47,36 @@ func deleteNodeGroupCmd(rc *cmdutils.ResourceCmd) {
 
 		rc.Wait = false
 		cmdutils.AddWaitFlag(fs, &rc.Wait, "deletion of all resources")
+		cmdutils.AddNoWaitFlags(fs, &noWait, &output, "the deletion of the nodegroup stacks")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
 }
 
-func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthConfigMap, deleteNodeGroupDrain, onlyMissing bool) error {
+func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthConfigMap, deleteNodeGroupDrain, onlyMissing, noWait bool, output string) error {
 	ngFilter := cmdutils.NewNodeGroupFilter()
 
 	if err := cmdutils.NewDeleteNodeGroupLoader(rc, ng, ngFilter).Load(); err != nil {
 		return err
 	}
 
+	var submittedPrinter printers.OutputPrinter
+	if noWait {
+		if rc.Wait {
+			return eksctlerrors.NewValidationError("--wait and --no-wait %s", cmdutils.IncompatibleFlags)
+		}
+		var err error
+		if submittedPrinter, err = cmdutils.NewSubmittedOperationsPrinter(output); err != nil {
+			return err
+		}
+	}
+
 	cfg := rc.ClusterConfig
 
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
 
 	if err := ctl.CheckAuth(); err != nil {
 		return err
@@ -85,6 +109,7 @@ func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthCo
 		cmdutils.LogIntendedAction(rc.Plan, "delete %d nodegroups from auth ConfigMap in cluster %q", ngCount, cfg.Metadata.Name)
 		err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
 			if rc.Plan {
+				rc.AddPlannedAction(cmdutils.NodeGroupAction("remove-nodegroup-from-auth-configmap", cfg.Metadata, ng.Name))
 				return nil
 			}
 			if ng.IAM == nil || ng.IAM.InstanceRoleARN == "" {
@@ -94,7 +119,7 @@ func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthCo
 				}
 			}
 			if err := authconfigmap.RemoveNodeGroup(clientSet, ng); err != nil {
-				logger.Warning(err.Error())
+				logger.Warning("%s", err.Error())
 			}
 			return nil
 		})
@@ -107,6 +132,7 @@ func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthCo
 		cmdutils.LogIntendedAction(rc.Plan, "drain %d nodegroups in cluster %q", ngCount, cfg.Metadata.Name)
 		err := ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
 			if rc.Plan {
+				rc.AddPlannedAction(cmdutils.NodeGroupAction("drain-nodegroup", cfg.Metadata, ng.Name))
 				return nil
 			}
 			if err := drain.NodeGroup(clientSet, ng, ctl.Provider.WaitTimeout(), false); err != nil {
@@ -120,6 +146,32 @@ func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthCo
 	}
 
 	cmdutils.LogIntendedAction(rc.Plan, "delete %d nodegroups from cluster %q", ngCount, cfg.Metadata.Name)
+	_ = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		rc.AddPlannedAction(cmdutils.NodeGroupAction("delete-nodegroup", cfg.Metadata, ng.Name))
+		return nil
+	})
+
+	submitted := []*cmdutils.SubmittedOperation{}
+	if noWait && !rc.Plan {
+		// the IDs are those of the stacks as they are before being deleted
+		stacks, err := stackManager.DescribeNodeGroupStacks()
+		if err != nil {
+			return err
+		}
+		for _, s := range stacks {
+			name := stackManager.GetNodeGroupName(s)
+			if !ngSubset.Has(name) {
+				continue
+			}
+			submitted = append(submitted, &cmdutils.SubmittedOperation{
+				Resource:    "nodegroup",
+				Name:        name,
+				Operation:   "delete",
+				ID:          aws.StringValue(s.StackId),
+				WaitCommand: fmt.Sprintf("eksctl utils wait nodegroup --region=%s --cluster=%s --name=%s --for=deleted", cfg.Metadata.Region, cfg.Metadata.Name, name),
+			})
+		}
+	}
 
 	{
 		tasks, err := stackManager.NewTasksToDeleteNodeGroups(ngSubset, rc.Wait, nil)
@@ -127,7 +179,7 @@ func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthCo
 			return err
 		}
 		tasks.PlanMode = rc.Plan
-		logger.Info(tasks.Describe())
+		logger.Info("%s", tasks.Describe())
 		if errs := tasks.DoAllSync(); len(errs) > 0 {
 			return handleErrors(errs, "nodegroup(s)")
 		}
@@ -136,5 +188,8 @@ func doDeleteNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, updateAuthCo
 
 	cmdutils.LogPlanModeWarning(rc.Plan && ngCount > 0)
 
+	if noWait {
+		return cmdutils.PrintSubmittedOperations(submittedPrinter, submitted)
+	}
 	return nil
 }
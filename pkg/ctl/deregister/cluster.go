@@ -0,0 +1,80 @@
+package deregister
+
+import (
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/connector"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func deregisterClusterCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var kubeconfig, context string
+
+	rc.SetDescription("cluster", "Deregister a non-EKS cluster",
+		"Deregisters the cluster from EKS, and removes the connector from it along with the IAM role eksctl created for it")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doDeregisterCluster(rc, kubeconfig, context)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig of the cluster (uses the default kubeconfig if unspecified)")
+		fs.StringVar(&context, "context", "", "context of the cluster in the kubeconfig (uses the current context if unspecified)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doDeregisterCluster(rc *cmdutils.ResourceCmd, kubeconfig, context string) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	connectorAPI, err := connector.NewAPI(ctl.Provider.EKS())
+	if err != nil {
+		return err
+	}
+	if err := connector.NewManager(ctl.Provider, connectorAPI).DeregisterCluster(meta.Name); err != nil {
+		return err
+	}
+	logger.Info("deregistered cluster %q from EKS", meta.Name)
+
+	// the cluster may be gone already, the registration is what matters
+	rawClient, err := connector.NewExternalClusterClient(kubeconfig, context)
+	if err != nil {
+		logger.Warning("not removing the connector from cluster %q: %s", meta.Name, err.Error())
+		return nil
+	}
+	if err := connector.Delete(rawClient.ClientSet()); err != nil {
+		logger.Warning("removing the connector from cluster %q: %s, delete namespace %q manually", meta.Name, err.Error(), connector.Namespace)
+		return nil
+	}
+
+	logger.Success("cluster %q has been deregistered", meta.Name)
+	return nil
+}
@@ -0,0 +1,180 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+// Command will create the hidden `docs` commands, which generate the reference
+// documentation of every command, for packagers to ship with each release
+func Command(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	var dir string
+
+	generate := func(format string, generator func(*Command) (string, []byte)) func(*cobra.Command, []string) error {
+		return func(_ *cobra.Command, _ []string) error {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				return errors.Wrapf(err, "creating directory %q", dir)
+			}
+			commands := Commands(rootCmd, flagGrouping)
+			for _, c := range commands {
+				name, data := generator(c)
+				if err := writeFile(filepath.Join(dir, name), data); err != nil {
+					return err
+				}
+			}
+			logger.Success("wrote %s of %d commands to %q", format, len(commands), dir)
+			return nil
+		}
+	}
+
+	markdownCmd := &cobra.Command{
+		Use:   "markdown",
+		Short: "Generates the Markdown reference of the commands",
+		RunE:  generate("Markdown reference", Markdown),
+	}
+	manCmd := &cobra.Command{
+		Use:   "man",
+		Short: "Generates the man pages of the commands",
+		RunE:  generate("man pages", ManPage),
+	}
+
+	cmd := &cobra.Command{
+		Use:    "docs",
+		Short:  "Generates reference documentation",
+		Hidden: true,
+		Run: func(c *cobra.Command, _ []string) {
+			if err := c.Help(); err != nil {
+				logger.Debug("ignoring error %q", err.Error())
+			}
+		},
+	}
+	cmd.PersistentFlags().StringVar(&dir, "dir", "docs/reference", "directory to write the files to")
+
+	cmd.AddCommand(markdownCmd)
+	cmd.AddCommand(manCmd)
+
+	return cmd
+}
+
+func writeFile(path string, data []byte) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.Wrapf(err, "creating %q", path)
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return errors.Wrapf(err, "writing %q", path)
+	}
+	return nil
+}
+
+// Command is a command, as it's documented
+type Command struct {
+	Cmd *cobra.Command
+
+	// FlagGroups are the flags of the command, grouped as they are listed in its usage
+	FlagGroups []FlagGroup
+	// CommonFlags are the flags the command shares with all others
+	CommonFlags []*Flag
+
+	Parent      *Command
+	SubCommands []*Command
+}
+
+// FlagGroup is a named group of flags of a command
+type FlagGroup struct {
+	Name  string
+	Flags []*Flag
+}
+
+// Flag is a flag of a command, as it's documented
+type Flag struct {
+	Name      string
+	Shorthand string
+	// Type is the name of the value of the flag, empty for boolean flags
+	Type  string
+	Usage string
+	// Default is empty when the default is the zero value
+	Default string
+}
+
+// Commands returns the commands of the tree, depth-first, without the
+// hidden commands and help
+func Commands(rootCmd *cobra.Command, flagGrouping *cmdutils.FlagGrouping) []*Command {
+	commands := []*Command{}
+	var walk func(*cobra.Command, *Command)
+	walk = func(cmd *cobra.Command, parent *Command) {
+		c := &Command{
+			Cmd:         cmd,
+			CommonFlags: documentFlags(cmd.InheritedFlags()),
+			Parent:      parent,
+		}
+		if !cmd.HasParent() {
+			c.CommonFlags = documentFlags(cmd.PersistentFlags())
+		}
+		for _, nfs := range flagGrouping.FlagSets(cmd) {
+			c.FlagGroups = append(c.FlagGroups, FlagGroup{Name: nfs.Name, Flags: documentFlags(nfs.FlagSet)})
+		}
+		if len(c.FlagGroups) == 0 && cmd.HasAvailableLocalFlags() {
+			c.FlagGroups = []FlagGroup{{Name: "General", Flags: documentFlags(cmd.LocalNonPersistentFlags())}}
+		}
+		if parent != nil {
+			parent.SubCommands = append(parent.SubCommands, c)
+		}
+		commands = append(commands, c)
+		for _, subCmd := range cmd.Commands() {
+			if subCmd.IsAvailableCommand() {
+				walk(subCmd, c)
+			}
+		}
+	}
+	walk(rootCmd, nil)
+	return commands
+}
+
+// documentFlags returns the flags of a flagset, without the hidden and deprecated ones
+func documentFlags(fs *pflag.FlagSet) []*Flag {
+	documented := []*Flag{}
+	fs.VisitAll(func(f *pflag.Flag) {
+		if f.Hidden || f.Deprecated != "" {
+			return
+		}
+		typeName, usage := pflag.UnquoteUsage(f)
+		flag := &Flag{
+			Name:      f.Name,
+			Shorthand: f.Shorthand,
+			Type:      typeName,
+			Usage:     usage,
+		}
+		if !isZeroDefault(f) {
+			flag.Default = f.DefValue
+			if f.Value.Type() == "string" {
+				flag.Default = `"` + f.DefValue + `"`
+			}
+		}
+		documented = append(documented, flag)
+	})
+	return documented
+}
+
+// isZeroDefault tells whether the default of a flag is omitted, as in usage
+func isZeroDefault(f *pflag.Flag) bool {
+	switch f.DefValue {
+	case "", "0", "0s", "false", "[]", "<nil>":
+		return true
+	}
+	return false
+}
+
+// fileName returns the name of the files documenting the command, e.g. "eksctl_create_cluster"
+func fileName(cmd *cobra.Command) string {
+	return strings.Replace(cmd.CommandPath(), " ", "_", -1)
+}
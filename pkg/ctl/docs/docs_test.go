@@ -0,0 +1,114 @@
+package docs_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	. "github.com/weaveworks/eksctl/pkg/ctl/docs"
+)
+
+var _ = Describe("docs", func() {
+	var commands []*Command
+
+	BeforeEach(func() {
+		grouping := cmdutils.NewGrouping()
+
+		rootCmd := &cobra.Command{Use: "eksctl", Short: "The official CLI for Amazon EKS"}
+		rootCmd.PersistentFlags().IntP("verbose", "v", 3, "set log level")
+
+		createCmd := &cobra.Command{Use: "create", Short: "Create resource(s)"}
+		clusterCmd := &cobra.Command{
+			Use:     "cluster",
+			Short:   "Create a cluster",
+			Long:    "Creates a cluster.\n\nA nodegroup is created with it.",
+			Aliases: []string{"clusters"},
+			Run:     func(*cobra.Command, []string) {},
+		}
+		group := grouping.New(clusterCmd)
+		group.InFlagSet("General", func(fs *pflag.FlagSet) {
+			fs.StringP("name", "n", "", "EKS cluster name")
+			fs.String("region", "us-west-2", "AWS region")
+			fs.Bool("hidden", false, "hidden flag")
+			Expect(fs.MarkHidden("hidden")).To(Succeed())
+		})
+		group.InFlagSet("Initial nodegroup", func(fs *pflag.FlagSet) {
+			fs.Int("nodes", 2, "total number of nodes")
+			fs.Bool("without-nodegroup", false, "if set, initial nodegroup will not be created")
+		})
+		group.AddTo(clusterCmd)
+
+		hiddenCmd := &cobra.Command{Use: "hidden", Hidden: true, Run: func(*cobra.Command, []string) {}}
+
+		createCmd.AddCommand(clusterCmd)
+		rootCmd.AddCommand(createCmd, hiddenCmd)
+
+		commands = Commands(rootCmd, grouping)
+	})
+
+	It("walks the command tree without hidden commands", func() {
+		paths := []string{}
+		for _, c := range commands {
+			paths = append(paths, c.Cmd.CommandPath())
+		}
+		Expect(paths).To(Equal([]string{"eksctl", "eksctl create", "eksctl create cluster"}))
+	})
+
+	It("documents the flags in their groups", func() {
+		cluster := commands[2]
+		Expect(cluster.Parent).To(Equal(commands[1]))
+		Expect(cluster.FlagGroups).To(HaveLen(2))
+
+		Expect(cluster.FlagGroups[0].Name).To(Equal("General"))
+		Expect(cluster.FlagGroups[0].Flags).To(Equal([]*Flag{
+			{Name: "name", Shorthand: "n", Type: "string", Usage: "EKS cluster name"},
+			{Name: "region", Type: "string", Usage: "AWS region", Default: `"us-west-2"`},
+		}))
+
+		Expect(cluster.FlagGroups[1].Name).To(Equal("Initial nodegroup"))
+		Expect(cluster.FlagGroups[1].Flags).To(Equal([]*Flag{
+			{Name: "nodes", Type: "int", Usage: "total number of nodes", Default: "2"},
+			{Name: "without-nodegroup", Usage: "if set, initial nodegroup will not be created"},
+		}))
+
+		Expect(cluster.CommonFlags).To(Equal([]*Flag{
+			{Name: "verbose", Shorthand: "v", Type: "int", Usage: "set log level", Default: "3"},
+		}))
+	})
+
+	It("generates Markdown references", func() {
+		name, data := Markdown(commands[2])
+		Expect(name).To(Equal("eksctl_create_cluster.md"))
+		Expect(string(data)).To(Equal("## eksctl create cluster\n\n" +
+			"Create a cluster\n\n" +
+			"### Synopsis\n\nCreates a cluster.\n\nA nodegroup is created with it.\n\n" +
+			"```\neksctl create cluster [flags]\n```\n\n" +
+			"### Aliases\n\ncluster, clusters\n\n" +
+			"### General flags\n\n" +
+			"| Flag | Description |\n| --- | --- |\n" +
+			"| `-n`, `--name string` | EKS cluster name |\n" +
+			"| `--region string` | AWS region (default `\"us-west-2\"`) |\n\n" +
+			"### Initial nodegroup flags\n\n" +
+			"| Flag | Description |\n| --- | --- |\n" +
+			"| `--nodes int` | total number of nodes (default `2`) |\n" +
+			"| `--without-nodegroup` | if set, initial nodegroup will not be created |\n\n" +
+			"### Common flags\n\n" +
+			"| Flag | Description |\n| --- | --- |\n" +
+			"| `-v`, `--verbose int` | set log level (default `3`) |\n\n" +
+			"### See also\n\n* [eksctl create](eksctl_create.md) - Create resource(s)"))
+
+		_, data = Markdown(commands[1])
+		Expect(string(data)).To(ContainSubstring("### Commands\n\n* [eksctl create cluster](eksctl_create_cluster.md) - Create a cluster\n"))
+	})
+
+	It("generates man pages", func() {
+		name, data := ManPage(commands[2])
+		Expect(name).To(Equal("eksctl-create-cluster.1"))
+		Expect(string(data)).To(ContainSubstring(".SH NAME\neksctl\\-create\\-cluster \\- Create a cluster\n"))
+		Expect(string(data)).To(ContainSubstring(".SH DESCRIPTION\nCreates a cluster.\n.PP\nA nodegroup is created with it.\n"))
+		Expect(string(data)).To(ContainSubstring(".SH INITIAL NODEGROUP FLAGS\n.TP\n\\fB\\-\\-nodes\\fP \\fIint\\fP\ntotal number of nodes (default 2)\n"))
+		Expect(string(data)).To(ContainSubstring(".SH SEE ALSO\n.BR eksctl\\-create (1)\n"))
+	})
+})
@@ -0,0 +1,93 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/weaveworks/eksctl/pkg/version"
+)
+
+// ManPage returns the name and content of the man page of the command, in section 1
+func ManPage(c *Command) (string, []byte) {
+	buf := &bytes.Buffer{}
+	cmd := c.Cmd
+	name := strings.Replace(cmd.CommandPath(), " ", "-", -1)
+
+	fmt.Fprintf(buf, ".TH %q \"1\" \"\" \"eksctl %s\" \"eksctl Manual\"\n", strings.ToUpper(name), version.Get().GitTag)
+	fmt.Fprintf(buf, ".SH NAME\n%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+	fmt.Fprintf(buf, ".SH SYNOPSIS\n.B %s\n", roffEscape(cmd.UseLine()))
+
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	buf.WriteString(".SH DESCRIPTION\n")
+	for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+		if strings.TrimSpace(line) == "" {
+			buf.WriteString(".PP\n")
+			continue
+		}
+		fmt.Fprintf(buf, "%s\n", roffEscape(line))
+	}
+
+	if len(c.SubCommands) > 0 {
+		buf.WriteString(".SH COMMANDS\n")
+		for _, sub := range c.SubCommands {
+			fmt.Fprintf(buf, ".TP\n.B %s\n%s\n", sub.Cmd.Name(), roffEscape(sub.Cmd.Short))
+		}
+	}
+
+	for _, group := range c.FlagGroups {
+		writeManFlags(buf, group.Name+" flags", group.Flags)
+	}
+	writeManFlags(buf, "Common flags", c.CommonFlags)
+
+	seeAlso := []string{}
+	if c.Parent != nil {
+		seeAlso = append(seeAlso, manReference(c.Parent))
+	}
+	for _, sub := range c.SubCommands {
+		seeAlso = append(seeAlso, manReference(sub))
+	}
+	if len(seeAlso) > 0 {
+		fmt.Fprintf(buf, ".SH SEE ALSO\n%s\n", strings.Join(seeAlso, ",\n"))
+	}
+
+	return name + ".1", buf.Bytes()
+}
+
+func writeManFlags(buf *bytes.Buffer, title string, flags []*Flag) {
+	if len(flags) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, ".SH %s\n", strings.ToUpper(title))
+	for _, f := range flags {
+		name := `\fB\-\-` + roffEscape(f.Name) + `\fP`
+		if f.Shorthand != "" {
+			name = `\fB\-` + roffEscape(f.Shorthand) + `\fP, ` + name
+		}
+		if f.Type != "" {
+			name += ` \fI` + roffEscape(f.Type) + `\fP`
+		}
+		usage := f.Usage
+		if f.Default != "" {
+			usage += fmt.Sprintf(" (default %s)", f.Default)
+		}
+		fmt.Fprintf(buf, ".TP\n%s\n%s\n", name, roffEscape(usage))
+	}
+}
+
+func manReference(c *Command) string {
+	return fmt.Sprintf(".BR %s (1)", roffEscape(strings.Replace(c.Cmd.CommandPath(), " ", "-", -1)))
+}
+
+// roffEscape escapes the characters of text that roff would interpret
+func roffEscape(text string) string {
+	text = strings.Replace(text, `\`, `\e`, -1)
+	text = strings.Replace(text, "-", `\-`, -1)
+	if strings.HasPrefix(text, ".") || strings.HasPrefix(text, "'") {
+		text = `\&` + text
+	}
+	return text
+}
@@ -0,0 +1,68 @@
+package docs
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// Markdown returns the name and content of the Markdown reference of the command,
+// which links to the references of its parent and subcommands
+func Markdown(c *Command) (string, []byte) {
+	buf := &bytes.Buffer{}
+	cmd := c.Cmd
+
+	fmt.Fprintf(buf, "## %s\n\n", cmd.CommandPath())
+	fmt.Fprintf(buf, "%s\n\n", cmd.Short)
+	if cmd.Long != "" {
+		fmt.Fprintf(buf, "### Synopsis\n\n%s\n\n", strings.TrimSpace(cmd.Long))
+	}
+	fmt.Fprintf(buf, "```\n%s\n```\n\n", cmd.UseLine())
+
+	if len(cmd.Aliases) > 0 {
+		fmt.Fprintf(buf, "### Aliases\n\n%s\n\n", cmd.NameAndAliases())
+	}
+
+	if len(c.SubCommands) > 0 {
+		buf.WriteString("### Commands\n\n")
+		for _, sub := range c.SubCommands {
+			fmt.Fprintf(buf, "* [%s](%s.md) - %s\n", sub.Cmd.CommandPath(), fileName(sub.Cmd), sub.Cmd.Short)
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, group := range c.FlagGroups {
+		writeMarkdownFlags(buf, group.Name+" flags", group.Flags)
+	}
+	writeMarkdownFlags(buf, "Common flags", c.CommonFlags)
+
+	if c.Parent != nil {
+		fmt.Fprintf(buf, "### See also\n\n* [%s](%s.md) - %s\n", c.Parent.Cmd.CommandPath(), fileName(c.Parent.Cmd), c.Parent.Cmd.Short)
+	}
+
+	return fileName(cmd) + ".md", bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+func writeMarkdownFlags(buf *bytes.Buffer, title string, flags []*Flag) {
+	if len(flags) == 0 {
+		return
+	}
+	fmt.Fprintf(buf, "### %s\n\n", title)
+	buf.WriteString("| Flag | Description |\n| --- | --- |\n")
+	for _, f := range flags {
+		name := "`--" + f.Name
+		if f.Type != "" {
+			name += " " + f.Type
+		}
+		name += "`"
+		if f.Shorthand != "" {
+			name = "`-" + f.Shorthand + "`, " + name
+		}
+		usage := f.Usage
+		if f.Default != "" {
+			usage += fmt.Sprintf(" (default `%s`)", f.Default)
+		}
+		fmt.Fprintf(buf, "| %s | %s |\n", name, strings.Replace(usage, "|", `\|`, -1))
+	}
+	buf.WriteString("\n")
+}
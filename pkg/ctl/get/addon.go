@@ -0,0 +1,33 @@
+package get
+
+import (
+	"context"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var addonGetter = &resourceGetter{
+	resource:  "addon",
+	short:     "Get default add-on(s)",
+	aliases:   []string{"addons"},
+	kind:      "addons",
+	nameUsage: "Name of the add-on (kube-proxy, aws-node or coredns)",
+	list: func(ctx context.Context, m *actions.Manager, opts listOptions) (interface{}, error) {
+		return m.GetAddons(ctx, opts.name)
+	},
+	addColumns: addAddonTableColumns,
+}
+
+func addAddonTableColumns(printer printers.ColumnPrinter, _ listOptions) {
+	printer.AddColumn("NAME", func(v *defaultaddons.AddonVersion) string {
+		return v.Name
+	})
+	printer.AddColumn("VERSION", func(v *defaultaddons.AddonVersion) string {
+		return v.Current
+	})
+	printer.AddColumn("LATEST", func(v *defaultaddons.AddonVersion) string {
+		return v.Latest
+	})
+}
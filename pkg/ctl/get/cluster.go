@@ -2,43 +2,65 @@ package get
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/kris-nova/logger"
 	"github.com/spf13/pflag"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/selector"
 )
 
 func getClusterCmd(rc *cmdutils.ResourceCmd) {
 	cfg := api.NewClusterConfig()
 	rc.ClusterConfig = cfg
 
-	var listAllRegions bool
+	var (
+		listAllRegions   bool
+		selectorString   string
+		outputStatusOnly bool
+	)
 
 	params := &getCmdParams{}
 
 	rc.SetDescription("cluster", "Get cluster(s)", "", "clusters")
 
 	rc.SetRunFuncWithNameArg(func() error {
-		return doGetCluster(rc, params, listAllRegions)
+		if outputStatusOnly {
+			return doGetClusterStatus(rc, params)
+		}
+		return doGetCluster(rc, params, listAllRegions, selectorString)
 	})
 
 	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddNameFlag(fs, cfg.Metadata)
 		fs.BoolVarP(&listAllRegions, "all-regions", "A", false, "List clusters across all supported regions")
+		cmdutils.AddSelectorFlag(fs, &selectorString)
 		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
 		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		fs.BoolVar(&outputStatusOnly, "output-status-only", false, "print only the status of the cluster as it appears in a config file, e.g. with -o jsonpath={.endpoint} (yaml unless --output is given)")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
 }
 
-func doGetCluster(rc *cmdutils.ResourceCmd, params *getCmdParams, listAllRegions bool) error {
+func doGetCluster(rc *cmdutils.ResourceCmd, params *getCmdParams, listAllRegions bool, selectorString string) error {
 	cfg := rc.ClusterConfig
+
+	sel, err := selector.Parse(selectorString)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
 	regionGiven := cfg.Metadata.Region != "" // eks.New resets this field, so we need to check if it was set in the fist place
 
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
 
 	if !ctl.IsSupportedRegion() {
 		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
@@ -64,5 +86,69 @@ func doGetCluster(rc *cmdutils.ResourceCmd, params *getCmdParams, listAllRegions
 		return err
 	}
 
-	return ctl.ListClusters(cfg.Metadata.Name, params.chunkSize, params.output, listAllRegions)
+	return ctl.ListClustersMatching(cfg.Metadata.Name, params.chunkSize, params.output, listAllRegions, sel)
+}
+
+func doGetClusterStatus(rc *cmdutils.ResourceCmd, params *getCmdParams) error {
+	cfg := rc.ClusterConfig
+
+	if cfg.Metadata.Name != "" && rc.NameArg != "" {
+		return cmdutils.ErrNameFlagAndArg(cfg.Metadata.Name, rc.NameArg)
+	}
+	if rc.NameArg != "" {
+		cfg.Metadata.Name = rc.NameArg
+	}
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--name")
+	}
+	for _, flag := range []string{"all-regions", "selector"} {
+		if rc.Command.Flag(flag).Changed {
+			return fmt.Errorf("--output-status-only and --%s %s", flag, cmdutils.IncompatibleFlags)
+		}
+	}
+
+	// the status is a single object, it isn't printed as a table
+	if !rc.Command.Flag("output").Changed {
+		params.output = "yaml"
+	}
+	if params.output == "table" || params.output == "csv" {
+		return eksctlerrors.NewValidationError("--output=%s is not supported with --output-status-only - use one of: json, yaml, jsonpath=<template>", params.output)
+	}
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	cluster, err := ctl.DescribeControlPlane(cfg.Metadata)
+	if err != nil {
+		return err
+	}
+	if err := eks.SetClusterStatus(cfg, cluster); err != nil {
+		return err
+	}
+	// clusters that weren't created by eksctl have no stack
+	stack, err := ctl.NewStackManager(cfg).DescribeClusterStack()
+	if err != nil && eksctlerrors.ClassOf(err) != eksctlerrors.ClassNotFound {
+		return err
+	}
+	if stack != nil {
+		if err := eks.SetClusterStatusFromStack(cfg, stack); err != nil {
+			return err
+		}
+	}
+
+	return printer.PrintObj(cfg.Status, os.Stdout)
 }
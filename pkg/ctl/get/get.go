@@ -16,8 +16,9 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	verbCmd := cmdutils.NewVerbCmd("get", "Get resource(s)", "")
 
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getClusterCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getNodeGroupCmd)
-	cmdutils.AddResourceCmd(flagGrouping, verbCmd, getIAMIdentityMappingCmd)
+	for _, g := range resourceGetters {
+		cmdutils.AddResourceCmd(flagGrouping, verbCmd, g.resourceCmd())
+	}
 
 	return verbCmd
 }
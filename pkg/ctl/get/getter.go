@@ -0,0 +1,157 @@
+package get
+
+import (
+	"context"
+	"os"
+
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/utils/selector"
+)
+
+// resourceGetter lists one type of resource of a cluster, the commands of all getters
+// share the same flags: --cluster, --config-file, --output and a flag that selects
+// resources by name, which can also be given as argument
+type resourceGetter struct {
+	// resource, short and aliases describe the command
+	resource, short string
+	aliases         []string
+
+	// kind is the plural of the resource, as passed to printers
+	kind string
+
+	// nameFlag is the flag that selects resources by name, it defaults to --name/-n
+	nameFlag, nameShorthand, nameUsage string
+	// nameRequired is set when resources can only be listed for one name
+	nameRequired bool
+
+	// selectable is set when resources have tags, which can be selected with --selector
+	selectable bool
+
+	// addFlags adds flags that are specific to the resource, they can set the cluster
+	// config or the options of the listing
+	addFlags func(fs *pflag.FlagSet, cfg *api.ClusterConfig, opts *listOptions)
+
+	// list returns the resources that match the options
+	list func(ctx context.Context, m *actions.Manager, opts listOptions) (interface{}, error)
+
+	// addColumns defines the columns of the table output, which can depend on the options
+	addColumns func(printer printers.ColumnPrinter, opts listOptions)
+}
+
+// listOptions select the resources that are listed
+type listOptions struct {
+	// name selects the resource with the given name when it's not empty
+	name string
+	// selector selects resources by their tags
+	selector selector.Selector
+	// showInstances adds the instances of nodegroups
+	showInstances bool
+	// orphansOnly selects the resources of the inventory that are orphans
+	orphansOnly bool
+}
+
+// resourceGetters are the getters of all resources of a cluster, `get cluster` isn't one of
+// them, as it lists clusters across regions
+var resourceGetters = []*resourceGetter{
+	nodeGroupGetter,
+	labelsGetter,
+	iamIdentityMappingGetter,
+	addonGetter,
+	inventoryGetter,
+}
+
+// resourceCmd returns the function that sets up the command of the getter
+func (g *resourceGetter) resourceCmd() func(rc *cmdutils.ResourceCmd) {
+	return func(rc *cmdutils.ResourceCmd) {
+		cfg := api.NewClusterConfig()
+		rc.ClusterConfig = cfg
+
+		var name, selectorString string
+		params := &getCmdParams{}
+		opts := &listOptions{}
+
+		rc.SetDescription(g.resource, g.short, "", g.aliases...)
+
+		rc.SetRunFuncWithNameArg(func() error {
+			return g.run(rc, name, selectorString, params, opts)
+		})
+
+		rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+			fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+			nameFlag, nameShorthand := g.nameFlag, g.nameShorthand
+			if nameFlag == "" {
+				nameFlag, nameShorthand = "name", "n"
+			}
+			fs.StringVarP(&name, nameFlag, nameShorthand, "", g.nameUsage)
+			if g.selectable {
+				cmdutils.AddSelectorFlag(fs, &selectorString)
+			}
+			if g.addFlags != nil {
+				g.addFlags(fs, cfg, opts)
+			}
+			cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+			cmdutils.AddConfigFileFlag(fs, rc)
+			cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
+		})
+
+		cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+	}
+}
+
+func (g *resourceGetter) nameFlagName() string {
+	if g.nameFlag == "" {
+		return "name"
+	}
+	return g.nameFlag
+}
+
+func (g *resourceGetter) run(rc *cmdutils.ResourceCmd, name, selectorString string, params *getCmdParams, opts *listOptions) error {
+	if name != "" && rc.NameArg != "" {
+		return cmdutils.ErrFlagAndArg("--"+g.nameFlagName(), name, rc.NameArg)
+	}
+	if rc.NameArg != "" {
+		name = rc.NameArg
+		// the argument selects a resource, not the cluster
+		rc.NameArg = ""
+	}
+	if g.nameRequired && name == "" {
+		return cmdutils.ErrMustBeSet("--" + g.nameFlagName())
+	}
+
+	sel, err := selector.Parse(selectorString)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	if err := cmdutils.NewGetResourceLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(params.output)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	m, err := actions.New(rc.ProviderConfig, rc.ClusterConfig)
+	if err != nil {
+		return err
+	}
+
+	opts.name, opts.selector = name, sel
+	resources, err := g.list(context.Background(), m, *opts)
+	if err != nil {
+		return err
+	}
+
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		g.addColumns(columnPrinter, *opts)
+	}
+
+	return printer.PrintObjWithKind(g.kind, resources, os.Stdout)
+}
@@ -1,100 +1,35 @@
 package get
 
 import (
-	"fmt"
-	"os"
+	"context"
 	"strings"
 
 	"github.com/spf13/pflag"
 
+	"github.com/weaveworks/eksctl/pkg/actions"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/authconfigmap"
-	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
-	"github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
-func getIAMIdentityMappingCmd(rc *cmdutils.ResourceCmd) {
-	cfg := api.NewClusterConfig()
-	rc.ClusterConfig = cfg
-
-	var role string
-
-	params := &getCmdParams{}
-
-	rc.SetDescription("iamidentitymapping", "Get IAM identity mapping(s)", "")
-
-	rc.SetRunFunc(func() error {
-		return doGetIAMIdentityMapping(rc, params, role)
-	})
-
-	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
-		fs.StringVar(&role, "role", "", "ARN of the IAM role")
-		cmdutils.AddNameFlag(fs, cfg.Metadata)
-		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
-		cmdutils.AddConfigFileFlag(fs, &rc.ClusterConfigFile)
-	})
-
-	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
-}
-
-func doGetIAMIdentityMapping(rc *cmdutils.ResourceCmd, params *getCmdParams, role string) error {
-	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
-		return err
-	}
-
-	cfg := rc.ClusterConfig
-
-	ctl := eks.New(rc.ProviderConfig, cfg)
-
-	if err := ctl.CheckAuth(); err != nil {
-		return err
-	}
-
-	if cfg.Metadata.Name == "" {
-		return cmdutils.ErrMustBeSet("--name")
-	}
-
-	if err := ctl.GetCredentials(cfg); err != nil {
-		return err
-	}
-	clientSet, err := ctl.NewStdClientSet(cfg)
-	if err != nil {
-		return err
-	}
-	acm, err := authconfigmap.NewFromClientSet(clientSet)
-	if err != nil {
-		return err
-	}
-	roles, err := acm.Roles()
-	if err != nil {
-		return err
-	}
-	if role != "" {
-		roles = roles.Get(role)
-		// If a filter was given, we error if none was found
-		if len(roles) == 0 {
-			return fmt.Errorf("no iamidentitymapping with role %q found", role)
-		}
-	}
-
-	printer, err := printers.NewPrinter(params.output)
-	if err != nil {
-		return err
-	}
-	if params.output == "table" {
-		addIAMIdentityMappingTableColumns(printer.(*printers.TablePrinter))
-	}
-
-	if err := printer.PrintObjWithKind("iamidentitymappings", roles, os.Stdout); err != nil {
-		return err
-	}
-
-	return nil
+var iamIdentityMappingGetter = &resourceGetter{
+	resource:  "iamidentitymapping",
+	short:     "Get IAM identity mapping(s)",
+	aliases:   []string{"iamidentitymappings"},
+	kind:      "iamidentitymappings",
+	nameFlag:  "role",
+	nameUsage: "ARN of the IAM role",
+	addFlags: func(fs *pflag.FlagSet, cfg *api.ClusterConfig, _ *listOptions) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name")
+		fs.MarkDeprecated("name", "use --cluster")
+	},
+	list: func(ctx context.Context, m *actions.Manager, opts listOptions) (interface{}, error) {
+		return m.GetIAMIdentityMappings(ctx, opts.name)
+	},
+	addColumns: addIAMIdentityMappingTableColumns,
 }
 
-func addIAMIdentityMappingTableColumns(printer *printers.TablePrinter) {
+func addIAMIdentityMappingTableColumns(printer printers.ColumnPrinter, _ listOptions) {
 	printer.AddColumn("ROLE", func(r authconfigmap.MapRole) string {
 		return r.RoleARN
 	})
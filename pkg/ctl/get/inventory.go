@@ -0,0 +1,55 @@
+package get
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+var inventoryGetter = &resourceGetter{
+	resource:  "inventory",
+	short:     "Get the AWS resources eksctl created for a cluster",
+	kind:      "resources",
+	nameFlag:  "type",
+	nameUsage: "Type of the resources, e.g. iam:role or ec2:vpc",
+	addFlags: func(fs *pflag.FlagSet, _ *api.ClusterConfig, opts *listOptions) {
+		fs.BoolVar(&opts.orphansOnly, "orphans", false, "only list resources that outlived their stack or the cluster")
+	},
+	list: func(ctx context.Context, m *actions.Manager, opts listOptions) (interface{}, error) {
+		resources, err := m.GetInventory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		selected := []*actions.InventoryResource{}
+		for _, r := range resources {
+			if (opts.name == "" || r.Type == opts.name) && (r.Orphan || !opts.orphansOnly) {
+				selected = append(selected, r)
+			}
+		}
+		return selected, nil
+	},
+	addColumns: addInventoryTableColumns,
+}
+
+func addInventoryTableColumns(printer printers.ColumnPrinter, _ listOptions) {
+	printer.AddColumn("TYPE", func(r *actions.InventoryResource) string {
+		return r.Type
+	})
+	printer.AddColumn("ID", func(r *actions.InventoryResource) string {
+		return r.ID
+	})
+	printer.AddColumn("STACK", func(r *actions.InventoryResource) string {
+		return r.Stack
+	})
+	printer.AddColumn("ORPHAN", func(r *actions.InventoryResource) string {
+		return strconv.FormatBool(r.Orphan)
+	})
+	printer.AddColumn("REASON", func(r *actions.InventoryResource) string {
+		return r.Reason
+	})
+}
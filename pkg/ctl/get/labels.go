@@ -0,0 +1,61 @@
+package get
+
+import (
+	"context"
+	"sort"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+type labelSummary struct {
+	Cluster   string `json:"cluster"`
+	NodeGroup string `json:"nodegroup"`
+	Key       string `json:"key"`
+	Value     string `json:"value"`
+}
+
+var labelsGetter = &resourceGetter{
+	resource:      "labels",
+	short:         "Get labels of a nodegroup",
+	kind:          "labels",
+	nameFlag:      "nodegroup",
+	nameShorthand: "n",
+	nameUsage:     "Name of the nodegroup",
+	nameRequired:  true,
+	list: func(ctx context.Context, m *actions.Manager, opts listOptions) (interface{}, error) {
+		nodeGroupName := opts.name
+		labels, err := m.GetLabels(ctx, nodeGroupName)
+		if err != nil {
+			return nil, err
+		}
+
+		summaries := []labelSummary{}
+		for k, v := range labels {
+			summaries = append(summaries, labelSummary{
+				Cluster:   m.ClusterConfig().Metadata.Name,
+				NodeGroup: nodeGroupName,
+				Key:       k,
+				Value:     v,
+			})
+		}
+		sort.Slice(summaries, func(i, j int) bool { return summaries[i].Key < summaries[j].Key })
+		return summaries, nil
+	},
+	addColumns: addLabelsTableColumns,
+}
+
+func addLabelsTableColumns(printer printers.ColumnPrinter, _ listOptions) {
+	printer.AddColumn("CLUSTER", func(s labelSummary) string {
+		return s.Cluster
+	})
+	printer.AddColumn("NODEGROUP", func(s labelSummary) string {
+		return s.NodeGroup
+	})
+	printer.AddColumn("KEY", func(s labelSummary) string {
+		return s.Key
+	})
+	printer.AddColumn("VALUE", func(s labelSummary) string {
+		return s.Value
+	})
+}
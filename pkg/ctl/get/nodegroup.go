@@ -1,108 +1,97 @@
 package get
 
 import (
-	"os"
+	"context"
+	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
-	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
 
+	"github.com/weaveworks/eksctl/pkg/actions"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
-	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
-	"github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
-func getNodeGroupCmd(rc *cmdutils.ResourceCmd) {
-	cfg := api.NewClusterConfig()
-	ng := cfg.NewNodeGroup()
-	rc.ClusterConfig = cfg
-
-	params := &getCmdParams{}
-
-	rc.SetDescription("nodegroup", "Get nodegroup(s)", "", "ng", "nodegroups")
-
-	rc.SetRunFuncWithNameArg(func() error {
-		return doGetNodeGroup(rc, ng, params)
-	})
-
-	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
-		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
-		fs.StringVarP(&ng.Name, "name", "n", "", "Name of the nodegroup")
-		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
-		cmdutils.AddCommonFlagsForGetCmd(fs, &params.chunkSize, &params.output)
-	})
-
-	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
-}
-
-func doGetNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, params *getCmdParams) error {
-	cfg := rc.ClusterConfig
-	ctl := eks.New(rc.ProviderConfig, cfg)
-
-	if err := ctl.CheckAuth(); err != nil {
-		return err
-	}
-
-	if cfg.Metadata.Name == "" {
-		return cmdutils.ErrMustBeSet("--cluster")
-	}
-
-	if ng.Name != "" && rc.NameArg != "" {
-		return cmdutils.ErrNameFlagAndArg(ng.Name, rc.NameArg)
-	}
-
-	if rc.NameArg != "" {
-		ng.Name = rc.NameArg
-	}
-
-	manager := ctl.NewStackManager(cfg)
-	summaries, err := manager.GetNodeGroupSummaries(ng.Name)
-	if err != nil {
-		return errors.Wrap(err, "getting nodegroup stack summaries")
-	}
-
-	printer, err := printers.NewPrinter(params.output)
-	if err != nil {
-		return err
-	}
-
-	if params.output == "table" {
-		addSummaryTableColumns(printer.(*printers.TablePrinter))
-	}
-
-	if err := printer.PrintObjWithKind("nodegroups", summaries, os.Stdout); err != nil {
-		return err
-	}
-
-	return nil
+var nodeGroupGetter = &resourceGetter{
+	resource:   "nodegroup",
+	short:      "Get nodegroup(s)",
+	aliases:    []string{"ng", "nodegroups"},
+	kind:       "nodegroups",
+	nameUsage:  "Name of the nodegroup",
+	selectable: true,
+	addFlags: func(fs *pflag.FlagSet, _ *api.ClusterConfig, opts *listOptions) {
+		fs.BoolVar(&opts.showInstances, "show-instances", false, "include the instances of nodegroups, with the status of their nodes")
+	},
+	list: func(ctx context.Context, m *actions.Manager, opts listOptions) (interface{}, error) {
+		summaries, err := m.GetNodeGroups(ctx, opts.name)
+		if err != nil {
+			return nil, err
+		}
+		selected := []*manager.NodeGroupSummary{}
+		for _, s := range summaries {
+			if opts.selector.Matches(s.Tags) {
+				selected = append(selected, s)
+			}
+		}
+		nodeGroups := []*actions.NodeGroupWithInstances{}
+		if opts.showInstances {
+			if nodeGroups, err = m.GetNodeGroupInstances(ctx, selected); err != nil {
+				return nil, err
+			}
+		} else {
+			for _, s := range selected {
+				nodeGroups = append(nodeGroups, &actions.NodeGroupWithInstances{NodeGroupSummary: s})
+			}
+		}
+		if err := m.SetNodeGroupSuspendedProcesses(ctx, nodeGroups); err != nil {
+			return nil, err
+		}
+		return nodeGroups, nil
+	},
+	addColumns: addSummaryTableColumns,
 }
 
-func addSummaryTableColumns(printer *printers.TablePrinter) {
-	printer.AddColumn("CLUSTER", func(s *manager.NodeGroupSummary) string {
+func addSummaryTableColumns(printer printers.ColumnPrinter, opts listOptions) {
+	printer.AddColumn("CLUSTER", func(s *actions.NodeGroupWithInstances) string {
 		return s.Cluster
 	})
-	printer.AddColumn("NODEGROUP", func(s *manager.NodeGroupSummary) string {
+	printer.AddColumn("NODEGROUP", func(s *actions.NodeGroupWithInstances) string {
 		return s.Name
 	})
-	printer.AddColumn("CREATED", func(s *manager.NodeGroupSummary) string {
+	printer.AddColumn("CREATED", func(s *actions.NodeGroupWithInstances) string {
 		return s.CreationTime.Format(time.RFC3339)
 	})
-	printer.AddColumn("MIN SIZE", func(s *manager.NodeGroupSummary) string {
+	printer.AddColumn("MIN SIZE", func(s *actions.NodeGroupWithInstances) string {
 		return strconv.Itoa(s.MinSize)
 	})
-	printer.AddColumn("MAX SIZE", func(s *manager.NodeGroupSummary) string {
+	printer.AddColumn("MAX SIZE", func(s *actions.NodeGroupWithInstances) string {
 		return strconv.Itoa(s.MaxSize)
 	})
-	printer.AddColumn("DESIRED CAPACITY", func(s *manager.NodeGroupSummary) string {
+	printer.AddColumn("DESIRED CAPACITY", func(s *actions.NodeGroupWithInstances) string {
 		return strconv.Itoa(s.DesiredCapacity)
 	})
-	printer.AddColumn("INSTANCE TYPE", func(s *manager.NodeGroupSummary) string {
+	printer.AddColumn("INSTANCE TYPE", func(s *actions.NodeGroupWithInstances) string {
 		return s.InstanceType
 	})
-	printer.AddColumn("IMAGE ID", func(s *manager.NodeGroupSummary) string {
+	printer.AddColumn("IMAGE ID", func(s *actions.NodeGroupWithInstances) string {
 		return s.ImageID
 	})
+	printer.AddColumn("SUSPENDED PROCESSES", func(s *actions.NodeGroupWithInstances) string {
+		return strings.Join(s.SuspendedProcesses, ",")
+	})
+	if opts.showInstances {
+		printer.AddColumn("INSTANCES", func(s *actions.NodeGroupWithInstances) string {
+			ready := 0
+			for _, i := range s.Instances {
+				if i.Ready == string(corev1.ConditionTrue) {
+					ready++
+				}
+			}
+			return fmt.Sprintf("%d (%d ready)", len(s.Instances), ready)
+		})
+	}
 }
@@ -0,0 +1,104 @@
+package register
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/connector"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+type registerOptions struct {
+	connector.ExternalCluster
+	kubeconfig string
+	context    string
+}
+
+func registerClusterCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var options registerOptions
+
+	rc.SetDescription("cluster", "Register a non-EKS cluster with EKS Connector",
+		"Registers the cluster with EKS and deploys the connector to it, so that it can be viewed in the EKS console")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doRegisterCluster(rc, options)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&options.Provider, "provider", "", fmt.Sprintf("provider of the cluster, one of: %s", strings.Join(connector.SupportedProviders(), ", ")))
+		fs.StringVar(&options.ConnectorRoleARN, "role-arn", "", "IAM role of the connector (created by eksctl if unspecified)")
+		fs.StringVar(&options.kubeconfig, "kubeconfig", "", "path to the kubeconfig of the cluster (uses the default kubeconfig if unspecified)")
+		fs.StringVar(&options.context, "context", "", "context of the cluster in the kubeconfig (uses the current context if unspecified)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doRegisterCluster(rc *cmdutils.ResourceCmd, options registerOptions) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	if options.Provider == "" {
+		return cmdutils.ErrMustBeSet("--provider")
+	}
+	options.Name = meta.Name
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	// connect to the cluster first, so that it isn't registered when it's unreachable
+	rawClient, err := connector.NewExternalClusterClient(options.kubeconfig, options.context)
+	if err != nil {
+		return err
+	}
+
+	connectorAPI, err := connector.NewAPI(ctl.Provider.EKS())
+	if err != nil {
+		return err
+	}
+	cluster, err := connector.NewManager(ctl.Provider, connectorAPI).RegisterCluster(options.ExternalCluster)
+	if err != nil {
+		return err
+	}
+	logger.Info("registered cluster %q with EKS", meta.Name)
+
+	manifests, err := connector.NewManifests(cluster, meta.Region)
+	if err != nil {
+		return err
+	}
+	if err := connector.Deploy(rawClient, manifests); err != nil {
+		return errors.Wrapf(err, "deploying the connector to cluster %q, run 'eksctl deregister cluster --name=%s' to clean up", meta.Name, meta.Name)
+	}
+
+	logger.Success("cluster %q has been registered, the connector in namespace %q will connect it to EKS", meta.Name, connector.Namespace)
+	if expiry := cluster.ConnectorConfig.ActivationExpiry; expiry != nil {
+		logger.Info("the connector must connect before the activation expires at %s", expiry.String())
+	}
+	return nil
+}
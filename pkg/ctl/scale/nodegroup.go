@@ -1,13 +1,16 @@
 package scale
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"github.com/weaveworks/eksctl/pkg/actions"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
-	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
 func scaleNodeGroupCmd(rc *cmdutils.ResourceCmd) {
@@ -15,10 +18,15 @@ func scaleNodeGroupCmd(rc *cmdutils.ResourceCmd) {
 	ng := cfg.NewNodeGroup()
 	rc.ClusterConfig = cfg
 
+	var (
+		noWait bool
+		output string
+	)
+
 	rc.SetDescription("nodegroup", "Scale a nodegroup", "", "ng")
 
 	rc.SetRunFuncWithNameArg(func() error {
-		return doScaleNodeGroup(rc, ng)
+		return doScaleNodeGroup(rc, ng, noWait, output)
 	})
 
 	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
@@ -33,17 +41,25 @@ func scaleNodeGroupCmd(rc *cmdutils.ResourceCmd) {
 		})
 
 		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddNoWaitFlags(fs, &noWait, &output, "the update of the nodegroup stack")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
 }
 
-func doScaleNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup) error {
+func doScaleNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, noWait bool, output string) error {
 	cfg := rc.ClusterConfig
 
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	var submittedPrinter printers.OutputPrinter
+	if noWait {
+		var err error
+		if submittedPrinter, err = cmdutils.NewSubmittedOperationsPrinter(output); err != nil {
+			return err
+		}
+	}
 
-	if err := ctl.CheckAuth(); err != nil {
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
 		return err
 	}
 
@@ -67,11 +83,29 @@ func doScaleNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup) error {
 		return fmt.Errorf("number of nodes must be 0 or greater. Use the --nodes/-N flag")
 	}
 
-	stackManager := ctl.NewStackManager(cfg)
-	err := stackManager.ScaleNodeGroup(ng)
-	if err != nil {
-		return fmt.Errorf("failed to scale nodegroup for cluster %q, error %v", cfg.Metadata.Name, err)
+	opts := actions.ScaleNodeGroupOptions{
+		Name:            ng.Name,
+		DesiredCapacity: *ng.DesiredCapacity,
+	}
+
+	if !noWait {
+		return m.ScaleNodeGroup(context.Background(), opts)
 	}
 
-	return nil
+	stack, err := m.SubmitNodeGroupScale(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	operations := []*cmdutils.SubmittedOperation{}
+	if stack != nil {
+		meta := cfg.Metadata
+		operations = append(operations, &cmdutils.SubmittedOperation{
+			Resource:    "nodegroup",
+			Name:        ng.Name,
+			Operation:   "update",
+			ID:          aws.StringValue(stack.StackId),
+			WaitCommand: fmt.Sprintf("eksctl utils wait nodegroup --region=%s --cluster=%s --name=%s --for=updated", meta.Region, meta.Name, ng.Name),
+		})
+	}
+	return cmdutils.PrintSubmittedOperations(submittedPrinter, operations)
 }
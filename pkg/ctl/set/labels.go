@@ -0,0 +1,55 @@
+package set
+
+import (
+	"context"
+
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func setLabelsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	opts := actions.UpdateLabelsOptions{}
+
+	rc.SetDescription("labels", "Set labels of a nodegroup", "Labels are set on the existing nodes, and on the nodes that are launched later")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doSetLabels(rc, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		fs.StringVarP(&opts.NodeGroup, "nodegroup", "n", "", "Name of the nodegroup")
+		fs.StringToStringVarP(&opts.Set, "labels", "l", nil, `Labels to set, e.g. "partition=backend,nodeclass=hugememory"`)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doSetLabels(rc *cmdutils.ResourceCmd, opts actions.UpdateLabelsOptions) error {
+	cfg := rc.ClusterConfig
+
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--cluster")
+	}
+	if opts.NodeGroup == "" {
+		return cmdutils.ErrMustBeSet("--nodegroup")
+	}
+	if len(opts.Set) == 0 {
+		return cmdutils.ErrMustBeSet("--labels")
+	}
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	return cmdutils.UpdateLabels(context.Background(), m, rc, opts)
+}
@@ -0,0 +1,140 @@
+package top
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func topNodeGroupCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var (
+		name   string
+		output string
+	)
+
+	rc.SetDescription("nodegroup", "Display CPU and memory requests, limits and usage of nodegroups",
+		"Sums the requests and limits of the running pods and the usage reported by metrics-server "+
+			"over the nodes of each nodegroup, against what the nodes can allocate", "ng", "nodegroups")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doTopNodeGroup(rc, name, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		fs.StringVarP(&name, "name", "n", "", "Name of the nodegroup, all nodegroups are included when not set")
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doTopNodeGroup(rc *cmdutils.ResourceCmd, name, output string) error {
+	if name != "" && rc.NameArg != "" {
+		return cmdutils.ErrFlagAndArg("--name", name, rc.NameArg)
+	}
+	if rc.NameArg != "" {
+		name = rc.NameArg
+		// the argument selects a nodegroup, not the cluster
+		rc.NameArg = ""
+	}
+
+	if err := cmdutils.NewGetResourceLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	m, err := actions.New(rc.ProviderConfig, rc.ClusterConfig)
+	if err != nil {
+		return err
+	}
+
+	opts := actions.TopNodeGroupsOptions{}
+	if name != "" {
+		opts.NodeGroups = []string{name}
+	}
+	usage, err := m.TopNodeGroups(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addUsageColumns(columnPrinter)
+	}
+	return printer.PrintObjWithKind("nodegroups", usage, os.Stdout)
+}
+
+func addUsageColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("NODEGROUP", func(u *actions.NodeGroupUsage) string {
+		return u.NodeGroup
+	})
+	printer.AddColumn("NODES", func(u *actions.NodeGroupUsage) string {
+		return strconv.Itoa(u.Nodes)
+	})
+	printer.AddColumn("CPU ALLOCATABLE", func(u *actions.NodeGroupUsage) string {
+		return formatCPU(u.CPU.Allocatable)
+	})
+	printer.AddColumn("CPU REQUESTS", func(u *actions.NodeGroupUsage) string {
+		return formatShare(u.CPU.Requests, u.CPU.Allocatable, formatCPU)
+	})
+	printer.AddColumn("CPU LIMITS", func(u *actions.NodeGroupUsage) string {
+		return formatShare(u.CPU.Limits, u.CPU.Allocatable, formatCPU)
+	})
+	printer.AddColumn("CPU USAGE", func(u *actions.NodeGroupUsage) string {
+		if u.CPU.Usage == nil {
+			return "-"
+		}
+		return formatShare(*u.CPU.Usage, u.CPU.Allocatable, formatCPU)
+	})
+	printer.AddColumn("MEMORY ALLOCATABLE", func(u *actions.NodeGroupUsage) string {
+		return formatMemory(u.Memory.Allocatable)
+	})
+	printer.AddColumn("MEMORY REQUESTS", func(u *actions.NodeGroupUsage) string {
+		return formatShare(u.Memory.Requests, u.Memory.Allocatable, formatMemory)
+	})
+	printer.AddColumn("MEMORY LIMITS", func(u *actions.NodeGroupUsage) string {
+		return formatShare(u.Memory.Limits, u.Memory.Allocatable, formatMemory)
+	})
+	printer.AddColumn("MEMORY USAGE", func(u *actions.NodeGroupUsage) string {
+		if u.Memory.Usage == nil {
+			return "-"
+		}
+		return formatShare(*u.Memory.Usage, u.Memory.Allocatable, formatMemory)
+	})
+}
+
+func formatCPU(q resource.Quantity) string {
+	return fmt.Sprintf("%dm", q.MilliValue())
+}
+
+func formatMemory(q resource.Quantity) string {
+	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
+}
+
+// formatShare formats the quantity along with its percentage of allocatable, which
+// exceeds 100% for limits of overcommitted nodes
+func formatShare(q, allocatable resource.Quantity, format func(resource.Quantity) string) string {
+	if allocatable.IsZero() {
+		return format(q)
+	}
+	return fmt.Sprintf("%s (%d%%)", format(q), q.MilliValue()*100/allocatable.MilliValue())
+}
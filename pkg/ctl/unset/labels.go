@@ -0,0 +1,55 @@
+package unset
+
+import (
+	"context"
+
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func unsetLabelsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	opts := actions.UpdateLabelsOptions{}
+
+	rc.SetDescription("labels", "Remove labels from a nodegroup", "Labels are removed from the existing nodes, and from the nodes that are launched later")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doUnsetLabels(rc, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		fs.StringVarP(&opts.NodeGroup, "nodegroup", "n", "", "Name of the nodegroup")
+		fs.StringSliceVarP(&opts.Unset, "labels", "l", nil, `Keys of the labels to remove, e.g. "partition,nodeclass"`)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doUnsetLabels(rc *cmdutils.ResourceCmd, opts actions.UpdateLabelsOptions) error {
+	cfg := rc.ClusterConfig
+
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--cluster")
+	}
+	if opts.NodeGroup == "" {
+		return cmdutils.ErrMustBeSet("--nodegroup")
+	}
+	if len(opts.Unset) == 0 {
+		return cmdutils.ErrMustBeSet("--labels")
+	}
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	return cmdutils.UpdateLabels(context.Background(), m, rc, opts)
+}
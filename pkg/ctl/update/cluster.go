@@ -10,6 +10,7 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
 	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
 	"github.com/weaveworks/eksctl/pkg/printers"
 )
 
@@ -17,41 +18,65 @@ func updateClusterCmd(rc *cmdutils.ResourceCmd) {
 	cfg := api.NewClusterConfig()
 	rc.ClusterConfig = cfg
 
+	var (
+		noWait bool
+		output string
+	)
+
 	rc.SetDescription("cluster", "Update cluster", "")
 
 	rc.SetRunFuncWithNameArg(func() error {
-		return doUpdateClusterCmd(rc)
+		return doUpdateClusterCmd(rc, noWait, output)
 	})
 
 	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddNameFlag(fs, cfg.Metadata)
 		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
-		cmdutils.AddConfigFileFlag(fs, &rc.ClusterConfigFile)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddNotifySNSTopicFlag(fs, rc, "update cluster")
 
 		// cmdutils.AddVersionFlag(fs, cfg.Metadata, `"next" and "latest" can be used to automatically increment version by one, or force latest`)
 
 		cmdutils.AddApproveFlag(fs, rc)
-		fs.BoolVar(&rc.Plan, "dry-run", rc.Plan, "")
-		fs.MarkDeprecated("dry-run", "see --aprove")
 
 		rc.Wait = true
 		cmdutils.AddWaitFlag(fs, &rc.Wait, "all update operations to complete")
+		cmdutils.AddNoWaitFlags(fs, &noWait, &output, "the version update of the control plane")
 	})
 
 	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
 
 }
 
-func doUpdateClusterCmd(rc *cmdutils.ResourceCmd) error {
+func doUpdateClusterCmd(rc *cmdutils.ResourceCmd, noWait bool, output string) error {
 	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
 		return err
 	}
 
+	var submittedPrinter printers.OutputPrinter
+	if noWait {
+		if rc.Command.Flag("wait").Changed && rc.Wait {
+			return eksctlerrors.NewValidationError("--wait and --no-wait %s", cmdutils.IncompatibleFlags)
+		}
+		rc.Wait = false
+		var err error
+		if submittedPrinter, err = cmdutils.NewSubmittedOperationsPrinter(output); err != nil {
+			return err
+		}
+	}
+
 	cfg := rc.ClusterConfig
 	meta := rc.ClusterConfig.Metadata
 
+	if err := api.ValidateControlPlaneIngressRules(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
 	printer := printers.NewJSONPrinter()
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
 
 	if !ctl.IsSupportedRegion() {
 		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
@@ -102,32 +127,50 @@ func doUpdateClusterCmd(rc *cmdutils.ResourceCmd) error {
 	if err != nil {
 		return err
 	}
+	if stackUpdateRequired {
+		rc.AddPlannedAction(cmdutils.ClusterAction("update-cluster-stack", meta, nil))
+	}
 
 	if err := ctl.ValidateExistingNodeGroupsForCompatibility(cfg, stackManager); err != nil {
-		logger.Critical("failed checking nodegroups", err.Error())
+		logger.Critical("failed checking nodegroups: %s", err.Error())
 	}
 
+	submitted := []*cmdutils.SubmittedOperation{}
 	if versionUpdateRequired {
 		msgNodeGroupsAndAddons := "you will need to follow the upgrade procedure for all of nodegroups and add-ons"
-		cmdutils.LogIntendedAction(rc.Plan, "upgrade cluster %q control plane from current version %q to %q", cfg.Metadata.Name, currentVersion, cfg.Metadata.Version)
+		rc.LogIntendedAction(cmdutils.ClusterAction("upgrade-control-plane", meta, map[string]string{
+			"currentVersion": currentVersion,
+			"version":        cfg.Metadata.Version,
+		}), "upgrade cluster %q control plane from current version %q to %q", cfg.Metadata.Name, currentVersion, cfg.Metadata.Version)
 		if !rc.Plan {
 			if rc.Wait {
 				if err := ctl.UpdateClusterVersionBlocking(cfg); err != nil {
 					return err
 				}
 				logger.Success("cluster %q control plane has been upgraded to version %q", cfg.Metadata.Name, cfg.Metadata.Version)
-				logger.Info(msgNodeGroupsAndAddons)
+				logger.Info("%s", msgNodeGroupsAndAddons)
 			} else {
-				if _, err := ctl.UpdateClusterVersion(cfg); err != nil {
+				updateID, err := ctl.UpdateClusterVersion(cfg)
+				if err != nil {
 					return err
 				}
 				logger.Success("a version update operation has been requested for cluster %q", cfg.Metadata.Name)
-				logger.Info("once it has been updated, %s", cfg.Metadata.Name, msgNodeGroupsAndAddons)
+				logger.Info("once it has been updated, %s", msgNodeGroupsAndAddons)
+				submitted = append(submitted, &cmdutils.SubmittedOperation{
+					Resource:    "update",
+					Name:        meta.Name,
+					Operation:   "update",
+					ID:          updateID,
+					WaitCommand: fmt.Sprintf("eksctl utils wait update --region=%s --name=%s --update-id=%s", meta.Region, meta.Name, updateID),
+				})
 			}
 		}
 	}
 
 	cmdutils.LogPlanModeWarning(rc.Plan && (stackUpdateRequired || versionUpdateRequired))
 
+	if noWait {
+		return cmdutils.PrintSubmittedOperations(submittedPrinter, submitted)
+	}
 	return nil
 }
@@ -0,0 +1,227 @@
+package upgrade
+
+import (
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/drain"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/ssh"
+)
+
+func upgradeNodeGroupCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	ng := cfg.NewNodeGroup()
+	rc.ClusterConfig = cfg
+
+	var (
+		oldName string
+		force   bool
+	)
+
+	rc.SetDescription("nodegroup", "Replace a nodegroup with a new one without dropping workloads",
+		"Creates a new nodegroup, waits for its nodes to become ready, drains the old nodegroup and deletes it", "ng")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doUpgradeNodeGroup(rc, &oldName, ng, force)
+	})
+
+	exampleNodeGroupName := cmdutils.NodeGroupName("", "")
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVarP(&oldName, "name", "n", "", "name of the nodegroup to replace")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddNotifySNSTopicFlag(fs, rc, "upgrade nodegroup")
+		cmdutils.AddVersionSkewForceFlag(fs, &force)
+	})
+
+	rc.FlagSetGroup.InFlagSet("New nodegroup", func(fs *pflag.FlagSet) {
+		fs.StringVar(&ng.Name, "new-name", "", fmt.Sprintf("name of the replacement nodegroup (generated if unspecified, e.g. %q); when a config file is used, it must be defined in the file", exampleNodeGroupName))
+		cmdutils.AddCommonCreateNodeGroupFlags(fs, rc, ng)
+	})
+
+	rc.FlagSetGroup.InFlagSet("IAM addons", func(fs *pflag.FlagSet) {
+		cmdutils.AddCommonCreateNodeGroupIAMAddonsFlags(fs, ng)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
+}
+
+func doUpgradeNodeGroup(rc *cmdutils.ResourceCmd, oldName *string, newNG *api.NodeGroup, force bool) error {
+	ngFilter := cmdutils.NewNodeGroupFilter()
+
+	if err := cmdutils.NewUpgradeNodeGroupLoader(rc, oldName, newNG, ngFilter).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	if err := ngFilter.ValidateNodeGroupsAndSetDefaults(cfg.NodeGroups); err != nil {
+		return err
+	}
+	if err := api.ValidateContainerRuntime(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateProxy(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateNodeRoles(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+	if err := api.ValidateNodeGroupDependencies(cfg); err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	printer := printers.NewJSONPrinter()
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+
+	// the replacement nodegroup always follows the control plane version
+	if meta.Version = ctl.ControlPlaneVersion(); meta.Version == "" {
+		return fmt.Errorf("unable to get control plane version")
+	}
+	logger.Info("will use version %s for the new nodegroup based on control plane version", meta.Version)
+
+	if err := ctl.GetClusterVPC(cfg); err != nil {
+		return errors.Wrapf(err, "getting VPC configuration for cluster %q", meta.Name)
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+
+	existing, err := stackManager.ListNodeGroupStacks()
+	if err != nil {
+		return err
+	}
+	existingNames := sets.NewString(existing...)
+	if !existingNames.Has(*oldName) {
+		return fmt.Errorf("nodegroup %q not found in cluster %q", *oldName, meta.Name)
+	}
+
+	var newNodeGroup *api.NodeGroup
+	err = ngFilter.ForEach(cfg.NodeGroups, func(_ int, ng *api.NodeGroup) error {
+		if existingNames.Has(ng.Name) {
+			return fmt.Errorf("nodegroup %q already exists in cluster %q, use a different --new-name", ng.Name, meta.Name)
+		}
+		newNodeGroup = ng
+
+		// resolve AMI
+		if err := ctl.EnsureAMI(meta.Version, ng); err != nil {
+			return err
+		}
+		logger.Info("nodegroup %q will use %q [%s/%s]", ng.Name, ng.AMI, ng.AMIFamily, meta.Version)
+		if err := cmdutils.CheckNodeGroupVersionSkew(ctl, ng, meta.Version, meta.Version, force); err != nil {
+			return err
+		}
+
+		if err := ctl.SetNodeLabels(ng, meta); err != nil {
+			return err
+		}
+
+		return ssh.LoadKeyForNodeGroup(ng, meta.Name, ctl.Provider)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := printer.LogObj(logger.Debug, "cfg.json = \\\n%s\n", cfg); err != nil {
+		return err
+	}
+
+	if err := ctl.ValidateClusterForCompatibility(cfg, stackManager); err != nil {
+		return errors.Wrap(err, "cluster compatibility check failed")
+	}
+
+	logger.Info("will replace nodegroup %q with nodegroup %q in cluster %q", *oldName, newNodeGroup.Name, meta.Name)
+
+	{ // create the new nodegroup
+		tasks := stackManager.NewTasksToCreateNodeGroups(sets.NewString(newNodeGroup.Name))
+		logger.Info("%s", tasks.Describe())
+		if errs := tasks.DoAllSync(); len(errs) > 0 {
+			for _, err := range errs {
+				if err != nil {
+					logger.Critical("%s\n", err.Error())
+				}
+			}
+			logger.Info("nodegroup %q was left untouched, to cleanup run 'eksctl delete nodegroup --region=%s --cluster=%s --name=%s'", *oldName, meta.Region, meta.Name, newNodeGroup.Name)
+			return fmt.Errorf("failed to create nodegroup %q for cluster %q", newNodeGroup.Name, meta.Name)
+		}
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	// authorise new nodes to join and wait for them before touching the old nodegroup
+	if err := authconfigmap.AddNodeGroup(clientSet, newNodeGroup); err != nil {
+		return err
+	}
+	if err := ctl.WaitForNodes(clientSet, newNodeGroup); err != nil {
+		return errors.Wrapf(err, "nodegroup %q was left untouched", *oldName)
+	}
+	logger.Success("created nodegroup %q in cluster %q", newNodeGroup.Name, meta.Name)
+
+	oldNodeGroup := &api.NodeGroup{Name: *oldName}
+
+	logger.Info("draining nodegroup %q", oldNodeGroup.Name)
+	if err := drain.NodeGroup(clientSet, oldNodeGroup, ctl.Provider.WaitTimeout(), false); err != nil {
+		return err
+	}
+
+	if err := ctl.GetNodeGroupIAM(stackManager, cfg, oldNodeGroup); err != nil {
+		logger.Warning("error getting instance role ARN for nodegroup %q", oldNodeGroup.Name)
+	} else if oldNodeGroup.IAM.InstanceRoleARN != newNodeGroup.IAM.InstanceRoleARN {
+		if err := authconfigmap.RemoveNodeGroup(clientSet, oldNodeGroup); err != nil {
+			logger.Warning("%s", err.Error())
+		}
+	}
+
+	{ // delete the old nodegroup
+		tasks, err := stackManager.NewTasksToDeleteNodeGroups(sets.NewString(oldNodeGroup.Name), true, nil)
+		if err != nil {
+			return err
+		}
+		logger.Info("%s", tasks.Describe())
+		if errs := tasks.DoAllSync(); len(errs) > 0 {
+			for _, err := range errs {
+				logger.Critical("%s\n", err.Error())
+			}
+			return fmt.Errorf("failed to delete nodegroup %q from cluster %q", oldNodeGroup.Name, meta.Name)
+		}
+	}
+
+	logger.Success("replaced nodegroup %q with nodegroup %q in cluster %q", oldNodeGroup.Name, newNodeGroup.Name, meta.Name)
+
+	if err := ctl.ValidateExistingNodeGroupsForCompatibility(cfg, stackManager); err != nil {
+		logger.Critical("failed checking nodegroups: %s", err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+func associateIAMOIDCProviderCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var disassociate bool
+
+	rc.SetDescription("associate-iam-oidc-provider", "Setup IAM OIDC provider for a cluster to enable IAM roles for pods",
+		"Creates an IAM OIDC provider for the OIDC issuer of a cluster, with the thumbprint of the root CA the issuer "+
+			"currently serves; when the provider exists, its thumbprint is refreshed if the root CA changed")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doAssociateIAMOIDCProvider(rc, disassociate)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.BoolVar(&disassociate, "disassociate", false, "delete the IAM OIDC provider of the cluster instead")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doAssociateIAMOIDCProvider(rc *cmdutils.ResourceCmd, disassociate bool) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	// the EKS API doesn't return the issuer, it's an output of the cluster stack
+	stack, err := ctl.NewStackManager(cfg).DescribeClusterStackByName()
+	if err != nil {
+		return err
+	}
+	if err := eks.SetClusterStatusFromStack(cfg, stack); err != nil {
+		return err
+	}
+	issuerURL := cfg.Status.OIDCIssuerURL
+	if issuerURL == "" {
+		return fmt.Errorf("the OIDC issuer of cluster %q is unknown, as its stack predates the %q output; run 'eksctl update cluster --name=%s --approve' to add it",
+			meta.Name, outputs.ClusterOIDCIssuerURL, meta.Name)
+	}
+
+	var updateRequired bool
+	if disassociate {
+		cmdutils.LogIntendedAction(rc.Plan, "delete IAM OIDC provider for %q of cluster %q", issuerURL, meta.Name)
+		updateRequired, err = iam.DisassociateOIDCProvider(ctl.Provider, issuerURL, rc.Plan)
+	} else {
+		thumbprint, thumbprintErr := iam.OIDCThumbprint(issuerURL, ctl.HTTPClient())
+		if thumbprintErr != nil {
+			return thumbprintErr
+		}
+		cmdutils.LogIntendedAction(rc.Plan, "create IAM OIDC provider for %q of cluster %q", issuerURL, meta.Name)
+		updateRequired, err = iam.AssociateOIDCProvider(ctl.Provider, issuerURL, thumbprint, rc.Plan)
+	}
+	if err != nil {
+		return err
+	}
+	if updateRequired {
+		actionType := "associate-iam-oidc-provider"
+		if disassociate {
+			actionType = "disassociate-iam-oidc-provider"
+		}
+		rc.AddPlannedAction(cmdutils.ClusterAction(actionType, meta, map[string]string{"issuer": issuerURL}))
+	}
+
+	cmdutils.LogPlanModeWarning(rc.Plan && updateRequired)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package utils
+
+import (
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/backup"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func backupClusterCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var bucket, prefix string
+
+	rc.SetDescription("backup-cluster", "Save a snapshot of the definition of a cluster to S3",
+		"Saves the cluster config, the templates of all stacks and the aws-auth ConfigMap of the cluster, to restore it with 'eksctl utils restore-cluster'")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doBackupCluster(rc, bucket, prefix)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		addSnapshotStoreFlags(fs, &bucket, &prefix)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func addSnapshotStoreFlags(fs *pflag.FlagSet, bucket, prefix *string) {
+	fs.StringVar(bucket, "s3-bucket", "", "S3 bucket the snapshots are stored in")
+	fs.StringVar(prefix, "s3-prefix", backup.DefaultPrefix, "prefix of the snapshots in the S3 bucket")
+}
+
+func doBackupCluster(rc *cmdutils.ResourceCmd, bucket, prefix string) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	if bucket == "" {
+		return cmdutils.ErrMustBeSet("--s3-bucket")
+	}
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	cluster, err := ctl.DescribeControlPlaneMustBeActive(meta)
+	if err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+
+	if err := ctl.GetClusterVPC(cfg); err != nil {
+		return errors.Wrapf(err, "getting VPC configuration for cluster %q", meta.Name)
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+	summaries, err := stackManager.GetNodeGroupSummaries("")
+	if err != nil {
+		return errors.Wrapf(err, "getting nodegroups of cluster %q", meta.Name)
+	}
+
+	stacks, err := stackManager.SnapshotStacks()
+	if err != nil {
+		return errors.Wrapf(err, "getting stacks of cluster %q", meta.Name)
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+	authConfigMap, err := backup.GetAuthConfigMap(clientSet)
+	if err != nil {
+		return err
+	}
+	if authConfigMap == nil {
+		logger.Warning("cluster %q has no aws-auth ConfigMap, no IAM identity mappings will be saved", meta.Name)
+	}
+
+	snapshot := backup.NewSnapshot(backup.ReconstructClusterConfig(cfg, cluster, summaries), stacks, authConfigMap)
+
+	store := backup.NewStore(ctl.Provider.S3(), bucket, prefix)
+	if err := store.Save(snapshot); err != nil {
+		return errors.Wrapf(err, "saving snapshot of cluster %q", meta.Name)
+	}
+
+	logger.Success("saved snapshot %q of cluster %q with %d stack(s) to %s", snapshot.Metadata.ID, meta.Name, len(stacks), store.URL(snapshot.Metadata))
+	return nil
+}
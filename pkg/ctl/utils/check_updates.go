@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"context"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func checkUpdatesCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var output string
+
+	rc.SetDescription("check-updates", "Check the cluster for pending updates",
+		"Compares the Kubernetes and platform versions of the cluster, its default add-ons and the AMIs of its nodegroups "+
+			"with the latest ones, and exits with code 7 when any of them can be updated")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doCheckUpdates(rc, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doCheckUpdates(rc *cmdutils.ResourceCmd, output string) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	updates, err := m.CheckUpdates(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addComponentUpdateColumns(columnPrinter)
+	}
+	if err := printer.PrintObjWithKind("updates", updates, os.Stdout); err != nil {
+		return err
+	}
+
+	pending := 0
+	for _, u := range updates {
+		if u.UpdateAvailable() {
+			pending++
+		}
+	}
+	if pending > 0 {
+		return eksctlerrors.New(eksctlerrors.ClassUpdatesPending, "%d update(s) pending for cluster %q", pending, meta.Name)
+	}
+	logger.Success("cluster %q is up-to-date", meta.Name)
+	return nil
+}
+
+func addComponentUpdateColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("KIND", func(u *actions.ComponentUpdate) string {
+		return u.Kind
+	})
+	printer.AddColumn("NAME", func(u *actions.ComponentUpdate) string {
+		return u.Name
+	})
+	printer.AddColumn("CURRENT", func(u *actions.ComponentUpdate) string {
+		return u.Current
+	})
+	printer.AddColumn("LATEST", func(u *actions.ComponentUpdate) string {
+		if u.Latest == "" {
+			return "unknown"
+		}
+		return u.Latest
+	})
+	printer.AddColumn("ACTION", func(u *actions.ComponentUpdate) string {
+		return u.Action
+	})
+}
@@ -38,7 +38,10 @@ func describeStacksCmd(rc *cmdutils.ResourceCmd) {
 func doDescribeStacksCmd(rc *cmdutils.ResourceCmd, all, events, trail bool) error {
 	cfg := rc.ClusterConfig
 
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
 
 	if err := ctl.CheckAuth(); err != nil {
 		return err
@@ -75,7 +78,7 @@ func doDescribeStacksCmd(rc *cmdutils.ResourceCmd, all, events, trail bool) erro
 		if events {
 			events, err := stackManager.DescribeStackEvents(s)
 			if err != nil {
-				logger.Critical(err.Error())
+				logger.Critical("%s", err.Error())
 			}
 			for i, e := range events {
 				logger.Info("CloudFormation.events/%s[%d] = %#v", *s.StackName, i, e)
@@ -84,7 +87,7 @@ func doDescribeStacksCmd(rc *cmdutils.ResourceCmd, all, events, trail bool) erro
 		if trail {
 			events, err := stackManager.LookupCloudTrailEvents(s)
 			if err != nil {
-				logger.Critical(err.Error())
+				logger.Critical("%s", err.Error())
 			}
 			for i, e := range events {
 				logger.Info("CloudTrail.events/%s[%d] = %#v", *s.StackName, i, e)
@@ -0,0 +1,117 @@
+package utils
+
+import (
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/addons/albingress"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+func enableALBIngressControllerCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var attachNodeRolePolicy, tagSubnets bool
+
+	rc.SetDescription("enable-alb-ingress-controller", "Deploy ALB Ingress Controller to provision Application Load Balancers for Ingress resources", "")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doEnableALBIngressController(rc, attachNodeRolePolicy, tagSubnets)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+		fs.BoolVar(&attachNodeRolePolicy, "attach-node-role-policy", true, "Put the IAM policy of the controller on instance roles of all nodegroups")
+		fs.BoolVar(&tagSubnets, "tag-subnets", true, "Tag the subnets of the cluster for discovery by the controller")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doEnableALBIngressController(rc *cmdutils.ResourceCmd, attachNodeRolePolicy, tagSubnets bool) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+
+	if err := ctl.GetClusterVPC(cfg); err != nil {
+		return errors.Wrapf(err, "getting VPC configuration for cluster %q", meta.Name)
+	}
+
+	policyUpdateRequired := false
+	if attachNodeRolePolicy {
+		stacks, err := ctl.NewStackManager(cfg).DescribeNodeGroupStacks()
+		if err != nil {
+			return err
+		}
+		cmdutils.LogIntendedAction(rc.Plan, "put policy %q on instance roles of %d nodegroup(s) in cluster %q", albingress.PolicyName, len(stacks), meta.Name)
+		policyUpdateRequired, err = albingress.AttachNodeRolePolicy(ctl.Provider, stacks, rc.Plan)
+		if err != nil {
+			return err
+		}
+		if policyUpdateRequired {
+			rc.AddPlannedAction(cmdutils.ClusterAction("put-node-role-policy", meta, map[string]string{"policy": albingress.PolicyName}))
+		}
+	} else {
+		logger.Warning("make sure the controller is allowed to manage load balancers, e.g. with the %q addon policy of nodegroups", "albIngress")
+	}
+
+	subnetsUpdateRequired := false
+	if tagSubnets {
+		cmdutils.LogIntendedAction(rc.Plan, "tag subnets of cluster %q for discovery by ALB Ingress Controller", meta.Name)
+		var err error
+		subnetsUpdateRequired, err = vpc.TagSubnets(ctl.Provider, cfg, rc.Plan)
+		if err != nil {
+			return err
+		}
+		if subnetsUpdateRequired {
+			rc.AddPlannedAction(cmdutils.ClusterAction("tag-subnets", meta, nil))
+		}
+	}
+
+	rawClient, err := ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	cmdutils.LogIntendedAction(rc.Plan, "deploy ALB Ingress Controller to cluster %q", meta.Name)
+	deployRequired, err := albingress.Deploy(rawClient, cfg, rc.Plan)
+	if err != nil {
+		return err
+	}
+	if deployRequired {
+		rc.AddPlannedAction(cmdutils.ClusterAction("deploy-alb-ingress-controller", meta, nil))
+	}
+
+	cmdutils.LogPlanModeWarning(rc.Plan && (policyUpdateRequired || subnetsUpdateRequired || deployRequired))
+
+	return nil
+}
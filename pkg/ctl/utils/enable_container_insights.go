@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/addons/containerinsights"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+func enableContainerInsightsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var attachNodeRolePolicy, withServiceAccountRole bool
+
+	rc.SetDescription("enable-container-insights", "Deploy CloudWatch agent and Fluent Bit to enable CloudWatch Container Insights", "")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doEnableContainerInsights(rc, attachNodeRolePolicy, withServiceAccountRole)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+		fs.BoolVar(&attachNodeRolePolicy, "attach-node-role-policy", true, "Attach CloudWatchAgentServerPolicy to instance roles of all nodegroups")
+		fs.BoolVar(&withServiceAccountRole, "iam-role-for-service-accounts", false, "Create an IAM role with CloudWatchAgentServerPolicy for the service accounts of CloudWatch agent and Fluent Bit instead, the IAM OIDC provider of the cluster must exist")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doEnableContainerInsights(rc *cmdutils.ResourceCmd, attachNodeRolePolicy, withServiceAccountRole bool) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	if withServiceAccountRole {
+		if rc.Command.Flag("attach-node-role-policy").Changed && attachNodeRolePolicy {
+			return eksctlerrors.NewValidationError("--iam-role-for-service-accounts and --attach-node-role-policy %s", cmdutils.IncompatibleFlags)
+		}
+		attachNodeRolePolicy = false
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+
+	policyUpdateRequired, roleARN := false, ""
+	if withServiceAccountRole {
+		policyUpdateRequired, roleARN, err = ensureContainerInsightsRole(rc, ctl, stackManager)
+		if err != nil {
+			return err
+		}
+	} else if attachNodeRolePolicy {
+		stacks, err := stackManager.DescribeNodeGroupStacks()
+		if err != nil {
+			return err
+		}
+		cmdutils.LogIntendedAction(rc.Plan, "attach %q to instance roles of %d nodegroup(s) in cluster %q", containerinsights.CloudWatchAgentServerPolicy, len(stacks), meta.Name)
+		policyUpdateRequired, err = containerinsights.AttachNodeRolePolicy(ctl.Provider, stacks, rc.Plan)
+		if err != nil {
+			return err
+		}
+		if policyUpdateRequired {
+			rc.AddPlannedAction(cmdutils.ClusterAction("attach-node-role-policy", meta, map[string]string{"policy": containerinsights.CloudWatchAgentServerPolicy}))
+		}
+	} else {
+		logger.Warning("make sure nodes are allowed to publish metrics and logs, e.g. by attaching %q to their instance roles", containerinsights.CloudWatchAgentServerPolicy)
+	}
+
+	rawClient, err := ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	cmdutils.LogIntendedAction(rc.Plan, "deploy CloudWatch agent and Fluent Bit to cluster %q", meta.Name)
+	deployRequired, err := containerinsights.Deploy(rawClient, cfg, roleARN, rc.Plan)
+	if err != nil {
+		return err
+	}
+	if deployRequired {
+		rc.AddPlannedAction(cmdutils.ClusterAction("deploy-container-insights", meta, nil))
+	}
+
+	cmdutils.LogPlanModeWarning(rc.Plan && (policyUpdateRequired || deployRequired))
+
+	return nil
+}
+
+// ensureContainerInsightsRole creates the IAM role of the service accounts of Container Insights,
+// it returns true when changes are required in plan mode, along with the ARN of the role, which is
+// unknown until it's created
+func ensureContainerInsightsRole(rc *cmdutils.ResourceCmd, ctl *eks.ClusterProvider, stackManager *manager.StackCollection) (bool, string, error) {
+	cfg := rc.ClusterConfig
+	meta := cfg.Metadata
+
+	// the EKS API doesn't return the issuer, it's an output of the cluster stack
+	stack, err := stackManager.DescribeClusterStackByName()
+	if err != nil {
+		return false, "", err
+	}
+	if err := eks.SetClusterStatusFromStack(cfg, stack); err != nil {
+		return false, "", err
+	}
+	if cfg.Status.OIDCIssuerURL == "" {
+		return false, "", fmt.Errorf("the OIDC issuer of cluster %q is unknown, as its stack predates the %q output; run 'eksctl update cluster --name=%s --approve' to add it",
+			meta.Name, "OIDCIssuerURL", meta.Name)
+	}
+	oidcProviderARN, err := iam.FindOIDCProvider(ctl.Provider, cfg.Status.OIDCIssuerURL)
+	if err != nil {
+		return false, "", err
+	}
+	if oidcProviderARN == "" {
+		return false, "", fmt.Errorf("cluster %q has no IAM OIDC provider, run 'eksctl utils associate-iam-oidc-provider --name=%s --approve' first", meta.Name, meta.Name)
+	}
+
+	roleARN, err := stackManager.GetContainerInsightsRoleARN()
+	if err != nil {
+		return false, "", err
+	}
+	if roleARN != "" {
+		logger.Info("using IAM role %q for the service accounts of Container Insights", roleARN)
+		return false, roleARN, nil
+	}
+
+	cmdutils.LogIntendedAction(rc.Plan, "create IAM role with %q for the service accounts of Container Insights in cluster %q", containerinsights.CloudWatchAgentServerPolicy, meta.Name)
+	if rc.Plan {
+		rc.AddPlannedAction(cmdutils.ClusterAction("create-container-insights-role", meta, map[string]string{"policy": containerinsights.CloudWatchAgentServerPolicy}))
+		return true, "", nil
+	}
+	roleARN, err = stackManager.CreateContainerInsightsStack(oidcProviderARN, containerinsights.ServiceAccounts())
+	if err != nil {
+		return false, "", err
+	}
+	logger.Info("created IAM role %q for the service accounts of Container Insights", roleARN)
+	return false, roleARN, nil
+}
@@ -0,0 +1,114 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/addons/ebscsi"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func enableEBSCSIDriverCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var attachNodeRolePolicy bool
+	opts := ebscsi.Options{}
+
+	rc.SetDescription("enable-ebs-csi-driver", "Deploy EBS CSI driver, to migrate from the in-tree EBS volume plugin", "")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doEnableEBSCSIDriver(rc, attachNodeRolePolicy, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+		fs.BoolVar(&attachNodeRolePolicy, "attach-node-role-policy", true, "Put the IAM policy of the driver on instance roles of all nodegroups")
+		fs.BoolVar(&opts.SetDefaultStorageClass, "set-default-storage-class", false, fmt.Sprintf("Create StorageClass %q of the driver and make it the default one", ebscsi.StorageClassName))
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doEnableEBSCSIDriver(rc *cmdutils.ResourceCmd, attachNodeRolePolicy bool, opts ebscsi.Options) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+
+	policyUpdateRequired := false
+	if attachNodeRolePolicy {
+		stacks, err := ctl.NewStackManager(cfg).DescribeNodeGroupStacks()
+		if err != nil {
+			return err
+		}
+		cmdutils.LogIntendedAction(rc.Plan, "put policy %q on instance roles of %d nodegroup(s) in cluster %q", ebscsi.PolicyName, len(stacks), meta.Name)
+		policyUpdateRequired, err = ebscsi.AttachNodeRolePolicy(ctl.Provider, stacks, rc.Plan)
+		if err != nil {
+			return err
+		}
+		if policyUpdateRequired {
+			rc.AddPlannedAction(cmdutils.ClusterAction("put-node-role-policy", meta, map[string]string{"policy": ebscsi.PolicyName}))
+		}
+	} else {
+		logger.Warning("make sure the driver is allowed to manage EBS volumes, e.g. with the %q addon policy of nodegroups", "ebs")
+	}
+
+	rawClient, err := ctl.NewRawClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	cmdutils.LogIntendedAction(rc.Plan, "deploy EBS CSI driver to cluster %q", meta.Name)
+	opts.Plan = rc.Plan
+	deployRequired, err := ebscsi.Deploy(rawClient, cfg, opts)
+	if err != nil {
+		return err
+	}
+	if deployRequired {
+		rc.AddPlannedAction(cmdutils.ClusterAction("deploy-ebs-csi-driver", meta, nil))
+	}
+
+	problems, err := ebscsi.VerifyInTreeVolumes(rawClient.ClientSet())
+	if err != nil {
+		return err
+	}
+	for _, problem := range problems {
+		logger.Warning("%s", problem)
+	}
+
+	cmdutils.LogPlanModeWarning(rc.Plan && (policyUpdateRequired || deployRequired))
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%d volume(s) of the in-tree EBS plugin may not be accessible", len(problems))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/iam"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func generateIAMPolicyCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var output string
+
+	rc.SetDescription("generate-iam-policy", "Generate a minimal IAM policy for running eksctl",
+		"Prints a policy document with the actions eksctl needs to create, update and delete the cluster of the config file, "+
+			"scoped to the resources eksctl names, to grant to the principal that runs eksctl, e.g. a CI role, in place of AdministratorAccess")
+
+	rc.SetRunFunc(func() error {
+		return doGenerateIAMPolicy(rc, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, rc)
+		fs.StringVar(&rc.ClusterConfigFile, "for", "", "load the config of the cluster to generate the policy for from the given file, same as --config-file")
+		fs.StringVarP(&output, "output", "o", "json", "specifies the output format (valid option: json, yaml)")
+	})
+}
+
+func doGenerateIAMPolicy(rc *cmdutils.ResourceCmd, output string) error {
+	if rc.ClusterConfigFile == "" {
+		return cmdutils.ErrMustBeSet("--for")
+	}
+
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+	cfg := rc.ClusterConfig
+
+	if output != "json" && output != "yaml" {
+		return eksctlerrors.NewValidationError("--output=%s is not supported - use one of: json, yaml", output)
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	return printer.PrintObj(iam.EksctlPolicyDocument(cfg), os.Stdout)
+}
@@ -0,0 +1,116 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/printers"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+// highUtilization is the percentage of used addresses above which a subnet is reported
+const highUtilization = 80
+
+func ipUsageCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var output string
+
+	rc.SetDescription("ip-usage", "Report the IP address utilization of the subnets of a cluster", "")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doIPUsage(rc, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format (valid option: table, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doIPUsage(rc *cmdutils.ResourceCmd, output string) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetClusterVPC(cfg); err != nil {
+		return errors.Wrapf(err, "getting VPC configuration for cluster %q", meta.Name)
+	}
+
+	usages, err := vpc.GetClusterSubnetUsage(ctl.Provider, cfg)
+	if err != nil {
+		return err
+	}
+
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addIPUsageTableColumns(columnPrinter)
+	}
+
+	if err := printer.PrintObjWithKind("subnets", usages, os.Stdout); err != nil {
+		return err
+	}
+
+	for _, s := range usages {
+		if s.Utilization() >= highUtilization {
+			logger.Warning("subnet %q (%s) has only %d free IP addresses left, new nodes and pods in %s may fail to start", s.ID, s.AvailabilityZone, s.Free, s.AvailabilityZone)
+		}
+	}
+	return nil
+}
+
+func addIPUsageTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("SUBNET", func(s *vpc.SubnetUsage) string {
+		return s.ID
+	})
+	printer.AddColumn("TYPE", func(s *vpc.SubnetUsage) string {
+		return string(s.Topology)
+	})
+	printer.AddColumn("ZONE", func(s *vpc.SubnetUsage) string {
+		return s.AvailabilityZone
+	})
+	printer.AddColumn("CIDR", func(s *vpc.SubnetUsage) string {
+		return s.CIDR
+	})
+	printer.AddColumn("USED", func(s *vpc.SubnetUsage) string {
+		return fmt.Sprintf("%d", s.Used())
+	})
+	printer.AddColumn("FREE", func(s *vpc.SubnetUsage) string {
+		return fmt.Sprintf("%d", s.Free)
+	})
+	printer.AddColumn("UTILIZATION", func(s *vpc.SubnetUsage) string {
+		return fmt.Sprintf("%.0f%%", s.Utilization())
+	})
+}
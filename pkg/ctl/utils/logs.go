@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/utils/file"
+)
+
+func logsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var outputFile string
+	opts := actions.SupportBundleOptions{}
+
+	rc.SetDescription("logs", "Generate a support bundle of the cluster",
+		"Collects the description of the cluster, the templates and events of its stacks, the aws-auth ConfigMap, "+
+			"the versions of the default add-ons and the status of nodes and kube-system daemonsets into a tarball "+
+			"to attach to bug reports")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doLogs(rc, outputFile, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		fs.StringVar(&outputFile, "output-file", "", "path of the tarball (default \"eksctl-<clusterName>-support-<time>.tar.gz\")")
+		fs.BoolVar(&opts.Redact, "redact", false, "replace AWS account IDs and ARNs of resources of the account")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doLogs(rc *cmdutils.ResourceCmd, outputFile string, opts actions.SupportBundleOptions) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	files, err := m.SupportBundle(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	if _, ok := files["errors.txt"]; ok {
+		logger.Warning("some information couldn't be collected, see errors.txt in the bundle")
+	}
+
+	if outputFile == "" {
+		outputFile = fmt.Sprintf("eksctl-%s-support-%s.tar.gz", meta.Name, time.Now().UTC().Format("20060102T150405Z"))
+	}
+	if err := file.WriteTarball(outputFile, files); err != nil {
+		return err
+	}
+	logger.Success("saved support bundle of cluster %q to %q", meta.Name, outputFile)
+	return nil
+}
@@ -0,0 +1,123 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func nodeGroupASGSuspendCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	opts := actions.NodeGroupProcessesOptions{}
+
+	rc.SetDescription("nodegroup-asg-suspend", "Suspend processes of the auto scaling group of a nodegroup for maintenance",
+		"Suspends the "+strings.Join(actions.MaintenanceProcesses, ", ")+" processes of the auto scaling group of a nodegroup, "+
+			"or those given with --processes, so that no instances are launched or terminated during maintenance; "+
+			"use 'eksctl utils nodegroup-asg-resume' to resume them")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doNodeGroupASGProcesses(rc, opts, true)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&opts.NodeGroup, "nodegroup", "", "name of the nodegroup")
+		fs.StringSliceVar(&opts.Processes, "processes", nil, "processes to suspend (default "+strings.Join(actions.MaintenanceProcesses, ",")+")")
+		fs.BoolVar(&opts.Force, "force", false, "suspend processes while the nodegroup is launching or terminating instances")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func nodeGroupASGResumeCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	opts := actions.NodeGroupProcessesOptions{}
+
+	rc.SetDescription("nodegroup-asg-resume", "Resume processes of the auto scaling group of a nodegroup after maintenance",
+		"Resumes the processes suspended by 'eksctl utils nodegroup-asg-suspend', or those given with --processes; "+
+			"processes suspended by other means, e.g. AZRebalance of nodegroups with azRebalance disabled, stay suspended")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doNodeGroupASGProcesses(rc, opts, false)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&opts.NodeGroup, "nodegroup", "", "name of the nodegroup")
+		fs.StringSliceVar(&opts.Processes, "processes", nil, "processes to resume (default: those suspended by nodegroup-asg-suspend)")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doNodeGroupASGProcesses(rc *cmdutils.ResourceCmd, opts actions.NodeGroupProcessesOptions, suspend bool) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+	if opts.NodeGroup == "" {
+		return cmdutils.ErrMustBeSet("--nodegroup")
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	verb, actionType := "resume", "resume-asg-processes"
+	operation := m.ResumeNodeGroupProcesses
+	if suspend {
+		verb, actionType = "suspend", "suspend-asg-processes"
+		operation = m.SuspendNodeGroupProcesses
+	}
+
+	opts.Plan = rc.Plan
+	update, err := operation(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	if len(update.Processes) == 0 {
+		if suspend {
+			logger.Success("requested processes of nodegroup %q are already suspended", opts.NodeGroup)
+		} else {
+			logger.Success("no processes of nodegroup %q were suspended for maintenance", opts.NodeGroup)
+		}
+		return nil
+	}
+
+	processes := strings.Join(update.Processes, ", ")
+	cmdutils.LogIntendedAction(rc.Plan, "%s processes %s of auto scaling group %q of nodegroup %q", verb, processes, update.AutoScalingGroup, opts.NodeGroup)
+	action := cmdutils.NodeGroupAction(actionType, meta, opts.NodeGroup)
+	action.Parameters["processes"] = strings.Join(update.Processes, ",")
+	rc.AddPlannedAction(action)
+
+	if !rc.Plan {
+		logger.Success("%sd processes %s of nodegroup %q", verb, processes, opts.NodeGroup)
+		if suspend {
+			logger.Warning("nodes of nodegroup %q are not replaced while it's in maintenance, run 'eksctl utils nodegroup-asg-resume --name=%s --nodegroup=%s --approve' when maintenance ends", opts.NodeGroup, meta.Name, opts.NodeGroup)
+		}
+	}
+
+	cmdutils.LogPlanModeWarning(rc.Plan)
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/health"
+)
+
+func nodeGroupHealthCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var nodeGroupName string
+
+	rc.SetDescription("nodegroup-health", "Diagnose problems with the nodegroups of a cluster",
+		"Correlates auto scaling activities, EC2 status checks, CloudFormation events and Kubernetes node conditions, and suggests fixes for common failures")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doNodeGroupHealth(rc, nodeGroupName)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&nodeGroupName, "nodegroup", "", "name of the nodegroup to check (all nodegroups if unspecified)")
+		cmdutils.AddConfigFileFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doNodeGroupHealth(rc *cmdutils.ResourceCmd, nodeGroupName string) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	stackManager := ctl.NewStackManager(cfg)
+	stacks, err := stackManager.DescribeNodeGroupStacksAndResources()
+	if err != nil {
+		return errors.Wrapf(err, "getting nodegroup stacks of cluster %q", meta.Name)
+	}
+
+	names := []string{}
+	for name := range stacks {
+		if nodeGroupName == "" || name == nodeGroupName {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		if nodeGroupName != "" {
+			return fmt.Errorf("nodegroup %q not found in cluster %q", nodeGroupName, meta.Name)
+		}
+		logger.Info("cluster %q has no nodegroups", meta.Name)
+		return nil
+	}
+	sort.Strings(names)
+
+	checker := health.NewChecker(ctl.Provider, stackManager, clientSet)
+	problems := 0
+	for _, name := range names {
+		report, err := checker.CheckNodeGroup(name, stacks[name])
+		if err != nil {
+			return errors.Wrapf(err, "checking nodegroup %q", name)
+		}
+		if err := report.Write(os.Stdout); err != nil {
+			return err
+		}
+		problems += len(report.Findings)
+	}
+
+	if problems > 0 {
+		logger.Warning("found %d problem(s) in %d nodegroup(s) of cluster %q", problems, len(names), meta.Name)
+	} else {
+		logger.Success("no problems found in %d nodegroup(s) of cluster %q", len(names), meta.Name)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/builder"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// nodeGroupIAMPolicy is the part of a nodegroup config that sets the policy
+type nodeGroupIAMPolicy struct {
+	Name string `json:"name"`
+	IAM  struct {
+		AttachPolicy api.InlineDocument `json:"attachPolicy"`
+	} `json:"iam"`
+}
+
+func nodeGroupIAMPolicyCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var output string
+
+	rc.SetDescription("nodegroup-iam-policy", "Generate minimal IAM policies for the addons of nodegroups",
+		"Prints, for each nodegroup of the config file, a policy document with the actions needed by the addons enabled in iam.withAddonPolicies, "+
+			"which can be set as iam.attachPolicy in place of the broader managed and inline policies")
+
+	rc.SetRunFunc(func() error {
+		return doNodeGroupIAMPolicy(rc, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddNodeGroupFilterFlags(fs, &rc.IncludeNodeGroups, &rc.ExcludeNodeGroups)
+		fs.StringVarP(&output, "output", "o", "yaml", "specifies the output format (valid option: json, yaml)")
+	})
+}
+
+func doNodeGroupIAMPolicy(rc *cmdutils.ResourceCmd, output string) error {
+	if rc.ClusterConfigFile == "" {
+		return cmdutils.ErrMustBeSet("--config-file")
+	}
+
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+	cfg := rc.ClusterConfig
+
+	if output != "json" && output != "yaml" {
+		return eksctlerrors.NewValidationError("--output=%s is not supported - use one of: json, yaml", output)
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	ngFilter := cmdutils.NewNodeGroupFilter()
+	if err := ngFilter.AppendGlobs(rc.IncludeNodeGroups, rc.ExcludeNodeGroups, cfg.NodeGroups); err != nil {
+		return err
+	}
+
+	policies := []*nodeGroupIAMPolicy{}
+	err = ngFilter.ForEach(cfg.NodeGroups, func(i int, ng *api.NodeGroup) error {
+		if err := api.SetNodeGroupDefaults(i, ng); err != nil {
+			return err
+		}
+		policy := &nodeGroupIAMPolicy{Name: ng.Name}
+		policy.IAM.AttachPolicy = builder.NodeGroupAddonPolicyDocument(ng, cfg.Partition())
+		policies = append(policies, policy)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return printer.PrintObj(map[string]interface{}{"nodeGroups": policies}, os.Stdout)
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"os"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func printDefaultsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	ng := cfg.NewNodeGroup()
+	rc.ClusterConfig = cfg
+
+	var (
+		withoutNodeGroup bool
+		output           string
+	)
+
+	rc.SetDescription("print-defaults", "Print the config of a cluster with its defaults applied",
+		"Prints the config file, or the config the flags of 'eksctl create cluster' amount to, with the defaults eksctl applies "+
+			"when it creates the cluster and its nodegroups, without calling AWS; defaults that depend on the account, "+
+			"such as availability zones, subnets and AMIs, are left out")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doPrintDefaults(rc, withoutNodeGroup, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVarP(&cfg.Metadata.Name, "name", "n", "", "EKS cluster name (generated if unspecified)")
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddVersionFlag(fs, cfg.Metadata, "")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		fs.StringVarP(&output, "output", "o", "yaml", "specifies the output format (valid option: json, yaml)")
+	})
+
+	rc.FlagSetGroup.InFlagSet("Initial nodegroup", func(fs *pflag.FlagSet) {
+		fs.StringVar(&ng.Name, "nodegroup-name", "", "name of the nodegroup (generated if unspecified)")
+		fs.BoolVar(&withoutNodeGroup, "without-nodegroup", false, "if set, initial nodegroup will not be created")
+		cmdutils.AddCommonCreateNodeGroupFlags(fs, rc, ng)
+		cmdutils.AddCommonCreateNodeGroupIAMAddonsFlags(fs, ng)
+	})
+}
+
+func doPrintDefaults(rc *cmdutils.ResourceCmd, withoutNodeGroup bool, output string) error {
+	if output != "json" && output != "yaml" {
+		return eksctlerrors.NewValidationError("--output=%s is not supported - use one of: json, yaml", output)
+	}
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return err
+	}
+
+	ngFilter := cmdutils.NewNodeGroupFilter()
+	ngFilter.ExcludeAll = withoutNodeGroup
+
+	if err := cmdutils.NewCreateClusterLoader(rc, ngFilter).Load(); err != nil {
+		return err
+	}
+	cfg := rc.ClusterConfig
+
+	if cfg.Metadata.Region == "" {
+		cfg.Metadata.Region = rc.ProviderConfig.Region
+	}
+	if cfg.Metadata.Region == "" {
+		// the region of the AWS profile would be used, but it's only known with a session
+		logger.Info("no region was set, %s is used unless the AWS profile sets one", api.DefaultRegion)
+		cfg.Metadata.Region = api.DefaultRegion
+	}
+
+	if withoutNodeGroup {
+		cfg.NodeGroups = nil
+	}
+	if err := ngFilter.ValidateNodeGroupsAndSetDefaults(cfg.NodeGroups); err != nil {
+		return err
+	}
+	api.SetClusterConfigDefaults(cfg)
+
+	return printer.PrintObj(cfg, os.Stdout)
+}
@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func repairStackCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var opts actions.RepairStackOptions
+
+	rc.SetDescription("repair-stack", "Repair stacks whose update rollback failed",
+		"Continues the rollback of the stacks of the cluster in UPDATE_ROLLBACK_FAILED, or only of the stack given "+
+			"with --stack, so that they can be updated or deleted again; resources that can't be rolled back, e.g. as "+
+			"they were changed by hand, can be skipped with --skip-resources or --skip-failed-resources")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		opts.Plan = rc.Plan
+		return doRepairStack(rc, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+		fs.StringVar(&opts.StackName, "stack", "", "name of the stack to repair, all stacks of the cluster that need it are repaired when unset")
+		fs.StringSliceVar(&opts.ResourcesToSkip, "skip-resources", nil, "logical IDs of the resources the rollback skips")
+		fs.BoolVar(&opts.SkipFailedResources, "skip-failed-resources", false, "skip all resources that couldn't be rolled back")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doRepairStack(rc *cmdutils.ResourceCmd, opts actions.RepairStackOptions) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	repairs, err := m.RepairStacks(context.Background(), opts)
+	if err != nil {
+		if !opts.SkipFailedResources && len(opts.ResourcesToSkip) == 0 {
+			logger.Info("if resources can't be rolled back, skip them with --skip-failed-resources or --skip-resources")
+		}
+		return err
+	}
+
+	if len(repairs) == 0 {
+		logger.Info("no stacks of cluster %q need to be repaired", meta.Name)
+		return nil
+	}
+	for _, r := range repairs {
+		if rc.Plan {
+			logger.Info("(plan) would continue rollback of stack %q, failed resources: %v, skipped resources: %v", r.StackName, r.FailedResources, r.SkippedResources)
+			rc.AddPlannedAction(cmdutils.ClusterAction("repair-stack", meta, map[string]string{
+				"stack":            r.StackName,
+				"skippedResources": strings.Join(r.SkippedResources, ","),
+			}))
+		} else {
+			logger.Success("repaired stack %q", r.StackName)
+		}
+	}
+	cmdutils.LogPlanModeWarning(rc.Plan)
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/backup"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+func restoreClusterCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var bucket, prefix, snapshotID string
+
+	rc.SetDescription("restore-cluster", "Re-create a cluster from a snapshot in S3",
+		"Creates the stacks of a snapshot saved by 'eksctl utils backup-cluster' that don't exist, and restores the aws-auth ConfigMap")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doRestoreCluster(rc, bucket, prefix, snapshotID)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		addSnapshotStoreFlags(fs, &bucket, &prefix)
+		fs.StringVar(&snapshotID, "snapshot", "", "ID of the snapshot to restore (the latest one if unspecified)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
+}
+
+func doRestoreCluster(rc *cmdutils.ResourceCmd, bucket, prefix, snapshotID string) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	if bucket == "" {
+		return cmdutils.ErrMustBeSet("--s3-bucket")
+	}
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	store := backup.NewStore(ctl.Provider.S3(), bucket, prefix)
+	snapshot, err := store.Load(meta.Region, meta.Name, snapshotID)
+	if err != nil {
+		return err
+	}
+	if snapshot.Metadata.Region != meta.Region {
+		return fmt.Errorf("snapshot %q was taken in region %s, it can only be restored there", snapshot.Metadata.ID, snapshot.Metadata.Region)
+	}
+	logger.Info("restoring cluster %q from snapshot %q taken at %s", meta.Name, snapshot.Metadata.ID, snapshot.Metadata.CreatedAt)
+
+	stackManager := ctl.NewStackManager(cfg)
+	if err := stackManager.RestoreStacks(snapshot.Stacks); err != nil {
+		return err
+	}
+
+	if err := ctl.GetCredentials(cfg); err != nil {
+		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
+	}
+	clientSet, err := ctl.NewStdClientSet(cfg)
+	if err != nil {
+		return err
+	}
+
+	if snapshot.AuthConfigMap != nil {
+		if err := backup.RestoreAuthConfigMap(clientSet, snapshot.AuthConfigMap); err != nil {
+			return err
+		}
+	}
+
+	// the instance roles of the restored nodegroups are new, so they have to be mapped again
+	acm, err := authconfigmap.NewFromClientSet(clientSet)
+	if err != nil {
+		return err
+	}
+	roles, err := acm.Roles()
+	if err != nil {
+		return err
+	}
+	for _, ng := range snapshot.ClusterConfig.NodeGroups {
+		if err := ctl.GetNodeGroupIAM(stackManager, cfg, ng); err != nil {
+			return errors.Wrapf(err, "getting instance role of nodegroup %q", ng.Name)
+		}
+		if len(roles.Get(ng.IAM.InstanceRoleARN)) > 0 {
+			continue
+		}
+		if err := authconfigmap.AddNodeGroup(clientSet, ng); err != nil {
+			return err
+		}
+	}
+
+	logger.Success("restored cluster %q from snapshot %q", meta.Name, snapshot.Metadata.ID)
+	return nil
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func rotateNodeAMICmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	opts := actions.RotateNodeAMIOptions{}
+
+	rc.SetDescription("rotate-node-ami", "Move nodegroups to the latest EKS-optimized AMI",
+		"Updates the AMI of nodegroups that lag the latest EKS-optimized AMI, then drains and replaces their instances, "+
+			"--max-unavailable at a time, waiting for the new nodes to become ready after each batch")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doRotateNodeAMI(rc, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&opts.NodeGroup, "nodegroup", "", "name of the nodegroup to rotate (all nodegroups if unspecified)")
+		fs.IntVar(&opts.MaxUnavailable, "max-unavailable", 1, "number of instances that are replaced at once")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, true)
+}
+
+func doRotateNodeAMI(rc *cmdutils.ResourceCmd, opts actions.RotateNodeAMIOptions) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	opts.Plan = rc.Plan
+	rotations, err := m.RotateNodeAMI(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	if len(rotations) == 0 {
+		logger.Success("all nodegroups of cluster %q use the latest AMI", meta.Name)
+	}
+	for _, r := range rotations {
+		action := cmdutils.NodeGroupAction("rotate-node-ami", meta, r.NodeGroup)
+		action.Parameters["currentAMI"] = r.CurrentAMI
+		action.Parameters["ami"] = r.LatestAMI
+		rc.AddPlannedAction(action)
+	}
+
+	cmdutils.LogPlanModeWarning(rc.Plan && len(rotations) > 0)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	"github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+func tagSubnetsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	rc.SetDescription("tag-subnets", "Tag the subnets of a cluster for load balancer discovery",
+		"Tags public subnets with "+vpc.PublicSubnetRoleTag+" and private subnets with "+vpc.PrivateSubnetRoleTag+
+			", so that Services of type LoadBalancer and ALB Ingress Controller can find them")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doTagSubnets(rc)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doTagSubnets(rc *cmdutils.ResourceCmd) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+	logger.Info("using region %s", meta.Region)
+
+	if err := ctl.CheckAuth(); err != nil {
+		return err
+	}
+
+	if err := ctl.GetClusterVPC(cfg); err != nil {
+		return errors.Wrapf(err, "getting VPC configuration for cluster %q", meta.Name)
+	}
+
+	cmdutils.LogIntendedAction(rc.Plan, "tag subnets of cluster %q for load balancer discovery", meta.Name)
+	updateRequired, err := vpc.TagSubnets(ctl.Provider, cfg, rc.Plan)
+	if err != nil {
+		return err
+	}
+	if updateRequired {
+		rc.AddPlannedAction(cmdutils.ClusterAction("tag-subnets", meta, nil))
+	}
+
+	cmdutils.LogPlanModeWarning(rc.Plan && updateRequired)
+
+	return nil
+}
@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func updateAutoscalerTagsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	opts := actions.UpdateAutoscalerTagsOptions{}
+
+	rc.SetDescription("update-autoscaler-tags", "Update Cluster Autoscaler node-template tags of nodegroups",
+		"Tags the auto scaling groups of nodegroups with the labels and taints of their nodes, "+
+			"so that Cluster Autoscaler can scale them up from zero")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doUpdateAutoscalerTags(rc, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&opts.NodeGroup, "nodegroup", "", "name of the nodegroup to update (all nodegroups if unspecified)")
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doUpdateAutoscalerTags(rc *cmdutils.ResourceCmd, opts actions.UpdateAutoscalerTagsOptions) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	opts.Plan = rc.Plan
+	updates, err := m.UpdateAutoscalerTags(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+	if len(updates) == 0 {
+		logger.Success("node-template tags of all nodegroups of cluster %q are up-to-date", meta.Name)
+		return nil
+	}
+
+	for _, u := range updates {
+		for _, c := range u.Changes {
+			if rc.Plan {
+				logger.Info("(plan) nodegroup %q: would %s", u.NodeGroup, c)
+				action := cmdutils.NodeGroupAction("set-autoscaler-tag", meta, u.NodeGroup)
+				action.Parameters["key"] = c.Key
+				if c.New == "" {
+					action.Type = "remove-autoscaler-tag"
+				} else {
+					action.Parameters["value"] = c.New
+				}
+				rc.AddPlannedAction(action)
+			} else {
+				logger.Info("nodegroup %q: %s", u.NodeGroup, c)
+			}
+		}
+	}
+	cmdutils.LogPlanModeWarning(rc.Plan)
+
+	return nil
+}
@@ -1,14 +1,14 @@
 package utils
 
 import (
+	"context"
+
 	"github.com/kris-nova/logger"
-	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 
-	defaultaddons "github.com/weaveworks/eksctl/pkg/addons/default"
+	"github.com/weaveworks/eksctl/pkg/actions"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
-	"github.com/weaveworks/eksctl/pkg/eks"
 )
 
 func updateAWSNodeCmd(rc *cmdutils.ResourceCmd) {
@@ -24,7 +24,7 @@ func updateAWSNodeCmd(rc *cmdutils.ResourceCmd) {
 	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
 		cmdutils.AddNameFlag(fs, cfg.Metadata)
 		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
-		cmdutils.AddConfigFileFlag(fs, &rc.ClusterConfigFile)
+		cmdutils.AddConfigFileFlag(fs, rc)
 		cmdutils.AddApproveFlag(fs, rc)
 	})
 
@@ -39,34 +39,18 @@ func doUpdateAWSNode(rc *cmdutils.ResourceCmd) error {
 	cfg := rc.ClusterConfig
 	meta := rc.ClusterConfig.Metadata
 
-	ctl := eks.New(rc.ProviderConfig, cfg)
-
-	if !ctl.IsSupportedRegion() {
-		return cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
-	}
-	logger.Info("using region %s", meta.Region)
-
-	if err := ctl.CheckAuth(); err != nil {
-		return err
-	}
-
-	if err := ctl.GetCredentials(cfg); err != nil {
-		return errors.Wrapf(err, "getting credentials for cluster %q", meta.Name)
-	}
-
-	rawClient, err := ctl.NewRawClient(cfg)
+	m, err := actions.New(rc.ProviderConfig, cfg)
 	if err != nil {
 		return err
 	}
+	logger.Info("using region %s", meta.Region)
 
-	kubernetesVersion, err := rawClient.ServerVersion()
+	updateRequired, err := m.UpdateAWSNode(context.Background(), actions.UpdateAddonOptions{Plan: rc.Plan})
 	if err != nil {
 		return err
 	}
-
-	updateRequired, err := defaultaddons.UpdateAWSNode(rawClient, meta.Region, kubernetesVersion, rc.Plan)
-	if err != nil {
-		return err
+	if updateRequired {
+		rc.AddPlannedAction(cmdutils.ClusterAction("update-addon", meta, map[string]string{"addon": "aws-node"}))
 	}
 
 	cmdutils.LogPlanModeWarning(rc.Plan && updateRequired)
@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+func updateClusterLoggingCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var (
+		opts         actions.UpdateClusterLoggingOptions
+		auditHistory bool
+		output       string
+	)
+
+	rc.SetDescription("update-cluster-logging", "Update CloudWatch logging of the control plane",
+		"Enables and disables types of control plane logs, or the types of cloudWatch.clusterLogging of the config file, "+
+			"and records who changed them, when, and the types enabled before and after in the audit trail of the cluster, "+
+			"the "+eks.LoggingAuditConfigMap+" ConfigMap in kube-system")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		opts.Plan = rc.Plan
+		if auditHistory {
+			return doPrintClusterLoggingHistory(rc, output)
+		}
+		return doUpdateClusterLogging(rc, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+		supported := strings.Join(append(api.SupportedCloudWatchClusterLogTypes(), "all"), ", ")
+		fs.StringSliceVar(&opts.Enable, "enable-types", nil, "log types to enable (valid options: "+supported+")")
+		fs.StringSliceVar(&opts.Disable, "disable-types", nil, "log types to disable (valid options: "+supported+")")
+		fs.BoolVar(&auditHistory, "audit-history", false, "print the changes to logging recorded in the audit trail instead of updating it")
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format of --audit-history (valid option: table, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doUpdateClusterLogging(rc *cmdutils.ResourceCmd, opts actions.UpdateClusterLoggingOptions) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	if len(opts.Enable) == 0 && len(opts.Disable) == 0 {
+		// the types of the config file are the only ones to be enabled
+		desired := cfg.EnabledClusterLogTypes()
+		if desired == nil {
+			return eksctlerrors.NewValidationError("at least one of --enable-types and --disable-types must be set, unless the config file sets cloudWatch.clusterLogging")
+		}
+		opts.Enable = desired
+		opts.Disable = sets.NewString(api.SupportedCloudWatchClusterLogTypes()...).Difference(sets.NewString(desired...)).List()
+	}
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	change, err := m.UpdateClusterLogging(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+
+	if !change.Required() {
+		logger.Info("no changes to CloudWatch logging of cluster %q, enabled types: %s", meta.Name, describeLogTypes(change.After))
+		return nil
+	}
+	if rc.Plan {
+		logger.Info("(plan) would %s", change)
+		rc.AddPlannedAction(cmdutils.ClusterAction("update-cluster-logging", meta, map[string]string{
+			"enableTypes":  strings.Join(change.Enable, ","),
+			"disableTypes": strings.Join(change.Disable, ","),
+		}))
+	} else {
+		logger.Success("updated CloudWatch logging of cluster %q: %s", meta.Name, change)
+	}
+	cmdutils.LogPlanModeWarning(rc.Plan)
+
+	return nil
+}
+
+func doPrintClusterLoggingHistory(rc *cmdutils.ResourceCmd, output string) error {
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+
+	records, err := m.ClusterLoggingHistory(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addLoggingAuditColumns(columnPrinter)
+	}
+	return printer.PrintObjWithKind("records", records, os.Stdout)
+}
+
+func addLoggingAuditColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("TIME", func(r *eks.LoggingAuditRecord) string {
+		return r.Time
+	})
+	printer.AddColumn("PRINCIPAL", func(r *eks.LoggingAuditRecord) string {
+		return r.Principal
+	})
+	printer.AddColumn("BEFORE", func(r *eks.LoggingAuditRecord) string {
+		return describeLogTypes(r.Before)
+	})
+	printer.AddColumn("AFTER", func(r *eks.LoggingAuditRecord) string {
+		return describeLogTypes(r.After)
+	})
+}
+
+func describeLogTypes(types []string) string {
+	if len(types) == 0 {
+		return "none"
+	}
+	return strings.Join(types, ",")
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/actions"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+)
+
+func updateClusterSecurityGroupsCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var opts actions.UpdateClusterSecurityGroupsOptions
+
+	rc.SetDescription("update-cluster-security-groups", "Update security groups of the control plane",
+		"Attaches vpc.extraControlPlaneSecurityGroups of the config file to the control plane and detaches the ones that "+
+			"were removed, and reconciles vpc.extraControlPlaneIngressRules with the cluster stack; when the cluster doesn't "+
+			"support changing its security groups, rules that allow HTTPS from them are added to the control plane security group instead")
+
+	rc.SetRunFunc(func() error {
+		opts.Plan = rc.Plan
+		return doUpdateClusterSecurityGroups(rc, opts)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		cmdutils.AddConfigFileFlag(fs, rc)
+		cmdutils.AddApproveFlag(fs, rc)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doUpdateClusterSecurityGroups(rc *cmdutils.ResourceCmd, opts actions.UpdateClusterSecurityGroupsOptions) error {
+	// the config file is the source of truth, without it all extra
+	// security groups and ingress rules would be removed
+	if rc.ClusterConfigFile == "" {
+		return cmdutils.ErrMustBeSet("--config-file")
+	}
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+	meta := rc.ClusterConfig.Metadata
+
+	m, err := actions.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
+	logger.Info("using region %s", meta.Region)
+
+	change, err := m.UpdateClusterSecurityGroups(context.Background(), opts)
+	if err != nil {
+		return err
+	}
+
+	if !change.Required() {
+		logger.Info("no changes to security groups of cluster %q", meta.Name)
+		return nil
+	}
+	if rc.Plan {
+		logger.Info("(plan) would %s", change)
+		rc.AddPlannedAction(cmdutils.ClusterAction("update-cluster-security-groups", meta, map[string]string{
+			"attach": strings.Join(change.Attach, ","),
+			"detach": strings.Join(change.Detach, ","),
+		}))
+	} else {
+		logger.Success("updated security groups of cluster %q: %s", meta.Name, change)
+	}
+	cmdutils.LogPlanModeWarning(rc.Plan)
+
+	return nil
+}
@@ -13,7 +13,7 @@ func updateClusterStackCmd(rc *cmdutils.ResourceCmd) {
 	rc.SetDescription("update-cluster-stack", "DEPRECATED: Use 'eksctl update cluster' instead", "")
 
 	rc.Command.Run = func(cmd *cobra.Command, _ []string) {
-		logger.Critical(cmd.Short)
+		logger.Critical("%s", cmd.Short)
 		os.Exit(1)
 	}
 }
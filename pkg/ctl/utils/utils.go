@@ -12,10 +12,33 @@ func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, waitNodesCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, writeKubeconfigCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, describeStacksCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, repairStackCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateClusterStackCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateClusterLoggingCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateClusterSecurityGroupsCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateKubeProxyCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateAWSNodeCmd)
 	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateCoreDNSCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableContainerInsightsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableALBIngressControllerCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, enableEBSCSIDriverCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, nodeGroupHealthCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, ipUsageCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, backupClusterCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, restoreClusterCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, nodeGroupIAMPolicyCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, generateIAMPolicyCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, printDefaultsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, checkUpdatesCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, rotateNodeAMICmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, updateAutoscalerTagsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, tagSubnetsCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, associateIAMOIDCProviderCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, nodeGroupASGSuspendCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, nodeGroupASGResumeCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, logsCmd)
+
+	verbCmd.AddCommand(waitCmd(flagGrouping))
 
 	return verbCmd
 }
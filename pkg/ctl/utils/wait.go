@@ -0,0 +1,278 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/printers"
+)
+
+// Conditions that can be waited for
+const (
+	waitForActive     = "active"
+	waitForCreated    = "created"
+	waitForDeleted    = "deleted"
+	waitForUpdated    = "updated"
+	waitForSuccessful = "successful"
+)
+
+// waitResult is the outcome of a wait, it is printed
+// in the requested output format once the wait is over
+type waitResult struct {
+	Resource  string
+	Name      string
+	Condition string
+	Met       bool
+	Elapsed   string
+	Error     string `json:",omitempty"`
+}
+
+func waitCmd(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	verbCmd := cmdutils.NewVerbCmd("wait", "Wait for a cluster, nodegroup or update to reach a condition",
+		"Block until a condition is met or the timeout expires, the exit code is 0 when the condition is met and 6 when the wait timed out")
+
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, waitClusterCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, waitNodeGroupCmd)
+	cmdutils.AddResourceCmd(flagGrouping, verbCmd, waitUpdateCmd)
+
+	return verbCmd
+}
+
+func addWaitFlags(fs *pflag.FlagSet, condition *string, conditions []string, output *string) {
+	fs.StringVar(condition, "for", conditions[0], "condition to wait for (valid options: "+strings.Join(conditions, ", ")+")")
+	fs.StringVarP(output, "output", "o", "table", "specifies the output format of the result (valid option: table, json, yaml)")
+}
+
+func validateWaitCondition(condition string, conditions []string) error {
+	for _, c := range conditions {
+		if condition == c {
+			return nil
+		}
+	}
+	return eksctlerrors.NewValidationError("--for=%s is not supported - use one of: %s", condition, strings.Join(conditions, ", "))
+}
+
+func waitClusterCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var condition, output string
+
+	rc.SetDescription("cluster", "Wait for a cluster to be active or deleted", "")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doWaitCluster(rc, condition, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		addWaitFlags(fs, &condition, []string{waitForActive, waitForDeleted}, &output)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doWaitCluster(rc *cmdutils.ResourceCmd, condition, output string) error {
+	if err := validateWaitCondition(condition, []string{waitForActive, waitForDeleted}); err != nil {
+		return err
+	}
+
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := newWaitClusterProvider(rc)
+	if err != nil {
+		return err
+	}
+
+	return doWait(output, "cluster", meta.Name, condition, func() error {
+		if condition == waitForDeleted {
+			return ctl.WaitForClusterDeleted(meta)
+		}
+		return ctl.WaitForClusterActive(meta)
+	})
+}
+
+func waitNodeGroupCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	ng := cfg.NewNodeGroup()
+	rc.ClusterConfig = cfg
+
+	var condition, output string
+
+	rc.SetDescription("nodegroup", "Wait for the stack of a nodegroup to be created, updated or deleted", "", "ng")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doWaitNodeGroup(rc, ng, condition, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		fs.StringVar(&cfg.Metadata.Name, "cluster", "", "EKS cluster name")
+		fs.StringVarP(&ng.Name, "name", "n", "", "name of the nodegroup")
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		addWaitFlags(fs, &condition, []string{waitForCreated, waitForUpdated, waitForDeleted}, &output)
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doWaitNodeGroup(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, condition, output string) error {
+	if err := validateWaitCondition(condition, []string{waitForCreated, waitForUpdated, waitForDeleted}); err != nil {
+		return err
+	}
+
+	cfg := rc.ClusterConfig
+
+	if cfg.Metadata.Name == "" {
+		return cmdutils.ErrMustBeSet("--cluster")
+	}
+
+	if ng.Name != "" && rc.NameArg != "" {
+		return cmdutils.ErrNameFlagAndArg(ng.Name, rc.NameArg)
+	}
+
+	if rc.NameArg != "" {
+		ng.Name = rc.NameArg
+	}
+
+	if ng.Name == "" {
+		return cmdutils.ErrMustBeSet("--name")
+	}
+
+	ctl, err := newWaitClusterProvider(rc)
+	if err != nil {
+		return err
+	}
+	stackManager := ctl.NewStackManager(cfg)
+
+	return doWait(output, "nodegroup", ng.Name, condition, func() error {
+		switch condition {
+		case waitForUpdated:
+			return stackManager.WaitForNodeGroupStackUpdated(ng.Name)
+		case waitForDeleted:
+			return stackManager.WaitForNodeGroupStackDeleted(ng.Name)
+		default:
+			return stackManager.WaitForNodeGroupStackCreated(ng.Name)
+		}
+	})
+}
+
+func waitUpdateCmd(rc *cmdutils.ResourceCmd) {
+	cfg := api.NewClusterConfig()
+	rc.ClusterConfig = cfg
+
+	var updateID, output string
+
+	rc.SetDescription("update", "Wait for an update of a cluster to be successful", "")
+
+	rc.SetRunFuncWithNameArg(func() error {
+		return doWaitUpdate(rc, updateID, output)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddNameFlag(fs, cfg.Metadata)
+		cmdutils.AddRegionFlag(fs, rc.ProviderConfig)
+		fs.StringVar(&updateID, "update-id", "", "ID of the update, as returned by the EKS API")
+		fs.StringVarP(&output, "output", "o", "table", "specifies the output format of the result (valid option: table, json, yaml)")
+	})
+
+	cmdutils.AddCommonFlagsForAWS(rc.FlagSetGroup, rc.ProviderConfig, false)
+}
+
+func doWaitUpdate(rc *cmdutils.ResourceCmd, updateID, output string) error {
+	if updateID == "" {
+		return cmdutils.ErrMustBeSet("--update-id")
+	}
+
+	if err := cmdutils.NewMetadataLoader(rc).Load(); err != nil {
+		return err
+	}
+	meta := rc.ClusterConfig.Metadata
+
+	ctl, err := newWaitClusterProvider(rc)
+	if err != nil {
+		return err
+	}
+
+	return doWait(output, "update", updateID, waitForSuccessful, func() error {
+		return ctl.WaitForUpdate(meta, updateID)
+	})
+}
+
+func newWaitClusterProvider(rc *cmdutils.ResourceCmd) (*eks.ClusterProvider, error) {
+	ctl, err := eks.New(rc.ProviderConfig, rc.ClusterConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ctl.IsSupportedRegion() {
+		return nil, cmdutils.ErrUnsupportedRegion(rc.ProviderConfig)
+	}
+
+	if err := ctl.CheckAuth(); err != nil {
+		return nil, err
+	}
+	return ctl, nil
+}
+
+// doWait calls wait and prints the result, also when the wait failed, so that
+// pipelines can read why the condition wasn't met
+func doWait(output, resource, name, condition string, wait func() error) error {
+	printer, err := printers.NewPrinter(output)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	startTime := time.Now()
+	waitErr := wait()
+
+	result := &waitResult{
+		Resource:  resource,
+		Name:      name,
+		Condition: condition,
+		Met:       waitErr == nil,
+		Elapsed:   time.Since(startTime).Round(time.Second).String(),
+	}
+	if waitErr != nil {
+		result.Error = waitErr.Error()
+	}
+
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addWaitResultTableColumns(columnPrinter)
+	}
+
+	if err := printer.PrintObjWithKind("results", []*waitResult{result}, os.Stdout); err != nil {
+		return err
+	}
+	return waitErr
+}
+
+func addWaitResultTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("RESOURCE", func(r *waitResult) string {
+		return r.Resource
+	})
+	printer.AddColumn("NAME", func(r *waitResult) string {
+		return r.Name
+	})
+	printer.AddColumn("CONDITION", func(r *waitResult) string {
+		return r.Condition
+	})
+	printer.AddColumn("MET", func(r *waitResult) string {
+		return strconv.FormatBool(r.Met)
+	})
+	printer.AddColumn("ELAPSED", func(r *waitResult) string {
+		return r.Elapsed
+	})
+}
@@ -38,7 +38,10 @@ func waitNodesCmd(rc *cmdutils.ResourceCmd) {
 func doWaitNodes(rc *cmdutils.ResourceCmd, ng *api.NodeGroup, kubeconfigPath string) error {
 	cfg := rc.ClusterConfig
 
-	ctl := eks.New(rc.ProviderConfig, cfg)
+	ctl, err := eks.New(rc.ProviderConfig, cfg)
+	if err != nil {
+		return err
+	}
 
 	if kubeconfigPath == "" {
 		return cmdutils.ErrMustBeSet("--kubeconfig")
@@ -0,0 +1,210 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/weaveworks/eksctl/pkg/ami"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+// subnetCIDR is a subnet of the config file, with the path of its CIDR
+type subnetCIDR struct {
+	path string
+	cidr *ipnet.IPNet
+}
+
+// validateConfig sets the defaults of the config as create commands do, and returns every
+// problem found, where create commands would only return the first one
+func validateConfig(cfg *api.ClusterConfig) []error {
+	problems := []error{}
+	check := func(err error) {
+		if err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	if cfg.Metadata == nil {
+		cfg.Metadata = &api.ClusterMeta{}
+	}
+	if cfg.VPC == nil {
+		cfg.VPC = api.NewClusterVPC()
+	}
+	if cfg.VPC.NAT == nil {
+		cfg.VPC.NAT = api.DefaultClusterNAT()
+	}
+	api.SetClusterStorageDefaults(cfg)
+
+	check(validateMetadata(cfg.Metadata))
+	if cfg.HasAnySubnets() && len(cfg.AvailabilityZones) != 0 {
+		check(fmt.Errorf("vpc.subnets and availabilityZones cannot be set at the same time"))
+	}
+
+	for _, validate := range []func(*api.ClusterConfig) error{
+		api.ValidateClusterAutoScaler,
+		api.ValidateNodeTerminationHandler,
+		api.ValidateClusterStorage,
+		api.ValidateKubernetesNetworkConfig,
+		api.ValidateContainerRuntime,
+		api.ValidateProxy,
+		api.ValidateNodeRoles,
+		api.ValidateNodeGroupDependencies,
+		api.ValidateLocalZones,
+		api.ValidateOutpost,
+		api.ValidateClusterEndpoints,
+		api.ValidateControlPlaneIngressRules,
+		api.ValidateSecurityGroupOverrides,
+		api.ValidateVPCCNI,
+		api.ValidateCloudWatchLogging,
+		api.ValidateCloudWatchAlarms,
+		api.ValidateCloudFormation,
+		api.ValidateClusterIAM,
+		api.ValidateHooks,
+	} {
+		check(validate(cfg))
+	}
+
+	for _, err := range validateSubnetCIDRs(cfg.VPC) {
+		check(err)
+	}
+
+	names := map[string]int{}
+	for i, ng := range cfg.NodeGroups {
+		if j, ok := names[ng.Name]; ok && ng.Name != "" {
+			check(fmt.Errorf("nodegroups[%d].name %q is already used by nodegroups[%d]", i, ng.Name, j))
+		} else {
+			names[ng.Name] = i
+		}
+		if err := api.ValidateNodeGroup(i, ng); err != nil {
+			// defaults can't be set reliably on an invalid nodegroup
+			check(err)
+			continue
+		}
+		check(api.SetNodeGroupDefaults(i, ng))
+		for _, err := range validateNodeGroupImage(i, ng, cfg.Metadata) {
+			check(err)
+		}
+	}
+
+	return problems
+}
+
+func validateMetadata(meta *api.ClusterMeta) error {
+	if meta.Name == "" {
+		return fmt.Errorf("metadata.name must be set")
+	}
+	if meta.Region == "" {
+		return fmt.Errorf("metadata.region must be set")
+	}
+	if !utils.IsOneOf(meta.Region, api.SupportedRegions()) {
+		return fmt.Errorf("metadata.region %q is not supported, supported values: %s", meta.Region, strings.Join(api.SupportedRegions(), ", "))
+	}
+	if _, ok := resolveVersion(meta.Version); !ok {
+		return fmt.Errorf("metadata.version %q is not supported, supported values: auto, default, latest, %s", meta.Version, strings.Join(api.SupportedVersions(), ", "))
+	}
+	return nil
+}
+
+// resolveVersion returns the version that nodegroups would use, which is empty when it's
+// inherited from the control plane, and whether it's supported
+func resolveVersion(version string) (string, bool) {
+	switch version {
+	case "", "default":
+		return api.DefaultVersion, true
+	case "latest":
+		return api.LatestVersion, true
+	case "auto":
+		return "", true
+	}
+	return version, utils.IsOneOf(version, api.SupportedVersions())
+}
+
+// validateSubnetCIDRs checks that subnet CIDRs are within the CIDRs of the VPC, when it's
+// set, and that they don't overlap with each other
+func validateSubnetCIDRs(vpc *api.ClusterVPC) []error {
+	subnets := []subnetCIDR{}
+	collect := func(prefix string, s *api.ClusterSubnets) {
+		if s == nil {
+			return
+		}
+		for topology, networks := range map[string]map[string]api.Network{"private": s.Private, "public": s.Public} {
+			for az, network := range networks {
+				if network.CIDR != nil {
+					subnets = append(subnets, subnetCIDR{path: fmt.Sprintf("%s.%s.%s.cidr", prefix, topology, az), cidr: network.CIDR})
+				}
+			}
+		}
+	}
+	collect("vpc.subnets", vpc.Subnets)
+	collect("vpc.localZoneSubnets", vpc.LocalZoneSubnets)
+	sort.Slice(subnets, func(i, j int) bool { return subnets[i].path < subnets[j].path })
+
+	problems := []error{}
+	vpcCIDRs := vpc.ExtraCIDRs
+	if vpc.CIDR != nil {
+		vpcCIDRs = append([]*ipnet.IPNet{vpc.CIDR}, vpcCIDRs...)
+	}
+	for i, s := range subnets {
+		if len(vpcCIDRs) > 0 && !withinAny(s.cidr, vpcCIDRs) {
+			problems = append(problems, fmt.Errorf("%s %s is outside of vpc.cidr and vpc.extraCIDRs", s.path, s.cidr))
+		}
+		for _, other := range subnets[:i] {
+			if s.cidr.Contains(other.cidr.IP) || other.cidr.Contains(s.cidr.IP) {
+				problems = append(problems, fmt.Errorf("%s %s overlaps with %s %s", s.path, s.cidr, other.path, other.cidr))
+			}
+		}
+	}
+	return problems
+}
+
+func withinAny(cidr *ipnet.IPNet, parents []*ipnet.IPNet) bool {
+	ones, _ := cidr.Mask.Size()
+	for _, parent := range parents {
+		parentOnes, _ := parent.Mask.Size()
+		if parent.Contains(cidr.IP) && parentOnes <= ones {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNodeGroupImage checks the instance types of a nodegroup against its AMI family and,
+// when AMIs are resolved statically, against the AMIs available in the region
+func validateNodeGroupImage(i int, ng *api.NodeGroup, meta *api.ClusterMeta) []error {
+	path := fmt.Sprintf("nodegroups[%d]", i)
+	instanceTypes := []string{ng.InstanceType}
+	if api.HasMixedInstances(ng) {
+		instanceTypes = ng.InstancesDistribution.InstanceTypes
+	}
+	version, ok := resolveVersion(meta.Version)
+	// problems with the region or version are reported once, for the metadata
+	// static AMIs are only known in the standard partition, others fall back to SSM parameters
+	knownImages := ok && version != "" && utils.IsOneOf(meta.Region, api.SupportedRegions()) && api.Partition(meta.Region) == api.PartitionAWS
+
+	problems := []error{}
+	for _, instanceType := range instanceTypes {
+		gpu := utils.IsGPUInstanceType(instanceType)
+		if gpu && ng.AMIFamily == api.NodeImageFamilyUbuntu1804 {
+			problems = append(problems, fmt.Errorf("%s.amiFamily %s doesn't support GPU instance type %s", path, ng.AMIFamily, instanceType))
+			continue
+		}
+		if ng.AMI != api.NodeImageResolverStatic || !knownImages {
+			continue
+		}
+		if utils.IsARMInstanceType(instanceType) {
+			problems = append(problems, fmt.Errorf("%s.ami %s doesn't support ARM instance type %s, use %s", path, ng.AMI, instanceType, api.NodeImageResolverAutoSSM))
+			continue
+		}
+		class := ami.ImageClassGeneral
+		if gpu {
+			class = ami.ImageClassGPU
+		}
+		if ami.StaticImages[version][ng.AMIFamily][class][meta.Region] == "" {
+			problems = append(problems, fmt.Errorf("%s.ami %s has no %s AMI for instance type %s in %s with version %s", path, ng.AMI, ng.AMIFamily, instanceType, meta.Region, version))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// fieldPathPattern matches the path of a field at the start of a validation error,
+	// e.g. "nodegroups[1].iam.instanceRoleARN"
+	fieldPathPattern   = regexp.MustCompile(`^[a-zA-Z][\w-]*(\[\d+\])?(\.[\w-]+(\[\d+\])?)*`)
+	pathSegmentPattern = regexp.MustCompile(`^([\w-]+)(?:\[(\d+)\])?$`)
+)
+
+// yamlLine is a non-empty line of a YAML document
+type yamlLine struct {
+	number int
+	// indent is the column of the first character, keyIndent is the column of the key,
+	// which is further than indent for the first line of a sequence item
+	indent, keyIndent int
+	key               string
+	item              bool
+}
+
+// fieldLine returns the line of the YAML document where the field that a validation error
+// starts with is set, or of its closest parent that is set; keys are matched case-insensitively,
+// as errors don't always use the case of the config file, e.g. "nodegroups" for "nodeGroups";
+// it returns 0 when none is found
+func fieldLine(data []byte, message string) int {
+	path := fieldPathPattern.FindString(message)
+	if path == "" {
+		return 0
+	}
+	lines := parseYAMLLines(data)
+
+	line := 0
+	start, scopeIndent, inItem := 0, -1, false
+	for _, segment := range strings.Split(path, ".") {
+		m := pathSegmentPattern.FindStringSubmatch(segment)
+		if m == nil {
+			return line
+		}
+
+		found, childIndent := -1, -1
+		for j := start; j < len(lines); j++ {
+			l := lines[j]
+			if inItem && j > start && l.indent <= scopeIndent {
+				break
+			}
+			if !inItem && (l.indent < scopeIndent || (l.indent == scopeIndent && !l.item)) {
+				break
+			}
+			if l.key == "" {
+				continue
+			}
+			if childIndent == -1 {
+				childIndent = l.keyIndent
+			}
+			if l.keyIndent == childIndent && strings.EqualFold(l.key, m[1]) {
+				found = j
+				break
+			}
+		}
+		if found < 0 {
+			return line
+		}
+		line = lines[found].number
+		start, scopeIndent, inItem = found+1, lines[found].keyIndent, false
+
+		if m[2] == "" {
+			continue
+		}
+		index, _ := strconv.Atoi(m[2])
+		found, itemIndent, count := -1, -1, -1
+		for j := start; j < len(lines); j++ {
+			l := lines[j]
+			if l.indent < scopeIndent || (l.indent == scopeIndent && !l.item) {
+				break
+			}
+			if !l.item {
+				continue
+			}
+			if itemIndent == -1 {
+				itemIndent = l.indent
+			}
+			if l.indent == itemIndent {
+				if count++; count == index {
+					found = j
+					break
+				}
+			}
+		}
+		if found < 0 {
+			return line
+		}
+		line = lines[found].number
+		start, scopeIndent, inItem = found, itemIndent, true
+	}
+	return line
+}
+
+func parseYAMLLines(data []byte) []yamlLine {
+	lines := []yamlLine{}
+	for i, raw := range strings.Split(string(data), "\n") {
+		text := strings.TrimLeft(raw, " ")
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		l := yamlLine{number: i + 1, indent: len(raw) - len(text)}
+		if text == "-" || strings.HasPrefix(text, "- ") {
+			l.item = true
+			text = strings.TrimLeft(text[1:], " ")
+		}
+		l.keyIndent = len(raw) - len(text)
+		if colon := strings.Index(text, ":"); colon > 0 {
+			l.key = strings.Trim(text[:colon], `"' `)
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
@@ -0,0 +1,93 @@
+package validate
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/kris-nova/logger"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/weaveworks/eksctl/pkg/ctl/cmdutils"
+	"github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+// Command will create the `validate` command
+func Command(flagGrouping *cmdutils.FlagGrouping) *cobra.Command {
+	return cmdutils.NewResourceCmd(flagGrouping, validateCmd).Command
+}
+
+func validateCmd(rc *cmdutils.ResourceCmd) {
+	rc.SetDescription("validate", "Validate a config file without calling AWS",
+		"Sets the defaults of a config file and checks it as create commands would, and further for mutually "+
+			"exclusive fields, instance type, AMI and region compatibility, overlapping subnet CIDRs and duplicate "+
+			"nodegroup names; all problems are reported at once, with their position in the file, and the command "+
+			"fails when there are any, e.g. to gate changes in CI; all the clusters of files that define several "+
+			"of them are checked, unless --cluster-name selects one")
+
+	rc.SetRunFunc(func() error {
+		return doValidate(rc)
+	})
+
+	rc.FlagSetGroup.InFlagSet("General", func(fs *pflag.FlagSet) {
+		cmdutils.AddConfigFileFlag(fs, rc)
+	})
+}
+
+func doValidate(rc *cmdutils.ResourceCmd) error {
+	configFile := rc.ClusterConfigFile
+	if configFile == "" {
+		return cmdutils.ErrMustBeSet("--config-file")
+	}
+
+	cfgs, err := eks.LoadConfigsFromFile(configFile)
+	if err != nil {
+		return eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+	}
+
+	// positions can't be found when the config is read from stdin, as it can only be read once
+	var docs []eks.ConfigDocument
+	if configFile != "-" {
+		data, err := ioutil.ReadFile(configFile)
+		if err != nil {
+			return err
+		}
+		docs = eks.SplitConfigDocuments(data)
+	}
+
+	// all the clusters of the file are checked, unless one is selected
+	found, count := false, 0
+	for i, cfg := range cfgs {
+		if rc.ClusterConfigName != "" && cfg.Metadata.Name != rc.ClusterConfigName {
+			continue
+		}
+		found = true
+
+		var doc eks.ConfigDocument
+		if i < len(docs) {
+			doc = docs[i]
+		}
+		prefix := ""
+		if len(cfgs) > 1 {
+			prefix = fmt.Sprintf("cluster %q: ", cfg.Metadata.Name)
+		}
+		for _, p := range validateConfig(cfg) {
+			count++
+			if line := fieldLine(doc.Data, p.Error()); line > 0 {
+				fmt.Printf("%s:%d: %s%s\n", configFile, doc.Line+line-1, prefix, p)
+			} else {
+				fmt.Printf("%s: %s%s\n", configFile, prefix, p)
+			}
+		}
+	}
+	if !found {
+		return eksctlerrors.NewValidationError("config file %q doesn't define cluster %q", configFile, rc.ClusterConfigName)
+	}
+
+	if count == 0 {
+		logger.Success("%s is valid", configFile)
+		return nil
+	}
+	return eksctlerrors.NewValidationError("found %d problem(s) in %s", count, configFile)
+}
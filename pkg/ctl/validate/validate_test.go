@@ -0,0 +1,80 @@
+package validate
+
+import (
+	"io/ioutil"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/eks"
+)
+
+var _ = Describe("validate", func() {
+	BeforeEach(func() {
+		Expect(api.Register()).To(Succeed())
+	})
+
+	It("reports all problems of a config file", func() {
+		cfg, err := eks.LoadConfigFromFile("testdata/invalid.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		messages := []string{}
+		for _, p := range validateConfig(cfg) {
+			messages = append(messages, p.Error())
+		}
+		Expect(messages).To(Equal([]string{
+			"vpc.subnets.private.us-west-2b.cidr 10.0.0.0/19 is outside of vpc.cidr and vpc.extraCIDRs",
+			"vpc.subnets.public.us-west-2a.cidr 192.168.16.0/20 overlaps with vpc.subnets.private.us-west-2a.cidr 192.168.0.0/19",
+			`nodegroups[1].name "ng-1" is already used by nodegroups[0]`,
+			"nodegroups[1].amiFamily Ubuntu1804 doesn't support GPU instance type p3.2xlarge",
+			"nodegroups[2].volumeType cannot be set without nodegroups[2].volumeSize",
+		}))
+	})
+
+	It("reports unsupported regions and versions", func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster-1"
+		cfg.Metadata.Region = "us-gov-west-1"
+		Expect(validateConfig(cfg)).To(ConsistOf(MatchError(ContainSubstring(`metadata.region "us-gov-west-1" is not supported`))))
+
+		cfg.Metadata.Region = api.RegionUSWest2
+		cfg.Metadata.Version = "1.9"
+		Expect(validateConfig(cfg)).To(ConsistOf(MatchError(ContainSubstring(`metadata.version "1.9" is not supported`))))
+	})
+
+	It("reports ARM instance types with static AMIs", func() {
+		cfg := api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster-1"
+		cfg.Metadata.Region = api.RegionUSWest2
+		ng := cfg.NewNodeGroup()
+		ng.Name = "ng-1"
+		ng.InstanceType = "a1.large"
+		Expect(validateConfig(cfg)).To(ConsistOf(MatchError("nodegroups[0].ami static doesn't support ARM instance type a1.large, use auto-ssm")))
+
+		ng.AMI = api.NodeImageResolverAutoSSM
+		Expect(validateConfig(cfg)).To(BeEmpty())
+	})
+
+	It("finds the line of fields in the config file", func() {
+		data, err := ioutil.ReadFile("testdata/invalid.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fieldLine(data, "metadata.region is not supported")).To(Equal(6))
+		Expect(fieldLine(data, "vpc.subnets.private.us-west-2b.cidr is outside of vpc.cidr")).To(Equal(17))
+		Expect(fieldLine(data, "vpc.subnets.public.us-west-2a.cidr overlaps")).To(Equal(21))
+		Expect(fieldLine(data, `nodegroups[1].name "ng-1" is already used`)).To(Equal(26))
+		Expect(fieldLine(data, "nodegroups[1].amiFamily doesn't support")).To(Equal(28))
+		Expect(fieldLine(data, "nodegroups[2].volumeType cannot be set")).To(Equal(31))
+		// the closest parent that is set
+		Expect(fieldLine(data, "nodegroups[2].volumeSize must be set")).To(Equal(29))
+		Expect(fieldLine(data, "nodegroups[3].name must be set")).To(Equal(23))
+		Expect(fieldLine(data, "only one ssh public key can be specified")).To(Equal(0))
+	})
+
+	It("finds the line of sequence items that aren't indented", func() {
+		data := []byte("nodeGroups:\n- name: ng-1\n  labels: {}\n- name: ng-2\n  labels: {}\nvpc:\n  cidr: 10.0.0.0/16\n")
+		Expect(fieldLine(data, "nodegroups[1].labels")).To(Equal(5))
+		Expect(fieldLine(data, "vpc.cidr")).To(Equal(7))
+	})
+})
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
@@ -24,7 +25,7 @@ func evictPods(drainer *Helper, node *corev1.Node) (int, error) {
 		return 0, fmt.Errorf("errs: %v", errs) // TODO: improve formatting
 	}
 	if w := list.Warnings(); w != "" {
-		logger.Warning(w)
+		logger.Warning("%s", w)
 	}
 	pods := list.Pods()
 	pending := len(pods)
@@ -37,9 +38,8 @@ func evictPods(drainer *Helper, node *corev1.Node) (int, error) {
 	return pending, nil
 }
 
-// NodeGroup drains a nodegroup
-func NodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup, waitTimeout time.Duration, undo bool) error {
-	drainer := &Helper{
+func newHelper(clientSet kubernetes.Interface) *Helper {
+	return &Helper{
 		Client: clientSet,
 
 		// TODO: Force, DeleteLocalData & IgnoreAllDaemonSets shouldn't
@@ -79,6 +79,11 @@ func NodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup, waitTimeout ti
 			},
 		},
 	}
+}
+
+// NodeGroup drains a nodegroup
+func NodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup, waitTimeout time.Duration, undo bool) error {
+	drainer := newHelper(clientSet)
 
 	if err := drainer.CanUseEvictions(); err != nil {
 		return errors.Wrapf(err, "checking if cluster implements policy API")
@@ -119,10 +124,10 @@ func NodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup, waitTimeout ti
 				if c.IsUpdateRequired() {
 					err, patchErr := c.PatchOrReplace(clientSet)
 					if patchErr != nil {
-						logger.Warning(patchErr.Error())
+						logger.Warning("%s", patchErr.Error())
 					}
 					if err != nil {
-						logger.Critical(err.Error())
+						logger.Critical("%s", err.Error())
 					}
 					logger.Info("%s node %q", desired, node.Name)
 				} else {
@@ -162,3 +167,69 @@ func NodeGroup(clientSet kubernetes.Interface, ng *api.NodeGroup, waitTimeout ti
 
 	return nil
 }
+
+// Nodes cordons the given nodes and evicts their pods, evictions that are refused
+// because of a PodDisruptionBudget are retried until waitTimeout
+func Nodes(clientSet kubernetes.Interface, nodeNames []string, pollInterval, waitTimeout time.Duration) error {
+	drainer := newHelper(clientSet)
+
+	if err := drainer.CanUseEvictions(); err != nil {
+		return errors.Wrapf(err, "checking if cluster implements policy API")
+	}
+
+	pendingNodes := sets.NewString(nodeNames...)
+	for _, name := range nodeNames {
+		node, err := clientSet.CoreV1().Nodes().Get(name, metav1.GetOptions{})
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				logger.Debug("node %q is already gone", name)
+				pendingNodes.Delete(name)
+				continue
+			}
+			return err
+		}
+		c := NewCordonHelper(node, CordonNode)
+		if c.IsUpdateRequired() {
+			err, patchErr := c.PatchOrReplace(clientSet)
+			if patchErr != nil {
+				logger.Warning("%s", patchErr.Error())
+			}
+			if err != nil {
+				return errors.Wrapf(err, "cordoning node %q", name)
+			}
+			logger.Info("%s node %q", CordonNode, name)
+		}
+	}
+
+	deadline := time.Now().Add(waitTimeout)
+	for ; pendingNodes.Len() > 0; time.Sleep(pollInterval) {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out (after %s) waiting for nodes %v to be drained", waitTimeout, pendingNodes.List())
+		}
+		for _, name := range pendingNodes.List() {
+			list, errs := drainer.GetPodsForDeletion(name)
+			if len(errs) > 0 {
+				return fmt.Errorf("errs: %v", errs)
+			}
+			if w := list.Warnings(); w != "" {
+				logger.Warning("%s", w)
+			}
+			pods := list.Pods()
+			if len(pods) == 0 {
+				logger.Info("drained node %q", name)
+				pendingNodes.Delete(name)
+				continue
+			}
+			for _, pod := range pods {
+				if err := drainer.EvictOrDeletePod(pod); err != nil {
+					if !apierrors.IsTooManyRequests(err) {
+						return errors.Wrapf(err, "evicting pod %s/%s", pod.Namespace, pod.Name)
+					}
+					logger.Debug("eviction of pod %s/%s is blocked by a PodDisruptionBudget, will retry", pod.Namespace, pod.Name)
+				}
+			}
+			logger.Debug("%d pods to be evicted from %s", len(pods), name)
+		}
+	}
+	return nil
+}
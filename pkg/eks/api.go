@@ -1,9 +1,13 @@
 package eks
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"os"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -12,10 +16,14 @@ import (
 	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/aws/aws-sdk-go/service/cloudtrail"
 	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/ec2"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	awseks "github.com/aws/aws-sdk-go/service/eks"
@@ -26,6 +34,16 @@ import (
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 	"github.com/kris-nova/logger"
@@ -35,19 +53,40 @@ import (
 	"sigs.k8s.io/yaml"
 
 	"github.com/weaveworks/eksctl/pkg/ami"
+	"github.com/weaveworks/eksctl/pkg/apirecorder"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/az"
 	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/logging"
+	"github.com/weaveworks/eksctl/pkg/metrics"
 	"github.com/weaveworks/eksctl/pkg/utils"
 	"github.com/weaveworks/eksctl/pkg/version"
 )
 
+const pricingRegion = "us-east-1"
+
 // ClusterProvider stores information about the cluster
 type ClusterProvider struct {
 	// core fields used for config and AWS APIs
 	Provider api.ClusterProvider
 	// informative fields, i.e. used as outputs
 	Status *ProviderStatus
+
+	// stackNamePrefix is set by the --stack-name-prefix flag
+	stackNamePrefix string
+	// disableRollback is set by the --cfn-disable-rollback flag
+	disableRollback bool
+	// templateOverrides is set by the --template-overrides flag
+	templateOverrides string
+	// caBundle is read from the file of the --ca-bundle flag
+	caBundle []byte
+	// httpClient is the HTTP client of the AWS session
+	httpClient *http.Client
+
+	// clusters and stacks cache descriptions for the duration of a command
+	clusters *clusterCache
+	stacks   *manager.StackCache
 }
 
 // ProviderServices stores the used APIs
@@ -62,6 +101,13 @@ type ProviderServices struct {
 	iam   iamiface.IAMAPI
 
 	cloudtrail cloudtrailiface.CloudTrailAPI
+	pricing    pricingiface.PricingAPI
+	ssm        ssmiface.SSMAPI
+	asg        autoscalingiface.AutoScalingAPI
+	s3         s3iface.S3API
+	logs       cloudwatchlogsiface.CloudWatchLogsAPI
+	sns        snsiface.SNSAPI
+	tagging    resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
 }
 
 // CloudFormation returns a representation of the CloudFormation API
@@ -91,6 +137,29 @@ func (p ProviderServices) IAM() iamiface.IAMAPI { return p.iam }
 // CloudTrail returns a representation of the CloudTrail API
 func (p ProviderServices) CloudTrail() cloudtrailiface.CloudTrailAPI { return p.cloudtrail }
 
+// Pricing returns a representation of the Pricing API
+func (p ProviderServices) Pricing() pricingiface.PricingAPI { return p.pricing }
+
+// SSM returns a representation of the SSM API
+func (p ProviderServices) SSM() ssmiface.SSMAPI { return p.ssm }
+
+// ASG returns a representation of the AutoScaling API
+func (p ProviderServices) ASG() autoscalingiface.AutoScalingAPI { return p.asg }
+
+// S3 returns a representation of the S3 API
+func (p ProviderServices) S3() s3iface.S3API { return p.s3 }
+
+// CloudWatchLogs returns a representation of the CloudWatch Logs API
+func (p ProviderServices) CloudWatchLogs() cloudwatchlogsiface.CloudWatchLogsAPI { return p.logs }
+
+// SNS returns a representation of the SNS API
+func (p ProviderServices) SNS() snsiface.SNSAPI { return p.sns }
+
+// ResourceGroupsTagging returns a representation of the Resource Groups Tagging API
+func (p ProviderServices) ResourceGroupsTagging() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+	return p.tagging
+}
+
 // Region returns provider-level region setting
 func (p ProviderServices) Region() string { return p.spec.Region }
 
@@ -100,6 +169,9 @@ func (p ProviderServices) Profile() string { return p.spec.Profile }
 // WaitTimeout returns provider-level duration after which any wait operation has to timeout
 func (p ProviderServices) WaitTimeout() time.Duration { return p.spec.WaitTimeout }
 
+// PollInterval returns provider-level interval between status checks of any wait operation
+func (p ProviderServices) PollInterval() time.Duration { return p.spec.PollInterval }
+
 // ProviderStatus stores information about the used IAM role and the resulting session
 type ProviderStatus struct {
 	iamRoleARN        string
@@ -107,107 +179,320 @@ type ProviderStatus struct {
 	cachedClusterInfo *awseks.Cluster
 }
 
-// New creates a new setup of the used AWS APIs
-func New(spec *api.ProviderConfig, clusterSpec *api.ClusterConfig) *ClusterProvider {
+// endpointEnvVars are the environment variables that override the endpoints of
+// services, keyed by the names of services used with --endpoint-url
+var endpointEnvVars = map[string]string{
+	"autoscaling":    "AWS_AUTOSCALING_ENDPOINT",
+	"cloudformation": "AWS_CLOUDFORMATION_ENDPOINT",
+	"cloudtrail":     "AWS_CLOUDTRAIL_ENDPOINT",
+	"ec2":            "AWS_EC2_ENDPOINT",
+	"eks":            "AWS_EKS_ENDPOINT",
+	"elb":            "AWS_ELB_ENDPOINT",
+	"elbv2":          "AWS_ELBV2_ENDPOINT",
+	"iam":            "AWS_IAM_ENDPOINT",
+	"logs":           "AWS_CLOUDWATCHLOGS_ENDPOINT",
+	"pricing":        "AWS_PRICING_ENDPOINT",
+	"s3":             "AWS_S3_ENDPOINT",
+	"sns":            "AWS_SNS_ENDPOINT",
+	"ssm":            "AWS_SSM_ENDPOINT",
+	"sts":            "AWS_STS_ENDPOINT",
+	"tagging":        "AWS_RESOURCEGROUPSTAGGINGAPI_ENDPOINT",
+}
+
+// EndpointServices returns the names of the services whose endpoint can be overridden
+func EndpointServices() []string {
+	services := []string{}
+	for service := range endpointEnvVars {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services
+}
+
+// localEndpointCredentials are the access key ID and secret key used with a local
+// endpoint, when no credentials are set
+const localEndpointCredentials = "test"
+
+// RegionalSTSEndpoint returns the STS endpoint of the region, which is used in place of the
+// global endpoint, e.g. when only the regional one is reachable through a VPC endpoint
+func RegionalSTSEndpoint(region string) string {
+	dnsSuffix := "amazonaws.com"
+	if strings.HasPrefix(region, "cn-") {
+		dnsSuffix = "amazonaws.com.cn"
+	}
+	return fmt.Sprintf("https://sts.%s.%s", region, dnsSuffix)
+}
+
+// New creates a new setup of the used AWS APIs, it fails when the CA bundle can't be used
+func New(spec *api.ProviderConfig, clusterSpec *api.ClusterConfig) (*ClusterProvider, error) {
 	provider := &ProviderServices{
 		spec: spec,
 	}
 	c := &ClusterProvider{
-		Provider: provider,
+		Provider:          provider,
+		stackNamePrefix:   spec.StackNamePrefix,
+		disableRollback:   spec.CloudFormationDisableRollback,
+		templateOverrides: spec.CloudFormationTemplateOverrides,
+		clusters:          newClusterCache(),
+		stacks:            manager.NewStackCache(),
+	}
+	setProxyEnvironment(clusterSpec)
+	if spec.CABundle != "" {
+		caBundle, err := ReadCABundle(spec.CABundle)
+		if err != nil {
+			return nil, eksctlerrors.WithClass(eksctlerrors.ClassValidation, err)
+		}
+		c.caBundle = caBundle
 	}
 	// Create a new session and save credentials for possible
 	// later re-use if overriding sessions due to custom URL
 	s := c.newSession(spec)
+	c.httpClient = s.Config.HTTPClient
 
 	provider.cfn = cloudformation.New(s)
 	provider.eks = awseks.New(s)
 	provider.ec2 = ec2.New(s)
 	provider.elb = elb.New(s)
 	provider.elbv2 = elbv2.New(s)
-	provider.sts = sts.New(s,
-		// STS retrier has to be disabled, as it's not very helpful
-		// (see https://github.com/weaveworks/eksctl/issues/705)
-		request.WithRetryer(s.Config.Copy(),
+	// STS retrier has to be disabled, as it's not very helpful
+	// (see https://github.com/weaveworks/eksctl/issues/705)
+	stsConfig := func(config *aws.Config) *aws.Config {
+		return request.WithRetryer(config,
 			&client.DefaultRetryer{
 				NumMaxRetries: 1,
 			},
-		),
-	)
+		)
+	}
+	provider.sts = sts.New(s, stsConfig(s.Config.Copy()))
 	provider.iam = iam.New(s)
 	provider.cloudtrail = cloudtrail.New(s)
+	provider.ssm = ssm.New(s)
+	provider.asg = autoscaling.New(s)
+	provider.s3 = s3.New(s)
+	provider.logs = cloudwatchlogs.New(s)
+	provider.sns = sns.New(s)
+	provider.tagging = resourcegroupstaggingapi.New(s)
+	// the Pricing API is only served from a couple of regions,
+	// so it's always called in us-east-1
+	provider.pricing = pricing.New(s, s.Config.Copy().WithRegion(pricingRegion))
 
 	c.Status = &ProviderStatus{
 		sessionCreds: s.Config.Credentials,
 	}
 
 	// override sessions if any custom endpoints specified
-	if endpoint, ok := os.LookupEnv("AWS_CLOUDFORMATION_ENDPOINT"); ok {
-		logger.Debug("Setting CloudFormation endpoint to %s", endpoint)
-		provider.cfn = cloudformation.New(s, s.Config.Copy().WithEndpoint(endpoint))
+	override := func(service string) (*aws.Config, bool) {
+		endpoint, ok := spec.Endpoints[service]
+		if !ok {
+			endpoint, ok = os.LookupEnv(endpointEnvVars[service])
+		}
+		if !ok && spec.LocalEndpoint != "" {
+			endpoint, ok = spec.LocalEndpoint, true
+		}
+		if !ok {
+			return nil, false
+		}
+		logger.Debug("Setting %s endpoint to %s", service, endpoint)
+		return s.Config.Copy().WithEndpoint(endpoint), true
 	}
-	if endpoint, ok := os.LookupEnv("AWS_EKS_ENDPOINT"); ok {
-		logger.Debug("Setting EKS endpoint to %s", endpoint)
-		provider.eks = awseks.New(s, s.Config.Copy().WithEndpoint(endpoint))
+	for service := range spec.Endpoints {
+		if _, ok := endpointEnvVars[service]; !ok {
+			logger.Warning("ignoring endpoint of unknown service %q, supported services: %s", service, strings.Join(EndpointServices(), ", "))
+		}
 	}
-	if endpoint, ok := os.LookupEnv("AWS_EC2_ENDPOINT"); ok {
-		logger.Debug("Setting EC2 endpoint to %s", endpoint)
-		provider.ec2 = ec2.New(s, s.Config.Copy().WithEndpoint(endpoint))
-
+	if config, ok := override("cloudformation"); ok {
+		provider.cfn = cloudformation.New(s, config)
 	}
-	if endpoint, ok := os.LookupEnv("AWS_ELB_ENDPOINT"); ok {
-		logger.Debug("Setting ELB endpoint to %s", endpoint)
-		provider.elb = elb.New(s, s.Config.Copy().WithEndpoint(endpoint))
-
+	if config, ok := override("eks"); ok {
+		provider.eks = awseks.New(s, config)
 	}
-	if endpoint, ok := os.LookupEnv("AWS_ELBV2_ENDPOINT"); ok {
-		logger.Debug("Setting ELBV2 endpoint to %s", endpoint)
-		provider.elbv2 = elbv2.New(s, s.Config.Copy().WithEndpoint(endpoint))
-
+	if config, ok := override("ec2"); ok {
+		provider.ec2 = ec2.New(s, config)
 	}
-	if endpoint, ok := os.LookupEnv("AWS_STS_ENDPOINT"); ok {
+	if config, ok := override("elb"); ok {
+		provider.elb = elb.New(s, config)
+	}
+	if config, ok := override("elbv2"); ok {
+		provider.elbv2 = elbv2.New(s, config)
+	}
+	if config, ok := override("sts"); ok {
+		provider.sts = sts.New(s, stsConfig(config))
+	} else if spec.STSRegionalEndpoint {
+		endpoint := RegionalSTSEndpoint(aws.StringValue(s.Config.Region))
 		logger.Debug("Setting STS endpoint to %s", endpoint)
-		provider.sts = sts.New(s, s.Config.Copy().WithEndpoint(endpoint))
+		provider.sts = sts.New(s, stsConfig(s.Config.Copy().WithEndpoint(endpoint)))
+	}
+	if config, ok := override("iam"); ok {
+		provider.iam = iam.New(s, config)
+	}
+	if config, ok := override("cloudtrail"); ok {
+		provider.cloudtrail = cloudtrail.New(s, config)
+	}
+	if config, ok := override("ssm"); ok {
+		provider.ssm = ssm.New(s, config)
+	}
+	if config, ok := override("autoscaling"); ok {
+		provider.asg = autoscaling.New(s, config)
+	}
+	if config, ok := override("s3"); ok {
+		provider.s3 = s3.New(s, config)
+	}
+	if config, ok := override("logs"); ok {
+		provider.logs = cloudwatchlogs.New(s, config)
 	}
-	if endpoint, ok := os.LookupEnv("AWS_IAM_ENDPOINT"); ok {
-		logger.Debug("Setting IAM endpoint to %s", endpoint)
-		provider.iam = iam.New(s, s.Config.Copy().WithEndpoint(endpoint))
+	if config, ok := override("sns"); ok {
+		provider.sns = sns.New(s, config)
 	}
-	if endpoint, ok := os.LookupEnv("AWS_CLOUDTRAIL_ENDPOINT"); ok {
-		logger.Debug("Setting CloudTrail endpoint to %s", endpoint)
-		provider.cloudtrail = cloudtrail.New(s, s.Config.Copy().WithEndpoint(endpoint))
+	if config, ok := override("tagging"); ok {
+		provider.tagging = resourcegroupstaggingapi.New(s, config)
+	}
+	if config, ok := override("pricing"); ok {
+		provider.pricing = pricing.New(s, config.WithRegion(pricingRegion))
 	}
 
 	if clusterSpec != nil {
 		clusterSpec.Metadata.Region = c.Provider.Region()
+		c.setStackNamePrefix(clusterSpec)
 	}
 
-	return c
+	return c, nil
 }
 
-// LoadConfigFromFile loads ClusterConfig from configFile
-func LoadConfigFromFile(configFile string) (*api.ClusterConfig, error) {
+// NewForNodeGroup creates a new setup of the AWS APIs for a nodegroup with a provider
+// override, using its profile and assuming its role, in the region of spec
+func NewForNodeGroup(spec *api.ProviderConfig, clusterSpec *api.ClusterConfig, ng *api.NodeGroup) (*ClusterProvider, error) {
+	ngSpec := *spec
+	if ng.ProviderOverride.Profile != "" {
+		ngSpec.Profile = ng.ProviderOverride.Profile
+	}
+	ngSpec.RoleARN = ng.ProviderOverride.RoleARN
+	return New(&ngSpec, clusterSpec)
+}
+
+// ConfigDocument is one of the YAML documents of a config file
+type ConfigDocument struct {
+	Data []byte
+	// Line is the line of the config file the document starts at
+	Line int
+}
+
+// SplitConfigDocuments splits the data of a config file into its YAML documents,
+// separated by "---" lines; documents that are empty or only hold comments are skipped
+func SplitConfigDocuments(data []byte) []ConfigDocument {
+	docs := []ConfigDocument{}
+	lines := strings.SplitAfter(string(data), "\n")
+	current, start := "", 1
+	add := func() {
+		if !isEmptyDocument(current) {
+			docs = append(docs, ConfigDocument{Data: []byte(current), Line: start})
+		}
+	}
+	for i, line := range lines {
+		if strings.HasPrefix(line, "---") && strings.TrimSpace(strings.TrimPrefix(line, "---")) == "" {
+			add()
+			current, start = "", i+2
+			continue
+		}
+		current += line
+	}
+	add()
+	return docs
+}
+
+func isEmptyDocument(doc string) bool {
+	for _, line := range strings.Split(doc, "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadConfigsFromFile loads all the ClusterConfig documents of configFile
+func LoadConfigsFromFile(configFile string) ([]*api.ClusterConfig, error) {
 	data, err := readConfig(configFile)
 	if err != nil {
 		return nil, errors.Wrapf(err, "reading config file %q", configFile)
 	}
 
+	docs := SplitConfigDocuments(data)
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("config file %q is empty", configFile)
+	}
+	cfgs := []*api.ClusterConfig{}
+	names := map[string]int{}
+	for _, doc := range docs {
+		cfg, err := decodeConfig(doc.Data)
+		if err != nil {
+			if len(docs) > 1 {
+				return nil, errors.Wrapf(err, "loading document at line %d of config file %q", doc.Line, configFile)
+			}
+			return nil, errors.Wrapf(err, "loading config file %q", configFile)
+		}
+		if line, ok := names[cfg.Metadata.Name]; ok && len(docs) > 1 {
+			return nil, fmt.Errorf("cluster %q is defined at lines %d and %d of config file %q", cfg.Metadata.Name, line, doc.Line, configFile)
+		}
+		names[cfg.Metadata.Name] = doc.Line
+		cfgs = append(cfgs, cfg)
+	}
+	return cfgs, nil
+}
+
+// LoadNamedConfigFromFile loads the ClusterConfig of the cluster called name from
+// configFile; name may be empty when the file defines a single cluster
+func LoadNamedConfigFromFile(configFile, name string) (*api.ClusterConfig, error) {
+	cfgs, err := LoadConfigsFromFile(configFile)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		if len(cfgs) > 1 {
+			return nil, fmt.Errorf("config file %q defines %d clusters (%s), select one with --cluster-name or use --all-clusters",
+				configFile, len(cfgs), strings.Join(ConfigNames(cfgs), ", "))
+		}
+		return cfgs[0], nil
+	}
+	for _, cfg := range cfgs {
+		if cfg.Metadata.Name == name {
+			return cfg, nil
+		}
+	}
+	return nil, fmt.Errorf("config file %q doesn't define cluster %q, it defines: %s",
+		configFile, name, strings.Join(ConfigNames(cfgs), ", "))
+}
+
+// LoadConfigFromFile loads ClusterConfig from configFile, which must define a single cluster
+func LoadConfigFromFile(configFile string) (*api.ClusterConfig, error) {
+	return LoadNamedConfigFromFile(configFile, "")
+}
+
+// ConfigNames returns the names of the clusters of cfgs
+func ConfigNames(cfgs []*api.ClusterConfig) []string {
+	names := []string{}
+	for _, cfg := range cfgs {
+		names = append(names, cfg.Metadata.Name)
+	}
+	return names
+}
+
+func decodeConfig(data []byte) (*api.ClusterConfig, error) {
 	// strict mode is not available in runtime.Decode, so we use the parser
 	// directly; we don't store the resulting object, this is just the means
 	// of detecting any unknown keys
 	// NOTE: we must use sigs.k8s.io/yaml, as it behaves differently from
 	// github.com/ghodss/yaml, which didn't handle nested structs well
 	if err := yaml.UnmarshalStrict(data, &api.ClusterConfig{}); err != nil {
-		return nil, errors.Wrapf(err, "loading config file %q", configFile)
+		return nil, err
 	}
 
 	obj, err := runtime.Decode(scheme.Codecs.UniversalDeserializer(), data)
 	if err != nil {
-		return nil, errors.Wrapf(err, "loading config file %q", configFile)
+		return nil, err
 	}
 
 	cfg, ok := obj.(*api.ClusterConfig)
 	if !ok {
-		return nil, fmt.Errorf("expected to decode object of type %T; got %T", &api.ClusterConfig{}, cfg)
+		return nil, fmt.Errorf("expected to decode object of type %T; got %T", &api.ClusterConfig{}, obj)
 	}
 	return cfg, nil
 }
@@ -260,10 +545,19 @@ func (c *ClusterProvider) CheckAuth() error {
 
 // EnsureAMI ensures that the node AMI is set and is available
 func (c *ClusterProvider) EnsureAMI(version string, ng *api.NodeGroup) error {
-	if ng.AMI == ami.ResolverAuto {
+	switch ng.AMI {
+	case ami.ResolverAuto:
 		ami.DefaultResolvers = []ami.Resolver{ami.NewAutoResolver(c.Provider.EC2())}
+	case ami.ResolverAutoSSM:
+		ami.DefaultResolvers = []ami.Resolver{ami.NewSSMResolver(c.Provider.SSM())}
+	case ami.ResolverStatic:
+		// static AMIs are only known in the standard partition
+		if partition := api.Partition(c.Provider.Region()); partition != api.PartitionAWS {
+			logger.Info("no static AMIs are known in partition %s, resolving AMI using SSM parameters", partition)
+			ami.DefaultResolvers = []ami.Resolver{ami.NewSSMResolver(c.Provider.SSM())}
+		}
 	}
-	if ng.AMI == ami.ResolverStatic || ng.AMI == ami.ResolverAuto {
+	if ng.AMI == ami.ResolverStatic || ng.AMI == ami.ResolverAuto || ng.AMI == ami.ResolverAutoSSM {
 		instanceType := selectInstanceType(ng)
 		id, err := ami.Resolve(c.Provider.Region(), version, instanceType, ng.AMIFamily)
 		if err != nil {
@@ -311,8 +605,12 @@ func errTooFewAvailabilityZones(azs []string) error {
 	return fmt.Errorf("only %d zones specified %v, %d are required (can be non-unque)", len(azs), azs, az.MinRequiredAvailabilityZones)
 }
 
-// SetAvailabilityZones sets the given (or chooses) the availability zones
+// SetAvailabilityZones sets the given (or chooses) the availability zones,
+// Local Zones and Wavelength Zones among them are moved to spec.LocalZones
 func (c *ClusterProvider) SetAvailabilityZones(spec *api.ClusterConfig, given []string) error {
+	given = separateLocalZones(spec, given)
+	spec.AvailabilityZones = separateLocalZones(spec, spec.AvailabilityZones)
+
 	if count := len(given); count != 0 {
 		if count < az.MinRequiredAvailabilityZones {
 			return errTooFewAvailabilityZones(given)
@@ -344,6 +642,33 @@ func (c *ClusterProvider) SetAvailabilityZones(spec *api.ClusterConfig, given []
 	return nil
 }
 
+func separateLocalZones(spec *api.ClusterConfig, zones []string) []string {
+	if zones == nil {
+		return nil
+	}
+	availabilityZones := []string{}
+	for _, zone := range zones {
+		if !api.IsLocalZone(spec.Metadata.Region, zone) {
+			availabilityZones = append(availabilityZones, zone)
+			continue
+		}
+		if !utils.IsOneOf(zone, spec.LocalZones) {
+			logger.Info("%s is a Local Zone or Wavelength Zone, it will only be used by nodegroups", zone)
+			spec.LocalZones = append(spec.LocalZones, zone)
+		}
+	}
+	return availabilityZones
+}
+
+// HTTPClient returns the HTTP client of the AWS session, for requests to endpoints that aren't
+// AWS APIs; it trusts the CA bundle of --ca-bundle and goes through the configured proxy
+func (c *ClusterProvider) HTTPClient() *http.Client {
+	if c.httpClient == nil {
+		return http.DefaultClient
+	}
+	return c.httpClient
+}
+
 func (c *ClusterProvider) newSession(spec *api.ProviderConfig) *session.Session {
 	// we might want to use bits from kops, although right now it seems like too many thing we
 	// don't want yet
@@ -356,17 +681,25 @@ func (c *ClusterProvider) newSession(spec *api.ProviderConfig) *session.Session
 
 	config = config.WithCredentialsChainVerboseErrors(true)
 	config = request.WithRetryer(config, newLoggingRetryer())
-	if logger.Level >= api.AWSDebugLevel {
+	if logging.IsAWSDebug() {
 		config = config.WithLogLevel(aws.LogDebug |
 			aws.LogDebugWithHTTPBody |
 			aws.LogDebugWithRequestRetries |
 			aws.LogDebugWithRequestErrors |
 			aws.LogDebugWithEventStreamBody)
 		config = config.WithLogger(aws.LoggerFunc(func(args ...interface{}) {
-			logger.Debug(fmt.Sprintln(args...))
+			logger.Debug("%s", fmt.Sprintln(args...))
 		}))
 	}
 
+	if spec.LocalEndpoint != "" {
+		// local emulations serve all buckets from the same host, and accept any credentials
+		config = config.WithS3ForcePathStyle(true)
+		if spec.Profile == "" && os.Getenv("AWS_ACCESS_KEY_ID") == "" {
+			config = config.WithCredentials(credentials.NewStaticCredentials(localEndpointCredentials, localEndpointCredentials, ""))
+		}
+	}
+
 	// Create the options for the session
 	opts := session.Options{
 		Config:                  *config,
@@ -374,6 +707,9 @@ func (c *ClusterProvider) newSession(spec *api.ProviderConfig) *session.Session
 		Profile:                 spec.Profile,
 		AssumeRoleTokenProvider: stscreds.StdinTokenProvider,
 	}
+	if len(c.caBundle) > 0 {
+		opts.CustomCABundle = bytes.NewReader(c.caBundle)
+	}
 
 	stscreds.DefaultDuration = 30 * time.Minute
 
@@ -385,22 +721,99 @@ func (c *ClusterProvider) newSession(spec *api.ProviderConfig) *session.Session
 			"eksctl", version.String()),
 	})
 
+	if spec.AWSDebug {
+		s.Handlers.Complete.PushBackNamed(apiTraceHandler)
+	}
+
+	if metrics.Enabled() {
+		s.Handlers.Complete.PushBackNamed(metrics.AWSAPICallHandler)
+		s.Handlers.AfterRetry.PushFrontNamed(metrics.AWSAPIRetryHandler)
+	}
+
+	if path := os.Getenv(apirecorder.EnvVar); path != "" {
+		logger.Warning("recording AWS API calls to fixture file %q", path)
+		s.Handlers.Complete.PushBackNamed(apirecorder.New(path).Handler())
+	}
+
+	if spec.RoleARN != "" {
+		logger.Debug("assuming role %q", spec.RoleARN)
+		if spec.STSRegionalEndpoint && api.IsSetAndNonEmptyString(s.Config.Region) {
+			stsClient := sts.New(s, s.Config.Copy().WithEndpoint(RegionalSTSEndpoint(aws.StringValue(s.Config.Region))))
+			s = s.Copy(&aws.Config{Credentials: stscreds.NewCredentialsWithClient(stsClient, spec.RoleARN)})
+		} else {
+			s = s.Copy(&aws.Config{Credentials: stscreds.NewCredentials(s, spec.RoleARN)})
+		}
+	}
+
 	if spec.Region == "" {
 		if api.IsSetAndNonEmptyString(s.Config.Region) {
 			// set cluster config region, based on session config
 			spec.Region = *s.Config.Region
+			spec.RegionSource = sessionRegionSource(spec.Region, os.Getenv)
 		} else {
-			// if session config doesn't have region set, make recursive call forcing default region
-			logger.Debug("no region specified in flags or config, setting to %s", api.DefaultRegion)
-			spec.Region = api.DefaultRegion
+			// if session config doesn't have region set, detect the region of the
+			// environment, or fall back to the default region, and make recursive
+			// call with it
+			spec.Region, spec.RegionSource = newRegionDetector(s).detect()
+			if spec.Region == "" {
+				spec.Region, spec.RegionSource = api.DefaultRegion, RegionSourceDefault
+			}
 			return c.newSession(spec)
 		}
 	}
+	if spec.RegionSource == "" {
+		spec.RegionSource = RegionSourceFlag
+	}
+	logger.Debug("using region %s from %s", spec.Region, spec.RegionSource)
 
 	return s
 }
 
 // NewStackManager returns a new stack manager
 func (c *ClusterProvider) NewStackManager(spec *api.ClusterConfig) *manager.StackCollection {
-	return manager.NewStackCollection(c.Provider, spec)
+	c.setStackNamePrefix(spec)
+	c.setDisableRollback(spec)
+	c.setTemplateOverrides(spec)
+	stackManager := manager.NewStackCollection(c.Provider, spec)
+	stackManager.SetStackCache(c.stacks)
+	return stackManager
+}
+
+// setStackNamePrefix sets the stack name prefix of the flag, unless the config
+// file sets one
+func (c *ClusterProvider) setStackNamePrefix(spec *api.ClusterConfig) {
+	if c.stackNamePrefix == "" {
+		return
+	}
+	if spec.CloudFormation == nil {
+		spec.CloudFormation = &api.ClusterCloudFormation{}
+	}
+	if spec.CloudFormation.StackNamePrefix == "" {
+		spec.CloudFormation.StackNamePrefix = c.stackNamePrefix
+	}
+}
+
+// setDisableRollback disables the rollback of stacks when the flag is set
+func (c *ClusterProvider) setDisableRollback(spec *api.ClusterConfig) {
+	if !c.disableRollback {
+		return
+	}
+	if spec.CloudFormation == nil {
+		spec.CloudFormation = &api.ClusterCloudFormation{}
+	}
+	spec.CloudFormation.DisableRollback = api.Enabled()
+}
+
+// setTemplateOverrides sets the template overrides directory of the flag, unless
+// the config file sets one
+func (c *ClusterProvider) setTemplateOverrides(spec *api.ClusterConfig) {
+	if c.templateOverrides == "" {
+		return
+	}
+	if spec.CloudFormation == nil {
+		spec.CloudFormation = &api.ClusterCloudFormation{}
+	}
+	if spec.CloudFormation.TemplateOverrides == "" {
+		spec.CloudFormation.TemplateOverrides = c.templateOverrides
+	}
 }
@@ -3,6 +3,9 @@ package eks_test
 import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sts"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -67,6 +70,37 @@ var _ = Describe("eksctl API", func() {
 			Expect(err.Error()).To(HavePrefix(`loading config file "testdata/old-version.json": no kind "ClusterConfig" is registered for version "eksctl.io/v1alpha3" in scheme`))
 		})
 
+		It("should load all the clusters of a multi-document config", func() {
+			cfgs, err := LoadConfigsFromFile("testdata/multi-cluster.yaml")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ConfigNames(cfgs)).To(Equal([]string{"cluster-1", "cluster-2"}))
+			Expect(cfgs[1].Metadata.Region).To(Equal("us-west-2"))
+			Expect(cfgs[1].NodeGroups).To(HaveLen(2))
+		})
+
+		It("should load the selected cluster of a multi-document config", func() {
+			cfg, err := LoadNamedConfigFromFile("testdata/multi-cluster.yaml", "cluster-2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(cfg.Metadata.Name).To(Equal("cluster-2"))
+
+			_, err = LoadNamedConfigFromFile("testdata/multi-cluster.yaml", "cluster-3")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(`config file "testdata/multi-cluster.yaml" doesn't define cluster "cluster-3", it defines: cluster-1, cluster-2`))
+		})
+
+		It("should require a cluster to be selected in a multi-document config", func() {
+			_, err := LoadConfigFromFile("testdata/multi-cluster.yaml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(`config file "testdata/multi-cluster.yaml" defines 2 clusters (cluster-1, cluster-2), select one with --cluster-name or use --all-clusters`))
+		})
+
+		It("should split documents and keep track of their lines", func() {
+			docs := SplitConfigDocuments([]byte("# comment\n---\nkind: ClusterConfig\n---\n\n---\nkind: ClusterConfig\n"))
+			Expect(docs).To(HaveLen(2))
+			Expect(docs[0].Line).To(Equal(3))
+			Expect(docs[1].Line).To(Equal(7))
+		})
+
 		It("should error when cannot read a file", func() {
 			_, err := LoadConfigFromFile("../../examples/nothing.xml")
 			Expect(err).To(HaveOccurred())
@@ -150,6 +184,51 @@ var _ = Describe("eksctl API", func() {
 			Expect(ng.AMI).To(Equal("ami-084e8e620163aa50e"))
 		})
 	})
+
+	Context("endpoints", func() {
+
+		It("should use the regional STS endpoint of the partition", func() {
+			Expect(RegionalSTSEndpoint("us-west-2")).To(Equal("https://sts.us-west-2.amazonaws.com"))
+			Expect(RegionalSTSEndpoint("cn-north-1")).To(Equal("https://sts.cn-north-1.amazonaws.com.cn"))
+		})
+
+		It("should override endpoints of services", func() {
+			c, err := New(&api.ProviderConfig{
+				Region:    "us-west-2",
+				Endpoints: map[string]string{"eks": "https://eks.example.com"},
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c.Provider.EKS().(*awseks.EKS).Endpoint).To(Equal("https://eks.example.com"))
+			Expect(c.Provider.STS().(*sts.STS).Endpoint).To(Equal("https://sts.amazonaws.com"))
+		})
+
+		It("should use the regional STS endpoint unless it's overridden", func() {
+			c, err := New(&api.ProviderConfig{Region: "us-west-2", STSRegionalEndpoint: true}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c.Provider.STS().(*sts.STS).Endpoint).To(Equal("https://sts.us-west-2.amazonaws.com"))
+
+			c, err = New(&api.ProviderConfig{
+				Region:              "us-west-2",
+				Endpoints:           map[string]string{"sts": "https://sts.example.com"},
+				STSRegionalEndpoint: true,
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c.Provider.STS().(*sts.STS).Endpoint).To(Equal("https://sts.example.com"))
+		})
+
+		It("should call services without an endpoint at the local endpoint", func() {
+			c, err := New(&api.ProviderConfig{
+				Region:        "us-east-1",
+				Endpoints:     map[string]string{"eks": "https://eks.example.com"},
+				LocalEndpoint: "http://localhost:4566",
+			}, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(c.Provider.EKS().(*awseks.EKS).Endpoint).To(Equal("https://eks.example.com"))
+			Expect(c.Provider.STS().(*sts.STS).Endpoint).To(Equal("http://localhost:4566"))
+			Expect(c.Provider.S3().(*s3.S3).Endpoint).To(Equal("http://localhost:4566"))
+			Expect(aws.BoolValue(c.Provider.S3().(*s3.S3).Config.S3ForcePathStyle)).To(BeTrue())
+		})
+	})
 })
 
 func mockDescribeImages(p *mockprovider.MockProvider, expectedNamePattern string, amiId string) {
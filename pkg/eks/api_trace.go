@@ -0,0 +1,50 @@
+package eks
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/kris-nova/logger"
+)
+
+// apiTraceHandler logs every AWS API call with a single line of key=value pairs,
+// which is much less verbose than SDK debug logging and is enough to spot slow
+// calls and throttling
+var apiTraceHandler = request.NamedHandler{
+	Name: "eksctlAPITrace",
+	Fn: func(r *request.Request) {
+		logger.Info("%s", formatAPICall(r, time.Since(r.Time)))
+	},
+}
+
+func formatAPICall(r *request.Request, duration time.Duration) string {
+	operation := "?"
+	if r.Operation != nil {
+		operation = r.Operation.Name
+	}
+
+	fields := []string{
+		"aws-api",
+		fmt.Sprintf("service=%s", r.ClientInfo.ServiceName),
+		fmt.Sprintf("operation=%s", operation),
+		fmt.Sprintf("duration=%s", duration.Round(time.Millisecond)),
+		fmt.Sprintf("retries=%d", r.RetryCount),
+	}
+	if r.HTTPResponse != nil {
+		fields = append(fields, fmt.Sprintf("status=%d", r.HTTPResponse.StatusCode))
+	}
+	if r.RequestID != "" {
+		fields = append(fields, fmt.Sprintf("request-id=%s", r.RequestID))
+	}
+	if r.Error != nil {
+		code := "unknown"
+		if awsErr, ok := r.Error.(awserr.Error); ok {
+			code = awsErr.Code()
+		}
+		fields = append(fields, fmt.Sprintf("error=%s", code))
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,41 @@
+package eks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AWS API tracing", func() {
+	var r *request.Request
+
+	BeforeEach(func() {
+		r = &request.Request{
+			ClientInfo:   metadata.ClientInfo{ServiceName: "cloudformation"},
+			Operation:    &request.Operation{Name: "DescribeStacks"},
+			HTTPResponse: &http.Response{StatusCode: 200},
+			RequestID:    "abc-123",
+		}
+	})
+
+	It("formats a successful call", func() {
+		Expect(formatAPICall(r, 231400*time.Microsecond)).To(Equal(
+			"aws-api service=cloudformation operation=DescribeStacks duration=231ms retries=0 status=200 request-id=abc-123",
+		))
+	})
+
+	It("formats a throttled call", func() {
+		r.RetryCount = 3
+		r.HTTPResponse.StatusCode = 400
+		r.Error = awserr.New("Throttling", "Rate exceeded", nil)
+
+		Expect(formatAPICall(r, 2*time.Second)).To(Equal(
+			"aws-api service=cloudformation operation=DescribeStacks duration=2s retries=3 status=400 request-id=abc-123 error=Throttling",
+		))
+	})
+})
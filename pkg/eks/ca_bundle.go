@@ -0,0 +1,21 @@
+package eks
+
+import (
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+// ReadCABundle reads a file of PEM-encoded certificates, it fails unless there
+// is at least one certificate in it
+func ReadCABundle(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading CA bundle %q", path)
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(data) {
+		return nil, errors.Errorf("CA bundle %q doesn't contain any PEM-encoded certificate", path)
+	}
+	return data, nil
+}
@@ -0,0 +1,72 @@
+package eks_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/eks"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+const testCABundle = `-----BEGIN CERTIFICATE-----
+MIIBijCCAS+gAwIBAgIUJvBNeRTmuKUlCuC2AuoWNZFHAQowCgYIKoZIzj0EAwIw
+GTEXMBUGA1UEAwwOZWtzY3RsLXRlc3QtY2EwIBcNMjYxMDE2MTUxMjE1WhgPMjEy
+NjA5MjIxNTEyMTVaMBkxFzAVBgNVBAMMDmVrc2N0bC10ZXN0LWNhMFkwEwYHKoZI
+zj0CAQYIKoZIzj0DAQcDQgAEAfjoJysHub/XayyYye2K61VrOHoZM5Rj7tFX7bwd
+V3DUvj/Ip5cIc7oyFoIFbcdcQlFCZDYHIwQvaFgr+r1GD6NTMFEwHQYDVR0OBBYE
+FMvfX5FSOL8po9ktP1K/qSotjoluMB8GA1UdIwQYMBaAFMvfX5FSOL8po9ktP1K/
+qSotjoluMA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDSQAwRgIhAKUJ3vTE
+RT5+5uu+84OARDszsYZO+IiuXtqjtfP5OV+oAiEAsyaFXz9duD4bkNV3pqyaaybv
+0VyC6Z/u3sccgsDAW/0=
+-----END CERTIFICATE-----
+`
+
+var _ = Describe("CA bundle", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "ca-bundle")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("should read PEM-encoded certificates", func() {
+		path := filepath.Join(dir, "ca.pem")
+		Expect(ioutil.WriteFile(path, []byte(testCABundle), 0644)).To(Succeed())
+
+		data, err := ReadCABundle(path)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(testCABundle))
+	})
+
+	It("should fail without certificates", func() {
+		path := filepath.Join(dir, "ca.pem")
+		Expect(ioutil.WriteFile(path, []byte("not a certificate"), 0644)).To(Succeed())
+
+		_, err := ReadCABundle(path)
+		Expect(err).To(MatchError(ContainSubstring("doesn't contain any PEM-encoded certificate")))
+	})
+
+	It("should fail when the file doesn't exist", func() {
+		_, err := ReadCABundle(filepath.Join(dir, "missing.pem"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should fail to set up the AWS APIs without certificates", func() {
+		path := filepath.Join(dir, "ca.pem")
+		Expect(ioutil.WriteFile(path, []byte("not a certificate"), 0644)).To(Succeed())
+
+		_, err := New(&api.ProviderConfig{Region: "us-west-2", CABundle: path}, nil)
+		Expect(err).To(MatchError(ContainSubstring("doesn't contain any PEM-encoded certificate")))
+		Expect(eksctlerrors.ClassOf(err)).To(Equal(eksctlerrors.ClassValidation))
+	})
+})
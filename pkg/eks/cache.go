@@ -0,0 +1,49 @@
+package eks
+
+import (
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+)
+
+// clusterCache memoizes descriptions of active control planes for the duration of
+// a command, so that checks and updates don't describe the same cluster again;
+// clusters in any other status are changing, so they are always described
+type clusterCache struct {
+	mu       sync.Mutex
+	clusters map[string]*awseks.Cluster
+}
+
+func newClusterCache() *clusterCache {
+	return &clusterCache{clusters: make(map[string]*awseks.Cluster)}
+}
+
+// get returns the cached description of a cluster, a nil cache never has one
+func (c *clusterCache) get(name string) (*awseks.Cluster, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cluster, ok := c.clusters[name]
+	return cluster, ok
+}
+
+func (c *clusterCache) set(cluster *awseks.Cluster) {
+	if c == nil || aws.StringValue(cluster.Status) != awseks.ClusterStatusActive {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clusters[aws.StringValue(cluster.Name)] = cluster
+}
+
+func (c *clusterCache) invalidate(name string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.clusters, name)
+}
@@ -25,6 +25,8 @@ type Client struct {
 	ContextName string
 
 	rawConfig *restclient.Config
+	// caBundle is trusted in addition to the CA of the cluster
+	caBundle []byte
 }
 
 // NewClient creates a new client config by embedding the STS token
@@ -34,6 +36,7 @@ func (c *ClusterProvider) NewClient(spec *api.ClusterConfig) (*Client, error) {
 	config := &Client{
 		Config:      clientConfig,
 		ContextName: contextName,
+		caBundle:    c.caBundle,
 	}
 
 	return config.new(spec, c.Provider.STS())
@@ -57,6 +60,11 @@ func (c *Client) new(spec *api.ClusterConfig, stsClient stsiface.STSAPI) (*Clien
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create API client configuration from client config")
 	}
+	if len(c.caBundle) > 0 {
+		// the CA of the cluster still has to be trusted when the bundle is set, as requests may
+		// not go through the TLS-intercepting proxy the bundle is for
+		rawConfig.TLSClientConfig.CAData = append(append(rawConfig.TLSClientConfig.CAData, '\n'), c.caBundle...)
+	}
 	c.rawConfig = rawConfig
 
 	return c, nil
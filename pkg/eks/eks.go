@@ -4,26 +4,41 @@ import (
 	"encoding/base64"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 
-	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	awseks "github.com/aws/aws-sdk-go/service/eks"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/logging"
 	"github.com/weaveworks/eksctl/pkg/printers"
-	"github.com/weaveworks/eksctl/pkg/utils/waiters"
+	"github.com/weaveworks/eksctl/pkg/utils/selector"
 	"github.com/weaveworks/eksctl/pkg/vpc"
 )
 
-// DescribeControlPlane describes the cluster control plane
+// DescribeControlPlane describes the cluster control plane, active clusters are only
+// described once per command, unless they are updated
 func (c *ClusterProvider) DescribeControlPlane(cl *api.ClusterMeta) (*awseks.Cluster, error) {
+	if cluster, ok := c.clusters.get(cl.Name); ok {
+		logger.Debug("using cached description of cluster %q", cl.Name)
+		return cluster, nil
+	}
 	input := &awseks.DescribeClusterInput{
 		Name: &cl.Name,
 	}
@@ -31,9 +46,17 @@ func (c *ClusterProvider) DescribeControlPlane(cl *api.ClusterMeta) (*awseks.Clu
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to describe cluster control plane")
 	}
+	c.clusters.set(output.Cluster)
 	return output.Cluster, nil
 }
 
+// InvalidateCachedControlPlane makes the next DescribeControlPlane call describe the
+// cluster again, it must be called after the cluster is changed by other means than
+// the update methods of ClusterProvider
+func (c *ClusterProvider) InvalidateCachedControlPlane(cl *api.ClusterMeta) {
+	c.clusters.invalidate(cl.Name)
+}
+
 // DescribeControlPlaneMustBeActive describes the cluster control plane and checks if status is active
 func (c *ClusterProvider) DescribeControlPlaneMustBeActive(cl *api.ClusterMeta) (*awseks.Cluster, error) {
 	cluster, err := c.DescribeControlPlane(cl)
@@ -47,7 +70,7 @@ func (c *ClusterProvider) DescribeControlPlaneMustBeActive(cl *api.ClusterMeta)
 	return cluster, nil
 }
 
-// GetCredentials retrieves cluster endpoint and the certificate authority data
+// GetCredentials retrieves cluster endpoint, the certificate authority data and the rest of the cluster status
 func (c *ClusterProvider) GetCredentials(spec *api.ClusterConfig) error {
 	// Check the cluster exists and is active
 	cluster, err := c.DescribeControlPlaneMustBeActive(spec.Metadata)
@@ -56,24 +79,60 @@ func (c *ClusterProvider) GetCredentials(spec *api.ClusterConfig) error {
 	}
 	logger.Debug("cluster = %#v", cluster)
 
-	data, err := base64.StdEncoding.DecodeString(*cluster.CertificateAuthority.Data)
-	if err != nil {
-		return errors.Wrap(err, "decoding certificate authority data")
+	if err := SetClusterStatus(spec, cluster); err != nil {
+		return err
 	}
 
+	c.Status.cachedClusterInfo = cluster
+
+	return nil
+}
+
+// SetClusterStatus sets the status of spec from the description of its control plane,
+// attributes that aren't known yet, e.g. while the cluster is being created, are left empty
+func SetClusterStatus(spec *api.ClusterConfig, cluster *awseks.Cluster) error {
 	if spec.Status == nil {
 		spec.Status = &api.ClusterStatus{}
 	}
 
-	spec.Status.Endpoint = *cluster.Endpoint
-	spec.Status.CertificateAuthorityData = data
-	spec.Status.ARN = *cluster.Arn
-
-	c.Status.cachedClusterInfo = cluster
+	if cluster.CertificateAuthority != nil && cluster.CertificateAuthority.Data != nil {
+		data, err := base64.StdEncoding.DecodeString(*cluster.CertificateAuthority.Data)
+		if err != nil {
+			return errors.Wrap(err, "decoding certificate authority data")
+		}
+		spec.Status.CertificateAuthorityData = data
+	}
 
+	spec.Status.Endpoint = aws.StringValue(cluster.Endpoint)
+	spec.Status.ARN = aws.StringValue(cluster.Arn)
+	spec.Status.PlatformVersion = aws.StringValue(cluster.PlatformVersion)
+	if cluster.CreatedAt != nil {
+		createdAt := metav1.NewTime(*cluster.CreatedAt)
+		spec.Status.CreatedAt = &createdAt
+	}
+	spec.Status.SecurityGroupIDs = nil
+	if cluster.ResourcesVpcConfig != nil {
+		spec.Status.SecurityGroupIDs = aws.StringValueSlice(cluster.ResourcesVpcConfig.SecurityGroupIds)
+	}
 	return nil
 }
 
+// SetClusterStatusFromStack sets the attributes of the status that the EKS API doesn't return
+// from the outputs of the cluster stack, they are left empty when the stack predates these
+// outputs, `eksctl update cluster` adds them
+func SetClusterStatusFromStack(spec *api.ClusterConfig, stack *manager.Stack) error {
+	if spec.Status == nil {
+		spec.Status = &api.ClusterStatus{}
+	}
+
+	return outputs.Collect(*stack, nil, map[string]outputs.Collector{
+		outputs.ClusterOIDCIssuerURL: func(v string) error {
+			spec.Status.OIDCIssuerURL = v
+			return nil
+		},
+	})
+}
+
 // ControlPlaneVersion returns cached version (EKS API)
 func (c *ClusterProvider) ControlPlaneVersion() string {
 	if c.Status.cachedClusterInfo == nil || c.Status.cachedClusterInfo.Version == nil {
@@ -94,6 +153,12 @@ func (c *ClusterProvider) GetClusterVPC(spec *api.ClusterConfig) error {
 
 // ListClusters display details of all the EKS cluster in your account
 func (c *ClusterProvider) ListClusters(clusterName string, chunkSize int, output string, eachRegion bool) error {
+	return c.ListClustersMatching(clusterName, chunkSize, output, eachRegion, nil)
+}
+
+// ListClustersMatching display details of the EKS clusters in your account whose tags
+// match the selector, clusters that weren't created by eksctl have no tags
+func (c *ClusterProvider) ListClustersMatching(clusterName string, chunkSize int, output string, eachRegion bool, sel selector.Selector) error {
 	// NOTE: this needs to be reworked in the future so that the functionality
 	// is combined. This require the ability to return details of all clusters
 	// in a single call.
@@ -103,17 +168,17 @@ func (c *ClusterProvider) ListClusters(clusterName string, chunkSize int, output
 	}
 
 	if clusterName != "" {
-		if output == "table" {
-			addSummaryTableColumns(printer.(*printers.TablePrinter))
+		if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+			addSummaryTableColumns(columnPrinter)
 		}
-		return c.doGetCluster(clusterName, printer)
+		return c.doGetCluster(clusterName, printer, sel)
 	}
 
-	if output == "table" {
-		addListTableColumns(printer.(*printers.TablePrinter))
+	if columnPrinter, ok := printer.(printers.ColumnPrinter); ok {
+		addListTableColumns(columnPrinter)
 	}
 	allClusters := []*api.ClusterMeta{}
-	if err := c.doListClusters(int64(chunkSize), printer, &allClusters, eachRegion); err != nil {
+	if err := c.doListClusters(int64(chunkSize), printer, &allClusters, eachRegion, sel); err != nil {
 		return err
 	}
 	return printer.PrintObjWithKind("clusters", allClusters, os.Stdout)
@@ -131,16 +196,25 @@ func (c *ClusterProvider) getClustersRequest(chunkSize int64, nextToken string)
 	return output.Clusters, output.NextToken, nil
 }
 
-func (c *ClusterProvider) doListClusters(chunkSize int64, printer printers.OutputPrinter, allClusters *[]*api.ClusterMeta, eachRegion bool) error {
+func (c *ClusterProvider) doListClusters(chunkSize int64, printer printers.OutputPrinter, allClusters *[]*api.ClusterMeta, eachRegion bool, sel selector.Selector) error {
 	if eachRegion {
 		// reset region and re-create the client, then make a recursive call
 		for _, region := range api.SupportedRegions() {
+			// credentials are only valid in one partition
+			if api.Partition(region) != api.Partition(c.Provider.Region()) {
+				continue
+			}
 			spec := &api.ProviderConfig{
-				Region:      region,
-				Profile:     c.Provider.Profile(),
-				WaitTimeout: c.Provider.WaitTimeout(),
+				Region:       region,
+				Profile:      c.Provider.Profile(),
+				WaitTimeout:  c.Provider.WaitTimeout(),
+				PollInterval: c.Provider.PollInterval(),
+			}
+			ctl, err := New(spec, nil)
+			if err == nil {
+				err = ctl.doListClusters(chunkSize, printer, allClusters, false, sel)
 			}
-			if err := New(spec, nil).doListClusters(chunkSize, printer, allClusters, false); err != nil {
+			if err != nil {
 				logger.Critical("error listing clusters in %q region: %s", region, err.Error())
 			}
 		}
@@ -155,6 +229,9 @@ func (c *ClusterProvider) doListClusters(chunkSize int64, printer printers.Outpu
 		}
 
 		for _, clusterName := range clusters {
+			if !sel.Empty() && !sel.Matches(c.clusterTags(*clusterName)) {
+				continue
+			}
 			*allClusters = append(*allClusters, &api.ClusterMeta{
 				Name:   *clusterName,
 				Region: c.Provider.Region(),
@@ -171,7 +248,79 @@ func (c *ClusterProvider) doListClusters(chunkSize int64, printer printers.Outpu
 	return nil
 }
 
-func (c *ClusterProvider) doGetCluster(clusterName string, printer printers.OutputPrinter) error {
+// ClusterSummary is the output of `get cluster`, it extends the description
+// of the control plane with tags of the cluster stack
+type ClusterSummary struct {
+	*awseks.Cluster
+
+	Tags map[string]string `json:",omitempty"`
+
+	// OIDCIssuerURL is an output of the cluster stack, as the EKS API doesn't return it
+	OIDCIssuerURL string `json:",omitempty"`
+
+	// AvailableVersion and AvailablePlatformVersion are set when newer versions exist
+	AvailableVersion         string `json:",omitempty"`
+	AvailablePlatformVersion string `json:",omitempty"`
+}
+
+// AvailableUpgrades returns the latest Kubernetes version and platform version when they
+// are newer than those of the cluster, or empty strings when the cluster is up-to-date
+func AvailableUpgrades(cluster *awseks.Cluster) (version, platformVersion string) {
+	current := aws.StringValue(cluster.Version)
+	if current == "" {
+		return "", ""
+	}
+
+	olderVersions := append(api.DeprecatedVersions(), api.SupportedVersions()...)
+	for _, v := range olderVersions {
+		if v == api.LatestVersion {
+			break
+		}
+		if v == current {
+			version = api.LatestVersion
+		}
+	}
+
+	latestPlatformVersion, ok := api.LatestPlatformVersions()[current]
+	if ok && platformVersionNumber(aws.StringValue(cluster.PlatformVersion)) < platformVersionNumber(latestPlatformVersion) {
+		platformVersion = latestPlatformVersion
+	}
+	return version, platformVersion
+}
+
+// platformVersionNumber returns N of a platform version "eks.N", or -1 when it can't be parsed
+func platformVersionNumber(platformVersion string) int {
+	n, err := strconv.Atoi(strings.TrimPrefix(platformVersion, "eks."))
+	if err != nil || !strings.HasPrefix(platformVersion, "eks.") {
+		return -1
+	}
+	return n
+}
+
+// setClusterStackAttributes sets the tags and the OIDC issuer URL of the summary from the
+// cluster stack, clusters that weren't created by eksctl have no stack, and hence neither
+func (c *ClusterProvider) setClusterStackAttributes(summary *ClusterSummary, clusterName string) {
+	spec := &api.ClusterConfig{Metadata: &api.ClusterMeta{Name: clusterName}}
+	stackManager := c.NewStackManager(spec)
+	tags, err := stackManager.GetClusterStackTags()
+	if err != nil {
+		logger.Debug("unable to get tags of cluster %q: %s", clusterName, err.Error())
+		return
+	}
+	summary.Tags = tags
+
+	stack, err := stackManager.DescribeClusterStackByName()
+	if err == nil {
+		err = SetClusterStatusFromStack(spec, stack)
+	}
+	if err != nil {
+		logger.Debug("unable to get OIDC issuer URL of cluster %q: %s", clusterName, err.Error())
+		return
+	}
+	summary.OIDCIssuerURL = spec.Status.OIDCIssuerURL
+}
+
+func (c *ClusterProvider) doGetCluster(clusterName string, printer printers.OutputPrinter, sel selector.Selector) error {
 	input := &awseks.DescribeClusterInput{
 		Name: &clusterName,
 	}
@@ -181,25 +330,29 @@ func (c *ClusterProvider) doGetCluster(clusterName string, printer printers.Outp
 	}
 	logger.Debug("cluster = %#v", output)
 
-	clusters := []*awseks.Cluster{output.Cluster} // TODO: in the future this will have multiple clusters
-	if err := printer.PrintObjWithKind("clusters", clusters, os.Stdout); err != nil {
-		return err
-	}
+	summary := &ClusterSummary{Cluster: output.Cluster}
+	summary.AvailableVersion, summary.AvailablePlatformVersion = AvailableUpgrades(output.Cluster)
 
-	if *output.Cluster.Status == awseks.ClusterStatusActive {
+	if *output.Cluster.Status == awseks.ClusterStatusActive || !sel.Empty() {
+		c.setClusterStackAttributes(summary, clusterName)
+	}
 
-		if logger.Level >= 4 {
-			spec := &api.ClusterConfig{Metadata: &api.ClusterMeta{Name: clusterName}}
-			stacks, err := c.NewStackManager(spec).ListStacks(fmt.Sprintf("^(eksclt|EKS)-%s-.*$", clusterName))
-			if err != nil {
-				return errors.Wrapf(err, "listing CloudFormation stack for %q", clusterName)
-			}
-			for _, s := range stacks {
-				logger.Debug("stack = %#v", *s)
-			}
+	if *output.Cluster.Status == awseks.ClusterStatusActive && logging.IsDebug() {
+		spec := &api.ClusterConfig{Metadata: &api.ClusterMeta{Name: clusterName}}
+		stacks, err := c.NewStackManager(spec).ListStacks(fmt.Sprintf("^(%s|EKS-)%s-.*$", regexp.QuoteMeta(spec.StackNamePrefix()), regexp.QuoteMeta(clusterName)))
+		if err != nil {
+			return errors.Wrapf(err, "listing CloudFormation stack for %q", clusterName)
+		}
+		for _, s := range stacks {
+			logger.Debug("stack = %#v", *s)
 		}
 	}
-	return nil
+
+	clusters := []*ClusterSummary{}
+	if sel.Matches(summary.Tags) {
+		clusters = append(clusters, summary) // TODO: in the future this will have multiple clusters
+	}
+	return printer.PrintObjWithKind("clusters", clusters, os.Stdout)
 }
 
 // WaitForControlPlane waits till the control plane is ready
@@ -223,7 +376,7 @@ func (c *ClusterProvider) WaitForControlPlane(id *api.ClusterMeta, clientSet *ku
 			}
 			logger.Debug("control plane not ready yet – %s", err.Error())
 		case <-timer.C:
-			return fmt.Errorf("timed out waiting for control plane %q after %s", id.Name, c.Provider.WaitTimeout())
+			return eksctlerrors.NewTimeout("timed out waiting for control plane %q after %s", id.Name, c.Provider.WaitTimeout())
 		}
 	}
 }
@@ -236,6 +389,7 @@ func (c *ClusterProvider) UpdateClusterVersion(cfg *api.ClusterConfig) (string,
 		Version: &cfg.Metadata.Version,
 	}
 	output, err := c.Provider.EKS().UpdateClusterVersion(input)
+	c.InvalidateCachedControlPlane(cfg.Metadata)
 	if err != nil {
 		return "", err
 	}
@@ -250,46 +404,172 @@ func (c *ClusterProvider) UpdateClusterVersionBlocking(cfg *api.ClusterConfig) e
 		return err
 	}
 
-	newRequest := func() *request.Request {
-		input := &awseks.DescribeUpdateInput{
-			Name:     &cfg.Metadata.Name,
-			UpdateId: &id,
+	return c.WaitForUpdate(cfg.Metadata, id)
+}
+
+// ClusterLogTypeChanges returns the log types to enable and to disable, so that
+// only the desired types are enabled; both are empty when desired is nil
+func ClusterLogTypeChanges(current, desired []string) (enable, disable []string) {
+	if desired == nil {
+		return nil, nil
+	}
+	currentSet, desiredSet := sets.NewString(current...), sets.NewString(desired...)
+	return desiredSet.Difference(currentSet).List(), currentSet.Difference(desiredSet).List()
+}
+
+// UpdateClusterLogTypes calls eks.UpdateClusterConfig to enable and disable types of
+// control plane logs, it will return update ID along with an error (if it occurrs)
+func (c *ClusterProvider) UpdateClusterLogTypes(cl *api.ClusterMeta, enable, disable []string) (string, error) {
+	logging := &awseks.Logging{}
+	if len(enable) > 0 {
+		logging.ClusterLogging = append(logging.ClusterLogging, &awseks.LogSetup{
+			Enabled: aws.Bool(true),
+			Types:   aws.StringSlice(enable),
+		})
+	}
+	if len(disable) > 0 {
+		logging.ClusterLogging = append(logging.ClusterLogging, &awseks.LogSetup{
+			Enabled: aws.Bool(false),
+			Types:   aws.StringSlice(disable),
+		})
+	}
+	input := &awseks.UpdateClusterConfigInput{
+		Name:    &cl.Name,
+		Logging: logging,
+	}
+	output, err := c.Provider.EKS().UpdateClusterConfig(input)
+	c.InvalidateCachedControlPlane(cl)
+	if err != nil {
+		return "", errors.Wrapf(err, "updating control plane logging of cluster %q", cl.Name)
+	}
+	return *output.Update.Id, nil
+}
+
+// UpdateClusterLogTypesBlocking calls UpdateClusterLogTypes and blocks until update
+// operation is successful, it doesn't call the API when there are no changes
+func (c *ClusterProvider) UpdateClusterLogTypesBlocking(cl *api.ClusterMeta, enable, disable []string) error {
+	if len(enable) == 0 && len(disable) == 0 {
+		return nil
+	}
+	id, err := c.UpdateClusterLogTypes(cl, enable, disable)
+	if err != nil {
+		return err
+	}
+	return c.WaitForUpdate(cl, id)
+}
+
+// ClusterLogGroupName returns the name of the CloudWatch log group EKS sends control plane logs to
+func ClusterLogGroupName(cl *api.ClusterMeta) string {
+	return fmt.Sprintf("/aws/eks/%s/cluster", cl.Name)
+}
+
+// DeleteClusterLogGroup deletes the log group of the control plane logs, which EKS
+// creates when logging is enabled and keeps after the cluster is deleted
+func (c *ClusterProvider) DeleteClusterLogGroup(cl *api.ClusterMeta) error {
+	name := ClusterLogGroupName(cl)
+	_, err := c.Provider.CloudWatchLogs().DeleteLogGroup(&cloudwatchlogs.DeleteLogGroupInput{
+		LogGroupName: &name,
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+			logger.Debug("log group %q doesn't exist", name)
+			return nil
 		}
-		req, _ := c.Provider.EKS().DescribeUpdateRequest(input)
-		return req
+		return errors.Wrapf(err, "deleting log group %q", name)
 	}
+	logger.Info("deleted log group %q", name)
+	return nil
+}
 
-	msg := fmt.Sprintf("waiting for control plane %q version update", cfg.Metadata.Name)
+// ClusterEndpointAccessChanges returns the access to the API server endpoint that is set in
+// endpoints, when it differs from the current access of the cluster; settings that aren't
+// set are left unchanged
+func ClusterEndpointAccessChanges(cluster *awseks.Cluster, endpoints *api.ClusterEndpoints) (public, private *bool, changed bool) {
+	if endpoints == nil || cluster.ResourcesVpcConfig == nil {
+		return nil, nil, false
+	}
+	current := cluster.ResourcesVpcConfig
+	if endpoints.PublicAccess != nil && *endpoints.PublicAccess != aws.BoolValue(current.EndpointPublicAccess) {
+		public, changed = endpoints.PublicAccess, true
+	}
+	if endpoints.PrivateAccess != nil && *endpoints.PrivateAccess != aws.BoolValue(current.EndpointPrivateAccess) {
+		private, changed = endpoints.PrivateAccess, true
+	}
+	return public, private, changed
+}
+
+// UpdateClusterEndpointAccessBlocking calls eks.UpdateClusterConfig to change the access to the
+// API server endpoint and blocks until update operation is successful, nil settings are unchanged
+func (c *ClusterProvider) UpdateClusterEndpointAccessBlocking(cl *api.ClusterMeta, public, private *bool) error {
+	input := &awseks.UpdateClusterConfigInput{
+		Name: &cl.Name,
+		ResourcesVpcConfig: &awseks.VpcConfigRequest{
+			EndpointPublicAccess:  public,
+			EndpointPrivateAccess: private,
+		},
+	}
+	output, err := c.Provider.EKS().UpdateClusterConfig(input)
+	c.InvalidateCachedControlPlane(cl)
+	if err != nil {
+		return errors.Wrapf(err, "updating endpoint access of cluster %q", cl.Name)
+	}
+	return c.WaitForUpdate(cl, *output.Update.Id)
+}
 
-	acceptors := waiters.MakeAcceptors(
-		"Update.Status",
-		awseks.UpdateStatusSuccessful,
-		[]string{
-			awseks.UpdateStatusCancelled,
-			awseks.UpdateStatusFailed,
+// ControlPlaneSecurityGroupChanges returns the security groups to attach to and to detach from the
+// control plane, so that exactly the desired ones are attached
+func ControlPlaneSecurityGroupChanges(cluster *awseks.Cluster, desired []string) (attach, detach []string) {
+	current := sets.NewString()
+	if cluster.ResourcesVpcConfig != nil {
+		current.Insert(aws.StringValueSlice(cluster.ResourcesVpcConfig.SecurityGroupIds)...)
+	}
+	desiredSet := sets.NewString(desired...)
+	return desiredSet.Difference(current).List(), current.Difference(desiredSet).List()
+}
+
+// UpdateClusterSecurityGroupsBlocking calls eks.UpdateClusterConfig to replace the security groups
+// attached to the control plane and blocks until update operation is successful; EKS rejects the
+// update with an InvalidParameterException when the cluster doesn't support it, which can be
+// checked with IsUnsupportedSecurityGroupsUpdate
+func (c *ClusterProvider) UpdateClusterSecurityGroupsBlocking(cl *api.ClusterMeta, securityGroupIDs []string) error {
+	input := &awseks.UpdateClusterConfigInput{
+		Name: &cl.Name,
+		ResourcesVpcConfig: &awseks.VpcConfigRequest{
+			SecurityGroupIds: aws.StringSlice(securityGroupIDs),
 		},
-	)
+	}
+	output, err := c.Provider.EKS().UpdateClusterConfig(input)
+	c.InvalidateCachedControlPlane(cl)
+	if err != nil {
+		return errors.Wrapf(err, "updating security groups of cluster %q", cl.Name)
+	}
+	return c.WaitForUpdate(cl, *output.Update.Id)
+}
 
-	return waiters.Wait(cfg.Metadata.Name, msg, acceptors, newRequest, c.Provider.WaitTimeout(), nil)
+// IsUnsupportedSecurityGroupsUpdate returns true when err is the rejection of an update of the
+// security groups of the control plane by EKS
+func IsUnsupportedSecurityGroupsUpdate(err error) bool {
+	awsErr, ok := errors.Cause(err).(awserr.Error)
+	return ok && awsErr.Code() == awseks.ErrCodeInvalidParameterException
 }
 
-func addSummaryTableColumns(printer *printers.TablePrinter) {
-	printer.AddColumn("NAME", func(c *awseks.Cluster) string {
+func addSummaryTableColumns(printer printers.ColumnPrinter) {
+	printer.AddColumn("NAME", func(c *ClusterSummary) string {
 		return *c.Name
 	})
-	printer.AddColumn("VERSION", func(c *awseks.Cluster) string {
+	printer.AddColumn("VERSION", func(c *ClusterSummary) string {
 		return *c.Version
 	})
-	printer.AddColumn("STATUS", func(c *awseks.Cluster) string {
+	printer.AddColumn("STATUS", func(c *ClusterSummary) string {
 		return *c.Status
 	})
-	printer.AddColumn("CREATED", func(c *awseks.Cluster) string {
+	printer.AddColumn("CREATED", func(c *ClusterSummary) string {
 		return c.CreatedAt.Format(time.RFC3339)
 	})
-	printer.AddColumn("VPC", func(c *awseks.Cluster) string {
+	printer.AddColumn("VPC", func(c *ClusterSummary) string {
 		return *c.ResourcesVpcConfig.VpcId
 	})
-	printer.AddColumn("SUBNETS", func(c *awseks.Cluster) string {
+	printer.AddColumn("SUBNETS", func(c *ClusterSummary) string {
 		subnets := sets.NewString()
 		for _, subnetid := range c.ResourcesVpcConfig.SubnetIds {
 			if api.IsSetAndNonEmptyString(subnetid) {
@@ -298,7 +578,7 @@ func addSummaryTableColumns(printer *printers.TablePrinter) {
 		}
 		return strings.Join(subnets.List(), ",")
 	})
-	printer.AddColumn("SECURITYGROUPS", func(c *awseks.Cluster) string {
+	printer.AddColumn("SECURITYGROUPS", func(c *ClusterSummary) string {
 		groups := sets.NewString()
 		for _, sg := range c.ResourcesVpcConfig.SecurityGroupIds {
 			if api.IsSetAndNonEmptyString(sg) {
@@ -307,9 +587,59 @@ func addSummaryTableColumns(printer *printers.TablePrinter) {
 		}
 		return strings.Join(groups.List(), ",")
 	})
+	printer.AddColumn("PUBLIC ACCESS", func(c *ClusterSummary) string {
+		return strconv.FormatBool(aws.BoolValue(c.ResourcesVpcConfig.EndpointPublicAccess))
+	})
+	printer.AddColumn("PRIVATE ACCESS", func(c *ClusterSummary) string {
+		return strconv.FormatBool(aws.BoolValue(c.ResourcesVpcConfig.EndpointPrivateAccess))
+	})
+	printer.AddColumn("LOGGING", func(c *ClusterSummary) string {
+		return strings.Join(EnabledLogTypes(c.Cluster), ",")
+	})
+	printer.AddColumn("PLATFORM VERSION", func(c *ClusterSummary) string {
+		return aws.StringValue(c.PlatformVersion)
+	})
+	printer.AddColumn("OIDC ISSUER", func(c *ClusterSummary) string {
+		return c.OIDCIssuerURL
+	})
+	printer.AddColumn("UPGRADES", func(c *ClusterSummary) string {
+		upgrades := []string{}
+		if c.AvailableVersion != "" {
+			upgrades = append(upgrades, "version="+c.AvailableVersion)
+		}
+		if c.AvailablePlatformVersion != "" {
+			upgrades = append(upgrades, "platform="+c.AvailablePlatformVersion)
+		}
+		return strings.Join(upgrades, ",")
+	})
+	printer.AddColumn("TAGS", func(c *ClusterSummary) string {
+		tags := []string{}
+		for k, v := range c.Tags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		return strings.Join(tags, ",")
+	})
+}
+
+// EnabledLogTypes returns the sorted CloudWatch log types enabled for the control plane
+func EnabledLogTypes(cluster *awseks.Cluster) []string {
+	types := sets.NewString()
+	if cluster.Logging == nil {
+		return types.List()
+	}
+	for _, setup := range cluster.Logging.ClusterLogging {
+		if !aws.BoolValue(setup.Enabled) {
+			continue
+		}
+		for _, t := range setup.Types {
+			types.Insert(aws.StringValue(t))
+		}
+	}
+	return types.List()
 }
 
-func addListTableColumns(printer *printers.TablePrinter) {
+func addListTableColumns(printer printers.ColumnPrinter) {
 	printer.AddColumn("NAME", func(c *api.ClusterMeta) string {
 		return c.Name
 	})
@@ -4,17 +4,23 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
 	awseks "github.com/aws/aws-sdk-go/service/eks"
 	"github.com/kris-nova/logger"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	. "github.com/weaveworks/eksctl/pkg/eks"
 	"github.com/weaveworks/eksctl/pkg/testutils"
 	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	"github.com/weaveworks/eksctl/pkg/utils/selector"
 )
 
 var _ = Describe("EKS API wrapper", func() {
@@ -49,6 +55,10 @@ var _ = Describe("EKS API wrapper", func() {
 				})).Return(&awseks.DescribeClusterOutput{
 					Cluster: testutils.NewFakeCluster(clusterName, awseks.ClusterStatusActive),
 				}, nil)
+
+				p.MockCloudFormation().On("DescribeStacks", mock.MatchedBy(func(input *cfn.DescribeStacksInput) bool {
+					return *input.StackName == "eksctl-"+clusterName+"-cluster"
+				})).Return(nil, fmt.Errorf("stack not found"))
 			})
 
 			Context("and normal log level", func() {
@@ -126,6 +136,112 @@ var _ = Describe("EKS API wrapper", func() {
 			})
 		})
 
+		Context("with a cluster name and tags on the cluster stack", func() {
+			var (
+				clusterName    string
+				err            error
+				originalStdout *os.File
+				reader         *os.File
+				writer         *os.File
+			)
+
+			BeforeEach(func() {
+				originalStdout = os.Stdout
+				reader, writer, _ = os.Pipe()
+				os.Stdout = writer
+
+				clusterName = "test-cluster"
+				logger.Level = 1
+
+				p = mockprovider.NewMockProvider()
+
+				c = &ClusterProvider{
+					Provider: p,
+				}
+
+				cluster := testutils.NewFakeCluster(clusterName, awseks.ClusterStatusActive)
+				cluster.Version = aws.String("1.12")
+				cluster.PlatformVersion = aws.String("eks.2")
+				cluster.Logging = &awseks.Logging{
+					ClusterLogging: []*awseks.LogSetup{
+						{Enabled: aws.Bool(true), Types: aws.StringSlice([]string{"audit", "api"})},
+						{Enabled: aws.Bool(false), Types: aws.StringSlice([]string{"scheduler"})},
+					},
+				}
+
+				p.MockEKS().On("DescribeCluster", mock.Anything).Return(&awseks.DescribeClusterOutput{
+					Cluster: cluster,
+				}, nil)
+
+				p.MockCloudFormation().On("DescribeStacks", mock.Anything).Return(&cfn.DescribeStacksOutput{
+					Stacks: []*cfn.Stack{
+						{
+							StackName: aws.String("eksctl-" + clusterName + "-cluster"),
+							Tags: []*cfn.Tag{
+								{Key: aws.String("alpha.eksctl.io/cluster-name"), Value: aws.String(clusterName)},
+								{Key: aws.String("team"), Value: aws.String("platform")},
+							},
+							Outputs: []*cfn.Output{
+								{OutputKey: aws.String("OIDCIssuerURL"), OutputValue: aws.String("https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF")},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			AfterEach(func() {
+				os.Stdout = originalStdout
+			})
+
+			It("should include tags of the cluster stack in JSON output", func() {
+				err = c.ListClusters(clusterName, 100, output, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				writer.Close()
+				actualOutput, _ := ioutil.ReadAll(reader)
+
+				Expect(string(actualOutput)).To(ContainSubstring(`"Tags": {`))
+				Expect(string(actualOutput)).To(ContainSubstring(`"team": "platform"`))
+				Expect(string(actualOutput)).NotTo(ContainSubstring(`"alpha.eksctl.io/cluster-name"`))
+				Expect(string(actualOutput)).To(ContainSubstring(`"PlatformVersion": "eks.2"`))
+				Expect(string(actualOutput)).To(ContainSubstring(`"OIDCIssuerURL": "https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"`))
+			})
+
+			It("should print endpoint access, logging, platform version, OIDC issuer and tags columns", func() {
+				err = c.ListClusters(clusterName, 100, "table", false)
+				Expect(err).NotTo(HaveOccurred())
+
+				writer.Close()
+				actualOutput, _ := ioutil.ReadAll(reader)
+
+				Expect(string(actualOutput)).To(ContainSubstring("PUBLIC ACCESS"))
+				Expect(string(actualOutput)).To(ContainSubstring("api,audit"))
+				Expect(string(actualOutput)).NotTo(ContainSubstring("scheduler"))
+				Expect(string(actualOutput)).To(ContainSubstring("eks.2"))
+				Expect(string(actualOutput)).To(ContainSubstring("OIDC ISSUER"))
+				Expect(string(actualOutput)).To(ContainSubstring("https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"))
+				Expect(string(actualOutput)).To(ContainSubstring("team=platform"))
+				Expect(string(actualOutput)).To(ContainSubstring("version=1.13"))
+			})
+
+			It("should only print the cluster when its tags match the selector", func() {
+				sel, err := selector.Parse("team=platform")
+				Expect(err).NotTo(HaveOccurred())
+				err = c.ListClustersMatching(clusterName, 100, output, false, sel)
+				Expect(err).NotTo(HaveOccurred())
+
+				sel, err = selector.Parse("team=payments")
+				Expect(err).NotTo(HaveOccurred())
+				err = c.ListClustersMatching(clusterName, 100, output, false, sel)
+				Expect(err).NotTo(HaveOccurred())
+
+				writer.Close()
+				actualOutput, _ := ioutil.ReadAll(reader)
+
+				Expect(strings.Count(string(actualOutput), `"team": "platform"`)).To(Equal(1))
+			})
+		})
+
 		Context("with a cluster name but cluster isn't ready", func() {
 			var (
 				clusterName    string
@@ -276,4 +392,179 @@ var _ = Describe("EKS API wrapper", func() {
 		})
 
 	})
+
+	Describe("cluster config updates", func() {
+		It("should enable and disable log types to match the desired ones", func() {
+			enable, disable := ClusterLogTypeChanges([]string{"api", "scheduler"}, []string{"audit", "api"})
+			Expect(enable).To(Equal([]string{"audit"}))
+			Expect(disable).To(Equal([]string{"scheduler"}))
+		})
+
+		It("should not change anything when logging isn't set", func() {
+			enable, disable := ClusterLogTypeChanges([]string{"api"}, nil)
+			Expect(enable).To(BeEmpty())
+			Expect(disable).To(BeEmpty())
+		})
+
+		It("should only change endpoint access that is set and differs", func() {
+			cluster := &awseks.Cluster{ResourcesVpcConfig: &awseks.VpcConfigResponse{
+				EndpointPublicAccess:  aws.Bool(true),
+				EndpointPrivateAccess: aws.Bool(false),
+			}}
+
+			_, _, changed := ClusterEndpointAccessChanges(cluster, nil)
+			Expect(changed).To(BeFalse())
+
+			_, _, changed = ClusterEndpointAccessChanges(cluster, &api.ClusterEndpoints{PublicAccess: api.Enabled()})
+			Expect(changed).To(BeFalse())
+
+			public, private, changed := ClusterEndpointAccessChanges(cluster, &api.ClusterEndpoints{PublicAccess: api.Enabled(), PrivateAccess: api.Enabled()})
+			Expect(changed).To(BeTrue())
+			Expect(public).To(BeNil())
+			Expect(*private).To(BeTrue())
+		})
+
+		It("should attach and detach control plane security groups to match the desired ones", func() {
+			cluster := &awseks.Cluster{ResourcesVpcConfig: &awseks.VpcConfigResponse{
+				SecurityGroupIds: aws.StringSlice([]string{"sg-control-plane", "sg-old"}),
+			}}
+
+			attach, detach := ControlPlaneSecurityGroupChanges(cluster, []string{"sg-control-plane", "sg-new"})
+			Expect(attach).To(Equal([]string{"sg-new"}))
+			Expect(detach).To(Equal([]string{"sg-old"}))
+
+			attach, detach = ControlPlaneSecurityGroupChanges(cluster, []string{"sg-old", "sg-control-plane"})
+			Expect(attach).To(BeEmpty())
+			Expect(detach).To(BeEmpty())
+		})
+
+		It("should recognise updates of security groups that EKS doesn't support", func() {
+			p = mockprovider.NewMockProvider()
+			c = &ClusterProvider{Provider: p}
+
+			p.MockEKS().On("UpdateClusterConfig", mock.Anything).Return(nil, awserr.New(awseks.ErrCodeInvalidParameterException, "security groups cannot be updated", nil))
+
+			err := c.UpdateClusterSecurityGroupsBlocking(&api.ClusterMeta{Name: "test-cluster"}, []string{"sg-control-plane", "sg-new"})
+			Expect(err).To(HaveOccurred())
+			Expect(IsUnsupportedSecurityGroupsUpdate(err)).To(BeTrue())
+			Expect(IsUnsupportedSecurityGroupsUpdate(fmt.Errorf("timed out"))).To(BeFalse())
+		})
+
+		It("should update logging with a single call", func() {
+			p = mockprovider.NewMockProvider()
+			c = &ClusterProvider{Provider: p}
+
+			p.MockEKS().On("UpdateClusterConfig", mock.MatchedBy(func(input *awseks.UpdateClusterConfigInput) bool {
+				return *input.Name == "test-cluster" && len(input.Logging.ClusterLogging) == 2 &&
+					*input.Logging.ClusterLogging[0].Enabled && *input.Logging.ClusterLogging[0].Types[0] == "audit" &&
+					!*input.Logging.ClusterLogging[1].Enabled && *input.Logging.ClusterLogging[1].Types[0] == "scheduler"
+			})).Return(&awseks.UpdateClusterConfigOutput{
+				Update: &awseks.Update{Id: aws.String("u-1")},
+			}, nil)
+
+			id, err := c.UpdateClusterLogTypes(&api.ClusterMeta{Name: "test-cluster"}, []string{"audit"}, []string{"scheduler"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("u-1"))
+		})
+
+		It("should delete the log group of the control plane logs, and ignore it when it doesn't exist", func() {
+			p = mockprovider.NewMockProvider()
+			c = &ClusterProvider{Provider: p}
+
+			p.MockCloudWatchLogs().On("DeleteLogGroup", mock.MatchedBy(func(input *cloudwatchlogs.DeleteLogGroupInput) bool {
+				return *input.LogGroupName == "/aws/eks/test-cluster/cluster"
+			})).Return(&cloudwatchlogs.DeleteLogGroupOutput{}, nil)
+			p.MockCloudWatchLogs().On("DeleteLogGroup", mock.MatchedBy(func(input *cloudwatchlogs.DeleteLogGroupInput) bool {
+				return *input.LogGroupName == "/aws/eks/no-logs/cluster"
+			})).Return(nil, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "not found", nil))
+
+			Expect(c.DeleteClusterLogGroup(&api.ClusterMeta{Name: "test-cluster"})).To(Succeed())
+			Expect(c.DeleteClusterLogGroup(&api.ClusterMeta{Name: "no-logs"})).To(Succeed())
+			Expect(p.MockCloudWatchLogs().AssertNumberOfCalls(GinkgoT(), "DeleteLogGroup", 2)).To(BeTrue())
+		})
+	})
+
+	Describe("AvailableUpgrades", func() {
+		It("should report newer Kubernetes and platform versions", func() {
+			version, platformVersion := AvailableUpgrades(&awseks.Cluster{
+				Version:         aws.String(api.Version1_11),
+				PlatformVersion: aws.String("eks.1"),
+			})
+			Expect(version).To(Equal(api.LatestVersion))
+			Expect(platformVersion).To(Equal(api.LatestPlatformVersions()[api.Version1_11]))
+		})
+
+		It("should not report anything for an up-to-date cluster", func() {
+			version, platformVersion := AvailableUpgrades(&awseks.Cluster{
+				Version:         aws.String(api.LatestVersion),
+				PlatformVersion: aws.String(api.LatestPlatformVersions()[api.LatestVersion]),
+			})
+			Expect(version).To(BeEmpty())
+			Expect(platformVersion).To(BeEmpty())
+		})
+
+		It("should not report anything for versions that eksctl doesn't know of", func() {
+			version, platformVersion := AvailableUpgrades(&awseks.Cluster{
+				Version:         aws.String("1.99"),
+				PlatformVersion: aws.String("eks.1"),
+			})
+			Expect(version).To(BeEmpty())
+			Expect(platformVersion).To(BeEmpty())
+		})
+	})
+
+	Describe("SetClusterStatus", func() {
+		It("should set the status from the description of the control plane", func() {
+			createdAt := time.Date(2019, time.June, 1, 12, 0, 0, 0, time.UTC)
+			cfg := api.NewClusterConfig()
+			Expect(SetClusterStatus(cfg, &awseks.Cluster{
+				Arn:                  aws.String("arn:aws:eks:us-west-2:123456789012:cluster/test"),
+				Endpoint:             aws.String("https://test.eks.amazonaws.com"),
+				CertificateAuthority: &awseks.Certificate{Data: aws.String("Y2E=")},
+				PlatformVersion:      aws.String("eks.2"),
+				CreatedAt:            &createdAt,
+				ResourcesVpcConfig: &awseks.VpcConfigResponse{
+					SecurityGroupIds: aws.StringSlice([]string{"sg-1", "sg-2"}),
+				},
+			})).To(Succeed())
+
+			Expect(cfg.Status.ARN).To(Equal("arn:aws:eks:us-west-2:123456789012:cluster/test"))
+			Expect(cfg.Status.Endpoint).To(Equal("https://test.eks.amazonaws.com"))
+			Expect(cfg.Status.CertificateAuthorityData).To(Equal([]byte("ca")))
+			Expect(cfg.Status.PlatformVersion).To(Equal("eks.2"))
+			Expect(cfg.Status.CreatedAt.Time).To(Equal(createdAt))
+			Expect(cfg.Status.SecurityGroupIDs).To(Equal([]string{"sg-1", "sg-2"}))
+		})
+
+		It("should leave out attributes of clusters that are being created", func() {
+			cfg := api.NewClusterConfig()
+			Expect(SetClusterStatus(cfg, &awseks.Cluster{
+				Arn:                  aws.String("arn:aws:eks:us-west-2:123456789012:cluster/test"),
+				CertificateAuthority: &awseks.Certificate{},
+			})).To(Succeed())
+
+			Expect(cfg.Status.ARN).To(Equal("arn:aws:eks:us-west-2:123456789012:cluster/test"))
+			Expect(cfg.Status.Endpoint).To(BeEmpty())
+			Expect(cfg.Status.CertificateAuthorityData).To(BeEmpty())
+			Expect(cfg.Status.CreatedAt).To(BeNil())
+		})
+	})
+
+	Describe("SetClusterStatusFromStack", func() {
+		It("should set the OIDC issuer URL from the outputs of the cluster stack", func() {
+			cfg := api.NewClusterConfig()
+			Expect(SetClusterStatusFromStack(cfg, &cfn.Stack{
+				Outputs: []*cfn.Output{
+					{OutputKey: aws.String("OIDCIssuerURL"), OutputValue: aws.String("https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF")},
+				},
+			})).To(Succeed())
+			Expect(cfg.Status.OIDCIssuerURL).To(Equal("https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"))
+		})
+
+		It("should leave the status alone when the stack predates the outputs", func() {
+			cfg := api.NewClusterConfig()
+			Expect(SetClusterStatusFromStack(cfg, &cfn.Stack{})).To(Succeed())
+			Expect(cfg.Status.OIDCIssuerURL).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,134 @@
+package eks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+const (
+	// LoggingAuditConfigMap is the ConfigMap in kube-system that holds the audit trail
+	// of the changes eksctl made to control plane logging
+	LoggingAuditConfigMap = "eksctl-cluster-logging-audit"
+	// LoggingAuditAnnotation is the annotation of LoggingAuditConfigMap that holds the
+	// records, as a JSON list, oldest first
+	LoggingAuditAnnotation = "eksctl.io/logging-audit"
+)
+
+// LoggingAuditRecord records a change to the types of control plane logs
+type LoggingAuditRecord struct {
+	// Time is when the change completed, in RFC 3339 format
+	Time string `json:"time"`
+	// Principal is the ARN of the AWS principal that made the change
+	Principal string `json:"principal"`
+	// Before and After are the types of logs enabled before and after the change
+	Before []string `json:"before"`
+	After  []string `json:"after"`
+}
+
+// RecordClusterLoggingChange appends a record of a change to control plane
+// logging from before to after to the audit trail of the cluster
+func (c *ClusterProvider) RecordClusterLoggingChange(spec *api.ClusterConfig, before, after []string) error {
+	clientSet, err := c.newAuditClientSet(spec)
+	if err != nil {
+		return err
+	}
+	return AppendLoggingAuditRecord(clientSet, &LoggingAuditRecord{
+		Time:      time.Now().UTC().Format(time.RFC3339),
+		Principal: c.Status.iamRoleARN,
+		Before:    before,
+		After:     after,
+	})
+}
+
+// ClusterLoggingHistory returns the audit trail of the changes to control plane
+// logging of the cluster, oldest first
+func (c *ClusterProvider) ClusterLoggingHistory(spec *api.ClusterConfig) ([]*LoggingAuditRecord, error) {
+	clientSet, err := c.newAuditClientSet(spec)
+	if err != nil {
+		return nil, err
+	}
+	return LoggingAuditRecords(clientSet)
+}
+
+func (c *ClusterProvider) newAuditClientSet(spec *api.ClusterConfig) (kubernetes.Interface, error) {
+	if err := c.GetCredentials(spec); err != nil {
+		return nil, errors.Wrapf(err, "getting credentials for cluster %q", spec.Metadata.Name)
+	}
+	clientSet, err := c.NewStdClientSet(spec)
+	if err != nil {
+		return nil, err
+	}
+	return clientSet, nil
+}
+
+// AppendLoggingAuditRecord appends the record to the annotation of LoggingAuditConfigMap,
+// which is created when it doesn't exist
+func AppendLoggingAuditRecord(clientSet kubernetes.Interface, record *LoggingAuditRecord) error {
+	configMaps := clientSet.CoreV1().ConfigMaps(metav1.NamespaceSystem)
+	cm, err := configMaps.Get(LoggingAuditConfigMap, metav1.GetOptions{})
+	create := apierrs.IsNotFound(err)
+	if err != nil && !create {
+		return errors.Wrapf(err, "getting ConfigMap %q", LoggingAuditConfigMap)
+	}
+	if create {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      LoggingAuditConfigMap,
+				Namespace: metav1.NamespaceSystem,
+			},
+		}
+	}
+
+	records, err := decodeLoggingAuditRecords(cm)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(append(records, record))
+	if err != nil {
+		return errors.Wrap(err, "encoding logging audit records")
+	}
+	if cm.Annotations == nil {
+		cm.Annotations = map[string]string{}
+	}
+	cm.Annotations[LoggingAuditAnnotation] = string(data)
+
+	if create {
+		_, err = configMaps.Create(cm)
+	} else {
+		_, err = configMaps.Update(cm)
+	}
+	return errors.Wrapf(err, "saving ConfigMap %q", LoggingAuditConfigMap)
+}
+
+// LoggingAuditRecords returns the records of LoggingAuditConfigMap, oldest first,
+// there are none when it doesn't exist
+func LoggingAuditRecords(clientSet kubernetes.Interface) ([]*LoggingAuditRecord, error) {
+	cm, err := clientSet.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(LoggingAuditConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return []*LoggingAuditRecord{}, nil
+		}
+		return nil, errors.Wrapf(err, "getting ConfigMap %q", LoggingAuditConfigMap)
+	}
+	return decodeLoggingAuditRecords(cm)
+}
+
+func decodeLoggingAuditRecords(cm *corev1.ConfigMap) ([]*LoggingAuditRecord, error) {
+	records := []*LoggingAuditRecord{}
+	data, ok := cm.Annotations[LoggingAuditAnnotation]
+	if !ok {
+		return records, nil
+	}
+	if err := json.Unmarshal([]byte(data), &records); err != nil {
+		return nil, errors.Wrapf(err, "decoding annotation %q of ConfigMap %q", LoggingAuditAnnotation, LoggingAuditConfigMap)
+	}
+	return records, nil
+}
@@ -0,0 +1,38 @@
+package eks
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Logging audit trail", func() {
+	It("should have no records until a change is recorded", func() {
+		records, err := LoggingAuditRecords(fake.NewSimpleClientset())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(records).To(BeEmpty())
+	})
+
+	It("should append records, oldest first", func() {
+		clientSet := fake.NewSimpleClientset()
+
+		first := &LoggingAuditRecord{
+			Time:      "2019-06-01T10:00:00Z",
+			Principal: "arn:aws:iam::123456789012:user/alice",
+			Before:    []string{},
+			After:     []string{"api", "audit"},
+		}
+		second := &LoggingAuditRecord{
+			Time:      "2019-06-02T10:00:00Z",
+			Principal: "arn:aws:iam::123456789012:role/ci",
+			Before:    []string{"api", "audit"},
+			After:     []string{"audit"},
+		}
+		Expect(AppendLoggingAuditRecord(clientSet, first)).To(Succeed())
+		Expect(AppendLoggingAuditRecord(clientSet, second)).To(Succeed())
+
+		records, err := LoggingAuditRecords(clientSet)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(records).To(Equal([]*LoggingAuditRecord{first, second}))
+	})
+})
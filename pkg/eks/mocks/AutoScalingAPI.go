@@ -0,0 +1,415 @@
+package mocks
+
+import autoscaling "github.com/aws/aws-sdk-go/service/autoscaling"
+import autoscalingiface "github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import request "github.com/aws/aws-sdk-go/aws/request"
+
+// AutoScalingAPI is a mock type for the AutoScalingAPI type, it's written by hand in
+// the same way as the other mocks, but only covers the calls used by eksctl, as the
+// full Auto Scaling API is very large; calling any other method will panic
+type AutoScalingAPI struct {
+	autoscalingiface.AutoScalingAPI
+	mock.Mock
+}
+
+// DescribeAutoScalingGroups provides a mock function with given fields: _a0
+func (_m *AutoScalingAPI) DescribeAutoScalingGroups(_a0 *autoscaling.DescribeAutoScalingGroupsInput) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *autoscaling.DescribeAutoScalingGroupsOutput
+	if rf, ok := ret.Get(0).(func(*autoscaling.DescribeAutoScalingGroupsInput) *autoscaling.DescribeAutoScalingGroupsOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.DescribeAutoScalingGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*autoscaling.DescribeAutoScalingGroupsInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeAutoScalingGroupsPages provides a mock function with given fields: _a0, _a1
+func (_m *AutoScalingAPI) DescribeAutoScalingGroupsPages(_a0 *autoscaling.DescribeAutoScalingGroupsInput, _a1 func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*autoscaling.DescribeAutoScalingGroupsInput, func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeAutoScalingGroupsPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *AutoScalingAPI) DescribeAutoScalingGroupsPagesWithContext(_a0 context.Context, _a1 *autoscaling.DescribeAutoScalingGroupsInput, _a2 func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.DescribeAutoScalingGroupsInput, func(*autoscaling.DescribeAutoScalingGroupsOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeAutoScalingGroupsRequest provides a mock function with given fields: _a0
+func (_m *AutoScalingAPI) DescribeAutoScalingGroupsRequest(_a0 *autoscaling.DescribeAutoScalingGroupsInput) (*request.Request, *autoscaling.DescribeAutoScalingGroupsOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*autoscaling.DescribeAutoScalingGroupsInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *autoscaling.DescribeAutoScalingGroupsOutput
+	if rf, ok := ret.Get(1).(func(*autoscaling.DescribeAutoScalingGroupsInput) *autoscaling.DescribeAutoScalingGroupsOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*autoscaling.DescribeAutoScalingGroupsOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeAutoScalingGroupsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AutoScalingAPI) DescribeAutoScalingGroupsWithContext(_a0 context.Context, _a1 *autoscaling.DescribeAutoScalingGroupsInput, _a2 ...request.Option) (*autoscaling.DescribeAutoScalingGroupsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *autoscaling.DescribeAutoScalingGroupsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.DescribeAutoScalingGroupsInput, ...request.Option) *autoscaling.DescribeAutoScalingGroupsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.DescribeAutoScalingGroupsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *autoscaling.DescribeAutoScalingGroupsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeScalingActivities provides a mock function with given fields: _a0
+func (_m *AutoScalingAPI) DescribeScalingActivities(_a0 *autoscaling.DescribeScalingActivitiesInput) (*autoscaling.DescribeScalingActivitiesOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *autoscaling.DescribeScalingActivitiesOutput
+	if rf, ok := ret.Get(0).(func(*autoscaling.DescribeScalingActivitiesInput) *autoscaling.DescribeScalingActivitiesOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.DescribeScalingActivitiesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*autoscaling.DescribeScalingActivitiesInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DescribeScalingActivitiesPages provides a mock function with given fields: _a0, _a1
+func (_m *AutoScalingAPI) DescribeScalingActivitiesPages(_a0 *autoscaling.DescribeScalingActivitiesInput, _a1 func(*autoscaling.DescribeScalingActivitiesOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*autoscaling.DescribeScalingActivitiesInput, func(*autoscaling.DescribeScalingActivitiesOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeScalingActivitiesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *AutoScalingAPI) DescribeScalingActivitiesPagesWithContext(_a0 context.Context, _a1 *autoscaling.DescribeScalingActivitiesInput, _a2 func(*autoscaling.DescribeScalingActivitiesOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.DescribeScalingActivitiesInput, func(*autoscaling.DescribeScalingActivitiesOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeScalingActivitiesRequest provides a mock function with given fields: _a0
+func (_m *AutoScalingAPI) DescribeScalingActivitiesRequest(_a0 *autoscaling.DescribeScalingActivitiesInput) (*request.Request, *autoscaling.DescribeScalingActivitiesOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*autoscaling.DescribeScalingActivitiesInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *autoscaling.DescribeScalingActivitiesOutput
+	if rf, ok := ret.Get(1).(func(*autoscaling.DescribeScalingActivitiesInput) *autoscaling.DescribeScalingActivitiesOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*autoscaling.DescribeScalingActivitiesOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// DescribeScalingActivitiesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AutoScalingAPI) DescribeScalingActivitiesWithContext(_a0 context.Context, _a1 *autoscaling.DescribeScalingActivitiesInput, _a2 ...request.Option) (*autoscaling.DescribeScalingActivitiesOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *autoscaling.DescribeScalingActivitiesOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.DescribeScalingActivitiesInput, ...request.Option) *autoscaling.DescribeScalingActivitiesOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.DescribeScalingActivitiesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *autoscaling.DescribeScalingActivitiesInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuspendProcesses provides a mock function with given fields: _a0
+func (_m *AutoScalingAPI) SuspendProcesses(_a0 *autoscaling.ScalingProcessQuery) (*autoscaling.SuspendProcessesOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *autoscaling.SuspendProcessesOutput
+	if rf, ok := ret.Get(0).(func(*autoscaling.ScalingProcessQuery) *autoscaling.SuspendProcessesOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.SuspendProcessesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*autoscaling.ScalingProcessQuery) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateOrUpdateTagsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AutoScalingAPI) CreateOrUpdateTagsWithContext(_a0 context.Context, _a1 *autoscaling.CreateOrUpdateTagsInput, _a2 ...request.Option) (*autoscaling.CreateOrUpdateTagsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *autoscaling.CreateOrUpdateTagsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.CreateOrUpdateTagsInput, ...request.Option) *autoscaling.CreateOrUpdateTagsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.CreateOrUpdateTagsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *autoscaling.CreateOrUpdateTagsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTagsWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AutoScalingAPI) DeleteTagsWithContext(_a0 context.Context, _a1 *autoscaling.DeleteTagsInput, _a2 ...request.Option) (*autoscaling.DeleteTagsOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *autoscaling.DeleteTagsOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.DeleteTagsInput, ...request.Option) *autoscaling.DeleteTagsOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.DeleteTagsOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *autoscaling.DeleteTagsInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResumeProcessesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AutoScalingAPI) ResumeProcessesWithContext(_a0 context.Context, _a1 *autoscaling.ScalingProcessQuery, _a2 ...request.Option) (*autoscaling.ResumeProcessesOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *autoscaling.ResumeProcessesOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.ScalingProcessQuery, ...request.Option) *autoscaling.ResumeProcessesOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.ResumeProcessesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *autoscaling.ScalingProcessQuery, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SuspendProcessesWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AutoScalingAPI) SuspendProcessesWithContext(_a0 context.Context, _a1 *autoscaling.ScalingProcessQuery, _a2 ...request.Option) (*autoscaling.SuspendProcessesOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *autoscaling.SuspendProcessesOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.ScalingProcessQuery, ...request.Option) *autoscaling.SuspendProcessesOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.SuspendProcessesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *autoscaling.ScalingProcessQuery, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TerminateInstanceInAutoScalingGroupWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *AutoScalingAPI) TerminateInstanceInAutoScalingGroupWithContext(_a0 context.Context, _a1 *autoscaling.TerminateInstanceInAutoScalingGroupInput, _a2 ...request.Option) (*autoscaling.TerminateInstanceInAutoScalingGroupOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *autoscaling.TerminateInstanceInAutoScalingGroupOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *autoscaling.TerminateInstanceInAutoScalingGroupInput, ...request.Option) *autoscaling.TerminateInstanceInAutoScalingGroupOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*autoscaling.TerminateInstanceInAutoScalingGroupOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *autoscaling.TerminateInstanceInAutoScalingGroupInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,74 @@
+package mocks
+
+import resourcegroupstaggingapi "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+import resourcegroupstaggingapiiface "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import request "github.com/aws/aws-sdk-go/aws/request"
+
+// ResourceGroupsTaggingAPIAPI is a mock type for the ResourceGroupsTaggingAPIAPI type, it's written
+// by hand in the same way as the other mocks, but only covers the listing of resources used by
+// eksctl; calling any other method will panic
+type ResourceGroupsTaggingAPIAPI struct {
+	resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI
+	mock.Mock
+}
+
+// GetResources provides a mock function with given fields: _a0
+func (_m *ResourceGroupsTaggingAPIAPI) GetResources(_a0 *resourcegroupstaggingapi.GetResourcesInput) (*resourcegroupstaggingapi.GetResourcesOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *resourcegroupstaggingapi.GetResourcesOutput
+	if rf, ok := ret.Get(0).(func(*resourcegroupstaggingapi.GetResourcesInput) *resourcegroupstaggingapi.GetResourcesOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*resourcegroupstaggingapi.GetResourcesOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*resourcegroupstaggingapi.GetResourcesInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetResourcesPages provides a mock function with given fields: _a0, _a1
+func (_m *ResourceGroupsTaggingAPIAPI) GetResourcesPages(_a0 *resourcegroupstaggingapi.GetResourcesInput, _a1 func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*resourcegroupstaggingapi.GetResourcesInput, func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool) error); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetResourcesPagesWithContext provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *ResourceGroupsTaggingAPIAPI) GetResourcesPagesWithContext(_a0 context.Context, _a1 *resourcegroupstaggingapi.GetResourcesInput, _a2 func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool, _a3 ...request.Option) error {
+	_va := make([]interface{}, len(_a3))
+	for _i := range _a3 {
+		_va[_i] = _a3[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1, _a2)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *resourcegroupstaggingapi.GetResourcesInput, func(*resourcegroupstaggingapi.GetResourcesOutput, bool) bool, ...request.Option) error); ok {
+		r0 = rf(_a0, _a1, _a2, _a3...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,94 @@
+package mocks
+
+import sns "github.com/aws/aws-sdk-go/service/sns"
+import snsiface "github.com/aws/aws-sdk-go/service/sns/snsiface"
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import request "github.com/aws/aws-sdk-go/aws/request"
+
+// SNSAPI is a mock type for the SNSAPI type, it's written by hand in the same
+// way as the other mocks, but only covers publishing used by eksctl; calling
+// any other method will panic
+type SNSAPI struct {
+	snsiface.SNSAPI
+	mock.Mock
+}
+
+// Publish provides a mock function with given fields: _a0
+func (_m *SNSAPI) Publish(_a0 *sns.PublishInput) (*sns.PublishOutput, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *sns.PublishOutput
+	if rf, ok := ret.Get(0).(func(*sns.PublishInput) *sns.PublishOutput); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sns.PublishOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*sns.PublishInput) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PublishRequest provides a mock function with given fields: _a0
+func (_m *SNSAPI) PublishRequest(_a0 *sns.PublishInput) (*request.Request, *sns.PublishOutput) {
+	ret := _m.Called(_a0)
+
+	var r0 *request.Request
+	if rf, ok := ret.Get(0).(func(*sns.PublishInput) *request.Request); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*request.Request)
+		}
+	}
+
+	var r1 *sns.PublishOutput
+	if rf, ok := ret.Get(1).(func(*sns.PublishInput) *sns.PublishOutput); ok {
+		r1 = rf(_a0)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*sns.PublishOutput)
+		}
+	}
+
+	return r0, r1
+}
+
+// PublishWithContext provides a mock function with given fields: _a0, _a1, _a2
+func (_m *SNSAPI) PublishWithContext(_a0 context.Context, _a1 *sns.PublishInput, _a2 ...request.Option) (*sns.PublishOutput, error) {
+	_va := make([]interface{}, len(_a2))
+	for _i := range _a2 {
+		_va[_i] = _a2[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, _a0, _a1)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *sns.PublishOutput
+	if rf, ok := ret.Get(0).(func(context.Context, *sns.PublishInput, ...request.Option) *sns.PublishOutput); ok {
+		r0 = rf(_a0, _a1, _a2...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*sns.PublishOutput)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *sns.PublishInput, ...request.Option) error); ok {
+		r1 = rf(_a0, _a1, _a2...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
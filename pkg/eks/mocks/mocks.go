@@ -8,6 +8,7 @@ import (
 	_ "github.com/aws/aws-sdk-go/service/elb/elbiface"
 	_ "github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	_ "github.com/aws/aws-sdk-go/service/iam/iamiface"
+	_ "github.com/aws/aws-sdk-go/service/pricing/pricingiface"
 	_ "github.com/aws/aws-sdk-go/service/sts/stsiface"
 	_ "github.com/vektra/mockery"
 )
@@ -21,3 +22,4 @@ import (
 //go:generate "${GOBIN}/mockery" -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/sts/stsiface -name=STSAPI -output=./
 //go:generate "${GOBIN}/mockery" -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/iam/iamiface -name=IAMAPI -output=./
 //go:generate "${GOBIN}/mockery" -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface -name=CloudTrailAPI -output=./
+//go:generate "${GOBIN}/mockery" -tags netgo -dir=../../../vendor/github.com/aws/aws-sdk-go/service/pricing/pricingiface -name=PricingAPI -output=./
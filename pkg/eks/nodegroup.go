@@ -2,6 +2,8 @@ package eks
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/kris-nova/logger"
@@ -10,10 +12,10 @@ import (
 	"github.com/weaveworks/eksctl/pkg/iam"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/utils/waiters"
 
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/sets"
-	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -45,57 +47,88 @@ func getNodes(clientSet kubernetes.Interface, ng *api.NodeGroup) (int, error) {
 	return counter, nil
 }
 
-// WaitForNodes waits till the nodes are ready
-func (c *ClusterProvider) WaitForNodes(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
-	if ng.MinSize == nil || *ng.MinSize == 0 {
-		return nil
-	}
-	timer := time.After(c.Provider.WaitTimeout())
-	timeout := false
-	readyNodes := sets.NewString()
-	watcher, err := clientSet.CoreV1().Nodes().Watch(ng.ListOptions())
-	if err != nil {
-		return errors.Wrap(err, "creating node watcher")
+// nodeNotReadyReason returns why the kubelet reports the node isn't ready, e.g. when
+// the CNI plugin isn't initialised or the pause container image can't be pulled
+func nodeNotReadyReason(node *corev1.Node) string {
+	for _, c := range node.Status.Conditions {
+		if c.Type != corev1.NodeReady {
+			continue
+		}
+		if c.Message != "" {
+			return fmt.Sprintf("%s: %s", c.Reason, c.Message)
+		}
+		return c.Reason
 	}
+	return "kubelet hasn't reported the status of the node yet"
+}
 
-	counter, err := getNodes(clientSet, ng)
-	if err != nil {
-		return errors.Wrap(err, "listing nodes")
+// WaitForNodes waits until the expected number of nodes of the nodegroup have registered
+// and are ready, as launched instances don't mean the kubelet works; the reasons nodes
+// aren't ready are logged as they change, and reported when the wait times out
+func (c *ClusterProvider) WaitForNodes(clientSet kubernetes.Interface, ng *api.NodeGroup) error {
+	expected := ng.NodeCount()
+	if expected == 0 {
+		return nil
 	}
+	timeout := c.Provider.WaitTimeout()
+	deadline := time.Now().Add(timeout)
+	notReady := map[string]string{}
 
-	logger.Info("waiting for at least %d node(s) to become ready in %q", *ng.MinSize, ng.Name)
-	for !timeout && counter < *ng.MinSize {
-		select {
-		case event := <-watcher.ResultChan():
-			logger.Debug("event = %#v", event)
-			if event.Object != nil && event.Type != watch.Deleted {
-				if node, ok := event.Object.(*corev1.Node); ok {
-					if isNodeReady(node) {
-						readyNodes.Insert(node.Name)
-						counter = readyNodes.Len()
-						logger.Debug("node %q is ready in %q", node.Name, ng.Name)
-					} else {
-						logger.Debug("node %q seen in %q, but not ready yet", node.Name, ng.Name)
-						logger.Debug("node = %#v", *node)
-					}
-				}
+	logger.Info("waiting for %d node(s) to join the cluster and become ready in %q", expected, ng.Name)
+	for {
+		nodes, err := clientSet.CoreV1().Nodes().List(ng.ListOptions())
+		if err != nil {
+			return errors.Wrap(err, "listing nodes")
+		}
+		ready := 0
+		reasons := map[string]string{}
+		for _, node := range nodes.Items {
+			if isNodeReady(&node) {
+				ready++
+				continue
+			}
+			reason := nodeNotReadyReason(&node)
+			if notReady[node.Name] != reason {
+				logger.Info("node %q is not ready: %s", node.Name, reason)
 			}
-		case <-timer:
-			timeout = true
+			reasons[node.Name] = reason
 		}
-	}
-	watcher.Stop()
-	if timeout {
-		return fmt.Errorf("timed out (after %s) waiting for at least %d nodes to join the cluster and become ready in %q", c.Provider.WaitTimeout(), *ng.MinSize, ng.Name)
+		notReady = reasons
+		logger.Debug("nodegroup %q has %d/%d registered and %d/%d ready node(s)", ng.Name, len(nodes.Items), expected, ready, expected)
+
+		if ready >= expected {
+			break
+		}
+		if !time.Now().Before(deadline) {
+			return eksctlerrors.NewTimeout("timed out (after %s) waiting for %d node(s) to join the cluster and become ready in %q, %d registered, %d ready%s",
+				timeout, expected, ng.Name, len(nodes.Items), ready, describeNotReadyNodes(notReady))
+		}
+		time.Sleep(waiters.PollInterval(c.Provider.PollInterval()))
 	}
 
-	if _, err = getNodes(clientSet, ng); err != nil {
+	if _, err := getNodes(clientSet, ng); err != nil {
 		return errors.Wrap(err, "re-listing nodes")
 	}
 
 	return nil
 }
 
+func describeNotReadyNodes(notReady map[string]string) string {
+	if len(notReady) == 0 {
+		return ""
+	}
+	names := []string{}
+	for name := range notReady {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	descriptions := []string{}
+	for _, name := range names {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", name, notReady[name]))
+	}
+	return ", not ready: " + strings.Join(descriptions, "; ")
+}
+
 // GetNodeGroupIAM retrieves the IAM configuration of the given nodegroup
 func (c *ClusterProvider) GetNodeGroupIAM(stackManager *manager.StackCollection, spec *api.ClusterConfig, ng *api.NodeGroup) error {
 	stacks, err := stackManager.DescribeNodeGroupStacks()
@@ -112,5 +145,5 @@ func (c *ClusterProvider) GetNodeGroupIAM(stackManager *manager.StackCollection,
 		}
 	}
 
-	return fmt.Errorf("stack not found for nodegroup %q", ng.Name)
+	return eksctlerrors.NewNotFound("stack not found for nodegroup %q", ng.Name)
 }
@@ -0,0 +1,68 @@
+package eks
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Waiting for nodes", func() {
+	newNode := func(name string, ready corev1.ConditionStatus, reason, message string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{api.NodeGroupNameLabel: "ng-1"},
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: ready, Reason: reason, Message: message},
+				},
+			},
+		}
+	}
+
+	var (
+		c  *ClusterProvider
+		ng *api.NodeGroup
+	)
+
+	BeforeEach(func() {
+		c = &ClusterProvider{Provider: mockprovider.NewMockProvider()}
+		ng = &api.NodeGroup{Name: "ng-1", MinSize: new(int), DesiredCapacity: new(int)}
+		*ng.MinSize = 1
+		*ng.DesiredCapacity = 2
+	})
+
+	It("doesn't wait for a nodegroup scaled to zero", func() {
+		*ng.DesiredCapacity = 0
+		Expect(c.WaitForNodes(fake.NewSimpleClientset(), ng)).To(Succeed())
+	})
+
+	It("succeeds once the expected number of nodes are ready", func() {
+		clientSet := fake.NewSimpleClientset(
+			newNode("node-1", corev1.ConditionTrue, "KubeletReady", ""),
+			newNode("node-2", corev1.ConditionTrue, "KubeletReady", ""),
+		)
+		Expect(c.WaitForNodes(clientSet, ng)).To(Succeed())
+	})
+
+	It("reports why nodes aren't ready when it times out", func() {
+		timeout := mockprovider.ProviderConfig.WaitTimeout
+		mockprovider.ProviderConfig.WaitTimeout = 0
+		defer func() { mockprovider.ProviderConfig.WaitTimeout = timeout }()
+
+		clientSet := fake.NewSimpleClientset(
+			newNode("node-1", corev1.ConditionTrue, "KubeletReady", ""),
+			newNode("node-2", corev1.ConditionFalse, "KubeletNotReady", "runtime network not ready: cni config uninitialized"),
+		)
+		err := c.WaitForNodes(clientSet, ng)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal(`timed out (after 0s) waiting for 2 node(s) to join the cluster and become ready in "ng-1", 2 registered, 1 ready, ` +
+			`not ready: node-2 (KubeletNotReady: runtime network not ready: cni config uninitialized)`))
+	})
+})
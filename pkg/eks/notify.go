@@ -0,0 +1,69 @@
+package eks
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/pkg/errors"
+)
+
+// Statuses of operations in OperationEvent
+const (
+	OperationStarted   = "started"
+	OperationSucceeded = "succeeded"
+	OperationFailed    = "failed"
+)
+
+// maxSNSSubjectLength is the length SNS allows for the subject of messages
+const maxSNSSubjectLength = 100
+
+var snsTopicARNPattern = regexp.MustCompile(`^arn:aws[a-z-]*:sns:([a-z0-9-]+):\d{12}:[A-Za-z0-9_-]{1,256}$`)
+
+// OperationEvent is published to SNS topics about long-running operations on clusters
+type OperationEvent struct {
+	Operation string    `json:"operation"`
+	Status    string    `json:"status"`
+	Cluster   string    `json:"cluster"`
+	Region    string    `json:"region,omitempty"`
+	Time      time.Time `json:"time"`
+	// DurationSeconds is set once the operation has succeeded or failed
+	DurationSeconds int64  `json:"durationSeconds,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// SNSTopicRegion returns the region of the SNS topic arn, which is
+// where it must be published to
+func SNSTopicRegion(arn string) (string, error) {
+	m := snsTopicARNPattern.FindStringSubmatch(arn)
+	if m == nil {
+		return "", fmt.Errorf("%q is not the ARN of an SNS topic", arn)
+	}
+	return m[1], nil
+}
+
+// PublishOperationEvent publishes event as JSON to the SNS topic, with its operation and
+// status in message attributes, so that subscriptions can filter on them
+func (c *ClusterProvider) PublishOperationEvent(topicARN string, event OperationEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	subject := fmt.Sprintf("eksctl: %s %q %s", event.Operation, event.Cluster, event.Status)
+	if len(subject) > maxSNSSubjectLength {
+		subject = subject[:maxSNSSubjectLength]
+	}
+	_, err = c.Provider.SNS().Publish(&sns.PublishInput{
+		TopicArn: aws.String(topicARN),
+		Subject:  aws.String(subject),
+		Message:  aws.String(string(data)),
+		MessageAttributes: map[string]*sns.MessageAttributeValue{
+			"operation": {DataType: aws.String("String"), StringValue: aws.String(event.Operation)},
+			"status":    {DataType: aws.String("String"), StringValue: aws.String(event.Status)},
+		},
+	})
+	return errors.Wrapf(err, "publishing to SNS topic %q", topicARN)
+}
@@ -0,0 +1,51 @@
+package eks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/aws/aws-sdk-go/service/sns"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Operation notifications", func() {
+	It("finds the region of SNS topics", func() {
+		region, err := SNSTopicRegion("arn:aws:sns:eu-west-1:123456789012:eksctl-operations")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(region).To(Equal("eu-west-1"))
+
+		_, err = SNSTopicRegion("arn:aws:sqs:eu-west-1:123456789012:eksctl-operations")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("publishes events with their operation and status in attributes", func() {
+		p := mockprovider.NewMockProvider()
+		c := &ClusterProvider{Provider: p}
+
+		var input *sns.PublishInput
+		p.MockSNS().On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+			input = args.Get(0).(*sns.PublishInput)
+		}).Return(&sns.PublishOutput{}, nil)
+
+		event := OperationEvent{
+			Operation:       "create cluster",
+			Status:          OperationSucceeded,
+			Cluster:         "cluster-1",
+			Region:          "us-west-2",
+			Time:            time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+			DurationSeconds: 960,
+		}
+		Expect(c.PublishOperationEvent("arn:aws:sns:us-west-2:123456789012:ops", event)).To(Succeed())
+
+		Expect(*input.TopicArn).To(Equal("arn:aws:sns:us-west-2:123456789012:ops"))
+		Expect(*input.Subject).To(Equal(`eksctl: create cluster "cluster-1" succeeded`))
+		Expect(*input.MessageAttributes["status"].StringValue).To(Equal("succeeded"))
+		var published OperationEvent
+		Expect(json.Unmarshal([]byte(*input.Message), &published)).To(Succeed())
+		Expect(published).To(Equal(event))
+	})
+})
@@ -0,0 +1,32 @@
+package eks
+
+import (
+	"os"
+	"strings"
+
+	"github.com/kris-nova/logger"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// setProxyEnvironment makes the AWS and Kubernetes clients of eksctl use the proxy of the
+// cluster, both read the proxy from the environment the first time they make a request;
+// settings of the environment take precedence over the ones of the cluster
+func setProxyEnvironment(spec *api.ClusterConfig) {
+	if spec == nil || spec.Proxy == nil {
+		return
+	}
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", spec.Proxy.HTTPProxy},
+		{"HTTPS_PROXY", spec.Proxy.HTTPSProxy},
+		{"NO_PROXY", strings.Join(spec.Proxy.NoProxy, ",")},
+	} {
+		if v.value == "" || os.Getenv(v.name) != "" || os.Getenv(strings.ToLower(v.name)) != "" {
+			continue
+		}
+		logger.Debug("setting %s=%s", v.name, v.value)
+		if err := os.Setenv(v.name, v.value); err != nil {
+			logger.Warning("unable to set %s: %s", v.name, err.Error())
+		}
+	}
+}
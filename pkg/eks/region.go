@@ -0,0 +1,117 @@
+package eks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+)
+
+// Sources of the region, in order of precedence
+const (
+	RegionSourceFlag              = "--region flag"
+	RegionSourceConfigFile        = "metadata.region of the config file"
+	RegionSourceEnv               = "AWS_REGION or AWS_DEFAULT_REGION environment variable"
+	RegionSourceProfile           = "AWS profile"
+	RegionSourceContainerMetadata = "container metadata"
+	RegionSourceInstanceMetadata  = "EC2 instance metadata"
+	RegionSourceDefault           = "default region"
+)
+
+// metadataTimeout bounds lookups of metadata endpoints, which
+// aren't reachable when eksctl doesn't run on AWS
+const metadataTimeout = time.Second
+
+// regionDetector finds the region of the AWS environment eksctl runs in
+type regionDetector struct {
+	getenv func(string) string
+	// getJSON decodes the JSON document at url into v
+	getJSON func(url string, v interface{}) error
+	// instanceRegion returns the region of the EC2 instance
+	instanceRegion func() (string, error)
+}
+
+func newRegionDetector(s *session.Session) *regionDetector {
+	client := &http.Client{Timeout: metadataTimeout}
+	metadata := ec2metadata.New(s, aws.NewConfig().WithHTTPClient(client).WithMaxRetries(0))
+	return &regionDetector{
+		getenv: os.Getenv,
+		getJSON: func(url string, v interface{}) error {
+			resp, err := client.Get(url)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				return errors.Errorf("unexpected status %s", resp.Status)
+			}
+			data, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				return err
+			}
+			return json.Unmarshal(data, v)
+		},
+		instanceRegion: metadata.Region,
+	}
+}
+
+// detect returns the region of the CodeBuild build or ECS task eksctl runs in, which
+// their ARNs contain, or the region of the EC2 instance; the region is empty when
+// eksctl doesn't run on AWS
+func (d *regionDetector) detect() (string, string) {
+	if region := arnRegion(d.getenv("CODEBUILD_BUILD_ARN")); region != "" {
+		return region, RegionSourceContainerMetadata
+	}
+
+	for _, env := range []string{"ECS_CONTAINER_METADATA_URI_V4", "ECS_CONTAINER_METADATA_URI"} {
+		uri := d.getenv(env)
+		if uri == "" {
+			continue
+		}
+		task := struct{ TaskARN string }{}
+		if err := d.getJSON(strings.TrimSuffix(uri, "/")+"/task", &task); err != nil {
+			logger.Debug("unable to get task metadata from %s: %s", uri, err.Error())
+			continue
+		}
+		if region := arnRegion(task.TaskARN); region != "" {
+			return region, RegionSourceContainerMetadata
+		}
+	}
+
+	if d.getenv("AWS_EC2_METADATA_DISABLED") == "true" {
+		return "", ""
+	}
+	region, err := d.instanceRegion()
+	if err != nil {
+		logger.Debug("unable to get region from EC2 instance metadata: %s", err.Error())
+		return "", ""
+	}
+	return region, RegionSourceInstanceMetadata
+}
+
+// arnRegion returns the region of an ARN, i.e. us-west-2 of
+// arn:aws:ecs:us-west-2:123456789012:task/abc, it's empty for invalid ARNs
+func arnRegion(s string) string {
+	parts := strings.SplitN(s, ":", 6)
+	if len(parts) < 6 || parts[0] != "arn" {
+		return ""
+	}
+	return parts[3]
+}
+
+// sessionRegionSource returns the source of the region the AWS SDK resolved,
+// which prefers the environment to the profile
+func sessionRegionSource(region string, getenv func(string) string) string {
+	if region == getenv("AWS_REGION") || region == getenv("AWS_DEFAULT_REGION") {
+		return RegionSourceEnv
+	}
+	return RegionSourceProfile
+}
@@ -0,0 +1,85 @@
+package eks
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Region detection", func() {
+	var (
+		env       map[string]string
+		documents map[string]string
+		instance  string
+		detector  *regionDetector
+	)
+
+	BeforeEach(func() {
+		env = map[string]string{}
+		documents = map[string]string{}
+		instance = ""
+		detector = &regionDetector{
+			getenv: func(key string) string { return env[key] },
+			getJSON: func(url string, v interface{}) error {
+				arn, ok := documents[url]
+				if !ok {
+					return errors.New("not found")
+				}
+				v.(*struct{ TaskARN string }).TaskARN = arn
+				return nil
+			},
+			instanceRegion: func() (string, error) {
+				if instance == "" {
+					return "", errors.New("timeout")
+				}
+				return instance, nil
+			},
+		}
+	})
+
+	It("detects the region of CodeBuild builds", func() {
+		env["CODEBUILD_BUILD_ARN"] = "arn:aws:codebuild:eu-west-1:123456789012:build/eksctl:abc"
+		instance = "us-east-1"
+
+		region, source := detector.detect()
+		Expect(region).To(Equal("eu-west-1"))
+		Expect(source).To(Equal(RegionSourceContainerMetadata))
+	})
+
+	It("detects the region of ECS tasks", func() {
+		env["ECS_CONTAINER_METADATA_URI_V4"] = "http://169.254.170.2/v4/abc/"
+		documents["http://169.254.170.2/v4/abc/task"] = "arn:aws:ecs:ap-south-1:123456789012:task/default/abc"
+
+		region, source := detector.detect()
+		Expect(region).To(Equal("ap-south-1"))
+		Expect(source).To(Equal(RegionSourceContainerMetadata))
+	})
+
+	It("falls back to EC2 instance metadata", func() {
+		env["ECS_CONTAINER_METADATA_URI"] = "http://169.254.170.2/v3/abc"
+		instance = "eu-north-1"
+
+		region, source := detector.detect()
+		Expect(region).To(Equal("eu-north-1"))
+		Expect(source).To(Equal(RegionSourceInstanceMetadata))
+	})
+
+	It("doesn't detect a region outside of AWS or when instance metadata is disabled", func() {
+		region, _ := detector.detect()
+		Expect(region).To(BeEmpty())
+
+		env["AWS_EC2_METADATA_DISABLED"] = "true"
+		instance = "eu-north-1"
+		region, _ = detector.detect()
+		Expect(region).To(BeEmpty())
+	})
+
+	It("tells the environment from the profile", func() {
+		env["AWS_DEFAULT_REGION"] = "eu-west-2"
+		getenv := func(key string) string { return env[key] }
+
+		Expect(sessionRegionSource("eu-west-2", getenv)).To(Equal(RegionSourceEnv))
+		Expect(sessionRegionSource("us-east-2", getenv)).To(Equal(RegionSourceProfile))
+	})
+})
@@ -0,0 +1,52 @@
+package eks
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/pkg/errors"
+)
+
+// requiredServiceRolePolicies are the managed policies an existing EKS service role
+// must have, keyed by the name they have in every partition
+var requiredServiceRolePolicies = []string{"AmazonEKSClusterPolicy"}
+
+// CheckServiceRole checks that the existing role EKS is given to manage the cluster can be
+// assumed by EKS and has the required managed policies, so that a misconfigured role fails
+// before any stack is created rather than in the middle of the creation of the control plane
+func (c *ClusterProvider) CheckServiceRole(roleARN string) error {
+	name := roleARN[strings.LastIndex(roleARN, "/")+1:]
+
+	role, err := c.Provider.IAM().GetRole(&iam.GetRoleInput{RoleName: &name})
+	if err != nil {
+		return errors.Wrapf(err, "getting service role %q", roleARN)
+	}
+	trustPolicy, err := url.QueryUnescape(aws.StringValue(role.Role.AssumeRolePolicyDocument))
+	if err != nil {
+		return errors.Wrapf(err, "decoding trust policy of service role %q", roleARN)
+	}
+	if !strings.Contains(trustPolicy, "eks.amazonaws.com") {
+		return fmt.Errorf("service role %q can't be assumed by eks.amazonaws.com", roleARN)
+	}
+
+	attached := map[string]bool{}
+	err = c.Provider.IAM().ListAttachedRolePoliciesPages(&iam.ListAttachedRolePoliciesInput{RoleName: &name},
+		func(output *iam.ListAttachedRolePoliciesOutput, _ bool) bool {
+			for _, p := range output.AttachedPolicies {
+				attached[aws.StringValue(p.PolicyName)] = true
+			}
+			return true
+		})
+	if err != nil {
+		return errors.Wrapf(err, "listing policies of service role %q", roleARN)
+	}
+	for _, policy := range requiredServiceRolePolicies {
+		if !attached[policy] {
+			return fmt.Errorf("service role %q doesn't have the %s policy attached", roleARN, policy)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package eks
+
+import (
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Existing service role", func() {
+	const roleARN = "arn:aws:iam::123456789012:role/path/eks-service-role"
+
+	var (
+		p *mockprovider.MockProvider
+		c *ClusterProvider
+	)
+
+	mockRole := func(service string, policies ...string) {
+		trustPolicy := `{"Statement":[{"Effect":"Allow","Principal":{"Service":"` + service + `"},"Action":"sts:AssumeRole"}]}`
+		p.MockIAM().On("GetRole", mock.MatchedBy(func(input *iam.GetRoleInput) bool {
+			return *input.RoleName == "eks-service-role"
+		})).Return(&iam.GetRoleOutput{
+			Role: &iam.Role{AssumeRolePolicyDocument: aws.String(url.QueryEscape(trustPolicy))},
+		}, nil)
+		p.MockIAM().On("ListAttachedRolePoliciesPages", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			output := &iam.ListAttachedRolePoliciesOutput{}
+			for _, name := range policies {
+				output.AttachedPolicies = append(output.AttachedPolicies, &iam.AttachedPolicy{PolicyName: aws.String(name)})
+			}
+			args.Get(1).(func(*iam.ListAttachedRolePoliciesOutput, bool) bool)(output, true)
+		}).Return(nil)
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		c = &ClusterProvider{Provider: p}
+	})
+
+	It("accepts roles EKS can assume with the cluster policy", func() {
+		mockRole("eks.amazonaws.com", "AmazonEKSServicePolicy", "AmazonEKSClusterPolicy")
+		Expect(c.CheckServiceRole(roleARN)).To(Succeed())
+	})
+
+	It("rejects roles EKS can't assume", func() {
+		mockRole("ec2.amazonaws.com", "AmazonEKSClusterPolicy")
+		err := c.CheckServiceRole(roleARN)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("can't be assumed by eks.amazonaws.com"))
+	})
+
+	It("rejects roles without the cluster policy", func() {
+		mockRole("eks.amazonaws.com", "AmazonEKSServicePolicy")
+		err := c.CheckServiceRole(roleARN)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("doesn't have the AmazonEKSClusterPolicy policy attached"))
+	})
+})
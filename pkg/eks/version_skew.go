@@ -0,0 +1,56 @@
+package eks
+
+import (
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/ami"
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// MaxKubeletVersionSkew is how many minor versions kubelets can be behind the
+// control plane, as per the version skew policy of Kubernetes
+const MaxKubeletVersionSkew = 2
+
+// CheckVersionSkew returns an error when kubelets of the given version aren't supported
+// by a control plane of the given version, i.e. when they are newer or too old
+func CheckVersionSkew(controlPlaneVersion, kubeletVersion string) error {
+	cp, err := semver.ParseTolerant(controlPlaneVersion)
+	if err != nil {
+		return errors.Wrapf(err, "parsing control plane version %q", controlPlaneVersion)
+	}
+	kubelet, err := semver.ParseTolerant(kubeletVersion)
+	if err != nil {
+		return errors.Wrapf(err, "parsing kubelet version %q", kubeletVersion)
+	}
+
+	if kubelet.Major != cp.Major {
+		return fmt.Errorf("kubelet version %s doesn't have the major version of control plane version %s", kubeletVersion, controlPlaneVersion)
+	}
+	if kubelet.Minor > cp.Minor {
+		return fmt.Errorf("kubelet version %s is newer than control plane version %s", kubeletVersion, controlPlaneVersion)
+	}
+	if cp.Minor-kubelet.Minor > MaxKubeletVersionSkew {
+		return fmt.Errorf("kubelet version %s is more than %d minor versions older than control plane version %s", kubeletVersion, MaxKubeletVersionSkew, controlPlaneVersion)
+	}
+	return nil
+}
+
+// CheckNodeGroupVersionSkew checks the version of the kubelet of the AMI of a nodegroup against
+// the version of the control plane; the version of AMIs that aren't named like those of the image
+// families can't be known, they are assumed to be of the version they were requested for
+func (c *ClusterProvider) CheckNodeGroupVersionSkew(ng *api.NodeGroup, controlPlaneVersion, version string) error {
+	kubeletVersion, err := ami.KubernetesVersionOf(c.Provider.EC2(), ng.AMI)
+	if err != nil {
+		return err
+	}
+	if kubeletVersion == "" {
+		kubeletVersion = version
+	}
+	if err := CheckVersionSkew(controlPlaneVersion, kubeletVersion); err != nil {
+		return errors.Wrapf(err, "AMI %q of nodegroup %q", ng.AMI, ng.Name)
+	}
+	return nil
+}
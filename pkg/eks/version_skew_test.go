@@ -0,0 +1,55 @@
+package eks
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("Version skew", func() {
+	It("allows kubelets up to two minor versions older than the control plane", func() {
+		Expect(CheckVersionSkew("1.13", "1.13")).To(Succeed())
+		Expect(CheckVersionSkew("1.13", "1.11")).To(Succeed())
+		Expect(CheckVersionSkew("1.13", "1.10")).To(MatchError("kubelet version 1.10 is more than 2 minor versions older than control plane version 1.13"))
+	})
+
+	It("rejects kubelets newer than the control plane", func() {
+		Expect(CheckVersionSkew("1.12", "1.13")).To(MatchError("kubelet version 1.13 is newer than control plane version 1.12"))
+	})
+
+	Describe("of nodegroups", func() {
+		var (
+			p *mockprovider.MockProvider
+			c *ClusterProvider
+		)
+
+		BeforeEach(func() {
+			p = mockprovider.NewMockProvider()
+			c = &ClusterProvider{Provider: p}
+		})
+
+		mockImageName := func(name string) {
+			p.MockEC2().On("DescribeImages", mock.Anything).Return(&ec2.DescribeImagesOutput{
+				Images: []*ec2.Image{{ImageId: aws.String("ami-12345"), Name: aws.String(name)}},
+			}, nil)
+		}
+
+		It("uses the version in the name of the AMI", func() {
+			mockImageName("amazon-eks-node-1.13-v20190701")
+			ng := &api.NodeGroup{Name: "ng-1", AMI: "ami-12345"}
+			err := c.CheckNodeGroupVersionSkew(ng, "1.12", "1.12")
+			Expect(err).To(MatchError(`AMI "ami-12345" of nodegroup "ng-1": kubelet version 1.13 is newer than control plane version 1.12`))
+		})
+
+		It("uses the requested version for custom AMIs", func() {
+			mockImageName("my-custom-node-image")
+			ng := &api.NodeGroup{Name: "ng-1", AMI: "ami-12345"}
+			Expect(c.CheckNodeGroupVersionSkew(ng, "1.13", "1.12")).To(Succeed())
+		})
+	})
+})
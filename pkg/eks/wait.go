@@ -0,0 +1,107 @@
+package eks
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/waiters"
+)
+
+const (
+	clusterStatus = "Cluster.Status"
+	updateStatus  = "Update.Status"
+)
+
+func clusterActiveAcceptors() []request.WaiterAcceptor {
+	return waiters.MakeAcceptors(
+		clusterStatus,
+		awseks.ClusterStatusActive,
+		[]string{
+			awseks.ClusterStatusDeleting,
+			awseks.ClusterStatusFailed,
+		},
+		// the cluster doesn't exist until its stack gets to create it,
+		// which is the case when the stack was submitted with --no-wait
+		request.WaiterAcceptor{
+			State:    request.RetryWaiterState,
+			Matcher:  request.ErrorWaiterMatch,
+			Expected: awseks.ErrCodeResourceNotFoundException,
+		},
+	)
+}
+
+func clusterDeletedAcceptors() []request.WaiterAcceptor {
+	return []request.WaiterAcceptor{
+		{
+			State:   request.SuccessWaiterState,
+			Matcher: request.ErrorWaiterMatch,
+			// the argument isn't used to match errors, only
+			// to report the status while the cluster is deleted
+			Argument: clusterStatus,
+			Expected: awseks.ErrCodeResourceNotFoundException,
+		},
+		{
+			State:    request.FailureWaiterState,
+			Matcher:  request.PathAllWaiterMatch,
+			Argument: clusterStatus,
+			Expected: awseks.ClusterStatusFailed,
+		},
+	}
+}
+
+func updateSuccessfulAcceptors() []request.WaiterAcceptor {
+	return waiters.MakeAcceptors(
+		updateStatus,
+		awseks.UpdateStatusSuccessful,
+		[]string{
+			awseks.UpdateStatusCancelled,
+			awseks.UpdateStatusFailed,
+		},
+	)
+}
+
+func (c *ClusterProvider) waitForCluster(cl *api.ClusterMeta, acceptors []request.WaiterAcceptor) error {
+	newRequest := func() *request.Request {
+		input := &awseks.DescribeClusterInput{
+			Name: &cl.Name,
+		}
+		req, _ := c.Provider.EKS().DescribeClusterRequest(input)
+		return req
+	}
+
+	msg := fmt.Sprintf("waiting for control plane %q", cl.Name)
+
+	return waiters.Wait(cl.Name, msg, acceptors, newRequest, c.Provider.WaitTimeout(), c.Provider.PollInterval(), nil)
+}
+
+// WaitForClusterActive blocks until the status of the cluster is ACTIVE, it fails
+// as soon as the cluster is being deleted or has failed
+func (c *ClusterProvider) WaitForClusterActive(cl *api.ClusterMeta) error {
+	return c.waitForCluster(cl, clusterActiveAcceptors())
+}
+
+// WaitForClusterDeleted blocks until the cluster no longer exists, it fails
+// as soon as the cluster has failed
+func (c *ClusterProvider) WaitForClusterDeleted(cl *api.ClusterMeta) error {
+	return c.waitForCluster(cl, clusterDeletedAcceptors())
+}
+
+// WaitForUpdate blocks until the update with the given ID is successful, it fails
+// as soon as the update is cancelled or has failed
+func (c *ClusterProvider) WaitForUpdate(cl *api.ClusterMeta, updateID string) error {
+	newRequest := func() *request.Request {
+		input := &awseks.DescribeUpdateInput{
+			Name:     &cl.Name,
+			UpdateId: &updateID,
+		}
+		req, _ := c.Provider.EKS().DescribeUpdateRequest(input)
+		return req
+	}
+
+	msg := fmt.Sprintf("waiting for update %q of control plane %q", updateID, cl.Name)
+
+	return waiters.Wait(cl.Name, msg, updateSuccessfulAcceptors(), newRequest, c.Provider.WaitTimeout(), c.Provider.PollInterval(), nil)
+}
@@ -0,0 +1,51 @@
+package eks
+
+import (
+	"github.com/aws/aws-sdk-go/aws/request"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cluster waiters", func() {
+	It("waits for the cluster to be active", func() {
+		acceptors := clusterActiveAcceptors()
+		Expect(acceptors[0].State).To(Equal(request.SuccessWaiterState))
+		Expect(acceptors[0].Argument).To(Equal("Cluster.Status"))
+		Expect(acceptors[0].Expected).To(Equal(awseks.ClusterStatusActive))
+
+		failures := []interface{}{}
+		for _, a := range acceptors[1 : len(acceptors)-1] {
+			Expect(a.State).To(Equal(request.FailureWaiterState))
+			failures = append(failures, a.Expected)
+		}
+		Expect(failures).To(ConsistOf(awseks.ClusterStatusDeleting, awseks.ClusterStatusFailed))
+
+		// the cluster may not exist yet when its stack was just submitted
+		retry := acceptors[len(acceptors)-1]
+		Expect(retry.State).To(Equal(request.RetryWaiterState))
+		Expect(retry.Matcher).To(Equal(request.ErrorWaiterMatch))
+		Expect(retry.Expected).To(Equal(awseks.ErrCodeResourceNotFoundException))
+	})
+
+	It("waits for the cluster to be deleted", func() {
+		acceptors := clusterDeletedAcceptors()
+		Expect(acceptors[0].State).To(Equal(request.SuccessWaiterState))
+		Expect(acceptors[0].Matcher).To(Equal(request.ErrorWaiterMatch))
+		Expect(acceptors[0].Expected).To(Equal(awseks.ErrCodeResourceNotFoundException))
+		// the status is still reported while the cluster is being deleted
+		Expect(acceptors[0].Argument).To(Equal("Cluster.Status"))
+
+		Expect(acceptors).To(HaveLen(2))
+		Expect(acceptors[1].State).To(Equal(request.FailureWaiterState))
+		Expect(acceptors[1].Expected).To(Equal(awseks.ClusterStatusFailed))
+	})
+
+	It("waits for the update to be successful", func() {
+		acceptors := updateSuccessfulAcceptors()
+		Expect(acceptors[0].State).To(Equal(request.SuccessWaiterState))
+		Expect(acceptors[0].Argument).To(Equal("Update.Status"))
+		Expect(acceptors[0].Expected).To(Equal(awseks.UpdateStatusSuccessful))
+		Expect(acceptors).To(HaveLen(3))
+	})
+})
@@ -0,0 +1,264 @@
+// Package errors classifies errors of eksctl, each class maps to a distinct
+// exit code, so that scripts and CI pipelines can branch on the class of a
+// failure, e.g. retry throttled commands, instead of matching log messages
+package errors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Class is the class of an error
+type Class int
+
+// Classes of errors
+const (
+	// ClassUnknown is the class of errors that couldn't be classified
+	ClassUnknown Class = iota
+	// ClassValidation is the class of invalid flags, arguments and config files
+	ClassValidation
+	// ClassNotFound is the class of missing clusters, nodegroups, stacks and other resources
+	ClassNotFound
+	// ClassAccessDenied is the class of authentication and authorization failures
+	ClassAccessDenied
+	// ClassThrottled is the class of requests that were rejected due to API rate limits
+	ClassThrottled
+	// ClassTimeout is the class of waits that didn't complete in time
+	ClassTimeout
+	// ClassUpdatesPending is the class of checks that found components to update
+	ClassUpdatesPending
+)
+
+// Exit codes of each class, 0 is success
+const (
+	ExitCodeUnknown      = 1
+	ExitCodeValidation   = 2
+	ExitCodeNotFound     = 3
+	ExitCodeAccessDenied = 4
+	ExitCodeThrottled    = 5
+	ExitCodeTimeout      = 6
+	// ExitCodeUpdatesPending is not a failure of eksctl, it allows scheduled checks to alert
+	ExitCodeUpdatesPending = 7
+)
+
+var classes = map[Class]struct {
+	name     string
+	exitCode int
+}{
+	ClassUnknown:      {"Unknown", ExitCodeUnknown},
+	ClassValidation:   {"ValidationError", ExitCodeValidation},
+	ClassNotFound:     {"NotFound", ExitCodeNotFound},
+	ClassAccessDenied: {"AccessDenied", ExitCodeAccessDenied},
+	ClassThrottled:    {"Throttled", ExitCodeThrottled},
+	ClassTimeout:      {"Timeout", ExitCodeTimeout},
+
+	ClassUpdatesPending: {"UpdatesPending", ExitCodeUpdatesPending},
+}
+
+func (c Class) String() string {
+	return classes[c].name
+}
+
+// ExitCode returns the exit code of the class
+func (c Class) ExitCode() int {
+	if code, ok := classes[c]; ok {
+		return code.exitCode
+	}
+	return ExitCodeUnknown
+}
+
+// Error is an error of a known class, its cause is kept so that
+// github.com/pkg/errors.Cause returns the original error
+type Error struct {
+	class   Class
+	message string
+	cause   error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.cause == nil:
+		return e.message
+	case e.message == "":
+		return e.cause.Error()
+	default:
+		return e.message + ": " + e.cause.Error()
+	}
+}
+
+// Cause returns the underlying error, if any
+func (e *Error) Cause() error { return e.cause }
+
+// Class returns the class of the error
+func (e *Error) Class() Class { return e.class }
+
+// New returns an error of the given class
+func New(class Class, format string, args ...interface{}) error {
+	return &Error{class: class, message: fmt.Sprintf(format, args...)}
+}
+
+// NewValidationError returns an error of invalid input
+func NewValidationError(format string, args ...interface{}) error {
+	return New(ClassValidation, format, args...)
+}
+
+// NewNotFound returns an error of a missing resource
+func NewNotFound(format string, args ...interface{}) error {
+	return New(ClassNotFound, format, args...)
+}
+
+// NewAccessDenied returns an error of missing permissions
+func NewAccessDenied(format string, args ...interface{}) error {
+	return New(ClassAccessDenied, format, args...)
+}
+
+// NewThrottled returns an error of a rate limit
+func NewThrottled(format string, args ...interface{}) error {
+	return New(ClassThrottled, format, args...)
+}
+
+// NewTimeout returns an error of a wait that didn't complete in time
+func NewTimeout(format string, args ...interface{}) error {
+	return New(ClassTimeout, format, args...)
+}
+
+// Wrap returns err annotated with message as an error of the given class,
+// it returns nil when err is nil
+func Wrap(class Class, err error, message string) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{class: class, message: message, cause: err}
+}
+
+// Wrapf is like Wrap with a formatted message
+func Wrapf(class Class, err error, format string, args ...interface{}) error {
+	return Wrap(class, err, fmt.Sprintf(format, args...))
+}
+
+// WithClass returns err as an error of the given class, unless its class is
+// already known, the message of err is kept as is; it returns nil when err is nil
+func WithClass(class Class, err error) error {
+	if err == nil || ClassOf(err) != ClassUnknown {
+		return err
+	}
+	return &Error{class: class, cause: err}
+}
+
+// ClassOf returns the class of err, it looks at errors of this package, AWS API
+// errors and Kubernetes API errors along the chain of causes of err
+func ClassOf(err error) Class {
+	for err != nil {
+		if e, ok := err.(*Error); ok {
+			return e.class
+		}
+		if class := classOfAWSError(err); class != ClassUnknown {
+			return class
+		}
+		if class := classOfKubernetesError(err); class != ClassUnknown {
+			return class
+		}
+		if err == context.DeadlineExceeded {
+			return ClassTimeout
+		}
+
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return ClassUnknown
+}
+
+// ExitCode returns the exit code of the class of err, 0 when err is nil
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return ClassOf(err).ExitCode()
+}
+
+var awsErrorCodes = map[string]Class{
+	"ValidationError":             ClassValidation,
+	"ValidationException":         ClassValidation,
+	"InvalidParameterException":   ClassValidation,
+	"InvalidParameterValue":       ClassValidation,
+	"InvalidParameterCombination": ClassValidation,
+	"InvalidRequestException":     ClassValidation,
+	"MalformedPolicyDocument":     ClassValidation,
+
+	"ResourceNotFoundException": ClassNotFound,
+	"NoSuchEntity":              ClassNotFound,
+	"NoSuchBucket":              ClassNotFound,
+	"NoSuchKey":                 ClassNotFound,
+
+	"AccessDenied":                ClassAccessDenied,
+	"AccessDeniedException":       ClassAccessDenied,
+	"UnauthorizedOperation":       ClassAccessDenied,
+	"UnrecognizedClientException": ClassAccessDenied,
+	"InvalidClientTokenId":        ClassAccessDenied,
+	"ExpiredToken":                ClassAccessDenied,
+	"ExpiredTokenException":       ClassAccessDenied,
+	"AuthFailure":                 ClassAccessDenied,
+	"SignatureDoesNotMatch":       ClassAccessDenied,
+
+	"Throttling":                             ClassThrottled,
+	"ThrottlingException":                    ClassThrottled,
+	"ThrottledException":                     ClassThrottled,
+	"RequestThrottled":                       ClassThrottled,
+	"RequestThrottledException":              ClassThrottled,
+	"RequestLimitExceeded":                   ClassThrottled,
+	"TooManyRequestsException":               ClassThrottled,
+	"ProvisionedThroughputExceededException": ClassThrottled,
+	"SlowDown":                               ClassThrottled,
+	"EC2ThrottledException":                  ClassThrottled,
+}
+
+func classOfAWSError(err error) Class {
+	awsErr, ok := err.(awserr.Error)
+	if !ok {
+		return ClassUnknown
+	}
+	code := awsErr.Code()
+
+	// CloudFormation reports missing stacks as validation errors
+	if code == "ValidationError" && strings.Contains(awsErr.Message(), "does not exist") {
+		return ClassNotFound
+	}
+	// waiters are canceled by their context when they time out
+	if code == request.CanceledErrorCode && awsErr.OrigErr() == context.DeadlineExceeded {
+		return ClassTimeout
+	}
+	if class, ok := awsErrorCodes[code]; ok {
+		return class
+	}
+	if strings.HasSuffix(code, "NotFound") || strings.HasSuffix(code, "NotFoundException") {
+		return ClassNotFound
+	}
+	return ClassUnknown
+}
+
+func classOfKubernetesError(err error) Class {
+	if _, ok := err.(apierrors.APIStatus); !ok {
+		return ClassUnknown
+	}
+	switch {
+	case apierrors.IsNotFound(err):
+		return ClassNotFound
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return ClassAccessDenied
+	case apierrors.IsTooManyRequests(err):
+		return ClassThrottled
+	case apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return ClassTimeout
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return ClassValidation
+	}
+	return ClassUnknown
+}
@@ -0,0 +1,92 @@
+package errors_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	. "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+var _ = Describe("Errors", func() {
+	It("keeps the message and the cause of wrapped errors", func() {
+		cause := fmt.Errorf("boom")
+		err := Wrap(ClassTimeout, cause, "waiting")
+
+		Expect(err.Error()).To(Equal("waiting: boom"))
+		Expect(errors.Cause(err)).To(Equal(cause))
+		Expect(Wrap(ClassTimeout, nil, "waiting")).To(BeNil())
+	})
+
+	It("finds the class along the chain of causes", func() {
+		err := errors.Wrap(errors.Wrap(NewNotFound("cluster %q not found", "test"), "describing"), "deleting")
+
+		Expect(ClassOf(err)).To(Equal(ClassNotFound))
+		Expect(ExitCode(err)).To(Equal(ExitCodeNotFound))
+	})
+
+	It("doesn't override a known class", func() {
+		err := WithClass(ClassValidation, NewThrottled("slow down"))
+		Expect(ClassOf(err)).To(Equal(ClassThrottled))
+
+		err = WithClass(ClassValidation, fmt.Errorf("invalid"))
+		Expect(ClassOf(err)).To(Equal(ClassValidation))
+		Expect(err.Error()).To(Equal("invalid"))
+	})
+
+	It("returns exit code 0 for no error and 1 for unknown errors", func() {
+		Expect(ExitCode(nil)).To(Equal(0))
+		Expect(ExitCode(fmt.Errorf("unknown"))).To(Equal(ExitCodeUnknown))
+	})
+
+	It("returns a distinct exit code for pending updates", func() {
+		err := New(ClassUpdatesPending, "%d update(s) pending", 2)
+		Expect(ExitCode(err)).To(Equal(ExitCodeUpdatesPending))
+		Expect(ClassOf(err).String()).To(Equal("UpdatesPending"))
+	})
+
+	DescribeTable("classifies AWS API errors",
+		func(code, message string, expected Class) {
+			err := errors.Wrap(awserr.New(code, message, nil), "calling AWS API")
+			Expect(ClassOf(err)).To(Equal(expected))
+		},
+		Entry("missing stack", "ValidationError", "Stack with id eksctl-test-cluster does not exist", ClassNotFound),
+		Entry("invalid template", "ValidationError", "Template format error", ClassValidation),
+		Entry("missing cluster", "ResourceNotFoundException", "No cluster found for name: test.", ClassNotFound),
+		Entry("missing VPC", "InvalidVpcID.NotFound", "The vpc ID 'vpc-1' does not exist", ClassNotFound),
+		Entry("missing role", "NoSuchEntity", "The role with name test cannot be found.", ClassNotFound),
+		Entry("missing permissions", "AccessDenied", "User is not authorized", ClassAccessDenied),
+		Entry("EC2 missing permissions", "UnauthorizedOperation", "You are not authorized", ClassAccessDenied),
+		Entry("expired token", "ExpiredToken", "The security token included in the request is expired", ClassAccessDenied),
+		Entry("CloudFormation rate limit", "Throttling", "Rate exceeded", ClassThrottled),
+		Entry("EC2 rate limit", "RequestLimitExceeded", "Request limit exceeded.", ClassThrottled),
+		Entry("other", "InternalFailure", "", ClassUnknown),
+	)
+
+	It("classifies canceled AWS API requests as timeouts", func() {
+		err := awserr.New(request.CanceledErrorCode, "waiter context canceled", context.DeadlineExceeded)
+		Expect(ClassOf(err)).To(Equal(ClassTimeout))
+		Expect(ExitCode(context.DeadlineExceeded)).To(Equal(ExitCodeTimeout))
+	})
+
+	DescribeTable("classifies Kubernetes API errors",
+		func(err error, expected Class) {
+			Expect(ClassOf(errors.Wrap(err, "calling Kubernetes API"))).To(Equal(expected))
+		},
+		Entry("not found", apierrors.NewNotFound(schema.GroupResource{Resource: "nodes"}, "node-1"), ClassNotFound),
+		Entry("forbidden", apierrors.NewForbidden(schema.GroupResource{Resource: "nodes"}, "node-1", fmt.Errorf("denied")), ClassAccessDenied),
+		Entry("unauthorized", apierrors.NewUnauthorized("unauthorized"), ClassAccessDenied),
+		Entry("too many requests", apierrors.NewTooManyRequests("slow down", 1), ClassThrottled),
+		Entry("timeout", apierrors.NewTimeoutError("timeout", 1), ClassTimeout),
+		Entry("bad request", apierrors.NewBadRequest("bad"), ClassValidation),
+		Entry("conflict", apierrors.NewConflict(schema.GroupResource{Resource: "nodes"}, "node-1", fmt.Errorf("conflict")), ClassUnknown),
+	)
+})
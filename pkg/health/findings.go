@@ -0,0 +1,188 @@
+package health
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// lowFreeAddresses is the number of free IP addresses below which a subnet
+	// is considered exhausted, as every pod on a node takes one
+	lowFreeAddresses = 16
+
+	// joinGracePeriod is how long an instance may run before it's expected
+	// to have registered as a node
+	joinGracePeriod = 10 * time.Minute
+
+	addressExhaustionSuggestion = "the VPC CNI assigns an IP address from the subnet to every pod; use larger subnets, " +
+		"spread the nodegroup across more availability zones or lower maxPodsPerNode"
+)
+
+// Finding is a problem detected in a nodegroup, with a suggested fix
+type Finding struct {
+	Problem    string
+	Suggestion string
+}
+
+func (r *NodeGroupReport) addFinding(suggestion, format string, a ...interface{}) {
+	r.Findings = append(r.Findings, Finding{
+		Problem:    fmt.Sprintf(format, a...),
+		Suggestion: suggestion,
+	})
+}
+
+// analyse correlates the collected data and records findings for common failures
+func (r *NodeGroupReport) analyse() {
+	if r.AutoScalingGroup == "" {
+		r.addFinding("check the failed stack events, or run 'eksctl utils describe-stacks --events'",
+			"auto scaling group of nodegroup %q was not found in its stack", r.NodeGroup)
+	}
+
+	for _, e := range r.StackEvents {
+		r.addFinding("fix the cause, then delete and re-create the nodegroup",
+			"stack resource %s is %s: %s", aws.StringValue(e.LogicalResourceId), aws.StringValue(e.ResourceStatus), aws.StringValue(e.ResourceStatusReason))
+	}
+
+	r.analyseActivities()
+
+	for _, s := range r.Subnets {
+		if s.FreeAddresses < lowFreeAddresses {
+			r.addFinding(addressExhaustionSuggestion,
+				"subnet %s (%s) has only %d free IP addresses", s.ID, s.AvailabilityZone, s.FreeAddresses)
+		}
+	}
+
+	r.analyseInstances()
+
+	for _, n := range r.Nodes {
+		if n.Ready {
+			continue
+		}
+		if isNetworkNotReady(n.Message) {
+			r.addFinding("the aws-node pod on the node couldn't start; check that nodes can pull images from ECR and reach "+
+				"the EC2 API through a NAT gateway or VPC endpoints, and run 'kubectl -n kube-system describe pods -l k8s-app=aws-node'",
+				"node %q is not ready, as its network plugin is not ready", n.Name)
+			continue
+		}
+		r.addFinding(fmt.Sprintf("run 'kubectl describe node %s'", n.Name),
+			"node %q is not ready: %s", n.Name, n.Message)
+	}
+}
+
+func (r *NodeGroupReport) analyseActivities() {
+	seen := sets.NewString()
+	for _, a := range r.Activities {
+		status := aws.StringValue(a.StatusCode)
+		if status != autoscaling.ScalingActivityStatusCodeFailed && status != autoscaling.ScalingActivityStatusCodeCancelled {
+			continue
+		}
+		message := aws.StringValue(a.StatusMessage)
+		if seen.Has(message) {
+			continue
+		}
+		seen.Insert(message)
+
+		if isAddressExhaustion(message) {
+			r.addFinding(addressExhaustionSuggestion,
+				"instances could not be launched, as the subnets ran out of IP addresses: %s", message)
+			continue
+		}
+		r.addFinding("check the activity history of the auto scaling group",
+			"scaling activity failed: %s", message)
+	}
+}
+
+func (r *NodeGroupReport) analyseInstances() {
+	notJoined := []string{}
+	for _, i := range r.Instances {
+		if i.InstanceStatus == ec2.SummaryStatusImpaired || i.SystemStatus == ec2.SummaryStatusImpaired {
+			r.addFinding("terminate the instance, the auto scaling group will replace it",
+				"instance %s failed EC2 status checks (instance: %s, system: %s)", i.ID, i.InstanceStatus, i.SystemStatus)
+		}
+		if i.State == ec2.InstanceStateNameRunning && i.NodeName == "" && r.CollectedAt.Sub(i.LaunchTime) > joinGracePeriod {
+			notJoined = append(notJoined, i.ID)
+		}
+	}
+	if len(notJoined) == 0 {
+		return
+	}
+
+	if r.RoleMapped != nil && !*r.RoleMapped {
+		r.addFinding(fmt.Sprintf("map the role with 'eksctl create iamidentitymapping --role=%s --username=system:node:{{EC2PrivateDNSName}} --group=system:bootstrappers --group=system:nodes'", r.InstanceRoleARN),
+			"instances %s have not joined the cluster, and instance role %s is not mapped in the aws-auth ConfigMap", strings.Join(notJoined, ", "), r.InstanceRoleARN)
+		return
+	}
+	r.addFinding("nodes need egress to the cluster endpoint, EC2 and ECR; check the NAT gateway and route tables of private subnets, "+
+		"the security groups of the nodegroup, and 'journalctl -u kubelet' on the instances",
+		"instances %s have been running for more than %s without joining the cluster", strings.Join(notJoined, ", "), joinGracePeriod)
+}
+
+func isAddressExhaustion(message string) bool {
+	return strings.Contains(message, "InsufficientFreeAddressesInSubnet") ||
+		strings.Contains(message, "not enough free addresses")
+}
+
+func isNetworkNotReady(message string) bool {
+	return strings.Contains(message, "NetworkPluginNotReady") ||
+		strings.Contains(message, "cni config uninitialized")
+}
+
+// Write prints the report
+func (r *NodeGroupReport) Write(w io.Writer) error {
+	fmt.Fprintf(w, "nodegroup %q\n", r.NodeGroup)
+	if r.AutoScalingGroup != "" {
+		fmt.Fprintf(w, "auto scaling group %q, desired capacity %d\n", r.AutoScalingGroup, r.DesiredCapacity)
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	if len(r.Instances) > 0 {
+		fmt.Fprintf(tw, "\nINSTANCE\tSTATE\tINSTANCE STATUS\tSYSTEM STATUS\tNODE\n")
+		for _, i := range r.Instances {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", i.ID, i.State, orDash(i.InstanceStatus), orDash(i.SystemStatus), orDash(i.NodeName))
+		}
+	}
+	if len(r.Nodes) > 0 {
+		fmt.Fprintf(tw, "\nNODE\tINSTANCE\tREADY\tREASON\n")
+		for _, n := range r.Nodes {
+			fmt.Fprintf(tw, "%s\t%s\t%t\t%s\n", n.Name, orDash(n.InstanceID), n.Ready, orDash(n.Reason))
+		}
+	}
+	if len(r.Subnets) > 0 {
+		fmt.Fprintf(tw, "\nSUBNET\tAVAILABILITY ZONE\tFREE IP ADDRESSES\n")
+		for _, s := range r.Subnets {
+			fmt.Fprintf(tw, "%s\t%s\t%d\n", s.ID, s.AvailabilityZone, s.FreeAddresses)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(r.Findings) == 0 {
+		fmt.Fprintf(w, "\nno problems found\n")
+	} else {
+		fmt.Fprintf(w, "\nproblems found:\n")
+		for _, f := range r.Findings {
+			fmt.Fprintf(w, "- %s\n  suggestion: %s\n", f.Problem, f.Suggestion)
+		}
+	}
+	for _, u := range r.Unavailable {
+		fmt.Fprintf(w, "could not check %s\n", u)
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
@@ -0,0 +1,121 @@
+package health
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Nodegroup health", func() {
+	var (
+		now    time.Time
+		report *NodeGroupReport
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		report = &NodeGroupReport{
+			NodeGroup:        "ng-1",
+			AutoScalingGroup: "eksctl-test-nodegroup-ng-1-NodeGroup-ABC",
+			DesiredCapacity:  2,
+			InstanceRoleARN:  "arn:aws:iam::123:role/eksctl-test-nodegroup-ng-1-NodeInstanceRole-ABC",
+			RoleMapped:       aws.Bool(true),
+			CollectedAt:      now,
+			Subnets: []Subnet{
+				{ID: "subnet-1", AvailabilityZone: "us-west-2a", FreeAddresses: 4000},
+			},
+		}
+	})
+
+	It("should extract instance IDs from provider IDs", func() {
+		Expect(instanceIDFromProviderID("aws:///us-west-2a/i-0123456789abcdef0")).To(Equal("i-0123456789abcdef0"))
+		Expect(instanceIDFromProviderID("")).To(BeEmpty())
+	})
+
+	It("should not report problems for a healthy nodegroup", func() {
+		report.Instances = []Instance{
+			{ID: "i-1", State: "running", LaunchTime: now.Add(-time.Hour), InstanceStatus: "ok", SystemStatus: "ok", NodeName: "ip-1"},
+		}
+		report.Nodes = []Node{{Name: "ip-1", InstanceID: "i-1", Ready: true}}
+
+		report.analyse()
+		Expect(report.Findings).To(BeEmpty())
+
+		buf := &bytes.Buffer{}
+		Expect(report.Write(buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("no problems found"))
+	})
+
+	It("should report instances that didn't join because of a missing aws-auth mapping", func() {
+		report.RoleMapped = aws.Bool(false)
+		report.Instances = []Instance{
+			{ID: "i-1", State: "running", LaunchTime: now.Add(-time.Hour), InstanceStatus: "ok", SystemStatus: "ok"},
+			{ID: "i-2", State: "running", LaunchTime: now.Add(-time.Minute)},
+		}
+
+		report.analyse()
+		Expect(report.Findings).To(HaveLen(1))
+		Expect(report.Findings[0].Problem).To(ContainSubstring("instances i-1 have not joined the cluster"))
+		Expect(report.Findings[0].Suggestion).To(ContainSubstring("eksctl create iamidentitymapping --role=" + report.InstanceRoleARN))
+	})
+
+	It("should suggest checking egress when the role is mapped but instances didn't join", func() {
+		report.Instances = []Instance{
+			{ID: "i-1", State: "running", LaunchTime: now.Add(-time.Hour)},
+		}
+
+		report.analyse()
+		Expect(report.Findings).To(HaveLen(1))
+		Expect(report.Findings[0].Suggestion).To(ContainSubstring("NAT gateway"))
+	})
+
+	It("should report subnet IP address exhaustion", func() {
+		report.Subnets = append(report.Subnets, Subnet{ID: "subnet-2", AvailabilityZone: "us-west-2b", FreeAddresses: 3})
+		report.Activities = []*autoscaling.Activity{
+			{
+				StatusCode:    aws.String(autoscaling.ScalingActivityStatusCodeFailed),
+				StatusMessage: aws.String("There are not enough free addresses in subnet 'subnet-2' to satisfy the requested number of instances."),
+			},
+			{
+				StatusCode:    aws.String(autoscaling.ScalingActivityStatusCodeFailed),
+				StatusMessage: aws.String("There are not enough free addresses in subnet 'subnet-2' to satisfy the requested number of instances."),
+			},
+			{
+				StatusCode: aws.String(autoscaling.ScalingActivityStatusCodeSuccessful),
+			},
+		}
+
+		report.analyse()
+		Expect(report.Findings).To(HaveLen(2))
+		Expect(report.Findings[0].Problem).To(ContainSubstring("ran out of IP addresses"))
+		Expect(report.Findings[1].Problem).To(Equal("subnet subnet-2 (us-west-2b) has only 3 free IP addresses"))
+	})
+
+	It("should report impaired instances, not ready nodes and failed stack resources", func() {
+		report.StackEvents = []*cfn.StackEvent{{
+			LogicalResourceId:    aws.String("NodeGroupLaunchTemplate"),
+			ResourceStatus:       aws.String(cfn.ResourceStatusCreateFailed),
+			ResourceStatusReason: aws.String("invalid AMI"),
+		}}
+		report.Instances = []Instance{
+			{ID: "i-1", State: "running", LaunchTime: now.Add(-time.Hour), InstanceStatus: "impaired", SystemStatus: "ok", NodeName: "ip-1"},
+		}
+		report.Nodes = []Node{{
+			Name:    "ip-1",
+			Ready:   false,
+			Message: "runtime network not ready: NetworkReady=false reason:NetworkPluginNotReady message:docker: network plugin is not ready: cni config uninitialized",
+		}}
+
+		report.analyse()
+		Expect(report.Findings).To(HaveLen(3))
+		Expect(report.Findings[0].Problem).To(Equal("stack resource NodeGroupLaunchTemplate is CREATE_FAILED: invalid AMI"))
+		Expect(report.Findings[1].Problem).To(ContainSubstring("instance i-1 failed EC2 status checks"))
+		Expect(report.Findings[2].Problem).To(ContainSubstring("network plugin is not ready"))
+		Expect(report.Findings[2].Suggestion).To(ContainSubstring("ECR"))
+	})
+})
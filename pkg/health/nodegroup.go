@@ -0,0 +1,325 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/authconfigmap"
+	"github.com/weaveworks/eksctl/pkg/cfn/manager"
+	"github.com/weaveworks/eksctl/pkg/iam"
+)
+
+const (
+	// AutoScalingGroupResource is the logical ID of the ASG in nodegroup stacks
+	AutoScalingGroupResource = "NodeGroup"
+
+	// maxActivities is the number of most recent scaling activities to look at
+	maxActivities = 20
+)
+
+// Instance holds the state of an instance of the nodegroup, and the
+// node it registered as, if any
+type Instance struct {
+	ID             string
+	State          string
+	LaunchTime     time.Time
+	InstanceStatus string
+	SystemStatus   string
+	NodeName       string
+}
+
+// Node holds the state of a Kubernetes node of the nodegroup
+type Node struct {
+	Name       string
+	InstanceID string
+	Ready      bool
+	Reason     string
+	Message    string
+}
+
+// Subnet holds the free capacity of a subnet used by the nodegroup
+type Subnet struct {
+	ID               string
+	AvailabilityZone string
+	FreeAddresses    int64
+}
+
+// NodeGroupReport holds all information collected about a nodegroup
+type NodeGroupReport struct {
+	NodeGroup        string
+	AutoScalingGroup string
+	DesiredCapacity  int64
+	InstanceRoleARN  string
+	// RoleMapped is nil when the aws-auth ConfigMap couldn't be checked
+	RoleMapped *bool
+
+	Activities  []*autoscaling.Activity
+	StackEvents []*cfn.StackEvent
+	Instances   []Instance
+	Nodes       []Node
+	Subnets     []Subnet
+	CollectedAt time.Time
+
+	// Findings are the problems detected, with suggested fixes
+	Findings []Finding
+	// Unavailable lists the sources that couldn't be checked
+	Unavailable []string
+}
+
+// Checker collects diagnostics about nodegroups from the Auto Scaling,
+// EC2, CloudFormation and Kubernetes APIs
+type Checker struct {
+	provider     api.ClusterProvider
+	stackManager *manager.StackCollection
+	clientSet    kubernetes.Interface
+}
+
+// NewChecker creates a new Checker
+func NewChecker(provider api.ClusterProvider, stackManager *manager.StackCollection, clientSet kubernetes.Interface) *Checker {
+	return &Checker{
+		provider:     provider,
+		stackManager: stackManager,
+		clientSet:    clientSet,
+	}
+}
+
+// CheckNodeGroup collects the state of the nodegroup from its stack and
+// looks for common failures; errors from individual sources don't stop
+// the check, they are recorded in the report instead
+func (c *Checker) CheckNodeGroup(name string, info manager.StackInfo) (*NodeGroupReport, error) {
+	report := &NodeGroupReport{
+		NodeGroup:   name,
+		CollectedAt: time.Now(),
+	}
+
+	for _, r := range info.Resources {
+		if *r.LogicalResourceId == AutoScalingGroupResource && r.PhysicalResourceId != nil {
+			report.AutoScalingGroup = *r.PhysicalResourceId
+		}
+	}
+
+	events, err := c.stackManager.DescribeStackEvents(info.Stack)
+	if err != nil {
+		report.unavailable("CloudFormation events", err)
+	}
+	for _, e := range events {
+		if e.ResourceStatus != nil && strings.HasSuffix(*e.ResourceStatus, "_FAILED") {
+			report.StackEvents = append(report.StackEvents, e)
+		}
+	}
+
+	if report.AutoScalingGroup == "" {
+		report.analyse()
+		return report, nil
+	}
+
+	subnetIDs, err := c.collectAutoScalingGroup(report)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.collectInstances(report); err != nil {
+		report.unavailable("EC2 instances", err)
+	}
+
+	if err := c.collectSubnets(report, subnetIDs); err != nil {
+		report.unavailable("EC2 subnets", err)
+	}
+
+	if err := c.collectNodes(report); err != nil {
+		report.unavailable("Kubernetes nodes", err)
+	}
+
+	ng := &api.NodeGroup{Name: name}
+	if err := iam.UseFromNodeGroup(c.provider, info.Stack, ng); err != nil {
+		report.unavailable("instance role", err)
+	} else {
+		report.InstanceRoleARN = ng.IAM.InstanceRoleARN
+		if err := c.collectRoleMapping(report); err != nil {
+			report.unavailable("aws-auth ConfigMap", err)
+		}
+	}
+
+	report.analyse()
+	return report, nil
+}
+
+func (r *NodeGroupReport) unavailable(source string, err error) {
+	logger.Debug("unable to collect %s for nodegroup %q: %s", source, r.NodeGroup, err.Error())
+	r.Unavailable = append(r.Unavailable, fmt.Sprintf("%s: %s", source, err.Error()))
+}
+
+// collectAutoScalingGroup fetches the ASG with its instances and recent scaling
+// activities, and returns the IDs of the subnets it launches instances in
+func (c *Checker) collectAutoScalingGroup(report *NodeGroupReport) ([]string, error) {
+	groups, err := c.provider.ASG().DescribeAutoScalingGroups(&autoscaling.DescribeAutoScalingGroupsInput{
+		AutoScalingGroupNames: []*string{aws.String(report.AutoScalingGroup)},
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "describing auto scaling group %q", report.AutoScalingGroup)
+	}
+	if len(groups.AutoScalingGroups) == 0 {
+		return nil, fmt.Errorf("auto scaling group %q of nodegroup %q not found", report.AutoScalingGroup, report.NodeGroup)
+	}
+	group := groups.AutoScalingGroups[0]
+
+	report.DesiredCapacity = aws.Int64Value(group.DesiredCapacity)
+	for _, i := range group.Instances {
+		report.Instances = append(report.Instances, Instance{
+			ID:    *i.InstanceId,
+			State: aws.StringValue(i.LifecycleState),
+		})
+	}
+
+	activities, err := c.provider.ASG().DescribeScalingActivities(&autoscaling.DescribeScalingActivitiesInput{
+		AutoScalingGroupName: aws.String(report.AutoScalingGroup),
+		MaxRecords:           aws.Int64(maxActivities),
+	})
+	if err != nil {
+		report.unavailable("scaling activities", err)
+	} else {
+		report.Activities = activities.Activities
+	}
+
+	subnetIDs := []string{}
+	for _, id := range strings.Split(aws.StringValue(group.VPCZoneIdentifier), ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			subnetIDs = append(subnetIDs, id)
+		}
+	}
+	return subnetIDs, nil
+}
+
+func (c *Checker) collectInstances(report *NodeGroupReport) error {
+	if len(report.Instances) == 0 {
+		return nil
+	}
+
+	ids := []*string{}
+	index := map[string]*Instance{}
+	for i := range report.Instances {
+		ids = append(ids, aws.String(report.Instances[i].ID))
+		index[report.Instances[i].ID] = &report.Instances[i]
+	}
+
+	instances, err := c.provider.EC2().DescribeInstances(&ec2.DescribeInstancesInput{
+		InstanceIds: ids,
+	})
+	if err != nil {
+		return errors.Wrap(err, "describing instances")
+	}
+	for _, reservation := range instances.Reservations {
+		for _, i := range reservation.Instances {
+			if instance, ok := index[*i.InstanceId]; ok {
+				if i.State != nil {
+					instance.State = aws.StringValue(i.State.Name)
+				}
+				instance.LaunchTime = aws.TimeValue(i.LaunchTime)
+			}
+		}
+	}
+
+	statuses, err := c.provider.EC2().DescribeInstanceStatus(&ec2.DescribeInstanceStatusInput{
+		InstanceIds:         ids,
+		IncludeAllInstances: aws.Bool(true),
+	})
+	if err != nil {
+		return errors.Wrap(err, "describing instance status")
+	}
+	for _, s := range statuses.InstanceStatuses {
+		if instance, ok := index[*s.InstanceId]; ok {
+			if s.InstanceStatus != nil {
+				instance.InstanceStatus = aws.StringValue(s.InstanceStatus.Status)
+			}
+			if s.SystemStatus != nil {
+				instance.SystemStatus = aws.StringValue(s.SystemStatus.Status)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Checker) collectSubnets(report *NodeGroupReport, subnetIDs []string) error {
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+	subnets, err := c.provider.EC2().DescribeSubnets(&ec2.DescribeSubnetsInput{
+		SubnetIds: aws.StringSlice(subnetIDs),
+	})
+	if err != nil {
+		return errors.Wrap(err, "describing subnets")
+	}
+	for _, s := range subnets.Subnets {
+		report.Subnets = append(report.Subnets, Subnet{
+			ID:               *s.SubnetId,
+			AvailabilityZone: aws.StringValue(s.AvailabilityZone),
+			FreeAddresses:    aws.Int64Value(s.AvailableIpAddressCount),
+		})
+	}
+	return nil
+}
+
+func (c *Checker) collectNodes(report *NodeGroupReport) error {
+	ng := &api.NodeGroup{Name: report.NodeGroup}
+	nodes, err := c.clientSet.CoreV1().Nodes().List(ng.ListOptions())
+	if err != nil {
+		return errors.Wrap(err, "listing nodes")
+	}
+
+	index := map[string]*Instance{}
+	for i := range report.Instances {
+		index[report.Instances[i].ID] = &report.Instances[i]
+	}
+
+	for _, n := range nodes.Items {
+		node := Node{
+			Name:       n.Name,
+			InstanceID: instanceIDFromProviderID(n.Spec.ProviderID),
+		}
+		for _, condition := range n.Status.Conditions {
+			if condition.Type == corev1.NodeReady {
+				node.Ready = condition.Status == corev1.ConditionTrue
+				node.Reason = condition.Reason
+				node.Message = condition.Message
+			}
+		}
+		if instance, ok := index[node.InstanceID]; ok {
+			instance.NodeName = node.Name
+		}
+		report.Nodes = append(report.Nodes, node)
+	}
+	return nil
+}
+
+func (c *Checker) collectRoleMapping(report *NodeGroupReport) error {
+	acm, err := authconfigmap.NewFromClientSet(c.clientSet)
+	if err != nil {
+		return err
+	}
+	roles, err := acm.Roles()
+	if err != nil {
+		return err
+	}
+	report.RoleMapped = aws.Bool(len(roles.Get(report.InstanceRoleARN)) > 0)
+	return nil
+}
+
+// instanceIDFromProviderID extracts the instance ID from a provider ID
+// of the form "aws:///<availability-zone>/<instance-id>"
+func instanceIDFromProviderID(providerID string) string {
+	if !strings.HasPrefix(providerID, "aws://") {
+		return ""
+	}
+	return providerID[strings.LastIndex(providerID, "/")+1:]
+}
@@ -0,0 +1,164 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// Event is the point of the operations on a cluster hooks run at
+type Event string
+
+// Events hooks can run at, named as in the config file
+const (
+	PreCreate           Event = "preCreate"
+	PostCreate          Event = "postCreate"
+	PreDelete           Event = "preDelete"
+	PostNodeGroupCreate Event = "postNodegroupCreate"
+)
+
+// DefaultTimeout is how long hooks may run when timeoutSeconds isn't set
+const DefaultTimeout = 5 * time.Minute
+
+// Payload is the metadata of the cluster passed to hooks, as JSON to webhooks
+// and in EKSCTL_HOOK_PAYLOAD to commands
+type Payload struct {
+	Event     Event             `json:"event"`
+	Cluster   string            `json:"cluster"`
+	Region    string            `json:"region"`
+	Version   string            `json:"version,omitempty"`
+	Endpoint  string            `json:"endpoint,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	NodeGroup string            `json:"nodeGroup,omitempty"`
+}
+
+// NewPayload returns the payload of the hooks of event, nodeGroup is only set
+// for postNodegroupCreate hooks
+func NewPayload(spec *api.ClusterConfig, event Event, nodeGroup string) Payload {
+	p := Payload{
+		Event:     event,
+		Cluster:   spec.Metadata.Name,
+		Region:    spec.Metadata.Region,
+		Version:   spec.Metadata.Version,
+		Tags:      spec.Metadata.Tags,
+		NodeGroup: nodeGroup,
+	}
+	if spec.Status != nil {
+		p.Endpoint = spec.Status.Endpoint
+	}
+	return p
+}
+
+// Env returns the environment variables commands are run with, in addition
+// to the environment of eksctl
+func (p Payload) Env() ([]string, error) {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return []string{
+		"EKSCTL_HOOK_EVENT=" + string(p.Event),
+		"EKSCTL_HOOK_PAYLOAD=" + string(data),
+		"EKSCTL_CLUSTER_NAME=" + p.Cluster,
+		"EKSCTL_CLUSTER_REGION=" + p.Region,
+		"EKSCTL_CLUSTER_VERSION=" + p.Version,
+		"EKSCTL_CLUSTER_ENDPOINT=" + p.Endpoint,
+		"EKSCTL_NODEGROUP_NAME=" + p.NodeGroup,
+	}, nil
+}
+
+// Run runs the hooks of spec for event in order, and stops at the first that fails
+func Run(spec *api.ClusterConfig, event Event, nodeGroup string) error {
+	hooks := forEvent(spec.Hooks, event)
+	if len(hooks) == 0 {
+		return nil
+	}
+	payload := NewPayload(spec, event, nodeGroup)
+	for i, hook := range hooks {
+		logger.Info("running %s hook %d of %d", event, i+1, len(hooks))
+		if err := runHook(hook, payload); err != nil {
+			return errors.Wrapf(err, "%s hook %d", event, i+1)
+		}
+	}
+	return nil
+}
+
+func forEvent(h *api.ClusterHooks, event Event) []api.ClusterHook {
+	if h == nil {
+		return nil
+	}
+	switch event {
+	case PreCreate:
+		return h.PreCreate
+	case PostCreate:
+		return h.PostCreate
+	case PreDelete:
+		return h.PreDelete
+	case PostNodeGroupCreate:
+		return h.PostNodeGroupCreate
+	}
+	return nil
+}
+
+func runHook(hook api.ClusterHook, payload Payload) error {
+	timeout := DefaultTimeout
+	if hook.TimeoutSeconds != nil {
+		timeout = time.Duration(*hook.TimeoutSeconds) * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if hook.Webhook != "" {
+		return callWebhook(ctx, hook.Webhook, payload)
+	}
+	return runCommand(ctx, hook.Command, payload)
+}
+
+func runCommand(ctx context.Context, command string, payload Payload) error {
+	env, err := payload.Env()
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("command %q timed out", command)
+		}
+		return errors.Wrapf(err, "running command %q", command)
+	}
+	return nil
+}
+
+func callWebhook(ctx context.Context, url string, payload Payload) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrapf(err, "creating request to %q", url)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "calling webhook %q", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %q returned %s", url, resp.Status)
+	}
+	return nil
+}
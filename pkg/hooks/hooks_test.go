@@ -0,0 +1,90 @@
+package hooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("hooks", func() {
+	var cfg *api.ClusterConfig
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster-1"
+		cfg.Metadata.Region = "us-west-2"
+		cfg.Metadata.Version = "1.14"
+	})
+
+	It("doesn't do anything without hooks", func() {
+		Expect(Run(cfg, PreCreate, "")).To(Succeed())
+	})
+
+	It("runs commands with the metadata of the cluster in the environment", func() {
+		dir, err := ioutil.TempDir("", "hooks")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+		out := filepath.Join(dir, "out")
+
+		cfg.Hooks = &api.ClusterHooks{
+			PostNodeGroupCreate: []api.ClusterHook{
+				{Command: `echo "$EKSCTL_HOOK_EVENT $EKSCTL_CLUSTER_NAME $EKSCTL_CLUSTER_REGION $EKSCTL_NODEGROUP_NAME" > ` + out},
+			},
+		}
+		Expect(Run(cfg, PostNodeGroupCreate, "ng-1")).To(Succeed())
+		data, err := ioutil.ReadFile(out)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("postNodegroupCreate cluster-1 us-west-2 ng-1\n"))
+	})
+
+	It("stops at the first hook that fails", func() {
+		called := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+		}))
+		defer server.Close()
+
+		cfg.Hooks = &api.ClusterHooks{
+			PreDelete: []api.ClusterHook{{Command: "exit 1"}, {Webhook: server.URL}},
+		}
+		err := Run(cfg, PreDelete, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix(`preDelete hook 1: running command "exit 1"`))
+		Expect(called).To(BeFalse())
+	})
+
+	It("posts the payload to webhooks", func() {
+		var payload Payload
+		status := http.StatusOK
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.Method).To(Equal(http.MethodPost))
+			Expect(json.NewDecoder(r.Body).Decode(&payload)).To(Succeed())
+			w.WriteHeader(status)
+		}))
+		defer server.Close()
+
+		cfg.Status = &api.ClusterStatus{Endpoint: "https://example.eks.amazonaws.com"}
+		cfg.Hooks = &api.ClusterHooks{PostCreate: []api.ClusterHook{{Webhook: server.URL}}}
+		Expect(Run(cfg, PostCreate, "")).To(Succeed())
+		Expect(payload).To(Equal(Payload{
+			Event:    PostCreate,
+			Cluster:  "cluster-1",
+			Region:   "us-west-2",
+			Version:  "1.14",
+			Endpoint: "https://example.eks.amazonaws.com",
+		}))
+
+		status = http.StatusInternalServerError
+		err := Run(cfg, PostCreate, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("returned 500 Internal Server Error"))
+	})
+})
@@ -7,6 +7,8 @@ import (
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
 	awsiam "github.com/aws/aws-sdk-go/service/iam"
 
@@ -14,14 +16,19 @@ import (
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
 )
 
+// NameFromARN returns the name of the role or instance profile with the given ARN,
+// which is the last part of its path, e.g. arn:aws:iam::123456789012:role/path/name
+func NameFromARN(arn string) string {
+	parts := strings.Split(arn, "/")
+	return parts[len(parts)-1]
+}
+
 // ImportInstanceRoleFromProfileARN fetches first role ARN from instance profile
 func ImportInstanceRoleFromProfileARN(provider api.ClusterProvider, ng *api.NodeGroup, profileARN string) error {
-	partsOfProfileARN := strings.Split(profileARN, "/")
-
-	if len(partsOfProfileARN) != 2 {
+	if !strings.Contains(profileARN, "/") {
 		return fmt.Errorf("unexpected format of instance profile ARN: %q", profileARN)
 	}
-	profileName := partsOfProfileARN[1]
+	profileName := NameFromARN(profileARN)
 	input := &awsiam.GetInstanceProfileInput{
 		InstanceProfileName: &profileName,
 	}
@@ -55,5 +62,56 @@ func UseFromNodeGroup(provider api.ClusterProvider, stack *cfn.Stack, ng *api.No
 			return nil
 		},
 	}
-	return outputs.Collect(*stack, requiredCollectors, nil)
+	optionalCollectors := map[string]outputs.Collector{
+		outputs.NodeGroupNodeRole: func(v string) error {
+			ng.IAM.NodeRole = v
+			return nil
+		},
+	}
+	return outputs.Collect(*stack, requiredCollectors, optionalCollectors)
+}
+
+// PutNodeGroupRolePolicy puts an inline policy on the instance roles of the nodegroups
+// described by the given stacks, roles that already have the policy are left as they are;
+// it returns true when changes are required in plan mode
+func PutNodeGroupRolePolicy(provider api.ClusterProvider, stacks []*cfn.Stack, policyName, document string, plan bool) (bool, error) {
+	changesRequired := false
+	for _, s := range stacks {
+		ng := &api.NodeGroup{}
+		if err := UseFromNodeGroup(provider, s, ng); err != nil {
+			return false, errors.Wrapf(err, "getting instance role of stack %q", *s.StackName)
+		}
+
+		roleName := NameFromARN(ng.IAM.InstanceRoleARN)
+
+		_, err := provider.IAM().GetRolePolicy(&awsiam.GetRolePolicyInput{
+			RoleName:   &roleName,
+			PolicyName: &policyName,
+		})
+		if err == nil {
+			logger.Info("instance role %q already has policy %q", roleName, policyName)
+			continue
+		}
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != awsiam.ErrCodeNoSuchEntityException {
+			return false, errors.Wrapf(err, "getting policy %q of instance role %q", policyName, roleName)
+		}
+
+		changesRequired = true
+		if plan {
+			logger.Info("(plan) would have put policy %q on instance role %q", policyName, roleName)
+			continue
+		}
+
+		input := &awsiam.PutRolePolicyInput{
+			RoleName:       &roleName,
+			PolicyName:     &policyName,
+			PolicyDocument: aws.String(document),
+		}
+		if _, err := provider.IAM().PutRolePolicy(input); err != nil {
+			return false, errors.Wrapf(err, "putting policy %q on instance role %q", policyName, roleName)
+		}
+		logger.Info("put policy %q on instance role %q", policyName, roleName)
+	}
+
+	return plan && changesRequired, nil
 }
@@ -0,0 +1,141 @@
+package iam
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// OIDCProviderClientID is the audience of the tokens of service accounts that assume IAM roles
+const OIDCProviderClientID = "sts.amazonaws.com"
+
+// OIDCThumbprint returns the SHA-1 fingerprint of the root CA certificate of the OIDC issuer,
+// which IAM uses to verify it; it's computed from the certificate chain the issuer serves, as
+// the root CA can change, e.g. when it's renewed. The issuer is requested with the given client,
+// so that the proxy and root CAs of its transport are used
+func OIDCThumbprint(issuerURL string, client *http.Client) (string, error) {
+	issuer, err := url.Parse(issuerURL)
+	if err != nil || issuer.Scheme != "https" || issuer.Host == "" {
+		return "", fmt.Errorf("OIDC issuer URL %q must be an https URL", issuerURL)
+	}
+
+	resp, err := client.Get(strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", errors.Wrapf(err, "connecting to OIDC issuer %q", issuerURL)
+	}
+	defer resp.Body.Close()
+
+	if resp.TLS == nil || len(resp.TLS.VerifiedChains) == 0 || len(resp.TLS.VerifiedChains[0]) == 0 {
+		return "", fmt.Errorf("OIDC issuer %q has no verified certificate chain", issuerURL)
+	}
+	chain := resp.TLS.VerifiedChains[0]
+	fingerprint := sha1.Sum(chain[len(chain)-1].Raw)
+	return hex.EncodeToString(fingerprint[:]), nil
+}
+
+// FindOIDCProvider returns the ARN of the IAM OIDC provider of the issuer, or an empty string
+// when there is none, ARNs of providers end with the issuer URL without its scheme
+func FindOIDCProvider(provider api.ClusterProvider, issuerURL string) (string, error) {
+	output, err := provider.IAM().ListOpenIDConnectProviders(&awsiam.ListOpenIDConnectProvidersInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "listing IAM OIDC providers")
+	}
+	suffix := ":oidc-provider/" + strings.TrimPrefix(issuerURL, "https://")
+	for _, p := range output.OpenIDConnectProviderList {
+		if strings.HasSuffix(aws.StringValue(p.Arn), suffix) {
+			return aws.StringValue(p.Arn), nil
+		}
+	}
+	return "", nil
+}
+
+// AssociateOIDCProvider creates the IAM OIDC provider of the issuer of a cluster, so that IAM roles
+// can trust its service accounts; when the provider exists, its thumbprint is updated if it differs
+// from the given one. It returns true when changes are required in plan mode
+func AssociateOIDCProvider(provider api.ClusterProvider, issuerURL, thumbprint string, plan bool) (bool, error) {
+	arn, err := FindOIDCProvider(provider, issuerURL)
+	if err != nil {
+		return false, err
+	}
+
+	if arn == "" {
+		if plan {
+			logger.Info("(plan) would have created IAM OIDC provider for %q", issuerURL)
+			return true, nil
+		}
+		output, err := provider.IAM().CreateOpenIDConnectProvider(&awsiam.CreateOpenIDConnectProviderInput{
+			Url:            aws.String(issuerURL),
+			ClientIDList:   aws.StringSlice([]string{OIDCProviderClientID}),
+			ThumbprintList: aws.StringSlice([]string{thumbprint}),
+		})
+		if err != nil {
+			return false, errors.Wrapf(err, "creating IAM OIDC provider for %q", issuerURL)
+		}
+		logger.Info("created IAM OIDC provider %q", aws.StringValue(output.OpenIDConnectProviderArn))
+		return false, nil
+	}
+
+	output, err := provider.IAM().GetOpenIDConnectProvider(&awsiam.GetOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(arn),
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "getting IAM OIDC provider %q", arn)
+	}
+	for _, t := range output.ThumbprintList {
+		if strings.EqualFold(aws.StringValue(t), thumbprint) {
+			logger.Info("IAM OIDC provider %q is already associated with the cluster", arn)
+			return false, nil
+		}
+	}
+
+	if plan {
+		logger.Info("(plan) would have updated the thumbprint of IAM OIDC provider %q to %s", arn, thumbprint)
+		return true, nil
+	}
+	if _, err := provider.IAM().UpdateOpenIDConnectProviderThumbprint(&awsiam.UpdateOpenIDConnectProviderThumbprintInput{
+		OpenIDConnectProviderArn: aws.String(arn),
+		ThumbprintList:           aws.StringSlice([]string{thumbprint}),
+	}); err != nil {
+		return false, errors.Wrapf(err, "updating the thumbprint of IAM OIDC provider %q", arn)
+	}
+	logger.Info("updated the thumbprint of IAM OIDC provider %q to %s", arn, thumbprint)
+	return false, nil
+}
+
+// DisassociateOIDCProvider deletes the IAM OIDC provider of the issuer of a cluster, if there is one;
+// it returns true when changes are required in plan mode
+func DisassociateOIDCProvider(provider api.ClusterProvider, issuerURL string, plan bool) (bool, error) {
+	arn, err := FindOIDCProvider(provider, issuerURL)
+	if err != nil {
+		return false, err
+	}
+	if arn == "" {
+		logger.Info("there is no IAM OIDC provider for %q", issuerURL)
+		return false, nil
+	}
+
+	if plan {
+		logger.Info("(plan) would have deleted IAM OIDC provider %q", arn)
+		return true, nil
+	}
+	if _, err := provider.IAM().DeleteOpenIDConnectProvider(&awsiam.DeleteOpenIDConnectProviderInput{
+		OpenIDConnectProviderArn: aws.String(arn),
+	}); err != nil {
+		if awsErr, ok := err.(awserr.Error); !ok || awsErr.Code() != awsiam.ErrCodeNoSuchEntityException {
+			return false, errors.Wrapf(err, "deleting IAM OIDC provider %q", arn)
+		}
+	}
+	logger.Info("deleted IAM OIDC provider %q", arn)
+	return false, nil
+}
@@ -0,0 +1,139 @@
+package iam
+
+import (
+	"crypto/sha1"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awsiam "github.com/aws/aws-sdk-go/service/iam"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+var _ = Describe("IAM OIDC provider", func() {
+	const (
+		issuerURL   = "https://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"
+		providerARN = "arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/ABCDEF"
+		thumbprint  = "9e99a48a9960b14926bb7f3b02e22da2b0ab7280"
+	)
+
+	var p *mockprovider.MockProvider
+
+	withProviders := func(arns ...string) {
+		list := []*awsiam.OpenIDConnectProviderListEntry{
+			{Arn: aws.String("arn:aws:iam::123456789012:oidc-provider/oidc.eks.us-west-2.amazonaws.com/id/OTHER")},
+		}
+		for _, arn := range arns {
+			list = append(list, &awsiam.OpenIDConnectProviderListEntry{Arn: aws.String(arn)})
+		}
+		p.MockIAM().On("ListOpenIDConnectProviders", mock.Anything).Return(&awsiam.ListOpenIDConnectProvidersOutput{
+			OpenIDConnectProviderList: list,
+		}, nil)
+	}
+
+	withThumbprint := func(thumbprint string) {
+		p.MockIAM().On("GetOpenIDConnectProvider", mock.MatchedBy(func(input *awsiam.GetOpenIDConnectProviderInput) bool {
+			return *input.OpenIDConnectProviderArn == providerARN
+		})).Return(&awsiam.GetOpenIDConnectProviderOutput{
+			ThumbprintList: aws.StringSlice([]string{thumbprint}),
+		}, nil)
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+	})
+
+	It("creates the provider of the issuer when there is none", func() {
+		withProviders()
+		p.MockIAM().On("CreateOpenIDConnectProvider", mock.MatchedBy(func(input *awsiam.CreateOpenIDConnectProviderInput) bool {
+			return *input.Url == issuerURL &&
+				aws.StringValueSlice(input.ClientIDList)[0] == OIDCProviderClientID &&
+				aws.StringValueSlice(input.ThumbprintList)[0] == thumbprint
+		})).Return(&awsiam.CreateOpenIDConnectProviderOutput{OpenIDConnectProviderArn: aws.String(providerARN)}, nil)
+
+		changesRequired, err := AssociateOIDCProvider(p, issuerURL, thumbprint, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeTrue())
+		p.MockIAM().AssertNotCalled(GinkgoT(), "CreateOpenIDConnectProvider", mock.Anything)
+
+		changesRequired, err = AssociateOIDCProvider(p, issuerURL, thumbprint, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeFalse())
+		p.MockIAM().AssertNumberOfCalls(GinkgoT(), "CreateOpenIDConnectProvider", 1)
+	})
+
+	It("leaves a provider with the current thumbprint alone", func() {
+		withProviders(providerARN)
+		withThumbprint(thumbprint)
+
+		changesRequired, err := AssociateOIDCProvider(p, issuerURL, thumbprint, true)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeFalse())
+		p.MockIAM().AssertNotCalled(GinkgoT(), "CreateOpenIDConnectProvider", mock.Anything)
+		p.MockIAM().AssertNotCalled(GinkgoT(), "UpdateOpenIDConnectProviderThumbprint", mock.Anything)
+	})
+
+	It("refreshes the thumbprint of the provider when the root CA changed", func() {
+		withProviders(providerARN)
+		withThumbprint("0000000000000000000000000000000000000000")
+		p.MockIAM().On("UpdateOpenIDConnectProviderThumbprint", mock.MatchedBy(func(input *awsiam.UpdateOpenIDConnectProviderThumbprintInput) bool {
+			return *input.OpenIDConnectProviderArn == providerARN && aws.StringValueSlice(input.ThumbprintList)[0] == thumbprint
+		})).Return(&awsiam.UpdateOpenIDConnectProviderThumbprintOutput{}, nil)
+
+		changesRequired, err := AssociateOIDCProvider(p, issuerURL, thumbprint, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeFalse())
+		p.MockIAM().AssertNumberOfCalls(GinkgoT(), "UpdateOpenIDConnectProviderThumbprint", 1)
+	})
+
+	It("deletes the provider of the issuer, if there is one", func() {
+		withProviders(providerARN)
+		p.MockIAM().On("DeleteOpenIDConnectProvider", mock.MatchedBy(func(input *awsiam.DeleteOpenIDConnectProviderInput) bool {
+			return *input.OpenIDConnectProviderArn == providerARN
+		})).Return(&awsiam.DeleteOpenIDConnectProviderOutput{}, nil)
+
+		changesRequired, err := DisassociateOIDCProvider(p, issuerURL, false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeFalse())
+		p.MockIAM().AssertNumberOfCalls(GinkgoT(), "DeleteOpenIDConnectProvider", 1)
+
+		changesRequired, err = DisassociateOIDCProvider(p, "https://oidc.eks.us-west-2.amazonaws.com/id/MISSING", false)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changesRequired).To(BeFalse())
+		p.MockIAM().AssertNumberOfCalls(GinkgoT(), "DeleteOpenIDConnectProvider", 1)
+	})
+
+	It("computes the thumbprint from the root CA of the issuer with the roots of the client", func() {
+		server := httptest.NewTLSServer(http.NotFoundHandler())
+		defer server.Close()
+
+		roots := x509.NewCertPool()
+		roots.AddCert(server.Certificate())
+		fingerprint := sha1.Sum(server.Certificate().Raw)
+
+		proxied := []string{}
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots},
+			Proxy: func(req *http.Request) (*url.URL, error) {
+				proxied = append(proxied, req.URL.Host)
+				return nil, nil
+			},
+		}}
+		Expect(OIDCThumbprint(server.URL+"/id/ABCDEF", client)).To(Equal(hex.EncodeToString(fingerprint[:])))
+		Expect(proxied).To(Equal([]string{server.Listener.Addr().String()}))
+
+		_, err := OIDCThumbprint(server.URL+"/id/ABCDEF", &http.Client{Transport: &http.Transport{}})
+		Expect(err).To(HaveOccurred())
+
+		_, err = OIDCThumbprint("http://oidc.eks.us-west-2.amazonaws.com/id/ABCDEF", client)
+		Expect(err).To(HaveOccurred())
+	})
+})
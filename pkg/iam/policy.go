@@ -0,0 +1,359 @@
+package iam
+
+import (
+	"fmt"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// policyStatement is a statement of the policy eksctl needs to manage a cluster
+type policyStatement struct {
+	sid       string
+	actions   []string
+	resources []string
+}
+
+// EksctlPolicyDocument returns a policy document with the actions eksctl needs to create,
+// update and delete the cluster of the config, as derived from the stacks and tasks that
+// the config leads to; the resources of the stacks are created with the credentials of
+// eksctl, unless CloudFormation is given a service role, in which case that role needs
+// those actions instead. Resources are scoped to the names eksctl gives them, accounts
+// are left as wildcards as the config doesn't know them
+func EksctlPolicyDocument(cfg *api.ClusterConfig) api.InlineDocument {
+	statements := []interface{}{}
+	for _, s := range eksctlPolicyStatements(cfg) {
+		statements = append(statements, map[string]interface{}{
+			"Sid":      s.sid,
+			"Effect":   "Allow",
+			"Action":   s.actions,
+			"Resource": s.resources,
+		})
+	}
+	return api.InlineDocument{
+		"Version":   "2012-10-17",
+		"Statement": statements,
+	}
+}
+
+func eksctlPolicyStatements(cfg *api.ClusterConfig) []policyStatement {
+	region, name, prefix, partition := cfg.Metadata.Region, cfg.Metadata.Name, cfg.StackNamePrefix(), cfg.Partition()
+	arn := func(service, resource string) string {
+		return fmt.Sprintf("arn:%s:%s:%s:*:%s", partition, service, region, resource)
+	}
+	iamARN := func(resource string) string {
+		return "arn:" + partition + ":iam::*:" + resource
+	}
+
+	statements := []policyStatement{
+		{
+			sid: "CloudFormation",
+			actions: []string{
+				"cloudformation:ContinueUpdateRollback",
+				"cloudformation:CreateChangeSet",
+				"cloudformation:CreateStack",
+				"cloudformation:DeleteChangeSet",
+				"cloudformation:DeleteStack",
+				"cloudformation:DescribeChangeSet",
+				"cloudformation:DescribeStackEvents",
+				"cloudformation:DescribeStackResource",
+				"cloudformation:DescribeStackResources",
+				"cloudformation:DescribeStacks",
+				"cloudformation:ExecuteChangeSet",
+				"cloudformation:GetTemplate",
+				"cloudformation:UpdateStack",
+				"cloudformation:UpdateTerminationProtection",
+			},
+			resources: []string{arn("cloudformation", "stack/"+prefix+name+"-*/*")},
+		},
+		{
+			sid: "Discovery",
+			actions: []string{
+				"cloudformation:ListStacks",
+				"ec2:DescribeAvailabilityZones",
+				"ec2:DescribeImages",
+				"ec2:DescribeInstanceTypeOfferings",
+				"ec2:DescribeInstances",
+				"ec2:DescribeKeyPairs",
+				"ec2:DescribeNatGateways",
+				"ec2:DescribeSecurityGroups",
+				"ec2:DescribeSubnets",
+				"ec2:DescribeVpcs",
+				"eks:ListClusters",
+				"sts:GetCallerIdentity",
+			},
+			resources: []string{"*"},
+		},
+		{
+			sid: "EKS",
+			actions: []string{
+				"eks:DescribeCluster",
+				"eks:DescribeUpdate",
+				"eks:UpdateClusterConfig",
+				"eks:UpdateClusterVersion",
+			},
+			resources: []string{arn("eks", "cluster/"+name)},
+		},
+		{
+			sid:       "AMIs",
+			actions:   []string{"ssm:GetParameter"},
+			resources: []string{arn("ssm", "parameter/aws/service/*")},
+		},
+	}
+
+	for _, ng := range cfg.NodeGroups {
+		if ng.SSH != nil && api.IsEnabled(ng.SSH.Allow) && (ng.SSH.PublicKeyPath != nil || ng.SSH.PublicKey != nil) {
+			statements = append(statements, policyStatement{
+				sid:       "SSHKeys",
+				actions:   []string{"ec2:ImportKeyPair"},
+				resources: []string{"*"},
+			})
+			break
+		}
+	}
+
+	if cfg.CloudWatch != nil && cfg.CloudWatch.ClusterLogging != nil {
+		// the log group is only deleted with --delete-all-dependents
+		statements = append(statements, policyStatement{
+			sid:       "ControlPlaneLogs",
+			actions:   []string{"logs:DeleteLogGroup"},
+			resources: []string{arn("logs", "log-group:/aws/eks/"+name+"/cluster:*")},
+		})
+	}
+
+	if cfg.CloudFormation != nil && cfg.CloudFormation.ServiceRoleARN != "" {
+		// the resources of the stacks are created by the service role
+		return append(statements, policyStatement{
+			sid:       "CloudFormationServiceRole",
+			actions:   []string{"iam:PassRole"},
+			resources: []string{cfg.CloudFormation.ServiceRoleARN},
+		})
+	}
+	return append(statements, stackResourcesPolicyStatements(cfg, arn, iamARN)...)
+}
+
+// stackResourcesPolicyStatements returns the statements of the resources of the stacks of the cluster
+func stackResourcesPolicyStatements(cfg *api.ClusterConfig, arn func(string, string) string, iamARN func(string) string) []policyStatement {
+	name, prefix := cfg.Metadata.Name, cfg.StackNamePrefix()
+
+	statements := []policyStatement{
+		{
+			sid: "ControlPlane",
+			actions: []string{
+				"eks:CreateCluster",
+				"eks:DeleteCluster",
+			},
+			resources: []string{arn("eks", "cluster/"+name)},
+		},
+		{
+			sid: "SecurityGroups",
+			actions: []string{
+				"ec2:AuthorizeSecurityGroupEgress",
+				"ec2:AuthorizeSecurityGroupIngress",
+				"ec2:CreateSecurityGroup",
+				"ec2:CreateTags",
+				"ec2:DeleteSecurityGroup",
+				"ec2:DeleteTags",
+				"ec2:RevokeSecurityGroupEgress",
+				"ec2:RevokeSecurityGroupIngress",
+			},
+			resources: []string{"*"},
+		},
+	}
+
+	if cfg.VPC == nil || cfg.VPC.ID == "" {
+		actions := []string{
+			"ec2:AssociateRouteTable",
+			"ec2:AttachInternetGateway",
+			"ec2:CreateInternetGateway",
+			"ec2:CreateRoute",
+			"ec2:CreateRouteTable",
+			"ec2:CreateSubnet",
+			"ec2:CreateVpc",
+			"ec2:DeleteInternetGateway",
+			"ec2:DeleteRoute",
+			"ec2:DeleteRouteTable",
+			"ec2:DeleteSubnet",
+			"ec2:DeleteVpc",
+			"ec2:DescribeInternetGateways",
+			"ec2:DescribeRouteTables",
+			"ec2:DetachInternetGateway",
+			"ec2:DisassociateRouteTable",
+			"ec2:ModifySubnetAttribute",
+			"ec2:ModifyVpcAttribute",
+		}
+		if cfg.VPC == nil || cfg.VPC.NAT == nil || cfg.VPC.NAT.Gateway == nil || *cfg.VPC.NAT.Gateway != api.ClusterDisableNAT {
+			actions = append(actions,
+				"ec2:AllocateAddress",
+				"ec2:CreateNatGateway",
+				"ec2:DeleteNatGateway",
+				"ec2:DescribeAddresses",
+				"ec2:ReleaseAddress",
+			)
+		}
+		statements = append(statements, policyStatement{sid: "VPC", actions: actions, resources: []string{"*"}})
+	}
+
+	// roles created by stacks are named after them, unless nodegroups name their instance role
+	createsNamelessRoles := cfg.IAM.ServiceRoleARN == ""
+	roles := []string{}
+	passRoles := []string{}
+	if cfg.IAM.ServiceRoleARN != "" {
+		passRoles = append(passRoles, cfg.IAM.ServiceRoleARN)
+	}
+	for _, ng := range cfg.NodeGroups {
+		switch {
+		case ng.IAM != nil && ng.IAM.InstanceRoleARN != "":
+			passRoles = append(passRoles, ng.IAM.InstanceRoleARN)
+		case ng.IAM != nil && ng.IAM.InstanceRoleName != "":
+			roles = append(roles, iamARN("role/"+ng.IAM.InstanceRoleName))
+		default:
+			createsNamelessRoles = true
+		}
+	}
+	if createsNamelessRoles {
+		roles = append([]string{iamARN("role/" + prefix + name + "-*")}, roles...)
+	}
+	if len(roles) > 0 {
+		actions := []string{
+			"iam:AttachRolePolicy",
+			"iam:CreateRole",
+			"iam:DeleteRole",
+			"iam:DeleteRolePolicy",
+			"iam:DetachRolePolicy",
+			"iam:GetRole",
+			"iam:GetRolePolicy",
+			"iam:PassRole",
+			"iam:PutRolePolicy",
+		}
+		if hasPermissionsBoundary(cfg) {
+			actions = append(actions, "iam:DeleteRolePermissionsBoundary", "iam:PutRolePermissionsBoundary")
+		}
+		statements = append(statements, policyStatement{sid: "IAMRoles", actions: actions, resources: roles})
+	}
+	if len(passRoles) > 0 {
+		statements = append(statements, policyStatement{
+			sid:       "ExistingIAMRoles",
+			actions:   []string{"iam:GetRole", "iam:ListAttachedRolePolicies", "iam:PassRole"},
+			resources: passRoles,
+		})
+	}
+
+	if len(cfg.NodeGroups) > 0 {
+		statements = append(statements,
+			policyStatement{
+				sid: "InstanceProfiles",
+				actions: []string{
+					"iam:AddRoleToInstanceProfile",
+					"iam:CreateInstanceProfile",
+					"iam:DeleteInstanceProfile",
+					"iam:GetInstanceProfile",
+					"iam:RemoveRoleFromInstanceProfile",
+				},
+				resources: []string{iamARN("instance-profile/" + prefix + name + "-*")},
+			},
+			policyStatement{
+				sid: "NodeGroups",
+				actions: []string{
+					"autoscaling:CreateAutoScalingGroup",
+					"autoscaling:CreateOrUpdateTags",
+					"autoscaling:DeleteAutoScalingGroup",
+					"autoscaling:DeleteLifecycleHook",
+					"autoscaling:DeleteTags",
+					"autoscaling:DescribeAutoScalingGroups",
+					"autoscaling:DescribeScalingActivities",
+					"autoscaling:PutLifecycleHook",
+					"autoscaling:ResumeProcesses",
+					"autoscaling:SuspendProcesses",
+					"autoscaling:UpdateAutoScalingGroup",
+					"ec2:CreateLaunchTemplate",
+					"ec2:CreateLaunchTemplateVersion",
+					"ec2:DeleteLaunchTemplate",
+					"ec2:DescribeLaunchTemplateVersions",
+					"ec2:DescribeLaunchTemplates",
+					"ec2:RunInstances",
+				},
+				resources: []string{"*"},
+			},
+		)
+	}
+
+	if cfg.HasEFS() {
+		statements = append(statements, policyStatement{
+			sid: "EFS",
+			actions: []string{
+				"elasticfilesystem:CreateFileSystem",
+				"elasticfilesystem:CreateMountTarget",
+				"elasticfilesystem:DeleteFileSystem",
+				"elasticfilesystem:DeleteMountTarget",
+				"elasticfilesystem:DescribeFileSystems",
+				"elasticfilesystem:DescribeMountTargets",
+			},
+			resources: []string{"*"},
+		})
+	}
+	if cfg.HasFSx() {
+		statements = append(statements, policyStatement{
+			sid: "FSx",
+			actions: []string{
+				"fsx:CreateFileSystem",
+				"fsx:DeleteFileSystem",
+				"fsx:DescribeFileSystems",
+				"fsx:TagResource",
+			},
+			resources: []string{"*"},
+		})
+	}
+
+	if cfg.NodeTerminationHandlerQueueMode() {
+		statements = append(statements, policyStatement{
+			sid: "NodeTerminationHandlerQueue",
+			actions: []string{
+				"events:DeleteRule",
+				"events:DescribeRule",
+				"events:PutRule",
+				"events:PutTargets",
+				"events:RemoveTargets",
+				"sqs:CreateQueue",
+				"sqs:DeleteQueue",
+				"sqs:GetQueueAttributes",
+				"sqs:SetQueueAttributes",
+			},
+			resources: []string{
+				arn("events", "rule/"+prefix+name+"-*"),
+				arn("sqs", cfg.NodeTerminationHandlerQueueName()),
+			},
+		})
+	}
+
+	if cfg.HasAlarms() {
+		statements = append(statements, policyStatement{
+			sid: "Alarms",
+			actions: []string{
+				"cloudwatch:DeleteAlarms",
+				"cloudwatch:DescribeAlarms",
+				"cloudwatch:PutMetricAlarm",
+				"logs:DeleteMetricFilter",
+				"logs:DescribeMetricFilters",
+				"logs:PutMetricFilter",
+			},
+			resources: []string{
+				arn("cloudwatch", "alarm:"+name+"-*"),
+				arn("logs", "log-group:/aws/eks/"+name+"/cluster:*"),
+			},
+		})
+	}
+
+	return statements
+}
+
+func hasPermissionsBoundary(cfg *api.ClusterConfig) bool {
+	if cfg.IAM.PermissionsBoundaryARN != "" {
+		return true
+	}
+	for _, ng := range cfg.NodeGroups {
+		if ng.IAM != nil && ng.IAM.PermissionsBoundaryARN != "" {
+			return true
+		}
+	}
+	return false
+}
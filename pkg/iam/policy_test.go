@@ -0,0 +1,74 @@
+package iam
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+var _ = Describe("eksctl policy", func() {
+	var cfg *api.ClusterConfig
+
+	sids := func() []string {
+		names := []string{}
+		for _, s := range eksctlPolicyStatements(cfg) {
+			names = append(names, s.sid)
+		}
+		return names
+	}
+
+	statement := func(sid string) policyStatement {
+		for _, s := range eksctlPolicyStatements(cfg) {
+			if s.sid == sid {
+				return s
+			}
+		}
+		Fail("no statement " + sid)
+		return policyStatement{}
+	}
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "cluster-1"
+		cfg.Metadata.Region = "eu-north-1"
+	})
+
+	It("scopes stacks and roles to the names of the cluster", func() {
+		ng := cfg.NewNodeGroup()
+		ng.Name = "ng-1"
+
+		Expect(sids()).To(Equal([]string{"CloudFormation", "Discovery", "EKS", "AMIs",
+			"ControlPlane", "SecurityGroups", "VPC", "IAMRoles", "InstanceProfiles", "NodeGroups"}))
+		Expect(statement("CloudFormation").resources).To(Equal([]string{"arn:aws:cloudformation:eu-north-1:*:stack/eksctl-cluster-1-*/*"}))
+		Expect(statement("IAMRoles").resources).To(Equal([]string{"arn:aws:iam::*:role/eksctl-cluster-1-*"}))
+		Expect(statement("VPC").actions).To(ContainElement("ec2:CreateNatGateway"))
+	})
+
+	It("only lets existing roles be passed", func() {
+		cfg.IAM.ServiceRoleARN = "arn:aws:iam::123456789012:role/eks-service-role"
+		cfg.VPC.ID = "vpc-1"
+
+		Expect(sids()).To(Equal([]string{"CloudFormation", "Discovery", "EKS", "AMIs",
+			"ControlPlane", "SecurityGroups", "ExistingIAMRoles"}))
+		Expect(statement("ExistingIAMRoles").resources).To(Equal([]string{cfg.IAM.ServiceRoleARN}))
+	})
+
+	It("leaves the resources of the stacks to the CloudFormation service role", func() {
+		cfg.CloudFormation = &api.ClusterCloudFormation{ServiceRoleARN: "arn:aws:iam::123456789012:role/cfn"}
+
+		Expect(sids()).To(Equal([]string{"CloudFormation", "Discovery", "EKS", "AMIs", "CloudFormationServiceRole"}))
+	})
+
+	It("adds the resources of optional stacks", func() {
+		cfg.IAM.ServiceRoleARN = "arn:aws:iam::123456789012:role/eks-service-role"
+		cfg.VPC.ID = "vpc-1"
+		cfg.NodeTerminationHandler = &api.ClusterNodeTerminationHandler{Install: api.Enabled(), Mode: api.NodeTerminationHandlerModeQueue}
+		cfg.CloudWatch = &api.ClusterCloudWatch{
+			Alarms: &api.ClusterCloudWatchAlarms{Create: api.Enabled(), SNSTopicARN: "arn:aws:sns:eu-north-1:123456789012:alarms"},
+		}
+
+		Expect(sids()).To(ContainElement("NodeTerminationHandlerQueue"))
+		Expect(statement("Alarms").resources).To(ContainElement("arn:aws:cloudwatch:eu-north-1:*:alarm:cluster-1-*"))
+	})
+})
@@ -1,7 +1,9 @@
 package kubernetes
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/blang/semver"
 	jsonpatch "github.com/evanphx/json-patch"
@@ -207,6 +209,86 @@ func (r *RawResource) CreateOrReplace(plan bool) (string, error) {
 	return r.LogAction(plan, "replaced"), nil
 }
 
+// Diff returns a JSON merge patch with the fields of the desired state that differ from the live
+// object, fields that are only set on the live object, e.g. its status or the defaults set by the
+// API server, are ignored, so the patch is nil when the object is up-to-date; the boolean result
+// is false when the object doesn't exist yet
+func (r *RawResource) Diff() ([]byte, bool, error) {
+	oldObj, err := r.Helper.Get(r.Info.Namespace, r.Info.Name, false)
+	if err != nil {
+		if apierrs.IsNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, errors.Wrap(err, "unexpected non-404 error")
+	}
+
+	oldData, err := runtime.Encode(unstructured.UnstructuredJSONScheme, oldObj)
+	if err != nil {
+		return nil, true, err
+	}
+
+	convertedObj, err := scheme.Scheme.ConvertToVersion(r.Info.Object.DeepCopyObject(), r.GVK.GroupVersion())
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "converting object")
+	}
+	scheme.Scheme.Default(convertedObj)
+	newData, err := runtime.Encode(unstructured.UnstructuredJSONScheme, convertedObj)
+	if err != nil {
+		return nil, true, err
+	}
+
+	var live, desired map[string]interface{}
+	if err := json.Unmarshal(oldData, &live); err != nil {
+		return nil, true, errors.Wrapf(err, "decoding live object %q", r)
+	}
+	if err := json.Unmarshal(newData, &desired); err != nil {
+		return nil, true, errors.Wrapf(err, "decoding desired object %q", r)
+	}
+	delete(desired, "status")
+
+	changes, changed := desiredChanges(live, desired)
+	if !changed {
+		return nil, true, nil
+	}
+	patch, err := json.Marshal(changes)
+	if err != nil {
+		return nil, true, errors.Wrapf(err, "creating patch for %q", r)
+	}
+	return patch, true, nil
+}
+
+// desiredChanges returns the parts of the desired value that differ from the live one, objects are
+// compared field by field and null fields of the desired value are unset, other values, including
+// lists, are compared and returned as a whole
+func desiredChanges(live, desired interface{}) (interface{}, bool) {
+	switch desired := desired.(type) {
+	case nil:
+		return nil, false
+	case map[string]interface{}:
+		liveMap, _ := live.(map[string]interface{})
+		changes := map[string]interface{}{}
+		for k, v := range desired {
+			if change, changed := desiredChanges(liveMap[k], v); changed {
+				changes[k] = change
+			}
+		}
+		return changes, len(changes) > 0
+	case []interface{}:
+		liveList, _ := live.([]interface{})
+		if len(liveList) != len(desired) {
+			return desired, true
+		}
+		for i := range desired {
+			if _, changed := desiredChanges(liveList[i], desired[i]); changed {
+				return desired, true
+			}
+		}
+		return desired, false
+	default:
+		return desired, !reflect.DeepEqual(live, desired)
+	}
+}
+
 /*
 
 	This doesn't work yet. We need to find a way to do defaulting properly, what we have now seems to cause following behaviour and nothing seems to make it go away.
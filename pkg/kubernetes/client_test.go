@@ -176,4 +176,42 @@ var _ = Describe("kubernets client wrappers", func() {
 			Expect(err).To(HaveOccurred())
 		})
 	})
+
+	Describe("can diff objects", func() {
+		It("only reports fields of the desired state that differ from the live object", func() {
+			live := &corev1.ServiceAccount{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: "v1",
+					Kind:       "ServiceAccount",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test",
+					Namespace: metav1.NamespaceDefault,
+					Labels:    map[string]string{"test": "live"},
+					UID:       "4f0c1b5e-6b3a-11e9-a923-1681be663d3e",
+				},
+			}
+
+			rc, _ := testutils.NewFakeRawResource(live, false, false, testutils.NewCollectionTracker())
+			patch, exists, err := rc.Diff()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(patch).To(BeNil())
+
+			desired := live.DeepCopy()
+			desired.UID = ""
+			desired.Labels = map[string]string{"test": "desired"}
+			rc.Info.Object = desired
+			patch, exists, err = rc.Diff()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeTrue())
+			Expect(string(patch)).To(MatchJSON(`{"metadata":{"labels":{"test":"desired"}}}`))
+
+			rc, _ = testutils.NewFakeRawResource(live, true, false, testutils.NewCollectionTracker())
+			patch, exists, err = rc.Diff()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(exists).To(BeFalse())
+			Expect(patch).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,131 @@
+// Package logging provides structured output for the log messages of eksctl,
+// it's an adapter for github.com/kris-nova/logger, which always writes through
+// github.com/fatih/color when colors are enabled, so that the writer of the
+// latter receives every log message and can encode it as a JSON record
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/kris-nova/logger"
+)
+
+// Log formats
+const (
+	// FormatText is the default format of human readable messages
+	FormatText = "text"
+	// FormatJSON is the format of one JSON record per message
+	FormatJSON = "json"
+)
+
+// DefaultComponent is the component of messages that don't name one
+const DefaultComponent = "eksctl"
+
+// Formats returns the supported log formats
+func Formats() []string {
+	return []string{FormatText, FormatJSON}
+}
+
+// Record is a structured log message
+type Record struct {
+	Level     string            `json:"level"`
+	Timestamp string            `json:"timestamp"`
+	Component string            `json:"component"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+var levels = map[string]string{
+	logger.AlwaysLabel:   "always",
+	logger.CriticalLabel: "critical",
+	logger.WarningLabel:  "warning",
+	logger.InfoLabel:     "info",
+	logger.SuccessLabel:  "success",
+	logger.DebugLabel:    "debug",
+}
+
+// Configure sets the format of log messages, which are written to w
+// in JSON format; it must be called after all other settings of the
+// logger are set, as it overrides colors and timestamps
+func Configure(format string, w io.Writer) error {
+	switch format {
+	case FormatText:
+		return nil
+	case FormatJSON:
+		logger.Color = true
+		logger.Fabulous = false
+		logger.Timestamps = true
+		color.NoColor = true
+		color.Output = NewJSONWriter(w)
+		return nil
+	default:
+		return fmt.Errorf("unknown log format %q (valid options: %s)", format, strings.Join(Formats(), ", "))
+	}
+}
+
+// JSONWriter encodes each message of the logger as a JSON record,
+// the logger writes every message with a single call to Write
+type JSONWriter struct {
+	mutex   sync.Mutex
+	encoder *json.Encoder
+}
+
+// NewJSONWriter returns a JSONWriter that writes records to w
+func NewJSONWriter(w io.Writer) *JSONWriter {
+	return &JSONWriter{encoder: json.NewEncoder(w)}
+}
+
+func (w *JSONWriter) Write(p []byte) (int, error) {
+	record := ParseMessage(string(p))
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if err := w.encoder.Encode(record); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// messages are formatted as "<RFC3339 timestamp> [<label>]  <message>"
+var messageRegexp = regexp.MustCompile(`(?s)^(\S+) \[(\S+)\]  (.*)$`)
+
+// a component followed by key=value pairs, e.g. "aws-api service=eks operation=DescribeCluster"
+var fieldsRegexp = regexp.MustCompile(`^[a-z][a-z0-9-]*( [A-Za-z][A-Za-z0-9-]*=\S*)+$`)
+
+// ParseMessage returns the record of a message of the logger, messages
+// of unknown format are kept as they are with an unknown level
+func ParseMessage(s string) *Record {
+	s = strings.TrimRight(s, "\n")
+	record := &Record{
+		Level:     "unknown",
+		Component: DefaultComponent,
+		Message:   s,
+	}
+
+	m := messageRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return record
+	}
+	record.Timestamp = m[1]
+	if level, ok := levels[m[2]]; ok {
+		record.Level = level
+	}
+	record.Message = strings.TrimSpace(m[3])
+
+	if fieldsRegexp.MatchString(record.Message) {
+		tokens := strings.Fields(record.Message)
+		record.Component = tokens[0]
+		record.Fields = map[string]string{}
+		for _, token := range tokens[1:] {
+			kv := strings.SplitN(token, "=", 2)
+			record.Fields[kv[0]] = kv[1]
+		}
+	}
+	return record
+}
@@ -0,0 +1,101 @@
+package logging_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kris-nova/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/logging"
+)
+
+var _ = Describe("Logging", func() {
+	It("parses messages of the logger", func() {
+		record := ParseMessage("2019-07-04T10:01:02Z [ℹ]  using region us-west-2\n")
+
+		Expect(*record).To(Equal(Record{
+			Level:     "info",
+			Timestamp: "2019-07-04T10:01:02Z",
+			Component: DefaultComponent,
+			Message:   "using region us-west-2",
+		}))
+	})
+
+	It("keeps multi-line messages", func() {
+		record := ParseMessage("2019-07-04T10:01:02Z [✖]  creating stack: failed\nsecond line\n")
+
+		Expect(record.Level).To(Equal("critical"))
+		Expect(record.Message).To(Equal("creating stack: failed\nsecond line"))
+	})
+
+	It("parses components and fields", func() {
+		record := ParseMessage("2019-07-04T10:01:02Z [ℹ]  aws-api service=eks operation=DescribeCluster retries=0 request-id=abc-123")
+
+		Expect(record.Component).To(Equal("aws-api"))
+		Expect(record.Fields).To(Equal(map[string]string{
+			"service":    "eks",
+			"operation":  "DescribeCluster",
+			"retries":    "0",
+			"request-id": "abc-123",
+		}))
+	})
+
+	It("doesn't take sentences with an equal sign for fields", func() {
+		record := ParseMessage("2019-07-04T10:01:02Z [!]  the value of --nodes=3 is ignored")
+
+		Expect(record.Level).To(Equal("warning"))
+		Expect(record.Component).To(Equal(DefaultComponent))
+		Expect(record.Fields).To(BeNil())
+	})
+
+	It("keeps messages of unknown format", func() {
+		record := ParseMessage("plain text\n")
+
+		Expect(record.Level).To(Equal("unknown"))
+		Expect(record.Message).To(Equal("plain text"))
+	})
+
+	It("writes one JSON record per message", func() {
+		buf := &bytes.Buffer{}
+		w := NewJSONWriter(buf)
+
+		_, err := w.Write([]byte("2019-07-04T10:01:02Z [✔]  created cluster\n"))
+		Expect(err).ToNot(HaveOccurred())
+		_, err = w.Write([]byte("2019-07-04T10:01:03Z [▶]  waiting\n"))
+		Expect(err).ToNot(HaveOccurred())
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		Expect(lines).To(HaveLen(2))
+
+		record := Record{}
+		Expect(json.Unmarshal([]byte(lines[0]), &record)).To(Succeed())
+		Expect(record.Level).To(Equal("success"))
+		Expect(record.Message).To(Equal("created cluster"))
+		Expect(lines[1]).To(ContainSubstring(`"level":"debug"`))
+	})
+
+	It("rejects unknown formats", func() {
+		Expect(Configure(FormatText, &bytes.Buffer{})).To(Succeed())
+		Expect(Configure("xml", &bytes.Buffer{})).ToNot(Succeed())
+	})
+
+	It("sets up the logger to write JSON records", func() {
+		defer func(color, fabulous, timestamps bool) {
+			logger.Color, logger.Fabulous, logger.Timestamps = color, fabulous, timestamps
+		}(logger.Color, logger.Fabulous, logger.Timestamps)
+		defer func(output io.Writer, noColor bool) {
+			color.Output, color.NoColor = output, noColor
+		}(color.Output, color.NoColor)
+
+		buf := &bytes.Buffer{}
+		Expect(Configure(FormatJSON, buf)).To(Succeed())
+		Expect(logger.Color).To(BeTrue())
+		Expect(logger.Timestamps).To(BeTrue())
+		Expect(color.Output).To(BeAssignableToTypeOf(&JSONWriter{}))
+	})
+})
@@ -0,0 +1,80 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/kris-nova/logger"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Levels of --verbose, which is the level of github.com/kris-nova/logger
+const (
+	// LevelQuiet only shows warnings and errors, it's the level of --quiet
+	LevelQuiet = 2
+	// LevelInfo is the default level, long operations show a spinner on terminals
+	LevelInfo = 3
+	// LevelDebug adds debug messages and timestamps, waiters log every poll
+	// and the stacks of clusters are logged when they are described
+	LevelDebug = 4
+	// LevelAWSDebug adds the requests and responses of the AWS SDK
+	LevelAWSDebug = 5
+)
+
+// OutputOptions are the global flags that control logs and output
+type OutputOptions struct {
+	// Level is the value of --verbose
+	Level int
+	// Quiet only shows warnings, errors and the final results of commands
+	Quiet bool
+	// Color is the value of --color, i.e. true, false or fabulous
+	Color string
+	// Format is the value of --log-format
+	Format string
+}
+
+// Apply validates the options and configures the logger, log messages and spinners are
+// written to w; --quiet cannot be combined with a --verbose other than the default
+func (o OutputOptions) Apply(w io.Writer, verboseSet bool) error {
+	if o.Quiet && verboseSet && o.Level != LevelQuiet {
+		return fmt.Errorf("--quiet and --verbose=%d cannot be used together", o.Level)
+	}
+	logger.Level = o.Level
+	if o.Quiet {
+		logger.Level = LevelQuiet
+	}
+	logger.Color = o.Color == "true"
+	logger.Fabulous = o.Color == "fabulous"
+	logger.Timestamps = IsDebug()
+	if err := Configure(o.Format, w); err != nil {
+		return err
+	}
+	// spinners only show the latest progress, they replace the per-poll
+	// messages of the default level but would hide debug messages
+	if o.Format == FormatText && logger.Color && logger.Level == LevelInfo && IsTerminal(w) {
+		EnableSpinner(w)
+	}
+	return nil
+}
+
+// IsQuiet returns true when info messages are suppressed
+func IsQuiet() bool {
+	return logger.Level <= LevelQuiet
+}
+
+// IsDebug returns true when debug messages are logged
+func IsDebug() bool {
+	return logger.Level >= LevelDebug
+}
+
+// IsAWSDebug returns true when requests and responses of the AWS SDK are logged
+func IsAWSDebug() bool {
+	return logger.Level >= LevelAWSDebug
+}
+
+// IsTerminal returns true when w is a terminal
+func IsTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && terminal.IsTerminal(int(f.Fd()))
+}
@@ -0,0 +1,93 @@
+package logging_test
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/kris-nova/logger"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	. "github.com/weaveworks/eksctl/pkg/logging"
+)
+
+var _ = Describe("Output", func() {
+	var (
+		level                        int
+		colors, fabulous, timestamps bool
+		output                       io.Writer
+	)
+
+	BeforeEach(func() {
+		level, colors, fabulous, timestamps = logger.Level, logger.Color, logger.Fabulous, logger.Timestamps
+		output = color.Output
+	})
+
+	AfterEach(func() {
+		DisableSpinner()
+		logger.Level, logger.Color, logger.Fabulous, logger.Timestamps = level, colors, fabulous, timestamps
+		color.Output = output
+	})
+
+	It("maps --quiet to warnings and errors", func() {
+		Expect(OutputOptions{Level: LevelInfo, Quiet: true, Color: "true", Format: FormatText}.Apply(&bytes.Buffer{}, false)).To(Succeed())
+		Expect(logger.Level).To(Equal(LevelQuiet))
+		Expect(IsQuiet()).To(BeTrue())
+		Expect(IsDebug()).To(BeFalse())
+		Expect(logger.Timestamps).To(BeFalse())
+	})
+
+	It("rejects --quiet with a --verbose level", func() {
+		err := OutputOptions{Level: LevelDebug, Quiet: true, Color: "true", Format: FormatText}.Apply(&bytes.Buffer{}, true)
+		Expect(err).To(MatchError("--quiet and --verbose=4 cannot be used together"))
+	})
+
+	It("maps verbose levels to debug and AWS debug logging", func() {
+		Expect(OutputOptions{Level: LevelDebug, Color: "false", Format: FormatText}.Apply(&bytes.Buffer{}, true)).To(Succeed())
+		Expect(IsDebug()).To(BeTrue())
+		Expect(IsAWSDebug()).To(BeFalse())
+		Expect(logger.Timestamps).To(BeTrue())
+		Expect(logger.Color).To(BeFalse())
+
+		logger.Level = LevelAWSDebug
+		Expect(IsAWSDebug()).To(BeTrue())
+	})
+
+	It("logs progress when spinners are disabled", func() {
+		Expect(OutputOptions{Level: LevelInfo, Color: "true", Format: FormatText}.Apply(&bytes.Buffer{}, false)).To(Succeed())
+		buf := &bytes.Buffer{}
+		color.Output = buf
+		p := StartProgress()
+		p.Update("waiting for stack")
+		p.Done()
+		Expect(buf.String()).To(ContainSubstring("waiting for stack"))
+	})
+
+	It("shows progress next to the spinner and clears it for log messages", func() {
+		logs := &bytes.Buffer{}
+		color.Output = logs
+		spinner := &bytes.Buffer{}
+		EnableSpinner(spinner)
+
+		first, second := StartProgress(), StartProgress()
+		first.Update("waiting for stack a")
+		Expect(spinner.String()).To(HaveSuffix("waiting for stack a"))
+		second.Update("waiting for stack b")
+		Expect(spinner.String()).To(HaveSuffix("waiting for stack b (and 1 more)"))
+		Expect(logs.String()).To(BeEmpty())
+
+		spinner.Reset()
+		_, err := io.WriteString(color.Output, "creating nodegroup\n")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(logs.String()).To(Equal("creating nodegroup\n"))
+		Expect(spinner.String()).To(HavePrefix("\r\x1b[K"))
+		Expect(spinner.String()).To(HaveSuffix("waiting for stack b (and 1 more)"))
+
+		second.Done()
+		Expect(spinner.String()).To(HaveSuffix("waiting for stack a"))
+		spinner.Reset()
+		first.Done()
+		Expect(spinner.String()).To(Equal("\r\x1b[K"))
+	})
+})
@@ -0,0 +1,212 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/kris-nova/logger"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	spinnerInterval = 100 * time.Millisecond
+	clearLine       = "\r\x1b[K"
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinner shows the latest message of the active progresses on a single line,
+// which is cleared before each log message and redrawn after it
+type spinner struct {
+	mutex      sync.Mutex
+	out        io.Writer
+	progresses []*Progress
+	frame      int
+	drawn      bool
+	stop       chan struct{}
+}
+
+var (
+	spinnerMutex  sync.Mutex
+	activeSpinner *spinner
+)
+
+// EnableSpinner shows a spinner on w while progresses are active, the logger
+// writes through color.Output when colors are enabled, which is wrapped so
+// that log messages don't end up on the line of the spinner
+func EnableSpinner(w io.Writer) {
+	s := &spinner{out: w}
+	color.Output = &spinnerWriter{spinner: s, out: color.Output}
+
+	spinnerMutex.Lock()
+	defer spinnerMutex.Unlock()
+	activeSpinner = s
+}
+
+// DisableSpinner stops showing spinners, progresses are logged instead
+func DisableSpinner() {
+	spinnerMutex.Lock()
+	s := activeSpinner
+	activeSpinner = nil
+	spinnerMutex.Unlock()
+
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.clear()
+	s.stopTicker()
+	s.progresses = nil
+	if w, ok := color.Output.(*spinnerWriter); ok && w.spinner == s {
+		color.Output = w.out
+	}
+}
+
+// Progress is a long operation, e.g. a wait for a stack, that shows its status
+// next to the spinner on terminals, or logs it otherwise
+type Progress struct {
+	spinner *spinner
+	msg     string
+}
+
+// StartProgress starts a progress, Done must be called when the operation completes
+func StartProgress() *Progress {
+	spinnerMutex.Lock()
+	defer spinnerMutex.Unlock()
+	return &Progress{spinner: activeSpinner}
+}
+
+// Update shows msg next to the spinner, or logs it as an info message when
+// spinners are disabled, e.g. when the output isn't a terminal
+func (p *Progress) Update(msg string) {
+	if p.spinner == nil {
+		logger.Info("%s", msg)
+		return
+	}
+	s := p.spinner
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p.msg = msg
+	s.remove(p)
+	s.progresses = append(s.progresses, p)
+	s.startTicker()
+	s.draw()
+}
+
+// Done removes the progress from the spinner, which stops once no progress is active
+func (p *Progress) Done() {
+	if p.spinner == nil {
+		return
+	}
+	s := p.spinner
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.remove(p)
+	s.clear()
+	if len(s.progresses) == 0 {
+		s.stopTicker()
+		return
+	}
+	s.draw()
+}
+
+func (s *spinner) remove(p *Progress) {
+	for i, active := range s.progresses {
+		if active == p {
+			s.progresses = append(s.progresses[:i], s.progresses[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *spinner) startTicker() {
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(spinnerInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				s.mutex.Lock()
+				s.frame = (s.frame + 1) % len(spinnerFrames)
+				s.draw()
+				s.mutex.Unlock()
+			}
+		}
+	}(s.stop)
+}
+
+func (s *spinner) stopTicker() {
+	if s.stop != nil {
+		close(s.stop)
+		s.stop = nil
+	}
+}
+
+// draw replaces the line of the spinner with the message of the
+// latest progress, others are counted
+func (s *spinner) draw() {
+	if len(s.progresses) == 0 {
+		return
+	}
+	line := s.progresses[len(s.progresses)-1].msg
+	if n := len(s.progresses) - 1; n > 0 {
+		line = fmt.Sprintf("%s (and %d more)", line, n)
+	}
+	line = spinnerFrames[s.frame] + " " + line
+	fmt.Fprint(s.out, clearLine+truncate(line, s.width()))
+	s.drawn = true
+}
+
+func (s *spinner) clear() {
+	if s.drawn {
+		fmt.Fprint(s.out, clearLine)
+		s.drawn = false
+	}
+}
+
+// width returns the width of the terminal, lines that wrap couldn't be cleared
+func (s *spinner) width() int {
+	if f, ok := s.out.(*os.File); ok {
+		if width, _, err := terminal.GetSize(int(f.Fd())); err == nil && width > 0 {
+			return width - 1
+		}
+	}
+	return 0
+}
+
+func truncate(line string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(line) <= width {
+		return line
+	}
+	return string([]rune(line)[:width-1]) + "…"
+}
+
+// spinnerWriter clears the line of the spinner before each log message
+type spinnerWriter struct {
+	spinner *spinner
+	out     io.Writer
+}
+
+func (w *spinnerWriter) Write(p []byte) (int, error) {
+	w.spinner.mutex.Lock()
+	defer w.spinner.mutex.Unlock()
+	redraw := w.spinner.drawn
+	w.spinner.clear()
+	n, err := w.out.Write(p)
+	if redraw {
+		w.spinner.draw()
+	}
+	return n, err
+}
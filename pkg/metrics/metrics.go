@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+)
+
+// Job is the job metrics are pushed to a Pushgateway as
+const Job = "eksctl"
+
+// Options holds where metrics are exported to once a command completes,
+// nothing is exported when neither is set
+var Options struct {
+	// PushgatewayURL is the URL of a Prometheus Pushgateway
+	PushgatewayURL string
+	// Textfile is a file to write metrics to in the text format, e.g. for
+	// the textfile collector of node_exporter
+	Textfile string
+}
+
+// durationBuckets range from 1s to ~1h, as eksctl operations are long
+var durationBuckets = prometheus.ExponentialBuckets(1, 2, 13)
+
+var (
+	registry = prometheus.NewRegistry()
+
+	commandDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "eksctl",
+		Name:      "command_duration_seconds",
+		Help:      "Duration of eksctl commands",
+		Buckets:   durationBuckets,
+	}, []string{"command", "result"})
+
+	waitDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "eksctl",
+		Name:      "wait_duration_seconds",
+		Help:      "Duration of waiting for resources to reach a status, e.g. for stacks to reach CREATE_COMPLETE",
+		Buckets:   durationBuckets,
+	}, []string{"status", "result"})
+
+	awsAPICalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "eksctl",
+		Name:      "aws_api_calls_total",
+		Help:      "AWS API calls, by error code, which is empty when calls succeed",
+	}, []string{"service", "operation", "code"})
+
+	awsAPIRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "eksctl",
+		Name:      "aws_api_retries_total",
+		Help:      "Retries of AWS API calls",
+	}, []string{"service", "operation"})
+
+	awsAPIThrottles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "eksctl",
+		Name:      "aws_api_throttles_total",
+		Help:      "AWS API calls that were throttled",
+	}, []string{"service", "operation"})
+
+	awsAPICallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "eksctl",
+		Name:      "aws_api_call_duration_seconds",
+		Help:      "Duration of AWS API calls, including retries",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"service", "operation"})
+)
+
+func init() {
+	registry.MustRegister(commandDuration, waitDuration, awsAPICalls, awsAPIRetries, awsAPIThrottles, awsAPICallDuration)
+}
+
+// Enabled is true when metrics are exported
+func Enabled() bool {
+	return Options.PushgatewayURL != "" || Options.Textfile != ""
+}
+
+// ObserveCommand records the duration of command since start
+func ObserveCommand(command string, start time.Time, err error) {
+	commandDuration.WithLabelValues(command, result(err)).Observe(time.Since(start).Seconds())
+}
+
+// ObserveWait records the duration of waiting for status since start
+func ObserveWait(status string, start time.Time, err error) {
+	waitDuration.WithLabelValues(status, result(err)).Observe(time.Since(start).Seconds())
+}
+
+func result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
+
+// AWSAPICallHandler records AWS API calls once they are complete
+var AWSAPICallHandler = request.NamedHandler{
+	Name: "eksctlMetrics",
+	Fn: func(r *request.Request) {
+		service, operation := r.ClientInfo.ServiceName, operationName(r)
+		code := ""
+		if r.Error != nil {
+			code = "unknown"
+			if awsErr, ok := r.Error.(awserr.Error); ok {
+				code = awsErr.Code()
+			}
+		}
+		awsAPICalls.WithLabelValues(service, operation, code).Inc()
+		awsAPIRetries.WithLabelValues(service, operation).Add(float64(r.RetryCount))
+		awsAPICallDuration.WithLabelValues(service, operation).Observe(time.Since(r.Time).Seconds())
+	},
+}
+
+// AWSAPIRetryHandler records AWS API calls that are throttled, it runs after
+// each attempt that failed
+var AWSAPIRetryHandler = request.NamedHandler{
+	Name: "eksctlMetricsThrottles",
+	Fn: func(r *request.Request) {
+		if r.Error != nil && r.IsErrorThrottle() {
+			awsAPIThrottles.WithLabelValues(r.ClientInfo.ServiceName, operationName(r)).Inc()
+		}
+	},
+}
+
+func operationName(r *request.Request) string {
+	if r.Operation == nil {
+		return "?"
+	}
+	return r.Operation.Name
+}
+
+// Export pushes the metrics to the Pushgateway and writes them to the
+// textfile, as set in Options
+func Export() error {
+	if Options.Textfile != "" {
+		if err := prometheus.WriteToTextfile(Options.Textfile, registry); err != nil {
+			return errors.Wrapf(err, "writing metrics to %q", Options.Textfile)
+		}
+	}
+	if Options.PushgatewayURL != "" {
+		if err := push.New(Options.PushgatewayURL, Job).Gatherer(registry).Add(); err != nil {
+			return errors.Wrapf(err, "pushing metrics to %q", Options.PushgatewayURL)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("metrics", func() {
+	AfterEach(func() {
+		Options.PushgatewayURL = ""
+		Options.Textfile = ""
+	})
+
+	It("isn't enabled without a destination", func() {
+		Expect(Enabled()).To(BeFalse())
+		Expect(Export()).To(Succeed())
+	})
+
+	It("writes metrics to a textfile", func() {
+		dir, err := ioutil.TempDir("", "metrics")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		Options.Textfile = filepath.Join(dir, "eksctl.prom")
+		Expect(Enabled()).To(BeTrue())
+
+		ObserveCommand("eksctl create cluster", time.Now().Add(-10*time.Minute), nil)
+		ObserveWait("CREATE_COMPLETE", time.Now().Add(-5*time.Minute), errors.New("timed out"))
+		Expect(Export()).To(Succeed())
+
+		data, err := ioutil.ReadFile(Options.Textfile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`eksctl_command_duration_seconds_count{command="eksctl create cluster",result="success"} 1`))
+		Expect(string(data)).To(ContainSubstring(`eksctl_wait_duration_seconds_count{result="failure",status="CREATE_COMPLETE"} 1`))
+	})
+
+	It("pushes metrics to a Pushgateway", func() {
+		var method, path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			method, path = r.Method, r.URL.Path
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		Options.PushgatewayURL = server.URL
+		Expect(Export()).To(Succeed())
+		Expect(method).To(Equal(http.MethodPost))
+		Expect(path).To(Equal("/metrics/job/eksctl"))
+	})
+})
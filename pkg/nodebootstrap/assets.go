@@ -71,7 +71,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var __10EkscltAl2Conf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x53\x4f\x6b\xdb\x4e\x10\xbd\xeb\x53\x2c\x24\x87\xdf\x0f\xbc\x52\xe2\xb8\x39\x04\x74\x50\x63\x25\x18\x54\x27\x44\x0e\x2d\xb4\xc5\x8c\x77\xc7\xce\xe0\xd5\xac\xd8\x5d\xd9\x49\x83\xbf\x7b\x91\x65\xb5\x2e\x09\xa5\x37\xed\xbc\x99\xf7\xde\xfc\xd1\x89\xc0\xb5\x57\xc1\x48\x5f\xa3\xa2\x25\x29\xe1\x5f\x7c\xc0\x4a\x0b\xed\x6c\x2d\x89\x45\xc3\x14\xc4\xd2\x3a\xb1\x6e\x16\x68\x30\x0c\xf6\x8f\xac\x82\x1f\x96\x45\x41\xdc\x3c\x8b\xa1\xf8\x2f\x2b\x86\xff\x47\xd1\xd7\x12\xdd\x86\x14\x7e\x8f\x4e\x44\x61\x15\x18\x51\x61\x00\x0d\x01\x44\x0d\x0e\x2a\x0c\xe8\xfc\x95\x78\xc8\x6f\x27\x77\xd3\x81\xc8\x3e\x97\xf3\x71\x7e\x93\x3d\x16\xb3\x79\x17\x8b\x72\xde\x90\xb3\x5c\x21\x87\x1b\x32\x98\x26\x18\x54\xd2\x59\x4c\x7a\xae\x18\x79\x13\x9d\x88\x5b\x63\x17\x60\x04\xb0\x16\x3e\x40\x20\xf5\x87\xc6\x75\xf1\x58\xce\xf2\x87\xf9\x78\x5a\x0e\xc4\xf4\x6e\x9c\xcf\x8b\xec\x63\x5e\xf4\x8f\x59\x36\x99\xce\xca\xbf\xca\x1d\xfa\x3d\xa8\x75\xed\xb0\x65\xf9\x8e\xd8\x9e\x72\x72\x3f\x10\x93\x69\x39\xcb\xa6\xd7\xf9\x7c\x32\xfe\x27\x6e\xd3\xb2\xee\x15\xa2\xfc\x19\x55\x19\xc0\x85\xf4\xe8\x33\x69\xbc\x4b\x16\xc4\x7d\x81\xf8\x16\x09\x21\x25\x5b\x8d\x92\xea\xf4\xf4\xf5\xa0\xbc\x3b\x06\x0c\x2c\xd0\xf8\x1e\xec\xda\xde\x0d\xc0\xd4\x4f\x10\x77\xfa\x31\xd9\x84\xd8\x07\x60\x85\x92\x74\x7a\xfa\x7a\x64\xbc\xe7\xaa\xe0\x59\xd6\x56\xb7\x44\x9f\xb2\x2f\xf3\xfb\xbb\x71\xd9\x43\x0e\x57\xe4\x03\xba\xbd\x5e\x1a\x5c\x83\xc7\xc1\x2d\x85\x27\x19\x80\x38\xfc\x32\xd1\x8d\xbb\x2f\x07\x63\xec\x56\xd6\x8e\x36\x64\x70\x85\xba\x63\xe8\x30\x65\x6c\xa3\x65\xed\xec\x86\x34\xba\x14\xb6\xbe\x07\x2c\xb7\x9c\xe8\xa4\x6b\x38\x50\x85\xa9\xb6\x6a\x8d\xae\xef\x1c\xc3\xd6\xba\xb5\xac\x4d\xb3\x22\x4e\x15\x53\x5f\xc7\x24\x17\xc4\x52\x93\x4b\x13\x5b\x87\x44\x31\xb5\x23\x3d\x82\x95\xe5\x65\x87\xb7\x2b\x6a\x71\xc6\x10\xeb\x43\x46\x6d\xb5\x24\x5e\x3a\x38\xb2\x40\x15\xac\x30\xbd\x3c\x1b\x8e\xce\xce\xcf\x47\x17\xa3\x0f\xc3\x58\xaf\x5d\x8c\xca\xc5\xa7\xaf\x6f\xcf\x7a\x17\xc3\xfe\x7f\x81\xad\x8f\x95\xad\xda\x2b\x48\x6a\x68\x3c\x4a\xa8\xf4\xe5\xe8\xea\x22\x3e\x3f\x88\xb5\x7b\x6e\xed\xd0\xea\xcd\xbd\x74\xe1\xf8\x05\x2a\xf3\x7b\x24\xef\x25\xb6\x87\xd5\x66\x45\x3f\x03\x00\x00\xff\xff\x5c\x6d\xc6\x40\xdd\x03\x00\x00")
+var __10EkscltAl2Conf = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x53\x5d\x8f\x9a\x40\x14\x7d\xe7\x57\x4c\xb2\x7d\x68\x13\x46\x92\x7d\x6c\xc2\x03\x55\x34\xa4\x88\x46\x30\x6d\xd2\x36\x64\x64\x46\xf7\xc6\x61\x86\x0c\x83\xba\x6d\xfc\xef\x7b\x11\xb1\x24\x5d\x4d\xdf\xb8\x73\xee\x3d\xe7\xdc\x0f\x9e\x88\xd8\xd7\x85\x95\xb4\xae\x44\x01\x5b\x28\x48\xfd\x5a\x5b\x51\x72\xc2\x8d\xae\x28\x28\xd2\x28\xb0\x64\xab\x0d\xd9\x37\x1b\x21\x85\x75\x2f\x41\x50\xb2\xdf\x5a\x91\x18\x54\x73\x22\xcf\xe4\x63\x10\x3f\x7f\x72\x9c\x1f\xa9\x30\x07\x28\xc4\x2f\xe7\x89\xc4\xba\x60\x92\x94\xc2\x32\xce\x2c\x23\x15\x33\x0c\x03\x61\xea\xcf\x64\x15\xce\xa2\x45\xe2\x92\xe0\x5b\x9a\x4f\xc2\x69\xb0\x8e\xb3\xbc\x7b\x73\x42\x75\x00\xa3\x55\x29\x94\x9d\x82\x14\xbe\x27\x6c\xe1\x75\x16\xbd\x9e\x6b\x24\xd4\x01\x05\x66\x52\x6f\x50\x81\x29\x4e\x6a\xcb\x2c\x5a\x1f\x6a\x8c\xe3\x75\x9a\x85\xab\x7c\x92\xa4\x2e\x49\x16\x93\x30\x8f\x83\x2f\x61\xdc\x07\x59\x10\x25\x59\xfa\x50\xee\xda\xef\x55\xad\x6b\x47\x69\x45\xdf\x11\xbb\x50\x46\x4b\x97\x44\x49\x9a\x05\xc9\x18\x83\x89\x4b\x96\x8b\x49\x1e\x25\xd3\x55\x90\x8f\x17\x49\x2b\x88\x76\xa2\x79\x30\x0b\x5d\xf2\x75\x8d\x5e\xc2\x6c\x00\xac\xd6\x49\x16\xcd\x1f\x41\x79\x98\x4c\x96\x0b\xb4\xfd\x5f\xae\x65\xeb\xf7\xe2\xdd\x09\x4f\xa2\x48\x2d\x33\xd6\x1f\x7c\x7a\x4d\x6d\xbc\x0d\xa8\xbe\x80\xfc\x74\x08\xa1\x54\x69\x2e\x28\x54\xfe\x87\x3f\xd7\x9e\xce\x43\x40\x32\xcc\xad\x7b\xb0\x1b\xe8\xd9\x65\xb2\x7a\xc1\xa5\x5c\xf4\x47\xa0\x3d\x50\x38\x22\x55\x20\x0f\xc7\xd4\xc1\x48\x7a\xae\x92\x9d\x68\xa5\x79\x4b\x34\x0f\xbe\xe7\x38\xa7\xb4\x87\x8c\xd8\x01\xde\x9f\xb9\xe8\xf9\xd6\x34\x62\xf8\x78\x04\xfb\x42\x2d\x03\x65\x6f\x26\xba\x45\xf6\xe5\x4c\x4a\x7d\xa4\x95\x81\x03\xce\x65\x27\x78\xc7\xd0\x61\x85\xd4\x0d\x47\x4c\x1f\x80\x0b\xe3\xb3\x63\xdd\x03\x5a\xb5\x9c\xc8\x6f\x1a\x65\xa1\x14\xc8\x7d\x77\x0b\xe7\x7b\x45\x54\x28\x5e\x69\xb4\xf6\xa8\xfa\xb6\xc3\xdb\x58\x85\x3d\x6a\xb3\xa7\x95\x6c\x76\xa0\xfc\x42\x41\xcf\xaf\x80\xe2\x7a\x28\x07\xe3\x7b\xba\xb2\x1e\x3e\xb4\xfb\x1a\xc0\x68\x61\xdb\xe1\xed\xfe\x5b\x1c\xd9\x46\xfc\x9a\x81\x03\xc6\xdf\x77\x6b\xd8\xc0\x2a\x94\x6c\xd7\x76\x77\xf7\x32\x7b\x5b\xed\x51\xb4\xf4\xb0\xfb\xe7\xb8\xba\xe7\xd1\x2b\x2b\xe5\xdf\x51\xbc\x97\xd8\x5e\x61\x9b\xe5\xbc\x01\xaa\xc3\x5e\xbd\x64\x04\x00\x00")
 
 func _10EkscltAl2ConfBytes() ([]byte, error) {
 	return bindataRead(
@@ -86,12 +86,12 @@ func _10EkscltAl2Conf() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "10-eksclt.al2.conf", size: 989, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
+	info := bindataFileInfo{name: "10-eksclt.al2.conf", size: 1124, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _bootstrapAl2Sh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x52\x5d\x6b\xdb\x30\x14\x7d\xd7\xaf\x38\x73\x4d\x69\x18\x8e\xd7\xd1\x0d\xda\x26\x83\xb2\x64\x90\x87\x25\x81\xf6\x61\x23\x64\x46\x91\x6f\x66\x51\x45\x32\xd2\x75\xd6\x12\xbc\xdf\x3e\x9c\xd9\xc5\x19\x7b\x1a\x7b\x92\xce\xc7\x3d\x5c\x1d\x74\xf6\x2a\xdd\x68\x9b\x6e\x64\x28\x84\x08\xc4\x48\x1c\xc8\x7b\x7a\xd2\xdc\xc1\x52\x97\xb4\x95\xda\x74\xd8\xba\xca\x06\x62\x21\xb6\x95\x55\xac\x9d\xc5\x77\xe2\x6c\x27\x9f\xb2\xd2\xe5\xe1\x62\x80\x83\x00\x7e\x14\xda\x10\x3c\xc9\x1c\xda\x06\x96\x56\x51\xc6\xcf\x25\xa1\xf1\xdc\x22\x77\x02\x00\xf4\x16\x58\xad\x10\xc5\x87\x13\x53\x1d\x61\x3c\x6e\xd8\xcb\x3a\xc2\x7a\x8d\xf3\xf3\xd6\xd5\x0c\x37\xe2\x4f\x7c\x5b\xbd\x49\xae\xd7\xaf\xe3\x46\xbe\x05\x17\x64\x8f\x81\x00\xa9\xc2\xa1\x75\xb6\x94\x27\xae\xfc\x6f\x7d\xab\x05\x90\x3b\x4b\x18\x21\x25\x56\x29\x3d\x06\xc5\x26\xed\xb6\x1f\xee\x64\x29\x6a\x21\xe6\x8b\xc9\x34\x9b\x2d\xc7\x51\x7c\xa1\x2a\x6f\x90\x24\x41\x1b\xb2\x8c\x82\xb9\xbc\x49\xd3\xcb\xf7\xd7\xc3\xb7\xef\xae\x86\xed\x99\x1a\xc9\x14\x38\xdd\x11\xcb\x24\x97\x2c\x53\xe3\x94\x34\x89\x2e\xf7\x57\x83\x48\xcc\xe6\xf7\x0f\x77\xf3\x8f\xd3\x6c\x36\xf9\xf7\xc4\xae\xa1\x44\xe7\xfd\xc8\x87\xaf\xcb\xe9\x7f\x08\x6d\x6a\x1f\x44\x42\x04\x57\x79\x45\x27\xdd\x3c\x56\x1b\x32\xc4\x43\xb2\x7b\x9c\x81\x0b\x1d\xa0\xa4\x85\xdb\x93\xf7\x3a\x27\x7c\xbe\xfb\x92\x2d\x17\x93\x7b\x21\x94\x64\x7c\xf8\xeb\xec\xb1\x8e\x63\xc2\x68\x34\x5d\x7c\x7a\xe9\x37\x3e\xb4\xb7\xfa\xa4\xa4\xf8\xd0\x43\xf5\x1f\x8f\xed\x89\x0d\xae\x45\xb7\xc0\x38\x3e\x74\xd7\x9b\x24\xbe\xe8\xff\xca\xe6\xf3\x9c\x4e\x45\x83\x5a\x34\x9b\x88\xf0\x1c\x98\x76\x8a\x0d\x72\x49\x3b\x67\x13\x4f\xc6\xc9\xbc\xc7\x93\x95\x1b\x43\x68\xdf\xd2\x13\x02\x4b\xcf\x2f\xfc\xaf\x00\x00\x00\xff\xff\x07\x7d\xb2\xb5\x4b\x03\x00\x00")
+var _bootstrapAl2Sh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x57\x6d\x73\xdb\x36\x0c\xfe\xae\x5f\x81\x29\x69\x9d\x74\x96\xd4\xa4\x69\xee\xea\xd6\xbb\x73\x1b\xa7\xe7\x5b\xe3\xe4\x1c\xe7\xb6\x9e\xe3\xe9\x18\x89\x8e\xd9\x48\xa4\x46\xd2\x49\xbc\xd4\xfb\xed\x03\xf5\x62\x5b\xf2\x4b\xbb\x6b\x3f\x49\x22\x40\x00\x7c\x00\x3c\x84\x76\x7e\xf1\x6e\x18\xf7\x6e\x88\x1a\x5b\x96\xa2\x1a\x1c\x01\x54\x4a\xfa\xc8\x74\xf1\x99\xb0\x84\x8e\x08\x8b\x8a\x6f\x2e\x26\x1c\x5f\x2d\x6b\x34\xe1\x81\x66\x82\xc3\x2d\xd5\x7e\x4c\x1e\xfd\x44\x84\x6a\x6f\x1f\x9e\x2c\x80\x87\x31\x8b\x28\x48\x4a\x42\x60\x5c\x69\xc2\x03\xea\xeb\x69\x42\xc1\xe8\xbc\x85\x50\xa0\x0e\x00\x1b\x01\x0c\x06\x60\xef\x3e\x95\x94\x66\x36\x34\x9b\x66\xf5\x00\xdf\x86\x43\x78\xfe\x3c\xd7\x32\x9b\x8d\xf0\x5f\xf8\x6b\xf0\xd2\x79\x33\xfc\x75\xd7\x88\xdf\x82\x1e\x53\x9e\x1a\x04\xa0\xc1\x58\x40\xae\x99\x2f\x49\xaa\x27\x32\x93\x8f\x18\x3e\x42\xc1\x29\xbc\x03\x8f\xea\xc0\xa3\x77\x2a\xd0\x91\x57\x44\xef\xc6\x24\xb1\x66\x4b\x47\x0b\x04\x1f\xb1\xdb\x89\xa4\x7e\x28\x82\x3b\x2a\xf3\xe3\x45\x22\x20\x11\x84\x84\xc6\x82\xfb\x5f\x94\xe0\x4d\x3b\x35\x97\x29\x79\x99\xc0\x35\x02\xdb\x4a\x8f\xe8\x28\x13\xff\xd2\x86\xec\x64\x5f\xbf\x66\x01\xd7\x9e\x66\x35\xf8\x6d\x45\x05\xf7\xee\xc0\x97\xbf\x81\x29\x5e\xd3\x19\x90\x51\x44\x43\xc0\xc0\xe8\x3d\x95\x53\x68\x9d\x75\xea\x90\x4c\xf5\x18\x57\x98\x02\xa2\x60\x3a\x89\x21\xa4\x09\xe5\xa1\x32\x6a\x98\x46\x80\x5e\xfb\x63\xe7\xb2\xdf\xfb\xec\x9f\x75\x7a\xbd\xf3\x5e\x13\xdd\x54\x96\x1a\x0e\xc6\xd3\xe9\x5e\xb6\x3f\x5c\xf5\xda\x7e\x2e\xec\xb4\x2f\x8d\xea\x9a\xe5\x54\xfd\xa4\x7d\xda\xba\xfa\xd4\xf7\x7b\x57\xdd\x7e\xe7\xac\x6d\x54\x2b\x4b\xa9\x5a\x1e\x9d\x13\x40\xcd\x62\x71\x22\xa4\x06\x73\xb8\x3a\x08\x55\x07\x35\x55\x56\x06\x31\x34\xd3\x65\x37\x12\x24\xdc\x13\x18\xff\x1e\xca\x5c\x22\x6f\xef\x07\x07\xc3\xfd\x7d\x0b\x4b\x45\x28\x97\xf2\x7b\x26\x05\x1f\xd8\x95\xf8\xed\x61\x23\xcd\x6f\x66\x6b\x60\x4b\x7a\xcb\x94\x96\x53\x27\x66\x52\x0a\xa9\xec\x21\xda\x1f\x6c\x35\x30\xac\xba\x58\x73\xee\xaa\x1b\xcc\x08\x0d\xb0\x38\x9c\xdc\x1f\xa3\x99\xa7\x6f\x9a\x71\x55\x12\x31\xbd\x67\xd7\xed\x95\x93\x55\x30\xac\xba\x0c\xb1\x17\x27\x91\x76\xe4\x84\x6b\x16\xd3\x15\x77\x2b\xdb\xad\x14\xd6\x70\x12\x27\x7b\x99\x8d\x14\x75\x57\xe9\x50\x4c\x74\x1d\x8b\x2a\xa4\x5c\x37\x0f\xf7\xad\xda\x6a\x85\xae\x54\xa4\xcb\xe9\x83\xa9\xca\xf8\x7e\xad\x64\x5d\x01\xa3\x33\x4d\x63\x6c\x32\xec\x43\xac\x5f\x4c\x7f\xd6\x24\x1b\xda\x0c\xdf\x34\x61\x9c\xca\xb0\xd4\x6a\x59\x1e\xd7\x56\xee\x58\xeb\x44\x35\x3c\x6f\x9e\xf3\x03\x37\xf3\xe0\x32\x91\x46\xb0\x6c\xc1\x1f\x0b\xa5\x8d\x99\xec\x73\xe7\x05\x6e\x4c\x95\xd6\x8a\xd3\xcf\x67\xcf\xbc\x17\x99\xca\x5d\xc8\x24\x38\x49\xc6\x1c\x8b\x40\x51\xf4\x64\xcd\xb9\xa7\x36\x48\xa2\xc9\x2d\x56\x86\x1b\x48\x36\xac\x2d\x04\x36\x62\x41\x78\x78\x23\x1e\x7d\x16\x93\x5b\x8a\x89\xbb\x46\x4f\x17\xe7\x27\x7e\xa7\x7b\xda\x6b\xf9\x1f\xce\xbb\xfd\x56\xa7\xdb\xee\xf9\x9d\xb3\xd6\xc7\xf6\xec\xda\xb6\x0b\x96\x34\x0c\xc2\x61\x43\x87\xad\xa5\xc0\x52\x18\xee\x22\xd8\x3c\xa2\x45\x4c\x79\x41\xf9\x79\x41\xf9\x9c\xc4\x45\x68\x15\x67\xf3\x80\x52\x16\x5d\xef\x87\x97\x8e\x5c\x03\xc0\xdb\xc5\x37\xb0\x21\x9f\x7b\x22\xd1\x1e\x6a\x98\x1b\xc7\x2e\x6b\x99\xfc\xcf\xd5\x52\x74\x51\x8d\x53\xed\x86\x25\xc5\x92\xaf\x22\xdf\x6e\xde\xe3\xae\x3d\x4f\xbb\x5d\xc1\x1d\xd9\x30\x11\x8c\x6b\x43\x02\xd7\xf3\xec\xe2\x79\x86\x25\x84\x15\x94\x58\x3c\xa0\x52\x2b\x37\xf4\x4a\xd5\x30\xf3\x02\x82\xee\xf5\x06\xd8\xed\xf5\x21\x66\xf5\xad\xdc\xeb\x72\x69\x61\x04\xae\x8e\x54\x1e\xc5\x22\xde\x80\xf8\x23\x73\x7f\x9a\x34\x7c\x77\x44\x95\xec\x8c\x84\x84\xc2\x3f\x76\x39\xac\x25\x72\xcf\xab\x7b\x30\x83\xf9\x85\xbc\xfd\x14\x05\xd0\xe6\x14\xc5\xe2\x02\xc4\x4d\x70\x17\xfd\x59\xda\x53\x87\x4c\x50\x5d\xaf\xd8\xfa\x36\x9a\x4b\x7b\x57\xa1\xc4\xca\x2a\x28\xda\x57\x77\x2c\xf1\xf1\xd2\x64\xa3\x29\xc6\xa5\xe5\x84\x66\x35\x62\xa6\x01\x7c\x99\x21\xd9\x55\xfa\xda\xcb\xfc\xb8\x5a\xc4\x51\x89\xc8\x32\x8a\x43\xd2\x37\x97\x55\x49\x42\x39\xb9\xc1\xbc\x95\xb8\x61\x95\x00\x97\xc4\x48\x82\x98\x96\x7e\xab\xfb\xa1\xed\x77\x4e\x90\x79\xf6\x30\xd8\x08\x1c\x47\x61\xfe\x11\xc1\x1c\xa3\x83\xe3\x37\xee\xe1\xeb\x23\x37\x7f\x7a\x11\xd1\x68\xcb\x8b\xa9\x26\x4e\x48\x34\xf1\x8a\xf9\xc9\x61\xe1\xbe\xbd\x30\xd9\xff\x7c\xd1\xfe\x09\x46\xcd\x50\x86\x66\x2d\x25\x26\x32\xa0\xe5\xc9\x09\xb5\x8d\xb2\xb9\x81\xd6\xc9\xef\x26\x37\x34\xc2\x46\x46\x31\xce\x32\x7a\x8c\x53\x4a\x40\x38\x08\xcc\x84\x64\x21\x85\xb3\xd6\x9f\x3e\x52\xe0\x65\x1d\x36\x12\x21\x20\x6b\x82\x19\x3e\x3b\x17\xfe\x29\x4e\x3c\x9f\x3e\xd7\xab\x23\x4d\x1d\x16\x7b\x72\xb6\xaa\xaf\x9b\x67\xea\xd5\xa9\xc5\xb2\xb2\xde\x37\x73\x4e\x61\xbd\xe1\xb0\xe4\xfe\x28\x1f\x42\xf1\xf5\xb8\xdc\xeb\xdd\xf3\x13\xcc\xd5\xc5\x0f\xc0\x8a\x26\x11\x4d\x1a\x29\xfa\x33\xcc\xa5\xd7\x5b\x1a\x32\x1a\xc5\xe6\xb7\x36\x02\xd9\xdc\x76\xdd\x34\x9c\xe3\x97\x87\x47\x2f\x0f\x0e\x8e\x5e\x1d\xbd\x3e\x74\xc3\x3b\xe9\xd2\x40\xba\xbb\x4f\xad\x3f\x2e\xfd\x39\x6a\x88\xe4\x79\x77\xe6\x92\x98\xfc\x23\x38\x79\xc0\xc6\x14\xb1\x49\xb5\x97\x90\x89\xa2\x0e\x89\xc3\xe3\xa3\xc6\x2b\x17\x07\xf7\x25\x64\x57\x72\xd3\x70\x32\x42\x0b\x73\x90\x17\x0d\x51\x86\xfa\xf7\xab\xf7\xed\x4f\xed\xbe\xbf\x62\xa0\x89\x13\x5e\x2c\x34\xb5\xb7\x29\xf9\xed\xee\xc9\xc5\x79\xa7\xdb\x6f\xda\x13\xce\x1e\x11\x4b\x0f\x2f\xb9\x4a\x83\xe7\xaf\xae\xc2\x80\xe6\x39\xd9\xe2\x37\x0b\xfc\xff\xfa\xbd\x27\x32\xf5\x9d\xed\x56\x63\x16\xe7\x0e\x4d\xbe\x02\xa2\x0b\xf2\xa9\x34\x4d\x9a\xda\xb4\x75\xde\xbd\x6b\x9f\x9f\x5a\x45\xad\xec\x3e\xe5\x6f\xb3\x12\x7b\xa4\x14\x5f\x7c\xcd\x2a\x2c\xb0\x24\x34\xdf\x33\xab\xe8\x3c\x94\x14\xaf\x0d\x67\x77\x6f\xf9\x67\x0e\xec\xea\x2e\x7b\x7f\xb6\xa5\xbe\xb6\x4d\x33\xd6\x66\x50\x77\x9f\x36\xca\xb6\x6c\x5b\xc0\xbc\x65\xff\x5c\x69\x66\x19\x00\xad\xf9\x4d\xbf\x0c\x77\x22\xc5\xe3\x34\x85\x79\xb9\xf8\x76\xf2\x41\x15\x1e\xf0\xb7\x2a\x25\x6e\xfc\xf3\xba\xa1\x78\xaf\x52\xa3\x02\xe9\x2e\x08\xa5\x48\x1c\xbc\x5e\x8d\xd2\x83\x64\x5a\xa7\x7b\xbf\xe7\xae\xa8\x8c\xc3\xa6\x12\x7e\xbc\x63\xd6\x0d\xd2\x58\xd5\xcb\x23\xe4\xea\xaf\xdf\xa6\x7f\xbc\x6f\x8f\x9b\xd5\xdf\xe3\xf4\x14\x9b\x0e\xbf\x72\x4d\xe6\x65\xbe\x24\xc8\x10\x29\xd6\xff\x03\xc7\xa9\x67\x68\x9d\x10\x00\x00")
 
 func bootstrapAl2ShBytes() ([]byte, error) {
 	return bindataRead(
@@ -106,12 +106,12 @@ func bootstrapAl2Sh() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "bootstrap.al2.sh", size: 843, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
+	info := bindataFileInfo{name: "bootstrap.al2.sh", size: 4253, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _bootstrapUbuntuSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xac\x55\x61\x6f\xdb\x36\x10\xfd\xce\x5f\x71\x73\x8d\x2e\xc1\x4a\x39\x49\xd3\x02\x4d\xab\x61\x5e\xe3\x0e\xc1\xb2\xa4\xa8\x5d\x74\x43\x90\x19\x67\xf1\x64\x11\xa6\x48\x81\x3c\xd9\x4d\x0d\xef\xb7\x0f\xb4\x2c\x57\x0e\xb6\x7e\x18\x06\x7f\xb0\x8e\xf7\xee\xe9\xf4\xf4\xee\xf4\xe4\xbb\xc1\x4c\xdb\xc1\x0c\x43\x21\x44\x20\x06\xe9\x80\xbc\xa7\xcf\x9a\xdb\xb0\xd2\x15\xe5\xa8\x4d\x1b\x5b\x57\xdb\x40\x2c\x44\x5e\xdb\x8c\xb5\xb3\x30\x27\x9e\x96\xf8\x79\x5a\x39\x15\x8e\x8e\x61\x2d\x00\x56\x85\x36\x04\x9e\x50\x81\xb6\x81\xd1\x66\x34\xe5\x87\x8a\x20\x62\x5e\x83\x72\x02\x00\x40\xe7\x00\x77\x77\xd0\xeb\xaf\x0f\x40\x9b\x1e\xa4\x69\x3c\x3d\xdd\xf4\xe0\xfe\x1e\x9e\x3e\xdd\xa1\x62\x71\x4c\xfe\x05\x7f\xde\x9d\xc8\x57\xf7\x3f\xf4\x63\xfa\x35\x70\x41\x76\x4b\x08\x40\x59\xe1\x60\x87\x7c\xbd\x3b\xf3\xc4\xb5\x6f\x00\xb9\x16\x00\xca\x59\x82\x37\x30\x20\xce\x06\xb4\x08\x19\x9b\x41\xdb\x7e\x52\x62\x25\x36\x42\xdc\xdc\x5e\x8e\xa6\x57\xef\xd3\x5e\xff\x28\xab\xbd\x01\x29\x83\x36\x64\x19\x0a\xe6\xea\x62\x30\x38\x7d\xf9\x2a\x39\x7b\x71\x9e\xec\xfe\x07\x06\x99\x02\x0f\x4a\x62\x94\x0a\x19\x07\xc6\x65\x68\xa4\xae\x96\xe7\xc7\x3d\x71\x75\x33\x9e\x0c\x6f\xde\x8e\xa6\x57\x97\xff\x9d\xb1\x95\x48\x6a\xd5\xa5\x9c\xfc\xf1\x7e\xf4\x3f\x90\x46\xdd\x8f\x7b\x42\x04\x57\xfb\x8c\x0e\xb4\x59\xd4\x33\x32\xc4\x09\xd9\x25\x3c\x01\x2e\x74\x80\x0c\x2d\xb8\x25\x79\xaf\x15\xc1\x6f\xc3\xdf\xa7\xef\x6f\x2f\xc7\x42\x64\xc8\xf0\xe3\x3f\xd6\x6e\xe5\xd8\x32\xbc\x79\x33\xba\x7d\xb7\xd7\xb7\xbf\xde\x5d\x6d\x0e\x44\xea\xaf\x3b\xd1\xe6\xd1\xc3\x76\x92\x31\xde\x88\xb6\x81\xb4\xbf\x6e\x2f\x2f\x64\xff\xa8\x6b\xcb\xe8\x9e\xc3\xaa\xde\xf1\x46\xc4\x4e\x44\xb0\x58\x01\x1a\x8d\x01\x76\xdd\x4a\x5a\x84\x64\x77\xdd\x9e\x3d\x86\x65\x6c\xf6\xb0\x8c\x4d\x7b\xd6\xc0\x02\xbb\xaa\x4b\x26\xc2\x43\x60\x2a\x23\xce\x53\x20\x96\x71\x9e\x48\x09\x71\x24\x00\x9e\xc0\xe4\xf6\xf2\xf6\x22\x9a\x38\x10\x84\xc2\xd5\x46\xc1\x8c\xc0\x38\xb7\x20\x05\xc8\x40\x4b\xf2\x0f\xc0\xba\xa4\x96\x14\x02\xa3\xe7\x00\x75\xf5\x6c\xcb\xb0\x2a\x74\x56\x80\x0e\xb0\x2a\x90\x61\x45\xa0\x1c\x68\x0b\xc3\xeb\x33\x38\xda\xe7\x66\x18\x48\x81\xb3\x50\x19\xd4\x16\x9a\x9e\x54\x43\x80\x56\x41\x49\x68\x19\xd8\xc5\x9b\x57\xce\x33\xce\x0c\xc5\xb0\x74\x81\x5b\x34\x28\x1d\xd8\xbb\x70\xfc\x0c\x66\x35\x83\xe6\xef\xc3\xb6\xde\x3a\x86\xcc\x10\x7a\x28\xdc\x2a\x16\x19\x87\x6a\xf7\x48\xb9\x77\xe5\xd7\xc6\xa3\x3e\x2b\xcd\x85\xab\x19\x0a\x5c\x6a\x3b\xdf\x12\xb0\x83\xac\x0e\xec\x4a\x1d\x28\xd6\x35\x40\xcd\x81\x4c\x2e\x00\xbe\x61\xcb\xbd\xb5\xbe\x0d\xfb\x57\x40\x1c\x86\x38\x0b\x5b\x84\x00\xc8\x0d\xce\x43\x7a\xb4\x5d\x17\x3d\x54\xca\x53\x08\xe9\x49\xb2\xfd\xf5\x9a\x53\xeb\x14\x49\x5d\x75\xdc\xbb\x4b\x64\xa6\x0e\x4c\x5e\x2a\x1b\xd2\xfe\xfa\xed\xf5\xc7\xf1\x64\xf4\x61\x7a\x79\x33\x6e\x01\x25\x7e\x96\xd1\x8f\x1d\xab\x6e\xba\xa4\x06\x67\x64\x42\x4b\x7c\x3d\xfc\x79\x74\x3d\xde\x3c\x43\x53\x15\x98\x34\xfd\x26\xda\x75\x57\xc1\xa3\x51\xd9\x71\x61\x1d\x57\x22\xeb\x0c\xe3\x82\x96\xec\x16\x64\xe5\x8a\x66\x85\x73\x8b\x94\x7d\x4d\x1d\x9c\xf3\xfa\x4b\x03\x2b\x9d\xa2\xf4\x53\x83\x6a\x01\xc6\xb8\x95\xac\xbc\x5e\x6a\x43\x73\x52\xdd\xe2\xca\x29\xa9\x6d\xee\x51\x66\xce\x32\x6a\x4b\x5e\xea\x12\xe7\x94\xbe\x3c\x39\x3b\x3f\x39\x3d\x3d\x7f\x7e\xfe\xe2\x2c\x51\x0b\x9f\x50\xe6\x93\xfe\x7a\xf8\x69\x3c\xbd\x1c\xbd\x1b\x7e\xbc\x9e\x4c\x3f\x8c\x7e\xb9\xba\xbd\xd9\x24\x58\xe2\x17\x67\x71\x15\x92\xcc\x95\xf1\x95\x0c\x2a\xac\x03\x49\x2c\xd5\xcb\xf3\x8b\xe7\xc9\xe9\x5e\x59\x57\x2b\x59\x79\xb7\xd4\x8a\x7c\x8a\xab\xf0\x58\x72\x57\xa2\xb6\xe9\x2e\x6c\x5c\xd1\x42\xac\x96\x33\x6d\xa5\xd2\x3e\x1d\xb8\x8a\x07\x99\xd5\xf1\xb3\xd7\x49\x67\xce\xe6\x4d\x3e\x3a\x23\xe6\x2d\x71\xa2\x5a\xc4\xfe\xf9\x7c\x6d\xe3\x1c\xa6\xca\x65\x0b\xf2\xed\x9b\x23\x5e\x39\xbf\x90\x95\xa9\xe7\xb1\x05\xab\xdb\xba\xb9\x77\x75\x25\x95\xd7\x4b\xf2\x69\x13\xe5\x6d\xe3\x9e\xe6\x7a\xdb\x79\x7c\xf1\x5d\x5d\xf7\x89\x38\x26\x32\xde\x98\xf7\x8e\x98\x0c\xaf\x6e\x26\x7b\xcb\x6c\x97\x8e\xb3\xb9\x9e\xa7\x8f\x2d\xdf\x1c\x27\x0f\x58\xb6\x2a\xe4\x84\x5c\x7b\x92\xf3\xf8\x0d\x48\x3f\x38\x46\xa6\x5f\x9b\xe1\x18\x93\x5f\x92\x7f\x4b\x9e\x75\x1e\x3d\x73\xd0\x0e\x5a\x67\x1f\x4a\x57\x07\x19\xdd\x92\xe6\x68\x02\xed\xb5\xd7\x64\x59\x66\x28\x73\x6d\xe8\xa0\x87\x0c\x93\xcc\x73\xc4\x1d\xc7\xa1\x6a\xd6\xe2\xd7\x75\x1a\xb7\x62\xdc\xc9\xdb\x61\xbb\xfb\xe9\x7e\xd3\x13\xc7\xa2\x5d\x9e\xe8\x0f\x70\xe2\xef\x00\x00\x00\xff\xff\x6f\xa0\xbe\x70\xa5\x08\x00\x00")
+var _bootstrapUbuntuSh = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xad\x57\x6d\x6f\xdb\x36\x10\xfe\xae\x5f\x71\x53\x83\xd6\xc6\x2c\x39\x2f\x6e\x80\xba\xd1\x30\x2f\x76\x0a\xa3\x89\x1d\xd8\x0e\xba\x22\xf0\x04\x46\xa2\x6c\x2e\x12\x29\x90\x54\x5c\x37\xf5\x7e\xfb\x8e\x7a\x71\x64\x37\x29\x30\xac\x08\x10\x89\xbc\xe7\x4e\xf7\xfa\x90\x7e\xf5\x4b\xfb\x8e\xf1\xf6\x1d\x51\x4b\xcb\x52\x54\x83\x23\x80\x4a\x49\xbf\x30\x5d\x2d\x53\x96\xd2\x88\xb0\xb8\x5a\x73\x91\x71\x7c\xb5\xac\x28\xe3\x81\x66\x82\xc3\x82\x6a\x3f\x21\x5f\xfc\x54\x84\xaa\xd1\x84\x47\x0b\x60\xb5\x64\x31\x05\x49\x49\x08\x8c\x2b\x4d\x78\x40\x7d\xbd\x4e\x29\x18\xcc\x7b\x08\x05\x62\x00\x58\x04\x70\x7b\x0b\xf6\xc1\xe3\x0e\x68\x63\x83\xe7\x99\xdd\x23\x7c\x9b\xcf\xe1\xf5\xeb\x12\x65\x94\x8d\xf0\x1f\xf8\xeb\xf6\xd0\x79\x37\xff\xf5\xc0\x88\xdf\x83\x5e\x52\x9e\x1b\x04\xa0\xc1\x52\x40\x89\x7c\x5f\xee\x49\xaa\x33\x59\x00\x22\x86\x8f\x50\x70\x0a\x67\xd0\xa6\x3a\x68\xd3\x7b\x15\xe8\xb8\x5d\xb9\xef\x26\x24\xb5\x36\xb5\xd8\x02\xc1\x23\xb6\xc8\x24\xf5\x43\x11\xdc\x53\x59\xc6\x17\x8b\x80\xc4\x10\x12\x9a\x08\xee\xff\xad\x04\xf7\xec\xdc\x5c\x01\x6a\x17\x02\xd7\x08\x6c\x2b\x8f\xd1\x51\x26\x80\x9a\x42\x11\xda\xb7\x6f\x85\xc7\x6f\x1e\x37\x6f\xe0\xb7\xef\x20\xa8\x9b\xae\xf5\x52\xf0\x13\x70\xbe\xd7\xc7\x8d\xc9\xe0\xc3\x70\x3a\x9b\x7c\xf6\xaf\x86\x93\xc9\x78\xd2\x75\x8a\xed\xe1\x68\x3a\x38\xbf\x99\x0c\xfc\x52\x3e\x1c\x4c\x2b\x51\x7f\x70\xd1\xbb\xb9\x9c\xf9\x93\x9b\xd1\x6c\x78\x35\xc8\xb7\xcf\xce\xae\x3f\x5b\x2c\x49\x85\xd4\x60\x6c\xb7\x40\xad\x95\xb5\x62\x7a\x09\x22\xa5\xbc\x81\x2b\x97\xc8\xc5\xc3\xed\xd1\xbc\x09\x44\x41\xd4\xcd\x93\x59\xe4\x06\xbc\x5c\xc7\x8d\x05\x09\x1b\x51\xd3\xc2\xa2\x6e\xf1\xc7\xf3\x3a\xf2\xd6\x96\x74\xc1\x94\x96\x6b\x27\x61\x52\x0a\xa9\xec\x39\x6a\xdf\xd6\xe0\xf3\x1d\xf5\x93\x3d\x75\xec\x12\x1a\x60\x2d\x9c\xd2\x0e\xa3\x85\x85\x9a\x82\xab\xd2\x98\xe9\x86\xdd\xb2\x77\x3d\xe9\xec\x99\x0a\xb1\xa7\xb3\x58\x3b\x32\xe3\x9a\x25\x74\xd7\x4c\x67\xfe\x7c\xf0\x2d\xb0\x57\x76\x3d\x03\x79\xe0\x61\x96\xa4\x8d\xc2\x6e\x0b\xa2\x16\x36\x7c\x48\xb9\xf6\x8e\x9b\x16\x66\x15\x8c\x55\x4d\x13\x6c\x32\xec\x43\x6c\x72\x4c\x71\xd1\x24\xa6\xcd\xb0\x4e\xb3\xde\xe8\x7c\xe0\x0f\xfb\x9e\x7d\xd0\xc0\xd8\x62\x70\x1c\x85\xb3\xc3\x35\x2c\xb5\x4e\xbb\xed\xf6\xd1\xe9\x3b\xf7\xf8\x6d\xc7\x2d\x9f\xed\x98\x68\xb4\xd3\x4e\xa8\x26\x4e\x48\x34\x69\x57\xb3\xe3\xb0\xb0\x69\x3f\x99\x9c\x7d\xbe\x1e\xfc\x04\xa3\x66\x20\xd1\xac\xa5\x44\x26\x03\xba\x3b\x34\x88\x36\x60\x97\xf2\x87\xe7\xe4\xf7\xd9\x1d\x8d\xa9\x36\x62\x78\x85\x43\xca\x14\x04\x84\x83\x78\x40\x86\x61\x21\x85\xab\xde\x9f\xfe\xf5\xb8\x3f\x6d\x01\xfe\xf7\x87\xa3\x8b\x49\xcf\x3f\x1f\x8f\x66\xbd\xe1\x68\x30\xf1\x87\x57\xbd\x0f\x03\x20\x3c\x04\x43\x3c\xc3\x6b\xff\xa2\x77\x35\xbc\xfc\xdc\x82\xbd\x9e\x6f\xc1\x33\xdd\xde\x82\xbd\x3e\xb7\x4c\x37\x14\x2c\xb2\x35\xd5\x75\x58\xfa\xd0\x29\xd9\x06\x5f\x4f\xed\x1d\x3a\x19\x8d\xfb\x58\x98\xeb\xff\x91\x43\x34\x89\xa9\xa3\xb1\xa2\x3f\xc3\x5c\xce\x39\xb9\xcb\x68\x14\x89\xcc\x0a\x88\x46\xce\x78\x2e\xe3\x39\x34\xcf\xfb\xd9\xd9\x60\x7c\x61\x55\xdf\x3e\x78\x2c\xdf\x36\x3b\xad\x97\x13\x46\xb5\xda\xec\xb5\x50\x4d\x68\xd6\x1b\xab\x2a\x1b\x4a\xaa\xd7\xae\x73\xd0\xa8\x9f\x02\x25\x07\xd5\xb4\xec\xe6\xc6\x7a\xb1\xca\x68\xe9\x45\x59\xd7\x39\x3d\x3c\xee\x1c\x1e\x1d\x75\x4e\x3a\x6f\x8f\xdd\xf0\x5e\xba\x34\x90\xee\xc1\x63\xef\xd3\xd4\xdf\x16\x19\x0b\x3f\x1e\x6d\x5c\x92\x90\xaf\x82\x93\x95\x72\x03\x91\x98\xa4\xb4\x53\x92\x29\xea\x90\x24\x3c\xed\x74\x4f\xdc\xa3\x8d\x65\xd2\x51\xf6\x02\x72\x72\x3d\x79\xa9\x14\x5f\xd6\x79\xd2\xea\x5d\xf0\xaa\x1c\x57\x58\xe1\xd8\xe7\xf3\x4b\x43\xb8\xa3\x91\x90\xd4\x40\x20\xd7\x82\x50\x8a\xd4\x61\x3c\x07\xad\x24\xd3\x3a\xd7\x7d\x1a\xfd\x82\xb8\x91\xb7\x0c\x4b\xfe\x88\x14\x4c\x5d\x4b\xef\xf8\xf3\x04\xff\x12\xbb\x3f\x4f\xed\xf5\x50\xf6\xcf\xb2\xfc\x63\x8a\x93\x14\x48\xcc\xd0\xf1\xb2\x7b\x1c\xcc\x87\x5b\xbe\x57\x7b\xfb\x30\xf4\x7c\x0b\x33\x51\x94\xcf\x02\xa6\xb4\x48\xeb\xc6\xac\x9d\x68\x71\xcf\x5c\x27\x68\x68\x59\x8d\x3c\xbf\xb3\x71\x7f\xdc\x35\x3e\x2a\x0a\x6a\x29\xb2\xd8\xe4\x17\x4f\x58\x71\x8f\x99\xc6\x16\xa7\xc8\x17\x6b\x30\x2c\x5d\x19\x2d\xea\xa0\x20\x4b\x5b\xb9\x05\xbc\x6d\x04\x4b\x40\x7a\x59\x2d\x11\xbf\xa2\x98\x4c\x64\x61\xe8\x5d\x1e\x43\x63\x2b\xc3\x3b\x0e\xda\xc3\x23\x3d\x8d\x09\x0a\x0b\x9f\xc2\xc2\x80\x61\x99\x84\x12\x1c\x46\x2d\xcc\xc7\xcd\x49\x48\xee\xf0\x06\x83\xcb\x44\x28\x5d\xa1\x21\x34\xc7\x8e\x50\xcd\x16\xdc\x65\x1a\x98\x7e\xa3\x72\x7d\x2e\x34\x04\x31\x25\x12\x96\x62\x65\x94\x4c\x99\xcb\x90\x22\x29\x92\x27\xc7\x4d\x7e\xcc\xd9\x22\x50\x7d\x49\x1e\x18\x5f\xe4\x06\x50\x25\xc8\x30\x6f\x09\x53\x45\x5b\xe5\x40\xa6\x15\x8d\x23\xd3\x2e\x2f\x93\xeb\x76\xd4\x7f\x0c\x7b\x11\xb0\x43\xe2\x08\xf9\x78\xf3\xc7\xe0\x72\x30\xf3\x7b\xfd\xfe\x64\x30\x9d\x7a\xf6\xa1\x9b\xff\x99\xbb\xc8\x7f\xa6\xd0\x67\xcc\x75\xbb\xc6\x92\xe9\x3c\xfc\x1f\x93\x85\xf2\x1a\x39\xd0\x26\x61\x88\xdd\xa1\x90\x0b\xf6\x74\xf2\x6b\x10\x02\xb8\x08\xf1\x8c\x4b\x6b\x24\x56\x0a\x82\x18\x53\x47\xa5\x13\x72\xa3\x7d\x7e\x79\x33\x9d\x21\x7b\xf4\x47\x5b\x4d\xa4\x25\xc7\xd0\x52\x8d\xb1\x76\x8c\xc6\x04\x93\xa4\x2a\xc3\x97\x3d\xfc\xfc\x74\xd3\x22\x71\xba\xc4\xb4\xe4\x69\x72\x99\xa8\x9f\xb3\x7b\x8c\x59\xda\x22\x99\x09\x5b\x33\xe4\x65\xbc\x3a\x3a\x1a\x1b\x98\x3b\x2b\x7a\xb7\xc4\x56\xf6\xb4\xcc\x68\x0d\x27\x24\xfb\x5a\xc0\x12\xf4\xc0\xfb\x54\xa0\x2a\x40\x1c\x8b\x95\x93\x4a\xf6\x80\x73\xb2\xa0\x61\x5d\x19\xe3\x40\xa2\x89\x24\x71\x70\x9c\x35\x76\x32\x06\xce\x12\xb2\xa0\x3f\x22\xd1\xa7\x4c\x89\x2c\x44\xc3\xe2\x01\xcf\x5f\xe9\x21\x51\xee\xa7\x50\x24\x68\xd2\x2b\x97\x45\x73\x55\x10\xce\x1c\xfc\xad\xe0\x84\x4c\x7a\x6d\x91\xea\x36\x6e\x98\x1f\x0f\x35\xb1\x21\x98\x42\x6e\x1a\xcc\xc8\x39\xb6\x5e\x58\x21\xb6\xfe\x96\x97\x2e\xaf\xa0\xa1\xaa\x12\x54\xaf\x84\xbc\x77\xd2\x38\x5b\x18\x17\x38\xab\xf4\x16\x52\x64\xa9\x13\x62\x3a\xd0\xe7\x62\x15\x55\x8e\x17\x37\x41\xb4\x69\x0a\x59\xcf\xd3\x56\x60\xa6\xcd\x31\x1f\xd6\xdb\x0a\x9b\xd4\xcc\xb6\x2d\x90\x73\x57\x4e\x8d\xde\xfe\xe4\x14\xdb\xee\x9a\x24\x55\x16\x22\x4a\xb4\xb9\x81\x2e\xcc\xe9\xec\x4d\x84\xc6\xe7\xc7\x62\xc6\xa6\x54\xa2\x83\xe7\x54\x6a\x16\x99\x1e\xd8\x71\x87\x70\xc1\xd7\x89\xc8\x94\x63\xaa\xef\x45\x04\x2f\x05\xdb\xdc\x33\xec\x1a\x27\x20\x4e\x84\xe5\xde\xf1\x21\x20\x6e\x20\xb5\xc1\x35\xcd\xbc\x14\xec\xfa\xc4\xca\x86\x5c\xcd\x38\xe6\x73\x74\xfb\xfb\x1c\x23\x6a\x5a\x15\x07\x9b\x43\xa5\x4e\xc2\xff\x02\x31\xa5\xe2\x9a\xeb\x0d\x00\x00")
 
 func bootstrapUbuntuShBytes() ([]byte, error) {
 	return bindataRead(
@@ -126,12 +126,12 @@ func bootstrapUbuntuSh() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "bootstrap.ubuntu.sh", size: 2213, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
+	info := bindataFileInfo{name: "bootstrap.ubuntu.sh", size: 3563, mode: os.FileMode(420), modTime: time.Unix(1, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _kubeletYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x91\x4f\x4f\xc3\x30\x0c\xc5\xef\xf9\x14\xfe\x04\x6d\x07\x9a\x04\xb9\x8d\x4d\x70\x60\x27\x36\xe0\xec\xa6\xee\x16\x35\x8d\x27\xc7\x19\x7f\x3e\x3d\x5a\x5a\x90\x26\xa1\x9c\x9e\xde\xb3\xdf\x4f\xce\xe0\x63\x67\xe1\x39\xb7\x14\x48\xd7\x1c\x7b\x7f\xc8\x82\xea\x39\x1a\x3c\xf9\x37\x92\xe4\x39\x5a\x18\xa6\x40\xe5\x4a\xa2\x1a\xee\x52\xe5\xb9\x3e\x2f\x5a\x52\x5c\x18\x83\x5d\x27\x94\x92\x85\xa6\x2a\xcf\xb8\x90\x93\x92\x6c\x78\x44\x1f\x2d\xcc\xb2\x0a\xec\x30\x18\x83\x59\x8f\x14\xd5\xbb\x52\x64\x0d\x00\x46\x8e\x5f\x23\xe7\x74\x11\x00\x14\xb1\x0d\xd4\x59\xe8\x31\x24\x32\x00\x1f\xd4\x1e\x99\x87\xc9\x75\xe8\x8e\xb4\xdf\x6f\x2d\xdc\x8c\x4d\xba\x1e\x50\xc9\x97\xfc\xe7\xb2\xb9\x9f\xc3\xc1\x53\xd4\xf5\xea\xd1\x07\xb2\x50\x93\xba\x9a\x86\xe4\x34\xd4\x0e\x2b\x27\x3a\xd1\xb0\xf8\xef\x3f\x98\x91\x3b\xb2\xf0\x3e\x55\xfe\x5b\xbe\x9a\x47\xa8\x2b\x18\xcb\x5f\x8c\x62\xbe\x46\xbc\xb6\x6f\x9b\x64\x4c\x22\x39\x93\xec\xb7\xbb\x07\x66\x4d\x2a\x78\x9a\x61\x8d\x3b\x08\xe7\xd3\x46\xfc\x99\xc4\xc2\xa4\xfa\x64\x4c\x4f\xa8\x59\xe8\x09\x95\xca\x59\x5e\x58\x51\x69\xfe\xaa\x5d\x59\xb7\x26\x51\xdf\x5f\xee\x48\xf3\xb6\x9f\x00\x00\x00\xff\xff\x1f\x2f\xa9\x0f\xd0\x01\x00\x00")
+var _kubeletYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6d\x51\x4d\x4f\xc3\x30\x0c\xbd\xe7\x57\xe4\x17\xb4\x1d\x68\x12\xf4\x36\x36\xc1\x81\x9d\x58\x81\xb3\x9b\xba\x6b\xd4\x34\x9e\x1c\x67\x7c\xfc\x7a\xb2\xb4\x20\x4d\x42\xb9\xd8\x7e\xef\xf9\x3d\x39\xa3\xf5\x5d\xad\x9f\x63\x8b\x0e\x65\x4b\xbe\xb7\xc7\xc8\x20\x96\xbc\x82\x93\x7d\x43\x0e\xa9\xac\xf5\x38\x13\x0a\x93\x19\xc5\x78\x17\x0a\x4b\xe5\x79\xd5\xa2\xc0\x4a\x29\xe8\x3a\xc6\x10\x6a\x5d\x15\xf9\x29\xe3\x62\x10\xe4\x1d\x4d\x60\x93\x7c\x69\x0b\x47\x06\x5c\xa2\x47\x19\xd0\x8b\x35\xd9\xa8\x56\x5a\x83\x27\xff\x35\x51\x0c\x97\x46\x6b\xf4\xd0\x3a\x4c\xc1\x7a\x70\x01\xd3\xe8\x03\xdb\x81\x68\x9c\x51\x03\x66\xc0\xa6\xd9\xd7\xfa\x66\xaa\xc2\xb5\x40\x38\x5e\xf8\x9f\xeb\xea\x7e\x21\x3b\x9b\xac\xb6\x9b\x47\xeb\xb0\xd6\x25\x8a\x29\x71\x0c\x46\x5c\x69\xa0\x30\x2c\x73\x1a\x62\xfb\xfd\x17\x66\xa2\x2e\x51\xdf\x67\xcb\x7f\xcd\x37\x8b\x04\xbb\x1c\x63\xfd\x1b\x23\x83\xaf\x1e\xae\xe1\xdb\x84\xaa\x80\x7c\x46\x6e\xf6\x87\x07\x22\x09\xc2\x70\x5a\xc2\x2a\x73\x64\x8a\xa7\x1d\xdb\x84\xa7\x53\xe5\xae\x4f\x8a\x1e\x41\x22\xe3\x13\x08\xe6\xb3\xbc\x90\xa4\x72\xf9\xaa\x43\x5e\xb7\x45\x16\xdb\x5f\xee\x88\xcb\xb6\x1f\x1f\x2f\xa9\x0f\xd0\x01\x00\x00")
 
 func kubeletYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -13,19 +13,102 @@ function get_max_pods() {
   done < /etc/eksctl/max_pods.map
 }
 
-NODE_IP="$(curl --silent http://169.254.169.254/latest/meta-data/local-ipv4)"
+function configure_docker() {
+  local daemon_json="/etc/docker/daemon.json"
+  [[ -s "${daemon_json}" ]] || echo '{}' > "${daemon_json}"
+  # jq isn't installed on every AMI, python is as yum depends on it
+  REGISTRY_MIRROR="${REGISTRY_MIRROR:-}" INSECURE_REGISTRIES="${INSECURE_REGISTRIES:-}" DEFAULT_RUNTIME="${DEFAULT_RUNTIME:-}" python -c '
+import json, os, sys
+config = json.load(open(sys.argv[1]))
+if os.environ["REGISTRY_MIRROR"]:
+    config["registry-mirrors"] = [os.environ["REGISTRY_MIRROR"]]
+if os.environ["INSECURE_REGISTRIES"]:
+    config["insecure-registries"] = os.environ["INSECURE_REGISTRIES"].split(",")
+if os.environ["DEFAULT_RUNTIME"]:
+    config["default-runtime"] = os.environ["DEFAULT_RUNTIME"]
+json.dump(config, sys.stdout, indent=2)
+' "${daemon_json}" > "${daemon_json}.new"
+  mv "${daemon_json}.new" "${daemon_json}"
+  systemctl restart docker
+}
+
+function configure_containerd() {
+  local mirror="${REGISTRY_MIRROR:-https://registry-1.docker.io}"
+  local mirror_host="${mirror#*://}"
+  mirror_host="${mirror_host%%/*}"
+  mkdir -p /etc/containerd
+  {
+    echo '[plugins.cri]'
+    echo "  sandbox_image = \"${POD_INFRA_CONTAINER_IMAGE}\""
+    if [[ -n "${DEFAULT_RUNTIME:-}" ]] ; then
+      echo '[plugins.cri.containerd]'
+      echo "  default_runtime_name = \"${DEFAULT_RUNTIME}\""
+    fi
+    echo '[plugins.cri.cni]'
+    echo '  bin_dir = "/opt/cni/bin"'
+    echo '  conf_dir = "/etc/cni/net.d"'
+    echo '[plugins.cri.registry.mirrors."docker.io"]'
+    echo "  endpoint = [\"${mirror}\"]"
+    if [[ -s "/etc/docker/certs.d/${mirror_host}/ca.crt" ]] ; then
+      echo "[plugins.cri.registry.configs.\"${mirror_host}\".tls]"
+      echo "  ca_file = \"/etc/docker/certs.d/${mirror_host}/ca.crt\""
+    fi
+    for registry in ${INSECURE_REGISTRIES//,/ } ; do
+      echo "[plugins.cri.registry.mirrors.\"${registry}\"]"
+      echo "  endpoint = [\"https://${registry}\", \"http://${registry}\"]"
+      echo "[plugins.cri.registry.configs.\"${registry}\".tls]"
+      echo '  insecure_skip_verify = true'
+    done
+  } > /etc/containerd/config.toml
+  systemctl daemon-reload
+  systemctl enable containerd
+  systemctl restart containerd
+}
+
 INSTANCE_ID="$(curl --silent http://169.254.169.254/latest/meta-data/instance-id)"
 INSTANCE_TYPE="$(curl --silent http://169.254.169.254/latest/meta-data/instance-type)"
 
-source /etc/eksctl/kubelet.env # this can override MAX_PODS
+source /etc/eksctl/metadata.env
+source /etc/eksctl/kubelet.env # this can override MAX_PODS, POD_INFRA_CONTAINER_IMAGE and set IP_FAMILY, REGISTRY_MIRROR, CONTAINER_RUNTIME, INSECURE_REGISTRIES, DEFAULT_RUNTIME
+
+if [[ "${IP_FAMILY:-ipv4}" == "ipv6" ]] ; then
+  NODE_IP="$(curl --silent http://169.254.169.254/latest/meta-data/ipv6)"
+else
+  NODE_IP="$(curl --silent http://169.254.169.254/latest/meta-data/local-ipv4)"
+fi
+
+POD_INFRA_CONTAINER_IMAGE="${POD_INFRA_CONTAINER_IMAGE:-602401143452.dkr.ecr.${AWS_DEFAULT_REGION}.amazonaws.com/eks/pause-amd64:3.1}"
+
+if [[ "${CONTAINER_RUNTIME:-dockerd}" == "containerd" ]] ; then
+  KUBELET_CONTAINER_RUNTIME="remote"
+  KUBELET_CONTAINER_RUNTIME_ENDPOINT="unix:///run/containerd/containerd.sock"
+else
+  KUBELET_CONTAINER_RUNTIME="docker"
+  KUBELET_CONTAINER_RUNTIME_ENDPOINT="unix:///var/run/dockershim.sock"
+fi
 
 cat > /etc/eksctl/kubelet.local.env <<EOF
 NODE_IP=${NODE_IP}
 INSTANCE_ID=${INSTANCE_ID}
 INSTANCE_TYPE=${INSTANCE_TYPE}
 MAX_PODS=${MAX_PODS:-$(get_max_pods "${INSTANCE_TYPE}")}
+POD_INFRA_CONTAINER_IMAGE=${POD_INFRA_CONTAINER_IMAGE}
+KUBELET_CONTAINER_RUNTIME=${KUBELET_CONTAINER_RUNTIME}
+KUBELET_CONTAINER_RUNTIME_ENDPOINT=${KUBELET_CONTAINER_RUNTIME_ENDPOINT}
 EOF
 
+if [[ -s /etc/eksctl/proxy.env ]] ; then
+  # docker was started before the proxy drop-in was written
+  systemctl daemon-reload
+  systemctl restart docker
+fi
+
+if [[ "${CONTAINER_RUNTIME:-dockerd}" == "containerd" ]] ; then
+  configure_containerd
+elif [[ -n "${REGISTRY_MIRROR:-}${INSECURE_REGISTRIES:-}${DEFAULT_RUNTIME:-}" ]] ; then
+  configure_docker
+fi
+
 systemctl daemon-reload
 systemctl enable kubelet
 systemctl start kubelet
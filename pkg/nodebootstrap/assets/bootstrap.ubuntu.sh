@@ -13,19 +13,55 @@ function get_max_pods() {
   done < /etc/eksctl/max_pods.map
 }
 
-NODE_IP="$(curl --silent http://169.254.169.254/latest/meta-data/local-ipv4)"
+function configure_docker() {
+  local daemon_json="/etc/docker/daemon.json"
+  [[ -s "${daemon_json}" ]] || echo '{}' > "${daemon_json}"
+  python3 - "${daemon_json}" "${REGISTRY_MIRROR:-}" "${INSECURE_REGISTRIES:-}" "${DEFAULT_RUNTIME:-}" <<PY
+import json, sys
+with open(sys.argv[1]) as f:
+    config = json.load(f)
+if sys.argv[2]:
+    config["registry-mirrors"] = [sys.argv[2]]
+if sys.argv[3]:
+    config["insecure-registries"] = sys.argv[3].split(",")
+if sys.argv[4]:
+    config["default-runtime"] = sys.argv[4]
+with open(sys.argv[1], "w") as f:
+    json.dump(config, f, indent=2)
+PY
+  systemctl restart docker
+}
+
 INSTANCE_ID="$(curl --silent http://169.254.169.254/latest/meta-data/instance-id)"
 INSTANCE_TYPE="$(curl --silent http://169.254.169.254/latest/meta-data/instance-type)"
 
-source /etc/eksctl/kubelet.env # this can override MAX_PODS
+source /etc/eksctl/metadata.env
+source /etc/eksctl/kubelet.env # this can override MAX_PODS, POD_INFRA_CONTAINER_IMAGE and set IP_FAMILY, REGISTRY_MIRROR, INSECURE_REGISTRIES, DEFAULT_RUNTIME
+
+if [[ "${IP_FAMILY:-ipv4}" == "ipv6" ]] ; then
+  NODE_IP="$(curl --silent http://169.254.169.254/latest/meta-data/ipv6)"
+else
+  NODE_IP="$(curl --silent http://169.254.169.254/latest/meta-data/local-ipv4)"
+fi
 
 cat > /etc/eksctl/kubelet.local.env <<EOF
 NODE_IP=${NODE_IP}
 INSTANCE_ID=${INSTANCE_ID}
 INSTANCE_TYPE=${INSTANCE_TYPE}
 MAX_PODS=${MAX_PODS:-$(get_max_pods "${INSTANCE_TYPE}")}
+POD_INFRA_CONTAINER_IMAGE=${POD_INFRA_CONTAINER_IMAGE:-602401143452.dkr.ecr.${AWS_DEFAULT_REGION}.amazonaws.com/eks/pause-amd64:3.1}
 EOF
 
+if [[ -s /etc/eksctl/proxy.env ]] ; then
+  # docker was started before the proxy drop-in was written
+  systemctl daemon-reload
+  systemctl restart docker
+fi
+
+if [[ -n "${REGISTRY_MIRROR:-}${INSECURE_REGISTRIES:-}${DEFAULT_RUNTIME:-}" ]] ; then
+  configure_docker
+fi
+
 snap alias kubelet-eks.kubelet kubelet
 snap alias kubectl-eks.kubectl kubectl
 snap stop kubelet-eks
@@ -41,8 +77,13 @@ systemctl reset-failed
   source /etc/eksctl/kubelet.env
   source /etc/eksctl/metadata.env
 
+  KUBELET_ADDRESS="0.0.0.0"
+  if [[ "${IP_FAMILY:-ipv4}" == "ipv6" ]] ; then
+    KUBELET_ADDRESS="::"
+  fi
+
   flags=(
-    "address=0.0.0.0"
+    "address=${KUBELET_ADDRESS}"
     "node-ip=${NODE_IP}"
     "cluster-dns=${CLUSTER_DNS}"
     "max-pods=${MAX_PODS}"
@@ -50,7 +91,7 @@ systemctl reset-failed
     "authentication-token-webhook=true"
     "authorization-mode=Webhook"
     "allow-privileged=true"
-    "pod-infra-container-image=602401143452.dkr.ecr.${AWS_DEFAULT_REGION}.amazonaws.com/eks/pause-amd64:3.1"
+    "pod-infra-container-image=${POD_INFRA_CONTAINER_IMAGE}"
     "cloud-provider=aws"
     "cluster-domain=cluster.local"
     "cni-bin-dir=/opt/cni/bin"
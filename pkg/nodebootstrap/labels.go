@@ -0,0 +1,101 @@
+package nodebootstrap
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/weaveworks/eksctl/pkg/cloudconfig"
+)
+
+const (
+	kubeletEnvFile   = configDir + "kubelet.env"
+	nodeLabelsPrefix = "NODE_LABELS="
+	nodeTaintsPrefix = "NODE_TAINTS="
+)
+
+// GetNodeLabels returns the labels the kubelet registers nodes with, as set in the user data of a nodegroup
+func GetNodeLabels(userData string) (map[string]string, error) {
+	return getKubeletEnvMap(userData, nodeLabelsPrefix, "node label")
+}
+
+// GetNodeTaints returns the taints the kubelet registers nodes with, as set in the user data of a nodegroup
+func GetNodeTaints(userData string) (map[string]string, error) {
+	return getKubeletEnvMap(userData, nodeTaintsPrefix, "node taint")
+}
+
+// getKubeletEnvMap parses the comma-separated key=value pairs of a variable of the kubelet env file
+func getKubeletEnvMap(userData, prefix, kind string) (map[string]string, error) {
+	_, file, err := decodeKubeletEnv(userData)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for _, line := range strings.Split(file.Content, "\n") {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		for _, kv := range strings.Split(strings.TrimPrefix(line, prefix), ",") {
+			if kv == "" {
+				continue
+			}
+			parts := strings.SplitN(kv, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("unexpected %s %q in %s", kind, kv, kubeletEnvFile)
+			}
+			values[parts[0]] = parts[1]
+		}
+	}
+	return values, nil
+}
+
+// SetNodeLabels returns the user data of a nodegroup with the labels the kubelet
+// registers nodes with replaced by the given ones
+func SetNodeLabels(userData string, labels map[string]string) (string, error) {
+	config, file, err := decodeKubeletEnv(userData)
+	if err != nil {
+		return "", err
+	}
+
+	keys := []string{}
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	kvs := []string{}
+	for _, k := range keys {
+		kvs = append(kvs, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+	nodeLabels := nodeLabelsPrefix + strings.Join(kvs, ",")
+
+	lines := strings.Split(file.Content, "\n")
+	found := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, nodeLabelsPrefix) {
+			lines[i] = nodeLabels
+			found = true
+		}
+	}
+	if !found {
+		lines = append([]string{nodeLabels}, lines...)
+	}
+	file.Content = strings.Join(lines, "\n")
+
+	return config.Encode()
+}
+
+func decodeKubeletEnv(userData string) (*cloudconfig.CloudConfig, *cloudconfig.File, error) {
+	config, err := cloudconfig.DecodeCloudConfig(userData)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "decoding user data")
+	}
+	for i := range config.WriteFiles {
+		if config.WriteFiles[i].Path == kubeletEnvFile {
+			return config, &config.WriteFiles[i], nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%s not found in user data", kubeletEnvFile)
+}
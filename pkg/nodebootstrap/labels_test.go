@@ -0,0 +1,49 @@
+package nodebootstrap
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/cloudconfig"
+)
+
+var _ = Describe("Node labels in user data", func() {
+	newUserData := func(kubeletEnv string) string {
+		config := cloudconfig.New()
+		config.AddFile(cloudconfig.File{Path: kubeletEnvFile, Content: kubeletEnv})
+		userData, err := config.Encode()
+		Expect(err).ToNot(HaveOccurred())
+		return userData
+	}
+
+	It("reads the labels", func() {
+		labels, err := GetNodeLabels(newUserData("NODE_LABELS=role=web,alpha.eksctl.io/nodegroup-name=ng-1\nNODE_TAINTS="))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(labels).To(Equal(map[string]string{"role": "web", "alpha.eksctl.io/nodegroup-name": "ng-1"}))
+	})
+
+	It("reads the taints", func() {
+		taints, err := GetNodeTaints(newUserData("NODE_LABELS=role=gpu\nNODE_TAINTS=nvidia.com/gpu=true:NoSchedule"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(taints).To(Equal(map[string]string{"nvidia.com/gpu": "true:NoSchedule"}))
+	})
+
+	It("replaces the labels and keeps the other settings", func() {
+		userData, err := SetNodeLabels(newUserData("NODE_LABELS=role=web\nNODE_TAINTS=\nMAX_PODS=17"), map[string]string{"team": "a", "env": "prod"})
+		Expect(err).ToNot(HaveOccurred())
+
+		config, err := cloudconfig.DecodeCloudConfig(userData)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(config.WriteFiles[0].Content).To(Equal("NODE_LABELS=env=prod,team=a\nNODE_TAINTS=\nMAX_PODS=17"))
+	})
+
+	It("fails when the user data wasn't generated by eksctl", func() {
+		config := cloudconfig.New()
+		config.AddShellCommand("/etc/eks/bootstrap.sh test-cluster")
+		userData, err := config.Encode()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = GetNodeLabels(userData)
+		Expect(err).To(MatchError("/etc/eksctl/kubelet.env not found in user data"))
+	})
+})
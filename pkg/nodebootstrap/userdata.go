@@ -1,8 +1,12 @@
 package nodebootstrap
 
 import (
+	"encoding/base64"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
@@ -24,6 +28,18 @@ import (
 const (
 	configDir            = "/etc/eksctl/"
 	kubeletDropInUnitDir = "/etc/systemd/system/kubelet.service.d/"
+	dockerCertsDir       = "/etc/docker/certs.d/"
+	dockerDropInUnitDir  = "/etc/systemd/system/docker.service.d/"
+
+	containerdDropInUnitDir = "/etc/systemd/system/containerd.service.d/"
+
+	// proxyDropInUnit loads the proxy settings into the environment of a systemd unit
+	proxyDropInUnit = "[Service]\nEnvironmentFile=" + configDir + "proxy.env\n"
+
+	pauseImageRepository = "/eks/pause-amd64:3.1"
+
+	// exportBootstrapEnv makes the generated settings available to custom bootstrap commands
+	exportBootstrapEnv = "set -a; . " + configDir + "metadata.env; . " + configDir + "kubelet.env; set +a"
 )
 
 type configFile struct {
@@ -88,16 +104,52 @@ func makeClientConfigData(spec *api.ClusterConfig, ng *api.NodeGroup) ([]byte, e
 	return clientConfigData, nil
 }
 
-func clusterDNS(spec *api.ClusterConfig, ng *api.NodeGroup) string {
+func clusterDNS(spec *api.ClusterConfig, ng *api.NodeGroup) (string, error) {
 	if ng.ClusterDNS != "" {
-		return ng.ClusterDNS
+		return ng.ClusterDNS, nil
+	}
+	if spec.IPv6Enabled() {
+		if spec.Status == nil || spec.Status.ServiceIPv6CIDR == "" {
+			return "", fmt.Errorf("service IPv6 CIDR of cluster %q is not known", spec.Metadata.Name)
+		}
+		return serviceCIDRDNSIP(spec.Status.ServiceIPv6CIDR)
+	}
+	if serviceCIDR := customServiceIPv4CIDR(spec); serviceCIDR != "" {
+		return serviceCIDRDNSIP(serviceCIDR)
 	}
 	// Default service network is 10.100.0.0, but it gets set 172.20.0.0 automatically when pod network
 	// is anywhere within 10.0.0.0/8
 	if spec.VPC.CIDR != nil && spec.VPC.CIDR.IP[0] == 10 {
-		return "172.20.0.10"
+		return "172.20.0.10", nil
+	}
+	return "10.100.0.10", nil
+}
+
+// serviceCIDRDNSIP returns the 10th address of the service CIDR,
+// which is where kube-dns service is placed by EKS
+func serviceCIDRDNSIP(serviceCIDR string) (string, error) {
+	_, ipNet, err := net.ParseCIDR(serviceCIDR)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing service CIDR %q", serviceCIDR)
+	}
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		ip = ipNet.IP.To16()
+	}
+
+	dnsIP := make(net.IP, len(ip))
+	copy(dnsIP, ip)
+	// add 10 to the network address, carrying over to higher bytes
+	carry := 10
+	for i := len(dnsIP) - 1; i >= 0 && carry > 0; i-- {
+		sum := int(dnsIP[i]) + carry
+		dnsIP[i] = byte(sum)
+		carry = sum >> 8
+	}
+	if !ipNet.Contains(dnsIP) {
+		return "", fmt.Errorf("service CIDR %q is too small", serviceCIDR)
 	}
-	return "10.100.0.10"
+	return dnsIP.String(), nil
 }
 
 func makeKubeletConfigYAML(spec *api.ClusterConfig, ng *api.NodeGroup) ([]byte, error) {
@@ -113,8 +165,15 @@ func makeKubeletConfigYAML(spec *api.ClusterConfig, ng *api.NodeGroup) ([]byte,
 		return nil, err
 	}
 
+	dnsIP, err := clusterDNS(spec, ng)
+	if err != nil {
+		return nil, err
+	}
 	obj["clusterDNS"] = []string{
-		clusterDNS(spec, ng),
+		dnsIP,
+	}
+	if spec.IPv6Enabled() {
+		obj["address"] = "::"
 	}
 
 	// Add extra configuration from configfile
@@ -154,27 +213,224 @@ func makeCommonKubeletEnvParams(spec *api.ClusterConfig, ng *api.NodeGroup) []st
 	if ng.MaxPodsPerNode != 0 {
 		variables = append(variables, fmt.Sprintf("MAX_PODS=%d", ng.MaxPodsPerNode))
 	}
+	if spec.IPv6Enabled() {
+		variables = append(variables, "IP_FAMILY=ipv6")
+	}
+	if ng.ContainerRuntime != "" {
+		variables = append(variables, fmt.Sprintf("CONTAINER_RUNTIME=%s", ng.ContainerRuntime))
+	}
+	if c := ng.ContainerRuntimeConfig; c != nil {
+		if len(c.InsecureRegistries) > 0 {
+			variables = append(variables, fmt.Sprintf("INSECURE_REGISTRIES=%s", strings.Join(c.InsecureRegistries, ",")))
+		}
+		if c.DefaultRuntime != "" {
+			variables = append(variables, fmt.Sprintf("DEFAULT_RUNTIME=%s", c.DefaultRuntime))
+		}
+	}
+	if cr := spec.ContainerRuntime; cr != nil {
+		if cr.RegistryMirror != nil {
+			variables = append(variables, fmt.Sprintf("REGISTRY_MIRROR=%s", cr.RegistryMirror.Endpoint))
+		}
+		if cr.Offline != nil {
+			pauseImage := spec.MirroredImage(api.EKSImageRegistry(spec.Metadata.Region) + pauseImageRepository)
+			variables = append(variables, fmt.Sprintf("POD_INFRA_CONTAINER_IMAGE=%s", pauseImage))
+		}
+	}
 	return variables
 }
 
+// addRegistryMirrorCA makes docker trust the CA bundle of the registry mirror
+func addRegistryMirrorCA(spec *api.ClusterConfig, files configFiles) error {
+	if spec.ContainerRuntime == nil || spec.ContainerRuntime.RegistryMirror == nil {
+		return nil
+	}
+	mirror := spec.ContainerRuntime.RegistryMirror
+	if mirror.CABundle == "" {
+		return nil
+	}
+	endpoint, err := url.Parse(mirror.Endpoint)
+	if err != nil {
+		return errors.Wrapf(err, "parsing registry mirror endpoint %q", mirror.Endpoint)
+	}
+	files[dockerCertsDir+endpoint.Host+"/"] = map[string]configFile{
+		"ca.crt": {content: mirror.CABundle},
+	}
+	return nil
+}
+
+// addProxy makes docker, containerd and kubelet use the proxy of the cluster, kubeletDropInDir
+// is where the systemd unit of kubelet looks for drop-ins
+func addProxy(spec *api.ClusterConfig, files configFiles, kubeletDropInDir string) {
+	if spec.Proxy == nil {
+		return
+	}
+	noProxy := strings.Join(makeNoProxy(spec), ",")
+	variables := []string{}
+	for _, v := range []struct{ name, value string }{
+		{"HTTP_PROXY", spec.Proxy.HTTPProxy},
+		{"HTTPS_PROXY", spec.Proxy.HTTPSProxy},
+		{"NO_PROXY", noProxy},
+	} {
+		if v.value == "" {
+			continue
+		}
+		variables = append(variables,
+			fmt.Sprintf("%s=%s", v.name, v.value),
+			fmt.Sprintf("%s=%s", strings.ToLower(v.name), v.value),
+		)
+	}
+	files[configDir]["proxy.env"] = configFile{content: strings.Join(variables, "\n")}
+
+	for _, dir := range []string{dockerDropInUnitDir, containerdDropInUnitDir, kubeletDropInDir} {
+		if files[dir] == nil {
+			files[dir] = map[string]configFile{}
+		}
+		files[dir]["http-proxy.conf"] = configFile{content: proxyDropInUnit}
+	}
+}
+
+// makeNoProxy returns the destinations nodes reach without the proxy, that is the
+// instance metadata, the VPC, the services of the cluster and the AWS endpoints that
+// resolve to private addresses, in addition to the ones set in noProxy
+func makeNoProxy(spec *api.ClusterConfig) []string {
+	noProxy := []string{"localhost", "127.0.0.1", "169.254.169.254", ".internal", ".eks.amazonaws.com"}
+	if spec.VPC != nil && spec.VPC.CIDR != nil {
+		noProxy = append(noProxy, spec.VPC.CIDR.String())
+	}
+	noProxy = append(noProxy, serviceIPv4CIDR(spec))
+	if spec.Status != nil && spec.Status.Endpoint != "" {
+		if endpoint, err := url.Parse(spec.Status.Endpoint); err == nil && endpoint.Hostname() != "" {
+			noProxy = append(noProxy, endpoint.Hostname())
+		}
+	}
+	return append(noProxy, spec.Proxy.NoProxy...)
+}
+
+// serviceIPv4CIDR returns the CIDR of the services of the cluster, see clusterDNS
+// for how EKS picks the default
+func serviceIPv4CIDR(spec *api.ClusterConfig) string {
+	if serviceCIDR := customServiceIPv4CIDR(spec); serviceCIDR != "" {
+		return serviceCIDR
+	}
+	if spec.VPC != nil && spec.VPC.CIDR != nil && spec.VPC.CIDR.IP[0] == 10 {
+		return "172.20.0.0/16"
+	}
+	return "10.100.0.0/16"
+}
+
+// customServiceIPv4CIDR returns the service CIDR set in the config file, or the
+// one imported from the cluster stack when the config file doesn't have it
+func customServiceIPv4CIDR(spec *api.ClusterConfig) string {
+	if knc := spec.KubernetesNetworkConfig; knc != nil && knc.ServiceIPv4CIDR != "" {
+		return knc.ServiceIPv4CIDR
+	}
+	if spec.Status != nil {
+		return spec.Status.ServiceIPv4CIDR
+	}
+	return ""
+}
+
 func makeMetadata(spec *api.ClusterConfig) []string {
 	return []string{
 		fmt.Sprintf("AWS_DEFAULT_REGION=%s", spec.Metadata.Region),
 		fmt.Sprintf("AWS_EKS_CLUSTER_NAME=%s", spec.Metadata.Name),
 		fmt.Sprintf("AWS_EKS_ENDPOINT=%s", spec.Status.Endpoint),
+		fmt.Sprintf("AWS_EKS_CLUSTER_CA=%s", base64.StdEncoding.EncodeToString(spec.Status.CertificateAuthorityData)),
 	}
 }
 
-func makeMaxPodsMapping() string {
+// addBootstrapCommands adds custom commands of the nodegroup, and returns the
+// bootstrap script to run, unless the nodegroup overrides it; custom commands
+// can use all variables from metadata.env and kubelet.env
+func addBootstrapCommands(config *cloudconfig.CloudConfig, ng *api.NodeGroup, bootstrapScript string) []string {
+	if len(ng.PreBootstrapCommands) > 0 || ng.OverrideBootstrapCommand != nil {
+		config.AddShellSnippet(exportBootstrapEnv)
+	}
+
+	for _, command := range ng.PreBootstrapCommands {
+		config.AddShellCommand(command)
+	}
+
+	if ng.OverrideBootstrapCommand != nil {
+		config.AddShellCommand(*ng.OverrideBootstrapCommand)
+		return nil
+	}
+	return []string{bootstrapScript}
+}
+
+// MaxPodsPerNode returns the number of pods the VPC CNI can run on an instance type,
+// based on the number of its network interfaces and addresses per interface
+func MaxPodsPerNode(instanceType string) (int, bool) {
+	maxPods, ok := maxPodsPerNodeType[instanceType]
+	return maxPods, ok
+}
+
+// makeMaxPodsMapping returns the number of pods of each instance type, as read
+// by the bootstrap scripts, when the VPC CNI assigns prefixes to network
+// interfaces, each secondary address becomes a prefix of 16 addresses
+func makeMaxPodsMapping(prefixDelegation bool) string {
 	var text strings.Builder
 	for k, v := range maxPodsPerNodeType {
+		if prefixDelegation {
+			v = maxPodsWithPrefixDelegation(k, v)
+		}
 		text.WriteString(fmt.Sprintf("%s %d\n", k, v))
 	}
 	return text.String()
 }
 
+// maxPodsWithPrefixDelegation returns the number of pods of an instance type with
+// prefix delegation, capped the way EKS recommends, at 110 pods for instances with
+// less than 30 vCPUs and at 250 pods for larger instances
+func maxPodsWithPrefixDelegation(instanceType string, maxPods int) int {
+	const (
+		addressesPerPrefix = 16
+		maxPodsSmall       = 110
+		maxPodsLarge       = 250
+	)
+	limit := maxPodsSmall
+	if estimateVCPUs(instanceType) >= 30 {
+		limit = maxPodsLarge
+	}
+	// maxPods is the number of secondary addresses plus 2 pods using host networking
+	maxPods = (maxPods-2)*addressesPerPrefix + 2
+	if maxPods > limit {
+		return limit
+	}
+	return maxPods
+}
+
+// estimateVCPUs estimates the number of vCPUs of an instance type from its size,
+// e.g. 4 for xlarge and 32 for 8xlarge, bare metal instances count as large ones
+func estimateVCPUs(instanceType string) int {
+	parts := strings.SplitN(instanceType, ".", 2)
+	if len(parts) != 2 {
+		return 0
+	}
+	size := parts[1]
+	switch {
+	case size == "large":
+		return 2
+	case size == "xlarge":
+		return 4
+	case strings.HasSuffix(size, "xlarge"):
+		n, err := strconv.Atoi(strings.TrimSuffix(size, "xlarge"))
+		if err != nil {
+			return 0
+		}
+		return 4 * n
+	case strings.HasPrefix(size, "metal"):
+		return 64
+	default:
+		return 1
+	}
+}
+
 // NewUserData creates new user data for a given node image family
 func NewUserData(spec *api.ClusterConfig, ng *api.NodeGroup) (string, error) {
+	if ng.UserDataTemplate != "" {
+		return NewUserDataFromTemplate(spec, ng)
+	}
 	switch ng.AMIFamily {
 	case ami.ImageFamilyAmazonLinux2:
 		return NewUserDataForAmazonLinux2(spec, ng)
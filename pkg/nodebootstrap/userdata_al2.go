@@ -35,10 +35,15 @@ func makeAmazonLinux2Config(spec *api.ClusterConfig, ng *api.NodeGroup) (configF
 			// TODO: https://github.com/weaveworks/eksctl/issues/161
 			"ca.crt":          {content: string(spec.Status.CertificateAuthorityData)},
 			"kubeconfig.yaml": {content: string(clientConfigData)},
-			"max_pods.map":    {content: makeMaxPodsMapping()},
+			"max_pods.map":    {content: makeMaxPodsMapping(spec.PrefixDelegationEnabled())},
 		},
 	}
 
+	if err := addRegistryMirrorCA(spec, files); err != nil {
+		return nil, err
+	}
+	addProxy(spec, files, kubeletDropInUnitDir)
+
 	return files, nil
 }
 
@@ -51,17 +56,7 @@ func NewUserDataForAmazonLinux2(spec *api.ClusterConfig, ng *api.NodeGroup) (str
 		return "", err
 	}
 
-	scripts := []string{}
-
-	for _, command := range ng.PreBootstrapCommands {
-		config.AddShellCommand(command)
-	}
-
-	if ng.OverrideBootstrapCommand != nil {
-		config.AddShellCommand(*ng.OverrideBootstrapCommand)
-	} else {
-		scripts = append(scripts, "bootstrap.al2.sh")
-	}
+	scripts := addBootstrapCommands(config, ng, "bootstrap.al2.sh")
 
 	if err = addFilesAndScripts(config, files, scripts); err != nil {
 		return "", err
@@ -0,0 +1,155 @@
+package nodebootstrap
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// userDataTemplateFilePrefix marks templates that are read from a file
+const userDataTemplateFilePrefix = "file://"
+
+// UserDataTemplateData holds the values computed by eksctl that user data
+// templates can use, e.g. {{ .Endpoint }}
+type UserDataTemplateData struct {
+	ClusterName   string
+	NodeGroupName string
+	Region        string
+
+	// Endpoint is the endpoint of the Kubernetes API server
+	Endpoint string
+	// CertificateAuthority is the CA of the cluster, base64-encoded,
+	// and CertificateAuthorityData is the same CA in PEM format
+	CertificateAuthority     string
+	CertificateAuthorityData string
+	// ClusterDNS is the address of the cluster DNS service
+	ClusterDNS string
+
+	Labels map[string]string
+	Taints map[string]string
+	// MaxPods is the number of pods eksctl computes for the instance type,
+	// unless the nodegroup sets maxPodsPerNode, it's 0 when it isn't known
+	MaxPods int
+
+	// KubeletConfig is the KubeletConfiguration eksctl generates, in YAML,
+	// including kubeletExtraConfig of the nodegroup
+	KubeletConfig string
+	// Kubeconfig is the kubeconfig of the kubelet, it reads the CA from
+	// /etc/eksctl/ca.crt
+	Kubeconfig string
+}
+
+// userDataTemplateFuncs are the functions user data templates can use besides
+// the builtin ones, kvs formats a map as comma-separated key=value pairs
+var userDataTemplateFuncs = template.FuncMap{
+	"kvs":    formatKeyValues,
+	"b64enc": func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) },
+	"indent": func(n int, s string) string {
+		pad := strings.Repeat(" ", n)
+		return pad + strings.Replace(s, "\n", "\n"+pad, -1)
+	},
+}
+
+// NewUserDataFromTemplate renders the userDataTemplate of the nodegroup, which
+// replaces the user data eksctl generates
+func NewUserDataFromTemplate(spec *api.ClusterConfig, ng *api.NodeGroup) (string, error) {
+	text, err := readUserDataTemplate(ng.UserDataTemplate)
+	if err != nil {
+		return "", err
+	}
+	tmpl, err := template.New(ng.Name).Funcs(userDataTemplateFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "parsing user data template of nodegroup %q", ng.Name)
+	}
+
+	data, err := makeUserDataTemplateData(spec, ng)
+	if err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", errors.Wrapf(err, "rendering user data template of nodegroup %q", ng.Name)
+	}
+
+	logger.Debug("user-data = %s", out.String())
+	return base64.StdEncoding.EncodeToString(out.Bytes()), nil
+}
+
+// readUserDataTemplate returns the template, reading it from a file when it starts with file://
+func readUserDataTemplate(userDataTemplate string) (string, error) {
+	if !strings.HasPrefix(userDataTemplate, userDataTemplateFilePrefix) {
+		return userDataTemplate, nil
+	}
+	path := strings.TrimPrefix(userDataTemplate, userDataTemplateFilePrefix)
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "reading user data template %q", path)
+	}
+	return string(data), nil
+}
+
+func makeUserDataTemplateData(spec *api.ClusterConfig, ng *api.NodeGroup) (*UserDataTemplateData, error) {
+	if len(spec.Status.CertificateAuthorityData) == 0 {
+		return nil, errors.New("invalid cluster config: missing CertificateAuthorityData")
+	}
+
+	clientConfigData, err := makeClientConfigData(spec, ng)
+	if err != nil {
+		return nil, err
+	}
+	kubeletConfigData, err := makeKubeletConfigYAML(spec, ng)
+	if err != nil {
+		return nil, err
+	}
+	dnsIP, err := clusterDNS(spec, ng)
+	if err != nil {
+		return nil, err
+	}
+
+	maxPods := ng.MaxPodsPerNode
+	if maxPods == 0 {
+		if n, ok := MaxPodsPerNode(ng.InstanceType); ok {
+			maxPods = n
+			if spec.PrefixDelegationEnabled() {
+				maxPods = maxPodsWithPrefixDelegation(ng.InstanceType, n)
+			}
+		}
+	}
+
+	return &UserDataTemplateData{
+		ClusterName:              spec.Metadata.Name,
+		NodeGroupName:            ng.Name,
+		Region:                   spec.Metadata.Region,
+		Endpoint:                 spec.Status.Endpoint,
+		CertificateAuthority:     base64.StdEncoding.EncodeToString(spec.Status.CertificateAuthorityData),
+		CertificateAuthorityData: string(spec.Status.CertificateAuthorityData),
+		ClusterDNS:               dnsIP,
+		Labels:                   ng.Labels,
+		Taints:                   ng.Taints,
+		MaxPods:                  maxPods,
+		KubeletConfig:            string(kubeletConfigData),
+		Kubeconfig:               string(clientConfigData),
+	}, nil
+}
+
+func formatKeyValues(kv map[string]string) string {
+	keys := make([]string, 0, len(kv))
+	for k := range kv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, kv[k]))
+	}
+	return strings.Join(pairs, ",")
+}
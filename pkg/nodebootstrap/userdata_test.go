@@ -1,9 +1,14 @@
 package nodebootstrap
 
 import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
 	kubeletapi "k8s.io/kubelet/config/v1beta1"
 	"sigs.k8s.io/yaml"
 	"strconv"
@@ -13,7 +18,7 @@ import (
 var _ = Describe("User data", func() {
 	Describe("generating max pods", func() {
 		It("max pods mapping has the correct format", func() {
-			maxPods := makeMaxPodsMapping()
+			maxPods := makeMaxPodsMapping(false)
 			lines := strings.Split(strings.TrimSpace(maxPods), "\n")
 			for _, line := range lines {
 				parts := strings.Split(line, " ")
@@ -22,6 +27,20 @@ var _ = Describe("User data", func() {
 				Expect(err).ToNot(HaveOccurred())
 			}
 		})
+
+		It("max pods mapping is recomputed with prefix delegation", func() {
+			maxPods := map[string]string{}
+			for _, line := range strings.Split(strings.TrimSpace(makeMaxPodsMapping(true)), "\n") {
+				parts := strings.Split(line, " ")
+				maxPods[parts[0]] = parts[1]
+			}
+			// 3 ENIs with 10 addresses each, (29-2)*16+2 is capped at 110 below 30 vCPUs
+			Expect(maxPods["m5.large"]).To(Equal("110"))
+			// 2 ENIs with 2 addresses each, (4-2)*16+2
+			Expect(maxPods["t3.nano"]).To(Equal("34"))
+			// 8 ENIs with 30 addresses each, capped at 250 from 30 vCPUs
+			Expect(maxPods["m5.12xlarge"]).To(Equal("250"))
+		})
 	})
 
 	Describe("creating kubelet config", func() {
@@ -66,5 +85,242 @@ var _ = Describe("User data", func() {
 			Expect(kubelet.KubeReserved["memory"]).To(Equal("300Mi"))
 			Expect(kubelet.KubeReserved["ephemeral-storage"]).To(Equal("1Gi"))
 		})
+
+		It("the cluster DNS is derived from a custom service CIDR", func() {
+			clusterConfig.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{
+				ServiceIPv4CIDR: "10.96.0.0/16",
+			}
+			data, err := makeKubeletConfigYAML(clusterConfig, ng)
+			Expect(err).ToNot(HaveOccurred())
+
+			kubelet := &kubeletapi.KubeletConfiguration{}
+
+			errUnmarshal := yaml.UnmarshalStrict(data, kubelet)
+			Expect(errUnmarshal).ToNot(HaveOccurred())
+
+			Expect(kubelet.ClusterDNS).To(Equal([]string{"10.96.0.10"}))
+		})
+
+		It("the cluster DNS is derived from the service CIDR imported from the cluster stack", func() {
+			clusterConfig.Status = &api.ClusterStatus{
+				ServiceIPv4CIDR: "172.16.0.0/16",
+			}
+			data, err := makeKubeletConfigYAML(clusterConfig, ng)
+			Expect(err).ToNot(HaveOccurred())
+
+			kubelet := &kubeletapi.KubeletConfiguration{}
+
+			errUnmarshal := yaml.UnmarshalStrict(data, kubelet)
+			Expect(errUnmarshal).ToNot(HaveOccurred())
+
+			Expect(kubelet.ClusterDNS).To(Equal([]string{"172.16.0.10"}))
+		})
+
+		It("the cluster DNS is derived from the service CIDR of an IPv6 cluster", func() {
+			clusterConfig.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{
+				IPFamily: api.IPV6Family,
+			}
+			clusterConfig.Status = &api.ClusterStatus{
+				ServiceIPv6CIDR: "fd12:3456:789a::/108",
+			}
+			data, err := makeKubeletConfigYAML(clusterConfig, ng)
+			Expect(err).ToNot(HaveOccurred())
+
+			kubelet := &kubeletapi.KubeletConfiguration{}
+
+			errUnmarshal := yaml.UnmarshalStrict(data, kubelet)
+			Expect(errUnmarshal).ToNot(HaveOccurred())
+
+			Expect(kubelet.ClusterDNS).To(Equal([]string{"fd12:3456:789a::a"}))
+			Expect(kubelet.Address).To(Equal("::"))
+		})
+
+		It("the service CIDR of an IPv6 cluster must be known", func() {
+			clusterConfig.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{
+				IPFamily: api.IPV6Family,
+			}
+			_, err := makeKubeletConfigYAML(clusterConfig, ng)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("the cluster DNS set on the nodegroup takes precedence over the service CIDR", func() {
+			clusterConfig.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{
+				ServiceIPv4CIDR: "10.96.0.0/16",
+			}
+			ng.ClusterDNS = "169.254.20.10"
+			data, err := makeKubeletConfigYAML(clusterConfig, ng)
+			Expect(err).ToNot(HaveOccurred())
+
+			kubelet := &kubeletapi.KubeletConfiguration{}
+
+			errUnmarshal := yaml.UnmarshalStrict(data, kubelet)
+			Expect(errUnmarshal).ToNot(HaveOccurred())
+
+			Expect(kubelet.ClusterDNS).To(Equal([]string{"169.254.20.10"}))
+		})
+	})
+
+	Describe("configuring container runtime", func() {
+		var (
+			clusterConfig *api.ClusterConfig
+			ng            *api.NodeGroup
+		)
+		BeforeEach(func() {
+			clusterConfig = api.NewClusterConfig()
+			clusterConfig.Metadata.Region = "us-west-2"
+			ng = &api.NodeGroup{}
+		})
+
+		It("doesn't set anything by default", func() {
+			Expect(makeCommonKubeletEnvParams(clusterConfig, ng)).To(HaveLen(2))
+
+			files := configFiles{}
+			Expect(addRegistryMirrorCA(clusterConfig, files)).To(Succeed())
+			Expect(files).To(BeEmpty())
+		})
+
+		It("sets registry mirror and its CA bundle", func() {
+			clusterConfig.ContainerRuntime = &api.ClusterContainerRuntime{
+				RegistryMirror: &api.RegistryMirror{
+					Endpoint: "https://mirror.example.com:8443",
+					CABundle: "-----BEGIN CERTIFICATE-----\n...\n-----END CERTIFICATE-----\n",
+				},
+			}
+			Expect(makeCommonKubeletEnvParams(clusterConfig, ng)).To(ContainElement("REGISTRY_MIRROR=https://mirror.example.com:8443"))
+
+			files := configFiles{}
+			Expect(addRegistryMirrorCA(clusterConfig, files)).To(Succeed())
+			Expect(files).To(HaveKey("/etc/docker/certs.d/mirror.example.com:8443/"))
+			Expect(files["/etc/docker/certs.d/mirror.example.com:8443/"]["ca.crt"].content).To(Equal(clusterConfig.ContainerRuntime.RegistryMirror.CABundle))
+		})
+
+		It("uses pause image from the offline image registry", func() {
+			clusterConfig.ContainerRuntime = &api.ClusterContainerRuntime{
+				Offline: &api.OfflineConfig{ImageRegistry: "123456789012.dkr.ecr.us-west-2.amazonaws.com"},
+			}
+			Expect(makeCommonKubeletEnvParams(clusterConfig, ng)).To(ContainElement(
+				"POD_INFRA_CONTAINER_IMAGE=123456789012.dkr.ecr.us-west-2.amazonaws.com/eks/pause-amd64:3.1",
+			))
+		})
+
+		It("selects the container runtime of the nodegroup and its settings", func() {
+			ng.ContainerRuntime = api.NodeGroupContainerRuntimeContainerd
+			ng.ContainerRuntimeConfig = &api.NodeGroupContainerRuntimeConfig{
+				InsecureRegistries: []string{"registry.example.com:5000", "10.0.0.10"},
+				DefaultRuntime:     "nvidia",
+			}
+			Expect(makeCommonKubeletEnvParams(clusterConfig, ng)).To(ContainElement("CONTAINER_RUNTIME=containerd"))
+			Expect(makeCommonKubeletEnvParams(clusterConfig, ng)).To(ContainElement("INSECURE_REGISTRIES=registry.example.com:5000,10.0.0.10"))
+			Expect(makeCommonKubeletEnvParams(clusterConfig, ng)).To(ContainElement("DEFAULT_RUNTIME=nvidia"))
+		})
+	})
+
+	Describe("configuring proxy", func() {
+		var clusterConfig *api.ClusterConfig
+		BeforeEach(func() {
+			clusterConfig = api.NewClusterConfig()
+			clusterConfig.Status = &api.ClusterStatus{Endpoint: "https://ABCDEF.gr7.us-west-2.eks.amazonaws.com"}
+		})
+
+		It("doesn't set anything by default", func() {
+			files := configFiles{configDir: {}}
+			addProxy(clusterConfig, files, kubeletDropInUnitDir)
+			Expect(files).To(HaveLen(1))
+			Expect(files[configDir]).To(BeEmpty())
+		})
+
+		It("sets the proxy of docker, containerd and kubelet", func() {
+			clusterConfig.Proxy = &api.ClusterProxy{
+				HTTPSProxy: "http://proxy.example.com:3128",
+				NoProxy:    []string{".example.com"},
+			}
+			files := configFiles{
+				configDir:            {},
+				kubeletDropInUnitDir: {"10-eksclt.al2.conf": {isAsset: true}},
+			}
+			addProxy(clusterConfig, files, kubeletDropInUnitDir)
+
+			env := strings.Split(files[configDir]["proxy.env"].content, "\n")
+			Expect(env).To(ConsistOf(
+				"HTTPS_PROXY=http://proxy.example.com:3128",
+				"https_proxy=http://proxy.example.com:3128",
+				"NO_PROXY=localhost,127.0.0.1,169.254.169.254,.internal,.eks.amazonaws.com,192.168.0.0/16,10.100.0.0/16,ABCDEF.gr7.us-west-2.eks.amazonaws.com,.example.com",
+				"no_proxy=localhost,127.0.0.1,169.254.169.254,.internal,.eks.amazonaws.com,192.168.0.0/16,10.100.0.0/16,ABCDEF.gr7.us-west-2.eks.amazonaws.com,.example.com",
+			))
+			Expect(files[dockerDropInUnitDir]).To(HaveKey("http-proxy.conf"))
+			Expect(files[containerdDropInUnitDir]).To(HaveKey("http-proxy.conf"))
+			Expect(files[kubeletDropInUnitDir]).To(HaveKey("http-proxy.conf"))
+			Expect(files[kubeletDropInUnitDir]).To(HaveKey("10-eksclt.al2.conf"))
+		})
+
+		It("excludes the default service CIDR of VPCs within 10.0.0.0/8", func() {
+			clusterConfig.Proxy = &api.ClusterProxy{HTTPProxy: "http://proxy.example.com:3128"}
+			clusterConfig.VPC.CIDR = ipnet.MustParseCIDR("10.10.0.0/16")
+
+			Expect(makeNoProxy(clusterConfig)).To(ContainElement("10.10.0.0/16"))
+			Expect(makeNoProxy(clusterConfig)).To(ContainElement("172.20.0.0/16"))
+		})
+	})
+
+	Describe("rendering user data templates", func() {
+		var (
+			clusterConfig *api.ClusterConfig
+			ng            *api.NodeGroup
+		)
+		BeforeEach(func() {
+			clusterConfig = api.NewClusterConfig()
+			clusterConfig.Metadata.Name = "test-cluster"
+			clusterConfig.Metadata.Region = "us-west-2"
+			clusterConfig.Status = &api.ClusterStatus{
+				Endpoint:                 "https://test.eks.amazonaws.com",
+				CertificateAuthorityData: []byte("CA"),
+			}
+			ng = &api.NodeGroup{
+				Name:         "ng-1",
+				InstanceType: "m5.large",
+				Labels:       map[string]string{"role": "workers", "env": "test"},
+			}
+		})
+
+		decode := func(userData string) string {
+			data, err := base64.StdEncoding.DecodeString(userData)
+			Expect(err).ToNot(HaveOccurred())
+			return string(data)
+		}
+
+		It("renders the values computed by eksctl", func() {
+			ng.UserDataTemplate = "{{ .ClusterName }} {{ .Endpoint }} {{ .CertificateAuthority }} {{ kvs .Labels }} {{ .MaxPods }}"
+			userData, err := NewUserData(clusterConfig, ng)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decode(userData)).To(Equal("test-cluster https://test.eks.amazonaws.com Q0E= env=test,role=workers 29"))
+		})
+
+		It("prefers maxPodsPerNode of the nodegroup", func() {
+			ng.MaxPodsPerNode = 17
+			ng.UserDataTemplate = "{{ .MaxPods }}"
+			userData, err := NewUserData(clusterConfig, ng)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decode(userData)).To(Equal("17"))
+		})
+
+		It("reads templates from files", func() {
+			f, err := ioutil.TempFile("", "userdata")
+			Expect(err).ToNot(HaveOccurred())
+			defer os.Remove(f.Name())
+			_, err = f.WriteString("#!/bin/bash\necho {{ .NodeGroupName }}\n")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Close()).To(Succeed())
+
+			ng.UserDataTemplate = "file://" + f.Name()
+			userData, err := NewUserData(clusterConfig, ng)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decode(userData)).To(Equal("#!/bin/bash\necho ng-1\n"))
+		})
+
+		It("fails on unknown values", func() {
+			ng.UserDataTemplate = "{{ .Unknown }}"
+			_, err := NewUserData(clusterConfig, ng)
+			Expect(err).To(HaveOccurred())
+		})
 	})
 })
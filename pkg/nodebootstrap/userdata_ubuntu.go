@@ -20,8 +20,13 @@ func makeUbuntu1804Config(spec *api.ClusterConfig, ng *api.NodeGroup) (configFil
 		return nil, errors.New("invalid cluster config: missing CertificateAuthorityData")
 	}
 
+	dnsIP, err := clusterDNS(spec, ng)
+	if err != nil {
+		return nil, err
+	}
+
 	kubeletEnvParams := append(makeCommonKubeletEnvParams(spec, ng),
-		fmt.Sprintf("CLUSTER_DNS=%s", clusterDNS(spec, ng)),
+		fmt.Sprintf("CLUSTER_DNS=%s", dnsIP),
 	)
 
 	files := configFiles{
@@ -31,10 +36,15 @@ func makeUbuntu1804Config(spec *api.ClusterConfig, ng *api.NodeGroup) (configFil
 			// TODO: https://github.com/weaveworks/eksctl/issues/161
 			"ca.crt":          {content: string(spec.Status.CertificateAuthorityData)},
 			"kubeconfig.yaml": {content: string(clientConfigData)},
-			"max_pods.map":    {content: makeMaxPodsMapping()},
+			"max_pods.map":    {content: makeMaxPodsMapping(spec.PrefixDelegationEnabled())},
 		},
 	}
 
+	if err := addRegistryMirrorCA(spec, files); err != nil {
+		return nil, err
+	}
+	addProxy(spec, files, "/etc/systemd/system/snap.kubelet-eks.daemon.service.d/")
+
 	return files, nil
 }
 
@@ -47,17 +57,7 @@ func NewUserDataForUbuntu1804(spec *api.ClusterConfig, ng *api.NodeGroup) (strin
 		return "", err
 	}
 
-	scripts := []string{}
-
-	for _, command := range ng.PreBootstrapCommands {
-		config.AddShellCommand(command)
-	}
-
-	if ng.OverrideBootstrapCommand != nil {
-		config.AddShellCommand(*ng.OverrideBootstrapCommand)
-	} else {
-		scripts = append(scripts, "bootstrap.ubuntu.sh")
-	}
+	scripts := addBootstrapCommands(config, ng, "bootstrap.ubuntu.sh")
 
 	if err = addFilesAndScripts(config, files, scripts); err != nil {
 		return "", err
@@ -0,0 +1,294 @@
+package pricing
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// HoursPerMonth is the number of hours used to turn hourly rates into monthly ones
+const HoursPerMonth = 730
+
+const (
+	serviceCodeEC2 = "AmazonEC2"
+	serviceCodeEKS = "AmazonEKS"
+
+	natGatewayUsageSuffix   = "NatGateway-Hours"
+	controlPlaneUsageSuffix = "perCluster"
+)
+
+// NodeGroupEstimate holds the estimated cost of a single nodegroup
+type NodeGroupEstimate struct {
+	Name         string
+	InstanceType string
+	Count        int
+	Monthly      float64
+}
+
+// Estimate holds an estimated monthly cost breakdown of a cluster
+type Estimate struct {
+	ControlPlane float64
+	NATGateways  int
+	NATGateway   float64
+	NodeGroups   []NodeGroupEstimate
+}
+
+// Total returns the sum of all items in the estimate
+func (e *Estimate) Total() float64 {
+	total := e.ControlPlane + e.NATGateway
+	for _, ng := range e.NodeGroups {
+		total += ng.Monthly
+	}
+	return total
+}
+
+// Write prints the breakdown as a table
+func (e *Estimate) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "ITEM\tDETAILS\tMONTHLY (USD)\n")
+	fmt.Fprintf(tw, "control plane\t1 x EKS cluster\t%.2f\n", e.ControlPlane)
+	if e.NATGateways > 0 {
+		fmt.Fprintf(tw, "NAT gateways\t%d x NAT gateway\t%.2f\n", e.NATGateways, e.NATGateway)
+	}
+	for _, ng := range e.NodeGroups {
+		fmt.Fprintf(tw, "nodegroup %q\t%d x %s\t%.2f\n", ng.Name, ng.Count, ng.InstanceType, ng.Monthly)
+	}
+	fmt.Fprintf(tw, "total\t\t%.2f\n", e.Total())
+	return tw.Flush()
+}
+
+// Estimator uses the AWS Pricing API to estimate the cost of a cluster
+type Estimator struct {
+	api      pricingiface.PricingAPI
+	location string
+
+	cache map[string]float64
+}
+
+// NewEstimator creates an Estimator for a given region
+func NewEstimator(pricingAPI pricingiface.PricingAPI, region string) (*Estimator, error) {
+	location, err := regionLocation(region)
+	if err != nil {
+		return nil, err
+	}
+	return &Estimator{
+		api:      pricingAPI,
+		location: location,
+		cache:    map[string]float64{},
+	}, nil
+}
+
+// regionLocation maps a region code to the location name used by the Pricing API
+func regionLocation(region string) (string, error) {
+	for _, p := range endpoints.DefaultPartitions() {
+		if r, ok := p.Regions()[region]; ok && r.Description() != "" {
+			return r.Description(), nil
+		}
+	}
+	return "", fmt.Errorf("unable to determine pricing location for region %q", region)
+}
+
+// Estimate returns the estimated monthly on-demand cost of the cluster
+// and the given nodegroups, it doesn't account for data transfer, EBS
+// volumes or discounts from spot instances
+func (e *Estimator) Estimate(spec *api.ClusterConfig, nodeGroups []*api.NodeGroup) (*Estimate, error) {
+	estimate := &Estimate{}
+
+	hourly, err := e.controlPlaneHourly()
+	if err != nil {
+		return nil, err
+	}
+	estimate.ControlPlane = hourly * HoursPerMonth
+
+	if estimate.NATGateways = natGatewayCount(spec); estimate.NATGateways > 0 {
+		hourly, err := e.natGatewayHourly()
+		if err != nil {
+			return nil, err
+		}
+		estimate.NATGateway = hourly * HoursPerMonth * float64(estimate.NATGateways)
+	}
+
+	for _, ng := range nodeGroups {
+		instanceType := nodeGroupInstanceType(ng)
+		hourly, err := e.instanceHourly(instanceType)
+		if err != nil {
+			return nil, err
+		}
+		count := ng.NodeCount()
+		estimate.NodeGroups = append(estimate.NodeGroups, NodeGroupEstimate{
+			Name:         ng.Name,
+			InstanceType: instanceType,
+			Count:        count,
+			Monthly:      hourly * HoursPerMonth * float64(count),
+		})
+	}
+
+	return estimate, nil
+}
+
+func (e *Estimator) controlPlaneHourly() (float64, error) {
+	return e.hourlyPrice(serviceCodeEKS, controlPlaneUsageSuffix, termMatch("location", e.location))
+}
+
+func (e *Estimator) natGatewayHourly() (float64, error) {
+	return e.hourlyPrice(serviceCodeEC2, natGatewayUsageSuffix,
+		termMatch("location", e.location),
+		termMatch("productFamily", "NAT Gateway"),
+	)
+}
+
+func (e *Estimator) instanceHourly(instanceType string) (float64, error) {
+	return e.hourlyPrice(serviceCodeEC2, "",
+		termMatch("location", e.location),
+		termMatch("instanceType", instanceType),
+		termMatch("operatingSystem", "Linux"),
+		termMatch("tenancy", "Shared"),
+		termMatch("preInstalledSw", "NA"),
+		termMatch("capacitystatus", "Used"),
+	)
+}
+
+func termMatch(field, value string) *pricing.Filter {
+	return &pricing.Filter{
+		Type:  aws.String(pricing.FilterTypeTermMatch),
+		Field: aws.String(field),
+		Value: aws.String(value),
+	}
+}
+
+// hourlyPrice returns the on-demand price of the first product matching all of the
+// filters, and, if usageSuffix is set, whose usage type ends with usageSuffix
+func (e *Estimator) hourlyPrice(serviceCode, usageSuffix string, filters ...*pricing.Filter) (float64, error) {
+	descriptions := []string{serviceCode, usageSuffix}
+	for _, f := range filters {
+		descriptions = append(descriptions, *f.Field+"="+*f.Value)
+	}
+	key := strings.Join(descriptions, ",")
+	if price, ok := e.cache[key]; ok {
+		return price, nil
+	}
+
+	input := &pricing.GetProductsInput{
+		ServiceCode:   aws.String(serviceCode),
+		Filters:       filters,
+		FormatVersion: aws.String("aws_v1"),
+	}
+
+	var (
+		price float64
+		found bool
+		err   error
+	)
+	pageErr := e.api.GetProductsPages(input, func(output *pricing.GetProductsOutput, _ bool) bool {
+		for _, product := range output.PriceList {
+			if usageSuffix != "" && !strings.HasSuffix(usageType(product), usageSuffix) {
+				continue
+			}
+			price, err = onDemandPrice(product)
+			found = err == nil
+			return false
+		}
+		return true
+	})
+	if pageErr != nil {
+		return 0, errors.Wrapf(pageErr, "querying prices for %s", key)
+	}
+	if err != nil {
+		return 0, errors.Wrapf(err, "parsing price for %s", key)
+	}
+	if !found {
+		return 0, fmt.Errorf("no price found for %s", key)
+	}
+
+	e.cache[key] = price
+	return price, nil
+}
+
+func usageType(product aws.JSONValue) string {
+	attributes, _ := lookup(product, "product", "attributes")
+	usage, _ := attributes["usagetype"].(string)
+	return usage
+}
+
+// onDemandPrice extracts the USD rate from the on-demand term of a price list entry
+func onDemandPrice(product aws.JSONValue) (float64, error) {
+	terms, ok := lookup(product, "terms", "OnDemand")
+	if !ok {
+		return 0, fmt.Errorf("on-demand terms not found")
+	}
+	for _, termKey := range sortedKeys(terms) {
+		term, _ := terms[termKey].(map[string]interface{})
+		dimensions, ok := lookup(term, "priceDimensions")
+		if !ok {
+			continue
+		}
+		for _, dimensionKey := range sortedKeys(dimensions) {
+			dimension, _ := dimensions[dimensionKey].(map[string]interface{})
+			perUnit, ok := lookup(dimension, "pricePerUnit")
+			if !ok {
+				continue
+			}
+			usd, ok := perUnit["USD"].(string)
+			if !ok {
+				continue
+			}
+			return strconv.ParseFloat(usd, 64)
+		}
+	}
+	return 0, fmt.Errorf("USD price not found")
+}
+
+func lookup(value map[string]interface{}, path ...string) (map[string]interface{}, bool) {
+	for _, key := range path {
+		next, ok := value[key].(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		value = next
+	}
+	return value, true
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// natGatewayCount returns the number of NAT gateways eksctl will create
+func natGatewayCount(spec *api.ClusterConfig) int {
+	if spec.VPC == nil || spec.VPC.ID != "" || spec.VPC.NAT == nil || spec.VPC.NAT.Gateway == nil {
+		return 0
+	}
+	switch *spec.VPC.NAT.Gateway {
+	case api.ClusterHighlyAvailableNAT:
+		return len(spec.AvailabilityZones)
+	case api.ClusterSingleNAT:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// nodeGroupInstanceType returns the instance type used for the estimate, for
+// mixed instances nodegroups that's the first type in the list
+func nodeGroupInstanceType(ng *api.NodeGroup) string {
+	if api.HasMixedInstances(ng) {
+		return ng.InstancesDistribution.InstanceTypes[0]
+	}
+	return ng.InstanceType
+}
@@ -0,0 +1,181 @@
+package pricing_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awspricing "github.com/aws/aws-sdk-go/service/pricing"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/pricing"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+func priceListEntry(usageType, usd string) aws.JSONValue {
+	return aws.JSONValue{
+		"product": map[string]interface{}{
+			"attributes": map[string]interface{}{
+				"usagetype": usageType,
+			},
+		},
+		"terms": map[string]interface{}{
+			"OnDemand": map[string]interface{}{
+				"SKU.JRTCKXETXF": map[string]interface{}{
+					"priceDimensions": map[string]interface{}{
+						"SKU.JRTCKXETXF.6YS6EN2CT7": map[string]interface{}{
+							"unit": "Hrs",
+							"pricePerUnit": map[string]interface{}{
+								"USD": usd,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func hasFilter(input *awspricing.GetProductsInput, field, value string) bool {
+	for _, f := range input.Filters {
+		if *f.Field == field && *f.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func mockGetProducts(p *mockprovider.MockProvider, matcher func(*awspricing.GetProductsInput) bool, entries ...aws.JSONValue) {
+	p.MockPricing().On("GetProductsPages", mock.MatchedBy(matcher), mock.Anything).Run(func(args mock.Arguments) {
+		consume := args[1].(func(*awspricing.GetProductsOutput, bool) bool)
+		consume(&awspricing.GetProductsOutput{PriceList: entries}, true)
+	}).Return(nil)
+}
+
+var _ = Describe("Cost estimation", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg = api.NewClusterConfig()
+		cfg.AvailabilityZones = []string{"us-west-2a", "us-west-2b", "us-west-2c"}
+
+		mockGetProducts(p, func(input *awspricing.GetProductsInput) bool {
+			return *input.ServiceCode == "AmazonEKS" && hasFilter(input, "location", "US West (Oregon)")
+		}, priceListEntry("USW2-AmazonEKS-Hours:perCluster", "0.2000000000"))
+
+		mockGetProducts(p, func(input *awspricing.GetProductsInput) bool {
+			return *input.ServiceCode == "AmazonEC2" && hasFilter(input, "productFamily", "NAT Gateway")
+		}, priceListEntry("USW2-NatGateway-Bytes", "0.0450000000"), priceListEntry("USW2-NatGateway-Hours", "0.0450000000"))
+
+		mockGetProducts(p, func(input *awspricing.GetProductsInput) bool {
+			return *input.ServiceCode == "AmazonEC2" && hasFilter(input, "instanceType", "m5.large")
+		}, priceListEntry("USW2-BoxUsage:m5.large", "0.0960000000"))
+
+		mockGetProducts(p, func(input *awspricing.GetProductsInput) bool {
+			return *input.ServiceCode == "AmazonEC2" && hasFilter(input, "instanceType", "c5.xlarge")
+		}, priceListEntry("USW2-BoxUsage:c5.xlarge", "0.1700000000"))
+
+		mockGetProducts(p, func(input *awspricing.GetProductsInput) bool {
+			return *input.ServiceCode == "AmazonEC2" && hasFilter(input, "instanceType", "x9.nonexistent")
+		})
+	})
+
+	It("should fail for an unknown region", func() {
+		_, err := NewEstimator(p.Pricing(), "moon-1")
+		Expect(err).To(MatchError(`unable to determine pricing location for region "moon-1"`))
+	})
+
+	It("should estimate the control plane, NAT gateway and nodegroups", func() {
+		ng1 := cfg.NewNodeGroup()
+		ng1.Name = "ng-1"
+		ng1.InstanceType = "m5.large"
+		ng1.DesiredCapacity = aws.Int(3)
+
+		ng2 := cfg.NewNodeGroup()
+		ng2.Name = "ng-2"
+		ng2.InstanceType = "mixed"
+		ng2.MinSize = aws.Int(1)
+		ng2.InstancesDistribution = &api.NodeGroupInstancesDistribution{
+			InstanceTypes: []string{"c5.xlarge", "m5.large"},
+		}
+
+		estimator, err := NewEstimator(p.Pricing(), "us-west-2")
+		Expect(err).ToNot(HaveOccurred())
+
+		estimate, err := estimator.Estimate(cfg, cfg.NodeGroups)
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(estimate.ControlPlane).To(BeNumerically("~", 146, 0.001))
+		Expect(estimate.NATGateways).To(Equal(1))
+		Expect(estimate.NATGateway).To(BeNumerically("~", 32.85, 0.001))
+		Expect(estimate.NodeGroups).To(HaveLen(2))
+		Expect(estimate.NodeGroups[0].Count).To(Equal(3))
+		Expect(estimate.NodeGroups[0].Monthly).To(BeNumerically("~", 210.24, 0.001))
+		Expect(estimate.NodeGroups[1].InstanceType).To(Equal("c5.xlarge"))
+		Expect(estimate.NodeGroups[1].Count).To(Equal(1))
+		Expect(estimate.NodeGroups[1].Monthly).To(BeNumerically("~", 124.1, 0.001))
+		Expect(estimate.Total()).To(BeNumerically("~", 513.19, 0.001))
+
+		buf := &bytes.Buffer{}
+		Expect(estimate.Write(buf)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring(`nodegroup "ng-1"  3 x m5.large`))
+		Expect(buf.String()).To(ContainSubstring("total"))
+		Expect(buf.String()).To(ContainSubstring("513.19"))
+	})
+
+	It("should count one NAT gateway per zone in highly available mode", func() {
+		*cfg.VPC.NAT.Gateway = api.ClusterHighlyAvailableNAT
+
+		estimator, err := NewEstimator(p.Pricing(), "us-west-2")
+		Expect(err).ToNot(HaveOccurred())
+
+		estimate, err := estimator.Estimate(cfg, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(estimate.NATGateways).To(Equal(3))
+		Expect(estimate.NATGateway).To(BeNumerically("~", 98.55, 0.001))
+	})
+
+	It("should not count NAT gateways for an existing VPC", func() {
+		cfg.VPC.ID = "vpc-123"
+
+		estimator, err := NewEstimator(p.Pricing(), "us-west-2")
+		Expect(err).ToNot(HaveOccurred())
+
+		estimate, err := estimator.Estimate(cfg, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(estimate.NATGateways).To(Equal(0))
+		Expect(estimate.Total()).To(BeNumerically("~", 146, 0.001))
+	})
+
+	It("should fail when there is no price for an instance type", func() {
+		ng := cfg.NewNodeGroup()
+		ng.InstanceType = "x9.nonexistent"
+
+		estimator, err := NewEstimator(p.Pricing(), "us-west-2")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = estimator.Estimate(cfg, cfg.NodeGroups)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("no price found"))
+	})
+
+	It("should surface errors from the Pricing API", func() {
+		p = mockprovider.NewMockProvider()
+		p.MockPricing().On("GetProductsPages", mock.Anything, mock.Anything).Return(fmt.Errorf("throttled"))
+
+		estimator, err := NewEstimator(p.Pricing(), "us-west-2")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = estimator.Estimate(cfg, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("throttled"))
+	})
+})
@@ -0,0 +1,76 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+)
+
+// CSVPrinter is a printer that outputs an object formatted
+// as comma-separated values, one row per item
+type CSVPrinter struct {
+	columnNames []string
+	getters     []reflect.Value
+}
+
+// NewCSVPrinter creates a new CSVPrinter with no columns.
+func NewCSVPrinter() OutputPrinter {
+	return &CSVPrinter{}
+}
+
+// PrintObj will print the passed object formatted as CSV to
+// the supplied writer.
+func (c *CSVPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	return c.PrintObjWithKind("objects", obj, writer)
+}
+
+// PrintObjWithKind will print the passed object formatted as CSV to
+// the supplied writer. A header row is always written, even when
+// there are no items.
+func (c *CSVPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	itemsValue := reflect.ValueOf(obj)
+	if itemsValue.Kind() != reflect.Slice {
+		return errors.Errorf("csv printer expects a slice but the kind was %v", itemsValue.Kind())
+	}
+	if len(c.columnNames) == 0 {
+		return errors.Errorf("csv output is not supported for %s", strings.ToLower(kind))
+	}
+
+	rows, err := collectRows(itemsValue, c.columnNames, c.getters)
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(writer)
+	return w.WriteAll(rows)
+}
+
+// LogObj will print the passed object formatted as CSV to
+// the logger.
+func (c *CSVPrinter) LogObj(log logger.Logger, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := c.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
+
+// AddColumn adds a column to the output, getter must be a function
+// that takes an item and returns a string
+func (c *CSVPrinter) AddColumn(name string, getter interface{}) {
+	getterValue := reflect.ValueOf(getter)
+	getterType := getterValue.Type()
+	if getterType.Kind() != reflect.Func || getterType.NumIn() != 1 || getterType.NumOut() != 1 || getterType.Out(0).Kind() != reflect.String {
+		panic(errors.Errorf("column %q must have a getter of type func(T) string, got %v", name, getterType))
+	}
+	c.columnNames = append(c.columnNames, name)
+	c.getters = append(c.getters, getterValue)
+}
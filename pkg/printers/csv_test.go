@@ -0,0 +1,79 @@
+package printers_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CSV Printer", func() {
+
+	Describe("When creating New CSV printer", func() {
+		var (
+			printer     OutputPrinter
+			actualBytes bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			printer = NewCSVPrinter()
+
+			printer.(ColumnPrinter).AddColumn("NAME", func(c *awseks.Cluster) string {
+				return *c.Name
+			})
+			printer.(ColumnPrinter).AddColumn("ARN", func(c *awseks.Cluster) string {
+				return *c.Arn
+			})
+		})
+
+		AfterEach(func() {
+			actualBytes.Reset()
+		})
+
+		It("should be created by NewPrinter", func() {
+			p, err := NewPrinter("csv")
+			Expect(err).NotTo(HaveOccurred())
+			_ = p.(*CSVPrinter)
+		})
+
+		It("should return an error when given just a cluster struct (no slice)", func() {
+			cluster := &awseks.Cluster{Name: aws.String("test-cluster")}
+			Expect(printer.PrintObjWithKind("clusters", cluster, &actualBytes)).ToNot(Succeed())
+		})
+
+		It("should only write the header for an empty slice", func() {
+			Expect(printer.PrintObjWithKind("clusters", []*awseks.Cluster{}, &actualBytes)).To(Succeed())
+			Expect(actualBytes.String()).To(Equal("NAME,ARN\n"))
+		})
+
+		It("the output should equal the golden file csvtest_2clusters.golden", func() {
+			clusters := []*awseks.Cluster{
+				{
+					Name: aws.String("test-cluster-1"),
+					Arn:  aws.String("arn-12345678"),
+				},
+				{
+					Name: aws.String("test-cluster-2"),
+					Arn:  aws.String("arn-87654321,quoted"),
+				},
+			}
+			Expect(printer.PrintObjWithKind("clusters", clusters, &actualBytes)).To(Succeed())
+
+			g, err := ioutil.ReadFile("testdata/csvtest_2clusters.golden")
+			if err != nil {
+				GinkgoT().Fatalf("failed reading .golden: %s", err)
+			}
+
+			Expect(actualBytes.String()).To(Equal(string(g)))
+		})
+
+		It("should return an error when there are no columns", func() {
+			Expect(NewCSVPrinter().PrintObjWithKind("clusters", []*awseks.Cluster{}, &actualBytes)).ToNot(Succeed())
+		})
+	})
+})
@@ -0,0 +1,77 @@
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// JSONPathPrinter is a printer that outputs fields of an object
+// selected by a JSONPath template
+type JSONPathPrinter struct {
+	template string
+	jsonPath *jsonpath.JSONPath
+}
+
+// NewJSONPathPrinter creates a new JSONPathPrinter for the given template,
+// which uses the same syntax as kubectl, e.g. '{[*].Endpoint}'; the braces
+// may be omitted for a single expression
+func NewJSONPathPrinter(template string) (OutputPrinter, error) {
+	if !strings.Contains(template, "{") {
+		template = fmt.Sprintf("{%s}", template)
+	}
+	j := jsonpath.New("output")
+	j.AllowMissingKeys(true)
+	if err := j.Parse(template); err != nil {
+		return nil, errors.Wrapf(err, "parsing JSONPath template %q", template)
+	}
+	return &JSONPathPrinter{template: template, jsonPath: j}, nil
+}
+
+// PrintObj will print the fields of the passed object selected by
+// the template to the supplied writer. Fields are named the same way
+// as in JSON output.
+func (j *JSONPathPrinter) PrintObj(obj interface{}, writer io.Writer) error {
+	// go through JSON, so that the template can refer to the same
+	// keys as the JSON output rather than to Go struct fields
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	if err := j.jsonPath.Execute(writer, value); err != nil {
+		return errors.Wrapf(err, "executing JSONPath template %q", j.template)
+	}
+	_, err = fmt.Fprintln(writer)
+	return err
+}
+
+// PrintObjWithKind will print the fields of the passed object selected by
+// the template to the supplied writer. This printer ignores kind argument.
+func (j *JSONPathPrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
+	return j.PrintObj(obj, writer)
+}
+
+// LogObj will print the fields of the passed object selected by
+// the template to the logger.
+func (j *JSONPathPrinter) LogObj(log logger.Logger, msgFmt string, obj interface{}) error {
+	b := &bytes.Buffer{}
+	if err := j.PrintObj(obj, b); err != nil {
+		return err
+	}
+
+	log(msgFmt, strings.ReplaceAll(b.String(), "%", "%%"))
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package printers_test
+
+import (
+	"bytes"
+
+	. "github.com/weaveworks/eksctl/pkg/printers"
+
+	"github.com/aws/aws-sdk-go/aws"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("JSONPath Printer", func() {
+
+	Describe("When creating New JSONPath printer", func() {
+		var (
+			clusters    []*awseks.Cluster
+			actualBytes bytes.Buffer
+		)
+
+		BeforeEach(func() {
+			clusters = []*awseks.Cluster{
+				{
+					Name:     aws.String("test-cluster-1"),
+					Status:   aws.String(awseks.ClusterStatusActive),
+					Endpoint: aws.String("https://1.eks.amazonaws.com"),
+				},
+				{
+					Name:     aws.String("test-cluster-2"),
+					Status:   aws.String(awseks.ClusterStatusCreating),
+					Endpoint: aws.String("https://2.eks.amazonaws.com"),
+				},
+			}
+		})
+
+		AfterEach(func() {
+			actualBytes.Reset()
+		})
+
+		It("should be created by NewPrinter", func() {
+			printer, err := NewPrinter("jsonpath={[0].Endpoint}")
+			Expect(err).NotTo(HaveOccurred())
+			_ = printer.(*JSONPathPrinter)
+		})
+
+		It("should reject invalid templates", func() {
+			_, err := NewPrinter("jsonpath={[0].Endpoint")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should print the selected fields", func() {
+			printer, err := NewJSONPathPrinter(`{range [*]}{.Name}{"\t"}{.Status}{"\n"}{end}`)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(printer.PrintObjWithKind("clusters", clusters, &actualBytes)).To(Succeed())
+			Expect(actualBytes.String()).To(Equal("test-cluster-1\tACTIVE\ntest-cluster-2\tCREATING\n\n"))
+		})
+
+		It("should accept a template without braces", func() {
+			printer, err := NewJSONPathPrinter("[1].Endpoint")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(printer.PrintObjWithKind("clusters", clusters, &actualBytes)).To(Succeed())
+			Expect(actualBytes.String()).To(Equal("https://2.eks.amazonaws.com\n"))
+		})
+	})
+})
@@ -3,8 +3,11 @@ package printers
 import (
 	"fmt"
 	"io"
+	"reflect"
+	"strings"
 
 	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
 )
 
 // OutputPrinter is the interface that printer must implement. This allows
@@ -15,11 +18,23 @@ type OutputPrinter interface {
 	LogObj(log logger.Logger, msgFmt string, obj interface{}) error
 }
 
+// ColumnPrinter is implemented by printers that output each item
+// as a row with named columns
+type ColumnPrinter interface {
+	AddColumn(name string, getter interface{})
+}
+
+const jsonPathPrefix = "jsonpath="
+
 // NewPrinter creates a new printer based in the printer type requested
-// as a string.
+// as a string. JSONPath printer is requested as "jsonpath=<template>".
 func NewPrinter(printerType string) (OutputPrinter, error) {
 	var printer OutputPrinter
 
+	if strings.HasPrefix(printerType, jsonPathPrefix) {
+		return NewJSONPathPrinter(strings.TrimPrefix(printerType, jsonPathPrefix))
+	}
+
 	switch printerType {
 	case "yaml":
 		printer = NewYAMLPrinter()
@@ -27,9 +42,29 @@ func NewPrinter(printerType string) (OutputPrinter, error) {
 		printer = NewJSONPrinter()
 	case "table":
 		printer = NewTablePrinter()
+	case "csv":
+		printer = NewCSVPrinter()
 	default:
 		return nil, fmt.Errorf("unknown output printer type: %s", printerType)
 	}
 
 	return printer, nil
 }
+
+// collectRows calls column getters for each item of a slice,
+// the first row holds column names
+func collectRows(itemsValue reflect.Value, columnNames []string, getters []reflect.Value) ([][]string, error) {
+	rows := [][]string{columnNames}
+	for i := 0; i < itemsValue.Len(); i++ {
+		item := itemsValue.Index(i)
+		row := make([]string, len(getters))
+		for j, getter := range getters {
+			if !item.Type().AssignableTo(getter.Type().In(0)) {
+				return nil, errors.Errorf("column %q expects %v but the item was %v", columnNames[j], getter.Type().In(0), item.Type())
+			}
+			row[j] = getter.Call([]reflect.Value{item})[0].String()
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
@@ -18,6 +18,7 @@ import (
 type TablePrinter struct {
 	table      *tables.Table
 	columnames []string
+	getters    []reflect.Value
 }
 
 // NewTablePrinter creates a new TablePrinter with defaults.
@@ -32,7 +33,8 @@ func (t *TablePrinter) PrintObj(obj interface{}, writer io.Writer) error {
 }
 
 // PrintObjWithKind will print the passed object formatted as textual
-// table to the supplied writer.
+// table to the supplied writer. When the writer is a terminal, columns
+// are fitted into its width and STATUS values are colorized.
 func (t *TablePrinter) PrintObjWithKind(kind string, obj interface{}, writer io.Writer) error {
 	itemsValue := reflect.ValueOf(obj)
 	if itemsValue.Kind() != reflect.Slice {
@@ -50,6 +52,14 @@ func (t *TablePrinter) PrintObjWithKind(kind string, obj interface{}, writer io.
 		return nil
 	}
 
+	if width, ok := terminalWidth(writer); ok {
+		rows, err := collectRows(itemsValue, t.columnames, t.getters)
+		if err != nil {
+			return err
+		}
+		return renderAligned(rows, writer, width, Color)
+	}
+
 	return t.table.Render(obj, writer, t.columnames...)
 }
 
@@ -69,5 +79,6 @@ func (t *TablePrinter) LogObj(log logger.Logger, msgFmt string, obj interface{})
 // AddColumn adds a column to the table that will be printed
 func (t *TablePrinter) AddColumn(name string, getter interface{}) {
 	t.columnames = append(t.columnames, name)
+	t.getters = append(t.getters, reflect.ValueOf(getter))
 	t.table.AddColumn(name, getter)
 }
@@ -0,0 +1,149 @@
+package printers
+
+import (
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+// Color controls whether values are colorized when a table is written
+// to a terminal, it's disabled by --no-color, --color=false or NO_COLOR
+var Color = true
+
+const (
+	defaultTerminalWidth = 120
+	columnSeparator      = "   "
+
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+)
+
+// terminalWidth returns the width of the terminal the writer is attached to,
+// the second value is false when the writer is not a terminal
+func terminalWidth(writer io.Writer) (int, bool) {
+	f, ok := writer.(*os.File)
+	if !ok || !terminal.IsTerminal(int(f.Fd())) {
+		return 0, false
+	}
+	width, _, err := terminal.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 {
+		return defaultTerminalWidth, true
+	}
+	return width, true
+}
+
+// statusColor picks a color for values of STATUS columns, which
+// are either EKS or CloudFormation statuses
+func statusColor(status string) string {
+	switch {
+	case strings.Contains(status, "FAILED"), strings.HasSuffix(status, "ROLLBACK_COMPLETE"):
+		return colorRed
+	case status == "ACTIVE", strings.HasSuffix(status, "_COMPLETE"):
+		return colorGreen
+	case strings.HasSuffix(status, "ING"), strings.HasSuffix(status, "IN_PROGRESS"):
+		return colorYellow
+	default:
+		return ""
+	}
+}
+
+// fitColumns shrinks the widest columns until the table fits into the
+// given width, columns are never made narrower than their names
+func fitColumns(rows [][]string, width int) []int {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	total := len(columnSeparator) * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > width {
+		widest := -1
+		for i, w := range widths {
+			if w > utf8.RuneCountInString(rows[0][i]) && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+		total--
+	}
+	return widths
+}
+
+// wrapCell splits a value into lines of the given width, preferring
+// to break lists after a comma
+func wrapCell(cell string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	runes := []rune(cell)
+	lines := []string{}
+	for len(runes) > width {
+		n := width
+		if i := strings.LastIndex(string(runes[:width]), ","); i > 0 {
+			n = utf8.RuneCountInString(string(runes[:width])[:i+1])
+		}
+		lines = append(lines, string(runes[:n]))
+		runes = runes[n:]
+	}
+	return append(lines, string(runes))
+}
+
+// renderAligned writes rows as space-aligned columns that fit into the
+// width of the terminal, long values are wrapped onto continuation lines
+func renderAligned(rows [][]string, writer io.Writer, width int, color bool) error {
+	widths := fitColumns(rows, width)
+	statusColumn := -1
+	for i, name := range rows[0] {
+		if name == "STATUS" {
+			statusColumn = i
+		}
+	}
+
+	var out strings.Builder
+	for r, row := range rows {
+		cells := make([][]string, len(row))
+		height := 1
+		for i, cell := range row {
+			cells[i] = wrapCell(cell, widths[i])
+			if len(cells[i]) > height {
+				height = len(cells[i])
+			}
+		}
+		for l := 0; l < height; l++ {
+			var line strings.Builder
+			for i := range cells {
+				text := ""
+				if l < len(cells[i]) {
+					text = cells[i][l]
+				}
+				padding := widths[i] - utf8.RuneCountInString(text)
+				if c := statusColor(text); color && r > 0 && i == statusColumn && c != "" {
+					text = c + text + colorReset
+				}
+				line.WriteString(text)
+				if i < len(cells)-1 {
+					line.WriteString(strings.Repeat(" ", padding) + columnSeparator)
+				}
+			}
+			out.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+		}
+	}
+
+	_, err := io.WriteString(writer, out.String())
+	return err
+}
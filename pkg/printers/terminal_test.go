@@ -0,0 +1,63 @@
+package printers
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Terminal table rendering", func() {
+	var (
+		rows        [][]string
+		actualBytes bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		rows = [][]string{
+			{"NAME", "STATUS", "SUBNETS"},
+			{"cluster-1", "ACTIVE", "subnet-11111111,subnet-22222222,subnet-33333333"},
+			{"cluster-2", "FAILED", "subnet-44444444"},
+		}
+	})
+
+	AfterEach(func() {
+		actualBytes.Reset()
+	})
+
+	It("aligns columns with spaces when they fit", func() {
+		Expect(renderAligned(rows, &actualBytes, 120, false)).To(Succeed())
+		Expect(actualBytes.String()).To(Equal(
+			"NAME        STATUS   SUBNETS\n" +
+				"cluster-1   ACTIVE   subnet-11111111,subnet-22222222,subnet-33333333\n" +
+				"cluster-2   FAILED   subnet-44444444\n",
+		))
+	})
+
+	It("wraps long columns after a comma to fit the width", func() {
+		Expect(renderAligned(rows, &actualBytes, 55, false)).To(Succeed())
+		Expect(actualBytes.String()).To(Equal(
+			"NAME        STATUS   SUBNETS\n" +
+				"cluster-1   ACTIVE   subnet-11111111,subnet-22222222,\n" +
+				"                     subnet-33333333\n" +
+				"cluster-2   FAILED   subnet-44444444\n",
+		))
+	})
+
+	It("colorizes status values", func() {
+		Expect(renderAligned(rows, &actualBytes, 120, true)).To(Succeed())
+		Expect(actualBytes.String()).To(ContainSubstring("cluster-1   " + colorGreen + "ACTIVE" + colorReset + "   subnet-11111111"))
+		Expect(actualBytes.String()).To(ContainSubstring("cluster-2   " + colorRed + "FAILED" + colorReset + "   subnet-44444444"))
+		Expect(actualBytes.String()).To(HavePrefix("NAME        STATUS   SUBNETS\n"))
+	})
+
+	It("picks colors for EKS and CloudFormation statuses", func() {
+		Expect(statusColor("ACTIVE")).To(Equal(colorGreen))
+		Expect(statusColor("CREATE_COMPLETE")).To(Equal(colorGreen))
+		Expect(statusColor("CREATING")).To(Equal(colorYellow))
+		Expect(statusColor("UPDATE_IN_PROGRESS")).To(Equal(colorYellow))
+		Expect(statusColor("FAILED")).To(Equal(colorRed))
+		Expect(statusColor("UPDATE_ROLLBACK_COMPLETE")).To(Equal(colorRed))
+		Expect(statusColor("unknown")).To(BeEmpty())
+	})
+})
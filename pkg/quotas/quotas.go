@@ -0,0 +1,254 @@
+// Package quotas checks service quotas before a cluster is created, so that
+// creation fails fast instead of leaving half-created stacks behind
+package quotas
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// Default quotas, used for quotas that can't be looked up with the APIs available
+const (
+	DefaultVPCsPerRegion     = 5
+	DefaultClustersPerRegion = 100
+)
+
+const (
+	attributeMaxInstances  = "max-instances"
+	attributeMaxElasticIPs = "vpc-max-elastic-ips"
+)
+
+// Quota is a service quota that the resources of a cluster count against
+type Quota struct {
+	Name string
+	// ServiceCode and QuotaCode identify the quota in the Service Quotas console
+	ServiceCode string
+	QuotaCode   string
+
+	Limit int
+	// DefaultLimit is true when the limit of the account is unknown,
+	// and the default quota is assumed
+	DefaultLimit bool
+	Used         int
+	Required     int
+}
+
+// Exceeded returns true when the required resources don't fit in the quota
+func (q *Quota) Exceeded() bool {
+	return q.Used+q.Required > q.Limit
+}
+
+// IncreaseURL returns the page of the Service Quotas console to request an increase of the quota
+func (q *Quota) IncreaseURL(region string) string {
+	return fmt.Sprintf("https://console.aws.amazon.com/servicequotas/home?region=%s#!/services/%s/quotas/%s", region, q.ServiceCode, q.QuotaCode)
+}
+
+func (q *Quota) String() string {
+	limit := strconv.Itoa(q.Limit)
+	if q.DefaultLimit {
+		limit += " (default)"
+	}
+	return fmt.Sprintf("%s: %d used, %d required, limit %s", q.Name, q.Used, q.Required, limit)
+}
+
+// Check returns the quotas the cluster and the given nodegroups count against,
+// quotas that can't be looked up are skipped
+func Check(provider api.ClusterProvider, spec *api.ClusterConfig, nodeGroups []*api.NodeGroup) []*Quota {
+	checks := []func(api.ClusterProvider, *api.ClusterConfig, []*api.NodeGroup) (*Quota, error){
+		clusterQuota,
+		vpcQuota,
+		elasticIPQuota,
+		instanceQuota,
+	}
+
+	quotas := []*Quota{}
+	for _, check := range checks {
+		q, err := check(provider, spec, nodeGroups)
+		if err != nil {
+			logger.Debug("skipping quota check: %s", err.Error())
+			continue
+		}
+		if q != nil {
+			logger.Debug("quota %s", q.String())
+			quotas = append(quotas, q)
+		}
+	}
+	return quotas
+}
+
+// Validate returns an error that lists the exceeded quotas along with
+// the pages to request their increase
+func Validate(region string, quotas []*Quota) error {
+	var exceeded []string
+	for _, q := range quotas {
+		if q.Exceeded() {
+			exceeded = append(exceeded, fmt.Sprintf("%s, request an increase of quota %s of service %s at %s",
+				q.String(), q.QuotaCode, q.ServiceCode, q.IncreaseURL(region)))
+		}
+	}
+	if len(exceeded) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cluster would exceed %d service quota(s):\n- %s", len(exceeded), strings.Join(exceeded, "\n- "))
+}
+
+func clusterQuota(provider api.ClusterProvider, _ *api.ClusterConfig, _ []*api.NodeGroup) (*Quota, error) {
+	used := 0
+	input := &awseks.ListClustersInput{}
+	for {
+		output, err := provider.EKS().ListClusters(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "listing clusters")
+		}
+		used += len(output.Clusters)
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return &Quota{
+		Name:         "EKS clusters",
+		ServiceCode:  "eks",
+		QuotaCode:    "L-1194D53C",
+		Limit:        DefaultClustersPerRegion,
+		DefaultLimit: true,
+		Used:         used,
+		Required:     1,
+	}, nil
+}
+
+func vpcQuota(provider api.ClusterProvider, spec *api.ClusterConfig, _ []*api.NodeGroup) (*Quota, error) {
+	if spec.VPC != nil && spec.VPC.ID != "" {
+		return nil, nil
+	}
+	output, err := provider.EC2().DescribeVpcs(&ec2.DescribeVpcsInput{})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing VPCs")
+	}
+
+	return &Quota{
+		Name:         "VPCs",
+		ServiceCode:  "vpc",
+		QuotaCode:    "L-F678F1CE",
+		Limit:        DefaultVPCsPerRegion,
+		DefaultLimit: true,
+		Used:         len(output.Vpcs),
+		Required:     1,
+	}, nil
+}
+
+func elasticIPQuota(provider api.ClusterProvider, spec *api.ClusterConfig, _ []*api.NodeGroup) (*Quota, error) {
+	required := natGatewayCount(spec)
+	if required == 0 {
+		return nil, nil
+	}
+	limit, err := accountAttribute(provider, attributeMaxElasticIPs)
+	if err != nil {
+		return nil, err
+	}
+	output, err := provider.EC2().DescribeAddresses(&ec2.DescribeAddressesInput{
+		Filters: []*ec2.Filter{{Name: aws.String("domain"), Values: aws.StringSlice([]string{"vpc"})}},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "describing Elastic IP addresses")
+	}
+
+	return &Quota{
+		Name:        "Elastic IP addresses",
+		ServiceCode: "ec2",
+		QuotaCode:   "L-0263D0A3",
+		Limit:       limit,
+		Used:        len(output.Addresses),
+		Required:    required,
+	}, nil
+}
+
+func instanceQuota(provider api.ClusterProvider, _ *api.ClusterConfig, nodeGroups []*api.NodeGroup) (*Quota, error) {
+	required := 0
+	for _, ng := range nodeGroups {
+		required += ng.NodeCount()
+	}
+	if required == 0 {
+		return nil, nil
+	}
+	limit, err := accountAttribute(provider, attributeMaxInstances)
+	if err != nil {
+		return nil, err
+	}
+
+	used := 0
+	input := &ec2.DescribeInstancesInput{
+		Filters: []*ec2.Filter{{
+			Name:   aws.String("instance-state-name"),
+			Values: aws.StringSlice([]string{ec2.InstanceStateNamePending, ec2.InstanceStateNameRunning}),
+		}},
+	}
+	for {
+		output, err := provider.EC2().DescribeInstances(input)
+		if err != nil {
+			return nil, errors.Wrap(err, "describing instances")
+		}
+		for _, r := range output.Reservations {
+			used += len(r.Instances)
+		}
+		if output.NextToken == nil {
+			break
+		}
+		input.NextToken = output.NextToken
+	}
+
+	return &Quota{
+		Name:        "On-Demand instances",
+		ServiceCode: "ec2",
+		QuotaCode:   "L-1216C47A",
+		Limit:       limit,
+		Used:        used,
+		Required:    required,
+	}, nil
+}
+
+func accountAttribute(provider api.ClusterProvider, name string) (int, error) {
+	output, err := provider.EC2().DescribeAccountAttributes(&ec2.DescribeAccountAttributesInput{
+		AttributeNames: aws.StringSlice([]string{name}),
+	})
+	if err != nil {
+		return 0, errors.Wrapf(err, "describing account attribute %q", name)
+	}
+	for _, a := range output.AccountAttributes {
+		if aws.StringValue(a.AttributeName) != name || len(a.AttributeValues) == 0 {
+			continue
+		}
+		value := aws.StringValue(a.AttributeValues[0].AttributeValue)
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, errors.Wrapf(err, "parsing account attribute %q", name)
+		}
+		return limit, nil
+	}
+	return 0, fmt.Errorf("account attribute %q not found", name)
+}
+
+// natGatewayCount returns the number of NAT gateways eksctl will create, each takes an Elastic IP
+func natGatewayCount(spec *api.ClusterConfig) int {
+	if spec.VPC == nil || spec.VPC.ID != "" || spec.VPC.NAT == nil || spec.VPC.NAT.Gateway == nil {
+		return 0
+	}
+	switch *spec.VPC.NAT.Gateway {
+	case api.ClusterHighlyAvailableNAT:
+		return len(spec.AvailabilityZones)
+	case api.ClusterSingleNAT:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,143 @@
+package quotas_test
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	awseks "github.com/aws/aws-sdk-go/service/eks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	. "github.com/weaveworks/eksctl/pkg/quotas"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+)
+
+func mockAccountAttribute(p *mockprovider.MockProvider, name, value string) {
+	p.MockEC2().On("DescribeAccountAttributes", mock.MatchedBy(func(input *ec2.DescribeAccountAttributesInput) bool {
+		return len(input.AttributeNames) == 1 && *input.AttributeNames[0] == name
+	})).Return(&ec2.DescribeAccountAttributesOutput{
+		AccountAttributes: []*ec2.AccountAttribute{{
+			AttributeName:   aws.String(name),
+			AttributeValues: []*ec2.AccountAttributeValue{{AttributeValue: aws.String(value)}},
+		}},
+	}, nil)
+}
+
+func findQuota(quotas []*Quota, name string) *Quota {
+	for _, q := range quotas {
+		if q.Name == name {
+			return q
+		}
+	}
+	return nil
+}
+
+var _ = Describe("Service quotas", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+		ng  *api.NodeGroup
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Region = "us-west-2"
+		cfg.AvailabilityZones = []string{"us-west-2a", "us-west-2b", "us-west-2c"}
+		ng = cfg.NewNodeGroup()
+		ng.DesiredCapacity = aws.Int(3)
+
+		p.MockEKS().On("ListClusters", mock.MatchedBy(func(input *awseks.ListClustersInput) bool {
+			return input.NextToken == nil
+		})).Return(&awseks.ListClustersOutput{
+			Clusters:  aws.StringSlice([]string{"a", "b"}),
+			NextToken: aws.String("next"),
+		}, nil)
+		p.MockEKS().On("ListClusters", mock.MatchedBy(func(input *awseks.ListClustersInput) bool {
+			return input.NextToken != nil
+		})).Return(&awseks.ListClustersOutput{
+			Clusters: aws.StringSlice([]string{"c"}),
+		}, nil)
+
+		p.MockEC2().On("DescribeVpcs", mock.Anything).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []*ec2.Vpc{{}, {}},
+		}, nil)
+		p.MockEC2().On("DescribeAddresses", mock.Anything).Return(&ec2.DescribeAddressesOutput{
+			Addresses: []*ec2.Address{{}, {}, {}},
+		}, nil)
+		p.MockEC2().On("DescribeInstances", mock.Anything).Return(&ec2.DescribeInstancesOutput{
+			Reservations: []*ec2.Reservation{{Instances: []*ec2.Instance{{}, {}}}},
+		}, nil)
+		mockAccountAttribute(p, "vpc-max-elastic-ips", "5")
+		mockAccountAttribute(p, "max-instances", "20")
+	})
+
+	It("counts used and required resources", func() {
+		quotas := Check(p, cfg, []*api.NodeGroup{ng})
+		Expect(quotas).To(HaveLen(4))
+
+		clusters := findQuota(quotas, "EKS clusters")
+		Expect(clusters.Used).To(Equal(3))
+		Expect(clusters.Required).To(Equal(1))
+		Expect(clusters.DefaultLimit).To(BeTrue())
+
+		vpcs := findQuota(quotas, "VPCs")
+		Expect(vpcs.Used).To(Equal(2))
+		Expect(vpcs.Limit).To(Equal(DefaultVPCsPerRegion))
+
+		// a single NAT gateway is created by default
+		eips := findQuota(quotas, "Elastic IP addresses")
+		Expect(eips.Used).To(Equal(3))
+		Expect(eips.Required).To(Equal(1))
+		Expect(eips.Limit).To(Equal(5))
+		Expect(eips.DefaultLimit).To(BeFalse())
+
+		instances := findQuota(quotas, "On-Demand instances")
+		Expect(instances.Used).To(Equal(2))
+		Expect(instances.Required).To(Equal(3))
+		Expect(instances.Limit).To(Equal(20))
+
+		Expect(Validate("us-west-2", quotas)).To(Succeed())
+	})
+
+	It("requires an Elastic IP per zone with highly available NAT", func() {
+		cfg.VPC.NAT.Gateway = aws.String(api.ClusterHighlyAvailableNAT)
+		quotas := Check(p, cfg, []*api.NodeGroup{ng})
+
+		eips := findQuota(quotas, "Elastic IP addresses")
+		Expect(eips.Required).To(Equal(3))
+		Expect(eips.Exceeded()).To(BeTrue())
+
+		err := Validate("us-west-2", quotas)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Elastic IP addresses: 3 used, 3 required, limit 5"))
+		Expect(err.Error()).To(ContainSubstring("https://console.aws.amazon.com/servicequotas/home?region=us-west-2#!/services/ec2/quotas/L-0263D0A3"))
+	})
+
+	It("doesn't count a VPC nor Elastic IPs when the VPC exists", func() {
+		cfg.VPC.ID = "vpc-1"
+		quotas := Check(p, cfg, []*api.NodeGroup{ng})
+		Expect(findQuota(quotas, "VPCs")).To(BeNil())
+		Expect(findQuota(quotas, "Elastic IP addresses")).To(BeNil())
+	})
+
+	It("skips quotas that can't be looked up", func() {
+		p = mockprovider.NewMockProvider()
+		p.MockEKS().On("ListClusters", mock.Anything).Return(nil, fmt.Errorf("access denied"))
+		p.MockEC2().On("DescribeVpcs", mock.Anything).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []*ec2.Vpc{{}, {}, {}, {}, {}},
+		}, nil)
+		p.MockEC2().On("DescribeAccountAttributes", mock.Anything).Return(nil, fmt.Errorf("access denied"))
+
+		quotas := Check(p, cfg, []*api.NodeGroup{ng})
+		Expect(quotas).To(HaveLen(1))
+
+		err := Validate("us-west-2", quotas)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("VPCs: 5 used, 1 required, limit 5 (default)"))
+		Expect(err.Error()).To(ContainSubstring("services/vpc/quotas/L-F678F1CE"))
+	})
+})
@@ -18,6 +18,55 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 )
 
+// LoadKeyForNodeGroup loads the ssh public key specified in the NodeGroup. The key should be specified
+// in only one way: by name (for a key existing in EC2), by path (for a key in a local file)
+// or by its contents (in the config-file). It also assumes that if ssh is enabled (SSH.Allow
+// == true) then one key was specified
+func LoadKeyForNodeGroup(ng *api.NodeGroup, clusterName string, provider api.ClusterProvider) error {
+	sshConfig := ng.SSH
+	if sshConfig.Allow == nil || *sshConfig.Allow == false {
+		return nil
+	}
+
+	switch {
+
+	// Load Key by content
+	case sshConfig.PublicKey != nil:
+		keyName, err := LoadKeyByContent(sshConfig.PublicKey, clusterName, ng.Name, provider)
+		if err != nil {
+			return err
+		}
+		sshConfig.PublicKeyName = &keyName
+
+	// Use key by name in EC2
+	case sshConfig.PublicKeyName != nil && *sshConfig.PublicKeyName != "":
+		if err := CheckKeyExistsInEC2(*sshConfig.PublicKeyName, provider); err != nil {
+			return err
+		}
+		logger.Info("using EC2 key pair %q", *sshConfig.PublicKeyName)
+
+	// Local ssh key file
+	case file.Exists(*sshConfig.PublicKeyPath):
+		keyName, err := LoadKeyFromFile(*sshConfig.PublicKeyPath, clusterName, ng.Name, provider)
+		if err != nil {
+			return err
+		}
+		sshConfig.PublicKeyName = &keyName
+
+	// A keyPath, when specified as a flag, can mean a local key (checked above) or a key name in EC2
+	default:
+		err := CheckKeyExistsInEC2(*sshConfig.PublicKeyPath, provider)
+		if err != nil {
+			return err
+		}
+		sshConfig.PublicKeyName = sshConfig.PublicKeyPath
+		sshConfig.PublicKeyPath = nil
+		logger.Info("using EC2 key pair %q", *ng.SSH.PublicKeyName)
+	}
+
+	return nil
+}
+
 // LoadKeyFromFile loads and imports a public SSH key from a file provided a path to that file.
 // returns the name of the key
 func LoadKeyFromFile(filePath, clusterName, ngName string, provider api.ClusterProvider) (string, error) {
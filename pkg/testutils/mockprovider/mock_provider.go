@@ -3,13 +3,20 @@ package mockprovider
 import (
 	"time"
 
+	"github.com/aws/aws-sdk-go/service/autoscaling/autoscalingiface"
 	"github.com/aws/aws-sdk-go/service/cloudformation/cloudformationiface"
 	"github.com/aws/aws-sdk-go/service/cloudtrail/cloudtrailiface"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs/cloudwatchlogsiface"
 	"github.com/aws/aws-sdk-go/service/ec2/ec2iface"
 	"github.com/aws/aws-sdk-go/service/eks/eksiface"
 	"github.com/aws/aws-sdk-go/service/elb/elbiface"
 	"github.com/aws/aws-sdk-go/service/elbv2/elbv2iface"
 	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/pricing/pricingiface"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi/resourcegroupstaggingapiiface"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/sns/snsiface"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
 	"github.com/aws/aws-sdk-go/service/sts/stsiface"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
@@ -27,6 +34,13 @@ type MockProvider struct {
 	sts        *mocks.STSAPI
 	iam        *mocks.IAMAPI
 	cloudtrail *mocks.CloudTrailAPI
+	pricing    *mocks.PricingAPI
+	ssm        *mocks.SSMAPI
+	asg        *mocks.AutoScalingAPI
+	s3         *mocks.S3API
+	logs       *mocks.CloudWatchLogsAPI
+	sns        *mocks.SNSAPI
+	tagging    *mocks.ResourceGroupsTaggingAPIAPI
 }
 
 // NewMockProvider returns a new MockProvider
@@ -40,6 +54,13 @@ func NewMockProvider() *MockProvider {
 		sts:        &mocks.STSAPI{},
 		iam:        &mocks.IAMAPI{},
 		cloudtrail: &mocks.CloudTrailAPI{},
+		pricing:    &mocks.PricingAPI{},
+		ssm:        &mocks.SSMAPI{},
+		asg:        &mocks.AutoScalingAPI{},
+		s3:         &mocks.S3API{},
+		logs:       &mocks.CloudWatchLogsAPI{},
+		sns:        &mocks.SNSAPI{},
+		tagging:    &mocks.ResourceGroupsTaggingAPIAPI{},
 	}
 }
 
@@ -99,6 +120,54 @@ func (m MockProvider) MockCloudTrail() *mocks.CloudTrailAPI {
 	return m.CloudTrail().(*mocks.CloudTrailAPI)
 }
 
+// Pricing returns a representation of the Pricing API
+func (m MockProvider) Pricing() pricingiface.PricingAPI { return m.pricing }
+
+// MockPricing returns a mocked Pricing API
+func (m MockProvider) MockPricing() *mocks.PricingAPI { return m.Pricing().(*mocks.PricingAPI) }
+
+// SSM returns a representation of the SSM API
+func (m MockProvider) SSM() ssmiface.SSMAPI { return m.ssm }
+
+// MockSSM returns a mocked SSM API
+func (m MockProvider) MockSSM() *mocks.SSMAPI { return m.SSM().(*mocks.SSMAPI) }
+
+// ASG returns a representation of the AutoScaling API
+func (m MockProvider) ASG() autoscalingiface.AutoScalingAPI { return m.asg }
+
+// MockASG returns a mocked AutoScaling API
+func (m MockProvider) MockASG() *mocks.AutoScalingAPI { return m.ASG().(*mocks.AutoScalingAPI) }
+
+// S3 returns a representation of the S3 API
+func (m MockProvider) S3() s3iface.S3API { return m.s3 }
+
+// MockS3 returns a mocked S3 API
+func (m MockProvider) MockS3() *mocks.S3API { return m.S3().(*mocks.S3API) }
+
+// CloudWatchLogs returns a representation of the CloudWatch Logs API
+func (m MockProvider) CloudWatchLogs() cloudwatchlogsiface.CloudWatchLogsAPI { return m.logs }
+
+// MockCloudWatchLogs returns a mocked CloudWatch Logs API
+func (m MockProvider) MockCloudWatchLogs() *mocks.CloudWatchLogsAPI {
+	return m.CloudWatchLogs().(*mocks.CloudWatchLogsAPI)
+}
+
+// SNS returns a representation of the SNS API
+func (m MockProvider) SNS() snsiface.SNSAPI { return m.sns }
+
+// MockSNS returns a mocked SNS API
+func (m MockProvider) MockSNS() *mocks.SNSAPI { return m.SNS().(*mocks.SNSAPI) }
+
+// ResourceGroupsTagging returns a representation of the Resource Groups Tagging API
+func (m MockProvider) ResourceGroupsTagging() resourcegroupstaggingapiiface.ResourceGroupsTaggingAPIAPI {
+	return m.tagging
+}
+
+// MockResourceGroupsTagging returns a mocked Resource Groups Tagging API
+func (m MockProvider) MockResourceGroupsTagging() *mocks.ResourceGroupsTaggingAPIAPI {
+	return m.ResourceGroupsTagging().(*mocks.ResourceGroupsTaggingAPIAPI)
+}
+
 // Profile returns current profile setting
 func (m MockProvider) Profile() string { return ProviderConfig.Profile }
 
@@ -107,3 +176,6 @@ func (m MockProvider) Region() string { return ProviderConfig.Region }
 
 // WaitTimeout returns current timeout setting
 func (m MockProvider) WaitTimeout() time.Duration { return ProviderConfig.WaitTimeout }
+
+// PollInterval returns current poll interval setting
+func (m MockProvider) PollInterval() time.Duration { return ProviderConfig.PollInterval }
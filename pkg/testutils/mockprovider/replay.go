@@ -0,0 +1,213 @@
+package mockprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/autoscaling"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/aws/aws-sdk-go/service/cloudtrail"
+	"github.com/aws/aws-sdk-go/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/eks"
+	"github.com/aws/aws-sdk-go/service/elb"
+	"github.com/aws/aws-sdk-go/service/elbv2"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/pricing"
+	"github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/weaveworks/eksctl/pkg/apirecorder"
+)
+
+// NewReplayProvider returns a MockProvider that replays the calls of a fixture file
+func NewReplayProvider(fixtureFile string) (*MockProvider, error) {
+	m := NewMockProvider()
+	if err := m.Replay(fixtureFile); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Replay mocks the operations of the calls recorded in a fixture file, which is written by
+// running eksctl with EKSCTL_RECORD_FIXTURES set to its path; calls of an operation are
+// returned in the order they were recorded, whether they are made with or without a context,
+// or through the Pages methods, but Request methods and waiters have to be mocked by hand
+func (m *MockProvider) Replay(fixtureFile string) error {
+	interactions, err := apirecorder.Load(fixtureFile)
+	if err != nil {
+		return err
+	}
+	return m.ReplayInteractions(interactions)
+}
+
+// ReplayInteractions mocks the operations of the calls, see Replay
+func (m *MockProvider) ReplayInteractions(interactions []apirecorder.Interaction) error {
+	apis := map[string]interface{}{
+		autoscaling.ServiceID:              m.asg,
+		cloudformation.ServiceID:           m.cfn,
+		cloudtrail.ServiceID:               m.cloudtrail,
+		cloudwatchlogs.ServiceID:           m.logs,
+		ec2.ServiceID:                      m.ec2,
+		eks.ServiceID:                      m.eks,
+		elb.ServiceID:                      m.elb,
+		elbv2.ServiceID:                    m.elbv2,
+		iam.ServiceID:                      m.iam,
+		pricing.ServiceID:                  m.pricing,
+		resourcegroupstaggingapi.ServiceID: m.tagging,
+		s3.ServiceID:                       m.s3,
+		sns.ServiceID:                      m.sns,
+		ssm.ServiceID:                      m.ssm,
+		sts.ServiceID:                      m.sts,
+	}
+
+	queues := map[string]*replayQueue{}
+	for _, i := range interactions {
+		key := i.Service + "/" + i.Operation
+		q, ok := queues[key]
+		if !ok {
+			api, ok := apis[i.Service]
+			if !ok {
+				return fmt.Errorf("calls of service %q can't be replayed", i.Service)
+			}
+			q = &replayQueue{service: i.Service, operation: i.Operation}
+			if err := q.mock(api); err != nil {
+				return err
+			}
+			queues[key] = q
+		}
+		q.interactions = append(q.interactions, i)
+	}
+	return nil
+}
+
+// replayQueue holds the calls of an operation that are still to be replayed
+type replayQueue struct {
+	service, operation string
+
+	mutex        sync.Mutex
+	interactions []apirecorder.Interaction
+}
+
+type mockedAPI interface {
+	On(methodName string, arguments ...interface{}) *mock.Call
+}
+
+// mock sets up the methods of the operation to return the calls of the queue
+func (q *replayQueue) mock(api interface{}) error {
+	value := reflect.ValueOf(api)
+	method := value.MethodByName(q.operation)
+	if !method.IsValid() {
+		return fmt.Errorf("%s has no operation %q", q.service, q.operation)
+	}
+	outputType := method.Type().Out(0)
+
+	for _, name := range []string{q.operation, q.operation + "WithContext"} {
+		call := on(api, name)
+		call.Run(func(mock.Arguments) {
+			output, err := q.next(outputType)
+			call.ReturnArguments = mock.Arguments{output, err}
+		})
+	}
+
+	for _, name := range []string{q.operation + "Pages", q.operation + "PagesWithContext"} {
+		if !value.MethodByName(name).IsValid() {
+			continue
+		}
+		// the callback comes after the input
+		fnIndex := 1
+		if name == q.operation+"PagesWithContext" {
+			fnIndex = 2
+		}
+		call := on(api, name)
+		call.Run(func(args mock.Arguments) {
+			call.ReturnArguments = mock.Arguments{q.nextPages(outputType, reflect.ValueOf(args.Get(fnIndex)))}
+		})
+	}
+	return nil
+}
+
+// on mocks a method for any arguments, except variadic request options
+func on(api interface{}, name string) *mock.Call {
+	methodType := reflect.ValueOf(api).MethodByName(name).Type()
+	numArgs := methodType.NumIn()
+	if methodType.IsVariadic() {
+		numArgs--
+	}
+	arguments := make([]interface{}, numArgs)
+	for i := range arguments {
+		arguments[i] = mock.Anything
+	}
+	return api.(mockedAPI).On(name, arguments...)
+}
+
+// next returns the output and error of the next call
+func (q *replayQueue) next(outputType reflect.Type) (interface{}, error) {
+	i, err := q.pop()
+	if err != nil {
+		return reflect.Zero(outputType).Interface(), err
+	}
+	return decodeInteraction(i, outputType)
+}
+
+// nextPages passes the next calls to fn as pages, until a page without a
+// next token or marker, or until fn stops
+func (q *replayQueue) nextPages(outputType reflect.Type, fn reflect.Value) error {
+	for {
+		i, err := q.pop()
+		if err != nil {
+			return err
+		}
+		page, err := decodeInteraction(i, outputType)
+		if err != nil {
+			return err
+		}
+		lastPage := !hasNextPage(i.Output)
+		if !fn.Call([]reflect.Value{reflect.ValueOf(page), reflect.ValueOf(lastPage)})[0].Bool() || lastPage {
+			return nil
+		}
+	}
+}
+
+func (q *replayQueue) pop() (apirecorder.Interaction, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	if len(q.interactions) == 0 {
+		return apirecorder.Interaction{}, fmt.Errorf("no more recorded calls of %s %s", q.service, q.operation)
+	}
+	i := q.interactions[0]
+	q.interactions = q.interactions[1:]
+	return i, nil
+}
+
+func decodeInteraction(i apirecorder.Interaction, outputType reflect.Type) (interface{}, error) {
+	if i.Error != nil {
+		return reflect.Zero(outputType).Interface(), awserr.New(i.Error.Code, i.Error.Message, nil)
+	}
+	output := reflect.New(outputType.Elem())
+	if len(i.Output) > 0 {
+		if err := json.Unmarshal(i.Output, output.Interface()); err != nil {
+			return nil, fmt.Errorf("decoding recorded output of %s %s: %s", i.Service, i.Operation, err.Error())
+		}
+	}
+	return output.Interface(), nil
+}
+
+func hasNextPage(output json.RawMessage) bool {
+	var fields struct {
+		NextToken   string
+		NextMarker  string
+		IsTruncated bool
+	}
+	if err := json.Unmarshal(output, &fields); err != nil {
+		return false
+	}
+	return fields.NextToken != "" || fields.NextMarker != "" || fields.IsTruncated
+}
@@ -0,0 +1,48 @@
+package file
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"sort"
+	"time"
+)
+
+// WriteTarball writes the files to a gzipped tarball at path, in the order of their names
+func WriteTarball(path string, files map[string][]byte) error {
+	f, err := os.Create(ExpandPath(path))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+
+	names := []string{}
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(files[name])),
+			ModTime: time.Now(),
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,36 @@
+package file
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tarball", func() {
+	It("writes the files sorted by name", func() {
+		dir, err := ioutil.TempDir("", "tarball")
+		Expect(err).ToNot(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "bundle.tar.gz")
+		Expect(WriteTarball(path, map[string][]byte{"b.json": []byte("{}"), "a/b.log": []byte("log")})).To(Succeed())
+
+		f, err := os.Open(path)
+		Expect(err).ToNot(HaveOccurred())
+		defer f.Close()
+		gz, err := gzip.NewReader(f)
+		Expect(err).ToNot(HaveOccurred())
+		tr := tar.NewReader(gz)
+
+		names := []string{}
+		for header, err := tr.Next(); err == nil; header, err = tr.Next() {
+			names = append(names, header.Name)
+		}
+		Expect(names).To(Equal([]string{"a/b.log", "b.json"}))
+	})
+})
@@ -213,7 +213,7 @@ func MaybeDeleteConfig(cl *api.ClusterMeta) {
 
 	if file.Exists(p) {
 		if err := isValidConfig(p, cl.Name); err != nil {
-			logger.Debug(err.Error())
+			logger.Debug("%s", err.Error())
 			return
 		}
 		if err := os.Remove(p); err != nil {
@@ -270,7 +270,7 @@ func deleteClusterInfo(existing *clientcmdapi.Config, cl *api.ClusterMeta) bool
 
 	if existing.CurrentContext == currentContextName {
 		existing.CurrentContext = ""
-		logger.Debug("reset current-context in kubeconfig", currentContextName)
+		logger.Debug("reset current-context %q in kubeconfig", currentContextName)
 		isChanged = true
 	}
 
@@ -278,7 +278,7 @@ func deleteClusterInfo(existing *clientcmdapi.Config, cl *api.ClusterMeta) bool
 		if strings.HasSuffix(parts[1], "eksctl.io") {
 			if _, ok := existing.Contexts[existing.CurrentContext]; !ok {
 				existing.CurrentContext = ""
-				logger.Debug("reset stale current-context in kubeconfig", currentContextName)
+				logger.Debug("reset stale current-context %q in kubeconfig", currentContextName)
 				isChanged = true
 			}
 		}
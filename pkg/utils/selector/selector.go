@@ -0,0 +1,89 @@
+// Package selector implements selectors of the tags of clusters and nodegroups,
+// with a syntax similar to Kubernetes label selectors
+package selector
+
+import (
+	"fmt"
+	"strings"
+)
+
+type operator string
+
+const (
+	equals       operator = "="
+	notEquals    operator = "!="
+	exists       operator = "exists"
+	doesNotExist operator = "!"
+)
+
+type requirement struct {
+	key, value string
+	op         operator
+}
+
+func (r requirement) matches(tags map[string]string) bool {
+	value, ok := tags[r.key]
+	switch r.op {
+	case equals:
+		return ok && value == r.value
+	case notEquals:
+		return !ok || value != r.value
+	case exists:
+		return ok
+	default:
+		return !ok
+	}
+}
+
+// Selector matches tags against all of its requirements, an empty
+// selector matches everything
+type Selector []requirement
+
+// Parse parses a comma-separated list of requirements, each of them is
+// one of `key=value`, `key==value`, `key!=value`, `key` or `!key`
+func Parse(s string) (Selector, error) {
+	sel := Selector{}
+	if strings.TrimSpace(s) == "" {
+		return sel, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		var r requirement
+		switch {
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			r = requirement{key: kv[0], value: kv[1], op: notEquals}
+		case strings.Contains(part, "=="):
+			kv := strings.SplitN(part, "==", 2)
+			r = requirement{key: kv[0], value: kv[1], op: equals}
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			r = requirement{key: kv[0], value: kv[1], op: equals}
+		case strings.HasPrefix(part, "!"):
+			r = requirement{key: strings.TrimPrefix(part, "!"), op: doesNotExist}
+		default:
+			r = requirement{key: part, op: exists}
+		}
+		r.key, r.value = strings.TrimSpace(r.key), strings.TrimSpace(r.value)
+		if r.key == "" {
+			return nil, fmt.Errorf("invalid selector %q: requirement %q has no key", s, part)
+		}
+		sel = append(sel, r)
+	}
+	return sel, nil
+}
+
+// Empty returns true when the selector matches everything
+func (s Selector) Empty() bool {
+	return len(s) == 0
+}
+
+// Matches returns true when the tags meet all requirements of the selector
+func (s Selector) Matches(tags map[string]string) bool {
+	for _, r := range s {
+		if !r.matches(tags) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package selector
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("tag selectors", func() {
+	tags := map[string]string{
+		"env":  "prod",
+		"team": "payments",
+	}
+
+	It("matches everything when empty", func() {
+		sel, err := Parse("")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sel.Empty()).To(BeTrue())
+		Expect(sel.Matches(tags)).To(BeTrue())
+		Expect(sel.Matches(nil)).To(BeTrue())
+	})
+
+	It("matches all requirements", func() {
+		sel, err := Parse("env=prod, team==payments")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(sel).To(HaveLen(2))
+		Expect(sel.Matches(tags)).To(BeTrue())
+		Expect(sel.Matches(map[string]string{"env": "prod"})).To(BeFalse())
+		Expect(sel.Matches(nil)).To(BeFalse())
+	})
+
+	It("supports inequality and existence", func() {
+		for s, expected := range map[string]bool{
+			"env!=dev":        true,
+			"env!=prod":       false,
+			"owner!=me":       true,
+			"team":            true,
+			"owner":           false,
+			"!owner":          true,
+			"!team":           false,
+			"env=prod,!owner": true,
+		} {
+			sel, err := Parse(s)
+			Expect(err).ToNot(HaveOccurred(), s)
+			Expect(sel.Matches(tags)).To(Equal(expected), s)
+		}
+	})
+
+	It("rejects requirements without a key", func() {
+		_, err := Parse("env=prod,=payments")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`requirement "=payments" has no key`))
+
+		_, err = Parse("env=prod,")
+		Expect(err).To(HaveOccurred())
+	})
+})
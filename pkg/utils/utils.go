@@ -10,6 +10,11 @@ func IsGPUInstanceType(instanceType string) bool {
 	return strings.HasPrefix(instanceType, "p2") || strings.HasPrefix(instanceType, "p3")
 }
 
+// IsARMInstanceType returns true if the instance type uses an ARM (arm64) processor
+func IsARMInstanceType(instanceType string) bool {
+	return strings.HasPrefix(instanceType, "a1")
+}
+
 // HasGPUInstanceType returns true if it finds a gpu instance among the mixed instances
 func HasGPUInstanceType(instanceTypes []string) bool {
 	if instanceTypes == nil || len(instanceTypes) == 0 {
@@ -22,3 +27,13 @@ func HasGPUInstanceType(instanceTypes []string) bool {
 	}
 	return false
 }
+
+// IsOneOf returns true if the value is one of the given values
+func IsOneOf(value string, values []string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,16 @@
+package utils_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/weaveworks/eksctl/pkg/utils"
+)
+
+var _ = Describe("IsOneOf", func() {
+	It("should find the value among the values", func() {
+		Expect(utils.IsOneOf("b", []string{"a", "b"})).To(BeTrue())
+		Expect(utils.IsOneOf("c", []string{"a", "b"})).To(BeFalse())
+		Expect(utils.IsOneOf("", nil)).To(BeFalse())
+	})
+})
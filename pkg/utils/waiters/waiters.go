@@ -7,49 +7,136 @@ import (
 	"strings"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awsutil"
 	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/kris-nova/logger"
 	"github.com/pkg/errors"
+
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+	"github.com/weaveworks/eksctl/pkg/logging"
+	"github.com/weaveworks/eksctl/pkg/metrics"
 )
 
 // Wait for something with a name to reach status that is expressed by acceptors using newRequest
-// until we hit waitTimeout, on unexpected status troubleshoot will be called with the desired
-// status as an argument, so that it can find what migth have gone wrong
-func Wait(name, msg string, acceptors []request.WaiterAcceptor, newRequest func() *request.Request, waitTimeout time.Duration, troubleshoot func(string)) error {
+// until we hit waitTimeout, polling every pollInterval (or every 15-20s when it's zero), on
+// unexpected status troubleshoot will be called with the desired status as an argument, so that
+// it can find what migth have gone wrong
+func Wait(name, msg string, acceptors []request.WaiterAcceptor, newRequest func() *request.Request, waitTimeout, pollInterval time.Duration, troubleshoot func(string)) error {
+	return WaitWithContext(context.Background(), name, msg, acceptors, newRequest, waitTimeout, pollInterval, troubleshoot)
+}
+
+// WaitWithContext is like Wait, but it also stops waiting as soon as ctx is done
+func WaitWithContext(ctx context.Context, name, msg string, acceptors []request.WaiterAcceptor, newRequest func() *request.Request, waitTimeout, pollInterval time.Duration, troubleshoot func(string)) error {
 	desiredStatus := fmt.Sprintf("%v", acceptors[0].Expected)
 	msg = fmt.Sprintf("%s to reach %q status", msg, desiredStatus)
 	name = strings.Join([]string{"wait", name, desiredStatus}, "_")
 
-	ctx, cancel := context.WithTimeout(context.Background(), waitTimeout)
+	ctx, cancel := context.WithTimeout(ctx, waitTimeout)
 	defer cancel()
 	startTime := time.Now()
-	w := makeWaiter(ctx, name, msg, acceptors, newRequest)
+	progress := &progressReporter{
+		msg:        msg,
+		statusPath: acceptors[0].Argument,
+		startTime:  startTime,
+		timeout:    waitTimeout,
+		progress:   logging.StartProgress(),
+	}
+	w := makeWaiter(ctx, name, msg, acceptors, newRequest, pollInterval, progress)
 	logger.Debug("start %s", msg)
-	if waitErr := w.WaitWithContext(ctx); waitErr != nil {
+	waitErr := w.WaitWithContext(ctx)
+	progress.progress.Done()
+	metrics.ObserveWait(desiredStatus, startTime, waitErr)
+	if waitErr != nil {
 		if troubleshoot != nil {
 			troubleshoot(desiredStatus)
 		}
+		if ctx.Err() == context.DeadlineExceeded {
+			return eksctlerrors.Wrapf(eksctlerrors.ClassTimeout, waitErr, "timed out (after %s) %s", waitTimeout, msg)
+		}
 		return errors.Wrap(waitErr, msg)
 	}
 	logger.Debug("done after %s of %s", time.Since(startTime), msg)
 	return nil
 }
 
-func makeWaiter(ctx context.Context, name, msg string, acceptors []request.WaiterAcceptor, newRequest func() *request.Request) request.Waiter {
+// DefaultPollInterval is used by polling loops that don't use a request.Waiter
+// when the poll interval isn't set
+const DefaultPollInterval = 15 * time.Second
+
+// PollInterval returns pollInterval, or DefaultPollInterval when it's zero
+func PollInterval(pollInterval time.Duration) time.Duration {
+	if pollInterval > 0 {
+		return pollInterval
+	}
+	return DefaultPollInterval
+}
+
+func makeWaiter(ctx context.Context, name, msg string, acceptors []request.WaiterAcceptor, newRequest func() *request.Request, pollInterval time.Duration, progress *progressReporter) request.Waiter {
+	delay := makeWaiterDelay()
+	if pollInterval > 0 {
+		delay = request.ConstantWaiterDelay(pollInterval)
+	}
 	return request.Waiter{
 		Name:        name,
 		MaxAttempts: 1024, // we use context deadline instead
-		Delay:       makeWaiterDelay(),
+		Delay:       delay,
 		Acceptors:   acceptors,
 		NewRequest: func(_ []request.Option) (*request.Request, error) {
-			logger.Debug(msg)
+			logger.Debug("%s", msg)
 			req := newRequest()
 			req.SetContext(ctx)
+			req.Handlers.Complete.PushBack(progress.report)
 			return req, nil
 		},
 	}
 }
 
+// progressReporter shows the current status after every poll next to the spinner,
+// or logs it when spinners are disabled, so that long waits show what they are
+// waiting for and how much time is left
+type progressReporter struct {
+	msg        string
+	statusPath string
+	startTime  time.Time
+	timeout    time.Duration
+	progress   *logging.Progress
+}
+
+func (p *progressReporter) report(req *request.Request) {
+	if req.Error != nil {
+		logger.Debug("%s: %s", p.msg, req.Error.Error())
+		return
+	}
+	if p.statusPath == "" {
+		return
+	}
+	values, err := awsutil.ValuesAtPath(req.Data, p.statusPath)
+	if err != nil {
+		logger.Debug("%s: cannot get %s: %s", p.msg, p.statusPath, err.Error())
+		return
+	}
+	p.progress.Update(progressMessage(p.msg, p.statusPath, values, time.Since(p.startTime), p.timeout))
+}
+
+// progressMessage formats the current status along with elapsed and remaining time
+func progressMessage(msg, statusPath string, values []interface{}, elapsed, timeout time.Duration) string {
+	statuses := []string{}
+	for _, v := range values {
+		if s, ok := v.(*string); ok {
+			statuses = append(statuses, aws.StringValue(s))
+		} else {
+			statuses = append(statuses, fmt.Sprintf("%v", v))
+		}
+	}
+	remaining := timeout - elapsed
+	if remaining < 0 {
+		remaining = 0
+	}
+	return fmt.Sprintf("%s (%s=%s, %s elapsed, %s remaining)", msg, statusPath, strings.Join(statuses, ","),
+		elapsed.Round(time.Second), remaining.Round(time.Second))
+}
+
 // MakeAcceptors constructs a slice of request acceptors
 func MakeAcceptors(statusPath string, successStatus string, failureStates []string, extraAcceptors ...request.WaiterAcceptor) []request.WaiterAcceptor {
 	acceptors := []request.WaiterAcceptor{makeStatusAcceptor(successStatus, statusPath)}
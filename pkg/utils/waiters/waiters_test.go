@@ -0,0 +1,61 @@
+package waiters
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client/metadata"
+	"github.com/aws/aws-sdk-go/aws/request"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	eksctlerrors "github.com/weaveworks/eksctl/pkg/errors"
+)
+
+var _ = Describe("Waiters", func() {
+	It("reports current status with elapsed and remaining time", func() {
+		msg := progressMessage(`waiting for control plane "test" version update to reach "Successful" status`, "Update.Status",
+			[]interface{}{aws.String("InProgress")}, 90*time.Second+300*time.Millisecond, 25*time.Minute)
+
+		Expect(msg).To(Equal(`waiting for control plane "test" version update to reach "Successful" status` +
+			" (Update.Status=InProgress, 1m30s elapsed, 23m30s remaining)"))
+	})
+
+	It("reports status of every stack and never negative remaining time", func() {
+		msg := progressMessage("waiting", "Stacks[].StackStatus",
+			[]interface{}{aws.String("CREATE_IN_PROGRESS"), aws.String("CREATE_COMPLETE")}, 2*time.Minute, time.Minute)
+
+		Expect(msg).To(Equal("waiting (Stacks[].StackStatus=CREATE_IN_PROGRESS,CREATE_COMPLETE, 2m0s elapsed, 0s remaining)"))
+	})
+
+	It("uses the poll interval when it's set", func() {
+		progress := &progressReporter{}
+
+		w := makeWaiter(context.Background(), "test", "waiting", nil, nil, 5*time.Second, progress)
+		Expect(w.Delay(1)).To(Equal(5 * time.Second))
+
+		w = makeWaiter(context.Background(), "test", "waiting", nil, nil, 0, progress)
+		Expect(w.Delay(1)).To(BeNumerically(">=", 15*time.Second))
+		Expect(w.Delay(1)).To(BeNumerically("<=", 20*time.Second))
+	})
+
+	It("stops waiting when the context is cancelled", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		acceptors := []request.WaiterAcceptor{{State: request.SuccessWaiterState, Matcher: request.ErrorWaiterMatch, Expected: "Done"}}
+		newRequest := func() *request.Request {
+			return request.New(aws.Config{}, metadata.ClientInfo{}, request.Handlers{}, nil, &request.Operation{Name: "Test"}, nil, nil)
+		}
+
+		err := WaitWithContext(ctx, "test", "waiting", acceptors, newRequest, time.Hour, time.Hour, nil)
+		Expect(err).To(HaveOccurred())
+		Expect(eksctlerrors.ClassOf(err)).ToNot(Equal(eksctlerrors.ClassTimeout))
+	})
+
+	It("falls back to the default poll interval for polling loops", func() {
+		Expect(PollInterval(5 * time.Second)).To(Equal(5 * time.Second))
+		Expect(PollInterval(0)).To(Equal(DefaultPollInterval))
+	})
+})
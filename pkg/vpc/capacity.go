@@ -0,0 +1,226 @@
+package vpc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/nodebootstrap"
+	"github.com/weaveworks/eksctl/pkg/utils"
+	"github.com/weaveworks/eksctl/pkg/utils/ipnet"
+)
+
+// reservedAddressesPerSubnet is the number of addresses AWS reserves in every subnet
+const reservedAddressesPerSubnet = 5
+
+// SubnetUsage holds the address usage of a subnet, and how many addresses the
+// nodegroups about to be created in it may need
+type SubnetUsage struct {
+	ID               string
+	Topology         api.SubnetTopology
+	AvailabilityZone string
+	CIDR             string
+	Usable           int64
+	Free             int64
+
+	NodeGroups []string
+	Nodes      int
+	Required   int64
+}
+
+// Used returns the number of addresses in use
+func (s *SubnetUsage) Used() int64 { return s.Usable - s.Free }
+
+// Utilization returns the percentage of usable addresses in use
+func (s *SubnetUsage) Utilization() float64 {
+	if s.Usable == 0 {
+		return 0
+	}
+	return 100 * float64(s.Used()) / float64(s.Usable)
+}
+
+// AddressesPerNode estimates how many addresses the VPC CNI takes from the subnet for
+// a node of the nodegroup; with the default WARM_ENI_TARGET=1 the CNI attaches network
+// interfaces with all their secondary addresses ahead of need, so a busy node holds
+// about as many addresses as it can run pods, whatever the kubelet's pod limit is
+func AddressesPerNode(ng *api.NodeGroup) (int, bool) {
+	instanceTypes := []string{ng.InstanceType}
+	if api.HasMixedInstances(ng) {
+		instanceTypes = ng.InstancesDistribution.InstanceTypes
+	}
+
+	addresses := 0
+	for _, instanceType := range instanceTypes {
+		maxPods, ok := nodebootstrap.MaxPodsPerNode(instanceType)
+		if !ok {
+			return 0, false
+		}
+		if maxPods > addresses {
+			addresses = maxPods
+		}
+	}
+	return addresses, true
+}
+
+// NodeGroupSubnetIDs returns the IDs of the subnets the nodegroup will launch instances in
+func NodeGroupSubnetIDs(spec *api.ClusterConfig, ng *api.NodeGroup) []string {
+	if spec.VPC == nil {
+		return nil
+	}
+	topology := api.SubnetTopologyPublic
+	if ng.PrivateNetworking {
+		topology = api.SubnetTopologyPrivate
+	}
+	if spec.NodeGroupUsesLocalZoneSubnets(ng) {
+		return nodeGroupLocalZoneSubnetIDs(spec, ng, topology)
+	}
+	if spec.VPC.Subnets == nil {
+		return nil
+	}
+	subnets := spec.SubnetsWithTopology(topology)
+
+	ids := []string{}
+	for az, subnet := range subnets {
+		if subnet.ID == "" {
+			continue
+		}
+		if len(ng.AvailabilityZones) > 0 && !utils.IsOneOf(az, ng.AvailabilityZones) {
+			continue
+		}
+		ids = append(ids, subnet.ID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// nodeGroupLocalZoneSubnetIDs returns the subnets in the Local Zones or the Outpost of the nodegroup
+func nodeGroupLocalZoneSubnetIDs(spec *api.ClusterConfig, ng *api.NodeGroup, topology api.SubnetTopology) []string {
+	ids := []string{}
+	for zone, subnet := range spec.LocalZoneSubnetsWithTopology(topology) {
+		if subnet.ID == "" {
+			continue
+		}
+		if (ng.OutpostARN != "" && subnet.OutpostARN == ng.OutpostARN) || utils.IsOneOf(zone, ng.LocalZones) {
+			ids = append(ids, subnet.ID)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// GetNodeGroupsSubnetUsage returns the usage of the subnets the nodegroups will use,
+// along with the number of nodes and addresses they need in each subnet, assuming
+// the auto scaling groups spread the desired capacity evenly
+func GetNodeGroupsSubnetUsage(provider api.ClusterProvider, spec *api.ClusterConfig, nodeGroups []*api.NodeGroup) ([]*SubnetUsage, error) {
+	demand := map[string]*SubnetUsage{}
+	ids := []string{}
+
+	for _, ng := range nodeGroups {
+		subnetIDs := NodeGroupSubnetIDs(spec, ng)
+		if len(subnetIDs) == 0 {
+			continue
+		}
+
+		nodes := (ng.NodeCount() + len(subnetIDs) - 1) / len(subnetIDs)
+		addresses, ok := AddressesPerNode(ng)
+		if !ok {
+			logger.Debug("number of addresses per node of nodegroup %q is unknown, only counting the primary address", ng.Name)
+			addresses = 1
+		}
+
+		for _, id := range subnetIDs {
+			usage, ok := demand[id]
+			if !ok {
+				usage = &SubnetUsage{ID: id}
+				demand[id] = usage
+				ids = append(ids, id)
+			}
+			usage.NodeGroups = append(usage.NodeGroups, ng.Name)
+			usage.Nodes += nodes
+			usage.Required += int64(nodes * addresses)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	usages, err := describeSubnetUsage(provider, spec, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, usage := range usages {
+		if d, ok := demand[usage.ID]; ok {
+			usage.NodeGroups = d.NodeGroups
+			usage.Nodes = d.Nodes
+			usage.Required = d.Required
+		}
+	}
+	return usages, nil
+}
+
+// GetClusterSubnetUsage returns the usage of all subnets of the cluster
+func GetClusterSubnetUsage(provider api.ClusterProvider, spec *api.ClusterConfig) ([]*SubnetUsage, error) {
+	ids := append(spec.PrivateSubnetIDs(), spec.PublicSubnetIDs()...)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return describeSubnetUsage(provider, spec, ids)
+}
+
+func describeSubnetUsage(provider api.ClusterProvider, spec *api.ClusterConfig, ids []string) ([]*SubnetUsage, error) {
+	subnets, err := describeSubnets(provider, ids...)
+	if err != nil {
+		return nil, errors.Wrap(err, "describing subnets")
+	}
+
+	privateIDs := spec.PrivateSubnetIDs()
+
+	usages := []*SubnetUsage{}
+	for _, s := range subnets {
+		usage := &SubnetUsage{
+			ID:               *s.SubnetId,
+			Topology:         api.SubnetTopologyPublic,
+			AvailabilityZone: aws.StringValue(s.AvailabilityZone),
+			CIDR:             aws.StringValue(s.CidrBlock),
+			Free:             aws.Int64Value(s.AvailableIpAddressCount),
+		}
+		if utils.IsOneOf(usage.ID, privateIDs) {
+			usage.Topology = api.SubnetTopologyPrivate
+		}
+		cidr, err := ipnet.ParseCIDR(usage.CIDR)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parsing CIDR of subnet %q", usage.ID)
+		}
+		ones, bits := cidr.Mask.Size()
+		usage.Usable = int64(1)<<uint(bits-ones) - reservedAddressesPerSubnet
+		usages = append(usages, usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool { return usages[i].ID < usages[j].ID })
+	return usages, nil
+}
+
+// ValidateSubnetCapacity fails when a subnet doesn't have enough free addresses to launch
+// the nodes planned in it, and warns when it may run out of addresses for their pods
+func ValidateSubnetCapacity(usages []*SubnetUsage) error {
+	exhausted := []string{}
+	for _, s := range usages {
+		nodeGroups := strings.Join(s.NodeGroups, ", ")
+		switch {
+		case s.Free < int64(s.Nodes):
+			exhausted = append(exhausted, fmt.Sprintf("subnet %q (%s) has %d free IP addresses, but nodegroup(s) %s need at least %d", s.ID, s.AvailabilityZone, s.Free, nodeGroups, s.Nodes))
+		case s.Free < s.Required:
+			logger.Warning("subnet %q (%s) has %d free IP addresses, but the %d node(s) of nodegroup(s) %s may use up to %d for their pods", s.ID, s.AvailabilityZone, s.Free, s.Nodes, nodeGroups, s.Required)
+		}
+	}
+	if len(exhausted) > 0 {
+		return fmt.Errorf("not enough free IP addresses: %s", strings.Join(exhausted, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package vpc_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	. "github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+var _ = Describe("Subnet capacity", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+	)
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+
+		cfg = api.NewClusterConfig()
+		cfg.VPC.ID = "vpc-1"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Private: map[string]api.Network{
+				"us-west-2a": {ID: "subnet-private-a"},
+				"us-west-2b": {ID: "subnet-private-b"},
+			},
+			Public: map[string]api.Network{
+				"us-west-2a": {ID: "subnet-public-a"},
+			},
+		}
+
+		p.MockEC2().On("DescribeSubnets", mock.Anything).Return(func(input *ec2.DescribeSubnetsInput) *ec2.DescribeSubnetsOutput {
+			free := map[string]int64{
+				"subnet-private-a": 100,
+				"subnet-private-b": 2,
+				"subnet-public-a":  200,
+			}
+			zones := map[string]string{
+				"subnet-private-a": "us-west-2a",
+				"subnet-private-b": "us-west-2b",
+				"subnet-public-a":  "us-west-2a",
+			}
+			output := &ec2.DescribeSubnetsOutput{}
+			for _, id := range input.SubnetIds {
+				output.Subnets = append(output.Subnets, &ec2.Subnet{
+					SubnetId:                aws.String(*id),
+					AvailabilityZone:        aws.String(zones[*id]),
+					CidrBlock:               aws.String("192.168.0.0/24"),
+					AvailableIpAddressCount: aws.Int64(free[*id]),
+				})
+			}
+			return output
+		}, nil)
+	})
+
+	It("should estimate addresses per node from the max pods of the instance type", func() {
+		ng := cfg.NewNodeGroup()
+		ng.InstanceType = "m5.large"
+		addresses, ok := AddressesPerNode(ng)
+		Expect(ok).To(BeTrue())
+		Expect(addresses).To(Equal(29))
+
+		ng.InstancesDistribution = &api.NodeGroupInstancesDistribution{
+			InstanceTypes: []string{"m5.large", "m5.xlarge"},
+		}
+		addresses, ok = AddressesPerNode(ng)
+		Expect(ok).To(BeTrue())
+		Expect(addresses).To(Equal(58))
+
+		ng.InstancesDistribution = nil
+		ng.InstanceType = "x9.nonexistent"
+		_, ok = AddressesPerNode(ng)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should select subnets by topology and availability zones", func() {
+		ng := cfg.NewNodeGroup()
+		ng.PrivateNetworking = true
+		Expect(NodeGroupSubnetIDs(cfg, ng)).To(Equal([]string{"subnet-private-a", "subnet-private-b"}))
+
+		ng.AvailabilityZones = []string{"us-west-2b"}
+		Expect(NodeGroupSubnetIDs(cfg, ng)).To(Equal([]string{"subnet-private-b"}))
+
+		ng.PrivateNetworking = false
+		Expect(NodeGroupSubnetIDs(cfg, ng)).To(BeEmpty())
+	})
+
+	It("should spread nodes across subnets and fail when a subnet can't fit them", func() {
+		ng := cfg.NewNodeGroup()
+		ng.Name = "ng-1"
+		ng.InstanceType = "m5.large"
+		ng.PrivateNetworking = true
+		ng.DesiredCapacity = aws.Int(6)
+
+		usages, err := GetNodeGroupsSubnetUsage(p, cfg, cfg.NodeGroups)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(HaveLen(2))
+
+		Expect(usages[0].ID).To(Equal("subnet-private-a"))
+		Expect(usages[0].Topology).To(Equal(api.SubnetTopologyPrivate))
+		Expect(usages[0].Usable).To(Equal(int64(251)))
+		Expect(usages[0].Used()).To(Equal(int64(151)))
+		Expect(usages[0].Nodes).To(Equal(3))
+		Expect(usages[0].Required).To(Equal(int64(87)))
+		Expect(usages[0].NodeGroups).To(Equal([]string{"ng-1"}))
+
+		err = ValidateSubnetCapacity(usages)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`subnet "subnet-private-b" (us-west-2b) has 2 free IP addresses, but nodegroup(s) ng-1 need at least 3`))
+	})
+
+	It("should pass when subnets have enough free addresses", func() {
+		ng := cfg.NewNodeGroup()
+		ng.InstanceType = "m5.large"
+		ng.DesiredCapacity = aws.Int(2)
+
+		usages, err := GetNodeGroupsSubnetUsage(p, cfg, cfg.NodeGroups)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(HaveLen(1))
+		Expect(usages[0].Topology).To(Equal(api.SubnetTopologyPublic))
+		Expect(ValidateSubnetCapacity(usages)).To(Succeed())
+	})
+
+	It("should report the usage of all subnets of the cluster", func() {
+		usages, err := GetClusterSubnetUsage(p, cfg)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(usages).To(HaveLen(3))
+		Expect(usages[1].ID).To(Equal("subnet-private-b"))
+		Expect(usages[1].Utilization()).To(BeNumerically("~", 99.2, 0.1))
+	})
+})
@@ -13,9 +13,9 @@ import (
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 )
 
-func fmtSecurityGroupNameRegexForCluster(name string) string {
-	const ourSecurityGroupNameRegexFmt = "^eksctl-%s-(cluster|nodegroup)-.+$"
-	return fmt.Sprintf(ourSecurityGroupNameRegexFmt, name)
+func fmtSecurityGroupNameRegexForCluster(prefix, name string) string {
+	const ourSecurityGroupNameRegexFmt = "^%s%s-(cluster|nodegroup)-.+$"
+	return fmt.Sprintf(ourSecurityGroupNameRegexFmt, regexp.QuoteMeta(prefix), regexp.QuoteMeta(name))
 }
 
 func findDanglingENIs(ec2API ec2iface.EC2API, spec *api.ClusterConfig) ([]string, error) {
@@ -32,7 +32,7 @@ func findDanglingENIs(ec2API ec2iface.EC2API, spec *api.ClusterConfig) ([]string
 		},
 	}
 
-	securityGroupRE, err := regexp.Compile(fmtSecurityGroupNameRegexForCluster(spec.Metadata.Name))
+	securityGroupRE, err := regexp.Compile(fmtSecurityGroupNameRegexForCluster(spec.StackNamePrefix(), spec.Metadata.Name))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create security group regex")
 	}
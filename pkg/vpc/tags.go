@@ -0,0 +1,116 @@
+package vpc
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/kris-nova/logger"
+	"github.com/pkg/errors"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+)
+
+// Tags that Kubernetes and ALB Ingress Controller use to discover the subnets of load balancers
+const (
+	// PublicSubnetRoleTag marks subnets for internet-facing load balancers
+	PublicSubnetRoleTag = "kubernetes.io/role/elb"
+	// PrivateSubnetRoleTag marks subnets for internal load balancers
+	PrivateSubnetRoleTag = "kubernetes.io/role/internal-elb"
+)
+
+// TagSubnets tags the subnets of the cluster for the discovery of load balancer subnets, public
+// subnets are used for internet-facing load balancers and private subnets for internal ones;
+// only missing tags are added, as clusters may share subnets that are tagged as owned by another
+// cluster; it returns true when changes are required in plan mode
+func TagSubnets(provider api.ClusterProvider, spec *api.ClusterConfig, plan bool) (bool, error) {
+	if spec.VPC == nil || spec.VPC.Subnets == nil {
+		return false, fmt.Errorf("subnets of cluster %q must be known to tag them", spec.Metadata.Name)
+	}
+
+	wantedTags := map[string]map[string]string{}
+	unwantedRoles := map[string]string{}
+	clusterTag := "kubernetes.io/cluster/" + spec.Metadata.Name
+	// subnets shared from another account can only be tagged by their owner
+	for _, subnet := range spec.VPC.Subnets.Public {
+		if subnet.OwnerID != "" {
+			continue
+		}
+		wantedTags[subnet.ID] = map[string]string{PublicSubnetRoleTag: "1", clusterTag: "shared"}
+		unwantedRoles[subnet.ID] = PrivateSubnetRoleTag
+	}
+	for _, subnet := range spec.VPC.Subnets.Private {
+		if subnet.OwnerID != "" {
+			continue
+		}
+		wantedTags[subnet.ID] = map[string]string{PrivateSubnetRoleTag: "1", clusterTag: "shared"}
+		unwantedRoles[subnet.ID] = PublicSubnetRoleTag
+	}
+	if len(wantedTags) == 0 {
+		return false, nil
+	}
+
+	subnetIDs := []string{}
+	for id := range wantedTags {
+		subnetIDs = append(subnetIDs, id)
+	}
+	sort.Strings(subnetIDs)
+
+	subnets, err := describeSubnets(provider, subnetIDs...)
+	if err != nil {
+		return false, errors.Wrapf(err, "describing subnets of cluster %q", spec.Metadata.Name)
+	}
+
+	changesRequired := false
+	for _, subnet := range subnets {
+		id := aws.StringValue(subnet.SubnetId)
+		existing := map[string]string{}
+		for _, tag := range subnet.Tags {
+			existing[*tag.Key] = aws.StringValue(tag.Value)
+		}
+
+		if _, ok := existing[unwantedRoles[id]]; ok {
+			logger.Warning("subnet %q is tagged with %q, load balancers of the other kind may be placed in it", id, unwantedRoles[id])
+		}
+
+		missing := []*ec2.Tag{}
+		keys := []string{}
+		for k := range wantedTags[id] {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if _, ok := existing[k]; !ok {
+				missing = append(missing, &ec2.Tag{Key: aws.String(k), Value: aws.String(wantedTags[id][k])})
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		changesRequired = true
+		if plan {
+			logger.Info("(plan) would have tagged subnet %q with %s", id, formatTags(missing))
+			continue
+		}
+		if _, err := provider.EC2().CreateTags(&ec2.CreateTagsInput{
+			Resources: []*string{subnet.SubnetId},
+			Tags:      missing,
+		}); err != nil {
+			return false, errors.Wrapf(err, "tagging subnet %q", id)
+		}
+		logger.Info("tagged subnet %q with %s", id, formatTags(missing))
+	}
+
+	return plan && changesRequired, nil
+}
+
+func formatTags(tags []*ec2.Tag) string {
+	formatted := []string{}
+	for _, tag := range tags {
+		formatted = append(formatted, fmt.Sprintf("%s=%s", *tag.Key, *tag.Value))
+	}
+	return strings.Join(formatted, ", ")
+}
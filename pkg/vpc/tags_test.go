@@ -0,0 +1,87 @@
+package vpc_test
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	. "github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+var _ = Describe("Tagging subnets", func() {
+	var (
+		p      *mockprovider.MockProvider
+		cfg    *api.ClusterConfig
+		tagged map[string][]string
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "test-cluster"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Public: map[string]api.Network{
+				"eu-west-1a": {ID: "subnet-public-a"},
+			},
+			Private: map[string]api.Network{
+				"eu-west-1a": {ID: "subnet-private-a"},
+			},
+		}
+
+		p = mockprovider.NewMockProvider()
+		p.MockEC2().On("DescribeSubnets", mock.Anything).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []*ec2.Subnet{
+				{
+					SubnetId: aws.String("subnet-public-a"),
+					Tags: []*ec2.Tag{
+						{Key: aws.String("kubernetes.io/role/elb"), Value: aws.String("1")},
+					},
+				},
+				{
+					SubnetId: aws.String("subnet-private-a"),
+					Tags: []*ec2.Tag{
+						{Key: aws.String("kubernetes.io/cluster/test-cluster"), Value: aws.String("owned")},
+					},
+				},
+			},
+		}, nil)
+
+		tagged = map[string][]string{}
+		p.MockEC2().On("CreateTags", mock.Anything).Return(&ec2.CreateTagsOutput{}, nil).Run(func(args mock.Arguments) {
+			input := args.Get(0).(*ec2.CreateTagsInput)
+			for _, tag := range input.Tags {
+				tagged[*input.Resources[0]] = append(tagged[*input.Resources[0]], *tag.Key+"="+*tag.Value)
+			}
+		})
+	})
+
+	It("only adds missing tags", func() {
+		Expect(TagSubnets(p, cfg, false)).To(BeFalse())
+		Expect(tagged).To(Equal(map[string][]string{
+			"subnet-public-a":  {"kubernetes.io/cluster/test-cluster=shared"},
+			"subnet-private-a": {"kubernetes.io/role/internal-elb=1"},
+		}))
+	})
+
+	It("doesn't tag subnets in plan mode", func() {
+		Expect(TagSubnets(p, cfg, true)).To(BeTrue())
+		Expect(tagged).To(BeEmpty())
+	})
+
+	It("skips subnets shared from another account", func() {
+		cfg.VPC.Subnets.Private["eu-west-1a"] = api.Network{ID: "subnet-private-a", OwnerID: "210987654321"}
+		Expect(TagSubnets(p, cfg, false)).To(BeFalse())
+		Expect(tagged).To(Equal(map[string][]string{
+			"subnet-public-a": {"kubernetes.io/cluster/test-cluster=shared"},
+		}))
+	})
+
+	It("requires the subnets of the cluster", func() {
+		cfg.VPC.Subnets = nil
+		_, err := TagSubnets(p, cfg, false)
+		Expect(err).To(MatchError(`subnets of cluster "test-cluster" must be known to tag them`))
+	})
+})
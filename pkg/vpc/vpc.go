@@ -9,6 +9,8 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	cfn "github.com/aws/aws-sdk-go/service/cloudformation"
 	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/pkg/errors"
 
 	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
 	"github.com/weaveworks/eksctl/pkg/cfn/outputs"
@@ -43,8 +45,8 @@ func SetSubnets(spec *api.ClusterConfig) error {
 	logger.Debug("VPC CIDR (%s) was divided into 8 subnets %v", vpc.CIDR.String(), zoneCIDRs)
 
 	zonesTotal := len(spec.AvailabilityZones)
-	if 2*zonesTotal > len(zoneCIDRs) {
-		return fmt.Errorf("insufficient number of subnets (have %d, but need %d) for %d availability zones", len(zoneCIDRs), 2*zonesTotal, zonesTotal)
+	if required := 2*zonesTotal + localZoneSubnetCount(spec); required > len(zoneCIDRs) {
+		return fmt.Errorf("insufficient number of subnets (have %d, but need %d) for %d availability zones and %d local zones", len(zoneCIDRs), required, zonesTotal, len(spec.LocalZones))
 	}
 
 	for i, zone := range spec.AvailabilityZones {
@@ -59,9 +61,48 @@ func SetSubnets(spec *api.ClusterConfig) error {
 		logger.Info("subnets for %s - public:%s private:%s", zone, public.String(), private.String())
 	}
 
+	if len(spec.LocalZones) == 0 {
+		return nil
+	}
+	vpc.LocalZoneSubnets = &api.ClusterSubnets{
+		Private: map[string]api.Network{},
+		Public:  map[string]api.Network{},
+	}
+	next := 2 * zonesTotal
+	for _, zone := range spec.LocalZones {
+		public := zoneCIDRs[next]
+		next++
+		vpc.LocalZoneSubnets.Public[zone] = api.Network{
+			CIDR: &ipnet.IPNet{IPNet: *public},
+		}
+		if api.IsWavelengthZone(zone) {
+			// Wavelength Zones have no NAT gateways, nodes get carrier IP addresses instead
+			logger.Info("subnets for %s - carrier:%s", zone, public.String())
+			continue
+		}
+		private := zoneCIDRs[next]
+		next++
+		vpc.LocalZoneSubnets.Private[zone] = api.Network{
+			CIDR: &ipnet.IPNet{IPNet: *private},
+		}
+		logger.Info("subnets for %s - public:%s private:%s", zone, public.String(), private.String())
+	}
+
 	return nil
 }
 
+func localZoneSubnetCount(spec *api.ClusterConfig) int {
+	count := 0
+	for _, zone := range spec.LocalZones {
+		if api.IsWavelengthZone(zone) {
+			count++
+		} else {
+			count += 2
+		}
+	}
+	return count
+}
+
 func describeSubnets(provider api.ClusterProvider, subnetIDs ...string) ([]*ec2.Subnet, error) {
 	input := &ec2.DescribeSubnetsInput{
 		SubnetIds: aws.StringSlice(subnetIDs),
@@ -118,6 +159,33 @@ func UseFromCluster(provider api.ClusterProvider, stack *cfn.Stack, spec *api.Cl
 		outputs.ClusterSubnetsPublic: func(v string) error {
 			return ImportSubnetsFromList(provider, spec, api.SubnetTopologyPublic, strings.Split(v, ","))
 		},
+		// only clusters with subnets in Local Zones or Outposts have these outputs
+		outputs.ClusterLocalZoneSubnetsPrivate: func(v string) error {
+			return ImportLocalZoneSubnetsFromList(provider, spec, api.SubnetTopologyPrivate, strings.Split(v, ","))
+		},
+		outputs.ClusterLocalZoneSubnetsPublic: func(v string) error {
+			return ImportLocalZoneSubnetsFromList(provider, spec, api.SubnetTopologyPublic, strings.Split(v, ","))
+		},
+		// only clusters with a custom service CIDR have this output, nodegroups need it to work out cluster DNS address
+		outputs.ClusterServiceIPv4CIDR: func(v string) error {
+			if spec.Status == nil {
+				spec.Status = &api.ClusterStatus{}
+			}
+			spec.Status.ServiceIPv4CIDR = v
+			return nil
+		},
+		// only IPv6 clusters have this output, nodegroups need it to work out cluster DNS address
+		outputs.ClusterServiceIPv6CIDR: func(v string) error {
+			if spec.KubernetesNetworkConfig == nil {
+				spec.KubernetesNetworkConfig = &api.KubernetesNetworkConfig{}
+			}
+			spec.KubernetesNetworkConfig.IPFamily = api.IPV6Family
+			if spec.Status == nil {
+				spec.Status = &api.ClusterStatus{}
+			}
+			spec.Status.ServiceIPv6CIDR = v
+			return nil
+		},
 	}
 
 	if !outputs.Exists(*stack, outputs.ClusterSubnetsPublic) &&
@@ -178,6 +246,7 @@ func ImportSubnets(provider api.ClusterProvider, spec *api.ClusterConfig, topolo
 			return err
 		}
 	}
+	account := ""
 	for _, subnet := range subnets {
 		if spec.VPC.ID == "" {
 			// if VPC wasn't defined, import it based on VPC of the first
@@ -189,14 +258,68 @@ func ImportSubnets(provider api.ClusterProvider, spec *api.ClusterConfig, topolo
 			return fmt.Errorf("given %s is in %s, not in %s", *subnet.SubnetId, *subnet.VpcId, spec.VPC.ID)
 		}
 
+		if err := validateSubnetZone(spec, topology, subnet); err != nil {
+			return err
+		}
+
+		if spec.IsLocalZoneSubnet(topology, *subnet.AvailabilityZone, *subnet.SubnetId) {
+			// subnets in Local Zones and Outposts are only used by nodegroups that select them
+			if err := spec.ImportLocalZoneSubnet(topology, *subnet.AvailabilityZone, *subnet.SubnetId, *subnet.CidrBlock); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if err := spec.ImportSubnet(topology, *subnet.AvailabilityZone, *subnet.SubnetId, *subnet.CidrBlock); err != nil {
 			return err
 		}
 		spec.AppendAvailabilityZone(*subnet.AvailabilityZone)
+
+		if subnet.OwnerId == nil {
+			continue
+		}
+		if account == "" {
+			var err error
+			if account, err = callerAccount(provider); err != nil {
+				return err
+			}
+		}
+		if *subnet.OwnerId != account {
+			// subnets shared with AWS RAM can be used, but only the owner can modify or tag them
+			logger.Info("subnet %q is shared by account %s, it has to be tagged by its owner", *subnet.SubnetId, *subnet.OwnerId)
+			networks := spec.SubnetsWithTopology(topology)
+			network := networks[*subnet.AvailabilityZone]
+			network.OwnerID = *subnet.OwnerId
+			networks[*subnet.AvailabilityZone] = network
+		}
+	}
+	return nil
+}
+
+// validateSubnetZone makes sure that the subnet is keyed by its actual AZ, AZ names are mapped
+// to different zones in each account, so subnets shared from another account may be in a
+// different zone than the one the network team refers to
+func validateSubnetZone(spec *api.ClusterConfig, topology api.SubnetTopology, subnet *ec2.Subnet) error {
+	for az, network := range spec.SubnetsWithTopology(topology) {
+		if network.ID != *subnet.SubnetId || az == *subnet.AvailabilityZone {
+			continue
+		}
+		return fmt.Errorf("subnet %q is in availability zone %s (zone ID %s) in this account, not in %s; "+
+			"note that availability zone names map to different zones in each account, so shared subnets "+
+			"have to be listed under the zone name of the account that uses them",
+			*subnet.SubnetId, *subnet.AvailabilityZone, aws.StringValue(subnet.AvailabilityZoneId), az)
 	}
 	return nil
 }
 
+func callerAccount(provider api.ClusterProvider) (string, error) {
+	output, err := provider.STS().GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", errors.Wrap(err, "getting account of the current session")
+	}
+	return aws.StringValue(output.Account), nil
+}
+
 // ImportSubnetsFromList will update spec with subnets, it will call describeSubnets first,
 // then pass resulting subnets to ImportSubnets
 // NOTE: it does respect all fields set in spec.VPC, and will error if
@@ -212,6 +335,27 @@ func ImportSubnetsFromList(provider api.ClusterProvider, spec *api.ClusterConfig
 	return ImportSubnets(provider, spec, topology, subnets)
 }
 
+// ImportLocalZoneSubnetsFromList will update spec with subnets in Local Zones, Wavelength
+// Zones or Outposts, subnets in Outposts can't be told apart from others by their zone
+func ImportLocalZoneSubnetsFromList(provider api.ClusterProvider, spec *api.ClusterConfig, topology api.SubnetTopology, subnetIDs []string) error {
+	if len(subnetIDs) == 0 {
+		return nil
+	}
+	subnets, err := describeSubnets(provider, subnetIDs...)
+	if err != nil {
+		return err
+	}
+	for _, subnet := range subnets {
+		if spec.VPC.ID != "" && spec.VPC.ID != *subnet.VpcId {
+			return fmt.Errorf("given %s is in %s, not in %s", *subnet.SubnetId, *subnet.VpcId, spec.VPC.ID)
+		}
+		if err := spec.ImportLocalZoneSubnet(topology, *subnet.AvailabilityZone, *subnet.SubnetId, *subnet.CidrBlock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // ImportAllSubnets will update spec with subnets, it will call describeSubnets first,
 // then pass resulting subnets to ImportSubnets
 // NOTE: it does respect all fields set in spec.VPC, and will error if
@@ -223,12 +367,108 @@ func ImportAllSubnets(provider api.ClusterProvider, spec *api.ClusterConfig) err
 			return err
 		}
 	}
+	spec.SeparateLocalZoneSubnets()
 	if err := ImportSubnetsFromList(provider, spec, api.SubnetTopologyPrivate, spec.PrivateSubnetIDs()); err != nil {
 		return err
 	}
 	if err := ImportSubnetsFromList(provider, spec, api.SubnetTopologyPublic, spec.PublicSubnetIDs()); err != nil {
 		return err
 	}
+	for _, topology := range api.SubnetTopologies() {
+		if err := ImportLocalZoneSubnetsFromList(provider, spec, topology, spec.LocalZoneSubnetIDs(topology)); err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
+
+// ValidateNodeGroupAccess checks that the subnets and security groups the nodegroup will
+// use are in the VPC of the cluster, as seen by the provider the nodegroup is created
+// with; for a nodegroup in another account, this ensures the subnets are shared with it
+func ValidateNodeGroupAccess(provider api.ClusterProvider, spec *api.ClusterConfig, ng *api.NodeGroup) error {
+	subnetIDs := NodeGroupSubnetIDs(spec, ng)
+	if len(subnetIDs) == 0 {
+		return fmt.Errorf("VPC of cluster %q has no subnets for nodegroup %q", spec.Metadata.Name, ng.Name)
+	}
+	subnets, err := describeSubnets(provider, subnetIDs...)
+	if err != nil {
+		return errors.Wrapf(err, "describing subnets %s for nodegroup %q, they may not be shared with its account", strings.Join(subnetIDs, ", "), ng.Name)
+	}
+	for _, s := range subnets {
+		if aws.StringValue(s.VpcId) != spec.VPC.ID {
+			return fmt.Errorf("subnet %q of nodegroup %q is in %q, not in the VPC of the cluster %q", *s.SubnetId, ng.Name, aws.StringValue(s.VpcId), spec.VPC.ID)
+		}
+	}
+
+	if ng.SecurityGroups == nil || len(ng.SecurityGroups.AttachIDs) == 0 {
+		return nil
+	}
+	output, err := provider.EC2().DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: aws.StringSlice(ng.SecurityGroups.AttachIDs),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "describing security groups of nodegroup %q", ng.Name)
+	}
+	for _, sg := range output.SecurityGroups {
+		if aws.StringValue(sg.VpcId) != spec.VPC.ID {
+			return fmt.Errorf("security group %q of nodegroup %q is in %q, not in the VPC of the cluster %q", *sg.GroupId, ng.Name, aws.StringValue(sg.VpcId), spec.VPC.ID)
+		}
+	}
+	return nil
+}
+
+// ValidateSecurityGroups checks that the pre-defined security groups of the cluster are
+// in its VPC, and that the shared node security group allows traffic between nodes,
+// unless eksctl is allowed to add that rule with vpc.manageSharedNodeSecurityGroupRules
+func ValidateSecurityGroups(provider api.ClusterProvider, spec *api.ClusterConfig) error {
+	ids := []string{}
+	for _, id := range []string{spec.VPC.SecurityGroup, spec.VPC.SharedNodeSecurityGroup} {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	if spec.VPC.ID == "" {
+		return fmt.Errorf("vpc.securityGroup and vpc.sharedNodeSecurityGroup can only be used with an existing VPC")
+	}
+
+	output, err := provider.EC2().DescribeSecurityGroups(&ec2.DescribeSecurityGroupsInput{
+		GroupIds: aws.StringSlice(ids),
+	})
+	if err != nil {
+		return errors.Wrapf(err, "describing security groups %s", strings.Join(ids, ", "))
+	}
+	for _, sg := range output.SecurityGroups {
+		if aws.StringValue(sg.VpcId) != spec.VPC.ID {
+			return fmt.Errorf("security group %q is in %q, not in the VPC of the cluster %q", *sg.GroupId, aws.StringValue(sg.VpcId), spec.VPC.ID)
+		}
+		if *sg.GroupId != spec.VPC.SharedNodeSecurityGroup || allowsTrafficFromItself(sg) {
+			continue
+		}
+		if !api.IsEnabled(spec.VPC.ManageSharedNodeSecurityGroupRules) {
+			return fmt.Errorf("shared node security group %q doesn't allow traffic between nodes, add an ingress rule for all traffic from %q, or set vpc.manageSharedNodeSecurityGroupRules to let eksctl add it",
+				*sg.GroupId, *sg.GroupId)
+		}
+		logger.Info("the rule that allows traffic between nodes will be added to shared node security group %q", *sg.GroupId)
+	}
+	return nil
+}
+
+// allowsTrafficFromItself returns true when the security group has an ingress
+// rule for all traffic with the security group itself as source
+func allowsTrafficFromItself(sg *ec2.SecurityGroup) bool {
+	for _, p := range sg.IpPermissions {
+		if aws.StringValue(p.IpProtocol) != "-1" {
+			continue
+		}
+		for _, pair := range p.UserIdGroupPairs {
+			if aws.StringValue(pair.GroupId) == aws.StringValue(sg.GroupId) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package vpc_test
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ec2"
+	"github.com/aws/aws-sdk-go/service/sts"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/testutils/mockprovider"
+	. "github.com/weaveworks/eksctl/pkg/vpc"
+)
+
+var _ = Describe("Importing subnets", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+	)
+
+	newSubnet := func(id, az, azID, owner string) *ec2.Subnet {
+		return &ec2.Subnet{
+			SubnetId:           aws.String(id),
+			VpcId:              aws.String("vpc-1"),
+			AvailabilityZone:   aws.String(az),
+			AvailabilityZoneId: aws.String(azID),
+			CidrBlock:          aws.String("10.0.0.0/24"),
+			OwnerId:            aws.String(owner),
+		}
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.VPC.ID = "vpc-1"
+		cfg.VPC.CIDR = nil
+
+		p.MockEC2().On("DescribeVpcs", mock.Anything).Return(&ec2.DescribeVpcsOutput{
+			Vpcs: []*ec2.Vpc{{
+				VpcId:     aws.String("vpc-1"),
+				CidrBlock: aws.String("10.0.0.0/16"),
+				OwnerId:   aws.String("111111111111"),
+			}},
+		}, nil)
+		p.MockSTS().On("GetCallerIdentity", mock.Anything).Return(&sts.GetCallerIdentityOutput{
+			Account: aws.String("222222222222"),
+			Arn:     aws.String("arn:aws:iam::222222222222:user/dev"),
+		}, nil)
+	})
+
+	It("should record the owner of subnets shared from another account", func() {
+		err := ImportSubnets(p, cfg, api.SubnetTopologyPrivate, []*ec2.Subnet{
+			newSubnet("subnet-shared", "us-west-2a", "usw2-az2", "111111111111"),
+			newSubnet("subnet-own", "us-west-2b", "usw2-az1", "222222222222"),
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(cfg.VPC.Subnets.Private["us-west-2a"].OwnerID).To(Equal("111111111111"))
+		Expect(cfg.VPC.Subnets.Private["us-west-2b"].OwnerID).To(BeEmpty())
+		Expect(cfg.SharedSubnetIDs()).To(Equal([]string{"subnet-shared"}))
+		Expect(p.MockSTS().AssertNumberOfCalls(GinkgoT(), "GetCallerIdentity", 1)).To(BeTrue())
+	})
+
+	It("should fail when a subnet is listed under a different availability zone", func() {
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Private: map[string]api.Network{
+				"us-west-2a": {ID: "subnet-shared"},
+			},
+		}
+
+		err := ImportSubnets(p, cfg, api.SubnetTopologyPrivate, []*ec2.Subnet{
+			newSubnet("subnet-shared", "us-west-2c", "usw2-az2", "111111111111"),
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`subnet "subnet-shared" is in availability zone us-west-2c (zone ID usw2-az2) in this account, not in us-west-2a`))
+	})
+})
+
+var _ = Describe("Validating nodegroup access to the VPC", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+		ng  *api.NodeGroup
+	)
+
+	mockSecurityGroup := func(id, vpcID string) {
+		p.MockEC2().On("DescribeSecurityGroups", mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []*ec2.SecurityGroup{{
+				GroupId: aws.String(id),
+				VpcId:   aws.String(vpcID),
+			}},
+		}, nil)
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.VPC.ID = "vpc-1"
+		cfg.VPC.Subnets = &api.ClusterSubnets{
+			Private: map[string]api.Network{
+				"us-west-2a": {ID: "subnet-1"},
+			},
+		}
+		ng = cfg.NewNodeGroup()
+		ng.Name = "ng-1"
+		ng.PrivateNetworking = true
+		ng.SecurityGroups.AttachIDs = []string{"sg-1"}
+	})
+
+	It("should accept subnets and security groups in the cluster VPC", func() {
+		p.MockEC2().On("DescribeSubnets", mock.Anything).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-1")}},
+		}, nil)
+		mockSecurityGroup("sg-1", "vpc-1")
+
+		Expect(ValidateNodeGroupAccess(p, cfg, ng)).To(Succeed())
+	})
+
+	It("should fail when the subnets aren't visible", func() {
+		p.MockEC2().On("DescribeSubnets", mock.Anything).Return(nil, fmt.Errorf("InvalidSubnetID.NotFound"))
+
+		err := ValidateNodeGroupAccess(p, cfg, ng)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("may not be shared"))
+	})
+
+	It("should fail when a security group is in another VPC", func() {
+		p.MockEC2().On("DescribeSubnets", mock.Anything).Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []*ec2.Subnet{{SubnetId: aws.String("subnet-1"), VpcId: aws.String("vpc-1")}},
+		}, nil)
+		mockSecurityGroup("sg-1", "vpc-2")
+
+		Expect(ValidateNodeGroupAccess(p, cfg, ng)).ToNot(Succeed())
+	})
+})
+
+var _ = Describe("Validating pre-defined security groups", func() {
+	var (
+		p   *mockprovider.MockProvider
+		cfg *api.ClusterConfig
+	)
+
+	mockSharedNodeSecurityGroup := func(vpcID string, permissions ...*ec2.IpPermission) {
+		p.MockEC2().On("DescribeSecurityGroups", mock.Anything).Return(&ec2.DescribeSecurityGroupsOutput{
+			SecurityGroups: []*ec2.SecurityGroup{{
+				GroupId:       aws.String("sg-shared"),
+				VpcId:         aws.String(vpcID),
+				IpPermissions: permissions,
+			}},
+		}, nil)
+	}
+
+	selfIngress := &ec2.IpPermission{
+		IpProtocol:       aws.String("-1"),
+		UserIdGroupPairs: []*ec2.UserIdGroupPair{{GroupId: aws.String("sg-shared")}},
+	}
+
+	BeforeEach(func() {
+		p = mockprovider.NewMockProvider()
+		cfg = api.NewClusterConfig()
+		cfg.VPC.ID = "vpc-1"
+		cfg.VPC.SharedNodeSecurityGroup = "sg-shared"
+	})
+
+	It("should accept a shared node security group that allows traffic between nodes", func() {
+		mockSharedNodeSecurityGroup("vpc-1", selfIngress)
+		Expect(ValidateSecurityGroups(p, cfg)).To(Succeed())
+	})
+
+	It("should require the rule between nodes, unless eksctl may add it", func() {
+		mockSharedNodeSecurityGroup("vpc-1")
+		err := ValidateSecurityGroups(p, cfg)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("manageSharedNodeSecurityGroupRules"))
+
+		cfg.VPC.ManageSharedNodeSecurityGroupRules = api.Enabled()
+		Expect(ValidateSecurityGroups(p, cfg)).To(Succeed())
+	})
+
+	It("should fail when the security group is in another VPC", func() {
+		mockSharedNodeSecurityGroup("vpc-2", selfIngress)
+		Expect(ValidateSecurityGroups(p, cfg)).ToNot(Succeed())
+	})
+
+	It("should fail when the VPC is created by eksctl", func() {
+		cfg.VPC.ID = ""
+		Expect(ValidateSecurityGroups(p, cfg)).ToNot(Succeed())
+	})
+})
@@ -0,0 +1,304 @@
+// Package wizard asks for the settings of a new cluster on a terminal,
+// instance types are checked and priced as they are selected
+package wizard
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/pricing"
+)
+
+// Endpoint access options
+const (
+	EndpointAccessPublic           = "public"
+	EndpointAccessPrivate          = "private"
+	EndpointAccessPublicAndPrivate = "public-and-private"
+)
+
+// EndpointAccessOptions returns the options of API server endpoint access
+func EndpointAccessOptions() []string {
+	return []string{EndpointAccessPublic, EndpointAccessPrivate, EndpointAccessPublicAndPrivate}
+}
+
+// Prompter asks questions and reads answers, one per line
+type Prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// NewPrompter creates a Prompter that reads answers from in and writes questions to out
+func NewPrompter(in io.Reader, out io.Writer) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out}
+}
+
+// Printf writes a message
+func (p *Prompter) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(p.out, format, args...)
+}
+
+// Ask asks a question until the answer is valid, an empty answer selects
+// the default value; validate may be nil
+func (p *Prompter) Ask(question, defaultValue string, validate func(string) error) (string, error) {
+	for {
+		if defaultValue != "" {
+			p.Printf("%s [%s]: ", question, defaultValue)
+		} else {
+			p.Printf("%s: ", question)
+		}
+		line, err := p.in.ReadString('\n')
+		if err != nil && (err != io.EOF || line == "") {
+			return "", fmt.Errorf("no answer to %q", question)
+		}
+		answer := strings.TrimSpace(line)
+		if answer == "" {
+			answer = defaultValue
+		}
+		if validate == nil {
+			return answer, nil
+		}
+		if err := validate(answer); err != nil {
+			p.Printf("  %s\n", err.Error())
+			continue
+		}
+		return answer, nil
+	}
+}
+
+// Choose asks to choose one of the options
+func (p *Prompter) Choose(question string, options []string, defaultValue string) (string, error) {
+	question = fmt.Sprintf("%s (%s)", question, strings.Join(options, ", "))
+	return p.Ask(question, defaultValue, func(answer string) error {
+		for _, o := range options {
+			if answer == o {
+				return nil
+			}
+		}
+		return fmt.Errorf("%q is not one of: %s", answer, strings.Join(options, ", "))
+	})
+}
+
+// AskInt asks for a number that is at least min
+func (p *Prompter) AskInt(question string, defaultValue, min int) (int, error) {
+	answer, err := p.Ask(question, strconv.Itoa(defaultValue), func(answer string) error {
+		n, err := strconv.Atoi(answer)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", answer)
+		}
+		if n < min {
+			return fmt.Errorf("%d is less than %d", n, min)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(answer)
+}
+
+// Confirm asks a yes or no question, the default is no
+func (p *Prompter) Confirm(question string) (bool, error) {
+	answer, err := p.Choose(question, []string{"yes", "no"}, "no")
+	if err != nil {
+		return false, err
+	}
+	return answer == "yes", nil
+}
+
+// Checks use AWS APIs to check the settings as they are selected
+type Checks struct {
+	// InstanceTypeOffered returns true when the instance type is offered in the region
+	InstanceTypeOffered func(instanceType string) (bool, error)
+	// EstimateCost returns the estimated monthly cost of the cluster
+	EstimateCost func(cfg *api.ClusterConfig, nodeGroups []*api.NodeGroup) (*pricing.Estimate, error)
+}
+
+// Wizard asks for the settings of a new cluster
+type Wizard struct {
+	*Prompter
+
+	// NewChecks returns the checks of the selected region, checks
+	// are skipped when it's nil or returns an error
+	NewChecks func(region string) (*Checks, error)
+
+	checks *Checks
+}
+
+// New creates a Wizard
+func New(prompter *Prompter, newChecks func(region string) (*Checks, error)) *Wizard {
+	return &Wizard{Prompter: prompter, NewChecks: newChecks}
+}
+
+var clusterNameRegexp = regexp.MustCompile(`^[a-zA-Z][-a-zA-Z0-9]*$`)
+
+func validateClusterName(name string) error {
+	if len(name) > 100 || !clusterNameRegexp.MatchString(name) {
+		return fmt.Errorf("%q is not a valid cluster name, it must start with a letter and only have letters, digits and hyphens, up to 100 characters", name)
+	}
+	return nil
+}
+
+// Run asks for the name, region and version of the cluster, the instance type
+// and size of the nodegroup, unless ng is nil, and the API server endpoint access;
+// the current settings of cfg and ng are the defaults
+func (w *Wizard) Run(cfg *api.ClusterConfig, ng *api.NodeGroup) error {
+	var err error
+	meta := cfg.Metadata
+
+	if meta.Name, err = w.Ask("Cluster name", meta.Name, validateClusterName); err != nil {
+		return err
+	}
+
+	region := meta.Region
+	if region == "" {
+		region = api.DefaultRegion
+	}
+	if meta.Region, err = w.Choose("Region", api.SupportedRegions(), region); err != nil {
+		return err
+	}
+
+	version := meta.Version
+	if version == "" {
+		version = api.DefaultVersion
+	}
+	if meta.Version, err = w.Choose("Kubernetes version", api.SupportedVersions(), version); err != nil {
+		return err
+	}
+
+	w.setupChecks(meta.Region)
+
+	if ng != nil {
+		if err := w.askNodeGroup(ng); err != nil {
+			return err
+		}
+	}
+
+	if err := w.askEndpointAccess(cfg); err != nil {
+		return err
+	}
+
+	if ng != nil {
+		w.showCostEstimate(cfg, ng)
+	}
+	return nil
+}
+
+func (w *Wizard) setupChecks(region string) {
+	w.checks = nil
+	if w.NewChecks == nil {
+		return
+	}
+	checks, err := w.NewChecks(region)
+	if err != nil {
+		w.Printf("  instance types won't be checked and priced: %s\n", err.Error())
+		return
+	}
+	w.checks = checks
+}
+
+func (w *Wizard) askNodeGroup(ng *api.NodeGroup) error {
+	instanceType := ng.InstanceType
+	if instanceType == "" {
+		instanceType = api.DefaultNodeType
+	}
+	var err error
+	if ng.InstanceType, err = w.Ask("Instance type of nodes", instanceType, w.checkInstanceType); err != nil {
+		return err
+	}
+
+	desired := api.DefaultNodeCount
+	if ng.DesiredCapacity != nil {
+		desired = *ng.DesiredCapacity
+	}
+	if desired, err = w.AskInt("Number of nodes", desired, 1); err != nil {
+		return err
+	}
+	min := desired
+	if ng.MinSize != nil && *ng.MinSize <= desired {
+		min = *ng.MinSize
+	}
+	if min, err = w.AskInt("Minimum number of nodes", min, 0); err != nil {
+		return err
+	}
+	max := desired
+	if ng.MaxSize != nil && *ng.MaxSize >= desired {
+		max = *ng.MaxSize
+	}
+	for {
+		if max, err = w.AskInt("Maximum number of nodes", max, 1); err != nil {
+			return err
+		}
+		if min <= desired && desired <= max {
+			break
+		}
+		w.Printf("  the number of nodes (%d) must be between the minimum (%d) and the maximum (%d)\n", desired, min, max)
+		if min, err = w.AskInt("Minimum number of nodes", min, 0); err != nil {
+			return err
+		}
+	}
+	ng.DesiredCapacity, ng.MinSize, ng.MaxSize = &desired, &min, &max
+	return nil
+}
+
+func (w *Wizard) checkInstanceType(instanceType string) error {
+	if instanceType == "" {
+		return fmt.Errorf("instance type must be set")
+	}
+	if w.checks == nil || w.checks.InstanceTypeOffered == nil {
+		return nil
+	}
+	offered, err := w.checks.InstanceTypeOffered(instanceType)
+	if err != nil {
+		w.Printf("  unable to check instance type %q: %s\n", instanceType, err.Error())
+		return nil
+	}
+	if !offered {
+		return fmt.Errorf("instance type %q is not offered in the region", instanceType)
+	}
+	return nil
+}
+
+func (w *Wizard) askEndpointAccess(cfg *api.ClusterConfig) error {
+	current := EndpointAccessPublic
+	if e := cfg.VPC.ClusterEndpoints; e != nil && api.IsEnabled(e.PrivateAccess) {
+		current = EndpointAccessPublicAndPrivate
+		if api.IsDisabled(e.PublicAccess) {
+			current = EndpointAccessPrivate
+		}
+	}
+
+	access, err := w.Choose("API server endpoint access", EndpointAccessOptions(), current)
+	if err != nil {
+		return err
+	}
+	switch access {
+	case EndpointAccessPublic:
+		cfg.VPC.ClusterEndpoints = nil
+	case EndpointAccessPrivate:
+		cfg.VPC.ClusterEndpoints = &api.ClusterEndpoints{PublicAccess: api.Disabled(), PrivateAccess: api.Enabled()}
+	case EndpointAccessPublicAndPrivate:
+		cfg.VPC.ClusterEndpoints = &api.ClusterEndpoints{PublicAccess: api.Enabled(), PrivateAccess: api.Enabled()}
+	}
+	return nil
+}
+
+func (w *Wizard) showCostEstimate(cfg *api.ClusterConfig, ng *api.NodeGroup) {
+	if w.checks == nil || w.checks.EstimateCost == nil {
+		return
+	}
+	estimate, err := w.checks.EstimateCost(cfg, []*api.NodeGroup{ng})
+	if err != nil {
+		w.Printf("  unable to estimate the cost of the cluster: %s\n", err.Error())
+		return
+	}
+	w.Printf("\nEstimated monthly cost (on-demand prices, excluding EBS volumes and data transfer):\n\n")
+	if err := estimate.Write(w.out); err != nil {
+		w.Printf("  unable to show the cost of the cluster: %s\n", err.Error())
+	}
+	w.Printf("\n")
+}
@@ -0,0 +1,131 @@
+package wizard_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/weaveworks/eksctl/pkg/apis/eksctl.io/v1alpha5"
+	"github.com/weaveworks/eksctl/pkg/pricing"
+	. "github.com/weaveworks/eksctl/pkg/wizard"
+)
+
+var _ = Describe("Wizard", func() {
+	var (
+		cfg *api.ClusterConfig
+		ng  *api.NodeGroup
+		out *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		cfg = api.NewClusterConfig()
+		cfg.Metadata.Name = "generated-name"
+		ng = cfg.NewNodeGroup()
+		out = &bytes.Buffer{}
+	})
+
+	run := func(answers []string, newChecks func(string) (*Checks, error)) error {
+		in := strings.NewReader(strings.Join(answers, "\n") + "\n")
+		return New(NewPrompter(in, out), newChecks).Run(cfg, ng)
+	}
+
+	It("uses the defaults for empty answers", func() {
+		Expect(run([]string{"", "", "", "", "", "", "", ""}, nil)).To(Succeed())
+
+		Expect(cfg.Metadata.Name).To(Equal("generated-name"))
+		Expect(cfg.Metadata.Region).To(Equal(api.DefaultRegion))
+		Expect(cfg.Metadata.Version).To(Equal(api.DefaultVersion))
+		Expect(ng.InstanceType).To(Equal(api.DefaultNodeType))
+		Expect(*ng.DesiredCapacity).To(Equal(api.DefaultNodeCount))
+		Expect(*ng.MinSize).To(Equal(api.DefaultNodeCount))
+		Expect(*ng.MaxSize).To(Equal(api.DefaultNodeCount))
+		Expect(cfg.VPC.ClusterEndpoints).To(BeNil())
+	})
+
+	It("asks again until answers are valid", func() {
+		answers := []string{
+			"1-invalid", "my-cluster",
+			"mars-1", api.RegionUSEast1,
+			"", "c5.xlarge",
+			"three", "3",
+			"1",
+			"2", "1", "4",
+			"private",
+		}
+		Expect(run(answers, nil)).To(Succeed())
+
+		Expect(cfg.Metadata.Name).To(Equal("my-cluster"))
+		Expect(cfg.Metadata.Region).To(Equal(api.RegionUSEast1))
+		Expect(ng.InstanceType).To(Equal("c5.xlarge"))
+		Expect(*ng.DesiredCapacity).To(Equal(3))
+		Expect(*ng.MinSize).To(Equal(1))
+		Expect(*ng.MaxSize).To(Equal(4))
+		Expect(cfg.VPC.ClusterEndpoints).To(Equal(&api.ClusterEndpoints{PublicAccess: api.Disabled(), PrivateAccess: api.Enabled()}))
+
+		Expect(out.String()).To(ContainSubstring(`"1-invalid" is not a valid cluster name`))
+		Expect(out.String()).To(ContainSubstring(`"mars-1" is not one of`))
+		Expect(out.String()).To(ContainSubstring(`"three" is not a number`))
+		Expect(out.String()).To(ContainSubstring("the number of nodes (3) must be between the minimum (1) and the maximum (2)"))
+	})
+
+	It("checks and prices instance types in the selected region", func() {
+		var checkedRegion string
+		newChecks := func(region string) (*Checks, error) {
+			checkedRegion = region
+			return &Checks{
+				InstanceTypeOffered: func(instanceType string) (bool, error) {
+					return instanceType != "p3dn.24xlarge", nil
+				},
+				EstimateCost: func(_ *api.ClusterConfig, nodeGroups []*api.NodeGroup) (*pricing.Estimate, error) {
+					return &pricing.Estimate{
+						ControlPlane: 73,
+						NodeGroups: []pricing.NodeGroupEstimate{
+							{Name: nodeGroups[0].Name, InstanceType: nodeGroups[0].InstanceType, Count: *nodeGroups[0].DesiredCapacity, Monthly: 140.16},
+						},
+					}, nil
+				},
+			}, nil
+		}
+		answers := []string{"", api.RegionEUWest1, "", "p3dn.24xlarge", "m5.large", "2", "", "", ""}
+		Expect(run(answers, newChecks)).To(Succeed())
+
+		Expect(checkedRegion).To(Equal(api.RegionEUWest1))
+		Expect(ng.InstanceType).To(Equal("m5.large"))
+		Expect(out.String()).To(ContainSubstring(`instance type "p3dn.24xlarge" is not offered in the region`))
+		Expect(out.String()).To(ContainSubstring("2 x m5.large"))
+		Expect(out.String()).To(MatchRegexp(`total\s+213.16`))
+	})
+
+	It("skips checks that can't be set up", func() {
+		newChecks := func(string) (*Checks, error) {
+			return nil, fmt.Errorf("no credentials")
+		}
+		Expect(run([]string{"", "", "", "x1e.32xlarge", "", "", "", ""}, newChecks)).To(Succeed())
+
+		Expect(ng.InstanceType).To(Equal("x1e.32xlarge"))
+		Expect(out.String()).To(ContainSubstring("instance types won't be checked and priced: no credentials"))
+	})
+
+	It("only asks for the cluster without a nodegroup", func() {
+		in := strings.NewReader("\n\n\npublic-and-private\n")
+		Expect(New(NewPrompter(in, out), nil).Run(cfg, nil)).To(Succeed())
+
+		Expect(cfg.VPC.ClusterEndpoints).To(Equal(&api.ClusterEndpoints{PublicAccess: api.Enabled(), PrivateAccess: api.Enabled()}))
+		Expect(out.String()).ToNot(ContainSubstring("nodes"))
+	})
+
+	It("fails when answers run out", func() {
+		Expect(run([]string{"my-cluster"}, nil)).To(MatchError(ContainSubstring("no answer to")))
+	})
+
+	It("confirms only with yes", func() {
+		p := NewPrompter(strings.NewReader("maybe\nyes\n"), out)
+		Expect(p.Confirm("Create cluster?")).To(BeTrue())
+
+		p = NewPrompter(strings.NewReader("\n"), out)
+		Expect(p.Confirm("Create cluster?")).To(BeFalse())
+	})
+})